@@ -172,6 +172,14 @@ func (t *Tapes) BuildRelease(
 
 	// Git commit SHA of build
 	commit string,
+
+	// Base64 Ed25519 public key that 'tapes update' verifies checksums with
+	// +optional
+	publicKey string,
+
+	// PEM Ed25519 private key that signs the checksums
+	// +optional
+	signingKey *dagger.Secret,
 ) *dagger.Directory {
 	buildtime := time.Now()
 
@@ -182,28 +190,72 @@ func (t *Tapes) BuildRelease(
 		fmt.Sprintf("-X 'github.com/papercomputeco/tapes/pkg/utils.Sha=%s'", commit),
 		fmt.Sprintf("-X 'github.com/papercomputeco/tapes/pkg/utils.Buildtime=%s'", buildtime),
 	}
+	if publicKey != "" {
+		ldflags = append(ldflags, fmt.Sprintf("-X 'github.com/papercomputeco/tapes/pkg/update.PublicKey=%s'", publicKey))
+	}
 
 	dir := t.Build(ctx, strings.Join(ldflags, " "))
-	return t.checksum(ctx, dir)
+	dir = t.checksum(ctx, dir, version)
+	if signingKey != nil {
+		dir = t.sign(ctx, dir, signingKey)
+	}
+	return dir
 }
 
-// checksum generates SHA256 checksums for all files in the given dagger directory
+// checksum generates SHA256 checksums for all files in the given dagger
+// directory. Each file at <os>/<arch>/<name> is named <name>-<version>-<os>-<arch>
+// in its checksum, so that its signature is bound to the release and platform
+// and 'tapes update' rejects it for any other.
 func (t *Tapes) checksum(
 	ctx context.Context,
 
 	// Directory containing build artifacts
 	dir *dagger.Directory,
+
+	// Version string of build
+	version string,
 ) *dagger.Directory {
 	// Use a container to generate checksums
 	checksumContainer := dag.Container().
 		From("alpine:latest").
 		WithDirectory("/artifacts", dir).
 		WithWorkdir("/artifacts").
+		WithEnvVariable("VERSION", version).
 		WithExec([]string{"sh", "-c", `
 			find . -type f ! -name "*.sha256" | while read file; do
-				sha256sum "$file" | sed 's|./||' > "${file}.sha256"
+				rel="${file#./}"
+				os="${rel%%/*}"
+				arch="$(basename "$(dirname "$rel")")"
+				digest="$(sha256sum "$file" | cut -d' ' -f1)"
+				echo "${digest}  $(basename "$rel")-${VERSION}-${os}-${arch}" > "${file}.sha256"
 			done
 		`})
 
 	return checksumContainer.Directory("/artifacts")
 }
+
+// sign writes an Ed25519 signature of every checksum file next to it, which
+// 'tapes update' verifies before installing a release
+func (t *Tapes) sign(
+	ctx context.Context,
+
+	// Directory containing build artifacts and checksums
+	dir *dagger.Directory,
+
+	// PEM Ed25519 private key
+	signingKey *dagger.Secret,
+) *dagger.Directory {
+	signContainer := dag.Container().
+		From("alpine:latest").
+		WithExec([]string{"apk", "add", "--no-cache", "openssl"}).
+		WithMountedSecret("/run/secrets/signing-key.pem", signingKey).
+		WithDirectory("/artifacts", dir).
+		WithWorkdir("/artifacts").
+		WithExec([]string{"sh", "-c", `
+			find . -type f -name "*.sha256" | while read file; do
+				openssl pkeyutl -sign -rawin -inkey /run/secrets/signing-key.pem -in "$file" -out "${file}.sig"
+			done
+		`})
+
+	return signContainer.Directory("/artifacts")
+}
@@ -91,8 +91,16 @@ func (t *Tapes) ReleaseLatest(
 
 	// Bucket secret access key
 	secretAccessKey *dagger.Secret,
+
+	// Base64 Ed25519 public key that 'tapes update' verifies checksums with
+	// +optional
+	publicKey string,
+
+	// PEM Ed25519 private key that signs the checksums
+	// +optional
+	signingKey *dagger.Secret,
 ) (*dagger.Directory, error) {
-	artifacts := t.BuildRelease(ctx, version, commit)
+	artifacts := t.BuildRelease(ctx, version, commit, publicKey, signingKey)
 	err := t.upload(
 		ctx,
 		&uploadOpts{
@@ -148,7 +156,7 @@ func (t *Tapes) Nightly(
 	secretAccessKey *dagger.Secret,
 ) (*dagger.Directory, error) {
 	prefix := "nightly"
-	artifacts := t.BuildRelease(ctx, prefix, commit)
+	artifacts := t.BuildRelease(ctx, prefix, commit, "", nil)
 	err := t.upload(
 		ctx,
 		&uploadOpts{
@@ -1,8 +1,10 @@
 package api
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
+	"sync"
 
 	"github.com/gofiber/adaptor/v2"
 	"github.com/gofiber/fiber/v2"
@@ -21,6 +23,11 @@ type Server struct {
 	logger    *zap.Logger
 	app       *fiber.App
 	mcpServer *mcp.Server
+
+	// done is closed on Shutdown to end long-lived event streams, which
+	// would otherwise keep the server from shutting down.
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
 // NewServer creates a new API server.
@@ -38,23 +45,50 @@ func NewServer(config Config, driver storage.Driver, dagLoader merkle.DagLoader,
 		dagLoader: dagLoader,
 		logger:    logger,
 		app:       app,
+		done:      make(chan struct{}),
+	}
+
+	app.Use(config.Crashes.Middleware(logger, "api"))
+
+	if config.AuthToken != "" || config.ReadToken != "" {
+		app.Use(s.requireToken)
 	}
 
 	app.Get("/ping", s.handlePing)
+	app.Get("/health", s.handleHealth)
+	app.Get("/metrics", s.handleMetrics)
 	app.Get("/dag/stats", s.handleDAGStats)
 	app.Get("/dag/node/:hash", s.handleGetNode)
+	app.Get("/dag/node/:hash/media/:index", s.handleGetNodeMedia)
 	app.Get("/dag/history", s.handleListHistories)
 	app.Get("/dag/history/:hash", s.handleGetHistory)
-	app.Get("/v1/search", s.handleSearchEndpoint)
 
-	// Register MCP server if vector driver and embedder are configured
+	v1 := app.Group("/v1")
+	v1.Get("/health", s.handleHealth)
+	v1.Get("/openapi.json", s.handleOpenAPI)
+	v1.Get("/sessions", s.handleListSessions)
+	v1.Get("/sessions/:id", s.handleGetSession)
+	v1.Patch("/sessions/:id", s.handleUpdateSession)
+	v1.Get("/analytics", s.handleAnalytics)
+	v1.Get("/search", s.handleSearchEndpoint)
+	v1.Get("/events", s.handleEvents)
+	v1.Post("/runs/:id/token", s.handleIssueRunToken)
+	v1.Delete("/runs/:id/token", s.handleRevokeRunTokens)
+	v1.Get("/tokens", s.handleListRunTokens)
+	v1.Delete("/tokens/:id", s.handleRevokeRunToken)
+	v1.Get("/log-level", s.handleGetLogLevel)
+	v1.Put("/log-level", s.handlePutLogLevel)
+
+	// Register MCP server if sessions or a vector driver and embedder are
+	// configured
 	var mcpServer *mcp.Server
-	if config.VectorDriver != nil && config.Embedder != nil {
+	if config.Sessions != nil || (config.VectorDriver != nil && config.Embedder != nil) {
 		s.logger.Debug("creating mcp server")
 		mcpServer, err = mcp.NewServer(mcp.Config{
 			DagLoader:    dagLoader,
 			VectorDriver: config.VectorDriver,
 			Embedder:     config.Embedder,
+			Sessions:     config.Sessions,
 			Logger:       logger,
 		})
 		if err != nil {
@@ -81,21 +115,27 @@ func NewServer(config Config, driver storage.Driver, dagLoader merkle.DagLoader,
 
 // Run starts the API server on the configured address.
 func (s *Server) Run() error {
-	s.logger.Info("starting API server",
-		zap.String("listen", s.config.ListenAddr),
-	)
-	return s.app.Listen(s.config.ListenAddr)
+	listener, err := net.Listen("tcp", s.config.ListenAddr)
+	if err != nil {
+		return err
+	}
+	return s.RunWithListener(listener)
 }
 
 // RunWithListener starts the API server using the provided listener.
 func (s *Server) RunWithListener(listener net.Listener) error {
 	s.logger.Info("starting API server",
 		zap.String("listen", listener.Addr().String()),
+		zap.Bool("tls", s.config.TLS != nil),
 	)
+	if s.config.TLS != nil {
+		listener = tls.NewListener(listener, s.config.TLS)
+	}
 	return s.app.Listener(listener)
 }
 
 // Shutdown gracefully shuts down the API server.
 func (s *Server) Shutdown() error {
+	s.closeOnce.Do(func() { close(s.done) })
 	return s.app.Shutdown()
 }
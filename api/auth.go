@@ -0,0 +1,64 @@
+package api
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// publicPaths are served without the auth token so that health checks and
+// API discovery work before a client is configured.
+var publicPaths = map[string]bool{
+	"/ping":            true,
+	"/health":          true,
+	"/v1/health":       true,
+	"/v1/openapi.json": true,
+}
+
+// readOnlyPaths accept requests of any method from read-only tokens because
+// they never change stored data. The MCP endpoint takes POSTs, but its tools
+// only list, search, and read sessions.
+var readOnlyPaths = map[string]bool{
+	"/v1/mcp": true,
+}
+
+// requireToken rejects requests that do not carry the admin token or the
+// read-only token as a bearer token. Read-only tokens are limited to requests
+// that cannot change stored data.
+func (s *Server) requireToken(c *fiber.Ctx) error {
+	if publicPaths[c.Path()] {
+		return c.Next()
+	}
+
+	token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	switch {
+	case ok && tokenMatches(token, s.config.AuthToken):
+		return c.Next()
+	case ok && tokenMatches(token, s.config.ReadToken):
+		if isReadRequest(c) {
+			return c.Next()
+		}
+		return c.Status(fiber.StatusForbidden).JSON(llm.ErrorResponse{Error: "read-only token cannot modify sessions"})
+	default:
+		c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="tapes"`)
+		return c.Status(fiber.StatusUnauthorized).JSON(llm.ErrorResponse{Error: "missing or invalid auth token"})
+	}
+}
+
+// tokenMatches compares a presented token to a configured one in constant
+// time. An unconfigured token matches nothing.
+func tokenMatches(presented, configured string) bool {
+	return configured != "" && subtle.ConstantTimeCompare([]byte(presented), []byte(configured)) == 1
+}
+
+// isReadRequest reports whether a request only reads stored data.
+func isReadRequest(c *fiber.Ctx) bool {
+	switch c.Method() {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+		return true
+	}
+	return readOnlyPaths[c.Path()]
+}
@@ -2,7 +2,16 @@
 package api
 
 import (
+	"crypto/tls"
+
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/crash"
+	"github.com/papercomputeco/tapes/pkg/deck"
 	"github.com/papercomputeco/tapes/pkg/embeddings"
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/health"
+	"github.com/papercomputeco/tapes/pkg/runtoken"
 	"github.com/papercomputeco/tapes/pkg/vector"
 )
 
@@ -16,4 +25,51 @@ type Config struct {
 
 	// Embedder for converting query text to vectors (optional, enables MCP server)
 	Embedder embeddings.Embedder
+
+	// Sessions serves the /v1/sessions and /v1/analytics endpoints and
+	// full-text search (optional, requires SQLite storage)
+	Sessions deck.Querier
+
+	// AuthToken, when set, must be sent as "Authorization: Bearer <token>"
+	// on every request except /ping, /health, /v1/health, and
+	// /v1/openapi.json. It grants admin access.
+	AuthToken string
+
+	// ReadToken, when set, is accepted in place of AuthToken on requests
+	// that only read, so teammates sharing the server cannot change sessions
+	ReadToken string
+
+	// TLS, when set, serves the API over HTTPS
+	TLS *tls.Config
+
+	// Events streams newly stored nodes on /v1/events (optional, requires
+	// the proxy to run in the same process and publish to the same broker)
+	Events *events.Broker
+
+	// CaptureSkips reports the turns the proxy's capture rules skipped, per
+	// rule, on /v1/health (optional, requires the proxy to run in the same
+	// process)
+	CaptureSkips func() map[string]uint64
+
+	// Metrics reports counters and gauges by Prometheus metric name on
+	// /metrics, alongside the status of each Health component (optional,
+	// requires the proxy to run in the same process)
+	Metrics func() map[string]float64
+
+	// Health checks the server's components for /v1/health and /health
+	// (optional, without it the server reports ok with no components)
+	Health *health.Checker
+
+	// RunTokens issues, lists, and revokes run tokens on /v1/runs/:id/token
+	// and /v1/tokens (optional, requires the proxy to run in the same process
+	// and check the same tokens)
+	RunTokens *runtoken.Service
+
+	// LogLevel is read and changed on /v1/log-level (optional, set to the
+	// level of the logger the server process logs with)
+	LogLevel *zap.AtomicLevel
+
+	// Crashes optionally records a report for each panic recovered in a
+	// handler. Panics are recovered either way.
+	Crashes *crash.Recorder
 }
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// eventsKeepalive is how often an idle event stream sends a comment line,
+// which stops intermediaries from timing out the connection and lets the
+// server notice clients that went away.
+var eventsKeepalive = 15 * time.Second
+
+// handleEvents handles GET /v1/events, a server-sent events stream of nodes
+// as they are stored.
+// Query parameters:
+//   - session (optional): only nodes whose conversation root hash starts with this prefix
+//   - provider (optional): only nodes from this provider
+//   - run (optional): only nodes captured in this agent run
+func (s *Server) handleEvents(c *fiber.Ctx) error {
+	if s.config.Events == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(llm.ErrorResponse{
+			Error: "live events are not available: the API is not running alongside the proxy",
+		})
+	}
+
+	ch, cancel := s.config.Events.Subscribe(events.Filter{
+		Session:  c.Query("session"),
+		Provider: c.Query("provider"),
+		Run:      c.Query("run"),
+	})
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// As in the proxy, io.Pipe makes every write reach the socket before the
+	// next one starts, and fails once the client disconnects.
+	pr, pw := io.Pipe()
+	go s.streamEvents(pw, ch, cancel)
+	c.Context().Response.SetBodyStream(pr, -1)
+
+	return nil
+}
+
+// streamEvents writes events from ch to pw in SSE format until the client
+// disconnects, the subscription ends, or the server shuts down.
+func (s *Server) streamEvents(pw *io.PipeWriter, ch <-chan events.Event, cancel func()) {
+	defer pw.Close()
+	defer cancel()
+
+	ticker := time.NewTicker(eventsKeepalive)
+	defer ticker.Stop()
+
+	// An initial comment sends the response headers right away.
+	if _, err := io.WriteString(pw, ": connected\n\n"); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				s.logger.Error("failed to encode event", zap.Error(err))
+				continue
+			}
+			if _, err := fmt.Fprintf(pw, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if _, err := io.WriteString(pw, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
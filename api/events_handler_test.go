@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/sse"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+var _ = Describe("GET /v1/events", func() {
+	var (
+		server *Server
+		broker *events.Broker
+		inMem  *inmemory.Driver
+		base   string
+	)
+
+	BeforeEach(func() {
+		logger, _ := zap.NewDevelopment()
+		inMem = inmemory.NewDriver()
+		broker = events.NewBroker()
+
+		var err error
+		server, err = NewServer(Config{ListenAddr: ":0", Events: broker}, inMem, inMem, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		base = "http://" + listener.Addr().String()
+		go func() { _ = server.RunWithListener(listener) }()
+
+		DeferCleanup(func() {
+			Expect(server.Shutdown()).To(Succeed())
+			broker.Close()
+		})
+	})
+
+	It("streams matching events as they are published", func() {
+		resp, err := http.Get(base + "/v1/events?session=root1")
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(HavePrefix("text/event-stream"))
+
+		Eventually(broker.Subscribers).Should(Equal(1))
+		broker.Publish(events.Event{Type: events.TypeNodeCreated, Hash: "skipped", RootHash: "root2"})
+		broker.Publish(events.Event{Type: events.TypeNodeCreated, Hash: "wanted", RootHash: "root1", Role: "assistant"})
+
+		reader := sse.NewTeeReader(resp.Body, io.Discard)
+		ev, err := reader.Next()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ev).NotTo(BeNil())
+		Expect(ev.Type).To(Equal(events.TypeNodeCreated))
+		Expect(ev.ID).To(Equal("2"))
+
+		var event events.Event
+		Expect(json.Unmarshal([]byte(ev.Data), &event)).To(Succeed())
+		Expect(event.Hash).To(Equal("wanted"))
+		Expect(event.Role).To(Equal("assistant"))
+	})
+
+	It("ends the subscription when the client disconnects", func() {
+		resp, err := http.Get(base + "/v1/events")
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(broker.Subscribers).Should(Equal(1))
+
+		resp.Body.Close()
+		// The server notices on its next write.
+		Eventually(func() int {
+			broker.Publish(events.Event{Type: events.TypeNodeCreated})
+			return broker.Subscribers()
+		}).Should(BeZero())
+	})
+
+	It("returns 503 when no broker is configured", func() {
+		logger, _ := zap.NewDevelopment()
+		noEvents, err := NewServer(Config{ListenAddr: ":0"}, inMem, inMem, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		req, err := http.NewRequest(http.MethodGet, "/v1/events", nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := noEvents.app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(fiber.StatusServiceUnavailable))
+	})
+})
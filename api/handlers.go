@@ -7,6 +7,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/media"
 )
 
 // HistoryResponse contains the conversation history for a given node.
@@ -79,6 +81,40 @@ func (s *Server) handleGetNode(c *fiber.Ctx) error {
 	return c.JSON(node)
 }
 
+// handleGetNodeMedia returns the stored image or file of one of a node's
+// content blocks, so multimodal turns can be reviewed.
+func (s *Server) handleGetNodeMedia(c *fiber.Ctx) error {
+	node, err := s.driver.Get(c.Context(), c.Params("hash"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(llm.ErrorResponse{Error: "node not found"})
+	}
+
+	index, err := c.ParamsInt("index")
+	if err != nil || index < 0 || index >= len(node.Bucket.Content) {
+		return c.Status(fiber.StatusNotFound).JSON(llm.ErrorResponse{Error: "content block not found"})
+	}
+	block := node.Bucket.Content[index]
+	if block.MediaURI == "" {
+		return c.Status(fiber.StatusNotFound).JSON(llm.ErrorResponse{Error: "content block has no stored media"})
+	}
+
+	data, err := media.Read(c.Context(), block, storage.CipherOf(s.driver))
+	if err != nil {
+		s.logger.Warn("failed to read media", zap.String("hash", node.Hash), zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(llm.ErrorResponse{Error: "failed to read media"})
+	}
+
+	if block.FileName != "" {
+		c.Attachment(block.FileName)
+	}
+	contentType := block.MediaType
+	if contentType == "" {
+		contentType = fiber.MIMEOctetStream
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	return c.Send(data)
+}
+
 // handleListHistories returns all conversation histories (one per leaf node).
 func (s *Server) handleListHistories(c *fiber.Ctx) error {
 	ctx := c.Context()
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// LogLevel is the level of the server process's logs.
+type LogLevel struct {
+	// Level is "debug", "info", "warn", or "error".
+	Level string `json:"level"`
+}
+
+// handleGetLogLevel handles GET /v1/log-level.
+func (s *Server) handleGetLogLevel(c *fiber.Ctx) error {
+	if s.config.LogLevel == nil {
+		return logLevelUnavailable(c)
+	}
+	return c.JSON(LogLevel{Level: s.config.LogLevel.Level().String()})
+}
+
+// handlePutLogLevel handles PUT /v1/log-level, changing what the running
+// process logs.
+func (s *Server) handlePutLogLevel(c *fiber.Ctx) error {
+	if s.config.LogLevel == nil {
+		return logLevelUnavailable(c)
+	}
+
+	var req LogLevel
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: fmt.Sprintf("invalid body: %v", err)})
+	}
+	level, err := zapcore.ParseLevel(req.Level)
+	if err != nil || req.Level == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: fmt.Sprintf("invalid level %q", req.Level)})
+	}
+
+	previous := s.config.LogLevel.Level()
+	s.config.LogLevel.SetLevel(level)
+	s.logger.Info("log level changed",
+		zap.Stringer("from", previous),
+		zap.Stringer("to", level),
+	)
+	return c.JSON(LogLevel{Level: level.String()})
+}
+
+func logLevelUnavailable(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusServiceUnavailable).JSON(llm.ErrorResponse{
+		Error: "the log level cannot be changed in this process",
+	})
+}
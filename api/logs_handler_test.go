@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+var _ = Describe("Log level", func() {
+	newServer := func(config Config) *Server {
+		inMem := inmemory.NewDriver()
+		config.ListenAddr = ":0"
+		s, err := NewServer(config, inMem, inMem, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+		return s
+	}
+
+	send := func(s *Server, method, body string) (*http.Response, []byte) {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		req := httptest.NewRequest(method, "/v1/log-level", reader)
+		if body != "" {
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		}
+		resp, err := s.app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return resp, data
+	}
+
+	It("reads and changes the level", func() {
+		level := zap.NewAtomicLevelAt(zap.InfoLevel)
+		server := newServer(Config{LogLevel: &level})
+
+		resp, body := send(server, http.MethodGet, "")
+		Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+		var got LogLevel
+		Expect(json.Unmarshal(body, &got)).To(Succeed())
+		Expect(got.Level).To(Equal("info"))
+
+		resp, body = send(server, http.MethodPut, `{"level":"warn"}`)
+		Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+		Expect(json.Unmarshal(body, &got)).To(Succeed())
+		Expect(got.Level).To(Equal("warn"))
+		Expect(level.Level()).To(Equal(zap.WarnLevel))
+	})
+
+	It("rejects invalid levels", func() {
+		level := zap.NewAtomicLevelAt(zap.InfoLevel)
+		server := newServer(Config{LogLevel: &level})
+
+		resp, _ := send(server, http.MethodPut, `{"level":"loud"}`)
+		Expect(resp.StatusCode).To(Equal(fiber.StatusBadRequest))
+		resp, _ = send(server, http.MethodPut, `{}`)
+		Expect(resp.StatusCode).To(Equal(fiber.StatusBadRequest))
+		Expect(level.Level()).To(Equal(zap.InfoLevel))
+	})
+
+	It("is unavailable without a level", func() {
+		server := newServer(Config{})
+
+		resp, _ := send(server, http.MethodGet, "")
+		Expect(resp.StatusCode).To(Equal(fiber.StatusServiceUnavailable))
+	})
+
+	It("only lets the admin token change the level", func() {
+		level := zap.NewAtomicLevelAt(zap.InfoLevel)
+		server := newServer(Config{LogLevel: &level, AuthToken: "admin", ReadToken: "reader"})
+
+		req := httptest.NewRequest(http.MethodGet, "/v1/log-level", nil)
+		req.Header.Set("Authorization", "Bearer reader")
+		resp, err := server.app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+
+		req = httptest.NewRequest(http.MethodPut, "/v1/log-level", strings.NewReader(`{"level":"debug"}`))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Set("Authorization", "Bearer reader")
+		resp, err = server.app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(fiber.StatusForbidden))
+		Expect(level.Level()).To(Equal(zap.InfoLevel))
+	})
+})
@@ -2,12 +2,14 @@
 package mcp
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.uber.org/zap"
 
+	"github.com/papercomputeco/tapes/pkg/deck"
 	"github.com/papercomputeco/tapes/pkg/embeddings"
 	"github.com/papercomputeco/tapes/pkg/merkle"
 	"github.com/papercomputeco/tapes/pkg/utils"
@@ -25,6 +27,11 @@ type Config struct {
 	// configured VectorDriver
 	Embedder embeddings.Embedder
 
+	// Sessions serves the list_sessions, search_sessions, and get_session
+	// tools (optional, requires SQLite storage). When set, the semantic search
+	// tool is only added if VectorDriver and Embedder are configured too.
+	Sessions deck.Querier
+
 	// Noop for empty MCP server
 	Noop bool
 
@@ -38,7 +45,8 @@ type Server struct {
 	handler   *mcp.StreamableHTTPHandler
 }
 
-// NewServer creates a new MCP server with the search tool.
+// NewServer creates a new MCP server with the semantic search tool and, when
+// Sessions is configured, the session tools.
 func NewServer(c Config) (*Server, error) {
 	s := &Server{
 		config: c,
@@ -60,24 +68,43 @@ func NewServer(c Config) (*Server, error) {
 		return s, nil
 	}
 
-	if c.DagLoader == nil {
-		return nil, errors.New("storage driver is required")
-	}
-	if c.VectorDriver == nil {
-		return nil, errors.New("vector driver is required")
-	}
-	if c.Embedder == nil {
-		return nil, errors.New("embedder is required")
+	semantic := c.Sessions == nil || c.VectorDriver != nil || c.Embedder != nil
+	if semantic {
+		if c.DagLoader == nil {
+			return nil, errors.New("storage driver is required")
+		}
+		if c.VectorDriver == nil {
+			return nil, errors.New("vector driver is required")
+		}
+		if c.Embedder == nil {
+			return nil, errors.New("embedder is required")
+		}
 	}
 	if c.Logger == nil {
 		return nil, errors.New("logger is required")
 	}
 
 	// Add tools
-	mcp.AddTool(mcpServer, &mcp.Tool{
-		Name:        searchToolName,
-		Description: searchDescription,
-	}, s.handleSearch)
+	if semantic {
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name:        searchToolName,
+			Description: searchDescription,
+		}, s.handleSearch)
+	}
+	if c.Sessions != nil {
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name:        listSessionsToolName,
+			Description: listSessionsDescription,
+		}, s.handleListSessions)
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name:        searchSessionsToolName,
+			Description: searchSessionsDescription,
+		}, s.handleSearchSessions)
+		mcp.AddTool(mcpServer, &mcp.Tool{
+			Name:        getSessionToolName,
+			Description: getSessionDescription,
+		}, s.handleGetSession)
+	}
 
 	s.mcpServer = mcpServer
 
@@ -98,3 +125,9 @@ func NewServer(c Config) (*Server, error) {
 func (s *Server) Handler() http.Handler {
 	return s.handler
 }
+
+// RunStdio serves MCP over stdin and stdout until the client disconnects or
+// ctx is cancelled. Agents launch it as a subprocess.
+func (s *Server) RunStdio(ctx context.Context) error {
+	return s.mcpServer.Run(ctx, &mcp.StdioTransport{})
+}
@@ -0,0 +1,227 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+var (
+	listSessionsToolName    = "list_sessions"
+	listSessionsDescription = "List recorded LLM sessions, most recent first, with their label, model, project, status, token usage, cost, and tool call count. Use the returned id with get_session."
+
+	searchSessionsToolName    = "search_sessions"
+	searchSessionsDescription = "Full-text search over recorded LLM sessions, including message text and tool inputs and outputs. Use it to recall what was tried before, e.g. the error text of a failing test. Returns matching turns with a snippet and the session_id to pass to get_session."
+
+	getSessionToolName    = "get_session"
+	getSessionDescription = "Get the messages of a recorded LLM session in order, with each message's role, model, tool calls, and text. Long sessions are paginated: pass next_cursor back as cursor for the next page."
+)
+
+const (
+	// defaultListLimit and defaultSearchLimit keep tool output small enough
+	// for an agent's context window.
+	defaultListLimit   = 20
+	defaultSearchLimit = 10
+
+	// defaultMessageLimit is the page size for get_session.
+	defaultMessageLimit = 50
+
+	// maxMessageChars truncates message text returned by get_session.
+	maxMessageChars = 4000
+)
+
+// ListSessionsInput represents the input arguments for the MCP list_sessions tool.
+type ListSessionsInput struct {
+	Since   string `json:"since,omitempty" jsonschema:"only sessions active within this age, e.g. 24h or 7d"`
+	Project string `json:"project,omitempty" jsonschema:"only sessions from this project"`
+	User    string `json:"user,omitempty" jsonschema:"only sessions captured for this user"`
+	Model   string `json:"model,omitempty" jsonschema:"only sessions using this model"`
+	Label   string `json:"label,omitempty" jsonschema:"only sessions whose label contains this text"`
+	Tool    string `json:"tool,omitempty" jsonschema:"only sessions that called a tool whose name contains this text"`
+	Limit   int    `json:"limit,omitempty" jsonschema:"number of sessions to return (default: 20)"`
+}
+
+// ListSessionsOutput is the structured result of the list_sessions tool.
+type ListSessionsOutput struct {
+	Sessions []deck.SessionSummary `json:"sessions"`
+	Total    int                   `json:"total"`
+}
+
+// SearchSessionsInput represents the input arguments for the MCP search_sessions tool.
+type SearchSessionsInput struct {
+	Query string `json:"query" jsonschema:"full-text query; quote phrases, e.g. \"connection refused\", or combine terms with OR"`
+	Since string `json:"since,omitempty" jsonschema:"only match turns newer than this age, e.g. 24h or 7d"`
+	Limit int    `json:"limit,omitempty" jsonschema:"number of hits to return (default: 10)"`
+}
+
+// SearchSessionsOutput is the structured result of the search_sessions tool.
+type SearchSessionsOutput struct {
+	Hits []deck.SearchHit `json:"hits"`
+}
+
+// GetSessionInput represents the input arguments for the MCP get_session tool.
+type GetSessionInput struct {
+	SessionID string `json:"session_id" jsonschema:"the session id from list_sessions or search_sessions"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"number of messages to return (default: 50)"`
+	Cursor    string `json:"cursor,omitempty" jsonschema:"next_cursor from a previous call, to fetch the next page"`
+}
+
+// GetSessionOutput is the structured result of the get_session tool.
+type GetSessionOutput struct {
+	Summary    deck.SessionSummary `json:"summary"`
+	Messages   []SessionMessage    `json:"messages"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// SessionMessage is a message in the get_session output. Text longer than
+// maxMessageChars is truncated.
+type SessionMessage struct {
+	Hash      string    `json:"hash"`
+	Role      string    `json:"role"`
+	Model     string    `json:"model,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	ToolCalls []string  `json:"tool_calls,omitempty"`
+	Text      string    `json:"text"`
+	Truncated bool      `json:"truncated,omitempty"`
+}
+
+// handleListSessions processes a list_sessions request via MCP.
+func (s *Server) handleListSessions(ctx context.Context, _ *mcp.CallToolRequest, input ListSessionsInput) (*mcp.CallToolResult, ListSessionsOutput, error) {
+	filters := deck.Filters{
+		Project: input.Project,
+		User:    input.User,
+		Model:   input.Model,
+		Label:   input.Label,
+		Tool:    input.Tool,
+		Limit:   input.Limit,
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = defaultListLimit
+	}
+	if input.Since != "" {
+		since, err := utils.ParseDuration(input.Since)
+		if err != nil {
+			return toolError[ListSessionsOutput]("Invalid since: %v", err)
+		}
+		filters.Since = since
+	}
+
+	overview, err := s.config.Sessions.Overview(ctx, filters)
+	if err != nil {
+		return toolError[ListSessionsOutput]("Listing sessions failed: %v", err)
+	}
+
+	output := ListSessionsOutput{
+		Sessions: overview.Sessions,
+		Total:    overview.TotalSessions,
+	}
+	if output.Sessions == nil {
+		output.Sessions = []deck.SessionSummary{}
+	}
+	return toolResult(output)
+}
+
+// handleSearchSessions processes a search_sessions request via MCP.
+func (s *Server) handleSearchSessions(ctx context.Context, _ *mcp.CallToolRequest, input SearchSessionsInput) (*mcp.CallToolResult, SearchSessionsOutput, error) {
+	if strings.TrimSpace(input.Query) == "" {
+		return toolError[SearchSessionsOutput]("query is required")
+	}
+
+	opts := deck.SearchOptions{Query: input.Query, Limit: input.Limit}
+	if opts.Limit <= 0 {
+		opts.Limit = defaultSearchLimit
+	}
+	if input.Since != "" {
+		since, err := utils.ParseDuration(input.Since)
+		if err != nil {
+			return toolError[SearchSessionsOutput]("Invalid since: %v", err)
+		}
+		opts.Since = since
+	}
+
+	hits, err := s.config.Sessions.Search(ctx, opts)
+	if err != nil {
+		return toolError[SearchSessionsOutput]("Search failed: %v", err)
+	}
+
+	if hits == nil {
+		hits = []deck.SearchHit{}
+	}
+	return toolResult(SearchSessionsOutput{Hits: hits})
+}
+
+// handleGetSession processes a get_session request via MCP.
+func (s *Server) handleGetSession(ctx context.Context, _ *mcp.CallToolRequest, input GetSessionInput) (*mcp.CallToolResult, GetSessionOutput, error) {
+	if input.SessionID == "" {
+		return toolError[GetSessionOutput]("session_id is required")
+	}
+
+	page := deck.Page{Limit: input.Limit, Cursor: input.Cursor}
+	if page.Limit <= 0 {
+		page.Limit = defaultMessageLimit
+	}
+
+	detail, err := s.config.Sessions.SessionDetailPage(ctx, input.SessionID, page)
+	if err != nil {
+		return toolError[GetSessionOutput]("Getting session failed: %v", err)
+	}
+
+	output := GetSessionOutput{
+		Summary:    detail.Summary,
+		Messages:   make([]SessionMessage, 0, len(detail.Messages)),
+		NextCursor: detail.NextCursor,
+	}
+	for _, msg := range detail.Messages {
+		text, truncated := truncateText(msg.Text, maxMessageChars)
+		output.Messages = append(output.Messages, SessionMessage{
+			Hash:      msg.Hash,
+			Role:      msg.Role,
+			Model:     msg.Model,
+			Timestamp: msg.Timestamp,
+			ToolCalls: msg.ToolCalls,
+			Text:      text,
+			Truncated: truncated,
+		})
+	}
+
+	return toolResult(output)
+}
+
+// toolResult returns output as structured content along with its JSON
+// serialization as text, per the MCP spec's backwards compatibility advice.
+func toolResult[T any](output T) (*mcp.CallToolResult, T, error) {
+	jsonBytes, err := json.Marshal(output)
+	if err != nil {
+		return toolError[T]("Failed to serialize results: %v", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(jsonBytes)},
+		},
+	}, output, nil
+}
+
+// toolError reports a failure to the calling agent. The SDK returns plain
+// errors as a tool result with IsError set rather than a protocol error, so
+// the agent can see and react to it.
+func toolError[T any](format string, args ...any) (*mcp.CallToolResult, T, error) {
+	var zero T
+	return nil, zero, fmt.Errorf(format, args...)
+}
+
+// truncateText cuts text to at most limit runes, reporting whether it did.
+func truncateText(text string, limit int) (string, bool) {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text, false
+	}
+	return string(runes[:limit]) + "…", true
+}
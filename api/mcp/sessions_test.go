@@ -0,0 +1,151 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+
+	sdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/api/mcp"
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+// fakeSessions serves canned deck results and records the arguments of the
+// last call. Methods the tools do not use panic through the nil interface.
+type fakeSessions struct {
+	deck.Querier
+
+	filters deck.Filters
+	search  deck.SearchOptions
+	page    deck.Page
+}
+
+func (f *fakeSessions) Overview(_ context.Context, filters deck.Filters) (*deck.Overview, error) {
+	f.filters = filters
+	return &deck.Overview{
+		Sessions:      []deck.SessionSummary{{ID: "s1", Label: "fix flaky test"}},
+		TotalSessions: 7,
+	}, nil
+}
+
+func (f *fakeSessions) Search(_ context.Context, opts deck.SearchOptions) ([]deck.SearchHit, error) {
+	f.search = opts
+	return []deck.SearchHit{{Hash: "h1", SessionID: "s1", Snippet: "connection refused"}}, nil
+}
+
+func (f *fakeSessions) SessionDetailPage(_ context.Context, sessionID string, page deck.Page) (*deck.SessionDetail, error) {
+	f.page = page
+	if sessionID != "s1" {
+		return nil, errors.New("session not found")
+	}
+	return &deck.SessionDetail{
+		Summary: deck.SessionSummary{ID: "s1"},
+		Messages: []deck.SessionMessage{
+			{Hash: "h1", Role: "user", Text: "why does the test fail?"},
+			{Hash: "h2", Role: "assistant", Text: strings.Repeat("x", 5000), ToolCalls: []string{"Bash"}},
+		},
+		NextCursor: "next",
+	}, nil
+}
+
+var _ = Describe("MCP session tools", func() {
+	var (
+		sessions *fakeSessions
+		client   *sdk.ClientSession
+	)
+
+	callTool := func(name string, args map[string]any) *sdk.CallToolResult {
+		result, err := client.CallTool(context.Background(), &sdk.CallToolParams{Name: name, Arguments: args})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	decode := func(result *sdk.CallToolResult, v any) {
+		Expect(result.IsError).To(BeFalse())
+		Expect(result.Content).To(HaveLen(1))
+		text, ok := result.Content[0].(*sdk.TextContent)
+		Expect(ok).To(BeTrue())
+		Expect(json.Unmarshal([]byte(text.Text), v)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		logger, _ := zap.NewDevelopment()
+		sessions = &fakeSessions{}
+
+		server, err := mcp.NewServer(mcp.Config{
+			Sessions: sessions,
+			Logger:   logger,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		httpServer := httptest.NewServer(server.Handler())
+		DeferCleanup(httpServer.Close)
+
+		c := sdk.NewClient(&sdk.Implementation{Name: "test"}, nil)
+		client, err = c.Connect(context.Background(), &sdk.StreamableClientTransport{Endpoint: httpServer.URL}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(client.Close)
+	})
+
+	It("offers only the session tools without semantic search", func() {
+		tools, err := client.ListTools(context.Background(), nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		names := make([]string, 0, len(tools.Tools))
+		for _, tool := range tools.Tools {
+			names = append(names, tool.Name)
+		}
+		Expect(names).To(ConsistOf("list_sessions", "search_sessions", "get_session"))
+	})
+
+	It("lists sessions with default and requested filters", func() {
+		var output mcp.ListSessionsOutput
+		decode(callTool("list_sessions", map[string]any{"project": "tapes", "since": "7d"}), &output)
+
+		Expect(output.Total).To(Equal(7))
+		Expect(output.Sessions).To(HaveLen(1))
+		Expect(sessions.filters.Project).To(Equal("tapes"))
+		Expect(sessions.filters.Limit).To(Equal(20))
+		Expect(sessions.filters.Since.Hours()).To(Equal(float64(7 * 24)))
+	})
+
+	It("searches sessions", func() {
+		var output mcp.SearchSessionsOutput
+		decode(callTool("search_sessions", map[string]any{"query": `"connection refused"`}), &output)
+
+		Expect(output.Hits).To(HaveLen(1))
+		Expect(output.Hits[0].SessionID).To(Equal("s1"))
+		Expect(sessions.search.Query).To(Equal(`"connection refused"`))
+		Expect(sessions.search.Limit).To(Equal(10))
+	})
+
+	It("reports an empty query as a tool error", func() {
+		result := callTool("search_sessions", map[string]any{"query": " "})
+		Expect(result.IsError).To(BeTrue())
+	})
+
+	It("gets a page of session messages, truncating long text", func() {
+		var output mcp.GetSessionOutput
+		decode(callTool("get_session", map[string]any{"session_id": "s1", "cursor": "abc"}), &output)
+
+		Expect(output.Messages).To(HaveLen(2))
+		Expect(output.Messages[0].Text).To(Equal("why does the test fail?"))
+		Expect(output.Messages[0].Truncated).To(BeFalse())
+		Expect(output.Messages[1].Truncated).To(BeTrue())
+		Expect(len([]rune(output.Messages[1].Text))).To(Equal(4001))
+		Expect(output.Messages[1].ToolCalls).To(Equal([]string{"Bash"}))
+		Expect(output.NextCursor).To(Equal("next"))
+		Expect(sessions.page).To(Equal(deck.Page{Limit: 50, Cursor: "abc"}))
+	})
+
+	It("reports unknown sessions as a tool error", func() {
+		result := callTool("get_session", map[string]any{"session_id": "missing"})
+		Expect(result.IsError).To(BeTrue())
+	})
+})
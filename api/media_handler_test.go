@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+	"github.com/papercomputeco/tapes/pkg/storage/media"
+)
+
+var _ = Describe("GET /dag/node/:hash/media/:index", func() {
+	var (
+		server *Server
+		node   *merkle.Node
+		png    []byte
+	)
+
+	BeforeEach(func() {
+		ctx := context.Background()
+		png = []byte("\x89PNG fake image")
+
+		store, err := media.NewStore(GinkgoT().TempDir(), media.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		content, err := store.Capture(ctx, []llm.ContentBlock{
+			{Type: "text", Text: "what is this?"},
+			{Type: "image", MediaType: "image/png", ImageBase64: base64.StdEncoding.EncodeToString(png)},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		inMem := inmemory.NewDriver()
+		node = merkle.NewNode(merkle.Bucket{Type: "message", Role: "user", Content: content}, nil)
+		_, err = inMem.Put(ctx, node)
+		Expect(err).NotTo(HaveOccurred())
+
+		logger, _ := zap.NewDevelopment()
+		server, err = NewServer(Config{ListenAddr: ":0"}, inMem, inMem, logger)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	get := func(path string) *http.Response {
+		resp, err := server.app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	It("serves the stored image with its media type", func() {
+		resp := get("/dag/node/" + node.Hash + "/media/1")
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("image/png"))
+
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(Equal(png))
+	})
+
+	It("returns 404 for blocks without stored media", func() {
+		for _, path := range []string{
+			"/dag/node/" + node.Hash + "/media/0",
+			"/dag/node/" + node.Hash + "/media/5",
+			"/dag/node/missing/media/1",
+		} {
+			resp := get(path)
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(fiber.StatusNotFound), path)
+		}
+	})
+})
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/papercomputeco/tapes/pkg/health"
+)
+
+// metricsContentType is the Prometheus text exposition format.
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// componentStatusValues encode component statuses as gauge values.
+var componentStatusValues = map[string]int{
+	health.StatusOK:       0,
+	health.StatusDegraded: 1,
+	health.StatusDown:     2,
+}
+
+// handleMetrics handles GET /metrics, serving the proxy's counters and the
+// status of each component (0 ok, 1 degraded, 2 down) for Prometheus.
+func (s *Server) handleMetrics(c *fiber.Ctx) error {
+	var b strings.Builder
+	if s.config.Metrics != nil {
+		metrics := s.config.Metrics()
+		for _, name := range slices.Sorted(maps.Keys(metrics)) {
+			fmt.Fprintf(&b, "%s %g\n", name, metrics[name])
+		}
+	}
+
+	_, components := s.config.Health.Run(c.UserContext())
+	if len(components) > 0 {
+		b.WriteString("# TYPE tapes_component_status gauge\n")
+	}
+	for _, name := range slices.Sorted(maps.Keys(components)) {
+		fmt.Fprintf(&b, "tapes_component_status{component=%q} %d\n", name, componentStatusValues[components[name].Status])
+	}
+
+	c.Set(fiber.HeaderContentType, metricsContentType)
+	return c.SendString(b.String())
+}
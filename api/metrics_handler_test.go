@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/health"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+var _ = Describe("Metrics", func() {
+	It("serves the metrics and component statuses in the Prometheus text format", func() {
+		checker := health.NewChecker()
+		checker.Add("queue", func(context.Context) health.Component {
+			return health.Component{Status: health.StatusDegraded}
+		})
+		checker.Add("storage", func(context.Context) health.Component {
+			return health.Component{Status: health.StatusOK}
+		})
+
+		inMem := inmemory.NewDriver()
+		server, err := NewServer(Config{
+			ListenAddr: ":0",
+			AuthToken:  "secret",
+			Metrics: func() map[string]float64 {
+				return map[string]float64{"tapes_turns_processed_total": 12, "tapes_queue_depth": 3}
+			},
+			Health: checker,
+		}, inMem, inMem, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		resp, err := server.app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(fiber.StatusUnauthorized))
+
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+		resp, err = server.app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+		Expect(resp.Header.Get(fiber.HeaderContentType)).To(HavePrefix("text/plain; version=0.0.4"))
+
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(Equal(`tapes_queue_depth 3
+tapes_turns_processed_total 12
+# TYPE tapes_component_status gauge
+tapes_component_status{component="queue"} 1
+tapes_component_status{component="storage"} 0
+`))
+	})
+})
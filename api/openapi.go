@@ -0,0 +1,18 @@
+package api
+
+import (
+	_ "embed"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// openAPISpec describes the versioned /v1 endpoints.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// handleOpenAPI handles GET /v1/openapi.json.
+func (s *Server) handleOpenAPI(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(openAPISpec)
+}
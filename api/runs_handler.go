@@ -0,0 +1,106 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/runtoken"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+// RunTokenRequest optionally limits a run token being issued. Zero values
+// are unlimited.
+type RunTokenRequest struct {
+	// TTL is a duration such as "8h" or "2d".
+	TTL            string `json:"ttl,omitempty"`
+	MaxRequests    int    `json:"max_requests,omitempty"`
+	MaxInputTokens int    `json:"max_input_tokens,omitempty"`
+}
+
+// RunTokenResponse is returned when a run token is issued. The token itself
+// is only ever returned here.
+type RunTokenResponse struct {
+	runtoken.Info
+
+	Token string `json:"token"`
+}
+
+// RunTokenList is returned when run tokens are listed.
+type RunTokenList struct {
+	Tokens []runtoken.Info `json:"tokens"`
+}
+
+// RunTokenRevokeResponse is returned when run tokens are revoked.
+type RunTokenRevokeResponse struct {
+	Run     string `json:"run,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Revoked int    `json:"revoked"`
+}
+
+// handleIssueRunToken handles POST /v1/runs/:id/token, issuing a token the
+// run's agent sends in place of its API keys.
+func (s *Server) handleIssueRunToken(c *fiber.Ctx) error {
+	if s.config.RunTokens == nil {
+		return runTokensUnavailable(c)
+	}
+
+	var req RunTokenRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: fmt.Sprintf("invalid body: %v", err)})
+		}
+	}
+	limits := runtoken.Limits{MaxRequests: req.MaxRequests, MaxInputTokens: req.MaxInputTokens}
+	if req.TTL != "" {
+		ttl, err := utils.ParseDuration(req.TTL)
+		if err != nil || ttl <= 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: fmt.Sprintf("invalid ttl %q", req.TTL)})
+		}
+		limits.TTL = ttl
+	}
+	if limits.MaxRequests < 0 || limits.MaxInputTokens < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: "limits must not be negative"})
+	}
+
+	token, info := s.config.RunTokens.Issue(c.Params("id"), limits)
+	return c.Status(fiber.StatusCreated).JSON(RunTokenResponse{Info: info, Token: token})
+}
+
+// handleRevokeRunTokens handles DELETE /v1/runs/:id/token, revoking every
+// token of the run.
+func (s *Server) handleRevokeRunTokens(c *fiber.Ctx) error {
+	if s.config.RunTokens == nil {
+		return runTokensUnavailable(c)
+	}
+	run := c.Params("id")
+	return c.JSON(RunTokenRevokeResponse{Run: run, Revoked: s.config.RunTokens.RevokeRun(run)})
+}
+
+// handleListRunTokens handles GET /v1/tokens, listing the issued run tokens
+// and what they were used for.
+func (s *Server) handleListRunTokens(c *fiber.Ctx) error {
+	if s.config.RunTokens == nil {
+		return runTokensUnavailable(c)
+	}
+	return c.JSON(RunTokenList{Tokens: s.config.RunTokens.List()})
+}
+
+// handleRevokeRunToken handles DELETE /v1/tokens/:id, revoking one run token.
+func (s *Server) handleRevokeRunToken(c *fiber.Ctx) error {
+	if s.config.RunTokens == nil {
+		return runTokensUnavailable(c)
+	}
+	id := c.Params("id")
+	if !s.config.RunTokens.Revoke(id) {
+		return c.Status(fiber.StatusNotFound).JSON(llm.ErrorResponse{Error: "run token not found"})
+	}
+	return c.JSON(RunTokenRevokeResponse{ID: id, Revoked: 1})
+}
+
+func runTokensUnavailable(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusServiceUnavailable).JSON(llm.ErrorResponse{
+		Error: "run tokens are not available: the proxy must run in the same process",
+	})
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/runtoken"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+var _ = Describe("Run tokens", func() {
+	var tokens *runtoken.Service
+
+	BeforeEach(func() {
+		tokens = runtoken.New()
+	})
+
+	newServer := func(config Config) *Server {
+		inMem := inmemory.NewDriver()
+		config.ListenAddr = ":0"
+		s, err := NewServer(config, inMem, inMem, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+		return s
+	}
+
+	send := func(s *Server, method, target, body string, headers ...string) (*http.Response, []byte) {
+		var reader io.Reader
+		if body != "" {
+			reader = strings.NewReader(body)
+		}
+		req := httptest.NewRequest(method, target, reader)
+		if body != "" {
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		}
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		resp, err := s.app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return resp, data
+	}
+
+	It("issues a run token with limits", func() {
+		server := newServer(Config{RunTokens: tokens})
+
+		resp, body := send(server, http.MethodPost, "/v1/runs/r1/token", `{"ttl":"2h","max_requests":5,"max_input_tokens":1000}`)
+		Expect(resp.StatusCode).To(Equal(fiber.StatusCreated))
+		var issued RunTokenResponse
+		Expect(json.Unmarshal(body, &issued)).To(Succeed())
+		Expect(issued.Token).To(HavePrefix(runtoken.Prefix))
+		Expect(issued.Run).To(Equal("r1"))
+		Expect(issued.ExpiresAt.Sub(issued.IssuedAt)).To(Equal(2 * time.Hour))
+		Expect(issued.MaxRequests).To(Equal(5))
+		Expect(issued.MaxInputTokens).To(Equal(1000))
+
+		_, err := tokens.Authorize(issued.Token)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("rejects invalid limits", func() {
+		server := newServer(Config{RunTokens: tokens})
+
+		resp, _ := send(server, http.MethodPost, "/v1/runs/r1/token", `{"ttl":"soon"}`)
+		Expect(resp.StatusCode).To(Equal(fiber.StatusBadRequest))
+
+		resp, _ = send(server, http.MethodPost, "/v1/runs/r1/token", `{"max_requests":-1}`)
+		Expect(resp.StatusCode).To(Equal(fiber.StatusBadRequest))
+		Expect(tokens.List()).To(BeEmpty())
+	})
+
+	It("lists tokens without revealing them", func() {
+		server := newServer(Config{RunTokens: tokens})
+		token, info := tokens.Issue("r1", runtoken.Limits{})
+
+		resp, body := send(server, http.MethodGet, "/v1/tokens", "")
+		Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+		Expect(string(body)).NotTo(ContainSubstring(token))
+		var list RunTokenList
+		Expect(json.Unmarshal(body, &list)).To(Succeed())
+		Expect(list.Tokens).To(HaveLen(1))
+		Expect(list.Tokens[0].ID).To(Equal(info.ID))
+	})
+
+	It("revokes a token by ID", func() {
+		server := newServer(Config{RunTokens: tokens})
+		_, info := tokens.Issue("r1", runtoken.Limits{})
+
+		resp, body := send(server, http.MethodDelete, "/v1/tokens/"+info.ID, "")
+		Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+		var revoked RunTokenRevokeResponse
+		Expect(json.Unmarshal(body, &revoked)).To(Succeed())
+		Expect(revoked).To(Equal(RunTokenRevokeResponse{ID: info.ID, Revoked: 1}))
+		Expect(tokens.List()).To(BeEmpty())
+
+		resp, _ = send(server, http.MethodDelete, "/v1/tokens/"+info.ID, "")
+		Expect(resp.StatusCode).To(Equal(fiber.StatusNotFound))
+	})
+
+	It("revokes a run's tokens", func() {
+		server := newServer(Config{RunTokens: tokens})
+		tokens.Issue("r1", runtoken.Limits{})
+		tokens.Issue("r1", runtoken.Limits{})
+		tokens.Issue("r2", runtoken.Limits{})
+
+		resp, body := send(server, http.MethodDelete, "/v1/runs/r1/token", "")
+		Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+		var revoked RunTokenRevokeResponse
+		Expect(json.Unmarshal(body, &revoked)).To(Succeed())
+		Expect(revoked).To(Equal(RunTokenRevokeResponse{Run: "r1", Revoked: 2}))
+		Expect(tokens.List()).To(HaveLen(1))
+	})
+
+	It("lets read-only tokens list but not issue or revoke", func() {
+		server := newServer(Config{RunTokens: tokens, AuthToken: "admin", ReadToken: "reader"})
+		resp, _ := send(server, http.MethodPost, "/v1/runs/r1/token", "", fiber.HeaderAuthorization, "Bearer reader")
+		Expect(resp.StatusCode).To(Equal(fiber.StatusForbidden))
+
+		_, info := tokens.Issue("r1", runtoken.Limits{})
+		resp, _ = send(server, http.MethodDelete, "/v1/tokens/"+info.ID, "", fiber.HeaderAuthorization, "Bearer reader")
+		Expect(resp.StatusCode).To(Equal(fiber.StatusForbidden))
+
+		resp, _ = send(server, http.MethodGet, "/v1/tokens", "", fiber.HeaderAuthorization, "Bearer reader")
+		Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+	})
+
+	It("is unavailable without run tokens", func() {
+		resp, _ := send(newServer(Config{}), http.MethodPost, "/v1/runs/r1/token", "")
+		Expect(resp.StatusCode).To(Equal(fiber.StatusServiceUnavailable))
+	})
+})
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
@@ -9,11 +10,32 @@ import (
 	"github.com/papercomputeco/tapes/pkg/llm"
 )
 
+// Search modes accepted by the mode query parameter.
+const (
+	searchModeSemantic = "semantic"
+	searchModeText     = "text"
+)
+
 // handleSearchEndpoint handles GET /v1/search requests.
 // Query parameters:
 //   - query (required): the search query text
 //   - top_k (optional, default 5): number of results to return
+//   - mode (optional, default semantic): semantic or text
 func (s *Server) handleSearchEndpoint(c *fiber.Ctx) error {
+	switch c.Query("mode", searchModeSemantic) {
+	case searchModeSemantic:
+	case searchModeText:
+		query, topK, err := searchParams(c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: err.Error()})
+		}
+		return s.handleTextSearch(c, query, topK)
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{
+			Error: "mode must be semantic or text",
+		})
+	}
+
 	// Verify search is configured
 	if s.config.VectorDriver == nil || s.config.Embedder == nil {
 		return c.Status(fiber.StatusServiceUnavailable).JSON(llm.ErrorResponse{
@@ -21,22 +43,9 @@ func (s *Server) handleSearchEndpoint(c *fiber.Ctx) error {
 		})
 	}
 
-	query := c.Query("query")
-	if query == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{
-			Error: "query parameter is required",
-		})
-	}
-
-	topK := 5
-	if topKStr := c.Query("top_k"); topKStr != "" {
-		parsed, err := strconv.Atoi(topKStr)
-		if err != nil || parsed <= 0 {
-			return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{
-				Error: "top_k must be a positive integer",
-			})
-		}
-		topK = parsed
+	query, topK, err := searchParams(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: err.Error()})
 	}
 
 	searcher := apisearch.NewSearcher(
@@ -55,3 +64,21 @@ func (s *Server) handleSearchEndpoint(c *fiber.Ctx) error {
 
 	return c.JSON(output)
 }
+
+// searchParams parses the query and top_k query parameters.
+func searchParams(c *fiber.Ctx) (string, int, error) {
+	query := c.Query("query")
+	if query == "" {
+		return "", 0, errors.New("query parameter is required")
+	}
+
+	topK := 5
+	if topKStr := c.Query("top_k"); topKStr != "" {
+		parsed, err := strconv.Atoi(topKStr)
+		if err != nil || parsed <= 0 {
+			return "", 0, errors.New("top_k must be a positive integer")
+		}
+		topK = parsed
+	}
+	return query, topK, nil
+}
@@ -0,0 +1,293 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/health"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+// maxPageSize caps the limit query parameter on paginated endpoints.
+const maxPageSize = 500
+
+// defaultPageSize is used when a paginated request has no limit.
+const defaultPageSize = 50
+
+// SessionsResponse is a page of sessions from GET /v1/sessions. Pass
+// NextCursor as the cursor parameter to fetch the next page; it is empty on
+// the last page.
+type SessionsResponse struct {
+	Sessions   []deck.SessionSummary `json:"sessions"`
+	Total      int                   `json:"total"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+}
+
+// HealthResponse reports the API version and which optional endpoints are
+// available, the status of the server's components when health checks are
+// configured, and the turns skipped by capture rules when the proxy runs in
+// the same process.
+type HealthResponse struct {
+	Status   string `json:"status"`
+	Version  string `json:"version"`
+	Sessions bool   `json:"sessions"`
+	Search   bool   `json:"search"`
+
+	Components map[string]health.Component `json:"components,omitempty"`
+
+	Skipped map[string]uint64 `json:"skipped,omitempty"`
+}
+
+// handleHealth handles GET /v1/health and GET /health. It answers 503 when a
+// component is down, so that orchestrators can act on the status code alone.
+func (s *Server) handleHealth(c *fiber.Ctx) error {
+	resp := HealthResponse{
+		Version:  utils.Version,
+		Sessions: s.config.Sessions != nil,
+		Search:   s.config.Sessions != nil || (s.config.VectorDriver != nil && s.config.Embedder != nil),
+	}
+	resp.Status, resp.Components = s.config.Health.Run(c.Context())
+	if s.config.CaptureSkips != nil {
+		resp.Skipped = s.config.CaptureSkips()
+	}
+
+	if resp.Status == health.StatusDown {
+		c.Status(fiber.StatusServiceUnavailable)
+	}
+	return c.JSON(resp)
+}
+
+// handleListSessions handles GET /v1/sessions.
+// Query parameters:
+//   - limit (optional, default 50, max 500) and cursor: pagination
+//   - sort (cost|date|tokens|duration, default date) and sort_dir (asc|desc)
+//   - since (e.g. 24h, 7d), model, project, user, status, tool: filters
+func (s *Server) handleListSessions(c *fiber.Ctx) error {
+	if s.config.Sessions == nil {
+		return sessionsUnavailable(c)
+	}
+
+	filters, err := sessionFilters(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: err.Error()})
+	}
+	filters.Limit, filters.Cursor, err = pageParams(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: err.Error()})
+	}
+
+	overview, err := s.config.Sessions.Overview(c.Context(), filters)
+	if errors.Is(err, deck.ErrInvalidCursor) {
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: err.Error()})
+	}
+	if err != nil {
+		s.logger.Error("failed to list sessions", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(llm.ErrorResponse{Error: "failed to list sessions"})
+	}
+
+	return c.JSON(SessionsResponse{
+		Sessions:   overview.Sessions,
+		Total:      overview.TotalSessions,
+		NextCursor: overview.NextCursor,
+	})
+}
+
+// handleGetSession handles GET /v1/sessions/:id, returning the session
+// summary and a page of its messages selected by limit and cursor.
+func (s *Server) handleGetSession(c *fiber.Ctx) error {
+	if s.config.Sessions == nil {
+		return sessionsUnavailable(c)
+	}
+
+	limit, cursor, err := pageParams(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: err.Error()})
+	}
+
+	detail, err := s.config.Sessions.SessionDetailPage(c.Context(), c.Params("id"), deck.Page{Limit: limit, Cursor: cursor})
+	if errors.Is(err, deck.ErrInvalidCursor) {
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(llm.ErrorResponse{Error: "session not found"})
+	}
+
+	return c.JSON(detail)
+}
+
+// SessionUpdate is the body of PATCH /v1/sessions/:id. Fields left out keep
+// their current value.
+type SessionUpdate struct {
+	Title   *string `json:"title"`
+	Summary *string `json:"summary"`
+	Outcome *string `json:"outcome"`
+}
+
+// handleUpdateSession handles PATCH /v1/sessions/:id, replacing the title,
+// summary, or outcome of a session. It requires the admin token when tokens
+// are configured.
+func (s *Server) handleUpdateSession(c *fiber.Ctx) error {
+	if s.config.Sessions == nil {
+		return sessionsUnavailable(c)
+	}
+
+	var update SessionUpdate
+	if err := c.BodyParser(&update); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: fmt.Sprintf("invalid body: %v", err)})
+	}
+
+	detail, err := s.config.Sessions.SessionDetailPage(c.Context(), c.Params("id"), deck.Page{Limit: 1})
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(llm.ErrorResponse{Error: "session not found"})
+	}
+
+	annotation := &deck.SessionAnnotation{
+		Title:   detail.Summary.Title,
+		Summary: detail.Summary.Summary,
+		Outcome: detail.Summary.Outcome,
+	}
+	if update.Title != nil {
+		annotation.Title = *update.Title
+	}
+	if update.Summary != nil {
+		annotation.Summary = *update.Summary
+	}
+	if update.Outcome != nil {
+		annotation.Outcome = *update.Outcome
+	}
+
+	if err := s.config.Sessions.Annotate(c.Context(), detail.Summary.ID, annotation); err != nil {
+		s.logger.Error("failed to update session", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(llm.ErrorResponse{Error: "failed to update session"})
+	}
+
+	detail.Summary.Title = annotation.Title
+	detail.Summary.Summary = annotation.Summary
+	detail.Summary.Outcome = annotation.Outcome
+	return c.JSON(detail.Summary)
+}
+
+// handleAnalytics handles GET /v1/analytics, accepting the same filters as
+// GET /v1/sessions.
+func (s *Server) handleAnalytics(c *fiber.Ctx) error {
+	if s.config.Sessions == nil {
+		return sessionsUnavailable(c)
+	}
+
+	filters, err := sessionFilters(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: err.Error()})
+	}
+
+	analytics, err := s.config.Sessions.AnalyticsOverview(c.Context(), filters)
+	if err != nil {
+		s.logger.Error("failed to compute analytics", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(llm.ErrorResponse{Error: "failed to compute analytics"})
+	}
+
+	return c.JSON(analytics)
+}
+
+// handleTextSearch handles GET /v1/search?mode=text, a full-text search
+// across captured turns.
+func (s *Server) handleTextSearch(c *fiber.Ctx, query string, limit int) error {
+	if s.config.Sessions == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(llm.ErrorResponse{
+			Error: "text search is not configured: SQLite storage is required",
+		})
+	}
+
+	opts := deck.SearchOptions{
+		Query:    query,
+		Provider: c.Query("provider"),
+		Limit:    limit,
+	}
+	if since := c.Query("since"); since != "" {
+		duration, err := utils.ParseDuration(since)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: fmt.Sprintf("invalid since: %v", err)})
+		}
+		opts.Since = duration
+	}
+
+	hits, err := s.config.Sessions.Search(c.Context(), opts)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(llm.ErrorResponse{Error: err.Error()})
+	}
+
+	return c.JSON(map[string]any{
+		"query": query,
+		"count": len(hits),
+		"hits":  hits,
+	})
+}
+
+func sessionsUnavailable(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusServiceUnavailable).JSON(llm.ErrorResponse{
+		Error: "sessions are not available: SQLite storage is required",
+	})
+}
+
+// sessionFilters parses the session filter and sort query parameters.
+func sessionFilters(c *fiber.Ctx) (deck.Filters, error) {
+	filters := deck.Filters{
+		Sort:    strings.ToLower(c.Query("sort", "date")),
+		SortDir: strings.ToLower(c.Query("sort_dir", "desc")),
+		Model:   c.Query("model"),
+		Project: c.Query("project"),
+		User:    c.Query("user"),
+		Status:  c.Query("status"),
+		Tool:    c.Query("tool"),
+	}
+
+	switch filters.Sort {
+	case "cost", "date", "tokens", "duration":
+	default:
+		return filters, fmt.Errorf("invalid sort %q", filters.Sort)
+	}
+	if filters.SortDir != "asc" && filters.SortDir != "desc" {
+		return filters, fmt.Errorf("invalid sort_dir %q", filters.SortDir)
+	}
+
+	if since := c.Query("since"); since != "" {
+		duration, err := utils.ParseDuration(since)
+		if err != nil {
+			return filters, fmt.Errorf("invalid since: %w", err)
+		}
+		filters.Since = duration
+	}
+	for name, target := range map[string]**time.Time{"from": &filters.From, "to": &filters.To} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filters, fmt.Errorf("invalid %s: expected RFC3339", name)
+		}
+		*target = &parsed
+	}
+
+	return filters, nil
+}
+
+// pageParams parses the limit and cursor query parameters.
+func pageParams(c *fiber.Ctx) (int, string, error) {
+	limit := defaultPageSize
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxPageSize {
+			return 0, "", fmt.Errorf("limit must be an integer between 1 and %d", maxPageSize)
+		}
+		limit = parsed
+	}
+	return limit, c.Query("cursor"), nil
+}
@@ -0,0 +1,346 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/health"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+// fakeSessions is a deck.Querier that serves canned responses and records the
+// filters and pages it was called with.
+type fakeSessions struct {
+	deck.Querier
+
+	filters    deck.Filters
+	page       deck.Page
+	search     deck.SearchOptions
+	annotation *deck.SessionAnnotation
+}
+
+func (f *fakeSessions) Overview(_ context.Context, filters deck.Filters) (*deck.Overview, error) {
+	f.filters = filters
+	if filters.Cursor == "bad" {
+		return nil, deck.ErrInvalidCursor
+	}
+	return &deck.Overview{
+		Sessions:      []deck.SessionSummary{{ID: "group:a", Label: "first"}},
+		TotalSessions: 3,
+		NextCursor:    "next",
+	}, nil
+}
+
+func (f *fakeSessions) SessionDetailPage(_ context.Context, id string, page deck.Page) (*deck.SessionDetail, error) {
+	f.page = page
+	if id != "group:a" {
+		return nil, errors.New("not found")
+	}
+	return &deck.SessionDetail{Summary: deck.SessionSummary{ID: id, Label: "first", Summary: "Fixed the login test."}}, nil
+}
+
+func (f *fakeSessions) Annotate(_ context.Context, _ string, annotation *deck.SessionAnnotation) error {
+	f.annotation = annotation
+	return nil
+}
+
+func (f *fakeSessions) AnalyticsOverview(_ context.Context, filters deck.Filters) (*deck.AnalyticsOverview, error) {
+	f.filters = filters
+	return &deck.AnalyticsOverview{TotalSessions: 3}, nil
+}
+
+func (f *fakeSessions) Search(_ context.Context, opts deck.SearchOptions) ([]deck.SearchHit, error) {
+	f.search = opts
+	return []deck.SearchHit{{Hash: "abc", Role: "user"}}, nil
+}
+
+var _ = Describe("v1 session endpoints", func() {
+	var (
+		server   *Server
+		sessions *fakeSessions
+		inMem    *inmemory.Driver
+	)
+
+	newServer := func(config Config) *Server {
+		logger, _ := zap.NewDevelopment()
+		config.ListenAddr = ":0"
+		s, err := NewServer(config, inMem, inMem, logger)
+		Expect(err).NotTo(HaveOccurred())
+		return s
+	}
+
+	get := func(s *Server, target string, headers ...string) (*http.Response, []byte) {
+		req, err := http.NewRequest(http.MethodGet, target, nil)
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		resp, err := s.app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return resp, body
+	}
+
+	patch := func(s *Server, target, body string, headers ...string) (*http.Response, []byte) {
+		req, err := http.NewRequest(http.MethodPatch, target, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+		resp, err := s.app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		respBody, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return resp, respBody
+	}
+
+	BeforeEach(func() {
+		inMem = inmemory.NewDriver()
+		sessions = &fakeSessions{}
+		server = newServer(Config{Sessions: sessions})
+	})
+
+	Describe("GET /v1/health", func() {
+		It("reports which endpoints are available", func() {
+			resp, body := get(server, "/v1/health")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+
+			var health HealthResponse
+			Expect(json.Unmarshal(body, &health)).To(Succeed())
+			Expect(health.Status).To(Equal("ok"))
+			Expect(health.Sessions).To(BeTrue())
+			Expect(health.Search).To(BeTrue())
+			Expect(health.Skipped).To(BeEmpty())
+		})
+
+		It("reports the turns skipped by capture rules", func() {
+			server = newServer(Config{CaptureSkips: func() map[string]uint64 {
+				return map[string]uint64{"embeddings": 3}
+			}})
+
+			_, body := get(server, "/v1/health")
+			var health HealthResponse
+			Expect(json.Unmarshal(body, &health)).To(Succeed())
+			Expect(health.Skipped).To(Equal(map[string]uint64{"embeddings": 3}))
+		})
+
+		It("reports component statuses and answers 503 when one is down", func() {
+			checker := health.NewChecker()
+			checker.Add("storage", func(context.Context) health.Component {
+				return health.Component{Status: health.StatusOK}
+			})
+			queue := health.Component{Status: health.StatusDegraded, Message: "230/256 queued"}
+			checker.Add("queue", func(context.Context) health.Component { return queue })
+			server = newServer(Config{Health: checker})
+
+			resp, body := get(server, "/health")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+			var got HealthResponse
+			Expect(json.Unmarshal(body, &got)).To(Succeed())
+			Expect(got.Status).To(Equal(health.StatusDegraded))
+			Expect(got.Components).To(HaveLen(2))
+			Expect(got.Components["queue"].Message).To(Equal("230/256 queued"))
+
+			queue.Status = health.StatusDown
+			resp, body = get(server, "/v1/health")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusServiceUnavailable))
+			Expect(json.Unmarshal(body, &got)).To(Succeed())
+			Expect(got.Status).To(Equal(health.StatusDown))
+		})
+	})
+
+	Describe("GET /v1/openapi.json", func() {
+		It("serves the OpenAPI document", func() {
+			resp, body := get(server, "/v1/openapi.json")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+
+			var doc map[string]any
+			Expect(json.Unmarshal(body, &doc)).To(Succeed())
+			Expect(doc).To(HaveKeyWithValue("openapi", "3.1.0"))
+			Expect(doc["paths"]).To(HaveKey("/v1/sessions"))
+		})
+	})
+
+	Describe("GET /v1/sessions", func() {
+		It("returns a page of sessions with the next cursor", func() {
+			resp, body := get(server, "/v1/sessions?limit=1&sort=cost&sort_dir=asc&model=claude&since=7d")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+
+			var page SessionsResponse
+			Expect(json.Unmarshal(body, &page)).To(Succeed())
+			Expect(page.Sessions).To(HaveLen(1))
+			Expect(page.Total).To(Equal(3))
+			Expect(page.NextCursor).To(Equal("next"))
+
+			Expect(sessions.filters.Limit).To(Equal(1))
+			Expect(sessions.filters.Sort).To(Equal("cost"))
+			Expect(sessions.filters.SortDir).To(Equal("asc"))
+			Expect(sessions.filters.Model).To(Equal("claude"))
+			Expect(sessions.filters.Since).NotTo(BeZero())
+		})
+
+		It("defaults to the newest sessions first", func() {
+			resp, _ := get(server, "/v1/sessions")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+			Expect(sessions.filters.Sort).To(Equal("date"))
+			Expect(sessions.filters.SortDir).To(Equal("desc"))
+			Expect(sessions.filters.Limit).To(Equal(defaultPageSize))
+		})
+
+		DescribeTable("rejects invalid parameters",
+			func(target string) {
+				resp, _ := get(server, target)
+				Expect(resp.StatusCode).To(Equal(fiber.StatusBadRequest))
+			},
+			Entry("sort", "/v1/sessions?sort=name"),
+			Entry("sort_dir", "/v1/sessions?sort_dir=up"),
+			Entry("limit", "/v1/sessions?limit=0"),
+			Entry("limit over the maximum", "/v1/sessions?limit=501"),
+			Entry("since", "/v1/sessions?since=soon"),
+			Entry("from", "/v1/sessions?from=yesterday"),
+			Entry("cursor", "/v1/sessions?cursor=bad"),
+		)
+
+		It("returns 503 without session storage", func() {
+			resp, _ := get(newServer(Config{}), "/v1/sessions")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusServiceUnavailable))
+		})
+	})
+
+	Describe("GET /v1/sessions/:id", func() {
+		It("returns the session with the requested message page", func() {
+			resp, body := get(server, "/v1/sessions/group:a?limit=10&cursor=abc")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+
+			var detail deck.SessionDetail
+			Expect(json.Unmarshal(body, &detail)).To(Succeed())
+			Expect(detail.Summary.Label).To(Equal("first"))
+			Expect(sessions.page).To(Equal(deck.Page{Limit: 10, Cursor: "abc"}))
+		})
+
+		It("returns 404 for an unknown session", func() {
+			resp, _ := get(server, "/v1/sessions/missing")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusNotFound))
+		})
+	})
+
+	Describe("PATCH /v1/sessions/:id", func() {
+		It("updates the given fields and keeps the others", func() {
+			resp, body := patch(server, "/v1/sessions/group:a", `{"title":"Fix login test"}`)
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+
+			var summary deck.SessionSummary
+			Expect(json.Unmarshal(body, &summary)).To(Succeed())
+			Expect(summary.Title).To(Equal("Fix login test"))
+			Expect(sessions.annotation).To(Equal(&deck.SessionAnnotation{
+				Title:   "Fix login test",
+				Summary: "Fixed the login test.",
+			}))
+		})
+
+		It("returns 404 for an unknown session", func() {
+			resp, _ := patch(server, "/v1/sessions/missing", `{"title":"x"}`)
+			Expect(resp.StatusCode).To(Equal(fiber.StatusNotFound))
+		})
+	})
+
+	Describe("GET /v1/analytics", func() {
+		It("returns analytics for the filtered sessions", func() {
+			resp, body := get(server, "/v1/analytics?project=tapes")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+
+			var analytics deck.AnalyticsOverview
+			Expect(json.Unmarshal(body, &analytics)).To(Succeed())
+			Expect(analytics.TotalSessions).To(Equal(3))
+			Expect(sessions.filters.Project).To(Equal("tapes"))
+		})
+	})
+
+	Describe("GET /v1/search?mode=text", func() {
+		It("runs a full-text search", func() {
+			resp, body := get(server, "/v1/search?mode=text&query=retry&top_k=3&provider=anthropic")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+			Expect(string(body)).To(ContainSubstring(`"count":1`))
+			Expect(sessions.search.Query).To(Equal("retry"))
+			Expect(sessions.search.Limit).To(Equal(3))
+			Expect(sessions.search.Provider).To(Equal("anthropic"))
+		})
+
+		It("rejects unknown modes", func() {
+			resp, _ := get(server, "/v1/search?mode=fuzzy&query=retry")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusBadRequest))
+		})
+	})
+
+	Describe("auth token", func() {
+		BeforeEach(func() {
+			server = newServer(Config{Sessions: sessions, AuthToken: "secret"})
+		})
+
+		It("rejects requests without the token", func() {
+			resp, _ := get(server, "/v1/sessions")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusUnauthorized))
+			Expect(resp.Header.Get(fiber.HeaderWWWAuthenticate)).To(ContainSubstring("Bearer"))
+		})
+
+		It("rejects a wrong token", func() {
+			resp, _ := get(server, "/v1/sessions", fiber.HeaderAuthorization, "Bearer nope")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusUnauthorized))
+		})
+
+		It("accepts the bearer token", func() {
+			resp, _ := get(server, "/v1/sessions", fiber.HeaderAuthorization, "Bearer secret")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+		})
+
+		It("leaves health and the OpenAPI document public", func() {
+			resp, _ := get(server, "/v1/health")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+			resp, _ = get(server, "/health")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+			resp, _ = get(server, "/v1/openapi.json")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+		})
+	})
+
+	Describe("read-only token", func() {
+		BeforeEach(func() {
+			server = newServer(Config{Sessions: sessions, AuthToken: "admin", ReadToken: "reader"})
+		})
+
+		It("reads sessions filtered by user", func() {
+			resp, _ := get(server, "/v1/sessions?user=alice", fiber.HeaderAuthorization, "Bearer reader")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+			Expect(sessions.filters.User).To(Equal("alice"))
+		})
+
+		It("cannot update sessions", func() {
+			resp, _ := patch(server, "/v1/sessions/group:a", `{"title":"x"}`, fiber.HeaderAuthorization, "Bearer reader")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusForbidden))
+			Expect(sessions.annotation).To(BeNil())
+		})
+
+		It("leaves updates to the admin token", func() {
+			resp, _ := patch(server, "/v1/sessions/group:a", `{"title":"x"}`, fiber.HeaderAuthorization, "Bearer admin")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusOK))
+		})
+
+		It("is not accepted when only an admin token is configured", func() {
+			server = newServer(Config{Sessions: sessions, AuthToken: "admin"})
+			resp, _ := get(server, "/v1/sessions", fiber.HeaderAuthorization, "Bearer ")
+			Expect(resp.StatusCode).To(Equal(fiber.StatusUnauthorized))
+		})
+	})
+})
@@ -0,0 +1,42 @@
+// Package analyticscmder provides the `tapes analytics` CLI commands for
+// exporting usage trends to dashboards like Grafana and Metabase.
+package analyticscmder
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const analyticsLongDesc string = `Export token usage, errors, and spend for charting in existing dashboards.
+
+Export writes daily usage per model, provider, and project as CSV, or the
+totals as a Prometheus textfile for the node_exporter textfile collector.
+
+Dashboards that read SQLite directly can chart the views tapes keeps in its
+database instead:
+  tapes_turns        One row per assistant turn: day, model, provider,
+                     project, user, agent_name, run_id, is_error, and token
+                     counts
+  tapes_usage_daily  Requests, errors, and token counts per day, model,
+                     provider, and project
+
+The views do not include costs, which are computed from the pricing managed
+with tapes pricing. Export them with tapes analytics export.
+
+Examples:
+  tapes analytics export --format csv --since 90d > usage.csv
+  tapes analytics export --format prometheus-textfile --output /var/lib/node_exporter/tapes.prom`
+
+const analyticsShortDesc string = "Export usage trends for dashboards"
+
+// NewAnalyticsCmd creates the parent analytics command.
+func NewAnalyticsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "analytics",
+		Short: analyticsShortDesc,
+		Long:  analyticsLongDesc,
+	}
+
+	cmd.AddCommand(newExportCmd())
+
+	return cmd
+}
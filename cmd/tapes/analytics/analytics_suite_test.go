@@ -0,0 +1,13 @@
+package analyticscmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestAnalytics(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Analytics Command Suite")
+}
@@ -0,0 +1,242 @@
+package analyticscmder
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+// Export formats.
+const (
+	formatCSV        = "csv"
+	formatPrometheus = "prometheus-textfile"
+)
+
+type exportCommander struct {
+	sqlitePath  string
+	pricingPath string
+	format      string
+	since       string
+	output      string
+}
+
+func newExportCmd() *cobra.Command {
+	cmder := &exportCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export usage as CSV or a Prometheus textfile",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().StringVar(&cmder.pricingPath, "pricing", "", "Path to pricing TOML or JSON overrides")
+	cmd.Flags().StringVar(&cmder.format, "format", formatCSV, "Output format: csv|prometheus-textfile")
+	cmd.Flags().StringVar(&cmder.since, "since", "", "Only include turns newer than this age (e.g. 30d, 2w, 12h)")
+	cmd.Flags().StringVarP(&cmder.output, "output", "o", "", "Write to this file, replacing it atomically (default: stdout)")
+
+	return cmd
+}
+
+func (c *exportCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	var write func(io.Writer, []deck.UsagePoint) error
+	switch c.format {
+	case formatCSV:
+		write = writeCSV
+	case formatPrometheus:
+		write = writePrometheus
+	default:
+		return fmt.Errorf("unsupported format %q (expected %s or %s)", c.format, formatCSV, formatPrometheus)
+	}
+
+	var opts deck.UsageOptions
+	if c.since != "" {
+		since, err := utils.ParseDuration(c.since)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		opts.Since = since
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	pricing, err := deck.ResolvePricing(configDir, c.pricingPath)
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	query, closeFn, err := deck.NewReadOnlyQuery(ctx, dbPath, pricing, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = closeFn() }()
+
+	points, err := query.Usage(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if c.output == "" {
+		return write(cmd.OutOrStdout(), points)
+	}
+	var buf bytes.Buffer
+	if err := write(&buf, points); err != nil {
+		return err
+	}
+	return writeFileAtomic(c.output, buf.Bytes())
+}
+
+// writeFileAtomic replaces path with data through a rename, so collectors
+// polling the file never read a partial export.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("creating export file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing export file: %w", err)
+	}
+	if err := tmp.Chmod(0o644); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing export file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing export file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replacing export file: %w", err)
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, points []deck.UsagePoint) error {
+	cw := csv.NewWriter(w)
+	header := []string{
+		"day", "model", "provider", "project", "requests", "errors", "unpriced_requests",
+		"input_tokens", "output_tokens", "reasoning_tokens", "cache_read_tokens", "cache_write_tokens", "cost",
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, p := range points {
+		record := []string{
+			p.Day,
+			p.Model,
+			p.Provider,
+			p.Project,
+			strconv.Itoa(p.Requests),
+			strconv.Itoa(p.Errors),
+			strconv.Itoa(p.UnpricedRequests),
+			strconv.FormatInt(p.InputTokens, 10),
+			strconv.FormatInt(p.OutputTokens, 10),
+			strconv.FormatInt(p.ReasoningTokens, 10),
+			strconv.FormatInt(p.CacheReadTokens, 10),
+			strconv.FormatInt(p.CacheWriteTokens, 10),
+			strconv.FormatFloat(p.Cost, 'f', 6, 64),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// series is the usage of one model, provider, and project summed over days.
+type series struct {
+	labels string
+	deck.UsagePoint
+}
+
+// writePrometheus writes the usage totals per model, provider, and project
+// as counters in the Prometheus text exposition format.
+func writePrometheus(w io.Writer, points []deck.UsagePoint) error {
+	byLabels := map[string]*series{}
+	for _, p := range points {
+		labels := fmt.Sprintf(`model="%s",provider="%s",project="%s"`, escapeLabel(p.Model), escapeLabel(p.Provider), escapeLabel(p.Project))
+		s, ok := byLabels[labels]
+		if !ok {
+			s = &series{labels: labels}
+			byLabels[labels] = s
+		}
+		s.Requests += p.Requests
+		s.Errors += p.Errors
+		s.InputTokens += p.InputTokens
+		s.OutputTokens += p.OutputTokens
+		s.ReasoningTokens += p.ReasoningTokens
+		s.CacheReadTokens += p.CacheReadTokens
+		s.CacheWriteTokens += p.CacheWriteTokens
+		s.Cost += p.Cost
+	}
+	all := make([]*series, 0, len(byLabels))
+	for _, s := range byLabels {
+		all = append(all, s)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].labels < all[j].labels })
+
+	var b strings.Builder
+	metric := func(name, help string, value func(*series) string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+		for _, s := range all {
+			fmt.Fprintf(&b, "%s{%s} %s\n", name, s.labels, value(s))
+		}
+	}
+	metric("tapes_requests_total", "Assistant turns captured through the proxy.",
+		func(s *series) string { return strconv.Itoa(s.Requests) })
+	metric("tapes_errors_total", "Assistant turns the upstream provider answered with an error.",
+		func(s *series) string { return strconv.Itoa(s.Errors) })
+
+	fmt.Fprintf(&b, "# HELP tapes_tokens_total Tokens used by captured turns, by kind.\n# TYPE tapes_tokens_total counter\n")
+	for _, s := range all {
+		for _, kind := range []struct {
+			name  string
+			value int64
+		}{
+			{"input", s.InputTokens},
+			{"output", s.OutputTokens},
+			{"reasoning", s.ReasoningTokens},
+			{"cache_read", s.CacheReadTokens},
+			{"cache_write", s.CacheWriteTokens},
+		} {
+			fmt.Fprintf(&b, "tapes_tokens_total{%s,kind=\"%s\"} %d\n", s.labels, kind.name, kind.value)
+		}
+	}
+
+	metric("tapes_cost_usd_total", "Spend on captured turns in USD, excluding models without pricing.",
+		func(s *series) string { return strconv.FormatFloat(s.Cost, 'f', -1, 64) })
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeLabel escapes a Prometheus label value.
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
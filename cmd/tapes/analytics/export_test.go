@@ -0,0 +1,101 @@
+package analyticscmder
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("analytics export command", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		prompt := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: "hello"}},
+		}, nil)
+		reply := merkle.NewNode(merkle.Bucket{
+			Type:     "message",
+			Role:     "assistant",
+			Content:  []llm.ContentBlock{{Type: "text", Text: "hi"}},
+			Model:    "gpt-4o",
+			Provider: "openai",
+		}, prompt, merkle.NodeMeta{Project: `my "app"`, Usage: &llm.Usage{PromptTokens: 1_000_000, CompletionTokens: 1_000}})
+		for _, n := range []*merkle.Node{prompt, reply} {
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(driver.Close()).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewAnalyticsCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append([]string{"export", "--sqlite", dbPath}, args...))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	It("writes daily usage as CSV", func() {
+		out, err := run("--since", "30d")
+		Expect(err).NotTo(HaveOccurred())
+
+		today := time.Now().UTC().Format(time.DateOnly)
+		Expect(out).To(Equal("day,model,provider,project,requests,errors,unpriced_requests,input_tokens,output_tokens,reasoning_tokens,cache_read_tokens,cache_write_tokens,cost\n" +
+			today + `,gpt-4o,openai,"my ""app""",1,0,0,1000000,1000,0,0,0,2.510000` + "\n"))
+	})
+
+	It("writes a Prometheus textfile", func() {
+		out, err := run("--format", "prometheus-textfile")
+		Expect(err).NotTo(HaveOccurred())
+
+		labels := `model="gpt-4o",provider="openai",project="my \"app\""`
+		Expect(out).To(ContainSubstring("# TYPE tapes_requests_total counter\ntapes_requests_total{" + labels + "} 1\n"))
+		Expect(out).To(ContainSubstring("tapes_errors_total{" + labels + "} 0\n"))
+		Expect(out).To(ContainSubstring("tapes_tokens_total{" + labels + `,kind="input"} 1000000` + "\n"))
+		Expect(out).To(ContainSubstring("tapes_tokens_total{" + labels + `,kind="output"} 1000` + "\n"))
+		Expect(out).To(ContainSubstring("tapes_cost_usd_total{" + labels + "} 2.51\n"))
+	})
+
+	It("replaces the output file", func() {
+		output := filepath.Join(GinkgoT().TempDir(), "tapes.prom")
+		Expect(os.WriteFile(output, []byte("stale"), 0o644)).To(Succeed())
+
+		out, err := run("--format", "prometheus-textfile", "--output", output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(BeEmpty())
+
+		data, err := os.ReadFile(output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(HavePrefix("# HELP tapes_requests_total"))
+
+		entries, err := os.ReadDir(filepath.Dir(output))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("rejects unknown formats", func() {
+		_, err := run("--format", "json")
+		Expect(err).To(MatchError(`unsupported format "json" (expected csv or prometheus-textfile)`))
+	})
+})
@@ -0,0 +1,134 @@
+// Package auditcmder provides the audit command, which shows the changes
+// tapes has made to credentials, auth files, config, and stored data.
+package auditcmder
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/audit"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+const auditLongDesc string = `Show what tapes has changed on this machine.
+
+Tapes appends an entry to audit.log in the .tapes/ directory whenever it
+stores or removes credentials, patches an agent's auth.json or config.toml
+for "tapes start", changes config, issues or revokes run tokens, or deletes
+data, including prunes run by the daemon. Each entry records when, which user and process, what was
+changed, and how.
+Secret values are never logged.
+
+Actions:
+  credential.set, credential.remove
+  auth_file.patch, auth_file.restore
+  agent_config.patch, agent_config.restore
+  config.set
+  run_token.issue, run_token.revoke
+  prune, prune.gc
+  dead_letter.delete
+
+Examples:
+  tapes audit
+  tapes audit --action credential
+  tapes audit --since 7d --limit 0
+  tapes audit --json`
+
+const auditShortDesc string = "Show changes tapes has made to credentials, config, and data"
+
+type auditCommander struct {
+	action     string
+	since      string
+	limit      int
+	jsonOutput bool
+}
+
+func NewAuditCmd() *cobra.Command {
+	cmder := &auditCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: auditShortDesc,
+		Long:  auditLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			configDir, _ := cmd.Flags().GetString("config-dir")
+			return cmder.run(cmd.OutOrStdout(), configDir)
+		},
+	}
+
+	cmd.Flags().StringVar(&cmder.action, "action", "", "Only show this action or action group (e.g. credential, config.set)")
+	cmd.Flags().StringVar(&cmder.since, "since", "", "Only show entries newer than this age (e.g. 24h, 7d)")
+	cmd.Flags().IntVar(&cmder.limit, "limit", 50, "Show at most this many of the most recent entries (0 for all)")
+	cmd.Flags().BoolVar(&cmder.jsonOutput, "json", false, "Print entries as JSON lines")
+
+	return cmd
+}
+
+func (c *auditCommander) run(w io.Writer, configDir string) error {
+	filter := audit.Filter{Action: c.action, Limit: c.limit}
+	if c.since != "" {
+		since, err := utils.ParseDuration(c.since)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		filter.Since = time.Now().Add(-since)
+	}
+
+	log, err := audit.Open(configDir)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+
+	entries, err := log.Entries(filter)
+	if err != nil {
+		return err
+	}
+
+	if c.jsonOutput {
+		enc := json.NewEncoder(w)
+		for _, entry := range entries {
+			if err := enc.Encode(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No audit entries.")
+		return nil
+	}
+
+	fmt.Fprintf(w, "\nAudit log (%d)  %s\n\n", len(entries), cliui.DimStyle.Render(log.Path()))
+	for _, entry := range entries {
+		fmt.Fprintf(w, "  %s  %s  %s  %s\n",
+			cliui.DimStyle.Render(entry.Time.Local().Format("2006-01-02 15:04:05")),
+			cliui.NameStyle.Render(entry.Action),
+			entry.Target,
+			cliui.DimStyle.Render(fmt.Sprintf("%s (pid %d)", entry.Actor, entry.PID)),
+		)
+		if details := formatDetails(entry.Details); details != "" {
+			fmt.Fprintf(w, "    %s\n", cliui.PreviewStyle.Render(details))
+		}
+	}
+
+	return nil
+}
+
+// formatDetails renders details as sorted key=value pairs.
+func formatDetails(details map[string]string) string {
+	parts := make([]string, 0, len(details))
+	for _, key := range slices.Sorted(maps.Keys(details)) {
+		parts = append(parts, fmt.Sprintf("%s=%q", key, details[key]))
+	}
+	return strings.Join(parts, " ")
+}
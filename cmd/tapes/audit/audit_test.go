@@ -0,0 +1,75 @@
+package auditcmder_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	auditcmder "github.com/papercomputeco/tapes/cmd/tapes/audit"
+	"github.com/papercomputeco/tapes/pkg/audit"
+)
+
+var _ = Describe("Audit Command", func() {
+	var tmpDir string
+
+	execute := func(args ...string) (string, error) {
+		cmd := auditcmder.NewAuditCmd()
+		cmd.PersistentFlags().String("config-dir", "", "Override path to .tapes/ config directory")
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetArgs(append([]string{"--config-dir", tmpDir}, args...))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	BeforeEach(func() {
+		tmpDir = GinkgoT().TempDir()
+	})
+
+	It("reports an empty log", func() {
+		out, err := execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("No audit entries."))
+	})
+
+	It("lists entries with their details", func() {
+		Expect(audit.Record(tmpDir, audit.ActionCredentialSet, "openai", map[string]string{"env_var": "OPENAI_API_KEY"})).To(Succeed())
+		Expect(audit.Record(tmpDir, audit.ActionPrune, "/tmp/tapes.db", map[string]string{"sessions": "3"})).To(Succeed())
+
+		out, err := execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Audit log (2)"))
+		Expect(out).To(ContainSubstring("credential.set"))
+		Expect(out).To(ContainSubstring(`env_var="OPENAI_API_KEY"`))
+		Expect(out).To(ContainSubstring(`sessions="3"`))
+	})
+
+	It("filters by action and prints JSON lines", func() {
+		Expect(audit.Record(tmpDir, audit.ActionCredentialSet, "openai", nil)).To(Succeed())
+		Expect(audit.Record(tmpDir, audit.ActionPrune, "", nil)).To(Succeed())
+
+		out, err := execute("--action", "prune", "--json")
+		Expect(err).NotTo(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		Expect(lines).To(HaveLen(1))
+		var entry audit.Entry
+		Expect(json.Unmarshal([]byte(lines[0]), &entry)).To(Succeed())
+		Expect(entry.Action).To(Equal(audit.ActionPrune))
+	})
+
+	It("rejects an invalid --since", func() {
+		_, err := execute("--since", "soon")
+		Expect(err).To(MatchError(ContainSubstring("invalid --since")))
+	})
+
+	It("takes no arguments", func() {
+		cmd := auditcmder.NewAuditCmd()
+		Expect(cmd.Args).NotTo(BeNil())
+		Expect(cmd.Args(&cobra.Command{}, []string{"extra"})).To(HaveOccurred())
+	})
+})
@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 
+	"github.com/papercomputeco/tapes/pkg/audit"
 	"github.com/papercomputeco/tapes/pkg/credentials"
 )
 
@@ -102,6 +103,9 @@ func runAuth(provider, configDir string) error {
 	}
 
 	envVar := credentials.EnvVarForProvider(provider)
+	if err := audit.Record(configDir, audit.ActionCredentialSet, provider, map[string]string{"env_var": envVar}); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write audit log: %v\n", err)
+	}
 	fmt.Printf("Stored %s credentials (will be injected as %s)\n", provider, envVar)
 
 	if provider == "openai" {
@@ -157,6 +161,9 @@ func runRemove(provider, configDir string) error {
 	if err := mgr.RemoveKey(provider); err != nil {
 		return err
 	}
+	if err := audit.Record(configDir, audit.ActionCredentialRemove, provider, nil); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write audit log: %v\n", err)
+	}
 
 	fmt.Printf("Removed %s credentials.\n", provider)
 
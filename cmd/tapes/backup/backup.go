@@ -0,0 +1,160 @@
+// Package backupcmder provides the `tapes backup` commands for snapshotting
+// and restoring the local SQLite database.
+package backupcmder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/dotdir"
+)
+
+const backupLongDesc string = `Back up and restore the SQLite database.
+
+Backups use SQLite's online backup API, so they can be taken while the daemon
+started by "tapes start" keeps capturing. They are written to backups/ in the
+.tapes/ directory, and the oldest are deleted once more than --keep exist.
+
+With --encrypt, backups are sealed with AES-256-GCM using the storage
+encryption key from TAPES_ENCRYPTION_KEY or the OS keychain. The same key is
+needed to restore them.
+
+Examples:
+  tapes backup create
+  tapes backup create --encrypt --keep 10
+  tapes backup list
+  tapes backup restore latest
+  tapes backup restore tapes-20260115T093000Z.db`
+
+const backupShortDesc string = "Back up and restore the database"
+
+const (
+	// backupsDir is the directory backups are kept in, inside .tapes/.
+	backupsDir = "backups"
+
+	// backupPrefix and the extensions name backup files, which sort by the
+	// time they were taken.
+	backupPrefix       = "tapes-"
+	backupExt          = ".db"
+	encryptedBackupExt = ".db.enc"
+	backupTimeLayout   = "20060102T150405Z"
+
+	// preRestorePrefix names the copy of the database that restore saves
+	// before replacing it. It is not a backup name, so the copy is never
+	// rotated out or picked as the latest backup.
+	preRestorePrefix = "pre-restore-"
+
+	// defaultKeep is how many backups are kept when neither --keep nor
+	// storage.backup_keep is set.
+	defaultKeep = 5
+)
+
+// NewBackupCmd creates the parent backup command.
+func NewBackupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: backupShortDesc,
+		Long:  backupLongDesc,
+	}
+
+	cmd.PersistentFlags().StringP("sqlite", "s", "", "Path to SQLite database")
+	cmd.PersistentFlags().String("dir", "", "Directory backups are kept in (default: backups/ in the .tapes/ directory)")
+
+	cmd.AddCommand(newCreateCmd())
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newRestoreCmd())
+
+	return cmd
+}
+
+// resolveBackupDir returns the directory backups are kept in: the --dir flag,
+// backups/ in the .tapes/ directory, or backups/ next to the database.
+func resolveBackupDir(cmd *cobra.Command, dbPath string) (string, error) {
+	if dir, _ := cmd.Flags().GetString("dir"); dir != "" {
+		return dir, nil
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	target, err := dotdir.NewManager().Target(configDir)
+	if err != nil {
+		return "", err
+	}
+	if target == "" {
+		target = filepath.Dir(dbPath)
+	}
+	return filepath.Join(target, backupsDir), nil
+}
+
+// backupName returns the file name of a backup taken at t.
+func backupName(t time.Time, encrypted bool) string {
+	ext := backupExt
+	if encrypted {
+		ext = encryptedBackupExt
+	}
+	return backupPrefix + t.UTC().Format(backupTimeLayout) + ext
+}
+
+// isBackupName reports whether name is a file written by backup create.
+func isBackupName(name string) bool {
+	if !strings.HasPrefix(name, backupPrefix) {
+		return false
+	}
+	stamp := strings.TrimPrefix(name, backupPrefix)
+	stamp, ok := strings.CutSuffix(stamp, encryptedBackupExt)
+	if !ok {
+		stamp, ok = strings.CutSuffix(stamp, backupExt)
+	}
+	if !ok {
+		return false
+	}
+	_, err := time.Parse(backupTimeLayout, stamp)
+	return err == nil
+}
+
+// listBackups returns the backups in dir, oldest first.
+func listBackups(dir string) ([]os.DirEntry, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading backups: %w", err)
+	}
+
+	var backups []os.DirEntry
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && isBackupName(entry.Name()) {
+			backups = append(backups, entry)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Name() < backups[j].Name()
+	})
+	return backups, nil
+}
+
+// rotateBackups deletes the oldest backups in dir beyond keep and returns
+// the names of the deleted files.
+func rotateBackups(dir string, keep int) ([]string, error) {
+	backups, err := listBackups(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for len(backups) > keep {
+		name := backups[0].Name()
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return deleted, fmt.Errorf("deleting old backup: %w", err)
+		}
+		deleted = append(deleted, name)
+		backups = backups[1:]
+	}
+	return deleted, nil
+}
@@ -0,0 +1,187 @@
+package backupcmder
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("backup commands", func() {
+	var (
+		ctx       context.Context
+		dbPath    string
+		backupDir string
+		input     string
+	)
+
+	put := func(text string) *merkle.Node {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		n := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: text}},
+		}, nil)
+		_, err = driver.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+		return n
+	}
+
+	has := func(n *merkle.Node) bool {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		ok, err := driver.Has(ctx, n.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		return ok
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := NewBackupCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetIn(strings.NewReader(input))
+		cmd.SetArgs(append(args, "--sqlite", dbPath, "--dir", backupDir))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		input = ""
+		dir := GinkgoT().TempDir()
+		dbPath = filepath.Join(dir, "tapes.db")
+		backupDir = filepath.Join(dir, "backups")
+	})
+
+	It("creates a backup and restores it", func() {
+		before := put("before backup")
+
+		out, err := run("create")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Wrote " + backupDir))
+
+		out, err = run("list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchRegexp(`tapes-\d{8}T\d{6}Z\.db`))
+
+		after := put("after backup")
+
+		_, err = run("restore", "latest", "--yes")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has(before)).To(BeTrue())
+		Expect(has(after)).To(BeFalse())
+	})
+
+	It("asks before restoring and saves the database it replaces", func() {
+		put("before backup")
+		_, err := run("create")
+		Expect(err).NotTo(HaveOccurred())
+		after := put("after backup")
+
+		input = "n\n"
+		out, err := run("restore", "latest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("[y/N]"))
+		Expect(out).To(ContainSubstring("Nothing restored."))
+		Expect(has(after)).To(BeTrue())
+
+		input = "y\n"
+		_, err = run("restore", "latest")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has(after)).To(BeFalse())
+
+		saved, err := filepath.Glob(filepath.Join(backupDir, preRestorePrefix+"*"+backupExt))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(saved).To(HaveLen(1))
+
+		_, err = run("restore", filepath.Base(saved[0]), "--yes")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has(after)).To(BeTrue())
+	})
+
+	It("encrypts backups with the storage key", func() {
+		key, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv(encryption.KeyEnvVar, key)
+
+		before := put("secret prompt")
+
+		_, err = run("create", "--encrypt")
+		Expect(err).NotTo(HaveOccurred())
+
+		backups, err := listBackups(backupDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backups).To(HaveLen(1))
+		Expect(backups[0].Name()).To(HaveSuffix(encryptedBackupExt))
+
+		data, err := os.ReadFile(filepath.Join(backupDir, backups[0].Name()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(encryption.IsEncryptedStream(data)).To(BeTrue())
+
+		after := put("after backup")
+		_, err = run("restore", backups[0].Name(), "--yes")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has(before)).To(BeTrue())
+		Expect(has(after)).To(BeFalse())
+
+		other, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv(encryption.KeyEnvVar, other)
+		_, err = run("restore", "latest", "--yes")
+		Expect(err).To(MatchError(ContainSubstring("another key")))
+	})
+
+	It("requires a key to encrypt", func() {
+		GinkgoT().Setenv(encryption.KeyEnvVar, "")
+		put("prompt")
+
+		if key, err := encryption.LoadKey(ctx); err == nil && key != nil {
+			Skip("a storage key is configured in the OS keychain")
+		}
+		_, err := run("create", "--encrypt")
+		Expect(err).To(MatchError(ContainSubstring("no encryption key")))
+	})
+
+	It("keeps only the newest backups", func() {
+		Expect(os.MkdirAll(backupDir, 0o700)).To(Succeed())
+		start := time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC)
+		for i := range 4 {
+			name := backupName(start.Add(time.Duration(i)*time.Hour), i%2 == 1)
+			Expect(os.WriteFile(filepath.Join(backupDir, name), nil, 0o600)).To(Succeed())
+		}
+		Expect(os.WriteFile(filepath.Join(backupDir, "notes.txt"), nil, 0o600)).To(Succeed())
+
+		deleted, err := rotateBackups(backupDir, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deleted).To(Equal([]string{
+			"tapes-20260115T093000Z.db",
+			"tapes-20260115T103000Z.db.enc",
+		}))
+
+		entries, err := os.ReadDir(backupDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(3))
+	})
+
+	It("fails to restore a missing backup", func() {
+		put("prompt")
+		_, err := run("restore", "latest")
+		Expect(err).To(MatchError(ContainSubstring("no backups")))
+	})
+})
@@ -0,0 +1,180 @@
+package backupcmder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+type createCommander struct {
+	encrypt bool
+	keep    uint
+
+	now func() time.Time
+}
+
+func newCreateCmd() *cobra.Command {
+	cmder := &createCommander{now: time.Now}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Snapshot the database into the backups directory",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			if cmd.Flags().Changed("keep") {
+				return nil
+			}
+
+			configDir, _ := cmd.Flags().GetString("config-dir")
+			cfger, err := config.NewConfiger(configDir)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			cfg, err := cfger.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+			cmder.keep = cfg.Storage.BackupKeep
+			if cmder.keep == 0 {
+				cmder.keep = defaultKeep
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().BoolVar(&cmder.encrypt, "encrypt", false, "Encrypt the backup with the storage encryption key")
+	cmd.Flags().UintVar(&cmder.keep, "keep", defaultKeep, "Number of backups to keep (default: storage.backup_keep or 5)")
+
+	return cmd
+}
+
+func (c *createCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	w := cmd.OutOrStdout()
+
+	if c.keep == 0 {
+		return errors.New("--keep must be at least 1")
+	}
+
+	override, err := cmd.Flags().GetString("sqlite")
+	if err != nil {
+		return err
+	}
+	dbPath, err := sqlitepath.ResolveSQLitePath(override)
+	if err != nil {
+		return err
+	}
+
+	var cipher *encryption.Cipher
+	if c.encrypt {
+		cipher, err = loadCipher(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	dir, err := resolveBackupDir(cmd, dbPath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating backups directory: %w", err)
+	}
+
+	path := filepath.Join(dir, backupName(c.now(), c.encrypt))
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("backup %s already exists", path)
+	}
+
+	err = cliui.Step(w, "Backing up "+dbPath, func() error {
+		return writeBackup(ctx, dbPath, path, cipher)
+	})
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%s Wrote %s (%s)\n", cliui.SuccessMark, path, utils.FormatSize(info.Size()))
+
+	deleted, err := rotateBackups(dir, int(c.keep))
+	for _, name := range deleted {
+		fmt.Fprintf(w, "  Deleted old backup %s\n", name)
+	}
+	return err
+}
+
+// writeBackup snapshots the database to a temporary file next to path and
+// moves it into place, sealing it first when cipher is set. A failed backup
+// never leaves a partial file under a backup name.
+func writeBackup(ctx context.Context, dbPath, path string, cipher *encryption.Cipher) error {
+	snapshot, err := os.CreateTemp(filepath.Dir(path), ".backup-*.db")
+	if err != nil {
+		return fmt.Errorf("creating backup: %w", err)
+	}
+	snapshotPath := snapshot.Name()
+	_ = snapshot.Close()
+	defer os.Remove(snapshotPath)
+
+	if err := sqlite.Backup(ctx, dbPath, snapshotPath); err != nil {
+		return err
+	}
+
+	if cipher == nil {
+		return os.Rename(snapshotPath, path)
+	}
+
+	sealedPath := snapshotPath + ".enc"
+	defer os.Remove(sealedPath)
+	if err := sealFile(cipher, snapshotPath, sealedPath); err != nil {
+		return err
+	}
+	return os.Rename(sealedPath, path)
+}
+
+// sealFile encrypts the file at src into dst.
+func sealFile(cipher *encryption.Cipher, src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := cipher.EncryptStream(out, in); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("encrypting backup: %w", err)
+	}
+	return out.Close()
+}
+
+// loadCipher returns a cipher for the storage encryption key.
+func loadCipher(ctx context.Context) (*encryption.Cipher, error) {
+	key, err := encryption.LoadKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, fmt.Errorf("no encryption key: set %s or store a key in the OS keychain", encryption.KeyEnvVar)
+	}
+	return encryption.NewCipher(key)
+}
@@ -0,0 +1,54 @@
+package backupcmder
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List backups, oldest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runList(cmd)
+		},
+	}
+}
+
+func runList(cmd *cobra.Command) error {
+	w := cmd.OutOrStdout()
+
+	// The database path only locates backups when there is no .tapes/
+	// directory, so a missing database is not an error here.
+	override, _ := cmd.Flags().GetString("sqlite")
+	dbPath, _ := sqlitepath.ResolveSQLitePath(override)
+
+	dir, err := resolveBackupDir(cmd, dbPath)
+	if err != nil {
+		return err
+	}
+	backups, err := listBackups(dir)
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		fmt.Fprintf(w, "No backups in %s\n", dir)
+		return nil
+	}
+
+	fmt.Fprintf(w, "Backups in %s\n\n", dir)
+	for _, entry := range backups {
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "  %s  %s\n", entry.Name(), cliui.DimStyle.Render(utils.FormatSize(info.Size())))
+	}
+	return nil
+}
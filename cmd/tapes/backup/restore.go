@@ -0,0 +1,238 @@
+package backupcmder
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/audit"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+const restoreLongDesc string = `Restore the database from a backup.
+
+The backup is given as a path, as the name of a file in the backups
+directory, or as "latest". It is checked for integrity before anything is
+replaced, and encrypted backups are decrypted with the storage encryption
+key. The whole database is replaced in one transaction, so a running daemon
+sees either the old contents or the restored ones.
+
+After confirmation, the current database is first copied to
+pre-restore-<time>.db in the backups directory, which can be restored by
+name to undo the restore. These copies are never rotated out.
+
+Examples:
+  tapes backup restore latest
+  tapes backup restore tapes-20260115T093000Z.db --yes
+  tapes backup restore ~/Downloads/tapes-20260115T093000Z.db.enc`
+
+type restoreCommander struct {
+	yes bool
+	now func() time.Time
+}
+
+func newRestoreCmd() *cobra.Command {
+	cmder := &restoreCommander{now: time.Now}
+
+	cmd := &cobra.Command{
+		Use:   "restore <backup>",
+		Short: "Replace the database with a backup",
+		Long:  restoreLongDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmder.run(cmd.Context(), cmd, args[0])
+		},
+	}
+
+	cmd.Flags().BoolVarP(&cmder.yes, "yes", "y", false, "Restore without asking for confirmation")
+
+	return cmd
+}
+
+func (c *restoreCommander) run(ctx context.Context, cmd *cobra.Command, name string) error {
+	w := cmd.OutOrStdout()
+
+	override, err := cmd.Flags().GetString("sqlite")
+	if err != nil {
+		return err
+	}
+	dbPath, err := sqlitepath.ResolveSQLitePath(override)
+	if err != nil {
+		return err
+	}
+
+	dir, err := resolveBackupDir(cmd, dbPath)
+	if err != nil {
+		return err
+	}
+	path, err := findBackup(dir, name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(dbPath); err == nil {
+		if !c.yes {
+			ok, err := confirmRestore(cmd.InOrStdin(), w, dbPath, path)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Fprintln(w, "Nothing restored.")
+				return nil
+			}
+		}
+
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("creating backups directory: %w", err)
+		}
+		saved := preRestorePath(dir, c.now())
+		err = cliui.Step(w, "Saving the current database to "+saved, func() error {
+			return writeBackup(ctx, dbPath, saved, nil)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = cliui.Step(w, "Restoring "+dbPath+" from "+path, func() error {
+		return restoreBackup(ctx, path, dbPath)
+	})
+	if err != nil {
+		return err
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	details := map[string]string{"backup": path, "source": "cli"}
+	if err := audit.Record(configDir, audit.ActionBackupRestore, dbPath, details); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write audit log: %v\n", err)
+	}
+
+	fmt.Fprintf(w, "%s Restored %s\n", cliui.SuccessMark, dbPath)
+	return nil
+}
+
+// preRestorePath returns a path in dir that no file has yet for the copy of
+// the database restore saves at t, numbering copies saved within a second.
+func preRestorePath(dir string, t time.Time) string {
+	stamp := preRestorePrefix + t.UTC().Format(backupTimeLayout)
+	path := filepath.Join(dir, stamp+backupExt)
+	for i := 2; ; i++ {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return path
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s-%d%s", stamp, i, backupExt))
+	}
+}
+
+// confirmRestore asks whether to replace the database at dbPath with the
+// backup at path, returning true only for an explicit yes.
+func confirmRestore(stdin io.Reader, stdout io.Writer, dbPath, path string) (bool, error) {
+	fmt.Fprintf(stdout, "Replace %s with %s? [y/N]: ", dbPath, path)
+
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("reading confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// findBackup resolves a backup argument: "latest", a path to an existing
+// file, or the name of a backup in dir.
+func findBackup(dir, name string) (string, error) {
+	if name == "latest" {
+		backups, err := listBackups(dir)
+		if err != nil {
+			return "", err
+		}
+		if len(backups) == 0 {
+			return "", fmt.Errorf("no backups in %s", dir)
+		}
+		return filepath.Join(dir, backups[len(backups)-1].Name()), nil
+	}
+
+	if _, err := os.Stat(name); err == nil {
+		return name, nil
+	}
+	if !strings.ContainsRune(name, os.PathSeparator) {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("backup %s not found", name)
+}
+
+// restoreBackup restores the database from the backup at path, decrypting
+// it to a temporary file first when it is encrypted.
+func restoreBackup(ctx context.Context, path, dbPath string) error {
+	encrypted, err := isEncrypted(path)
+	if err != nil {
+		return err
+	}
+	if !encrypted {
+		return sqlite.Restore(ctx, path, dbPath)
+	}
+
+	cipher, err := loadCipher(ctx)
+	if err != nil {
+		return err
+	}
+
+	plain, err := os.CreateTemp(filepath.Dir(dbPath), ".restore-*.db")
+	if err != nil {
+		return fmt.Errorf("decrypting backup: %w", err)
+	}
+	defer os.Remove(plain.Name())
+
+	in, err := os.Open(path)
+	if err != nil {
+		_ = plain.Close()
+		return err
+	}
+	defer in.Close()
+
+	if err := cipher.DecryptStream(plain, in); err != nil {
+		_ = plain.Close()
+		if errors.Is(err, encryption.ErrInvalidCiphertext) {
+			return fmt.Errorf("decrypting backup (was it encrypted with another key?): %w", err)
+		}
+		return fmt.Errorf("decrypting backup: %w", err)
+	}
+	if err := plain.Close(); err != nil {
+		return err
+	}
+	return sqlite.Restore(ctx, plain.Name(), dbPath)
+}
+
+// isEncrypted reports whether the file at path was sealed by backup create.
+func isEncrypted(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	header := make([]byte, len(encryption.StreamMagic))
+	if _, err := io.ReadFull(f, header); err != nil {
+		// Too short to be encrypted; the integrity check rejects it.
+		return false, nil //nolint:nilerr // not an error for the caller
+	}
+	return encryption.IsEncryptedStream(header), nil
+}
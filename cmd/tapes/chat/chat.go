@@ -21,10 +21,12 @@ import (
 	"github.com/papercomputeco/tapes/pkg/dotdir"
 	"github.com/papercomputeco/tapes/pkg/logger"
 	"github.com/papercomputeco/tapes/pkg/utils"
+	"github.com/papercomputeco/tapes/proxy/header"
 )
 
 type chatCommander struct {
 	proxyTarget string
+	proxyToken  string
 	apiTarget   string
 	model       string
 	debug       bool
@@ -104,6 +106,7 @@ func NewChatCmd() *cobra.Command {
 			if !cmd.Flags().Changed("proxy-target") {
 				cmder.proxyTarget = cfg.Client.ProxyTarget
 			}
+			cmder.proxyToken = cfg.Proxy.Token
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -233,6 +236,9 @@ func (c *chatCommander) sendAndStream(messages []ollamaMessage) (string, error)
 		return "", fmt.Errorf("creating request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	if c.proxyToken != "" {
+		httpReq.Header.Set(header.AuthTokenHeader, c.proxyToken)
+	}
 
 	client := &http.Client{
 		// LLM responses can be slow
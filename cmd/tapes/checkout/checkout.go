@@ -24,6 +24,7 @@ import (
 type checkoutCommander struct {
 	hash      string
 	apiTarget string
+	apiToken  string
 	debug     bool
 
 	logger *zap.Logger
@@ -85,6 +86,7 @@ func NewCheckoutCmd() *cobra.Command {
 			if !cmd.Flags().Changed("api-target") {
 				cmder.apiTarget = cfg.Client.APITarget
 			}
+			cmder.apiToken = cfg.API.Token
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -170,6 +172,9 @@ func (c *checkoutCommander) fetchHistory(hash string) (*historyResponse, error)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
+	if c.apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("requesting history from API: %w", err)
@@ -9,26 +9,42 @@ import (
 const configLongDesc string = `Manage persistent tapes configuration.
 
 Configuration is stored as config.toml in the .tapes/ directory and provides
-default values for command flags. CLI flags always take precedence over
-config file values.
+default values for command flags. Every key can also be set by a TAPES_*
+environment variable named after it, e.g. TAPES_PROXY_LISTEN for proxy.listen
+or TAPES_RETENTION_MAX_AGE for retention.max_age, so that servers can be
+configured without a config file. Settings are resolved with this precedence,
+highest first: CLI flags, environment variables, config.toml, and defaults.
 
 Keys use dotted notation matching the TOML section structure:
-  proxy.provider, proxy.upstream, proxy.listen,
-  api.listen, storage.sqlite_path,
+  proxy.provider, proxy.upstream, proxy.listen, proxy.sign_nodes,
+  proxy.workers, proxy.queue_size, proxy.overflow_policy,
+  proxy.enqueue_timeout, proxy.job_timeout,
+  api.listen, api.token, storage.sqlite_path, storage.encryption,
   client.proxy_target, client.api_target,
   vector_store.provider, vector_store.target,
-  embedding.provider, embedding.target, embedding.model, embedding.dimensions
+  embedding.provider, embedding.target, embedding.model, embedding.dimensions,
+  telemetry.otlp_endpoint,
+  summarizer.enabled, summarizer.provider, summarizer.model, summarizer.idle_after,
+  titles.provider, titles.model,
+  report.slack_webhook, report.slack_daily_at,
+  logs.level, logs.max_file_size, logs.max_files,
+  health.probe_interval, redaction.enabled, redaction.rules_file,
+  retention.max_age, retention.max_db_size, retention.max_sessions, retention.gc,
+  start.confirm_recording, start.codex_config, start.codex_wire_api,
+  start.run_token_ttl, start.run_token_max_requests, start.run_token_max_input_tokens
 
 Use subcommands to get, set, or list configuration values:
   tapes config set <key> <value>    Set a configuration value
   tapes config get <key>            Get a configuration value
   tapes config list                 List all configuration values
+  tapes config print [--resolved]   Print the configuration as TOML
 
 Examples:
   tapes config set proxy.provider anthropic
   tapes config set embedding.model nomic-embed-text
   tapes config get proxy.provider
-  tapes config list`
+  tapes config list
+  tapes config print --resolved`
 
 const configShortDesc string = "Manage persistent tapes configuration"
 
@@ -42,6 +58,7 @@ func NewConfigCmd() *cobra.Command {
 	cmd.AddCommand(newSetCmd())
 	cmd.AddCommand(newGetCmd())
 	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newPrintCmd())
 
 	return cmd
 }
@@ -1,6 +1,7 @@
 package configcmder_test
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 
@@ -8,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	configcmder "github.com/papercomputeco/tapes/cmd/tapes/config"
+	"github.com/papercomputeco/tapes/pkg/audit"
 )
 
 var _ = Describe("NewConfigCmd", func() {
@@ -16,14 +18,14 @@ var _ = Describe("NewConfigCmd", func() {
 		Expect(cmd.Use).To(Equal("config"))
 	})
 
-	It("has set, get, and list subcommands", func() {
+	It("has set, get, list, and print subcommands", func() {
 		cmd := configcmder.NewConfigCmd()
 		cmds := cmd.Commands()
 		subcommands := make([]string, 0, len(cmds))
 		for _, sub := range cmds {
 			subcommands = append(subcommands, sub.Name())
 		}
-		Expect(subcommands).To(ContainElements("set", "get", "list"))
+		Expect(subcommands).To(ContainElements("set", "get", "list", "print"))
 	})
 })
 
@@ -94,6 +96,31 @@ var _ = Describe("Config command execution", func() {
 			err := cmd.Execute()
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("records changes in the audit log without secret values", func() {
+			cmd := configcmder.NewConfigCmd()
+			cmd.SetArgs([]string{"set", "proxy.provider", "anthropic"})
+			Expect(cmd.Execute()).To(Succeed())
+
+			cmd = configcmder.NewConfigCmd()
+			cmd.SetArgs([]string{"set", "api.token", "s3cret"})
+			Expect(cmd.Execute()).To(Succeed())
+
+			log, err := audit.Open("")
+			Expect(err).NotTo(HaveOccurred())
+			entries, err := log.Entries(audit.Filter{Action: audit.ActionConfigSet})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+
+			Expect(entries[0].Target).To(Equal("proxy.provider"))
+			Expect(entries[0].Details).To(HaveKeyWithValue("new", "anthropic"))
+			Expect(entries[1].Target).To(Equal("api.token"))
+			Expect(entries[1].Details).To(Equal(map[string]string{"old": "", "new": "[redacted]"}))
+
+			data, err := os.ReadFile(log.Path())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).NotTo(ContainSubstring("s3cret"))
+		})
 	})
 
 	Describe("get subcommand", func() {
@@ -161,4 +188,44 @@ var _ = Describe("Config command execution", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+	Describe("print subcommand", func() {
+		printConfig := func(args ...string) string {
+			var out bytes.Buffer
+			cmd := configcmder.NewConfigCmd()
+			cmd.SetOut(&out)
+			cmd.SetArgs(append([]string{"print"}, args...))
+			Expect(cmd.Execute()).To(Succeed())
+			return out.String()
+		}
+
+		BeforeEach(func() {
+			setCmd := configcmder.NewConfigCmd()
+			setCmd.SetArgs([]string{"set", "proxy.listen", ":9090"})
+			Expect(setCmd.Execute()).To(Succeed())
+			setCmd = configcmder.NewConfigCmd()
+			setCmd.SetArgs([]string{"set", "api.token", "file-secret"})
+			Expect(setCmd.Execute()).To(Succeed())
+
+			GinkgoT().Setenv("TAPES_PROXY_LISTEN", "0.0.0.0:8080")
+		})
+
+		It("prints the config file without environment overrides", func() {
+			out := printConfig()
+			Expect(out).To(ContainSubstring(`listen = ":9090"`))
+			Expect(out).NotTo(ContainSubstring("TAPES_PROXY_LISTEN"))
+		})
+
+		It("prints the resolved config with the overriding variables", func() {
+			out := printConfig("--resolved")
+			Expect(out).To(ContainSubstring("# proxy.listen set by TAPES_PROXY_LISTEN"))
+			Expect(out).To(ContainSubstring(`listen = "0.0.0.0:8080"`))
+			Expect(out).NotTo(ContainSubstring(":9090"))
+		})
+
+		It("redacts secrets", func() {
+			out := printConfig("--resolved")
+			Expect(out).To(ContainSubstring(`token = "<redacted>"`))
+			Expect(out).NotTo(ContainSubstring("file-secret"))
+		})
+	})
 })
@@ -0,0 +1,81 @@
+package configcmder
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+)
+
+const printLongDesc string = `Print the configuration as TOML.
+
+Prints the settings of the config.toml file stored in the .tapes/ directory,
+with defaults filled in. With --resolved, prints the settings commands run
+with instead: the file overridden by TAPES_* environment variables, with the
+rules of redaction.rules_file added, and lists the overriding variables.
+
+Tokens and other secrets are printed as <redacted>.
+
+Examples:
+  tapes config print
+  TAPES_PROXY_LISTEN=0.0.0.0:8080 tapes config print --resolved`
+
+const printShortDesc string = "Print the configuration as TOML"
+
+func newPrintCmd() *cobra.Command {
+	var resolved bool
+
+	cmd := &cobra.Command{
+		Use:   "print",
+		Short: printShortDesc,
+		Long:  printLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			configDir, _ := cmd.Flags().GetString("config-dir")
+			return runPrint(cmd.OutOrStdout(), configDir, resolved)
+		},
+	}
+
+	cmd.Flags().BoolVar(&resolved, "resolved", false, "Apply TAPES_* environment variables and the redaction rules file")
+
+	return cmd
+}
+
+func runPrint(out io.Writer, configDir string, resolved bool) error {
+	cfger, err := config.NewConfiger(configDir)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	var cfg *config.Config
+	if resolved {
+		cfg, err = cfger.LoadConfig()
+	} else {
+		cfg, err = cfger.LoadFileConfig()
+	}
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	config.MaskSecrets(cfg)
+
+	data, err := config.MarshalConfigTOML(cfg)
+	if err != nil {
+		return err
+	}
+
+	if target := cfger.GetTarget(); target != "" {
+		fmt.Fprintf(out, "# Config file: %s\n", target)
+	} else {
+		fmt.Fprintln(out, "# No config file found, using defaults.")
+	}
+	if resolved {
+		for _, key := range config.EnvOverrides() {
+			fmt.Fprintf(out, "# %s set by %s\n", key, config.EnvName(key))
+		}
+	}
+	fmt.Fprintln(out)
+	_, err = out.Write(data)
+	return err
+}
@@ -2,10 +2,12 @@ package configcmder
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/papercomputeco/tapes/pkg/audit"
 	"github.com/papercomputeco/tapes/pkg/config"
 )
 
@@ -16,12 +18,23 @@ stored in the .tapes/ directory. Keys use dotted notation matching
 the TOML section structure.
 
 Valid keys:
-  storage.sqlite_path,
-  proxy.provider, proxy.upstream, proxy.listen,
-  api.listen,
+  storage.sqlite_path, storage.encryption,
+  proxy.provider, proxy.upstream, proxy.listen, proxy.sign_nodes,
+  proxy.workers, proxy.queue_size, proxy.overflow_policy,
+  proxy.enqueue_timeout, proxy.job_timeout,
+  api.listen, api.token,
   client.proxy_target, client.api_target,
   vector_store.provider, vector_store.target,
-  embedding.provider, embedding.target, embedding.model, embedding.dimensions
+  embedding.provider, embedding.target, embedding.model, embedding.dimensions,
+  telemetry.otlp_endpoint,
+  summarizer.enabled, summarizer.provider, summarizer.model, summarizer.idle_after,
+  titles.provider, titles.model,
+  report.slack_webhook, report.slack_daily_at,
+  logs.level, logs.max_file_size, logs.max_files,
+  health.probe_interval, redaction.enabled, redaction.rules_file,
+  retention.max_age, retention.max_db_size, retention.max_sessions, retention.gc,
+  start.confirm_recording, start.codex_config, start.codex_wire_api,
+  start.run_token_ttl, start.run_token_max_requests, start.run_token_max_input_tokens
 
 Examples:
   tapes config set proxy.provider anthropic
@@ -69,11 +82,33 @@ func runSet(key, value, configDir string) error {
 		fmt.Print("No config file found. Using default config.\n\n")
 	}
 
+	previous, err := cfger.GetConfigValue(key)
+	if err != nil {
+		return err
+	}
+
 	err = cfger.SetConfigValue(key, value)
 	if err != nil {
 		return err
 	}
 
+	details := map[string]string{"old": previous, "new": value}
+	if config.IsSecretConfigKey(key) {
+		details = map[string]string{"old": redactedValue(previous), "new": redactedValue(value)}
+	}
+	if err := audit.Record(configDir, audit.ActionConfigSet, key, details); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write audit log: %v\n", err)
+	}
+
 	fmt.Printf("Set %s = %q\n", key, value)
 	return nil
 }
+
+// redactedValue hides a secret config value in the audit log while still
+// showing whether it was set or cleared.
+func redactedValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "[redacted]"
+}
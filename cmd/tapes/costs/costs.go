@@ -0,0 +1,209 @@
+// Package costscmder provides the costs command for reporting LLM spend
+// captured through the proxy.
+package costscmder
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+const costsLongDesc string = `Report token usage and LLM spend captured through the proxy.
+
+Costs are computed from the token counts recorded for each turn and the model
+pricing managed with tapes pricing, including discounted cache reads and cache
+writes. Each turn is counted once, even when several session branches share it.
+Turns for models without pricing are counted as unpriced requests.
+
+Group the report by model, provider, day, project, user, or the tapes start
+run that made each turn, and print it as a table, CSV, or JSON for
+spreadsheets and scripts.
+
+Examples:
+  tapes costs
+  tapes costs --group-by day --since 30d
+  tapes costs --group-by project --format csv > costs.csv
+  tapes costs --group-by provider --format json
+  tapes costs --pricing ./pricing.json`
+
+const costsShortDesc string = "Report LLM spend"
+
+// Output formats.
+const (
+	formatTable = "table"
+	formatCSV   = "csv"
+	formatJSON  = "json"
+)
+
+type costsCommander struct {
+	sqlitePath  string
+	pricingPath string
+	groupBy     string
+	since       string
+	format      string
+}
+
+func NewCostsCmd() *cobra.Command {
+	cmder := &costsCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "costs",
+		Short: costsShortDesc,
+		Long:  costsLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().StringVar(&cmder.pricingPath, "pricing", "", "Path to pricing TOML or JSON overrides")
+	cmd.Flags().StringVar(&cmder.groupBy, "group-by", deck.CostGroupModel, "Group costs by "+strings.Join(deck.CostGroupings(), "|"))
+	cmd.Flags().StringVar(&cmder.since, "since", "", "Only include turns newer than this age (e.g. 30d, 2w, 12h)")
+	cmd.Flags().StringVar(&cmder.format, "format", formatTable, "Output format: table|csv|json")
+
+	return cmd
+}
+
+func (c *costsCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	if !slices.Contains([]string{formatTable, formatCSV, formatJSON}, c.format) {
+		return fmt.Errorf("unsupported format %q (expected table, csv, or json)", c.format)
+	}
+
+	opts := deck.CostOptions{GroupBy: c.groupBy}
+	if c.since != "" {
+		since, err := utils.ParseDuration(c.since)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		opts.Since = since
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	pricing, err := deck.ResolvePricing(configDir, c.pricingPath)
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	query, closeFn, err := deck.NewReadOnlyQuery(ctx, dbPath, pricing, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = closeFn() }()
+
+	report, err := query.Costs(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	switch c.format {
+	case formatCSV:
+		return writeCSV(w, report)
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	default:
+		return writeTable(w, report)
+	}
+}
+
+func writeTable(w io.Writer, report *deck.CostReport) error {
+	if len(report.Rows) == 0 {
+		fmt.Fprintln(w, "No usage recorded.")
+		return nil
+	}
+
+	// Reasoning is only shown once a reasoning model has been used.
+	showReasoning := report.Total.ReasoningTokens > 0
+	reasoningHeader := ""
+	if showReasoning {
+		reasoningHeader = "REASONING\t"
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "%s\tREQUESTS\tINPUT\tOUTPUT\t%sCACHE READ\tCACHE WRITE\tCACHE SAVED\tCOST\t\n", strings.ToUpper(report.GroupBy), reasoningHeader)
+	for _, row := range append(report.Rows, report.Total) {
+		reasoning := ""
+		if showReasoning {
+			reasoning = cliui.FormatTokens(row.ReasoningTokens) + "\t"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s%s\t%s\t%s\t%s\t\n",
+			row.Key,
+			row.Requests,
+			cliui.FormatTokens(row.InputTokens),
+			cliui.FormatTokens(row.OutputTokens),
+			reasoning,
+			cliui.FormatTokens(row.CacheReadTokens),
+			cliui.FormatTokens(row.CacheWriteTokens),
+			cliui.FormatCost(row.CacheSavings),
+			cliui.FormatCost(row.TotalCost),
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if report.Total.UnpricedRequests > 0 {
+		fmt.Fprintf(w, "\n%d requests used models without pricing and are not included in costs. Use tapes pricing set to add them.\n",
+			report.Total.UnpricedRequests)
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, report *deck.CostReport) error {
+	cw := csv.NewWriter(w)
+	header := []string{
+		report.GroupBy, "requests", "unpriced_requests",
+		"input_tokens", "output_tokens", "reasoning_tokens", "cache_read_tokens", "cache_write_tokens",
+		"input_cost", "output_cost", "reasoning_cost", "cache_savings", "total_cost",
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, row := range report.Rows {
+		record := []string{
+			row.Key,
+			strconv.Itoa(row.Requests),
+			strconv.Itoa(row.UnpricedRequests),
+			strconv.FormatInt(row.InputTokens, 10),
+			strconv.FormatInt(row.OutputTokens, 10),
+			strconv.FormatInt(row.ReasoningTokens, 10),
+			strconv.FormatInt(row.CacheReadTokens, 10),
+			strconv.FormatInt(row.CacheWriteTokens, 10),
+			strconv.FormatFloat(row.InputCost, 'f', 6, 64),
+			strconv.FormatFloat(row.OutputCost, 'f', 6, 64),
+			strconv.FormatFloat(row.ReasoningCost, 'f', 6, 64),
+			strconv.FormatFloat(row.CacheSavings, 'f', 6, 64),
+			strconv.FormatFloat(row.TotalCost, 'f', 6, 64),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,13 @@
+package costscmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCosts(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Costs Command Suite")
+}
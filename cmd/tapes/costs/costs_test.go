@@ -0,0 +1,88 @@
+package costscmder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("costs command", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		for _, model := range []string{"gpt-4o", "unpriced-model"} {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:     "message",
+				Role:     "assistant",
+				Content:  []llm.ContentBlock{{Type: "text", Text: "hello from " + model}},
+				Model:    model,
+				Provider: "openai",
+			}, nil, merkle.NodeMeta{Usage: &llm.Usage{PromptTokens: 1_000_000, CompletionTokens: 1_000}})
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(driver.Close()).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewCostsCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append([]string{"--sqlite", dbPath}, args...))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	It("prints a table with totals and an unpriced note", func() {
+		out, err := run("--since", "30d")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("MODEL"))
+		Expect(out).To(MatchRegexp(`gpt-4o\s+1\s+1\.0M\s+1\.0K\s+0\s+0\s+\$0\.00\s+\$2\.51`))
+		Expect(out).To(MatchRegexp(`total\s+2\s+2\.0M`))
+		Expect(out).To(ContainSubstring("1 requests used models without pricing"))
+	})
+
+	It("writes CSV", func() {
+		out, err := run("--group-by", "provider", "--format", "csv")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(HavePrefix("provider,requests,unpriced_requests,"))
+		Expect(out).To(ContainSubstring("\nopenai,2,1,2000000,2000,0,0,0,2.500000,0.010000,0.000000,0.000000,2.510000\n"))
+	})
+
+	It("writes JSON", func() {
+		out, err := run("--format", "json")
+		Expect(err).NotTo(HaveOccurred())
+
+		var report deck.CostReport
+		Expect(json.Unmarshal([]byte(out), &report)).To(Succeed())
+		Expect(report.GroupBy).To(Equal("model"))
+		Expect(report.Rows).To(HaveLen(2))
+		Expect(report.Total.TotalCost).To(BeNumerically("~", 2.51, 1e-9))
+	})
+
+	It("rejects unknown formats and groupings", func() {
+		_, err := run("--format", "xml")
+		Expect(err).To(MatchError(ContainSubstring("unsupported format")))
+
+		_, err = run("--group-by", "team")
+		Expect(err).To(MatchError(ContainSubstring("unsupported cost grouping")))
+	})
+})
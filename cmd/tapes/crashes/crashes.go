@@ -0,0 +1,148 @@
+// Package crashescmder provides the `tapes crashes` CLI commands for
+// inspecting the reports of panics tapes recovered from.
+package crashescmder
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/crash"
+	"github.com/papercomputeco/tapes/pkg/start"
+)
+
+const crashesLongDesc string = `Inspect crash reports.
+
+When a turn's payload trips a bug, tapes recovers: the storage worker moves
+on to the next turn, and the proxy or API answers the request with an error.
+Each recovery writes a report to .tapes/crashes with the panic, its stack,
+and what was being processed, such as the provider, model, and agent of a
+turn. Reports never hold the turn's content, so they are safe to attach to a
+bug report. The newest 100 are kept.
+
+Examples:
+  tapes crashes list
+  tapes crashes show 20260102-030405.123456-4f2a1c`
+
+const crashesShortDesc string = "Inspect reports of crashes tapes recovered from"
+
+// NewCrashesCmd creates the parent crashes command.
+func NewCrashesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "crashes",
+		Short: crashesShortDesc,
+		Long:  crashesLongDesc,
+	}
+
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newShowCmd())
+
+	return cmd
+}
+
+func newListCmd() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List crash reports, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			w := cmd.OutOrStdout()
+
+			reports, err := listReports(cmd)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				enc := json.NewEncoder(w)
+				for _, report := range reports {
+					if err := enc.Encode(report); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
+			if len(reports) == 0 {
+				fmt.Fprintln(w, "No crashes.")
+				return nil
+			}
+
+			fmt.Fprintf(w, "\nCrashes (%d)\n\n", len(reports))
+			for _, report := range reports {
+				fmt.Fprintf(w, "  %s  %s  %s\n",
+					cliui.NameStyle.Render(report.ID),
+					report.Component,
+					cliui.DimStyle.Render(report.Time.Local().Format("2006-01-02 15:04:05")),
+				)
+				fmt.Fprintf(w, "    %s\n", report.Panic)
+				if meta := formatMetadata(report.Metadata); meta != "" {
+					fmt.Fprintf(w, "    %s\n", cliui.DimStyle.Render(meta))
+				}
+				fmt.Fprintln(w)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print reports, with their stacks, as JSON lines")
+
+	return cmd
+}
+
+func newShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a crash report with its stack",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reports, err := listReports(cmd)
+			if err != nil {
+				return err
+			}
+			idx := slices.IndexFunc(reports, func(r crash.Report) bool { return r.ID == args[0] })
+			if idx < 0 {
+				return fmt.Errorf("no crash report %q: see tapes crashes list", args[0])
+			}
+			report := reports[idx]
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintf(w, "ID:        %s\n", report.ID)
+			fmt.Fprintf(w, "Time:      %s\n", report.Time.Local().Format("2006-01-02 15:04:05"))
+			fmt.Fprintf(w, "Version:   %s\n", report.Version)
+			fmt.Fprintf(w, "Component: %s\n", report.Component)
+			if meta := formatMetadata(report.Metadata); meta != "" {
+				fmt.Fprintf(w, "Metadata:  %s\n", meta)
+			}
+			fmt.Fprintf(w, "\npanic: %s\n\n%s", report.Panic, report.Stack)
+			return nil
+		},
+	}
+}
+
+// listReports reads the reports of the .tapes dir selected by the
+// --config-dir flag.
+func listReports(cmd *cobra.Command) ([]crash.Report, error) {
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	manager, err := start.NewManager(configDir)
+	if err != nil {
+		return nil, err
+	}
+	return crash.List(manager.CrashDir)
+}
+
+// formatMetadata formats metadata as key=value pairs in key order.
+func formatMetadata(meta map[string]string) string {
+	pairs := make([]string, 0, len(meta))
+	for _, key := range slices.Sorted(maps.Keys(meta)) {
+		pairs = append(pairs, key+"="+meta[key])
+	}
+	return strings.Join(pairs, " ")
+}
@@ -0,0 +1,13 @@
+package crashescmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCrashesCommander(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Crashes Commander Suite")
+}
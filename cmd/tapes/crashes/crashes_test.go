@@ -0,0 +1,71 @@
+package crashescmder
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/crash"
+	"github.com/papercomputeco/tapes/pkg/start"
+)
+
+var _ = Describe("crashes command", func() {
+	var configDir string
+
+	BeforeEach(func() {
+		configDir = GinkgoT().TempDir()
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewCrashesCmd()
+		cmd.PersistentFlags().String("config-dir", "", "Override path to .tapes/ config directory")
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		cmd.SetArgs(append(args, "--config-dir", configDir))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	record := func() crash.Report {
+		manager, err := start.NewManager(configDir)
+		Expect(err).NotTo(HaveOccurred())
+		report, err := crash.NewRecorder(manager.CrashDir).Record("worker", "index out of range", map[string]string{
+			"provider": "anthropic",
+			"model":    "claude-sonnet-4-5",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return report
+	}
+
+	It("lists crash reports", func() {
+		report := record()
+
+		out, err := run("list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Crashes (1)"))
+		Expect(out).To(ContainSubstring(report.ID))
+		Expect(out).To(ContainSubstring("index out of range"))
+		Expect(out).To(ContainSubstring("model=claude-sonnet-4-5 provider=anthropic"))
+	})
+
+	It("says when there are no crashes", func() {
+		out, err := run("list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("No crashes.\n"))
+	})
+
+	It("shows a report with its stack", func() {
+		report := record()
+
+		out, err := run("show", report.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Component: worker"))
+		Expect(out).To(ContainSubstring("panic: index out of range"))
+		Expect(out).To(ContainSubstring("goroutine"))
+
+		_, err = run("show", "missing")
+		Expect(err).To(MatchError(ContainSubstring(`no crash report "missing"`)))
+	})
+})
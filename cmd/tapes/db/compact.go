@@ -0,0 +1,91 @@
+package dbcmder
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+const compactLongDesc string = `Compact the SQLite database.
+
+Runs VACUUM to return free pages to the filesystem, rebuilds the indexes used
+by deck queries, and runs ANALYZE to refresh query planner statistics. The
+database file size before and after is reported.
+
+The daemon started by "tapes start" can also compact on a schedule:
+
+  tapes config set storage.compact_interval 24h`
+
+func newCompactCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "compact",
+		Short: "Vacuum, reindex, and analyze the database",
+		Long:  compactLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runCompact(cmd.Context(), cmd)
+		},
+	}
+}
+
+func runCompact(ctx context.Context, cmd *cobra.Command) error {
+	w := cmd.OutOrStdout()
+
+	override, err := cmd.Flags().GetString("sqlite")
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(override)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	driver, err := sqlite.NewDriver(ctx, dbPath, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer driver.Close()
+
+	var result *sqlite.CompactResult
+	err = cliui.Step(w, "Compacting "+dbPath, func() error {
+		var err error
+		result, err = driver.Compact(ctx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.Blocks > 0 {
+		fmt.Fprintf(w, "  Removed %d unreferenced content blocks.\n", result.Blocks)
+	}
+	printSizes(w, result)
+	return nil
+}
+
+// printSizes reports the database file size before and after compaction.
+func printSizes(w io.Writer, result *sqlite.CompactResult) {
+	fmt.Fprintln(w)
+	for _, row := range []struct {
+		label string
+		size  int64
+	}{
+		{"Before", result.SizeBefore},
+		{"After", result.SizeAfter},
+		{"Reclaimed", result.Reclaimed()},
+	} {
+		fmt.Fprintf(w, "  %s %s\n", cliui.DimStyle.Render(fmt.Sprintf("%-10s", row.label+":")), utils.FormatSize(row.size))
+	}
+}
@@ -0,0 +1,36 @@
+package dbcmder
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("db compact command", func() {
+	It("compacts the database and reports sizes", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Close()).To(Succeed())
+
+		cmd := NewDBCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs([]string{"compact", "--sqlite", dbPath})
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+		out := buf.String()
+		Expect(out).To(ContainSubstring("Compacting " + dbPath))
+		Expect(out).To(ContainSubstring("Before:"))
+		Expect(out).To(ContainSubstring("After:"))
+		Expect(out).To(ContainSubstring("Reclaimed:"))
+	})
+})
@@ -0,0 +1,48 @@
+// Package dbcmder provides the `tapes db` CLI commands for maintaining the
+// local SQLite database.
+package dbcmder
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const dbLongDesc string = `Maintain the local SQLite database.
+
+Long-running capture databases accumulate free pages and stale query planner
+statistics, which slows down the deck overview. Compacting the database
+reclaims the space and restores query performance. Collecting garbage deletes
+turns orphaned by crashes or partial imports. Verifying the database checks
+that stored sessions have not been altered. Embedding the database
+indexes existing turns for semantic search. Migrating upgrades the schema
+after installing a new version of tapes.
+
+Examples:
+  tapes db compact
+  tapes db compact --sqlite ./tapes.db
+  tapes db dedup
+  tapes db embed
+  tapes db gc
+  tapes db migrate
+  tapes db verify`
+
+const dbShortDesc string = "Maintain the SQLite database"
+
+// NewDBCmd creates the parent db command.
+func NewDBCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: dbShortDesc,
+		Long:  dbLongDesc,
+	}
+
+	cmd.PersistentFlags().StringP("sqlite", "s", "", "Path to SQLite database")
+
+	cmd.AddCommand(newCompactCmd())
+	cmd.AddCommand(newDedupCmd())
+	cmd.AddCommand(newEmbedCmd())
+	cmd.AddCommand(newGCCmd())
+	cmd.AddCommand(newMigrateCmd())
+	cmd.AddCommand(newVerifyCmd())
+
+	return cmd
+}
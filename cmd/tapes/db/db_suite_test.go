@@ -0,0 +1,13 @@
+package dbcmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDB(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "DB Command Suite")
+}
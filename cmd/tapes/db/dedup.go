@@ -0,0 +1,80 @@
+package dbcmder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	entdriver "github.com/papercomputeco/tapes/pkg/storage/ent/driver"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+const dedupLongDesc string = `Deduplicate content blocks stored before deduplication was enabled.
+
+New turns store large content blocks, such as system prompts and file
+contents, once by content hash and reference them from each turn. This command
+migrates turns written by older versions to the same layout and then compacts
+the database. It is safe to run more than once.`
+
+func newDedupCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "dedup",
+		Short: "Migrate existing turns to deduplicated content blocks",
+		Long:  dedupLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runDedup(cmd.Context(), cmd)
+		},
+	}
+}
+
+func runDedup(ctx context.Context, cmd *cobra.Command) error {
+	w := cmd.OutOrStdout()
+
+	override, err := cmd.Flags().GetString("sqlite")
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(override)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	driver, err := sqlite.NewDriver(ctx, dbPath, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer driver.Close()
+
+	var dedup *entdriver.DedupResult
+	err = cliui.Step(w, "Deduplicating content blocks", func() error {
+		var err error
+		dedup, err = driver.DeduplicateBlocks(ctx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	var compact *sqlite.CompactResult
+	err = cliui.Step(w, "Compacting "+dbPath, func() error {
+		var err error
+		compact, err = driver.Compact(ctx)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "  Rewrote %d turns, %d distinct content blocks stored.\n", dedup.Nodes, dedup.Blocks)
+	printSizes(w, compact)
+	return nil
+}
@@ -0,0 +1,45 @@
+package dbcmder
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("db dedup command", func() {
+	It("reports migrated turns and sizes", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		n := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "system",
+			Content: []llm.ContentBlock{{Type: "text", Text: strings.Repeat("system prompt ", 200)}},
+		}, nil)
+		_, err = driver.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Close()).To(Succeed())
+
+		cmd := NewDBCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs([]string{"dedup", "--sqlite", dbPath})
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+		out := buf.String()
+		// New turns are deduplicated on write, so nothing needs rewriting.
+		Expect(out).To(ContainSubstring("Rewrote 0 turns, 1 distinct content blocks stored."))
+		Expect(out).To(ContainSubstring("Reclaimed:"))
+	})
+})
@@ -0,0 +1,98 @@
+package dbcmder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	searchcmder "github.com/papercomputeco/tapes/cmd/tapes/search"
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/embeddings"
+	"github.com/papercomputeco/tapes/pkg/logger"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+const embedLongDesc string = `Compute embeddings for turns that do not have one yet.
+
+The proxy embeds new turns as they are captured. This command indexes turns
+captured before an embedding provider was configured, or while it was
+unavailable, so that tapes search --semantic can find them. It uses the
+embedding provider and vector store from the tapes config and is safe to run
+more than once.`
+
+func newEmbedCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "embed",
+		Short: "Compute embeddings for turns for semantic search",
+		Long:  embedLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runEmbed(cmd.Context(), cmd)
+		},
+	}
+}
+
+func runEmbed(ctx context.Context, cmd *cobra.Command) error {
+	w := cmd.OutOrStdout()
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	cfger, err := config.NewConfiger(configDir)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	cfg, err := cfger.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	override, err := cmd.Flags().GetString("sqlite")
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(override)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	driver, err := sqlite.NewDriver(ctx, dbPath, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer driver.Close()
+
+	debug, _ := cmd.Flags().GetBool("debug")
+	zapLogger := logger.NewLogger(debug)
+	defer func() { _ = zapLogger.Sync() }()
+
+	embedder, vectors, err := searchcmder.OpenSemanticIndex(cfg, dbPath, zapLogger)
+	if err != nil {
+		return err
+	}
+	defer embedder.Close()
+	defer vectors.Close()
+
+	var result *embeddings.IndexResult
+	err = cliui.Step(w, fmt.Sprintf("Embedding turns with %s/%s", cfg.Embedding.Provider, cfg.Embedding.Model), func() error {
+		nodes, err := driver.List(ctx)
+		if err != nil {
+			return err
+		}
+		result, err = embeddings.Index(ctx, embedder, vectors, nodes)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "  Embedded %d turns, %d already indexed, %d without text.\n",
+		result.Embedded, result.Existing, result.Empty)
+	return nil
+}
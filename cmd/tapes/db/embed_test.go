@@ -0,0 +1,71 @@
+package dbcmder
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("db embed command", func() {
+	It("embeds turns that are not indexed yet", func() {
+		ctx := context.Background()
+		dir := GinkgoT().TempDir()
+		dbPath := filepath.Join(dir, "tapes.db")
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"data":[{"embedding":[0.1,0.2,0.3]}]}`))
+		}))
+		defer server.Close()
+
+		configDir := filepath.Join(dir, ".tapes")
+		Expect(os.MkdirAll(configDir, 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(`version = 0
+
+[vector_store]
+provider = "sqlite"
+
+[embedding]
+provider = "openai"
+target = "`+server.URL+`"
+model = "text-embedding-3-small"
+dimensions = 3
+`), 0o600)).To(Succeed())
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		prompt := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: "why does auth fail"}},
+		}, nil)
+		_, err = driver.Put(ctx, prompt)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = driver.Put(ctx, merkle.NewNode(merkle.Bucket{Type: "message", Role: "assistant"}, prompt))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Close()).To(Succeed())
+
+		run := func() string {
+			cmd := NewDBCmd()
+			cmd.PersistentFlags().String("config-dir", "", "")
+			buf := &bytes.Buffer{}
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+			cmd.SetArgs([]string{"embed", "--sqlite", dbPath, "--config-dir", configDir})
+			Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+			return buf.String()
+		}
+
+		Expect(run()).To(ContainSubstring("Embedded 1 turns, 0 already indexed, 1 without text."))
+		Expect(run()).To(ContainSubstring("Embedded 0 turns, 1 already indexed, 1 without text."))
+	})
+})
@@ -0,0 +1,158 @@
+package dbcmder
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/audit"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/retention"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+const gcLongDesc string = `Delete orphaned turns from the SQLite database.
+
+A turn is orphaned when its ancestry no longer reaches a root turn: its parent
+was never stored, it descends from such a turn, or its parent links loop back
+on themselves. Crashes and partial imports can leave these behind. No session
+includes them, so they are invisible in the deck and not pruned with sessions.
+
+The orphaned turns are listed, and after confirmation they are deleted and the
+database is vacuumed. "tapes prune --gc" collects them too, and so does the
+daemon's background pruner when retention.gc is set.
+
+Examples:
+  tapes db gc --dry-run
+  tapes db gc
+  tapes db gc --yes`
+
+type gcCommander struct {
+	dryRun bool
+	yes    bool
+}
+
+func newGCCmd() *cobra.Command {
+	cmder := &gcCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Delete turns unreachable from any session",
+		Long:  gcLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().BoolVar(&cmder.dryRun, "dry-run", false, "List orphaned turns without deleting anything")
+	cmd.Flags().BoolVarP(&cmder.yes, "yes", "y", false, "Delete without asking for confirmation")
+	cmd.MarkFlagsMutuallyExclusive("dry-run", "yes")
+
+	return cmd
+}
+
+func (c *gcCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	w := cmd.OutOrStdout()
+
+	override, err := cmd.Flags().GetString("sqlite")
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(override)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	driver, err := sqlite.NewDriver(ctx, dbPath, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer driver.Close()
+
+	pruner := retention.NewPruner(driver, retention.Policy{})
+	plan, err := pruner.PlanGC(ctx)
+	if err != nil {
+		return err
+	}
+
+	if plan.Empty() {
+		fmt.Fprintf(w, "%s No orphaned turns.\n", cliui.SuccessMark)
+		return nil
+	}
+
+	fmt.Fprintf(w, "\nOrphaned turns (%d)\n\n", len(plan.Orphans))
+	printOrphans(w, plan.Orphans)
+
+	if c.dryRun {
+		fmt.Fprintf(w, "\nDry run: would delete %d orphaned turns.\n", len(plan.Orphans))
+		return nil
+	}
+
+	if !c.yes {
+		ok, err := confirmGC(cmd.InOrStdin(), w, len(plan.Orphans))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(w, "Nothing deleted.")
+			return nil
+		}
+	}
+
+	result, err := pruner.Apply(ctx, plan)
+	if err != nil {
+		return err
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	details := result.AuditDetails()
+	details["source"] = "cli"
+	if err := audit.Record(configDir, audit.ActionGC, dbPath, details); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write audit log: %v\n", err)
+	}
+
+	fmt.Fprintf(w, "\n%s Deleted %d orphaned turns (%d facets), reclaimed %s.\n",
+		cliui.SuccessMark, result.Orphans, result.Facets, utils.FormatSize(result.ReclaimedBytes))
+	return nil
+}
+
+// confirmGC asks whether to delete the orphaned turns, returning true only
+// for an explicit yes.
+func confirmGC(stdin io.Reader, stdout io.Writer, n int) (bool, error) {
+	fmt.Fprintf(stdout, "\nDelete %d orphaned turns? [y/N]: ", n)
+
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("reading confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func printOrphans(w io.Writer, orphans []retention.Orphan) {
+	for _, o := range orphans {
+		fmt.Fprintf(w, "  %s  %s  %s\n",
+			cliui.HashStyle.Render(o.Hash[:min(12, len(o.Hash))]),
+			cliui.DimStyle.Render(o.CreatedAt.Local().Format("2006-01-02 15:04:05")),
+			cliui.RoleStyle.Render(o.Reason),
+		)
+	}
+}
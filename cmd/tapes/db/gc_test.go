@@ -0,0 +1,103 @@
+package dbcmder
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("db gc command", func() {
+	var (
+		ctx       context.Context
+		dbPath    string
+		configDir string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir := GinkgoT().TempDir()
+		dbPath = filepath.Join(dir, "tapes.db")
+		configDir = filepath.Join(dir, ".tapes")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = driver.Client.Node.Create().SetID("root").Save(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Close()).To(Succeed())
+
+		// The driver enforces foreign keys, so store the orphan directly.
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+		_, err = db.ExecContext(ctx,
+			"INSERT INTO nodes (hash, parent_hash, created_at) VALUES (?, ?, ?)",
+			"orphan-hash", "missing-parent", time.Now())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	run := func(stdin string, args ...string) (string, error) {
+		cmd := NewDBCmd()
+		cmd.PersistentFlags().String("config-dir", "", "")
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetIn(strings.NewReader(stdin))
+		cmd.SetArgs(append([]string{"gc", "--sqlite", dbPath, "--config-dir", configDir}, args...))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	nodeIDs := func() []string {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+		ids, err := driver.Client.Node.Query().IDs(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		return ids
+	}
+
+	It("lists orphans without deleting on a dry run", func() {
+		out, err := run("", "--dry-run")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Orphaned turns (1)"))
+		Expect(out).To(ContainSubstring("orphan-hash"))
+		Expect(out).To(ContainSubstring("missing_parent"))
+		Expect(out).To(ContainSubstring("Dry run: would delete 1 orphaned turns."))
+		Expect(nodeIDs()).To(ConsistOf("root", "orphan-hash"))
+	})
+
+	It("keeps orphans when the prompt is declined", func() {
+		out, err := run("n\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Delete 1 orphaned turns? [y/N]: "))
+		Expect(out).To(ContainSubstring("Nothing deleted."))
+		Expect(nodeIDs()).To(ConsistOf("root", "orphan-hash"))
+	})
+
+	It("deletes orphans after confirmation", func() {
+		out, err := run("y\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Deleted 1 orphaned turns"))
+		Expect(nodeIDs()).To(ConsistOf("root"))
+		Expect(filepath.Join(configDir, "audit.log")).To(BeAnExistingFile())
+	})
+
+	It("deletes without prompting with --yes", func() {
+		out, err := run("", "--yes")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).NotTo(ContainSubstring("[y/N]"))
+		Expect(nodeIDs()).To(ConsistOf("root"))
+
+		out, err = run("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("No orphaned turns."))
+	})
+})
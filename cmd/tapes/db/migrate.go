@@ -0,0 +1,93 @@
+package dbcmder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+const migrateLongDesc string = `Upgrade the SQLite database schema and check the config file.
+
+The database schema is upgraded to the one this version of tapes writes, and
+the config file is checked to be readable by it. tapes upgrades the schema
+whenever it opens the database, so this is only needed to migrate eagerly,
+as 'tapes update' does after installing a new release.
+
+The session summaries that 'tapes deck' and the API read are then rebuilt
+from every stored turn, so that turns stored by older versions of tapes are
+counted and costs follow the current pricing.
+
+Examples:
+  tapes db migrate
+  tapes db migrate --sqlite ./tapes.db`
+
+func newMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade the database schema and check the config",
+		Long:  migrateLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runMigrate(cmd.Context(), cmd)
+		},
+	}
+
+	return cmd
+}
+
+func runMigrate(ctx context.Context, cmd *cobra.Command) error {
+	w := cmd.OutOrStdout()
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	cfger, err := config.NewConfiger(configDir)
+	if err != nil {
+		return err
+	}
+	if _, err := cfger.LoadConfig(); err != nil {
+		return fmt.Errorf("checking config: %w", err)
+	}
+	fmt.Fprintf(w, "%s Config is up to date (version %d).\n", cliui.SuccessMark, config.CurrentV)
+
+	override, err := cmd.Flags().GetString("sqlite")
+	if err != nil {
+		return err
+	}
+	dbPath, err := sqlitepath.ResolveSQLitePath(override)
+	if err != nil {
+		fmt.Fprintln(w, cliui.DimStyle.Render("No database found; nothing to migrate."))
+		return nil //nolint:nilerr // a missing database has no schema to migrate
+	}
+
+	pricing, err := deck.ResolvePricing(configDir, "")
+	if err != nil {
+		return err
+	}
+
+	// Opening the database runs the schema migration.
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	driver, err := sqlite.NewDriver(ctx, dbPath, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("migrating database: %w", err)
+	}
+	defer driver.Close()
+
+	fmt.Fprintf(w, "%s Database schema is up to date: %s\n", cliui.SuccessMark, dbPath)
+
+	driver.SetSessionCost(pricing.TurnCost)
+	sessions, err := driver.RebuildSessions(ctx)
+	if err != nil {
+		return fmt.Errorf("rebuilding sessions: %w", err)
+	}
+	fmt.Fprintf(w, "%s Rebuilt %d session summaries.\n", cliui.SuccessMark, sessions)
+	return nil
+}
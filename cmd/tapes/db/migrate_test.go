@@ -0,0 +1,42 @@
+package dbcmder
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("db migrate command", func() {
+	It("upgrades the database schema", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		root := merkle.NewNode(merkle.Bucket{Type: "message", Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "hello"}}}, nil)
+		_, err = driver.Put(ctx, root)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = driver.Client.Session.Delete().Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Close()).To(Succeed())
+
+		cmd := NewDBCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs([]string{"migrate", "--sqlite", dbPath})
+		Expect(cmd.ExecuteContext(ctx)).To(Succeed())
+
+		out := buf.String()
+		Expect(out).To(ContainSubstring("Config is up to date"))
+		Expect(out).To(ContainSubstring("Database schema is up to date: " + dbPath))
+		Expect(out).To(ContainSubstring("Rebuilt 1 session summaries."))
+	})
+})
@@ -0,0 +1,157 @@
+package dbcmder
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/integrity"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+const verifyLongDesc string = `Verify the Merkle integrity of the SQLite database.
+
+Every stored turn is rehashed from its content and parent link and compared
+with its hash, parent links are resolved, and each turn's ancestry is walked
+back to a root. Turns descending from a failing turn are reported as tainted,
+since the session they belong to can no longer be trusted.
+
+With --signed, every intact turn must also carry a valid signature of its
+hash, as stored by installations with proxy.sign_nodes enabled, and the keys
+that signed the turns are listed by fingerprint. Compare them with the
+fingerprints "tapes share" prints to see which machine produced each session.
+
+With --quarantine, failing and tainted turns are written to the given file as
+JSON lines and removed from the database. The command exits with an error when
+problems are found and not quarantined.
+
+Examples:
+  tapes db verify
+  tapes db verify --signed
+  tapes db verify --quarantine quarantine.jsonl`
+
+type verifyCommander struct {
+	quarantine string
+	signed     bool
+}
+
+func newVerifyCmd() *cobra.Command {
+	cmder := &verifyCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Check stored turns for tampering and broken ancestry",
+		Long:  verifyLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().StringVar(&cmder.quarantine, "quarantine", "", "Move failing turns out of the database into this JSON lines file")
+	cmd.Flags().BoolVar(&cmder.signed, "signed", false, "Also require a valid signature on every turn and list the signing keys")
+	// Signature failures do not taint descendants, so quarantining them
+	// could leave surviving turns without their parents.
+	cmd.MarkFlagsMutuallyExclusive("quarantine", "signed")
+
+	return cmd
+}
+
+func (c *verifyCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	w := cmd.OutOrStdout()
+
+	override, err := cmd.Flags().GetString("sqlite")
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(override)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	driver, err := sqlite.NewDriver(ctx, dbPath, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer driver.Close()
+
+	verify := integrity.Verify
+	if c.signed {
+		verify = integrity.VerifySigned
+	}
+	report, err := verify(ctx, driver)
+	if err != nil {
+		return err
+	}
+
+	if report.OK() {
+		fmt.Fprintf(w, "%s Verified %d turns across %d roots.\n", cliui.SuccessMark, report.Nodes, report.Roots)
+		printSigners(w, report)
+		return nil
+	}
+
+	printIssues(w, report)
+	printSigners(w, report)
+
+	if c.quarantine == "" {
+		return fmt.Errorf("integrity check failed: %d of %d turns", len(report.Issues), report.Nodes)
+	}
+
+	f, err := os.OpenFile(c.quarantine, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening quarantine file: %w", err)
+	}
+	defer f.Close()
+
+	n, err := integrity.Quarantine(ctx, driver.Client, report, f)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\n%s Quarantined %d turns to %s\n", cliui.SuccessMark, n, c.quarantine)
+	return nil
+}
+
+// printSigners lists the keys that signed turns, most turns first.
+func printSigners(w io.Writer, report *integrity.Report) {
+	if len(report.Signers) == 0 {
+		return
+	}
+
+	signers := slices.Collect(maps.Keys(report.Signers))
+	slices.SortFunc(signers, func(a, b string) int {
+		if n := cmp.Compare(report.Signers[b], report.Signers[a]); n != 0 {
+			return n
+		}
+		return cmp.Compare(a, b)
+	})
+
+	fmt.Fprintf(w, "\nSigned by:\n")
+	for _, signer := range signers {
+		fmt.Fprintf(w, "  %s  %s\n", cliui.HashStyle.Render(signer), cliui.DimStyle.Render(fmt.Sprintf("%d turns", report.Signers[signer])))
+	}
+}
+
+func printIssues(w io.Writer, report *integrity.Report) {
+	fmt.Fprintf(w, "\n%s %d of %d turns failed verification\n\n", cliui.FailMark, len(report.Issues), report.Nodes)
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "  %s  %s  %s\n",
+			cliui.HashStyle.Render(issue.Hash[:min(12, len(issue.Hash))]),
+			cliui.RoleStyle.Render(string(issue.Kind)),
+			cliui.DimStyle.Render(issue.Detail),
+		)
+	}
+}
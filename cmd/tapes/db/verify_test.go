@@ -0,0 +1,117 @@
+package dbcmder
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"database/sql"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/share"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("db verify command", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+		leaf   *merkle.Node
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		root := merkle.NewNode(merkle.Bucket{Type: "message", Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "hi"}}}, nil)
+		leaf = merkle.NewNode(merkle.Bucket{Type: "message", Role: "assistant", Content: []llm.ContentBlock{{Type: "text", Text: "hello"}}}, root)
+		for _, n := range []*merkle.Node{root, leaf} {
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewDBCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append([]string{"verify", "--sqlite", dbPath}, args...))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	tamper := func() {
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+		_, err = db.ExecContext(ctx, "UPDATE nodes SET bucket = json_set(bucket, '$.role', 'system') WHERE hash = ?", leaf.Hash)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("reports a clean database", func() {
+		out, err := run()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Verified 2 turns across 1 roots"))
+	})
+
+	It("fails when turns have been altered", func() {
+		tamper()
+
+		out, err := run()
+		Expect(err).To(MatchError(ContainSubstring("integrity check failed: 1 of 2 turns")))
+		Expect(out).To(ContainSubstring("hash_mismatch"))
+		Expect(out).To(ContainSubstring(leaf.Hash[:12]))
+	})
+
+	It("quarantines altered turns", func() {
+		tamper()
+		quarantine := filepath.Join(GinkgoT().TempDir(), "quarantine.jsonl")
+
+		out, err := run("--quarantine", quarantine)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Quarantined 1 turns"))
+
+		data, err := os.ReadFile(quarantine)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(leaf.Hash))
+
+		out, err = run()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Verified 1 turns"))
+	})
+
+	It("requires signatures and lists signers with --signed", func() {
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		signed := merkle.NewNode(merkle.Bucket{Type: "message", Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "signed"}}}, nil)
+		signed.Sign(key)
+		_, err = driver.Put(ctx, signed)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Close()).To(Succeed())
+
+		out, err := run("--signed")
+		Expect(err).To(MatchError(ContainSubstring("integrity check failed: 2 of 3 turns")))
+		Expect(out).To(ContainSubstring("unsigned"))
+		Expect(out).To(ContainSubstring(share.Fingerprint(pub)))
+		Expect(out).To(ContainSubstring("1 turns"))
+	})
+
+	It("rejects --signed with --quarantine", func() {
+		_, err := run("--signed", "--quarantine", filepath.Join(GinkgoT().TempDir(), "q.jsonl"))
+		Expect(err).To(HaveOccurred())
+	})
+})
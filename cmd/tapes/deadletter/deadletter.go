@@ -0,0 +1,69 @@
+// Package deadlettercmder provides the `tapes deadletter` CLI commands for
+// inspecting and reprocessing proxied turns that failed to parse.
+package deadlettercmder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+const deadLetterLongDesc string = `Inspect and reprocess proxied turns that could not be parsed.
+
+When a provider fails to parse a request or response, the proxy keeps the raw
+payloads, the request headers (without credentials), and the parse error in a
+dead-letter table instead of dropping the turn. After a parser fix, retry the
+dead letters to store them as regular conversation turns.
+
+Examples:
+  tapes deadletter list
+  tapes deadletter retry 3 4
+  tapes deadletter retry --all
+  tapes deadletter export -o deadletters.jsonl`
+
+const deadLetterShortDesc string = "Inspect and reprocess unparseable turns"
+
+// NewDeadLetterCmd creates the parent deadletter command.
+func NewDeadLetterCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deadletter",
+		Short: deadLetterShortDesc,
+		Long:  deadLetterLongDesc,
+	}
+
+	cmd.PersistentFlags().StringP("sqlite", "s", "", "Path to SQLite database")
+
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newRetryCmd())
+	cmd.AddCommand(newExportCmd())
+
+	return cmd
+}
+
+// openDriver opens the SQLite database selected by the --sqlite flag or the
+// default resolution order.
+func openDriver(ctx context.Context, cmd *cobra.Command) (*sqlite.Driver, error) {
+	override, err := cmd.Flags().GetString("sqlite")
+	if err != nil {
+		return nil, err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(override)
+	if err != nil {
+		return nil, err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := sqlite.NewDriver(ctx, dbPath, driverOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+	return driver, nil
+}
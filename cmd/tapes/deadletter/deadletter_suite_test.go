@@ -0,0 +1,13 @@
+package deadlettercmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDeadLetterCommander(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Dead Letter Commander Suite")
+}
@@ -0,0 +1,116 @@
+package deadlettercmder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+const (
+	ollamaRequest  = `{"model":"llama3","messages":[{"role":"user","content":"hello"}],"stream":false}`
+	ollamaResponse = `{"model":"llama3","message":{"role":"assistant","content":"hi there"},"done":true}`
+)
+
+var _ = Describe("deadletter command", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		// A dead letter that parses with the current parser (as if fixed).
+		Expect(driver.AddDeadLetter(ctx, &storage.DeadLetter{
+			Stage:    storage.DeadLetterStageResponse,
+			Provider: "ollama",
+			Path:     "/api/chat",
+			Request:  ollamaRequest,
+			Response: ollamaResponse,
+			Error:    "unexpected field",
+		})).To(Succeed())
+
+		// A streamed dead letter with no captured response.
+		Expect(driver.AddDeadLetter(ctx, &storage.DeadLetter{
+			Stage:    storage.DeadLetterStageRequest,
+			Provider: "ollama",
+			Path:     "/api/chat",
+			Request:  "{broken",
+			Error:    "invalid character",
+		})).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewDeadLetterCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append(args, "--sqlite", dbPath))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	It("lists dead letters", func() {
+		out, err := run("list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Dead letters (2)"))
+		Expect(out).To(ContainSubstring("unexpected field"))
+		Expect(out).To(ContainSubstring("invalid character"))
+	})
+
+	It("exports dead letters as JSON lines", func() {
+		out, err := run("export")
+		Expect(err).NotTo(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		Expect(lines).To(HaveLen(2))
+
+		var dl storage.DeadLetter
+		Expect(json.Unmarshal([]byte(lines[0]), &dl)).To(Succeed())
+		Expect(dl.Request).To(Equal(ollamaRequest))
+		Expect(dl.Response).To(Equal(ollamaResponse))
+	})
+
+	It("stores dead letters that now parse and keeps the rest", func() {
+		out, err := run("retry", "--all")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Recovered 1 of 2 dead letters"))
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		letters, err := driver.ListDeadLetters(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters).To(HaveLen(1))
+		Expect(letters[0].Stage).To(Equal(storage.DeadLetterStageRequest))
+
+		leaves, err := driver.Leaves(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(HaveLen(1))
+		Expect(leaves[0].Bucket.ExtractText()).To(Equal("hi there"))
+	})
+
+	It("requires IDs or --all", func() {
+		_, err := run("retry")
+		Expect(err).To(MatchError(ContainSubstring("--all")))
+	})
+
+	It("errors on unknown IDs", func() {
+		_, err := run("retry", "99")
+		Expect(err).To(MatchError(storage.DeadLetterNotFoundError{ID: 99}))
+	})
+})
@@ -0,0 +1,67 @@
+package deadlettercmder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+type exportCommander struct {
+	output string
+}
+
+func newExportCmd() *cobra.Command {
+	cmder := &exportCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export dead letters as newline-delimited JSON",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.output, "output", "o", "", "Write to file instead of stdout")
+
+	return cmd
+}
+
+func (c *exportCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	driver, err := openDriver(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer driver.Close()
+
+	letters, err := driver.ListDeadLetters(ctx)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = cmd.OutOrStdout()
+	if c.output != "" {
+		file, err := os.Create(c.output)
+		if err != nil {
+			return fmt.Errorf("creating export file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	enc := json.NewEncoder(w)
+	for _, dl := range letters {
+		if err := enc.Encode(dl); err != nil {
+			return fmt.Errorf("encoding dead letter %d: %w", dl.ID, err)
+		}
+	}
+
+	if c.output != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d dead letters to %s\n", len(letters), c.output)
+	}
+	return nil
+}
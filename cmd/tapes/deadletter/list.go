@@ -0,0 +1,70 @@
+package deadlettercmder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/cliui"
+)
+
+const maxErrorPreview = 80
+
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List dead letters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runList(cmd.Context(), cmd)
+		},
+	}
+}
+
+func runList(ctx context.Context, cmd *cobra.Command) error {
+	w := cmd.OutOrStdout()
+
+	driver, err := openDriver(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer driver.Close()
+
+	letters, err := driver.ListDeadLetters(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(letters) == 0 {
+		fmt.Fprintln(w, "No dead letters.")
+		return nil
+	}
+
+	fmt.Fprintf(w, "\nDead letters (%d)\n\n", len(letters))
+
+	for _, dl := range letters {
+		agent := dl.AgentName
+		if agent == "" {
+			agent = "-"
+		}
+
+		errMsg := strings.ReplaceAll(dl.Error, "\n", " ")
+		if len(errMsg) > maxErrorPreview {
+			errMsg = errMsg[:maxErrorPreview-3] + "..."
+		}
+
+		fmt.Fprintf(w, "  %s  %s  %s  %s  %s  %s\n",
+			cliui.NameStyle.Render(fmt.Sprintf("#%d", dl.ID)),
+			cliui.DimStyle.Render(dl.CreatedAt.Local().Format("2006-01-02 15:04:05")),
+			cliui.TagStyle.Render(dl.Provider),
+			cliui.ScoreStyle.Render(agent),
+			cliui.RoleStyle.Render(string(dl.Stage)),
+			cliui.DimStyle.Render(dl.Path),
+		)
+		fmt.Fprintf(w, "  %s\n\n", cliui.PreviewStyle.Render(errMsg))
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package deadlettercmder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/audit"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/proxy/worker"
+)
+
+var errNoResponse = errors.New("no response payload captured (streamed turn)")
+
+type retryCommander struct {
+	all     bool
+	project string
+}
+
+func newRetryCmd() *cobra.Command {
+	cmder := &retryCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "retry [id...]",
+		Short: "Re-parse dead letters and store them as conversation turns",
+		Long: `Re-parse dead letters with the current provider parsers. Dead letters that
+now parse are stored as conversation turns and removed from the dead-letter
+table; the rest are left in place.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmder.run(cmd.Context(), cmd, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&cmder.all, "all", false, "Retry every dead letter")
+	cmd.Flags().StringVar(&cmder.project, "project", "", "Project name to tag recovered turns")
+
+	return cmd
+}
+
+func (c *retryCommander) run(ctx context.Context, cmd *cobra.Command, args []string) error {
+	if c.all == (len(args) > 0) {
+		return errors.New("pass dead letter IDs or --all")
+	}
+
+	w := cmd.OutOrStdout()
+
+	driver, err := openDriver(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer driver.Close()
+
+	var letters []*storage.DeadLetter
+	if c.all {
+		letters, err = driver.ListDeadLetters(ctx)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, arg := range args {
+			id, err := strconv.Atoi(arg)
+			if err != nil {
+				return fmt.Errorf("invalid dead letter ID %q", arg)
+			}
+			dl, err := driver.GetDeadLetter(ctx, id)
+			if err != nil {
+				return err
+			}
+			letters = append(letters, dl)
+		}
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+
+	recovered := 0
+	for _, dl := range letters {
+		err := c.retry(ctx, driver, dl)
+		fmt.Fprintf(w, "  %s #%d  %s\n", cliui.Mark(err), dl.ID, retryDetail(err))
+		if err != nil {
+			continue
+		}
+
+		if err := driver.DeleteDeadLetter(ctx, dl.ID); err != nil {
+			return err
+		}
+		if err := audit.Record(configDir, audit.ActionDeadLetterDelete, strconv.Itoa(dl.ID), map[string]string{"reason": "recovered"}); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not write audit log: %v\n", err)
+		}
+		recovered++
+	}
+
+	fmt.Fprintf(w, "\nRecovered %d of %d dead letters\n", recovered, len(letters))
+	return nil
+}
+
+// retry parses the dead letter's payloads and stores the resulting turn.
+func (c *retryCommander) retry(ctx context.Context, driver storage.Driver, dl *storage.DeadLetter) error {
+	prov, err := provider.New(dl.Provider)
+	if err != nil {
+		return err
+	}
+
+	req, err := prov.ParseRequest([]byte(dl.Request))
+	if err != nil {
+		return fmt.Errorf("parsing request: %w", err)
+	}
+
+	if dl.Response == "" {
+		return errNoResponse
+	}
+	resp, err := prov.ParseResponse([]byte(dl.Response))
+	if err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+
+	pool, err := worker.NewPool(&worker.Config{
+		Driver:     driver,
+		NumWorkers: 1,
+		Project:    c.project,
+		Logger:     zap.NewNop(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := pool.Submit(worker.Job{
+		Provider:  prov.Name(),
+		AgentName: dl.AgentName,
+		Req:       req,
+		Resp:      resp,
+	}); err != nil {
+		_, _ = pool.Close(ctx)
+		return err
+	}
+
+	if _, err := pool.Close(ctx); err != nil {
+		return err
+	}
+	// A turn stored by an earlier retry counts as a duplicate.
+	if stats := pool.Stats(); stats.Processed+stats.Duplicates != 1 {
+		return errors.New("storing conversation turn failed")
+	}
+	return nil
+}
+
+func retryDetail(err error) string {
+	if err != nil {
+		return cliui.DimStyle.Render(err.Error())
+	}
+	return "stored"
+}
@@ -25,10 +25,12 @@ Examples:
   tapes deck --since 24h
   tapes deck --from 2026-01-30 --to 2026-01-31
   tapes deck --sort cost --model claude-sonnet-4.5
+  tapes deck --tool Bash --min-cost 1.50
+  tapes deck --since 168h --until 24h --stop-reason max_tokens
   tapes deck --session sess_a8f2c1d3
   tapes deck --web
   tapes deck --web --port 9999
-  tapes deck --pricing ./pricing.json
+  tapes deck --pricing ./pricing.toml
   tapes deck --demo
   tapes deck --demo --overwrite
   tapes deck -m
@@ -44,6 +46,7 @@ type deckCommander struct {
 	sqlitePath       string
 	pricingPath      string
 	since            string
+	until            string
 	from             string
 	to               string
 	sort             string
@@ -51,6 +54,12 @@ type deckCommander struct {
 	model            string
 	status           string
 	project          string
+	user             string
+	label            string
+	tool             string
+	stopReason       string
+	minCost          float64
+	maxCost          float64
 	session          string
 	refresh          uint
 	web              bool
@@ -78,8 +87,9 @@ func NewDeckCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
-	cmd.Flags().StringVar(&cmder.pricingPath, "pricing", "", "Path to pricing JSON overrides")
+	cmd.Flags().StringVar(&cmder.pricingPath, "pricing", "", "Path to pricing TOML or JSON overrides")
 	cmd.Flags().StringVar(&cmder.since, "since", "", "Look back duration (e.g. 24h)")
+	cmd.Flags().StringVar(&cmder.until, "until", "", "Only sessions started at least this long ago (e.g. 24h)")
 	cmd.Flags().StringVar(&cmder.from, "from", "", "Start time (YYYY-MM-DD or RFC3339)")
 	cmd.Flags().StringVar(&cmder.to, "to", "", "End time (YYYY-MM-DD or RFC3339)")
 	cmd.Flags().StringVar(&cmder.sort, "sort", "cost", "Sort sessions by cost|time|tokens|duration")
@@ -87,6 +97,12 @@ func NewDeckCmd() *cobra.Command {
 	cmd.Flags().StringVar(&cmder.model, "model", "", "Filter by model")
 	cmd.Flags().StringVar(&cmder.status, "status", "", "Filter by status (completed|failed|abandoned)")
 	cmd.Flags().StringVar(&cmder.project, "project", "", "Filter by project name")
+	cmd.Flags().StringVar(&cmder.user, "user", "", "Filter by the user sessions were captured for")
+	cmd.Flags().StringVar(&cmder.label, "label", "", "Filter by session label text")
+	cmd.Flags().StringVar(&cmder.tool, "tool", "", "Filter by sessions that called a tool whose name contains this text")
+	cmd.Flags().StringVar(&cmder.stopReason, "stop-reason", "", "Filter by sessions with a turn that stopped for this reason (e.g. max_tokens)")
+	cmd.Flags().Float64Var(&cmder.minCost, "min-cost", 0, "Filter by minimum session cost in USD")
+	cmd.Flags().Float64Var(&cmder.maxCost, "max-cost", 0, "Filter by maximum session cost in USD")
 	cmd.Flags().StringVar(&cmder.session, "session", "", "Drill into a specific session ID")
 	cmd.Flags().UintVar(&cmder.refresh, "refresh", 10, "Auto-refresh interval in seconds (0 to disable)")
 	cmd.Flags().BoolVar(&cmder.web, "web", false, "Serve the web dashboard locally")
@@ -119,7 +135,8 @@ func (c *deckCommander) run(ctx context.Context, cmd *cobra.Command) error {
 		}
 	}
 
-	pricing, err := deck.LoadPricing(c.pricingPath)
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	pricing, err := deck.ResolvePricing(configDir, c.pricingPath)
 	if err != nil {
 		return err
 	}
@@ -147,7 +164,19 @@ func (c *deckCommander) run(ctx context.Context, cmd *cobra.Command) error {
 		fmt.Fprintf(cmd.OutOrStdout(), "Seeded %d demo sessions (%d messages) into %s\n", sessionCount, messageCount, sqlitePath)
 	}
 
-	query, closeFn, err := deck.NewQuery(ctx, sqlitePath, pricing)
+	// Insights store facets, so only the web dashboard without them can
+	// open the database read-only alongside a running daemon.
+	insightsCfg, insights := c.insightsConfig()
+	openQuery := deck.NewQuery
+	if c.web && !insights {
+		openQuery = deck.NewReadOnlyQuery
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	query, closeFn, err := openQuery(ctx, sqlitePath, pricing, driverOpts...)
 	if err != nil {
 		return err
 	}
@@ -163,7 +192,9 @@ func (c *deckCommander) run(ctx context.Context, cmd *cobra.Command) error {
 	var facetWorker *deck.FacetWorker
 	var facetAnalyticsFunc func(context.Context) (*deck.FacetAnalytics, error)
 
-	facets, facetWorker, facetAnalyticsFunc = c.buildFacetDeps(cmd, query)
+	if insights {
+		facets, facetWorker, facetAnalyticsFunc = c.buildFacetDeps(cmd, query, insightsCfg)
+	}
 
 	if c.web {
 		return runDeckWeb(ctx, query, filters, c.port, facets)
@@ -177,10 +208,10 @@ func (c *deckCommander) run(ctx context.Context, cmd *cobra.Command) error {
 	return RunDeckTUI(ctx, query, filters, refreshDuration, facetWorker, facetAnalyticsFunc)
 }
 
-// buildFacetDeps auto-detects API credentials and creates facet extraction
-// dependencies. Returns nil values if no credentials are available and
-// --insights was not explicitly set.
-func (c *deckCommander) buildFacetDeps(cmd *cobra.Command, query *deck.Query) (*facetDeps, *deck.FacetWorker, func(context.Context) (*deck.FacetAnalytics, error)) {
+// insightsConfig auto-detects API credentials for facet extraction. It
+// reports false if no credentials are available and --insights was not
+// explicitly set.
+func (c *deckCommander) insightsConfig() (deck.LLMCallerConfig, bool) {
 	credMgr, err := credentials.NewManager("")
 	if err != nil {
 		credMgr = nil
@@ -195,12 +226,12 @@ func (c *deckCommander) buildFacetDeps(cmd *cobra.Command, query *deck.Query) (*
 
 	// If not explicitly enabled, check whether any API key can be resolved.
 	// Skip auto-enable if nothing is available — don't fall back to ollama silently.
-	if !c.insights {
-		if !deck.HasLLMCredentials(cfg) {
-			return nil, nil, nil
-		}
-	}
+	return cfg, c.insights || deck.HasLLMCredentials(cfg)
+}
 
+// buildFacetDeps creates facet extraction dependencies. Returns nil values
+// if the LLM caller cannot be created.
+func (c *deckCommander) buildFacetDeps(cmd *cobra.Command, query *deck.Query, cfg deck.LLMCallerConfig) (*facetDeps, *deck.FacetWorker, func(context.Context) (*deck.FacetAnalytics, error)) {
 	llmCaller, err := deck.NewLLMCaller(cfg)
 	if err != nil {
 		return nil, nil, nil
@@ -242,7 +273,14 @@ func (c *deckCommander) parseFilters() (deck.Filters, error) {
 		Model:   strings.TrimSpace(c.model),
 		Status:  strings.TrimSpace(c.status),
 		Project: strings.TrimSpace(c.project),
+		User:    strings.TrimSpace(c.user),
 		Session: strings.TrimSpace(c.session),
+
+		Label:      strings.TrimSpace(c.label),
+		Tool:       strings.TrimSpace(c.tool),
+		StopReason: strings.TrimSpace(c.stopReason),
+		MinCost:    c.minCost,
+		MaxCost:    c.maxCost,
 	}
 
 	if filters.SortDir == "" {
@@ -257,6 +295,21 @@ func (c *deckCommander) parseFilters() (deck.Filters, error) {
 		filters.Since = duration
 	}
 
+	if c.until != "" {
+		duration, err := time.ParseDuration(c.until)
+		if err != nil {
+			return filters, fmt.Errorf("invalid until duration: %w", err)
+		}
+		filters.Until = duration
+	}
+
+	if c.minCost < 0 || c.maxCost < 0 {
+		return filters, errors.New("cost filters must not be negative")
+	}
+	if c.maxCost > 0 && c.minCost > c.maxCost {
+		return filters, errors.New("min cost must not exceed max cost")
+	}
+
 	if c.from != "" {
 		parsed, err := parseTime(c.from)
 		if err != nil {
@@ -18,6 +18,7 @@ import (
 	"github.com/charmbracelet/x/ansi"
 	"github.com/muesli/termenv"
 
+	"github.com/papercomputeco/tapes/pkg/cliui"
 	"github.com/papercomputeco/tapes/pkg/deck"
 )
 
@@ -1008,7 +1009,7 @@ func (m deckModel) filteredSessions() []deck.SessionSummary {
 	lower := strings.ToLower(term)
 	var result []deck.SessionSummary
 	for _, s := range m.overview.Sessions {
-		if strings.Contains(strings.ToLower(s.Label), lower) {
+		if strings.Contains(strings.ToLower(s.DisplayLabel()), lower) {
 			result = append(result, s)
 		}
 	}
@@ -1187,11 +1188,11 @@ func (m deckModel) viewMetrics(stats deckOverviewStats) string {
 	metrics := []metricData{
 		{
 			label: "TOTAL SPEND",
-			value: formatCost(stats.TotalCost),
+			value: cliui.FormatCost(stats.TotalCost),
 		},
 		{
 			label: "TOKENS USED",
-			value: fmt.Sprintf("%s in / %s out", formatTokens(stats.InputTokens), formatTokens(stats.OutputTokens)),
+			value: fmt.Sprintf("%s in / %s out", cliui.FormatTokens(stats.InputTokens), cliui.FormatTokens(stats.OutputTokens)),
 		},
 		{
 			label: "AGENT TIME",
@@ -1320,8 +1321,8 @@ func (m deckModel) viewMetrics(stats deckOverviewStats) string {
 
 	// Add average row (no blank line before, pull closer)
 	avgValues := []string{
-		formatCost(avgCost) + " avg",
-		fmt.Sprintf("%s / %s avg", formatTokens(avgTokenCount(stats.InputTokens, stats.TotalSessions)), formatTokens(avgTokenCount(stats.OutputTokens, stats.TotalSessions))),
+		cliui.FormatCost(avgCost) + " avg",
+		fmt.Sprintf("%s / %s avg", cliui.FormatTokens(avgTokenCount(stats.InputTokens, stats.TotalSessions)), cliui.FormatTokens(avgTokenCount(stats.OutputTokens, stats.TotalSessions))),
 		formatDuration(avgTime) + " avg",
 		fmt.Sprintf("%d avg", avgTools),
 		fmt.Sprintf("%d/%d complete", stats.Completed, stats.TotalSessions),
@@ -1404,7 +1405,7 @@ func (m deckModel) renderCostByModelChart(stats deckOverviewStats, width int) []
 		modelColor := getModelColor(cost.Model)
 		coloredBar := lipgloss.NewStyle().Foreground(modelColor).Render(bar)
 
-		line := fmt.Sprintf(" %-17s %s %s %d", cost.Model, coloredBar, formatCost(cost.TotalCost), cost.SessionCount)
+		line := fmt.Sprintf(" %-17s %s %s %d", cost.Model, coloredBar, cliui.FormatCost(cost.TotalCost), cost.SessionCount)
 		// Calculate padding to fill the width
 		contentWidth := lipgloss.Width(line)
 		paddingNeeded := width - contentWidth
@@ -1483,7 +1484,7 @@ func (m deckModel) renderStatusPieChart(stats deckOverviewStats, width int) []st
 	// Efficiency metrics - simplified to fit
 	efficiencyLine := fmt.Sprintf(" %s %s/sess  %d tok/m",
 		deckMutedStyle.Render("eff:"),
-		formatCost(efficiency.perSession),
+		cliui.FormatCost(efficiency.perSession),
 		efficiency.tokPerMin)
 
 	lines = append(lines, deckDimStyle.Render("│")+efficiencyLine+strings.Repeat(" ", max(0, width-lipgloss.Width(efficiencyLine)))+deckDimStyle.Render("│"))
@@ -1550,7 +1551,7 @@ func (m deckModel) viewInsights(stats deckOverviewStats) string {
 	costPerSession := safeDivide(stats.TotalCost, float64(total))
 	costPerMin := costPerMinute(stats.TotalCost, stats.TotalDuration)
 	tokensPerMin := tokensPerMinute(stats.InputTokens+stats.OutputTokens, stats.TotalDuration)
-	efficiency := fmt.Sprintf("efficiency: %s/session · %s/min · %s tok/min", formatCost(costPerSession), formatCost(costPerMin), formatTokens(tokensPerMin))
+	efficiency := fmt.Sprintf("efficiency: %s/session · %s/min · %s tok/min", cliui.FormatCost(costPerSession), cliui.FormatCost(costPerMin), cliui.FormatTokens(tokensPerMin))
 
 	avgTools := safeDivide(float64(stats.TotalToolCalls), float64(total))
 	tools := fmt.Sprintf("tools: %d total · %.1f avg/session", stats.TotalToolCalls, avgTools)
@@ -1666,16 +1667,16 @@ func (m deckModel) viewSessionList(availableHeight int) string {
 		session := sessions[i]
 		rowIdx := i - start
 
-		rows[rowIdx].label = session.Label
+		rows[rowIdx].label = session.DisplayLabel()
 		rows[rowIdx].project = session.Project
 		rows[rowIdx].date = session.StartTime.Format("Jan 02'06")
 		rows[rowIdx].model = session.Model
 		rows[rowIdx].modelColored = colorizeModel(session.Model)
 		rows[rowIdx].dur = formatDurationMinutes(session.Duration)
-		rows[rowIdx].tokens = formatTokens(session.InputTokens + session.OutputTokens)
+		rows[rowIdx].tokens = cliui.FormatTokens(session.InputTokens + session.OutputTokens)
 		rows[rowIdx].barbell = renderCostWeightedBarbell(session.InputTokens, session.OutputTokens, session.InputCost, session.OutputCost, m.overview.Sessions)
 		rows[rowIdx].costInd = formatCostIndicator(session.TotalCost, m.overview.Sessions)
-		rows[rowIdx].costRaw = formatCost(session.TotalCost)
+		rows[rowIdx].costRaw = cliui.FormatCost(session.TotalCost)
 		rows[rowIdx].cost = formatCostWithScale(session.TotalCost, m.overview.Sessions)
 		rows[rowIdx].tools = strconv.Itoa(session.ToolCalls)
 		rows[rowIdx].msgs = strconv.Itoa(session.MessageCount)
@@ -1839,7 +1840,7 @@ func (m deckModel) viewSession() string {
 	if m.detail.Summary.Project != "" {
 		breadcrumb += deckMutedStyle.Render(" > ") + deckMutedStyle.Render(m.detail.Summary.Project)
 	}
-	breadcrumb += deckMutedStyle.Render(" > ") + deckTitleStyle.Render(m.detail.Summary.Label)
+	breadcrumb += deckMutedStyle.Render(" > ") + deckTitleStyle.Render(m.detail.Summary.DisplayLabel())
 	headerRight := deckMutedStyle.Render(fmt.Sprintf("%s · %s %s", m.detail.Summary.ID, statusDot, m.detail.Summary.Status))
 	if len(m.detail.SubSessions) > 1 {
 		headerRight = deckMutedStyle.Render(fmt.Sprintf("%d sessions · %s %s", len(m.detail.SubSessions), statusDot, m.detail.Summary.Status))
@@ -1921,13 +1922,13 @@ func (m deckModel) renderSessionMetrics() []string {
 	metrics := []metricData{
 		{
 			label:     "TOTAL COST",
-			value:     formatCost(thisCost),
-			secondary: formatCost(avgCost) + " avg",
+			value:     cliui.FormatCost(thisCost),
+			secondary: cliui.FormatCost(avgCost) + " avg",
 		},
 		{
 			label:     "TOKENS USED",
-			value:     fmt.Sprintf("%s in / %s out", formatTokens(m.detail.Summary.InputTokens), formatTokens(m.detail.Summary.OutputTokens)),
-			secondary: formatTokens(int64(avgTokens)) + " avg",
+			value:     fmt.Sprintf("%s in / %s out", cliui.FormatTokens(m.detail.Summary.InputTokens), cliui.FormatTokens(m.detail.Summary.OutputTokens)),
+			secondary: cliui.FormatTokens(int64(avgTokens)) + " avg",
 		},
 		{
 			label:     "AGENT TIME",
@@ -2007,7 +2008,7 @@ func (m deckModel) renderSessionMetrics() []string {
 		// Tokens metric gets a custom layout to match the design.
 		if metric.label == "TOKENS USED" {
 			// Line 2: total + change
-			totalStr := highlightValueStyle.Render(formatTokens(totalTokens) + " total")
+			totalStr := highlightValueStyle.Render(cliui.FormatTokens(totalTokens) + " total")
 			changeStr := ""
 			if metric.change != "" {
 				arrowStyle := deckStatusFailStyle
@@ -2025,8 +2026,8 @@ func (m deckModel) renderSessionMetrics() []string {
 			// Line 3: input/output breakdown
 			leftWidth := max(colWidth/2-1, 8)
 			rightWidth := max(colWidth-leftWidth-1, 8)
-			left := fmt.Sprintf("%s in  %2.0f%%", formatTokens(m.detail.Summary.InputTokens), tokenInPercent)
-			right := fmt.Sprintf("%s out %2.0f%%", formatTokens(m.detail.Summary.OutputTokens), tokenOutPercent)
+			left := fmt.Sprintf("%s in  %2.0f%%", cliui.FormatTokens(m.detail.Summary.InputTokens), tokenInPercent)
+			right := fmt.Sprintf("%s out %2.0f%%", cliui.FormatTokens(m.detail.Summary.OutputTokens), tokenOutPercent)
 			line3 := fitCell(left, leftWidth) + " " + fitCellRight(right, rightWidth)
 			block = append(block, fitCell(line3, colWidth))
 
@@ -2199,6 +2200,18 @@ func (m deckModel) buildAnalyticsContent() []string {
 		combined = joinColumns(leftModel, rightProvider, 4)
 		lines = append(lines, combined...)
 
+		// ── Prompt cache hit rates ──
+		if cacheLines := m.renderAnalyticsCache(a.Cache, a.CacheHitRate, w); len(cacheLines) > 0 {
+			lines = append(lines, "")
+			lines = append(lines, cacheLines...)
+		}
+
+		// ── Upstream error rates ──
+		if errorLines := m.renderAnalyticsErrors(a.Errors, a.ErrorRate, w); len(errorLines) > 0 {
+			lines = append(lines, "")
+			lines = append(lines, errorLines...)
+		}
+
 	case analyticsTabInsights:
 		// ── AI Insights (without summaries) ──
 		if insightLines := m.renderFacetInsightsNoSummaries(w); len(insightLines) > 0 {
@@ -2266,7 +2279,7 @@ func (m deckModel) renderAnalyticsSummaryCards(a *deck.AnalyticsOverview, width
 
 	cards := []metricCard{
 		{label: "TOTAL SESSIONS", value: strconv.Itoa(a.TotalSessions), sub: "sessions tracked"},
-		{label: "AVG COST/SESSION", value: formatCost(a.AvgSessionCost), sub: "per session avg"},
+		{label: "AVG COST/SESSION", value: cliui.FormatCost(a.AvgSessionCost), sub: "per session avg"},
 		{label: "AVG DURATION", value: formatDurationMinutes(avgDuration), sub: "per session avg"},
 		{label: "MODELS TRACKED", value: strconv.Itoa(modelCount), sub: fmt.Sprintf("%d providers", len(a.ProviderBreakdown))},
 	}
@@ -2437,7 +2450,7 @@ func (m deckModel) renderAnalyticsHeatmap(days []deck.DayActivity, width int) []
 		peakDate = peakDate[5:]
 	}
 	lines = append(lines, "  "+bullet+" "+insightStyle.Render(fmt.Sprintf(
-		"peak: %s with %d sessions (%s spent)", peakDate, peakDay.Sessions, formatCost(peakDay.Cost))))
+		"peak: %s with %d sessions (%s spent)", peakDate, peakDay.Sessions, cliui.FormatCost(peakDay.Cost))))
 
 	pct := 0
 	if len(days) > 0 {
@@ -2523,7 +2536,7 @@ func (m deckModel) renderAnalyticsSelectedDay(days []deck.DayActivity) string {
 			if len(label) > 5 {
 				label = label[5:]
 			}
-			return fmt.Sprintf("selected: %s · %d sessions · %s spent", label, d.Sessions, formatCost(d.Cost))
+			return fmt.Sprintf("selected: %s · %d sessions · %s spent", label, d.Sessions, cliui.FormatCost(d.Cost))
 		}
 	}
 	return ""
@@ -2558,8 +2571,8 @@ func (m deckModel) renderAnalyticsDayDetail(width int) []string {
 	}
 	metrics := fmt.Sprintf("  sessions: %d · total: %s · avg: %s · success: %s",
 		sessionCount,
-		formatCost(overview.TotalCost),
-		formatCost(avgCost),
+		cliui.FormatCost(overview.TotalCost),
+		cliui.FormatCost(avgCost),
 		formatPercent(overview.SuccessRate),
 	)
 	lines = append(lines, deckMutedStyle.Render(metrics))
@@ -2622,10 +2635,10 @@ func (m deckModel) renderAnalyticsDaySessions(sessions []deck.SessionSummary, wi
 		}
 		row := strings.Join([]string{
 			fitCell(fmt.Sprintf("%02d", i+1), indexW),
-			fitCell(truncateText(s.Label, labelW), labelW),
+			fitCell(truncateText(s.DisplayLabel(), labelW), labelW),
 			fitCell(truncateText(model, modelW), modelW),
 			fitCell(formatDurationMinutes(s.Duration), durW),
-			fitCell(formatCost(s.TotalCost), costW),
+			fitCell(cliui.FormatCost(s.TotalCost), costW),
 			statusStyleFor(s.Status).Render(fitCell(s.Status, statusW)),
 		}, gapStr)
 		lines = append(lines, "  "+row)
@@ -2793,6 +2806,18 @@ func (m deckModel) renderAnalyticsTools(tools []deck.ToolMetric, width int) []st
 		lines = append(lines, bullet+" "+insightStyle.Render("no tool errors detected"))
 	}
 
+	var slowest *deck.ToolMetric
+	for i := range tools {
+		if tools[i].P95DurationMs > 0 && (slowest == nil || tools[i].P95DurationMs > slowest.P95DurationMs) {
+			slowest = &tools[i]
+		}
+	}
+	if slowest != nil {
+		lines = append(lines, bullet+" "+insightStyle.Render(fmt.Sprintf(
+			"%s is slowest (p95 %.1fs)",
+			slowest.Name, float64(slowest.P95DurationMs)/1000)))
+	}
+
 	lines = append(lines, bullet+" "+insightStyle.Render(fmt.Sprintf(
 		"%d unique tools across %d sessions", len(tools), m.analytics.TotalSessions)))
 
@@ -2906,8 +2931,8 @@ func (m deckModel) renderAnalyticsModelTable(models []deck.ModelPerformance, wid
 		modelPadded := padRightWithColor(modelName, nameW)
 
 		avgDur := formatDurationMinutes(time.Duration(mp.AvgDurationNs))
-		tokens := formatTokens(mp.AvgTokens)
-		cost := formatCost(mp.AvgCost)
+		tokens := cliui.FormatTokens(mp.AvgTokens)
+		cost := cliui.FormatCost(mp.AvgCost)
 
 		// Color success rate based on threshold (like the web)
 		successStr := formatPercent(mp.SuccessRate)
@@ -2955,7 +2980,7 @@ func (m deckModel) renderAnalyticsModelTable(models []deck.ModelPerformance, wid
 			}
 		}
 		lines = append(lines, bullet+" "+insightStyle.Render(fmt.Sprintf(
-			"%s is most cost-effective at %s/session", bestValue.Model, formatCost(bestValue.AvgCost))))
+			"%s is most cost-effective at %s/session", bestValue.Model, cliui.FormatCost(bestValue.AvgCost))))
 		if bestSuccess.Model != bestValue.Model {
 			lines = append(lines, bullet+" "+insightStyle.Render(fmt.Sprintf(
 				"%s has highest success rate at %s", bestSuccess.Model, formatPercent(bestSuccess.SuccessRate))))
@@ -2965,6 +2990,81 @@ func (m deckModel) renderAnalyticsModelTable(models []deck.ModelPerformance, wid
 	return lines
 }
 
+// renderAnalyticsCache renders the prompt cache hit rate per model as bars.
+// It renders nothing when no model used a prompt cache.
+func (m deckModel) renderAnalyticsCache(metrics []deck.CacheMetric, hitRate float64, width int) []string {
+	cached := make([]deck.CacheMetric, 0, len(metrics))
+	var savings float64
+	for _, cm := range metrics {
+		if cm.CacheReadTokens > 0 || cm.CacheWriteTokens > 0 {
+			cached = append(cached, cm)
+			savings += cm.Savings
+		}
+	}
+	if len(cached) == 0 {
+		return nil
+	}
+
+	lines := []string{renderAnalyticsSectionHeader("prompt cache", width)}
+
+	nameW := 24
+	statsW := 36
+	barWidth := max(width-nameW-statsW-4, 10)
+	barStyle := lipgloss.NewStyle().Foreground(colorGreen)
+
+	for _, cm := range cached {
+		filled := int(cm.HitRate * float64(barWidth))
+		bar := barStyle.Render(strings.Repeat("█", filled)) + deckDimStyle.Render(strings.Repeat("░", barWidth-filled))
+		stats := fmt.Sprintf("%4s hit  %s read  %s saved",
+			formatPercent(cm.HitRate), cliui.FormatTokens(cm.CacheReadTokens), cliui.FormatCost(cm.Savings))
+		lines = append(lines, padRightWithColor(colorizeModel(truncateText(cm.Model, nameW-1)), nameW)+"  "+bar+"  "+deckMutedStyle.Render(stats))
+	}
+
+	insightStyle := lipgloss.NewStyle().Foreground(colorBrightBlack)
+	bullet := lipgloss.NewStyle().Foreground(colorMagenta).Render("▸")
+	lines = append(lines, "", bullet+" "+insightStyle.Render(fmt.Sprintf(
+		"%s of input tokens were read from cache, saving %s", formatPercent(hitRate), cliui.FormatCost(savings))))
+
+	return lines
+}
+
+// renderAnalyticsErrors renders the upstream error rate per model as bars.
+// It renders nothing when no request failed.
+func (m deckModel) renderAnalyticsErrors(metrics []deck.ErrorMetric, errorRate float64, width int) []string {
+	failing := make([]deck.ErrorMetric, 0, len(metrics))
+	var errs int
+	for _, em := range metrics {
+		if em.Errors > 0 {
+			failing = append(failing, em)
+			errs += em.Errors
+		}
+	}
+	if len(failing) == 0 {
+		return nil
+	}
+
+	lines := []string{renderAnalyticsSectionHeader("upstream errors", width)}
+
+	nameW := 24
+	statsW := 36
+	barWidth := max(width-nameW-statsW-4, 10)
+	barStyle := lipgloss.NewStyle().Foreground(colorRed)
+
+	for _, em := range failing {
+		filled := int(em.ErrorRate * float64(barWidth))
+		bar := barStyle.Render(strings.Repeat("█", filled)) + deckDimStyle.Render(strings.Repeat("░", barWidth-filled))
+		stats := fmt.Sprintf("%4s failed  %d of %d", formatPercent(em.ErrorRate), em.Errors, em.Requests)
+		lines = append(lines, padRightWithColor(colorizeModel(truncateText(em.Model, nameW-1)), nameW)+"  "+bar+"  "+deckMutedStyle.Render(stats))
+	}
+
+	insightStyle := lipgloss.NewStyle().Foreground(colorBrightBlack)
+	bullet := lipgloss.NewStyle().Foreground(colorMagenta).Render("▸")
+	lines = append(lines, "", bullet+" "+insightStyle.Render(fmt.Sprintf(
+		"%d requests failed upstream, %s of all requests", errs, formatPercent(errorRate))))
+
+	return lines
+}
+
 func (m deckModel) renderAnalyticsProviders(providers map[string]int, width int) []string {
 	lines := []string{renderAnalyticsSectionHeader("provider split", width)}
 
@@ -3755,12 +3855,12 @@ func formatCostIndicator(cost float64, allSessions []deck.SessionSummary) string
 
 func formatCostWithScale(cost float64, allSessions []deck.SessionSummary) string {
 	if len(allSessions) == 0 {
-		return formatCost(cost)
+		return cliui.FormatCost(cost)
 	}
 	index := costGradientIndex(cost, allSessions)
 	colorIndex := min(max(index, 0), len(costOrangeGradient)-1)
 	style := lipgloss.NewStyle().Foreground(lipgloss.Color(costOrangeGradient[colorIndex]))
-	return style.Render(formatCost(cost))
+	return style.Render(cliui.FormatCost(cost))
 }
 
 // renderCostWeightedBarbell creates a mini visualization showing token distribution and cost
@@ -3900,20 +4000,6 @@ func formatStatusWithCircle(status string) (string, string) {
 	return circle, text
 }
 
-func formatCost(value float64) string {
-	return fmt.Sprintf("$%.2f", value)
-}
-
-func formatTokens(value int64) string {
-	if value >= 1_000_000 {
-		return fmt.Sprintf("%.1fM", float64(value)/1_000_000.0)
-	}
-	if value >= 1_000 {
-		return fmt.Sprintf("%.1fK", float64(value)/1_000.0)
-	}
-	return strconv.FormatInt(value, 10)
-}
-
 func formatDuration(value time.Duration) string {
 	if value <= 0 {
 		return "0s"
@@ -4326,9 +4412,9 @@ func (m deckModel) buildWaveform(groups []deck.SessionMessageGroup, selected *de
 		case 0:
 			label = labelTokens
 		case 1:
-			label = formatTokens(maxTokens)
+			label = cliui.FormatTokens(maxTokens)
 		case maxBarHeight / 2:
-			label = formatTokens(maxTokens / 2)
+			label = cliui.FormatTokens(maxTokens / 2)
 		case maxBarHeight - 1:
 			label = "0"
 		}
@@ -4427,7 +4513,7 @@ func (m deckModel) renderConversationTable(width, height int) []string {
 			msgNum := strconv.Itoa(group.StartIndex + 1)
 			timeStr := group.StartTime.Format("15:04:05")
 			tokensStr := formatTokensCompact(group.TotalTokens)
-			costStr := formatCost(group.TotalCost)
+			costStr := cliui.FormatCost(group.TotalCost)
 			deltaStr := ""
 			if group.Delta > 0 {
 				deltaStr = formatDuration(group.Delta)
@@ -4512,7 +4598,7 @@ func (m deckModel) renderConversationTable(width, height int) []string {
 		msgNum := strconv.Itoa(i + 1)
 		timeStr := msg.Timestamp.Format("15:04:05")
 		tokensStr := formatTokensCompact(msg.TotalTokens)
-		costStr := formatCost(msg.TotalCost)
+		costStr := cliui.FormatCost(msg.TotalCost)
 		deltaStr := ""
 		if msg.Delta > 0 {
 			deltaStr = formatDuration(msg.Delta)
@@ -4621,9 +4707,9 @@ func (m deckModel) renderMessageDetailPane(width, height int) []string {
 		))
 		contentLines = append(contentLines, deckMutedStyle.Render("Cost:   ")+fmt.Sprintf(
 			"In %s  Out %s  Total %s",
-			formatCost(group.InputCost),
-			formatCost(group.OutputCost),
-			deckAccentStyle.Render(formatCost(group.TotalCost)),
+			cliui.FormatCost(group.InputCost),
+			cliui.FormatCost(group.OutputCost),
+			deckAccentStyle.Render(cliui.FormatCost(group.TotalCost)),
 		))
 		contentLines = append(contentLines, "")
 
@@ -4704,7 +4790,7 @@ func (m deckModel) renderMessageDetailPane(width, height int) []string {
 	contentLines = append(contentLines, deckMutedStyle.Render("Tokens: ")+
 		fmt.Sprintf("In %s  Out %s  Total %s", formatTokensDetail(msg.InputTokens), formatTokensDetail(msg.OutputTokens), formatTokensDetail(msg.TotalTokens)))
 	contentLines = append(contentLines, deckMutedStyle.Render("Cost:   ")+
-		fmt.Sprintf("In %s  Out %s  Total %s", formatCost(msg.InputCost), formatCost(msg.OutputCost), deckAccentStyle.Render(formatCost(msg.TotalCost))))
+		fmt.Sprintf("In %s  Out %s  Total %s", cliui.FormatCost(msg.InputCost), cliui.FormatCost(msg.OutputCost), deckAccentStyle.Render(cliui.FormatCost(msg.TotalCost))))
 	contentLines = append(contentLines, "")
 
 	// Tools
@@ -5200,7 +5286,7 @@ func formatTokensDetail(value int64) string {
 	if value < 10_000 {
 		return formatInt(value) + " tok"
 	}
-	return formatTokens(value) + " tok"
+	return cliui.FormatTokens(value) + " tok"
 }
 
 func formatInt(value int64) string {
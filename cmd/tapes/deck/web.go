@@ -3,6 +3,7 @@ package deckcmder
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -79,7 +80,13 @@ func runDeckWeb(ctx context.Context, query deck.Querier, filters deck.Filters, p
 			return
 		}
 
-		detail, err := query.SessionDetail(r.Context(), sessionID)
+		page, err := parsePage(r)
+		if err != nil {
+			writeJSONError(w, err)
+			return
+		}
+
+		detail, err := query.SessionDetailPage(r.Context(), sessionID, page)
 		if err != nil {
 			writeJSONError(w, err)
 			return
@@ -87,6 +94,51 @@ func runDeckWeb(ctx context.Context, query deck.Querier, filters deck.Filters, p
 		writeJSON(w, detail)
 	})
 
+	mux.HandleFunc("/api/tree/", func(w http.ResponseWriter, r *http.Request) {
+		sessionID := strings.TrimPrefix(r.URL.Path, "/api/tree/")
+		if sessionID == "" {
+			http.Error(w, "missing session id", http.StatusBadRequest)
+			return
+		}
+
+		tree, err := query.SessionTree(r.Context(), sessionID)
+		if err != nil {
+			writeJSONError(w, err)
+			return
+		}
+		writeJSON(w, tree)
+	})
+
+	mux.HandleFunc("/api/diff", func(w http.ResponseWriter, r *http.Request) {
+		a := r.URL.Query().Get("a")
+		b := r.URL.Query().Get("b")
+		if a == "" || b == "" {
+			http.Error(w, "missing session a or b", http.StatusBadRequest)
+			return
+		}
+
+		diff, err := query.DiffSessions(r.Context(), a, b)
+		if err != nil {
+			writeJSONError(w, err)
+			return
+		}
+		writeJSON(w, diff)
+	})
+
+	mux.HandleFunc("/api/search", func(w http.ResponseWriter, r *http.Request) {
+		opts, err := parseSearchOptions(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		hits, err := query.Search(r.Context(), opts)
+		if err != nil {
+			writeJSONError(w, err)
+			return
+		}
+		writeJSON(w, hits)
+	})
+
 	// Facet endpoints — real data when extractor is configured, empty stubs otherwise.
 	mux.HandleFunc("/api/facets", func(w http.ResponseWriter, r *http.Request) {
 		if facets == nil || facets.extractor == nil {
@@ -189,6 +241,9 @@ func applyWebFilters(base deck.Filters, r *http.Request) (deck.Filters, error) {
 	if value := strings.TrimSpace(query.Get("project")); value != "" {
 		filters.Project = value
 	}
+	if value := strings.TrimSpace(query.Get("user")); value != "" {
+		filters.User = value
+	}
 	if value := strings.TrimSpace(query.Get("since")); value != "" {
 		duration, err := parseSince(value)
 		if err != nil {
@@ -210,10 +265,82 @@ func applyWebFilters(base deck.Filters, r *http.Request) (deck.Filters, error) {
 		}
 		filters.To = &parsed
 	}
+	if value := strings.TrimSpace(query.Get("until")); value != "" {
+		duration, err := parseSince(value)
+		if err != nil {
+			return filters, err
+		}
+		filters.Until = duration
+	}
+	if value := strings.TrimSpace(query.Get("label")); value != "" {
+		filters.Label = value
+	}
+	if value := strings.TrimSpace(query.Get("tool")); value != "" {
+		filters.Tool = value
+	}
+	if value := strings.TrimSpace(query.Get("stop_reason")); value != "" {
+		filters.StopReason = value
+	}
+	for name, target := range map[string]*float64{"min_cost": &filters.MinCost, "max_cost": &filters.MaxCost} {
+		value := strings.TrimSpace(query.Get(name))
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed < 0 {
+			return filters, fmt.Errorf("invalid %s: %q", name, value)
+		}
+		*target = parsed
+	}
+
+	page, err := parsePage(r)
+	if err != nil {
+		return filters, err
+	}
+	filters.Limit = page.Limit
+	filters.Cursor = page.Cursor
 
 	return filters, nil
 }
 
+func parsePage(r *http.Request) (deck.Page, error) {
+	values := r.URL.Query()
+	page := deck.Page{Cursor: strings.TrimSpace(values.Get("cursor"))}
+	if limit := strings.TrimSpace(values.Get("limit")); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil || parsed < 0 {
+			return page, fmt.Errorf("invalid limit: %q", limit)
+		}
+		page.Limit = parsed
+	}
+	return page, nil
+}
+
+func parseSearchOptions(r *http.Request) (deck.SearchOptions, error) {
+	values := r.URL.Query()
+	opts := deck.SearchOptions{
+		Query:    strings.TrimSpace(values.Get("q")),
+		Provider: values.Get("provider"),
+	}
+	if opts.Query == "" {
+		return opts, errors.New("missing search query")
+	}
+
+	since, err := parseSince(values.Get("since"))
+	if err != nil {
+		return opts, err
+	}
+	opts.Since = since
+
+	if limit := values.Get("limit"); limit != "" {
+		opts.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return opts, fmt.Errorf("invalid limit: %w", err)
+		}
+	}
+	return opts, nil
+}
+
 func parseSince(value string) (time.Duration, error) {
 	value = strings.TrimSpace(strings.ToLower(value))
 	if value == "" {
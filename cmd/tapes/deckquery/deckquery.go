@@ -0,0 +1,67 @@
+// Package deckquery opens the deck query and validates the output format
+// shared by the commands that read sessions through --sqlite, --pricing and
+// --format flags.
+package deckquery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+// Output formats accepted by --format.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+// Open opens a deck query over the SQLite database and pricing selected by
+// the --sqlite and --pricing flags.
+func Open(ctx context.Context, cmd *cobra.Command) (*deck.Query, func() error, error) {
+	override, err := cmd.Flags().GetString("sqlite")
+	if err != nil {
+		return nil, nil, err
+	}
+	pricingPath, err := cmd.Flags().GetString("pricing")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	pricing, err := deck.ResolvePricing(configDir, pricingPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(override)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	query, closeFn, err := deck.NewReadOnlyQuery(ctx, dbPath, pricing, driverOpts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening database: %w", err)
+	}
+	return query, closeFn, nil
+}
+
+// Format returns the validated --format flag.
+func Format(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains([]string{FormatTable, FormatJSON}, format) {
+		return "", fmt.Errorf("unsupported format %q (expected table or json)", format)
+	}
+	return format, nil
+}
@@ -0,0 +1,212 @@
+// Package diffcmder provides the diff command for comparing two
+// conversations turn by turn.
+package diffcmder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+const diffLongDesc string = `Compare two conversations turn by turn.
+
+Turns are content-addressed, so two conversations share every turn up to the
+point where they diverged. The diff reports that point, then pairs the turns
+after it and shows how their text, tool calls, and cost differ, followed by
+overall tool usage and totals. This is useful for comparing a conversation
+replayed from a checkout against the original.
+
+Each session is a turn hash, usually the last turn of a conversation, or a
+unique prefix of one.
+
+Examples:
+  tapes diff 3f2a9c1 8be41d0
+  tapes diff 3f2a9c1 8be41d0 --json`
+
+const diffShortDesc string = "Compare two conversations"
+
+type diffCommander struct {
+	sqlitePath string
+	jsonOutput bool
+}
+
+func NewDiffCmd() *cobra.Command {
+	cmder := &diffCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "diff <session-a> <session-b>",
+		Short: diffShortDesc,
+		Long:  diffLongDesc,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmder.run(cmd.Context(), cmd, args[0], args[1])
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().BoolVar(&cmder.jsonOutput, "json", false, "Print the diff as JSON")
+
+	return cmd
+}
+
+func (c *diffCommander) run(ctx context.Context, cmd *cobra.Command, a, b string) error {
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	pricing, err := deck.ResolvePricing(configDir, "")
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	query, closeFn, err := deck.NewReadOnlyQuery(ctx, dbPath, pricing, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = closeFn() }()
+
+	diff, err := query.DiffSessions(ctx, a, b)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if c.jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(diff)
+	}
+
+	return writeDiff(w, diff)
+}
+
+func writeDiff(w io.Writer, diff *deck.SessionDiff) error {
+	for _, side := range []struct {
+		name    string
+		summary deck.SessionSummary
+	}{{"A", diff.A}, {"B", diff.B}} {
+		fmt.Fprintf(w, "%s  %s  %d turns  %s  %s  %s\n",
+			side.name,
+			cliui.ShortHash(side.summary.ID),
+			side.summary.MessageCount,
+			cliui.FormatCost(side.summary.TotalCost),
+			side.summary.Status,
+			side.summary.DisplayLabel(),
+		)
+	}
+	fmt.Fprintln(w)
+
+	switch {
+	case diff.CommonTurns == 0:
+		fmt.Fprintln(w, "No shared turns.")
+	case len(diff.Turns) == 0:
+		fmt.Fprintf(w, "Identical: both share all %d turns.\n", diff.CommonTurns)
+	default:
+		fmt.Fprintf(w, "Shared %d turns, diverged after %s.\n", diff.CommonTurns, cliui.ShortHash(diff.CommonHash))
+	}
+
+	for _, turn := range diff.Turns {
+		writeTurn(w, turn)
+	}
+
+	if len(diff.ToolUsage) > 0 {
+		fmt.Fprintln(w)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "TOOL\tA\tB\t")
+		for _, t := range diff.ToolUsage {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", t.Name, t.A, t.B, formatIntDelta(t.B-t.A))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TOTAL\tA\tB\t")
+	fmt.Fprintf(tw, "cost\t%s\t%s\t%s\n", cliui.FormatCost(diff.A.TotalCost), cliui.FormatCost(diff.B.TotalCost), formatCostDelta(diff.CostDelta))
+	fmt.Fprintf(tw, "input tokens\t%d\t%d\t%s\n", diff.A.InputTokens, diff.B.InputTokens, formatIntDelta(int(diff.InputTokensDelta)))
+	fmt.Fprintf(tw, "output tokens\t%d\t%d\t%s\n", diff.A.OutputTokens, diff.B.OutputTokens, formatIntDelta(int(diff.OutputTokensDelta)))
+	fmt.Fprintf(tw, "tool calls\t%d\t%d\t%s\n", diff.A.ToolCalls, diff.B.ToolCalls, formatIntDelta(diff.ToolCallsDelta))
+	return tw.Flush()
+}
+
+func writeTurn(w io.Writer, turn deck.TurnDiff) {
+	fmt.Fprintln(w)
+	switch {
+	case turn.B == nil:
+		fmt.Fprintf(w, "Turn %d  %s  only in A (%s)\n", turn.Index+1, turn.A.Role, cliui.ShortHash(turn.A.Hash))
+		writeSideText(w, "-", turn.A.Text)
+		return
+	case turn.A == nil:
+		fmt.Fprintf(w, "Turn %d  %s  only in B (%s)\n", turn.Index+1, turn.B.Role, cliui.ShortHash(turn.B.Hash))
+		writeSideText(w, "+", turn.B.Text)
+		return
+	}
+
+	role := turn.A.Role
+	if turn.B.Role != role {
+		role += " / " + turn.B.Role
+	}
+	fmt.Fprintf(w, "Turn %d  %s  %s → %s", turn.Index+1, role, cliui.ShortHash(turn.A.Hash), cliui.ShortHash(turn.B.Hash))
+	if turn.Same {
+		fmt.Fprintln(w, "  (same content)")
+		return
+	}
+	fmt.Fprintln(w)
+
+	for _, line := range turn.TextDiff {
+		op := " "
+		if line.Op != deck.DiffEqual {
+			op = line.Op
+		}
+		fmt.Fprintf(w, "  %s %s\n", op, line.Text)
+	}
+
+	toolsA := strings.Join(turn.A.Tools, ", ")
+	toolsB := strings.Join(turn.B.Tools, ", ")
+	if toolsA != toolsB {
+		fmt.Fprintf(w, "  tools: A [%s]  B [%s]\n", toolsA, toolsB)
+	}
+	if turn.A.Cost != 0 || turn.B.Cost != 0 {
+		fmt.Fprintf(w, "  cost: A %s  B %s  %s\n", cliui.FormatCost(turn.A.Cost), cliui.FormatCost(turn.B.Cost), formatCostDelta(turn.B.Cost-turn.A.Cost))
+	}
+}
+
+func writeSideText(w io.Writer, op, text string) {
+	if text == "" {
+		return
+	}
+	for line := range strings.SplitSeq(strings.TrimSuffix(text, "\n"), "\n") {
+		fmt.Fprintf(w, "  %s %s\n", op, line)
+	}
+}
+
+func formatCostDelta(value float64) string {
+	if value >= 0 {
+		return fmt.Sprintf("(+$%.2f)", value)
+	}
+	return fmt.Sprintf("(-$%.2f)", -value)
+}
+
+func formatIntDelta(value int) string {
+	if value == 0 {
+		return ""
+	}
+	return fmt.Sprintf("(%+d)", value)
+}
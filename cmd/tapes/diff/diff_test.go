@@ -0,0 +1,96 @@
+package diffcmder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("diff command", func() {
+	var (
+		ctx                context.Context
+		dbPath             string
+		original, replayed *merkle.Node
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(role string, blocks []llm.ContentBlock, parent *merkle.Node) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:    "message",
+				Role:    role,
+				Content: blocks,
+				Model:   "gpt-4o",
+			}, parent, merkle.NodeMeta{Usage: &llm.Usage{PromptTokens: 1000, CompletionTokens: 100}})
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+
+		root := put("user", []llm.ContentBlock{{Type: "text", Text: "list the files"}}, nil)
+		original = put("assistant", []llm.ContentBlock{
+			{Type: "text", Text: "main.go\ngo.mod"},
+		}, root)
+		replayed = put("assistant", []llm.ContentBlock{
+			{Type: "text", Text: "main.go\nREADME.md"},
+			{Type: "tool_use", ToolUseID: "call_1", ToolName: "Bash"},
+		}, root)
+		Expect(driver.Close()).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewDiffCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append([]string{"--sqlite", dbPath}, args...))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	It("prints the divergence, text diff, tools, and totals", func() {
+		out, err := run(original.Hash[:10], replayed.Hash[:10])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Shared 1 turns, diverged after "))
+		Expect(out).To(ContainSubstring("Turn 2  assistant  " + original.Hash[:12] + " → " + replayed.Hash[:12] + "\n"))
+		Expect(out).To(ContainSubstring("    main.go\n  - go.mod\n  + README.md\n"))
+		Expect(out).To(ContainSubstring("  tools: A []  B [Bash]\n"))
+		Expect(out).To(MatchRegexp(`Bash\s+0\s+1\s+\(\+1\)`))
+		Expect(out).To(MatchRegexp(`tool calls\s+0\s+1\s+\(\+1\)`))
+	})
+
+	It("reports identical sessions", func() {
+		out, err := run(original.Hash, original.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Identical: both share all 2 turns."))
+	})
+
+	It("prints JSON", func() {
+		out, err := run(original.Hash, replayed.Hash, "--json")
+		Expect(err).NotTo(HaveOccurred())
+
+		var diff deck.SessionDiff
+		Expect(json.Unmarshal([]byte(out), &diff)).To(Succeed())
+		Expect(diff.CommonTurns).To(Equal(1))
+		Expect(diff.Turns).To(HaveLen(1))
+	})
+
+	It("requires two sessions", func() {
+		_, err := run(original.Hash)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,255 @@
+// Package evalcmder provides the eval command for running assertion suites
+// against recorded sessions.
+package evalcmder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sessionfilter"
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/credentials"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/eval"
+)
+
+const evalLongDesc string = `Run an eval suite against the assistant turns of recorded sessions.
+
+The suite is a TOML file of assertions, checked against every reply they
+apply to:
+
+  regex        A pattern the reply text must match, or with
+               expect = "no_match", must not match
+  json_schema  A JSON schema the arguments of calls to a tool must satisfy
+  judge        A rubric a judge model grades each reply against
+
+Pass rates are reported per assertion, per model, and per system prompt
+version (see tapes prompts list), so recordings double as a regression suite.
+With --min-pass-rate the command fails when any assertion falls below it.
+
+Example eval.toml:
+
+  [judge]
+  provider = "anthropic"
+  model = "claude-haiku-4-5"
+
+  [[assertion]]
+  name = "no-apologies"
+  type = "regex"
+  pattern = "(?i)\\bI apologi[sz]e\\b"
+  expect = "no_match"
+
+  [[assertion]]
+  name = "bash-args"
+  type = "json_schema"
+  tool = "Bash"
+  schema = { type = "object", required = ["command"] }
+
+  [[assertion]]
+  name = "grounded"
+  type = "judge"
+  rubric = "The reply only refers to files the user or a tool output mentioned."
+
+Sessions are selected with --filter key=value, repeated to narrow further.
+Keys: since, model, project, user, status, tool, label, stop-reason,
+min-cost, max-cost, and limit, which caps the turns checked.
+
+Examples:
+  tapes eval
+  tapes eval --suite evals/agent.toml --filter since=7d --min-pass-rate 0.9
+  tapes eval --filter model=gpt-4o --format json`
+
+const evalShortDesc string = "Run assertions against recorded sessions"
+
+// Output formats.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+type evalCommander struct {
+	sqlitePath  string
+	suite       string
+	filters     []string
+	format      string
+	provider    string
+	model       string
+	apiKey      string
+	minPassRate float64
+}
+
+func NewEvalCmd() *cobra.Command {
+	cmder := &evalCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "eval",
+		Short: evalShortDesc,
+		Long:  evalLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().StringVar(&cmder.suite, "suite", "eval.toml", "Path to the eval suite")
+	cmd.Flags().StringArrayVar(&cmder.filters, "filter", nil, "Select sessions by key=value (repeatable)")
+	cmd.Flags().StringVar(&cmder.format, "format", formatTable, "Output format: table|json")
+	cmd.Flags().StringVar(&cmder.provider, "provider", "", "Judge LLM provider (openai|anthropic|ollama), overriding the suite")
+	cmd.Flags().StringVar(&cmder.model, "model", "", "Judge LLM model, overriding the suite")
+	cmd.Flags().StringVar(&cmder.apiKey, "api-key", "", "API key for the judge LLM provider")
+	cmd.Flags().Float64Var(&cmder.minPassRate, "min-pass-rate", 0, "Fail when an assertion passes less often than this (0-1)")
+
+	return cmd
+}
+
+func (c *evalCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	if c.format != formatTable && c.format != formatJSON {
+		return fmt.Errorf("unsupported format %q (expected table or json)", c.format)
+	}
+	filters, limit, err := sessionfilter.Parse(c.filters)
+	if err != nil {
+		return err
+	}
+
+	suite, err := eval.Load(c.suite)
+	if err != nil {
+		return err
+	}
+
+	var judge deck.LLMCallFunc
+	if suite.NeedsJudge() {
+		if judge, err = c.judge(suite.Judge); err != nil {
+			return err
+		}
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	pricing, err := deck.ResolvePricing(configDir, "")
+	if err != nil {
+		return err
+	}
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	query, closeFn, err := deck.NewReadOnlyQuery(ctx, dbPath, pricing, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = closeFn() }()
+
+	runner, err := eval.NewRunner(query, suite, judge)
+	if err != nil {
+		return err
+	}
+	report, err := runner.Run(ctx, filters, limit)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if c.format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else if err := writeReport(w, report); err != nil {
+		return err
+	}
+
+	if c.minPassRate > 0 {
+		for _, a := range report.Assertions {
+			if a.Passed+a.Failed > 0 && a.PassRate < c.minPassRate {
+				return fmt.Errorf("assertion %s passed %.1f%% of checks, below %.1f%%", a.Name, a.PassRate*100, c.minPassRate*100)
+			}
+		}
+	}
+	return nil
+}
+
+// judge creates the LLM caller that grades judge assertions, configured by
+// the suite unless overridden by flags.
+func (c *evalCommander) judge(cfg eval.JudgeConfig) (deck.LLMCallFunc, error) {
+	provider := cfg.Provider
+	if c.provider != "" {
+		provider = c.provider
+	}
+	if provider == "" {
+		provider = "openai"
+	}
+	model := cfg.Model
+	if c.model != "" {
+		model = c.model
+	}
+
+	credMgr, err := credentials.NewManager("")
+	if err != nil {
+		return nil, fmt.Errorf("loading credentials: %w", err)
+	}
+	return deck.NewLLMCaller(deck.LLMCallerConfig{
+		Provider: provider,
+		Model:    model,
+		APIKey:   c.apiKey,
+		CredMgr:  credMgr,
+	})
+}
+
+func writeReport(w io.Writer, report *eval.Report) error {
+	fmt.Fprintf(w, "Checked %d assistant turns\n\n", report.Turns)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ASSERTION\tTYPE\tPASSED\tFAILED\tERRORS\tPASS RATE")
+	for _, a := range report.Assertions {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%s\n", a.Name, a.Type, a.Passed, a.Failed, a.Errors, formatRate(a.Rate))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, a := range report.Assertions {
+		if a.Passed+a.Failed+a.Errors == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s\n", a.Name)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, g := range a.ByModel {
+			fmt.Fprintf(tw, "  model\t%s\t%d/%d\t%s\n", orDash(g.Key), g.Passed, g.Passed+g.Failed, formatRate(g.Rate))
+		}
+		for _, g := range a.ByPrompt {
+			fmt.Fprintf(tw, "  prompt\t%s\t%d/%d\t%s\n", orDash(cliui.ShortHash(g.Key)), g.Passed, g.Passed+g.Failed, formatRate(g.Rate))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		for _, f := range a.Failures {
+			fmt.Fprintf(w, "  ✗ %s  %s\n", cliui.ShortHash(f.Turn), f.Detail)
+		}
+	}
+	return nil
+}
+
+func formatRate(r eval.Rate) string {
+	if r.Passed+r.Failed == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", r.PassRate*100)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,104 @@
+package evalcmder
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+const testSuite = `
+[[assertion]]
+name = "no-apologies"
+type = "regex"
+pattern = "(?i)sorry"
+expect = "no_match"
+
+[[assertion]]
+name = "bash-args"
+type = "json_schema"
+tool = "bash"
+schema = { type = "object", required = ["cmd"] }
+`
+
+var _ = Describe("eval command", func() {
+	var (
+		ctx       context.Context
+		dbPath    string
+		suitePath string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir := GinkgoT().TempDir()
+		dbPath = filepath.Join(dir, "tapes.db")
+		suitePath = filepath.Join(dir, "eval.toml")
+		Expect(os.WriteFile(suitePath, []byte(testSuite), 0o600)).To(Succeed())
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(role, model string, blocks []llm.ContentBlock, parent *merkle.Node) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:    "message",
+				Role:    role,
+				Content: blocks,
+				Model:   model,
+			}, parent, merkle.NodeMeta{})
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+		text := func(s string) []llm.ContentBlock { return []llm.ContentBlock{{Type: "text", Text: s}} }
+
+		put("assistant", "gpt-4o", []llm.ContentBlock{
+			{Type: "text", Text: "Listing them."},
+			{Type: "tool_use", ToolUseID: "c1", ToolName: "bash", ToolInput: map[string]any{"cmd": "ls"}},
+		}, put("user", "gpt-4o", text("List the files."), nil))
+		put("assistant", "claude-sonnet-4-5", text("Sorry, I can't."), put("user", "claude-sonnet-4-5", text("Hello."), nil))
+		Expect(driver.Close()).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewEvalCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append([]string{"--sqlite", dbPath, "--suite", suitePath}, args...))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	It("prints pass rates and failures", func() {
+		out, err := run()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("no-apologies"))
+		Expect(out).To(ContainSubstring("50.0%"))
+		Expect(out).To(ContainSubstring("100.0%"))
+		Expect(out).To(ContainSubstring(`matched "Sorry"`))
+	})
+
+	It("narrows the turns with filters and prints JSON", func() {
+		out, err := run("--filter", "model=gpt-4o", "--format", "json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring(`"turns": 1`))
+		Expect(out).NotTo(ContainSubstring("claude-sonnet-4-5"))
+	})
+
+	It("fails when an assertion falls below the minimum pass rate", func() {
+		_, err := run("--min-pass-rate", "0.9")
+		Expect(err).To(MatchError("assertion no-apologies passed 50.0% of checks, below 90.0%"))
+	})
+
+	It("rejects unknown formats", func() {
+		_, err := run("--format", "yaml")
+		Expect(err).To(MatchError(`unsupported format "yaml" (expected table or json)`))
+	})
+})
@@ -0,0 +1,156 @@
+package exportcmder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sessionfilter"
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/dataset"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/redact"
+)
+
+const datasetLongDesc string = `Convert recorded sessions into a fine-tuning or eval dataset.
+
+Every conversation of the selected sessions becomes one JSON line, with its
+system prompt, turns, and the tools its session offered. Formats:
+
+  openai-jsonl  OpenAI chat fine-tuning: {"messages": [...], "tools": [...]}
+  anthropic     Messages API: {"system": "...", "messages": [...], "tools": [...]}
+  sharegpt      ShareGPT: {"conversations": [{"from": "human", "value": "..."}, ...]}
+
+Secrets and personal data are redacted with the built-in detectors and the
+custom rules in config.toml before anything is written. Tool calls are kept in
+the structure of the format, or flattened into text turns with
+--tools flatten for models trained without tool support.
+
+Sessions are selected with --filter key=value, repeated to narrow further.
+Keys: since, model, project, user, status, tool, label, stop-reason,
+min-cost, max-cost, and limit, which caps the conversations exported.
+
+Examples:
+  tapes export dataset -o train.jsonl
+  tapes export dataset --format anthropic --filter status=completed --filter since=7d
+  tapes export dataset --format sharegpt --tools flatten --filter project=api`
+
+// Tool modes.
+const (
+	toolsPreserve = "preserve"
+	toolsFlatten  = "flatten"
+)
+
+type datasetCommander struct {
+	sqlitePath string
+	format     string
+	filters    []string
+	tools      string
+	output     string
+}
+
+func newDatasetCmd() *cobra.Command {
+	cmder := &datasetCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "dataset",
+		Short: "Convert sessions into a fine-tuning or eval dataset",
+		Long:  datasetLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().StringVar(&cmder.format, "format", dataset.FormatOpenAIJSONL, "Dataset format: "+strings.Join(dataset.Formats(), "|"))
+	cmd.Flags().StringArrayVar(&cmder.filters, "filter", nil, "Select sessions by key=value (repeatable)")
+	cmd.Flags().StringVar(&cmder.tools, "tools", toolsPreserve, "Tool calls: preserve|flatten")
+	cmd.Flags().StringVarP(&cmder.output, "output", "o", "", "Write to file instead of stdout")
+
+	return cmd
+}
+
+func (c *datasetCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	if c.tools != toolsPreserve && c.tools != toolsFlatten {
+		return fmt.Errorf("unsupported --tools %q (expected preserve or flatten)", c.tools)
+	}
+	filters, limit, err := sessionfilter.Parse(c.filters)
+	if err != nil {
+		return err
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	cfger, err := config.NewConfiger(configDir)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	cfg, err := cfger.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	redactor, err := redact.NewRedactor(cfg.Redaction.Rules)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = cmd.OutOrStdout()
+	if c.output != "" {
+		file, err := os.Create(c.output)
+		if err != nil {
+			return fmt.Errorf("creating dataset file: %w", err)
+		}
+		defer file.Close()
+		w = file
+	}
+	writer, err := dataset.NewWriter(w, dataset.Options{
+		Format:       c.format,
+		FlattenTools: c.tools == toolsFlatten,
+		Redactor:     redactor,
+	})
+	if err != nil {
+		return err
+	}
+
+	pricing, err := deck.ResolvePricing(configDir, "")
+	if err != nil {
+		return err
+	}
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	query, closeFn, err := deck.NewReadOnlyQuery(ctx, dbPath, pricing, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = closeFn() }()
+
+	examples := 0
+	for req, err := range query.Conversations(ctx, filters) {
+		if err != nil {
+			return err
+		}
+		if limit > 0 && examples == limit {
+			break
+		}
+		if err := writer.Write(req); err != nil {
+			return fmt.Errorf("writing example: %w", err)
+		}
+		examples++
+	}
+
+	if c.output != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d conversations to %s\n", examples, c.output)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package exportcmder
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("export dataset command", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(role, text, project string, parent *merkle.Node) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:    "message",
+				Role:    role,
+				Content: []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:   "gpt-4o",
+			}, parent, merkle.NodeMeta{Project: project})
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+		put("assistant", "Done.", "api", put("user", "Email ops@example.com about the deploy.", "api", nil))
+		put("assistant", "Hi.", "web", put("user", "Hello.", "web", nil))
+		Expect(driver.Close()).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewExportCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append([]string{"dataset", "--sqlite", dbPath}, args...))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	It("exports one redacted example per conversation", func() {
+		out, err := run()
+		Expect(err).NotTo(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		Expect(lines).To(HaveLen(2))
+		Expect(out).To(ContainSubstring(`"content":"Email [REDACTED:email] about the deploy."`))
+		Expect(out).NotTo(ContainSubstring("ops@example.com"))
+	})
+
+	It("selects sessions with filters and writes to a file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "train.jsonl")
+		out, err := run("--format", "sharegpt", "--filter", "project=web", "-o", path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Exported 1 conversations to " + path))
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(MatchJSON(`{"conversations": [{"from": "human", "value": "Hello."}, {"from": "gpt", "value": "Hi."}]}`))
+	})
+
+	It("caps the conversations exported", func() {
+		out, err := run("--filter", "limit=1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Count(out, "\n")).To(Equal(1))
+	})
+
+	It("rejects unknown filters and tool modes", func() {
+		_, err := run("--filter", "color=red")
+		Expect(err).To(MatchError(ContainSubstring(`unknown --filter key "color"`)))
+
+		_, err = run("--tools", "drop")
+		Expect(err).To(MatchError(ContainSubstring(`unsupported --tools "drop"`)))
+	})
+})
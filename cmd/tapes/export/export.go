@@ -0,0 +1,28 @@
+// Package exportcmder provides the export command for turning recorded
+// sessions into files used outside of tapes.
+package exportcmder
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const exportLongDesc string = `Export recorded sessions for use outside of tapes.
+
+Examples:
+  tapes export dataset --format openai-jsonl -o train.jsonl
+  tapes export dataset --format sharegpt --filter project=api --filter since=30d`
+
+const exportShortDesc string = "Export recorded sessions"
+
+// NewExportCmd creates the parent export command.
+func NewExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: exportShortDesc,
+		Long:  exportLongDesc,
+	}
+
+	cmd.AddCommand(newDatasetCmd())
+
+	return cmd
+}
@@ -0,0 +1,119 @@
+// Package forkcmder provides the fork command for turning any point of a
+// recorded conversation into a request that continues it.
+package forkcmder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+)
+
+const forkLongDesc string = `Print a provider request body that continues a recorded conversation.
+
+The body holds every turn from the root of the conversation up to and
+including the given turn, the system prompt it was sent with, and the tools
+its session offered, so the conversation can be resumed or branched from any
+point in curl, a notebook, or another agent. Turns recorded from one provider
+can be written in the format of the other; content the target API cannot
+carry, such as reasoning for OpenAI, is left out.
+
+The turn is a hash or a unique prefix of one.
+
+Examples:
+  tapes fork 3f2a9c1 > request.json
+  tapes fork 3f2a9c1 --format anthropic --model claude-sonnet-4-5
+  tapes fork 3f2a9c1 | curl https://api.openai.com/v1/chat/completions \
+    -H "Authorization: Bearer $OPENAI_API_KEY" -H "Content-Type: application/json" -d @-`
+
+const forkShortDesc string = "Print a request that continues a conversation"
+
+type forkCommander struct {
+	sqlitePath string
+	format     string
+	model      string
+	maxTokens  int
+}
+
+func NewForkCmd() *cobra.Command {
+	cmder := &forkCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "fork <node-hash>",
+		Short: forkShortDesc,
+		Long:  forkLongDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmder.run(cmd.Context(), cmd, args[0])
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().StringVar(&cmder.format, "format", provider.OpenAI, "Request format: openai|anthropic")
+	cmd.Flags().StringVar(&cmder.model, "model", "", "Model to request (defaults to the model of the turn)")
+	cmd.Flags().IntVar(&cmder.maxTokens, "max-tokens", 0, "Maximum tokens to generate")
+
+	return cmd
+}
+
+func (c *forkCommander) run(ctx context.Context, cmd *cobra.Command, hash string) error {
+	p, err := provider.New(c.format)
+	if err != nil {
+		return err
+	}
+	encoder, ok := p.(provider.RequestEncoder)
+	if !ok {
+		return fmt.Errorf("requests cannot be written in %s format (expected openai or anthropic)", c.format)
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	pricing, err := deck.ResolvePricing(configDir, "")
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	query, closeFn, err := deck.NewReadOnlyQuery(ctx, dbPath, pricing, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = closeFn() }()
+
+	req, err := query.ForkRequest(ctx, hash)
+	if err != nil {
+		return err
+	}
+	if c.model != "" {
+		req.Model = c.model
+	}
+	if c.maxTokens > 0 {
+		req.MaxTokens = &c.maxTokens
+	}
+
+	body, err := encoder.EncodeRequest(req)
+	if err != nil {
+		return fmt.Errorf("encoding request: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, body, "", "  "); err != nil {
+		return err
+	}
+	out.WriteByte('\n')
+	_, err = out.WriteTo(cmd.OutOrStdout())
+	return err
+}
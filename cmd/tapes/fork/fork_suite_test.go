@@ -0,0 +1,13 @@
+package forkcmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFork(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Fork Command Suite")
+}
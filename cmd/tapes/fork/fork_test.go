@@ -0,0 +1,88 @@
+package forkcmder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("fork command", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+		answer *merkle.Node
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(role, text string, parent *merkle.Node) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:    "message",
+				Role:    role,
+				Content: []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:   "gpt-4o",
+			}, parent)
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+		system := put("system", "You are a coding agent.", nil)
+		question := put("user", "List the files.", system)
+		answer = put("assistant", "main.go", question)
+		put("user", "Read it.", answer)
+		Expect(driver.Close()).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewForkCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append([]string{"--sqlite", dbPath}, args...))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	It("prints an OpenAI request truncated at the node", func() {
+		out, err := run(answer.Hash[:10])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchJSON(`{
+			"model": "gpt-4o",
+			"messages": [
+				{"role": "system", "content": "You are a coding agent."},
+				{"role": "user", "content": "List the files."},
+				{"role": "assistant", "content": "main.go"}
+			]
+		}`))
+	})
+
+	It("prints an Anthropic request with another model", func() {
+		out, err := run(answer.Hash, "--format", "anthropic", "--model", "claude-sonnet-4-5", "--max-tokens", "512")
+		Expect(err).NotTo(HaveOccurred())
+
+		var req map[string]any
+		Expect(json.Unmarshal([]byte(out), &req)).To(Succeed())
+		Expect(req).To(HaveKeyWithValue("model", "claude-sonnet-4-5"))
+		Expect(req).To(HaveKeyWithValue("max_tokens", BeNumerically("==", 512)))
+		Expect(req).To(HaveKeyWithValue("system", "You are a coding agent."))
+		Expect(req["messages"]).To(HaveLen(2))
+	})
+
+	It("rejects formats it cannot write", func() {
+		_, err := run(answer.Hash, "--format", "ollama")
+		Expect(err).To(MatchError(ContainSubstring("cannot be written in ollama format")))
+	})
+})
@@ -0,0 +1,118 @@
+package logscmder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/api"
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/start"
+)
+
+// requestTimeout bounds each request to the daemon.
+const requestTimeout = 5 * time.Second
+
+func newLevelCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "level [debug|info|warn|error]",
+		Short: "Show or change the level the running daemon logs at",
+		Long: `Show the level the running tapes start daemon logs at, or change it.
+
+The change lasts until the daemon stops: set logs.level to keep it.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configDir, _ := cmd.Flags().GetString("config-dir")
+			method, body := http.MethodGet, ""
+			if len(args) == 1 {
+				payload, err := json.Marshal(api.LogLevel{Level: args[0]})
+				if err != nil {
+					return err
+				}
+				method, body = http.MethodPut, string(payload)
+			}
+
+			level, err := requestLogLevel(cmd.Context(), configDir, method, body)
+			if err != nil {
+				return err
+			}
+			if method == http.MethodPut {
+				fmt.Fprintf(cmd.OutOrStdout(), "Daemon now logs at %s.\n", level.Level)
+				return nil
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), level.Level)
+			return nil
+		},
+	}
+}
+
+// requestLogLevel sends a request to /v1/log-level of the running daemon of
+// configDir.
+func requestLogLevel(ctx context.Context, configDir, method, body string) (*api.LogLevel, error) {
+	manager, err := start.NewManager(configDir)
+	if err != nil {
+		return nil, err
+	}
+	state, err := manager.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	if state == nil || state.APIURL == "" || !start.ProcessAlive(state.DaemonPID) {
+		return nil, errors.New("no tapes start daemon is running")
+	}
+
+	cfger, err := config.NewConfiger(configDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	cfg, err := cfger.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	url := strings.TrimRight(state.APIURL, "/") + "/v1/log-level"
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader([]byte(body)))
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if cfg.API.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.API.Token)
+	}
+
+	client := &http.Client{Timeout: requestTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("contacting daemon: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr llm.ErrorResponse
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Error != "" {
+			return nil, fmt.Errorf("daemon returned %s: %s", resp.Status, apiErr.Error)
+		}
+		return nil, fmt.Errorf("daemon returned %s", resp.Status)
+	}
+
+	level := &api.LogLevel{}
+	if err := json.Unmarshal(data, level); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return level, nil
+}
@@ -0,0 +1,105 @@
+// Package logscmder provides the `tapes logs` CLI commands for reading the
+// tapes start daemon's logs and changing their level while it runs.
+package logscmder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/papercomputeco/tapes/pkg/logger"
+	"github.com/papercomputeco/tapes/pkg/start"
+)
+
+// defaultLines is how many recent entries are shown without --lines.
+const defaultLines = 100
+
+const logsLongDesc string = `Show the logs of the tapes start daemon.
+
+The daemon writes structured logs to .tapes/logs/tapes.log, rotated to
+tapes.log.1, tapes.log.2, and so on once they reach logs.max_file_size
+(10MB by default), keeping logs.max_files of them (5 by default). Output
+the daemon writes outside of its logs, such as the trace of a crash, goes to
+.tapes/logs/daemon.out.
+
+The daemon logs at logs.level (info by default, or debug with --debug),
+which "tapes logs level" changes while it runs. --level filters what is
+shown, down to the level that was logged.
+
+Examples:
+  tapes logs
+  tapes logs -f --level warn
+  tapes logs -n 0
+  tapes logs level debug`
+
+const logsShortDesc string = "Show the tapes start daemon's logs"
+
+type logsCommander struct {
+	follow bool
+	level  string
+	lines  int
+}
+
+// NewLogsCmd creates the logs command.
+func NewLogsCmd() *cobra.Command {
+	cmder := &logsCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: logsShortDesc,
+		Long:  logsLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&cmder.follow, "follow", "f", false, "Keep showing entries as they are logged")
+	cmd.Flags().StringVar(&cmder.level, "level", "debug", "Only show entries at or above this level (debug, info, warn, error)")
+	cmd.Flags().IntVarP(&cmder.lines, "lines", "n", defaultLines, "Number of recent entries to show (0 for all)")
+
+	cmd.AddCommand(newLevelCmd())
+
+	return cmd
+}
+
+func (c *logsCommander) run(cmd *cobra.Command) error {
+	level, err := zapcore.ParseLevel(c.level)
+	if err != nil {
+		return fmt.Errorf("invalid --level: %w", err)
+	}
+	if c.lines < 0 {
+		return errors.New("--lines must not be negative")
+	}
+	lines := c.lines
+	if lines == 0 {
+		lines = -1
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	manager, err := start.NewManager(configDir)
+	if err != nil {
+		return err
+	}
+	path := manager.LogPath
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errors.New("no start logs found: run tapes start first")
+		}
+		return fmt.Errorf("checking log file: %w", err)
+	}
+
+	err = logger.Tail(cmd.Context(), path, cmd.OutOrStdout(), logger.TailOptions{
+		Level:  level,
+		Lines:  lines,
+		Follow: c.follow,
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,13 @@
+package logscmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLogsCommander(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Logs Commander Suite")
+}
@@ -0,0 +1,106 @@
+package logscmder
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/api"
+	"github.com/papercomputeco/tapes/pkg/start"
+)
+
+var _ = Describe("logs command", func() {
+	var (
+		configDir string
+		manager   *start.Manager
+	)
+
+	BeforeEach(func() {
+		configDir = GinkgoT().TempDir()
+		var err error
+		manager, err = start.NewManager(configDir)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewLogsCmd()
+		cmd.PersistentFlags().String("config-dir", "", "Override path to .tapes/ config directory")
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		cmd.SetArgs(append(args, "--config-dir", configDir))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	entry := func(level, msg string) string {
+		return `{"level":"` + level + `","time":"2026-01-02T03:04:05.000Z","msg":"` + msg + `"}` + "\n"
+	}
+
+	It("shows recent entries at or above a level", func() {
+		Expect(os.WriteFile(manager.LogPath, []byte(
+			entry("debug", "probing")+entry("info", "started")+entry("warn", "slow upstream")+entry("error", "upstream failed"),
+		), 0o600)).To(Succeed())
+
+		out, err := run()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Count(out, "\n")).To(Equal(4))
+
+		out, err = run("--level", "warn", "-n", "1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("ERROR\tupstream failed"))
+		Expect(out).NotTo(ContainSubstring("slow upstream"))
+	})
+
+	It("reports a missing log file and invalid flags", func() {
+		_, err := run()
+		Expect(err).To(MatchError(ContainSubstring("no start logs found")))
+
+		_, err = run("--level", "loud")
+		Expect(err).To(MatchError(ContainSubstring("invalid --level")))
+	})
+
+	Describe("level", func() {
+		var level string
+
+		BeforeEach(func() {
+			level = "info"
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /v1/log-level", func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode(api.LogLevel{Level: level})
+			})
+			mux.HandleFunc("PUT /v1/log-level", func(w http.ResponseWriter, r *http.Request) {
+				var req api.LogLevel
+				Expect(json.NewDecoder(r.Body).Decode(&req)).To(Succeed())
+				level = req.Level
+				_ = json.NewEncoder(w).Encode(req)
+			})
+			daemon := httptest.NewServer(mux)
+			DeferCleanup(daemon.Close)
+
+			Expect(manager.SaveState(&start.State{DaemonPID: os.Getpid(), APIURL: daemon.URL})).To(Succeed())
+		})
+
+		It("shows and changes the daemon's level", func() {
+			out, err := run("level")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("info\n"))
+
+			out, err = run("level", "debug")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("Daemon now logs at debug.\n"))
+			Expect(level).To(Equal("debug"))
+		})
+	})
+
+	It("fails to change the level without a running daemon", func() {
+		_, err := run("level", "warn")
+		Expect(err).To(MatchError("no tapes start daemon is running"))
+	})
+})
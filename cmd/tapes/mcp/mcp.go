@@ -0,0 +1,103 @@
+// Package mcpcmder provides the mcp command, which serves recorded sessions
+// to agents over the Model Context Protocol.
+package mcpcmder
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/api/mcp"
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/logger"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+const mcpLongDesc string = `Serve recorded sessions to agents over MCP.
+
+Runs a Model Context Protocol server on stdin and stdout so an agent can query
+its own past sessions as memory, e.g. "what did I try last time this test
+failed?". The server offers three tools backed by the local SQLite database:
+
+  list_sessions    List recent sessions with cost, model, and project
+  search_sessions  Full-text search over messages and tool calls
+  get_session      Read a session's messages in order
+
+Agents launch the command themselves. For example, with Claude Code:
+
+  claude mcp add tapes -- tapes mcp
+
+The same tools are served over HTTP at /v1/mcp by "tapes serve" and
+"tapes start" when they use SQLite storage.
+
+Examples:
+  tapes mcp
+  tapes mcp --sqlite ./tapes.db`
+
+const mcpShortDesc string = "Serve recorded sessions to agents over MCP"
+
+type mcpCommander struct {
+	sqlitePath  string
+	pricingPath string
+	debug       bool
+}
+
+func NewMCPCmd() *cobra.Command {
+	cmder := &mcpCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: mcpShortDesc,
+		Long:  mcpLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			configDir, _ := cmd.Flags().GetString("config-dir")
+			cmder.debug, _ = cmd.Flags().GetBool("debug")
+			driverOpts, err := sqlitepath.DriverOptions(cmd)
+			if err != nil {
+				return err
+			}
+			return cmder.run(cmd.Context(), configDir, driverOpts, cmd.ErrOrStderr())
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().StringVar(&cmder.pricingPath, "pricing", "", "Path to pricing TOML or JSON overrides")
+
+	return cmd
+}
+
+func (c *mcpCommander) run(ctx context.Context, configDir string, driverOpts []sqlite.Option, errOut io.Writer) error {
+	// stdout carries the protocol, so logs go to stderr.
+	zapLogger := logger.NewLoggerWithWriters(c.debug, errOut)
+	defer func() { _ = zapLogger.Sync() }()
+
+	pricing, err := deck.ResolvePricing(configDir, c.pricingPath)
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+
+	query, closeQuery, err := deck.NewQuery(ctx, dbPath, pricing, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = closeQuery() }()
+
+	server, err := mcp.NewServer(mcp.Config{
+		Sessions: query,
+		Logger:   zapLogger,
+	})
+	if err != nil {
+		return fmt.Errorf("creating MCP server: %w", err)
+	}
+
+	return server.RunStdio(ctx)
+}
@@ -0,0 +1,13 @@
+package mcpcmder_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestMCP(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "MCP Command Suite")
+}
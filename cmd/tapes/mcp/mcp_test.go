@@ -0,0 +1,23 @@
+package mcpcmder_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	mcpcmder "github.com/papercomputeco/tapes/cmd/tapes/mcp"
+)
+
+var _ = Describe("NewMCPCmd", func() {
+	It("creates the mcp command with database flags", func() {
+		cmd := mcpcmder.NewMCPCmd()
+		Expect(cmd.Use).To(Equal("mcp"))
+		Expect(cmd.Flags().Lookup("sqlite")).NotTo(BeNil())
+		Expect(cmd.Flags().Lookup("pricing")).NotTo(BeNil())
+	})
+
+	It("rejects positional arguments", func() {
+		cmd := mcpcmder.NewMCPCmd()
+		cmd.SetArgs([]string{"extra"})
+		Expect(cmd.Execute()).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,66 @@
+package pricingcmder
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+const importLongDesc string = `Import model rates from a TOML or JSON file into pricing.toml.
+
+TOML files use the pricing.toml layout. JSON files map model names to rates,
+as accepted by --pricing:
+
+  {"gpt-5": {"input": 1.25, "output": 10, "cache_read": 0.125, "cache_write": 1.25}}
+
+Imported models replace existing entries for the same model. Use --replace to
+discard all existing entries first.`
+
+func newImportCmd() *cobra.Command {
+	var replace bool
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import model rates from a file",
+		Long:  importLongDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(cmd, args[0], replace)
+		},
+	}
+
+	cmd.Flags().BoolVar(&replace, "replace", false, "Discard existing entries in pricing.toml before importing")
+
+	return cmd
+}
+
+func runImport(cmd *cobra.Command, file string, replace bool) error {
+	imported, err := deck.ReadPricingFile(file)
+	if err != nil {
+		return err
+	}
+
+	path, err := pricingFilePath(cmd)
+	if err != nil {
+		return err
+	}
+
+	overrides := deck.PricingTable{}
+	if !replace {
+		overrides, err = readOverrides(path)
+		if err != nil {
+			return err
+		}
+	}
+	maps.Copy(overrides, imported)
+
+	if err := deck.WritePricingFile(path, overrides); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Imported %d models into %s.\n", len(imported), path)
+	return nil
+}
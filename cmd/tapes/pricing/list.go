@@ -0,0 +1,94 @@
+package pricingcmder
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+// Pricing sources shown by list.
+const (
+	sourceDefault  = "default"
+	sourceOverride = "pricing.toml"
+)
+
+type pricingEntry struct {
+	Model  string `json:"model"`
+	Source string `json:"source"`
+	deck.Pricing
+}
+
+func newListCmd() *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List effective model pricing",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runList(cmd, asJSON)
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Output pricing as JSON")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, asJSON bool) error {
+	path, err := pricingFilePath(cmd)
+	if err != nil {
+		return err
+	}
+	overrides, err := readOverrides(path)
+	if err != nil {
+		return err
+	}
+
+	pricing := deck.DefaultPricing()
+	sources := make(map[string]string, len(pricing)+len(overrides))
+	for model := range pricing {
+		sources[model] = sourceDefault
+	}
+	for model, p := range overrides {
+		pricing[model] = p
+		sources[model] = sourceOverride
+	}
+
+	models := make([]string, 0, len(pricing))
+	for model := range pricing {
+		models = append(models, model)
+	}
+	slices.Sort(models)
+
+	entries := make([]pricingEntry, 0, len(models))
+	for _, model := range models {
+		entries = append(entries, pricingEntry{Model: model, Source: sources[model], Pricing: pricing[model]})
+	}
+
+	w := cmd.OutOrStdout()
+	if asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	fmt.Fprintln(w, "Rates in dollars per million tokens.")
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODEL\tINPUT\tOUTPUT\tCACHE READ\tCACHE WRITE\tSOURCE")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Model, formatRate(e.Input), formatRate(e.Output), formatRate(e.CacheRead), formatRate(e.CacheWrite), e.Source)
+	}
+	return tw.Flush()
+}
+
+func formatRate(rate float64) string {
+	return fmt.Sprintf("$%.3f", rate)
+}
@@ -0,0 +1,79 @@
+// Package pricingcmder provides the `tapes pricing` CLI commands for
+// managing the per-model rates used to compute costs.
+package pricingcmder
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+const pricingLongDesc string = `Manage the per-model rates used to compute costs.
+
+tapes ships with list prices for common models. Rates in pricing.toml in the
+.tapes/ directory override them, so new models and negotiated rates produce
+correct costs in tapes deck and tapes costs. Rates are in dollars per million
+tokens:
+
+  [models."claude-sonnet-4.6"]
+  input = 3.00
+  output = 15.00
+  cache_read = 0.30
+  cache_write = 3.75
+
+Model names may use wildcards, such as "gpt-5*". Models without an exact
+entry use the most specific matching wildcard, or else the longest entry they
+extend with a dash, so "o3" also prices "o3-pro".
+
+Examples:
+  tapes pricing list
+  tapes pricing set gpt-5 --input 1.25 --output 10 --cache-read 0.125
+  tapes pricing set "claude-*" --input 2.40 --output 12
+  tapes pricing import ./negotiated-rates.toml`
+
+const pricingShortDesc string = "Manage model pricing"
+
+// NewPricingCmd creates the parent pricing command.
+func NewPricingCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pricing",
+		Short: pricingShortDesc,
+		Long:  pricingLongDesc,
+	}
+
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newSetCmd())
+	cmd.AddCommand(newImportCmd())
+
+	return cmd
+}
+
+// pricingFilePath resolves pricing.toml for the command's --config-dir.
+func pricingFilePath(cmd *cobra.Command) (string, error) {
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	path, err := deck.PricingFilePath(configDir)
+	if err != nil {
+		return "", err
+	}
+	if path == "" {
+		return "", errors.New("no .tapes/ directory found: run 'tapes init' or pass --config-dir")
+	}
+	return path, nil
+}
+
+// readOverrides reads pricing.toml, returning an empty table when it does not
+// exist yet.
+func readOverrides(path string) (deck.PricingTable, error) {
+	overrides, err := deck.ReadPricingFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return deck.PricingTable{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,73 @@
+package pricingcmder
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+var _ = Describe("pricing command", func() {
+	var configDir string
+
+	BeforeEach(func() {
+		configDir = GinkgoT().TempDir()
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewPricingCmd()
+		cmd.PersistentFlags().String("config-dir", "", "")
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append(args, "--config-dir", configDir))
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	overrides := func() deck.PricingTable {
+		table, err := deck.ReadPricingFile(filepath.Join(configDir, deck.PricingFile))
+		Expect(err).NotTo(HaveOccurred())
+		return table
+	}
+
+	It("sets rates on top of the built-in price", func() {
+		out, err := run("set", "gpt-4o", "--input", "2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Set gpt-4o: input $2.000, output $10.000"))
+		Expect(overrides()).To(HaveKeyWithValue("gpt-4o", deck.Pricing{Input: 2, Output: 10, CacheRead: 1.25, CacheWrite: 2.50}))
+
+		out, err = run("list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchRegexp(`gpt-4o\s+\$2\.000\s+\$10\.000\s+\$1\.250\s+\$2\.500\s+pricing\.toml`))
+		Expect(out).To(MatchRegexp(`o3\s+\$2\.000\s+\$8\.000\s+\$0\.500\s+\$2\.000\s+default`))
+	})
+
+	It("requires at least one rate", func() {
+		_, err := run("set", "gpt-5")
+		Expect(err).To(MatchError(ContainSubstring("no rates given")))
+	})
+
+	It("imports rates from JSON and TOML files", func() {
+		_, err := run("set", "gpt-4o", "--input", "2")
+		Expect(err).NotTo(HaveOccurred())
+
+		jsonPath := filepath.Join(GinkgoT().TempDir(), "rates.json")
+		Expect(os.WriteFile(jsonPath, []byte(`{"gpt-5*": {"input": 1.25, "output": 10}}`), 0o600)).To(Succeed())
+		out, err := run("import", jsonPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Imported 1 models"))
+		Expect(overrides()).To(HaveKey("gpt-4o"))
+		Expect(overrides()).To(HaveKeyWithValue("gpt-5*", deck.Pricing{Input: 1.25, Output: 10}))
+
+		tomlPath := filepath.Join(GinkgoT().TempDir(), "rates.toml")
+		Expect(os.WriteFile(tomlPath, []byte("[models.o3]\ninput = 1.5\noutput = 6\n"), 0o600)).To(Succeed())
+		_, err = run("import", tomlPath, "--replace")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overrides()).To(Equal(deck.PricingTable{"o3": {Input: 1.5, Output: 6}}))
+	})
+})
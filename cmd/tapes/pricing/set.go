@@ -0,0 +1,83 @@
+package pricingcmder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+const setLongDesc string = `Set the rates for a model in pricing.toml.
+
+Rates not given keep their current value, starting from the built-in price
+for known models. Model names may use wildcards, such as "gpt-5*".`
+
+func newSetCmd() *cobra.Command {
+	var rates deck.Pricing
+
+	cmd := &cobra.Command{
+		Use:   "set <model>",
+		Short: "Set the rates for a model",
+		Long:  setLongDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSet(cmd, args[0], rates)
+		},
+	}
+
+	cmd.Flags().Float64Var(&rates.Input, "input", 0, "Input rate per million tokens")
+	cmd.Flags().Float64Var(&rates.Output, "output", 0, "Output rate per million tokens")
+	cmd.Flags().Float64Var(&rates.CacheRead, "cache-read", 0, "Cache read rate per million tokens")
+	cmd.Flags().Float64Var(&rates.CacheWrite, "cache-write", 0, "Cache write rate per million tokens")
+
+	return cmd
+}
+
+func runSet(cmd *cobra.Command, model string, rates deck.Pricing) error {
+	flags := cmd.Flags()
+	if !flags.Changed("input") && !flags.Changed("output") && !flags.Changed("cache-read") && !flags.Changed("cache-write") {
+		return errors.New("no rates given: use --input, --output, --cache-read, or --cache-write")
+	}
+	for _, rate := range []float64{rates.Input, rates.Output, rates.CacheRead, rates.CacheWrite} {
+		if rate < 0 {
+			return errors.New("rates must not be negative")
+		}
+	}
+
+	path, err := pricingFilePath(cmd)
+	if err != nil {
+		return err
+	}
+	overrides, err := readOverrides(path)
+	if err != nil {
+		return err
+	}
+
+	current, ok := overrides[model]
+	if !ok {
+		current = deck.DefaultPricing()[model]
+	}
+	if flags.Changed("input") {
+		current.Input = rates.Input
+	}
+	if flags.Changed("output") {
+		current.Output = rates.Output
+	}
+	if flags.Changed("cache-read") {
+		current.CacheRead = rates.CacheRead
+	}
+	if flags.Changed("cache-write") {
+		current.CacheWrite = rates.CacheWrite
+	}
+	overrides[model] = current
+
+	if err := deck.WritePricingFile(path, overrides); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Set %s: input %s, output %s, cache read %s, cache write %s per million tokens.\n",
+		model, formatRate(current.Input), formatRate(current.Output), formatRate(current.CacheRead), formatRate(current.CacheWrite))
+	return nil
+}
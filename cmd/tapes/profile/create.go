@@ -0,0 +1,143 @@
+package profilecmder
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/dotdir"
+)
+
+const createLongDesc string = `Create a profile.
+
+Creates ~/.tapes/profiles/<name>/ with a config.toml whose proxy and API
+ports differ from the default profile and from other profiles, so "tapes
+serve" can run for several profiles at once. "tapes start" picks free ports
+on its own.
+
+Examples:
+  tapes profile create work
+  tapes profile create client-acme`
+
+const createShortDesc string = "Create a profile"
+
+// Profiles get ports portStride apart, starting above the default ports.
+const (
+	baseProxyPort = 8080
+	baseAPIPort   = 8081
+	portStride    = 10
+)
+
+func newCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: createShortDesc,
+		Long:  createLongDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(cmd, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runCreate(cmd *cobra.Command, name string) error {
+	if name == dotdir.DefaultProfile {
+		return fmt.Errorf("%q is the unscoped profile and always exists", name)
+	}
+
+	manager := dotdir.NewManager()
+	dir, err := manager.ProfileDir(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("profile %q already exists", name)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("checking profile: %w", err)
+	}
+
+	slot, err := freeSlot(manager)
+	if err != nil {
+		return err
+	}
+
+	cfger, err := config.NewConfiger(dir)
+	if err != nil {
+		return fmt.Errorf("creating profile: %w", err)
+	}
+	if err := cfger.SaveConfig(profileConfig(slot)); err != nil {
+		return fmt.Errorf("creating profile: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Created profile %s in %s\n", name, dir)
+	fmt.Fprintf(cmd.OutOrStdout(), "Run 'tapes profile use %s' to make it the default.\n", name)
+	return nil
+}
+
+// freeSlot returns the lowest profile slot whose ports no existing profile
+// listens on. Slots are not tied to the profile count, so the ports of a
+// deleted profile are reused rather than colliding with a later one.
+func freeSlot(manager *dotdir.Manager) (int, error) {
+	profiles, err := manager.Profiles()
+	if err != nil {
+		return 0, err
+	}
+
+	used := map[int]bool{}
+	for _, name := range profiles {
+		dir, err := manager.ProfileDir(name)
+		if err != nil {
+			return 0, err
+		}
+		cfger, err := config.NewConfiger(dir)
+		if err != nil {
+			return 0, fmt.Errorf("reading profile %s: %w", name, err)
+		}
+		cfg, err := cfger.LoadFileConfig()
+		if err != nil {
+			return 0, fmt.Errorf("reading profile %s: %w", name, err)
+		}
+		for _, listen := range []string{cfg.Proxy.Listen, cfg.API.Listen} {
+			if port, ok := listenPort(listen); ok {
+				used[port] = true
+			}
+		}
+	}
+
+	slot := 1
+	for used[baseProxyPort+slot*portStride] || used[baseAPIPort+slot*portStride] {
+		slot++
+	}
+	return slot, nil
+}
+
+// listenPort returns the port of a listen address such as ":8090".
+func listenPort(listen string) (int, bool) {
+	_, port, err := net.SplitHostPort(listen)
+	if err != nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(port)
+	return n, err == nil
+}
+
+// profileConfig returns the default config with the ports of the nth
+// profile slot.
+func profileConfig(n int) *config.Config {
+	cfg := config.NewDefaultConfig()
+	proxyPort := strconv.Itoa(baseProxyPort + n*portStride)
+	apiPort := strconv.Itoa(baseAPIPort + n*portStride)
+
+	cfg.Proxy.Listen = ":" + proxyPort
+	cfg.API.Listen = ":" + apiPort
+	cfg.Client.ProxyTarget = "http://" + net.JoinHostPort("localhost", proxyPort)
+	cfg.Client.APITarget = "http://" + net.JoinHostPort("localhost", apiPort)
+	return cfg
+}
@@ -0,0 +1,62 @@
+package profilecmder
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/dotdir"
+)
+
+const listLongDesc string = `List profiles.
+
+The active profile is marked with an asterisk. The default profile is the
+unscoped ~/.tapes/ directory (or a local .tapes/ directory).
+
+Examples:
+  tapes profile list`
+
+const listShortDesc string = "List profiles"
+
+func newListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: listShortDesc,
+		Long:  listLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runList(cmd)
+		},
+	}
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command) error {
+	manager := dotdir.NewManager()
+	names, err := manager.Profiles()
+	if err != nil {
+		return err
+	}
+
+	active, _ := cmd.Flags().GetString("profile")
+	if active == "" {
+		active, err = manager.ActiveProfile()
+		if err != nil {
+			return err
+		}
+	}
+	if active == "" {
+		active = dotdir.DefaultProfile
+	}
+
+	out := cmd.OutOrStdout()
+	for _, name := range append([]string{dotdir.DefaultProfile}, names...) {
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		fmt.Fprintf(out, "%s %s\n", marker, name)
+	}
+	return nil
+}
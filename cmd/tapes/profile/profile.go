@@ -0,0 +1,117 @@
+// Package profilecmder provides the profile command for running several
+// isolated tapes instances, each with its own config, database, daemon
+// state, and ports.
+package profilecmder
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/dotdir"
+)
+
+const profileLongDesc string = `Manage named tapes profiles.
+
+Each profile is a separate .tapes/ directory under ~/.tapes/profiles/ with its
+own config.toml, database, daemon state, and listen ports, so recordings from
+unrelated contexts (work, personal, a client) never mix.
+
+Select a profile for one command with --profile, for one shell with
+TAPES_PROFILE, or for every command with "tapes profile use". An explicit
+--config-dir takes precedence over the profile.
+
+Use subcommands to manage profiles:
+  tapes profile list            List profiles and show the active one
+  tapes profile create <name>   Create a profile
+  tapes profile use <name>      Use a profile by default ("default" to stop)
+
+Examples:
+  tapes profile create work
+  tapes profile use work
+  tapes --profile personal start claude`
+
+const profileShortDesc string = "Manage named tapes profiles"
+
+func NewProfileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: profileShortDesc,
+		Long:  profileLongDesc,
+		// Profile commands manage profiles and must work even when the
+		// active profile is missing, so the root profile hook is skipped.
+		PersistentPreRunE: func(*cobra.Command, []string) error { return nil },
+	}
+
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newCreateCmd())
+	cmd.AddCommand(newUseCmd())
+
+	return cmd
+}
+
+// Apply scopes cmd to the selected profile by pointing --config-dir at the
+// profile's directory. Commands that locate the database without a config
+// directory are pointed at the profile's database through TAPES_SQLITE.
+func Apply(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("config-dir") {
+		return nil
+	}
+
+	name, _ := cmd.Flags().GetString("profile")
+	manager := dotdir.NewManager()
+	if name == "" {
+		var err error
+		name, err = manager.ActiveProfile()
+		if err != nil {
+			return err
+		}
+	}
+	if name == "" || name == dotdir.DefaultProfile {
+		return nil
+	}
+
+	dir, err := manager.ProfileDir(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("profile %q does not exist: run 'tapes profile create %s'", name, name)
+	}
+
+	if err := cmd.Flags().Set("config-dir", dir); err != nil {
+		return fmt.Errorf("selecting profile %q: %w", name, err)
+	}
+
+	if os.Getenv("TAPES_SQLITE") == "" && os.Getenv("TAPES_DB") == "" {
+		sqlitePath, err := profileSQLitePath(dir)
+		if err != nil {
+			return err
+		}
+		if err := os.Setenv("TAPES_SQLITE", sqlitePath); err != nil {
+			return fmt.Errorf("selecting profile %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// profileSQLitePath returns the database of the profile in dir, as resolved
+// by "tapes start" and "tapes serve".
+func profileSQLitePath(dir string) (string, error) {
+	cfger, err := config.NewConfiger(dir)
+	if err != nil {
+		return "", fmt.Errorf("loading config: %w", err)
+	}
+	cfg, err := cfger.LoadConfig()
+	if err != nil {
+		return "", fmt.Errorf("loading config: %w", err)
+	}
+	if cfg.Storage.SQLitePath != "" {
+		return cfg.Storage.SQLitePath, nil
+	}
+	return filepath.Join(dir, "tapes.sqlite"), nil
+}
@@ -0,0 +1,13 @@
+package profilecmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestProfile(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Profile Command Suite")
+}
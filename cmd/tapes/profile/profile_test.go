@@ -0,0 +1,150 @@
+package profilecmder
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/dotdir"
+)
+
+var _ = Describe("profile command", func() {
+	var home string
+
+	BeforeEach(func() {
+		home = GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", home)
+		GinkgoT().Setenv(dotdir.ProfileEnv, "")
+		GinkgoT().Setenv("TAPES_SQLITE", "")
+		GinkgoT().Setenv("TAPES_DB", "")
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewProfileCmd()
+		cmd.PersistentFlags().String("profile", "", "")
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return buf.String(), err
+	}
+
+	It("creates profiles with their own ports", func() {
+		_, err := run("create", "work")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = run("create", "personal")
+		Expect(err).NotTo(HaveOccurred())
+
+		cfger, err := config.NewConfiger(filepath.Join(home, ".tapes", "profiles", "personal"))
+		Expect(err).NotTo(HaveOccurred())
+		cfg, err := cfger.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Proxy.Listen).To(Equal(":8100"))
+		Expect(cfg.API.Listen).To(Equal(":8101"))
+		Expect(cfg.Client.ProxyTarget).To(Equal("http://localhost:8100"))
+
+		_, err = run("create", "work")
+		Expect(err).To(MatchError(ContainSubstring("already exists")))
+	})
+
+	It("reuses the ports of a deleted profile without colliding", func() {
+		for _, name := range []string{"one", "two", "three"} {
+			_, err := run("create", name)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(os.RemoveAll(filepath.Join(home, ".tapes", "profiles", "one"))).To(Succeed())
+
+		listen := func(name string) string {
+			cfger, err := config.NewConfiger(filepath.Join(home, ".tapes", "profiles", name))
+			Expect(err).NotTo(HaveOccurred())
+			cfg, err := cfger.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			return cfg.Proxy.Listen
+		}
+
+		_, err := run("create", "four")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listen("four")).To(Equal(":8090"))
+		_, err = run("create", "five")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(listen("five")).To(Equal(":8120"))
+	})
+
+	It("switches the active profile", func() {
+		_, err := run("use", "work")
+		Expect(err).To(MatchError(ContainSubstring("does not exist")))
+
+		_, err = run("create", "work")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = run("use", "work")
+		Expect(err).NotTo(HaveOccurred())
+
+		out, err := run("list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("  default\n* work\n"))
+
+		_, err = run("use", "default")
+		Expect(err).NotTo(HaveOccurred())
+		out, err = run("list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("* default\n  work\n"))
+	})
+})
+
+var _ = Describe("Apply", func() {
+	var home string
+
+	BeforeEach(func() {
+		home = GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", home)
+		GinkgoT().Setenv(dotdir.ProfileEnv, "")
+		GinkgoT().Setenv("TAPES_SQLITE", "")
+		GinkgoT().Setenv("TAPES_DB", "")
+	})
+
+	// newCmd returns a command with the root's profile flags, parsed from args.
+	newCmd := func(args ...string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("config-dir", "", "")
+		cmd.Flags().String("profile", "", "")
+		Expect(cmd.Flags().Parse(args)).To(Succeed())
+		return cmd
+	}
+
+	It("leaves commands unscoped without a profile", func() {
+		cmd := newCmd()
+		Expect(Apply(cmd)).To(Succeed())
+		Expect(cmd.Flags().Changed("config-dir")).To(BeFalse())
+		Expect(os.Getenv("TAPES_SQLITE")).To(BeEmpty())
+	})
+
+	It("scopes the config directory and database to the profile", func() {
+		dir := filepath.Join(home, ".tapes", "profiles", "work")
+		Expect(os.MkdirAll(dir, 0o755)).To(Succeed())
+
+		cmd := newCmd("--profile", "work")
+		Expect(Apply(cmd)).To(Succeed())
+
+		configDir, err := cmd.Flags().GetString("config-dir")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configDir).To(Equal(dir))
+		Expect(os.Getenv("TAPES_SQLITE")).To(Equal(filepath.Join(dir, "tapes.sqlite")))
+	})
+
+	It("uses the active profile and prefers an explicit config directory", func() {
+		Expect(dotdir.NewManager().SetActiveProfile("missing")).To(Succeed())
+		Expect(Apply(newCmd())).To(MatchError(ContainSubstring(`profile "missing" does not exist`)))
+
+		cmd := newCmd("--config-dir", "/tmp/elsewhere")
+		Expect(Apply(cmd)).To(Succeed())
+		configDir, err := cmd.Flags().GetString("config-dir")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configDir).To(Equal("/tmp/elsewhere"))
+	})
+})
@@ -0,0 +1,59 @@
+package profilecmder
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/dotdir"
+)
+
+const useLongDesc string = `Use a profile by default.
+
+Later commands use the profile unless --profile, TAPES_PROFILE, or
+--config-dir selects another. Use "default" to return to the unscoped
+~/.tapes/ directory.
+
+Examples:
+  tapes profile use work
+  tapes profile use default`
+
+const useShortDesc string = "Use a profile by default"
+
+func newUseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "use <name>",
+		Short: useShortDesc,
+		Long:  useLongDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUse(cmd, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runUse(cmd *cobra.Command, name string) error {
+	manager := dotdir.NewManager()
+	if name != dotdir.DefaultProfile {
+		dir, err := manager.ProfileDir(name)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("profile %q does not exist: run 'tapes profile create %s'", name, name)
+		}
+	}
+
+	if err := manager.SetActiveProfile(name); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Using profile %s\n", name)
+	if os.Getenv(dotdir.ProfileEnv) != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Note: %s is set and overrides this in the current shell.\n", dotdir.ProfileEnv)
+	}
+	return nil
+}
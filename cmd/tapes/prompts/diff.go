@@ -0,0 +1,81 @@
+package promptscmder
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/deckquery"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+func newDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff <prompt-a> <prompt-b>",
+		Short: "Compare two system prompt versions",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd.Context(), cmd, args[0], args[1])
+		},
+	}
+
+	return cmd
+}
+
+func runDiff(ctx context.Context, cmd *cobra.Command, a, b string) error {
+	format, err := deckquery.Format(cmd)
+	if err != nil {
+		return err
+	}
+
+	query, closeFn, err := deckquery.Open(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = closeFn() }()
+
+	diff, err := query.DiffSystemPrompts(ctx, a, b)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if format == deckquery.FormatJSON {
+		return writeJSON(w, diff)
+	}
+	writeDiff(w, diff)
+	return nil
+}
+
+func writeDiff(w io.Writer, diff *deck.SystemPromptDiff) {
+	for _, side := range []struct {
+		name    string
+		version deck.SystemPromptVersion
+	}{{"A", diff.A}, {"B", diff.B}} {
+		fmt.Fprintf(w, "%s  %s  %d sessions  %d requests  %s  %s → %s\n",
+			side.name,
+			cliui.ShortHash(side.version.Hash),
+			side.version.Sessions,
+			side.version.Requests,
+			cliui.FormatCost(side.version.TotalCost),
+			formatTime(side.version.FirstSeen),
+			formatTime(side.version.LastSeen),
+		)
+	}
+	fmt.Fprintln(w)
+
+	if diff.A.Hash == diff.B.Hash {
+		fmt.Fprintln(w, "Identical prompts.")
+		return
+	}
+	for _, line := range diff.Lines {
+		op := " "
+		if line.Op != deck.DiffEqual {
+			op = line.Op
+		}
+		fmt.Fprintf(w, "%s %s\n", op, line.Text)
+	}
+}
@@ -0,0 +1,94 @@
+package promptscmder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/deckquery"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+type listCommander struct {
+	since   string
+	session string
+}
+
+func newListCmd() *cobra.Command {
+	cmder := &listCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List system prompt versions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().StringVar(&cmder.since, "since", "", "Only include turns newer than this age (e.g. 30d, 2w, 12h)")
+	cmd.Flags().StringVar(&cmder.session, "session", "", "Only include the turns of this agent session")
+
+	return cmd
+}
+
+func (c *listCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	format, err := deckquery.Format(cmd)
+	if err != nil {
+		return err
+	}
+
+	opts := deck.SystemPromptOptions{Session: c.session}
+	if c.since != "" {
+		since, err := utils.ParseDuration(c.since)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		opts.Since = since
+	}
+
+	query, closeFn, err := deckquery.Open(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = closeFn() }()
+
+	versions, err := query.SystemPrompts(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if format == deckquery.FormatJSON {
+		return writeJSON(w, versions)
+	}
+	return writeVersionTable(w, versions)
+}
+
+func writeVersionTable(w io.Writer, versions []deck.SystemPromptVersion) error {
+	if len(versions) == 0 {
+		fmt.Fprintln(w, "No system prompts recorded.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "HASH\tFIRST SEEN\tLAST SEEN\tSESSIONS\tREQUESTS\tINPUT\tCOST\tPROMPT")
+	for _, v := range versions {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\n",
+			cliui.ShortHash(v.Hash),
+			formatTime(v.FirstSeen),
+			formatTime(v.LastSeen),
+			v.Sessions,
+			v.Requests,
+			cliui.FormatTokens(v.InputTokens),
+			cliui.FormatCost(v.TotalCost),
+			v.Preview,
+		)
+	}
+	return tw.Flush()
+}
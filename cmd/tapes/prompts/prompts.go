@@ -0,0 +1,62 @@
+// Package promptscmder provides the `tapes prompts` CLI commands for listing
+// the system prompts agents were run with and comparing their versions.
+package promptscmder
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/deckquery"
+)
+
+const promptsLongDesc string = `List and compare the system prompts captured through the proxy.
+
+Each distinct system prompt is stored once, by the hash of its text, and every
+turn records the prompt it was sent with. List shows each prompt version with
+when it was first and last seen, how many sessions and requests used it, and
+what they cost, so that prompt changes can be correlated with agent behavior
+and spend. Diff compares two versions line by line.
+
+Prompts are named by their hash or a unique prefix of one.
+
+Examples:
+  tapes prompts list
+  tapes prompts list --since 7d
+  tapes prompts list --session 9e0d-41a2 --format json
+  tapes prompts diff 3f2a9c1 8be41d0`
+
+const promptsShortDesc string = "List and compare system prompts"
+
+// NewPromptsCmd creates the parent prompts command.
+func NewPromptsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prompts",
+		Short: promptsShortDesc,
+		Long:  promptsLongDesc,
+	}
+
+	cmd.PersistentFlags().StringP("sqlite", "s", "", "Path to SQLite database")
+	cmd.PersistentFlags().String("pricing", "", "Path to pricing TOML or JSON overrides")
+	cmd.PersistentFlags().String("format", deckquery.FormatTable, "Output format: table|json")
+
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newDiffCmd())
+
+	return cmd
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
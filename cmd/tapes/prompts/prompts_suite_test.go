@@ -0,0 +1,13 @@
+package promptscmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPrompts(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Prompts Command Suite")
+}
@@ -0,0 +1,90 @@
+package promptscmder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("prompts command", func() {
+	var (
+		ctx          context.Context
+		dbPath       string
+		hashA, hashB string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		turn := func(prompt, text string) string {
+			hash, err := driver.AddSystemPrompt(ctx, prompt)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = driver.Put(ctx, merkle.NewNode(merkle.Bucket{
+				Type:      "message",
+				Role:      "assistant",
+				Content:   []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:     "gpt-4o",
+				Provider:  "openai",
+				SessionID: "s1",
+			}, nil, merkle.NodeMeta{
+				Usage:            &llm.Usage{PromptTokens: 1_000_000},
+				SystemPromptHash: hash,
+			}))
+			Expect(err).NotTo(HaveOccurred())
+			return hash
+		}
+		hashA = turn("You are a coding agent.\nBe concise.", "first")
+		hashB = turn("You are a coding agent.\nBe thorough.", "second")
+		Expect(driver.Close()).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewPromptsCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append(args, "--sqlite", dbPath))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	It("lists prompt versions with their costs", func() {
+		out, err := run("list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("HASH"))
+		Expect(out).To(MatchRegexp(hashA[:12] + `.+1\s+1\s+1\.0M\s+\$2\.50\s+You are a coding agent\.`))
+		Expect(out).To(ContainSubstring(hashB[:12]))
+	})
+
+	It("lists prompt versions as JSON", func() {
+		out, err := run("list", "--session", "s1", "--format", "json")
+		Expect(err).NotTo(HaveOccurred())
+
+		var versions []deck.SystemPromptVersion
+		Expect(json.Unmarshal([]byte(out), &versions)).To(Succeed())
+		Expect(versions).To(HaveLen(2))
+	})
+
+	It("diffs two prompt versions", func() {
+		out, err := run("diff", hashA[:8], hashB[:8])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("  You are a coding agent.\n- Be concise.\n+ Be thorough.\n"))
+	})
+
+	It("rejects an unknown format", func() {
+		_, err := run("list", "--format", "xml")
+		Expect(err).To(MatchError(ContainSubstring(`unsupported format "xml"`)))
+	})
+})
@@ -0,0 +1,206 @@
+// Package prunecmder provides the prune command for deleting old sessions
+// from the local SQLite store.
+package prunecmder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/audit"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/retention"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+const pruneLongDesc string = `Delete old sessions from the SQLite database.
+
+Sessions are deleted as whole trees, from the root turn through every branch,
+so pruning never leaves a turn whose ancestry has been removed. With --gc,
+turns already orphaned by a crash or a partial import are deleted along with
+them (see "tapes db gc"). Afterwards the database is vacuumed and the
+reclaimed space is reported.
+
+Limits default to the [retention] section of config.toml, which the daemon
+started by "tapes start" also enforces in the background:
+
+  [retention]
+  max_age = "30d"
+  max_db_size = "2GB"
+  max_sessions = 1000
+  gc = true          # also delete orphaned turns, in the daemon too
+
+Examples:
+  tapes prune --older-than 30d --dry-run
+  tapes prune --older-than 2w
+  tapes prune --max-sessions 500 --gc
+  tapes prune --max-db-size 1GB --sqlite ./tapes.db`
+
+const pruneShortDesc string = "Delete old sessions"
+
+type pruneCommander struct {
+	configDir   string
+	sqlitePath  string
+	olderThan   string
+	maxDBSize   string
+	maxSessions uint
+	gc          bool
+	dryRun      bool
+}
+
+func NewPruneCmd() *cobra.Command {
+	cmder := &pruneCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: pruneShortDesc,
+		Long:  pruneLongDesc,
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			configDir, _ := cmd.Flags().GetString("config-dir")
+			cfger, err := config.NewConfiger(configDir)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			cfg, err := cfger.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			cmder.configDir = configDir
+			if !cmd.Flags().Changed("older-than") {
+				cmder.olderThan = cfg.Retention.MaxAge
+			}
+			if !cmd.Flags().Changed("max-db-size") {
+				cmder.maxDBSize = cfg.Retention.MaxDBSize
+			}
+			if !cmd.Flags().Changed("max-sessions") {
+				cmder.maxSessions = cfg.Retention.MaxSessions
+			}
+			if !cmd.Flags().Changed("gc") {
+				cmder.gc = cfg.Retention.GC
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().StringVar(&cmder.olderThan, "older-than", "", "Delete sessions with no activity within this duration (e.g. 30d, 2w, 12h)")
+	cmd.Flags().StringVar(&cmder.maxDBSize, "max-db-size", "", "Delete the oldest sessions until the database fits this size (e.g. 2GB)")
+	cmd.Flags().UintVar(&cmder.maxSessions, "max-sessions", 0, "Keep only this many of the most recent sessions")
+	cmd.Flags().BoolVar(&cmder.gc, "gc", false, "Also delete orphaned turns (see tapes db gc)")
+	cmd.Flags().BoolVar(&cmder.dryRun, "dry-run", false, "Show what would be deleted without deleting anything")
+
+	return cmd
+}
+
+func (c *pruneCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	w := cmd.OutOrStdout()
+
+	policy, err := retention.ParsePolicy(c.olderThan, c.maxDBSize, c.maxSessions)
+	if err != nil {
+		return err
+	}
+	if policy.IsZero() {
+		return fmt.Errorf("no retention limits set: use --older-than, --max-db-size, or --max-sessions, or configure [retention] in config.toml")
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	driver, err := sqlite.NewDriver(ctx, dbPath, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer driver.Close()
+
+	pruner := retention.NewPruner(driver, policy)
+	pruner.GC = c.gc
+	plan, err := pruner.Plan(ctx)
+	if err != nil {
+		return err
+	}
+
+	if plan.Empty() {
+		fmt.Fprintf(w, "Nothing to prune (%d sessions within limits).\n", plan.TotalSessions)
+		return nil
+	}
+
+	printPlan(w, plan)
+
+	if c.dryRun {
+		fmt.Fprintf(w, "\nDry run: would delete %d of %d sessions (%d turns)", len(plan.Sessions), plan.TotalSessions, plan.Nodes)
+		if c.gc {
+			fmt.Fprintf(w, " and %d orphaned turns", len(plan.Orphans))
+		}
+		fmt.Fprintln(w, ".")
+		return nil
+	}
+
+	result, err := pruner.Apply(ctx, plan)
+	if err != nil {
+		return err
+	}
+
+	details := result.AuditDetails()
+	details["source"] = "cli"
+	if err := audit.Record(c.configDir, audit.ActionPrune, dbPath, details); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write audit log: %v\n", err)
+	}
+
+	fmt.Fprintf(w, "\n%s Deleted %d sessions (%d turns, %d facets), reclaimed %s.\n",
+		cliui.SuccessMark, result.Sessions, result.Nodes, result.Facets,
+		utils.FormatSize(result.ReclaimedBytes))
+	if result.Orphans > 0 {
+		fmt.Fprintf(w, "  Also deleted %d orphaned turns.\n", result.Orphans)
+	}
+	if result.Files > 0 {
+		fmt.Fprintf(w, "  Also deleted %d offloaded files.\n", result.Files)
+	}
+	return nil
+}
+
+func printPlan(w io.Writer, plan *retention.Plan) {
+	if len(plan.Sessions) > 0 {
+		fmt.Fprintf(w, "\nSessions to delete (%d)\n\n", len(plan.Sessions))
+		for _, s := range plan.Sessions {
+			fmt.Fprintf(w, "  %s  %s  %s  %s\n",
+				cliui.HashStyle.Render(s.Root[:min(12, len(s.Root))]),
+				cliui.DimStyle.Render(s.LastActivity.Local().Format("2006-01-02 15:04:05")),
+				cliui.ScoreStyle.Render(fmt.Sprintf("%d turns", len(s.NodeIDs))),
+				cliui.RoleStyle.Render(s.Reason),
+			)
+		}
+	}
+	if len(plan.Orphans) > 0 {
+		fmt.Fprintf(w, "\nOrphaned turns to delete (%d)\n\n", len(plan.Orphans))
+		printOrphans(w, plan.Orphans)
+	}
+}
+
+// printOrphans lists orphaned turns with the reason each is unreachable.
+func printOrphans(w io.Writer, orphans []retention.Orphan) {
+	for _, o := range orphans {
+		fmt.Fprintf(w, "  %s  %s  %s\n",
+			cliui.HashStyle.Render(o.Hash[:min(12, len(o.Hash))]),
+			cliui.DimStyle.Render(o.CreatedAt.Local().Format("2006-01-02 15:04:05")),
+			cliui.RoleStyle.Render(o.Reason),
+		)
+	}
+}
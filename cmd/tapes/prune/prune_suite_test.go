@@ -0,0 +1,13 @@
+package prunecmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestPrune(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Prune Command Suite")
+}
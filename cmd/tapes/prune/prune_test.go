@@ -0,0 +1,114 @@
+package prunecmder
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("prune command", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		old := time.Now().Add(-45 * 24 * time.Hour)
+		for _, id := range []string{"old-root", "new-root"} {
+			created := time.Now()
+			if id == "old-root" {
+				created = old
+			}
+			_, err := driver.Client.Node.Create().SetID(id).SetCreatedAt(created).Save(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err = driver.Client.Node.Create().
+			SetID("old-child").
+			SetParentHash("old-root").
+			SetCreatedAt(old).
+			Save(ctx)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewPruneCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append(args, "--sqlite", dbPath))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	countNodes := func() int {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		n, err := driver.Client.Node.Query().Count(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		return n
+	}
+
+	It("reports without deleting on a dry run", func() {
+		out, err := run("--older-than", "30d", "--dry-run")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Sessions to delete (1)"))
+		Expect(out).To(ContainSubstring("old-root"))
+		Expect(out).To(ContainSubstring("would delete 1 of 2 sessions (2 turns)"))
+		Expect(countNodes()).To(Equal(3))
+	})
+
+	It("deletes old sessions and reports reclaimed space", func() {
+		out, err := run("--older-than", "30d")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Deleted 1 sessions (2 turns, 0 facets), reclaimed"))
+		Expect(countNodes()).To(Equal(1))
+	})
+
+	It("reports when nothing needs pruning", func() {
+		out, err := run("--older-than", "90d")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Nothing to prune"))
+	})
+
+	It("deletes orphaned turns along with old sessions only with --gc", func() {
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = db.ExecContext(ctx,
+			"INSERT INTO nodes (hash, parent_hash, created_at) VALUES (?, ?, ?)",
+			"orphan", "missing", time.Now())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.Close()).To(Succeed())
+
+		out, err := run("--older-than", "90d")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Nothing to prune"))
+		Expect(countNodes()).To(Equal(4))
+
+		out, err = run("--older-than", "90d", "--gc")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Orphaned turns to delete (1)"))
+		Expect(out).To(ContainSubstring("Also deleted 1 orphaned turns."))
+		Expect(countNodes()).To(Equal(3))
+	})
+
+	It("rejects invalid durations", func() {
+		_, err := run("--older-than", "soon")
+		Expect(err).To(MatchError(ContainSubstring("invalid max age")))
+	})
+})
@@ -0,0 +1,71 @@
+// Package queuepolicy converts the proxy queue settings in config.toml into
+// the queue policy used by the proxy.
+package queuepolicy
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/utils"
+	"github.com/papercomputeco/tapes/proxy"
+	"github.com/papercomputeco/tapes/proxy/worker"
+)
+
+// FromConfig returns the queue policy for cfg. Unset settings keep the
+// worker defaults.
+func FromConfig(cfg config.QueueConfig) (proxy.QueuePolicy, error) {
+	policy := proxy.QueuePolicy{
+		Workers: cfg.Workers,
+		Size:    cfg.QueueSize,
+	}
+
+	var err error
+	policy.Overflow, err = worker.ParseOverflowPolicy(cfg.OverflowPolicy)
+	if err != nil {
+		return proxy.QueuePolicy{}, err
+	}
+	if cfg.EnqueueTimeout != "" {
+		policy.EnqueueTimeout, err = utils.ParseDuration(cfg.EnqueueTimeout)
+		if err != nil {
+			return proxy.QueuePolicy{}, fmt.Errorf("invalid enqueue timeout: %w", err)
+		}
+	}
+	if cfg.JobTimeout != "" {
+		policy.JobTimeout, err = utils.ParseDuration(cfg.JobTimeout)
+		if err != nil {
+			return proxy.QueuePolicy{}, fmt.Errorf("invalid job timeout: %w", err)
+		}
+	}
+	return policy, nil
+}
+
+// AddFlags adds the flags that set the queue settings in cfg.
+func AddFlags(cmd *cobra.Command, cfg *config.QueueConfig) {
+	cmd.Flags().UintVar(&cfg.Workers, "workers", 0, "Number of captured turns stored concurrently (default: 3)")
+	cmd.Flags().UintVar(&cfg.QueueSize, "queue-size", 0, "Number of captured turns that can wait to be stored (default: 256)")
+	cmd.Flags().StringVar(&cfg.OverflowPolicy, "overflow-policy", "", "What to do with turns captured while the queue is full: drop-new, drop-oldest, or block (default: drop-new)")
+	cmd.Flags().StringVar(&cfg.EnqueueTimeout, "enqueue-timeout", "", "How long the block policy waits for room in the queue, e.g. 5s (default: indefinitely)")
+	cmd.Flags().StringVar(&cfg.JobTimeout, "job-timeout", "", "How long storing a single turn may take, e.g. 30s (default: no limit)")
+}
+
+// ApplyConfig fills the queue settings from config.toml that were not set by
+// flags.
+func ApplyConfig(cmd *cobra.Command, cfg *config.QueueConfig, fileCfg config.QueueConfig) {
+	if !cmd.Flags().Changed("workers") {
+		cfg.Workers = fileCfg.Workers
+	}
+	if !cmd.Flags().Changed("queue-size") {
+		cfg.QueueSize = fileCfg.QueueSize
+	}
+	if !cmd.Flags().Changed("overflow-policy") {
+		cfg.OverflowPolicy = fileCfg.OverflowPolicy
+	}
+	if !cmd.Flags().Changed("enqueue-timeout") {
+		cfg.EnqueueTimeout = fileCfg.EnqueueTimeout
+	}
+	if !cmd.Flags().Changed("job-timeout") {
+		cfg.JobTimeout = fileCfg.JobTimeout
+	}
+}
@@ -0,0 +1,13 @@
+package queuepolicy
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestQueuePolicy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Queue Policy Suite")
+}
@@ -0,0 +1,59 @@
+package queuepolicy
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/proxy"
+	"github.com/papercomputeco/tapes/proxy/worker"
+)
+
+var _ = Describe("FromConfig", func() {
+	It("keeps the worker defaults when unset", func() {
+		policy, err := FromConfig(config.QueueConfig{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy).To(Equal(proxy.QueuePolicy{Overflow: worker.OverflowDropNew}))
+	})
+
+	It("converts every setting", func() {
+		policy, err := FromConfig(config.QueueConfig{
+			Workers:        4,
+			QueueSize:      1024,
+			OverflowPolicy: "block",
+			EnqueueTimeout: "5s",
+			JobTimeout:     "1m",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy).To(Equal(proxy.QueuePolicy{
+			Workers:        4,
+			Size:           1024,
+			Overflow:       worker.OverflowBlock,
+			EnqueueTimeout: 5 * time.Second,
+			JobTimeout:     time.Minute,
+		}))
+	})
+
+	It("rejects unknown policies and malformed timeouts", func() {
+		_, err := FromConfig(config.QueueConfig{OverflowPolicy: "drop-all"})
+		Expect(err).To(MatchError(ContainSubstring("unknown overflow policy")))
+		_, err = FromConfig(config.QueueConfig{JobTimeout: "soon"})
+		Expect(err).To(MatchError(ContainSubstring("invalid job timeout")))
+	})
+})
+
+var _ = Describe("ApplyConfig", func() {
+	It("keeps settings given by flags over config.toml", func() {
+		var cfg config.QueueConfig
+		cmd := &cobra.Command{}
+		AddFlags(cmd, &cfg)
+		Expect(cmd.Flags().Parse([]string{"--queue-size", "64"})).To(Succeed())
+
+		ApplyConfig(cmd, &cfg, config.QueueConfig{QueueSize: 512, OverflowPolicy: "drop-oldest"})
+		Expect(cfg.QueueSize).To(Equal(uint(64)))
+		Expect(cfg.OverflowPolicy).To(Equal("drop-oldest"))
+	})
+})
@@ -0,0 +1,34 @@
+// Package reportcmder provides the `tapes report` CLI commands for posting
+// digests of recorded sessions to chat tools.
+package reportcmder
+
+import (
+	"github.com/spf13/cobra"
+)
+
+const reportLongDesc string = `Post digests of recorded sessions to chat tools.
+
+A digest covers the sessions with a turn in its period: how many were
+recorded and how many failed, their total cost, the models that cost the most,
+and the models that answered with upstream errors, for team leads following
+agent usage without a dashboard.
+
+Examples:
+  tapes report slack --daily
+  tapes report slack --since 7d --webhook https://hooks.slack.com/services/...
+  tapes report slack --daily --dry-run`
+
+const reportShortDesc string = "Post session digests to chat tools"
+
+// NewReportCmd creates the parent report command.
+func NewReportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: reportShortDesc,
+		Long:  reportLongDesc,
+	}
+
+	cmd.AddCommand(newSlackCmd())
+
+	return cmd
+}
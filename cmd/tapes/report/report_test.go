@@ -0,0 +1,96 @@
+package reportcmder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("report slack command", func() {
+	var (
+		ctx       context.Context
+		dbPath    string
+		configDir string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+		configDir = GinkgoT().TempDir()
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		prompt := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: "hello"}},
+			Model:   "gpt-4o",
+		}, nil)
+		reply := merkle.NewNode(merkle.Bucket{
+			Type:     "message",
+			Role:     "assistant",
+			Content:  []llm.ContentBlock{{Type: "text", Text: "hi"}},
+			Model:    "gpt-4o",
+			Provider: "openai",
+		}, prompt, merkle.NodeMeta{StopReason: "stop", Usage: &llm.Usage{PromptTokens: 1_000_000, CompletionTokens: 1_000}})
+		for _, n := range []*merkle.Node{prompt, reply} {
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(driver.Close()).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewReportCmd()
+		cmd.PersistentFlags().String("config-dir", configDir, "")
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append([]string{"slack", "--sqlite", dbPath}, args...))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	It("prints the digest on a dry run", func() {
+		out, err := run("--daily", "--dry-run")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("*Sessions:* 1 (1 completed, 0 failed)\n"))
+		Expect(out).To(ContainSubstring("*Total cost:* $2.51\n"))
+		Expect(out).To(ContainSubstring("• gpt-4o: $2.51 across 1 sessions\n"))
+	})
+
+	It("posts the digest to the webhook", func() {
+		var text string
+		server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			var body map[string]string
+			data, _ := io.ReadAll(r.Body)
+			Expect(json.Unmarshal(data, &body)).To(Succeed())
+			text = body["text"]
+		}))
+		defer server.Close()
+
+		out, err := run("--since", "7d", "--webhook", server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("Posted digest of 1 sessions to Slack.\n"))
+		Expect(text).To(ContainSubstring("*Total cost:* $2.51"))
+	})
+
+	It("requires a period and a webhook", func() {
+		_, err := run()
+		Expect(err).To(MatchError(ContainSubstring("at least one of the flags in the group [daily since] is required")))
+
+		_, err = run("--daily")
+		Expect(err).To(MatchError("no Slack webhook: pass --webhook or set report.slack_webhook"))
+	})
+})
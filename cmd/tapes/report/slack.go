@@ -0,0 +1,124 @@
+package reportcmder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/notify"
+	"github.com/papercomputeco/tapes/pkg/report"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+const slackLongDesc string = `Post a digest of recorded sessions to a Slack incoming webhook.
+
+--daily covers the last 24 hours and --since any other period. The webhook is
+taken from --webhook or the report.slack_webhook config key. Use --dry-run to
+print the digest instead of posting it.
+
+To have the tapes start daemon post the daily digest, set the time of day
+it is posted at:
+  tapes config set report.slack_webhook https://hooks.slack.com/services/...
+  tapes config set report.slack_daily_at 09:00`
+
+type slackCommander struct {
+	sqlitePath  string
+	pricingPath string
+	webhook     string
+	daily       bool
+	since       string
+	dryRun      bool
+}
+
+func newSlackCmd() *cobra.Command {
+	cmder := &slackCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "slack",
+		Short: "Post a session digest to Slack",
+		Long:  slackLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().StringVar(&cmder.pricingPath, "pricing", "", "Path to pricing TOML or JSON overrides")
+	cmd.Flags().StringVar(&cmder.webhook, "webhook", "", "Slack incoming webhook URL (default: report.slack_webhook)")
+	cmd.Flags().BoolVar(&cmder.daily, "daily", false, "Digest the last 24 hours")
+	cmd.Flags().StringVar(&cmder.since, "since", "", "Digest this period up to now (e.g. 7d, 12h)")
+	cmd.Flags().BoolVar(&cmder.dryRun, "dry-run", false, "Print the digest instead of posting it")
+	cmd.MarkFlagsMutuallyExclusive("daily", "since")
+	cmd.MarkFlagsOneRequired("daily", "since")
+
+	return cmd
+}
+
+func (c *slackCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	period := 24 * time.Hour
+	if c.since != "" {
+		var err error
+		period, err = utils.ParseDuration(c.since)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	webhook := c.webhook
+	if webhook == "" && !c.dryRun {
+		cfger, err := config.NewConfiger(configDir)
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+		cfg, err := cfger.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+		webhook = cfg.Report.SlackWebhook
+		if webhook == "" {
+			return errors.New("no Slack webhook: pass --webhook or set report.slack_webhook")
+		}
+	}
+
+	pricing, err := deck.ResolvePricing(configDir, c.pricingPath)
+	if err != nil {
+		return err
+	}
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	query, closeFn, err := deck.NewReadOnlyQuery(ctx, dbPath, pricing, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = closeFn() }()
+
+	to := time.Now()
+	digest, err := report.Build(ctx, query, to.Add(-period), to)
+	if err != nil {
+		return err
+	}
+
+	if c.dryRun {
+		fmt.Fprintln(cmd.OutOrStdout(), digest.SlackText())
+		return nil
+	}
+	if err := notify.PostSlack(ctx, nil, webhook, digest.SlackText()); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Posted digest of %d sessions to Slack.\n", digest.Sessions)
+	return nil
+}
@@ -0,0 +1,270 @@
+// Package reprocesscmder provides the `tapes reprocess` command, which
+// re-parses retained raw payloads with the current provider parsers.
+package reprocesscmder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+	"github.com/papercomputeco/tapes/pkg/utils"
+	"github.com/papercomputeco/tapes/proxy"
+	"github.com/papercomputeco/tapes/proxy/worker"
+)
+
+const reprocessLongDesc string = `Re-parse retained raw payloads with the current provider parsers.
+
+When raw capture is enabled (proxy.raw_capture or --raw-capture), the proxy
+keeps each turn's original request and response payloads, compressed, next to
+the normalized nodes. After a parser fix, reprocess them to store the turns as
+the fixed parser sees them. Turns are content-addressed, so unchanged turns
+are left as they are. Changed turns are stored as new nodes alongside the
+originals, and the original nodes they replace are marked as superseded.
+
+Examples:
+  tapes reprocess
+  tapes reprocess --since 7d --provider anthropic
+  tapes reprocess --dry-run`
+
+const reprocessShortDesc string = "Re-parse retained raw payloads"
+
+type reprocessCommander struct {
+	sqlitePath string
+	since      string
+	provider   string
+	dryRun     bool
+}
+
+// NewReprocessCmd creates the reprocess command.
+func NewReprocessCmd() *cobra.Command {
+	cmder := &reprocessCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "reprocess",
+		Short: reprocessShortDesc,
+		Long:  reprocessLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().StringVar(&cmder.since, "since", "", "Only reprocess turns captured within this age (e.g. 24h, 7d)")
+	cmd.Flags().StringVar(&cmder.provider, "provider", "", "Only reprocess turns from this provider")
+	cmd.Flags().BoolVar(&cmder.dryRun, "dry-run", false, "Parse the payloads without storing anything")
+
+	return cmd
+}
+
+func (c *reprocessCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	var since time.Time
+	if c.since != "" {
+		age, err := utils.ParseDuration(c.since)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		since = time.Now().Add(-age)
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	driver, err := sqlite.NewDriver(ctx, dbPath, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer driver.Close()
+
+	captures, err := driver.ListRawCaptures(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	pool, err := worker.NewPool(&worker.Config{
+		Driver:     driver,
+		NumWorkers: 1,
+		Logger:     zap.NewNop(),
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { _, _ = pool.Close(ctx) }()
+
+	w := cmd.OutOrStdout()
+	var total result
+	reprocessed := 0
+	for _, rc := range captures {
+		if c.provider != "" && rc.Provider != c.provider {
+			continue
+		}
+
+		res, err := c.reprocess(ctx, driver, pool, rc)
+		fmt.Fprintf(w, "  %s #%d  %s\n", cliui.Mark(err), rc.ID, c.detail(rc, res, err))
+		if err != nil {
+			continue
+		}
+		reprocessed++
+		total.newNodes += res.newNodes
+		total.superseded += res.superseded
+	}
+
+	if c.dryRun {
+		fmt.Fprintf(w, "\nParsed %d of %d captures (dry run)\n", reprocessed, len(captures))
+		return nil
+	}
+	fmt.Fprintf(w, "\nReprocessed %d of %d captures, %d new nodes, %d superseded\n",
+		reprocessed, len(captures), total.newNodes, total.superseded)
+	return nil
+}
+
+// result describes what reprocessing a capture changed.
+type result struct {
+	head       string
+	newNodes   int
+	superseded int
+}
+
+// reprocess parses a capture and, unless this is a dry run, stores the turn
+// and reconciles the DAG: nodes of the previously stored turn that the new
+// parse replaced are marked as superseded, and the capture is pointed at the
+// new turn.
+func (c *reprocessCommander) reprocess(ctx context.Context, driver *sqlite.Driver, pool *worker.Pool, rc *storage.RawCapture) (result, error) {
+	prov, err := provider.New(rc.Provider)
+	if err != nil {
+		return result{}, err
+	}
+
+	req, err := prov.ParseRequest(rc.Request)
+	if err != nil {
+		return result{}, fmt.Errorf("parsing request: %w", err)
+	}
+
+	var resp *llm.ChatResponse
+	if rc.Streamed {
+		resp = proxy.ReassembleStream(prov, rc.Response)
+		if resp == nil {
+			return result{}, errors.New("reassembling stream: no response")
+		}
+	} else {
+		resp, err = prov.ParseResponse(rc.Response)
+		if err != nil {
+			return result{}, fmt.Errorf("parsing response: %w", err)
+		}
+	}
+
+	if c.dryRun {
+		return result{}, nil
+	}
+
+	before, err := driver.Client.Node.Query().Count(ctx)
+	if err != nil {
+		return result{}, err
+	}
+
+	head, err := pool.Store(ctx, worker.Job{
+		Provider:  prov.Name(),
+		AgentName: rc.AgentName,
+		SessionID: rc.SessionID,
+		RunID:     rc.RunID,
+		Path:      rc.Path,
+		Project:   rc.Project,
+		User:      rc.User,
+		Req:       req,
+		Resp:      resp,
+	})
+	if err != nil {
+		return result{}, fmt.Errorf("storing turn: %w", err)
+	}
+	if head == "" {
+		return result{}, errors.New("turn dropped by middleware")
+	}
+
+	after, err := driver.Client.Node.Query().Count(ctx)
+	if err != nil {
+		return result{}, err
+	}
+	res := result{head: head, newNodes: after - before}
+	if head == rc.NodeHash {
+		return res, nil
+	}
+
+	superseded, err := supersessions(ctx, driver, rc.NodeHash, head)
+	if err != nil {
+		return result{}, err
+	}
+	if err := driver.ReconcileRawCapture(ctx, rc.ID, head, superseded); err != nil {
+		return result{}, err
+	}
+	res.superseded = len(superseded)
+	return res, nil
+}
+
+// supersessions pairs the paths of the old and new turns by depth and maps
+// each old node missing from the new path to the new node at its depth. An
+// old turn that is no longer stored supersedes nothing.
+func supersessions(ctx context.Context, driver storage.Driver, oldHead, newHead string) (map[string]string, error) {
+	superseded := map[string]string{}
+
+	exists, err := driver.Has(ctx, oldHead)
+	if err != nil || !exists {
+		return superseded, err
+	}
+
+	// Ancestry lists the head first and the root last.
+	oldPath, err := driver.Ancestry(ctx, oldHead)
+	if err != nil {
+		return nil, err
+	}
+	newPath, err := driver.Ancestry(ctx, newHead)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make(map[string]bool, len(newPath))
+	for _, n := range newPath {
+		kept[n.Hash] = true
+	}
+	for depth := range oldPath {
+		old := oldPath[len(oldPath)-1-depth]
+		if kept[old.Hash] {
+			continue
+		}
+		replacement := newPath[0]
+		if depth < len(newPath) {
+			replacement = newPath[len(newPath)-1-depth]
+		}
+		superseded[old.Hash] = replacement.Hash
+	}
+	return superseded, nil
+}
+
+func (c *reprocessCommander) detail(rc *storage.RawCapture, res result, err error) string {
+	switch {
+	case err != nil:
+		return cliui.DimStyle.Render(err.Error())
+	case c.dryRun:
+		return "parsed"
+	case res.head == rc.NodeHash:
+		return cliui.DimStyle.Render("unchanged " + cliui.ShortHash(rc.NodeHash))
+	default:
+		return fmt.Sprintf("%s -> %s (%d new nodes, %d superseded)",
+			cliui.ShortHash(rc.NodeHash), cliui.ShortHash(res.head), res.newNodes, res.superseded)
+	}
+}
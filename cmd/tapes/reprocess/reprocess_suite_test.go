@@ -0,0 +1,13 @@
+package reprocesscmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestReprocessCommander(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Reprocess Commander Suite")
+}
@@ -0,0 +1,169 @@
+package reprocesscmder
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+	"github.com/papercomputeco/tapes/proxy/worker"
+)
+
+const ollamaRequest = `{"model":"llama3","messages":[{"role":"user","content":"hello"}],"stream":false}`
+
+var _ = Describe("reprocess command", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		Expect(driver.AddRawCapture(ctx, &storage.RawCapture{
+			NodeHash: "stale",
+			Provider: "ollama",
+			Project:  "tapes",
+			Path:     "/api/chat",
+			Request:  []byte(ollamaRequest),
+			Response: []byte(`{"model":"llama3","message":{"role":"assistant","content":"hi there"},"done":true}`),
+		})).To(Succeed())
+
+		Expect(driver.AddRawCapture(ctx, &storage.RawCapture{
+			NodeHash: "streamed",
+			Provider: "ollama",
+			Path:     "/api/chat",
+			Streamed: true,
+			Request:  []byte(`{"model":"llama3","messages":[{"role":"user","content":"again"}],"stream":true}`),
+			Response: []byte(`{"model":"llama3","message":{"role":"assistant","content":"hi"},"done":false}` + "\n" +
+				`{"model":"llama3","message":{"role":"assistant","content":" again"},"done":false}` + "\n" +
+				`{"model":"llama3","message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":3,"eval_count":2}`),
+		})).To(Succeed())
+
+		Expect(driver.AddRawCapture(ctx, &storage.RawCapture{
+			NodeHash: "broken",
+			Provider: "anthropic",
+			Request:  []byte("{broken"),
+			Response: []byte("{}"),
+		})).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewReprocessCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append(args, "--sqlite", dbPath))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	nodes := func() []string {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		all, err := driver.List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		texts := make([]string, 0, len(all))
+		for _, n := range all {
+			texts = append(texts, n.Bucket.ExtractText())
+		}
+		return texts
+	}
+
+	It("stores the turns the current parsers produce", func() {
+		out, err := run()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("parsing request"))
+		Expect(out).To(ContainSubstring("Reprocessed 2 of 3 captures, 4 new nodes, 0 superseded"))
+		Expect(nodes()).To(ConsistOf("hello", "hi there", "again", "hi again"))
+	})
+
+	It("leaves unchanged turns alone on a second run", func() {
+		_, err := run()
+		Expect(err).NotTo(HaveOccurred())
+
+		out, err := run()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("unchanged"))
+		Expect(out).To(ContainSubstring("Reprocessed 2 of 3 captures, 0 new nodes, 0 superseded"))
+		Expect(nodes()).To(HaveLen(4))
+	})
+
+	It("filters by provider", func() {
+		out, err := run("--provider", "anthropic")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Reprocessed 0 of 3 captures, 0 new nodes, 0 superseded"))
+	})
+
+	It("marks the nodes a reprocessed turn replaces as superseded", func() {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Store the turn as a buggy parser saw it, truncating the reply.
+		pool, err := worker.NewPool(&worker.Config{Driver: driver, Logger: zap.NewNop()})
+		Expect(err).NotTo(HaveOccurred())
+		prov, err := provider.New("ollama")
+		Expect(err).NotTo(HaveOccurred())
+		req, err := prov.ParseRequest([]byte(ollamaRequest))
+		Expect(err).NotTo(HaveOccurred())
+		oldHead, err := pool.Store(ctx, worker.Job{
+			Provider: "ollama",
+			Project:  "tapes",
+			Req:      req,
+			Resp:     &llm.ChatResponse{Model: "llama3", Message: llm.NewTextMessage("assistant", "hi"), Done: true},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = pool.Close(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		captures, err := driver.ListRawCaptures(ctx, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.ReconcileRawCapture(ctx, captures[0].ID, oldHead, nil)).To(Succeed())
+		Expect(driver.Close()).To(Succeed())
+
+		out, err := run("--provider", "ollama")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Reprocessed 2 of 3 captures, 3 new nodes, 1 superseded"))
+
+		driver, err = sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		captures, err = driver.ListRawCaptures(ctx, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		newHead := captures[0].NodeHash
+		Expect(newHead).NotTo(Equal(oldHead))
+
+		old, err := driver.Get(ctx, oldHead)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(old.SupersededBy).To(Equal(newHead))
+
+		replacement, err := driver.Get(ctx, newHead)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replacement.Bucket.ExtractText()).To(Equal("hi there"))
+		Expect(replacement.SupersededBy).To(BeEmpty())
+		Expect(replacement.ParentHash).To(Equal(old.ParentHash))
+	})
+
+	It("stores nothing on a dry run", func() {
+		out, err := run("--dry-run")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Parsed 2 of 3 captures (dry run)"))
+		Expect(nodes()).To(BeEmpty())
+	})
+})
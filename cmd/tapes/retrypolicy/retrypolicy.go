@@ -0,0 +1,55 @@
+// Package retrypolicy converts the proxy retry settings in config.toml into
+// the retry policies used by the proxy.
+package retrypolicy
+
+import (
+	"fmt"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/utils"
+	"github.com/papercomputeco/tapes/proxy"
+)
+
+// FromConfig returns the default retry policy and the overrides per provider
+// type. Settings left unset in a provider override fall back to the default.
+func FromConfig(cfg config.RetryConfig) (proxy.RetryPolicy, map[string]proxy.RetryPolicy, error) {
+	policy, err := toPolicy(cfg, proxy.RetryPolicy{})
+	if err != nil {
+		return proxy.RetryPolicy{}, nil, err
+	}
+
+	var providers map[string]proxy.RetryPolicy
+	for name, override := range cfg.Providers {
+		providerPolicy, err := toPolicy(override, policy)
+		if err != nil {
+			return proxy.RetryPolicy{}, nil, fmt.Errorf("provider %s: %w", name, err)
+		}
+		if providers == nil {
+			providers = make(map[string]proxy.RetryPolicy, len(cfg.Providers))
+		}
+		providers[name] = providerPolicy
+	}
+	return policy, providers, nil
+}
+
+func toPolicy(cfg config.RetryConfig, base proxy.RetryPolicy) (proxy.RetryPolicy, error) {
+	policy := base
+	if cfg.MaxAttempts > 0 {
+		policy.MaxAttempts = int(cfg.MaxAttempts) //nolint:gosec // config values are far below MaxInt
+	}
+	if cfg.BaseDelay != "" {
+		d, err := utils.ParseDuration(cfg.BaseDelay)
+		if err != nil {
+			return proxy.RetryPolicy{}, fmt.Errorf("invalid retry base_delay: %w", err)
+		}
+		policy.BaseDelay = d
+	}
+	if cfg.MaxDelay != "" {
+		d, err := utils.ParseDuration(cfg.MaxDelay)
+		if err != nil {
+			return proxy.RetryPolicy{}, fmt.Errorf("invalid retry max_delay: %w", err)
+		}
+		policy.MaxDelay = d
+	}
+	return policy, nil
+}
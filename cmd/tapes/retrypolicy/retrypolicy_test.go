@@ -0,0 +1,43 @@
+package retrypolicy
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/proxy"
+)
+
+var _ = Describe("FromConfig", func() {
+	It("leaves retries disabled by default", func() {
+		policy, providers, err := FromConfig(config.RetryConfig{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy).To(Equal(proxy.RetryPolicy{}))
+		Expect(providers).To(BeEmpty())
+	})
+
+	It("falls back to the default policy for unset provider settings", func() {
+		policy, providers, err := FromConfig(config.RetryConfig{
+			MaxAttempts: 3,
+			BaseDelay:   "1s",
+			MaxDelay:    "20s",
+			Providers: map[string]config.RetryConfig{
+				"anthropic": {MaxAttempts: 5, MaxDelay: "1m"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy).To(Equal(proxy.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: 20 * time.Second}))
+		Expect(providers).To(Equal(map[string]proxy.RetryPolicy{
+			"anthropic": {MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Minute},
+		}))
+	})
+
+	It("rejects malformed delays", func() {
+		_, _, err := FromConfig(config.RetryConfig{
+			Providers: map[string]config.RetryConfig{"openai": {BaseDelay: "soon"}},
+		})
+		Expect(err).To(MatchError(ContainSubstring("provider openai")))
+	})
+})
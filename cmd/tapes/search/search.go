@@ -1,4 +1,5 @@
-// Package searchcmder provides the search command for semantic search over sessions.
+// Package searchcmder provides the search command, which finds sessions by
+// similarity through the API or in the local full-text and embedding indexes.
 package searchcmder
 
 import (
@@ -16,9 +17,12 @@ import (
 	"go.uber.org/zap"
 
 	apisearch "github.com/papercomputeco/tapes/api/search"
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
 	"github.com/papercomputeco/tapes/pkg/cliui"
 	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/deck"
 	"github.com/papercomputeco/tapes/pkg/logger"
+	"github.com/papercomputeco/tapes/pkg/utils"
 )
 
 type searchCommander struct {
@@ -27,6 +31,15 @@ type searchCommander struct {
 	quiet bool
 
 	apiTarget string
+	apiToken  string
+
+	text       bool
+	semantic   bool
+	provider   string
+	since      string
+	sqlitePath string
+
+	cfg *config.Config
 
 	debug  bool
 	logger *zap.Logger
@@ -41,14 +54,28 @@ query text. Requires a running Tapes API server with search configured
 For each result, the full session branch is displayed, including all ancestors
 (from root to matched node) and all descendants (from matched node to leaves).
 
+Use --text to run a keyword search against the local full-text index instead.
+Text search matches message text and tool inputs and outputs, ranks hits by
+relevance, and does not need a running API server.
+
+Use --semantic to search the local embeddings directly, without a running API
+server. Semantic search finds turns by meaning rather than keywords, using the
+embedding provider and vector store from the tapes config. Turns captured
+before embeddings were configured can be indexed with tapes db embed.
+
+Filter --text and --semantic hits with --provider and --since.
+
 Use --quiet to output only leaf hashes, one per line. This is useful for piping
-into other commands like tapes skill generate.
+into other commands like tapes skill generate. With --text or --semantic,
+--quiet outputs session IDs instead.
 
 Example:
   tapes search "how to configure logging"
   tapes search "error handling patterns" --api-target http://localhost:8081
   tapes search "how to configure logging" --top 10
   tapes search "gum glow charm" --quiet
+  tapes search --text "connection refused" --provider openai --since 7d
+  tapes search --semantic "where the agent struggled with auth middleware"
   tapes skill generate $(tapes search "charm CLI" --quiet --top 1) --name charm-patterns`
 
 const searchShortDesc string = "Search session data"
@@ -76,6 +103,8 @@ func NewSearchCmd() *cobra.Command {
 			if !cmd.Flags().Changed("api-target") {
 				cmder.apiTarget = cfg.Client.APITarget
 			}
+			cmder.apiToken = cfg.API.Token
+			cmder.cfg = cfg
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -87,6 +116,9 @@ func NewSearchCmd() *cobra.Command {
 				return fmt.Errorf("could not get debug flag: %w", err)
 			}
 
+			if cmder.text || cmder.semantic {
+				return cmder.runLocal(cmd)
+			}
 			return cmder.run()
 		},
 	}
@@ -95,6 +127,12 @@ func NewSearchCmd() *cobra.Command {
 	cmd.Flags().IntVarP(&cmder.topK, "top", "k", 5, "Number of results to return")
 	cmd.Flags().BoolVarP(&cmder.quiet, "quiet", "q", false, "Output only leaf hashes, one per line (for piping)")
 	cmd.Flags().StringVar(&cmder.apiTarget, "api-target", defaults.Client.APITarget, "Tapes API server URL")
+	cmd.Flags().BoolVar(&cmder.text, "text", false, "Keyword search over the local full-text index")
+	cmd.Flags().BoolVar(&cmder.semantic, "semantic", false, "Search local embeddings by meaning")
+	cmd.Flags().StringVar(&cmder.provider, "provider", "", "Only match turns from this provider (with --text or --semantic)")
+	cmd.Flags().StringVar(&cmder.since, "since", "", "Only match turns newer than this age, e.g. 24h or 7d (with --text or --semantic)")
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database (with --text or --semantic)")
+	cmd.MarkFlagsMutuallyExclusive("text", "semantic")
 
 	return cmd
 }
@@ -103,7 +141,7 @@ func (c *searchCommander) run() error {
 	c.logger = logger.NewLogger(c.debug)
 	defer func() { _ = c.logger.Sync() }()
 
-	output, err := SearchAPI(c.apiTarget, c.query, c.topK)
+	output, err := SearchAPI(c.apiTarget, c.apiToken, c.query, c.topK)
 	if err != nil {
 		return err
 	}
@@ -187,9 +225,120 @@ func (c *searchCommander) printResult(rank int, result apisearch.Result) {
 	fmt.Println()
 }
 
+func (c *searchCommander) runLocal(cmd *cobra.Command) error {
+	opts := deck.SearchOptions{
+		Query:    c.query,
+		Provider: c.provider,
+		Limit:    c.topK,
+	}
+	if c.since != "" {
+		since, err := utils.ParseDuration(c.since)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		opts.Since = since
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	query, closeFn, err := deck.NewReadOnlyQuery(cmd.Context(), dbPath, deck.DefaultPricing(), driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = closeFn() }()
+
+	var hits []deck.SearchHit
+	if c.semantic {
+		hits, err = c.semanticSearch(cmd, query, dbPath, opts)
+	} else {
+		hits, err = query.Search(cmd.Context(), opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if c.quiet {
+		seen := map[string]bool{}
+		for _, hit := range hits {
+			if hit.SessionID == "" || seen[hit.SessionID] {
+				continue
+			}
+			seen[hit.SessionID] = true
+			fmt.Fprintln(w, hit.SessionID)
+		}
+		return nil
+	}
+
+	if len(hits) == 0 {
+		fmt.Fprintln(w, "No results found.")
+		return nil
+	}
+
+	fmt.Fprintf(w, "\n%s %s\n\n",
+		cliui.HeaderStyle.Render("Search Results for:"),
+		cliui.HashStyle.Render(fmt.Sprintf("%q", c.query)),
+	)
+	for i, hit := range hits {
+		printHit(w, i+1, hit)
+	}
+	return nil
+}
+
+func (c *searchCommander) semanticSearch(cmd *cobra.Command, query *deck.Query, dbPath string, opts deck.SearchOptions) ([]deck.SearchHit, error) {
+	c.logger = logger.NewLogger(c.debug)
+	defer func() { _ = c.logger.Sync() }()
+
+	cfg := c.cfg
+	if cfg == nil {
+		cfg = config.NewDefaultConfig()
+	}
+
+	embedder, vectors, err := OpenSemanticIndex(cfg, dbPath, c.logger)
+	if err != nil {
+		return nil, err
+	}
+	defer embedder.Close()
+	defer vectors.Close()
+
+	return query.SemanticSearch(cmd.Context(), embedder, vectors, opts)
+}
+
+func printHit(w io.Writer, rank int, hit deck.SearchHit) {
+	fmt.Fprintf(w, "  %s  %s  %s\n",
+		cliui.RankStyle.Render(fmt.Sprintf("#%d", rank)),
+		cliui.ScoreStyle.Render(fmt.Sprintf("score: %.4f", hit.Score)),
+		cliui.HashStyle.Render(hit.Hash[:min(12, len(hit.Hash))]),
+	)
+
+	if hit.SessionID == "" {
+		fmt.Fprintf(w, "  %s\n", cliui.DimStyle.Render("(no session found)"))
+	} else {
+		label := hit.SessionLabel
+		if hit.SessionTitle != "" {
+			label = hit.SessionTitle
+		}
+		fmt.Fprintf(w, "  %s %s\n",
+			cliui.PreviewStyle.Render(label),
+			cliui.DimStyle.Render(fmt.Sprintf("turn %d/%d · %s · %s", hit.Turn, hit.Turns, hit.Provider, hit.Timestamp.Format(time.DateTime))),
+		)
+	}
+
+	snippet := strings.ReplaceAll(hit.Snippet, "\n", " ")
+	fmt.Fprintf(w, "  %s %s\n\n", cliui.RoleStyle.Render(hit.Role+":"), snippet)
+}
+
 // SearchAPI calls the tapes search API and returns the parsed output.
 // Exported so other commands (e.g. skill generate --search) can reuse it.
-func SearchAPI(apiTarget, query string, topK int) (*apisearch.Output, error) {
+// The token is sent as a bearer token when non-empty.
+func SearchAPI(apiTarget, token, query string, topK int) (*apisearch.Output, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
@@ -207,6 +356,9 @@ func SearchAPI(apiTarget, query string, topK int) (*apisearch.Output, error) {
 	if err != nil {
 		return nil, fmt.Errorf("creating search request: %w", err)
 	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
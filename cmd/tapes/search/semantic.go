@@ -0,0 +1,47 @@
+package searchcmder
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/embeddings"
+	embeddingutils "github.com/papercomputeco/tapes/pkg/embeddings/utils"
+	"github.com/papercomputeco/tapes/pkg/vector"
+	vectorutils "github.com/papercomputeco/tapes/pkg/vector/utils"
+)
+
+// OpenSemanticIndex creates the embedder and vector store configured for
+// semantic search. The sqlite vector store defaults to the session database
+// at dbPath, matching where tapes start stores embeddings.
+// Exported so other commands (e.g. db embed) can reuse it.
+func OpenSemanticIndex(cfg *config.Config, dbPath string, logger *zap.Logger) (embeddings.Embedder, vector.Driver, error) {
+	target := cfg.VectorStore.Target
+	if target == "" && cfg.VectorStore.Provider == "sqlite" {
+		target = dbPath
+	}
+
+	vectorDriver, err := vectorutils.NewVectorDriver(&vectorutils.NewVectorDriverOpts{
+		ProviderType: cfg.VectorStore.Provider,
+		Target:       target,
+		Dimensions:   cfg.Embedding.Dimensions,
+		Logger:       logger,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating vector driver: %w", err)
+	}
+
+	embedder, err := embeddingutils.NewEmbedder(&embeddingutils.NewEmbedderOpts{
+		ProviderType: cfg.Embedding.Provider,
+		TargetURL:    cfg.Embedding.Target,
+		Model:        cfg.Embedding.Model,
+		Dimensions:   cfg.Embedding.Dimensions,
+	})
+	if err != nil {
+		vectorDriver.Close()
+		return nil, nil, fmt.Errorf("creating embedder: %w", err)
+	}
+
+	return embedder, vectorDriver, nil
+}
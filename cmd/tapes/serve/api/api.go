@@ -9,7 +9,9 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/papercomputeco/tapes/api"
+	"github.com/papercomputeco/tapes/cmd/tapes/servetls"
 	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/deck"
 	"github.com/papercomputeco/tapes/pkg/logger"
 	"github.com/papercomputeco/tapes/pkg/merkle"
 	"github.com/papercomputeco/tapes/pkg/storage"
@@ -19,8 +21,13 @@ import (
 
 type apiCommander struct {
 	listen     string
+	token      string
+	readToken  string
+	configDir  string
 	debug      bool
 	sqlitePath string
+	encryption bool
+	tls        config.TLSConfig
 	logger     *zap.Logger
 }
 
@@ -53,6 +60,15 @@ func NewAPICmd() *cobra.Command {
 			if !cmd.Flags().Changed("sqlite") {
 				cmder.sqlitePath = cfg.Storage.SQLitePath
 			}
+			cmder.encryption = cfg.Storage.Encryption
+			if !cmd.Flags().Changed("token") {
+				cmder.token = cfg.API.Token
+			}
+			if !cmd.Flags().Changed("read-token") {
+				cmder.readToken = cfg.API.ReadToken
+			}
+			servetls.ApplyConfig(cmd, &cmder.tls, cfg.TLS)
+			cmder.configDir = configDir
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -69,6 +85,9 @@ func NewAPICmd() *cobra.Command {
 	defaults := config.NewDefaultConfig()
 	cmd.Flags().StringVarP(&cmder.listen, "listen", "l", defaults.API.Listen, "Address for API server to listen on")
 	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database (default: in-memory)")
+	cmd.Flags().StringVar(&cmder.token, "token", "", "Bearer token required on API requests (default: none)")
+	cmd.Flags().StringVar(&cmder.readToken, "read-token", "", "Bearer token granting read-only access (default: none)")
+	servetls.AddFlags(cmd, &cmder.tls)
 
 	return cmd
 }
@@ -91,6 +110,24 @@ func (c *apiCommander) run() error {
 
 	config := api.Config{
 		ListenAddr: c.listen,
+		AuthToken:  c.token,
+		ReadToken:  c.readToken,
+	}
+	config.TLS, err = servetls.ServerConfig(c.tls, c.configDir)
+	if err != nil {
+		return err
+	}
+	if c.sqlitePath != "" {
+		pricing, err := deck.ResolvePricing(c.configDir, "")
+		if err != nil {
+			return err
+		}
+		query, closeQuery, err := deck.NewQuery(context.Background(), c.sqlitePath, pricing, sqlite.WithEncryption(c.encryption))
+		if err != nil {
+			return fmt.Errorf("opening sessions: %w", err)
+		}
+		defer func() { _ = closeQuery() }()
+		config.Sessions = query
 	}
 
 	server, err := api.NewServer(config, driver, dagLoader, c.logger)
@@ -107,7 +144,7 @@ func (c *apiCommander) run() error {
 
 func (c *apiCommander) newStorageDriver() (storage.Driver, error) {
 	if c.sqlitePath != "" {
-		driver, err := sqlite.NewDriver(context.Background(), c.sqlitePath)
+		driver, err := sqlite.NewDriver(context.Background(), c.sqlitePath, sqlite.WithEncryption(c.encryption))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create SQLite storer: %w", err)
 		}
@@ -121,7 +158,7 @@ func (c *apiCommander) newStorageDriver() (storage.Driver, error) {
 
 func (c *apiCommander) newDagLoader() (merkle.DagLoader, error) {
 	if c.sqlitePath != "" {
-		driver, err := sqlite.NewDriver(context.Background(), c.sqlitePath)
+		driver, err := sqlite.NewDriver(context.Background(), c.sqlitePath, sqlite.WithEncryption(c.encryption))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create SQLite storer: %w", err)
 		}
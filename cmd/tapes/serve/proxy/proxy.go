@@ -8,15 +8,28 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
+	"github.com/papercomputeco/tapes/cmd/tapes/queuepolicy"
+	"github.com/papercomputeco/tapes/cmd/tapes/retrypolicy"
+	"github.com/papercomputeco/tapes/cmd/tapes/servetls"
+	"github.com/papercomputeco/tapes/pkg/capture"
 	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/deck"
 	embeddingutils "github.com/papercomputeco/tapes/pkg/embeddings/utils"
 	"github.com/papercomputeco/tapes/pkg/git"
+	"github.com/papercomputeco/tapes/pkg/identity"
 	"github.com/papercomputeco/tapes/pkg/logger"
+	"github.com/papercomputeco/tapes/pkg/policy"
+	"github.com/papercomputeco/tapes/pkg/redact"
+	"github.com/papercomputeco/tapes/pkg/share"
 	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
 	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+	"github.com/papercomputeco/tapes/pkg/storage/media"
 	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+	"github.com/papercomputeco/tapes/pkg/telemetry"
 	vectorutils "github.com/papercomputeco/tapes/pkg/vector/utils"
 	"github.com/papercomputeco/tapes/proxy"
+	"github.com/papercomputeco/tapes/proxy/worker"
 )
 
 type proxyCommander struct {
@@ -25,7 +38,37 @@ type proxyCommander struct {
 	providerType string
 	debug        bool
 	sqlitePath   string
+	encryption   bool
+	snapshotPath string
 	project      string
+	user         string
+	otlpEndpoint string
+	configDir    string
+
+	token      string
+	userTokens map[string]string
+	tls        config.TLSConfig
+
+	maxCaptureBytes uint
+	maxInputTokens  uint
+	rawCapture      bool
+	signNodes       bool
+	redact          bool
+	redactionRules  []redact.RuleSpec
+	captureRules    []capture.RuleSpec
+	policyRules     []policy.RuleSpec
+	blobDir         string
+	blobThreshold   uint
+	mediaDir        string
+	mediaMaxBytes   uint
+	mediaDownload   bool
+
+	retry            config.RetryConfig
+	retryMaxAttempts uint
+
+	sessionIdleTimeout string
+
+	queue config.QueueConfig
 
 	vectorStoreProvider string
 	vectorStoreTarget   string
@@ -71,15 +114,22 @@ func NewProxyCmd() *cobra.Command {
 			if !cmd.Flags().Changed("listen") {
 				cmder.listen = cfg.Proxy.Listen
 			}
+			if !cmd.Flags().Changed("token") {
+				cmder.token = cfg.Proxy.Token
+			}
+			cmder.userTokens = cfg.Proxy.UserTokens
+			servetls.ApplyConfig(cmd, &cmder.tls, cfg.TLS)
+			cmder.configDir = configDir
 			if !cmd.Flags().Changed("upstream") {
 				cmder.upstream = cfg.Proxy.Upstream
 			}
 			if !cmd.Flags().Changed("provider") {
 				cmder.providerType = cfg.Proxy.Provider
 			}
-			if !cmd.Flags().Changed("sqlite") {
+			if !cmd.Flags().Changed("sqlite") && !cmd.Flags().Changed("snapshot") {
 				cmder.sqlitePath = cfg.Storage.SQLitePath
 			}
+			cmder.encryption = cfg.Storage.Encryption
 			if !cmd.Flags().Changed("vector-store-provider") {
 				cmder.vectorStoreProvider = cfg.VectorStore.Provider
 			}
@@ -98,9 +148,57 @@ func NewProxyCmd() *cobra.Command {
 			if !cmd.Flags().Changed("project") {
 				cmder.project = cfg.Proxy.Project
 			}
+			if !cmd.Flags().Changed("user") {
+				cmder.user = cfg.Proxy.User
+			}
+			if !cmd.Flags().Changed("otlp-endpoint") {
+				cmder.otlpEndpoint = cfg.Telemetry.OTLPEndpoint
+			}
+			if !cmd.Flags().Changed("max-capture-bytes") {
+				cmder.maxCaptureBytes = cfg.Proxy.MaxCaptureBytes
+			}
+			if !cmd.Flags().Changed("max-input-tokens") {
+				cmder.maxInputTokens = cfg.Proxy.MaxInputTokens
+			}
+			if !cmd.Flags().Changed("raw-capture") {
+				cmder.rawCapture = cfg.Proxy.RawCapture
+			}
+			if !cmd.Flags().Changed("sign-nodes") {
+				cmder.signNodes = cfg.Proxy.SignNodes
+			}
+			if !cmd.Flags().Changed("session-idle-timeout") {
+				cmder.sessionIdleTimeout = cfg.Proxy.SessionIdleTimeout
+			}
+			queuepolicy.ApplyConfig(cmd, &cmder.queue, cfg.Proxy.QueueConfig)
+			if !cmd.Flags().Changed("redact") {
+				cmder.redact = cfg.Redaction.Enabled
+			}
+			cmder.redactionRules = cfg.Redaction.Rules
+			cmder.captureRules = cfg.Capture.Rules
+			cmder.policyRules = cfg.Policy.Rules
+			if !cmd.Flags().Changed("blob-dir") {
+				cmder.blobDir = cfg.Storage.BlobDir
+			}
+			if !cmd.Flags().Changed("blob-threshold") {
+				cmder.blobThreshold = cfg.Storage.BlobThreshold
+			}
+			if !cmd.Flags().Changed("media-dir") {
+				cmder.mediaDir = cfg.Storage.MediaDir
+			}
+			if !cmd.Flags().Changed("media-max-bytes") {
+				cmder.mediaMaxBytes = cfg.Storage.MediaMaxBytes
+			}
+			if !cmd.Flags().Changed("media-download") {
+				cmder.mediaDownload = cfg.Storage.MediaDownload
+			}
+			cmder.retry = cfg.Proxy.Retry
+			if !cmd.Flags().Changed("retry-max-attempts") {
+				cmder.retryMaxAttempts = cfg.Proxy.Retry.MaxAttempts
+			}
 			if cmder.project == "" {
 				cmder.project = git.RepoName(cmd.Context())
 			}
+			cmder.user = identity.Resolve(cmder.user)
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -119,12 +217,31 @@ func NewProxyCmd() *cobra.Command {
 	cmd.Flags().StringVarP(&cmder.upstream, "upstream", "u", defaults.Proxy.Upstream, "Upstream LLM provider URL")
 	cmd.Flags().StringVarP(&cmder.providerType, "provider", "p", defaults.Proxy.Provider, "LLM provider type (anthropic, openai, ollama)")
 	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database (default: in-memory)")
+	cmd.Flags().StringVar(&cmder.snapshotPath, "snapshot", "", "Use in-memory storage restored from this JSON file and saved back to it every minute and on exit")
+	cmd.MarkFlagsMutuallyExclusive("sqlite", "snapshot")
 	cmd.Flags().StringVar(&cmder.vectorStoreProvider, "vector-store-provider", defaults.VectorStore.Provider, "Vector store provider type (e.g., chroma, sqlite)")
 	cmd.Flags().StringVar(&cmder.vectorStoreTarget, "vector-store-target", defaults.VectorStore.Target, "Vector store URL (e.g., http://localhost:8000)")
 	cmd.Flags().StringVar(&cmder.embeddingProvider, "embedding-provider", defaults.Embedding.Provider, "Embedding provider type (e.g., ollama)")
 	cmd.Flags().StringVar(&cmder.embeddingTarget, "embedding-target", defaults.Embedding.Target, "Embedding provider URL")
 	cmd.Flags().StringVar(&cmder.embeddingModel, "embedding-model", defaults.Embedding.Model, "Embedding model name (e.g., nomic-embed-text)")
 	cmd.Flags().StringVar(&cmder.project, "project", "", "Project name to tag sessions (default: auto-detect from git)")
+	cmd.Flags().StringVar(&cmder.user, "user", "", "User to attribute captured turns to, unless a request sends X-Tapes-User (default: OS user)")
+	cmd.Flags().UintVar(&cmder.maxCaptureBytes, "max-capture-bytes", 0, "Truncate stored content blocks larger than this many bytes (0 = no limit)")
+	cmd.Flags().UintVar(&cmder.maxInputTokens, "max-input-tokens", 0, "Reject chat requests with more input tokens than this before forwarding them (0 = no limit)")
+	cmd.Flags().BoolVar(&cmder.rawCapture, "raw-capture", false, "Retain compressed raw request and response payloads for tapes reprocess")
+	cmd.Flags().BoolVar(&cmder.signNodes, "sign-nodes", false, "Sign stored turns with this installation's identity key, the one tapes share uses")
+	cmd.Flags().StringVar(&cmder.sessionIdleTimeout, "session-idle-timeout", "", "Close sessions after this long without a turn, e.g. 2h (default: 30m, 0 = never)")
+	queuepolicy.AddFlags(cmd, &cmder.queue)
+	cmd.Flags().BoolVar(&cmder.redact, "redact", false, "Redact API keys, credentials, and emails from content before storing")
+	cmd.Flags().StringVar(&cmder.blobDir, "blob-dir", "", "Directory, or s3://bucket/prefix, to offload large content blocks to (default: store inline)")
+	cmd.Flags().UintVar(&cmder.blobThreshold, "blob-threshold", 0, "Offload content blocks larger than this many bytes (default: 262144)")
+	cmd.Flags().StringVar(&cmder.mediaDir, "media-dir", "", "Directory to store captured images and files in (default: store inline)")
+	cmd.Flags().UintVar(&cmder.mediaMaxBytes, "media-max-bytes", 0, "Store images and files up to this many bytes (default: 20971520)")
+	cmd.Flags().BoolVar(&cmder.mediaDownload, "media-download", false, "Download images referenced by URL into the media directory")
+	cmd.Flags().UintVar(&cmder.retryMaxAttempts, "retry-max-attempts", 0, "Retry throttled and overloaded upstream requests up to this many attempts in total (0 = no retries)")
+	cmd.Flags().StringVar(&cmder.token, "token", "", "Token required in the X-Tapes-Token header on proxied requests (default: none)")
+	servetls.AddFlags(cmd, &cmder.tls)
+	cmd.Flags().StringVar(&cmder.otlpEndpoint, "otlp-endpoint", "", "OTLP/HTTP collector URL to export traces to (default: OTEL_EXPORTER_OTLP_ENDPOINT)")
 
 	return cmd
 }
@@ -133,6 +250,12 @@ func (c *proxyCommander) run() error {
 	c.logger = logger.NewLogger(c.debug)
 	defer func() { _ = c.logger.Sync() }()
 
+	shutdownTracing, err := telemetry.Setup(context.Background(), telemetry.Config{Endpoint: c.otlpEndpoint})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = shutdownTracing(context.Background()) }()
+
 	driver, err := c.newStorageDriver()
 	if err != nil {
 		return err
@@ -140,10 +263,91 @@ func (c *proxyCommander) run() error {
 	defer driver.Close()
 
 	config := proxy.Config{
-		ListenAddr:   c.listen,
-		UpstreamURL:  c.upstream,
-		ProviderType: c.providerType,
-		Project:      c.project,
+		ListenAddr:      c.listen,
+		UpstreamURL:     c.upstream,
+		ProviderType:    c.providerType,
+		Project:         c.project,
+		User:            c.user,
+		MaxCaptureBytes: int(c.maxCaptureBytes), //nolint:gosec // flag values are far below MaxInt
+		RawCapture:      c.rawCapture,
+		AuthToken:       c.token,
+		UserTokens:      c.userTokens,
+	}
+	pricing, err := deck.ResolvePricing(c.configDir, "")
+	if err != nil {
+		return err
+	}
+	config.TurnCost = pricing.TurnCost
+	config.SessionIdleTimeout, err = worker.ParseSessionIdleTimeout(c.sessionIdleTimeout)
+	if err != nil {
+		return err
+	}
+	config.Queue, err = queuepolicy.FromConfig(c.queue)
+	if err != nil {
+		return err
+	}
+	if c.signNodes {
+		dir, err := share.IdentityDir(c.configDir)
+		if err != nil {
+			return err
+		}
+		if config.SigningKey, err = share.LoadIdentity(dir); err != nil {
+			return err
+		}
+	}
+	if c.maxInputTokens > 0 {
+		config.Budget = proxy.MaxInputTokens(c.maxInputTokens) //nolint:gosec // flag values are far below MaxInt
+	}
+
+	config.TLS, err = servetls.ServerConfig(c.tls, c.configDir)
+	if err != nil {
+		return err
+	}
+
+	c.retry.MaxAttempts = c.retryMaxAttempts
+	config.Retry, config.ProviderRetries, err = retrypolicy.FromConfig(c.retry)
+	if err != nil {
+		return err
+	}
+
+	if c.redact {
+		config.Redactor, err = redact.NewRedactor(c.redactionRules)
+		if err != nil {
+			return fmt.Errorf("creating redactor: %w", err)
+		}
+	}
+
+	if len(c.captureRules) > 0 {
+		config.CaptureRules, err = capture.NewRules(c.captureRules)
+		if err != nil {
+			return fmt.Errorf("creating capture rules: %w", err)
+		}
+	}
+
+	if len(c.policyRules) > 0 {
+		config.Policy, err = policy.New(c.policyRules)
+		if err != nil {
+			return fmt.Errorf("creating policy: %w", err)
+		}
+	}
+
+	if c.blobDir != "" {
+		config.BlobStore, err = blob.NewStore(c.blobDir, storage.CipherOf(driver))
+		if err != nil {
+			return fmt.Errorf("creating blob store: %w", err)
+		}
+		config.BlobThreshold = int(c.blobThreshold) //nolint:gosec // flag values are far below MaxInt
+	}
+
+	if c.mediaDir != "" {
+		config.MediaStore, err = media.NewStore(c.mediaDir, media.Options{
+			MaxBytes: int(c.mediaMaxBytes), //nolint:gosec // flag values are far below MaxInt
+			Download: c.mediaDownload,
+			Cipher:   storage.CipherOf(driver),
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	if c.vectorStoreTarget != "" {
@@ -196,7 +400,7 @@ func (c *proxyCommander) run() error {
 
 func (c *proxyCommander) newStorageDriver() (storage.Driver, error) {
 	if c.sqlitePath != "" {
-		driver, err := sqlite.NewDriver(context.Background(), c.sqlitePath)
+		driver, err := sqlite.NewDriver(context.Background(), c.sqlitePath, sqlite.WithEncryption(c.encryption))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create SQLite storer: %w", err)
 		}
@@ -204,6 +408,15 @@ func (c *proxyCommander) newStorageDriver() (storage.Driver, error) {
 		return driver, nil
 	}
 
+	if c.snapshotPath != "" {
+		driver, err := inmemory.NewPersistentDriver(c.snapshotPath, inmemory.SnapshotOptions{Logger: c.logger})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create in-memory storer: %w", err)
+		}
+		c.logger.Info("using in-memory storage", zap.String("snapshot", c.snapshotPath))
+		return driver, nil
+	}
+
 	c.logger.Info("using in-memory storage")
 	return inmemory.NewDriver(), nil
 }
@@ -3,40 +3,94 @@ package servecmder
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"syscall"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"go.uber.org/zap"
 
 	"github.com/papercomputeco/tapes/api"
+	"github.com/papercomputeco/tapes/cmd/tapes/queuepolicy"
+	"github.com/papercomputeco/tapes/cmd/tapes/retrypolicy"
 	apicmder "github.com/papercomputeco/tapes/cmd/tapes/serve/api"
 	proxycmder "github.com/papercomputeco/tapes/cmd/tapes/serve/proxy"
+	"github.com/papercomputeco/tapes/cmd/tapes/servetls"
+	"github.com/papercomputeco/tapes/pkg/capture"
 	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/crash"
+	"github.com/papercomputeco/tapes/pkg/deck"
 	"github.com/papercomputeco/tapes/pkg/dotdir"
 	embeddingutils "github.com/papercomputeco/tapes/pkg/embeddings/utils"
+	"github.com/papercomputeco/tapes/pkg/events"
 	"github.com/papercomputeco/tapes/pkg/git"
+	"github.com/papercomputeco/tapes/pkg/health"
+	"github.com/papercomputeco/tapes/pkg/identity"
 	"github.com/papercomputeco/tapes/pkg/logger"
 	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/notify"
+	"github.com/papercomputeco/tapes/pkg/policy"
+	"github.com/papercomputeco/tapes/pkg/redact"
+	"github.com/papercomputeco/tapes/pkg/share"
 	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
 	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+	"github.com/papercomputeco/tapes/pkg/storage/media"
 	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+	"github.com/papercomputeco/tapes/pkg/telemetry"
+	"github.com/papercomputeco/tapes/pkg/utils"
 	vectorutils "github.com/papercomputeco/tapes/pkg/vector/utils"
 	"github.com/papercomputeco/tapes/proxy"
+	"github.com/papercomputeco/tapes/proxy/worker"
 )
 
 type ServeCommander struct {
 	proxyListen string
 	apiListen   string
+	apiToken    string
+	proxyToken  string
+	userTokens  map[string]string
+	configDir   string
 	upstream    string
 	debug       bool
 	sqlitePath  string
+	encryption  bool
 	project     string
-
-	providerType string
+	user        string
+	readToken   string
+
+	otlpEndpoint string
+	tls          config.TLSConfig
+
+	providerType    string
+	maxCaptureBytes uint
+	maxInputTokens  uint
+	rawCapture      bool
+	signNodes       bool
+	redact          bool
+	redactionRules  []redact.RuleSpec
+	captureRules    []capture.RuleSpec
+	policyRules     []policy.RuleSpec
+	notifyRules     []notify.RuleSpec
+	blobDir         string
+	blobThreshold   uint
+	mediaDir        string
+	mediaMaxBytes   uint
+	mediaDownload   bool
+
+	retry            config.RetryConfig
+	retryMaxAttempts uint
+
+	sessionIdleTimeout string
+
+	queue config.QueueConfig
 
 	vectorStoreProvider string
 	vectorStoreTarget   string
@@ -46,6 +100,17 @@ type ServeCommander struct {
 	embeddingModel      string
 	embeddingDimensions uint
 
+	// crashDir receives crash reports, when a .tapes dir is in use.
+	crashDir string
+
+	// probeInterval enables probing the upstream for health checks.
+	probeInterval string
+
+	// headless runs for containers and shared deployments; see
+	// serveLongDesc.
+	headless     bool
+	drainTimeout string
+
 	logger *zap.Logger
 }
 
@@ -57,7 +122,32 @@ Use subcommands to run individual services or all services together:
   tapes serve proxy    Run just the proxy server
 
 Optionally configure vector storage and embeddings of text content for "tapes search"
-agentic functionality.`
+agentic functionality.
+
+Before listening beyond loopback, protect both listeners: --tls serves HTTPS
+with the configured certificate or a self-signed one kept in .tapes/tls,
+--tls-client-ca requires client certificates, --api-token requires a bearer
+token on the API, and --proxy-token requires agents to send an X-Tapes-Token
+header alongside their usual provider credentials.
+
+To share one server with a small team, give teammates --api-read-token to
+browse sessions while keeping --api-token for changes, and have each agent
+send an X-Tapes-User header so its turns are attributed to its user. Turns
+without the header are attributed to --user, which defaults to the OS user.
+With --proxy-token the header is ignored, since teammates would share one
+token, and every turn is attributed to --user.
+
+For a shared team proxy in a container, run with --headless. Headless mode
+launches no agents and takes every flag from a TAPES_* environment variable
+unless the flag is given, e.g. TAPES_API_TOKEN for --api-token or TAPES_SQLITE
+for --sqlite; settings without a flag are set by the TAPES_* variables of
+their config keys (see "tapes config"). It requires --api-token and --proxy-token, binds both listeners
+on all interfaces, and logs JSON to stdout. /health reports the status of each
+component, and /metrics serves counters in the Prometheus text format.
+
+On SIGTERM or SIGINT the server drains: /health reports it down, the proxy
+stops accepting connections, and in-flight requests and queued turns are
+given --drain-timeout to finish.`
 
 const serveShortDesc string = "Run Tapes services"
 
@@ -69,6 +159,12 @@ func NewServeCmd() *cobra.Command {
 		Short: serveShortDesc,
 		Long:  serveLongDesc,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			if cmder.headless {
+				if err := flagsFromEnv(cmd.Flags()); err != nil {
+					return err
+				}
+			}
+
 			configDir, _ := cmd.Flags().GetString("config-dir")
 			cfger, err := config.NewConfiger(configDir)
 			if err != nil {
@@ -87,6 +183,9 @@ func NewServeCmd() *cobra.Command {
 				return fmt.Errorf("resolving target dir: %w", err)
 			}
 			defaultTargetSqliteFile := filepath.Join(defaultTargetDir, "tapes.sqlite")
+			if defaultTargetDir != "" {
+				cmder.crashDir = filepath.Join(defaultTargetDir, crash.DirName)
+			}
 
 			if !cmd.Flags().Changed("proxy-listen") {
 				cmder.proxyListen = cfg.Proxy.Listen
@@ -94,6 +193,18 @@ func NewServeCmd() *cobra.Command {
 			if !cmd.Flags().Changed("api-listen") {
 				cmder.apiListen = cfg.API.Listen
 			}
+			if !cmd.Flags().Changed("api-token") {
+				cmder.apiToken = cfg.API.Token
+			}
+			if !cmd.Flags().Changed("api-read-token") {
+				cmder.readToken = cfg.API.ReadToken
+			}
+			if !cmd.Flags().Changed("proxy-token") {
+				cmder.proxyToken = cfg.Proxy.Token
+			}
+			cmder.userTokens = cfg.Proxy.UserTokens
+			servetls.ApplyConfig(cmd, &cmder.tls, cfg.TLS)
+			cmder.configDir = configDir
 			if !cmd.Flags().Changed("upstream") {
 				cmder.upstream = cfg.Proxy.Upstream
 			}
@@ -107,6 +218,7 @@ func NewServeCmd() *cobra.Command {
 					cmder.sqlitePath = defaultTargetSqliteFile
 				}
 			}
+			cmder.encryption = cfg.Storage.Encryption
 			if !cmd.Flags().Changed("vector-store-provider") {
 				cmder.vectorStoreProvider = cfg.VectorStore.Provider
 			}
@@ -132,9 +244,71 @@ func NewServeCmd() *cobra.Command {
 			if !cmd.Flags().Changed("project") {
 				cmder.project = cfg.Proxy.Project
 			}
+			if !cmd.Flags().Changed("user") {
+				cmder.user = cfg.Proxy.User
+			}
+			if !cmd.Flags().Changed("otlp-endpoint") {
+				cmder.otlpEndpoint = cfg.Telemetry.OTLPEndpoint
+			}
+			if !cmd.Flags().Changed("max-capture-bytes") {
+				cmder.maxCaptureBytes = cfg.Proxy.MaxCaptureBytes
+			}
+			if !cmd.Flags().Changed("max-input-tokens") {
+				cmder.maxInputTokens = cfg.Proxy.MaxInputTokens
+			}
+			if !cmd.Flags().Changed("raw-capture") {
+				cmder.rawCapture = cfg.Proxy.RawCapture
+			}
+			if !cmd.Flags().Changed("sign-nodes") {
+				cmder.signNodes = cfg.Proxy.SignNodes
+			}
+			if !cmd.Flags().Changed("session-idle-timeout") {
+				cmder.sessionIdleTimeout = cfg.Proxy.SessionIdleTimeout
+			}
+			queuepolicy.ApplyConfig(cmd, &cmder.queue, cfg.Proxy.QueueConfig)
+			if !cmd.Flags().Changed("redact") {
+				cmder.redact = cfg.Redaction.Enabled
+			}
+			cmder.redactionRules = cfg.Redaction.Rules
+			cmder.probeInterval = cfg.Health.ProbeInterval
+			cmder.captureRules = cfg.Capture.Rules
+			cmder.policyRules = cfg.Policy.Rules
+			cmder.notifyRules = cfg.Notify.Rules
+			if !cmd.Flags().Changed("blob-dir") {
+				cmder.blobDir = cfg.Storage.BlobDir
+			}
+			if !cmd.Flags().Changed("blob-threshold") {
+				cmder.blobThreshold = cfg.Storage.BlobThreshold
+			}
+			if !cmd.Flags().Changed("media-dir") {
+				cmder.mediaDir = cfg.Storage.MediaDir
+			}
+			if !cmd.Flags().Changed("media-max-bytes") {
+				cmder.mediaMaxBytes = cfg.Storage.MediaMaxBytes
+			}
+			if !cmd.Flags().Changed("media-download") {
+				cmder.mediaDownload = cfg.Storage.MediaDownload
+			}
+			cmder.retry = cfg.Proxy.Retry
+			if !cmd.Flags().Changed("retry-max-attempts") {
+				cmder.retryMaxAttempts = cfg.Proxy.Retry.MaxAttempts
+			}
 			if cmder.project == "" {
 				cmder.project = git.RepoName(cmd.Context())
 			}
+			cmder.user = identity.Resolve(cmder.user)
+
+			if cmder.headless {
+				if cmder.apiToken == "" || (cmder.proxyToken == "" && len(cmder.userTokens) == 0) {
+					return errors.New("headless mode requires --api-token and --proxy-token (or TAPES_API_TOKEN and TAPES_PROXY_TOKEN)")
+				}
+				if !cmd.Flags().Changed("proxy-listen") {
+					cmder.proxyListen = allInterfaces(cmder.proxyListen)
+				}
+				if !cmd.Flags().Changed("api-listen") {
+					cmder.apiListen = allInterfaces(cmder.apiListen)
+				}
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, _ []string) error {
@@ -150,6 +324,10 @@ func NewServeCmd() *cobra.Command {
 	defaults := config.NewDefaultConfig()
 	cmd.Flags().StringVarP(&cmder.proxyListen, "proxy-listen", "p", defaults.Proxy.Listen, "Address for proxy to listen on")
 	cmd.Flags().StringVarP(&cmder.apiListen, "api-listen", "a", defaults.API.Listen, "Address for API server to listen on")
+	cmd.Flags().StringVar(&cmder.apiToken, "api-token", "", "Bearer token required on API requests (default: none)")
+	cmd.Flags().StringVar(&cmder.readToken, "api-read-token", "", "Bearer token granting read-only API access (default: none)")
+	cmd.Flags().StringVar(&cmder.proxyToken, "proxy-token", "", "Token required in the X-Tapes-Token header on proxied requests (default: none)")
+	servetls.AddFlags(cmd, &cmder.tls)
 	cmd.Flags().StringVarP(&cmder.upstream, "upstream", "u", defaults.Proxy.Upstream, "Upstream LLM provider URL")
 	cmd.Flags().StringVar(&cmder.providerType, "provider", defaults.Proxy.Provider, "LLM provider type (anthropic, openai, ollama)")
 	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database (e.g., ./tapes.sqlite, in-memory)")
@@ -160,6 +338,23 @@ func NewServeCmd() *cobra.Command {
 	cmd.Flags().StringVar(&cmder.embeddingModel, "embedding-model", defaults.Embedding.Model, "Embedding model name (e.g., nomic-embed-text)")
 	cmd.Flags().UintVar(&cmder.embeddingDimensions, "embedding-dimensions", defaults.Embedding.Dimensions, "Embedding dimensionality.")
 	cmd.Flags().StringVar(&cmder.project, "project", "", "Project name to tag sessions (default: auto-detect from git)")
+	cmd.Flags().StringVar(&cmder.user, "user", "", "User to attribute captured turns to, unless a request sends X-Tapes-User (default: OS user)")
+	cmd.Flags().UintVar(&cmder.maxCaptureBytes, "max-capture-bytes", 0, "Truncate stored content blocks larger than this many bytes (0 = no limit)")
+	cmd.Flags().UintVar(&cmder.maxInputTokens, "max-input-tokens", 0, "Reject chat requests with more input tokens than this before forwarding them (0 = no limit)")
+	cmd.Flags().BoolVar(&cmder.rawCapture, "raw-capture", false, "Retain compressed raw request and response payloads for tapes reprocess")
+	cmd.Flags().BoolVar(&cmder.signNodes, "sign-nodes", false, "Sign stored turns with this installation's identity key, the one tapes share uses")
+	cmd.Flags().StringVar(&cmder.sessionIdleTimeout, "session-idle-timeout", "", "Close sessions after this long without a turn, e.g. 2h (default: 30m, 0 = never)")
+	queuepolicy.AddFlags(cmd, &cmder.queue)
+	cmd.Flags().BoolVar(&cmder.redact, "redact", false, "Redact API keys, credentials, and emails from content before storing")
+	cmd.Flags().StringVar(&cmder.blobDir, "blob-dir", "", "Directory, or s3://bucket/prefix, to offload large content blocks to (default: store inline)")
+	cmd.Flags().UintVar(&cmder.blobThreshold, "blob-threshold", 0, "Offload content blocks larger than this many bytes (default: 262144)")
+	cmd.Flags().StringVar(&cmder.mediaDir, "media-dir", "", "Directory to store captured images and files in (default: store inline)")
+	cmd.Flags().UintVar(&cmder.mediaMaxBytes, "media-max-bytes", 0, "Store images and files up to this many bytes (default: 20971520)")
+	cmd.Flags().BoolVar(&cmder.mediaDownload, "media-download", false, "Download images referenced by URL into the media directory")
+	cmd.Flags().UintVar(&cmder.retryMaxAttempts, "retry-max-attempts", 0, "Retry throttled and overloaded upstream requests up to this many attempts in total (0 = no retries)")
+	cmd.Flags().StringVar(&cmder.otlpEndpoint, "otlp-endpoint", "", "OTLP/HTTP collector URL to export traces to (default: OTEL_EXPORTER_OTLP_ENDPOINT)")
+	cmd.Flags().BoolVar(&cmder.headless, "headless", false, "Run as a headless server for containers: read flags from TAPES_* env vars, require auth tokens, and log JSON")
+	cmd.Flags().StringVar(&cmder.drainTimeout, "drain-timeout", "", "On shutdown, wait this long for in-flight requests and queued turns, e.g. 1m (default: 30s)")
 
 	cmd.AddCommand(apicmder.NewAPICmd())
 	cmd.AddCommand(proxycmder.NewProxyCmd())
@@ -168,9 +363,23 @@ func NewServeCmd() *cobra.Command {
 }
 
 func (c *ServeCommander) run() error {
-	c.logger = logger.NewLogger(c.debug)
+	level := zap.NewAtomicLevelAt(zap.InfoLevel)
+	if c.debug {
+		level.SetLevel(zap.DebugLevel)
+	}
+	if c.headless {
+		c.logger = logger.NewStructuredLogger(level, os.Stdout, nil)
+	} else {
+		c.logger = logger.NewLogger(c.debug)
+	}
 	defer func() { _ = c.logger.Sync() }()
 
+	shutdownTracing, err := telemetry.Setup(context.Background(), telemetry.Config{Endpoint: c.otlpEndpoint})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = shutdownTracing(context.Background()) }()
+
 	// Create shared driver
 	driver, err := c.newStorageDriver()
 	if err != nil {
@@ -184,11 +393,121 @@ func (c *ServeCommander) run() error {
 	}
 	defer driver.Close()
 
+	broker := events.NewBroker()
+	defer broker.Close()
+
+	pricing, err := deck.ResolvePricing(c.configDir, "")
+	if err != nil {
+		return err
+	}
+
+	if len(c.notifyRules) > 0 {
+		notifier, err := notify.New(c.notifyRules, pricing)
+		if err != nil {
+			return fmt.Errorf("creating notifier: %w", err)
+		}
+		stream, unsubscribe := broker.Subscribe(events.Filter{})
+		defer unsubscribe()
+		go notifier.Run(context.Background(), stream, c.logger)
+	}
+
+	var crashes *crash.Recorder
+	if c.crashDir != "" {
+		crashes = crash.NewRecorder(c.crashDir)
+	}
+
 	proxyConfig := proxy.Config{
-		ListenAddr:   c.proxyListen,
-		UpstreamURL:  c.upstream,
-		ProviderType: c.providerType,
-		Project:      c.project,
+		ListenAddr:      c.proxyListen,
+		UpstreamURL:     c.upstream,
+		ProviderType:    c.providerType,
+		Project:         c.project,
+		User:            c.user,
+		MaxCaptureBytes: int(c.maxCaptureBytes), //nolint:gosec // flag values are far below MaxInt
+		RawCapture:      c.rawCapture,
+		AuthToken:       c.proxyToken,
+		UserTokens:      c.userTokens,
+		Events:          broker,
+		Crashes:         crashes,
+		TurnCost:        pricing.TurnCost,
+	}
+	proxyConfig.SessionIdleTimeout, err = worker.ParseSessionIdleTimeout(c.sessionIdleTimeout)
+	if err != nil {
+		return err
+	}
+	proxyConfig.Queue, err = queuepolicy.FromConfig(c.queue)
+	if err != nil {
+		return err
+	}
+	if c.signNodes {
+		dir, err := share.IdentityDir(c.configDir)
+		if err != nil {
+			return err
+		}
+		if proxyConfig.SigningKey, err = share.LoadIdentity(dir); err != nil {
+			return err
+		}
+	}
+	if c.maxInputTokens > 0 {
+		proxyConfig.Budget = proxy.MaxInputTokens(c.maxInputTokens) //nolint:gosec // flag values are far below MaxInt
+	}
+	if c.drainTimeout != "" {
+		proxyConfig.DrainTimeout, err = utils.ParseDuration(c.drainTimeout)
+		if err != nil || proxyConfig.DrainTimeout <= 0 {
+			return fmt.Errorf("invalid drain timeout %q", c.drainTimeout)
+		}
+	}
+
+	// The proxy and API share one certificate.
+	tlsConfig, err := servetls.ServerConfig(c.tls, c.configDir)
+	if err != nil {
+		return err
+	}
+	proxyConfig.TLS = tlsConfig
+
+	c.retry.MaxAttempts = c.retryMaxAttempts
+	proxyConfig.Retry, proxyConfig.ProviderRetries, err = retrypolicy.FromConfig(c.retry)
+	if err != nil {
+		return err
+	}
+
+	if c.redact {
+		proxyConfig.Redactor, err = redact.NewRedactor(c.redactionRules)
+		if err != nil {
+			return fmt.Errorf("creating redactor: %w", err)
+		}
+	}
+
+	if len(c.captureRules) > 0 {
+		proxyConfig.CaptureRules, err = capture.NewRules(c.captureRules)
+		if err != nil {
+			return fmt.Errorf("creating capture rules: %w", err)
+		}
+	}
+
+	if len(c.policyRules) > 0 {
+		proxyConfig.Policy, err = policy.New(c.policyRules)
+		if err != nil {
+			return fmt.Errorf("creating policy: %w", err)
+		}
+	}
+
+	if c.blobDir != "" {
+		proxyConfig.BlobStore, err = blob.NewStore(c.blobDir, storage.CipherOf(driver))
+		if err != nil {
+			return fmt.Errorf("creating blob store: %w", err)
+		}
+		proxyConfig.BlobThreshold = int(c.blobThreshold) //nolint:gosec // flag values are far below MaxInt
+	}
+
+	if c.mediaDir != "" {
+		proxyConfig.MediaStore, err = media.NewStore(c.mediaDir, media.Options{
+			MaxBytes: int(c.mediaMaxBytes), //nolint:gosec // flag values are far below MaxInt
+			Download: c.mediaDownload,
+			Cipher:   storage.CipherOf(driver),
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	proxyConfig.VectorDriver, err = vectorutils.NewVectorDriver(&vectorutils.NewVectorDriverOpts{
@@ -233,11 +552,50 @@ func (c *ServeCommander) run() error {
 		zap.String("provider", c.providerType),
 	)
 
+	var draining atomic.Bool
+	checker := health.NewChecker()
+	checker.Add("server", health.DrainCheck(&draining))
+	p.AddHealthChecks(checker)
+	if c.probeInterval != "" {
+		interval, err := utils.ParseDuration(c.probeInterval)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf("invalid health.probe_interval %q", c.probeInterval)
+		}
+		prober := health.NewProber(map[string]string{c.providerType: c.upstream})
+		prober.AddChecks(checker)
+		probeCtx, stopProbes := context.WithCancel(context.Background())
+		defer stopProbes()
+		go prober.Run(probeCtx, interval, c.logger)
+	}
+
 	// Create API server
 	apiConfig := api.Config{
 		ListenAddr:   c.apiListen,
 		VectorDriver: proxyConfig.VectorDriver,
 		Embedder:     proxyConfig.Embedder,
+		AuthToken:    c.apiToken,
+		ReadToken:    c.readToken,
+		TLS:          tlsConfig,
+		Events:       broker,
+		CaptureSkips: func() map[string]uint64 { return p.Stats().Skipped },
+		Crashes:      crashes,
+		Health:       checker,
+		Metrics:      p.Metrics,
+	}
+	if c.headless {
+		apiConfig.LogLevel = &level
+	}
+	if c.sqlitePath != "" {
+		pricing, err := deck.ResolvePricing(c.configDir, "")
+		if err != nil {
+			return err
+		}
+		query, closeQuery, err := deck.NewQuery(context.Background(), c.sqlitePath, pricing, sqlite.WithEncryption(c.encryption))
+		if err != nil {
+			return fmt.Errorf("opening sessions: %w", err)
+		}
+		defer func() { _ = closeQuery() }()
+		apiConfig.Sessions = query
 	}
 	apiServer, err := api.NewServer(apiConfig, driver, dagLoader, c.logger)
 	if err != nil {
@@ -273,14 +631,46 @@ func (c *ServeCommander) run() error {
 	case err := <-errChan:
 		return err
 	case sig := <-sigChan:
-		c.logger.Info("received signal, shutting down", zap.String("signal", sig.String()))
+		// Closing the proxy on return drains it while the API keeps
+		// reporting the server down on /health.
+		draining.Store(true)
+		c.logger.Info("received signal, draining", zap.String("signal", sig.String()))
 		return nil
 	}
 }
 
+// flagsFromEnv sets each flag not given on the command line from its TAPES_*
+// environment variable: the flag's name in upper case with dashes replaced
+// by underscores.
+func flagsFromEnv(flags *pflag.FlagSet) error {
+	var err error
+	flags.VisitAll(func(f *pflag.Flag) {
+		if err != nil || f.Changed {
+			return
+		}
+		name := "TAPES_" + strings.ToUpper(strings.ReplaceAll(f.Name, "-", "_"))
+		if value, ok := os.LookupEnv(name); ok {
+			if setErr := flags.Set(f.Name, value); setErr != nil {
+				err = fmt.Errorf("invalid %s: %w", name, setErr)
+			}
+		}
+	})
+	return err
+}
+
+// allInterfaces returns addr with its host replaced so that it listens on
+// all interfaces.
+func allInterfaces(addr string) string {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return net.JoinHostPort("0.0.0.0", port)
+}
+
 func (c *ServeCommander) newStorageDriver() (storage.Driver, error) {
 	if c.sqlitePath != "" {
-		driver, err := sqlite.NewDriver(context.Background(), c.sqlitePath)
+		driver, err := sqlite.NewDriver(context.Background(), c.sqlitePath, sqlite.WithEncryption(c.encryption))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create SQLite storer: %w", err)
 		}
@@ -294,7 +684,7 @@ func (c *ServeCommander) newStorageDriver() (storage.Driver, error) {
 
 func (c *ServeCommander) newDagLoader() (merkle.DagLoader, error) {
 	if c.sqlitePath != "" {
-		driver, err := sqlite.NewDriver(context.Background(), c.sqlitePath)
+		driver, err := sqlite.NewDriver(context.Background(), c.sqlitePath, sqlite.WithEncryption(c.encryption))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create SQLite storer: %w", err)
 		}
@@ -0,0 +1,13 @@
+package servecmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestServeCommander(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Serve Commander Suite")
+}
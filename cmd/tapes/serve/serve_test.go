@@ -0,0 +1,49 @@
+package servecmder
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("headless mode", func() {
+	run := func(args ...string) error {
+		cmd := NewServeCmd()
+		cmd.PersistentFlags().String("config-dir", "", "Override path to .tapes/ config directory")
+		cmd.PersistentFlags().Bool("debug", false, "Enable debug logging")
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		cmd.SetArgs(append(args, "--config-dir", GinkgoT().TempDir()))
+		return cmd.Execute()
+	}
+
+	It("requires both auth tokens", func() {
+		GinkgoT().Setenv("TAPES_API_TOKEN", "api-secret")
+		Expect(run("--headless")).To(MatchError(ContainSubstring("headless mode requires --api-token and --proxy-token")))
+	})
+
+	It("takes flags not given from TAPES_* environment variables", func() {
+		cmd := NewServeCmd()
+		GinkgoT().Setenv("TAPES_API_TOKEN", "api-secret")
+		GinkgoT().Setenv("TAPES_MAX_CAPTURE_BYTES", "1024")
+		GinkgoT().Setenv("TAPES_UPSTREAM", "http://env-upstream:11434")
+		Expect(cmd.Flags().Set("upstream", "http://flag-upstream:11434")).To(Succeed())
+
+		Expect(flagsFromEnv(cmd.Flags())).To(Succeed())
+		Expect(cmd.Flags().GetString("api-token")).To(Equal("api-secret"))
+		Expect(cmd.Flags().GetUint("max-capture-bytes")).To(Equal(uint(1024)))
+		Expect(cmd.Flags().GetString("upstream")).To(Equal("http://flag-upstream:11434"))
+	})
+
+	It("rejects invalid environment values", func() {
+		GinkgoT().Setenv("TAPES_MAX_CAPTURE_BYTES", "lots")
+		Expect(flagsFromEnv(NewServeCmd().Flags())).To(MatchError(ContainSubstring("invalid TAPES_MAX_CAPTURE_BYTES")))
+	})
+
+	It("listens on all interfaces", func() {
+		Expect(allInterfaces("localhost:8080")).To(Equal("0.0.0.0:8080"))
+		Expect(allInterfaces(":8081")).To(Equal("0.0.0.0:8081"))
+	})
+})
@@ -0,0 +1,72 @@
+// Package servetls resolves the TLS settings of the "tapes serve" listeners
+// from config.toml and command flags.
+package servetls
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/dotdir"
+	"github.com/papercomputeco/tapes/pkg/tlsutil"
+)
+
+// AddFlags registers the TLS flags on cmd, bound to cfg.
+func AddFlags(cmd *cobra.Command, cfg *config.TLSConfig) {
+	cmd.Flags().BoolVar(&cfg.Enabled, "tls", false, "Serve over HTTPS (default: self-signed certificate in .tapes/tls)")
+	cmd.Flags().StringVar(&cfg.CertFile, "tls-cert", "", "PEM certificate file for HTTPS")
+	cmd.Flags().StringVar(&cfg.KeyFile, "tls-key", "", "PEM key file for HTTPS")
+	cmd.Flags().StringVar(&cfg.ClientCAFile, "tls-client-ca", "", "Require client certificates signed by the CAs in this PEM file")
+}
+
+// ApplyConfig fills the TLS settings from config.toml that were not set by
+// flags.
+func ApplyConfig(cmd *cobra.Command, cfg *config.TLSConfig, fileCfg config.TLSConfig) {
+	if !cmd.Flags().Changed("tls") {
+		cfg.Enabled = fileCfg.Enabled
+	}
+	if !cmd.Flags().Changed("tls-cert") {
+		cfg.CertFile = fileCfg.CertFile
+	}
+	if !cmd.Flags().Changed("tls-key") {
+		cfg.KeyFile = fileCfg.KeyFile
+	}
+	if !cmd.Flags().Changed("tls-client-ca") {
+		cfg.ClientCAFile = fileCfg.ClientCAFile
+	}
+}
+
+// ServerConfig returns the listener TLS configuration, or nil when TLS is
+// disabled. TLS is enabled explicitly or by configuring a certificate.
+// Self-signed certificates are kept in the tls/ directory of the .tapes/
+// directory resolved from configDir.
+func ServerConfig(cfg config.TLSConfig, configDir string) (*tls.Config, error) {
+	if !cfg.Enabled && cfg.CertFile == "" && cfg.KeyFile == "" {
+		return nil, nil
+	}
+
+	opts := tlsutil.Options{
+		CertFile:     cfg.CertFile,
+		KeyFile:      cfg.KeyFile,
+		ClientCAFile: cfg.ClientCAFile,
+	}
+	if opts.CertFile == "" && opts.KeyFile == "" {
+		dir, err := dotdir.NewManager().Target(configDir)
+		if err != nil {
+			return nil, fmt.Errorf("resolving target dir: %w", err)
+		}
+		if dir == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("resolving home dir: %w", err)
+			}
+			dir = filepath.Join(home, ".tapes")
+		}
+		opts.Dir = filepath.Join(dir, "tls")
+	}
+	return tlsutil.ServerConfig(opts)
+}
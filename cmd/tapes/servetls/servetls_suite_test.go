@@ -0,0 +1,13 @@
+package servetls
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestServeTLS(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Serve TLS Suite")
+}
@@ -0,0 +1,39 @@
+package servetls
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+)
+
+var _ = Describe("ServerConfig", func() {
+	It("returns nil when TLS is disabled", func() {
+		cfg, err := ServerConfig(config.TLSConfig{}, GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg).To(BeNil())
+	})
+
+	It("generates a self-signed certificate in the tapes directory", func() {
+		dir := GinkgoT().TempDir()
+		cfg, err := ServerConfig(config.TLSConfig{Enabled: true}, dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Certificates).To(HaveLen(1))
+		Expect(filepath.Join(dir, "tls", "cert.pem")).To(BeAnExistingFile())
+	})
+})
+
+var _ = Describe("ApplyConfig", func() {
+	It("prefers flags over config.toml", func() {
+		var tlsCfg config.TLSConfig
+		cmd := &cobra.Command{}
+		AddFlags(cmd, &tlsCfg)
+		Expect(cmd.Flags().Parse([]string{"--tls-cert", "flag.pem"})).To(Succeed())
+
+		ApplyConfig(cmd, &tlsCfg, config.TLSConfig{Enabled: true, CertFile: "file.pem", KeyFile: "key.pem"})
+		Expect(tlsCfg).To(Equal(config.TLSConfig{Enabled: true, CertFile: "flag.pem", KeyFile: "key.pem"}))
+	})
+})
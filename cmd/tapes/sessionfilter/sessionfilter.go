@@ -0,0 +1,63 @@
+// Package sessionfilter parses the --filter key=value flags that select
+// sessions for commands processing many of them in bulk.
+package sessionfilter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+// Keys are the keys accepted by --filter, in the order they are listed in
+// errors.
+var Keys = []string{"since", "model", "project", "user", "status", "tool", "label", "stop-reason", "min-cost", "max-cost", "limit"}
+
+// Parse converts key=value filters into deck filters, which select sessions
+// oldest first, and the limit they set, which is zero when unset.
+func Parse(values []string) (deck.Filters, int, error) {
+	filters := deck.Filters{Sort: "date", SortDir: "asc"}
+	limit := 0
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		val = strings.TrimSpace(val)
+		if !ok || val == "" {
+			return filters, 0, fmt.Errorf("invalid --filter %q (expected key=value)", value)
+		}
+
+		var err error
+		switch key {
+		case "since":
+			filters.Since, err = utils.ParseDuration(val)
+		case "model":
+			filters.Model = val
+		case "project":
+			filters.Project = val
+		case "user":
+			filters.User = val
+		case "status":
+			filters.Status = val
+		case "tool":
+			filters.Tool = val
+		case "label":
+			filters.Label = val
+		case "stop-reason":
+			filters.StopReason = val
+		case "min-cost":
+			filters.MinCost, err = strconv.ParseFloat(val, 64)
+		case "max-cost":
+			filters.MaxCost, err = strconv.ParseFloat(val, 64)
+		case "limit":
+			limit, err = strconv.Atoi(val)
+		default:
+			return filters, 0, fmt.Errorf("unknown --filter key %q (expected %s)", key, strings.Join(Keys, ", "))
+		}
+		if err != nil {
+			return filters, 0, fmt.Errorf("invalid --filter %s: %w", key, err)
+		}
+	}
+	return filters, limit, nil
+}
@@ -0,0 +1,221 @@
+package sessionscmder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/deckquery"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+const maxTopTools = 10
+
+func newAnalyticsCmd() *cobra.Command {
+	var since string
+
+	cmd := &cobra.Command{
+		Use:   "analytics [session-id]",
+		Short: "Show analytics across sessions or for one session",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				return runSessionAnalytics(cmd.Context(), cmd, args[0])
+			}
+			return runAnalyticsOverview(cmd.Context(), cmd, since)
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only sessions active within this age (e.g. 24h, 7d)")
+
+	return cmd
+}
+
+func runAnalyticsOverview(ctx context.Context, cmd *cobra.Command, since string) error {
+	format, err := deckquery.Format(cmd)
+	if err != nil {
+		return err
+	}
+
+	var filters deck.Filters
+	if since != "" {
+		filters.Since, err = utils.ParseDuration(since)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+
+	query, closeFn, err := deckquery.Open(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = closeFn() }()
+
+	analytics, err := query.AnalyticsOverview(ctx, filters)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if format == deckquery.FormatJSON {
+		return writeJSON(w, analytics)
+	}
+	return writeAnalyticsOverview(w, analytics)
+}
+
+func runSessionAnalytics(ctx context.Context, cmd *cobra.Command, sessionID string) error {
+	format, err := deckquery.Format(cmd)
+	if err != nil {
+		return err
+	}
+
+	query, closeFn, err := deckquery.Open(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = closeFn() }()
+
+	analytics, err := query.SessionAnalytics(ctx, strings.TrimSpace(sessionID))
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if format == deckquery.FormatJSON {
+		return writeJSON(w, analytics)
+	}
+	return writeSessionAnalytics(w, analytics)
+}
+
+func writeAnalyticsOverview(w io.Writer, a *deck.AnalyticsOverview) error {
+	if a.TotalSessions == 0 {
+		fmt.Fprintln(w, "No sessions recorded.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Sessions\t%d\n", a.TotalSessions)
+	fmt.Fprintf(tw, "Avg cost\t%s\n", cliui.FormatCost(a.AvgSessionCost))
+	fmt.Fprintf(tw, "Avg duration\t%s\n", cliui.FormatDuration(time.Duration(a.AvgDurationNs)))
+	if len(a.ProviderBreakdown) > 0 {
+		providers := slices.Sorted(maps.Keys(a.ProviderBreakdown))
+		counts := make([]string, len(providers))
+		for i, provider := range providers {
+			counts[i] = fmt.Sprintf("%s %d", provider, a.ProviderBreakdown[provider])
+		}
+		fmt.Fprintf(tw, "Providers\t%s\n", strings.Join(counts, ", "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(a.ModelPerformance) > 0 {
+		fmt.Fprintln(w, "\nModels")
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "MODEL\tPROVIDER\tSESSIONS\tAVG COST\tAVG TOKENS\tTOTAL COST\tSUCCESS")
+		for _, m := range a.ModelPerformance {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\t%.0f%%\n",
+				m.Model, orDash(m.Provider), m.Sessions,
+				cliui.FormatCost(m.AvgCost), cliui.FormatTokens(m.AvgTokens), cliui.FormatCost(m.TotalCost),
+				m.SuccessRate*100)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if hasCacheActivity(a.Cache) {
+		fmt.Fprintf(w, "\nPrompt cache (%.0f%% of input tokens read from cache)\n", a.CacheHitRate*100)
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "MODEL\tPROVIDER\tREQUESTS\tHIT RATE\tCACHE READ\tCACHE WRITE\tSAVED")
+		for _, m := range a.Cache {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%.0f%%\t%s\t%s\t%s\n",
+				orDash(m.Model), orDash(m.Provider), m.Requests, m.HitRate*100,
+				cliui.FormatTokens(m.CacheReadTokens), cliui.FormatTokens(m.CacheWriteTokens), cliui.FormatCost(m.Savings))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if a.ErrorRate > 0 {
+		fmt.Fprintf(w, "\nUpstream errors (%.1f%% of requests failed)\n", a.ErrorRate*100)
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "MODEL\tPROVIDER\tREQUESTS\tERRORS\tERROR RATE\tSTATUSES")
+		for _, m := range a.Errors {
+			if m.Errors == 0 {
+				continue
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%.1f%%\t%s\n",
+				orDash(m.Model), orDash(m.Provider), m.Requests, m.Errors, m.ErrorRate*100, formatStatuses(m.Statuses))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(a.TopTools) > 0 {
+		fmt.Fprintln(w, "\nTop tools")
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "TOOL\tCALLS\tSESSIONS\tERRORS\tAVG MS")
+		for _, t := range a.TopTools[:min(len(a.TopTools), maxTopTools)] {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\n", t.Name, t.Count, t.Sessions, t.ErrorCount, t.AvgDurationMs)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasCacheActivity reports whether any model read from or wrote to a prompt
+// cache, so the cache table is omitted for providers without caching.
+func hasCacheActivity(metrics []deck.CacheMetric) bool {
+	for _, m := range metrics {
+		if m.CacheReadTokens > 0 || m.CacheWriteTokens > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// formatStatuses lists error counts by HTTP status, such as "429 (3), 529 (1)".
+func formatStatuses(statuses map[int]int) string {
+	codes := slices.Sorted(maps.Keys(statuses))
+	parts := make([]string, len(codes))
+	for i, code := range codes {
+		parts[i] = fmt.Sprintf("%d (%d)", code, statuses[code])
+	}
+	return orDash(strings.Join(parts, ", "))
+}
+
+func writeSessionAnalytics(w io.Writer, a *deck.SessionAnalytics) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	rows := [][2]string{
+		{"Session", a.SessionID},
+		{"User messages", strconv.Itoa(a.UserMessageCount)},
+		{"Assistant messages", strconv.Itoa(a.AssistantMsgCount)},
+		{"Avg response time", cliui.FormatDuration(time.Duration(a.AvgResponseTimeNs))},
+		{"Longest pause", cliui.FormatDuration(time.Duration(a.LongestPauseNs))},
+		{"Unique tools", strconv.Itoa(a.UniqueTools)},
+		{"Tool errors", strconv.Itoa(a.ToolErrorCount)},
+		{"Tokens per minute", fmt.Sprintf("%.0f", a.TokensPerMinute)},
+		{"Avg prompt length", strconv.Itoa(a.AvgPromptLength)},
+		{"Avg response length", strconv.Itoa(a.AvgResponseLength)},
+		{"First prompt", truncate(a.FirstPrompt, maxLabelWidth)},
+	}
+	for _, row := range rows {
+		fmt.Fprintf(tw, "%s\t%s\n", row[0], row[1])
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,218 @@
+package sessionscmder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/deckquery"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+// sortKeys are the values accepted by --sort, in the order they are listed
+// in help text.
+var sortKeys = []string{"cost", "tokens", "date", "duration"}
+
+// column renders one field of a session summary in the list table.
+type column struct {
+	header string
+	value  func(deck.SessionSummary) string
+}
+
+var columns = map[string]column{
+	"id":       {"ID", func(s deck.SessionSummary) string { return s.ID }},
+	"label":    {"LABEL", func(s deck.SessionSummary) string { return truncate(s.DisplayLabel(), maxLabelWidth) }},
+	"outcome":  {"OUTCOME", func(s deck.SessionSummary) string { return orDash(s.Outcome) }},
+	"model":    {"MODEL", func(s deck.SessionSummary) string { return orDash(s.Model) }},
+	"project":  {"PROJECT", func(s deck.SessionSummary) string { return orDash(s.Project) }},
+	"user":     {"USER", func(s deck.SessionSummary) string { return orDash(s.User) }},
+	"agent":    {"AGENT", func(s deck.SessionSummary) string { return orDash(s.AgentName) }},
+	"status":   {"STATUS", func(s deck.SessionSummary) string { return s.Status }},
+	"start":    {"START", func(s deck.SessionSummary) string { return formatTime(s.StartTime) }},
+	"end":      {"END", func(s deck.SessionSummary) string { return formatTime(s.EndTime) }},
+	"duration": {"DURATION", func(s deck.SessionSummary) string { return cliui.FormatDuration(s.Duration) }},
+	"input":    {"INPUT", func(s deck.SessionSummary) string { return cliui.FormatTokens(s.InputTokens) }},
+	"output":   {"OUTPUT", func(s deck.SessionSummary) string { return cliui.FormatTokens(s.OutputTokens) }},
+	"tokens":   {"TOKENS", func(s deck.SessionSummary) string { return cliui.FormatTokens(s.InputTokens + s.OutputTokens) }},
+	"cost":     {"COST", func(s deck.SessionSummary) string { return cliui.FormatCost(s.TotalCost) }},
+	"tools":    {"TOOLS", func(s deck.SessionSummary) string { return strconv.Itoa(s.ToolCalls) }},
+	"messages": {"MESSAGES", func(s deck.SessionSummary) string { return strconv.Itoa(s.MessageCount) }},
+}
+
+const (
+	defaultColumns = "id,label,model,status,start,duration,tokens,cost"
+	maxLabelWidth  = 48
+)
+
+type listCommander struct {
+	since   string
+	model   string
+	project string
+	user    string
+	status  string
+	tool    string
+	sort    string
+	asc     bool
+	limit   int
+	columns string
+}
+
+func newListCmd() *cobra.Command {
+	cmder := &listCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recorded sessions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().StringVar(&cmder.since, "since", "", "Only sessions active within this age (e.g. 24h, 7d)")
+	cmd.Flags().StringVar(&cmder.model, "model", "", "Filter by model")
+	cmd.Flags().StringVar(&cmder.project, "project", "", "Filter by project name")
+	cmd.Flags().StringVar(&cmder.user, "user", "", "Filter by the user sessions were captured for")
+	cmd.Flags().StringVar(&cmder.status, "status", "", "Filter by status (completed|failed|abandoned)")
+	cmd.Flags().StringVar(&cmder.tool, "tool", "", "Filter by sessions that called a tool whose name contains this text")
+	cmd.Flags().StringVar(&cmder.sort, "sort", "date", "Sort sessions by "+strings.Join(sortKeys, "|"))
+	cmd.Flags().BoolVar(&cmder.asc, "asc", false, "Sort in ascending order")
+	cmd.Flags().IntVarP(&cmder.limit, "limit", "n", 0, "Maximum number of sessions to list (0 for all)")
+	cmd.Flags().StringVar(&cmder.columns, "columns", defaultColumns, "Comma-separated table columns: "+strings.Join(columnNames(), ","))
+
+	return cmd
+}
+
+func (c *listCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	format, err := deckquery.Format(cmd)
+	if err != nil {
+		return err
+	}
+	filters, err := c.filters()
+	if err != nil {
+		return err
+	}
+	selected, err := parseColumns(c.columns)
+	if err != nil {
+		return err
+	}
+
+	query, closeFn, err := deckquery.Open(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = closeFn() }()
+
+	overview, err := query.Overview(ctx, filters)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if format == deckquery.FormatJSON {
+		return writeJSON(w, overview.Sessions)
+	}
+	return writeSessionTable(w, overview.Sessions, selected)
+}
+
+func (c *listCommander) filters() (deck.Filters, error) {
+	sortKey := strings.ToLower(strings.TrimSpace(c.sort))
+	if !slices.Contains(sortKeys, sortKey) {
+		return deck.Filters{}, fmt.Errorf("unsupported sort %q (expected %s)", c.sort, strings.Join(sortKeys, ", "))
+	}
+	if c.limit < 0 {
+		return deck.Filters{}, fmt.Errorf("invalid --limit %d", c.limit)
+	}
+
+	filters := deck.Filters{
+		Model:   strings.TrimSpace(c.model),
+		Project: strings.TrimSpace(c.project),
+		User:    strings.TrimSpace(c.user),
+		Status:  strings.TrimSpace(c.status),
+		Tool:    strings.TrimSpace(c.tool),
+		Sort:    sortKey,
+		SortDir: "desc",
+		Limit:   c.limit,
+	}
+	if c.asc {
+		filters.SortDir = "asc"
+	}
+	if c.since != "" {
+		since, err := utils.ParseDuration(c.since)
+		if err != nil {
+			return filters, fmt.Errorf("invalid --since: %w", err)
+		}
+		filters.Since = since
+	}
+	return filters, nil
+}
+
+// parseColumns resolves a comma-separated list of column names.
+func parseColumns(value string) ([]column, error) {
+	var selected []column
+	for name := range strings.SplitSeq(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		col, ok := columns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (expected %s)", name, strings.Join(columnNames(), ", "))
+		}
+		selected = append(selected, col)
+	}
+	if len(selected) == 0 {
+		return nil, errors.New("no columns selected")
+	}
+	return selected, nil
+}
+
+func columnNames() []string {
+	names := make([]string, 0, len(columns))
+	for name := range columns {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func writeSessionTable(w io.Writer, sessions []deck.SessionSummary, selected []column) error {
+	if len(sessions) == 0 {
+		fmt.Fprintln(w, "No sessions recorded.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(selected))
+	for i, col := range selected {
+		headers[i] = col.header
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	values := make([]string, len(selected))
+	for _, session := range sessions {
+		for i, col := range selected {
+			values[i] = col.value(session)
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+func truncate(value string, limit int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	runes := []rune(value)
+	if len(runes) <= limit {
+		return value
+	}
+	return string(runes[:limit-3]) + "..."
+}
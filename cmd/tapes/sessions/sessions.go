@@ -0,0 +1,69 @@
+// Package sessionscmder provides the `tapes sessions` CLI commands for
+// listing and inspecting recorded sessions.
+package sessionscmder
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/deckquery"
+)
+
+const sessionsLongDesc string = `List and inspect the sessions captured through the proxy.
+
+Sessions are built the same way as in tapes deck: each conversation branch is a
+session, and branches of the same task are grouped together. Use list to browse
+sessions, show to read one turn by turn, and analytics for aggregate metrics.
+Every command prints a table by default or JSON with --format json.
+
+Examples:
+  tapes sessions list
+  tapes sessions list --sort tokens --limit 10
+  tapes sessions list --since 7d --columns id,label,cost --format json
+  tapes sessions show <session-id>
+  tapes sessions analytics --since 30d
+  tapes sessions analytics <session-id>`
+
+const sessionsShortDesc string = "List and inspect recorded sessions"
+
+// NewSessionsCmd creates the parent sessions command.
+func NewSessionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sessions",
+		Short: sessionsShortDesc,
+		Long:  sessionsLongDesc,
+	}
+
+	cmd.PersistentFlags().StringP("sqlite", "s", "", "Path to SQLite database")
+	cmd.PersistentFlags().String("pricing", "", "Path to pricing TOML or JSON overrides")
+	cmd.PersistentFlags().String("format", deckquery.FormatTable, "Output format: table|json")
+
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newShowCmd())
+	cmd.AddCommand(newAnalyticsCmd())
+
+	return cmd
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,13 @@
+package sessionscmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSessions(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sessions Command Suite")
+}
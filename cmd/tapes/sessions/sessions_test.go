@@ -0,0 +1,164 @@
+package sessionscmder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("sessions command", func() {
+	var (
+		ctx          context.Context
+		dbPath       string
+		small, large *merkle.Node
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		conversation := func(prompt, model string, promptTokens int, blocks ...llm.ContentBlock) *merkle.Node {
+			root := merkle.NewNode(merkle.Bucket{
+				Type:    "message",
+				Role:    "user",
+				Content: []llm.ContentBlock{{Type: "text", Text: prompt}},
+				Model:   model,
+			}, nil)
+			reply := merkle.NewNode(merkle.Bucket{
+				Type:    "message",
+				Role:    "assistant",
+				Content: blocks,
+				Model:   model,
+			}, root, merkle.NodeMeta{Usage: &llm.Usage{PromptTokens: promptTokens, CompletionTokens: 100}})
+			for _, n := range []*merkle.Node{root, reply} {
+				_, err := driver.Put(ctx, n)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			return reply
+		}
+
+		small = conversation("say hello", "gpt-4o-mini", 1_000, llm.ContentBlock{Type: "text", Text: "hello"})
+		large = conversation("fix the build", "gpt-4o", 1_000_000,
+			llm.ContentBlock{Type: "text", Text: "Running make"},
+			llm.ContentBlock{Type: "tool_use", ToolUseID: "call_1", ToolName: "Bash"},
+		)
+		Expect(driver.Close()).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewSessionsCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append(args, "--sqlite", dbPath))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	Describe("list", func() {
+		It("prints the default columns sorted by the requested key", func() {
+			out, err := run("list", "--sort", "tokens")
+			Expect(err).NotTo(HaveOccurred())
+
+			lines := strings.Split(strings.TrimSpace(out), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[0]).To(MatchRegexp(`^ID\s+LABEL\s+MODEL\s+STATUS\s+START\s+DURATION\s+TOKENS\s+COST$`))
+			Expect(lines[1]).To(MatchRegexp(`fix the build\s+gpt-4o\s+.*1\.0M\s+\$2\.50$`))
+			Expect(lines[2]).To(ContainSubstring("say hello"))
+
+			out, err = run("list", "--sort", "tokens", "--asc", "--limit", "1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("say hello"))
+			Expect(out).NotTo(ContainSubstring("fix the build"))
+		})
+
+		It("prints only the selected columns", func() {
+			out, err := run("list", "--columns", "model,tools", "--sort", "cost")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchRegexp(`MODEL\s+TOOLS\n`))
+			Expect(out).To(MatchRegexp(`gpt-4o\s+1\n`))
+			Expect(out).NotTo(ContainSubstring("fix the build"))
+		})
+
+		It("filters by tool and writes JSON", func() {
+			out, err := run("list", "--tool", "bash", "--format", "json")
+			Expect(err).NotTo(HaveOccurred())
+
+			var sessions []deck.SessionSummary
+			Expect(json.Unmarshal([]byte(out), &sessions)).To(Succeed())
+			Expect(sessions).To(HaveLen(1))
+			Expect(sessions[0].Label).To(Equal("fix the build"))
+			Expect(sessions[0].ToolCalls).To(Equal(1))
+		})
+
+		It("rejects unknown sorts, columns, and formats", func() {
+			_, err := run("list", "--sort", "name")
+			Expect(err).To(MatchError(ContainSubstring("unsupported sort")))
+			_, err = run("list", "--columns", "id,color")
+			Expect(err).To(MatchError(ContainSubstring(`unknown column "color"`)))
+			_, err = run("list", "--format", "xml")
+			Expect(err).To(MatchError(ContainSubstring("unsupported format")))
+		})
+	})
+
+	Describe("show", func() {
+		It("prints the summary and each turn", func() {
+			out, err := run("show", large.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("fix the build"))
+			Expect(out).To(ContainSubstring("Turns (2)"))
+			Expect(out).To(ContainSubstring("tools: Bash"))
+			Expect(out).To(ContainSubstring("Running make"))
+		})
+
+		It("writes JSON", func() {
+			out, err := run("show", small.Hash, "--format", "json")
+			Expect(err).NotTo(HaveOccurred())
+
+			var detail deck.SessionDetail
+			Expect(json.Unmarshal([]byte(out), &detail)).To(Succeed())
+			Expect(detail.Summary.ID).To(Equal(small.Hash))
+			Expect(detail.Messages).To(HaveLen(2))
+		})
+
+		It("fails for unknown sessions", func() {
+			_, err := run("show", "missing")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("analytics", func() {
+		It("summarizes all sessions", func() {
+			out, err := run("analytics")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(MatchRegexp(`Sessions\s+2\n`))
+			Expect(out).To(ContainSubstring("Top tools"))
+			Expect(out).To(MatchRegexp(`Bash\s+1\s+1`))
+			Expect(out).NotTo(ContainSubstring("Prompt cache"))
+			Expect(out).NotTo(ContainSubstring("Upstream errors"))
+		})
+
+		It("summarizes one session as JSON", func() {
+			out, err := run("analytics", large.Hash, "--format", "json")
+			Expect(err).NotTo(HaveOccurred())
+
+			var analytics deck.SessionAnalytics
+			Expect(json.Unmarshal([]byte(out), &analytics)).To(Succeed())
+			Expect(analytics.SessionID).To(Equal(large.Hash))
+			Expect(analytics.UniqueTools).To(Equal(1))
+		})
+	})
+})
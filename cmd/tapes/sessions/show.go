@@ -0,0 +1,130 @@
+package sessionscmder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/deckquery"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+const maxMessagePreview = 200
+
+func newShowCmd() *cobra.Command {
+	var full bool
+
+	cmd := &cobra.Command{
+		Use:   "show <session-id>",
+		Short: "Show a session's summary and turns",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runShow(cmd.Context(), cmd, args[0], full)
+		},
+	}
+
+	cmd.Flags().BoolVar(&full, "full", false, "Print full message text instead of a preview")
+
+	return cmd
+}
+
+func runShow(ctx context.Context, cmd *cobra.Command, sessionID string, full bool) error {
+	format, err := deckquery.Format(cmd)
+	if err != nil {
+		return err
+	}
+
+	query, closeFn, err := deckquery.Open(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = closeFn() }()
+
+	detail, err := query.SessionDetail(ctx, strings.TrimSpace(sessionID))
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if format == deckquery.FormatJSON {
+		return writeJSON(w, detail)
+	}
+	writeDetail(w, detail, full)
+	return nil
+}
+
+func writeDetail(w io.Writer, detail *deck.SessionDetail, full bool) {
+	s := detail.Summary
+	fmt.Fprintf(w, "\n%s\n", cliui.HeaderStyle.Render(s.DisplayLabel()))
+	if s.Summary != "" {
+		fmt.Fprintf(w, "  %s\n", cliui.PreviewStyle.Render(s.Summary))
+	}
+	fmt.Fprintf(w, "  %s  %s\n", cliui.DimStyle.Render("id:      "), cliui.HashStyle.Render(s.ID))
+	fmt.Fprintf(w, "  %s  %s\n", cliui.DimStyle.Render("model:   "), orDash(s.Model))
+	if s.Project != "" {
+		fmt.Fprintf(w, "  %s  %s\n", cliui.DimStyle.Render("project: "), s.Project)
+	}
+	if s.User != "" {
+		fmt.Fprintf(w, "  %s  %s\n", cliui.DimStyle.Render("user:    "), s.User)
+	}
+	if s.AgentName != "" {
+		agent := s.AgentName
+		if s.AgentVersion != "" {
+			agent += " " + s.AgentVersion
+		}
+		fmt.Fprintf(w, "  %s  %s\n", cliui.DimStyle.Render("agent:   "), agent)
+	}
+	fmt.Fprintf(w, "  %s  %s\n", cliui.DimStyle.Render("status:  "), s.Status)
+	if s.Outcome != "" {
+		fmt.Fprintf(w, "  %s  %s\n", cliui.DimStyle.Render("outcome: "), s.Outcome)
+	}
+	fmt.Fprintf(w, "  %s  %s → %s (%s)\n", cliui.DimStyle.Render("time:    "),
+		formatTime(s.StartTime), formatTime(s.EndTime), cliui.FormatDuration(s.Duration))
+	fmt.Fprintf(w, "  %s  %s in, %s out, %s\n", cliui.DimStyle.Render("usage:   "),
+		cliui.FormatTokens(s.InputTokens), cliui.FormatTokens(s.OutputTokens), cliui.FormatCost(s.TotalCost))
+
+	if len(detail.ToolFrequency) > 0 {
+		tools := slices.SortedFunc(maps.Keys(detail.ToolFrequency), func(a, b string) int {
+			if c := detail.ToolFrequency[b] - detail.ToolFrequency[a]; c != 0 {
+				return c
+			}
+			return strings.Compare(a, b)
+		})
+		counts := make([]string, len(tools))
+		for i, tool := range tools {
+			counts[i] = fmt.Sprintf("%s×%d", tool, detail.ToolFrequency[tool])
+		}
+		fmt.Fprintf(w, "  %s  %s\n", cliui.DimStyle.Render("tools:   "), strings.Join(counts, ", "))
+	}
+
+	fmt.Fprintf(w, "\nTurns (%d)\n\n", len(detail.Messages))
+	for i, msg := range detail.Messages {
+		text := msg.Text
+		if !full {
+			text = truncate(text, maxMessagePreview)
+		}
+
+		usage := ""
+		if msg.TotalTokens > 0 {
+			usage = fmt.Sprintf("  %s tokens  %s", cliui.FormatTokens(msg.TotalTokens), cliui.FormatCost(msg.TotalCost))
+		}
+		fmt.Fprintf(w, "  %s  %s  %s%s\n",
+			cliui.DimStyle.Render(fmt.Sprintf("%3d", i+1)),
+			cliui.RoleStyle.Render(fmt.Sprintf("%-9s", msg.Role)),
+			cliui.DimStyle.Render(msg.Timestamp.Local().Format("15:04:05")),
+			cliui.DimStyle.Render(usage),
+		)
+		if len(msg.ToolCalls) > 0 {
+			fmt.Fprintf(w, "       %s\n", cliui.TagStyle.Render("tools: "+strings.Join(msg.ToolCalls, ", ")))
+		}
+		if text != "" {
+			fmt.Fprintf(w, "       %s\n", cliui.PreviewStyle.Render(text))
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package sharecmder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/audit"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/share"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+	"github.com/papercomputeco/tapes/pkg/storage/media"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+const receiveLongDesc string = `Import a session bundle created with 'tapes share'.
+
+The bundle's signature is checked and every turn is rehashed before anything
+is stored. Turns keep their original timestamps and record who shared them.
+Turns already in the database are left as they are. Large content and
+media in the bundle are offloaded to the stores set by storage.blob_dir and
+storage.media_dir, as they are when captured.
+
+The signer's key fingerprint is printed; pass --trust to refuse bundles signed
+by any other key.
+
+Examples:
+  tapes receive tapes-share-3f2a9c1b7d4e.json
+  tapes receive flake.tapes.json --key <key>
+  tapes receive flake.tapes.json --trust 1a2b:3c4d:5e6f:7a8b`
+
+const receiveShortDesc string = "Import a shared session bundle"
+
+type receiveCommander struct {
+	sqlitePath string
+	key        string
+	trust      string
+}
+
+func NewReceiveCmd() *cobra.Command {
+	cmder := &receiveCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "receive <bundle>",
+		Short: receiveShortDesc,
+		Long:  receiveLongDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmder.run(cmd.Context(), cmd, args[0])
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().StringVar(&cmder.key, "key", "", "Key of an encrypted bundle")
+	cmd.Flags().StringVar(&cmder.trust, "trust", "", "Only accept bundles signed by this key fingerprint")
+
+	return cmd
+}
+
+func (c *receiveCommander) run(ctx context.Context, cmd *cobra.Command, path string) error {
+	w := cmd.OutOrStdout()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading bundle: %w", err)
+	}
+
+	var cipher *encryption.Cipher
+	if c.key != "" {
+		cipher, err = parseCipher(c.key)
+		if err != nil {
+			return err
+		}
+	}
+
+	received, err := share.Open(data, cipher)
+	if err != nil {
+		return err
+	}
+	if c.trust != "" && !strings.EqualFold(strings.TrimSpace(c.trust), received.Signer) {
+		return fmt.Errorf("bundle is signed by %s, not the trusted key %s", received.Signer, c.trust)
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	driver, err := sqlite.NewDriver(ctx, dbPath, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer driver.Close()
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	offload, err := configuredOffload(configDir, driver)
+	if err != nil {
+		return err
+	}
+
+	added, err := share.Import(ctx, driver, received, offload)
+	if err != nil {
+		return err
+	}
+
+	details := map[string]string{
+		"session":   received.Session,
+		"shared_by": received.SharedBy,
+		"signer":    received.Signer,
+		"turns":     strconv.Itoa(added),
+		"source":    "cli",
+	}
+	if err := audit.Record(configDir, audit.ActionShareReceive, dbPath, details); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write audit log: %v\n", err)
+	}
+
+	fmt.Fprintf(w, "%s Imported session %s from %s\n", cliui.SuccessMark, received.Session, received.SharedBy)
+	fmt.Fprintf(w, "  %s %s\n", cliui.DimStyle.Render("signed by"), received.Signer)
+	fmt.Fprintf(w, "  %s %s\n", cliui.DimStyle.Render("shared at"), received.SharedAt.Local().Format("2006-01-02 15:04"))
+	if received.Note != "" {
+		fmt.Fprintf(w, "  %s %s\n", cliui.DimStyle.Render("note     "), received.Note)
+	}
+	fmt.Fprintf(w, "  %s %d of %d turns new\n", cliui.DimStyle.Render("turns    "), added, len(received.Nodes))
+	return nil
+}
+
+// configuredOffload returns the blob and media stores configured for
+// captured content, so that imported content is stored the same way.
+func configuredOffload(configDir string, driver storage.Driver) (share.Offload, error) {
+	cfger, err := config.NewConfiger(configDir)
+	if err != nil {
+		return share.Offload{}, err
+	}
+	cfg, err := cfger.LoadConfig()
+	if err != nil {
+		return share.Offload{}, err
+	}
+
+	var offload share.Offload
+	if cfg.Storage.BlobDir != "" {
+		offload.Blobs, err = blob.NewStore(cfg.Storage.BlobDir, storage.CipherOf(driver))
+		if err != nil {
+			return share.Offload{}, fmt.Errorf("creating blob store: %w", err)
+		}
+		offload.BlobThreshold = int(cfg.Storage.BlobThreshold) //nolint:gosec // config values are far below MaxInt
+	}
+	if cfg.Storage.MediaDir != "" {
+		offload.Media, err = media.NewStore(cfg.Storage.MediaDir, media.Options{
+			MaxBytes: int(cfg.Storage.MediaMaxBytes), //nolint:gosec // config values are far below MaxInt
+			Cipher:   storage.CipherOf(driver),
+		})
+		if err != nil {
+			return share.Offload{}, err
+		}
+	}
+	return offload, nil
+}
@@ -0,0 +1,201 @@
+// Package sharecmder provides the `tapes share` and `tapes receive` commands
+// for handing a session to another tapes user.
+package sharecmder
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/identity"
+	"github.com/papercomputeco/tapes/pkg/share"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+const shareLongDesc string = `Package a session into a bundle a teammate can import.
+
+The bundle holds every turn of the session from its root and is signed with
+your identity key, created in the .tapes/ directory the first time you share.
+The receiver imports it with 'tapes receive', which checks the signature and
+every turn's hash and records who shared it. Content offloaded to a blob or
+media store is read back into the bundle, so the receiver needs no access to
+your stores.
+
+With --encrypt the bundle is encrypted with a new random key, which is
+printed once. Send it to the receiver separately from the bundle.
+
+Examples:
+  tapes share 3f2a9c1b7d4e
+  tapes share 3f2a9c1b7d4e --note "login test flake" -o flake.tapes.json
+  tapes share 3f2a9c1b7d4e --encrypt`
+
+const shareShortDesc string = "Export a session as a signed bundle"
+
+type shareCommander struct {
+	sqlitePath string
+	output     string
+	note       string
+	from       string
+	encrypt    bool
+}
+
+func NewShareCmd() *cobra.Command {
+	cmder := &shareCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "share <session-id>",
+		Short: shareShortDesc,
+		Long:  shareLongDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmder.run(cmd.Context(), cmd, strings.TrimSpace(args[0]))
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().StringVarP(&cmder.output, "output", "o", "", "Bundle file to write (default: tapes-share-<id>.json)")
+	cmd.Flags().StringVar(&cmder.note, "note", "", "Message for the receiver")
+	cmd.Flags().StringVar(&cmder.from, "from", "", "Name to share as (default: user@host)")
+	cmd.Flags().BoolVar(&cmder.encrypt, "encrypt", false, "Encrypt the bundle with a new random key")
+
+	return cmd
+}
+
+func (c *shareCommander) run(ctx context.Context, cmd *cobra.Command, sessionID string) error {
+	w := cmd.OutOrStdout()
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	leaves, err := sessionLeaves(ctx, dbPath, sessionID, driverOpts)
+	if err != nil {
+		return err
+	}
+
+	driver, err := sqlite.NewDriver(ctx, dbPath, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer driver.Close()
+
+	nodes, err := share.Collect(ctx, driver, leaves)
+	if err != nil {
+		return err
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	dir, err := share.IdentityDir(configDir)
+	if err != nil {
+		return err
+	}
+	key, err := share.LoadIdentity(dir)
+	if err != nil {
+		return err
+	}
+
+	var (
+		cipher     *encryption.Cipher
+		encodedKey string
+	)
+	if c.encrypt {
+		encodedKey, err = encryption.GenerateKey()
+		if err != nil {
+			return err
+		}
+		cipher, err = parseCipher(encodedKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	from := c.from
+	if from == "" {
+		from = defaultSender()
+	}
+	bundle := &share.Bundle{
+		Session:  sessionID,
+		SharedBy: from,
+		SharedAt: time.Now().UTC(),
+		Note:     c.note,
+		Nodes:    nodes,
+	}
+	data, err := share.Seal(bundle, key, cipher)
+	if err != nil {
+		return err
+	}
+
+	output := c.output
+	if output == "" {
+		output = fmt.Sprintf("tapes-share-%s.json", sessionID[:min(12, len(sessionID))])
+	}
+	if err := os.WriteFile(output, data, 0o600); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+
+	fmt.Fprintf(w, "%s Wrote %s (%d turns)\n", cliui.SuccessMark, output, len(nodes))
+	fmt.Fprintf(w, "  %s %s\n", cliui.DimStyle.Render("signed by"), share.Fingerprint(key.Public().(ed25519.PublicKey)))
+	if encodedKey != "" {
+		fmt.Fprintf(w, "\nKey: %s\n", encodedKey)
+		fmt.Fprintln(w, cliui.DimStyle.Render("Send the key separately. The receiver runs:"))
+		fmt.Fprintf(w, "  tapes receive %s --key <key>\n", filepath.Base(output))
+	}
+	return nil
+}
+
+// sessionLeaves returns the leaf turns of a session: the ID itself for a
+// single branch, or every branch of a grouped session.
+func sessionLeaves(ctx context.Context, dbPath, sessionID string, driverOpts []sqlite.Option) ([]string, error) {
+	query, closeFn, err := deck.NewQuery(ctx, dbPath, deck.DefaultPricing(), driverOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = closeFn() }()
+
+	detail, err := query.SessionDetail(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("session %s not found: %w", sessionID, err)
+	}
+	if len(detail.SubSessions) == 0 {
+		return []string{sessionID}, nil
+	}
+
+	leaves := make([]string, 0, len(detail.SubSessions))
+	for _, sub := range detail.SubSessions {
+		leaves = append(leaves, sub.ID)
+	}
+	return leaves, nil
+}
+
+// defaultSender names the sharer as user@host.
+func defaultSender() string {
+	name := identity.User()
+	if host, err := os.Hostname(); err == nil && host != "" {
+		return name + "@" + host
+	}
+	return name
+}
+
+func parseCipher(encoded string) (*encryption.Cipher, error) {
+	key, err := encryption.ParseKey(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return encryption.NewCipher(key)
+}
@@ -0,0 +1,113 @@
+package sharecmder
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"regexp"
+
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("share and receive commands", func() {
+	var (
+		ctx        context.Context
+		dir        string
+		senderDB   string
+		receiverDB string
+		bundlePath string
+		leaf       *merkle.Node
+	)
+
+	run := func(cmd *cobra.Command, args ...string) (string, error) {
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(args)
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir = GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", dir)
+		senderDB = filepath.Join(dir, "sender.db")
+		receiverDB = filepath.Join(dir, "receiver.db")
+		bundlePath = filepath.Join(dir, "bundle.json")
+
+		driver, err := sqlite.NewDriver(ctx, senderDB)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		prompt := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: "why does the login test fail?"}},
+		}, nil)
+		leaf = merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "assistant",
+			Content: []llm.ContentBlock{{Type: "text", Text: "The session cookie expires too early."}},
+		}, prompt)
+		for _, n := range []*merkle.Node{prompt, leaf} {
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	receivedBy := func() string {
+		driver, err := sqlite.NewDriver(ctx, receiverDB)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		got, err := driver.Get(ctx, leaf.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		return got.SharedBy
+	}
+
+	It("hands a session to another database", func() {
+		out, err := run(NewShareCmd(), leaf.Hash, "--sqlite", senderDB, "-o", bundlePath, "--from", "alice@laptop", "--note", "flaky")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("(2 turns)"))
+
+		out, err = run(NewReceiveCmd(), bundlePath, "--sqlite", receiverDB)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("from alice@laptop"))
+		Expect(out).To(ContainSubstring("2 of 2 turns new"))
+		Expect(receivedBy()).To(HavePrefix("alice@laptop ("))
+	})
+
+	It("requires the key of an encrypted bundle", func() {
+		out, err := run(NewShareCmd(), leaf.Hash, "--sqlite", senderDB, "-o", bundlePath, "--encrypt")
+		Expect(err).NotTo(HaveOccurred())
+		key := regexp.MustCompile(`Key: (\S+)`).FindStringSubmatch(out)
+		Expect(key).To(HaveLen(2))
+
+		_, err = run(NewReceiveCmd(), bundlePath, "--sqlite", receiverDB)
+		Expect(err).To(MatchError(ContainSubstring("encrypted")))
+
+		_, err = run(NewReceiveCmd(), bundlePath, "--sqlite", receiverDB, "--key", key[1])
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("refuses bundles from untrusted keys", func() {
+		_, err := run(NewShareCmd(), leaf.Hash, "--sqlite", senderDB, "-o", bundlePath)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = run(NewReceiveCmd(), bundlePath, "--sqlite", receiverDB, "--trust", "0000:0000:0000:0000")
+		Expect(err).To(MatchError(ContainSubstring("not the trusted key")))
+	})
+
+	It("fails for unknown sessions", func() {
+		_, err := run(NewShareCmd(), "does-not-exist", "--sqlite", senderDB, "-o", bundlePath)
+		Expect(err).To(MatchError(ContainSubstring("not found")))
+	})
+})
@@ -30,6 +30,7 @@ type generateCommander struct {
 	search     string
 	searchTop  int
 	apiTarget  string
+	apiToken   string
 }
 
 func newGenerateCmd() *cobra.Command {
@@ -56,9 +57,6 @@ Examples:
   tapes skill generate --search "react hooks" --search-top 3 --name react-debug
   tapes skill generate abc123 --name morning-work --since 2026-02-17`,
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
-			if cmd.Flags().Changed("api-target") {
-				return nil
-			}
 			configDir, _ := cmd.Flags().GetString("config-dir")
 			cfger, err := config.NewConfiger(configDir)
 			if err != nil {
@@ -68,7 +66,10 @@ Examples:
 			if err != nil {
 				return nil //nolint:nilerr // non-fatal, fall back to default
 			}
-			cmder.apiTarget = cfg.Client.APITarget
+			if !cmd.Flags().Changed("api-target") {
+				cmder.apiTarget = cfg.Client.APITarget
+			}
+			cmder.apiToken = cfg.API.Token
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -123,7 +124,11 @@ func (c *generateCommander) run(cmd *cobra.Command, args []string) error {
 		if dbErr != nil {
 			return dbErr
 		}
-		query, closeFn, dbErr = deck.NewQuery(cmd.Context(), dbPath, nil)
+		driverOpts, dbErr := sqlitepath.DriverOptions(cmd)
+		if dbErr != nil {
+			return dbErr
+		}
+		query, closeFn, dbErr = deck.NewQuery(cmd.Context(), dbPath, nil, driverOpts...)
 		return dbErr
 	}); err != nil {
 		return err
@@ -222,7 +227,7 @@ func (c *generateCommander) resolveHashes(cmd *cobra.Command, args []string) ([]
 func (c *generateCommander) searchForHashes(cmd *cobra.Command) ([]string, error) {
 	fmt.Fprintf(cmd.OutOrStdout(), "Searching for %q...\n", c.search)
 
-	output, err := searchcmder.SearchAPI(c.apiTarget, c.search, c.searchTop)
+	output, err := searchcmder.SearchAPI(c.apiTarget, c.apiToken, c.search, c.searchTop)
 	if err != nil {
 		return nil, fmt.Errorf("search: %w", err)
 	}
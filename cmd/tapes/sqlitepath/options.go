@@ -0,0 +1,24 @@
+package sqlitepath
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+// DriverOptions returns the options for opening the database from the
+// configuration in cmd's --config-dir, so that storage.encryption applies to
+// every command that reads or writes it.
+func DriverOptions(cmd *cobra.Command) ([]sqlite.Option, error) {
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	cfger, err := config.NewConfiger(configDir)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := cfger.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return []sqlite.Option{sqlite.WithEncryption(cfg.Storage.Encryption)}, nil
+}
@@ -0,0 +1,55 @@
+package startcmder
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// aiderVersionTimeout bounds "aider --version", which starts a Python
+// interpreter and can be slow on a cold cache.
+const aiderVersionTimeout = 10 * time.Second
+
+// aiderEnv returns the variables pointing aider at the proxy. Aider talks to
+// each provider through litellm, which takes a base URL per provider, so
+// every provider is routed through its /providers/<name> prefix. Model, when
+// set, selects aider's model.
+func aiderEnv(baseURL, model string) []string {
+	env := []string{
+		"OPENAI_API_BASE=" + baseURL + "/providers/openai",
+		"ANTHROPIC_API_URL=" + baseURL + "/providers/anthropic",
+		// litellm versions differ in which Anthropic variable they read.
+		"ANTHROPIC_BASE_URL=" + baseURL + "/providers/anthropic",
+		"OLLAMA_API_BASE=" + baseURL + "/providers/ollama",
+	}
+	if model != "" {
+		env = append(env, "AIDER_MODEL="+model)
+	}
+	return env
+}
+
+// aiderVersion returns the version of the aider at path, or "" when it
+// cannot be determined.
+func aiderVersion(ctx context.Context, path string) string {
+	ctx, cancel := context.WithTimeout(ctx, aiderVersionTimeout)
+	defer cancel()
+
+	// #nosec G204 -- path is the aider binary found on PATH.
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	return parseAiderVersion(string(out))
+}
+
+// parseAiderVersion reads the version from "aider --version" output, such as
+// "aider 0.86.1".
+func parseAiderVersion(out string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	fields := strings.Fields(line)
+	if len(fields) != 2 || fields[0] != "aider" {
+		return ""
+	}
+	return fields[1]
+}
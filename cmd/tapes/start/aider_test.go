@@ -0,0 +1,29 @@
+package startcmder
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("aiderEnv", func() {
+	It("routes every provider through the proxy", func() {
+		Expect(aiderEnv("http://127.0.0.1:8080/runs/r1/agents/aider", "")).To(ConsistOf(
+			"OPENAI_API_BASE=http://127.0.0.1:8080/runs/r1/agents/aider/providers/openai",
+			"ANTHROPIC_API_URL=http://127.0.0.1:8080/runs/r1/agents/aider/providers/anthropic",
+			"ANTHROPIC_BASE_URL=http://127.0.0.1:8080/runs/r1/agents/aider/providers/anthropic",
+			"OLLAMA_API_BASE=http://127.0.0.1:8080/runs/r1/agents/aider/providers/ollama",
+		))
+	})
+
+	It("selects the model when one is given", func() {
+		Expect(aiderEnv("http://127.0.0.1:8080", "sonnet")).To(ContainElement("AIDER_MODEL=sonnet"))
+	})
+})
+
+var _ = Describe("parseAiderVersion", func() {
+	It("reads the version from aider --version", func() {
+		Expect(parseAiderVersion("aider 0.86.1\n")).To(Equal("0.86.1"))
+		Expect(parseAiderVersion("Traceback (most recent call last):\n")).To(BeEmpty())
+		Expect(parseAiderVersion("")).To(BeEmpty())
+	})
+})
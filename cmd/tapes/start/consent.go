@@ -0,0 +1,57 @@
+package startcmder
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// recordingEnv tells a launched agent whether its session is recorded,
+	// as "1" or "0", so that it can surface it to the people using it.
+	recordingEnv = "TAPES_RECORDING"
+
+	// runIDEnv carries the ID of the run a launched agent belongs to.
+	runIDEnv = "TAPES_RUN_ID"
+)
+
+// confirmRecording asks whether agent's session may be recorded, reporting
+// true only for an explicit yes.
+func confirmRecording(stdin io.Reader, stdout io.Writer, agent string) (bool, error) {
+	fmt.Fprintf(stdout, "tapes will record this %s session, storing every prompt and response.\n", agent)
+	fmt.Fprint(stdout, "Record it? Declining runs it without recording [y/N]: ")
+
+	line, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("reading recording confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// recordingAgentEnv returns env with the variables that tell an agent
+// whether it is recorded and which run it belongs to.
+func recordingAgentEnv(env []string, record bool, runID string) []string {
+	recording := "0"
+	if record {
+		recording = "1"
+	}
+	return append(env, recordingEnv+"="+recording, runIDEnv+"="+runID)
+}
+
+// showRecordingTitle marks the terminal title as recording agent's session
+// and returns a function restoring the previous title. Terminals keep a
+// stack of titles: the current one is pushed first and popped on restore.
+// Agents that set their own title replace the indicator until they exit.
+func showRecordingTitle(out io.Writer, agent string) func() {
+	fmt.Fprintf(out, "\x1b[22;0t\x1b]0;● tapes recording · %s\a", agent)
+	return func() {
+		fmt.Fprint(out, "\x1b[23;0t")
+	}
+}
@@ -0,0 +1,42 @@
+package startcmder
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("confirmRecording", func() {
+	It("records only on an explicit yes", func() {
+		for answer, want := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+			var out bytes.Buffer
+			record, err := confirmRecording(strings.NewReader(answer), &out, "claude")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record).To(Equal(want), answer)
+			Expect(out.String()).To(ContainSubstring("record this claude session"))
+		}
+	})
+})
+
+var _ = Describe("recordingAgentEnv", func() {
+	It("tells the agent whether it is recorded and its run", func() {
+		Expect(recordingAgentEnv([]string{"HOME=/home/me"}, true, "run-1")).To(Equal([]string{
+			"HOME=/home/me", "TAPES_RECORDING=1", "TAPES_RUN_ID=run-1",
+		}))
+		Expect(recordingAgentEnv(nil, false, "run-2")).To(ContainElement("TAPES_RECORDING=0"))
+	})
+})
+
+var _ = Describe("showRecordingTitle", func() {
+	It("sets the terminal title and restores the previous one", func() {
+		var out bytes.Buffer
+		restore := showRecordingTitle(&out, "codex")
+		Expect(out.String()).To(ContainSubstring("\x1b]0;● tapes recording · codex\a"))
+
+		out.Reset()
+		restore()
+		Expect(out.String()).To(Equal("\x1b[23;0t"))
+	})
+})
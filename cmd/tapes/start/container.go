@@ -0,0 +1,169 @@
+package startcmder
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// containerHost is the host name containers reach the gateway at. It is
+	// mapped to the host with --add-host, which Docker Desktop also provides
+	// on its own.
+	containerHost = "host.docker.internal"
+
+	// dockerBridge is the interface of Docker's default bridge network on
+	// Linux, whose address containers on that network reach the host at.
+	dockerBridge = "docker0"
+
+	// containerReadHeaderTimeout bounds how long the gateway waits for a
+	// request's headers.
+	containerReadHeaderTimeout = 10 * time.Second
+)
+
+// containerGateway forwards requests from containers to an agent base URL on
+// the proxy, which only listens on loopback. It listens on an address
+// containers can reach, so it requires a random token as the first path
+// segment of every request.
+type containerGateway struct {
+	server   *http.Server
+	listener net.Listener
+	token    string
+}
+
+// startContainerGateway starts a gateway on listenAddr forwarding to target.
+// An empty listenAddr picks the Docker bridge address, or loopback where there
+// is none, as with Docker Desktop.
+func startContainerGateway(listenAddr, target string) (*containerGateway, error) {
+	targetURL, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("parsing proxy URL: %w", err)
+	}
+	if listenAddr == "" {
+		listenAddr = defaultContainerListenAddr()
+	}
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("creating container gateway listener: %w", err)
+	}
+
+	g := &containerGateway{listener: listener, token: strings.ToLower(rand.Text())}
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(targetURL)
+		},
+		// Relay streamed responses as they arrive.
+		FlushInterval: -1,
+	}
+	g.server = &http.Server{
+		Handler:           g.requireToken(proxy),
+		ReadHeaderTimeout: containerReadHeaderTimeout,
+	}
+	go func() { _ = g.server.Serve(listener) }()
+	return g, nil
+}
+
+// requireToken rejects requests whose path does not start with the gateway's
+// token, and strips it from the others.
+func (g *containerGateway) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(g.token)) != 1 {
+			http.Error(w, "missing or invalid tapes container token", http.StatusUnauthorized)
+			return
+		}
+		r.URL.Path = "/" + rest
+		r.URL.RawPath = ""
+		next.ServeHTTP(w, r)
+	})
+}
+
+// URL returns the base URL containers reach the gateway at.
+func (g *containerGateway) URL() string {
+	_, port, _ := net.SplitHostPort(g.listener.Addr().String())
+	return fmt.Sprintf("http://%s/%s", net.JoinHostPort(containerHost, port), g.token)
+}
+
+// Close stops the gateway.
+func (g *containerGateway) Close() error {
+	return g.server.Close()
+}
+
+// defaultContainerListenAddr returns the Docker bridge address when there is
+// one, and loopback otherwise.
+func defaultContainerListenAddr() string {
+	iface, err := net.InterfaceByName(dockerBridge)
+	if err != nil {
+		return "127.0.0.1:0"
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return "127.0.0.1:0"
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+			return net.JoinHostPort(ipNet.IP.String(), "0")
+		}
+	}
+	return "127.0.0.1:0"
+}
+
+// containerEnv returns the variables pointing agents in a container at the
+// gateway at baseURL. Provider, when set, also selects the provider that
+// frameworks taking a single base URL, such as OpenHands, are pointed at.
+func containerEnv(baseURL, provider string) []string {
+	env := []string{
+		"OPENAI_BASE_URL=" + baseURL + "/providers/openai",
+		"OPENAI_API_BASE=" + baseURL + "/providers/openai",
+		"ANTHROPIC_BASE_URL=" + baseURL + "/providers/anthropic",
+		"OLLAMA_API_BASE=" + baseURL + "/providers/ollama",
+	}
+	if provider != "" {
+		env = append(env, "LLM_BASE_URL="+baseURL+"/providers/"+provider)
+	}
+	return env
+}
+
+// containerCredentialEnv names the credential variables passed into the
+// container. They are passed by name, so that their values are copied from
+// the environment of the docker command and never appear in its arguments.
+var containerCredentialEnv = []string{"OPENAI_API_KEY", "ANTHROPIC_API_KEY"}
+
+// containerRunArgs inserts the flags setting env in the container, and mapping
+// containerHost to the host, after the "run" of a docker or podman run
+// command's arguments.
+func containerRunArgs(args, env []string) ([]string, error) {
+	run := -1
+	for i, arg := range args {
+		if arg == "run" {
+			run = i
+			break
+		}
+		if strings.HasPrefix(arg, "-") || i > 0 {
+			break
+		}
+	}
+	if run < 0 {
+		return nil, errors.New("tapes start container wraps a docker or podman run command, as in: tapes start container -- docker run IMAGE")
+	}
+
+	flags := []string{"--add-host=" + containerHost + ":host-gateway"}
+	for _, kv := range env {
+		flags = append(flags, "-e", kv)
+	}
+	for _, name := range containerCredentialEnv {
+		flags = append(flags, "-e", name)
+	}
+
+	out := make([]string, 0, len(args)+len(flags))
+	out = append(out, args[:run+1]...)
+	out = append(out, flags...)
+	return append(out, args[run+1:]...), nil
+}
@@ -0,0 +1,89 @@
+package startcmder
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("containerRunArgs", func() {
+	env := []string{"OPENAI_BASE_URL=http://gw/providers/openai"}
+	injected := []string{
+		"--add-host=host.docker.internal:host-gateway",
+		"-e", "OPENAI_BASE_URL=http://gw/providers/openai",
+		"-e", "OPENAI_API_KEY",
+		"-e", "ANTHROPIC_API_KEY",
+	}
+
+	It("inserts its flags after run", func() {
+		args, err := containerRunArgs([]string{"run", "-it", "img", "--task", "fix"}, env)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args).To(Equal(append(append([]string{"run"}, injected...), "-it", "img", "--task", "fix")))
+	})
+
+	It("accepts docker container run", func() {
+		args, err := containerRunArgs([]string{"container", "run", "img"}, env)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(args).To(Equal(append(append([]string{"container", "run"}, injected...), "img")))
+	})
+
+	It("rejects commands other than run", func() {
+		_, err := containerRunArgs([]string{"exec", "box", "run"}, env)
+		Expect(err).To(MatchError(ContainSubstring("docker or podman run")))
+		_, err = containerRunArgs(nil, env)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("containerGateway", func() {
+	var (
+		upstream *httptest.Server
+		gateway  *containerGateway
+		paths    chan string
+	)
+
+	BeforeEach(func() {
+		paths = make(chan string, 1)
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths <- r.URL.Path
+			_, _ = io.WriteString(w, "ok")
+		}))
+		var err error
+		gateway, err = startContainerGateway("127.0.0.1:0", upstream.URL+"/runs/r1/agents/container")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		_ = gateway.Close()
+		upstream.Close()
+	})
+
+	localURL := func(path string) string {
+		return "http://" + gateway.listener.Addr().String() + path
+	}
+
+	It("advertises the host name containers reach it at, with its token", func() {
+		Expect(gateway.URL()).To(HavePrefix("http://host.docker.internal:"))
+		Expect(gateway.URL()).To(HaveSuffix("/" + gateway.token))
+	})
+
+	It("forwards requests carrying the token to the agent base URL", func() {
+		resp, err := http.Post(localURL("/"+gateway.token+"/providers/openai/chat/completions"), "application/json", strings.NewReader("{}"))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(<-paths).To(Equal("/runs/r1/agents/container/providers/openai/chat/completions"))
+	})
+
+	It("rejects requests without the token", func() {
+		resp, err := http.Post(localURL("/wrong/providers/openai/chat/completions"), "application/json", strings.NewReader("{}"))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		Expect(paths).To(BeEmpty())
+	})
+})
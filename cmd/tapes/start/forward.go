@@ -0,0 +1,124 @@
+package startcmder
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/tlsutil"
+	"github.com/papercomputeco/tapes/proxy/forward"
+)
+
+const (
+	// forwardReadHeaderTimeout bounds how long the forward proxy waits for a
+	// CONNECT request's headers.
+	forwardReadHeaderTimeout = 10 * time.Second
+
+	// caBundleFileName is the bundle of the system's CA certificates and the
+	// tapes CA, written next to the tapes CA.
+	caBundleFileName = "bundle.pem"
+)
+
+// systemCABundles are where Linux distributions and macOS keep their bundle
+// of trusted CA certificates.
+var systemCABundles = []string{
+	"/etc/ssl/certs/ca-certificates.crt",
+	"/etc/pki/tls/certs/ca-bundle.crt",
+	"/etc/ssl/ca-bundle.pem",
+	"/etc/ssl/cert.pem",
+}
+
+// startForwardProxy starts a forward proxy on loopback, sending the provider
+// requests of tools that honor HTTPS_PROXY to target, and returns the
+// variables pointing the agent at it and a cleanup function stopping it.
+func startForwardProxy(caDir, target string) ([]string, func() error, error) {
+	ca, err := tlsutil.LoadCA(caDir, forward.HostNames(forward.DefaultHosts()))
+	if err != nil {
+		return nil, nil, err
+	}
+	if ca.Replaced() {
+		fmt.Fprintf(os.Stderr, "Warning: the tapes CA was regenerated. Anything told to trust the old one, such as a system trust store, must be told to trust %s again, or HTTPS requests through tapes will fail.\n", ca.CertFile())
+	}
+	fwd, err := forward.New(forward.Config{Target: target, CA: ca}, zap.NewNop())
+	if err != nil {
+		return nil, nil, err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating forward proxy listener: %w", err)
+	}
+	server := &http.Server{
+		Handler:           fwd,
+		ReadHeaderTimeout: forwardReadHeaderTimeout,
+	}
+	go func() { _ = server.Serve(listener) }()
+
+	proxyURL := "http://" + listener.Addr().String()
+	noProxy := "127.0.0.1,localhost"
+	if existing := os.Getenv("NO_PROXY"); existing != "" {
+		noProxy = existing + "," + noProxy
+	}
+	env := []string{
+		"HTTPS_PROXY=" + proxyURL,
+		"https_proxy=" + proxyURL,
+		"NO_PROXY=" + noProxy,
+		"no_proxy=" + noProxy,
+		// Node adds these to its built-in CAs.
+		"NODE_EXTRA_CA_CERTS=" + ca.CertFile(),
+	}
+
+	// Other runtimes replace their trusted CAs with the file they are given,
+	// so they are given the system's CAs too. Without a system bundle to add
+	// to, they are left alone, and only trust the tapes CA when it is
+	// installed.
+	bundle, err := writeCABundle(caDir, ca.CertFile())
+	if err != nil {
+		_ = server.Close()
+		return nil, nil, err
+	}
+	if bundle != "" {
+		env = append(env,
+			"SSL_CERT_FILE="+bundle,
+			"REQUESTS_CA_BUNDLE="+bundle,
+			"CURL_CA_BUNDLE="+bundle,
+		)
+	}
+	return env, server.Close, nil
+}
+
+// writeCABundle writes the system's CA certificates followed by the
+// certificate in caFile to a bundle in dir, and returns its path, or "" when
+// no system bundle is found.
+func writeCABundle(dir, caFile string) (string, error) {
+	candidates := systemCABundles
+	if current := os.Getenv("SSL_CERT_FILE"); current != "" {
+		candidates = append([]string{current}, candidates...)
+	}
+	var system []byte
+	for _, path := range candidates {
+		data, err := os.ReadFile(path)
+		if err == nil && len(data) > 0 {
+			system = data
+			break
+		}
+	}
+	if system == nil {
+		return "", nil
+	}
+
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return "", fmt.Errorf("reading tapes CA: %w", err)
+	}
+	bundle := filepath.Join(dir, caBundleFileName)
+	data := append(append(system, '\n'), caPEM...)
+	if err := os.WriteFile(bundle, data, 0o644); err != nil { //nolint:gosec // certificates are public
+		return "", fmt.Errorf("writing CA bundle: %w", err)
+	}
+	return bundle, nil
+}
@@ -0,0 +1,26 @@
+package startcmder
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("writeCABundle", func() {
+	It("appends the tapes CA to the system's CAs", func() {
+		dir := GinkgoT().TempDir()
+		system := filepath.Join(dir, "system.pem")
+		caFile := filepath.Join(dir, "ca.pem")
+		Expect(os.WriteFile(system, []byte("SYSTEM"), 0o600)).To(Succeed())
+		Expect(os.WriteFile(caFile, []byte("TAPES"), 0o600)).To(Succeed())
+		GinkgoT().Setenv("SSL_CERT_FILE", system)
+
+		bundle, err := writeCABundle(dir, caFile)
+		Expect(err).NotTo(HaveOccurred())
+		data, err := os.ReadFile(bundle)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("SYSTEM\nTAPES"))
+	})
+})
@@ -267,7 +267,7 @@ var _ = Describe("configureOpenCode config merge", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(os.WriteFile(filepath.Join(userConfigDir, "opencode.json"), data, 0o600)).To(Succeed())
 
-		cleanup, configRoot, err := configureOpenCode("http://localhost:9999", tmpTapesDir)
+		cleanup, configRoot, err := configureOpenCode("http://localhost:9999", tmpTapesDir, "")
 		Expect(err).NotTo(HaveOccurred())
 		DeferCleanup(func() { _ = cleanup() })
 
@@ -318,7 +318,7 @@ var _ = Describe("configureOpenCode config merge", func() {
 
 	It("creates config from scratch when no user config exists", func() {
 		// tmpXDG is empty, no opencode config exists.
-		cleanup, configRoot, err := configureOpenCode("http://localhost:8888", tmpTapesDir)
+		cleanup, configRoot, err := configureOpenCode("http://localhost:8888", tmpTapesDir, "")
 		Expect(err).NotTo(HaveOccurred())
 		DeferCleanup(func() { _ = cleanup() })
 
@@ -336,7 +336,7 @@ var _ = Describe("configureOpenCode config merge", func() {
 	})
 
 	It("cleanup removes temp directory", func() {
-		cleanup, configRoot, err := configureOpenCode("http://localhost:7777", tmpTapesDir)
+		cleanup, configRoot, err := configureOpenCode("http://localhost:7777", tmpTapesDir, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(configRoot).To(BeADirectory())
@@ -350,7 +350,7 @@ var _ = Describe("configureOpenCode config merge", func() {
 		Expect(mgr.SetKey("openai", "sk-test-openai-key")).To(Succeed())
 		Expect(mgr.SetKey("anthropic", "sk-test-anthropic-key")).To(Succeed())
 
-		cleanup, configRoot, err := configureOpenCode("http://localhost:6666", tmpTapesDir)
+		cleanup, configRoot, err := configureOpenCode("http://localhost:6666", tmpTapesDir, "")
 		Expect(err).NotTo(HaveOccurred())
 		DeferCleanup(func() { _ = cleanup() })
 
@@ -386,7 +386,7 @@ var _ = Describe("configureOpenCode config merge", func() {
 	})
 
 	It("works without stored credentials", func() {
-		cleanup, configRoot, err := configureOpenCode("http://localhost:5555", tmpTapesDir)
+		cleanup, configRoot, err := configureOpenCode("http://localhost:5555", tmpTapesDir, "")
 		Expect(err).NotTo(HaveOccurred())
 		DeferCleanup(func() { _ = cleanup() })
 
@@ -0,0 +1,205 @@
+package startcmder
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/sse"
+)
+
+// topToolCount is the number of most called tools listed in a run summary.
+const topToolCount = 3
+
+var (
+	// runWatchConnectTimeout bounds how long launching an agent waits for the
+	// daemon's event stream to connect.
+	runWatchConnectTimeout = 2 * time.Second
+
+	// runSummaryGrace is how long the run summary waits after the agent exits
+	// for the daemon to store the agent's last turns.
+	runSummaryGrace = 500 * time.Millisecond
+)
+
+// runStats tallies the turns of one agent run from the daemon's event
+// stream, for the summary printed when the agent exits.
+type runStats struct {
+	pricing deck.PricingTable
+
+	mu           sync.Mutex
+	turns        int
+	inputTokens  int64
+	outputTokens int64
+	cost         float64
+	unpriced     int
+	tools        map[string]int
+}
+
+func newRunStats(pricing deck.PricingTable) *runStats {
+	return &runStats{
+		pricing: pricing,
+		tools:   map[string]int{},
+	}
+}
+
+// add tallies one event. Each response of the agent counts as a turn.
+func (s *runStats) add(event events.Event) {
+	if event.Role != "assistant" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.turns++
+	for _, tool := range event.ToolCalls {
+		s.tools[tool]++
+	}
+	if event.Usage == nil {
+		return
+	}
+
+	input := int64(event.Usage.PromptTokens)
+	output := int64(event.Usage.CompletionTokens)
+	s.inputTokens += input
+	s.outputTokens += output
+
+	pricing, ok := deck.PricingForModel(s.pricing, event.Model)
+	if !ok {
+		s.unpriced++
+		return
+	}
+	_, _, cost := deck.CostForTokensWithCache(pricing, input, output,
+		int64(event.Usage.CacheCreationInputTokens),
+		int64(event.Usage.CacheReadInputTokens),
+	)
+	s.cost += cost
+}
+
+// summary formats the run's totals on one line, or returns "" when the run
+// had no turns.
+func (s *runStats) summary() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.turns == 0 {
+		return ""
+	}
+
+	turns := "turns"
+	if s.turns == 1 {
+		turns = "turn"
+	}
+	cost := fmt.Sprintf("$%.2f", s.cost)
+	if s.unpriced > 0 {
+		cost += fmt.Sprintf(" (%d unpriced)", s.unpriced)
+	}
+	parts := []string{
+		fmt.Sprintf("%d %s", s.turns, turns),
+		fmt.Sprintf("%s in / %s out", cliui.FormatTokens(s.inputTokens), cliui.FormatTokens(s.outputTokens)),
+		cost,
+	}
+	if tools := s.topTools(topToolCount); len(tools) > 0 {
+		parts = append(parts, "top tools: "+strings.Join(tools, ", "))
+	}
+	return "tapes: " + strings.Join(parts, " · ")
+}
+
+// topTools returns up to n of the most called tools with their call counts,
+// most called first. The caller must hold s.mu.
+func (s *runStats) topTools(n int) []string {
+	names := make([]string, 0, len(s.tools))
+	for name := range s.tools {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		if c := cmp.Compare(s.tools[b], s.tools[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+
+	top := make([]string, 0, n)
+	for _, name := range names[:min(n, len(names))] {
+		top = append(top, fmt.Sprintf("%s (%d)", name, s.tools[name]))
+	}
+	return top
+}
+
+// watchRun tallies the events of run from the API at apiURL into stats until
+// ctx is done. It returns once the stream is connected or fails to, waiting
+// at most runWatchConnectTimeout, so that a daemon without an event stream
+// never holds up the agent.
+func watchRun(ctx context.Context, apiURL, apiToken, run string, stats *runStats) {
+	connected := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = streamRun(ctx, apiURL, apiToken, run, stats, func() { close(connected) })
+	}()
+
+	select {
+	case <-connected:
+	case <-done:
+	case <-time.After(runWatchConnectTimeout):
+	case <-ctx.Done():
+	}
+}
+
+// streamRun reads the events of run until the connection ends, calling
+// onConnect once the stream is open.
+func streamRun(ctx context.Context, apiURL, apiToken, run string, stats *runStats, onConnect func()) error {
+	target, err := url.Parse(strings.TrimRight(apiURL, "/") + "/v1/events")
+	if err != nil {
+		return fmt.Errorf("invalid API URL: %w", err)
+	}
+	target.RawQuery = url.Values{"run": {run}}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+apiToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("connecting to event stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("event stream request failed (HTTP %d)", resp.StatusCode)
+	}
+	onConnect()
+
+	reader := sse.NewTeeReader(resp.Body, io.Discard)
+	for {
+		ev, err := reader.Next()
+		if err != nil || ev == nil {
+			return err
+		}
+		if ev.Type != events.TypeNodeCreated {
+			continue
+		}
+
+		var event events.Event
+		if err := json.Unmarshal([]byte(ev.Data), &event); err != nil {
+			continue
+		}
+		stats.add(event)
+	}
+}
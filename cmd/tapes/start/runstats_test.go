@@ -0,0 +1,80 @@
+package startcmder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+var _ = Describe("runStats", func() {
+	It("returns no summary for a run without turns", func() {
+		stats := newRunStats(deck.DefaultPricing())
+		stats.add(events.Event{Role: "user", Text: "hello"})
+		Expect(stats.summary()).To(BeEmpty())
+	})
+
+	It("summarizes the turns, tokens, cost, and top tools of a run", func() {
+		stats := newRunStats(deck.DefaultPricing())
+		stats.add(events.Event{Role: "user", Text: "list files"})
+		stats.add(events.Event{
+			Role:      "assistant",
+			Model:     "gpt-4o",
+			ToolCalls: []string{"Bash", "Read", "Bash"},
+			Usage:     &llm.Usage{PromptTokens: 1_000_000, CompletionTokens: 1_000},
+		})
+		stats.add(events.Event{
+			Role:      "assistant",
+			Model:     "mystery-model",
+			ToolCalls: []string{"Edit", "Grep", "Read"},
+			Usage:     &llm.Usage{PromptTokens: 500},
+		})
+
+		// gpt-4o: 1M input at $2.50 + 1K output at $10.00.
+		Expect(stats.summary()).To(Equal(
+			"tapes: 2 turns · 1.0M in / 1.0K out · $2.51 (1 unpriced) · top tools: Bash (2), Read (2), Edit (1)",
+		))
+	})
+})
+
+var _ = Describe("watchRun", func() {
+	It("tallies the events of the run from the event stream", func() {
+		var query string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			w.Header().Set("Content-Type", "text/event-stream")
+			for i, event := range []events.Event{
+				{Role: "user"},
+				{Role: "assistant", Model: "gpt-4o", Usage: &llm.Usage{PromptTokens: 10, CompletionTokens: 5}},
+			} {
+				data, err := json.Marshal(event)
+				Expect(err).NotTo(HaveOccurred())
+				fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", i+1, events.TypeNodeCreated, data)
+			}
+		}))
+		defer server.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stats := newRunStats(deck.DefaultPricing())
+		watchRun(ctx, server.URL, "", "run-1", stats)
+
+		Eventually(stats.summary).Should(HavePrefix("tapes: 1 turn · 10 in / 5 out"))
+		Expect(query).To(Equal("run=run-1"))
+	})
+
+	It("does not wait for a daemon that is not reachable", func() {
+		stats := newRunStats(deck.DefaultPricing())
+		watchRun(context.Background(), "http://127.0.0.1:1", "", "run-1", stats)
+		Expect(stats.summary()).To(BeEmpty())
+	})
+})
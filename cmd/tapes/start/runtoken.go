@@ -0,0 +1,93 @@
+package startcmder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/api"
+)
+
+// runTokenTimeout bounds issuing and revoking run tokens with the daemon.
+const runTokenTimeout = 5 * time.Second
+
+// issueRunToken asks the daemon for a token, bounded by limits, that run's
+// agent sends in place of its API keys.
+func issueRunToken(ctx context.Context, apiURL, apiToken, run string, limits api.RunTokenRequest) (api.RunTokenResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, runTokenTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(limits)
+	if err != nil {
+		return api.RunTokenResponse{}, fmt.Errorf("encoding run token limits: %w", err)
+	}
+	resp, err := runTokenRequest(ctx, http.MethodPost, apiURL, apiToken, run, bytes.NewReader(body))
+	if err != nil {
+		return api.RunTokenResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return api.RunTokenResponse{}, fmt.Errorf("issuing run token: daemon returned %s", resp.Status)
+	}
+	var issued api.RunTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&issued); err != nil {
+		return api.RunTokenResponse{}, fmt.Errorf("decoding run token: %w", err)
+	}
+	return issued, nil
+}
+
+// runTokenOf returns the token issueRunToken issued, or the error it failed
+// with, treating a response without a token as a failure.
+func runTokenOf(issued api.RunTokenResponse, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	if issued.Token == "" {
+		return "", errors.New("issuing run token: daemon returned no token")
+	}
+	return issued.Token, nil
+}
+
+// revokeRunToken revokes run's tokens with the daemon.
+func revokeRunToken(ctx context.Context, apiURL, apiToken, run string) error {
+	ctx, cancel := context.WithTimeout(ctx, runTokenTimeout)
+	defer cancel()
+
+	resp, err := runTokenRequest(ctx, http.MethodDelete, apiURL, apiToken, run, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("revoking run token: daemon returned %s", resp.Status)
+	}
+	return nil
+}
+
+func runTokenRequest(ctx context.Context, method, apiURL, apiToken, run string, body io.Reader) (*http.Response, error) {
+	target := strings.TrimRight(apiURL, "/") + "/v1/runs/" + url.PathEscape(run) + "/token"
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+apiToken)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("contacting daemon: %w", err)
+	}
+	return resp, nil
+}
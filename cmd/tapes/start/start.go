@@ -3,12 +3,14 @@ package startcmder
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -17,26 +19,46 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
 	"github.com/papercomputeco/tapes/api"
+	"github.com/papercomputeco/tapes/cmd/tapes/queuepolicy"
+	"github.com/papercomputeco/tapes/cmd/tapes/retrypolicy"
+	"github.com/papercomputeco/tapes/pkg/audit"
+	"github.com/papercomputeco/tapes/pkg/capture"
 	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/crash"
 	"github.com/papercomputeco/tapes/pkg/credentials"
+	"github.com/papercomputeco/tapes/pkg/deck"
 	"github.com/papercomputeco/tapes/pkg/dotdir"
 	"github.com/papercomputeco/tapes/pkg/embeddings"
 	embeddingutils "github.com/papercomputeco/tapes/pkg/embeddings/utils"
+	"github.com/papercomputeco/tapes/pkg/events"
 	"github.com/papercomputeco/tapes/pkg/git"
+	"github.com/papercomputeco/tapes/pkg/health"
+	"github.com/papercomputeco/tapes/pkg/identity"
 	"github.com/papercomputeco/tapes/pkg/logger"
 	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/notify"
+	"github.com/papercomputeco/tapes/pkg/policy"
+	"github.com/papercomputeco/tapes/pkg/redact"
+	"github.com/papercomputeco/tapes/pkg/report"
+	"github.com/papercomputeco/tapes/pkg/retention"
+	"github.com/papercomputeco/tapes/pkg/runtoken"
+	"github.com/papercomputeco/tapes/pkg/share"
 	"github.com/papercomputeco/tapes/pkg/start"
 	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
 	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+	"github.com/papercomputeco/tapes/pkg/storage/media"
 	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+	"github.com/papercomputeco/tapes/pkg/telemetry"
+	"github.com/papercomputeco/tapes/pkg/utils"
 	"github.com/papercomputeco/tapes/pkg/vector"
 	vectorutils "github.com/papercomputeco/tapes/pkg/vector/utils"
 	"github.com/papercomputeco/tapes/proxy"
+	"github.com/papercomputeco/tapes/proxy/worker"
 )
 
 const (
@@ -49,13 +71,89 @@ Examples:
   tapes start opencode --provider anthropic --model claude-sonnet-4-5
   tapes start opencode --provider ollama --model qwen3-coder:30b
   tapes start codex
+  tapes start aider -- --model sonnet --no-auto-commits
+  tapes start container --provider openai -- docker run -it IMAGE
+  tapes start claude --record=off
   tapes start --logs
+
+Arguments after -- are passed to the agent. Aider is pointed at the proxy
+for every provider it can use; --model selects its model.
+
+Containerized agent frameworks, such as OpenHands or SWE-agent, are captured
+with tapes start container, followed by a docker or podman run command after
+--. The container is started with OPENAI_BASE_URL, OPENAI_API_BASE,
+ANTHROPIC_BASE_URL, and OLLAMA_API_BASE pointed at a gateway to the proxy,
+and LLM_BASE_URL too when --provider is set. OPENAI_API_KEY and
+ANTHROPIC_API_KEY are passed through by name. The gateway listens on the
+docker0 bridge address, or 127.0.0.1 where there is none, as with Docker
+Desktop, and only accepts requests carrying the run's random token in their
+path. Pass --container-listen to pick another address, for example
+0.0.0.0:0 for rootless docker or podman.
+
+Tools that ignore base URL settings but honor HTTPS_PROXY can be recorded
+with --forward-proxy. The agent is started with HTTPS_PROXY pointed at a
+forward proxy that intercepts api.openai.com and api.anthropic.com, sending
+their requests through tapes, and tunnels every other host untouched.
+Intercepted connections use certificates from a local CA kept in the ca/
+directory of .tapes/, which the agent is pointed at with SSL_CERT_FILE,
+REQUESTS_CA_BUNDLE, CURL_CA_BUNDLE, and NODE_EXTRA_CA_CERTS. Tools that
+read none of them must be told to trust ca.pem there.
+
+API keys stored with tapes auth stay in the daemon. Each agent is given a
+token for its run in their place, in its environment and in the files
+patched for it, and the proxy swaps the token for the key of the provider
+each request goes to. The run's tokens are revoked when the agent exits.
+Bound them with the start.run_token_ttl, start.run_token_max_requests, and
+start.run_token_max_input_tokens config keys, and list or revoke them with
+tapes tokens.
+
+Each run's sessions are labeled with the project, --project or the git
+repository the agent is launched in, and for aider with its version.
+
+While an agent's session is recorded, the terminal title says so, and the
+agent is started with TAPES_RECORDING=1 (0 when it is not) and TAPES_RUN_ID
+set. To be asked before each session is recorded, run:
+
+  tapes config set start.confirm_recording true
+
+Declining, or launching without a terminal to ask on, runs the agent with
+recording off.
+
+Codex is pointed at the proxy with OPENAI_BASE_URL. For codex versions that
+ignore it, tapes can instead select a "tapes" model provider in codex's
+config.toml while codex runs, restoring the file when it exits:
+
+  tapes config set start.codex_config true
+  tapes config set start.codex_wire_api chat   # default: responses
+
+Without an OpenAI key stored with tapes auth, codex signed in with a ChatGPT
+account (codex login) keeps using it, and its requests go to the ChatGPT
+backend. When its access token expires mid-run, the daemon refreshes it with
+the refresh token in codex's auth.json, writes the rotated tokens back, and
+retries the request.
+
+When an agent exits, a summary of its run is printed: the turns it took, the
+tokens it used, their estimated cost, and the tools it called most. Pass
+--summary=false to turn it off.
+
+With --record=off, the agent's traffic is forwarded with credentials injected
+and budgets enforced, but none of its turns are stored or published: only
+aggregate request and token counts are kept, in the daemon's log. Use it for
+sensitive work that should leave no transcript.
 `
 	startShortDesc = "Start tapes services and agents"
 
 	agentClaude   = "claude"
 	agentOpenCode = "opencode"
 	agentCodex    = "codex"
+	agentAider    = "aider"
+
+	// agentContainer runs the docker or podman run command after "--",
+	// pointing the agents in the container at the proxy.
+	agentContainer = "container"
+
+	recordOn  = "on"
+	recordOff = "off"
 )
 
 type startCommander struct {
@@ -66,10 +164,27 @@ type startCommander struct {
 	provider  string
 	model     string
 	project   string
+	summary   bool
+	record    string
+
+	// agentArgs are the arguments after "--", passed to the agent.
+	agentArgs []string
+
+	// containerListen is the address the container gateway listens on.
+	containerListen string
+
+	// forwardProxy also points the agent at a forward proxy with
+	// HTTPS_PROXY, for tools that ignore base URL settings.
+	forwardProxy bool
+
+	// allowRawKeys gives the agent the stored API keys when the daemon
+	// cannot issue it a run token.
+	allowRawKeys bool
 }
 
 type startConfig struct {
 	SQLitePath          string
+	Encryption          bool
 	VectorStoreProvider string
 	VectorStoreTarget   string
 	EmbeddingProvider   string
@@ -81,16 +196,58 @@ type startConfig struct {
 	OllamaUpstream      string
 	OpenCodeProvider    string
 	Project             string
+	User                string
+	MaxCaptureBytes     uint
+	MaxInputTokens      uint
+	RawCapture          bool
+	SignNodes           bool
+	SessionIdleTimeout  string
+	Queue               config.QueueConfig
+	Retry               config.RetryConfig
+	Redaction           config.RedactionConfig
+	BlobDir             string
+	BlobThreshold       uint
+	MediaDir            string
+	MediaMaxBytes       uint
+	MediaDownload       bool
+	Retention           config.RetentionConfig
+	Summarizer          config.SummarizerConfig
+	Titles              config.TitlesConfig
+	Report              config.ReportConfig
+	Logs                config.LogsConfig
+	Health              config.HealthConfig
+	CompactInterval     string
+	APIToken            string
+	OTLPEndpoint        string
+
+	ConfirmRecording bool
+	CaptureRules     []capture.RuleSpec
+	PolicyRules      []policy.RuleSpec
+	NotifyRules      []notify.RuleSpec
+	CodexConfig      bool
+	CodexWireAPI     string
+	RunToken         api.RunTokenRequest
+
+	// CADir keeps the local CA of the forward proxy.
+	CADir string
 }
 
 func NewStartCmd() *cobra.Command {
 	cmder := &startCommander{}
 
 	cmd := &cobra.Command{
-		Use:   "start [agent]",
+		Use:   "start [agent] [-- agent args...]",
 		Short: startShortDesc,
 		Long:  startLongDesc,
-		Args:  cobra.MaximumNArgs(1),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+				if dash == 0 {
+					return errors.New("arguments after -- are passed to an agent: name one, as in tapes start aider -- --help")
+				}
+				args = args[:dash]
+			}
+			return cobra.MaximumNArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var err error
 			cmder.debug, err = cmd.Flags().GetBool("debug")
@@ -110,6 +267,9 @@ func NewStartCmd() *cobra.Command {
 				return fmt.Errorf("could not get daemon flag: %w", err)
 			}
 
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+				args, cmder.agentArgs = args[:dash], args[dash:]
+			}
 			agent := ""
 			if len(args) == 1 {
 				agent = strings.ToLower(strings.TrimSpace(args[0]))
@@ -131,9 +291,14 @@ func NewStartCmd() *cobra.Command {
 	cmd.Flags().Bool("logs", false, "Stream logs from the running tapes start daemon")
 	cmd.Flags().Bool("daemon", false, "Run start daemon (internal)")
 	_ = cmd.Flags().MarkHidden("daemon")
-	cmd.Flags().StringVar(&cmder.provider, "provider", "", "LLM provider for opencode (anthropic, openai, ollama)")
-	cmd.Flags().StringVar(&cmder.model, "model", "", "Model for opencode (e.g. claude-sonnet-4-5)")
+	cmd.Flags().StringVar(&cmder.provider, "provider", "", "LLM provider for opencode, or for container's LLM_BASE_URL (anthropic, openai, ollama)")
+	cmd.Flags().StringVar(&cmder.model, "model", "", "Model for opencode or aider (e.g. claude-sonnet-4-5)")
 	cmd.Flags().StringVar(&cmder.project, "project", "", "Project name to tag sessions (default: auto-detect from git)")
+	cmd.Flags().BoolVar(&cmder.summary, "summary", true, "Print the turns, tokens, cost, and top tools of the run when the agent exits")
+	cmd.Flags().StringVar(&cmder.containerListen, "container-listen", "", "Address the gateway for tapes start container listens on (default: the docker0 address, or 127.0.0.1)")
+	cmd.Flags().BoolVar(&cmder.forwardProxy, "forward-proxy", false, "Also point the agent at an HTTPS_PROXY intercepting provider hosts, for tools that ignore base URLs")
+	cmd.Flags().BoolVar(&cmder.allowRawKeys, "allow-raw-keys", false, "Give the agent the stored API keys if the daemon cannot issue it a run token")
+	cmd.Flags().StringVar(&cmder.record, "record", recordOn, "Whether the agent's turns are recorded (on, off)")
 
 	return cmd
 }
@@ -171,13 +336,29 @@ func (c *startCommander) runLogs(ctx context.Context, out io.Writer) error {
 		return fmt.Errorf("checking log file: %w", err)
 	}
 
-	return followLog(ctx, logPath, out)
+	return logger.Tail(ctx, logPath, out, logger.TailOptions{Follow: true})
 }
 
 func (c *startCommander) runAgent(ctx context.Context, agent string) error {
 	if !isSupportedAgent(agent) {
 		return fmt.Errorf("unsupported agent: %s", agent)
 	}
+	record, err := parseRecordMode(c.record)
+	if err != nil {
+		return err
+	}
+	startCfg, err := c.loadConfig()
+	if err != nil {
+		return err
+	}
+	if record && startCfg.ConfirmRecording {
+		if !isTerminal(os.Stdin) {
+			fmt.Fprintln(os.Stderr, "Note: start.confirm_recording is set and there is no terminal to confirm on.")
+			record = false
+		} else if record, err = confirmRecording(os.Stdin, os.Stderr, agent); err != nil {
+			return err
+		}
+	}
 
 	manager, err := start.NewManager(c.configDir)
 	if err != nil {
@@ -189,8 +370,43 @@ func (c *startCommander) runAgent(ctx context.Context, agent string) error {
 		return err
 	}
 
+	// Each launch is its own run. Wrapping the agent's base URL in the run
+	// tags its turns with it whether or not the agent can send custom
+	// headers, and keeps agents running side by side through the one proxy
+	// from being stored as one conversation.
+	runID := strings.ToLower(rand.Text())
 	proxyURL := strings.TrimRight(state.ProxyURL, "/")
-	agentBaseURL := fmt.Sprintf("%s/agents/%s", proxyURL, agent)
+	if !record {
+		proxyURL += "/record/off"
+	}
+	command := agentCommand(agent)
+	if agent == agentContainer {
+		if len(c.agentArgs) == 0 {
+			return errors.New("tapes start container needs a command after --, as in: tapes start container -- docker run IMAGE")
+		}
+		command = c.agentArgs[0]
+	}
+	agentPath, err := exec.LookPath(command)
+	if err != nil {
+		return fmt.Errorf("finding %s: %w", agent, err)
+	}
+
+	// Label the run's sessions with the project it was launched in and the
+	// agent's version, when known.
+	agentBaseURL := fmt.Sprintf("%s/runs/%s", proxyURL, runID)
+	project := startCfg.Project
+	if project == "" {
+		project = git.RepoName(ctx)
+	}
+	if project != "" {
+		agentBaseURL += "/projects/" + url.PathEscape(project)
+	}
+	agentBaseURL += "/agents/" + agent
+	if agent == agentAider {
+		if version := aiderVersion(ctx, agentPath); version != "" {
+			agentBaseURL += "@" + version
+		}
+	}
 
 	// Resolve opencode provider/model before building the command,
 	// since we need to pass --model as a CLI argument.
@@ -204,25 +420,69 @@ func (c *startCommander) runAgent(ctx context.Context, agent string) error {
 		fmt.Fprintf(os.Stderr, "Note: tapes will capture telemetry for %s/%s. Switching models inside opencode will not be captured by tapes.\n", pref.Provider, pref.Model)
 	}
 
+	if agent == agentContainer {
+		// The proxy only listens on loopback, so containers reach it
+		// through a gateway on an address they can reach.
+		gateway, err := startContainerGateway(c.containerListen, agentBaseURL)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = gateway.Close() }()
+		agentArgs, err = containerRunArgs(c.agentArgs[1:], containerEnv(gateway.URL(), c.provider))
+		if err != nil {
+			return err
+		}
+	} else {
+		agentArgs = append(agentArgs, c.agentArgs...)
+	}
+	agentPath, agentArgs = start.WrapCommand(agentPath, agentArgs)
+
 	// #nosec G204 -- agent commands are restricted to known binaries.
-	cmd := exec.CommandContext(ctx, agentCommand(agent), agentArgs...)
+	cmd := exec.CommandContext(ctx, agentPath, agentArgs...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Stdin = os.Stdin
 	cmd.Env = os.Environ()
 
+	// The agent is given a token for its run in place of the stored API
+	// keys, which the proxy swaps back, so that keys stay out of its
+	// environment and the files patched for it. A daemon that cannot issue
+	// one, such as one of an older version, stops the run unless
+	// --allow-raw-keys leaves the agent the keys.
+	issued, err := issueRunToken(ctx, state.APIURL, startCfg.APIToken, runID, startCfg.RunToken)
+	runToken, err := runTokenOf(issued, err)
+	switch {
+	case err != nil && !c.allowRawKeys:
+		return fmt.Errorf("%w: pass --allow-raw-keys to give %s the stored API keys instead", err, agent)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Warning: passing API keys to %s: %v\n", agent, err)
+	default:
+		c.recordAudit(audit.ActionRunTokenIssue, runID, map[string]string{"agent": agent, "token_id": issued.ID})
+	}
+
 	cleanup := func() error { return nil }
+	if runToken != "" {
+		// The daemon also revokes the run's tokens when the agent exits, so
+		// failing to reach it here is not an error.
+		cleanup = func() error {
+			_ = revokeRunToken(context.WithoutCancel(ctx), state.APIURL, startCfg.APIToken, runID)
+			return nil
+		}
+	}
 
 	switch agent {
 	case agentClaude:
 		cmd.Env = append(cmd.Env, "ANTHROPIC_BASE_URL="+agentBaseURL)
+	case agentAider:
+		cmd.Env = append(cmd.Env, aiderEnv(agentBaseURL, c.model)...)
 	case agentCodex:
 		cmd.Env = append(cmd.Env,
 			"OPENAI_BASE_URL="+agentBaseURL,
 			"OPENAI_API_BASE="+agentBaseURL,
 		)
-		codexCleanup, err := c.configureCodexAuth()
+		codexCleanup, err := c.configureCodexAuth(runToken)
 		if err != nil {
+			_ = cleanup()
 			return err
 		}
 		prevCleanup := cleanup
@@ -234,18 +494,46 @@ func (c *startCommander) runAgent(ctx context.Context, agent string) error {
 			}
 			return err2
 		}
+
+		if startCfg.CodexConfig {
+			configCleanup, err := c.configureCodexConfig(agentBaseURL, startCfg.CodexWireAPI)
+			if err != nil {
+				_ = cleanup()
+				return err
+			}
+			prevCleanup := cleanup
+			cleanup = func() error {
+				err1 := configCleanup()
+				err2 := prevCleanup()
+				if err1 != nil {
+					return err1
+				}
+				return err2
+			}
+		}
 	case agentOpenCode:
 		var configRoot string
-		cleanup, configRoot, err = configureOpenCode(agentBaseURL, c.configDir)
+		var configCleanup func() error
+		configCleanup, configRoot, err = configureOpenCode(agentBaseURL, c.configDir, runToken)
 		if err != nil {
+			_ = cleanup()
 			return err
 		}
+		prevCleanup := cleanup
+		cleanup = func() error {
+			err1 := configCleanup()
+			err2 := prevCleanup()
+			if err1 != nil {
+				return err1
+			}
+			return err2
+		}
 		cmd.Env = append(cmd.Env, "XDG_CONFIG_HOME="+configRoot)
 
 		// Clear OpenCode's stored OAuth tokens so it uses API keys from
 		// our config/env instead. Same pattern as configureCodexAuth.
 		ocAuthCleanup := c.patchOpenCodeAuth()
-		prevCleanup := cleanup
+		prevCleanup = cleanup
 		cleanup = func() error {
 			err1 := ocAuthCleanup()
 			err2 := prevCleanup()
@@ -256,15 +544,64 @@ func (c *startCommander) runAgent(ctx context.Context, agent string) error {
 		}
 	}
 
-	cmd.Env = c.injectCredentials(cmd.Env)
+	if c.forwardProxy {
+		forwardEnv, forwardCleanup, err := startForwardProxy(startCfg.CADir, agentBaseURL)
+		if err != nil {
+			_ = cleanup()
+			return err
+		}
+		cmd.Env = append(cmd.Env, forwardEnv...)
+		prevCleanup := cleanup
+		cleanup = func() error {
+			err1 := forwardCleanup()
+			err2 := prevCleanup()
+			if err1 != nil {
+				return err1
+			}
+			return err2
+		}
+	}
+
+	cmd.Env = c.injectCredentials(cmd.Env, runToken)
+	cmd.Env = recordingAgentEnv(cmd.Env, record, runID)
+
+	if !record {
+		fmt.Fprintln(os.Stderr, "Note: recording is off. tapes will forward this run's traffic without storing any of its turns.")
+	} else if isTerminal(os.Stderr) {
+		defer showRecordingTitle(os.Stderr, agent)()
+	}
+
+	// Tally the run from the daemon's event stream, subscribing before the
+	// agent starts so that its first turns are not missed. Unrecorded runs
+	// publish no events.
+	var stats *runStats
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	defer stopWatch()
+	if c.summary && record {
+		pricing, err := deck.ResolvePricing(c.configDir, "")
+		if err != nil {
+			_ = cleanup()
+			return err
+		}
+		stats = newRunStats(pricing)
+		watchRun(watchCtx, state.APIURL, startCfg.APIToken, runID, stats)
+	}
 
 	if err := cmd.Start(); err != nil {
 		_ = cleanup()
 		return fmt.Errorf("starting %s: %w", agent, err)
 	}
 
+	release, err := start.Contain(cmd.Process)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cleanup()
+		return err
+	}
+	defer func() { _ = release() }()
+
 	agentPID := cmd.Process.Pid
-	if err := c.registerAgent(manager, agent, agentPID); err != nil {
+	if err := c.registerAgent(manager, agent, runID, agentPID); err != nil {
 		_ = cleanup()
 		return err
 	}
@@ -274,6 +611,13 @@ func (c *startCommander) runAgent(ctx context.Context, agent string) error {
 	if err := c.unregisterAgent(manager, agentPID); err != nil {
 		return err
 	}
+	if stats != nil {
+		time.Sleep(runSummaryGrace)
+		stopWatch()
+		if summary := stats.summary(); summary != "" {
+			fmt.Fprintln(os.Stderr, summary)
+		}
+	}
 	if cleanupErr != nil {
 		return cleanupErr
 	}
@@ -286,51 +630,76 @@ func (c *startCommander) runAgent(ctx context.Context, agent string) error {
 }
 
 func (c *startCommander) runForeground(ctx context.Context) error {
+	return c.runWithLogs(ctx, os.Stdout, false)
+}
+
+func (c *startCommander) runDaemon(ctx context.Context) error {
+	return c.runWithLogs(ctx, nil, true)
+}
+
+// runWithLogs runs the services logging to the rotated log file, and to
+// console when not nil.
+func (c *startCommander) runWithLogs(ctx context.Context, console io.Writer, shutdownWhenIdle bool) error {
 	manager, err := start.NewManager(c.configDir)
 	if err != nil {
 		return err
 	}
 
-	logFile, err := os.OpenFile(manager.LogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	startCfg, err := c.loadConfig()
 	if err != nil {
-		return fmt.Errorf("opening log file: %w", err)
+		return err
 	}
-	defer logFile.Close()
-
-	logger := logger.NewLoggerWithWriters(c.debug, os.Stdout, logFile)
-	defer func() { _ = logger.Sync() }()
-
-	return c.runServices(ctx, manager, logger, false)
-}
 
-func (c *startCommander) runDaemon(ctx context.Context) error {
-	manager, err := start.NewManager(c.configDir)
+	level, err := c.logLevel(startCfg.Logs)
 	if err != nil {
 		return err
 	}
+	maxBytes := int64(0)
+	if startCfg.Logs.MaxFileSize != "" {
+		if maxBytes, err = utils.ParseSize(startCfg.Logs.MaxFileSize); err != nil {
+			return fmt.Errorf("parsing logs.max_file_size: %w", err)
+		}
+	}
 
-	logFile, err := os.OpenFile(manager.LogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	logFile, err := logger.OpenRotatingFile(manager.LogPath, maxBytes, int(startCfg.Logs.MaxFiles)) //nolint:gosec // config values are far below MaxInt
 	if err != nil {
-		return fmt.Errorf("opening log file: %w", err)
+		return err
 	}
 	defer logFile.Close()
 
-	logger := logger.NewLoggerWithWriters(c.debug, logFile)
+	logger := logger.NewStructuredLogger(level, logFile, console)
 	defer func() { _ = logger.Sync() }()
 
-	return c.runServices(ctx, manager, logger, true)
+	return c.runServices(ctx, manager, startCfg, logger, level, shutdownWhenIdle)
 }
 
-func (c *startCommander) runServices(ctx context.Context, manager *start.Manager, zapLogger *zap.Logger, shutdownWhenIdle bool) error {
-	startCfg, err := c.loadConfig()
+// logLevel returns the initial level of the daemon's logs: debug with
+// --debug, else the configured level, else info.
+func (c *startCommander) logLevel(cfg config.LogsConfig) (zap.AtomicLevel, error) {
+	if c.debug {
+		return zap.NewAtomicLevelAt(zap.DebugLevel), nil
+	}
+	if cfg.Level == "" {
+		return zap.NewAtomicLevelAt(zap.InfoLevel), nil
+	}
+	level, err := zap.ParseAtomicLevel(cfg.Level)
 	if err != nil {
-		return err
+		return zap.AtomicLevel{}, fmt.Errorf("parsing logs.level: %w", err)
 	}
+	return level, nil
+}
 
+func (c *startCommander) runServices(ctx context.Context, manager *start.Manager, startCfg *startConfig, zapLogger *zap.Logger, level zap.AtomicLevel, shutdownWhenIdle bool) error {
 	if startCfg.Project == "" {
 		startCfg.Project = git.RepoName(ctx)
 	}
 
+	shutdownTracing, err := telemetry.Setup(ctx, telemetry.Config{Endpoint: startCfg.OTLPEndpoint})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = shutdownTracing(context.Background()) }()
+
 	lock, err := manager.Lock()
 	if err != nil {
 		return err
@@ -371,6 +740,16 @@ func (c *startCommander) runServices(ctx context.Context, manager *start.Manager
 	}
 	defer driver.Close()
 
+	// Stop background maintenance before the driver is closed.
+	maintenanceCtx, cancelMaintenance := context.WithCancel(ctx)
+	defer cancelMaintenance()
+	if err := c.startPruner(maintenanceCtx, startCfg, driver, zapLogger); err != nil {
+		return err
+	}
+	if err := c.startCompactor(maintenanceCtx, startCfg, driver, zapLogger); err != nil {
+		return err
+	}
+
 	dagLoader, err := c.newDagLoader(ctx, startCfg, zapLogger, driver)
 	if err != nil {
 		return err
@@ -388,16 +767,25 @@ func (c *startCommander) runServices(ctx context.Context, manager *start.Manager
 	}
 
 	openCodeRoute := resolveOpenCodeAgentRoute(startCfg)
+	crashes := crash.NewRecorder(manager.CrashDir)
+
+	broker := events.NewBroker()
+	defer broker.Close()
+	if err := c.startNotifier(ctx, startCfg, broker, zapLogger); err != nil {
+		return err
+	}
 
 	proxyConfig := proxy.Config{
 		ListenAddr:   proxyListener.Addr().String(),
 		UpstreamURL:  startCfg.DefaultUpstream,
 		ProviderType: startCfg.DefaultProvider,
 		Project:      startCfg.Project,
+		User:         startCfg.User,
 		AgentRoutes: map[string]proxy.AgentRoute{
 			agentClaude:   {ProviderType: "anthropic", UpstreamURL: "https://api.anthropic.com"},
 			agentOpenCode: openCodeRoute,
 			agentCodex:    {ProviderType: "openai", UpstreamURL: "https://api.openai.com/v1"},
+			agentAider:    {ProviderType: "openai", UpstreamURL: "https://api.openai.com/v1"},
 		},
 		ProviderUpstreams: map[string]string{
 			"anthropic": "https://api.anthropic.com",
@@ -406,6 +794,107 @@ func (c *startCommander) runServices(ctx context.Context, manager *start.Manager
 		},
 		VectorDriver: vectorDriver,
 		Embedder:     embedder,
+		Events:       broker,
+		JournalPath:  manager.JournalPath,
+		Crashes:      crashes,
+
+		MaxCaptureBytes: int(startCfg.MaxCaptureBytes), //nolint:gosec // config values are far below MaxInt
+		RawCapture:      startCfg.RawCapture,
+	}
+
+	pricing, err := deck.ResolvePricing(c.configDir, "")
+	if err != nil {
+		return err
+	}
+	proxyConfig.TurnCost = pricing.TurnCost
+
+	proxyConfig.SessionIdleTimeout, err = worker.ParseSessionIdleTimeout(startCfg.SessionIdleTimeout)
+	if err != nil {
+		return err
+	}
+	proxyConfig.Queue, err = queuepolicy.FromConfig(startCfg.Queue)
+	if err != nil {
+		return err
+	}
+	if startCfg.SignNodes {
+		if proxyConfig.SigningKey, err = share.LoadIdentity(manager.Dir); err != nil {
+			return err
+		}
+	}
+
+	if startCfg.MaxInputTokens > 0 {
+		proxyConfig.Budget = proxy.MaxInputTokens(startCfg.MaxInputTokens) //nolint:gosec // config values are far below MaxInt
+	}
+
+	proxyConfig.Retry, proxyConfig.ProviderRetries, err = retrypolicy.FromConfig(startCfg.Retry)
+	if err != nil {
+		return err
+	}
+
+	if startCfg.Redaction.Enabled {
+		proxyConfig.Redactor, err = redact.NewRedactor(startCfg.Redaction.Rules)
+		if err != nil {
+			return fmt.Errorf("creating redactor: %w", err)
+		}
+	}
+
+	if len(startCfg.CaptureRules) > 0 {
+		proxyConfig.CaptureRules, err = capture.NewRules(startCfg.CaptureRules)
+		if err != nil {
+			return fmt.Errorf("creating capture rules: %w", err)
+		}
+	}
+
+	if len(startCfg.PolicyRules) > 0 {
+		proxyConfig.Policy, err = policy.New(startCfg.PolicyRules)
+		if err != nil {
+			return fmt.Errorf("creating policy: %w", err)
+		}
+	}
+
+	if startCfg.BlobDir != "" {
+		proxyConfig.BlobStore, err = blob.NewStore(startCfg.BlobDir, storage.CipherOf(driver))
+		if err != nil {
+			return fmt.Errorf("creating blob store: %w", err)
+		}
+		proxyConfig.BlobThreshold = int(startCfg.BlobThreshold) //nolint:gosec // config values are far below MaxInt
+	}
+
+	if startCfg.MediaDir != "" {
+		proxyConfig.MediaStore, err = media.NewStore(startCfg.MediaDir, media.Options{
+			MaxBytes: int(startCfg.MediaMaxBytes), //nolint:gosec // config values are far below MaxInt
+			Download: startCfg.MediaDownload,
+			Cipher:   storage.CipherOf(driver),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Agents send run tokens in place of the stored API keys, which are
+	// read on each request so that keys stored while the daemon runs are
+	// used.
+	credMgr, err := credentials.NewManager(c.configDir)
+	if err != nil {
+		return fmt.Errorf("loading credentials: %w", err)
+	}
+	proxyConfig.RunTokens = runtoken.New()
+	proxyConfig.ProviderKeys = credMgr.GetKey
+
+	// codex signed in with a ChatGPT account has its access token refreshed
+	// when it expires mid-run, with the refresh token in its auth.json.
+	if authPath, err := credentials.CodexAuthPath(); err == nil {
+		refresher := credentials.NewCodexRefresher(authPath)
+		proxyConfig.RefreshOAuth = func(ctx context.Context, expired string) (string, error) {
+			token, rotated, err := refresher.Refresh(ctx, expired)
+			if rotated {
+				c.recordAudit(audit.ActionAuthFilePatch, authPath, map[string]string{
+					"agent":   agentCodex,
+					"changes": "refreshed OAuth tokens",
+				})
+			}
+			return token, err
+		}
 	}
 
 	//nolint:contextcheck // Proxy lifecycle manages its own background context.
@@ -415,10 +904,43 @@ func (c *startCommander) runServices(ctx context.Context, manager *start.Manager
 	}
 	defer proxyServer.Close()
 
+	checker, err := c.startHealthChecks(ctx, startCfg, proxyServer, proxyConfig, zapLogger)
+	if err != nil {
+		return err
+	}
+
 	apiConfig := api.Config{
 		ListenAddr:   apiListener.Addr().String(),
 		VectorDriver: vectorDriver,
 		Embedder:     embedder,
+		AuthToken:    startCfg.APIToken,
+		Events:       broker,
+		CaptureSkips: func() map[string]uint64 { return proxyServer.Stats().Skipped },
+		RunTokens:    proxyConfig.RunTokens,
+		LogLevel:     &level,
+		Crashes:      crashes,
+		Health:       checker,
+	}
+	if startCfg.SQLitePath != "" {
+		query, closeQuery, err := deck.NewQuery(ctx, startCfg.SQLitePath, pricing, sqlite.WithEncryption(startCfg.Encryption))
+		if err != nil {
+			return fmt.Errorf("opening sessions: %w", err)
+		}
+		defer func() { _ = closeQuery() }()
+		apiConfig.Sessions = query
+
+		// Stop the summarizer before the query is closed.
+		summarizerCtx, cancelSummarizer := context.WithCancel(ctx)
+		defer cancelSummarizer()
+		if err := c.startSummarizer(summarizerCtx, startCfg, query, zapLogger); err != nil {
+			return err
+		}
+		if err := c.startTitler(summarizerCtx, startCfg, query, broker, zapLogger); err != nil {
+			return err
+		}
+		if err := c.startSlackDigest(summarizerCtx, startCfg, query, zapLogger); err != nil {
+			return err
+		}
 	}
 	apiServer, err := api.NewServer(apiConfig, driver, dagLoader, zapLogger)
 	if err != nil {
@@ -440,9 +962,19 @@ func (c *startCommander) runServices(ctx context.Context, manager *start.Manager
 		}
 	}()
 
-	if shutdownWhenIdle {
-		go c.monitorIdle(manager, zapLogger, errChan)
+	// Sessions of an agent end when it exits, rather than when they go idle.
+	agentExited := func(session start.AgentSession) {
+		ended := proxyServer.EndSessions(ctx, session.Name, session.RunID, session.StartedAt)
+		revoked := proxyConfig.RunTokens.RevokeRun(session.RunID)
+		zapLogger.Debug("agent exited",
+			zap.String("agent", session.Name),
+			zap.String("run", session.RunID),
+			zap.Int("pid", session.PID),
+			zap.Int("sessions_ended", ended),
+			zap.Int("run_tokens_revoked", revoked),
+		)
 	}
+	go c.monitorAgents(manager, zapLogger, agentExited, errChan)
 
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -457,10 +989,219 @@ func (c *startCommander) runServices(ctx context.Context, manager *start.Manager
 	}
 }
 
-func (c *startCommander) monitorIdle(manager *start.Manager, zapLogger *zap.Logger, errChan chan<- error) {
+// startPruner enforces the configured retention policy in the background for
+// as long as ctx is live. Only the SQLite store is pruned.
+func (c *startCommander) startPruner(ctx context.Context, cfg *startConfig, driver storage.Driver, zapLogger *zap.Logger) error {
+	sqliteDriver, ok := driver.(*sqlite.Driver)
+	if !ok {
+		return nil
+	}
+
+	policy, err := retention.ParsePolicy(cfg.Retention.MaxAge, cfg.Retention.MaxDBSize, cfg.Retention.MaxSessions)
+	if err != nil {
+		return fmt.Errorf("parsing retention policy: %w", err)
+	}
+	if policy.IsZero() && !cfg.Retention.GC {
+		return nil
+	}
+
+	pruner := retention.NewPruner(sqliteDriver, policy)
+	pruner.GC = cfg.Retention.GC
+	if auditLog, err := audit.Open(c.configDir); err == nil {
+		pruner.Audit = auditLog
+	} else {
+		zapLogger.Warn("audit log unavailable", zap.Error(err))
+	}
+
+	go pruner.Run(ctx, retention.DefaultInterval, zapLogger)
+	return nil
+}
+
+// startNotifier sends the notifications of the configured rules on events
+// of captured sessions, for as long as ctx is live.
+func (c *startCommander) startNotifier(ctx context.Context, cfg *startConfig, broker *events.Broker, zapLogger *zap.Logger) error {
+	if len(cfg.NotifyRules) == 0 {
+		return nil
+	}
+
+	pricing, err := deck.ResolvePricing(c.configDir, "")
+	if err != nil {
+		return err
+	}
+	notifier, err := notify.New(cfg.NotifyRules, pricing)
+	if err != nil {
+		return fmt.Errorf("creating notifier: %w", err)
+	}
+
+	stream, unsubscribe := broker.Subscribe(events.Filter{})
+	go func() {
+		defer unsubscribe()
+		notifier.Run(ctx, stream, zapLogger)
+	}()
+	return nil
+}
+
+// startSummarizer titles and summarizes idle sessions in the background for
+// as long as ctx is live, when enabled in config.
+func (c *startCommander) startSummarizer(ctx context.Context, cfg *startConfig, query *deck.Query, zapLogger *zap.Logger) error {
+	if !cfg.Summarizer.Enabled {
+		return nil
+	}
+
+	var idleAfter time.Duration
+	if cfg.Summarizer.IdleAfter != "" {
+		var err error
+		idleAfter, err = utils.ParseDuration(cfg.Summarizer.IdleAfter)
+		if err != nil {
+			return fmt.Errorf("parsing summarizer idle time: %w", err)
+		}
+	}
+
+	credMgr, err := credentials.NewManager(c.configDir)
+	if err != nil {
+		credMgr = nil
+	}
+	llmCaller, err := deck.NewLLMCaller(deck.LLMCallerConfig{
+		Provider: cfg.Summarizer.Provider,
+		Model:    cfg.Summarizer.Model,
+		CredMgr:  credMgr,
+	})
+	if err != nil {
+		return fmt.Errorf("creating summarizer: %w", err)
+	}
+
+	summarizer := deck.NewSummarizer(query, llmCaller, idleAfter)
+	go summarizer.Run(ctx, deck.DefaultSummarizeInterval, zapLogger)
+	return nil
+}
+
+// startTitler titles new sessions from their first prompt as it is captured,
+// for as long as ctx is live. A model writes the titles when one is
+// configured.
+func (c *startCommander) startTitler(ctx context.Context, cfg *startConfig, query *deck.Query, broker *events.Broker, zapLogger *zap.Logger) error {
+	var llmCaller deck.LLMCallFunc
+	if cfg.Titles.Provider != "" {
+		credMgr, err := credentials.NewManager(c.configDir)
+		if err != nil {
+			credMgr = nil
+		}
+		llmCaller, err = deck.NewLLMCaller(deck.LLMCallerConfig{
+			Provider: cfg.Titles.Provider,
+			Model:    cfg.Titles.Model,
+			CredMgr:  credMgr,
+		})
+		if err != nil {
+			return fmt.Errorf("creating titler: %w", err)
+		}
+	}
+
+	stream, unsubscribe := broker.Subscribe(events.Filter{})
+	titler := deck.NewTitler(query, llmCaller)
+	go func() {
+		defer unsubscribe()
+		titler.Run(ctx, stream, zapLogger)
+	}()
+	return nil
+}
+
+// startSlackDigest posts the daily digest of sessions to Slack for as long as
+// ctx is live, when a webhook and time of day are configured.
+func (c *startCommander) startSlackDigest(ctx context.Context, cfg *startConfig, query *deck.Query, zapLogger *zap.Logger) error {
+	if cfg.Report.SlackWebhook == "" || cfg.Report.SlackDailyAt == "" {
+		return nil
+	}
+
+	at, err := report.ParseDailyTime(cfg.Report.SlackDailyAt)
+	if err != nil {
+		return fmt.Errorf("parsing slack digest time: %w", err)
+	}
+
+	go report.RunDailySlack(ctx, query, cfg.Report.SlackWebhook, at, zapLogger)
+	return nil
+}
+
+// startCompactor compacts the SQLite store every configured interval for as
+// long as ctx is live.
+func (c *startCommander) startCompactor(ctx context.Context, cfg *startConfig, driver storage.Driver, zapLogger *zap.Logger) error {
+	sqliteDriver, ok := driver.(*sqlite.Driver)
+	if !ok || cfg.CompactInterval == "" {
+		return nil
+	}
+
+	interval, err := utils.ParseDuration(cfg.CompactInterval)
+	if err != nil {
+		return fmt.Errorf("parsing compact interval: %w", err)
+	}
+	if interval <= 0 {
+		return nil
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			result, err := sqliteDriver.Compact(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					zapLogger.Error("database compaction failed", zap.Error(err))
+				}
+				continue
+			}
+			zapLogger.Info("compacted database",
+				zap.Int64("size_before", result.SizeBefore),
+				zap.Int64("size_after", result.SizeAfter),
+			)
+		}
+	}()
+	return nil
+}
+
+// monitorAgents prunes agents that are no longer running from the state,
+// calls exited for each agent that has gone since the last check, and shuts
+// a daemon that should stop when idle down once no agents remain.
+// startHealthChecks returns the daemon's health checks. With
+// health.probe_interval set, it also probes the upstreams agents are routed
+// to, until ctx is done.
+func (c *startCommander) startHealthChecks(ctx context.Context, cfg *startConfig, proxyServer *proxy.Proxy, proxyConfig proxy.Config, zapLogger *zap.Logger) (*health.Checker, error) {
+	checker := health.NewChecker()
+	proxyServer.AddHealthChecks(checker)
+	if cfg.Health.ProbeInterval == "" {
+		return checker, nil
+	}
+
+	interval, err := utils.ParseDuration(cfg.Health.ProbeInterval)
+	if err != nil || interval <= 0 {
+		return nil, fmt.Errorf("invalid health.probe_interval %q", cfg.Health.ProbeInterval)
+	}
+
+	upstreams := map[string]string{}
+	for _, route := range proxyConfig.AgentRoutes {
+		if route.ProviderType != "" && route.UpstreamURL != "" {
+			upstreams[route.ProviderType] = route.UpstreamURL
+		}
+	}
+	if cfg.DefaultProvider != "" && cfg.DefaultUpstream != "" {
+		upstreams[cfg.DefaultProvider] = cfg.DefaultUpstream
+	}
+
+	prober := health.NewProber(upstreams)
+	prober.AddChecks(checker)
+	go prober.Run(ctx, interval, zapLogger)
+	return checker, nil
+}
+
+func (c *startCommander) monitorAgents(manager *start.Manager, zapLogger *zap.Logger, exited func(start.AgentSession), errChan chan<- error) {
 	ticker := time.NewTicker(2 * time.Second)
 	defer ticker.Stop()
 
+	known := map[int]start.AgentSession{}
 	for range ticker.C {
 		lock, err := manager.Lock()
 		if err != nil {
@@ -482,6 +1223,17 @@ func (c *startCommander) monitorIdle(manager *start.Manager, zapLogger *zap.Logg
 		}
 		_ = lock.Release()
 
+		running := make(map[int]start.AgentSession, len(active))
+		for _, session := range active {
+			running[session.PID] = session
+		}
+		for pid, session := range known {
+			if _, ok := running[pid]; !ok {
+				exited(session)
+			}
+		}
+		known = running
+
 		if state != nil && state.ShutdownWhenIdle && len(active) == 0 {
 			errChan <- nil
 			return
@@ -527,9 +1279,11 @@ func (c *startCommander) spawnDaemon(ctx context.Context, manager *start.Manager
 		return fmt.Errorf("resolving executable: %w", err)
 	}
 
-	logFile, err := os.OpenFile(manager.LogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	// The daemon logs to its rotated log file itself: its output holds only
+	// what escapes the logger, such as the trace of a crash.
+	outFile, err := os.OpenFile(manager.OutputPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
 	if err != nil {
-		return fmt.Errorf("opening log file: %w", err)
+		return fmt.Errorf("opening daemon output file: %w", err)
 	}
 
 	args := []string{"start", "--daemon"}
@@ -541,17 +1295,18 @@ func (c *startCommander) spawnDaemon(ctx context.Context, manager *start.Manager
 	}
 
 	cmd := exec.CommandContext(ctx, execPath, args...)
-	cmd.Stdout = logFile
-	cmd.Stderr = logFile
+	cmd.Stdout = outFile
+	cmd.Stderr = outFile
+	cmd.SysProcAttr = start.DaemonSysProcAttr()
 
 	if err := cmd.Start(); err != nil {
-		_ = logFile.Close()
+		_ = outFile.Close()
 		return fmt.Errorf("starting daemon: %w", err)
 	}
 	go func() {
 		_ = cmd.Wait()
 	}()
-	return logFile.Close()
+	return outFile.Close()
 }
 
 func (c *startCommander) waitForDaemon(ctx context.Context, manager *start.Manager) (*start.State, error) {
@@ -581,7 +1336,7 @@ func (c *startCommander) waitForDaemon(ctx context.Context, manager *start.Manag
 	}
 }
 
-func (c *startCommander) registerAgent(manager *start.Manager, name string, pid int) error {
+func (c *startCommander) registerAgent(manager *start.Manager, name, runID string, pid int) error {
 	lock, err := manager.Lock()
 	if err != nil {
 		return err
@@ -598,6 +1353,7 @@ func (c *startCommander) registerAgent(manager *start.Manager, name string, pid
 
 	state.Agents = append(state.Agents, start.AgentSession{
 		Name:      name,
+		RunID:     runID,
 		PID:       pid,
 		StartedAt: time.Now(),
 	})
@@ -675,6 +1431,7 @@ func (c *startCommander) loadConfig() (*startConfig, error) {
 
 	return &startConfig{
 		SQLitePath:          sqlitePath,
+		Encryption:          cfg.Storage.Encryption,
 		VectorStoreProvider: cfg.VectorStore.Provider,
 		VectorStoreTarget:   vectorTarget,
 		EmbeddingProvider:   cfg.Embedding.Provider,
@@ -686,12 +1443,47 @@ func (c *startCommander) loadConfig() (*startConfig, error) {
 		OllamaUpstream:      resolveOllamaUpstream(cfg.Proxy.Provider, cfg.Proxy.Upstream),
 		OpenCodeProvider:    cfg.OpenCode.Provider,
 		Project:             project,
+		User:                identity.Resolve(cfg.Proxy.User),
+		MaxCaptureBytes:     cfg.Proxy.MaxCaptureBytes,
+		MaxInputTokens:      cfg.Proxy.MaxInputTokens,
+		RawCapture:          cfg.Proxy.RawCapture,
+		SignNodes:           cfg.Proxy.SignNodes,
+		SessionIdleTimeout:  cfg.Proxy.SessionIdleTimeout,
+		Queue:               cfg.Proxy.QueueConfig,
+		Retry:               cfg.Proxy.Retry,
+		Redaction:           cfg.Redaction,
+		BlobDir:             cfg.Storage.BlobDir,
+		BlobThreshold:       cfg.Storage.BlobThreshold,
+		MediaDir:            cfg.Storage.MediaDir,
+		MediaMaxBytes:       cfg.Storage.MediaMaxBytes,
+		MediaDownload:       cfg.Storage.MediaDownload,
+		Retention:           cfg.Retention,
+		Summarizer:          cfg.Summarizer,
+		Titles:              cfg.Titles,
+		Report:              cfg.Report,
+		Logs:                cfg.Logs,
+		Health:              cfg.Health,
+		CompactInterval:     cfg.Storage.CompactInterval,
+		APIToken:            cfg.API.Token,
+		ConfirmRecording:    cfg.Start.ConfirmRecording,
+		CaptureRules:        cfg.Capture.Rules,
+		PolicyRules:         cfg.Policy.Rules,
+		NotifyRules:         cfg.Notify.Rules,
+		CodexConfig:         cfg.Start.CodexConfig,
+		CodexWireAPI:        cfg.Start.CodexWireAPI,
+		OTLPEndpoint:        cfg.Telemetry.OTLPEndpoint,
+		CADir:               filepath.Join(defaultTargetDir, "ca"),
+		RunToken: api.RunTokenRequest{
+			TTL:            cfg.Start.RunTokenTTL,
+			MaxRequests:    int(cfg.Start.RunTokenMaxRequests),    //nolint:gosec // config values are far below MaxInt
+			MaxInputTokens: int(cfg.Start.RunTokenMaxInputTokens), //nolint:gosec // config values are far below MaxInt
+		},
 	}, nil
 }
 
 func (c *startCommander) newStorageDriver(ctx context.Context, cfg *startConfig, zapLogger *zap.Logger) (storage.Driver, error) {
 	if cfg.SQLitePath != "" {
-		driver, err := sqlite.NewDriver(ctx, cfg.SQLitePath)
+		driver, err := sqlite.NewDriver(ctx, cfg.SQLitePath, sqlite.WithEncryption(cfg.Encryption))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create SQLite storer: %w", err)
 		}
@@ -711,7 +1503,7 @@ func (c *startCommander) newDagLoader(ctx context.Context, cfg *startConfig, zap
 	}
 
 	if cfg.SQLitePath != "" {
-		loader, err := sqlite.NewDriver(ctx, cfg.SQLitePath)
+		loader, err := sqlite.NewDriver(ctx, cfg.SQLitePath, sqlite.WithEncryption(cfg.Encryption))
 		if err != nil {
 			return nil, fmt.Errorf("failed to create SQLite storer: %w", err)
 		}
@@ -749,9 +1541,10 @@ func (c *startCommander) newVectorAndEmbedder(cfg *startConfig, zapLogger *zap.L
 
 // configureCodexAuth temporarily writes the stored OpenAI API key into codex's
 // ~/.codex/auth.json so that codex uses it instead of its OAuth token when
-// routing through the tapes proxy. The returned cleanup function restores the
-// original auth.json contents.
-func (c *startCommander) configureCodexAuth() (func() error, error) {
+// routing through the tapes proxy. When runToken is set, it is written in
+// place of the key. Without a stored key, a ChatGPT login is left as it is.
+// The returned cleanup function restores the original auth.json contents.
+func (c *startCommander) configureCodexAuth(runToken string) (func() error, error) {
 	noop := func() error { return nil }
 
 	mgr, err := credentials.NewManager(c.configDir)
@@ -763,15 +1556,24 @@ func (c *startCommander) configureCodexAuth() (func() error, error) {
 	if err != nil {
 		return noop, errors.New("run 'tapes auth openai' with a service account key (sk-svcacct-...) before starting codex")
 	}
-	if apiKey == "" {
-		return noop, errors.New("no OpenAI API key found — run 'tapes auth openai' with a service account key first")
-	}
 
 	original, authPath := credentials.ReadCodexAuthFile()
+	if apiKey == "" {
+		// codex signed in with a ChatGPT account keeps using it. The proxy
+		// sends its requests to the ChatGPT backend and refreshes its
+		// access token when it expires.
+		if original != nil && credentials.ReadCodexTokens(original) != nil {
+			return noop, nil
+		}
+		return noop, errors.New("no OpenAI API key found — run 'tapes auth openai' with a service account key, or 'codex login' with a ChatGPT account, first")
+	}
 	if original == nil {
 		return noop, nil
 	}
 
+	if runToken != "" {
+		apiKey = runToken
+	}
 	updated, ok := credentials.PatchCodexAuthKey(original, apiKey)
 	if !ok {
 		return noop, nil
@@ -780,9 +1582,68 @@ func (c *startCommander) configureCodexAuth() (func() error, error) {
 	if err := os.WriteFile(authPath, updated, 0o600); err != nil {
 		return noop, fmt.Errorf("writing codex auth: %w", err)
 	}
+	c.recordAudit(audit.ActionAuthFilePatch, authPath, map[string]string{
+		"agent":   agentCodex,
+		"changes": "set OPENAI_API_KEY, removed OAuth tokens",
+	})
 
 	restore := func() error {
-		return os.WriteFile(authPath, original, 0o600)
+		if err := os.WriteFile(authPath, original, 0o600); err != nil {
+			return err
+		}
+		c.recordAudit(audit.ActionAuthFileRestore, authPath, map[string]string{"agent": agentCodex})
+		return nil
+	}
+
+	return restore, nil
+}
+
+// configureCodexConfig temporarily selects a tapes model provider pointing at
+// baseURL in codex's config.toml, for codex versions that ignore the
+// OPENAI_BASE_URL override. The returned cleanup function restores the
+// original config.toml, or removes it if there was none.
+func (c *startCommander) configureCodexConfig(baseURL, wireAPI string) (func() error, error) {
+	if wireAPI == "" {
+		wireAPI = credentials.CodexWireResponses
+	}
+
+	configPath, err := credentials.CodexConfigPath()
+	if err != nil {
+		return nil, fmt.Errorf("finding codex config: %w", err)
+	}
+	original, err := os.ReadFile(configPath)
+	existed := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading codex config: %w", err)
+	}
+
+	updated, err := credentials.PatchCodexConfig(original, baseURL, wireAPI)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o700); err != nil {
+		return nil, fmt.Errorf("creating codex home: %w", err)
+	}
+	if err := os.WriteFile(configPath, updated, 0o600); err != nil {
+		return nil, fmt.Errorf("writing codex config: %w", err)
+	}
+	c.recordAudit(audit.ActionAgentConfigPatch, configPath, map[string]string{
+		"agent":   agentCodex,
+		"changes": "set model_provider to " + credentials.CodexProvider + " with wire_api " + wireAPI,
+	})
+
+	restore := func() error {
+		var err error
+		if existed {
+			err = os.WriteFile(configPath, original, 0o600)
+		} else {
+			err = os.Remove(configPath)
+		}
+		if err != nil {
+			return fmt.Errorf("restoring codex config: %w", err)
+		}
+		c.recordAudit(audit.ActionAgentConfigRestore, configPath, map[string]string{"agent": agentCodex})
+		return nil
 	}
 
 	return restore, nil
@@ -810,16 +1671,33 @@ func (c *startCommander) patchOpenCodeAuth() func() error {
 		fmt.Fprintf(os.Stderr, "Warning: could not patch opencode auth: %v\n", err)
 		return noop
 	}
+	c.recordAudit(audit.ActionAuthFilePatch, authPath, map[string]string{
+		"agent":   agentOpenCode,
+		"changes": "removed OAuth entries for openai, anthropic",
+	})
 
 	return func() error {
-		return os.WriteFile(authPath, original, 0o600)
+		if err := os.WriteFile(authPath, original, 0o600); err != nil {
+			return err
+		}
+		c.recordAudit(audit.ActionAuthFileRestore, authPath, map[string]string{"agent": agentOpenCode})
+		return nil
+	}
+}
+
+// recordAudit appends an entry to the audit log, warning instead of failing
+// so that an unwritable log never blocks the agent.
+func (c *startCommander) recordAudit(action, target string, details map[string]string) {
+	if err := audit.Record(c.configDir, action, target, details); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write audit log: %v\n", err)
 	}
 }
 
 // injectCredentials appends stored credential env vars to the given env slice.
 // If an env var is already set in the slice, the stored credential is skipped
-// so that shell environment takes precedence.
-func (c *startCommander) injectCredentials(env []string) []string {
+// so that shell environment takes precedence. When runToken is set, it is
+// injected in place of each stored key.
+func (c *startCommander) injectCredentials(env []string, runToken string) []string {
 	mgr, err := credentials.NewManager(c.configDir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: could not load credential manager: %v\n", err)
@@ -851,15 +1729,31 @@ func (c *startCommander) injectCredentials(env []string) []string {
 		if existing[envVar] {
 			continue
 		}
-		env = append(env, envVar+"="+pc.APIKey)
+		value := pc.APIKey
+		if runToken != "" {
+			value = runToken
+		}
+		env = append(env, envVar+"="+value)
 	}
 
 	return env
 }
 
+// parseRecordMode reports whether a --record value turns recording on.
+func parseRecordMode(mode string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", recordOn:
+		return true, nil
+	case recordOff:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid --record value %q (expected %s or %s)", mode, recordOn, recordOff)
+	}
+}
+
 func isSupportedAgent(agent string) bool {
 	switch agent {
-	case agentClaude, agentOpenCode, agentCodex:
+	case agentClaude, agentOpenCode, agentCodex, agentAider, agentContainer:
 		return true
 	default:
 		return false
@@ -874,6 +1768,8 @@ func agentCommand(agent string) string {
 		return "opencode"
 	case agentCodex:
 		return "codex"
+	case agentAider:
+		return "aider"
 	default:
 		return agent
 	}
@@ -883,7 +1779,7 @@ func stateHealthy(ctx context.Context, state *start.State) bool {
 	if state == nil || state.DaemonPID == 0 || state.APIURL == "" {
 		return false
 	}
-	if !processAlive(state.DaemonPID) {
+	if !start.ProcessAlive(state.DaemonPID) {
 		return false
 	}
 	return apiReachable(ctx, state.APIURL)
@@ -895,24 +1791,13 @@ func filterActiveAgents(state *start.State) []start.AgentSession {
 	}
 	active := make([]start.AgentSession, 0, len(state.Agents))
 	for _, session := range state.Agents {
-		if processAlive(session.PID) {
+		if start.ProcessAlive(session.PID) {
 			active = append(active, session)
 		}
 	}
 	return active
 }
 
-func processAlive(pid int) bool {
-	if pid <= 0 {
-		return false
-	}
-	proc, err := os.FindProcess(pid)
-	if err != nil {
-		return false
-	}
-	return proc.Signal(syscall.Signal(0)) == nil
-}
-
 func apiReachable(ctx context.Context, apiURL string) bool {
 	client := &http.Client{Timeout: 2 * time.Second}
 	url := strings.TrimRight(apiURL, "/") + "/ping"
@@ -928,75 +1813,7 @@ func apiReachable(ctx context.Context, apiURL string) bool {
 	return resp.StatusCode == http.StatusOK
 }
 
-func followLog(ctx context.Context, path string, out io.Writer) error {
-	file, err := os.Open(path)
-	if err != nil {
-		return fmt.Errorf("opening log file: %w", err)
-	}
-	defer file.Close()
-
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		return fmt.Errorf("creating log watcher: %w", err)
-	}
-	defer watcher.Close()
-
-	stat, err := file.Stat()
-	if err != nil {
-		return fmt.Errorf("stat log file: %w", err)
-	}
-
-	if _, err := file.Seek(stat.Size(), io.SeekStart); err != nil {
-		return fmt.Errorf("seek log file: %w", err)
-	}
-
-	if err := watcher.Add(filepath.Dir(path)); err != nil {
-		return fmt.Errorf("watching log dir: %w", err)
-	}
-
-	buf := make([]byte, 4096)
-	readAvailable := func() error {
-		for {
-			n, err := file.Read(buf)
-			if n > 0 {
-				if _, writeErr := out.Write(buf[:n]); writeErr != nil {
-					return writeErr
-				}
-			}
-			if err != nil {
-				if errors.Is(err, io.EOF) {
-					return nil
-				}
-				return err
-			}
-		}
-	}
-
-	if err := readAvailable(); err != nil {
-		return err
-	}
-
-	for {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case event := <-watcher.Events:
-			if filepath.Clean(event.Name) != filepath.Clean(path) {
-				continue
-			}
-			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
-				continue
-			}
-			if err := readAvailable(); err != nil {
-				return err
-			}
-		case err := <-watcher.Errors:
-			return fmt.Errorf("log watcher error: %w", err)
-		}
-	}
-}
-
-func configureOpenCode(baseURL, tapesConfigDir string) (func() error, string, error) {
+func configureOpenCode(baseURL, tapesConfigDir, runToken string) (func() error, string, error) {
 	configRoot, err := os.MkdirTemp("", "tapes-opencode-config-")
 	if err != nil {
 		return nil, "", fmt.Errorf("creating opencode config root: %w", err)
@@ -1013,6 +1830,11 @@ func configureOpenCode(baseURL, tapesConfigDir string) (func() error, string, er
 	// This is the same pattern as configureCodexAuth — opencode uses its own
 	// auth flow, so env vars alone are not sufficient.
 	apiKeys := loadStoredAPIKeys(tapesConfigDir)
+	if runToken != "" {
+		for provider := range apiKeys {
+			apiKeys[provider] = runToken
+		}
+	}
 
 	// Start from the user's existing opencode config if available.
 	existing := loadUserOpenCodeConfig()
@@ -3,6 +3,7 @@ package startcmder
 import (
 	"bytes"
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -13,6 +14,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/papercomputeco/tapes/api"
 	"github.com/papercomputeco/tapes/pkg/credentials"
 	"github.com/papercomputeco/tapes/pkg/start"
 )
@@ -75,41 +77,6 @@ var _ = Describe("runLogs", func() {
 	})
 })
 
-var _ = Describe("followLog", func() {
-	var tmpDir string
-
-	BeforeEach(func() {
-		var err error
-		tmpDir, err = os.MkdirTemp("", "tapes-follow-logs-*")
-		Expect(err).NotTo(HaveOccurred())
-	})
-
-	AfterEach(func() {
-		Expect(os.RemoveAll(tmpDir)).To(Succeed())
-	})
-
-	It("tails new log content only", func() {
-		logPath := filepath.Join(tmpDir, "start.log")
-		Expect(os.WriteFile(logPath, []byte("old\n"), 0o600)).To(Succeed())
-
-		ctx, cancel := context.WithCancel(context.Background())
-		DeferCleanup(cancel)
-		var out bytes.Buffer
-		errChan := make(chan error, 1)
-		go func() {
-			errChan <- followLog(ctx, logPath, &out)
-		}()
-
-		time.Sleep(50 * time.Millisecond)
-		Expect(appendToFile(logPath, []byte("new\n"))).To(Succeed())
-
-		Eventually(out.String, 2*time.Second, 50*time.Millisecond).Should(ContainSubstring("new"))
-		Expect(out.String()).NotTo(ContainSubstring("old"))
-		cancel()
-		Eventually(errChan, 2*time.Second, 50*time.Millisecond).Should(Receive(MatchError(context.Canceled)))
-	})
-})
-
 var _ = Describe("injectCredentials", func() {
 	var tmpDir string
 
@@ -129,7 +96,7 @@ var _ = Describe("injectCredentials", func() {
 		Expect(mgr.SetKey("openai", "sk-test-inject")).To(Succeed())
 
 		cmder := &startCommander{configDir: tmpDir}
-		env := cmder.injectCredentials([]string{"HOME=/tmp"})
+		env := cmder.injectCredentials([]string{"HOME=/tmp"}, "")
 
 		found := false
 		for _, e := range env {
@@ -147,7 +114,7 @@ var _ = Describe("injectCredentials", func() {
 		Expect(mgr.SetKey("openai", "sk-stored")).To(Succeed())
 
 		cmder := &startCommander{configDir: tmpDir}
-		env := cmder.injectCredentials([]string{"OPENAI_API_KEY=sk-existing"})
+		env := cmder.injectCredentials([]string{"OPENAI_API_KEY=sk-existing"}, "")
 
 		count := 0
 		for _, e := range env {
@@ -162,10 +129,20 @@ var _ = Describe("injectCredentials", func() {
 	It("returns env unchanged when no credentials stored", func() {
 		cmder := &startCommander{configDir: tmpDir}
 		original := []string{"HOME=/tmp", "PATH=/usr/bin"}
-		env := cmder.injectCredentials(original)
+		env := cmder.injectCredentials(original, "")
 		Expect(env).To(Equal(original))
 	})
 
+	It("injects the run token in place of stored keys", func() {
+		mgr, err := credentials.NewManager(tmpDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mgr.SetKey("openai", "sk-stored")).To(Succeed())
+
+		cmder := &startCommander{configDir: tmpDir}
+		env := cmder.injectCredentials([]string{}, "tapes_run_abc")
+		Expect(env).To(ConsistOf("OPENAI_API_KEY=tapes_run_abc"))
+	})
+
 	It("injects multiple providers", func() {
 		mgr, err := credentials.NewManager(tmpDir)
 		Expect(err).NotTo(HaveOccurred())
@@ -173,7 +150,7 @@ var _ = Describe("injectCredentials", func() {
 		Expect(mgr.SetKey("anthropic", "sk-anthropic-test")).To(Succeed())
 
 		cmder := &startCommander{configDir: tmpDir}
-		env := cmder.injectCredentials([]string{})
+		env := cmder.injectCredentials([]string{}, "")
 
 		envMap := make(map[string]string)
 		for _, e := range env {
@@ -186,6 +163,93 @@ var _ = Describe("injectCredentials", func() {
 	})
 })
 
+var _ = Describe("configureCodexConfig", func() {
+	It("selects the tapes provider while codex runs and restores the config", func() {
+		codexHome := GinkgoT().TempDir()
+		GinkgoT().Setenv(credentials.CodexHomeEnv, codexHome)
+		configPath := filepath.Join(codexHome, "config.toml")
+		original := "# my settings\nmodel = \"gpt-5-codex\"\n"
+		Expect(os.WriteFile(configPath, []byte(original), 0o600)).To(Succeed())
+
+		cmder := &startCommander{configDir: GinkgoT().TempDir()}
+		restore, err := cmder.configureCodexConfig("http://127.0.0.1:8080/runs/r1/agents/codex", "")
+		Expect(err).NotTo(HaveOccurred())
+
+		patched, err := os.ReadFile(configPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(patched)).To(ContainSubstring(`model_provider = "tapes"`))
+		Expect(string(patched)).To(ContainSubstring(`base_url = "http://127.0.0.1:8080/runs/r1/agents/codex"`))
+		Expect(string(patched)).To(ContainSubstring(`wire_api = "responses"`))
+
+		Expect(restore()).To(Succeed())
+		restored, err := os.ReadFile(configPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(restored)).To(Equal(original))
+	})
+
+	It("removes the config it wrote when there was none", func() {
+		codexHome := GinkgoT().TempDir()
+		GinkgoT().Setenv(credentials.CodexHomeEnv, codexHome)
+
+		cmder := &startCommander{configDir: GinkgoT().TempDir()}
+		restore, err := cmder.configureCodexConfig("http://127.0.0.1:8080", "chat")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Join(codexHome, "config.toml")).To(BeAnExistingFile())
+
+		Expect(restore()).To(Succeed())
+		Expect(filepath.Join(codexHome, "config.toml")).NotTo(BeAnExistingFile())
+	})
+})
+
+var _ = Describe("NewStartCmd", func() {
+	It("requires an agent for arguments after --", func() {
+		cmd := NewStartCmd()
+		cmd.SetArgs([]string{"--", "--no-auto-commits"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		Expect(cmd.Execute()).To(MatchError(ContainSubstring("name one")))
+	})
+
+	It("accepts one agent followed by its arguments", func() {
+		cmd := NewStartCmd()
+		Expect(cmd.ParseFlags([]string{"aider", "--", "--model", "sonnet"})).To(Succeed())
+		Expect(cmd.Args(cmd, cmd.Flags().Args())).To(Succeed())
+
+		cmd = NewStartCmd()
+		Expect(cmd.ParseFlags([]string{"aider", "codex", "--", "--model", "sonnet"})).To(Succeed())
+		Expect(cmd.Args(cmd, cmd.Flags().Args())).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("parseRecordMode", func() {
+	It("records unless recording is turned off", func() {
+		for mode, want := range map[string]bool{"": true, "on": true, "off": false, "OFF": false} {
+			record, err := parseRecordMode(mode)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record).To(Equal(want), mode)
+		}
+
+		_, err := parseRecordMode("maybe")
+		Expect(err).To(MatchError(ContainSubstring("invalid --record value")))
+	})
+})
+
+var _ = Describe("runTokenOf", func() {
+	It("returns the issued token", func() {
+		token, err := runTokenOf(api.RunTokenResponse{Token: "tapes_run_abc"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("tapes_run_abc"))
+	})
+
+	It("fails when the daemon cannot issue a token or issues none", func() {
+		_, err := runTokenOf(api.RunTokenResponse{}, errors.New("daemon unreachable"))
+		Expect(err).To(MatchError("daemon unreachable"))
+
+		_, err = runTokenOf(api.RunTokenResponse{}, nil)
+		Expect(err).To(MatchError(ContainSubstring("daemon returned no token")))
+	})
+})
+
 var _ = Describe("loadConfig project resolution", func() {
 	var tmpDir string
 
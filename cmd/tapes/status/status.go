@@ -1,16 +1,45 @@
 // Package statuscmder provides the status command for displaying the current
-// checkout state of the local .tapes directory.
+// checkout state of the local .tapes directory and the running daemon's
+// capture counts.
 package statuscmder
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/papercomputeco/tapes/pkg/config"
 	"github.com/papercomputeco/tapes/pkg/dotdir"
 	"github.com/papercomputeco/tapes/pkg/utils"
 )
 
+// healthTimeout bounds the request for the daemon's capture counts, so that
+// status stays quick when no daemon is running.
+const healthTimeout = time.Second
+
+type statusCommander struct {
+	apiTarget string
+}
+
+// healthResponse mirrors the fields of the API's HealthResponse used here.
+type healthResponse struct {
+	Status     string                     `json:"status"`
+	Components map[string]healthComponent `json:"components"`
+	Skipped    map[string]uint64          `json:"skipped"`
+}
+
+type healthComponent struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
 const statusLongDesc string = `Show the current tapes checkout state.
 
 Reads the local .tapes/ directory (or ~/.tapes/) to display the checked-out
@@ -19,22 +48,52 @@ conversation point, including the hash and message history.
 If no checkout state exists, indicates that the next chat session will start
 a new conversation.
 
+When a daemon is reachable at the API target, also shows its health, per
+component (storage, events, the capture queue, and probed providers), and how
+many turns its capture rules skipped, per rule.
+
 Examples:
   tapes status`
 
 const statusShortDesc string = "Show current checkout state"
 
 func NewStatusCmd() *cobra.Command {
+	cmder := &statusCommander{}
+
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: statusShortDesc,
 		Long:  statusLongDesc,
 		Args:  cobra.NoArgs,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			return runStatus()
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			configDir, _ := cmd.Flags().GetString("config-dir")
+			cfger, err := config.NewConfiger(configDir)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			cfg, err := cfger.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			if !cmd.Flags().Changed("api-target") {
+				cmder.apiTarget = cfg.Client.APITarget
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := runStatus(); err != nil {
+				return err
+			}
+			cmder.printDaemon(cmd.OutOrStdout())
+			return nil
 		},
 	}
 
+	defaults := config.NewDefaultConfig()
+	cmd.Flags().StringVarP(&cmder.apiTarget, "api-target", "a", defaults.Client.APITarget, "Tapes API server URL")
+
 	return cmd
 }
 
@@ -62,3 +121,61 @@ func runStatus() error {
 
 	return nil
 }
+
+// printDaemon prints the daemon's health and the turns its capture rules
+// skipped. It prints nothing when no daemon is reachable.
+func (c *statusCommander) printDaemon(out io.Writer) {
+	client := &http.Client{Timeout: healthTimeout}
+	resp, err := client.Get(strings.TrimSuffix(c.apiTarget, "/") + "/v1/health")
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	// A down daemon answers 503 with the same body.
+	var health healthResponse
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusServiceUnavailable {
+		return
+	}
+	if json.NewDecoder(resp.Body).Decode(&health) != nil {
+		return
+	}
+
+	printHealth(out, health)
+	printCaptureSkips(out, health.Skipped)
+}
+
+// printHealth prints the daemon's overall health and that of each component.
+func printHealth(out io.Writer, health healthResponse) {
+	if health.Status == "" {
+		return
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Daemon health: %s\n", health.Status)
+	for _, name := range slices.Sorted(maps.Keys(health.Components)) {
+		component := health.Components[name]
+		if component.Message == "" {
+			fmt.Fprintf(out, "  %s: %s\n", name, component.Status)
+			continue
+		}
+		fmt.Fprintf(out, "  %s: %s (%s)\n", name, component.Status, component.Message)
+	}
+}
+
+// printCaptureSkips prints the turns the daemon's capture rules skipped. It
+// prints nothing when no turn was skipped.
+func printCaptureSkips(out io.Writer, skipped map[string]uint64) {
+	if len(skipped) == 0 {
+		return
+	}
+
+	var total uint64
+	for _, n := range skipped {
+		total += n
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Skipped by capture rules: %d turns\n", total)
+	for _, rule := range slices.Sorted(maps.Keys(skipped)) {
+		fmt.Fprintf(out, "  %s: %d\n", rule, skipped[rule])
+	}
+}
@@ -1,7 +1,10 @@
 package statuscmder_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 
@@ -89,4 +92,38 @@ var _ = Describe("Status command execution", func() {
 		err = cmd.Execute()
 		Expect(err).NotTo(HaveOccurred())
 	})
+
+	It("shows the turns the daemon's capture rules skipped", func() {
+		Expect(os.MkdirAll(filepath.Join(tmpDir, ".tapes"), 0o755)).To(Succeed())
+		daemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/v1/health"))
+			_, _ = w.Write([]byte(`{"status":"ok","skipped":{"not_included":1,"embeddings":2}}`))
+		}))
+		defer daemon.Close()
+
+		var out bytes.Buffer
+		cmd := statuscmder.NewStatusCmd()
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"--api-target", daemon.URL})
+		Expect(cmd.Execute()).To(Succeed())
+
+		Expect(out.String()).To(ContainSubstring("Skipped by capture rules: 3 turns\n  embeddings: 2\n  not_included: 1\n"))
+	})
+	It("shows the daemon's component health, also when it is down", func() {
+		Expect(os.MkdirAll(filepath.Join(tmpDir, ".tapes"), 0o755)).To(Succeed())
+		daemon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"status":"down","components":{"storage":{"status":"down","message":"disk I/O error"},"queue":{"status":"ok"}}}`))
+		}))
+		defer daemon.Close()
+
+		var out bytes.Buffer
+		cmd := statuscmder.NewStatusCmd()
+		cmd.SetOut(&out)
+		cmd.SetArgs([]string{"--api-target", daemon.URL})
+		Expect(cmd.Execute()).To(Succeed())
+
+		Expect(out.String()).To(ContainSubstring("Daemon health: down\n  queue: ok\n  storage: down (disk I/O error)\n"))
+		Expect(out.String()).NotTo(ContainSubstring("Skipped by capture rules"))
+	})
 })
@@ -61,7 +61,11 @@ func (c *syncCommander) run(ctx context.Context, cmd *cobra.Command) error {
 		Verbose: c.verbose,
 	}
 
-	b, cleanup, err := backfill.NewBackfiller(ctx, dbPath, opts)
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	b, cleanup, err := backfill.NewBackfiller(ctx, dbPath, opts, driverOpts...)
 	if err != nil {
 		return err
 	}
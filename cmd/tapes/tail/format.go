@@ -0,0 +1,139 @@
+package tailcmder
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/events"
+)
+
+// maxLineWidth caps each line of message text.
+const maxLineWidth = 160
+
+var (
+	userStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+	assistantStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("82")).Bold(true)
+	toolStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+)
+
+// printer writes events in a compact, human-readable form.
+type printer struct {
+	out       io.Writer
+	pricing   deck.PricingTable
+	toolsOnly bool
+	lines     int
+
+	lastSeq uint64
+}
+
+func (p *printer) print(event events.Event) {
+	if p.lastSeq != 0 && event.Seq > p.lastSeq+1 {
+		fmt.Fprintln(p.out, cliui.DimStyle.Render(fmt.Sprintf("… %d events missed", event.Seq-p.lastSeq-1)))
+	}
+	p.lastSeq = event.Seq
+
+	if p.toolsOnly && len(event.ToolCalls) == 0 {
+		return
+	}
+
+	header := []string{
+		cliui.DimStyle.Render(event.Time.Local().Format("15:04:05")),
+		cliui.HashStyle.Render(cliui.ShortHash(event.RootHash)),
+		roleStyle(event.Role).Render(event.Role),
+	}
+	if event.Model != "" {
+		header = append(header, event.Model)
+	}
+	if usage := p.usage(event); usage != "" {
+		header = append(header, cliui.DimStyle.Render(usage))
+	}
+	fmt.Fprintln(p.out, strings.Join(header, "  "))
+
+	if !p.toolsOnly {
+		for _, line := range previewLines(event.Text, p.lines) {
+			fmt.Fprintln(p.out, "  "+cliui.PreviewStyle.Render(line))
+		}
+		if event.ToolResults > 0 {
+			fmt.Fprintln(p.out, "  "+cliui.DimStyle.Render(fmt.Sprintf("%d tool %s", event.ToolResults, plural(event.ToolResults, "result", "results"))))
+		}
+	}
+	if len(event.ToolCalls) > 0 {
+		fmt.Fprintln(p.out, "  "+toolStyle.Render("→ "+strings.Join(event.ToolCalls, ", ")))
+	}
+}
+
+// usage formats the tokens and cost of a response, or returns "" when the
+// event carries no usage. Estimated usage is marked with a leading "~".
+func (p *printer) usage(event events.Event) string {
+	if event.Usage == nil || (event.Usage.PromptTokens == 0 && event.Usage.CompletionTokens == 0) {
+		return ""
+	}
+	usage := fmt.Sprintf("%s in / %s out", cliui.FormatTokens(int64(event.Usage.PromptTokens)), cliui.FormatTokens(int64(event.Usage.CompletionTokens)))
+	if event.Usage.Estimated {
+		usage = "~" + usage
+	}
+
+	pricing, ok := deck.PricingForModel(p.pricing, event.Model)
+	if !ok {
+		return usage
+	}
+	_, _, cost := deck.CostForTokensWithCache(pricing,
+		int64(event.Usage.PromptTokens),
+		int64(event.Usage.CompletionTokens),
+		int64(event.Usage.CacheCreationInputTokens),
+		int64(event.Usage.CacheReadInputTokens),
+	)
+	return fmt.Sprintf("%s  $%.4f", usage, cost)
+}
+
+func roleStyle(role string) lipgloss.Style {
+	switch role {
+	case "user":
+		return userStyle
+	case "assistant":
+		return assistantStyle
+	default:
+		return cliui.RoleStyle
+	}
+}
+
+// previewLines returns up to n non-blank lines of text, each truncated to
+// maxLineWidth, with a marker when lines were left out.
+func previewLines(text string, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	var lines []string
+	for line := range strings.SplitSeq(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	var out []string
+	for i, line := range lines {
+		if i == n {
+			out = append(out, fmt.Sprintf("… %d more %s", len(lines)-n, plural(len(lines)-n, "line", "lines")))
+			break
+		}
+		if len(line) > maxLineWidth {
+			line = line[:maxLineWidth] + "…"
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,206 @@
+// Package tailcmder provides the tail command, which prints turns as the
+// daemon captures them.
+package tailcmder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/sse"
+)
+
+const tailLongDesc string = `Watch turns as they are captured.
+
+Connects to the event stream of a running "tapes start" or "tapes serve" and
+prints each stored message as it arrives: the time, session, role, model,
+token usage and cost of responses, a preview of the text, and any tools
+called. The session shown is the hash of the conversation's first message;
+pass a prefix of it to --session to follow one conversation.
+
+If the connection drops, tail reconnects until interrupted.
+
+Examples:
+  tapes tail
+  tapes tail --session 3f2a9c
+  tapes tail --tools-only
+  tapes tail --provider anthropic --lines 0`
+
+const tailShortDesc string = "Watch turns live as they are captured"
+
+// reconnectDelay is how long tail waits before reconnecting to the stream.
+var reconnectDelay = 2 * time.Second
+
+type tailCommander struct {
+	apiTarget   string
+	apiToken    string
+	pricingPath string
+	configDir   string
+	session     string
+	provider    string
+	toolsOnly   bool
+	lines       int
+}
+
+func NewTailCmd() *cobra.Command {
+	cmder := &tailCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: tailShortDesc,
+		Long:  tailLongDesc,
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			configDir, _ := cmd.Flags().GetString("config-dir")
+			cfger, err := config.NewConfiger(configDir)
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			cfg, err := cfger.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("loading config: %w", err)
+			}
+
+			if !cmd.Flags().Changed("api-target") {
+				cmder.apiTarget = cfg.Client.APITarget
+			}
+			cmder.apiToken = cfg.API.Token
+			cmder.configDir = configDir
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+
+	defaults := config.NewDefaultConfig()
+	cmd.Flags().StringVar(&cmder.apiTarget, "api-target", defaults.Client.APITarget, "Tapes API server URL")
+	cmd.Flags().StringVar(&cmder.session, "session", "", "Only show the session whose first message hash starts with this prefix")
+	cmd.Flags().StringVar(&cmder.provider, "provider", "", "Only show turns from this provider")
+	cmd.Flags().BoolVar(&cmder.toolsOnly, "tools-only", false, "Only show messages that call tools")
+	cmd.Flags().IntVar(&cmder.lines, "lines", 3, "Lines of text to show per message (0 hides text)")
+	cmd.Flags().StringVar(&cmder.pricingPath, "pricing", "", "Path to pricing TOML or JSON overrides")
+
+	return cmd
+}
+
+func (c *tailCommander) run(ctx context.Context, out, errOut io.Writer) error {
+	pricing, err := deck.ResolvePricing(c.configDir, c.pricingPath)
+	if err != nil {
+		return err
+	}
+	p := &printer{
+		out:       out,
+		pricing:   pricing,
+		toolsOnly: c.toolsOnly,
+		lines:     c.lines,
+	}
+
+	connected := false
+	for {
+		err := c.stream(ctx, p, func() {
+			if connected {
+				fmt.Fprintln(errOut, cliui.DimStyle.Render("reconnected"))
+			} else {
+				fmt.Fprintln(errOut, cliui.DimStyle.Render("watching "+c.apiTarget+" (ctrl-c to stop)"))
+			}
+			connected = true
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		var fatal *fatalError
+		if errors.As(err, &fatal) {
+			return fatal.err
+		}
+		if err != nil && !connected {
+			return err
+		}
+
+		fmt.Fprintln(errOut, cliui.DimStyle.Render("connection lost, reconnecting..."))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// fatalError marks stream errors that reconnecting will not fix.
+type fatalError struct {
+	err error
+}
+
+func (e *fatalError) Error() string { return e.err.Error() }
+
+// stream reads events from the API until the connection ends, calling
+// onConnect once the stream is open.
+func (c *tailCommander) stream(ctx context.Context, p *printer, onConnect func()) error {
+	target, err := url.Parse(c.apiTarget)
+	if err != nil {
+		return &fatalError{fmt.Errorf("invalid API target URL: %w", err)}
+	}
+	target.Path = "/v1/events"
+	q := target.Query()
+	if c.session != "" {
+		q.Set("session", c.session)
+	}
+	if c.provider != "" {
+		q.Set("provider", c.provider)
+	}
+	target.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target.String(), nil)
+	if err != nil {
+		return &fatalError{fmt.Errorf("creating request: %w", err)}
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if c.apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Tapes API at %s: %w", c.apiTarget, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return &fatalError{fmt.Errorf("event stream request failed (HTTP %d): %s", resp.StatusCode, strings.TrimSpace(string(body)))}
+	}
+	onConnect()
+
+	reader := sse.NewTeeReader(resp.Body, io.Discard)
+	for {
+		ev, err := reader.Next()
+		if err != nil {
+			return err
+		}
+		if ev == nil {
+			return nil
+		}
+		if ev.Type != events.TypeNodeCreated {
+			continue
+		}
+
+		var event events.Event
+		if err := json.Unmarshal([]byte(ev.Data), &event); err != nil {
+			continue
+		}
+		p.print(event)
+	}
+}
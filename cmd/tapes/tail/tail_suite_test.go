@@ -0,0 +1,13 @@
+package tailcmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTail(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tail Command Suite")
+}
@@ -0,0 +1,128 @@
+package tailcmder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+var _ = Describe("tail command", func() {
+	var (
+		stream  []events.Event
+		queries chan string
+		server  *httptest.Server
+	)
+
+	BeforeEach(func() {
+		stream = []events.Event{
+			{Seq: 1, Type: events.TypeNodeCreated, RootHash: "3f2a9c0011223344", Role: "user", Text: "fix the build"},
+			{
+				Seq: 2, Type: events.TypeNodeCreated, RootHash: "3f2a9c0011223344", Role: "assistant",
+				Model: "gpt-4o", Text: "Running make", ToolCalls: []string{"Bash"},
+				Usage: &llm.Usage{PromptTokens: 1_000_000, CompletionTokens: 100},
+			},
+		}
+		queries = make(chan string, 10)
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			queries <- r.URL.RawQuery
+			if r.Header.Get("Authorization") == "Bearer wrong" {
+				http.Error(w, `{"error":"missing or invalid auth token"}`, http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, ": connected\n\n")
+			for _, event := range stream {
+				data, _ := json.Marshal(event)
+				fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Type, data)
+			}
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}))
+		DeferCleanup(server.Close)
+	})
+
+	// start runs tail until the returned cancel function is called.
+	start := func(args ...string) (*gbytes.Buffer, func() error) {
+		ctx, cancel := context.WithCancel(context.Background())
+		out := gbytes.NewBuffer()
+		cmd := NewTailCmd()
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		cmd.SetArgs(append(args, "--api-target", server.URL))
+
+		done := make(chan error, 1)
+		go func() { done <- cmd.ExecuteContext(ctx) }()
+		return out, func() error {
+			cancel()
+			return <-done
+		}
+	}
+
+	It("prints each captured message", func() {
+		out, stop := start()
+		Eventually(out).Should(gbytes.Say(`3f2a9c001122  user`))
+		Eventually(out).Should(gbytes.Say(`fix the build`))
+		Eventually(out).Should(gbytes.Say(`3f2a9c001122  assistant  gpt-4o  1\.0M in / 100 out  \$2\.5010`))
+		Eventually(out).Should(gbytes.Say(`Running make`))
+		Eventually(out).Should(gbytes.Say(`→ Bash`))
+		Expect(stop()).To(Succeed())
+	})
+
+	It("passes the session and provider filters to the stream", func() {
+		_, stop := start("--session", "3f2a", "--provider", "openai")
+		Eventually(queries).Should(Receive(Equal("provider=openai&session=3f2a")))
+		Expect(stop()).To(Succeed())
+	})
+
+	It("shows only tool calls with --tools-only", func() {
+		out, stop := start("--tools-only")
+		Eventually(out).Should(gbytes.Say(`assistant`))
+		Eventually(out).Should(gbytes.Say(`→ Bash`))
+		Expect(stop()).To(Succeed())
+		Expect(string(out.Contents())).NotTo(ContainSubstring("fix the build"))
+		Expect(string(out.Contents())).NotTo(ContainSubstring("Running make"))
+	})
+
+	It("fails without retrying when the API rejects the request", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		cmder := &tailCommander{apiTarget: server.URL, apiToken: "wrong"}
+		err := cmder.run(ctx, gbytes.NewBuffer(), gbytes.NewBuffer())
+		Expect(err).To(MatchError(ContainSubstring("HTTP 401")))
+	})
+
+	It("fails when the daemon is not running", func() {
+		cmder := &tailCommander{apiTarget: "http://127.0.0.1:1"}
+		err := cmder.run(context.Background(), gbytes.NewBuffer(), gbytes.NewBuffer())
+		Expect(err).To(MatchError(ContainSubstring("failed to connect")))
+	})
+})
+
+var _ = Describe("printer", func() {
+	It("reports gaps in the event sequence", func() {
+		out := gbytes.NewBuffer()
+		p := &printer{out: out, pricing: deck.DefaultPricing(), lines: 3}
+
+		p.print(events.Event{Seq: 1, Role: "user"})
+		p.print(events.Event{Seq: 5, Role: "user"})
+		Expect(out).To(gbytes.Say(`3 events missed`))
+	})
+
+	It("limits the preview to the requested number of lines", func() {
+		Expect(previewLines("one\n\ntwo\nthree\nfour", 2)).To(Equal([]string{"one", "two", "… 2 more lines"}))
+		Expect(previewLines("one", 0)).To(BeEmpty())
+	})
+})
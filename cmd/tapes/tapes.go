@@ -4,19 +4,45 @@ package tapescmder
 import (
 	"github.com/spf13/cobra"
 
+	analyticscmder "github.com/papercomputeco/tapes/cmd/tapes/analytics"
+	auditcmder "github.com/papercomputeco/tapes/cmd/tapes/audit"
 	authcmder "github.com/papercomputeco/tapes/cmd/tapes/auth"
+	backupcmder "github.com/papercomputeco/tapes/cmd/tapes/backup"
 	chatcmder "github.com/papercomputeco/tapes/cmd/tapes/chat"
 	checkoutcmder "github.com/papercomputeco/tapes/cmd/tapes/checkout"
 	configcmder "github.com/papercomputeco/tapes/cmd/tapes/config"
+	costscmder "github.com/papercomputeco/tapes/cmd/tapes/costs"
+	crashescmder "github.com/papercomputeco/tapes/cmd/tapes/crashes"
+	dbcmder "github.com/papercomputeco/tapes/cmd/tapes/db"
+	deadlettercmder "github.com/papercomputeco/tapes/cmd/tapes/deadletter"
 	deckcmder "github.com/papercomputeco/tapes/cmd/tapes/deck"
+	diffcmder "github.com/papercomputeco/tapes/cmd/tapes/diff"
+	evalcmder "github.com/papercomputeco/tapes/cmd/tapes/eval"
+	exportcmder "github.com/papercomputeco/tapes/cmd/tapes/export"
+	forkcmder "github.com/papercomputeco/tapes/cmd/tapes/fork"
 	initcmder "github.com/papercomputeco/tapes/cmd/tapes/init"
+	logscmder "github.com/papercomputeco/tapes/cmd/tapes/logs"
+	mcpcmder "github.com/papercomputeco/tapes/cmd/tapes/mcp"
+	pricingcmder "github.com/papercomputeco/tapes/cmd/tapes/pricing"
+	profilecmder "github.com/papercomputeco/tapes/cmd/tapes/profile"
+	promptscmder "github.com/papercomputeco/tapes/cmd/tapes/prompts"
+	prunecmder "github.com/papercomputeco/tapes/cmd/tapes/prune"
+	reportcmder "github.com/papercomputeco/tapes/cmd/tapes/report"
+	reprocesscmder "github.com/papercomputeco/tapes/cmd/tapes/reprocess"
 	searchcmder "github.com/papercomputeco/tapes/cmd/tapes/search"
 	seedcmder "github.com/papercomputeco/tapes/cmd/tapes/seed"
 	servecmder "github.com/papercomputeco/tapes/cmd/tapes/serve"
+	sessionscmder "github.com/papercomputeco/tapes/cmd/tapes/sessions"
+	sharecmder "github.com/papercomputeco/tapes/cmd/tapes/share"
 	skillcmder "github.com/papercomputeco/tapes/cmd/tapes/skill"
 	startcmder "github.com/papercomputeco/tapes/cmd/tapes/start"
 	statuscmder "github.com/papercomputeco/tapes/cmd/tapes/status"
 	synccmder "github.com/papercomputeco/tapes/cmd/tapes/sync"
+	tailcmder "github.com/papercomputeco/tapes/cmd/tapes/tail"
+	tokenscmder "github.com/papercomputeco/tapes/cmd/tapes/tokens"
+	treecmder "github.com/papercomputeco/tapes/cmd/tapes/tree"
+	uicmder "github.com/papercomputeco/tapes/cmd/tapes/ui"
+	updatecmder "github.com/papercomputeco/tapes/cmd/tapes/update"
 	versioncmder "github.com/papercomputeco/tapes/cmd/version"
 )
 
@@ -34,21 +60,45 @@ Experimental: Chat through the proxy:
   tapes checkout <hash>    Checkout a conversation point
   tapes checkout           Clear checkout state, start fresh
   tapes status             Show current checkout state
+  tapes fork <hash>        Print a request body that continues a conversation
   tapes init                         Initialize a local .tapes directory
   tapes init --preset <preset|url>   Initialize with a provider preset or remote config
 
 Search sessions:
-  tapes search         Search sessions using semantic similarity
+  tapes search <query>             Search sessions by similarity through the API
+  tapes search --text <query>      Keyword search over the local full-text index
+  tapes search --semantic <query>  Search local embeddings by meaning, no API needed
+  tapes mcp                        Serve sessions to agents over MCP
 
 	Deck sessions:
+	  tapes sessions list  List recorded sessions
+	  tapes ui             Browse sessions in a terminal UI
+	  tapes tail           Watch turns live as they are captured
 	  tapes deck           ROI dashboard for sessions
 	  tapes deck --web     Local web dashboard
 	  tapes seed           Seed demo sessions
+	  tapes share <id>     Hand a session to a teammate (tapes receive)
+	  tapes export dataset Convert sessions into a fine-tuning dataset
+	  tapes eval           Run an eval suite against recorded sessions
+	  tapes report slack   Post a digest of recent sessions to Slack
+	  tapes analytics export  Export usage trends for Grafana or Metabase
+
+Diagnostics:
+  tapes logs -f            Follow the start daemon's logs
+  tapes crashes list       List reports of crashes tapes recovered from
+  tapes deadletter list    List turns that failed to parse
+  tapes deadletter retry   Reprocess dead letters after a parser fix
+  tapes audit              Show changes tapes has made to credentials, config, and data
+  tapes prompts list       List the system prompt versions agents ran with
+  tapes tokens list        List the run tokens issued to agents
+  tapes backup create      Snapshot the database, even while the daemon runs
 
 	Configuration:
 	  tapes config set <key> <value>    Set a configuration value
   tapes config get <key>            Get a configuration value
-  tapes config list                 List all configuration values`
+  tapes config list                 List all configuration values
+  tapes profile use <name>          Switch to an isolated named profile
+  tapes update                      Update tapes to the latest release`
 
 const tapesShortDesc string = "Tapes - Agent Telemetry"
 
@@ -57,26 +107,57 @@ func NewTapesCmd() *cobra.Command {
 		Use:   "tapes",
 		Short: tapesShortDesc,
 		Long:  tapesLongDesc,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			return profilecmder.Apply(cmd)
+		},
 	}
 
 	// Global flags
 	cmd.PersistentFlags().BoolP("debug", "d", false, "Enable debug logging")
 	cmd.PersistentFlags().String("config-dir", "", "Override path to .tapes/ config directory")
+	cmd.PersistentFlags().String("profile", "", "Use the named profile (default: TAPES_PROFILE or 'tapes profile use')")
 
 	// Add subcommands
 	cmd.AddCommand(synccmder.NewSyncCmd())
+	cmd.AddCommand(analyticscmder.NewAnalyticsCmd())
+	cmd.AddCommand(auditcmder.NewAuditCmd())
+	cmd.AddCommand(backupcmder.NewBackupCmd())
 	cmd.AddCommand(chatcmder.NewChatCmd())
 	cmd.AddCommand(checkoutcmder.NewCheckoutCmd())
 	cmd.AddCommand(configcmder.NewConfigCmd())
+	cmd.AddCommand(costscmder.NewCostsCmd())
+	cmd.AddCommand(crashescmder.NewCrashesCmd())
+	cmd.AddCommand(dbcmder.NewDBCmd())
+	cmd.AddCommand(deadlettercmder.NewDeadLetterCmd())
 	cmd.AddCommand(deckcmder.NewDeckCmd())
+	cmd.AddCommand(diffcmder.NewDiffCmd())
+	cmd.AddCommand(evalcmder.NewEvalCmd())
+	cmd.AddCommand(exportcmder.NewExportCmd())
+	cmd.AddCommand(forkcmder.NewForkCmd())
 	cmd.AddCommand(authcmder.NewAuthCmd())
 	cmd.AddCommand(initcmder.NewInitCmd())
+	cmd.AddCommand(logscmder.NewLogsCmd())
+	cmd.AddCommand(mcpcmder.NewMCPCmd())
+	cmd.AddCommand(pricingcmder.NewPricingCmd())
+	cmd.AddCommand(profilecmder.NewProfileCmd())
+	cmd.AddCommand(promptscmder.NewPromptsCmd())
+	cmd.AddCommand(prunecmder.NewPruneCmd())
+	cmd.AddCommand(sharecmder.NewReceiveCmd())
+	cmd.AddCommand(reportcmder.NewReportCmd())
+	cmd.AddCommand(reprocesscmder.NewReprocessCmd())
 	cmd.AddCommand(searchcmder.NewSearchCmd())
 	cmd.AddCommand(seedcmder.NewSeedCmd())
 	cmd.AddCommand(servecmder.NewServeCmd())
+	cmd.AddCommand(sessionscmder.NewSessionsCmd())
+	cmd.AddCommand(sharecmder.NewShareCmd())
 	cmd.AddCommand(skillcmder.NewSkillCmd())
 	cmd.AddCommand(startcmder.NewStartCmd())
 	cmd.AddCommand(statuscmder.NewStatusCmd())
+	cmd.AddCommand(tailcmder.NewTailCmd())
+	cmd.AddCommand(tokenscmder.NewTokensCmd())
+	cmd.AddCommand(treecmder.NewTreeCmd())
+	cmd.AddCommand(uicmder.NewUICmd())
+	cmd.AddCommand(updatecmder.NewUpdateCmd())
 	cmd.AddCommand(versioncmder.NewVersionCmd())
 
 	return cmd
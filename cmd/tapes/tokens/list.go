@@ -0,0 +1,89 @@
+package tokenscmder
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/api"
+	"github.com/papercomputeco/tapes/pkg/cliui"
+)
+
+type listCommander struct {
+	jsonOutput bool
+}
+
+func newListCmd() *cobra.Command {
+	cmder := &listCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the run tokens issued by the daemon",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd)
+		},
+	}
+
+	cmd.Flags().BoolVar(&cmder.jsonOutput, "json", false, "Print tokens as JSON lines")
+
+	return cmd
+}
+
+func (c *listCommander) run(cmd *cobra.Command) error {
+	w := cmd.OutOrStdout()
+
+	client, err := newDaemonClient(cmd)
+	if err != nil {
+		return err
+	}
+	var list api.RunTokenList
+	if err := client.do(cmd.Context(), http.MethodGet, "/v1/tokens", &list); err != nil {
+		return err
+	}
+
+	if c.jsonOutput {
+		enc := json.NewEncoder(w)
+		for _, info := range list.Tokens {
+			if err := enc.Encode(info); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(list.Tokens) == 0 {
+		fmt.Fprintln(w, "No run tokens.")
+		return nil
+	}
+
+	fmt.Fprintf(w, "\nRun tokens (%d)\n\n", len(list.Tokens))
+	for _, info := range list.Tokens {
+		expires := "never expires"
+		if !info.ExpiresAt.IsZero() {
+			expires = "expires " + info.ExpiresAt.Local().Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "  %s  %s  %s  %s\n",
+			cliui.NameStyle.Render(info.ID),
+			info.Run,
+			cliui.DimStyle.Render("issued "+info.IssuedAt.Local().Format("2006-01-02 15:04:05")),
+			cliui.DimStyle.Render(expires),
+		)
+		fmt.Fprintf(w, "    requests %s  input tokens %s\n\n",
+			usage(info.Requests, info.MaxRequests),
+			usage(info.InputTokens, info.MaxInputTokens),
+		)
+	}
+	return nil
+}
+
+// usage formats what a token used out of its budget, if it has one.
+func usage(used, limit int) string {
+	if limit == 0 {
+		return strconv.Itoa(used)
+	}
+	return fmt.Sprintf("%d/%d", used, limit)
+}
@@ -0,0 +1,67 @@
+package tokenscmder
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/api"
+	"github.com/papercomputeco/tapes/pkg/audit"
+)
+
+type revokeCommander struct {
+	runID string
+}
+
+func newRevokeCmd() *cobra.Command {
+	cmder := &revokeCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "revoke [id]",
+		Short: "Revoke a run token, or every token of a run",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmder.run(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&cmder.runID, "run", "", "Revoke every token of this run")
+
+	return cmd
+}
+
+func (c *revokeCommander) run(cmd *cobra.Command, args []string) error {
+	if (len(args) == 0) == (c.runID == "") {
+		return errors.New("give either a token ID or --run")
+	}
+
+	client, err := newDaemonClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	path := "/v1/runs/" + url.PathEscape(c.runID) + "/token"
+	target := c.runID
+	if len(args) == 1 {
+		path = "/v1/tokens/" + url.PathEscape(args[0])
+		target = args[0]
+	}
+	var revoked api.RunTokenRevokeResponse
+	if err := client.do(cmd.Context(), http.MethodDelete, path, &revoked); err != nil {
+		return err
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	details := map[string]string{"revoked": strconv.Itoa(revoked.Revoked)}
+	if err := audit.Record(configDir, audit.ActionRunTokenRevoke, target, details); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write audit log: %v\n", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Revoked %d run token(s).\n", revoked.Revoked)
+	return nil
+}
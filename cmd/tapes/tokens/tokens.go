@@ -0,0 +1,128 @@
+// Package tokenscmder provides the `tapes tokens` CLI commands for listing
+// and revoking the run tokens the tapes start daemon issued to agents.
+package tokenscmder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/start"
+)
+
+// requestTimeout bounds each request to the daemon.
+const requestTimeout = 5 * time.Second
+
+const tokensLongDesc string = `List and revoke run tokens.
+
+Agents launched by "tapes start" are given a token for their run in place of
+the stored API keys, which the proxy swaps back. Each token can expire and
+carry a budget of requests and input tokens, set with the
+start.run_token_ttl, start.run_token_max_requests, and
+start.run_token_max_input_tokens config keys. The daemon counts what each
+token was used for and revokes a run's tokens when its agent exits.
+
+Revoking a token cuts off its agent's access to the providers at once.
+
+Examples:
+  tapes tokens list
+  tapes tokens revoke 4kq7x2mz
+  tapes tokens revoke --run 20260102-030405-claude`
+
+const tokensShortDesc string = "List and revoke run tokens"
+
+// NewTokensCmd creates the parent tokens command.
+func NewTokensCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tokens",
+		Short: tokensShortDesc,
+		Long:  tokensLongDesc,
+	}
+
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newRevokeCmd())
+
+	return cmd
+}
+
+// daemonClient sends requests to the API of the running tapes start daemon.
+type daemonClient struct {
+	apiURL string
+	token  string
+	client *http.Client
+}
+
+// newDaemonClient finds the running daemon through its state in the config
+// dir selected by the --config-dir flag.
+func newDaemonClient(cmd *cobra.Command) (*daemonClient, error) {
+	configDir, _ := cmd.Flags().GetString("config-dir")
+
+	manager, err := start.NewManager(configDir)
+	if err != nil {
+		return nil, err
+	}
+	state, err := manager.LoadState()
+	if err != nil {
+		return nil, err
+	}
+	if state == nil || state.APIURL == "" || !start.ProcessAlive(state.DaemonPID) {
+		return nil, errors.New("no tapes start daemon is running")
+	}
+
+	cfger, err := config.NewConfiger(configDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	cfg, err := cfger.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	return &daemonClient{
+		apiURL: strings.TrimRight(state.APIURL, "/"),
+		token:  cfg.API.Token,
+		client: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// do sends a request to path and decodes its JSON response into out.
+func (d *daemonClient) do(ctx context.Context, method, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, d.apiURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	if d.token != "" {
+		req.Header.Set("Authorization", "Bearer "+d.token)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("contacting daemon: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr llm.ErrorResponse
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Error != "" {
+			return fmt.Errorf("daemon returned %s: %s", resp.Status, apiErr.Error)
+		}
+		return fmt.Errorf("daemon returned %s", resp.Status)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package tokenscmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTokensCommander(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tokens Commander Suite")
+}
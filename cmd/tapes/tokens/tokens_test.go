@@ -0,0 +1,116 @@
+package tokenscmder
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/api"
+	"github.com/papercomputeco/tapes/pkg/audit"
+	"github.com/papercomputeco/tapes/pkg/runtoken"
+	"github.com/papercomputeco/tapes/pkg/start"
+)
+
+var _ = Describe("tokens command", func() {
+	var (
+		configDir string
+		daemon    *httptest.Server
+		deleted   []string
+	)
+
+	BeforeEach(func() {
+		configDir = GinkgoT().TempDir()
+		deleted = nil
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("GET /v1/tokens", func(w http.ResponseWriter, _ *http.Request) {
+			issued := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+			_ = json.NewEncoder(w).Encode(api.RunTokenList{Tokens: []runtoken.Info{{
+				ID:          "abcd1234",
+				Run:         "run-1",
+				IssuedAt:    issued,
+				ExpiresAt:   issued.Add(time.Hour),
+				MaxRequests: 10,
+				Requests:    3,
+			}}})
+		})
+		mux.HandleFunc("DELETE /v1/tokens/{id}", func(w http.ResponseWriter, r *http.Request) {
+			deleted = append(deleted, r.URL.Path)
+			_ = json.NewEncoder(w).Encode(api.RunTokenRevokeResponse{ID: r.PathValue("id"), Revoked: 1})
+		})
+		mux.HandleFunc("DELETE /v1/runs/{id}/token", func(w http.ResponseWriter, r *http.Request) {
+			deleted = append(deleted, r.URL.Path)
+			_ = json.NewEncoder(w).Encode(api.RunTokenRevokeResponse{Run: r.PathValue("id"), Revoked: 2})
+		})
+		daemon = httptest.NewServer(mux)
+		DeferCleanup(daemon.Close)
+
+		manager, err := start.NewManager(configDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(manager.SaveState(&start.State{DaemonPID: os.Getpid(), APIURL: daemon.URL})).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewTokensCmd()
+		cmd.PersistentFlags().String("config-dir", "", "Override path to .tapes/ config directory")
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+		cmd.SetErr(out)
+		cmd.SetArgs(append(args, "--config-dir", configDir))
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	It("lists the daemon's run tokens with their usage", func() {
+		out, err := run("list")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Run tokens (1)"))
+		Expect(out).To(ContainSubstring("abcd1234"))
+		Expect(out).To(ContainSubstring("run-1"))
+		Expect(out).To(ContainSubstring("requests 3/10"))
+	})
+
+	It("revokes a token by ID and records it in the audit log", func() {
+		out, err := run("revoke", "abcd1234")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Revoked 1 run token(s)."))
+		Expect(deleted).To(Equal([]string{"/v1/tokens/abcd1234"}))
+
+		log, err := audit.Open(configDir)
+		Expect(err).NotTo(HaveOccurred())
+		entries, err := log.Entries(audit.Filter{Action: "run_token"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Action).To(Equal(audit.ActionRunTokenRevoke))
+		Expect(entries[0].Target).To(Equal("abcd1234"))
+	})
+
+	It("revokes every token of a run", func() {
+		out, err := run("revoke", "--run", "run-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Revoked 2 run token(s)."))
+		Expect(deleted).To(Equal([]string{"/v1/runs/run-1/token"}))
+	})
+
+	It("requires exactly one of a token ID and --run", func() {
+		_, err := run("revoke")
+		Expect(err).To(HaveOccurred())
+		_, err = run("revoke", "abcd1234", "--run", "run-1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails without a running daemon", func() {
+		manager, err := start.NewManager(configDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(manager.ClearState()).To(Succeed())
+
+		_, err = run("list")
+		Expect(err).To(MatchError(ContainSubstring("no tapes start daemon is running")))
+	})
+})
@@ -0,0 +1,95 @@
+package treecmder
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+// renderTree writes tree as ASCII art followed by a per-branch summary.
+// Unless expand is set, runs of turns between forks are collapsed into a
+// single line.
+func renderTree(w io.Writer, tree *deck.SessionTree, expand bool) {
+	if tree.Root == nil {
+		fmt.Fprintln(w, "No turns recorded.")
+		return
+	}
+
+	branches := make(map[int]deck.TreeBranch, len(tree.Branches))
+	for _, b := range tree.Branches {
+		branches[b.Number] = b
+	}
+
+	r := &treeRenderer{w: w, expand: expand, branches: branches}
+	r.render(tree.Root, "", "", true)
+
+	fmt.Fprintf(w, "\n%d turns, %d forks, %d branches\n", tree.NodeCount, tree.ForkCount, len(tree.Branches))
+	for _, b := range tree.Branches {
+		origin := "original"
+		if b.ForkHash != "" {
+			origin = fmt.Sprintf("%s at %s", b.ForkKind, cliui.ShortHash(b.ForkHash))
+		}
+		fmt.Fprintf(w, "  branch %d  %s  %d turns  %s  $%.2f  %s  (%s)\n",
+			b.Number,
+			cliui.ShortHash(b.LeafHash),
+			b.Depth,
+			cliui.FormatDuration(b.Summary.Duration),
+			b.Summary.TotalCost,
+			b.Summary.Status,
+			origin,
+		)
+	}
+}
+
+type treeRenderer struct {
+	w        io.Writer
+	expand   bool
+	branches map[int]deck.TreeBranch
+}
+
+// render writes n and its descendants. first prefixes n's own line and rest
+// prefixes every line below it. branchStart reports whether n starts a
+// branch, i.e. it is the root or one of several children.
+func (r *treeRenderer) render(n *deck.TreeNode, first, rest string, branchStart bool) {
+	r.line(first, n)
+
+	// Follow the run of single children down to the next fork or leaf.
+	skipped := 0
+	for len(n.Children) == 1 {
+		child := n.Children[0]
+		if !r.expand && !branchStart && len(child.Children) == 1 {
+			skipped++
+			n = child
+			continue
+		}
+		if skipped > 0 {
+			fmt.Fprintf(r.w, "%s⋮ %d more turns\n", rest, skipped)
+			skipped = 0
+		}
+		r.line(rest, child)
+		n = child
+		branchStart = false
+	}
+
+	for i, child := range n.Children {
+		if i == len(n.Children)-1 {
+			r.render(child, rest+"└─ ", rest+"   ", true)
+		} else {
+			r.render(child, rest+"├─ ", rest+"│  ", true)
+		}
+	}
+}
+
+func (r *treeRenderer) line(prefix string, n *deck.TreeNode) {
+	text := fmt.Sprintf("%s%s %-9s %s", prefix, cliui.ShortHash(n.Hash), n.Role, n.Preview)
+	switch {
+	case n.ForkKind != "":
+		text += fmt.Sprintf("  [%s ×%d]", n.ForkKind, len(n.Children))
+	case n.Branch > 0:
+		b := r.branches[n.Branch]
+		text += fmt.Sprintf("  [branch %d · $%.2f · %s]", b.Number, b.Summary.TotalCost, b.Summary.Status)
+	}
+	fmt.Fprintln(r.w, text)
+}
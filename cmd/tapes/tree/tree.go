@@ -0,0 +1,96 @@
+// Package treecmder provides the tree command for showing where a
+// conversation branched.
+package treecmder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+const treeLongDesc string = `Show the branch structure of a conversation.
+
+Conversations are stored as a Merkle DAG, so retried responses, edited prompts,
+and conversations replayed from a checkout all branch off the turns they share.
+The tree starts at the conversation's root and marks every fork, then lists
+each branch with its turns, cost, and status.
+
+The session may be any turn hash in the conversation, a unique prefix of one,
+or a session ID from tapes deck. Runs of turns without forks are collapsed;
+use --expand to show every turn.
+
+Examples:
+  tapes tree 3f2a9c1
+  tapes tree 3f2a9c1 --expand
+  tapes tree 3f2a9c1 --json`
+
+const treeShortDesc string = "Show where a conversation branched"
+
+type treeCommander struct {
+	sqlitePath string
+	expand     bool
+	jsonOutput bool
+}
+
+func NewTreeCmd() *cobra.Command {
+	cmder := &treeCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "tree <session>",
+		Short: treeShortDesc,
+		Long:  treeLongDesc,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmder.run(cmd.Context(), cmd, args[0])
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().BoolVar(&cmder.expand, "expand", false, "Show every turn instead of collapsing runs without forks")
+	cmd.Flags().BoolVar(&cmder.jsonOutput, "json", false, "Print the tree as JSON")
+
+	return cmd
+}
+
+func (c *treeCommander) run(ctx context.Context, cmd *cobra.Command, session string) error {
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	pricing, err := deck.ResolvePricing(configDir, "")
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	query, closeFn, err := deck.NewReadOnlyQuery(ctx, dbPath, pricing, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = closeFn() }()
+
+	tree, err := query.SessionTree(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if c.jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(tree)
+	}
+
+	renderTree(w, tree, c.expand)
+	return nil
+}
@@ -0,0 +1,13 @@
+package treecmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTree(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Tree Command Suite")
+}
@@ -0,0 +1,99 @@
+package treecmder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("tree command", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+		root   *merkle.Node
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(role, text string, parent *merkle.Node) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:    "message",
+				Role:    role,
+				Content: []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:   "claude-sonnet-4",
+			}, parent)
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+
+		// A long linear conversation with a retried final response.
+		root = put("user", "turn 0", nil)
+		parent := root
+		for i := 1; i < 8; i++ {
+			role := "assistant"
+			if i%2 == 0 {
+				role = "user"
+			}
+			parent = put(role, fmt.Sprintf("turn %d", i), parent)
+		}
+		last := put("user", "one more thing", parent)
+		put("assistant", "first try", last)
+		put("assistant", "second try", last)
+		Expect(driver.Close()).To(Succeed())
+	})
+
+	run := func(args ...string) (string, error) {
+		cmd := NewTreeCmd()
+		buf := &bytes.Buffer{}
+		cmd.SetOut(buf)
+		cmd.SetErr(buf)
+		cmd.SetArgs(append([]string{"--sqlite", dbPath}, args...))
+		err := cmd.ExecuteContext(ctx)
+		return buf.String(), err
+	}
+
+	It("renders forks and collapses linear runs", func() {
+		out, err := run(root.Hash[:10])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring(root.Hash[:12] + " user      turn 0\n"))
+		Expect(out).To(ContainSubstring("⋮ 6 more turns"))
+		Expect(out).To(ContainSubstring("one more thing  [retry ×2]"))
+		Expect(out).To(MatchRegexp(`├─ \w{12} assistant first try  \[branch 1 · \$0\.00 · `))
+		Expect(out).To(MatchRegexp(`└─ \w{12} assistant second try  \[branch 2 · `))
+		Expect(out).To(ContainSubstring("11 turns, 1 forks, 2 branches"))
+		Expect(out).To(MatchRegexp(`branch 2\s+\w{12}\s+10 turns .*\(retry at \w{12}\)`))
+	})
+
+	It("shows every turn with --expand", func() {
+		out, err := run(root.Hash, "--expand")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).NotTo(ContainSubstring("more turns"))
+		Expect(out).To(ContainSubstring("assistant turn 5\n"))
+	})
+
+	It("prints JSON", func() {
+		out, err := run(root.Hash, "--json")
+		Expect(err).NotTo(HaveOccurred())
+
+		var tree deck.SessionTree
+		Expect(json.Unmarshal([]byte(out), &tree)).To(Succeed())
+		Expect(tree.RootHash).To(Equal(root.Hash))
+		Expect(tree.Branches).To(HaveLen(2))
+	})
+})
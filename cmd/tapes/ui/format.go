@@ -0,0 +1,18 @@
+package uicmder
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// topTools returns up to limit tool names, most called first.
+func topTools(frequency map[string]int, limit int) []string {
+	tools := slices.SortedFunc(maps.Keys(frequency), func(a, b string) int {
+		if c := cmp.Compare(frequency[b], frequency[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	return tools[:min(len(tools), limit)]
+}
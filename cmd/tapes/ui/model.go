@@ -0,0 +1,507 @@
+package uicmder
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+// pane identifies which pane receives navigation keys.
+type pane int
+
+const (
+	paneSessions pane = iota
+	paneConversation
+)
+
+const (
+	listWidth    = 36
+	sidebarWidth = 30
+
+	// chromeHeight is the number of lines used by the header and footer.
+	chromeHeight = 2
+)
+
+var (
+	headerStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("252"))
+	dimStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("82")).Bold(true)
+	roleStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
+	toolStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	followStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("82"))
+
+	paneStyle        = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("238"))
+	focusedPaneStyle = paneStyle.BorderForeground(lipgloss.Color("82"))
+)
+
+type overviewLoadedMsg struct {
+	overview *deck.Overview
+	err      error
+}
+
+type sessionLoadedMsg struct {
+	id        string
+	detail    *deck.SessionDetail
+	analytics *deck.SessionAnalytics
+	err       error
+}
+
+type refreshTickMsg time.Time
+
+type uiModel struct {
+	ctx          context.Context
+	query        deck.Querier
+	filters      deck.Filters
+	refreshEvery time.Duration
+
+	overview  *deck.Overview
+	detail    *deck.SessionDetail
+	analytics *deck.SessionAnalytics
+	err       error
+
+	cursor      int
+	selectedID  string
+	focus       pane
+	expandTools bool
+	follow      bool
+
+	width    int
+	height   int
+	viewport viewport.Model
+
+	// markdownStyle is the glamour style used for message text. It is
+	// chosen once up front because detecting it inside the program would
+	// query the terminal while bubbletea owns it.
+	markdownStyle string
+	renderer      *glamour.TermRenderer
+	rendererWidth int
+}
+
+// RunUI starts the session browser over query. Sessions are listed in the
+// order given by filters, which should sort by date for follow mode to tail
+// the most recently active session.
+func RunUI(ctx context.Context, query deck.Querier, filters deck.Filters, refreshEvery time.Duration, follow bool) error {
+	model := newUIModel(ctx, query, filters, refreshEvery)
+	model.follow = follow
+	model.markdownStyle = styles.LightStyle
+	if lipgloss.HasDarkBackground() {
+		model.markdownStyle = styles.DarkStyle
+	}
+
+	program := bubbletea.NewProgram(model,
+		bubbletea.WithContext(ctx),
+		bubbletea.WithAltScreen(),
+	)
+	_, err := program.Run()
+	return err
+}
+
+func newUIModel(ctx context.Context, query deck.Querier, filters deck.Filters, refreshEvery time.Duration) uiModel {
+	return uiModel{
+		ctx:           ctx,
+		query:         query,
+		filters:       filters,
+		refreshEvery:  refreshEvery,
+		viewport:      viewport.New(0, 0),
+		markdownStyle: styles.NoTTYStyle,
+	}
+}
+
+func (m uiModel) Init() bubbletea.Cmd {
+	return bubbletea.Batch(m.loadOverview(), m.tick())
+}
+
+func (m uiModel) Update(msg bubbletea.Msg) (bubbletea.Model, bubbletea.Cmd) {
+	switch msg := msg.(type) {
+	case bubbletea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.resize()
+		return m, nil
+
+	case overviewLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.overview = msg.overview
+		return m, m.syncSelection()
+
+	case sessionLoadedMsg:
+		if msg.id != m.selectedID {
+			// A newer selection is already loading.
+			return m, nil
+		}
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.err = nil
+		m.detail = msg.detail
+		m.analytics = msg.analytics
+		m.renderConversation()
+		if m.follow {
+			m.viewport.GotoBottom()
+		}
+		return m, nil
+
+	case refreshTickMsg:
+		return m, bubbletea.Batch(m.loadOverview(), m.tick())
+
+	case bubbletea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m uiModel) handleKey(msg bubbletea.KeyMsg) (bubbletea.Model, bubbletea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, bubbletea.Quit
+	case "tab":
+		if m.focus == paneSessions {
+			m.focus = paneConversation
+		} else {
+			m.focus = paneSessions
+		}
+		return m, nil
+	case "t":
+		m.expandTools = !m.expandTools
+		m.renderConversation()
+		return m, nil
+	case "f":
+		m.follow = !m.follow
+		if m.follow {
+			return m, m.syncSelection()
+		}
+		return m, nil
+	case "r":
+		return m, m.loadOverview()
+	}
+
+	if m.focus == paneConversation {
+		var cmd bubbletea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		return m.moveCursor(-1)
+	case "down", "j":
+		return m.moveCursor(1)
+	case "enter":
+		m.focus = paneConversation
+		return m, nil
+	}
+	return m, nil
+}
+
+// moveCursor selects another session and leaves follow mode, since the user
+// has picked a session to read.
+func (m uiModel) moveCursor(delta int) (bubbletea.Model, bubbletea.Cmd) {
+	sessions := m.sessions()
+	if len(sessions) == 0 {
+		return m, nil
+	}
+	m.follow = false
+	m.cursor = max(0, min(len(sessions)-1, m.cursor+delta))
+	return m, m.selectSession(sessions[m.cursor].ID)
+}
+
+// syncSelection keeps the selection valid after the session list changes.
+// In follow mode it selects the first session; otherwise it keeps the
+// selected session, reloading it so new turns appear.
+func (m *uiModel) syncSelection() bubbletea.Cmd {
+	sessions := m.sessions()
+	if len(sessions) == 0 {
+		m.cursor = 0
+		m.selectedID = ""
+		m.detail = nil
+		m.analytics = nil
+		m.renderConversation()
+		return nil
+	}
+
+	if !m.follow {
+		for i, session := range sessions {
+			if session.ID == m.selectedID {
+				m.cursor = i
+				return m.loadSession(session.ID)
+			}
+		}
+	}
+	m.cursor = 0
+	return m.selectSession(sessions[0].ID)
+}
+
+func (m *uiModel) selectSession(id string) bubbletea.Cmd {
+	if id != m.selectedID {
+		m.viewport.GotoTop()
+	}
+	m.selectedID = id
+	return m.loadSession(id)
+}
+
+func (m uiModel) sessions() []deck.SessionSummary {
+	if m.overview == nil {
+		return nil
+	}
+	return m.overview.Sessions
+}
+
+func (m uiModel) loadOverview() bubbletea.Cmd {
+	query, filters, ctx := m.query, m.filters, m.ctx
+	return func() bubbletea.Msg {
+		overview, err := query.Overview(ctx, filters)
+		return overviewLoadedMsg{overview: overview, err: err}
+	}
+}
+
+func (m uiModel) loadSession(id string) bubbletea.Cmd {
+	query, ctx := m.query, m.ctx
+	return func() bubbletea.Msg {
+		detail, err := query.SessionDetail(ctx, id)
+		if err != nil {
+			return sessionLoadedMsg{id: id, err: err}
+		}
+		analytics, err := query.SessionAnalytics(ctx, id)
+		if err != nil {
+			return sessionLoadedMsg{id: id, err: err}
+		}
+		return sessionLoadedMsg{id: id, detail: detail, analytics: analytics}
+	}
+}
+
+func (m uiModel) tick() bubbletea.Cmd {
+	if m.refreshEvery <= 0 {
+		return nil
+	}
+	return bubbletea.Tick(m.refreshEvery, func(t time.Time) bubbletea.Msg {
+		return refreshTickMsg(t)
+	})
+}
+
+// resize fits the conversation viewport between the list and the sidebar.
+func (m *uiModel) resize() {
+	m.viewport.Width = max(0, m.conversationWidth()-2)
+	m.viewport.Height = max(0, m.paneHeight()-2)
+	m.renderConversation()
+}
+
+func (m uiModel) conversationWidth() int {
+	return max(20, m.width-listWidth-sidebarWidth)
+}
+
+func (m uiModel) paneHeight() int {
+	return max(3, m.height-chromeHeight)
+}
+
+// renderConversation renders the selected session into the viewport.
+func (m *uiModel) renderConversation() {
+	if m.detail == nil {
+		m.viewport.SetContent(dimStyle.Render("No session selected."))
+		return
+	}
+	m.viewport.SetContent(renderMessages(m.detail.Messages, m.viewport.Width, m.expandTools, m.markdown))
+}
+
+// markdown renders text as markdown wrapped to width, falling back to the
+// plain text when rendering fails.
+func (m *uiModel) markdown(text string, width int) string {
+	if width <= 0 {
+		width = 80
+	}
+	if m.renderer == nil || m.rendererWidth != width {
+		renderer, err := glamour.NewTermRenderer(
+			glamour.WithStandardStyle(m.markdownStyle),
+			glamour.WithWordWrap(width),
+		)
+		if err != nil {
+			return text
+		}
+		m.renderer, m.rendererWidth = renderer, width
+	}
+	rendered, err := m.renderer.Render(text)
+	if err != nil {
+		return text
+	}
+	return strings.Trim(rendered, "\n")
+}
+
+// renderMessages renders each message with a header line, its text as
+// markdown, and its tool calls, either folded into one line or listed.
+func renderMessages(messages []deck.SessionMessage, width int, expandTools bool, markdown func(string, int) string) string {
+	if len(messages) == 0 {
+		return dimStyle.Render("No messages.")
+	}
+
+	var b strings.Builder
+	for i, msg := range messages {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+
+		header := roleStyle.Render(msg.Role)
+		if msg.Model != "" {
+			header += dimStyle.Render(" · " + msg.Model)
+		}
+		header += dimStyle.Render(" · " + msg.Timestamp.Local().Format("15:04:05"))
+		if msg.TotalTokens > 0 {
+			header += dimStyle.Render(fmt.Sprintf(" · %s tokens · %s", cliui.FormatTokens(msg.TotalTokens), cliui.FormatCost(msg.TotalCost)))
+		}
+		b.WriteString(header)
+
+		if text := strings.TrimSpace(msg.Text); text != "" {
+			b.WriteString("\n")
+			b.WriteString(markdown(text, width))
+		}
+
+		if len(msg.ToolCalls) == 0 {
+			continue
+		}
+		b.WriteString("\n")
+		if !expandTools {
+			b.WriteString(toolStyle.Render(fmt.Sprintf("▸ %d tool calls: %s", len(msg.ToolCalls), strings.Join(msg.ToolCalls, ", "))))
+			continue
+		}
+		b.WriteString(toolStyle.Render(fmt.Sprintf("▾ %d tool calls", len(msg.ToolCalls))))
+		for _, tool := range msg.ToolCalls {
+			b.WriteString("\n  " + toolStyle.Render("• "+tool))
+		}
+	}
+	return b.String()
+}
+
+func (m uiModel) View() string {
+	if m.width == 0 {
+		return ""
+	}
+
+	height := m.paneHeight()
+	list := m.paneStyle(paneSessions).Width(listWidth - 2).Height(height - 2).Render(m.viewSessionList(height - 2))
+	conversation := m.paneStyle(paneConversation).Width(m.conversationWidth() - 2).Height(height - 2).Render(m.viewport.View())
+	sidebar := paneStyle.Width(sidebarWidth - 2).Height(height - 2).Render(m.viewSidebar())
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		m.viewHeader(),
+		lipgloss.JoinHorizontal(lipgloss.Top, list, conversation, sidebar),
+		m.viewFooter(),
+	)
+}
+
+func (m uiModel) paneStyle(p pane) lipgloss.Style {
+	if m.focus == p {
+		return focusedPaneStyle
+	}
+	return paneStyle
+}
+
+func (m uiModel) viewHeader() string {
+	header := headerStyle.Render("tapes")
+	if m.overview != nil {
+		header += dimStyle.Render(fmt.Sprintf("  %d sessions · %s · %s tokens",
+			m.overview.TotalSessions, cliui.FormatCost(m.overview.TotalCost), cliui.FormatTokens(m.overview.TotalTokens)))
+	}
+	if m.follow {
+		header += "  " + followStyle.Render("● following")
+	}
+	return header
+}
+
+func (m uiModel) viewFooter() string {
+	if m.err != nil {
+		return errorStyle.Render(ansi.Truncate("error: "+m.err.Error(), m.width, "…"))
+	}
+	tools := "t expand tools"
+	if m.expandTools {
+		tools = "t fold tools"
+	}
+	return dimStyle.Render("↑/↓ move · tab switch pane · " + tools + " · f follow · r refresh · q quit")
+}
+
+func (m uiModel) viewSessionList(height int) string {
+	sessions := m.sessions()
+	if len(sessions) == 0 {
+		return dimStyle.Render("No sessions recorded.")
+	}
+
+	// Each session takes two lines; keep the cursor in view.
+	visible := max(1, height/2)
+	start := max(0, m.cursor-visible+1)
+	end := min(len(sessions), start+visible)
+
+	width := listWidth - 2
+	lines := make([]string, 0, 2*(end-start))
+	for i := start; i < end; i++ {
+		s := sessions[i]
+		label := ansi.Truncate(strings.Join(strings.Fields(s.DisplayLabel()), " "), width-2, "…")
+		meta := fmt.Sprintf("%s · %s · %s", s.StartTime.Local().Format("01-02 15:04"), cliui.FormatCost(s.TotalCost), s.Status)
+		if i == m.cursor {
+			lines = append(lines, selectedStyle.Render("▌ "+label))
+		} else {
+			lines = append(lines, "  "+label)
+		}
+		lines = append(lines, dimStyle.Render("  "+ansi.Truncate(meta, width-2, "…")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m uiModel) viewSidebar() string {
+	if m.detail == nil {
+		return dimStyle.Render("No session selected.")
+	}
+
+	s := m.detail.Summary
+	rows := [][2]string{
+		{"model", s.Model},
+		{"status", s.Status},
+		{"duration", cliui.FormatDuration(s.Duration)},
+		{"input", cliui.FormatTokens(s.InputTokens)},
+		{"output", cliui.FormatTokens(s.OutputTokens)},
+		{"cost", cliui.FormatCost(s.TotalCost)},
+		{"tool calls", strconv.Itoa(s.ToolCalls)},
+	}
+	if a := m.analytics; a != nil {
+		rows = append(rows,
+			[2]string{"prompts", strconv.Itoa(a.UserMessageCount)},
+			[2]string{"responses", strconv.Itoa(a.AssistantMsgCount)},
+			[2]string{"avg response", cliui.FormatDuration(time.Duration(a.AvgResponseTimeNs))},
+			[2]string{"longest pause", cliui.FormatDuration(time.Duration(a.LongestPauseNs))},
+			[2]string{"tool errors", strconv.Itoa(a.ToolErrorCount)},
+			[2]string{"tokens/min", fmt.Sprintf("%.0f", a.TokensPerMinute)},
+		)
+	}
+
+	width := sidebarWidth - 2
+	lines := []string{headerStyle.Render("Session")}
+	for _, row := range rows {
+		value := row[1]
+		if value == "" {
+			value = "-"
+		}
+		lines = append(lines, ansi.Truncate(dimStyle.Render(fmt.Sprintf("%-14s", row[0]))+" "+value, width, "…"))
+	}
+
+	if len(m.detail.ToolFrequency) > 0 {
+		lines = append(lines, "", headerStyle.Render("Tools"))
+		for _, tool := range topTools(m.detail.ToolFrequency, 8) {
+			lines = append(lines, ansi.Truncate(fmt.Sprintf("%-14s %d", tool, m.detail.ToolFrequency[tool]), width, "…"))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,148 @@
+package uicmder
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	bubbletea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/x/ansi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+// fakeQuerier serves fixed sessions and records which details were loaded.
+type fakeQuerier struct {
+	deck.Querier
+
+	sessions []deck.SessionSummary
+	details  map[string]*deck.SessionDetail
+}
+
+func (f *fakeQuerier) Overview(_ context.Context, _ deck.Filters) (*deck.Overview, error) {
+	return &deck.Overview{Sessions: f.sessions, TotalSessions: len(f.sessions)}, nil
+}
+
+func (f *fakeQuerier) SessionDetail(_ context.Context, id string) (*deck.SessionDetail, error) {
+	detail, ok := f.details[id]
+	if !ok {
+		return nil, errors.New("session not found")
+	}
+	return detail, nil
+}
+
+func (f *fakeQuerier) SessionAnalytics(_ context.Context, id string) (*deck.SessionAnalytics, error) {
+	return &deck.SessionAnalytics{SessionID: id, UserMessageCount: 1}, nil
+}
+
+var _ = Describe("session browser", func() {
+	var (
+		query *fakeQuerier
+		model uiModel
+	)
+
+	now := time.Now()
+	detail := func(id, text string, tools ...string) *deck.SessionDetail {
+		return &deck.SessionDetail{
+			Summary: deck.SessionSummary{ID: id, Label: id, Model: "gpt-4o", Status: deck.StatusCompleted},
+			Messages: []deck.SessionMessage{
+				{Role: "user", Text: "what changed?", Timestamp: now},
+				{Role: "assistant", Text: text, ToolCalls: tools, Timestamp: now},
+			},
+			ToolFrequency: map[string]int{},
+		}
+	}
+
+	// send delivers msg and then runs the returned command, delivering its
+	// message too, so loads complete synchronously.
+	send := func(msg bubbletea.Msg) {
+		next, cmd := model.Update(msg)
+		model = next.(uiModel)
+		if cmd != nil {
+			if result := cmd(); result != nil {
+				next, _ = model.Update(result)
+				model = next.(uiModel)
+			}
+		}
+	}
+
+	BeforeEach(func() {
+		query = &fakeQuerier{
+			sessions: []deck.SessionSummary{
+				{ID: "newest", Label: "newest session", StartTime: now},
+				{ID: "older", Label: "older session", StartTime: now.Add(-time.Hour)},
+			},
+			details: map[string]*deck.SessionDetail{
+				"newest": detail("newest", "**Done**", "Bash", "Read"),
+				"older":  detail("older", "Nothing to do"),
+			},
+		}
+		model = newUIModel(context.Background(), query, deck.Filters{}, 0)
+		model.markdownStyle = styles.DarkStyle
+		send(bubbletea.WindowSizeMsg{Width: 140, Height: 30})
+		send(model.loadOverview()())
+	})
+
+	It("selects the first session and renders its conversation", func() {
+		Expect(model.selectedID).To(Equal("newest"))
+		view := ansi.Strip(model.View())
+		Expect(view).To(ContainSubstring("newest session"))
+		Expect(view).To(ContainSubstring("Done"))
+		Expect(view).NotTo(ContainSubstring("**Done**"))
+		Expect(view).To(ContainSubstring("prompts"))
+	})
+
+	It("folds tool calls until expanded", func() {
+		content := ansi.Strip(model.viewport.View())
+		Expect(content).To(ContainSubstring("▸ 2 tool calls: Bash, Read"))
+
+		send(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("t")})
+		content = ansi.Strip(model.viewport.View())
+		Expect(content).To(ContainSubstring("▾ 2 tool calls"))
+		Expect(content).To(ContainSubstring("• Read"))
+	})
+
+	It("moves between sessions in the list", func() {
+		send(bubbletea.KeyMsg{Type: bubbletea.KeyDown})
+		Expect(model.selectedID).To(Equal("older"))
+		Expect(ansi.Strip(model.viewport.View())).To(ContainSubstring("Nothing to do"))
+
+		send(bubbletea.KeyMsg{Type: bubbletea.KeyTab})
+		send(bubbletea.KeyMsg{Type: bubbletea.KeyDown})
+		Expect(model.selectedID).To(Equal("older"))
+	})
+
+	It("follows the most recently active session on refresh", func() {
+		send(bubbletea.KeyMsg{Type: bubbletea.KeyDown})
+		send(bubbletea.KeyMsg{Type: bubbletea.KeyRunes, Runes: []rune("f")})
+		Expect(model.follow).To(BeTrue())
+		Expect(model.selectedID).To(Equal("newest"))
+
+		query.sessions = append([]deck.SessionSummary{{ID: "live", Label: "live session", StartTime: now}}, query.sessions...)
+		query.details["live"] = detail("live", "Working on it")
+		send(model.loadOverview()())
+		Expect(model.selectedID).To(Equal("live"))
+		Expect(ansi.Strip(model.View())).To(ContainSubstring("following"))
+	})
+
+	It("keeps the selection when the list refreshes outside follow mode", func() {
+		send(bubbletea.KeyMsg{Type: bubbletea.KeyDown})
+		query.sessions = append([]deck.SessionSummary{{ID: "live", Label: "live session", StartTime: now}}, query.sessions...)
+		query.details["live"] = detail("live", "Working on it")
+		send(model.loadOverview()())
+		Expect(model.selectedID).To(Equal("older"))
+		Expect(model.cursor).To(Equal(2))
+	})
+
+	It("shows load errors in the footer", func() {
+		send(model.loadSession("missing")())
+		Expect(ansi.Strip(model.View())).NotTo(ContainSubstring("session not found"))
+
+		model.selectedID = "missing"
+		send(model.loadSession("missing")())
+		Expect(ansi.Strip(model.View())).To(ContainSubstring("error: session not found"))
+	})
+})
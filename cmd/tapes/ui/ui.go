@@ -0,0 +1,105 @@
+// Package uicmder provides the ui command, an interactive terminal browser
+// for recorded sessions.
+package uicmder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/papercomputeco/tapes/cmd/tapes/sqlitepath"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+const uiLongDesc string = `Browse recorded sessions in an interactive terminal UI.
+
+The session list is on the left, the selected conversation in the middle, and
+its analytics on the right. Messages are rendered as markdown, and each turn's
+tool calls are folded into a single line until expanded.
+
+Follow mode tails the most recently active session: the UI refreshes on an
+interval, switches to whichever session received the latest turn, and keeps
+the conversation scrolled to the bottom.
+
+Keys:
+  ↑/↓ j/k    Move through sessions, or scroll the conversation
+  tab        Switch focus between the session list and conversation
+  enter      Open the selected session
+  t          Fold or expand tool calls
+  f          Follow the most recently active session
+  r          Refresh now
+  q          Quit
+
+Examples:
+  tapes ui
+  tapes ui --since 7d
+  tapes ui --follow --refresh 2`
+
+const uiShortDesc string = "Browse sessions in a terminal UI"
+
+type uiCommander struct {
+	sqlitePath  string
+	pricingPath string
+	since       string
+	refresh     uint
+	follow      bool
+}
+
+func NewUICmd() *cobra.Command {
+	cmder := &uiCommander{}
+
+	cmd := &cobra.Command{
+		Use:   "ui",
+		Short: uiShortDesc,
+		Long:  uiLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmder.run(cmd.Context(), cmd)
+		},
+	}
+
+	cmd.Flags().StringVarP(&cmder.sqlitePath, "sqlite", "s", "", "Path to SQLite database")
+	cmd.Flags().StringVar(&cmder.pricingPath, "pricing", "", "Path to pricing TOML or JSON overrides")
+	cmd.Flags().StringVar(&cmder.since, "since", "", "Only sessions active within this age (e.g. 24h, 7d)")
+	cmd.Flags().UintVar(&cmder.refresh, "refresh", 5, "Refresh interval in seconds (0 to disable)")
+	cmd.Flags().BoolVar(&cmder.follow, "follow", false, "Start in follow mode, tailing the most recently active session")
+
+	return cmd
+}
+
+func (c *uiCommander) run(ctx context.Context, cmd *cobra.Command) error {
+	filters := deck.Filters{Sort: "date", SortDir: "desc"}
+	if c.since != "" {
+		since, err := utils.ParseDuration(c.since)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		filters.Since = since
+	}
+
+	configDir, _ := cmd.Flags().GetString("config-dir")
+	pricing, err := deck.ResolvePricing(configDir, c.pricingPath)
+	if err != nil {
+		return err
+	}
+
+	dbPath, err := sqlitepath.ResolveSQLitePath(c.sqlitePath)
+	if err != nil {
+		return err
+	}
+
+	driverOpts, err := sqlitepath.DriverOptions(cmd)
+	if err != nil {
+		return err
+	}
+	query, closeFn, err := deck.NewReadOnlyQuery(ctx, dbPath, pricing, driverOpts...)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() { _ = closeFn() }()
+
+	return RunUI(ctx, query, filters, time.Duration(c.refresh)*time.Second, c.follow)
+}
@@ -0,0 +1,162 @@
+// Package updatecmder provides the update command, which replaces a
+// standalone tapes binary with the latest release.
+package updatecmder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/update"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+const updateLongDesc string = `Update tapes to the latest release.
+
+Checks the latest GitHub release and, when it is newer than the running
+version, downloads the binary for this platform. The binary is installed only
+if its checksum signature verifies against the release signing key built into
+tapes and the binary matches the checksum. It then replaces the running binary
+atomically and upgrades the database schema with 'tapes db migrate'.
+
+Installs managed by Homebrew are left alone; use 'brew upgrade tapes' instead.
+
+Examples:
+  tapes update
+  tapes update --check
+  tapes update --version v0.4.0`
+
+const updateShortDesc string = "Update tapes to the latest release"
+
+type updateCommander struct {
+	check     bool
+	version   string
+	configDir string
+
+	current    string
+	newUpdater func() (*update.Updater, error)
+	executable func() (string, error)
+	migrate    func(ctx context.Context, exe string, out io.Writer) error
+}
+
+func NewUpdateCmd() *cobra.Command {
+	cmder := &updateCommander{
+		current:    utils.Version,
+		newUpdater: update.NewUpdater,
+		executable: executablePath,
+	}
+	cmder.migrate = cmder.runMigrate
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: updateShortDesc,
+		Long:  updateLongDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmder.configDir, _ = cmd.Flags().GetString("config-dir")
+			return cmder.run(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().BoolVar(&cmder.check, "check", false, "Only report whether an update is available")
+	cmd.Flags().StringVar(&cmder.version, "version", "", "Install this release tag instead of the latest")
+
+	return cmd
+}
+
+func (c *updateCommander) run(ctx context.Context, w io.Writer) error {
+	if c.version != "" && !semver.IsValid(c.version) {
+		return fmt.Errorf("invalid release tag %q (expected a version such as v0.4.0)", c.version)
+	}
+
+	exe, err := c.executable()
+	if err != nil {
+		return err
+	}
+	if isHomebrew(exe) {
+		return errors.New("tapes was installed with Homebrew; run 'brew upgrade tapes' instead")
+	}
+
+	updater, err := c.newUpdater()
+	if err != nil {
+		return err
+	}
+
+	tag := c.version
+	if tag == "" {
+		tag, err = updater.Latest(ctx)
+		if err != nil {
+			return err
+		}
+		if !semver.IsValid(c.current) {
+			return fmt.Errorf("tapes %s is not a release build; pass --version %s to install the latest release", c.current, tag)
+		}
+		if !update.Newer(c.current, tag) {
+			fmt.Fprintf(w, "%s tapes %s is up to date.\n", cliui.SuccessMark, c.current)
+			return nil
+		}
+	}
+
+	if c.check {
+		fmt.Fprintf(w, "Update available: %s → %s\n", c.current, tag)
+		return nil
+	}
+
+	fmt.Fprintf(w, "Downloading tapes %s ...\n", tag)
+	binary, err := updater.Download(ctx, tag)
+	if err != nil {
+		return err
+	}
+	if err := update.Replace(exe, binary); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%s Installed tapes %s to %s\n", cliui.SuccessMark, tag, exe)
+
+	if err := c.migrate(ctx, exe, w); err != nil {
+		return fmt.Errorf("tapes %s is installed but migration failed: %w", tag, err)
+	}
+	return nil
+}
+
+// runMigrate runs the migration of the newly installed binary, since only it
+// knows the schema it expects.
+func (c *updateCommander) runMigrate(ctx context.Context, exe string, out io.Writer) error {
+	args := []string{"db", "migrate"}
+	if c.configDir != "" {
+		args = append(args, "--config-dir", c.configDir)
+	}
+
+	// #nosec G204 -- exe is the tapes binary that was just installed.
+	cmd := exec.CommandContext(ctx, exe, args...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.Env = os.Environ()
+	return cmd.Run()
+}
+
+// executablePath returns the resolved path of the running binary.
+func executablePath() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("finding tapes binary: %w", err)
+	}
+	resolved, err := filepath.EvalSymlinks(exe)
+	if err != nil {
+		return "", fmt.Errorf("finding tapes binary: %w", err)
+	}
+	return resolved, nil
+}
+
+// isHomebrew reports whether exe lives in a Homebrew Cellar.
+func isHomebrew(exe string) bool {
+	return strings.Contains(filepath.ToSlash(exe), "/Cellar/")
+}
@@ -0,0 +1,13 @@
+package updatecmder
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestUpdate(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Update Command Suite")
+}
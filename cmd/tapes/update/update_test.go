@@ -0,0 +1,126 @@
+package updatecmder
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/update"
+)
+
+var _ = Describe("update command", func() {
+	const latest = "v0.4.0"
+
+	var (
+		ctx      context.Context
+		exe      string
+		out      *bytes.Buffer
+		migrated bool
+		cmder    *updateCommander
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		out = &bytes.Buffer{}
+		migrated = false
+
+		exe = filepath.Join(GinkgoT().TempDir(), "tapes")
+		Expect(os.WriteFile(exe, []byte("old binary"), 0o755)).To(Succeed())
+
+		pub, priv, err := ed25519.GenerateKey(nil)
+		Expect(err).NotTo(HaveOccurred())
+		binary := []byte("new binary")
+		digest := sha256.Sum256(binary)
+		sums := []byte(hex.EncodeToString(digest[:]) + "  tapes-" + latest + "-" + runtime.GOOS + "-" + runtime.GOARCH + "\n")
+		asset := "/download/" + latest + "/tapes-" + runtime.GOOS + "-" + runtime.GOARCH
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/latest", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"tag_name":"` + latest + `"}`))
+		})
+		mux.HandleFunc(asset, func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(binary)
+		})
+		mux.HandleFunc(asset+".sha256", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(sums)
+		})
+		mux.HandleFunc(asset+".sha256.sig", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, sums))))
+		})
+		server := httptest.NewServer(mux)
+		DeferCleanup(server.Close)
+
+		cmder = &updateCommander{
+			current: "v0.3.0",
+			newUpdater: func() (*update.Updater, error) {
+				return &update.Updater{
+					APIURL:      server.URL + "/latest",
+					DownloadURL: server.URL + "/download",
+					PublicKey:   pub,
+					GOOS:        runtime.GOOS,
+					GOARCH:      runtime.GOARCH,
+				}, nil
+			},
+			executable: func() (string, error) { return exe, nil },
+			migrate: func(_ context.Context, path string, _ io.Writer) error {
+				Expect(path).To(Equal(exe))
+				migrated = true
+				return nil
+			},
+		}
+	})
+
+	readExe := func() string {
+		data, err := os.ReadFile(exe)
+		Expect(err).NotTo(HaveOccurred())
+		return string(data)
+	}
+
+	It("installs a newer release and migrates", func() {
+		Expect(cmder.run(ctx, out)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("Installed tapes " + latest))
+		Expect(readExe()).To(Equal("new binary"))
+		Expect(migrated).To(BeTrue())
+	})
+
+	It("only reports the update with --check", func() {
+		cmder.check = true
+		Expect(cmder.run(ctx, out)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("Update available: v0.3.0 → " + latest))
+		Expect(readExe()).To(Equal("old binary"))
+		Expect(migrated).To(BeFalse())
+	})
+
+	It("does nothing when already up to date", func() {
+		cmder.current = latest
+		Expect(cmder.run(ctx, out)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("is up to date"))
+		Expect(readExe()).To(Equal("old binary"))
+	})
+
+	It("refuses to replace development builds without --version", func() {
+		cmder.current = "dev"
+		Expect(cmder.run(ctx, out)).To(MatchError(ContainSubstring("not a release build")))
+
+		cmder.version = latest
+		Expect(cmder.run(ctx, out)).To(Succeed())
+		Expect(readExe()).To(Equal("new binary"))
+	})
+
+	It("leaves Homebrew installs alone", func() {
+		cmder.executable = func() (string, error) { return "/opt/homebrew/Cellar/tapes/0.3.0/bin/tapes", nil }
+		Expect(cmder.run(ctx, out)).To(MatchError(ContainSubstring("brew upgrade tapes")))
+	})
+})
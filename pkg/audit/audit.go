@@ -0,0 +1,210 @@
+// Package audit records the changes tapes makes to the user's files and data.
+//
+// Entries are appended as JSON lines to audit.log in the .tapes/ directory.
+// The log is append-only: tapes never rewrites or truncates it, so it remains
+// a record of every credential change, auth file patch, config change, and
+// deletion tapes has made, whether from the CLI or the background daemon.
+// Secret values are never written to the log.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/dotdir"
+)
+
+const logFile = "audit.log"
+
+// Actions recorded in the audit log. Related actions share a prefix, so a
+// filter of "credential" matches both credential actions.
+const (
+	ActionCredentialSet      = "credential.set"
+	ActionCredentialRemove   = "credential.remove"
+	ActionAuthFilePatch      = "auth_file.patch"
+	ActionAuthFileRestore    = "auth_file.restore"
+	ActionAgentConfigPatch   = "agent_config.patch"
+	ActionAgentConfigRestore = "agent_config.restore"
+	ActionConfigSet          = "config.set"
+	ActionPrune              = "prune"
+	ActionGC                 = "prune.gc"
+	ActionDeadLetterDelete   = "dead_letter.delete"
+	ActionBackupRestore      = "backup.restore"
+	ActionShareReceive       = "share.receive"
+	ActionRunTokenIssue      = "run_token.issue"
+	ActionRunTokenRevoke     = "run_token.revoke"
+)
+
+// Entry is a single audit record.
+type Entry struct {
+	// Time is when the action completed.
+	Time time.Time `json:"time"`
+
+	// Actor is the operating system user that ran tapes.
+	Actor string `json:"actor"`
+
+	// PID is the process that performed the action.
+	PID int `json:"pid"`
+
+	// Action is one of the Action* constants.
+	Action string `json:"action"`
+
+	// Target is what the action changed: a provider, a file path, a config
+	// key, or a database path.
+	Target string `json:"target,omitempty"`
+
+	// Details holds action-specific context such as counts or old and new
+	// config values.
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Filter selects entries from the log. Zero values match everything.
+type Filter struct {
+	// Action matches entries whose action equals it or starts with it
+	// followed by a ".".
+	Action string
+
+	// Since excludes entries before this time.
+	Since time.Time
+
+	// Limit keeps only the most recent matching entries.
+	Limit int
+}
+
+// Match reports whether entry passes the filter's action and time criteria.
+func (f Filter) Match(entry Entry) bool {
+	if f.Action != "" && entry.Action != f.Action && !strings.HasPrefix(entry.Action, f.Action+".") {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+// Log appends to and reads from an audit log file.
+type Log struct {
+	path string
+}
+
+// Open returns the audit log in the .tapes/ directory. If override is
+// non-empty it is used as the .tapes/ directory; otherwise the standard
+// dotdir resolution applies, falling back to ~/.tapes/.
+func Open(override string) (*Log, error) {
+	target, err := dotdir.NewManager().Target(override)
+	if err != nil {
+		return nil, err
+	}
+
+	if target == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("resolving home dir: %w", err)
+		}
+		target = filepath.Join(home, ".tapes")
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return nil, fmt.Errorf("creating tapes dir: %w", err)
+		}
+	}
+
+	return &Log{path: filepath.Join(target, logFile)}, nil
+}
+
+// Path returns the audit log file path.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Record appends an entry for action to the log, stamped with the current
+// time, user, and process.
+func (l *Log) Record(action, target string, details map[string]string) error {
+	entry := Entry{
+		Time:    time.Now().UTC(),
+		Actor:   currentUser(),
+		PID:     os.Getpid(),
+		Action:  action,
+		Target:  target,
+		Details: details,
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encoding audit entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	defer f.Close()
+
+	// A single O_APPEND write keeps lines from the CLI and the daemon from
+	// interleaving.
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("writing audit log: %w", err)
+	}
+	return nil
+}
+
+// Entries returns the entries matching filter, oldest first. A missing log
+// returns no entries. Lines that cannot be parsed are skipped.
+func (l *Log) Entries(filter Filter) ([]Entry, error) {
+	f, err := os.Open(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if filter.Match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading audit log: %w", err)
+	}
+
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+	return entries, nil
+}
+
+// Record opens the audit log for the .tapes/ directory override and appends
+// an entry to it.
+func Record(override, action, target string, details map[string]string) error {
+	log, err := Open(override)
+	if err != nil {
+		return err
+	}
+	return log.Record(action, target, details)
+}
+
+// currentUser returns the name of the user running tapes.
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
@@ -0,0 +1,93 @@
+package audit_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/audit"
+)
+
+var _ = Describe("Log", func() {
+	var (
+		tmpDir string
+		log    *audit.Log
+	)
+
+	BeforeEach(func() {
+		tmpDir = GinkgoT().TempDir()
+
+		var err error
+		log, err = audit.Open(tmpDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(log.Path()).To(Equal(filepath.Join(tmpDir, "audit.log")))
+	})
+
+	It("returns no entries before anything is recorded", func() {
+		entries, err := log.Entries(audit.Filter{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("appends entries stamped with the time, user, and process", func() {
+		before := time.Now()
+		Expect(log.Record(audit.ActionCredentialSet, "openai", map[string]string{"env_var": "OPENAI_API_KEY"})).To(Succeed())
+		Expect(audit.Record(tmpDir, audit.ActionConfigSet, "proxy.provider", nil)).To(Succeed())
+
+		entries, err := log.Entries(audit.Filter{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+
+		Expect(entries[0].Action).To(Equal(audit.ActionCredentialSet))
+		Expect(entries[0].Target).To(Equal("openai"))
+		Expect(entries[0].Details).To(HaveKeyWithValue("env_var", "OPENAI_API_KEY"))
+		Expect(entries[0].Actor).NotTo(BeEmpty())
+		Expect(entries[0].PID).To(Equal(os.Getpid()))
+		Expect(entries[0].Time).To(BeTemporally(">=", before.Add(-time.Second)))
+		Expect(entries[1].Action).To(Equal(audit.ActionConfigSet))
+
+		info, err := os.Stat(log.Path())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+	})
+
+	It("filters by action group and keeps the most recent entries", func() {
+		Expect(log.Record(audit.ActionCredentialSet, "openai", nil)).To(Succeed())
+		Expect(log.Record(audit.ActionPrune, "", nil)).To(Succeed())
+		Expect(log.Record(audit.ActionCredentialRemove, "openai", nil)).To(Succeed())
+		Expect(log.Record(audit.ActionCredentialSet, "anthropic", nil)).To(Succeed())
+
+		entries, err := log.Entries(audit.Filter{Action: "credential"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(3))
+
+		entries, err = log.Entries(audit.Filter{Action: "credential", Limit: 2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].Action).To(Equal(audit.ActionCredentialRemove))
+		Expect(entries[1].Target).To(Equal("anthropic"))
+
+		// A group name only matches at a "." boundary.
+		entries, err = log.Entries(audit.Filter{Action: "cred"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	It("filters by time and skips malformed lines", func() {
+		Expect(os.WriteFile(log.Path(), []byte(
+			`{"time":"2020-01-01T00:00:00Z","action":"prune"}`+"\n"+"not json\n",
+		), 0o600)).To(Succeed())
+		Expect(log.Record(audit.ActionPrune, "", nil)).To(Succeed())
+
+		entries, err := log.Entries(audit.Filter{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+
+		entries, err = log.Entries(audit.Filter{Since: time.Now().Add(-time.Hour)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+})
@@ -24,10 +24,10 @@ type Backfiller struct {
 	options Options
 }
 
-// NewBackfiller creates a Backfiller connected to the given SQLite database.
-// The returned cleanup function closes the database.
-func NewBackfiller(ctx context.Context, dbPath string, opts Options) (*Backfiller, func() error, error) {
-	driver, err := sqlite.NewDriver(ctx, dbPath)
+// NewBackfiller creates a Backfiller connected to the given SQLite database,
+// opened with driverOpts. The returned cleanup function closes the database.
+func NewBackfiller(ctx context.Context, dbPath string, opts Options, driverOpts ...sqlite.Option) (*Backfiller, func() error, error) {
+	driver, err := sqlite.NewDriver(ctx, dbPath, driverOpts...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -74,11 +74,12 @@ func (b *Backfiller) Run(ctx context.Context, transcriptDir string) (*Result, er
 func (b *Backfiller) matchAndUpdate(ctx context.Context, entries []TranscriptEntry) (*Result, error) {
 	result := &Result{}
 
-	// Query all assistant nodes where token fields are NULL.
+	// Query all assistant nodes where token fields are NULL or were only
+	// estimated by the proxy.
 	candidates, err := b.driver.Client.Node.Query().
 		Where(
 			node.RoleEQ("assistant"),
-			node.PromptTokensIsNil(),
+			node.Or(node.PromptTokensIsNil(), node.UsageEstimatedEQ(true)),
 		).
 		All(ctx)
 	if err != nil {
@@ -208,6 +208,39 @@ var _ = Describe("Backfiller", func() {
 		Expect(*updated.CacheReadInputTokens).To(Equal(500))
 	})
 
+	It("replaces usage the proxy only estimated", func() {
+		dbPath := filepath.Join(GinkgoT().TempDir(), "test.db")
+		sharedDriver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer sharedDriver.Close()
+
+		ts := time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+
+		bucket := makeAssistantBucket("Hello from Claude!", "claude-sonnet-4-5-20250929")
+		node := merkle.NewNode(bucket, nil, merkle.NodeMeta{
+			Usage: &llm.Usage{PromptTokens: 90, CompletionTokens: 6, TotalTokens: 96, Estimated: true},
+		})
+		_, err = sharedDriver.Put(ctx, node)
+		Expect(err).NotTo(HaveOccurred())
+
+		jsonl := fmt.Sprintf(`{"type":"assistant","uuid":"a1","timestamp":"%s","sessionId":"s1","message":{"id":"msg_001","role":"assistant","model":"claude-sonnet-4-5-20250929","content":[{"type":"text","text":"Hello from Claude!"}],"stop_reason":"end_turn","usage":{"input_tokens":100,"output_tokens":50}}}`, ts)
+		writeJSONL(tmpDir, "test.jsonl", jsonl)
+
+		b, cleanup, err := backfill.NewBackfiller(ctx, dbPath, backfill.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		defer cleanup()
+
+		result, err := b.Run(ctx, tmpDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Matched).To(Equal(1))
+
+		updated, err := sharedDriver.Get(ctx, node.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Usage.PromptTokens).To(Equal(100))
+		Expect(updated.Usage.CompletionTokens).To(Equal(50))
+		Expect(updated.Usage.Estimated).To(BeFalse())
+	})
+
 	It("sets PromptTokens equal to input_tokens when no cache tokens present", func() {
 		dbPath := filepath.Join(GinkgoT().TempDir(), "test.db")
 		sharedDriver, err := sqlite.NewDriver(ctx, dbPath)
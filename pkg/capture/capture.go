@@ -0,0 +1,176 @@
+// Package capture decides which proxied turns are recorded, from ordered
+// include and exclude rules matched against a turn's path, provider, agent,
+// model, and system prompt.
+//
+// The first rule that matches a turn decides it. Turns no rule matches are
+// recorded, unless there are include rules, in which case only included
+// turns are recorded.
+package capture
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"sync"
+)
+
+// Rule actions.
+const (
+	ActionExclude = "exclude"
+	ActionInclude = "include"
+)
+
+// NotIncluded is the name skipped turns are counted under when include
+// rules are configured and none of them matched.
+const NotIncluded = "not_included"
+
+// ruleNamePattern constrains rule names, which key the skip counts.
+var ruleNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// RuleSpec selects which turns are stored, as listed under [[capture.rules]].
+// Each set field is a regular expression the turn's value must match; a rule
+// with several set fields matches only turns matching all of them.
+type RuleSpec struct {
+	Name string `toml:"name"`
+
+	// Action is ActionExclude (the default) or ActionInclude.
+	Action string `toml:"action,omitempty"`
+
+	Path         string `toml:"path,omitempty"`
+	Provider     string `toml:"provider,omitempty"`
+	Agent        string `toml:"agent,omitempty"`
+	Model        string `toml:"model,omitempty"`
+	SystemPrompt string `toml:"system_prompt,omitempty"`
+}
+
+// Turn is what rules are matched against.
+type Turn struct {
+	Path         string
+	Provider     string
+	Agent        string
+	Model        string
+	SystemPrompt string
+}
+
+// rule is a compiled RuleSpec. Nil patterns match anything.
+type rule struct {
+	name         string
+	include      bool
+	path         *regexp.Regexp
+	provider     *regexp.Regexp
+	agent        *regexp.Regexp
+	model        *regexp.Regexp
+	systemPrompt *regexp.Regexp
+}
+
+func (r rule) match(turn Turn) bool {
+	return matches(r.path, turn.Path) &&
+		matches(r.provider, turn.Provider) &&
+		matches(r.agent, turn.Agent) &&
+		matches(r.model, turn.Model) &&
+		matches(r.systemPrompt, turn.SystemPrompt)
+}
+
+func matches(re *regexp.Regexp, s string) bool {
+	return re == nil || re.MatchString(s)
+}
+
+// Rules decides which turns are recorded and counts the turns it skips per
+// rule. It is safe for concurrent use.
+type Rules struct {
+	rules       []rule
+	hasIncludes bool
+
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+// NewRules compiles rule specs, in the order they are evaluated.
+func NewRules(specs []RuleSpec) (*Rules, error) {
+	r := &Rules{counts: make(map[string]uint64)}
+	for _, spec := range specs {
+		compiled, err := compileRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		r.rules = append(r.rules, compiled)
+		r.hasIncludes = r.hasIncludes || compiled.include
+	}
+	return r, nil
+}
+
+func compileRule(spec RuleSpec) (rule, error) {
+	if !ruleNamePattern.MatchString(spec.Name) {
+		return rule{}, fmt.Errorf("invalid capture rule name %q: use lowercase letters, digits, and underscores", spec.Name)
+	}
+
+	compiled := rule{name: spec.Name}
+	switch spec.Action {
+	case "", ActionExclude:
+	case ActionInclude:
+		compiled.include = true
+	default:
+		return rule{}, fmt.Errorf("invalid action %q for capture rule %q (expected %s or %s)", spec.Action, spec.Name, ActionExclude, ActionInclude)
+	}
+
+	var err error
+	for _, field := range []struct {
+		name    string
+		pattern string
+		dst     **regexp.Regexp
+	}{
+		{"path", spec.Path, &compiled.path},
+		{"provider", spec.Provider, &compiled.provider},
+		{"agent", spec.Agent, &compiled.agent},
+		{"model", spec.Model, &compiled.model},
+		{"system_prompt", spec.SystemPrompt, &compiled.systemPrompt},
+	} {
+		if field.pattern == "" {
+			continue
+		}
+		if *field.dst, err = regexp.Compile(field.pattern); err != nil {
+			return rule{}, fmt.Errorf("invalid %s pattern for capture rule %q: %w", field.name, spec.Name, err)
+		}
+	}
+	if spec.Path == "" && spec.Provider == "" && spec.Agent == "" && spec.Model == "" && spec.SystemPrompt == "" {
+		return rule{}, fmt.Errorf("capture rule %q sets no pattern: set at least one of path, provider, agent, model, or system_prompt", spec.Name)
+	}
+	return compiled, nil
+}
+
+// Record reports whether turn is recorded. Skipped turns are counted under
+// the name of the rule that excluded them, or NotIncluded.
+func (r *Rules) Record(turn Turn) bool {
+	if r == nil {
+		return true
+	}
+
+	for _, rule := range r.rules {
+		if !rule.match(turn) {
+			continue
+		}
+		if !rule.include {
+			r.count(rule.name)
+		}
+		return rule.include
+	}
+
+	if r.hasIncludes {
+		r.count(NotIncluded)
+		return false
+	}
+	return true
+}
+
+func (r *Rules) count(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts[name]++
+}
+
+// Counts returns a snapshot of the number of turns skipped per rule.
+func (r *Rules) Counts() map[string]uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return maps.Clone(r.counts)
+}
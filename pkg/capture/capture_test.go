@@ -0,0 +1,73 @@
+package capture_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/capture"
+)
+
+var _ = Describe("Rules", func() {
+	It("records every turn without rules", func() {
+		rules, err := capture.NewRules(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules.Record(capture.Turn{Path: "/v1/messages"})).To(BeTrue())
+		Expect(rules.Counts()).To(BeEmpty())
+	})
+
+	It("skips turns matching an exclude rule and counts them per rule", func() {
+		rules, err := capture.NewRules([]capture.RuleSpec{
+			{Name: "embeddings", Path: `/embeddings$`},
+			{Name: "secret_project", SystemPrompt: `(?i)project: classified`},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(rules.Record(capture.Turn{Path: "/v1/embeddings"})).To(BeFalse())
+		Expect(rules.Record(capture.Turn{Path: "/v1/messages", SystemPrompt: "Project: Classified"})).To(BeFalse())
+		Expect(rules.Record(capture.Turn{Path: "/v1/embeddings"})).To(BeFalse())
+		Expect(rules.Record(capture.Turn{Path: "/v1/messages", SystemPrompt: "You are helpful."})).To(BeTrue())
+
+		Expect(rules.Counts()).To(Equal(map[string]uint64{"embeddings": 2, "secret_project": 1}))
+	})
+
+	It("requires every set pattern of a rule to match", func() {
+		rules, err := capture.NewRules([]capture.RuleSpec{
+			{Name: "codex_minis", Agent: `^codex$`, Model: `mini`},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(rules.Record(capture.Turn{Agent: "codex", Model: "gpt-4o-mini"})).To(BeFalse())
+		Expect(rules.Record(capture.Turn{Agent: "claude", Model: "gpt-4o-mini"})).To(BeTrue())
+		Expect(rules.Record(capture.Turn{Agent: "codex", Model: "gpt-4o"})).To(BeTrue())
+	})
+
+	It("records only included turns once there are include rules, with the first match deciding", func() {
+		rules, err := capture.NewRules([]capture.RuleSpec{
+			{Name: "no_haiku", Model: `haiku`},
+			{Name: "anthropic", Action: capture.ActionInclude, Provider: `^anthropic$`},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(rules.Record(capture.Turn{Provider: "anthropic", Model: "claude-sonnet-4"})).To(BeTrue())
+		Expect(rules.Record(capture.Turn{Provider: "anthropic", Model: "claude-haiku-4-5"})).To(BeFalse())
+		Expect(rules.Record(capture.Turn{Provider: "openai", Model: "gpt-4o"})).To(BeFalse())
+
+		Expect(rules.Counts()).To(Equal(map[string]uint64{"no_haiku": 1, capture.NotIncluded: 1}))
+	})
+
+	It("records every turn when nil", func() {
+		var rules *capture.Rules
+		Expect(rules.Record(capture.Turn{})).To(BeTrue())
+	})
+
+	DescribeTable("rejects invalid rules",
+		func(spec capture.RuleSpec, message string) {
+			_, err := capture.NewRules([]capture.RuleSpec{spec})
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("bad name", capture.RuleSpec{Name: "Bad Name", Path: "x"}, "invalid capture rule name"),
+		Entry("bad action", capture.RuleSpec{Name: "r", Action: "drop", Path: "x"}, "invalid action"),
+		Entry("bad pattern", capture.RuleSpec{Name: "r", Model: "("}, "invalid model pattern"),
+		Entry("no pattern", capture.RuleSpec{Name: "r"}, "sets no pattern"),
+	)
+})
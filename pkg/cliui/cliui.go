@@ -87,14 +87,6 @@ func Mark(err error) string {
 	return SuccessMark
 }
 
-// FormatDuration formats a duration for display (e.g. "12ms" or "3.2s").
-func FormatDuration(d time.Duration) string {
-	if d < time.Second {
-		return fmt.Sprintf("%dms", d.Milliseconds())
-	}
-	return fmt.Sprintf("%.1fs", d.Seconds())
-}
-
 // RenderMarkdown renders markdown content for terminal display using glamour.
 func RenderMarkdown(content string) (string, error) {
 	r, err := glamour.NewTermRenderer(
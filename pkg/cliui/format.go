@@ -0,0 +1,50 @@
+package cliui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ShortHashLen is the number of characters ShortHash keeps of a hash.
+const ShortHashLen = 12
+
+// ShortHash abbreviates a node hash for display.
+func ShortHash(hash string) string {
+	if len(hash) > ShortHashLen {
+		return hash[:ShortHashLen]
+	}
+	return hash
+}
+
+// FormatCost formats a USD cost for display (e.g. "$1.25").
+func FormatCost(value float64) string {
+	return fmt.Sprintf("$%.2f", value)
+}
+
+// FormatTokens formats a token count for display (e.g. "950", "1.2K" or
+// "3.4M").
+func FormatTokens(value int64) string {
+	if value >= 1_000_000 {
+		return fmt.Sprintf("%.1fM", float64(value)/1_000_000.0)
+	}
+	if value >= 1_000 {
+		return fmt.Sprintf("%.1fK", float64(value)/1_000.0)
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+// FormatDuration formats a duration for display (e.g. "12ms", "3.2s",
+// "4m05s" or "1h30m").
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
@@ -66,10 +66,39 @@ func ValidConfigKeys() []string {
 	// Return in a stable, logical order matching the TOML section layout.
 	ordered := []string{
 		"storage.sqlite_path",
+		"storage.blob_dir",
+		"storage.blob_threshold",
+		"storage.media_dir",
+		"storage.media_max_bytes",
+		"storage.media_download",
+		"storage.compact_interval",
+		"storage.backup_keep",
+		"storage.encryption",
 		"proxy.provider",
 		"proxy.upstream",
 		"proxy.listen",
+		"proxy.user",
+		"proxy.max_capture_bytes",
+		"proxy.max_input_tokens",
+		"proxy.raw_capture",
+		"proxy.sign_nodes",
+		"proxy.session_idle_timeout",
+		"proxy.token",
+		"proxy.retry.max_attempts",
+		"proxy.retry.base_delay",
+		"proxy.retry.max_delay",
+		"proxy.workers",
+		"proxy.queue_size",
+		"proxy.overflow_policy",
+		"proxy.enqueue_timeout",
+		"proxy.job_timeout",
 		"api.listen",
+		"api.token",
+		"api.read_token",
+		"tls.enabled",
+		"tls.cert_file",
+		"tls.key_file",
+		"tls.client_ca_file",
 		"client.proxy_target",
 		"client.api_target",
 		"vector_store.provider",
@@ -80,6 +109,31 @@ func ValidConfigKeys() []string {
 		"embedding.dimensions",
 		"opencode.provider",
 		"opencode.model",
+		"redaction.enabled",
+		"redaction.rules_file",
+		"retention.max_age",
+		"retention.max_db_size",
+		"retention.max_sessions",
+		"retention.gc",
+		"telemetry.otlp_endpoint",
+		"summarizer.enabled",
+		"summarizer.provider",
+		"summarizer.model",
+		"summarizer.idle_after",
+		"titles.provider",
+		"titles.model",
+		"start.confirm_recording",
+		"start.codex_config",
+		"start.codex_wire_api",
+		"start.run_token_ttl",
+		"start.run_token_max_requests",
+		"start.run_token_max_input_tokens",
+		"report.slack_webhook",
+		"report.slack_daily_at",
+		"logs.level",
+		"logs.max_file_size",
+		"logs.max_files",
+		"health.probe_interval",
 	}
 
 	// Sanity: only return keys that actually exist in the map.
@@ -110,16 +164,38 @@ func IsValidConfigKey(key string) bool {
 	return ok
 }
 
+// IsSecretConfigKey returns true if the given key holds a credential whose
+// value should not be echoed into logs.
+func IsSecretConfigKey(key string) bool {
+	return configKeys[key].secret
+}
+
 func (c *Configer) GetTarget() string {
 	return c.targetPath
 }
 
-// LoadConfig loads the configuration from config.toml in the target .tapes/ directory.
+// LoadConfig loads the resolved configuration: config.toml in the target
+// .tapes/ directory, overridden by TAPES_* environment variables (see
+// EnvName), with the rules of redaction.rules_file added.
+func (c *Configer) LoadConfig() (*Config, error) {
+	cfg, err := c.LoadFileConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+	if err := loadRedactionRules(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadFileConfig loads the configuration from config.toml in the target .tapes/ directory.
 // If the file does not exist, returns DefaultConfig() so callers always receive
 // a fully-populated Config with sane defaults. Fields explicitly set in the file
 // override the defaults.
-// If overrideDir is non-empty, it is used instead of the default .tapes/ location.
-func (c *Configer) LoadConfig() (*Config, error) {
+func (c *Configer) LoadFileConfig() (*Config, error) {
 	if c.targetPath == "" {
 		return NewDefaultConfig(), nil
 	}
@@ -200,20 +276,19 @@ func (c *Configer) SaveConfig(cfg *Config) error {
 		return errors.New("cannot save empty target path")
 	}
 
-	var buf bytes.Buffer
-	encoder := toml.NewEncoder(&buf)
-	if err := encoder.Encode(cfg); err != nil {
-		return fmt.Errorf("encoding config: %w", err)
+	data, err := MarshalConfigTOML(cfg)
+	if err != nil {
+		return err
 	}
 
-	if err := os.WriteFile(c.targetPath, buf.Bytes(), 0o600); err != nil {
+	if err := os.WriteFile(c.targetPath, data, 0o600); err != nil {
 		return fmt.Errorf("writing config: %w", err)
 	}
 
 	return nil
 }
 
-// SetConfigValue loads the config, sets the given key to the given value, and saves it.
+// SetConfigValue loads the config file, sets the given key to the given value, and saves it.
 // Returns an error if the key is not a valid config key.
 func (c *Configer) SetConfigValue(key string, value string) error {
 	info, ok := configKeys[key]
@@ -221,7 +296,7 @@ func (c *Configer) SetConfigValue(key string, value string) error {
 		return fmt.Errorf("unknown config key: %q", key)
 	}
 
-	cfg, err := c.LoadConfig()
+	cfg, err := c.LoadFileConfig()
 	if err != nil {
 		return err
 	}
@@ -233,7 +308,7 @@ func (c *Configer) SetConfigValue(key string, value string) error {
 	return c.SaveConfig(cfg)
 }
 
-// GetConfigValue loads the config and returns the string representation of the given key.
+// GetConfigValue loads the config file and returns the string representation of the given key.
 // Returns an error if the key is not a valid config key.
 func (c *Configer) GetConfigValue(key string) (string, error) {
 	info, ok := configKeys[key]
@@ -241,7 +316,7 @@ func (c *Configer) GetConfigValue(key string) (string, error) {
 		return "", fmt.Errorf("unknown config key: %q", key)
 	}
 
-	cfg, err := c.LoadConfig()
+	cfg, err := c.LoadFileConfig()
 	if err != nil {
 		return "", err
 	}
@@ -321,6 +396,15 @@ func ValidPresetNames() []string {
 	return []string{"openai", "anthropic", "ollama"}
 }
 
+// MarshalConfigTOML encodes cfg in the layout of config.toml.
+func MarshalConfigTOML(cfg *Config) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		return nil, fmt.Errorf("encoding config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
 // ParseConfigTOML parses raw TOML bytes into a Config.
 // Returns an error if the version field is present and not equal to CurrentConfigVersion.
 func ParseConfigTOML(data []byte) (*Config, error) {
@@ -8,7 +8,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/papercomputeco/tapes/pkg/capture"
 	"github.com/papercomputeco/tapes/pkg/config"
+	"github.com/papercomputeco/tapes/pkg/notify"
+	"github.com/papercomputeco/tapes/pkg/policy"
 )
 
 func TestConfig(t *testing.T) {
@@ -398,6 +401,482 @@ provider = "openai"
 			Expect(val).To(Equal("http://localhost:8081"))
 		})
 
+		It("sets and gets proxy.max_capture_bytes", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("proxy.max_capture_bytes", "1048576")).To(Succeed())
+
+			val, err := c.GetConfigValue("proxy.max_capture_bytes")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("1048576"))
+
+			Expect(c.SetConfigValue("proxy.max_capture_bytes", "lots")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets proxy.max_input_tokens", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("proxy.max_input_tokens", "200000")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Proxy.MaxInputTokens).To(Equal(uint(200000)))
+
+			Expect(c.SetConfigValue("proxy.max_input_tokens", "-1")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets proxy.raw_capture", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("proxy.raw_capture", "true")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Proxy.RawCapture).To(BeTrue())
+
+			Expect(c.SetConfigValue("proxy.raw_capture", "sometimes")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets proxy.sign_nodes", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("proxy.sign_nodes", "true")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Proxy.SignNodes).To(BeTrue())
+
+			Expect(c.SetConfigValue("proxy.sign_nodes", "maybe")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets proxy.session_idle_timeout", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("proxy.session_idle_timeout", "45m")).To(Succeed())
+
+			val, err := c.GetConfigValue("proxy.session_idle_timeout")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("45m"))
+
+			Expect(c.SetConfigValue("proxy.session_idle_timeout", "a while")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets TLS and proxy token keys", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("proxy.token", "proxy-secret")).To(Succeed())
+			Expect(c.SetConfigValue("tls.enabled", "true")).To(Succeed())
+			Expect(c.SetConfigValue("tls.cert_file", "/etc/tapes/cert.pem")).To(Succeed())
+			Expect(c.SetConfigValue("tls.key_file", "/etc/tapes/key.pem")).To(Succeed())
+			Expect(c.SetConfigValue("tls.client_ca_file", "/etc/tapes/ca.pem")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Proxy.Token).To(Equal("proxy-secret"))
+			Expect(cfg.TLS).To(Equal(config.TLSConfig{
+				Enabled:      true,
+				CertFile:     "/etc/tapes/cert.pem",
+				KeyFile:      "/etc/tapes/key.pem",
+				ClientCAFile: "/etc/tapes/ca.pem",
+			}))
+
+			Expect(c.SetConfigValue("tls.enabled", "maybe")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets team attribution and access keys", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("proxy.user", "alice")).To(Succeed())
+			Expect(c.SetConfigValue("api.token", "admin-secret")).To(Succeed())
+			Expect(c.SetConfigValue("api.read_token", "read-secret")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Proxy.User).To(Equal("alice"))
+			Expect(cfg.API.Token).To(Equal("admin-secret"))
+			Expect(cfg.API.ReadToken).To(Equal("read-secret"))
+		})
+
+		It("sets and gets proxy retry keys", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("proxy.retry.max_attempts", "4")).To(Succeed())
+			Expect(c.SetConfigValue("proxy.retry.base_delay", "1s")).To(Succeed())
+			Expect(c.SetConfigValue("proxy.retry.max_delay", "1m")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Proxy.Retry).To(Equal(config.RetryConfig{
+				MaxAttempts: 4,
+				BaseDelay:   "1s",
+				MaxDelay:    "1m",
+			}))
+
+			Expect(c.SetConfigValue("proxy.retry.max_attempts", "many")).To(MatchError(ContainSubstring("invalid value")))
+			Expect(c.SetConfigValue("proxy.retry.max_delay", "a while")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets storage blob settings", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("storage.blob_dir", "/var/lib/tapes/blobs")).To(Succeed())
+			Expect(c.SetConfigValue("storage.blob_threshold", "65536")).To(Succeed())
+
+			val, err := c.GetConfigValue("storage.blob_dir")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("/var/lib/tapes/blobs"))
+
+			val, err = c.GetConfigValue("storage.blob_threshold")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("65536"))
+
+			Expect(c.SetConfigValue("storage.blob_threshold", "big")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets storage media settings", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("storage.media_dir", "/var/lib/tapes/media")).To(Succeed())
+			Expect(c.SetConfigValue("storage.media_max_bytes", "1048576")).To(Succeed())
+			Expect(c.SetConfigValue("storage.media_download", "true")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Storage.MediaDir).To(Equal("/var/lib/tapes/media"))
+			Expect(cfg.Storage.MediaMaxBytes).To(Equal(uint(1048576)))
+			Expect(cfg.Storage.MediaDownload).To(BeTrue())
+
+			Expect(c.SetConfigValue("storage.media_max_bytes", "big")).To(MatchError(ContainSubstring("invalid value")))
+			Expect(c.SetConfigValue("storage.media_download", "maybe")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets storage.encryption", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			val, err := c.GetConfigValue("storage.encryption")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(BeEmpty())
+
+			Expect(c.SetConfigValue("storage.encryption", "true")).To(Succeed())
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Storage.Encryption).To(BeTrue())
+
+			Expect(c.SetConfigValue("storage.encryption", "maybe")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets redaction.enabled", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("redaction.enabled", "true")).To(Succeed())
+
+			val, err := c.GetConfigValue("redaction.enabled")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("true"))
+
+			Expect(c.SetConfigValue("redaction.enabled", "maybe")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets storage.compact_interval", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("storage.compact_interval", "7d")).To(Succeed())
+
+			val, err := c.GetConfigValue("storage.compact_interval")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("7d"))
+
+			Expect(c.SetConfigValue("storage.compact_interval", "weekly")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets storage.backup_keep", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("storage.backup_keep", "3")).To(Succeed())
+
+			val, err := c.GetConfigValue("storage.backup_keep")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("3"))
+
+			Expect(c.SetConfigValue("storage.backup_keep", "-1")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets proxy queue keys", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("proxy.queue_size", "1024")).To(Succeed())
+			Expect(c.SetConfigValue("proxy.overflow_policy", "block")).To(Succeed())
+			Expect(c.SetConfigValue("proxy.enqueue_timeout", "5s")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Proxy.QueueSize).To(Equal(uint(1024)))
+			Expect(cfg.Proxy.OverflowPolicy).To(Equal("block"))
+			Expect(cfg.Proxy.EnqueueTimeout).To(Equal("5s"))
+
+			Expect(c.SetConfigValue("proxy.overflow_policy", "drop-all")).To(MatchError(ContainSubstring("invalid value")))
+			Expect(c.SetConfigValue("proxy.job_timeout", "soon")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets retention keys", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("retention.max_age", "30d")).To(Succeed())
+			Expect(c.SetConfigValue("retention.max_db_size", "2GB")).To(Succeed())
+			Expect(c.SetConfigValue("retention.max_sessions", "500")).To(Succeed())
+			Expect(c.SetConfigValue("retention.gc", "true")).To(Succeed())
+
+			val, err := c.GetConfigValue("retention.max_age")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("30d"))
+
+			val, err = c.GetConfigValue("retention.max_db_size")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("2GB"))
+
+			val, err = c.GetConfigValue("retention.max_sessions")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("500"))
+
+			val, err = c.GetConfigValue("retention.gc")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("true"))
+
+			Expect(c.SetConfigValue("retention.max_age", "soon")).To(MatchError(ContainSubstring("invalid value")))
+			Expect(c.SetConfigValue("retention.max_db_size", "huge")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets summarizer keys", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("summarizer.enabled", "true")).To(Succeed())
+			Expect(c.SetConfigValue("summarizer.provider", "ollama")).To(Succeed())
+			Expect(c.SetConfigValue("summarizer.model", "llama3.2")).To(Succeed())
+			Expect(c.SetConfigValue("summarizer.idle_after", "15m")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Summarizer).To(Equal(config.SummarizerConfig{
+				Enabled:   true,
+				Provider:  "ollama",
+				Model:     "llama3.2",
+				IdleAfter: "15m",
+			}))
+
+			Expect(c.SetConfigValue("summarizer.idle_after", "later")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets titles keys", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("titles.provider", "anthropic")).To(Succeed())
+			Expect(c.SetConfigValue("titles.model", "claude-haiku-4-5-20251001")).To(Succeed())
+
+			val, err := c.GetConfigValue("titles.provider")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("anthropic"))
+
+			val, err = c.GetConfigValue("titles.model")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("claude-haiku-4-5-20251001"))
+		})
+
+		It("sets and gets report keys", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("report.slack_webhook", "https://hooks.slack.com/services/T000/B000/XXXX")).To(Succeed())
+			Expect(c.SetConfigValue("report.slack_daily_at", "09:30")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Report).To(Equal(config.ReportConfig{
+				SlackWebhook: "https://hooks.slack.com/services/T000/B000/XXXX",
+				SlackDailyAt: "09:30",
+			}))
+			Expect(config.IsSecretConfigKey("report.slack_webhook")).To(BeTrue())
+
+			Expect(c.SetConfigValue("report.slack_daily_at", "9am")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets logs keys", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("logs.level", "warn")).To(Succeed())
+			Expect(c.SetConfigValue("logs.max_file_size", "20MB")).To(Succeed())
+			Expect(c.SetConfigValue("logs.max_files", "3")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Logs).To(Equal(config.LogsConfig{Level: "warn", MaxFileSize: "20MB", MaxFiles: 3}))
+
+			val, err := c.GetConfigValue("logs.max_files")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("3"))
+
+			Expect(c.SetConfigValue("logs.level", "loud")).To(MatchError(ContainSubstring("invalid value")))
+			Expect(c.SetConfigValue("logs.max_file_size", "big")).To(MatchError(ContainSubstring("invalid value")))
+			Expect(c.SetConfigValue("logs.max_files", "-1")).To(MatchError(ContainSubstring("invalid value")))
+		})
+
+		It("sets and gets health keys", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("health.probe_interval", "1m")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Health.ProbeInterval).To(Equal("1m"))
+
+			Expect(c.SetConfigValue("health.probe_interval", "often")).To(MatchError(ContainSubstring("invalid value")))
+			Expect(c.SetConfigValue("health.probe_interval", "0s")).To(MatchError(ContainSubstring("must be positive")))
+		})
+
+		It("sets and gets start keys", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.SetConfigValue("start.confirm_recording", "true")).To(Succeed())
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Start.ConfirmRecording).To(BeTrue())
+
+			Expect(c.SetConfigValue("start.confirm_recording", "sometimes")).To(MatchError(ContainSubstring("invalid value")))
+
+			Expect(c.SetConfigValue("start.codex_config", "true")).To(Succeed())
+			Expect(c.SetConfigValue("start.codex_wire_api", "chat")).To(Succeed())
+			val, err := c.GetConfigValue("start.codex_wire_api")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(val).To(Equal("chat"))
+			Expect(c.SetConfigValue("start.codex_wire_api", "grpc")).To(MatchError(ContainSubstring("invalid value")))
+
+			Expect(c.SetConfigValue("start.run_token_ttl", "2d")).To(Succeed())
+			Expect(c.SetConfigValue("start.run_token_ttl", "soon")).To(MatchError(ContainSubstring("invalid value")))
+			Expect(c.SetConfigValue("start.run_token_max_requests", "500")).To(Succeed())
+			Expect(c.SetConfigValue("start.run_token_max_input_tokens", "-1")).To(MatchError(ContainSubstring("invalid value")))
+			cfg, err = c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Start.RunTokenTTL).To(Equal("2d"))
+			Expect(cfg.Start.RunTokenMaxRequests).To(Equal(uint(500)))
+		})
+
+		It("loads custom redaction rules", func() {
+			data := `[redaction]
+enabled = true
+
+[[redaction.rules]]
+name = "ticket"
+pattern = 'TICKET-\d+'
+`
+			Expect(os.WriteFile(filepath.Join(tmpDir, "config.toml"), []byte(data), 0o600)).To(Succeed())
+
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cfg.Redaction.Enabled).To(BeTrue())
+			Expect(cfg.Redaction.Rules).To(HaveLen(1))
+			Expect(cfg.Redaction.Rules[0].Name).To(Equal("ticket"))
+			Expect(cfg.Redaction.Rules[0].Pattern).To(Equal(`TICKET-\d+`))
+		})
+
+		It("loads capture rules in order", func() {
+			data := `[[capture.rules]]
+name = "embeddings"
+path = '/embeddings$'
+
+[[capture.rules]]
+name = "claude"
+action = "include"
+agent = '^claude$'
+`
+			Expect(os.WriteFile(filepath.Join(tmpDir, "config.toml"), []byte(data), 0o600)).To(Succeed())
+
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cfg.Capture.Rules).To(Equal([]capture.RuleSpec{
+				{Name: "embeddings", Path: `/embeddings$`},
+				{Name: "claude", Action: capture.ActionInclude, Agent: `^claude$`},
+			}))
+		})
+
+		It("loads policy rules", func() {
+			data := `[[policy.rules]]
+name = "no_force_push"
+action = "block"
+tool = '^Bash$'
+command = 'git push .*--force'
+
+[[policy.rules]]
+name = "small_writes"
+max_write_bytes = 65536
+`
+			Expect(os.WriteFile(filepath.Join(tmpDir, "config.toml"), []byte(data), 0o600)).To(Succeed())
+
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cfg.Policy.Rules).To(Equal([]policy.RuleSpec{
+				{Name: "no_force_push", Action: policy.ActionBlock, Tool: `^Bash$`, Command: `git push .*--force`},
+				{Name: "small_writes", MaxWriteBytes: 65536},
+			}))
+		})
+
+		It("loads notify rules", func() {
+			data := `[[notify.rules]]
+name = "daily_spend"
+event = "budget"
+channel = "slack"
+webhook = "https://hooks.slack.com/services/T000/B000/XXXX"
+threshold = 25.0
+
+[[notify.rules]]
+name = "claude_errors"
+event = "error_streak"
+channel = "desktop"
+agent = '^claude$'
+streak = 5
+`
+			Expect(os.WriteFile(filepath.Join(tmpDir, "config.toml"), []byte(data), 0o600)).To(Succeed())
+
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(cfg.Notify.Rules).To(Equal([]notify.RuleSpec{
+				{Name: "daily_spend", Event: notify.EventBudget, Channel: notify.ChannelSlack, Webhook: "https://hooks.slack.com/services/T000/B000/XXXX", Threshold: 25},
+				{Name: "claude_errors", Event: notify.EventErrorStreak, Channel: notify.ChannelDesktop, Agent: `^claude$`, Streak: 5},
+			}))
+		})
+
 		It("gets a uint config value as string", func() {
 			c, err := config.NewConfiger(tmpDir)
 			Expect(err).NotTo(HaveOccurred())
@@ -420,6 +899,7 @@ provider = "openai"
 				"proxy.upstream",
 				"proxy.listen",
 				"api.listen",
+				"api.token",
 				"client.proxy_target",
 				"client.api_target",
 				"vector_store.provider",
@@ -430,6 +910,20 @@ provider = "openai"
 				"embedding.dimensions",
 				"opencode.provider",
 				"opencode.model",
+				"start.confirm_recording",
+				"start.codex_config",
+				"start.codex_wire_api",
+				"start.run_token_ttl",
+				"start.run_token_max_requests",
+				"start.run_token_max_input_tokens",
+				"report.slack_webhook",
+				"report.slack_daily_at",
+				"logs.level",
+				"logs.max_file_size",
+				"logs.max_files",
+				"health.probe_interval",
+				"redaction.rules_file",
+				"proxy.sign_nodes",
 			))
 		})
 
@@ -440,6 +934,104 @@ provider = "openai"
 		})
 	})
 
+	Describe("environment variables", func() {
+		It("names each key's variable after the key", func() {
+			Expect(config.EnvName("proxy.listen")).To(Equal("TAPES_PROXY_LISTEN"))
+			Expect(config.EnvName("proxy.retry.max_attempts")).To(Equal("TAPES_PROXY_RETRY_MAX_ATTEMPTS"))
+		})
+
+		It("override the config file when loading the resolved config", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.SetConfigValue("proxy.listen", ":9090")).To(Succeed())
+			Expect(c.SetConfigValue("retention.max_age", "30d")).To(Succeed())
+
+			GinkgoT().Setenv("TAPES_PROXY_LISTEN", "0.0.0.0:8080")
+			GinkgoT().Setenv("TAPES_RETENTION_MAX_SESSIONS", "500")
+			GinkgoT().Setenv("TAPES_API_LISTEN", "")
+			Expect(config.EnvOverrides()).To(Equal([]string{"proxy.listen", "retention.max_sessions"}))
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Proxy.Listen).To(Equal("0.0.0.0:8080"))
+			Expect(cfg.Retention.MaxAge).To(Equal("30d"))
+			Expect(cfg.Retention.MaxSessions).To(Equal(uint(500)))
+			Expect(cfg.API.Listen).To(Equal(":8081"))
+
+			fileCfg, err := c.LoadFileConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fileCfg.Proxy.Listen).To(Equal(":9090"))
+		})
+
+		It("are not saved by config set", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			GinkgoT().Setenv("TAPES_API_TOKEN", "from-env")
+			Expect(c.SetConfigValue("proxy.provider", "anthropic")).To(Succeed())
+
+			data, err := os.ReadFile(filepath.Join(tmpDir, "config.toml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).NotTo(ContainSubstring("from-env"))
+		})
+
+		It("are validated like config set values", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			GinkgoT().Setenv("TAPES_RETENTION_MAX_SESSIONS", "many")
+
+			_, err = c.LoadConfig()
+			Expect(err).To(MatchError(ContainSubstring("invalid TAPES_RETENTION_MAX_SESSIONS")))
+		})
+	})
+
+	Describe("redaction.rules_file", func() {
+		It("adds the rules of the file to those of the config", func() {
+			rulesFile := filepath.Join(tmpDir, "redaction.toml")
+			Expect(os.WriteFile(rulesFile, []byte(`[[rules]]
+name = "ticket"
+pattern = "TICKET-[0-9]+"
+`), 0o600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(tmpDir, "config.toml"), []byte(`[redaction]
+enabled = true
+
+[[redaction.rules]]
+name = "employee_id"
+pattern = "EMP[0-9]{6}"
+`), 0o600)).To(Succeed())
+
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			GinkgoT().Setenv("TAPES_REDACTION_RULES_FILE", rulesFile)
+
+			cfg, err := c.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cfg.Redaction.Rules).To(HaveLen(2))
+			Expect(cfg.Redaction.Rules[0].Name).To(Equal("employee_id"))
+			Expect(cfg.Redaction.Rules[1].Name).To(Equal("ticket"))
+		})
+
+		It("fails when the file cannot be read", func() {
+			c, err := config.NewConfiger(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			GinkgoT().Setenv("TAPES_REDACTION_RULES_FILE", filepath.Join(tmpDir, "missing.toml"))
+
+			_, err = c.LoadConfig()
+			Expect(err).To(MatchError(ContainSubstring("reading redaction rules")))
+		})
+	})
+
+	Describe("MaskSecrets", func() {
+		It("replaces tokens and leaves other values", func() {
+			cfg := config.NewDefaultConfig()
+			cfg.API.Token = "secret"
+			cfg.Proxy.Upstream = "https://api.anthropic.com"
+			config.MaskSecrets(cfg)
+			Expect(cfg.API.Token).To(Equal("<redacted>"))
+			Expect(cfg.API.ReadToken).To(BeEmpty())
+			Expect(cfg.Proxy.Upstream).To(Equal("https://api.anthropic.com"))
+		})
+	})
+
 	Describe("IsValidConfigKey", func() {
 		It("returns true for valid keys", func() {
 			Expect(config.IsValidConfigKey("proxy.provider")).To(BeTrue())
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/papercomputeco/tapes/pkg/redact"
+)
+
+// EnvPrefix starts the names of the environment variables that set config
+// keys. Settings are resolved with this precedence, highest first: command
+// line flags, TAPES_* environment variables, config.toml, and defaults.
+const EnvPrefix = "TAPES_"
+
+// EnvName returns the environment variable that sets a config key: the key
+// in upper case with dots replaced by underscores, after EnvPrefix, e.g.
+// TAPES_PROXY_LISTEN for proxy.listen.
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// EnvOverrides returns the config keys set by environment variables, in
+// the order of ValidConfigKeys. Empty variables are ignored.
+func EnvOverrides() []string {
+	var keys []string
+	for _, key := range ValidConfigKeys() {
+		if os.Getenv(EnvName(key)) != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// applyEnv sets the config keys that have an environment variable set.
+func applyEnv(cfg *Config) error {
+	for _, key := range EnvOverrides() {
+		name := EnvName(key)
+		if err := configKeys[key].set(cfg, os.Getenv(name)); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// redactionRulesFile is the layout of redaction.rules_file: the same
+// [[rules]] tables as [[redaction.rules]] in config.toml.
+type redactionRulesFile struct {
+	Rules []redact.RuleSpec `toml:"rules"`
+}
+
+// loadRedactionRules appends the rules of redaction.rules_file, when set, to
+// the redaction rules of cfg.
+func loadRedactionRules(cfg *Config) error {
+	if cfg.Redaction.RulesFile == "" {
+		return nil
+	}
+
+	var file redactionRulesFile
+	if _, err := toml.DecodeFile(cfg.Redaction.RulesFile, &file); err != nil {
+		return fmt.Errorf("reading redaction rules: %w", err)
+	}
+	cfg.Redaction.Rules = append(cfg.Redaction.Rules, file.Rules...)
+	return nil
+}
+
+// MaskSecrets replaces the values of secret keys in cfg, such as API
+// tokens, so that it can be printed.
+func MaskSecrets(cfg *Config) {
+	for _, info := range configKeys {
+		if info.secret && info.get(cfg) != "" {
+			_ = info.set(cfg, "<redacted>")
+		}
+	}
+}
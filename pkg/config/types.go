@@ -1,8 +1,17 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
+
+	"github.com/papercomputeco/tapes/pkg/capture"
+	"github.com/papercomputeco/tapes/pkg/notify"
+	"github.com/papercomputeco/tapes/pkg/policy"
+	"github.com/papercomputeco/tapes/pkg/redact"
+	"github.com/papercomputeco/tapes/pkg/report"
+	"github.com/papercomputeco/tapes/pkg/utils"
+	"go.uber.org/zap/zapcore"
 )
 
 // Config represents the persistent tapes configuration stored as config.toml
@@ -12,15 +21,53 @@ type Config struct {
 	Storage     StorageConfig     `toml:"storage"`
 	Proxy       ProxyConfig       `toml:"proxy"`
 	API         APIConfig         `toml:"api"`
+	TLS         TLSConfig         `toml:"tls"`
 	Client      ClientConfig      `toml:"client"`
 	VectorStore VectorStoreConfig `toml:"vector_store"`
 	Embedding   EmbeddingConfig   `toml:"embedding"`
 	OpenCode    OpenCodeConfig    `toml:"opencode"`
+	Redaction   RedactionConfig   `toml:"redaction"`
+	Retention   RetentionConfig   `toml:"retention"`
+	Telemetry   TelemetryConfig   `toml:"telemetry"`
+	Summarizer  SummarizerConfig  `toml:"summarizer"`
+	Titles      TitlesConfig      `toml:"titles"`
+	Start       StartConfig       `toml:"start"`
+	Capture     CaptureConfig     `toml:"capture"`
+	Policy      PolicyConfig      `toml:"policy"`
+	Notify      NotifyConfig      `toml:"notify"`
+	Report      ReportConfig      `toml:"report"`
+	Logs        LogsConfig        `toml:"logs"`
+	Health      HealthConfig      `toml:"health"`
 }
 
 // StorageConfig holds shared storage settings used by both proxy and API.
 type StorageConfig struct {
 	SQLitePath string `toml:"sqlite_path,omitempty"`
+
+	// BlobDir enables offloading large content blocks to files in this
+	// directory, or to an S3 bucket given as s3://bucket/prefix with the
+	// standard AWS_* environment variables, keeping the database small.
+	BlobDir       string `toml:"blob_dir,omitempty"`
+	BlobThreshold uint   `toml:"blob_threshold,omitempty"`
+
+	// MediaDir enables storing captured images and attached files in this
+	// directory, content-addressed, instead of inline. MediaDownload also
+	// fetches images referenced by URL.
+	MediaDir      string `toml:"media_dir,omitempty"`
+	MediaMaxBytes uint   `toml:"media_max_bytes,omitempty"`
+	MediaDownload bool   `toml:"media_download,omitempty"`
+
+	// CompactInterval schedules "tapes db compact" in the daemon, e.g. "24h".
+	CompactInterval string `toml:"compact_interval,omitempty"`
+
+	// BackupKeep is how many backups "tapes backup create" keeps in the
+	// backups/ directory, deleting the oldest beyond it.
+	BackupKeep uint `toml:"backup_keep,omitempty"`
+
+	// Encryption seals content at rest with the key from
+	// TAPES_ENCRYPTION_KEY or the OS keychain. The key is only looked up
+	// when it is set.
+	Encryption bool `toml:"encryption,omitempty"`
 }
 
 // ProxyConfig holds proxy-specific settings.
@@ -29,11 +76,103 @@ type ProxyConfig struct {
 	Upstream string `toml:"upstream,omitempty"`
 	Listen   string `toml:"listen,omitempty"`
 	Project  string `toml:"project,omitempty"`
+
+	// User is the identity captured turns are attributed to, defaulting to
+	// the OS user. Agents sharing one proxy can send their own in the
+	// X-Tapes-User header, when the proxy has no token, or be issued a
+	// per-user token in UserTokens.
+	User string `toml:"user,omitempty"`
+
+	MaxCaptureBytes uint `toml:"max_capture_bytes,omitempty"`
+
+	// MaxInputTokens, when set, rejects chat requests whose input is larger
+	// before they are forwarded upstream. Inputs whose size is estimated
+	// locally, rather than counted by the provider, are allowed a margin for
+	// the estimate's error.
+	MaxInputTokens uint `toml:"max_input_tokens,omitempty"`
+
+	// RawCapture retains each turn's original request and response payloads,
+	// compressed, so "tapes reprocess" can re-parse them after a parser fix.
+	RawCapture bool `toml:"raw_capture,omitempty"`
+
+	// SignNodes signs every stored turn with the installation's identity
+	// key, the one "tapes share" signs bundles with, so that sessions
+	// aggregated elsewhere prove which machine produced them.
+	SignNodes bool `toml:"sign_nodes,omitempty"`
+
+	// SessionIdleTimeout closes a session after this long without a turn,
+	// e.g. "30m" (the default). "0" disables idle closing.
+	SessionIdleTimeout string `toml:"session_idle_timeout,omitempty"`
+
+	// Token, when set, is required in the X-Tapes-Token header on proxied
+	// requests. Agents keep sending their provider credentials as usual.
+	Token string `toml:"token,omitempty"`
+
+	// UserTokens maps users to tokens of their own, accepted in place of
+	// Token, so that turns are attributed to the user whose token they were
+	// sent with. They can only be set by editing config.toml, under
+	// [proxy.user_tokens].
+	UserTokens map[string]string `toml:"user_tokens,omitempty"`
+
+	Retry RetryConfig `toml:"retry,omitempty"`
+
+	QueueConfig
+}
+
+// QueueConfig holds settings for the queue of captured turns waiting to be
+// stored, which are in the [proxy] section. Zero values keep the defaults:
+// 3 workers, 256 queued turns, and the drop-new overflow policy.
+type QueueConfig struct {
+	Workers   uint `toml:"workers,omitempty"`
+	QueueSize uint `toml:"queue_size,omitempty"`
+
+	// OverflowPolicy is what happens to a turn captured while the queue is
+	// full: "drop-new", "drop-oldest", or "block".
+	OverflowPolicy string `toml:"overflow_policy,omitempty"`
+
+	// EnqueueTimeout bounds how long the block policy holds up a response
+	// waiting for room in the queue, e.g. "5s". Unset waits indefinitely.
+	EnqueueTimeout string `toml:"enqueue_timeout,omitempty"`
+
+	// JobTimeout bounds how long storing a single turn may take, e.g. "30s".
+	// Unset disables the timeout.
+	JobTimeout string `toml:"job_timeout,omitempty"`
+}
+
+// RetryConfig holds settings for retrying throttled and overloaded upstream
+// requests. Retries are off unless MaxAttempts is at least 2. Overrides per
+// provider type can only be set by editing config.toml, e.g. under
+// [proxy.retry.providers.anthropic].
+type RetryConfig struct {
+	MaxAttempts uint   `toml:"max_attempts,omitempty"`
+	BaseDelay   string `toml:"base_delay,omitempty"`
+	MaxDelay    string `toml:"max_delay,omitempty"`
+
+	Providers map[string]RetryConfig `toml:"providers,omitempty"`
 }
 
 // APIConfig holds API server settings.
 type APIConfig struct {
 	Listen string `toml:"listen,omitempty"`
+
+	// Token, when set, is required as a bearer token on API requests and
+	// sent by the CLI commands that call the API. It grants admin access.
+	Token string `toml:"token,omitempty"`
+
+	// ReadToken is a second bearer token for teammates sharing the server.
+	// It can read sessions and analytics but not change them.
+	ReadToken string `toml:"read_token,omitempty"`
+}
+
+// TLSConfig holds HTTPS settings shared by the proxy and API listeners of
+// "tapes serve". When enabled without a certificate, a self-signed one is
+// generated in the tls/ directory under .tapes/. Setting ClientCAFile
+// requires clients to present a certificate signed by that CA.
+type TLSConfig struct {
+	Enabled      bool   `toml:"enabled,omitempty"`
+	CertFile     string `toml:"cert_file,omitempty"`
+	KeyFile      string `toml:"key_file,omitempty"`
+	ClientCAFile string `toml:"client_ca_file,omitempty"`
 }
 
 // ClientConfig holds settings for CLI commands that connect to the running
@@ -64,10 +203,126 @@ type OpenCodeConfig struct {
 	Model    string `toml:"model,omitempty"`
 }
 
+// RedactionConfig holds settings for scrubbing secrets and personal data from
+// captured content before it is stored. Custom rules are applied after the
+// built-in detectors and can only be set by editing config.toml or the TOML
+// file at RulesFile, whose [[rules]] are added to them.
+type RedactionConfig struct {
+	Enabled   bool              `toml:"enabled,omitempty"`
+	RulesFile string            `toml:"rules_file,omitempty"`
+	Rules     []redact.RuleSpec `toml:"rules,omitempty"`
+}
+
+// RetentionConfig holds limits enforced by the background pruner in the
+// daemon and used as defaults by "tapes prune". Zero values disable a limit.
+type RetentionConfig struct {
+	MaxAge      string `toml:"max_age,omitempty"`
+	MaxDBSize   string `toml:"max_db_size,omitempty"`
+	MaxSessions uint   `toml:"max_sessions,omitempty"`
+
+	// GC also deletes orphaned turns when pruning, without the confirmation
+	// "tapes db gc" asks for.
+	GC bool `toml:"gc,omitempty"`
+}
+
+// TelemetryConfig holds OpenTelemetry tracing settings. Trace context from
+// agents is always propagated; spans are only exported when an OTLP endpoint
+// is set here or through the standard OTEL_EXPORTER_OTLP_* variables.
+type TelemetryConfig struct {
+	OTLPEndpoint string `toml:"otlp_endpoint,omitempty"`
+}
+
+// SummarizerConfig holds settings for the daemon's session summarizer, which
+// sends idle sessions to a model to title and summarize them. It is off by
+// default since transcripts leave the machine when a hosted provider is used.
+type SummarizerConfig struct {
+	Enabled   bool   `toml:"enabled,omitempty"`
+	Provider  string `toml:"provider,omitempty"`
+	Model     string `toml:"model,omitempty"`
+	IdleAfter string `toml:"idle_after,omitempty"`
+}
+
+// TitlesConfig holds settings for titling new sessions from their first
+// prompt. Titles are cut from the prompt unless a provider is set, in which
+// case the prompt is sent to that provider's model for a title.
+type TitlesConfig struct {
+	Provider string `toml:"provider,omitempty"`
+	Model    string `toml:"model,omitempty"`
+}
+
+// StartConfig holds settings for agents launched by "tapes start".
+type StartConfig struct {
+	// ConfirmRecording asks before an agent's session is recorded, running
+	// it unrecorded when declined or when there is no terminal to ask on.
+	ConfirmRecording bool `toml:"confirm_recording,omitempty"`
+
+	// CodexConfig points codex at the proxy through a model provider in its
+	// config.toml, restored when codex exits, for codex versions that ignore
+	// the OPENAI_BASE_URL override. CodexWireAPI selects the wire API the
+	// provider uses: "responses" (the default) or "chat".
+	CodexConfig  bool   `toml:"codex_config,omitempty"`
+	CodexWireAPI string `toml:"codex_wire_api,omitempty"`
+
+	// RunTokenTTL, RunTokenMaxRequests, and RunTokenMaxInputTokens limit the
+	// token each agent is given in place of the stored API keys. Zero values
+	// are unlimited; the token is revoked when the agent exits either way.
+	RunTokenTTL            string `toml:"run_token_ttl,omitempty"`
+	RunTokenMaxRequests    uint   `toml:"run_token_max_requests,omitempty"`
+	RunTokenMaxInputTokens uint   `toml:"run_token_max_input_tokens,omitempty"`
+}
+
+// CaptureConfig holds the ordered include and exclude rules that select which
+// proxied turns are recorded. Rules can only be set by editing config.toml.
+type CaptureConfig struct {
+	Rules []capture.RuleSpec `toml:"rules,omitempty"`
+}
+
+// PolicyConfig holds the guardrail rules the proxy checks the tool calls of
+// live traffic against. Rules can only be set by editing config.toml.
+type PolicyConfig struct {
+	Rules []policy.RuleSpec `toml:"rules,omitempty"`
+}
+
+// NotifyConfig holds the rules that send notifications on session events,
+// such as a session ending or a policy violation. Rules can only be set by
+// editing config.toml.
+type NotifyConfig struct {
+	Rules []notify.RuleSpec `toml:"rules,omitempty"`
+}
+
+// ReportConfig holds settings for the daily digest of sessions that the
+// daemon posts to Slack, as "tapes report slack --daily" does. It is posted
+// when both the webhook and the local time of day ("HH:MM") are set.
+type ReportConfig struct {
+	SlackWebhook string `toml:"slack_webhook,omitempty"`
+	SlackDailyAt string `toml:"slack_daily_at,omitempty"`
+}
+
+// LogsConfig holds settings for the tapes start daemon's log files under
+// .tapes/logs. Level is the initial level ("debug", "info", "warn", or
+// "error"), which can be changed while the daemon runs through the API.
+// MaxFileSize (e.g. "10MB") is the size files are rotated at, and MaxFiles
+// the number of rotated files kept.
+type LogsConfig struct {
+	Level       string `toml:"level,omitempty"`
+	MaxFileSize string `toml:"max_file_size,omitempty"`
+	MaxFiles    uint   `toml:"max_files,omitempty"`
+}
+
+// HealthConfig holds settings for the health checks of tapes start and
+// tapes serve. ProbeInterval (e.g. "1m") enables probing the upstream
+// providers for reachability at that interval; empty disables it.
+type HealthConfig struct {
+	ProbeInterval string `toml:"probe_interval,omitempty"`
+}
+
 // configKeyInfo maps a user-facing dotted key name to a getter and setter on *Config.
 type configKeyInfo struct {
 	get func(c *Config) string
 	set func(c *Config, v string) error
+
+	// secret marks values that must not be echoed into logs.
+	secret bool
 }
 
 // configKeys is the authoritative map of all supported config keys.
@@ -77,6 +332,104 @@ var configKeys = map[string]configKeyInfo{
 		get: func(c *Config) string { return c.Storage.SQLitePath },
 		set: func(c *Config, v string) error { c.Storage.SQLitePath = v; return nil },
 	},
+	"storage.blob_dir": {
+		get: func(c *Config) string { return c.Storage.BlobDir },
+		set: func(c *Config, v string) error { c.Storage.BlobDir = v; return nil },
+	},
+	"storage.blob_threshold": {
+		get: func(c *Config) string {
+			if c.Storage.BlobThreshold == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(c.Storage.BlobThreshold), 10)
+		},
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for storage.blob_threshold: %w", err)
+			}
+			c.Storage.BlobThreshold = uint(n)
+			return nil
+		},
+	},
+	"storage.media_dir": {
+		get: func(c *Config) string { return c.Storage.MediaDir },
+		set: func(c *Config, v string) error { c.Storage.MediaDir = v; return nil },
+	},
+	"storage.media_max_bytes": {
+		get: func(c *Config) string {
+			if c.Storage.MediaMaxBytes == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(c.Storage.MediaMaxBytes), 10)
+		},
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for storage.media_max_bytes: %w", err)
+			}
+			c.Storage.MediaMaxBytes = uint(n)
+			return nil
+		},
+	},
+	"storage.media_download": {
+		get: func(c *Config) string {
+			if !c.Storage.MediaDownload {
+				return ""
+			}
+			return strconv.FormatBool(c.Storage.MediaDownload)
+		},
+		set: func(c *Config, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for storage.media_download: %w", err)
+			}
+			c.Storage.MediaDownload = b
+			return nil
+		},
+	},
+	"storage.compact_interval": {
+		get: func(c *Config) string { return c.Storage.CompactInterval },
+		set: func(c *Config, v string) error {
+			if _, err := utils.ParseDuration(v); err != nil {
+				return fmt.Errorf("invalid value for storage.compact_interval: %w", err)
+			}
+			c.Storage.CompactInterval = v
+			return nil
+		},
+	},
+	"storage.backup_keep": {
+		get: func(c *Config) string {
+			if c.Storage.BackupKeep == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(c.Storage.BackupKeep), 10)
+		},
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for storage.backup_keep: %w", err)
+			}
+			c.Storage.BackupKeep = uint(n)
+			return nil
+		},
+	},
+	"storage.encryption": {
+		get: func(c *Config) string {
+			if !c.Storage.Encryption {
+				return ""
+			}
+			return strconv.FormatBool(c.Storage.Encryption)
+		},
+		set: func(c *Config, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for storage.encryption: %w", err)
+			}
+			c.Storage.Encryption = b
+			return nil
+		},
+	},
 	"proxy.provider": {
 		get: func(c *Config) string { return c.Proxy.Provider },
 		set: func(c *Config, v string) error { c.Proxy.Provider = v; return nil },
@@ -93,10 +446,231 @@ var configKeys = map[string]configKeyInfo{
 		get: func(c *Config) string { return c.Proxy.Project },
 		set: func(c *Config, v string) error { c.Proxy.Project = v; return nil },
 	},
+	"proxy.user": {
+		get: func(c *Config) string { return c.Proxy.User },
+		set: func(c *Config, v string) error { c.Proxy.User = v; return nil },
+	},
+	"proxy.max_capture_bytes": {
+		get: func(c *Config) string {
+			if c.Proxy.MaxCaptureBytes == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(c.Proxy.MaxCaptureBytes), 10)
+		},
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for proxy.max_capture_bytes: %w", err)
+			}
+			c.Proxy.MaxCaptureBytes = uint(n)
+			return nil
+		},
+	},
+	"proxy.max_input_tokens": {
+		get: func(c *Config) string {
+			if c.Proxy.MaxInputTokens == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(c.Proxy.MaxInputTokens), 10)
+		},
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for proxy.max_input_tokens: %w", err)
+			}
+			c.Proxy.MaxInputTokens = uint(n)
+			return nil
+		},
+	},
+	"proxy.token": {
+		get:    func(c *Config) string { return c.Proxy.Token },
+		set:    func(c *Config, v string) error { c.Proxy.Token = v; return nil },
+		secret: true,
+	},
+	"proxy.retry.max_attempts": {
+		get: func(c *Config) string {
+			if c.Proxy.Retry.MaxAttempts == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(c.Proxy.Retry.MaxAttempts), 10)
+		},
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for proxy.retry.max_attempts: %w", err)
+			}
+			c.Proxy.Retry.MaxAttempts = uint(n)
+			return nil
+		},
+	},
+	"proxy.retry.base_delay": {
+		get: func(c *Config) string { return c.Proxy.Retry.BaseDelay },
+		set: func(c *Config, v string) error {
+			if _, err := utils.ParseDuration(v); err != nil {
+				return fmt.Errorf("invalid value for proxy.retry.base_delay: %w", err)
+			}
+			c.Proxy.Retry.BaseDelay = v
+			return nil
+		},
+	},
+	"proxy.retry.max_delay": {
+		get: func(c *Config) string { return c.Proxy.Retry.MaxDelay },
+		set: func(c *Config, v string) error {
+			if _, err := utils.ParseDuration(v); err != nil {
+				return fmt.Errorf("invalid value for proxy.retry.max_delay: %w", err)
+			}
+			c.Proxy.Retry.MaxDelay = v
+			return nil
+		},
+	},
 	"api.listen": {
 		get: func(c *Config) string { return c.API.Listen },
 		set: func(c *Config, v string) error { c.API.Listen = v; return nil },
 	},
+	"api.token": {
+		get:    func(c *Config) string { return c.API.Token },
+		set:    func(c *Config, v string) error { c.API.Token = v; return nil },
+		secret: true,
+	},
+	"api.read_token": {
+		get:    func(c *Config) string { return c.API.ReadToken },
+		set:    func(c *Config, v string) error { c.API.ReadToken = v; return nil },
+		secret: true,
+	},
+	"proxy.raw_capture": {
+		get: func(c *Config) string {
+			if !c.Proxy.RawCapture {
+				return ""
+			}
+			return strconv.FormatBool(c.Proxy.RawCapture)
+		},
+		set: func(c *Config, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for proxy.raw_capture: %w", err)
+			}
+			c.Proxy.RawCapture = b
+			return nil
+		},
+	},
+	"proxy.sign_nodes": {
+		get: func(c *Config) string {
+			if !c.Proxy.SignNodes {
+				return ""
+			}
+			return strconv.FormatBool(c.Proxy.SignNodes)
+		},
+		set: func(c *Config, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for proxy.sign_nodes: %w", err)
+			}
+			c.Proxy.SignNodes = b
+			return nil
+		},
+	},
+	"proxy.session_idle_timeout": {
+		get: func(c *Config) string { return c.Proxy.SessionIdleTimeout },
+		set: func(c *Config, v string) error {
+			if _, err := utils.ParseDuration(v); err != nil {
+				return fmt.Errorf("invalid value for proxy.session_idle_timeout: %w", err)
+			}
+			c.Proxy.SessionIdleTimeout = v
+			return nil
+		},
+	},
+	"proxy.workers": {
+		get: func(c *Config) string {
+			if c.Proxy.Workers == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(c.Proxy.Workers), 10)
+		},
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for proxy.workers: %w", err)
+			}
+			c.Proxy.Workers = uint(n)
+			return nil
+		},
+	},
+	"proxy.queue_size": {
+		get: func(c *Config) string {
+			if c.Proxy.QueueSize == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(c.Proxy.QueueSize), 10)
+		},
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for proxy.queue_size: %w", err)
+			}
+			c.Proxy.QueueSize = uint(n)
+			return nil
+		},
+	},
+	"proxy.overflow_policy": {
+		get: func(c *Config) string { return c.Proxy.OverflowPolicy },
+		set: func(c *Config, v string) error {
+			switch v {
+			case "", "drop-new", "drop-oldest", "block":
+			default:
+				return fmt.Errorf("invalid value for proxy.overflow_policy: %q (supported: drop-new, drop-oldest, block)", v)
+			}
+			c.Proxy.OverflowPolicy = v
+			return nil
+		},
+	},
+	"proxy.enqueue_timeout": {
+		get: func(c *Config) string { return c.Proxy.EnqueueTimeout },
+		set: func(c *Config, v string) error {
+			if _, err := utils.ParseDuration(v); err != nil {
+				return fmt.Errorf("invalid value for proxy.enqueue_timeout: %w", err)
+			}
+			c.Proxy.EnqueueTimeout = v
+			return nil
+		},
+	},
+	"proxy.job_timeout": {
+		get: func(c *Config) string { return c.Proxy.JobTimeout },
+		set: func(c *Config, v string) error {
+			if _, err := utils.ParseDuration(v); err != nil {
+				return fmt.Errorf("invalid value for proxy.job_timeout: %w", err)
+			}
+			c.Proxy.JobTimeout = v
+			return nil
+		},
+	},
+	"tls.enabled": {
+		get: func(c *Config) string {
+			if !c.TLS.Enabled {
+				return ""
+			}
+			return strconv.FormatBool(c.TLS.Enabled)
+		},
+		set: func(c *Config, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for tls.enabled: %w", err)
+			}
+			c.TLS.Enabled = b
+			return nil
+		},
+	},
+	"tls.cert_file": {
+		get: func(c *Config) string { return c.TLS.CertFile },
+		set: func(c *Config, v string) error { c.TLS.CertFile = v; return nil },
+	},
+	"tls.key_file": {
+		get: func(c *Config) string { return c.TLS.KeyFile },
+		set: func(c *Config, v string) error { c.TLS.KeyFile = v; return nil },
+	},
+	"tls.client_ca_file": {
+		get: func(c *Config) string { return c.TLS.ClientCAFile },
+		set: func(c *Config, v string) error { c.TLS.ClientCAFile = v; return nil },
+	},
 	"client.proxy_target": {
 		get: func(c *Config) string { return c.Client.ProxyTarget },
 		set: func(c *Config, v string) error { c.Client.ProxyTarget = v; return nil },
@@ -149,4 +723,273 @@ var configKeys = map[string]configKeyInfo{
 		get: func(c *Config) string { return c.OpenCode.Model },
 		set: func(c *Config, v string) error { c.OpenCode.Model = v; return nil },
 	},
+	"redaction.enabled": {
+		get: func(c *Config) string {
+			if !c.Redaction.Enabled {
+				return ""
+			}
+			return strconv.FormatBool(c.Redaction.Enabled)
+		},
+		set: func(c *Config, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for redaction.enabled: %w", err)
+			}
+			c.Redaction.Enabled = b
+			return nil
+		},
+	},
+	"redaction.rules_file": {
+		get: func(c *Config) string { return c.Redaction.RulesFile },
+		set: func(c *Config, v string) error { c.Redaction.RulesFile = v; return nil },
+	},
+	"retention.max_age": {
+		get: func(c *Config) string { return c.Retention.MaxAge },
+		set: func(c *Config, v string) error {
+			if _, err := utils.ParseDuration(v); err != nil {
+				return fmt.Errorf("invalid value for retention.max_age: %w", err)
+			}
+			c.Retention.MaxAge = v
+			return nil
+		},
+	},
+	"retention.max_db_size": {
+		get: func(c *Config) string { return c.Retention.MaxDBSize },
+		set: func(c *Config, v string) error {
+			if _, err := utils.ParseSize(v); err != nil {
+				return fmt.Errorf("invalid value for retention.max_db_size: %w", err)
+			}
+			c.Retention.MaxDBSize = v
+			return nil
+		},
+	},
+	"retention.max_sessions": {
+		get: func(c *Config) string {
+			if c.Retention.MaxSessions == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(c.Retention.MaxSessions), 10)
+		},
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for retention.max_sessions: %w", err)
+			}
+			c.Retention.MaxSessions = uint(n)
+			return nil
+		},
+	},
+	"retention.gc": {
+		get: func(c *Config) string {
+			if !c.Retention.GC {
+				return ""
+			}
+			return strconv.FormatBool(c.Retention.GC)
+		},
+		set: func(c *Config, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for retention.gc: %w", err)
+			}
+			c.Retention.GC = b
+			return nil
+		},
+	},
+	"telemetry.otlp_endpoint": {
+		get: func(c *Config) string { return c.Telemetry.OTLPEndpoint },
+		set: func(c *Config, v string) error { c.Telemetry.OTLPEndpoint = v; return nil },
+	},
+	"summarizer.enabled": {
+		get: func(c *Config) string {
+			if !c.Summarizer.Enabled {
+				return ""
+			}
+			return strconv.FormatBool(c.Summarizer.Enabled)
+		},
+		set: func(c *Config, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for summarizer.enabled: %w", err)
+			}
+			c.Summarizer.Enabled = b
+			return nil
+		},
+	},
+	"summarizer.provider": {
+		get: func(c *Config) string { return c.Summarizer.Provider },
+		set: func(c *Config, v string) error { c.Summarizer.Provider = v; return nil },
+	},
+	"summarizer.model": {
+		get: func(c *Config) string { return c.Summarizer.Model },
+		set: func(c *Config, v string) error { c.Summarizer.Model = v; return nil },
+	},
+	"summarizer.idle_after": {
+		get: func(c *Config) string { return c.Summarizer.IdleAfter },
+		set: func(c *Config, v string) error {
+			if _, err := utils.ParseDuration(v); err != nil {
+				return fmt.Errorf("invalid value for summarizer.idle_after: %w", err)
+			}
+			c.Summarizer.IdleAfter = v
+			return nil
+		},
+	},
+	"titles.provider": {
+		get: func(c *Config) string { return c.Titles.Provider },
+		set: func(c *Config, v string) error { c.Titles.Provider = v; return nil },
+	},
+	"titles.model": {
+		get: func(c *Config) string { return c.Titles.Model },
+		set: func(c *Config, v string) error { c.Titles.Model = v; return nil },
+	},
+	"report.slack_webhook": {
+		get:    func(c *Config) string { return c.Report.SlackWebhook },
+		set:    func(c *Config, v string) error { c.Report.SlackWebhook = v; return nil },
+		secret: true,
+	},
+	"report.slack_daily_at": {
+		get: func(c *Config) string { return c.Report.SlackDailyAt },
+		set: func(c *Config, v string) error {
+			if _, err := report.ParseDailyTime(v); err != nil {
+				return fmt.Errorf("invalid value for report.slack_daily_at: %w", err)
+			}
+			c.Report.SlackDailyAt = v
+			return nil
+		},
+	},
+	"logs.level": {
+		get: func(c *Config) string { return c.Logs.Level },
+		set: func(c *Config, v string) error {
+			if _, err := zapcore.ParseLevel(v); err != nil {
+				return fmt.Errorf("invalid value for logs.level: %w", err)
+			}
+			c.Logs.Level = v
+			return nil
+		},
+	},
+	"logs.max_file_size": {
+		get: func(c *Config) string { return c.Logs.MaxFileSize },
+		set: func(c *Config, v string) error {
+			if _, err := utils.ParseSize(v); err != nil {
+				return fmt.Errorf("invalid value for logs.max_file_size: %w", err)
+			}
+			c.Logs.MaxFileSize = v
+			return nil
+		},
+	},
+	"logs.max_files": {
+		get: func(c *Config) string {
+			if c.Logs.MaxFiles == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(c.Logs.MaxFiles), 10)
+		},
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for logs.max_files: %w", err)
+			}
+			c.Logs.MaxFiles = uint(n)
+			return nil
+		},
+	},
+	"health.probe_interval": {
+		get: func(c *Config) string { return c.Health.ProbeInterval },
+		set: func(c *Config, v string) error {
+			d, err := utils.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for health.probe_interval: %w", err)
+			}
+			if d <= 0 {
+				return errors.New("invalid value for health.probe_interval: must be positive")
+			}
+			c.Health.ProbeInterval = v
+			return nil
+		},
+	},
+	"start.confirm_recording": {
+		get: func(c *Config) string {
+			if !c.Start.ConfirmRecording {
+				return ""
+			}
+			return strconv.FormatBool(c.Start.ConfirmRecording)
+		},
+		set: func(c *Config, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for start.confirm_recording: %w", err)
+			}
+			c.Start.ConfirmRecording = b
+			return nil
+		},
+	},
+	"start.codex_config": {
+		get: func(c *Config) string {
+			if !c.Start.CodexConfig {
+				return ""
+			}
+			return strconv.FormatBool(c.Start.CodexConfig)
+		},
+		set: func(c *Config, v string) error {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("invalid value for start.codex_config: %w", err)
+			}
+			c.Start.CodexConfig = b
+			return nil
+		},
+	},
+	"start.codex_wire_api": {
+		get: func(c *Config) string { return c.Start.CodexWireAPI },
+		set: func(c *Config, v string) error {
+			switch v {
+			case "", "responses", "chat":
+			default:
+				return fmt.Errorf("invalid value for start.codex_wire_api: %q (expected responses or chat)", v)
+			}
+			c.Start.CodexWireAPI = v
+			return nil
+		},
+	},
+	"start.run_token_ttl": {
+		get: func(c *Config) string { return c.Start.RunTokenTTL },
+		set: func(c *Config, v string) error {
+			if _, err := utils.ParseDuration(v); err != nil {
+				return fmt.Errorf("invalid value for start.run_token_ttl: %w", err)
+			}
+			c.Start.RunTokenTTL = v
+			return nil
+		},
+	},
+	"start.run_token_max_requests": {
+		get: func(c *Config) string {
+			if c.Start.RunTokenMaxRequests == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(c.Start.RunTokenMaxRequests), 10)
+		},
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for start.run_token_max_requests: %w", err)
+			}
+			c.Start.RunTokenMaxRequests = uint(n)
+			return nil
+		},
+	},
+	"start.run_token_max_input_tokens": {
+		get: func(c *Config) string {
+			if c.Start.RunTokenMaxInputTokens == 0 {
+				return ""
+			}
+			return strconv.FormatUint(uint64(c.Start.RunTokenMaxInputTokens), 10)
+		},
+		set: func(c *Config, v string) error {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value for start.run_token_max_input_tokens: %w", err)
+			}
+			c.Start.RunTokenMaxInputTokens = uint(n)
+			return nil
+		},
+	},
 }
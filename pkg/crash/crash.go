@@ -0,0 +1,218 @@
+// Package crash records reports of panics recovered in long-running tapes
+// processes, so that a payload that trips a bug costs one turn or request
+// rather than the whole capture session.
+//
+// Reports hold the panic, its stack, and metadata describing the work that
+// panicked, never the content of the turn, so they are safe to attach to a
+// bug report.
+package crash
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+const (
+	// DirName is the directory under .tapes/ that holds crash reports.
+	DirName = "crashes"
+
+	// MaxReports is the number of reports kept: recording one more removes
+	// the oldest.
+	MaxReports = 100
+
+	reportExt = ".json"
+)
+
+// Report describes a recovered panic.
+type Report struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	Version   string    `json:"version"`
+	Component string    `json:"component"`
+	Panic     string    `json:"panic"`
+	Stack     string    `json:"stack"`
+
+	// Metadata describes the work that panicked, such as the provider and
+	// model of a turn, without its content.
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Recorder writes crash reports to a directory. A nil Recorder writes
+// nothing. It is safe for concurrent use.
+type Recorder struct {
+	dir string
+
+	// mu guards last, the time of the last report.
+	mu   sync.Mutex
+	last time.Time
+}
+
+// NewRecorder returns a Recorder writing reports to dir, which is created
+// on the first report.
+func NewRecorder(dir string) *Recorder {
+	return &Recorder{dir: dir}
+}
+
+// Record builds the report of a panic with value, recovered in component,
+// with the stack of the calling goroutine, and writes it. Call it from the
+// deferred function that recovered the panic, so the stack shows where the
+// panic happened. The report is returned even when writing it fails.
+func (r *Recorder) Record(component string, value any, metadata map[string]string) (Report, error) {
+	report := Report{
+		Time:      time.Now().UTC(),
+		Version:   utils.Version,
+		Component: component,
+		Panic:     fmt.Sprint(value),
+		Stack:     string(debug.Stack()),
+		Metadata:  metadata,
+	}
+	if r == nil {
+		report.ID = newID(report.Time)
+		return report, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Keep IDs in order of recording, which List and prune rely on.
+	if !report.Time.After(r.last) {
+		report.Time = r.last.Add(time.Microsecond)
+	}
+	r.last = report.Time
+	report.ID = newID(report.Time)
+
+	if err := os.MkdirAll(r.dir, 0o700); err != nil {
+		return report, fmt.Errorf("creating crash dir: %w", err)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return report, err
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, report.ID+reportExt), data, 0o600); err != nil {
+		return report, fmt.Errorf("writing crash report: %w", err)
+	}
+	return report, r.prune()
+}
+
+// prune removes the oldest reports beyond MaxReports.
+func (r *Recorder) prune() error {
+	names, err := reportNames(r.dir)
+	if err != nil {
+		return err
+	}
+	for len(names) > MaxReports {
+		if err := os.Remove(filepath.Join(r.dir, names[0])); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("removing old crash report: %w", err)
+		}
+		names = names[1:]
+	}
+	return nil
+}
+
+// Log records a recovered panic and logs it, with the path of its report
+// when one was written.
+func (r *Recorder) Log(logger *zap.Logger, component string, value any, metadata map[string]string) Report {
+	report, err := r.Record(component, value, metadata)
+	fields := []zap.Field{
+		zap.String("component", component),
+		zap.String("panic", report.Panic),
+		zap.String("crash_id", report.ID),
+	}
+	for _, key := range slices.Sorted(maps.Keys(metadata)) {
+		fields = append(fields, zap.String(key, metadata[key]))
+	}
+	if err != nil {
+		fields = append(fields, zap.NamedError("report_error", err))
+	}
+	logger.Error("recovered from panic", fields...)
+	return report
+}
+
+// Middleware recovers panics in the handlers after it, recording a report
+// with the request's method and path and answering with a 500 error.
+func (r *Recorder) Middleware(logger *zap.Logger, component string) fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			value := recover()
+			if value == nil {
+				return
+			}
+			report := r.Log(logger, component, value, map[string]string{
+				"method": c.Method(),
+				"path":   c.Path(),
+			})
+			err = c.Status(fiber.StatusInternalServerError).JSON(llm.ErrorResponse{
+				Error: "internal error: tapes recovered from a crash (report " + report.ID + ")",
+			})
+		}()
+		return c.Next()
+	}
+}
+
+// List returns the reports in dir, newest first. A missing dir has none.
+func List(dir string) ([]Report, error) {
+	names, err := reportNames(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]Report, 0, len(names))
+	for _, name := range slices.Backward(names) {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("reading crash report: %w", err)
+		}
+		var report Report
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, fmt.Errorf("parsing crash report %s: %w", name, err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// reportNames returns the names of the report files in dir, oldest first.
+func reportNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading crash dir: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), reportExt) {
+			names = append(names, entry.Name())
+		}
+	}
+	// IDs start with the time, so names sort oldest first.
+	slices.Sort(names)
+	return names, nil
+}
+
+// newID returns an ID that sorts by t, with a random suffix for processes
+// recording at the same time.
+func newID(t time.Time) string {
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return t.Format("20060102-150405.000000") + "-" + hex.EncodeToString(suffix)
+}
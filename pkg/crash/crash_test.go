@@ -0,0 +1,101 @@
+package crash_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"github.com/gofiber/fiber/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/crash"
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+var _ = Describe("Recorder", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = filepath.Join(GinkgoT().TempDir(), crash.DirName)
+	})
+
+	recordPanic := func(r *crash.Recorder, value any, meta map[string]string) (report crash.Report, err error) {
+		defer func() {
+			report, err = r.Record("worker", recover(), meta)
+		}()
+		panic(value)
+	}
+
+	It("writes a report with the panic, its stack, and metadata", func() {
+		report, err := recordPanic(crash.NewRecorder(dir), "boom", map[string]string{"provider": "anthropic"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Panic).To(Equal("boom"))
+		Expect(report.Stack).To(ContainSubstring("crash_test.go"))
+
+		reports, err := crash.List(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reports).To(HaveLen(1))
+		Expect(reports[0].ID).To(Equal(report.ID))
+		Expect(reports[0].Component).To(Equal("worker"))
+		Expect(reports[0].Metadata).To(Equal(map[string]string{"provider": "anthropic"}))
+	})
+
+	It("builds reports without writing them when nil", func() {
+		var recorder *crash.Recorder
+		report, err := recordPanic(recorder, "boom", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Panic).To(Equal("boom"))
+		Expect(dir).NotTo(BeADirectory())
+	})
+
+	It("lists reports newest first and keeps only the newest", func() {
+		recorder := crash.NewRecorder(dir)
+		var ids []string
+		for range crash.MaxReports + 2 {
+			report, err := recordPanic(recorder, "boom", nil)
+			Expect(err).NotTo(HaveOccurred())
+			ids = append(ids, report.ID)
+		}
+
+		reports, err := crash.List(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reports).To(HaveLen(crash.MaxReports))
+		Expect(reports[0].ID).To(Equal(ids[len(ids)-1]))
+		Expect(reports[len(reports)-1].ID).To(Equal(ids[2]))
+	})
+
+	It("lists no reports for a missing dir", func() {
+		reports, err := crash.List(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reports).To(BeEmpty())
+	})
+
+	It("recovers panics in handlers with a 500 error", func() {
+		app := fiber.New()
+		app.Use(crash.NewRecorder(dir).Middleware(zap.NewNop(), "api"))
+		app.Get("/boom", func(*fiber.Ctx) error { panic("bad payload") })
+
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/boom?q=secret", nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(fiber.StatusInternalServerError))
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		var apiErr llm.ErrorResponse
+		Expect(json.Unmarshal(body, &apiErr)).To(Succeed())
+
+		reports, err := crash.List(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reports).To(HaveLen(1))
+		Expect(apiErr.Error).To(ContainSubstring(reports[0].ID))
+		Expect(reports[0].Component).To(Equal("api"))
+		Expect(reports[0].Metadata).To(Equal(map[string]string{"method": "GET", "path": "/boom"}))
+
+		entries, err := os.ReadDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+})
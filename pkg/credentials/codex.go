@@ -1,20 +1,58 @@
 package credentials
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/BurntSushi/toml"
 )
 
-// ReadCodexAuthFile reads ~/.codex/auth.json and returns its contents and path.
-// Returns nil, "" if the file cannot be read.
-func ReadCodexAuthFile() ([]byte, string) {
+// CodexHomeEnv overrides the directory codex keeps its state in.
+const CodexHomeEnv = "CODEX_HOME"
+
+// CodexProvider is the model provider ID tapes adds to codex's config.toml.
+const CodexProvider = "tapes"
+
+// Codex wire APIs: the OpenAI endpoint codex sends turns to through a model
+// provider.
+const (
+	CodexWireResponses = "responses"
+	CodexWireChat      = "chat"
+)
+
+// CodexHome returns the directory codex keeps its state in: $CODEX_HOME when
+// set, otherwise ~/.codex (%USERPROFILE%\.codex on Windows).
+func CodexHome() (string, error) {
+	if dir := os.Getenv(CodexHomeEnv); dir != "" {
+		return dir, nil
+	}
 	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".codex"), nil
+}
+
+// CodexAuthPath returns the path of auth.json in the codex home directory.
+func CodexAuthPath() (string, error) {
+	dir, err := CodexHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "auth.json"), nil
+}
+
+// ReadCodexAuthFile reads auth.json from the codex home directory and returns
+// its contents and path. Returns nil, "" if the file cannot be read.
+func ReadCodexAuthFile() ([]byte, string) {
+	authPath, err := CodexAuthPath()
 	if err != nil {
 		return nil, ""
 	}
 
-	authPath := filepath.Join(home, ".codex", "auth.json")
 	data, err := os.ReadFile(authPath)
 	if err != nil {
 		return nil, ""
@@ -50,3 +88,42 @@ func PatchCodexAuthKey(data []byte, apiKey string) ([]byte, bool) {
 
 	return updated, true
 }
+
+// CodexConfigPath returns the path of config.toml in the codex home directory.
+func CodexConfigPath() (string, error) {
+	dir, err := CodexHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.toml"), nil
+}
+
+// PatchCodexConfig adds a CodexProvider model provider sending turns to
+// baseURL over wireAPI to the codex config.toml in data, which may be empty,
+// and selects it as the model provider. The key comes from OPENAI_API_KEY.
+// Other settings are kept, but comments and formatting are not.
+func PatchCodexConfig(data []byte, baseURL, wireAPI string) ([]byte, error) {
+	cfg := map[string]any{}
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing codex config: %w", err)
+	}
+
+	providers, ok := cfg["model_providers"].(map[string]any)
+	if !ok {
+		providers = map[string]any{}
+		cfg["model_providers"] = providers
+	}
+	providers[CodexProvider] = map[string]any{
+		"name":     "tapes",
+		"base_url": baseURL,
+		"env_key":  "OPENAI_API_KEY",
+		"wire_api": wireAPI,
+	}
+	cfg["model_provider"] = CodexProvider
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+		return nil, fmt.Errorf("encoding codex config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
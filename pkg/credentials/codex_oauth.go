@@ -0,0 +1,188 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// CodexTokenURL is where codex exchanges its ChatGPT refresh token for
+	// new tokens.
+	CodexTokenURL = "https://auth.openai.com/oauth/token"
+
+	// codexClientID is the OAuth client codex signs in to ChatGPT as.
+	codexClientID = "app_EMoamEEZ73f0CkXaXp7hrann"
+
+	// codexRefreshTimeout bounds a refresh-token exchange.
+	codexRefreshTimeout = 30 * time.Second
+)
+
+// CodexTokens are the ChatGPT OAuth tokens codex keeps under "tokens" in its
+// auth.json.
+type CodexTokens struct {
+	IDToken      string `json:"id_token,omitempty"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	AccountID    string `json:"account_id,omitempty"`
+}
+
+// ReadCodexTokens returns the ChatGPT tokens in the codex auth JSON, or nil
+// when it holds none.
+func ReadCodexTokens(data []byte) *CodexTokens {
+	var auth struct {
+		Tokens *CodexTokens `json:"tokens"`
+	}
+	if err := json.Unmarshal(data, &auth); err != nil || auth.Tokens == nil || auth.Tokens.AccessToken == "" {
+		return nil
+	}
+	return auth.Tokens
+}
+
+// UpdateCodexTokens replaces the ChatGPT tokens in the codex auth JSON with
+// tokens, stamps last_refresh with now, and returns the updated bytes. Other
+// fields are kept.
+func UpdateCodexTokens(data []byte, tokens CodexTokens, now time.Time) ([]byte, error) {
+	var auth map[string]json.RawMessage
+	if err := json.Unmarshal(data, &auth); err != nil {
+		return nil, fmt.Errorf("parsing codex auth: %w", err)
+	}
+
+	tokensJSON, err := json.Marshal(tokens)
+	if err != nil {
+		return nil, err
+	}
+	auth["tokens"] = tokensJSON
+	refreshedJSON, err := json.Marshal(now.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, err
+	}
+	auth["last_refresh"] = refreshedJSON
+
+	return json.MarshalIndent(auth, "", "  ")
+}
+
+// CodexRefresher refreshes the ChatGPT access token of codex with the refresh
+// token stored in its auth.json, and writes the rotated tokens back, so that
+// codex and later refreshes use them. It is safe for concurrent use.
+type CodexRefresher struct {
+	// TokenURL is the OAuth token endpoint. Defaults to CodexTokenURL.
+	TokenURL string
+
+	// Client sends the refresh-token exchange. Defaults to a client with a
+	// 30 second timeout.
+	Client *http.Client
+
+	authPath string
+
+	mu sync.Mutex
+	// rotated holds the access tokens replaced by a refresh, since codex
+	// keeps sending the token it loaded until it restarts.
+	rotated map[string]struct{}
+}
+
+// NewCodexRefresher creates a refresher for the codex auth.json at authPath.
+func NewCodexRefresher(authPath string) *CodexRefresher {
+	return &CodexRefresher{
+		TokenURL: CodexTokenURL,
+		Client:   &http.Client{Timeout: codexRefreshTimeout},
+		authPath: authPath,
+		rotated:  make(map[string]struct{}),
+	}
+}
+
+// Refresh returns an access token replacing expired, and reports whether it
+// exchanged the refresh token for it. It returns "" when expired is not the
+// access token in auth.json or one it replaced, so that other credentials are
+// never swapped for codex's.
+func (r *CodexRefresher) Refresh(ctx context.Context, expired string) (string, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := os.ReadFile(r.authPath)
+	if err != nil {
+		return "", false, fmt.Errorf("reading codex auth: %w", err)
+	}
+	tokens := ReadCodexTokens(data)
+	switch {
+	case tokens == nil:
+		return "", false, nil
+	case tokens.AccessToken != expired:
+		// The token was already refreshed, by a concurrent request or by
+		// codex itself.
+		if _, ok := r.rotated[expired]; ok {
+			return tokens.AccessToken, false, nil
+		}
+		return "", false, nil
+	case tokens.RefreshToken == "":
+		return "", false, errors.New("codex auth has no refresh token: run 'codex login'")
+	}
+
+	fresh, err := r.exchange(ctx, tokens.RefreshToken)
+	if err != nil {
+		return "", false, err
+	}
+	updated := *tokens
+	updated.AccessToken = fresh.AccessToken
+	if fresh.RefreshToken != "" {
+		updated.RefreshToken = fresh.RefreshToken
+	}
+	if fresh.IDToken != "" {
+		updated.IDToken = fresh.IDToken
+	}
+
+	// The refresh token may be single use, so the rotated tokens must be
+	// kept even though codex still holds the old ones.
+	data, err = UpdateCodexTokens(data, updated, time.Now())
+	if err != nil {
+		return "", false, err
+	}
+	if err := os.WriteFile(r.authPath, data, 0o600); err != nil {
+		return "", false, fmt.Errorf("writing codex auth: %w", err)
+	}
+
+	r.rotated[expired] = struct{}{}
+	return updated.AccessToken, true, nil
+}
+
+// exchange trades refreshToken for new tokens at the token endpoint.
+func (r *CodexRefresher) exchange(ctx context.Context, refreshToken string) (*CodexTokens, error) {
+	body, err := json.Marshal(map[string]string{
+		"client_id":     codexClientID,
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+		"scope":         "openid profile email",
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.TokenURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating refresh request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("refreshing codex token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("refreshing codex token: %s returned %s: run 'codex login'", r.TokenURL, resp.Status)
+	}
+	var fresh CodexTokens
+	if err := json.NewDecoder(resp.Body).Decode(&fresh); err != nil {
+		return nil, fmt.Errorf("decoding refreshed codex token: %w", err)
+	}
+	if fresh.AccessToken == "" {
+		return nil, errors.New("refreshing codex token: no access token returned")
+	}
+	return &fresh, nil
+}
@@ -0,0 +1,100 @@
+package credentials_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/credentials"
+)
+
+var _ = Describe("CodexRefresher", func() {
+	var (
+		authPath  string
+		exchanges []map[string]string
+		status    int
+		refresher *credentials.CodexRefresher
+	)
+
+	BeforeEach(func() {
+		authPath = filepath.Join(GinkgoT().TempDir(), "auth.json")
+		Expect(os.WriteFile(authPath, []byte(`{
+  "OPENAI_API_KEY": null,
+  "tokens": {"id_token": "id-1", "access_token": "access-1", "refresh_token": "refresh-1", "account_id": "acct"},
+  "last_refresh": "2026-01-01T00:00:00Z"
+}`), 0o600)).To(Succeed())
+
+		exchanges = nil
+		status = http.StatusOK
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]string
+			Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			exchanges = append(exchanges, body)
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"access_token":"access-2","refresh_token":"refresh-2"}`))
+		}))
+		DeferCleanup(server.Close)
+
+		refresher = credentials.NewCodexRefresher(authPath)
+		refresher.TokenURL = server.URL
+	})
+
+	readTokens := func() *credentials.CodexTokens {
+		data, err := os.ReadFile(authPath)
+		Expect(err).NotTo(HaveOccurred())
+		return credentials.ReadCodexTokens(data)
+	}
+
+	It("exchanges the refresh token and persists the rotated tokens", func() {
+		token, rotated, err := refresher.Refresh(context.Background(), "access-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("access-2"))
+		Expect(rotated).To(BeTrue())
+
+		Expect(exchanges).To(HaveLen(1))
+		Expect(exchanges[0]).To(HaveKeyWithValue("grant_type", "refresh_token"))
+		Expect(exchanges[0]).To(HaveKeyWithValue("refresh_token", "refresh-1"))
+
+		Expect(*readTokens()).To(Equal(credentials.CodexTokens{
+			IDToken:      "id-1",
+			AccessToken:  "access-2",
+			RefreshToken: "refresh-2",
+			AccountID:    "acct",
+		}))
+		data, err := os.ReadFile(authPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("2026-01-01T00:00:00Z"))
+	})
+
+	It("hands out the refreshed token for a replaced one without exchanging again", func() {
+		_, _, err := refresher.Refresh(context.Background(), "access-1")
+		Expect(err).NotTo(HaveOccurred())
+
+		token, rotated, err := refresher.Refresh(context.Background(), "access-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(Equal("access-2"))
+		Expect(rotated).To(BeFalse())
+		Expect(exchanges).To(HaveLen(1))
+	})
+
+	It("ignores tokens that are not codex's", func() {
+		token, rotated, err := refresher.Refresh(context.Background(), "sk-other")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).To(BeEmpty())
+		Expect(rotated).To(BeFalse())
+		Expect(exchanges).To(BeEmpty())
+	})
+
+	It("keeps auth.json when the exchange fails", func() {
+		status = http.StatusUnauthorized
+		_, _, err := refresher.Refresh(context.Background(), "access-1")
+		Expect(err).To(MatchError(ContainSubstring("codex login")))
+		Expect(readTokens().AccessToken).To(Equal("access-1"))
+	})
+})
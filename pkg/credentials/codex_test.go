@@ -8,6 +8,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/BurntSushi/toml"
+
 	"github.com/papercomputeco/tapes/pkg/credentials"
 )
 
@@ -21,6 +23,17 @@ var _ = Describe("ReadCodexAuthFile", func() {
 		}
 		Expect(path).To(BeEmpty())
 	})
+
+	It("reads auth.json from CODEX_HOME", func() {
+		dir := GinkgoT().TempDir()
+		GinkgoT().Setenv(credentials.CodexHomeEnv, dir)
+		authPath := filepath.Join(dir, "auth.json")
+		Expect(os.WriteFile(authPath, []byte(`{"OPENAI_API_KEY":"sk-test"}`), 0o600)).To(Succeed())
+
+		data, path := credentials.ReadCodexAuthFile()
+		Expect(path).To(Equal(authPath))
+		Expect(string(data)).To(ContainSubstring("sk-test"))
+	})
 })
 
 var _ = Describe("PatchCodexAuthKey", func() {
@@ -133,3 +146,43 @@ var _ = Describe("PatchCodexAuthKey", func() {
 		Expect(key).To(Equal("sk-file-test"))
 	})
 })
+
+var _ = Describe("PatchCodexConfig", func() {
+	It("selects a tapes model provider and keeps other settings", func() {
+		original := []byte(`model = "gpt-5-codex"
+model_provider = "openai"
+
+[model_providers.azure]
+name = "Azure"
+base_url = "https://example.openai.azure.com/openai"
+`)
+
+		updated, err := credentials.PatchCodexConfig(original, "http://localhost:8080/agents/codex", credentials.CodexWireResponses)
+		Expect(err).NotTo(HaveOccurred())
+
+		var cfg map[string]any
+		Expect(toml.Unmarshal(updated, &cfg)).To(Succeed())
+		Expect(cfg).To(HaveKeyWithValue("model", "gpt-5-codex"))
+		Expect(cfg).To(HaveKeyWithValue("model_provider", credentials.CodexProvider))
+
+		providers := cfg["model_providers"].(map[string]any)
+		Expect(providers).To(HaveKey("azure"))
+		Expect(providers[credentials.CodexProvider]).To(Equal(map[string]any{
+			"name":     "tapes",
+			"base_url": "http://localhost:8080/agents/codex",
+			"env_key":  "OPENAI_API_KEY",
+			"wire_api": "responses",
+		}))
+	})
+
+	It("writes a config when there is none", func() {
+		updated, err := credentials.PatchCodexConfig(nil, "http://localhost:8080", credentials.CodexWireChat)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(updated)).To(ContainSubstring(`wire_api = "chat"`))
+	})
+
+	It("rejects invalid TOML", func() {
+		_, err := credentials.PatchCodexConfig([]byte("model = "), "http://localhost:8080", credentials.CodexWireChat)
+		Expect(err).To(MatchError(ContainSubstring("parsing codex config")))
+	})
+})
@@ -0,0 +1,255 @@
+// Package dataset converts recorded conversations into the JSONL formats used
+// to fine-tune and evaluate models.
+//
+// Every conversation becomes one example line. Content is redacted before it
+// is written, and tool calls are either kept in the structure of the format
+// or flattened into plain text, for training models without tool support.
+package dataset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+	"github.com/papercomputeco/tapes/pkg/llm/provider/anthropic"
+	"github.com/papercomputeco/tapes/pkg/llm/provider/openai"
+	"github.com/papercomputeco/tapes/pkg/redact"
+)
+
+// Dataset formats.
+const (
+	// FormatOpenAIJSONL is the chat fine-tuning format of OpenAI: messages
+	// and tools as sent to Chat Completions.
+	FormatOpenAIJSONL = "openai-jsonl"
+
+	// FormatAnthropic holds the system prompt, messages, and tools of a
+	// Messages API request.
+	FormatAnthropic = "anthropic"
+
+	// FormatShareGPT is the ShareGPT conversation format, with tool calls
+	// and results as function_call and observation turns.
+	FormatShareGPT = "sharegpt"
+)
+
+// Formats returns the supported dataset formats.
+func Formats() []string {
+	return []string{FormatOpenAIJSONL, FormatAnthropic, FormatShareGPT}
+}
+
+// Options configure how examples are written.
+type Options struct {
+	Format string
+
+	// FlattenTools writes tool calls and results as text turns and drops
+	// tool definitions.
+	FlattenTools bool
+
+	// Redactor scrubs the content of every example. Nil writes content as
+	// recorded.
+	Redactor *redact.Redactor
+}
+
+// Writer writes conversations as dataset examples, one JSON line each.
+type Writer struct {
+	w    io.Writer
+	opts Options
+}
+
+// NewWriter creates a writer of examples in opts.Format to w.
+func NewWriter(w io.Writer, opts Options) (*Writer, error) {
+	if !slices.Contains(Formats(), opts.Format) {
+		return nil, fmt.Errorf("unsupported dataset format %q (expected %s)", opts.Format, strings.Join(Formats(), ", "))
+	}
+	return &Writer{w: w, opts: opts}, nil
+}
+
+// Write writes req as one example. Conversations with nothing the format
+// can carry are skipped.
+func (w *Writer) Write(req *llm.ChatRequest) error {
+	req = w.prepare(req)
+	if len(req.Messages) == 0 {
+		return nil
+	}
+
+	var (
+		example []byte
+		err     error
+	)
+	switch w.opts.Format {
+	case FormatOpenAIJSONL:
+		example, err = encodeFields(openai.New(), req, "messages", "tools")
+	case FormatAnthropic:
+		example, err = encodeFields(anthropic.New(), req, "system", "messages", "tools")
+	case FormatShareGPT:
+		example, err = encodeShareGPT(req)
+	}
+	if err != nil || example == nil {
+		return err
+	}
+
+	_, err = w.w.Write(append(example, '\n'))
+	return err
+}
+
+// prepare returns a copy of req that is redacted, and flattened when tools
+// are flattened.
+func (w *Writer) prepare(req *llm.ChatRequest) *llm.ChatRequest {
+	out := &llm.ChatRequest{
+		Model:    req.Model,
+		System:   req.System,
+		Messages: make([]llm.Message, 0, len(req.Messages)),
+		Tools:    req.Tools,
+	}
+	if w.opts.Redactor != nil {
+		out.System = w.opts.Redactor.RedactString(out.System)
+	}
+
+	for _, msg := range req.Messages {
+		content := msg.Content
+		if w.opts.Redactor != nil {
+			content = w.opts.Redactor.RedactBlocks(content)
+			for i := range content {
+				content[i].Thinking = w.opts.Redactor.RedactString(content[i].Thinking)
+			}
+		}
+		if w.opts.FlattenTools {
+			msg.Role, content = flattenTools(msg.Role, content)
+		}
+		if len(content) > 0 {
+			out.Messages = append(out.Messages, llm.Message{Role: msg.Role, Content: content})
+		}
+	}
+	if w.opts.FlattenTools {
+		out.Tools = nil
+	}
+	return out
+}
+
+// flattenTools rewrites tool calls and results as text blocks. Tool messages
+// become user messages, since tool results are then ordinary input.
+func flattenTools(role string, content []llm.ContentBlock) (string, []llm.ContentBlock) {
+	if role == "tool" {
+		role = "user"
+	}
+
+	flat := make([]llm.ContentBlock, 0, len(content))
+	for _, block := range content {
+		switch block.Type {
+		case "tool_use":
+			input, _ := json.Marshal(block.ToolInput)
+			flat = append(flat, llm.ContentBlock{Type: "text", Text: fmt.Sprintf("[tool call: %s] %s", block.ToolName, input)})
+		case "tool_result":
+			label := "tool result"
+			if block.IsError {
+				label = "tool error"
+			}
+			flat = append(flat, llm.ContentBlock{Type: "text", Text: fmt.Sprintf("[%s] %s", label, block.ToolOutput)})
+		default:
+			flat = append(flat, block)
+		}
+	}
+	return role, flat
+}
+
+// encodeFields encodes req as a request of p, keeping only fields, so that
+// examples carry the conversation but no generation parameters.
+func encodeFields(p provider.RequestEncoder, req *llm.ChatRequest, fields ...string) ([]byte, error) {
+	body, err := p.EncodeRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(body, &all); err != nil {
+		return nil, err
+	}
+	example := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			example[field] = value
+		}
+	}
+	return json.Marshal(example)
+}
+
+// shareGPTTurn is one turn of a ShareGPT conversation.
+type shareGPTTurn struct {
+	From  string `json:"from"`
+	Value string `json:"value"`
+}
+
+// shareGPTExample is a ShareGPT conversation. Tools holds the JSON of the
+// tool definitions, as ShareGPT loaders expect a string.
+type shareGPTExample struct {
+	Conversations []shareGPTTurn `json:"conversations"`
+	System        string         `json:"system,omitempty"`
+	Tools         string         `json:"tools,omitempty"`
+}
+
+// encodeShareGPT converts req into a ShareGPT conversation, or returns nil
+// when it has no text or tool turns. Consecutive text of one speaker is
+// joined into one turn, and media is left out.
+func encodeShareGPT(req *llm.ChatRequest) ([]byte, error) {
+	example := shareGPTExample{System: req.SystemPrompt()}
+
+	appendTurn := func(from, value string) {
+		if last := len(example.Conversations) - 1; last >= 0 && example.Conversations[last].From == from &&
+			(from == "human" || from == "gpt") {
+			example.Conversations[last].Value += "\n" + value
+			return
+		}
+		example.Conversations = append(example.Conversations, shareGPTTurn{From: from, Value: value})
+	}
+
+	for _, msg := range req.Messages {
+		if msg.Role == "system" {
+			continue
+		}
+		from := "human"
+		if msg.Role == "assistant" {
+			from = "gpt"
+		}
+		for _, block := range msg.Content {
+			switch block.Type {
+			case "text":
+				appendTurn(from, block.Text)
+			case "tool_use":
+				call, err := json.Marshal(map[string]any{"name": block.ToolName, "arguments": block.ToolInput})
+				if err != nil {
+					return nil, err
+				}
+				appendTurn("function_call", string(call))
+			case "tool_result":
+				appendTurn("observation", block.ToolOutput)
+			}
+		}
+	}
+	if len(example.Conversations) == 0 {
+		return nil, nil
+	}
+
+	if len(req.Tools) > 0 {
+		tools := make([]map[string]any, 0, len(req.Tools))
+		for _, tool := range req.Tools {
+			def := map[string]any{"name": tool.Name}
+			if tool.Description != "" {
+				def["description"] = tool.Description
+			}
+			if tool.Schema != nil {
+				def["parameters"] = tool.Schema
+			}
+			tools = append(tools, def)
+		}
+		data, err := json.Marshal(tools)
+		if err != nil {
+			return nil, err
+		}
+		example.Tools = string(data)
+	}
+
+	return json.Marshal(example)
+}
@@ -0,0 +1,108 @@
+package dataset_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/dataset"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/redact"
+)
+
+var _ = Describe("Writer", func() {
+	conversation := func() *llm.ChatRequest {
+		return &llm.ChatRequest{
+			Model:  "claude-sonnet-4-5",
+			System: "You are a coding agent.",
+			Messages: []llm.Message{
+				{Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "Deploy with key sk-ant-REDACTED."}}},
+				{Role: "assistant", Content: []llm.ContentBlock{
+					{Type: "tool_use", ToolUseID: "call_1", ToolName: "bash", ToolInput: map[string]any{"cmd": "deploy"}},
+				}},
+				{Role: "user", Content: []llm.ContentBlock{{Type: "tool_result", ToolResultID: "call_1", ToolOutput: "ok"}}},
+				{Role: "assistant", Content: []llm.ContentBlock{{Type: "text", Text: "Deployed."}}},
+			},
+			Tools: []llm.ToolDefinition{{Type: "function", Name: "bash", Schema: map[string]any{"type": "object"}}},
+		}
+	}
+
+	write := func(opts dataset.Options, reqs ...*llm.ChatRequest) string {
+		var buf bytes.Buffer
+		w, err := dataset.NewWriter(&buf, opts)
+		Expect(err).NotTo(HaveOccurred())
+		for _, req := range reqs {
+			Expect(w.Write(req)).To(Succeed())
+		}
+		return buf.String()
+	}
+
+	redactor := func() *redact.Redactor {
+		r, err := redact.NewRedactor(nil)
+		Expect(err).NotTo(HaveOccurred())
+		return r
+	}
+
+	It("writes OpenAI fine-tuning examples with tool calls", func() {
+		out := write(dataset.Options{Format: dataset.FormatOpenAIJSONL, Redactor: redactor()}, conversation())
+		Expect(out).To(HaveSuffix("\n"))
+		Expect(out).To(MatchJSON(`{
+			"messages": [
+				{"role": "system", "content": "You are a coding agent."},
+				{"role": "user", "content": "Deploy with key [REDACTED:anthropic_api_key]."},
+				{"role": "assistant", "content": null, "tool_calls": [
+					{"id": "call_1", "type": "function", "function": {"name": "bash", "arguments": "{\"cmd\":\"deploy\"}"}}
+				]},
+				{"role": "tool", "tool_call_id": "call_1", "content": "ok"},
+				{"role": "assistant", "content": "Deployed."}
+			],
+			"tools": [{"type": "function", "function": {"name": "bash", "parameters": {"type": "object"}}}]
+		}`))
+	})
+
+	It("writes Anthropic examples without generation parameters", func() {
+		out := write(dataset.Options{Format: dataset.FormatAnthropic}, conversation())
+		Expect(out).To(ContainSubstring(`"system":"You are a coding agent."`))
+		Expect(out).To(ContainSubstring(`{"content":"ok","tool_use_id":"call_1","type":"tool_result"}`))
+		Expect(out).NotTo(ContainSubstring("max_tokens"))
+		Expect(out).NotTo(ContainSubstring("model"))
+	})
+
+	It("writes ShareGPT conversations with function calls and observations", func() {
+		out := write(dataset.Options{Format: dataset.FormatShareGPT}, conversation())
+		Expect(out).To(MatchJSON(`{
+			"system": "You are a coding agent.",
+			"conversations": [
+				{"from": "human", "value": "Deploy with key sk-ant-REDACTED."},
+				{"from": "function_call", "value": "{\"arguments\":{\"cmd\":\"deploy\"},\"name\":\"bash\"}"},
+				{"from": "observation", "value": "ok"},
+				{"from": "gpt", "value": "Deployed."}
+			],
+			"tools": "[{\"name\":\"bash\",\"parameters\":{\"type\":\"object\"}}]"
+		}`))
+	})
+
+	It("flattens tool calls into text", func() {
+		out := write(dataset.Options{Format: dataset.FormatShareGPT, FlattenTools: true}, conversation())
+		Expect(out).To(MatchJSON(`{
+			"system": "You are a coding agent.",
+			"conversations": [
+				{"from": "human", "value": "Deploy with key sk-ant-REDACTED."},
+				{"from": "gpt", "value": "[tool call: bash] {\"cmd\":\"deploy\"}"},
+				{"from": "human", "value": "[tool result] ok"},
+				{"from": "gpt", "value": "Deployed."}
+			]
+		}`))
+	})
+
+	It("writes one line per conversation and skips empty ones", func() {
+		out := write(dataset.Options{Format: dataset.FormatOpenAIJSONL}, conversation(), &llm.ChatRequest{}, conversation())
+		Expect(bytes.Count([]byte(out), []byte("\n"))).To(Equal(2))
+	})
+
+	It("rejects unknown formats", func() {
+		_, err := dataset.NewWriter(&bytes.Buffer{}, dataset.Options{Format: "alpaca"})
+		Expect(err).To(MatchError(ContainSubstring(`unsupported dataset format "alpaca"`)))
+	})
+})
@@ -0,0 +1,81 @@
+package deck
+
+import (
+	"sort"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+)
+
+// cacheAccumulator collects prompt cache token counts per model and
+// provider. Turns are counted once even when several sessions share them.
+type cacheAccumulator struct {
+	pricing PricingTable
+	metrics map[latencyKey]*CacheMetric
+	seen    map[string]bool
+}
+
+func newCacheAccumulator(pricing PricingTable) *cacheAccumulator {
+	return &cacheAccumulator{
+		pricing: pricing,
+		metrics: map[latencyKey]*CacheMetric{},
+		seen:    map[string]bool{},
+	}
+}
+
+func (a *cacheAccumulator) add(n *ent.Node) {
+	if n.PromptTokens == nil || *n.PromptTokens == 0 || a.seen[n.ID] {
+		return
+	}
+	a.seen[n.ID] = true
+
+	key := latencyKey{model: normalizeModel(n.Model), provider: n.Provider}
+	m, ok := a.metrics[key]
+	if !ok {
+		m = &CacheMetric{Model: key.model, Provider: key.provider}
+		a.metrics[key] = m
+	}
+
+	t := tokenCounts(n)
+	m.Requests++
+	m.InputTokens += t.Input
+	m.CacheReadTokens += t.CacheRead
+	m.CacheWriteTokens += t.CacheCreation
+	if t.CacheRead > 0 {
+		m.CacheHits++
+	}
+	if pricing, ok := PricingForModel(a.pricing, key.model); ok {
+		m.Savings += cacheReadSavings(pricing, t.CacheRead)
+	}
+}
+
+// result returns the cache metrics per model and provider, largest input
+// first, along with the hit rate across all of them.
+func (a *cacheAccumulator) result() ([]CacheMetric, float64) {
+	metrics := make([]CacheMetric, 0, len(a.metrics))
+	var input, cacheRead int64
+	for _, m := range a.metrics {
+		m.HitRate = cacheHitRate(m.CacheReadTokens, m.InputTokens)
+		input += m.InputTokens
+		cacheRead += m.CacheReadTokens
+		metrics = append(metrics, *m)
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].InputTokens != metrics[j].InputTokens {
+			return metrics[i].InputTokens > metrics[j].InputTokens
+		}
+		if metrics[i].Model != metrics[j].Model {
+			return metrics[i].Model < metrics[j].Model
+		}
+		return metrics[i].Provider < metrics[j].Provider
+	})
+	return metrics, cacheHitRate(cacheRead, input)
+}
+
+// cacheHitRate is the share of input tokens served from the prompt cache.
+func cacheHitRate(cacheRead, input int64) float64 {
+	if input == 0 {
+		return 0
+	}
+	return float64(cacheRead) / float64(input)
+}
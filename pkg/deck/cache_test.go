@@ -0,0 +1,82 @@
+package deck
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("Cache analytics", func() {
+	It("reports prompt cache hit rates and savings per model", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(model, provider, text string, parent *merkle.Node, usage *llm.Usage) *merkle.Node {
+			role := "user"
+			if usage != nil {
+				role = "assistant"
+			}
+			n := merkle.NewNode(merkle.Bucket{
+				Type:     "message",
+				Role:     role,
+				Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:    model,
+				Provider: provider,
+			}, parent, merkle.NodeMeta{Usage: usage})
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+
+		// The first Claude turn writes the cache and the second reads it.
+		var parent *merkle.Node
+		usages := []*llm.Usage{
+			{PromptTokens: 1_000_000, CompletionTokens: 100, CacheCreationInputTokens: 900_000},
+			{PromptTokens: 1_000_000, CompletionTokens: 100, CacheReadInputTokens: 900_000},
+		}
+		for i, usage := range usages {
+			prompt := put("claude-sonnet-4-20250514", "anthropic", fmt.Sprintf("question %d", i), parent, nil)
+			parent = put("claude-sonnet-4-20250514", "anthropic", fmt.Sprintf("answer %d", i), prompt, usage)
+		}
+
+		prompt := put("gpt-4o", "openai", "hello", nil, nil)
+		put("gpt-4o", "openai", "hi", prompt, &llm.Usage{PromptTokens: 1000, CompletionTokens: 10})
+		Expect(driver.Close()).To(Succeed())
+
+		q, closeFn, err := NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		defer closeFn()
+
+		overview, err := q.AnalyticsOverview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overview.Cache).To(HaveLen(2))
+
+		claude := overview.Cache[0]
+		Expect(claude.Model).To(Equal("claude-sonnet-4"))
+		Expect(claude.Provider).To(Equal("anthropic"))
+		Expect(claude.Requests).To(Equal(2))
+		Expect(claude.CacheHits).To(Equal(1))
+		Expect(claude.InputTokens).To(Equal(int64(2_000_000)))
+		Expect(claude.CacheReadTokens).To(Equal(int64(900_000)))
+		Expect(claude.CacheWriteTokens).To(Equal(int64(900_000)))
+		Expect(claude.HitRate).To(BeNumerically("~", 0.45, 1e-9))
+		// 0.9M tokens read at $0.30 instead of $3.00 per million.
+		Expect(claude.Savings).To(BeNumerically("~", 2.43, 1e-9))
+
+		gpt := overview.Cache[1]
+		Expect(gpt.Model).To(Equal("gpt-4o"))
+		Expect(gpt.HitRate).To(BeZero())
+
+		Expect(overview.CacheHitRate).To(BeNumerically("~", 900_000.0/2_001_000.0, 1e-9))
+	})
+})
@@ -0,0 +1,142 @@
+package deck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// Conversations iterates over the conversation of every session matching
+// filters, rebuilt as a request by ForkRequest from the session's last turn.
+// A session group yields each of its conversations, oldest first. Iteration
+// stops at the first error.
+func (q *Query) Conversations(ctx context.Context, filters Filters) iter.Seq2[*llm.ChatRequest, error] {
+	return func(yield func(*llm.ChatRequest, error) bool) {
+		for session, err := range q.Sessions(ctx, filters) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			leaves, err := q.sessionLeaves(ctx, session.ID)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, leaf := range leaves {
+				req, err := q.ForkRequest(ctx, leaf)
+				if !yield(req, err) || err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// sessionLeaves returns the last turn of each conversation in a session.
+func (q *Query) sessionLeaves(ctx context.Context, sessionID string) ([]string, error) {
+	if !isGroupID(sessionID) {
+		return []string{sessionID}, nil
+	}
+
+	candidates, err := q.loadSessionCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	group := findGroupByID(groupSessionCandidates(candidates), sessionID)
+	if group == nil {
+		return nil, fmt.Errorf("get session group: %s", sessionID)
+	}
+
+	members := make([]SessionSummary, 0, len(group.members))
+	for _, member := range group.members {
+		members = append(members, member.summary)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].StartTime.Before(members[j].StartTime)
+	})
+
+	leaves := make([]string, 0, len(members))
+	for _, member := range members {
+		leaves = append(leaves, member.ID)
+	}
+	return leaves, nil
+}
+
+// promptText joins the text a user typed into a turn, leaving out the tool
+// results that agents send as user turns.
+func promptText(blocks []llm.ContentBlock) string {
+	var texts []string
+	for _, block := range blocks {
+		if block.Type == "text" && block.Text != "" {
+			texts = append(texts, block.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// AssistantTurns iterates over the assistant turns of every session matching
+// filters, in conversation order. Turns shared by several sessions, such as the
+// common prefix of forked conversations, are yielded once. Iteration stops at the first error.
+func (q *Query) AssistantTurns(ctx context.Context, filters Filters) iter.Seq2[AssistantTurn, error] {
+	return func(yield func(AssistantTurn, error) bool) {
+		if q.driver == nil {
+			yield(AssistantTurn{}, errors.New("reading turns requires a SQLite-backed query"))
+			return
+		}
+
+		seen := map[string]bool{}
+		for session, err := range q.Sessions(ctx, filters) {
+			if err != nil {
+				yield(AssistantTurn{}, err)
+				return
+			}
+
+			leaves, err := q.sessionLeaves(ctx, session.ID)
+			if err != nil {
+				yield(AssistantTurn{}, err)
+				return
+			}
+			for _, leaf := range leaves {
+				nodes, err := q.driver.Ancestry(ctx, leaf)
+				if err != nil {
+					yield(AssistantTurn{}, err)
+					return
+				}
+				slices.Reverse(nodes)
+
+				prompt := ""
+				for _, n := range nodes {
+					switch n.Bucket.Role {
+					case "user":
+						if text := promptText(n.Bucket.Content); text != "" {
+							prompt = text
+						}
+					case "assistant":
+						if seen[n.Hash] {
+							continue
+						}
+						seen[n.Hash] = true
+						turn := AssistantTurn{
+							Hash:             n.Hash,
+							Session:          session.ID,
+							Model:            n.Bucket.Model,
+							SystemPromptHash: n.SystemPromptHash,
+							Prompt:           prompt,
+							Content:          n.Bucket.Content,
+						}
+						if !yield(turn, nil) {
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package deck
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("Conversations", func() {
+	var (
+		ctx context.Context
+		q   *Query
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(role, text, model string, parent *merkle.Node) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:    "message",
+				Role:    role,
+				Content: []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:   model,
+			}, parent)
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+		put("assistant", "main.go", "gpt-4o", put("user", "List the files.", "gpt-4o", nil))
+		put("assistant", "Hi.", "claude-sonnet-4-5", put("user", "Hello.", "claude-sonnet-4-5", nil))
+		Expect(driver.Close()).To(Succeed())
+
+		var closeFn func() error
+		q, closeFn, err = NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	collect := func(filters Filters) []*llm.ChatRequest {
+		var reqs []*llm.ChatRequest
+		for req, err := range q.Conversations(ctx, filters) {
+			Expect(err).NotTo(HaveOccurred())
+			reqs = append(reqs, req)
+		}
+		return reqs
+	}
+
+	It("yields the conversation of every matching session", func() {
+		reqs := collect(Filters{})
+		Expect(reqs).To(HaveLen(2))
+		for _, req := range reqs {
+			Expect(req.Messages).To(HaveLen(2))
+		}
+
+		reqs = collect(Filters{Model: "gpt-4o"})
+		Expect(reqs).To(HaveLen(1))
+		Expect(reqs[0].Messages[1].GetText()).To(Equal("main.go"))
+	})
+})
+
+var _ = Describe("AssistantTurns", func() {
+	It("yields each assistant reply once with the prompt that led to it", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(role, text string, parent *merkle.Node) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:    "message",
+				Role:    role,
+				Content: []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:   "gpt-4o",
+			}, parent, merkle.NodeMeta{SystemPromptHash: "p1"})
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+		first := put("assistant", "main.go", put("user", "List the files.", nil))
+		put("assistant", "Done.", put("user", "Delete it.", first))
+		put("assistant", "Kept.", put("user", "Keep it.", first))
+		Expect(driver.Close()).To(Succeed())
+
+		q, closeFn, err := NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+
+		prompts := map[string]string{}
+		for turn, err := range q.AssistantTurns(ctx, Filters{}) {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(turn.SystemPromptHash).To(Equal("p1"))
+			Expect(prompts).NotTo(HaveKey(turn.Content[0].Text))
+			prompts[turn.Content[0].Text] = turn.Prompt
+		}
+		Expect(prompts).To(HaveKeyWithValue("main.go", "List the files."))
+		Expect(prompts).To(HaveKeyWithValue("Done.", "Delete it."))
+		Expect(prompts).To(HaveKeyWithValue("Kept.", "Keep it."))
+	})
+})
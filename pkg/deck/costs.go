@@ -0,0 +1,174 @@
+package deck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+)
+
+// Cost report groupings.
+const (
+	CostGroupModel    = "model"
+	CostGroupProvider = "provider"
+	CostGroupDay      = "day"
+	CostGroupProject  = "project"
+	CostGroupUser     = "user"
+	CostGroupRun      = "run"
+)
+
+// CostGroupings lists the supported CostOptions.GroupBy values.
+func CostGroupings() []string {
+	return []string{CostGroupModel, CostGroupProvider, CostGroupDay, CostGroupProject, CostGroupUser, CostGroupRun}
+}
+
+// unknownCostKey labels turns with no value for the grouping field.
+const unknownCostKey = "(unknown)"
+
+// Costs aggregates token usage and spend across all captured turns, grouped
+// by model, provider, day, project, user, or agent run. Each turn is counted once even when
+// it is shared by several session branches. Rows are ordered by total cost,
+// highest first, except day groupings which are ordered by date.
+func (q *Query) Costs(ctx context.Context, opts CostOptions) (*CostReport, error) {
+	groupBy := opts.GroupBy
+	if groupBy == "" {
+		groupBy = CostGroupModel
+	}
+
+	var keyFor func(n *ent.Node, model string) string
+	switch groupBy {
+	case CostGroupModel:
+		keyFor = func(_ *ent.Node, model string) string { return model }
+	case CostGroupProvider:
+		keyFor = func(n *ent.Node, _ string) string { return n.Provider }
+	case CostGroupDay:
+		keyFor = func(n *ent.Node, _ string) string { return n.CreatedAt.Local().Format(time.DateOnly) }
+	case CostGroupProject:
+		keyFor = func(n *ent.Node, _ string) string { return derefString(n.Project) }
+	case CostGroupUser:
+		keyFor = func(n *ent.Node, _ string) string { return derefString(n.User) }
+	case CostGroupRun:
+		keyFor = func(n *ent.Node, _ string) string { return derefString(n.RunID) }
+	default:
+		return nil, fmt.Errorf("unsupported cost grouping %q (expected one of %v)", groupBy, CostGroupings())
+	}
+
+	predicates := []predicate.Node{
+		node.Or(node.PromptTokensNotNil(), node.CompletionTokensNotNil()),
+	}
+	if opts.Since > 0 {
+		predicates = append(predicates, node.CreatedAtGTE(time.Now().Add(-opts.Since)))
+	}
+
+	nodes, err := q.client.Node.Query().
+		Where(predicates...).
+		Select(
+			node.FieldModel,
+			node.FieldProvider,
+			node.FieldProject,
+			node.FieldUser,
+			node.FieldRunID,
+			node.FieldCreatedAt,
+			node.FieldPromptTokens,
+			node.FieldCompletionTokens,
+			node.FieldTotalTokens,
+			node.FieldCacheCreationInputTokens,
+			node.FieldCacheReadInputTokens,
+			node.FieldReasoningTokens,
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query usage: %w", err)
+	}
+
+	report := &CostReport{GroupBy: groupBy}
+	rows := map[string]*CostRow{}
+	for _, n := range nodes {
+		model := normalizeModel(n.Model)
+		key := keyFor(n, model)
+		if key == "" {
+			key = unknownCostKey
+		}
+		row, ok := rows[key]
+		if !ok {
+			row = &CostRow{Key: key}
+			rows[key] = row
+		}
+
+		t := tokenCounts(n)
+		row.Requests++
+		row.InputTokens += t.Input
+		row.OutputTokens += t.Output
+		row.ReasoningTokens += t.Reasoning
+		row.CacheWriteTokens += t.CacheCreation
+		row.CacheReadTokens += t.CacheRead
+
+		pricing, ok := PricingForModel(q.pricing, model)
+		if !ok {
+			row.UnpricedRequests++
+			continue
+		}
+		inputCost, outputCost, totalCost := CostForTokensWithCache(pricing, t.Input, t.Output, t.CacheCreation, t.CacheRead)
+		row.InputCost += inputCost
+		row.OutputCost += outputCost
+		row.ReasoningCost += float64(t.Reasoning) / 1_000_000.0 * pricing.Output
+		row.TotalCost += totalCost
+		row.CacheSavings += cacheReadSavings(pricing, t.CacheRead)
+	}
+
+	report.Rows = make([]CostRow, 0, len(rows))
+	for _, row := range rows {
+		report.Rows = append(report.Rows, *row)
+		report.Total.add(*row)
+	}
+	report.Total.Key = "total"
+
+	sort.Slice(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if groupBy == CostGroupDay {
+			return a.Key < b.Key
+		}
+		if a.TotalCost != b.TotalCost {
+			return a.TotalCost > b.TotalCost
+		}
+		return a.Key < b.Key
+	})
+
+	return report, nil
+}
+
+// cacheReadSavings returns how much cheaper cache reads were than paying
+// the full input rate for the same tokens.
+func cacheReadSavings(pricing Pricing, cacheRead int64) float64 {
+	if cacheRead == 0 || pricing.CacheRead >= pricing.Input {
+		return 0
+	}
+	return float64(cacheRead) / 1_000_000.0 * (pricing.Input - pricing.CacheRead)
+}
+
+func (r *CostRow) add(other CostRow) {
+	r.Requests += other.Requests
+	r.UnpricedRequests += other.UnpricedRequests
+	r.InputTokens += other.InputTokens
+	r.OutputTokens += other.OutputTokens
+	r.ReasoningTokens += other.ReasoningTokens
+	r.CacheWriteTokens += other.CacheWriteTokens
+	r.CacheReadTokens += other.CacheReadTokens
+	r.InputCost += other.InputCost
+	r.OutputCost += other.OutputCost
+	r.ReasoningCost += other.ReasoningCost
+	r.CacheSavings += other.CacheSavings
+	r.TotalCost += other.TotalCost
+}
+
+// derefString returns the value of an optional string field, or "" when unset.
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,236 @@
+package deck
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("Costs", func() {
+	var (
+		ctx context.Context
+		q   *Query
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Each project's turns belong to one teammate
+		users := map[string]string{"tapes": "alice", "legacy": "bob"}
+		// and was made in one tapes start run.
+		runs := map[string]string{"tapes": "run-1", "legacy": "run-2"}
+		turn := func(model, provider, project string, usage *llm.Usage, parent *merkle.Node) *merkle.Node {
+			return merkle.NewNode(merkle.Bucket{
+				Type:     "message",
+				Role:     "assistant",
+				Content:  []llm.ContentBlock{{Type: "text", Text: model + " reply for " + project}},
+				Model:    model,
+				Provider: provider,
+			}, parent, merkle.NodeMeta{Project: project, User: users[project], RunID: runs[project], Usage: usage})
+		}
+
+		cached := turn("claude-sonnet-4-20250514", "anthropic", "tapes", &llm.Usage{
+			PromptTokens:         1_000_000,
+			CompletionTokens:     100_000,
+			CacheReadInputTokens: 800_000,
+		}, nil)
+		_, err = driver.Put(ctx, cached)
+		Expect(err).NotTo(HaveOccurred())
+
+		// A child turn on the same branch, billed separately.
+		_, err = driver.Put(ctx, turn("gpt-4o", "openai", "", &llm.Usage{
+			PromptTokens:     1_000_000,
+			CompletionTokens: 1_000_000,
+		}, cached))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = driver.Put(ctx, turn("mystery-model", "ollama", "tapes", &llm.Usage{PromptTokens: 10}, nil))
+		Expect(err).NotTo(HaveOccurred())
+
+		// Turns without usage are not billed.
+		_, err = driver.Put(ctx, turn("gpt-4o", "openai", "scratch", nil, nil))
+		Expect(err).NotTo(HaveOccurred())
+
+		old := turn("gpt-4o", "openai", "legacy", &llm.Usage{PromptTokens: 2_000_000}, nil)
+		Expect(createEntNode(ctx, driver.Client, old, time.Now().Add(-60*24*time.Hour))).To(Succeed())
+
+		Expect(driver.Close()).To(Succeed())
+
+		var closeFn func() error
+		q, closeFn, err = NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	It("groups spend by model with cache-read discounts", func() {
+		report, err := q.Costs(ctx, CostOptions{GroupBy: CostGroupModel, Since: 30 * 24 * time.Hour})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Rows).To(HaveLen(3))
+
+		// gpt-4o: 1M input at $2.50 + 1M output at $10.00.
+		Expect(report.Rows[0].Key).To(Equal("gpt-4o"))
+		Expect(report.Rows[0].Requests).To(Equal(1))
+		Expect(report.Rows[0].TotalCost).To(BeNumerically("~", 12.50, 1e-9))
+
+		// claude-sonnet-4: 200k input at $3.00 + 800k cache reads at $0.30
+		// + 100k output at $15.00, saving 800k * ($3.00 - $0.30).
+		sonnet := report.Rows[1]
+		Expect(sonnet.Key).To(Equal("claude-sonnet-4"))
+		Expect(sonnet.CacheReadTokens).To(Equal(int64(800_000)))
+		Expect(sonnet.InputCost).To(BeNumerically("~", 0.84, 1e-9))
+		Expect(sonnet.TotalCost).To(BeNumerically("~", 2.34, 1e-9))
+		Expect(sonnet.CacheSavings).To(BeNumerically("~", 2.16, 1e-9))
+
+		Expect(report.Rows[2].Key).To(Equal("mystery-model"))
+		Expect(report.Rows[2].UnpricedRequests).To(Equal(1))
+		Expect(report.Rows[2].TotalCost).To(BeZero())
+
+		Expect(report.Total.Requests).To(Equal(3))
+		Expect(report.Total.TotalCost).To(BeNumerically("~", 14.84, 1e-9))
+	})
+
+	It("groups by project and includes older turns without a since window", func() {
+		report, err := q.Costs(ctx, CostOptions{GroupBy: CostGroupProject})
+		Expect(err).NotTo(HaveOccurred())
+
+		keys := make([]string, 0, len(report.Rows))
+		for _, row := range report.Rows {
+			keys = append(keys, row.Key)
+		}
+		Expect(keys).To(ConsistOf("tapes", "legacy", unknownCostKey))
+		Expect(report.Total.Requests).To(Equal(4))
+	})
+
+	It("groups by the user each turn is attributed to", func() {
+		report, err := q.Costs(ctx, CostOptions{GroupBy: CostGroupUser})
+		Expect(err).NotTo(HaveOccurred())
+
+		requests := map[string]int{}
+		for _, row := range report.Rows {
+			requests[row.Key] = row.Requests
+		}
+		Expect(requests).To(Equal(map[string]int{"alice": 2, "bob": 1, unknownCostKey: 1}))
+	})
+
+	It("groups by the agent run each turn was made in", func() {
+		report, err := q.Costs(ctx, CostOptions{GroupBy: CostGroupRun})
+		Expect(err).NotTo(HaveOccurred())
+
+		requests := map[string]int{}
+		for _, row := range report.Rows {
+			requests[row.Key] = row.Requests
+		}
+		Expect(requests).To(Equal(map[string]int{"run-1": 2, "run-2": 1, unknownCostKey: 1}))
+	})
+
+	It("orders day groupings by date", func() {
+		report, err := q.Costs(ctx, CostOptions{GroupBy: CostGroupDay})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Rows).To(HaveLen(2))
+		Expect(report.Rows[0].Key < report.Rows[1].Key).To(BeTrue())
+		Expect(report.Rows[1].Key).To(Equal(time.Now().Format(time.DateOnly)))
+	})
+
+	It("rejects unknown groupings", func() {
+		_, err := q.Costs(ctx, CostOptions{GroupBy: "team"})
+		Expect(err).To(MatchError(ContainSubstring("unsupported cost grouping")))
+	})
+})
+
+var _ = Describe("Costs with reasoning tokens", func() {
+	It("bills reasoning tokens as output", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(text string, usage *llm.Usage) {
+			_, err := driver.Put(ctx, merkle.NewNode(merkle.Bucket{
+				Type:     "message",
+				Role:     "assistant",
+				Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:    "o3",
+				Provider: "openai",
+			}, nil, merkle.NodeMeta{Usage: usage}))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		// Reasoning included in the completion count, as OpenAI reports it.
+		put("first", &llm.Usage{CompletionTokens: 500_000, ReasoningTokens: 400_000})
+		// Reasoning reported apart from a smaller completion count.
+		put("second", &llm.Usage{CompletionTokens: 100_000, ReasoningTokens: 400_000})
+		Expect(driver.Close()).To(Succeed())
+
+		q, closeFn, err := NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		defer closeFn()
+
+		report, err := q.Costs(ctx, CostOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Rows).To(HaveLen(1))
+
+		// 1M output tokens at $8.00, 800k of them reasoning.
+		o3 := report.Rows[0]
+		Expect(o3.OutputTokens).To(Equal(int64(1_000_000)))
+		Expect(o3.ReasoningTokens).To(Equal(int64(800_000)))
+		Expect(o3.OutputCost).To(BeNumerically("~", 8.00, 1e-9))
+		Expect(o3.ReasoningCost).To(BeNumerically("~", 6.40, 1e-9))
+		Expect(report.Total.ReasoningTokens).To(Equal(int64(800_000)))
+	})
+})
+
+var _ = Describe("Costs with usage records", func() {
+	It("bills embeddings without listing them as sessions", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(kind, model, text string, usage *llm.Usage) {
+			_, err := driver.Put(ctx, merkle.NewNode(merkle.Bucket{
+				Type:     kind,
+				Role:     "assistant",
+				Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:    model,
+				Provider: "openai",
+			}, nil, merkle.NodeMeta{Usage: usage}))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		put("message", "gpt-4o", "hello", &llm.Usage{PromptTokens: 1000, CompletionTokens: 10})
+		put(llm.RecordEmbedding, "text-embedding-3-small", "embedding: 1 input(s), 5 bytes", &llm.Usage{PromptTokens: 2_000_000, TotalTokens: 2_000_000})
+		Expect(driver.Close()).To(Succeed())
+
+		q, closeFn, err := NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		defer closeFn()
+
+		report, err := q.Costs(ctx, CostOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Rows).To(HaveLen(2))
+
+		// 2M embedding tokens at $0.02 per million.
+		embedding := report.Rows[0]
+		Expect(embedding.Key).To(Equal("text-embedding-3-small"))
+		Expect(embedding.InputTokens).To(Equal(int64(2_000_000)))
+		Expect(embedding.TotalCost).To(BeNumerically("~", 0.04, 1e-9))
+
+		overview, err := q.Overview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overview.Sessions).To(HaveLen(1))
+		Expect(overview.Sessions[0].Model).To(Equal("gpt-4o"))
+	})
+})
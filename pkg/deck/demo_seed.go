@@ -13,6 +13,7 @@ import (
 	"github.com/papercomputeco/tapes/pkg/llm"
 	"github.com/papercomputeco/tapes/pkg/merkle"
 	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	entdriver "github.com/papercomputeco/tapes/pkg/storage/ent/driver"
 	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
 )
 
@@ -192,6 +193,12 @@ func createEntNode(ctx context.Context, client *ent.Client, node *merkle.Node, c
 	if node.Project != "" {
 		create.SetProject(node.Project)
 	}
+	if node.User != "" {
+		create.SetUser(node.User)
+	}
+	if node.RunID != "" {
+		create.SetRunID(node.RunID)
+	}
 
 	bucketJSON, err := json.Marshal(node.Bucket)
 	if err != nil {
@@ -214,6 +221,7 @@ func createEntNode(ctx context.Context, client *ent.Client, node *merkle.Node, c
 		return fmt.Errorf("unmarshal content: %w", err)
 	}
 	create.SetContent(contentSlice)
+	create.SetToolNames(entdriver.ToolNames(contentSlice))
 
 	if node.Usage != nil {
 		if node.Usage.PromptTokens > 0 {
@@ -0,0 +1,239 @@
+package deck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+)
+
+// Line diff operations.
+const (
+	DiffEqual  = "="
+	DiffInsert = "+"
+	DiffDelete = "-"
+)
+
+// maxDiffLines bounds the line diff table; longer texts are reported as a
+// whole-text replacement.
+const maxDiffLines = 2000
+
+// DiffSessions compares the conversations ending at hashes a and b. Turns
+// are aligned by their shared Merkle prefix: identical turns have identical
+// hashes, so the last shared node is where the conversations diverged. The
+// turns after it are paired by position. Either hash may be a unique prefix.
+func (q *Query) DiffSessions(ctx context.Context, a, b string) (*SessionDiff, error) {
+	chainA, err := q.loadDiffChain(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	chainB, err := q.loadDiffChain(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	summaryA, _, _, err := q.buildSessionSummaryFromNodes(chainA)
+	if err != nil {
+		return nil, err
+	}
+	summaryB, _, _, err := q.buildSessionSummaryFromNodes(chainB)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &SessionDiff{
+		A:                 summaryA,
+		B:                 summaryB,
+		CostDelta:         summaryB.TotalCost - summaryA.TotalCost,
+		InputTokensDelta:  summaryB.InputTokens - summaryA.InputTokens,
+		OutputTokensDelta: summaryB.OutputTokens - summaryA.OutputTokens,
+		ToolCallsDelta:    summaryB.ToolCalls - summaryA.ToolCalls,
+	}
+
+	for diff.CommonTurns < min(len(chainA), len(chainB)) &&
+		chainA[diff.CommonTurns].ID == chainB[diff.CommonTurns].ID {
+		diff.CommonTurns++
+	}
+	if diff.CommonTurns > 0 {
+		diff.CommonHash = chainA[diff.CommonTurns-1].ID
+	}
+
+	for i := diff.CommonTurns; i < max(len(chainA), len(chainB)); i++ {
+		td := TurnDiff{Index: i}
+		if i < len(chainA) {
+			td.A = q.diffTurn(chainA[i])
+		}
+		if i < len(chainB) {
+			td.B = q.diffTurn(chainB[i])
+		}
+		if td.A != nil && td.B != nil {
+			td.Same = td.A.Role == td.B.Role && td.A.Text == td.B.Text &&
+				strings.Join(td.A.Tools, "\x00") == strings.Join(td.B.Tools, "\x00")
+			if td.A.Text != td.B.Text {
+				td.TextDiff = DiffLines(td.A.Text, td.B.Text)
+			}
+		}
+		diff.Turns = append(diff.Turns, td)
+	}
+
+	diff.ToolUsage = diffToolUsage(chainA, chainB)
+	return diff, nil
+}
+
+// loadDiffChain resolves hash to a node and returns its root-first ancestry.
+func (q *Query) loadDiffChain(ctx context.Context, hash string) ([]*ent.Node, error) {
+	if isGroupID(hash) {
+		return nil, fmt.Errorf("diff session %s: session groups cannot be diffed, use a turn hash", hash)
+	}
+
+	leaf, err := q.client.Node.Get(ctx, hash)
+	if ent.IsNotFound(err) {
+		leaf, err = q.nodeByPrefix(ctx, hash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("diff session %s: %w", hash, err)
+	}
+
+	return q.loadAncestry(ctx, leaf)
+}
+
+// nodeByPrefix returns the only node whose hash starts with prefix.
+func (q *Query) nodeByPrefix(ctx context.Context, prefix string) (*ent.Node, error) {
+	if prefix == "" {
+		return nil, errors.New("empty hash")
+	}
+
+	matches, err := q.client.Node.Query().
+		Where(predicate.Node(sql.FieldHasPrefix(node.FieldID, prefix))).
+		Limit(2).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no node matches %q", prefix)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("%q matches more than one node", prefix)
+	}
+}
+
+func (q *Query) diffTurn(n *ent.Node) *DiffTurn {
+	blocks, _ := parseContentBlocks(n.Content)
+	tokens := tokenCounts(n)
+	_, _, cost := q.costForNode(n, tokens)
+
+	return &DiffTurn{
+		Hash:         n.ID,
+		Role:         n.Role,
+		Model:        n.Model,
+		Text:         extractText(blocks),
+		Tools:        extractToolCalls(blocks),
+		InputTokens:  tokens.Input,
+		OutputTokens: tokens.Output,
+		Cost:         cost,
+	}
+}
+
+func diffToolUsage(chainA, chainB []*ent.Node) []ToolUsageDiff {
+	counts := map[string]*ToolUsageDiff{}
+	tally := func(chain []*ent.Node, side func(*ToolUsageDiff) *int) {
+		for _, n := range chain {
+			blocks, _ := parseContentBlocks(n.Content)
+			for _, tool := range extractToolCalls(blocks) {
+				if counts[tool] == nil {
+					counts[tool] = &ToolUsageDiff{Name: tool}
+				}
+				*side(counts[tool])++
+			}
+		}
+	}
+	tally(chainA, func(t *ToolUsageDiff) *int { return &t.A })
+	tally(chainB, func(t *ToolUsageDiff) *int { return &t.B })
+
+	usage := make([]ToolUsageDiff, 0, len(counts))
+	for _, t := range counts {
+		usage = append(usage, *t)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].A+usage[i].B != usage[j].A+usage[j].B {
+			return usage[i].A+usage[i].B > usage[j].A+usage[j].B
+		}
+		return usage[i].Name < usage[j].Name
+	})
+	return usage
+}
+
+// DiffLines returns a line diff that turns a into b, computed from their
+// longest common subsequence of lines.
+func DiffLines(a, b string) []DiffLine {
+	linesA := splitDiffLines(a)
+	linesB := splitDiffLines(b)
+
+	if len(linesA) > maxDiffLines || len(linesB) > maxDiffLines {
+		out := make([]DiffLine, 0, len(linesA)+len(linesB))
+		for _, line := range linesA {
+			out = append(out, DiffLine{Op: DiffDelete, Text: line})
+		}
+		for _, line := range linesB {
+			out = append(out, DiffLine{Op: DiffInsert, Text: line})
+		}
+		return out
+	}
+
+	// lcs[i][j] is the LCS length of linesA[i:] and linesB[j:].
+	lcs := make([][]int, len(linesA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(linesB)+1)
+	}
+	for i := len(linesA) - 1; i >= 0; i-- {
+		for j := len(linesB) - 1; j >= 0; j-- {
+			if linesA[i] == linesB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	out := make([]DiffLine, 0, len(linesA)+len(linesB))
+	i, j := 0, 0
+	for i < len(linesA) && j < len(linesB) {
+		switch {
+		case linesA[i] == linesB[j]:
+			out = append(out, DiffLine{Op: DiffEqual, Text: linesA[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, DiffLine{Op: DiffDelete, Text: linesA[i]})
+			i++
+		default:
+			out = append(out, DiffLine{Op: DiffInsert, Text: linesB[j]})
+			j++
+		}
+	}
+	for ; i < len(linesA); i++ {
+		out = append(out, DiffLine{Op: DiffDelete, Text: linesA[i]})
+	}
+	for ; j < len(linesB); j++ {
+		out = append(out, DiffLine{Op: DiffInsert, Text: linesB[j]})
+	}
+	return out
+}
+
+func splitDiffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,137 @@
+package deck
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("DiffLines", func() {
+	It("keeps common lines and marks changes", func() {
+		lines := DiffLines("one\ntwo\nthree\n", "one\n2\nthree\nfour")
+		Expect(lines).To(Equal([]DiffLine{
+			{Op: DiffEqual, Text: "one"},
+			{Op: DiffDelete, Text: "two"},
+			{Op: DiffInsert, Text: "2"},
+			{Op: DiffEqual, Text: "three"},
+			{Op: DiffInsert, Text: "four"},
+		}))
+	})
+
+	It("handles empty text", func() {
+		Expect(DiffLines("", "added")).To(Equal([]DiffLine{{Op: DiffInsert, Text: "added"}}))
+		Expect(DiffLines("", "")).To(BeEmpty())
+	})
+})
+
+var _ = Describe("DiffSessions", func() {
+	var (
+		ctx                context.Context
+		q                  *Query
+		shared             *merkle.Node
+		original, replayed *merkle.Node
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(role string, blocks []llm.ContentBlock, parent *merkle.Node, usage *llm.Usage) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:     "message",
+				Role:     role,
+				Content:  blocks,
+				Model:    "gpt-4o",
+				Provider: "openai",
+			}, parent, merkle.NodeMeta{Usage: usage})
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+		text := func(t string) []llm.ContentBlock { return []llm.ContentBlock{{Type: "text", Text: t}} }
+
+		root := put("user", text("fix the failing test"), nil, nil)
+		shared = put("assistant", []llm.ContentBlock{
+			{Type: "text", Text: "Let me look."},
+			{Type: "tool_use", ToolUseID: "call_1", ToolName: "Read"},
+		}, root, &llm.Usage{PromptTokens: 1000, CompletionTokens: 100})
+
+		result := put("user", []llm.ContentBlock{{Type: "tool_result", ToolResultID: "call_1", ToolOutput: "ok"}}, shared, nil)
+		original = put("assistant", text("The assertion is wrong.\nFixed it."), result,
+			&llm.Usage{PromptTokens: 2000, CompletionTokens: 200})
+
+		replayResult := put("user", []llm.ContentBlock{{Type: "tool_result", ToolResultID: "call_1", ToolOutput: "changed"}}, shared, nil)
+		replayAnswer := put("assistant", []llm.ContentBlock{
+			{Type: "text", Text: "The fixture is stale.\nFixed it."},
+			{Type: "tool_use", ToolUseID: "call_2", ToolName: "Edit"},
+		}, replayResult, &llm.Usage{PromptTokens: 3000, CompletionTokens: 300})
+		replayed = put("user", text("thanks"), replayAnswer, nil)
+		Expect(driver.Close()).To(Succeed())
+
+		var closeFn func() error
+		q, closeFn, err = NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	It("aligns turns by their shared prefix", func() {
+		diff, err := q.DiffSessions(ctx, original.Hash, replayed.Hash[:10])
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(diff.CommonTurns).To(Equal(2))
+		Expect(diff.CommonHash).To(Equal(shared.Hash))
+		Expect(diff.Turns).To(HaveLen(3))
+
+		Expect(diff.Turns[0].Index).To(Equal(2))
+		Expect(diff.Turns[0].Same).To(BeFalse())
+
+		answers := diff.Turns[1]
+		Expect(answers.A.Role).To(Equal("assistant"))
+		Expect(answers.B.Tools).To(Equal([]string{"Edit"}))
+		Expect(answers.TextDiff).To(Equal([]DiffLine{
+			{Op: DiffDelete, Text: "The assertion is wrong."},
+			{Op: DiffInsert, Text: "The fixture is stale."},
+			{Op: DiffEqual, Text: "Fixed it."},
+			{Op: DiffInsert, Text: "tool call: Edit"},
+		}))
+		Expect(answers.B.Cost).To(BeNumerically(">", answers.A.Cost))
+
+		Expect(diff.Turns[2].A).To(BeNil())
+		Expect(diff.Turns[2].B.Text).To(Equal("thanks"))
+	})
+
+	It("compares tool usage and totals", func() {
+		diff, err := q.DiffSessions(ctx, original.Hash, replayed.Hash)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(diff.ToolUsage).To(ConsistOf(
+			ToolUsageDiff{Name: "Read", A: 1, B: 1},
+			ToolUsageDiff{Name: "Edit", A: 0, B: 1},
+		))
+		Expect(diff.ToolCallsDelta).To(Equal(1))
+		Expect(diff.InputTokensDelta).To(Equal(int64(1000)))
+		Expect(diff.CostDelta).To(BeNumerically("~", diff.B.TotalCost-diff.A.TotalCost))
+		Expect(diff.CostDelta).To(BeNumerically(">", 0))
+	})
+
+	It("reports identical sessions", func() {
+		diff, err := q.DiffSessions(ctx, original.Hash, original.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.CommonTurns).To(Equal(4))
+		Expect(diff.Turns).To(BeEmpty())
+	})
+
+	It("returns an error for unknown sessions", func() {
+		_, err := q.DiffSessions(ctx, original.Hash, "does-not-exist")
+		Expect(err).To(MatchError(ContainSubstring("no node matches")))
+	})
+})
@@ -0,0 +1,79 @@
+package deck
+
+import (
+	"sort"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+)
+
+// errorAccumulator counts upstream errors against requests per model and
+// provider. Every assistant turn is a request, and error nodes are the ones
+// that failed. Turns are counted once even when several sessions share them.
+type errorAccumulator struct {
+	metrics map[latencyKey]*ErrorMetric
+	seen    map[string]bool
+}
+
+func newErrorAccumulator() *errorAccumulator {
+	return &errorAccumulator{
+		metrics: map[latencyKey]*ErrorMetric{},
+		seen:    map[string]bool{},
+	}
+}
+
+func (a *errorAccumulator) add(n *ent.Node) {
+	if n.Role != roleAssistant || a.seen[n.ID] {
+		return
+	}
+	a.seen[n.ID] = true
+
+	key := latencyKey{model: normalizeModel(n.Model), provider: n.Provider}
+	m, ok := a.metrics[key]
+	if !ok {
+		m = &ErrorMetric{Model: key.model, Provider: key.provider, Statuses: map[int]int{}}
+		a.metrics[key] = m
+	}
+
+	m.Requests++
+	if n.Type == nodeTypeError {
+		m.Errors++
+		if n.ErrorStatus != nil {
+			m.Statuses[*n.ErrorStatus]++
+		}
+	}
+}
+
+// result returns the error metrics per model and provider, most errors
+// first, along with the error rate across all of them.
+func (a *errorAccumulator) result() ([]ErrorMetric, float64) {
+	metrics := make([]ErrorMetric, 0, len(a.metrics))
+	var requests, errs int
+	for _, m := range a.metrics {
+		m.ErrorRate = errorRate(m.Errors, m.Requests)
+		requests += m.Requests
+		errs += m.Errors
+		metrics = append(metrics, *m)
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Errors != metrics[j].Errors {
+			return metrics[i].Errors > metrics[j].Errors
+		}
+		if metrics[i].Requests != metrics[j].Requests {
+			return metrics[i].Requests > metrics[j].Requests
+		}
+		if metrics[i].Model != metrics[j].Model {
+			return metrics[i].Model < metrics[j].Model
+		}
+		return metrics[i].Provider < metrics[j].Provider
+	})
+	return metrics, errorRate(errs, requests)
+}
+
+// errorRate is the share of requests that failed.
+func errorRate(errs, requests int) float64 {
+	if requests == 0 {
+		return 0
+	}
+	return float64(errs) / float64(requests)
+}
@@ -0,0 +1,73 @@
+package deck
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("Error analytics", func() {
+	It("reports upstream error rates per model", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(kind, role, text string, parent *merkle.Node, meta merkle.NodeMeta) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:     kind,
+				Role:     role,
+				Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:    "claude-sonnet-4-20250514",
+				Provider: "anthropic",
+			}, parent, meta)
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+
+		// The first attempt is rate limited and the retry succeeds.
+		prompt := put("message", "user", "hello", nil, merkle.NodeMeta{})
+		put("error", "assistant", "429 rate_limit_error: slow down", prompt, merkle.NodeMeta{
+			StopReason: "error",
+			Error:      &llm.UpstreamError{Status: 429, Type: "rate_limit_error", Message: "slow down"},
+		})
+		reply := put("message", "assistant", "hi", prompt, merkle.NodeMeta{StopReason: "end_turn"})
+
+		// The next turn fails outright.
+		next := put("message", "user", "and now?", reply, merkle.NodeMeta{})
+		put("error", "assistant", "529 overloaded_error: Overloaded", next, merkle.NodeMeta{
+			StopReason: "error",
+			Error:      &llm.UpstreamError{Status: 529, Type: "overloaded_error", Message: "Overloaded"},
+		})
+		Expect(driver.Close()).To(Succeed())
+
+		q, closeFn, err := NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		defer closeFn()
+
+		overview, err := q.AnalyticsOverview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overview.Errors).To(HaveLen(1))
+
+		claude := overview.Errors[0]
+		Expect(claude.Model).To(Equal("claude-sonnet-4"))
+		Expect(claude.Requests).To(Equal(3))
+		Expect(claude.Errors).To(Equal(2))
+		Expect(claude.Statuses).To(Equal(map[int]int{429: 1, 529: 1}))
+		Expect(claude.ErrorRate).To(BeNumerically("~", 2.0/3.0, 1e-9))
+		Expect(overview.ErrorRate).To(BeNumerically("~", 2.0/3.0, 1e-9))
+
+		sessions, err := q.Overview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sessions.Sessions).To(HaveLen(1))
+		Expect(sessions.Sessions[0].Status).To(Equal(StatusFailed))
+	})
+})
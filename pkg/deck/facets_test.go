@@ -29,6 +29,26 @@ func (m *mockQuerier) SessionAnalytics(_ context.Context, _ string) (*SessionAna
 	return &SessionAnalytics{}, nil
 }
 
+func (m *mockQuerier) Search(_ context.Context, _ SearchOptions) ([]SearchHit, error) {
+	return nil, nil
+}
+
+func (m *mockQuerier) SessionDetailPage(ctx context.Context, sessionID string, _ Page) (*SessionDetail, error) {
+	return m.SessionDetail(ctx, sessionID)
+}
+
+func (m *mockQuerier) SessionTree(_ context.Context, _ string) (*SessionTree, error) {
+	return nil, nil
+}
+
+func (m *mockQuerier) DiffSessions(_ context.Context, _, _ string) (*SessionDiff, error) {
+	return nil, nil
+}
+
+func (m *mockQuerier) Annotate(_ context.Context, _ string, _ *SessionAnnotation) error {
+	return nil
+}
+
 var _ = Describe("FacetExtractor", func() {
 	It("extracts facets from a session using a mock LLM", func() {
 		detail := &SessionDetail{
@@ -0,0 +1,92 @@
+package deck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+)
+
+// ForkRequest rebuilds the request that continues the conversation at hash:
+// every turn from the root up to and including that node, the system prompt
+// the conversation was sent with, and the tools its session offered. It uses
+// the model of the node. hash may be a unique prefix.
+func (q *Query) ForkRequest(ctx context.Context, hash string) (*llm.ChatRequest, error) {
+	if q.driver == nil {
+		return nil, errors.New("forking requires a SQLite-backed query")
+	}
+	if isGroupID(hash) {
+		return nil, fmt.Errorf("fork %s: session groups cannot be forked, use a turn hash", hash)
+	}
+
+	leaf, err := q.client.Node.Get(ctx, hash)
+	if ent.IsNotFound(err) {
+		leaf, err = q.nodeByPrefix(ctx, hash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fork %s: %w", hash, err)
+	}
+
+	nodes, err := q.driver.Ancestry(ctx, leaf.ID)
+	if err != nil {
+		return nil, fmt.Errorf("fork %s: %w", hash, err)
+	}
+	slices.Reverse(nodes)
+
+	req := &llm.ChatRequest{Model: leaf.Model}
+	var promptHash string
+	hasSystemMessages := false
+	for _, n := range nodes {
+		content := make([]llm.ContentBlock, 0, len(n.Bucket.Content))
+		for _, block := range n.Bucket.Content {
+			if block.Type != "truncated" {
+				content = append(content, block)
+			}
+		}
+		req.Messages = append(req.Messages, llm.Message{Role: n.Bucket.Role, Content: content})
+		hasSystemMessages = hasSystemMessages || n.Bucket.Role == "system"
+		if n.SystemPromptHash != "" {
+			promptHash = n.SystemPromptHash
+		}
+	}
+
+	// Providers that send the system prompt as messages have it in the
+	// conversation already.
+	if promptHash != "" && !hasSystemMessages {
+		if req.System, err = q.driver.GetSystemPrompt(ctx, promptHash); err != nil {
+			return nil, fmt.Errorf("fork %s: %w", hash, err)
+		}
+	}
+
+	if sessionID := derefString(leaf.SessionID); sessionID != "" {
+		if req.Tools, err = q.sessionToolDefinitions(ctx, sessionID); err != nil {
+			return nil, fmt.Errorf("fork %s: %w", hash, err)
+		}
+	}
+
+	return req, nil
+}
+
+// sessionToolDefinitions returns the tools offered in a session, keeping the
+// latest definition of tools whose definition changed during it.
+func (q *Query) sessionToolDefinitions(ctx context.Context, sessionID string) ([]llm.ToolDefinition, error) {
+	tools, err := q.driver.ListSessionTools(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var defs []llm.ToolDefinition
+	index := make(map[string]int, len(tools))
+	for _, tool := range tools {
+		if i, ok := index[tool.Tool.Name]; ok {
+			defs[i] = tool.Tool
+			continue
+		}
+		index[tool.Tool.Name] = len(defs)
+		defs = append(defs, tool.Tool)
+	}
+	return defs, nil
+}
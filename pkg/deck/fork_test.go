@@ -0,0 +1,98 @@
+package deck
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("ForkRequest", func() {
+	var (
+		ctx                     context.Context
+		q                       *Query
+		question, answer, again *merkle.Node
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		promptHash, err := driver.AddSystemPrompt(ctx, "You are a coding agent.")
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(role string, blocks []llm.ContentBlock, parent *merkle.Node, meta merkle.NodeMeta) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:      "message",
+				Role:      role,
+				Content:   blocks,
+				Model:     "claude-sonnet-4-5",
+				Provider:  "anthropic",
+				SessionID: "s1",
+			}, parent, meta)
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+		question = put("user", []llm.ContentBlock{{Type: "text", Text: "List the files."}}, nil, merkle.NodeMeta{})
+		answer = put("assistant", []llm.ContentBlock{
+			{Type: "text", Text: "main.go"},
+			{Type: "truncated", OriginalBytes: 4096},
+		}, question, merkle.NodeMeta{SystemPromptHash: promptHash})
+		again = put("user", []llm.ContentBlock{{Type: "text", Text: "Read it."}}, answer, merkle.NodeMeta{})
+
+		bash := llm.ToolDefinition{Type: "function", Name: "bash", Description: "Run a command"}
+		changed := bash
+		changed.Description = "Run a shell command"
+		Expect(driver.AddSessionTools(ctx, []*storage.SessionTool{
+			{SessionID: "s1", NodeHash: answer.Hash, Tool: bash},
+			{SessionID: "s1", NodeHash: answer.Hash, Tool: llm.ToolDefinition{Type: "function", Name: "read_file"}},
+		})).To(Succeed())
+		Expect(driver.AddSessionTools(ctx, []*storage.SessionTool{
+			{SessionID: "s1", NodeHash: answer.Hash, Tool: changed},
+		})).To(Succeed())
+		Expect(driver.Close()).To(Succeed())
+
+		var closeFn func() error
+		q, closeFn, err = NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	It("rebuilds the conversation up to the node with its prompt and tools", func() {
+		req, err := q.ForkRequest(ctx, answer.Hash[:10])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Model).To(Equal("claude-sonnet-4-5"))
+		Expect(req.System).To(Equal("You are a coding agent."))
+		Expect(req.Messages).To(Equal([]llm.Message{
+			{Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "List the files."}}},
+			{Role: "assistant", Content: []llm.ContentBlock{{Type: "text", Text: "main.go"}}},
+		}))
+
+		Expect(req.Tools).To(HaveLen(2))
+		Expect(req.Tools[0].Name).To(Equal("bash"))
+		Expect(req.Tools[0].Description).To(Equal("Run a shell command"))
+		Expect(req.Tools[1].Name).To(Equal("read_file"))
+	})
+
+	It("keeps the prompt of earlier turns", func() {
+		req, err := q.ForkRequest(ctx, again.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Messages).To(HaveLen(3))
+		Expect(req.System).To(Equal("You are a coding agent."))
+	})
+
+	It("rejects unknown hashes", func() {
+		_, err := q.ForkRequest(ctx, "zzzz")
+		Expect(err).To(MatchError(ContainSubstring(`no node matches "zzzz"`)))
+	})
+})
@@ -5,6 +5,8 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
 )
 
 var _ = Describe("Session grouping", func() {
@@ -33,6 +35,28 @@ var _ = Describe("Session grouping", func() {
 			Expect(groups).To(HaveLen(2))
 		})
 
+		It("keeps candidates of different users in separate groups", func() {
+			candidates := []sessionCandidate{
+				{summary: SessionSummary{ID: "a", Label: "fix bug", User: "alice", StartTime: now, EndTime: now.Add(5 * time.Minute), Status: StatusCompleted}},
+				{summary: SessionSummary{ID: "b", Label: "fix bug", User: "bob", StartTime: now.Add(1 * time.Minute), EndTime: now.Add(6 * time.Minute), Status: StatusCompleted}},
+			}
+
+			groups := groupSessionCandidates(candidates)
+			Expect(groups).To(HaveLen(2))
+			Expect(groups[0].summary.User).To(Equal("alice"))
+		})
+
+		It("keeps candidates of concurrent agent sessions in separate groups", func() {
+			candidates := []sessionCandidate{
+				{summary: SessionSummary{ID: "a", Label: "fix bug", SessionID: "run-a", StartTime: now, EndTime: now.Add(5 * time.Minute), Status: StatusCompleted}},
+				{summary: SessionSummary{ID: "b", Label: "fix bug", SessionID: "run-b", StartTime: now.Add(1 * time.Minute), EndTime: now.Add(6 * time.Minute), Status: StatusCompleted}},
+			}
+
+			groups := groupSessionCandidates(candidates)
+			Expect(groups).To(HaveLen(2))
+			Expect(groups[0].summary.SessionID).To(Equal("run-a"))
+		})
+
 		It("keeps candidates with different labels in separate groups", func() {
 			candidates := []sessionCandidate{
 				{summary: SessionSummary{ID: "a", Label: "fix bug", StartTime: now, EndTime: now.Add(5 * time.Minute), Status: StatusCompleted}},
@@ -300,6 +324,52 @@ var _ = Describe("Session grouping", func() {
 		})
 	})
 
+	Describe("groupNodes", func() {
+		turn := func(id string, index int, at time.Time) *ent.Node {
+			return &ent.Node{ID: id, TurnIndex: &index, CreatedAt: at}
+		}
+		ids := func(nodes []*ent.Node) []string {
+			out := make([]string, 0, len(nodes))
+			for _, n := range nodes {
+				out = append(out, n.ID)
+			}
+			return out
+		}
+
+		It("keeps each chain in turn order when its clock went back", func() {
+			chain := []*ent.Node{
+				turn("z", 0, now),
+				turn("y", 1, now.Add(time.Second)),
+				turn("x", 2, now.Add(-time.Hour)),
+			}
+
+			nodes := groupNodes([]sessionCandidate{{nodes: chain}})
+			Expect(ids(nodes)).To(Equal([]string{"z", "y", "x"}))
+		})
+
+		It("keeps turns stored within the same instant in turn order", func() {
+			chain := []*ent.Node{turn("z", 0, now), turn("y", 1, now), turn("x", 2, now)}
+
+			nodes := groupNodes([]sessionCandidate{{nodes: chain}})
+			Expect(ids(nodes)).To(Equal([]string{"z", "y", "x"}))
+		})
+
+		It("interleaves the chains of members by time", func() {
+			first := []*ent.Node{turn("a0", 0, now), turn("a1", 1, now.Add(2*time.Second))}
+			second := []*ent.Node{turn("b0", 0, now.Add(time.Second)), turn("b1", 1, now.Add(3*time.Second))}
+
+			nodes := groupNodes([]sessionCandidate{{nodes: first}, {nodes: second}})
+			Expect(ids(nodes)).To(Equal([]string{"a0", "b0", "a1", "b1"}))
+		})
+	})
+
+	Describe("turnGap", func() {
+		It("reports no gap when the clock went back between turns", func() {
+			Expect(turnGap(&ent.Node{CreatedAt: now}, &ent.Node{CreatedAt: now.Add(-time.Minute)})).To(BeZero())
+			Expect(turnGap(&ent.Node{CreatedAt: now}, &ent.Node{CreatedAt: now.Add(time.Minute)})).To(Equal(time.Minute))
+		})
+	})
+
 	Describe("truncateGroupedText", func() {
 		It("returns text unchanged when under the limit", func() {
 			Expect(truncateGroupedText("short")).To(Equal("short"))
@@ -0,0 +1,98 @@
+package deck
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+)
+
+type latencyKey struct {
+	model    string
+	provider string
+}
+
+type latencySamples struct {
+	latencies []time.Duration
+	ttfts     []time.Duration
+}
+
+// latencyAccumulator collects proxy timings per model and provider. Turns
+// are counted once even when several sessions share them.
+type latencyAccumulator struct {
+	samples map[latencyKey]*latencySamples
+	seen    map[string]bool
+}
+
+func newLatencyAccumulator() *latencyAccumulator {
+	return &latencyAccumulator{
+		samples: map[latencyKey]*latencySamples{},
+		seen:    map[string]bool{},
+	}
+}
+
+func (a *latencyAccumulator) add(n *ent.Node) {
+	// Failed requests return early and would skew the percentiles.
+	if n.RequestStartedAt == nil || n.ResponseCompletedAt == nil || n.Type == nodeTypeError || a.seen[n.ID] {
+		return
+	}
+	a.seen[n.ID] = true
+
+	key := latencyKey{model: normalizeModel(n.Model), provider: n.Provider}
+	s, ok := a.samples[key]
+	if !ok {
+		s = &latencySamples{}
+		a.samples[key] = s
+	}
+
+	s.latencies = append(s.latencies, max(n.ResponseCompletedAt.Sub(*n.RequestStartedAt), 0))
+	if n.FirstChunkAt != nil {
+		s.ttfts = append(s.ttfts, max(n.FirstChunkAt.Sub(*n.RequestStartedAt), 0))
+	}
+}
+
+// metrics returns latency percentiles per model and provider, busiest first.
+func (a *latencyAccumulator) metrics() []LatencyMetric {
+	metrics := make([]LatencyMetric, 0, len(a.samples))
+	for key, s := range a.samples {
+		m := LatencyMetric{
+			Model:            key.model,
+			Provider:         key.provider,
+			Requests:         len(s.latencies),
+			StreamedRequests: len(s.ttfts),
+		}
+		m.P50LatencyNs, m.P95LatencyNs, m.P99LatencyNs = percentiles(s.latencies)
+		m.P50TTFTNs, m.P95TTFTNs, m.P99TTFTNs = percentiles(s.ttfts)
+		metrics = append(metrics, m)
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Requests != metrics[j].Requests {
+			return metrics[i].Requests > metrics[j].Requests
+		}
+		if metrics[i].Model != metrics[j].Model {
+			return metrics[i].Model < metrics[j].Model
+		}
+		return metrics[i].Provider < metrics[j].Provider
+	})
+	return metrics
+}
+
+// percentiles returns the nearest-rank p50, p95, and p99 of samples in
+// nanoseconds, or zeros when there are no samples.
+func percentiles(samples []time.Duration) (int64, int64, int64) {
+	if len(samples) == 0 {
+		return 0, 0, 0
+	}
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+
+	rank := func(p float64) int64 {
+		idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+		idx = min(max(idx, 0), len(sorted)-1)
+		return sorted[idx].Nanoseconds()
+	}
+	return rank(0.50), rank(0.95), rank(0.99)
+}
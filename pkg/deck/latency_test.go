@@ -0,0 +1,114 @@
+package deck
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("percentiles", func() {
+	It("uses the nearest rank", func() {
+		samples := make([]time.Duration, 0, 100)
+		for i := 100; i >= 1; i-- {
+			samples = append(samples, time.Duration(i)*time.Millisecond)
+		}
+
+		p50, p95, p99 := percentiles(samples)
+		Expect(p50).To(Equal((50 * time.Millisecond).Nanoseconds()))
+		Expect(p95).To(Equal((95 * time.Millisecond).Nanoseconds()))
+		Expect(p99).To(Equal((99 * time.Millisecond).Nanoseconds()))
+	})
+
+	It("returns zeros without samples", func() {
+		p50, p95, p99 := percentiles(nil)
+		Expect([]int64{p50, p95, p99}).To(Equal([]int64{0, 0, 0}))
+	})
+})
+
+var _ = Describe("Latency analytics", func() {
+	var (
+		ctx  context.Context
+		q    *Query
+		leaf *merkle.Node
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		started := time.Now().Add(-time.Hour)
+		var parent *merkle.Node
+		for i := 1; i <= 4; i++ {
+			prompt := merkle.NewNode(merkle.Bucket{
+				Type:     "message",
+				Role:     "user",
+				Content:  []llm.ContentBlock{{Type: "text", Text: fmt.Sprintf("question %d", i)}},
+				Model:    "claude-sonnet-4",
+				Provider: "anthropic",
+			}, parent)
+			_, err = driver.Put(ctx, prompt)
+			Expect(err).NotTo(HaveOccurred())
+
+			timing := &llm.Timing{
+				RequestStartedAt:    started,
+				ResponseCompletedAt: started.Add(time.Duration(i) * time.Second),
+			}
+			// Only the first two responses were streamed.
+			if i <= 2 {
+				timing.FirstChunkAt = started.Add(time.Duration(i) * 100 * time.Millisecond)
+			}
+			parent = merkle.NewNode(merkle.Bucket{
+				Type:     "message",
+				Role:     "assistant",
+				Content:  []llm.ContentBlock{{Type: "text", Text: fmt.Sprintf("answer %d", i)}},
+				Model:    "claude-sonnet-4-20250514",
+				Provider: "anthropic",
+			}, prompt, merkle.NodeMeta{Timing: timing})
+			_, err = driver.Put(ctx, parent)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		leaf = parent
+		Expect(driver.Close()).To(Succeed())
+
+		var closeFn func() error
+		q, closeFn, err = NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	expectLatency := func(metrics []LatencyMetric) {
+		Expect(metrics).To(HaveLen(1))
+		m := metrics[0]
+		Expect(m.Model).To(Equal("claude-sonnet-4"))
+		Expect(m.Provider).To(Equal("anthropic"))
+		Expect(m.Requests).To(Equal(4))
+		Expect(m.P50LatencyNs).To(Equal((2 * time.Second).Nanoseconds()))
+		Expect(m.P99LatencyNs).To(Equal((4 * time.Second).Nanoseconds()))
+		Expect(m.StreamedRequests).To(Equal(2))
+		Expect(m.P50TTFTNs).To(Equal((100 * time.Millisecond).Nanoseconds()))
+		Expect(m.P95TTFTNs).To(Equal((200 * time.Millisecond).Nanoseconds()))
+	}
+
+	It("reports percentiles per model in the analytics overview", func() {
+		overview, err := q.AnalyticsOverview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+		expectLatency(overview.Latency)
+	})
+
+	It("reports percentiles for a single session", func() {
+		analytics, err := q.SessionAnalytics(ctx, leaf.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		expectLatency(analytics.Latency)
+	})
+})
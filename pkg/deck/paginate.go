@@ -0,0 +1,228 @@
+package deck
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+)
+
+// sessionPageSize is the page size Sessions uses when Filters.Limit is unset.
+const sessionPageSize = 200
+
+// ErrInvalidCursor is returned for cursors that were not issued for the
+// requested ordering or session.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// sessionCursor records the sort position of the last session on a page.
+// Paging resumes after that position rather than at an offset, so sessions
+// recorded between requests do not shift or repeat later pages.
+type sessionCursor struct {
+	Sort      string  `json:"s"`
+	Ascending bool    `json:"a,omitempty"`
+	ID        string  `json:"id"`
+	StartTime int64   `json:"t,omitempty"`
+	Tokens    int64   `json:"k,omitempty"`
+	Duration  int64   `json:"d,omitempty"`
+	TotalCost float64 `json:"c,omitempty"`
+}
+
+// messageCursor records the last message on a page of SessionDetailPage.
+type messageCursor struct {
+	Session string `json:"s"`
+	Hash    string `json:"h"`
+}
+
+// SortSessions orders sessions by sortKey ("date", "tokens", "duration", or
+// cost by default), descending unless sortDir is "asc". Ties are broken by
+// session ID so the order is stable across queries.
+func SortSessions(sessions []SessionSummary, sortKey, sortDir string) {
+	ascending := strings.EqualFold(sortDir, "asc")
+	slices.SortStableFunc(sessions, func(a, b SessionSummary) int {
+		return compareSessions(a, b, sortKey, ascending)
+	})
+}
+
+func compareSessions(a, b SessionSummary, sortKey string, ascending bool) int {
+	var c int
+	switch sortKey {
+	case "date":
+		c = a.StartTime.Compare(b.StartTime)
+	case "tokens":
+		c = cmp.Compare(a.InputTokens+a.OutputTokens, b.InputTokens+b.OutputTokens)
+	case "duration":
+		c = cmp.Compare(a.Duration, b.Duration)
+	default:
+		c = cmp.Compare(a.TotalCost, b.TotalCost)
+	}
+	if !ascending {
+		c = -c
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+// pageSessions returns the page of sorted sessions selected by the Limit and
+// Cursor in filters, and the cursor for the next page when there is one.
+func pageSessions(sorted []SessionSummary, filters Filters) ([]SessionSummary, string, error) {
+	ascending := strings.EqualFold(filters.SortDir, "asc")
+
+	start := 0
+	if filters.Cursor != "" {
+		var cursor sessionCursor
+		if err := decodeCursor(filters.Cursor, &cursor); err != nil {
+			return nil, "", err
+		}
+		if cursor.Sort != filters.Sort || cursor.Ascending != ascending {
+			return nil, "", fmt.Errorf("%w: cursor is for a different sort order", ErrInvalidCursor)
+		}
+		last := cursor.summary()
+		start, _ = slices.BinarySearchFunc(sorted, last, func(s, target SessionSummary) int {
+			return compareSessions(s, target, filters.Sort, ascending)
+		})
+		if start < len(sorted) && sorted[start].ID == last.ID {
+			start++
+		}
+	}
+
+	if filters.Limit <= 0 || start+filters.Limit >= len(sorted) {
+		return sorted[start:], "", nil
+	}
+
+	end := start + filters.Limit
+	last := sorted[end-1]
+	next, err := encodeCursor(sessionCursor{
+		Sort:      filters.Sort,
+		Ascending: ascending,
+		ID:        last.ID,
+		StartTime: last.StartTime.UnixNano(),
+		Tokens:    last.InputTokens + last.OutputTokens,
+		Duration:  int64(last.Duration),
+		TotalCost: last.TotalCost,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return sorted[start:end], next, nil
+}
+
+// summary rebuilds enough of a SessionSummary to compare against.
+func (c sessionCursor) summary() SessionSummary {
+	return SessionSummary{
+		ID:          c.ID,
+		StartTime:   time.Unix(0, c.StartTime),
+		InputTokens: c.Tokens,
+		Duration:    time.Duration(c.Duration),
+		TotalCost:   c.TotalCost,
+	}
+}
+
+// Sessions iterates over every session matching filters in sort order,
+// loading one page of Filters.Limit sessions at a time (200 when unset).
+// Iteration stops at the first error.
+func (q *Query) Sessions(ctx context.Context, filters Filters) iter.Seq2[SessionSummary, error] {
+	return func(yield func(SessionSummary, error) bool) {
+		if filters.Limit <= 0 {
+			filters.Limit = sessionPageSize
+		}
+		for {
+			overview, err := q.Overview(ctx, filters)
+			if err != nil {
+				yield(SessionSummary{}, err)
+				return
+			}
+			for _, session := range overview.Sessions {
+				if !yield(session, nil) {
+					return
+				}
+			}
+			if overview.NextCursor == "" {
+				return
+			}
+			filters.Cursor = overview.NextCursor
+		}
+	}
+}
+
+// sessionMessagesPage builds the page of messages selected by page from a
+// session's nodes, along with tool call counts for the whole session. Only
+// the nodes on the page are rehydrated from blob storage.
+func (q *Query) sessionMessagesPage(ctx context.Context, sessionID string, nodes []*ent.Node, page Page) ([]SessionMessage, map[string]int, string, error) {
+	start := 0
+	if page.Cursor != "" {
+		var cursor messageCursor
+		if err := decodeCursor(page.Cursor, &cursor); err != nil {
+			return nil, nil, "", err
+		}
+		if cursor.Session != sessionID {
+			return nil, nil, "", fmt.Errorf("%w: cursor is for a different session", ErrInvalidCursor)
+		}
+		idx := slices.IndexFunc(nodes, func(n *ent.Node) bool { return n.ID == cursor.Hash })
+		if idx < 0 {
+			return nil, nil, "", fmt.Errorf("%w: message %s is not in session", ErrInvalidCursor, cursor.Hash)
+		}
+		start = idx + 1
+	}
+
+	end := len(nodes)
+	if page.Limit > 0 {
+		end = min(start+page.Limit, len(nodes))
+	}
+
+	if start == 0 && end == len(nodes) {
+		messages, toolFrequency := q.buildSessionMessages(ctx, nodes)
+		return messages, toolFrequency, "", nil
+	}
+
+	messages, _ := q.buildSessionMessages(ctx, nodes[start:end])
+	if start > 0 && len(messages) > 0 {
+		messages[0].Delta = turnGap(nodes[start-1], nodes[start])
+	}
+
+	toolFrequency := map[string]int{}
+	for _, n := range nodes {
+		blocks, _ := parseContentBlocks(n.Content)
+		for _, tool := range extractToolCalls(blocks) {
+			toolFrequency[tool]++
+		}
+	}
+
+	var next string
+	if end < len(nodes) {
+		var err error
+		next, err = encodeCursor(messageCursor{Session: sessionID, Hash: nodes[end-1].ID})
+		if err != nil {
+			return nil, nil, "", err
+		}
+	}
+	return messages, toolFrequency, next, nil
+}
+
+func encodeCursor(v any) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(cursor string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return nil
+}
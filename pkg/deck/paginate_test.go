@@ -0,0 +1,186 @@
+package deck
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("SortSessions", func() {
+	It("breaks ties by session ID", func() {
+		sessions := []SessionSummary{
+			{ID: "c", TotalCost: 1},
+			{ID: "a", TotalCost: 1},
+			{ID: "b", TotalCost: 2},
+		}
+		SortSessions(sessions, "cost", "desc")
+		Expect([]string{sessions[0].ID, sessions[1].ID, sessions[2].ID}).To(Equal([]string{"b", "a", "c"}))
+	})
+})
+
+var _ = Describe("Pagination", func() {
+	var (
+		ctx    context.Context
+		q      *Query
+		writer *sqlite.Driver
+		leaves []*merkle.Node
+	)
+
+	putSession := func(i int) *merkle.Node {
+		prompt := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: fmt.Sprintf("session %d", i)}},
+		}, nil)
+		_, err := writer.Put(ctx, prompt)
+		Expect(err).NotTo(HaveOccurred())
+
+		answer := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "assistant",
+			Content: []llm.ContentBlock{{Type: "text", Text: fmt.Sprintf("answer %d", i)}},
+			Model:   "gpt-4o",
+		}, prompt, merkle.NodeMeta{Usage: &llm.Usage{PromptTokens: 1000 * (i + 1), CompletionTokens: 10}})
+		_, err = writer.Put(ctx, answer)
+		Expect(err).NotTo(HaveOccurred())
+		return answer
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		var err error
+		writer, err = sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(writer.Close)
+
+		leaves = nil
+		for i := range 5 {
+			leaves = append(leaves, putSession(i))
+		}
+
+		var closeFn func() error
+		q, closeFn, err = NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	collectIDs := func(sessions []SessionSummary) []string {
+		ids := make([]string, 0, len(sessions))
+		for _, s := range sessions {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}
+
+	It("pages through sessions in a stable order with totals for all of them", func() {
+		all, err := q.Overview(ctx, Filters{Sort: "tokens"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(all.Sessions).To(HaveLen(5))
+		Expect(all.NextCursor).To(BeEmpty())
+
+		var paged []SessionSummary
+		filters := Filters{Sort: "tokens", Limit: 2}
+		for {
+			page, err := q.Overview(ctx, filters)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(page.TotalSessions).To(Equal(5))
+			Expect(page.TotalCost).To(BeNumerically("~", all.TotalCost))
+			Expect(len(page.Sessions)).To(BeNumerically("<=", 2))
+			paged = append(paged, page.Sessions...)
+			if page.NextCursor == "" {
+				break
+			}
+			filters.Cursor = page.NextCursor
+		}
+		Expect(collectIDs(paged)).To(Equal(collectIDs(all.Sessions)))
+	})
+
+	It("does not repeat sessions recorded between pages", func() {
+		first, err := q.Overview(ctx, Filters{Sort: "tokens", Limit: 2})
+		Expect(err).NotTo(HaveOccurred())
+
+		// A new session that sorts ahead of everything on the first page.
+		putSession(9)
+
+		rest, err := q.Overview(ctx, Filters{Sort: "tokens", Limit: 10, Cursor: first.NextCursor})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rest.TotalSessions).To(Equal(6))
+		Expect(rest.Sessions).To(HaveLen(3))
+		for _, s := range rest.Sessions {
+			Expect(collectIDs(first.Sessions)).NotTo(ContainElement(s.ID))
+		}
+	})
+
+	It("rejects cursors for a different sort order", func() {
+		first, err := q.Overview(ctx, Filters{Sort: "tokens", Limit: 2})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = q.Overview(ctx, Filters{Sort: "date", Limit: 2, Cursor: first.NextCursor})
+		Expect(err).To(MatchError(ErrInvalidCursor))
+
+		_, err = q.Overview(ctx, Filters{Sort: "tokens", Cursor: "not a cursor"})
+		Expect(err).To(MatchError(ErrInvalidCursor))
+	})
+
+	It("iterates over every session a page at a time", func() {
+		var ids []string
+		for session, err := range q.Sessions(ctx, Filters{Sort: "date", SortDir: "asc", Limit: 2}) {
+			Expect(err).NotTo(HaveOccurred())
+			ids = append(ids, session.ID)
+		}
+
+		all, err := q.Overview(ctx, Filters{Sort: "date", SortDir: "asc"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids).To(Equal(collectIDs(all.Sessions)))
+	})
+
+	It("pages through session messages", func() {
+		leaf := leaves[0]
+		var hashes []string
+		for i := range 4 {
+			leaf = merkle.NewNode(merkle.Bucket{
+				Type:    "message",
+				Role:    "user",
+				Content: []llm.ContentBlock{{Type: "tool_use", ToolName: "Bash", ToolUseID: fmt.Sprintf("call_%d", i)}},
+			}, leaf)
+			_, err := writer.Put(ctx, leaf)
+			Expect(err).NotTo(HaveOccurred())
+			time.Sleep(time.Millisecond)
+		}
+
+		full, err := q.SessionDetail(ctx, leaf.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(full.Messages).To(HaveLen(6))
+		Expect(full.NextCursor).To(BeEmpty())
+		for _, m := range full.Messages {
+			hashes = append(hashes, m.Hash)
+		}
+
+		first, err := q.SessionDetailPage(ctx, leaf.Hash, Page{Limit: 4})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first.Messages).To(HaveLen(4))
+		Expect(first.ToolFrequency).To(HaveKeyWithValue("Bash", 4))
+		Expect(first.Summary.MessageCount).To(Equal(6))
+		Expect(first.NextCursor).NotTo(BeEmpty())
+
+		second, err := q.SessionDetailPage(ctx, leaf.Hash, Page{Limit: 4, Cursor: first.NextCursor})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second.Messages).To(HaveLen(2))
+		Expect(second.NextCursor).To(BeEmpty())
+		Expect(second.Messages[0].Hash).To(Equal(hashes[4]))
+		Expect(second.Messages[0].Delta).To(Equal(full.Messages[4].Delta))
+
+		_, err = q.SessionDetailPage(ctx, leaves[1].Hash, Page{Cursor: first.NextCursor})
+		Expect(err).To(MatchError(ErrInvalidCursor))
+	})
+})
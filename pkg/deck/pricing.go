@@ -1,13 +1,25 @@
 package deck
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/papercomputeco/tapes/pkg/dotdir"
+	"github.com/papercomputeco/tapes/pkg/llm"
 )
 
+// PricingFile is the name of the pricing overrides file in the .tapes/ directory.
+const PricingFile = "pricing.toml"
+
 type PricingTable map[string]Pricing
 
 // DefaultPricing returns hardcoded pricing per million tokens for supported models.
@@ -21,7 +33,8 @@ type PricingTable map[string]Pricing
 // Anthropic cache multipliers: CacheWrite = 1.25x input, CacheRead = 0.10x input.
 // OpenAI cache: CacheWrite = 1x input (no surcharge), CacheRead = 0.50x input (except o3-mini).
 //
-// To override at runtime, use --pricing with a JSON file. See LoadPricing.
+// To override, add rates to pricing.toml in the .tapes/ directory (see
+// tapes pricing) or pass --pricing with a TOML or JSON file. See ResolvePricing.
 func DefaultPricing() PricingTable {
 	return PricingTable{
 		// Anthropic
@@ -50,32 +63,137 @@ func DefaultPricing() PricingTable {
 		"o4-mini":      {Input: 1.10, Output: 4.40, CacheRead: 0.275, CacheWrite: 1.10},
 		"o1":           {Input: 15.00, Output: 60.00, CacheRead: 7.50, CacheWrite: 15.00},
 
+		// OpenAI embeddings and transcription. Audio input is billed as
+		// input tokens; whisper-1 is billed per minute and has no entry.
+		"text-embedding-3-small": {Input: 0.02},
+		"text-embedding-3-large": {Input: 0.13},
+		"text-embedding-ada-002": {Input: 0.10},
+		"gpt-4o-transcribe":      {Input: 2.50, Output: 10.00},
+		"gpt-4o-mini-transcribe": {Input: 1.25, Output: 5.00},
+
 		// DeepSeek
 		"deepseek-r1": {Input: 0.55, Output: 2.19, CacheRead: 0.14},
 	}
 }
 
+// LoadPricing returns the default pricing with the overrides from the file at
+// path applied. An empty path returns the defaults.
 func LoadPricing(path string) (PricingTable, error) {
 	pricing := DefaultPricing()
 	if path == "" {
 		return pricing, nil
 	}
 
+	overrides, err := ReadPricingFile(path)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(pricing, overrides)
+
+	return pricing, nil
+}
+
+// ResolvePricing returns the default pricing with overrides applied from
+// pricing.toml in the .tapes/ directory resolved from configDir, and then
+// from the file at path, if set.
+func ResolvePricing(configDir, path string) (PricingTable, error) {
+	pricing := DefaultPricing()
+
+	filePath, err := PricingFilePath(configDir)
+	if err != nil {
+		return nil, err
+	}
+	if filePath != "" {
+		overrides, err := ReadPricingFile(filePath)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, err
+		default:
+			maps.Copy(pricing, overrides)
+		}
+	}
+
+	if path != "" {
+		overrides, err := ReadPricingFile(path)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(pricing, overrides)
+	}
+
+	return pricing, nil
+}
+
+// PricingFilePath returns the path to pricing.toml in the .tapes/ directory
+// resolved from configDir, or "" when no .tapes/ directory exists.
+func PricingFilePath(configDir string) (string, error) {
+	target, err := dotdir.NewManager().Target(configDir)
+	if err != nil {
+		return "", err
+	}
+	if target == "" {
+		return "", nil
+	}
+	return filepath.Join(target, PricingFile), nil
+}
+
+// pricingDocument is the on-disk layout of a pricing TOML file:
+//
+//	[models."claude-sonnet-4.6"]
+//	input = 3.00
+//	output = 15.00
+//	cache_read = 0.30
+//	cache_write = 3.75
+type pricingDocument struct {
+	Models PricingTable `toml:"models"`
+}
+
+// ReadPricingFile reads per-model pricing overrides from a TOML file, or from
+// a JSON object keyed by model name when the file has a .json extension.
+// Rates are in dollars per million tokens.
+func ReadPricingFile(path string) (PricingTable, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("read pricing file: %w", err)
 	}
 
-	var overrides map[string]Pricing
-	if err := json.Unmarshal(data, &overrides); err != nil {
-		return nil, fmt.Errorf("parse pricing file: %w", err)
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		var overrides PricingTable
+		if err := json.Unmarshal(data, &overrides); err != nil {
+			return nil, fmt.Errorf("parse pricing file: %w", err)
+		}
+		return overrides, nil
 	}
 
-	maps.Copy(pricing, overrides)
+	var doc pricingDocument
+	if _, err := toml.Decode(string(data), &doc); err != nil {
+		return nil, fmt.Errorf("parse pricing file: %w", err)
+	}
+	if doc.Models == nil {
+		doc.Models = PricingTable{}
+	}
+	return doc.Models, nil
+}
 
-	return pricing, nil
+// WritePricingFile writes per-model pricing to a TOML file readable by
+// ReadPricingFile.
+func WritePricingFile(path string, pricing PricingTable) error {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(pricingDocument{Models: pricing}); err != nil {
+		return fmt.Errorf("encode pricing file: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("write pricing file: %w", err)
+	}
+	return nil
 }
 
+// PricingForModel looks up pricing for a model name. Exact matches on the
+// normalized or raw name win. Otherwise the most specific wildcard entry
+// (e.g. "gpt-5*") that matches is used, and failing that the longest entry
+// that is a dash-separated prefix of the model (e.g. "gpt-4o" for
+// "gpt-4o-audio-preview").
 func PricingForModel(pricing PricingTable, model string) (Pricing, bool) {
 	normalized := normalizeModel(model)
 	price, ok := pricing[normalized]
@@ -83,7 +201,54 @@ func PricingForModel(pricing PricingTable, model string) (Pricing, bool) {
 		return price, true
 	}
 	price, ok = pricing[model]
-	return price, ok
+	if ok {
+		return price, true
+	}
+	if normalized == "" {
+		return Pricing{}, false
+	}
+
+	if key := wildcardPricingKey(pricing, normalized); key != "" {
+		return pricing[key], true
+	}
+	if key := prefixPricingKey(pricing, normalized); key != "" {
+		return pricing[key], true
+	}
+	return Pricing{}, false
+}
+
+// wildcardPricingKey returns the matching wildcard key with the most literal
+// characters, breaking ties alphabetically so lookups are deterministic.
+func wildcardPricingKey(pricing PricingTable, model string) string {
+	best, bestLiteral := "", -1
+	for key := range pricing {
+		if !strings.ContainsAny(key, "*?[") {
+			continue
+		}
+		if matched, err := path.Match(strings.ToLower(key), model); err != nil || !matched {
+			continue
+		}
+		literal := len(key) - strings.Count(key, "*") - strings.Count(key, "?")
+		if literal > bestLiteral || (literal == bestLiteral && key < best) {
+			best, bestLiteral = key, literal
+		}
+	}
+	return best
+}
+
+// prefixPricingKey returns the longest key that model extends with a dash,
+// so "o3" prices "o3-pro" but "gpt-4" does not price "gpt-4o".
+func prefixPricingKey(pricing PricingTable, model string) string {
+	best := ""
+	for key := range pricing {
+		if len(key) <= len(best) || strings.ContainsAny(key, "*?[") {
+			continue
+		}
+		if strings.HasPrefix(model, strings.ToLower(key)+"-") {
+			best = key
+		}
+	}
+	return best
 }
 
 // CostForTokens calculates cost using base input/output pricing.
@@ -113,6 +278,19 @@ func CostForTokensWithCache(pricing Pricing, inputTokens, outputTokens, cacheCre
 	return inputCost, outputCost, inputCost + outputCost
 }
 
+// TurnCost returns the cost in USD of a turn of model with usage, or zero
+// when the model has no pricing.
+func (p PricingTable) TurnCost(model string, usage llm.Usage) float64 {
+	pricing, ok := PricingForModel(p, model)
+	if !ok {
+		return 0
+	}
+	_, _, cost := CostForTokensWithCache(pricing,
+		int64(usage.PromptTokens), int64(usage.CompletionTokens),
+		int64(usage.CacheCreationInputTokens), int64(usage.CacheReadInputTokens))
+	return cost
+}
+
 func normalizeModel(model string) string {
 	normalized := strings.ToLower(strings.TrimSpace(model))
 	if normalized == "" {
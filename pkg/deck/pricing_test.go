@@ -1,8 +1,13 @@
 package deck
 
 import (
+	"os"
+	"path/filepath"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
 )
 
 var _ = Describe("CostForTokens", func() {
@@ -23,6 +28,23 @@ var _ = Describe("CostForTokens", func() {
 	})
 })
 
+var _ = Describe("TurnCost", func() {
+	pricing := PricingTable{"claude-sonnet-4": {Input: 3.00, Output: 15.00, CacheRead: 0.30, CacheWrite: 3.75}}
+
+	It("prices a turn's usage for its model", func() {
+		cost := pricing.TurnCost("claude-sonnet-4-20250514", llm.Usage{
+			PromptTokens:         1_000_000,
+			CompletionTokens:     100_000,
+			CacheReadInputTokens: 500_000,
+		})
+		Expect(cost).To(BeNumerically("~", 1.50+0.15+1.50, 0.001))
+	})
+
+	It("returns zero for a model without pricing", func() {
+		Expect(pricing.TurnCost("unknown-model", llm.Usage{PromptTokens: 1000})).To(BeZero())
+	})
+})
+
 var _ = Describe("CostForTokensWithCache", func() {
 	pricing := Pricing{Input: 3.00, Output: 15.00, CacheRead: 0.30, CacheWrite: 3.75}
 
@@ -197,4 +219,66 @@ var _ = Describe("PricingForModel", func() {
 		_, ok := PricingForModel(pricing, "totally-unknown-model")
 		Expect(ok).To(BeFalse())
 	})
+
+	It("falls back to the longest dash-separated prefix", func() {
+		p, ok := PricingForModel(pricing, "gpt-4o-mini-audio-preview")
+		Expect(ok).To(BeTrue())
+		Expect(p.Input).To(Equal(0.15))
+
+		_, ok = PricingForModel(PricingTable{"gpt-4": {Input: 30}}, "gpt-4o")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("prefers the most specific wildcard over prefixes", func() {
+		table := PricingTable{
+			"gpt-5":       {Input: 1.25},
+			"gpt-5*":      {Input: 2.00},
+			"gpt-5-mini*": {Input: 0.25},
+		}
+
+		p, ok := PricingForModel(table, "gpt-5-mini-2025-08-07")
+		Expect(ok).To(BeTrue())
+		Expect(p.Input).To(Equal(0.25))
+
+		p, ok = PricingForModel(table, "gpt-5-codex")
+		Expect(ok).To(BeTrue())
+		Expect(p.Input).To(Equal(2.00))
+
+		p, ok = PricingForModel(table, "gpt-5")
+		Expect(ok).To(BeTrue())
+		Expect(p.Input).To(Equal(1.25))
+	})
+})
+
+var _ = Describe("Pricing files", func() {
+	It("round-trips TOML and reads JSON overrides", func() {
+		dir := GinkgoT().TempDir()
+		tomlPath := filepath.Join(dir, PricingFile)
+		Expect(WritePricingFile(tomlPath, PricingTable{
+			"claude-sonnet-4.6": {Input: 2.40, Output: 12, CacheRead: 0.24, CacheWrite: 3},
+		})).To(Succeed())
+
+		data, err := os.ReadFile(tomlPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`[models."claude-sonnet-4.6"]`))
+
+		read, err := ReadPricingFile(tomlPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(read).To(HaveKeyWithValue("claude-sonnet-4.6", Pricing{Input: 2.40, Output: 12, CacheRead: 0.24, CacheWrite: 3}))
+
+		jsonPath := filepath.Join(dir, "rates.json")
+		Expect(os.WriteFile(jsonPath, []byte(`{"gpt-5": {"input": 1.25, "output": 10}}`), 0o600)).To(Succeed())
+
+		pricing, err := ResolvePricing(dir, jsonPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pricing["claude-sonnet-4.6"].Input).To(Equal(2.40))
+		Expect(pricing["gpt-5"].Output).To(Equal(10.0))
+		Expect(pricing["gpt-4o"].Input).To(Equal(2.50))
+	})
+
+	It("uses defaults when no pricing file exists", func() {
+		pricing, err := ResolvePricing(GinkgoT().TempDir(), "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pricing).To(Equal(DefaultPricing()))
+	})
 })
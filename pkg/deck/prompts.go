@@ -0,0 +1,198 @@
+package deck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/systemprompt"
+)
+
+// maxPromptPreviewChars bounds the preview of a system prompt.
+const maxPromptPreviewChars = 80
+
+// SystemPrompts lists the distinct system prompts the selected turns were
+// sent with, oldest first, with when each was first and last seen and what
+// its turns cost. Each turn is counted once even when it is shared by several
+// session branches.
+func (q *Query) SystemPrompts(ctx context.Context, opts SystemPromptOptions) ([]SystemPromptVersion, error) {
+	predicates := []predicate.Node{node.SystemPromptHashNotNil()}
+	if opts.Since > 0 {
+		predicates = append(predicates, node.CreatedAtGTE(time.Now().Add(-opts.Since)))
+	}
+	if opts.Session != "" {
+		predicates = append(predicates, node.SessionID(opts.Session))
+	}
+	return q.systemPromptVersions(ctx, predicates)
+}
+
+// DiffSystemPrompts compares system prompts a and b line by line. Either hash
+// may be a unique prefix.
+func (q *Query) DiffSystemPrompts(ctx context.Context, a, b string) (*SystemPromptDiff, error) {
+	hashA, err := q.systemPromptByPrefix(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	hashB, err := q.systemPromptByPrefix(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	textA, err := q.systemPromptText(ctx, hashA)
+	if err != nil {
+		return nil, err
+	}
+	textB, err := q.systemPromptText(ctx, hashB)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := q.systemPromptVersions(ctx, []predicate.Node{node.SystemPromptHashIn(hashA, hashB)})
+	if err != nil {
+		return nil, err
+	}
+	diff := &SystemPromptDiff{
+		A:     SystemPromptVersion{Hash: hashA, Preview: promptPreview(textA), Size: len(textA)},
+		B:     SystemPromptVersion{Hash: hashB, Preview: promptPreview(textB), Size: len(textB)},
+		Lines: DiffLines(textA, textB),
+	}
+	for _, v := range versions {
+		if v.Hash == hashA {
+			diff.A = v
+		}
+		if v.Hash == hashB {
+			diff.B = v
+		}
+	}
+	return diff, nil
+}
+
+// systemPromptVersions aggregates the response nodes matching predicates by
+// their system prompt.
+func (q *Query) systemPromptVersions(ctx context.Context, predicates []predicate.Node) ([]SystemPromptVersion, error) {
+	nodes, err := q.client.Node.Query().
+		Where(predicates...).
+		Select(
+			node.FieldSystemPromptHash,
+			node.FieldSessionID,
+			node.FieldModel,
+			node.FieldCreatedAt,
+			node.FieldPromptTokens,
+			node.FieldCompletionTokens,
+			node.FieldCacheCreationInputTokens,
+			node.FieldCacheReadInputTokens,
+			node.FieldReasoningTokens,
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query system prompt turns: %w", err)
+	}
+
+	versions := map[string]*SystemPromptVersion{}
+	sessions := map[string]map[string]bool{}
+	for _, n := range nodes {
+		hash := derefString(n.SystemPromptHash)
+		v, ok := versions[hash]
+		if !ok {
+			v = &SystemPromptVersion{Hash: hash, FirstSeen: n.CreatedAt, LastSeen: n.CreatedAt}
+			versions[hash] = v
+			sessions[hash] = map[string]bool{}
+		}
+		if n.CreatedAt.Before(v.FirstSeen) {
+			v.FirstSeen = n.CreatedAt
+		}
+		if n.CreatedAt.After(v.LastSeen) {
+			v.LastSeen = n.CreatedAt
+		}
+		if id := derefString(n.SessionID); id != "" {
+			sessions[hash][id] = true
+		}
+
+		t := tokenCounts(n)
+		v.Requests++
+		v.InputTokens += t.Input
+		v.OutputTokens += t.Output
+		pricing, ok := PricingForModel(q.pricing, normalizeModel(n.Model))
+		if !ok {
+			v.UnpricedRequests++
+			continue
+		}
+		_, _, cost := CostForTokensWithCache(pricing, t.Input, t.Output, t.CacheCreation, t.CacheRead)
+		v.TotalCost += cost
+	}
+
+	result := make([]SystemPromptVersion, 0, len(versions))
+	for hash, v := range versions {
+		v.Sessions = len(sessions[hash])
+		// A prompt whose text cannot be read, such as one sealed with
+		// another key, is still listed.
+		if text, err := q.systemPromptText(ctx, hash); err == nil {
+			v.Preview = promptPreview(text)
+			v.Size = len(text)
+		}
+		result = append(result, *v)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].FirstSeen.Equal(result[j].FirstSeen) {
+			return result[i].FirstSeen.Before(result[j].FirstSeen)
+		}
+		return result[i].Hash < result[j].Hash
+	})
+	return result, nil
+}
+
+// systemPromptByPrefix returns the hash of the only stored system prompt
+// whose hash starts with prefix.
+func (q *Query) systemPromptByPrefix(ctx context.Context, prefix string) (string, error) {
+	if prefix == "" {
+		return "", errors.New("empty hash")
+	}
+
+	matches, err := q.client.SystemPrompt.Query().
+		Where(predicate.SystemPrompt(sql.FieldHasPrefix(systemprompt.FieldID, prefix))).
+		Limit(2).
+		IDs(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no system prompt matches %q", prefix)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%q matches more than one system prompt", prefix)
+	}
+}
+
+// systemPromptText reads a stored system prompt.
+func (q *Query) systemPromptText(ctx context.Context, hash string) (string, error) {
+	if q.driver == nil {
+		return "", errors.New("system prompts require a SQLite-backed query")
+	}
+	return q.driver.GetSystemPrompt(ctx, hash)
+}
+
+// promptPreview returns the first non-blank line of a prompt, shortened to
+// maxPromptPreviewChars.
+func promptPreview(text string) string {
+	for line := range strings.SplitSeq(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); len(runes) > maxPromptPreviewChars {
+			return string(runes[:maxPromptPreviewChars-3]) + "..."
+		}
+		return line
+	}
+	return ""
+}
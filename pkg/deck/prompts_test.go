@@ -0,0 +1,109 @@
+package deck
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("System prompts", func() {
+	const (
+		promptV1 = "You are a coding agent.\nBe concise."
+		promptV2 = "You are a coding agent.\nBe thorough.\nRun the tests."
+	)
+
+	var (
+		ctx context.Context
+		q   *Query
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		turn := func(session, prompt, text string) {
+			hash, err := driver.AddSystemPrompt(ctx, prompt)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = driver.Put(ctx, merkle.NewNode(merkle.Bucket{
+				Type:      "message",
+				Role:      "assistant",
+				Content:   []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:     "gpt-4o",
+				Provider:  "openai",
+				SessionID: session,
+			}, nil, merkle.NodeMeta{
+				Usage:            &llm.Usage{PromptTokens: 1_000_000, CompletionTokens: 100_000},
+				SystemPromptHash: hash,
+			}))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		turn("s1", promptV1, "first")
+		turn("s1", promptV1, "second")
+		turn("s2", promptV2, "third")
+
+		Expect(driver.Close()).To(Succeed())
+
+		var closeFn func() error
+		q, closeFn, err = NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	It("lists each prompt with its sessions and costs", func() {
+		versions, err := q.SystemPrompts(ctx, SystemPromptOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(2))
+
+		byHash := map[string]SystemPromptVersion{}
+		for _, v := range versions {
+			byHash[v.Hash] = v
+		}
+		v1 := byHash[storage.SystemPromptHash(promptV1)]
+		Expect(v1.Preview).To(Equal("You are a coding agent."))
+		Expect(v1.Size).To(Equal(len(promptV1)))
+		Expect(v1.Sessions).To(Equal(1))
+		Expect(v1.Requests).To(Equal(2))
+		// 1M input at $2.50 + 100k output at $10.00, twice.
+		Expect(v1.TotalCost).To(BeNumerically("~", 7.00, 1e-9))
+		Expect(v1.FirstSeen).NotTo(BeZero())
+
+		v2 := byHash[storage.SystemPromptHash(promptV2)]
+		Expect(v2.Requests).To(Equal(1))
+		Expect(v2.TotalCost).To(BeNumerically("~", 3.50, 1e-9))
+	})
+
+	It("lists the prompts of one session", func() {
+		versions, err := q.SystemPrompts(ctx, SystemPromptOptions{Session: "s2"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(HaveLen(1))
+		Expect(versions[0].Hash).To(Equal(storage.SystemPromptHash(promptV2)))
+	})
+
+	It("diffs two prompts by hash prefix", func() {
+		diff, err := q.DiffSystemPrompts(ctx, storage.SystemPromptHash(promptV1)[:8], storage.SystemPromptHash(promptV2)[:8])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff.A.Requests).To(Equal(2))
+		Expect(diff.B.Requests).To(Equal(1))
+		Expect(diff.Lines).To(Equal([]DiffLine{
+			{Op: DiffEqual, Text: "You are a coding agent."},
+			{Op: DiffDelete, Text: "Be concise."},
+			{Op: DiffInsert, Text: "Be thorough."},
+			{Op: DiffInsert, Text: "Run the tests."},
+		}))
+	})
+
+	It("rejects unknown prompts", func() {
+		_, err := q.DiffSystemPrompts(ctx, "zzzz", storage.SystemPromptHash(promptV2))
+		Expect(err).To(MatchError(ContainSubstring(`no system prompt matches "zzzz"`)))
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -15,7 +16,11 @@ import (
 	"time"
 
 	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/redact"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
 	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	entdriver "github.com/papercomputeco/tapes/pkg/storage/ent/driver"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
 	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
 )
@@ -24,9 +29,9 @@ const (
 	blockTypeToolUse    = "tool_use"
 	roleAssistant       = "assistant"
 	roleUser            = "user"
+	nodeTypeError       = "error"
 	groupIDPrefix       = "group:"
 	groupWindow         = time.Hour
-	sessionCacheTTL     = 10 * time.Second
 	messageGroupWindow  = 5 * time.Second
 	maxGroupedTextChars = 4000
 )
@@ -36,12 +41,18 @@ const (
 type Querier interface {
 	Overview(ctx context.Context, filters Filters) (*Overview, error)
 	SessionDetail(ctx context.Context, sessionID string) (*SessionDetail, error)
+	SessionDetailPage(ctx context.Context, sessionID string, page Page) (*SessionDetail, error)
 	AnalyticsOverview(ctx context.Context, filters Filters) (*AnalyticsOverview, error)
 	SessionAnalytics(ctx context.Context, sessionID string) (*SessionAnalytics, error)
+	Search(ctx context.Context, opts SearchOptions) ([]SearchHit, error)
+	SessionTree(ctx context.Context, hash string) (*SessionTree, error)
+	DiffSessions(ctx context.Context, a, b string) (*SessionDiff, error)
+	Annotate(ctx context.Context, sessionID string, annotation *SessionAnnotation) error
 }
 
 type Query struct {
 	client  *ent.Client
+	driver  *sqlite.Driver
 	pricing PricingTable
 	cache   sessionCache
 }
@@ -55,8 +66,8 @@ func (q *Query) EntClient() *ent.Client {
 	return q.client
 }
 
-func NewQuery(ctx context.Context, dbPath string, pricing PricingTable) (*Query, func() error, error) {
-	driver, err := sqlite.NewDriver(ctx, dbPath)
+func NewQuery(ctx context.Context, dbPath string, pricing PricingTable, opts ...sqlite.Option) (*Query, func() error, error) {
+	driver, err := sqlite.NewDriver(ctx, dbPath, opts...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -65,13 +76,32 @@ func NewQuery(ctx context.Context, dbPath string, pricing PricingTable) (*Query,
 		return driver.Close()
 	}
 
-	return &Query{client: driver.Client, pricing: pricing}, closeFn, nil
+	return &Query{client: driver.Client, driver: driver, pricing: pricing}, closeFn, nil
 }
 
-// userLabelCandidate holds pre-extracted label text from a user-role node,
-// avoiding a second parseContentBlocks call during label building.
-type userLabelCandidate struct {
-	text string
+// NewReadOnlyQuery is like NewQuery but opens the database read-only, so that
+// a database the tapes daemon is writing can be queried without contending
+// for its write lock. Annotate fails on a read-only query.
+func NewReadOnlyQuery(ctx context.Context, dbPath string, pricing PricingTable, opts ...sqlite.Option) (*Query, func() error, error) {
+	driver, err := sqlite.NewReadOnlyDriver(ctx, dbPath, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	closeFn := func() error {
+		return driver.Close()
+	}
+
+	return &Query{client: driver.Client, driver: driver, pricing: pricing}, closeFn, nil
+}
+
+// cipher returns the cipher the database seals content with, which also opens
+// sealed blobs, or nil when content is not encrypted.
+func (q *Query) cipher() *encryption.Cipher {
+	if q.driver == nil {
+		return nil
+	}
+	return q.driver.Cipher()
 }
 
 type sessionCandidate struct {
@@ -79,6 +109,10 @@ type sessionCandidate struct {
 	modelCosts map[string]ModelCost
 	status     string
 	nodes      []*ent.Node
+
+	// retried are error nodes branching off nodes, for requests that failed
+	// and were then retried successfully.
+	retried []*ent.Node
 }
 
 type sessionGroup struct {
@@ -88,64 +122,151 @@ type sessionGroup struct {
 	members      []sessionCandidate
 }
 
+// sessionCache holds the session candidates built at a storage version.
+// It is reused until a node is inserted, updated, or deleted, and then
+// refreshed from the sessions table where possible. See
+// refreshSessionCandidates.
 type sessionCache struct {
 	mu         sync.RWMutex
 	candidates []sessionCandidate
-	loadedAt   time.Time
+	entries    map[string]sessionEntry
+	version    int64
+	loaded     bool
 }
 
-func (q *Query) loadSessionCandidates(ctx context.Context, allowCache bool) ([]sessionCandidate, error) {
-	if allowCache {
-		if cached := q.cachedSessionCandidates(); cached != nil {
-			return cached, nil
-		}
+func (q *Query) loadSessionCandidates(ctx context.Context) ([]sessionCandidate, error) {
+	// Read the version before loading so that writes made while loading
+	// leave the cache stale rather than hiding them.
+	version, err := q.driver.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cached, ok := q.cachedSessionCandidates(version); ok {
+		return cached, nil
+	}
+	if refreshed, ok := q.refreshSessionCandidates(ctx, version); ok {
+		return refreshed, nil
 	}
 
+	// Revisions are read before the nodes, so that a session changed while
+	// loading is reloaded by the next refresh.
+	revisions, _ := q.sessionRevisions(ctx)
+
 	// Bulk-load all nodes in a single query and build ancestry chains
 	// in memory. This replaces the previous N+1 pattern where each leaf
-	// called loadAncestry with individual parent queries.
-	allNodes, err := q.client.Node.Query().Select(
-		node.FieldParentHash, node.FieldRole, node.FieldContent,
-		node.FieldModel, node.FieldProvider, node.FieldAgentName,
+	// called loadAncestry with individual parent queries. Usage records, such
+	// as embeddings, count toward costs but are not conversations. Content
+	// stays compressed until a summary or view parses it.
+	allNodes, err := q.client.Node.Query().Where(
+		node.Or(node.TypeIsNil(), node.TypeNotIn(llm.RecordEmbedding, llm.RecordTranscription)),
+	).Select(
+		node.FieldParentHash, node.FieldType, node.FieldRole, node.FieldContent,
+		node.FieldModel, node.FieldProvider, node.FieldAgentName, node.FieldSessionID,
 		node.FieldStopReason, node.FieldPromptTokens, node.FieldCompletionTokens,
 		node.FieldTotalTokens, node.FieldCacheCreationInputTokens,
-		node.FieldCacheReadInputTokens, node.FieldProject, node.FieldCreatedAt,
-	).All(ctx)
+		node.FieldCacheReadInputTokens, node.FieldReasoningTokens, node.FieldProject, node.FieldUser, node.FieldCreatedAt,
+		node.FieldAgentVersion,
+		node.FieldRequestStartedAt, node.FieldFirstChunkAt, node.FieldResponseCompletedAt,
+		node.FieldTitle, node.FieldSummary, node.FieldOutcome, node.FieldSummarizedAt,
+		node.FieldErrorStatus, node.FieldSupersededBy, node.FieldEndedAt, node.FieldEndReason,
+	).All(entdriver.WithoutDecompression(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("load nodes: %w", err)
 	}
 
 	byID := make(map[string]*ent.Node, len(allNodes))
 	hasChildren := make(map[string]bool)
+	hasSuccess := make(map[string]bool)
 	for _, n := range allNodes {
 		byID[n.ID] = n
 		if n.ParentHash != nil && *n.ParentHash != "" {
 			hasChildren[*n.ParentHash] = true
+			if n.Type != nodeTypeError {
+				hasSuccess[*n.ParentHash] = true
+			}
 		}
 	}
 
 	candidates := make([]sessionCandidate, 0)
+	entries := make(map[string]sessionEntry)
+	var retried []*ent.Node
 	for _, n := range allNodes {
 		if hasChildren[n.ID] {
 			continue
 		}
-
-		chain := buildAncestryChain(n, byID)
-		summary, modelCosts, status, err := q.buildSessionSummaryFromNodes(chain)
-		if err != nil {
+		entry, ok := q.buildSessionEntry(n, buildAncestryChain(n, byID), hasSuccess[derefString(n.ParentHash)])
+		entry.revision = revisionOf(revisions, n.ID)
+		entries[n.ID] = entry
+		if !ok {
+			continue
+		}
+		if entry.retried != nil {
+			retried = append(retried, entry.retried)
 			continue
 		}
+		candidates = append(candidates, *entry.candidate)
+	}
+
+	attachRetriedErrors(candidates, retried)
 
-		candidates = append(candidates, sessionCandidate{
+	q.storeSessionCandidates(candidates, version, entries)
+	return candidates, nil
+}
+
+// buildSessionEntry classifies the leaf n, with chain its conversation from
+// the root, reporting false when it is not part of any session. retriedOK
+// reports whether n's parent has a child that is not an error.
+func (q *Query) buildSessionEntry(n *ent.Node, chain []*ent.Node, retriedOK bool) (sessionEntry, bool) {
+	// A reprocessed turn replaces the leaf it superseded.
+	if n.SupersededBy != nil {
+		return sessionEntry{}, false
+	}
+	// A failed request that was retried belongs to the session of the
+	// retry rather than being a session of its own.
+	if n.Type == nodeTypeError && n.ParentHash != nil && retriedOK {
+		return sessionEntry{retried: n}, true
+	}
+
+	if len(chain) == 0 {
+		return sessionEntry{}, false
+	}
+	return tallyEntry(q.newSessionTally(chain)), true
+}
+
+// tallyEntry returns the entry of the session tallied by t.
+func tallyEntry(t *sessionTally) sessionEntry {
+	summary, status := t.summary()
+	return sessionEntry{
+		tally: t,
+		candidate: &sessionCandidate{
 			summary:    summary,
-			modelCosts: modelCosts,
+			modelCosts: t.modelCosts,
 			status:     status,
-			nodes:      chain,
-		})
+			nodes:      t.nodes,
+		},
 	}
+}
 
-	q.storeSessionCandidates(candidates)
-	return candidates, nil
+// attachRetriedErrors adds each retried error node to the first session
+// candidate whose chain contains the node it branched off.
+func attachRetriedErrors(candidates []sessionCandidate, retried []*ent.Node) {
+	if len(retried) == 0 {
+		return
+	}
+
+	owner := map[string]int{}
+	for i, candidate := range candidates {
+		for _, n := range candidate.nodes {
+			if _, ok := owner[n.ID]; !ok {
+				owner[n.ID] = i
+			}
+		}
+	}
+	for _, n := range retried {
+		if i, ok := owner[*n.ParentHash]; ok {
+			candidates[i].retried = append(candidates[i].retried, n)
+		}
+	}
 }
 
 // buildAncestryChain walks from a leaf to root using the in-memory node map,
@@ -171,25 +292,24 @@ func buildAncestryChain(leaf *ent.Node, byID map[string]*ent.Node) []*ent.Node {
 	return chain
 }
 
-func (q *Query) cachedSessionCandidates() []sessionCandidate {
+func (q *Query) cachedSessionCandidates(version int64) ([]sessionCandidate, bool) {
 	q.cache.mu.RLock()
 	defer q.cache.mu.RUnlock()
 
-	if len(q.cache.candidates) == 0 {
-		return nil
-	}
-	if time.Since(q.cache.loadedAt) > sessionCacheTTL {
-		return nil
+	if !q.cache.loaded || q.cache.version != version {
+		return nil, false
 	}
 
-	return copySessionCandidates(q.cache.candidates)
+	return copySessionCandidates(q.cache.candidates), true
 }
 
-func (q *Query) storeSessionCandidates(candidates []sessionCandidate) {
+func (q *Query) storeSessionCandidates(candidates []sessionCandidate, version int64, entries map[string]sessionEntry) {
 	q.cache.mu.Lock()
 	defer q.cache.mu.Unlock()
 	q.cache.candidates = copySessionCandidates(candidates)
-	q.cache.loadedAt = time.Now()
+	q.cache.entries = entries
+	q.cache.version = version
+	q.cache.loaded = true
 }
 
 func copySessionCandidates(candidates []sessionCandidate) []sessionCandidate {
@@ -229,11 +349,15 @@ func groupSessionCandidates(candidates []sessionCandidate) []*sessionGroup {
 					Label:        candidate.summary.Label,
 					Model:        candidate.summary.Model,
 					Project:      candidate.summary.Project,
+					User:         candidate.summary.User,
 					AgentName:    candidate.summary.AgentName,
+					AgentVersion: candidate.summary.AgentVersion,
 					Status:       candidate.summary.Status,
 					StartTime:    candidate.summary.StartTime,
 					EndTime:      candidate.summary.EndTime,
 					Duration:     candidate.summary.Duration,
+					EndReason:    candidate.summary.EndReason,
+					SessionID:    candidate.summary.SessionID,
 					InputTokens:  candidate.summary.InputTokens,
 					OutputTokens: candidate.summary.OutputTokens,
 					InputCost:    candidate.summary.InputCost,
@@ -242,6 +366,10 @@ func groupSessionCandidates(candidates []sessionCandidate) []*sessionGroup {
 					ToolCalls:    candidate.summary.ToolCalls,
 					MessageCount: candidate.summary.MessageCount,
 					SessionCount: 1,
+					Title:        candidate.summary.Title,
+					Summary:      candidate.summary.Summary,
+					Outcome:      candidate.summary.Outcome,
+					SummarizedAt: candidate.summary.SummarizedAt,
 				},
 				modelCosts:   copyModelCosts(candidate.modelCosts),
 				statusCounts: map[string]int{candidate.summary.Status: 1},
@@ -253,6 +381,9 @@ func groupSessionCandidates(candidates []sessionCandidate) []*sessionGroup {
 		}
 
 		group.members = append(group.members, candidate)
+		if !candidate.summary.EndTime.Before(group.summary.EndTime) {
+			group.summary.EndReason = candidate.summary.EndReason
+		}
 		group.summary.EndTime = maxTime(group.summary.EndTime, candidate.summary.EndTime)
 		group.summary.Duration = max(group.summary.EndTime.Sub(group.summary.StartTime), 0)
 		group.summary.InputTokens += candidate.summary.InputTokens
@@ -265,6 +396,14 @@ func groupSessionCandidates(candidates []sessionCandidate) []*sessionGroup {
 		group.summary.SessionCount++
 		group.statusCounts[candidate.summary.Status]++
 		mergeModelCosts(group.modelCosts, candidate.modelCosts)
+		if group.summary.SummarizedAt == nil && candidate.summary.SummarizedAt != nil {
+			group.summary.Title = candidate.summary.Title
+			group.summary.Summary = candidate.summary.Summary
+			group.summary.Outcome = candidate.summary.Outcome
+			group.summary.SummarizedAt = candidate.summary.SummarizedAt
+		} else if group.summary.Title == "" {
+			group.summary.Title = candidate.summary.Title
+		}
 	}
 
 	for _, group := range groups {
@@ -305,7 +444,17 @@ func sessionGroupKey(summary SessionSummary) string {
 	}
 	agent := strings.ToLower(strings.TrimSpace(summary.AgentName))
 	project := strings.ToLower(strings.TrimSpace(summary.Project))
-	return strings.Join([]string{label, agent, project}, "|")
+	parts := []string{label, agent, project}
+	// Sessions of different users are never merged. Sessions without a user
+	// keep the key, and so the group ID, they had before users were tracked.
+	if user := strings.ToLower(strings.TrimSpace(summary.User)); user != "" {
+		parts = append(parts, user)
+	}
+	// Likewise for concurrent sessions of agents that identify them.
+	if summary.SessionID != "" {
+		parts = append(parts, summary.SessionID)
+	}
+	return strings.Join(parts, "|")
 }
 
 func normalizeSessionLabel(label string) string {
@@ -411,12 +560,15 @@ func firstNonEmptyModel(members []sessionCandidate) string {
 }
 
 func (q *Query) Overview(ctx context.Context, filters Filters) (*Overview, error) {
-	candidates, err := q.loadSessionCandidates(ctx, false)
+	candidates, err := q.loadSessionCandidates(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	groups := groupSessionCandidates(candidates)
+	groups, err := q.filterGroups(ctx, groupSessionCandidates(candidates), filters)
+	if err != nil {
+		return nil, err
+	}
 	overview := &Overview{
 		Sessions:    make([]SessionSummary, 0, len(groups)),
 		CostByModel: map[string]ModelCost{},
@@ -424,9 +576,6 @@ func (q *Query) Overview(ctx context.Context, filters Filters) (*Overview, error
 
 	for _, group := range groups {
 		summary := group.summary
-		if !matchesFilters(summary, filters) {
-			continue
-		}
 
 		overview.Sessions = append(overview.Sessions, summary)
 
@@ -459,18 +608,32 @@ func (q *Query) Overview(ctx context.Context, filters Filters) (*Overview, error
 		}
 	}
 
-	if total := len(overview.Sessions); total > 0 {
-		overview.SuccessRate = float64(overview.Completed) / float64(total)
+	overview.TotalSessions = len(overview.Sessions)
+	if overview.TotalSessions > 0 {
+		overview.SuccessRate = float64(overview.Completed) / float64(overview.TotalSessions)
 	}
 
 	SortSessions(overview.Sessions, filters.Sort, filters.SortDir)
 
+	page, next, err := pageSessions(overview.Sessions, filters)
+	if err != nil {
+		return nil, err
+	}
+	overview.Sessions = page
+	overview.NextCursor = next
+
 	return overview, nil
 }
 
 func (q *Query) SessionDetail(ctx context.Context, sessionID string) (*SessionDetail, error) {
+	return q.SessionDetailPage(ctx, sessionID, Page{})
+}
+
+// SessionDetailPage returns a session with the page of its messages selected
+// by page. The summary and tool frequency always cover the whole session.
+func (q *Query) SessionDetailPage(ctx context.Context, sessionID string, page Page) (*SessionDetail, error) {
 	if isGroupID(sessionID) {
-		return q.groupSessionDetail(ctx, sessionID)
+		return q.groupSessionDetail(ctx, sessionID, page)
 	}
 
 	leaf, err := q.client.Node.Get(ctx, sessionID)
@@ -488,20 +651,24 @@ func (q *Query) SessionDetail(ctx context.Context, sessionID string) (*SessionDe
 		return nil, err
 	}
 
-	messages, toolFrequency := q.buildSessionMessages(nodes)
+	messages, toolFrequency, next, err := q.sessionMessagesPage(ctx, sessionID, nodes, page)
+	if err != nil {
+		return nil, err
+	}
 	grouped := buildGroupedMessages(messages)
 	detail := &SessionDetail{
 		Summary:         summary,
 		Messages:        messages,
 		GroupedMessages: grouped,
 		ToolFrequency:   toolFrequency,
+		NextCursor:      next,
 	}
 
 	return detail, nil
 }
 
-func (q *Query) groupSessionDetail(ctx context.Context, sessionID string) (*SessionDetail, error) {
-	candidates, err := q.loadSessionCandidates(ctx, true)
+func (q *Query) groupSessionDetail(ctx context.Context, sessionID string, page Page) (*SessionDetail, error) {
+	candidates, err := q.loadSessionCandidates(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -513,7 +680,10 @@ func (q *Query) groupSessionDetail(ctx context.Context, sessionID string) (*Sess
 	}
 
 	nodes := groupNodes(target.members)
-	messages, toolFrequency := q.buildSessionMessages(nodes)
+	messages, toolFrequency, next, err := q.sessionMessagesPage(ctx, sessionID, nodes, page)
+	if err != nil {
+		return nil, err
+	}
 	grouped := buildGroupedMessages(messages)
 
 	subSessions := make([]SessionSummary, 0, len(target.members))
@@ -530,11 +700,16 @@ func (q *Query) groupSessionDetail(ctx context.Context, sessionID string) (*Sess
 		GroupedMessages: grouped,
 		ToolFrequency:   toolFrequency,
 		SubSessions:     subSessions,
+		NextCursor:      next,
 	}
 
 	return detail, nil
 }
 
+// groupNodes merges the chains of a group's members into one transcript.
+// Each chain is already in turn order, which is kept whatever the nodes'
+// timestamps say: chains are interleaved by creation time, so that a clock
+// change or turns stored within the same instant cannot reorder a chain.
 func groupNodes(members []sessionCandidate) []*ent.Node {
 	total := 0
 	for _, member := range members {
@@ -542,27 +717,61 @@ func groupNodes(members []sessionCandidate) []*ent.Node {
 	}
 
 	nodes := make([]*ent.Node, 0, total)
-	for _, member := range members {
-		nodes = append(nodes, member.nodes...)
-	}
-
-	sort.Slice(nodes, func(i, j int) bool {
-		if nodes[i].CreatedAt.Equal(nodes[j].CreatedAt) {
-			return nodes[i].ID < nodes[j].ID
+	next := make([]int, len(members))
+	for len(nodes) < total {
+		pick := -1
+		for i, member := range members {
+			if next[i] == len(member.nodes) {
+				continue
+			}
+			if pick < 0 || nodeBefore(member.nodes[next[i]], members[pick].nodes[next[pick]]) {
+				pick = i
+			}
 		}
-		return nodes[i].CreatedAt.Before(nodes[j].CreatedAt)
-	})
+		nodes = append(nodes, members[pick].nodes[next[pick]])
+		next[pick]++
+	}
 
 	return nodes
 }
 
-func (q *Query) buildSessionMessages(nodes []*ent.Node) ([]SessionMessage, map[string]int) {
+// nodeBefore orders nodes of different chains by creation time, then by
+// turn index, then by hash.
+func nodeBefore(a, b *ent.Node) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	if ai, bi := turnIndex(a), turnIndex(b); ai != bi {
+		return ai < bi
+	}
+	return a.ID < b.ID
+}
+
+// turnIndex returns the node's position in its conversation, or 0 for nodes
+// stored before turn indexes were recorded.
+func turnIndex(n *ent.Node) int {
+	if n.TurnIndex == nil {
+		return 0
+	}
+	return *n.TurnIndex
+}
+
+// turnGap returns the time between two consecutive turns. A clock set back
+// between them would make the gap negative; it is reported as none.
+func turnGap(prev, next *ent.Node) time.Duration {
+	return max(next.CreatedAt.Sub(prev.CreatedAt), 0)
+}
+
+// buildSessionMessages builds the detailed message list for a session.
+// Content offloaded to a blob store is rehydrated so that full text is shown;
+// blocks whose blobs cannot be fetched keep their reference.
+func (q *Query) buildSessionMessages(ctx context.Context, nodes []*ent.Node) ([]SessionMessage, map[string]int) {
 	messages := make([]SessionMessage, 0, len(nodes))
 	toolFrequency := map[string]int{}
 
-	var lastTime time.Time
 	for i, node := range nodes {
 		blocks, _ := parseContentBlocks(node.Content)
+		blocks, _ = blob.Rehydrate(ctx, blocks, q.cipher())
 		t := tokenCounts(node)
 		inputCost, outputCost, totalCost := q.costForNode(node, t)
 
@@ -574,9 +783,13 @@ func (q *Query) buildSessionMessages(nodes []*ent.Node) ([]SessionMessage, map[s
 		text := extractText(blocks)
 		delta := time.Duration(0)
 		if i > 0 {
-			delta = node.CreatedAt.Sub(lastTime)
+			delta = turnGap(nodes[i-1], node)
+		}
+
+		traceID := ""
+		if node.TraceID != nil {
+			traceID = *node.TraceID
 		}
-		lastTime = node.CreatedAt
 
 		messages = append(messages, SessionMessage{
 			Hash:         node.ID,
@@ -592,6 +805,7 @@ func (q *Query) buildSessionMessages(nodes []*ent.Node) ([]SessionMessage, map[s
 			TotalCost:    totalCost,
 			ToolCalls:    toolCalls,
 			Text:         text,
+			TraceID:      traceID,
 		})
 	}
 
@@ -742,137 +956,192 @@ func (q *Query) buildSessionSummaryFromNodes(nodes []*ent.Node) (SessionSummary,
 	if len(nodes) == 0 {
 		return SessionSummary{}, nil, "", errors.New("empty session nodes")
 	}
+	t := q.newSessionTally(nodes)
+	summary, status := t.summary()
+	return summary, t.modelCosts, status, nil
+}
 
-	start := nodes[0].CreatedAt
-	end := nodes[len(nodes)-1].CreatedAt
-	duration := max(end.Sub(start), 0)
+// sessionTally accumulates a session's summary one turn at a time from its
+// root, so that a session that gained turns is summarized by adding only
+// them to the tally of its earlier turns.
+type sessionTally struct {
+	nodes []*ent.Node
 
-	toolCalls := 0
-	modelCosts := map[string]ModelCost{}
-	inputTokens := int64(0)
-	outputTokens := int64(0)
+	toolCalls      int
+	modelCosts     map[string]ModelCost
+	inputTokens    int64
+	outputTokens   int64
+	hasToolError   bool
+	hasGitActivity bool
 
-	// Parse content blocks once per node and collect label candidates
-	// from user-role nodes (in forward order). Label building reverses
-	// these later to pick the most recent prompts.
-	var userLabels []userLabelCandidate
+	// labelLines are the first lines of the most recent user prompts, at
+	// most labelPrompts of them, oldest first.
+	labelLines []string
+
+	model        string
+	project      string
+	user         string
+	agentName    string
+	agentVersion string
+}
+
+// newSessionTally tallies nodes, a conversation from its root.
+func (q *Query) newSessionTally(nodes []*ent.Node) *sessionTally {
+	t := &sessionTally{modelCosts: map[string]ModelCost{}}
+	q.addTurns(t, nodes)
+	return t
+}
+
+// extend returns a tally of t's turns followed by nodes, leaving t as it is.
+func (q *Query) extendSessionTally(t *sessionTally, nodes []*ent.Node) *sessionTally {
+	extended := *t
+	extended.nodes = slices.Clip(t.nodes)
+	extended.modelCosts = maps.Clone(t.modelCosts)
+	extended.labelLines = slices.Clone(t.labelLines)
+	q.addTurns(&extended, nodes)
+	return &extended
+}
+
+// addTurns adds nodes, the turns that follow t's, to t.
+func (q *Query) addTurns(t *sessionTally, nodes []*ent.Node) {
+	const labelPrompts = 3
 
-	hasToolError := false
-	hasGitActivity := false
 	for _, n := range nodes {
+		t.nodes = append(t.nodes, n)
+
 		blocks, _ := parseContentBlocks(n.Content)
-		toolCalls += countToolCalls(blocks)
+		t.toolCalls += countToolCalls(blocks)
 		if blocksHaveToolError(blocks) {
-			hasToolError = true
+			t.hasToolError = true
 		}
 		if blocksHaveGitActivity(blocks) {
-			hasGitActivity = true
+			t.hasGitActivity = true
 		}
 
-		// Collect label text from user-role nodes in the same pass
 		if n.Role == roleUser {
-			text := strings.TrimSpace(extractLabelText(blocks))
-			if text != "" {
-				userLabels = append(userLabels, userLabelCandidate{text: text})
+			if line := firstLabelLine(strings.TrimSpace(extractLabelText(blocks))); line != "" {
+				t.labelLines = append(t.labelLines, line)
+				if len(t.labelLines) > labelPrompts {
+					t.labelLines = slices.Delete(t.labelLines, 0, 1)
+				}
 			}
 		}
 
-		t := tokenCounts(n)
-		inputTokens += t.Input
-		outputTokens += t.Output
+		// Metadata comes from the first turn that has it set.
+		if t.model == "" && n.Model != "" {
+			t.model = normalizeModel(n.Model)
+		}
+		if t.project == "" && n.Project != nil {
+			t.project = *n.Project
+		}
+		if t.user == "" && n.User != nil {
+			t.user = *n.User
+		}
+		if t.agentName == "" {
+			t.agentName = n.AgentName
+		}
+		if t.agentVersion == "" && n.AgentVersion != nil {
+			t.agentVersion = *n.AgentVersion
+		}
+
+		tokens := tokenCounts(n)
+		t.inputTokens += tokens.Input
+		t.outputTokens += tokens.Output
 
 		model := normalizeModel(n.Model)
 		if model == "" {
 			continue
 		}
-
 		pricing, ok := PricingForModel(q.pricing, model)
 		if !ok {
 			continue
 		}
 
-		inputCost, outputCost, totalCost := CostForTokensWithCache(pricing, t.Input, t.Output, t.CacheCreation, t.CacheRead)
-		current := modelCosts[model]
+		inputCost, outputCost, totalCost := CostForTokensWithCache(pricing, tokens.Input, tokens.Output, tokens.CacheCreation, tokens.CacheRead)
+		current := t.modelCosts[model]
 		current.Model = model
-		current.InputTokens += t.Input
-		current.OutputTokens += t.Output
+		current.InputTokens += tokens.Input
+		current.OutputTokens += tokens.Output
 		current.InputCost += inputCost
 		current.OutputCost += outputCost
 		current.TotalCost += totalCost
 		current.SessionCount = 1
-		modelCosts[model] = current
+		t.modelCosts[model] = current
 	}
+}
+
+// summary returns the summary of the tallied session and its status.
+func (t *sessionTally) summary() (SessionSummary, string) {
+	const labelLimit = 36
 
-	// Build label from collected user prompts (most recent first)
-	label := buildLabelFromCandidates(userLabels, nodes[len(nodes)-1].ID)
+	root, leaf := t.nodes[0], t.nodes[len(t.nodes)-1]
 
-	model := dominantModel(modelCosts)
-	if model == "" {
-		model = firstModel(nodes)
+	// A session marked ended lasted until then, such as until its agent
+	// exited, rather than only until its last turn.
+	start := root.CreatedAt
+	end := leaf.CreatedAt
+	endReason := ""
+	if root.EndedAt != nil && !root.EndedAt.Before(end) {
+		end = *root.EndedAt
+		if root.EndReason != nil {
+			endReason = *root.EndReason
+		}
 	}
-	inputCost, outputCost, totalCost := sumModelCosts(modelCosts)
 
-	status := determineStatus(nodes[len(nodes)-1], hasToolError, hasGitActivity)
+	label := truncate(leaf.ID, 12)
+	if len(t.labelLines) > 0 {
+		label = truncate(strings.Join(t.labelLines, " / "), labelLimit)
+	}
 
-	// Extract project from the first node that has one set
-	project := ""
-	for _, n := range nodes {
-		if n.Project != nil && *n.Project != "" {
-			project = *n.Project
-			break
-		}
+	model := dominantModel(t.modelCosts)
+	if model == "" {
+		model = t.model
 	}
+	inputCost, outputCost, totalCost := sumModelCosts(t.modelCosts)
 
-	agentName := ""
-	for _, n := range nodes {
-		if n.AgentName != "" {
-			agentName = n.AgentName
-			break
-		}
+	status := determineStatus(leaf, t.hasToolError, t.hasGitActivity)
+
+	sessionID := ""
+	if root.SessionID != nil {
+		sessionID = *root.SessionID
 	}
 
 	summary := SessionSummary{
-		ID:           nodes[len(nodes)-1].ID,
+		ID:           leaf.ID,
 		Label:        label,
 		Model:        model,
-		Project:      project,
-		AgentName:    agentName,
+		Project:      t.project,
+		User:         t.user,
+		AgentName:    t.agentName,
+		AgentVersion: t.agentVersion,
 		Status:       status,
 		StartTime:    start,
 		EndTime:      end,
-		Duration:     duration,
-		InputTokens:  inputTokens,
-		OutputTokens: outputTokens,
+		Duration:     max(end.Sub(start), 0),
+		EndReason:    endReason,
+		SessionID:    sessionID,
+		InputTokens:  t.inputTokens,
+		OutputTokens: t.outputTokens,
 		InputCost:    inputCost,
 		OutputCost:   outputCost,
 		TotalCost:    totalCost,
-		ToolCalls:    toolCalls,
-		MessageCount: len(nodes),
+		ToolCalls:    t.toolCalls,
+		MessageCount: len(t.nodes),
 		SessionCount: 1,
 	}
+	applyAnnotation(&summary, root)
 
-	return summary, modelCosts, status, nil
+	return summary, status
 }
 
+// loadAncestry returns the nodes from the root of leaf's conversation to
+// leaf, loaded in a single query.
 func (q *Query) loadAncestry(ctx context.Context, leaf *ent.Node) ([]*ent.Node, error) {
-	nodes := []*ent.Node{}
-	current := leaf
-	for current != nil {
-		nodes = append(nodes, current)
-		parent, err := current.QueryParent().Only(ctx)
-		if ent.IsNotFound(err) {
-			break
-		}
-		if err != nil {
-			return nil, fmt.Errorf("query parent: %w", err)
-		}
-		current = parent
-	}
-
-	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
-		nodes[i], nodes[j] = nodes[j], nodes[i]
+	nodes, err := q.driver.AncestryNodes(ctx, leaf.ID)
+	if err != nil {
+		return nil, fmt.Errorf("load ancestry: %w", err)
 	}
-
+	slices.Reverse(nodes)
 	return nodes, nil
 }
 
@@ -897,6 +1166,9 @@ type nodeTokens struct {
 	Total         int64
 	CacheCreation int64
 	CacheRead     int64
+
+	// Reasoning is the part of Output spent on hidden reasoning.
+	Reasoning int64
 }
 
 func tokenCounts(node *ent.Node) nodeTokens {
@@ -913,6 +1185,15 @@ func tokenCounts(node *ent.Node) nodeTokens {
 	if node.CacheReadInputTokens != nil {
 		t.CacheRead = int64(*node.CacheReadInputTokens)
 	}
+	if node.ReasoningTokens != nil {
+		t.Reasoning = int64(*node.ReasoningTokens)
+		// Reasoning tokens are billed as output. Providers normally include
+		// them in the completion count; when the completion count is smaller
+		// it cannot have, so add them to avoid underreporting the turn.
+		if t.Reasoning > t.Output {
+			t.Output += t.Reasoning
+		}
+	}
 
 	t.Total = t.Input + t.Output
 	if node.TotalTokens != nil {
@@ -927,6 +1208,13 @@ func parseContentBlocks(raw []map[string]any) ([]llm.ContentBlock, error) {
 		return nil, nil
 	}
 
+	// Session candidates are loaded without decompression, so content is
+	// only decompressed for the nodes that are actually read.
+	raw, err := entdriver.DecompressContent(raw)
+	if err != nil {
+		return nil, err
+	}
+
 	data, err := json.Marshal(raw)
 	if err != nil {
 		return nil, err
@@ -969,6 +1257,20 @@ func blocksHaveToolError(blocks []llm.ContentBlock) bool {
 	return false
 }
 
+// countRedactions tallies the redaction markers left in stored content by rule.
+func countRedactions(blocks []llm.ContentBlock, counts map[string]int) {
+	for _, block := range blocks {
+		redact.CountMarkers(block.Text, counts)
+		redact.CountMarkers(block.Thinking, counts)
+		redact.CountMarkers(block.ToolOutput, counts)
+		if len(block.ToolInput) > 0 {
+			if data, err := json.Marshal(block.ToolInput); err == nil {
+				redact.CountMarkers(string(data), counts)
+			}
+		}
+	}
+}
+
 // gitCommandPattern matches common git commit and push invocations inside
 // shell command strings captured from Bash tool calls.
 var gitCommandPatterns = []string{
@@ -1047,36 +1349,6 @@ func buildLabel(nodes []*ent.Node) string {
 	return truncate(label, labelLimit)
 }
 
-// buildLabelFromCandidates builds a label from pre-extracted user prompt texts
-// (collected in forward order). This avoids re-parsing content blocks.
-func buildLabelFromCandidates(candidates []userLabelCandidate, fallbackID string) string {
-	const labelLimit = 36
-	const labelPrompts = 3
-
-	labelLines := make([]string, 0, labelPrompts)
-	for i := len(candidates) - 1; i >= 0; i-- {
-		line := firstLabelLine(candidates[i].text)
-		if line == "" {
-			continue
-		}
-		labelLines = append(labelLines, line)
-		if len(labelLines) >= labelPrompts {
-			break
-		}
-	}
-
-	if len(labelLines) == 0 {
-		return truncate(fallbackID, 12)
-	}
-
-	for i, j := 0, len(labelLines)-1; i < j; i, j = i+1, j-1 {
-		labelLines[i], labelLines[j] = labelLines[j], labelLines[i]
-	}
-
-	label := strings.Join(labelLines, " / ")
-	return truncate(label, labelLimit)
-}
-
 func firstLabelLine(text string) string {
 	for line := range strings.SplitSeq(text, "\n") {
 		line = strings.TrimSpace(line)
@@ -1156,15 +1428,6 @@ func dominantModel(costs map[string]ModelCost) string {
 	return model
 }
 
-func firstModel(nodes []*ent.Node) string {
-	for _, node := range nodes {
-		if node.Model != "" {
-			return normalizeModel(node.Model)
-		}
-	}
-	return ""
-}
-
 func sumModelCosts(costs map[string]ModelCost) (float64, float64, float64) {
 	inputCost := 0.0
 	outputCost := 0.0
@@ -1189,81 +1452,105 @@ func matchesFilters(summary SessionSummary, filters Filters) bool {
 	if filters.Project != "" && summary.Project != filters.Project {
 		return false
 	}
-	if filters.From != nil && summary.EndTime.Before(*filters.From) {
+	if filters.User != "" && summary.User != filters.User {
 		return false
 	}
-	if filters.To != nil && summary.StartTime.After(*filters.To) {
+	if filters.Label != "" {
+		label := strings.ToLower(filters.Label)
+		if !strings.Contains(strings.ToLower(summary.Label), label) && !strings.Contains(strings.ToLower(summary.Title), label) {
+			return false
+		}
+	}
+	if filters.MinCost > 0 && summary.TotalCost < filters.MinCost {
 		return false
 	}
+	if filters.MaxCost > 0 && summary.TotalCost > filters.MaxCost {
+		return false
+	}
+	return true
+}
+
+// leafFilter translates the filters that storage can evaluate: time bounds,
+// tools, and stop reasons.
+func leafFilter(filters Filters, now time.Time) sqlite.LeafFilter {
+	var f sqlite.LeafFilter
+	if filters.From != nil {
+		f.EndedAfter = *filters.From
+	}
 	if filters.Since > 0 {
-		cutoff := time.Now().Add(-filters.Since)
-		if summary.EndTime.Before(cutoff) {
-			return false
+		if cutoff := now.Add(-filters.Since); cutoff.After(f.EndedAfter) {
+			f.EndedAfter = cutoff
 		}
 	}
-	return true
+	if filters.To != nil {
+		f.StartedBefore = *filters.To
+	}
+	if filters.Until > 0 {
+		if cutoff := now.Add(-filters.Until); f.StartedBefore.IsZero() || cutoff.Before(f.StartedBefore) {
+			f.StartedBefore = cutoff
+		}
+	}
+	f.Tool = filters.Tool
+	f.StopReason = filters.StopReason
+	return f
 }
 
-// SortSessions sorts session summaries in place by the given key and direction.
-func SortSessions(sessions []SessionSummary, sortKey, sortDir string) {
-	ascending := strings.EqualFold(sortDir, "asc")
-	switch sortKey {
-	case "date":
-		sort.Slice(sessions, func(i, j int) bool {
-			if ascending {
-				return sessions[i].StartTime.Before(sessions[j].StartTime)
-			}
-			return sessions[i].StartTime.After(sessions[j].StartTime)
-		})
-	case "tokens":
-		sort.Slice(sessions, func(i, j int) bool {
-			left := sessions[i].InputTokens + sessions[i].OutputTokens
-			right := sessions[j].InputTokens + sessions[j].OutputTokens
-			if ascending {
-				return left < right
-			}
-			return left > right
-		})
-	case "duration":
-		sort.Slice(sessions, func(i, j int) bool {
-			if ascending {
-				return sessions[i].Duration < sessions[j].Duration
-			}
-			return sessions[i].Duration > sessions[j].Duration
-		})
-	default:
-		sort.Slice(sessions, func(i, j int) bool {
-			if ascending {
-				return sessions[i].TotalCost < sessions[j].TotalCost
-			}
-			return sessions[i].TotalCost > sessions[j].TotalCost
-		})
+// filterGroups returns the groups matching filters. Time, tool, and stop
+// reason filters are evaluated by storage, and a group matches them when any
+// of its conversations does; the rest apply to the group summary.
+func (q *Query) filterGroups(ctx context.Context, groups []*sessionGroup, filters Filters) ([]*sessionGroup, error) {
+	var leaves map[string]bool
+	if f := leafFilter(filters, time.Now()); !f.IsZero() {
+		var err error
+		leaves, err = q.driver.FilterLeaves(ctx, f)
+		if err != nil {
+			return nil, err
+		}
 	}
+
+	matched := make([]*sessionGroup, 0, len(groups))
+	for _, group := range groups {
+		if !matchesFilters(group.summary, filters) {
+			continue
+		}
+		if leaves != nil && !slices.ContainsFunc(group.members, func(member sessionCandidate) bool {
+			return leaves[branchLeaf(member)]
+		}) {
+			continue
+		}
+		matched = append(matched, group)
+	}
+	return matched, nil
 }
 
+// SortSessions sorts session summaries in place by the given key and direction.
 func (q *Query) AnalyticsOverview(ctx context.Context, filters Filters) (*AnalyticsOverview, error) {
-	candidates, err := q.loadSessionCandidates(ctx, false)
+	candidates, err := q.loadSessionCandidates(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	groups := groupSessionCandidates(candidates)
+	groups, err := q.filterGroups(ctx, groupSessionCandidates(candidates), filters)
+	if err != nil {
+		return nil, err
+	}
 	analytics := &AnalyticsOverview{
 		ProviderBreakdown: map[string]int{},
+		Redactions:        map[string]int{},
 	}
 
 	toolGlobal := map[string]*ToolMetric{}
-	toolErrors := map[string]int{}
+	toolCalls := newToolCallAccumulator()
 	toolSessions := map[string]map[string]bool{}
 	dayMap := map[string]*DayActivity{}
 	modelMap := map[string]*modelAccumulator{}
+	latency := newLatencyAccumulator()
+	cache := newCacheAccumulator(q.pricing)
+	errs := newErrorAccumulator()
 	var filteredSummaries []SessionSummary
 
 	for _, group := range groups {
 		summary := group.summary
-		if !matchesFilters(summary, filters) {
-			continue
-		}
 
 		filteredSummaries = append(filteredSummaries, summary)
 		analytics.TotalSessions++
@@ -1285,6 +1572,7 @@ func (q *Query) AnalyticsOverview(ctx context.Context, filters Filters) (*Analyt
 		sessionTools := map[string]bool{}
 		provider := ""
 		for _, member := range group.members {
+			toolCalls.addChain(member.nodes)
 			for _, n := range member.nodes {
 				blocks, _ := parseContentBlocks(n.Content)
 				for _, tool := range extractToolCalls(blocks) {
@@ -1294,11 +1582,10 @@ func (q *Query) AnalyticsOverview(ctx context.Context, filters Filters) (*Analyt
 					toolGlobal[tool].Count++
 					sessionTools[tool] = true
 				}
-				if blocksHaveToolError(blocks) {
-					for _, tool := range extractToolCalls(blocks) {
-						toolErrors[tool]++
-					}
-				}
+				countRedactions(blocks, analytics.Redactions)
+				latency.add(n)
+				cache.add(n)
+				errs.add(n)
 				if n.Provider != "" {
 					analytics.ProviderBreakdown[n.Provider]++
 					if provider == "" {
@@ -1306,6 +1593,9 @@ func (q *Query) AnalyticsOverview(ctx context.Context, filters Filters) (*Analyt
 					}
 				}
 			}
+			for _, n := range member.retried {
+				errs.add(n)
+			}
 		}
 		for tool := range sessionTools {
 			if toolSessions[tool] == nil {
@@ -1339,7 +1629,7 @@ func (q *Query) AnalyticsOverview(ctx context.Context, filters Filters) (*Analyt
 
 	// Build top tools sorted by count
 	for name, metric := range toolGlobal {
-		metric.ErrorCount = toolErrors[name]
+		toolCalls.apply(metric)
 		metric.Sessions = len(toolSessions[name])
 		analytics.TopTools = append(analytics.TopTools, *metric)
 	}
@@ -1391,6 +1681,9 @@ func (q *Query) AnalyticsOverview(ctx context.Context, filters Filters) (*Analyt
 	// Build duration and cost buckets from already-computed summaries
 	analytics.DurationBuckets = buildDurationBucketsFromSummaries(filteredSummaries)
 	analytics.CostBuckets = buildCostBucketsFromSummaries(filteredSummaries)
+	analytics.Latency = latency.metrics()
+	analytics.Cache, analytics.CacheHitRate = cache.result()
+	analytics.Errors, analytics.ErrorRate = errs.result()
 
 	return analytics, nil
 }
@@ -1414,7 +1707,7 @@ func (q *Query) SessionAnalytics(ctx context.Context, sessionID string) (*Sessio
 }
 
 func (q *Query) groupSessionAnalytics(ctx context.Context, sessionID string) (*SessionAnalytics, error) {
-	candidates, err := q.loadSessionCandidates(ctx, true)
+	candidates, err := q.loadSessionCandidates(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1432,8 +1725,8 @@ func (q *Query) groupSessionAnalytics(ctx context.Context, sessionID string) (*S
 func buildSessionAnalytics(sessionID string, nodes []*ent.Node) *SessionAnalytics {
 	sa := &SessionAnalytics{SessionID: sessionID}
 	uniqueTools := map[string]bool{}
+	latency := newLatencyAccumulator()
 
-	var lastTime time.Time
 	var responseTimes []int64
 	var promptLengths []int
 	var responseLengths []int
@@ -1464,9 +1757,10 @@ func buildSessionAnalytics(sessionID string, nodes []*ent.Node) *SessionAnalytic
 		if blocksHaveToolError(blocks) {
 			sa.ToolErrorCount++
 		}
+		latency.add(n)
 
 		if i > 0 {
-			delta := n.CreatedAt.Sub(lastTime).Nanoseconds()
+			delta := turnGap(nodes[i-1], n).Nanoseconds()
 			if delta > sa.LongestPauseNs {
 				sa.LongestPauseNs = delta
 			}
@@ -1474,10 +1768,10 @@ func buildSessionAnalytics(sessionID string, nodes []*ent.Node) *SessionAnalytic
 				responseTimes = append(responseTimes, delta)
 			}
 		}
-		lastTime = n.CreatedAt
 	}
 
 	sa.UniqueTools = len(uniqueTools)
+	sa.Latency = latency.metrics()
 
 	if len(responseTimes) > 0 {
 		var total int64
@@ -1,13 +1,22 @@
 package deck
 
 import (
+	"context"
+	"encoding/json"
+	"path/filepath"
 	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
 	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
 )
 
 var _ = Describe("Session labels", func() {
@@ -126,6 +135,48 @@ var _ = Describe("Analytics helper functions", func() {
 		})
 	})
 
+	Describe("buildSessionMessages", func() {
+		It("rehydrates content offloaded to a blob store", func() {
+			store, err := blob.NewLocalStore(GinkgoT().TempDir())
+			Expect(err).NotTo(HaveOccurred())
+			offloaded, err := blob.Offload(GinkgoT().Context(), store, []llm.ContentBlock{
+				{Type: "text", Text: "a long offloaded message"},
+			}, 4)
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err := json.Marshal(offloaded)
+			Expect(err).NotTo(HaveOccurred())
+			var content []map[string]any
+			Expect(json.Unmarshal(data, &content)).To(Succeed())
+
+			q := &Query{}
+			messages, _ := q.buildSessionMessages(GinkgoT().Context(), []*ent.Node{
+				{ID: "node-1", Role: "user", Content: content},
+			})
+			Expect(messages).To(HaveLen(1))
+			Expect(messages[0].Text).To(Equal("a long offloaded message"))
+		})
+	})
+
+	Describe("countRedactions", func() {
+		It("tallies markers in text, thinking, tool input, and tool output", func() {
+			counts := map[string]int{}
+			countRedactions([]llm.ContentBlock{
+				{Type: "text", Text: "key [REDACTED:openai_api_key] for [REDACTED:email]"},
+				{Type: "tool_use", ToolInput: map[string]any{"to": "[REDACTED:email]"}},
+				{Type: "tool_result", ToolOutput: "[REDACTED:aws_access_key_id]"},
+				{Type: "thinking", Thinking: "mail [REDACTED:email] later"},
+				{Type: "text", Text: "clean"},
+			}, counts)
+
+			Expect(counts).To(Equal(map[string]int{
+				"openai_api_key":    1,
+				"email":             3,
+				"aws_access_key_id": 1,
+			}))
+		})
+	})
+
 	Describe("AnalyticsOverview types", func() {
 		It("constructs a valid AnalyticsOverview", func() {
 			overview := &AnalyticsOverview{
@@ -204,3 +255,239 @@ var _ = Describe("Analytics helper functions", func() {
 		})
 	})
 })
+
+var _ = Describe("Session cache", func() {
+	var (
+		ctx    context.Context
+		q      *Query
+		writer *sqlite.Driver
+		dbPath string
+	)
+
+	put := func(text string, parent *merkle.Node) *merkle.Node {
+		n := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: text}},
+		}, parent)
+		_, err := writer.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+		return n
+	}
+	putRoot := func(text string) *merkle.Node {
+		return put(text, nil)
+	}
+	byRoot := func(candidates []sessionCandidate) map[string]sessionCandidate {
+		roots := map[string]sessionCandidate{}
+		for _, candidate := range candidates {
+			roots[candidate.nodes[0].ID] = candidate
+		}
+		return roots
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		var err error
+		writer, err = sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(writer.Close)
+
+		var closeFn func() error
+		q, closeFn, err = NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	It("reuses candidates until the database changes", func() {
+		putRoot("first session")
+
+		first, err := q.loadSessionCandidates(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).To(HaveLen(1))
+
+		again, err := q.loadSessionCandidates(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again[0].nodes[0]).To(BeIdenticalTo(first[0].nodes[0]))
+
+		putRoot("second session")
+		reloaded, err := q.loadSessionCandidates(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reloaded).To(HaveLen(2))
+	})
+
+	It("shows turns written by another process in the next overview", func() {
+		putRoot("first session")
+		overview, err := q.Overview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overview.Sessions).To(HaveLen(1))
+
+		putRoot("second session")
+		overview, err = q.Overview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overview.Sessions).To(HaveLen(2))
+	})
+
+	It("rebuilds only the sessions whose summary changed", func() {
+		first := putRoot("first session")
+		second := putRoot("second session")
+
+		loaded, err := q.loadSessionCandidates(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		before := byRoot(loaded)
+
+		put("first session, continued", first)
+		third := putRoot("third session")
+		refreshed, err := q.loadSessionCandidates(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refreshed).To(HaveLen(3))
+
+		after := byRoot(refreshed)
+		Expect(after[second.Hash].nodes[0]).To(BeIdenticalTo(before[second.Hash].nodes[0]))
+		Expect(after[first.Hash].nodes).To(HaveLen(2))
+		Expect(after[third.Hash].nodes).To(HaveLen(1))
+
+		// Only the turn appended to the first session was loaded.
+		Expect(after[first.Hash].nodes[0]).To(BeIdenticalTo(before[first.Hash].nodes[0]))
+		Expect(after[first.Hash].summary.MessageCount).To(Equal(2))
+		Expect(after[first.Hash].summary.Label).To(HavePrefix("first session / first session"))
+	})
+
+	It("summarizes appended turns like a full load", func() {
+		root := putRoot("first session")
+		_, err := q.loadSessionCandidates(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		leaf := root
+		for _, text := range []string{"second prompt", "third prompt", "fourth prompt"} {
+			leaf = put(text, leaf)
+		}
+		refreshed, err := q.loadSessionCandidates(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refreshed).To(HaveLen(1))
+
+		full, closeFn, err := NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		defer closeFn()
+		loaded, err := full.loadSessionCandidates(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(HaveLen(1))
+		Expect(refreshed[0].summary).To(Equal(loaded[0].summary))
+		Expect(refreshed[0].summary.ID).To(Equal(leaf.Hash))
+	})
+
+	It("reloads a session whose earlier turns changed along with new ones", func() {
+		root := putRoot("first session")
+		loaded, err := q.loadSessionCandidates(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(q.Annotate(ctx, root.Hash, &SessionAnnotation{Title: "Fix the build"})).To(Succeed())
+		put("continued", root)
+		refreshed, err := q.loadSessionCandidates(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refreshed).To(HaveLen(1))
+		Expect(refreshed[0].nodes[0]).NotTo(BeIdenticalTo(loaded[0].nodes[0]))
+		Expect(refreshed[0].summary.Title).To(Equal("Fix the build"))
+	})
+
+	It("shows annotations made after the sessions were loaded", func() {
+		root := putRoot("first session")
+		_, err := q.Overview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(q.Annotate(ctx, root.Hash, &SessionAnnotation{Title: "Fix the build", Summary: "Fixed it.", Outcome: "success"})).To(Succeed())
+		overview, err := q.Overview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overview.Sessions).To(HaveLen(1))
+		Expect(overview.Sessions[0].Title).To(Equal("Fix the build"))
+	})
+
+	It("loads every session when the summaries do not match the stored turns", func() {
+		first := putRoot("first session")
+		loaded, err := q.loadSessionCandidates(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		// A writer that does not maintain the table, such as an older
+		// version of tapes, leaves the latest turn without a row.
+		second := putRoot("second session")
+		_, err = writer.Client.Session.Delete().Where(session.ID(second.Hash)).Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		reloaded, err := q.loadSessionCandidates(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reloaded).To(HaveLen(2))
+		Expect(byRoot(reloaded)[first.Hash].nodes[0]).NotTo(BeIdenticalTo(loaded[0].nodes[0]))
+	})
+})
+
+var _ = Describe("Superseded turns", func() {
+	It("lists the reprocessed turn instead of the one it superseded", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		bucket := func(role, text string) merkle.Bucket {
+			return merkle.Bucket{
+				Type:     "message",
+				Role:     role,
+				Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:    "llama3",
+				Provider: "ollama",
+			}
+		}
+		prompt := merkle.NewNode(bucket("user", "hello"), nil)
+		fixed := merkle.NewNode(bucket("assistant", "hi there"), prompt)
+		stale := merkle.NewNode(bucket("assistant", "hi"), prompt)
+		stale.SupersededBy = fixed.Hash
+		for _, n := range []*merkle.Node{prompt, fixed, stale} {
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(driver.Close()).To(Succeed())
+
+		q, closeFn, err := NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		defer closeFn()
+
+		overview, err := q.Overview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overview.Sessions).To(HaveLen(1))
+		Expect(overview.Sessions[0].SessionCount).To(Equal(1))
+	})
+})
+
+var _ = Describe("Session ends", func() {
+	It("extends the session to its recorded end and reports why it ended", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		prompt := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: "hello"}},
+		}, nil)
+		_, err = driver.Put(ctx, prompt)
+		Expect(err).NotTo(HaveOccurred())
+
+		endedAt := time.Now().Add(time.Hour)
+		Expect(driver.EndSession(ctx, prompt.Hash, endedAt, storage.SessionEndExit)).To(Succeed())
+		Expect(driver.Close()).To(Succeed())
+
+		q, closeFn, err := NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		defer closeFn()
+
+		overview, err := q.Overview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overview.Sessions).To(HaveLen(1))
+		Expect(overview.Sessions[0].EndReason).To(Equal(storage.SessionEndExit))
+		Expect(overview.Sessions[0].EndTime).To(BeTemporally("~", endedAt, time.Millisecond))
+		Expect(overview.Sessions[0].Duration).To(BeNumerically("~", time.Hour, time.Second))
+	})
+})
@@ -0,0 +1,199 @@
+package deck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/embeddings"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+	"github.com/papercomputeco/tapes/pkg/vector"
+)
+
+const (
+	defaultSemanticLimit = 10
+	semanticOverfetch    = 5
+	semanticSnippetChars = 160
+)
+
+// Search runs a full-text search over message text and tool inputs and
+// outputs, returning hits ranked by relevance with the session and position
+// of each matching turn.
+func (q *Query) Search(ctx context.Context, opts SearchOptions) ([]SearchHit, error) {
+	if q.driver == nil {
+		return nil, errors.New("search requires a SQLite-backed query")
+	}
+
+	searchOpts := sqlite.SearchOptions{
+		Query:    opts.Query,
+		Provider: opts.Provider,
+		Limit:    opts.Limit,
+	}
+	if opts.Since > 0 {
+		searchOpts.Since = time.Now().Add(-opts.Since)
+	}
+
+	matches, err := q.driver.Search(ctx, searchOpts)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return []SearchHit{}, nil
+	}
+
+	hits := make([]SearchHit, 0, len(matches))
+	for _, m := range matches {
+		hits = append(hits, SearchHit{
+			Hash:      m.Hash,
+			Role:      m.Role,
+			Provider:  m.Provider,
+			Model:     m.Model,
+			Timestamp: m.CreatedAt,
+			Snippet:   m.Snippet,
+			Score:     m.Score,
+		})
+	}
+	if err := q.locateHits(ctx, hits); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+// SemanticSearch embeds the query text and returns the turns whose stored
+// embeddings are most similar, ranked by similarity with the session and
+// position of each turn. Turns only have embeddings once they have been
+// indexed, either by the proxy at capture time or with tapes db embed.
+func (q *Query) SemanticSearch(ctx context.Context, embedder embeddings.Embedder, vectors vector.Driver, opts SearchOptions) ([]SearchHit, error) {
+	if strings.TrimSpace(opts.Query) == "" {
+		return nil, errors.New("search query is empty")
+	}
+
+	embedding, err := embedder.Embed(ctx, opts.Query)
+	if err != nil {
+		return nil, fmt.Errorf("embedding query: %w", err)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultSemanticLimit
+	}
+	// Filters are applied after the nearest-neighbour lookup, so ask for
+	// more neighbours than needed to leave enough after filtering.
+	topK := limit
+	if opts.Provider != "" || opts.Since > 0 {
+		topK *= semanticOverfetch
+	}
+
+	results, err := vectors.Query(ctx, embedding, topK)
+	if err != nil {
+		return nil, fmt.Errorf("querying vector store: %w", err)
+	}
+	if len(results) == 0 {
+		return []SearchHit{}, nil
+	}
+
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.Hash)
+	}
+	nodes, err := q.client.Node.Query().Where(node.IDIn(ids...)).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading matched turns: %w", err)
+	}
+	byHash := make(map[string]*ent.Node, len(nodes))
+	for _, n := range nodes {
+		byHash[n.ID] = n
+	}
+
+	var cutoff time.Time
+	if opts.Since > 0 {
+		cutoff = time.Now().Add(-opts.Since)
+	}
+
+	hits := make([]SearchHit, 0, limit)
+	for _, r := range results {
+		n, ok := byHash[r.Hash]
+		if !ok {
+			// Embeddings can outlive their turns after a prune.
+			continue
+		}
+		if opts.Provider != "" && n.Provider != opts.Provider {
+			continue
+		}
+		if !cutoff.IsZero() && n.CreatedAt.Before(cutoff) {
+			continue
+		}
+
+		blocks, _ := parseContentBlocks(n.Content)
+		hits = append(hits, SearchHit{
+			Hash:      n.ID,
+			Role:      n.Role,
+			Provider:  n.Provider,
+			Model:     n.Model,
+			Timestamp: n.CreatedAt,
+			Snippet:   truncate(strings.Join(strings.Fields(extractText(blocks)), " "), semanticSnippetChars),
+			Score:     float64(r.Score),
+		})
+		if len(hits) == limit {
+			break
+		}
+	}
+
+	if err := q.locateHits(ctx, hits); err != nil {
+		return nil, err
+	}
+	return hits, nil
+}
+
+// locateHits fills in the session and turn position of each hit. A turn
+// shared by several branches is attributed to the session that was active
+// most recently.
+func (q *Query) locateHits(ctx context.Context, hits []SearchHit) error {
+	if len(hits) == 0 {
+		return nil
+	}
+
+	candidates, err := q.loadSessionCandidates(ctx)
+	if err != nil {
+		return err
+	}
+
+	type position struct {
+		session *SessionSummary
+		turn    int
+		turns   int
+	}
+	wanted := make(map[string]bool, len(hits))
+	for _, h := range hits {
+		wanted[h.Hash] = true
+	}
+	positions := make(map[string]position, len(hits))
+	for i := range candidates {
+		c := &candidates[i]
+		for idx, n := range c.nodes {
+			if !wanted[n.ID] {
+				continue
+			}
+			p, ok := positions[n.ID]
+			if ok && !c.summary.EndTime.After(p.session.EndTime) {
+				continue
+			}
+			positions[n.ID] = position{session: &c.summary, turn: idx + 1, turns: len(c.nodes)}
+		}
+	}
+
+	for i := range hits {
+		if p, ok := positions[hits[i].Hash]; ok {
+			hits[i].SessionID = p.session.ID
+			hits[i].SessionLabel = p.session.Label
+			hits[i].SessionTitle = p.session.Title
+			hits[i].Turn = p.turn
+			hits[i].Turns = p.turns
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package deck
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/embeddings"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+	"github.com/papercomputeco/tapes/pkg/vector"
+)
+
+var _ = Describe("Search", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+	})
+
+	It("returns ranked hits with their session and turn", func() {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		turn := func(role, provider, text string, parent *merkle.Node) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:     "message",
+				Role:     role,
+				Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:    "gpt-4o",
+				Provider: provider,
+			}, parent)
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+
+		prompt := turn("user", "openai", "run the build", nil)
+		failure := turn("assistant", "openai", "the build broke: connection refused", prompt)
+		leaf := turn("user", "openai", "try again", failure)
+		turn("user", "anthropic", "connection refused by the proxy", nil)
+		Expect(driver.Close()).To(Succeed())
+
+		q, closeFn, err := NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		defer closeFn()
+
+		hits, err := q.Search(ctx, SearchOptions{Query: `"connection refused"`, Provider: "openai", Since: 24 * time.Hour})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hits).To(HaveLen(1))
+
+		hit := hits[0]
+		Expect(hit.Hash).To(Equal(failure.Hash))
+		Expect(hit.SessionID).To(Equal(leaf.Hash))
+		Expect(hit.Turn).To(Equal(2))
+		Expect(hit.Turns).To(Equal(3))
+		Expect(hit.Role).To(Equal("assistant"))
+		Expect(hit.Snippet).To(ContainSubstring("[connection] [refused]"))
+	})
+
+	It("returns no hits for unmatched queries", func() {
+		q, closeFn, err := NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		defer closeFn()
+
+		hits, err := q.Search(ctx, SearchOptions{Query: "nothing"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hits).To(BeEmpty())
+	})
+})
+
+// fakeEmbedder embeds text as a fixed-size bag of the query vocabulary so
+// that similarity is deterministic in tests.
+type fakeEmbedder struct{}
+
+func (fakeEmbedder) Embed(_ context.Context, text string) ([]float32, error) {
+	vocab := []string{"auth", "middleware", "build", "deploy"}
+	v := make([]float32, len(vocab))
+	for i, word := range vocab {
+		if strings.Contains(strings.ToLower(text), word) {
+			v[i] = 1
+		}
+	}
+	return v, nil
+}
+
+func (fakeEmbedder) Close() error { return nil }
+
+// fakeVectors is an in-memory vector.Driver ranking by dot product.
+type fakeVectors struct {
+	docs []vector.Document
+}
+
+func (f *fakeVectors) Add(_ context.Context, docs []vector.Document) error {
+	f.docs = append(f.docs, docs...)
+	return nil
+}
+
+func (f *fakeVectors) Query(_ context.Context, embedding []float32, topK int) ([]vector.QueryResult, error) {
+	results := make([]vector.QueryResult, 0, len(f.docs))
+	for _, doc := range f.docs {
+		var score float32
+		for i := range embedding {
+			score += embedding[i] * doc.Embedding[i]
+		}
+		results = append(results, vector.QueryResult{Document: doc, Score: score})
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	return results[:min(topK, len(results))], nil
+}
+
+func (f *fakeVectors) Get(_ context.Context, _ []string) ([]vector.Document, error) {
+	return nil, nil
+}
+
+func (f *fakeVectors) Delete(_ context.Context, _ []string) error { return nil }
+
+func (f *fakeVectors) Close() error { return nil }
+
+var _ = Describe("SemanticSearch", func() {
+	It("returns the most similar turns with their session and turn", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		turn := func(role, provider, text string, parent *merkle.Node) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:     "message",
+				Role:     role,
+				Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:    "claude-sonnet-4",
+				Provider: provider,
+			}, parent)
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+
+		prompt := turn("user", "anthropic", "fix the login flow", nil)
+		struggle := turn("assistant", "anthropic", "the auth middleware keeps rejecting the token", prompt)
+		turn("user", "openai", "auth middleware order is wrong", nil)
+		turn("user", "anthropic", "deploy the build", nil)
+
+		nodes, err := driver.List(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Close()).To(Succeed())
+
+		vectors := &fakeVectors{}
+		result, err := embeddings.Index(ctx, fakeEmbedder{}, vectors, nodes)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Embedded).To(Equal(4))
+
+		q, closeFn, err := NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		defer closeFn()
+
+		hits, err := q.SemanticSearch(ctx, fakeEmbedder{}, vectors, SearchOptions{
+			Query:    "sessions where the agent struggled with auth middleware",
+			Provider: "anthropic",
+			Limit:    2,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hits).To(HaveLen(2))
+
+		Expect(hits[0].Hash).To(Equal(struggle.Hash))
+		Expect(hits[0].SessionID).To(Equal(struggle.Hash))
+		Expect(hits[0].Turn).To(Equal(2))
+		Expect(hits[0].Turns).To(Equal(2))
+		Expect(hits[0].Snippet).To(Equal("the auth middleware keeps rejecting the token"))
+		Expect(hits[0].Score).To(BeNumerically("==", 2))
+		// The openai turn is filtered out, leaving the weaker anthropic matches.
+		Expect(hits[1].Provider).To(Equal("anthropic"))
+		Expect(hits[1].Score).To(BeNumerically("<", hits[0].Score))
+	})
+
+	It("rejects empty queries", func() {
+		q := &Query{}
+		_, err := q.SemanticSearch(context.Background(), fakeEmbedder{}, &fakeVectors{}, SearchOptions{Query: "  "})
+		Expect(err).To(MatchError(ContainSubstring("empty")))
+	})
+})
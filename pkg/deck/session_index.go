@@ -0,0 +1,268 @@
+package deck
+
+import (
+	"context"
+	"slices"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+)
+
+// sessionEntry is what a leaf contributed to the cached session candidates:
+// a candidate of its own, an error retried by another session, or nothing.
+type sessionEntry struct {
+	// revision is the leaf's revision in the sessions table when the entry
+	// was built, or -1 when it had no row.
+	revision int64
+
+	// tally is the tally of the entry's session, kept so that turns
+	// appended to it can be added without reloading the rest.
+	tally *sessionTally
+
+	candidate *sessionCandidate
+	retried   *ent.Node
+}
+
+// revisionOf returns leaf's revision in revisions, or -1 when it has none.
+func revisionOf(revisions map[string]int64, leaf string) int64 {
+	if revision, ok := revisions[leaf]; ok {
+		return revision
+	}
+	return -1
+}
+
+// sessionRevisions returns the revision of every row in the sessions table,
+// keyed by leaf hash.
+func (q *Query) sessionRevisions(ctx context.Context) (map[string]int64, error) {
+	rows, err := q.client.Session.Query().
+		Select(session.FieldRevision).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		revisions[row.ID] = row.Revision
+	}
+	return revisions, nil
+}
+
+// maxAppendedTurns bounds how far a refresh walks back from a leaf that is
+// not cached, looking for the cached session it appended turns to, before
+// it loads the leaf's whole conversation instead.
+const maxAppendedTurns = 32
+
+// refreshSessionCandidates updates the cached candidates to version from
+// the sessions table, so that the cost of a refresh follows the number of
+// sessions and the turns written since, rather than every stored node.
+// Sessions whose row is unchanged are kept, sessions that only gained turns
+// add just those turns to their cached tally, and only the rest are loaded
+// whole. It reports false when the cache cannot be refreshed, such as before
+// the first full load or when the table is not current; see sessionsCurrent.
+func (q *Query) refreshSessionCandidates(ctx context.Context, version int64) ([]sessionCandidate, bool) {
+	q.cache.mu.RLock()
+	loaded, cached := q.cache.loaded, q.cache.entries
+	q.cache.mu.RUnlock()
+	if !loaded || cached == nil || q.driver == nil {
+		return nil, false
+	}
+
+	rows, err := q.client.Session.Query().
+		Select(session.FieldRevision, session.FieldTurnCount).
+		Order(ent.Asc(session.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, false
+	}
+	if current, err := q.sessionsCurrent(ctx, rows); err != nil || !current {
+		return nil, false
+	}
+
+	entries := make(map[string]sessionEntry, len(rows))
+	revisions := make(map[string]int64, len(rows))
+	var reload []string
+	for _, row := range rows {
+		revisions[row.ID] = row.Revision
+		if entry, ok := cached[row.ID]; ok && entry.revision >= 0 && entry.revision == row.Revision {
+			entries[row.ID] = entry
+			continue
+		}
+		entry, ok, err := q.appendedSessionEntry(ctx, row, cached)
+		if err != nil {
+			return nil, false
+		}
+		if ok {
+			entries[row.ID] = entry
+			continue
+		}
+		reload = append(reload, row.ID)
+	}
+
+	paths, err := q.driver.AncestryNodesOf(ctx, reload)
+	if err != nil {
+		return nil, false
+	}
+	for _, leaf := range reload {
+		path, ok := paths[leaf]
+		if !ok {
+			continue
+		}
+
+		chain := make([]*ent.Node, len(path))
+		for i, n := range path {
+			chain[len(path)-1-i] = n
+		}
+		n := path[0]
+
+		retriedOK := false
+		if n.Type == nodeTypeError && n.ParentHash != nil {
+			retriedOK, err = q.client.Node.Query().
+				Where(
+					node.ParentHash(*n.ParentHash),
+					conversationNode(),
+					node.Or(node.TypeIsNil(), node.TypeNEQ(nodeTypeError)),
+				).
+				Exist(ctx)
+			if err != nil {
+				return nil, false
+			}
+		}
+
+		entry, _ := q.buildSessionEntry(n, chain, retriedOK)
+		entry.revision = revisions[leaf]
+		entries[leaf] = entry
+	}
+
+	candidates := make([]sessionCandidate, 0, len(entries))
+	var retried []*ent.Node
+	for _, row := range rows {
+		entry := entries[row.ID]
+		switch {
+		case entry.retried != nil:
+			retried = append(retried, entry.retried)
+		case entry.candidate != nil:
+			candidate := *entry.candidate
+			candidate.retried = nil
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	attachRetriedErrors(candidates, retried)
+
+	q.storeSessionCandidates(candidates, version, entries)
+	return candidates, true
+}
+
+// appendedSessionEntry builds the entry of the session ending at row's leaf
+// from the cached session it appended turns to, loading only those turns.
+// It reports false when there is no such session: the leaf continues a
+// conversation that is not cached as a session, starts a new one or a new
+// branch, or the session's earlier turns changed since it was cached.
+func (q *Query) appendedSessionEntry(ctx context.Context, row *ent.Session, cached map[string]sessionEntry) (sessionEntry, bool, error) {
+	if _, ok := cached[row.ID]; ok {
+		// The leaf itself changed in place.
+		return sessionEntry{}, false, nil
+	}
+
+	var appended []*ent.Node
+	id := row.ID
+	for range maxAppendedTurns {
+		n, err := q.client.Node.Get(ctx, id)
+		if ent.IsNotFound(err) {
+			return sessionEntry{}, false, nil
+		}
+		if err != nil {
+			return sessionEntry{}, false, err
+		}
+		// Superseded and failed leaves are classified with their whole
+		// conversation, by buildSessionEntry.
+		if len(appended) == 0 && (n.SupersededBy != nil || n.Type == nodeTypeError) {
+			return sessionEntry{}, false, nil
+		}
+		appended = append(appended, n)
+		if n.ParentHash == nil {
+			return sessionEntry{}, false, nil
+		}
+
+		id = *n.ParentHash
+		prior, ok := cached[id]
+		if !ok {
+			continue
+		}
+		// A row replaced by each appended turn gains one revision per
+		// turn, so any other change to the session shows as more.
+		if prior.tally == nil || prior.revision < 0 ||
+			row.Revision != prior.revision+int64(len(appended)) ||
+			row.TurnCount != len(prior.tally.nodes)+len(appended) {
+			return sessionEntry{}, false, nil
+		}
+		slices.Reverse(appended)
+		entry := tallyEntry(q.extendSessionTally(prior.tally, appended))
+		entry.revision = row.Revision
+		return entry, true, nil
+	}
+	return sessionEntry{}, false, nil
+}
+
+// sessionsCurrent reports whether rows, the sessions table, has a row for
+// every current leaf and no other, as far as can be told without reading
+// every node: the conversation node stored last has a row, and no row's
+// leaf has been continued. A writer that does not maintain the table, such
+// as an older version of tapes, fails the first check until a later turn is
+// stored; 'tapes db migrate' rebuilds the table from every node.
+func (q *Query) sessionsCurrent(ctx context.Context, rows []*ent.Session) (bool, error) {
+	latest, err := q.client.Node.Query().
+		Where(conversationNode()).
+		Order(func(s *sql.Selector) { s.OrderBy(sql.Desc(s.C("rowid"))) }).
+		Limit(1).
+		IDs(ctx)
+	if err != nil {
+		return false, err
+	}
+	if len(latest) == 0 {
+		return len(rows) == 0, nil
+	}
+
+	leaves := make([]string, 0, len(rows))
+	for _, row := range rows {
+		leaves = append(leaves, row.ID)
+	}
+	if _, ok := slices.BinarySearch(leaves, latest[0]); !ok {
+		return false, nil
+	}
+
+	// Leaves are checked in batches, within SQLite's limit on variables.
+	for batch := range slices.Chunk(leaves, 500) {
+		continued, err := q.client.Node.Query().
+			Where(node.ParentHashIn(batch...), conversationNode()).
+			Exist(ctx)
+		if err != nil {
+			return false, err
+		}
+		if continued {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// conversationNode matches nodes that are part of conversations, leaving out
+// usage records such as embeddings.
+func conversationNode() predicate.Node {
+	return node.Or(node.TypeIsNil(), node.TypeNotIn(llm.RecordEmbedding, llm.RecordTranscription))
+}
+
+// touchSessions marks the sessions rooted at roots as changed after their
+// roots were annotated, so that cached candidates pick up the annotation.
+func (q *Query) touchSessions(ctx context.Context, roots ...string) error {
+	if q.driver == nil {
+		return nil
+	}
+	return q.driver.TouchSessions(ctx, roots...)
+}
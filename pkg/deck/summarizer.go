@@ -0,0 +1,330 @@
+package deck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+)
+
+const (
+	// DefaultSummarizeIdle is how long a session must be inactive before it
+	// is summarized.
+	DefaultSummarizeIdle = 10 * time.Minute
+
+	// DefaultSummarizeInterval is how often the daemon looks for idle
+	// sessions.
+	DefaultSummarizeInterval = time.Minute
+
+	// summarizeBatch caps the sessions summarized per pass, so enabling the
+	// summarizer on a large history spreads the model calls out.
+	summarizeBatch = 10
+
+	// summarizeMaxAge skips sessions that went idle before this, so enabling
+	// the summarizer does not send the whole history to the model.
+	summarizeMaxAge = 7 * 24 * time.Hour
+
+	// summarizeRetryAfter is how long a session that failed to summarize is
+	// skipped before it is tried again.
+	summarizeRetryAfter = time.Hour
+
+	// summaryTranscriptHead and summaryTranscriptTail bound the transcript
+	// sent to the model: the opening states the task and the end shows how
+	// it went.
+	summaryTranscriptHead = 10000
+	summaryTranscriptTail = 20000
+
+	maxTitleLength = 80
+)
+
+// Summary outcomes, matching the outcomes used by facet extraction.
+const (
+	OutcomeFullyAchieved     = "fully_achieved"
+	OutcomeMostlyAchieved    = "mostly_achieved"
+	OutcomePartiallyAchieved = "partially_achieved"
+	OutcomeNotAchieved       = "not_achieved"
+	OutcomeUnclear           = "unclear"
+)
+
+var summaryOutcomes = []string{
+	OutcomeFullyAchieved,
+	OutcomeMostlyAchieved,
+	OutcomePartiallyAchieved,
+	OutcomeNotAchieved,
+	OutcomeUnclear,
+}
+
+// SessionAnnotation is the title, summary, and outcome the summarizer stores
+// on a session's root node.
+type SessionAnnotation struct {
+	Title   string `json:"title"`
+	Summary string `json:"summary"`
+	Outcome string `json:"outcome"`
+}
+
+// Summarizer writes a title, summary, and outcome onto sessions once they
+// have been idle for a while, so long session lists stay readable.
+type Summarizer struct {
+	query   *Query
+	llmCall LLMCallFunc
+	idle    time.Duration
+
+	// failed holds sessions whose last attempt failed, with the time of
+	// the failure. Only Run's goroutine touches it.
+	failed map[string]time.Time
+
+	// now is overridable for tests.
+	now func() time.Time
+}
+
+// NewSummarizer creates a Summarizer that summarizes sessions inactive for
+// at least idle (DefaultSummarizeIdle when zero).
+func NewSummarizer(query *Query, llmCall LLMCallFunc, idle time.Duration) *Summarizer {
+	if idle <= 0 {
+		idle = DefaultSummarizeIdle
+	}
+	return &Summarizer{
+		query:   query,
+		llmCall: llmCall,
+		idle:    idle,
+		failed:  map[string]time.Time{},
+		now:     time.Now,
+	}
+}
+
+// Run summarizes idle sessions immediately and then every interval until
+// ctx is cancelled. Failures are logged and retried later.
+func (s *Summarizer) Run(ctx context.Context, interval time.Duration, logger *zap.Logger) {
+	if interval <= 0 {
+		interval = DefaultSummarizeInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, logger)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Summarizer) runOnce(ctx context.Context, logger *zap.Logger) {
+	pending, err := s.Pending(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Error("summarizer: listing sessions failed", zap.Error(err))
+		}
+		return
+	}
+
+	for _, sessionID := range pending {
+		if ctx.Err() != nil {
+			return
+		}
+		annotation, err := s.Summarize(ctx, sessionID)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.failed[sessionID] = s.now()
+				logger.Warn("summarizer: summarizing session failed",
+					zap.String("session", sessionID), zap.Error(err))
+			}
+			continue
+		}
+		delete(s.failed, sessionID)
+		logger.Info("summarized session",
+			zap.String("session", sessionID),
+			zap.String("title", annotation.Title),
+			zap.String("outcome", annotation.Outcome),
+		)
+	}
+}
+
+// Pending returns the sessions, most recent first, that have been idle for
+// the configured time and have not been summarized since their last
+// activity. At most one batch is returned per call.
+func (s *Summarizer) Pending(ctx context.Context) ([]string, error) {
+	overview, err := s.query.Overview(ctx, Filters{Sort: "date", SortDir: "desc"})
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	var pending []string
+	for _, session := range overview.Sessions {
+		idleFor := now.Sub(session.EndTime)
+		switch {
+		case idleFor < s.idle, idleFor > summarizeMaxAge:
+			continue
+		case session.MessageCount < 2:
+			continue
+		case session.SummarizedAt != nil && !session.EndTime.After(*session.SummarizedAt):
+			continue
+		}
+		if failedAt, ok := s.failed[session.ID]; ok && now.Sub(failedAt) < summarizeRetryAfter {
+			continue
+		}
+
+		pending = append(pending, session.ID)
+		if len(pending) == summarizeBatch {
+			break
+		}
+	}
+	return pending, nil
+}
+
+// Summarize sends the session's transcript to the model and stores the
+// resulting title, summary, and outcome on the session's root node.
+func (s *Summarizer) Summarize(ctx context.Context, sessionID string) (*SessionAnnotation, error) {
+	detail, err := s.query.SessionDetail(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("load session: %w", err)
+	}
+
+	prompt := buildSummaryPrompt(clipTranscript(buildTranscript(detail)))
+
+	var lastErr error
+	for attempt := range maxExtractRetries {
+		attemptPrompt := prompt
+		if attempt > 0 {
+			attemptPrompt += "\n\nReturn ONLY valid JSON, no markdown."
+		}
+
+		response, err := s.llmCall(ctx, attemptPrompt)
+		if err != nil {
+			return nil, fmt.Errorf("llm call: %w", err)
+		}
+
+		annotation, err := parseSummaryResponse(response)
+		if err != nil {
+			lastErr = fmt.Errorf("parse response (attempt %d): %w", attempt+1, err)
+			continue
+		}
+
+		if err := s.query.Annotate(ctx, sessionID, annotation); err != nil {
+			return nil, err
+		}
+		return annotation, nil
+	}
+
+	return nil, lastErr
+}
+
+// Annotate stores a title, summary, and outcome on the root node of a
+// session, marking it summarized now.
+func (q *Query) Annotate(ctx context.Context, sessionID string, annotation *SessionAnnotation) error {
+	root, err := q.sessionRoot(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	err = q.client.Node.UpdateOneID(root.ID).
+		SetTitle(annotation.Title).
+		SetSummary(annotation.Summary).
+		SetOutcome(annotation.Outcome).
+		SetSummarizedAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("annotate session: %w", err)
+	}
+	return q.touchSessions(ctx, root.ID)
+}
+
+// sessionRoot returns the root node of a session. A grouped session is
+// annotated on the root of its first branch.
+func (q *Query) sessionRoot(ctx context.Context, sessionID string) (*ent.Node, error) {
+	if isGroupID(sessionID) {
+		candidates, err := q.loadSessionCandidates(ctx)
+		if err != nil {
+			return nil, err
+		}
+		target := findGroupByID(groupSessionCandidates(candidates), sessionID)
+		if target == nil || len(target.members) == 0 || len(target.members[0].nodes) == 0 {
+			return nil, fmt.Errorf("get session group: %s", sessionID)
+		}
+		return target.members[0].nodes[0], nil
+	}
+
+	leaf, err := q.client.Node.Get(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("get session: %w", err)
+	}
+	nodes, err := q.loadAncestry(ctx, leaf)
+	if err != nil {
+		return nil, err
+	}
+	return nodes[0], nil
+}
+
+// applyAnnotation copies the summarizer's annotation from a root node onto a
+// session summary.
+func applyAnnotation(summary *SessionSummary, root *ent.Node) {
+	if root.Title != nil {
+		summary.Title = *root.Title
+	}
+	if root.Summary != nil {
+		summary.Summary = *root.Summary
+	}
+	if root.Outcome != nil {
+		summary.Outcome = *root.Outcome
+	}
+	summary.SummarizedAt = root.SummarizedAt
+}
+
+// clipTranscript keeps the start and end of a long transcript.
+func clipTranscript(transcript string) string {
+	if len(transcript) <= summaryTranscriptHead+summaryTranscriptTail {
+		return transcript
+	}
+	return transcript[:summaryTranscriptHead] + "\n[...]\n" + transcript[len(transcript)-summaryTranscriptTail:]
+}
+
+func buildSummaryPrompt(transcript string) string {
+	return "Summarize this LLM coding session transcript for a list of past sessions.\n" +
+		"Return ONLY valid JSON with these fields:\n\n" +
+		"{\n" +
+		"  \"title\": \"a short title of at most 8 words naming the task, e.g. Fix flaky login test\",\n" +
+		"  \"summary\": \"1-2 sentences on what was attempted and what happened\",\n" +
+		"  \"outcome\": \"one of: " + strings.Join(summaryOutcomes, ", ") + "\"\n" +
+		"}\n\nTranscript:\n" + transcript
+}
+
+func parseSummaryResponse(response string) (*SessionAnnotation, error) {
+	// Extract JSON from the response (may be wrapped in markdown code blocks)
+	jsonStr := response
+	if idx := strings.Index(response, "{"); idx >= 0 {
+		endIdx := strings.LastIndex(response, "}")
+		if endIdx > idx {
+			jsonStr = response[idx : endIdx+1]
+		}
+	}
+
+	var annotation SessionAnnotation
+	if err := json.Unmarshal([]byte(jsonStr), &annotation); err != nil {
+		return nil, fmt.Errorf("unmarshal summary JSON: %w", err)
+	}
+
+	annotation.Title = truncate(strings.TrimSpace(annotation.Title), maxTitleLength)
+	annotation.Summary = strings.TrimSpace(annotation.Summary)
+	if annotation.Title == "" {
+		return nil, errors.New("summary has no title")
+	}
+
+	annotation.Outcome = strings.ToLower(strings.TrimSpace(annotation.Outcome))
+	if !slices.Contains(summaryOutcomes, annotation.Outcome) {
+		annotation.Outcome = OutcomeUnclear
+	}
+
+	return &annotation, nil
+}
@@ -0,0 +1,138 @@
+package deck
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("Summarizer", func() {
+	var (
+		ctx     context.Context
+		q       *Query
+		leaf    *merkle.Node
+		prompts []string
+		reply   string
+	)
+
+	fakeLLM := func(_ context.Context, prompt string) (string, error) {
+		prompts = append(prompts, prompt)
+		return reply, nil
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		prompts = nil
+		reply = "```json\n{\"title\": \"Fix the flaky build\", \"summary\": \"Retried the build after a refused connection.\", \"outcome\": \"Mostly_Achieved\"}\n```"
+
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		turn := func(role, text string, parent *merkle.Node) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:     "message",
+				Role:     role,
+				Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:    "gpt-4o",
+				Provider: "openai",
+			}, parent)
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+
+		prompt := turn("user", "run the build", nil)
+		leaf = turn("assistant", "the build broke: connection refused", prompt)
+		Expect(driver.Close()).To(Succeed())
+
+		var closeFn func() error
+		q, closeFn, err = NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	idleSummarizer := func() *Summarizer {
+		s := NewSummarizer(q, fakeLLM, time.Minute)
+		s.now = func() time.Time { return time.Now().Add(time.Hour) }
+		return s
+	}
+
+	It("skips sessions that are still active", func() {
+		s := NewSummarizer(q, fakeLLM, time.Minute)
+		Expect(s.Pending(ctx)).To(BeEmpty())
+	})
+
+	It("stores the title, summary, and outcome on idle sessions", func() {
+		s := idleSummarizer()
+		pending, err := s.Pending(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pending).To(HaveLen(1))
+
+		annotation, err := s.Summarize(ctx, pending[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotation.Outcome).To(Equal(OutcomeMostlyAchieved))
+		Expect(prompts).To(HaveLen(1))
+		Expect(prompts[0]).To(ContainSubstring("connection refused"))
+
+		overview, err := q.Overview(ctx, Filters{Label: "flaky"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overview.Sessions).To(HaveLen(1))
+		session := overview.Sessions[0]
+		Expect(session.ID).To(Equal(pending[0]))
+		Expect(session.Label).To(Equal("run the build"))
+		Expect(session.Title).To(Equal("Fix the flaky build"))
+		Expect(session.Summary).To(Equal("Retried the build after a refused connection."))
+		Expect(session.Outcome).To(Equal(OutcomeMostlyAchieved))
+		Expect(session.SummarizedAt).NotTo(BeNil())
+
+		hits, err := q.Search(ctx, SearchOptions{Query: "refused"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hits).To(HaveLen(1))
+		Expect(hits[0].SessionTitle).To(Equal("Fix the flaky build"))
+
+		Expect(s.Pending(ctx)).To(BeEmpty())
+	})
+
+	It("retries responses that are not JSON", func() {
+		replies := []string{"Sure! Here is a summary.", `{"title": "Fix the build", "outcome": "done"}`}
+		s := NewSummarizer(q, func(_ context.Context, prompt string) (string, error) {
+			prompts = append(prompts, prompt)
+			r := replies[0]
+			replies = replies[1:]
+			return r, nil
+		}, time.Minute)
+
+		annotation, err := s.Summarize(ctx, leaf.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prompts).To(HaveLen(2))
+		Expect(prompts[1]).To(HaveSuffix("Return ONLY valid JSON, no markdown."))
+		Expect(annotation.Title).To(Equal("Fix the build"))
+		Expect(annotation.Outcome).To(Equal(OutcomeUnclear))
+	})
+
+	It("does not store responses without a title", func() {
+		reply = `{"summary": "did things"}`
+		s := idleSummarizer()
+
+		_, err := s.Summarize(ctx, leaf.Hash)
+		Expect(err).To(MatchError(ContainSubstring("no title")))
+		Expect(s.Pending(ctx)).To(HaveLen(1))
+	})
+
+	It("keeps the start and end of long transcripts", func() {
+		transcript := "START" + strings.Repeat("x", summaryTranscriptHead+summaryTranscriptTail) + "END"
+		clipped := clipTranscript(transcript)
+		Expect(clipped).To(HavePrefix("START"))
+		Expect(clipped).To(HaveSuffix("END"))
+		Expect(clipped).To(ContainSubstring("[...]"))
+	})
+})
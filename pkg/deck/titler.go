@@ -0,0 +1,192 @@
+package deck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+)
+
+const (
+	// maxHeuristicTitle bounds titles cut from the first prompt.
+	maxHeuristicTitle = 60
+
+	// maxTitlePrompt bounds the first prompt sent to the model for a title.
+	maxTitlePrompt = 4000
+
+	// maxTitledRoots bounds the set of sessions a Titler remembers having
+	// handled; past it the set is reset and the database check takes over.
+	maxTitledRoots = 10000
+)
+
+// Titler gives each new session a title as soon as its first prompt is
+// stored, so session lists show what a session is about rather than a hash.
+// Titles are cut from the first prompt, or written by a model when one is
+// configured. The summarizer later replaces them once the session is idle.
+type Titler struct {
+	query *Query
+
+	// llmCall is optional; without it titles are cut from the prompt.
+	llmCall LLMCallFunc
+
+	// titled holds the root hashes already handled. Only Run's goroutine
+	// touches it.
+	titled map[string]struct{}
+}
+
+// NewTitler creates a Titler. llmCall may be nil to title sessions from the
+// first prompt alone.
+func NewTitler(query *Query, llmCall LLMCallFunc) *Titler {
+	return &Titler{
+		query:   query,
+		llmCall: llmCall,
+		titled:  map[string]struct{}{},
+	}
+}
+
+// Run titles sessions as their first user message arrives on stream, until
+// ctx is cancelled or stream is closed. Failures are logged.
+func (t *Titler) Run(ctx context.Context, stream <-chan events.Event, logger *zap.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-stream:
+			if !ok {
+				return
+			}
+			if event.Role != roleUser || event.RootHash == "" {
+				continue
+			}
+			if _, ok := t.titled[event.RootHash]; ok {
+				continue
+			}
+
+			title, err := t.Title(ctx, event.Hash)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Warn("titler: titling session failed",
+						zap.String("session", event.RootHash), zap.Error(err))
+				}
+				continue
+			}
+			if title == "" {
+				// No prompt text yet, e.g. only a system reminder; try again
+				// on the next user message.
+				continue
+			}
+
+			if len(t.titled) >= maxTitledRoots {
+				clear(t.titled)
+			}
+			t.titled[event.RootHash] = struct{}{}
+		}
+	}
+}
+
+// Title titles the session containing the node with the given hash from its
+// first user prompt, unless the session already has a title. It returns the
+// session's title, or "" when the session has no prompt text yet.
+func (t *Titler) Title(ctx context.Context, hash string) (string, error) {
+	leaf, err := t.query.client.Node.Get(ctx, hash)
+	if err != nil {
+		return "", fmt.Errorf("get node: %w", err)
+	}
+	nodes, err := t.query.loadAncestry(ctx, leaf)
+	if err != nil {
+		return "", err
+	}
+
+	root := nodes[0]
+	if root.Title != nil {
+		return *root.Title, nil
+	}
+
+	prompt := firstPrompt(nodes)
+	title := heuristicTitle(prompt)
+	if title == "" {
+		return "", nil
+	}
+
+	if t.llmCall != nil {
+		if generated, err := t.generateTitle(ctx, prompt); err == nil && generated != "" {
+			title = generated
+		}
+	}
+
+	// Only set the title when it is still unset, so a concurrent summary is
+	// not overwritten.
+	updated, err := t.query.client.Node.Update().
+		Where(node.ID(root.ID), node.TitleIsNil()).
+		SetTitle(title).
+		Save(ctx)
+	if err != nil {
+		return "", fmt.Errorf("set title: %w", err)
+	}
+	if updated > 0 {
+		if err := t.query.touchSessions(ctx, root.ID); err != nil {
+			return "", err
+		}
+	}
+	return title, nil
+}
+
+func (t *Titler) generateTitle(ctx context.Context, prompt string) (string, error) {
+	if len(prompt) > maxTitlePrompt {
+		prompt = prompt[:maxTitlePrompt]
+	}
+
+	response, err := t.llmCall(ctx, "Write a title of at most 8 words for an LLM coding session that starts with the request below, e.g. Fix flaky login test.\n"+
+		"Reply with ONLY the title, no quotes or punctuation around it.\n\nRequest:\n"+prompt)
+	if err != nil {
+		return "", err
+	}
+
+	title := strings.TrimSpace(firstLabelLine(response))
+	title = strings.Trim(title, "\"'`*#")
+	return truncate(strings.TrimSpace(title), maxTitleLength), nil
+}
+
+// firstPrompt returns the text of the first user message with prompt text,
+// skipping injected reminders and command output.
+func firstPrompt(nodes []*ent.Node) string {
+	for _, n := range nodes {
+		if n.Role != roleUser {
+			continue
+		}
+		blocks, _ := parseContentBlocks(n.Content)
+		text := extractLabelText(blocks)
+		if firstLabelLine(text) != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// heuristicTitle cuts a title from a prompt: its first line, up to the end of
+// the first sentence, shortened at a word boundary.
+func heuristicTitle(prompt string) string {
+	title := strings.Join(strings.Fields(firstLabelLine(prompt)), " ")
+
+	if i := strings.IndexAny(title, ".?!"); i > 0 && i+1 < len(title) && title[i+1] == ' ' {
+		title = title[:i+1]
+	}
+	title = strings.TrimSuffix(title, ".")
+
+	if utf8.RuneCountInString(title) <= maxHeuristicTitle {
+		return title
+	}
+
+	runes := []rune(title)
+	cut := string(runes[:maxHeuristicTitle])
+	if i := strings.LastIndex(cut, " "); i > maxHeuristicTitle/2 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:-") + "..."
+}
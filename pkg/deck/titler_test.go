@@ -0,0 +1,139 @@
+package deck
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("heuristicTitle", func() {
+	DescribeTable("cuts a title from the first prompt",
+		func(prompt, expected string) {
+			Expect(heuristicTitle(prompt)).To(Equal(expected))
+		},
+		Entry("short prompt", "fix the login test", "fix the login test"),
+		Entry("first line only", "<system-reminder>\n\n  fix the   login test\nit fails on CI", "fix the login test"),
+		Entry("first sentence only", "The login test is flaky. It fails on CI about half the time.", "The login test is flaky"),
+		Entry("keeps questions", "Why does the login test fail? It passed yesterday.", "Why does the login test fail?"),
+		Entry("keeps dotted names", "bump go.mod to 1.25", "bump go.mod to 1.25"),
+		Entry("long prompt at a word boundary",
+			"refactor the session grouping code so that branches from the same agent are merged together",
+			"refactor the session grouping code so that branches from..."),
+		Entry("empty prompt", "", ""),
+	)
+})
+
+var _ = Describe("Titler", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+		driver *sqlite.Driver
+		q      *Query
+	)
+
+	turn := func(role, text string, parent *merkle.Node) *merkle.Node {
+		n := merkle.NewNode(merkle.Bucket{
+			Type:     "message",
+			Role:     role,
+			Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+			Model:    "gpt-4o",
+			Provider: "openai",
+		}, parent)
+		_, err := driver.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+		return n
+	}
+
+	sessionTitle := func() string {
+		overview, err := q.Overview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overview.Sessions).To(HaveLen(1))
+		return overview.Sessions[0].Title
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		var err error
+		driver, err = sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(driver.Close)
+
+		var closeFn func() error
+		q, closeFn, err = NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	It("titles a session from its first prompt", func() {
+		prompt := turn("user", "Fix the flaky login test. It fails on CI.", nil)
+		reply := turn("assistant", "Looking at it now.", prompt)
+
+		title, err := NewTitler(q, nil).Title(ctx, reply.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(title).To(Equal("Fix the flaky login test"))
+		Expect(sessionTitle()).To(Equal("Fix the flaky login test"))
+	})
+
+	It("does not replace an existing title", func() {
+		prompt := turn("user", "fix the login test", nil)
+		Expect(q.Annotate(ctx, prompt.Hash, &SessionAnnotation{Title: "Summarized title"})).To(Succeed())
+
+		title, err := NewTitler(q, nil).Title(ctx, prompt.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(title).To(Equal("Summarized title"))
+		Expect(sessionTitle()).To(Equal("Summarized title"))
+	})
+
+	It("uses the model's title when one is configured", func() {
+		prompt := turn("user", "the login test keeps failing on CI, can you look?", nil)
+
+		titler := NewTitler(q, func(_ context.Context, p string) (string, error) {
+			Expect(p).To(ContainSubstring("the login test keeps failing"))
+			return "\"Fix flaky login test\"\n", nil
+		})
+		title, err := titler.Title(ctx, prompt.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(title).To(Equal("Fix flaky login test"))
+	})
+
+	It("falls back to the prompt when the model fails", func() {
+		prompt := turn("user", "fix the login test", nil)
+
+		titler := NewTitler(q, func(context.Context, string) (string, error) {
+			return "", errors.New("model unavailable")
+		})
+		title, err := titler.Title(ctx, prompt.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(title).To(Equal("fix the login test"))
+	})
+
+	It("titles sessions as their first prompt is published", func() {
+		broker := events.NewBroker()
+		stream, unsubscribe := broker.Subscribe(events.Filter{})
+		defer unsubscribe()
+
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go NewTitler(q, nil).Run(runCtx, stream, zap.NewNop())
+
+		reminder := turn("user", "<system-reminder>be brief</system-reminder>", nil)
+		broker.Publish(events.NodeCreated(reminder, reminder.Hash))
+		reply := turn("assistant", "ok", reminder)
+		broker.Publish(events.NodeCreated(reply, reminder.Hash))
+		prompt := turn("user", "add a --json flag to tapes tail", reply)
+		broker.Publish(events.NodeCreated(prompt, reminder.Hash))
+
+		Eventually(sessionTitle).Should(Equal("add a --json flag to tapes tail"))
+	})
+})
@@ -0,0 +1,123 @@
+package deck
+
+import (
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+)
+
+type pendingToolCall struct {
+	name  string
+	start time.Time
+}
+
+type toolCallSamples struct {
+	durations []time.Duration
+	results   int
+	errors    int
+}
+
+// toolCallAccumulator pairs tool_use blocks with the tool_result blocks that
+// answer them in later turns. Calls are counted once even when several
+// branches of a session share them.
+type toolCallAccumulator struct {
+	pending  map[string]pendingToolCall
+	resolved map[string]bool
+	samples  map[string]*toolCallSamples
+}
+
+func newToolCallAccumulator() *toolCallAccumulator {
+	return &toolCallAccumulator{
+		pending:  map[string]pendingToolCall{},
+		resolved: map[string]bool{},
+		samples:  map[string]*toolCallSamples{},
+	}
+}
+
+// addChain walks a root-first chain of nodes, recording how long each tool
+// call took and whether its result was an error.
+func (a *toolCallAccumulator) addChain(chain []*ent.Node) {
+	for i, n := range chain {
+		blocks, _ := parseContentBlocks(n.Content)
+		for _, block := range blocks {
+			switch {
+			case block.Type == blockTypeToolUse && block.ToolUseID != "" && block.ToolName != "":
+				if _, ok := a.pending[block.ToolUseID]; !ok {
+					a.pending[block.ToolUseID] = pendingToolCall{name: block.ToolName, start: toolCallStart(n)}
+				}
+			case block.Type == "tool_result" && block.ToolResultID != "":
+				call, ok := a.pending[block.ToolResultID]
+				if !ok || a.resolved[block.ToolResultID] {
+					continue
+				}
+				a.resolved[block.ToolResultID] = true
+
+				s := a.samplesFor(call.name)
+				s.results++
+				if block.IsError {
+					s.errors++
+				}
+				if end := toolCallEnd(chain, i); end.After(call.start) {
+					s.durations = append(s.durations, end.Sub(call.start))
+				}
+			}
+		}
+	}
+}
+
+func (a *toolCallAccumulator) samplesFor(name string) *toolCallSamples {
+	s, ok := a.samples[name]
+	if !ok {
+		s = &toolCallSamples{}
+		a.samples[name] = s
+	}
+	return s
+}
+
+// apply fills in the duration and error fields of metric from the calls
+// recorded for its tool.
+func (a *toolCallAccumulator) apply(metric *ToolMetric) {
+	s, ok := a.samples[metric.Name]
+	if !ok {
+		return
+	}
+
+	metric.ErrorCount = s.errors
+	if s.results > 0 {
+		metric.ErrorRate = float64(s.errors) / float64(s.results)
+	}
+	if len(s.durations) > 0 {
+		var total time.Duration
+		for _, d := range s.durations {
+			total += d
+		}
+		metric.AvgDurationMs = (total / time.Duration(len(s.durations))).Milliseconds()
+		_, p95, _ := percentiles(s.durations)
+		metric.P95DurationMs = time.Duration(p95).Milliseconds()
+	}
+}
+
+// toolCallStart is when the assistant handed a tool call to the agent: the
+// end of the proxied response when it was timed, its creation time otherwise.
+func toolCallStart(n *ent.Node) time.Time {
+	if n.ResponseCompletedAt != nil {
+		return *n.ResponseCompletedAt
+	}
+	return n.CreatedAt
+}
+
+// toolCallEnd is when the agent sent the tool result at chain[i] back to the
+// model. Request nodes are stored once the following response completes, so
+// the start of that next proxied request is preferred when it was timed.
+func toolCallEnd(chain []*ent.Node, i int) time.Time {
+	for _, n := range chain[i+1:] {
+		if n.Role != roleAssistant {
+			continue
+		}
+		if n.RequestStartedAt != nil {
+			return *n.RequestStartedAt
+		}
+		break
+	}
+	return chain[i].CreatedAt
+}
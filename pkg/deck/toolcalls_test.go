@@ -0,0 +1,86 @@
+package deck
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("Tool call analytics", func() {
+	var (
+		ctx context.Context
+		q   *Query
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		started := time.Now().Add(-time.Hour)
+		at := func(d time.Duration) time.Time { return started.Add(d) }
+
+		var parent *merkle.Node
+		put := func(role string, blocks []llm.ContentBlock, timing *llm.Timing) {
+			parent = merkle.NewNode(merkle.Bucket{
+				Type:     "message",
+				Role:     role,
+				Content:  blocks,
+				Model:    "claude-sonnet-4",
+				Provider: "anthropic",
+			}, parent, merkle.NodeMeta{Timing: timing})
+			_, err := driver.Put(ctx, parent)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		toolUse := func(id, name string) []llm.ContentBlock {
+			return []llm.ContentBlock{{Type: "tool_use", ToolUseID: id, ToolName: name}}
+		}
+		toolResult := func(id string, isError bool) []llm.ContentBlock {
+			return []llm.ContentBlock{{Type: "tool_result", ToolResultID: id, IsError: isError}}
+		}
+
+		put("user", []llm.ContentBlock{{Type: "text", Text: "run the tests"}}, nil)
+		put("assistant", toolUse("toolu_1", "Bash"), &llm.Timing{RequestStartedAt: at(0), ResponseCompletedAt: at(time.Second)})
+		put("user", toolResult("toolu_1", true), nil)
+		put("assistant", toolUse("toolu_2", "Bash"), &llm.Timing{RequestStartedAt: at(5 * time.Second), ResponseCompletedAt: at(6 * time.Second)})
+		put("user", toolResult("toolu_2", false), nil)
+		put("assistant", toolUse("toolu_3", "Read"), &llm.Timing{RequestStartedAt: at(8 * time.Second), ResponseCompletedAt: at(9 * time.Second)})
+		put("user", toolResult("toolu_3", false), nil)
+		put("assistant", []llm.ContentBlock{{Type: "text", Text: "all green"}}, &llm.Timing{RequestStartedAt: at(9500 * time.Millisecond), ResponseCompletedAt: at(10 * time.Second)})
+		Expect(driver.Close()).To(Succeed())
+
+		var closeFn func() error
+		q, closeFn, err = NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	It("reports durations and error rates per tool", func() {
+		overview, err := q.AnalyticsOverview(ctx, Filters{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(overview.TopTools).To(HaveLen(2))
+
+		bash := overview.TopTools[0]
+		Expect(bash.Name).To(Equal("Bash"))
+		Expect(bash.Count).To(Equal(2))
+		Expect(bash.ErrorCount).To(Equal(1))
+		Expect(bash.ErrorRate).To(BeNumerically("~", 0.5))
+		Expect(bash.AvgDurationMs).To(Equal(int64(3000)))
+		Expect(bash.P95DurationMs).To(Equal(int64(4000)))
+
+		read := overview.TopTools[1]
+		Expect(read.Name).To(Equal("Read"))
+		Expect(read.ErrorCount).To(Equal(0))
+		Expect(read.AvgDurationMs).To(Equal(int64(500)))
+		Expect(read.P95DurationMs).To(Equal(int64(500)))
+	})
+})
@@ -0,0 +1,203 @@
+package deck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+)
+
+// Fork kinds describe why a conversation diverged at a node.
+const (
+	// ForkKindRetry marks a prompt that received several different responses.
+	ForkKindRetry = "retry"
+
+	// ForkKindFork marks a response that was followed by different prompts,
+	// e.g. an edited message or a conversation replayed from a checkout.
+	ForkKindFork = "fork"
+)
+
+const treePreviewLimit = 80
+
+// SessionTree returns the full branch structure of the conversation that
+// contains hash. The hash may be any node in the conversation, a unique
+// prefix of one, or a session group ID; the tree always starts at the root.
+func (q *Query) SessionTree(ctx context.Context, hash string) (*SessionTree, error) {
+	candidates, err := q.loadSessionCandidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rootHash, err := resolveTreeRoot(candidates, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var branches []sessionCandidate
+	for _, candidate := range candidates {
+		if len(candidate.nodes) > 0 && candidate.nodes[0].ID == rootHash {
+			branches = append(branches, candidate)
+		}
+	}
+
+	return buildSessionTree(rootHash, branches), nil
+}
+
+// resolveTreeRoot finds the root hash of the conversation containing hash.
+func resolveTreeRoot(candidates []sessionCandidate, hash string) (string, error) {
+	if isGroupID(hash) {
+		group := findGroupByID(groupSessionCandidates(candidates), hash)
+		if group == nil {
+			return "", fmt.Errorf("get session group: %s", hash)
+		}
+		earliest := group.members[0]
+		for _, member := range group.members[1:] {
+			if member.summary.StartTime.Before(earliest.summary.StartTime) {
+				earliest = member
+			}
+		}
+		return earliest.nodes[0].ID, nil
+	}
+
+	roots := map[string]string{}
+	for _, candidate := range candidates {
+		for _, n := range candidate.nodes {
+			if n.ID == hash {
+				return candidate.nodes[0].ID, nil
+			}
+			if strings.HasPrefix(n.ID, hash) {
+				roots[n.ID] = candidate.nodes[0].ID
+			}
+		}
+	}
+
+	switch len(roots) {
+	case 0:
+		return "", fmt.Errorf("get session tree: no node matches %q", hash)
+	case 1:
+		for _, root := range roots {
+			return root, nil
+		}
+	}
+	return "", fmt.Errorf("get session tree: %q matches %d nodes", hash, len(roots))
+}
+
+func buildSessionTree(rootHash string, branches []sessionCandidate) *SessionTree {
+	tree := &SessionTree{RootHash: rootHash}
+	if len(branches) == 0 {
+		return tree
+	}
+
+	treeNodes := map[string]*TreeNode{}
+	children := map[string]map[string]bool{}
+	for _, branch := range branches {
+		var parent *ent.Node
+		for _, n := range branch.nodes {
+			if _, ok := treeNodes[n.ID]; !ok {
+				treeNodes[n.ID] = newTreeNode(n)
+			}
+			if parent != nil {
+				if children[parent.ID] == nil {
+					children[parent.ID] = map[string]bool{}
+				}
+				if !children[parent.ID][n.ID] {
+					children[parent.ID][n.ID] = true
+					treeNodes[parent.ID].Children = append(treeNodes[parent.ID].Children, treeNodes[n.ID])
+				}
+			}
+			parent = n
+		}
+	}
+
+	tree.Root = treeNodes[rootHash]
+	tree.NodeCount = len(treeNodes)
+	for _, tn := range treeNodes {
+		if len(tn.Children) < 2 {
+			continue
+		}
+		sort.SliceStable(tn.Children, func(i, j int) bool {
+			return tn.Children[i].Timestamp.Before(tn.Children[j].Timestamp)
+		})
+		tn.ForkKind = forkKind(tn.Children)
+		tree.ForkCount++
+	}
+
+	// Number branches in depth-first order so the original conversation is
+	// branch 1 and later divergences follow it.
+	leafOrder := map[string]int{}
+	var walk func(tn *TreeNode)
+	walk = func(tn *TreeNode) {
+		if len(tn.Children) == 0 {
+			leafOrder[tn.Hash] = len(leafOrder)
+			return
+		}
+		for _, child := range tn.Children {
+			walk(child)
+		}
+	}
+	walk(tree.Root)
+
+	sort.SliceStable(branches, func(i, j int) bool {
+		return leafOrder[branchLeaf(branches[i])] < leafOrder[branchLeaf(branches[j])]
+	})
+
+	visited := map[string]bool{}
+	for i, branch := range branches {
+		tb := TreeBranch{
+			Number:   i + 1,
+			LeafHash: branchLeaf(branch),
+			Depth:    len(branch.nodes),
+			Summary:  branch.summary,
+		}
+		for j, n := range branch.nodes {
+			if !visited[n.ID] {
+				if j > 0 && i > 0 {
+					fork := treeNodes[branch.nodes[j-1].ID]
+					tb.ForkHash = fork.Hash
+					tb.ForkKind = fork.ForkKind
+				}
+				break
+			}
+		}
+		for _, n := range branch.nodes {
+			visited[n.ID] = true
+		}
+		treeNodes[tb.LeafHash].Branch = tb.Number
+		tree.Branches = append(tree.Branches, tb)
+	}
+
+	return tree
+}
+
+func newTreeNode(n *ent.Node) *TreeNode {
+	blocks, _ := parseContentBlocks(n.Content)
+	preview := strings.Join(strings.Fields(extractText(blocks)), " ")
+	if preview == "" {
+		if tools := extractToolCalls(blocks); len(tools) > 0 {
+			preview = "[tools: " + strings.Join(uniqueToolCalls(tools), ", ") + "]"
+		}
+	}
+
+	return &TreeNode{
+		Hash:      n.ID,
+		Role:      n.Role,
+		Model:     n.Model,
+		Preview:   truncate(preview, treePreviewLimit),
+		Timestamp: n.CreatedAt,
+	}
+}
+
+func forkKind(children []*TreeNode) string {
+	for _, child := range children {
+		if child.Role != roleAssistant {
+			return ForkKindFork
+		}
+	}
+	return ForkKindRetry
+}
+
+func branchLeaf(branch sessionCandidate) string {
+	return branch.nodes[len(branch.nodes)-1].ID
+}
@@ -0,0 +1,104 @@
+package deck
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("SessionTree", func() {
+	var (
+		ctx                          context.Context
+		q                            *Query
+		root, answer, followUp, edit *merkle.Node
+		original, retry, edited      *merkle.Node
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(role, text string, parent *merkle.Node) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:     "message",
+				Role:     role,
+				Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:    "claude-sonnet-4",
+				Provider: "anthropic",
+			}, parent)
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+
+		root = put("user", "write a haiku", nil)
+		answer = put("assistant", "autumn moonlight", root)
+		followUp = put("user", "make it rhyme", answer)
+		original = put("assistant", "the moon is a spoon", followUp)
+		retry = put("assistant", "a rhyme in the night", followUp)
+		edit = put("user", "make it longer", answer)
+		edited = put("assistant", "autumn moonlight, a worm digs silently", edit)
+		Expect(driver.Close()).To(Succeed())
+
+		var closeFn func() error
+		q, closeFn, err = NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	It("builds the branch structure from any node in the conversation", func() {
+		tree, err := q.SessionTree(ctx, retry.Hash)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(tree.RootHash).To(Equal(root.Hash))
+		Expect(tree.NodeCount).To(Equal(7))
+		Expect(tree.ForkCount).To(Equal(2))
+
+		Expect(tree.Root.Preview).To(Equal("write a haiku"))
+		Expect(tree.Root.Children).To(HaveLen(1))
+		fork := tree.Root.Children[0]
+		Expect(fork.Hash).To(Equal(answer.Hash))
+		Expect(fork.ForkKind).To(Equal(ForkKindFork))
+		Expect(fork.Children).To(HaveLen(2))
+		Expect(fork.Children[0].ForkKind).To(Equal(ForkKindRetry))
+	})
+
+	It("numbers branches in order with their fork points", func() {
+		tree, err := q.SessionTree(ctx, root.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tree.Branches).To(HaveLen(3))
+
+		Expect(tree.Branches[0].LeafHash).To(Equal(original.Hash))
+		Expect(tree.Branches[0].ForkHash).To(BeEmpty())
+		Expect(tree.Branches[0].Depth).To(Equal(4))
+		Expect(tree.Branches[0].Summary.MessageCount).To(Equal(4))
+
+		Expect(tree.Branches[1].LeafHash).To(Equal(retry.Hash))
+		Expect(tree.Branches[1].ForkHash).To(Equal(followUp.Hash))
+		Expect(tree.Branches[1].ForkKind).To(Equal(ForkKindRetry))
+
+		Expect(tree.Branches[2].LeafHash).To(Equal(edited.Hash))
+		Expect(tree.Branches[2].ForkHash).To(Equal(answer.Hash))
+		Expect(tree.Branches[2].ForkKind).To(Equal(ForkKindFork))
+	})
+
+	It("resolves unique hash prefixes", func() {
+		tree, err := q.SessionTree(ctx, edit.Hash[:12])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tree.RootHash).To(Equal(root.Hash))
+	})
+
+	It("returns an error for unknown hashes", func() {
+		_, err := q.SessionTree(ctx, "does-not-exist")
+		Expect(err).To(MatchError(ContainSubstring("no node matches")))
+	})
+})
@@ -1,12 +1,16 @@
 package deck
 
-import "time"
+import (
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
 
 type Pricing struct {
-	Input      float64 `json:"input"`
-	Output     float64 `json:"output"`
-	CacheRead  float64 `json:"cache_read"`
-	CacheWrite float64 `json:"cache_write"`
+	Input      float64 `json:"input" toml:"input"`
+	Output     float64 `json:"output" toml:"output"`
+	CacheRead  float64 `json:"cache_read" toml:"cache_read"`
+	CacheWrite float64 `json:"cache_write" toml:"cache_write"`
 }
 
 type SessionSummary struct {
@@ -14,7 +18,9 @@ type SessionSummary struct {
 	Label        string        `json:"label"`
 	Model        string        `json:"model"`
 	Project      string        `json:"project"`
+	User         string        `json:"user,omitempty"`
 	AgentName    string        `json:"agent_name,omitempty"`
+	AgentVersion string        `json:"agent_version,omitempty"`
 	Status       string        `json:"status"`
 	StartTime    time.Time     `json:"start_time"`
 	EndTime      time.Time     `json:"end_time"`
@@ -27,6 +33,32 @@ type SessionSummary struct {
 	ToolCalls    int           `json:"tool_calls"`
 	MessageCount int           `json:"message_count"`
 	SessionCount int           `json:"session_count,omitempty"`
+
+	// EndReason is set when the session was marked ended, because it went
+	// "idle" or because its agent exited ("exit"). EndTime is then when it
+	// ended rather than its last turn.
+	EndReason string `json:"end_reason,omitempty"`
+
+	// SessionID is the agent session the turns were captured in, when the
+	// agent or tapes start identified it.
+	SessionID string `json:"session_id,omitempty"`
+
+	// Title is set from the first prompt when the session starts and
+	// replaced by the summarizer, which also writes Summary and Outcome once
+	// the session has gone idle.
+	Title        string     `json:"title,omitempty"`
+	Summary      string     `json:"summary,omitempty"`
+	Outcome      string     `json:"outcome,omitempty"`
+	SummarizedAt *time.Time `json:"summarized_at,omitempty"`
+}
+
+// DisplayLabel returns the session's title, falling back to its label for
+// sessions that have not been titled.
+func (s SessionSummary) DisplayLabel() string {
+	if s.Title != "" {
+		return s.Title
+	}
+	return s.Label
 }
 
 type SessionMessage struct {
@@ -43,6 +75,7 @@ type SessionMessage struct {
 	TotalCost    float64       `json:"total_cost"`
 	ToolCalls    []string      `json:"tool_calls"`
 	Text         string        `json:"text"`
+	TraceID      string        `json:"trace_id,omitempty"`
 }
 
 type SessionMessageGroup struct {
@@ -69,6 +102,9 @@ type SessionDetail struct {
 	GroupedMessages []SessionMessageGroup `json:"grouped_messages,omitempty"`
 	ToolFrequency   map[string]int        `json:"tool_frequency"`
 	SubSessions     []SessionSummary      `json:"sub_sessions,omitempty"`
+
+	// NextCursor fetches the next page of messages; empty on the last page.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type ModelCost struct {
@@ -81,8 +117,12 @@ type ModelCost struct {
 	SessionCount int     `json:"session_count"`
 }
 
+// Overview summarizes the sessions matching a set of Filters. Totals cover
+// every matching session, while Sessions holds only the requested page.
 type Overview struct {
 	Sessions       []SessionSummary     `json:"sessions"`
+	TotalSessions  int                  `json:"total_sessions"`
+	NextCursor     string               `json:"next_cursor,omitempty"`
 	TotalCost      float64              `json:"total_cost"`
 	TotalTokens    int64                `json:"total_tokens"`
 	InputTokens    int64                `json:"input_tokens"`
@@ -108,14 +148,179 @@ type PeriodComparison struct {
 
 type Filters struct {
 	Since   time.Duration
+	Until   time.Duration
 	From    *time.Time
 	To      *time.Time
 	Model   string
 	Status  string
 	Project string
+	User    string
 	Sort    string
 	SortDir string
 	Session string
+
+	// Label keeps sessions whose label or title contains it, ignoring case.
+	Label string
+
+	// Tool keeps sessions that called a tool whose name contains it,
+	// ignoring case, and StopReason those with a turn that stopped for it.
+	Tool       string
+	StopReason string
+
+	// MinCost and MaxCost bound a session's total cost in USD; zero
+	// leaves that side unbounded.
+	MinCost float64
+	MaxCost float64
+
+	// Limit caps the sessions returned by Overview; zero returns them all.
+	// Cursor resumes after the page that returned it as NextCursor.
+	Limit  int
+	Cursor string
+}
+
+// Page selects a window of paginated results. A zero Limit returns every
+// result after Cursor, which is the NextCursor of the previous page.
+type Page struct {
+	Limit  int
+	Cursor string
+}
+
+// SearchOptions filters a full-text or semantic search across sessions.
+type SearchOptions struct {
+	// Query is a full-text query, e.g. "connection refused" with quotes for
+	// a phrase, or terms combined with OR. Semantic search embeds it as
+	// natural language instead.
+	Query    string
+	Provider string
+	Since    time.Duration
+
+	// Limit caps the number of hits. Zero returns all full-text hits, or
+	// a default number of semantic hits.
+	Limit int
+}
+
+// SearchHit is a turn matching a full-text or semantic search, located
+// within the most recently active session that contains it.
+type SearchHit struct {
+	Hash         string    `json:"hash"`
+	SessionID    string    `json:"session_id"`
+	SessionLabel string    `json:"session_label"`
+	SessionTitle string    `json:"session_title,omitempty"`
+	Turn         int       `json:"turn"`
+	Turns        int       `json:"turns"`
+	Role         string    `json:"role"`
+	Provider     string    `json:"provider"`
+	Model        string    `json:"model"`
+	Timestamp    time.Time `json:"timestamp"`
+	Snippet      string    `json:"snippet"`
+	Score        float64   `json:"score"`
+}
+
+// CostOptions selects how a cost report is grouped and which turns it covers.
+type CostOptions struct {
+	// GroupBy is one of CostGroupings. Defaults to grouping by model.
+	GroupBy string
+
+	// Since limits the report to turns newer than this age. Zero covers
+	// all captured turns.
+	Since time.Duration
+}
+
+// CostRow aggregates token usage and spend for one group of turns.
+// CacheSavings is the discount from cache reads compared to paying the full
+// input rate, and is already reflected in InputCost and TotalCost.
+type CostRow struct {
+	Key              string  `json:"key"`
+	Requests         int     `json:"requests"`
+	UnpricedRequests int     `json:"unpriced_requests"`
+	InputTokens      int64   `json:"input_tokens"`
+	OutputTokens     int64   `json:"output_tokens"`
+	ReasoningTokens  int64   `json:"reasoning_tokens"`
+	CacheWriteTokens int64   `json:"cache_write_tokens"`
+	CacheReadTokens  int64   `json:"cache_read_tokens"`
+	InputCost        float64 `json:"input_cost"`
+	OutputCost       float64 `json:"output_cost"`
+	ReasoningCost    float64 `json:"reasoning_cost"` // part of OutputCost
+	CacheSavings     float64 `json:"cache_savings"`
+	TotalCost        float64 `json:"total_cost"`
+}
+
+// CostReport is the result of Query.Costs.
+type CostReport struct {
+	GroupBy string    `json:"group_by"`
+	Rows    []CostRow `json:"rows"`
+	Total   CostRow   `json:"total"`
+}
+
+// UsageOptions selects the turns Query.Usage covers.
+type UsageOptions struct {
+	// Since limits usage to turns newer than this age. Zero covers all
+	// captured turns.
+	Since time.Duration
+}
+
+// UsagePoint is the usage of one model, provider, and project on one day.
+// UnpricedRequests counts turns with usage for models without pricing, which
+// Cost leaves out.
+type UsagePoint struct {
+	Day              string  `json:"day"`
+	Model            string  `json:"model"`
+	Provider         string  `json:"provider"`
+	Project          string  `json:"project"`
+	Requests         int     `json:"requests"`
+	Errors           int     `json:"errors"`
+	UnpricedRequests int     `json:"unpriced_requests"`
+	InputTokens      int64   `json:"input_tokens"`
+	OutputTokens     int64   `json:"output_tokens"`
+	ReasoningTokens  int64   `json:"reasoning_tokens"`
+	CacheWriteTokens int64   `json:"cache_write_tokens"`
+	CacheReadTokens  int64   `json:"cache_read_tokens"`
+	Cost             float64 `json:"cost"`
+}
+
+// AssistantTurn is a model reply recorded in a session, with the user input
+// that prompted it.
+type AssistantTurn struct {
+	Hash             string             `json:"hash"`
+	Session          string             `json:"session"`
+	Model            string             `json:"model"`
+	SystemPromptHash string             `json:"system_prompt_hash,omitempty"`
+	Prompt           string             `json:"prompt,omitempty"`
+	Content          []llm.ContentBlock `json:"content"`
+}
+
+// SystemPromptOptions selects the turns a system prompt listing covers.
+type SystemPromptOptions struct {
+	// Since limits the listing to turns newer than this age. Zero covers
+	// all captured turns.
+	Since time.Duration
+
+	// Session limits the listing to the turns of one agent session.
+	Session string
+}
+
+// SystemPromptVersion describes a distinct system prompt and the turns that
+// were sent with it. Tokens and costs are those of the turns' responses.
+type SystemPromptVersion struct {
+	Hash      string    `json:"hash"`
+	Preview   string    `json:"preview"`
+	Size      int       `json:"size"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+
+	Sessions         int     `json:"sessions"`
+	Requests         int     `json:"requests"`
+	UnpricedRequests int     `json:"unpriced_requests"`
+	InputTokens      int64   `json:"input_tokens"`
+	OutputTokens     int64   `json:"output_tokens"`
+	TotalCost        float64 `json:"total_cost"`
+}
+
+// SystemPromptDiff is a line diff that turns system prompt A into B.
+type SystemPromptDiff struct {
+	A     SystemPromptVersion `json:"a"`
+	B     SystemPromptVersion `json:"b"`
+	Lines []DiffLine          `json:"lines"`
 }
 
 // SessionAnalytics holds per-session computed analytics.
@@ -131,6 +336,8 @@ type SessionAnalytics struct {
 	AvgPromptLength   int     `json:"avg_prompt_length"`
 	AvgResponseLength int     `json:"avg_response_length"`
 	FirstPrompt       string  `json:"first_prompt"`
+
+	Latency []LatencyMetric `json:"latency"`
 }
 
 // AnalyticsOverview holds cross-session analytics.
@@ -144,13 +351,73 @@ type AnalyticsOverview struct {
 	CostBuckets       []Bucket           `json:"cost_buckets"`
 	ModelPerformance  []ModelPerformance `json:"model_performance"`
 	ProviderBreakdown map[string]int     `json:"provider_breakdown"`
+	Redactions        map[string]int     `json:"redactions"`
+	Latency           []LatencyMetric    `json:"latency"`
+
+	// CacheHitRate is the share of input tokens across all models that
+	// were read from the provider's prompt cache.
+	CacheHitRate float64       `json:"cache_hit_rate"`
+	Cache        []CacheMetric `json:"cache"`
+
+	// ErrorRate is the share of requests across all models that the
+	// upstream provider answered with an error.
+	ErrorRate float64       `json:"error_rate"`
+	Errors    []ErrorMetric `json:"errors"`
+}
+
+// ErrorMetric counts upstream error responses for one model and provider.
+// Requests includes the failed ones, so ErrorRate is Errors over Requests.
+// Statuses counts errors by HTTP status code.
+type ErrorMetric struct {
+	Model     string      `json:"model"`
+	Provider  string      `json:"provider"`
+	Requests  int         `json:"requests"`
+	Errors    int         `json:"errors"`
+	ErrorRate float64     `json:"error_rate"`
+	Statuses  map[int]int `json:"statuses"`
 }
 
+// CacheMetric summarizes prompt caching for one model and provider.
+// InputTokens includes cached tokens, so HitRate is CacheReadTokens over
+// InputTokens. Savings is what cache reads saved over the full input rate.
+type CacheMetric struct {
+	Model            string  `json:"model"`
+	Provider         string  `json:"provider"`
+	Requests         int     `json:"requests"`
+	CacheHits        int     `json:"cache_hits"`
+	InputTokens      int64   `json:"input_tokens"`
+	CacheReadTokens  int64   `json:"cache_read_tokens"`
+	CacheWriteTokens int64   `json:"cache_write_tokens"`
+	HitRate          float64 `json:"hit_rate"`
+	Savings          float64 `json:"savings"`
+}
+
+// ToolMetric summarizes one tool's usage. Durations and errors come from
+// tool calls whose tool_result was found later in the session.
 type ToolMetric struct {
-	Name       string `json:"name"`
-	Count      int    `json:"count"`
-	ErrorCount int    `json:"error_count"`
-	Sessions   int    `json:"sessions"`
+	Name          string  `json:"name"`
+	Count         int     `json:"count"`
+	ErrorCount    int     `json:"error_count"`
+	ErrorRate     float64 `json:"error_rate"`
+	Sessions      int     `json:"sessions"`
+	AvgDurationMs int64   `json:"avg_duration_ms"`
+	P95DurationMs int64   `json:"p95_duration_ms"`
+}
+
+// LatencyMetric holds request latency and time-to-first-token percentiles
+// for one model and provider, measured by the proxy. Time-to-first-token
+// only covers streamed requests.
+type LatencyMetric struct {
+	Model            string `json:"model"`
+	Provider         string `json:"provider"`
+	Requests         int    `json:"requests"`
+	P50LatencyNs     int64  `json:"p50_latency_ns"`
+	P95LatencyNs     int64  `json:"p95_latency_ns"`
+	P99LatencyNs     int64  `json:"p99_latency_ns"`
+	StreamedRequests int    `json:"streamed_requests"`
+	P50TTFTNs        int64  `json:"p50_ttft_ns"`
+	P95TTFTNs        int64  `json:"p95_ttft_ns"`
+	P99TTFTNs        int64  `json:"p99_ttft_ns"`
 }
 
 type DayActivity struct {
@@ -177,6 +444,90 @@ type ModelPerformance struct {
 	CompletedCount int     `json:"completed_count"`
 }
 
+// SessionTree is the branch structure of a conversation: every path from
+// its root to a leaf, with the nodes where paths diverge.
+type SessionTree struct {
+	RootHash  string       `json:"root_hash"`
+	Root      *TreeNode    `json:"root"`
+	Branches  []TreeBranch `json:"branches"`
+	NodeCount int          `json:"node_count"`
+	ForkCount int          `json:"fork_count"`
+}
+
+// TreeNode is one turn in a SessionTree. ForkKind is set on nodes with more
+// than one child, and Branch on leaves.
+type TreeNode struct {
+	Hash      string      `json:"hash"`
+	Role      string      `json:"role"`
+	Model     string      `json:"model,omitempty"`
+	Preview   string      `json:"preview"`
+	Timestamp time.Time   `json:"timestamp"`
+	ForkKind  string      `json:"fork_kind,omitempty"`
+	Branch    int         `json:"branch,omitempty"`
+	Children  []*TreeNode `json:"children,omitempty"`
+}
+
+// TreeBranch is one root-to-leaf path in a SessionTree. ForkHash is the last
+// node it shares with an earlier branch, empty for the first branch.
+type TreeBranch struct {
+	Number   int            `json:"number"`
+	LeafHash string         `json:"leaf_hash"`
+	ForkHash string         `json:"fork_hash,omitempty"`
+	ForkKind string         `json:"fork_kind,omitempty"`
+	Depth    int            `json:"depth"`
+	Summary  SessionSummary `json:"summary"`
+}
+
+// SessionDiff compares two conversations. CommonTurns counts the turns they
+// share; Turns pairs the turns after that by position.
+type SessionDiff struct {
+	A                 SessionSummary  `json:"a"`
+	B                 SessionSummary  `json:"b"`
+	CommonHash        string          `json:"common_hash,omitempty"`
+	CommonTurns       int             `json:"common_turns"`
+	Turns             []TurnDiff      `json:"turns"`
+	ToolUsage         []ToolUsageDiff `json:"tool_usage"`
+	CostDelta         float64         `json:"cost_delta"`
+	InputTokensDelta  int64           `json:"input_tokens_delta"`
+	OutputTokensDelta int64           `json:"output_tokens_delta"`
+	ToolCallsDelta    int             `json:"tool_calls_delta"`
+}
+
+// TurnDiff pairs the turns at Index in each conversation. A or B is nil when
+// that conversation has fewer turns.
+type TurnDiff struct {
+	Index    int        `json:"index"`
+	A        *DiffTurn  `json:"a,omitempty"`
+	B        *DiffTurn  `json:"b,omitempty"`
+	Same     bool       `json:"same"`
+	TextDiff []DiffLine `json:"text_diff,omitempty"`
+}
+
+type DiffTurn struct {
+	Hash         string   `json:"hash"`
+	Role         string   `json:"role"`
+	Model        string   `json:"model,omitempty"`
+	Text         string   `json:"text"`
+	Tools        []string `json:"tools,omitempty"`
+	InputTokens  int64    `json:"input_tokens"`
+	OutputTokens int64    `json:"output_tokens"`
+	Cost         float64  `json:"cost"`
+}
+
+// DiffLine is one line of a text diff; Op is DiffEqual, DiffInsert, or
+// DiffDelete.
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// ToolUsageDiff counts calls to one tool in each conversation.
+type ToolUsageDiff struct {
+	Name string `json:"name"`
+	A    int    `json:"a"`
+	B    int    `json:"b"`
+}
+
 const (
 	StatusCompleted = "completed"
 	StatusFailed    = "failed"
@@ -0,0 +1,102 @@
+package deck
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+)
+
+// Usage returns token usage, upstream errors, and spend per day, model,
+// provider, and project, for charting trends. Every assistant turn counts as
+// a request, once even when several session branches share it. Days are
+// UTC dates, so that series from several machines line up. Points are
+// ordered by day, then by model, provider, and project.
+func (q *Query) Usage(ctx context.Context, opts UsageOptions) ([]UsagePoint, error) {
+	predicates := []predicate.Node{node.RoleEQ(roleAssistant)}
+	if opts.Since > 0 {
+		predicates = append(predicates, node.CreatedAtGTE(time.Now().Add(-opts.Since)))
+	}
+
+	nodes, err := q.client.Node.Query().
+		Where(predicates...).
+		Select(
+			node.FieldType,
+			node.FieldModel,
+			node.FieldProvider,
+			node.FieldProject,
+			node.FieldCreatedAt,
+			node.FieldPromptTokens,
+			node.FieldCompletionTokens,
+			node.FieldTotalTokens,
+			node.FieldCacheCreationInputTokens,
+			node.FieldCacheReadInputTokens,
+			node.FieldReasoningTokens,
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query usage: %w", err)
+	}
+
+	type pointKey struct{ day, model, provider, project string }
+	points := map[pointKey]*UsagePoint{}
+	for _, n := range nodes {
+		model := normalizeModel(n.Model)
+		key := pointKey{
+			day:      n.CreatedAt.UTC().Format(time.DateOnly),
+			model:    model,
+			provider: n.Provider,
+			project:  derefString(n.Project),
+		}
+		p, ok := points[key]
+		if !ok {
+			p = &UsagePoint{Day: key.day, Model: key.model, Provider: key.provider, Project: key.project}
+			points[key] = p
+		}
+
+		p.Requests++
+		if n.Type == nodeTypeError {
+			p.Errors++
+		}
+
+		t := tokenCounts(n)
+		p.InputTokens += t.Input
+		p.OutputTokens += t.Output
+		p.ReasoningTokens += t.Reasoning
+		p.CacheWriteTokens += t.CacheCreation
+		p.CacheReadTokens += t.CacheRead
+		if t.Input == 0 && t.Output == 0 {
+			continue
+		}
+
+		pricing, ok := PricingForModel(q.pricing, model)
+		if !ok {
+			p.UnpricedRequests++
+			continue
+		}
+		_, _, cost := CostForTokensWithCache(pricing, t.Input, t.Output, t.CacheCreation, t.CacheRead)
+		p.Cost += cost
+	}
+
+	result := make([]UsagePoint, 0, len(points))
+	for _, p := range points {
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.Model != b.Model {
+			return a.Model < b.Model
+		}
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		return a.Project < b.Project
+	})
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package deck
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("Usage", func() {
+	It("sums requests, errors, tokens, and cost per day, model, provider, and project", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(kind, role, text, model string, parent *merkle.Node, meta merkle.NodeMeta) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:     kind,
+				Role:     role,
+				Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:    model,
+				Provider: "openai",
+			}, parent, meta)
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+
+		prompt := put("message", "user", "hello", "gpt-4o", nil, merkle.NodeMeta{Project: "api"})
+		put("error", "assistant", "429 rate_limit_error", "gpt-4o", prompt, merkle.NodeMeta{
+			StopReason: "error",
+			Project:    "api",
+			Error:      &llm.UpstreamError{Status: 429, Type: "rate_limit_error"},
+		})
+		put("message", "assistant", "hi", "gpt-4o", prompt, merkle.NodeMeta{
+			StopReason: "stop",
+			Project:    "api",
+			Usage:      &llm.Usage{PromptTokens: 1_000_000, CompletionTokens: 1_000, CacheReadInputTokens: 500},
+		})
+		other := put("message", "user", "hey", "local-model", nil, merkle.NodeMeta{})
+		put("message", "assistant", "yo", "local-model", other, merkle.NodeMeta{
+			StopReason: "stop",
+			Usage:      &llm.Usage{PromptTokens: 10, CompletionTokens: 5},
+		})
+		Expect(driver.Close()).To(Succeed())
+
+		q, closeFn, err := NewQuery(ctx, dbPath, DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		defer closeFn()
+
+		points, err := q.Usage(ctx, UsageOptions{Since: time.Hour})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(points).To(HaveLen(2))
+
+		today := time.Now().UTC().Format(time.DateOnly)
+		gpt := points[0]
+		Expect(gpt.Day).To(Equal(today))
+		Expect(gpt.Model).To(Equal("gpt-4o"))
+		Expect(gpt.Project).To(Equal("api"))
+		Expect(gpt.Requests).To(Equal(2))
+		Expect(gpt.Errors).To(Equal(1))
+		Expect(gpt.InputTokens).To(Equal(int64(1_000_000)))
+		Expect(gpt.CacheReadTokens).To(Equal(int64(500)))
+		Expect(gpt.Cost).To(BeNumerically(">", 2.5))
+
+		local := points[1]
+		Expect(local.Model).To(Equal("local-model"))
+		Expect(local.Requests).To(Equal(1))
+		Expect(local.UnpricedRequests).To(Equal(1))
+		Expect(local.Cost).To(BeZero())
+	})
+})
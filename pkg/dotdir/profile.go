@@ -0,0 +1,135 @@
+package dotdir
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// profilesDirName is the directory under ~/.tapes/ holding one .tapes/
+	// directory per named profile.
+	profilesDirName = "profiles"
+
+	// activeProfileFile records the profile selected by "tapes profile use".
+	activeProfileFile = "profile"
+
+	// DefaultProfile names the unscoped ~/.tapes/ (or local .tapes/) setup.
+	DefaultProfile = "default"
+
+	// ProfileEnv selects a profile for a single shell, overriding the one in
+	// use.
+	ProfileEnv = "TAPES_PROFILE"
+)
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidateProfileName rejects profile names that are not safe directory names.
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use lowercase letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// ProfileDir returns the .tapes/ directory of a named profile, which need not
+// exist yet.
+func (m *Manager) ProfileDir(name string) (string, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error getting home directory: %w", err)
+	}
+	return filepath.Join(home, dirName, profilesDirName, name), nil
+}
+
+// Profiles returns the names of the created profiles, sorted.
+func (m *Manager) Profiles() ([]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("error getting home directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(home, dirName, profilesDirName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading profiles: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && ValidateProfileName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ActiveProfile returns the profile in use: TAPES_PROFILE when set, else the
+// one selected by SetActiveProfile. It returns "" for the default profile.
+func (m *Manager) ActiveProfile() (string, error) {
+	name := strings.TrimSpace(os.Getenv(ProfileEnv))
+	if name == "" {
+		path, err := m.activeProfilePath()
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("error reading active profile: %w", err)
+		}
+		name = strings.TrimSpace(string(data))
+	}
+
+	if name == "" || name == DefaultProfile {
+		return "", nil
+	}
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// SetActiveProfile selects the profile used by later commands. Selecting ""
+// or DefaultProfile returns to the default profile.
+func (m *Manager) SetActiveProfile(name string) error {
+	path, err := m.activeProfilePath()
+	if err != nil {
+		return err
+	}
+
+	if name == "" || name == DefaultProfile {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error clearing active profile: %w", err)
+		}
+		return nil
+	}
+
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating tapes directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(name+"\n"), 0o600); err != nil {
+		return fmt.Errorf("error writing active profile: %w", err)
+	}
+	return nil
+}
+
+func (m *Manager) activeProfilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error getting home directory: %w", err)
+	}
+	return filepath.Join(home, dirName, activeProfileFile), nil
+}
@@ -0,0 +1,72 @@
+package dotdir_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/dotdir"
+)
+
+var _ = Describe("Profiles", func() {
+	var (
+		home string
+		m    *dotdir.Manager
+	)
+
+	BeforeEach(func() {
+		home = GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", home)
+		GinkgoT().Setenv(dotdir.ProfileEnv, "")
+		m = dotdir.NewManager()
+	})
+
+	It("keeps each profile in its own directory", func() {
+		dir, err := m.ProfileDir("work")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dir).To(Equal(filepath.Join(home, ".tapes", "profiles", "work")))
+
+		_, err = m.ProfileDir("../work")
+		Expect(err).To(MatchError(ContainSubstring("invalid profile name")))
+	})
+
+	It("lists created profiles", func() {
+		names, err := m.Profiles()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(BeEmpty())
+
+		for _, name := range []string{"work", "client-a"} {
+			dir, err := m.ProfileDir(name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.MkdirAll(dir, 0o755)).To(Succeed())
+		}
+
+		names, err = m.Profiles()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"client-a", "work"}))
+	})
+
+	It("remembers the active profile and lets TAPES_PROFILE override it", func() {
+		active, err := m.ActiveProfile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active).To(BeEmpty())
+
+		Expect(m.SetActiveProfile("work")).To(Succeed())
+		active, err = m.ActiveProfile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active).To(Equal("work"))
+
+		GinkgoT().Setenv(dotdir.ProfileEnv, "personal")
+		active, err = m.ActiveProfile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active).To(Equal("personal"))
+
+		GinkgoT().Setenv(dotdir.ProfileEnv, "")
+		Expect(m.SetActiveProfile(dotdir.DefaultProfile)).To(Succeed())
+		active, err = m.ActiveProfile()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active).To(BeEmpty())
+	})
+})
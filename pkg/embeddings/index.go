@@ -0,0 +1,80 @@
+package embeddings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/vector"
+)
+
+// indexLookupBatch bounds the number of IDs checked per vector store lookup.
+const indexLookupBatch = 500
+
+// IndexResult summarizes an Index run.
+type IndexResult struct {
+	// Embedded is the number of turns newly embedded and stored.
+	Embedded int
+
+	// Existing is the number of turns that already had an embedding.
+	Existing int
+
+	// Empty is the number of turns skipped because they have no text.
+	Empty int
+}
+
+// Index computes and stores embeddings for nodes that do not have one yet,
+// so that sessions recorded before an embedder was configured become
+// searchable. It stops at the first embedding or storage error.
+func Index(ctx context.Context, embedder Embedder, vectors vector.Driver, nodes []*merkle.Node) (*IndexResult, error) {
+	result := &IndexResult{}
+	if len(nodes) == 0 {
+		return result, nil
+	}
+
+	have := make(map[string]bool, len(nodes))
+	for start := 0; start < len(nodes); start += indexLookupBatch {
+		batch := nodes[start:min(start+indexLookupBatch, len(nodes))]
+		ids := make([]string, 0, len(batch))
+		for _, n := range batch {
+			ids = append(ids, n.Hash)
+		}
+		existing, err := vectors.Get(ctx, ids)
+		if err != nil {
+			return result, fmt.Errorf("loading existing embeddings: %w", err)
+		}
+		for _, doc := range existing {
+			have[doc.ID] = true
+		}
+	}
+
+	for _, n := range nodes {
+		if have[n.Hash] {
+			result.Existing++
+			continue
+		}
+
+		text := n.Bucket.ExtractText()
+		if text == "" {
+			result.Empty++
+			continue
+		}
+
+		embedding, err := embedder.Embed(ctx, text)
+		if err != nil {
+			return result, fmt.Errorf("embedding %s: %w", n.Hash, err)
+		}
+
+		doc := vector.Document{
+			ID:        n.Hash,
+			Hash:      n.Hash,
+			Embedding: embedding,
+		}
+		if err := vectors.Add(ctx, []vector.Document{doc}); err != nil {
+			return result, fmt.Errorf("storing embedding for %s: %w", n.Hash, err)
+		}
+		result.Embedded++
+	}
+
+	return result, nil
+}
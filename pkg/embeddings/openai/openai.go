@@ -0,0 +1,150 @@
+// Package openai implements pkg/embedding's Embedder client for OpenAI-compatible embedding APIs
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/embeddings"
+	"github.com/papercomputeco/tapes/pkg/vector"
+)
+
+const (
+	// DefaultEmbeddingModel is the default model used for embeddings.
+	DefaultEmbeddingModel = "text-embedding-3-small"
+
+	// DefaultBaseURL is the default OpenAI API URL.
+	DefaultBaseURL = "https://api.openai.com"
+
+	// APIKeyEnv is the environment variable read when no API key is configured.
+	APIKeyEnv = "OPENAI_API_KEY"
+)
+
+// Embedder wraps an OpenAI-compatible /v1/embeddings API.
+type Embedder struct {
+	baseURL    string
+	model      string
+	apiKey     string
+	dimensions uint
+	httpClient *http.Client
+}
+
+// EmbedderConfig holds configuration for the OpenAI embedder.
+type EmbedderConfig struct {
+	// BaseURL is the API URL (e.g., "https://api.openai.com").
+	// Defaults to DefaultBaseURL if empty.
+	BaseURL string
+
+	// Model is the embedding model to use (e.g., "text-embedding-3-small").
+	// Defaults to DefaultEmbeddingModel if empty.
+	Model string
+
+	// APIKey authenticates requests. Defaults to $OPENAI_API_KEY if empty.
+	APIKey string
+
+	// Dimensions truncates returned vectors for models that support it.
+	// Zero leaves the model's native size.
+	Dimensions uint
+}
+
+// embedRequest is the request body for the embeddings API.
+type embedRequest struct {
+	Model      string `json:"model"`
+	Input      string `json:"input"`
+	Dimensions uint   `json:"dimensions,omitempty"`
+}
+
+// embedResponse is the response from the embeddings API.
+type embedResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// NewEmbedder creates a new embedder using an OpenAI-compatible embeddings API.
+func NewEmbedder(cfg EmbedderConfig) (*Embedder, error) {
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	model := cfg.Model
+	if model == "" {
+		model = DefaultEmbeddingModel
+	}
+
+	apiKey := cfg.APIKey
+	if apiKey == "" {
+		apiKey = os.Getenv(APIKeyEnv)
+	}
+
+	return &Embedder{
+		baseURL:    baseURL,
+		model:      model,
+		apiKey:     apiKey,
+		dimensions: cfg.Dimensions,
+		httpClient: &http.Client{
+			Timeout: 60 * time.Second,
+		},
+	}, nil
+}
+
+// Embed converts text into a vector embedding.
+func (e *Embedder) Embed(ctx context.Context, text string) ([]float32, error) {
+	reqBody := embedRequest{
+		Model:      e.model,
+		Input:      text,
+		Dimensions: e.dimensions,
+	}
+
+	jsonBody, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("%w: marshaling request: %w", vector.ErrEmbedding, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/v1/embeddings", bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("%w: creating request: %w", vector.ErrEmbedding, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: sending request: %w", vector.ErrEmbedding, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%w: openai returned status %d: %s", vector.ErrEmbedding, resp.StatusCode, string(body))
+	}
+
+	var embedResp embedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embedResp); err != nil {
+		return nil, fmt.Errorf("%w: decoding response: %w", vector.ErrEmbedding, err)
+	}
+
+	if len(embedResp.Data) == 0 || len(embedResp.Data[0].Embedding) == 0 {
+		return nil, fmt.Errorf("%w: no embeddings returned", vector.ErrEmbedding)
+	}
+
+	return embedResp.Data[0].Embedding, nil
+}
+
+// Close releases resources held by the embedder.
+func (e *Embedder) Close() error {
+	return nil
+}
+
+// Ensure Embedder implements embeddings.Embedder
+var _ embeddings.Embedder = (*Embedder)(nil)
@@ -0,0 +1,56 @@
+package openai_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/embeddings/openai"
+	"github.com/papercomputeco/tapes/pkg/vector"
+)
+
+var _ = Describe("Embedder", func() {
+	It("posts the model and input and returns the first embedding", func() {
+		var got map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/v1/embeddings"))
+			Expect(r.Header.Get("Authorization")).To(Equal("Bearer sk-test"))
+			Expect(json.NewDecoder(r.Body).Decode(&got)).To(Succeed())
+			_, _ = w.Write([]byte(`{"data":[{"embedding":[0.1,0.2,0.3]}]}`))
+		}))
+		defer server.Close()
+
+		embedder, err := openai.NewEmbedder(openai.EmbedderConfig{
+			BaseURL:    server.URL,
+			Model:      "text-embedding-3-small",
+			APIKey:     "sk-test",
+			Dimensions: 3,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		embedding, err := embedder.Embed(context.Background(), "auth middleware")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(embedding).To(Equal([]float32{0.1, 0.2, 0.3}))
+		Expect(got).To(HaveKeyWithValue("model", "text-embedding-3-small"))
+		Expect(got).To(HaveKeyWithValue("input", "auth middleware"))
+		Expect(got).To(HaveKeyWithValue("dimensions", BeNumerically("==", 3)))
+	})
+
+	It("wraps API errors as embedding errors", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "bad key", http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		embedder, err := openai.NewEmbedder(openai.EmbedderConfig{BaseURL: server.URL})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = embedder.Embed(context.Background(), "text")
+		Expect(err).To(MatchError(vector.ErrEmbedding))
+		Expect(err.Error()).To(ContainSubstring("401"))
+	})
+})
@@ -6,12 +6,20 @@ import (
 
 	"github.com/papercomputeco/tapes/pkg/embeddings"
 	"github.com/papercomputeco/tapes/pkg/embeddings/ollama"
+	"github.com/papercomputeco/tapes/pkg/embeddings/openai"
 )
 
 type NewEmbedderOpts struct {
 	ProviderType string
 	TargetURL    string
 	Model        string
+
+	// APIKey authenticates hosted embedding APIs. Providers fall back to
+	// their conventional environment variable when empty.
+	APIKey string
+
+	// Dimensions requests a vector size from providers that support it.
+	Dimensions uint
 }
 
 func NewEmbedder(o *NewEmbedderOpts) (embeddings.Embedder, error) {
@@ -21,6 +29,13 @@ func NewEmbedder(o *NewEmbedderOpts) (embeddings.Embedder, error) {
 			BaseURL: o.TargetURL,
 			Model:   o.Model,
 		})
+	case "openai":
+		return openai.NewEmbedder(openai.EmbedderConfig{
+			BaseURL:    o.TargetURL,
+			Model:      o.Model,
+			APIKey:     o.APIKey,
+			Dimensions: o.Dimensions,
+		})
 	default:
 		return nil, fmt.Errorf("unsupported embedding provider: %s", o.ProviderType)
 	}
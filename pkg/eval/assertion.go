@@ -0,0 +1,212 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// judgeMaxChars bounds the prompt and reply text sent to the judge.
+const judgeMaxChars = 8000
+
+// check is the outcome of one assertion on one reply or tool call.
+type check struct {
+	passed bool
+	detail string
+	err    error
+}
+
+// assertion checks replies. It returns no checks for replies it does not
+// apply to.
+type assertion interface {
+	check(ctx context.Context, turn deck.AssistantTurn) []check
+}
+
+// compile builds the assertion described by spec.
+func compile(spec AssertionSpec, judge deck.LLMCallFunc) (assertion, error) {
+	switch spec.Type {
+	case TypeRegex:
+		if spec.Pattern == "" {
+			return nil, errors.New("regex assertion needs a pattern")
+		}
+		re, err := regexp.Compile(spec.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		switch spec.Expect {
+		case "", ExpectMatch, ExpectNoMatch:
+		default:
+			return nil, fmt.Errorf("unsupported expect %q (expected match or no_match)", spec.Expect)
+		}
+		return &regexAssertion{re: re, noMatch: spec.Expect == ExpectNoMatch}, nil
+
+	case TypeJSONSchema:
+		if spec.Schema == nil {
+			return nil, errors.New("json_schema assertion needs a schema")
+		}
+		data, err := json.Marshal(spec.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema: %w", err)
+		}
+		var schema jsonschema.Schema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return nil, fmt.Errorf("invalid schema: %w", err)
+		}
+		resolved, err := schema.Resolve(nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema: %w", err)
+		}
+		return &schemaAssertion{tool: spec.Tool, schema: resolved}, nil
+
+	case TypeJudge:
+		if spec.Rubric == "" {
+			return nil, errors.New("judge assertion needs a rubric")
+		}
+		if judge == nil {
+			return nil, errors.New("judge assertion needs a judge model")
+		}
+		return &judgeAssertion{rubric: spec.Rubric, judge: judge}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported type %q (expected regex, json_schema, or judge)", spec.Type)
+	}
+}
+
+// replyText joins the text of a reply, or returns "" for replies that only
+// call tools.
+func replyText(content []llm.ContentBlock) string {
+	var texts []string
+	for _, block := range content {
+		if block.Type == "text" && block.Text != "" {
+			texts = append(texts, block.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+type regexAssertion struct {
+	re      *regexp.Regexp
+	noMatch bool
+}
+
+func (a *regexAssertion) check(_ context.Context, turn deck.AssistantTurn) []check {
+	text := replyText(turn.Content)
+	if text == "" {
+		return nil
+	}
+
+	match := a.re.FindString(text)
+	switch {
+	case a.noMatch && match != "":
+		return []check{{detail: fmt.Sprintf("matched %q", match)}}
+	case !a.noMatch && match == "":
+		return []check{{detail: "no match"}}
+	default:
+		return []check{{passed: true}}
+	}
+}
+
+type schemaAssertion struct {
+	tool   string
+	schema *jsonschema.Resolved
+}
+
+func (a *schemaAssertion) check(_ context.Context, turn deck.AssistantTurn) []check {
+	var checks []check
+	for _, block := range turn.Content {
+		if block.Type != "tool_use" || (a.tool != "" && block.ToolName != a.tool) {
+			continue
+		}
+
+		var input any = map[string]any{}
+		if block.ToolInput != nil {
+			input = block.ToolInput
+		}
+		if err := a.schema.Validate(input); err != nil {
+			checks = append(checks, check{detail: fmt.Sprintf("%s: %v", block.ToolName, err)})
+			continue
+		}
+		checks = append(checks, check{passed: true})
+	}
+	return checks
+}
+
+type judgeAssertion struct {
+	rubric string
+	judge  deck.LLMCallFunc
+}
+
+// judgeVerdict is the answer the judge is asked for.
+type judgeVerdict struct {
+	Pass   *bool  `json:"pass"`
+	Reason string `json:"reason"`
+}
+
+func (a *judgeAssertion) check(ctx context.Context, turn deck.AssistantTurn) []check {
+	reply := replyText(turn.Content)
+	if reply == "" {
+		return nil
+	}
+
+	response, err := a.judge(ctx, buildJudgePrompt(a.rubric, turn.Prompt, reply))
+	if err != nil {
+		return []check{{err: fmt.Errorf("judging: %w", err)}}
+	}
+	verdict, err := parseVerdict(response)
+	if err != nil {
+		return []check{{err: err}}
+	}
+	return []check{{passed: *verdict.Pass, detail: verdict.Reason}}
+}
+
+func buildJudgePrompt(rubric, prompt, reply string) string {
+	return fmt.Sprintf(`You are grading a reply of an AI assistant against a rubric.
+
+Rubric:
+%s
+
+User message:
+%s
+
+Assistant reply:
+%s
+
+Respond with only a JSON object, with no other text:
+{"pass": true or false, "reason": "one sentence explaining the grade"}`,
+		rubric, truncate(prompt), truncate(reply))
+}
+
+// parseVerdict reads the JSON verdict from a judge response, which may wrap
+// it in other text.
+func parseVerdict(response string) (*judgeVerdict, error) {
+	jsonStr := response
+	if idx := strings.Index(response, "{"); idx >= 0 {
+		if endIdx := strings.LastIndex(response, "}"); endIdx > idx {
+			jsonStr = response[idx : endIdx+1]
+		}
+	}
+
+	var verdict judgeVerdict
+	if err := json.Unmarshal([]byte(jsonStr), &verdict); err != nil {
+		return nil, fmt.Errorf("parsing judge verdict: %w", err)
+	}
+	if verdict.Pass == nil {
+		return nil, errors.New("parsing judge verdict: pass missing")
+	}
+	return &verdict, nil
+}
+
+func truncate(s string) string {
+	if runes := []rune(s); len(runes) > judgeMaxChars {
+		return string(runes[:judgeMaxChars]) + "\n[truncated]"
+	}
+	return s
+}
@@ -0,0 +1,89 @@
+package eval
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Assertion types.
+const (
+	// TypeRegex checks the text of each reply against a pattern.
+	TypeRegex = "regex"
+
+	// TypeJSONSchema validates the arguments of each call to a tool.
+	TypeJSONSchema = "json_schema"
+
+	// TypeJudge asks a model whether each reply meets a rubric.
+	TypeJudge = "judge"
+)
+
+// Expectations of regex assertions.
+const (
+	ExpectMatch   = "match"
+	ExpectNoMatch = "no_match"
+)
+
+// Config is an eval suite, as read from eval.toml.
+type Config struct {
+	Judge      JudgeConfig     `toml:"judge"`
+	Assertions []AssertionSpec `toml:"assertion"`
+}
+
+// JudgeConfig selects the model that grades judge assertions.
+type JudgeConfig struct {
+	Provider string `toml:"provider"`
+	Model    string `toml:"model"`
+}
+
+// AssertionSpec is one [[assertion]] of an eval suite.
+type AssertionSpec struct {
+	Name string `toml:"name"`
+	Type string `toml:"type"`
+
+	// Model limits the assertion to replies of models whose name contains
+	// it.
+	Model string `toml:"model"`
+
+	// Pattern and Expect configure regex assertions. Expect defaults to
+	// "match".
+	Pattern string `toml:"pattern"`
+	Expect  string `toml:"expect"`
+
+	// Tool and Schema configure json_schema assertions. An empty Tool
+	// validates the calls to every tool.
+	Tool   string         `toml:"tool"`
+	Schema map[string]any `toml:"schema"`
+
+	// Rubric is what judge assertions grade replies against.
+	Rubric string `toml:"rubric"`
+}
+
+// Load reads an eval suite from path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading eval suite: %w", err)
+	}
+
+	var cfg Config
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing eval suite %s: %w", path, err)
+	}
+	if len(cfg.Assertions) == 0 {
+		return nil, errors.New("eval suite has no [[assertion]] entries")
+	}
+	return &cfg, nil
+}
+
+// NeedsJudge reports whether any assertion is graded by a model.
+func (c *Config) NeedsJudge() bool {
+	for _, spec := range c.Assertions {
+		if spec.Type == TypeJudge {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+// Package eval runs assertions against the assistant turns of recorded
+// sessions, so that recordings double as a regression suite for agents.
+//
+// A suite, read from eval.toml, lists assertions of three types: regular
+// expressions over reply text, JSON schemas over the arguments of tool calls,
+// and rubrics graded by a judge model. Every assertion is checked against
+// each reply it applies to, and pass rates are reported per model and per
+// system prompt version, so that changes to either can be compared.
+package eval
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+// maxFailures bounds the failures listed per assertion in a report.
+const maxFailures = 20
+
+// Report is the outcome of running a suite.
+type Report struct {
+	// Turns is the number of assistant turns the suite ran against.
+	Turns      int               `json:"turns"`
+	Assertions []AssertionReport `json:"assertions"`
+}
+
+// AssertionReport is how one assertion fared overall, per model, and per
+// system prompt version.
+type AssertionReport struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Rate
+
+	ByModel  []GroupRate `json:"by_model"`
+	ByPrompt []GroupRate `json:"by_prompt"`
+
+	// Failures are the first failed checks, oldest first.
+	Failures []Failure `json:"failures,omitempty"`
+}
+
+// Rate counts checks. Errors, such as judge calls that failed, are left out
+// of the pass rate.
+type Rate struct {
+	Passed   int     `json:"passed"`
+	Failed   int     `json:"failed"`
+	Errors   int     `json:"errors"`
+	PassRate float64 `json:"pass_rate"`
+}
+
+// GroupRate counts the checks of replies by one model or prompt version.
+// Replies recorded without a system prompt have an empty prompt key.
+type GroupRate struct {
+	Key string `json:"key"`
+	Rate
+}
+
+// Failure is a check that failed or could not be completed.
+type Failure struct {
+	Turn    string `json:"turn"`
+	Session string `json:"session"`
+	Model   string `json:"model"`
+	Detail  string `json:"detail"`
+}
+
+// Runner runs a suite against recorded sessions.
+type Runner struct {
+	query      *deck.Query
+	specs      []AssertionSpec
+	assertions []assertion
+}
+
+// NewRunner compiles the assertions of cfg. judge grades judge assertions and
+// may be nil when there are none.
+func NewRunner(query *deck.Query, cfg *Config, judge deck.LLMCallFunc) (*Runner, error) {
+	r := &Runner{query: query, specs: cfg.Assertions}
+	for i, spec := range cfg.Assertions {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("assertion %d has no name", i+1)
+		}
+		a, err := compile(spec, judge)
+		if err != nil {
+			return nil, fmt.Errorf("assertion %s: %w", spec.Name, err)
+		}
+		r.assertions = append(r.assertions, a)
+	}
+	return r, nil
+}
+
+// Run checks every assertion against the assistant turns of the sessions
+// matching filters. limit caps the turns checked when positive.
+func (r *Runner) Run(ctx context.Context, filters deck.Filters, limit int) (*Report, error) {
+	type counts struct {
+		total    Rate
+		byModel  map[string]*Rate
+		byPrompt map[string]*Rate
+		failures []Failure
+	}
+	all := make([]counts, len(r.assertions))
+	for i := range all {
+		all[i] = counts{byModel: map[string]*Rate{}, byPrompt: map[string]*Rate{}}
+	}
+
+	report := &Report{}
+	for turn, err := range r.query.AssistantTurns(ctx, filters) {
+		if err != nil {
+			return nil, err
+		}
+		if limit > 0 && report.Turns == limit {
+			break
+		}
+		report.Turns++
+
+		for i, a := range r.assertions {
+			if model := r.specs[i].Model; model != "" && !strings.Contains(turn.Model, model) {
+				continue
+			}
+			c := &all[i]
+			for _, result := range a.check(ctx, turn) {
+				for _, rate := range []*Rate{&c.total, group(c.byModel, turn.Model), group(c.byPrompt, turn.SystemPromptHash)} {
+					rate.add(result)
+				}
+				if !result.passed && len(c.failures) < maxFailures {
+					detail := result.detail
+					if result.err != nil {
+						detail = result.err.Error()
+					}
+					c.failures = append(c.failures, Failure{Turn: turn.Hash, Session: turn.Session, Model: turn.Model, Detail: detail})
+				}
+			}
+		}
+	}
+
+	for i, spec := range r.specs {
+		c := all[i]
+		c.total.finish()
+		report.Assertions = append(report.Assertions, AssertionReport{
+			Name:     spec.Name,
+			Type:     spec.Type,
+			Rate:     c.total,
+			ByModel:  groupRates(c.byModel),
+			ByPrompt: groupRates(c.byPrompt),
+			Failures: c.failures,
+		})
+	}
+	return report, nil
+}
+
+func group(groups map[string]*Rate, key string) *Rate {
+	rate, ok := groups[key]
+	if !ok {
+		rate = &Rate{}
+		groups[key] = rate
+	}
+	return rate
+}
+
+func (r *Rate) add(c check) {
+	switch {
+	case c.err != nil:
+		r.Errors++
+	case c.passed:
+		r.Passed++
+	default:
+		r.Failed++
+	}
+}
+
+func (r *Rate) finish() {
+	if graded := r.Passed + r.Failed; graded > 0 {
+		r.PassRate = float64(r.Passed) / float64(graded)
+	}
+}
+
+// groupRates returns the rates of groups sorted by key.
+func groupRates(groups map[string]*Rate) []GroupRate {
+	rates := make([]GroupRate, 0, len(groups))
+	for key, rate := range groups {
+		rate.finish()
+		rates = append(rates, GroupRate{Key: key, Rate: *rate})
+	}
+	slices.SortFunc(rates, func(a, b GroupRate) int { return cmp.Compare(a.Key, b.Key) })
+	return rates
+}
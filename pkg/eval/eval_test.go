@@ -0,0 +1,182 @@
+package eval_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/eval"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("Load", func() {
+	It("reads assertions and the judge from TOML", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "eval.toml")
+		Expect(os.WriteFile(path, []byte(`
+[judge]
+provider = "anthropic"
+
+[[assertion]]
+name = "args"
+type = "json_schema"
+tool = "bash"
+schema = { type = "object", required = ["cmd"] }
+
+[[assertion]]
+name = "grounded"
+type = "judge"
+rubric = "Stays on topic."
+`), 0o600)).To(Succeed())
+
+		cfg, err := eval.Load(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Judge.Provider).To(Equal("anthropic"))
+		Expect(cfg.Assertions).To(HaveLen(2))
+		Expect(cfg.Assertions[0].Schema).To(HaveKeyWithValue("type", "object"))
+		Expect(cfg.NeedsJudge()).To(BeTrue())
+	})
+
+	It("rejects suites without assertions", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "eval.toml")
+		Expect(os.WriteFile(path, []byte("[judge]\n"), 0o600)).To(Succeed())
+		_, err := eval.Load(path)
+		Expect(err).To(MatchError(ContainSubstring("no [[assertion]] entries")))
+	})
+})
+
+var _ = Describe("Runner", func() {
+	var (
+		ctx   context.Context
+		query *deck.Query
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(role, model, prompt string, blocks []llm.ContentBlock, parent *merkle.Node) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:    "message",
+				Role:    role,
+				Content: blocks,
+				Model:   model,
+			}, parent, merkle.NodeMeta{SystemPromptHash: prompt})
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+		text := func(s string) []llm.ContentBlock { return []llm.ContentBlock{{Type: "text", Text: s}} }
+
+		q := put("user", "gpt-4o", "", text("List the files."), nil)
+		call := put("assistant", "gpt-4o", "p1", []llm.ContentBlock{
+			{Type: "tool_use", ToolUseID: "c1", ToolName: "bash", ToolInput: map[string]any{"cmd": "ls"}},
+		}, q)
+		result := put("user", "gpt-4o", "", []llm.ContentBlock{{Type: "tool_result", ToolResultID: "c1", ToolOutput: "main.go"}}, call)
+		put("assistant", "gpt-4o", "p1", text("I apologize, there is only main.go."), result)
+
+		q2 := put("user", "claude-sonnet-4-5", "", text("Hello."), nil)
+		put("assistant", "claude-sonnet-4-5", "p2", []llm.ContentBlock{
+			{Type: "text", Text: "Hi."},
+			{Type: "tool_use", ToolUseID: "c2", ToolName: "bash", ToolInput: map[string]any{"command": "ls"}},
+		}, q2)
+		Expect(driver.Close()).To(Succeed())
+
+		var closeFn func() error
+		query, closeFn, err = deck.NewQuery(ctx, dbPath, deck.DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	find := func(report *eval.Report, name string) eval.AssertionReport {
+		for _, a := range report.Assertions {
+			if a.Name == name {
+				return a
+			}
+		}
+		Fail("no assertion " + name)
+		return eval.AssertionReport{}
+	}
+
+	It("reports pass rates per assertion, model, and prompt version", func() {
+		var prompts []string
+		judge := func(_ context.Context, prompt string) (string, error) {
+			prompts = append(prompts, prompt)
+			if strings.Contains(prompt, "Hi.") {
+				return `Sure: {"pass": false, "reason": "Ignores the user."}`, nil
+			}
+			return `{"pass": true, "reason": "Answers the question."}`, nil
+		}
+
+		runner, err := eval.NewRunner(query, &eval.Config{Assertions: []eval.AssertionSpec{
+			{Name: "no-apologies", Type: eval.TypeRegex, Pattern: `(?i)\bI apologi[sz]e\b`, Expect: eval.ExpectNoMatch},
+			{Name: "bash-args", Type: eval.TypeJSONSchema, Tool: "bash", Schema: map[string]any{
+				"type": "object", "required": []any{"cmd"},
+			}},
+			{Name: "helpful", Type: eval.TypeJudge, Rubric: "Answers the user."},
+			{Name: "claude-only", Type: eval.TypeRegex, Pattern: "Hi", Model: "claude"},
+		}}, judge)
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := runner.Run(ctx, deck.Filters{}, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Turns).To(Equal(3))
+
+		apologies := find(report, "no-apologies")
+		Expect(apologies.Rate).To(Equal(eval.Rate{Passed: 1, Failed: 1, PassRate: 0.5}))
+		Expect(apologies.Failures).To(HaveLen(1))
+		Expect(apologies.Failures[0].Detail).To(Equal(`matched "I apologize"`))
+		Expect(apologies.ByModel).To(Equal([]eval.GroupRate{
+			{Key: "claude-sonnet-4-5", Rate: eval.Rate{Passed: 1, PassRate: 1}},
+			{Key: "gpt-4o", Rate: eval.Rate{Failed: 1}},
+		}))
+		Expect(apologies.ByPrompt[0].Key).To(Equal("p1"))
+
+		args := find(report, "bash-args")
+		Expect(args.Passed).To(Equal(1))
+		Expect(args.Failed).To(Equal(1))
+		Expect(args.Failures[0].Detail).To(HavePrefix("bash: "))
+
+		helpful := find(report, "helpful")
+		Expect(helpful.Passed).To(Equal(1))
+		Expect(helpful.Failed).To(Equal(1))
+		Expect(helpful.Failures[0].Detail).To(Equal("Ignores the user."))
+		Expect(prompts).To(ContainElement(ContainSubstring("User message:\nList the files.")))
+
+		Expect(find(report, "claude-only").Rate).To(Equal(eval.Rate{Passed: 1, PassRate: 1}))
+	})
+
+	It("counts judge failures as errors", func() {
+		runner, err := eval.NewRunner(query, &eval.Config{Assertions: []eval.AssertionSpec{
+			{Name: "helpful", Type: eval.TypeJudge, Rubric: "Answers the user."},
+		}}, func(context.Context, string) (string, error) { return "", errors.New("rate limited") })
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := runner.Run(ctx, deck.Filters{}, 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Turns).To(Equal(1))
+		Expect(report.Assertions[0].Rate).To(Equal(eval.Rate{Errors: 1}))
+	})
+
+	It("rejects invalid assertions", func() {
+		_, err := eval.NewRunner(query, &eval.Config{Assertions: []eval.AssertionSpec{
+			{Name: "judged", Type: eval.TypeJudge, Rubric: "Be nice."},
+		}}, nil)
+		Expect(err).To(MatchError("assertion judged: judge assertion needs a judge model"))
+
+		_, err = eval.NewRunner(query, &eval.Config{Assertions: []eval.AssertionSpec{
+			{Name: "bad", Type: eval.TypeRegex, Pattern: "("},
+		}}, nil)
+		Expect(err).To(MatchError(ContainSubstring("assertion bad: invalid pattern")))
+	})
+})
@@ -0,0 +1,274 @@
+// Package events fans out notifications about newly captured nodes to live
+// subscribers, such as clients of the API's /v1/events stream.
+//
+// Delivery is best effort: a subscriber that falls behind misses events
+// rather than slowing down capture. Events carry a sequence number so that
+// subscribers can detect gaps.
+package events
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+// Event types.
+const (
+	// TypeNodeCreated is the type of events published when a node is first
+	// stored.
+	TypeNodeCreated = "node.created"
+
+	// TypeSessionEnded is the type of events published when a session is
+	// marked ended, because it went idle or its agent exited.
+	TypeSessionEnded = "session.ended"
+)
+
+// MaxTextBytes caps the text carried by an event. Clients that need the full
+// content can fetch the node by hash.
+const MaxTextBytes = 4096
+
+// subscriberBuffer is the number of events buffered per subscriber before
+// new events are dropped for it.
+const subscriberBuffer = 64
+
+// Event describes a newly stored node.
+type Event struct {
+	// Seq increases by one for every event published by a broker.
+	Seq  uint64    `json:"seq"`
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parent_hash,omitempty"`
+
+	// RootHash is the hash of the conversation's first node and identifies
+	// the session the node belongs to.
+	RootHash string `json:"root_hash"`
+
+	Role      string `json:"role"`
+	Provider  string `json:"provider,omitempty"`
+	Model     string `json:"model,omitempty"`
+	AgentName string `json:"agent_name,omitempty"`
+	Project   string `json:"project,omitempty"`
+
+	// RunID identifies the agent run the node was captured in, such as one
+	// launch by tapes start.
+	RunID string `json:"run_id,omitempty"`
+
+	// Text is the node's text content, truncated to MaxTextBytes.
+	Text string `json:"text,omitempty"`
+
+	// ToolCalls lists the tools the node called, in call order.
+	ToolCalls []string `json:"tool_calls,omitempty"`
+
+	// ToolResults is the number of tool results the node carries.
+	ToolResults int `json:"tool_results,omitempty"`
+
+	StopReason string     `json:"stop_reason,omitempty"`
+	Usage      *llm.Usage `json:"usage,omitempty"`
+
+	// PolicyViolations are the guardrail rules the node's turn broke.
+	PolicyViolations []llm.PolicyViolation `json:"policy_violations,omitempty"`
+
+	// SignerKey and Signature sign the node's SignedPayload with the key of
+	// the installation that stored the node, when it signs nodes (see
+	// merkle.Node.Sign).
+	SignerKey []byte `json:"signer_key,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+
+	// EndReason is why the session ended (only for TypeSessionEnded).
+	EndReason string `json:"end_reason,omitempty"`
+}
+
+// NodeCreated builds a TypeNodeCreated event for node, which belongs to the
+// conversation rooted at rootHash.
+func NodeCreated(node *merkle.Node, rootHash string) Event {
+	event := Event{
+		Type:       TypeNodeCreated,
+		Time:       time.Now(),
+		Hash:       node.Hash,
+		RootHash:   rootHash,
+		Role:       node.Bucket.Role,
+		Provider:   node.Bucket.Provider,
+		Model:      node.Bucket.Model,
+		AgentName:  node.Bucket.AgentName,
+		Project:    node.Project,
+		RunID:      node.RunID,
+		StopReason: node.StopReason,
+		Usage:      node.Usage,
+
+		PolicyViolations: node.PolicyViolations,
+
+		SignerKey: node.SignerKey,
+		Signature: node.Signature,
+	}
+	if node.ParentHash != nil {
+		event.ParentHash = *node.ParentHash
+	}
+	if node.Timing != nil && !node.Timing.ResponseCompletedAt.IsZero() {
+		event.Time = node.Timing.ResponseCompletedAt
+	}
+
+	var texts []string
+	for _, block := range node.Bucket.Content {
+		switch block.Type {
+		case "text":
+			if block.Text != "" {
+				texts = append(texts, block.Text)
+			}
+		case "tool_use":
+			event.ToolCalls = append(event.ToolCalls, block.ToolName)
+		case "tool_result":
+			event.ToolResults++
+		}
+	}
+	event.Text = utils.Truncate(strings.Join(texts, "\n"), MaxTextBytes)
+
+	return event
+}
+
+// SessionEnded builds a TypeSessionEnded event for the session rooted at
+// rootHash, of the given agent and run, which ended at for reason.
+func SessionEnded(rootHash, agentName, runID, reason string, at time.Time) Event {
+	return Event{
+		Type:      TypeSessionEnded,
+		Time:      at,
+		Hash:      rootHash,
+		RootHash:  rootHash,
+		AgentName: agentName,
+		RunID:     runID,
+		EndReason: reason,
+	}
+}
+
+// Filter selects the events delivered to a subscriber. Empty fields match
+// every event.
+type Filter struct {
+	// Session matches events whose RootHash starts with this prefix.
+	Session string
+
+	// Provider matches events from this provider.
+	Provider string
+
+	// Run matches events captured in this agent run.
+	Run string
+}
+
+// Match reports whether event passes the filter.
+func (f Filter) Match(event Event) bool {
+	if f.Session != "" && !strings.HasPrefix(event.RootHash, f.Session) {
+		return false
+	}
+	if f.Provider != "" && !strings.EqualFold(event.Provider, f.Provider) {
+		return false
+	}
+	if f.Run != "" && event.RunID != f.Run {
+		return false
+	}
+	return true
+}
+
+// Broker delivers published events to subscribers. It is safe for
+// concurrent use, and the zero value is not usable; create one with
+// NewBroker.
+type Broker struct {
+	mu     sync.Mutex
+	seq    uint64
+	subs   map[*subscriber]struct{}
+	closed bool
+}
+
+type subscriber struct {
+	ch     chan Event
+	filter Filter
+}
+
+// NewBroker creates a broker with no subscribers.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[*subscriber]struct{})}
+}
+
+// Publish assigns event the next sequence number and delivers it to every
+// subscriber whose filter matches. It never blocks: subscribers with a full
+// buffer miss the event.
+func (b *Broker) Publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.seq++
+	event.Seq = b.seq
+
+	for sub := range b.subs {
+		if !sub.filter.Match(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel of events matching filter and a function that
+// ends the subscription. The channel is closed when the subscription ends or
+// the broker is closed.
+func (b *Broker) Subscribe(filter Filter) (<-chan Event, func()) {
+	sub := &subscriber{
+		ch:     make(chan Event, subscriberBuffer),
+		filter: filter,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	b.subs[sub] = struct{}{}
+
+	return sub.ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subs[sub]; ok {
+			delete(b.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Subscribers returns the number of active subscriptions.
+func (b *Broker) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+// Closed reports whether the broker was closed.
+func (b *Broker) Closed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed
+}
+
+// Close ends all subscriptions. Later calls to Publish are ignored.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for sub := range b.subs {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
@@ -0,0 +1,144 @@
+package events_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+)
+
+var _ = Describe("NodeCreated", func() {
+	It("summarizes the node's content", func() {
+		root := merkle.NewNode(merkle.Bucket{
+			Type:     "message",
+			Role:     "user",
+			Content:  []llm.ContentBlock{{Type: "text", Text: "list files"}},
+			Provider: "anthropic",
+		}, nil)
+		reply := merkle.NewNode(merkle.Bucket{
+			Type: "message",
+			Role: "assistant",
+			Content: []llm.ContentBlock{
+				{Type: "text", Text: "Sure."},
+				{Type: "tool_use", ToolName: "Bash"},
+				{Type: "tool_use", ToolName: "Read"},
+			},
+			Model:    "claude-sonnet-4",
+			Provider: "anthropic",
+		}, root, merkle.NodeMeta{StopReason: "tool_use", RunID: "run-1", Usage: &llm.Usage{PromptTokens: 10}})
+
+		event := events.NodeCreated(reply, root.Hash)
+		Expect(event.Type).To(Equal(events.TypeNodeCreated))
+		Expect(event.Hash).To(Equal(reply.Hash))
+		Expect(event.ParentHash).To(Equal(root.Hash))
+		Expect(event.RootHash).To(Equal(root.Hash))
+		Expect(event.Role).To(Equal("assistant"))
+		Expect(event.Text).To(Equal("Sure."))
+		Expect(event.ToolCalls).To(Equal([]string{"Bash", "Read"}))
+		Expect(event.StopReason).To(Equal("tool_use"))
+		Expect(event.RunID).To(Equal("run-1"))
+		Expect(event.Usage.PromptTokens).To(Equal(10))
+	})
+
+	It("carries the node's policy violations", func() {
+		violations := []llm.PolicyViolation{{Rule: "no_web", Action: "annotate", Tool: "WebFetch", Message: "WebFetch is a blocked tool"}}
+		node := merkle.NewNode(merkle.Bucket{
+			Role:    "assistant",
+			Content: []llm.ContentBlock{{Type: "tool_use", ToolName: "WebFetch"}},
+		}, nil, merkle.NodeMeta{PolicyViolations: violations})
+
+		Expect(events.NodeCreated(node, node.Hash).PolicyViolations).To(Equal(violations))
+	})
+
+	It("truncates long text", func() {
+		node := merkle.NewNode(merkle.Bucket{
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: strings.Repeat("a", events.MaxTextBytes+10)}},
+		}, nil)
+
+		event := events.NodeCreated(node, node.Hash)
+		Expect(len(event.Text)).To(BeNumerically("<=", events.MaxTextBytes+3))
+	})
+})
+
+var _ = Describe("Broker", func() {
+	var broker *events.Broker
+
+	BeforeEach(func() {
+		broker = events.NewBroker()
+	})
+
+	It("delivers events with increasing sequence numbers", func() {
+		ch, cancel := broker.Subscribe(events.Filter{})
+		defer cancel()
+
+		broker.Publish(events.Event{Hash: "a"})
+		broker.Publish(events.Event{Hash: "b"})
+
+		first := <-ch
+		second := <-ch
+		Expect(first.Hash).To(Equal("a"))
+		Expect(second.Hash).To(Equal("b"))
+		Expect(second.Seq).To(Equal(first.Seq + 1))
+	})
+
+	It("applies the subscriber's filter", func() {
+		ch, cancel := broker.Subscribe(events.Filter{Session: "abc", Provider: "openai"})
+		defer cancel()
+
+		broker.Publish(events.Event{Hash: "1", RootHash: "abc123", Provider: "anthropic"})
+		broker.Publish(events.Event{Hash: "2", RootHash: "xyz", Provider: "openai"})
+		broker.Publish(events.Event{Hash: "3", RootHash: "abc123", Provider: "OpenAI"})
+
+		Expect((<-ch).Hash).To(Equal("3"))
+		Consistently(ch).ShouldNot(Receive())
+	})
+
+	It("filters by agent run", func() {
+		ch, cancel := broker.Subscribe(events.Filter{Run: "run-1"})
+		defer cancel()
+
+		broker.Publish(events.Event{Hash: "1", RunID: "run-2"})
+		broker.Publish(events.Event{Hash: "2"})
+		broker.Publish(events.Event{Hash: "3", RunID: "run-1"})
+
+		Expect((<-ch).Hash).To(Equal("3"))
+		Consistently(ch).ShouldNot(Receive())
+	})
+
+	It("drops events for subscribers that fall behind", func() {
+		ch, cancel := broker.Subscribe(events.Filter{})
+		defer cancel()
+
+		for range 1000 {
+			broker.Publish(events.Event{})
+		}
+		Expect(len(ch)).To(BeNumerically("<", 1000))
+	})
+
+	It("closes the channel when the subscription ends", func() {
+		ch, cancel := broker.Subscribe(events.Filter{})
+		Expect(broker.Subscribers()).To(Equal(1))
+
+		cancel()
+		cancel()
+		Expect(broker.Subscribers()).To(BeZero())
+		Eventually(ch).Should(BeClosed())
+	})
+
+	It("closes all subscriptions on Close", func() {
+		ch, cancel := broker.Subscribe(events.Filter{})
+		defer cancel()
+
+		broker.Close()
+		Eventually(ch).Should(BeClosed())
+		broker.Publish(events.Event{})
+
+		late, _ := broker.Subscribe(events.Filter{})
+		Eventually(late).Should(BeClosed())
+	})
+})
@@ -0,0 +1,172 @@
+// Package health checks the components of a running tapes server: that
+// storage accepts writes, that live events are published, that the storage
+// queue keeps up, and, optionally, that upstream providers are reachable.
+//
+// Each check reports a Component status. The server is down when any
+// component is down, degraded when any is degraded, and ok otherwise.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Component statuses, from best to worst.
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+// checkTimeout bounds each check run by Checker.Run.
+const checkTimeout = 5 * time.Second
+
+// Component is the status of one component of the server.
+type Component struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+
+	// CheckedAt is when the status was determined, which is earlier than the
+	// request for the results of background probes.
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Check determines the status of a component.
+type Check func(ctx context.Context) Component
+
+// Checker runs named checks. It is safe for concurrent use once its checks
+// are added.
+type Checker struct {
+	checks map[string]Check
+}
+
+// NewChecker returns a Checker without checks.
+func NewChecker() *Checker {
+	return &Checker{checks: map[string]Check{}}
+}
+
+// Add adds a check named name, replacing any of the same name.
+func (c *Checker) Add(name string, check Check) {
+	c.checks[name] = check
+}
+
+// Run runs the checks concurrently and returns the overall status with the
+// status of each component. A nil Checker has no components and is ok.
+func (c *Checker) Run(ctx context.Context) (string, map[string]Component) {
+	if c == nil || len(c.checks) == 0 {
+		return StatusOK, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var (
+		mu         sync.Mutex
+		wg         sync.WaitGroup
+		components = make(map[string]Component, len(c.checks))
+	)
+	for name, check := range c.checks {
+		wg.Go(func() {
+			component := check(ctx)
+			mu.Lock()
+			components[name] = component
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	return Overall(components), components
+}
+
+// Overall returns the worst status of components.
+func Overall(components map[string]Component) string {
+	status := StatusOK
+	for _, component := range components {
+		switch component.Status {
+		case StatusDown:
+			return StatusDown
+		case StatusDegraded:
+			status = StatusDegraded
+		}
+	}
+	return status
+}
+
+func newComponent(status, message string) Component {
+	return Component{Status: status, Message: message, CheckedAt: time.Now()}
+}
+
+// WritableStore is implemented by storage drivers that can check that they
+// accept writes without storing anything.
+type WritableStore interface {
+	CheckWritable(ctx context.Context) error
+}
+
+// StorageCheck checks that store accepts writes. Stores that cannot check
+// are assumed to.
+func StorageCheck(store any) Check {
+	return func(ctx context.Context) Component {
+		writable, canCheck := store.(WritableStore)
+		if !canCheck {
+			return newComponent(StatusOK, "")
+		}
+		if err := writable.CheckWritable(ctx); err != nil {
+			return newComponent(StatusDown, fmt.Sprintf("not writable: %v", err))
+		}
+		return newComponent(StatusOK, "writable")
+	}
+}
+
+// Publisher is the events broker live events are published to.
+type Publisher interface {
+	Subscribers() int
+	Closed() bool
+}
+
+// EventsCheck checks that events are still published to subscribers.
+func EventsCheck(publisher Publisher) Check {
+	return func(context.Context) Component {
+		if publisher.Closed() {
+			return newComponent(StatusDown, "event broker closed")
+		}
+		return newComponent(StatusOK, fmt.Sprintf("%d subscribers", publisher.Subscribers()))
+	}
+}
+
+// QueueDegraded is the fraction of its capacity at which the storage queue
+// is degraded: a burst of turns could fill it.
+const QueueDegraded = 0.8
+
+// QueueCheck checks that the storage queue keeps up: it is degraded once
+// QueueDegraded of its capacity is used, and down when full, as new turns
+// are then dropped or delayed.
+func QueueCheck(depth func() (queued, capacity int)) Check {
+	return func(context.Context) Component {
+		queued, capacity := depth()
+		message := fmt.Sprintf("%d/%d queued", queued, capacity)
+		switch {
+		case capacity <= 0:
+			return newComponent(StatusOK, message)
+		case queued >= capacity:
+			return newComponent(StatusDown, message+", queue full")
+		case float64(queued) >= QueueDegraded*float64(capacity):
+			return newComponent(StatusDegraded, message)
+		default:
+			return newComponent(StatusOK, message)
+		}
+	}
+}
+
+// DrainCheck reports the server down once draining is set, when it shuts
+// down, so that load balancers stop sending it requests while it finishes
+// those in flight.
+func DrainCheck(draining *atomic.Bool) Check {
+	return func(context.Context) Component {
+		if draining.Load() {
+			return newComponent(StatusDown, "draining")
+		}
+		return newComponent(StatusOK, "")
+	}
+}
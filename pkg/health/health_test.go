@@ -0,0 +1,153 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/health"
+)
+
+type fakeStore struct {
+	err error
+}
+
+func (s fakeStore) CheckWritable(context.Context) error {
+	return s.err
+}
+
+func status(status string) health.Check {
+	return func(context.Context) health.Component {
+		return health.Component{Status: status}
+	}
+}
+
+var _ = Describe("Checker", func() {
+	It("is ok without checks, also when nil", func() {
+		overall, components := health.NewChecker().Run(context.Background())
+		Expect(overall).To(Equal(health.StatusOK))
+		Expect(components).To(BeEmpty())
+
+		var checker *health.Checker
+		overall, _ = checker.Run(context.Background())
+		Expect(overall).To(Equal(health.StatusOK))
+	})
+
+	It("reports each component and the worst status overall", func() {
+		checker := health.NewChecker()
+		checker.Add("a", status(health.StatusOK))
+		checker.Add("b", status(health.StatusDegraded))
+
+		overall, components := checker.Run(context.Background())
+		Expect(overall).To(Equal(health.StatusDegraded))
+		Expect(components).To(HaveLen(2))
+		Expect(components["b"].Status).To(Equal(health.StatusDegraded))
+
+		checker.Add("c", status(health.StatusDown))
+		overall, _ = checker.Run(context.Background())
+		Expect(overall).To(Equal(health.StatusDown))
+	})
+})
+
+var _ = Describe("StorageCheck", func() {
+	It("is ok when the store accepts writes", func() {
+		Expect(health.StorageCheck(fakeStore{})(context.Background()).Status).To(Equal(health.StatusOK))
+	})
+
+	It("is down when the store does not accept writes", func() {
+		component := health.StorageCheck(fakeStore{err: errors.New("disk full")})(context.Background())
+		Expect(component.Status).To(Equal(health.StatusDown))
+		Expect(component.Message).To(ContainSubstring("disk full"))
+	})
+
+	It("assumes stores that cannot check are writable", func() {
+		Expect(health.StorageCheck(struct{}{})(context.Background()).Status).To(Equal(health.StatusOK))
+	})
+})
+
+var _ = Describe("EventsCheck", func() {
+	It("is ok while the broker is open and down once it is closed", func() {
+		broker := events.NewBroker()
+		_, unsubscribe := broker.Subscribe(events.Filter{})
+		defer unsubscribe()
+
+		component := health.EventsCheck(broker)(context.Background())
+		Expect(component.Status).To(Equal(health.StatusOK))
+		Expect(component.Message).To(Equal("1 subscribers"))
+
+		broker.Close()
+		Expect(health.EventsCheck(broker)(context.Background()).Status).To(Equal(health.StatusDown))
+	})
+})
+
+var _ = Describe("QueueCheck", func() {
+	DescribeTable("the status for a queue depth",
+		func(queued, capacity int, want string) {
+			check := health.QueueCheck(func() (int, int) { return queued, capacity })
+			Expect(check(context.Background()).Status).To(Equal(want))
+		},
+		Entry("empty", 0, 100, health.StatusOK),
+		Entry("below the threshold", 79, 100, health.StatusOK),
+		Entry("at the threshold", 80, 100, health.StatusDegraded),
+		Entry("full", 100, 100, health.StatusDown),
+		Entry("unbuffered", 0, 0, health.StatusOK),
+	)
+})
+
+var _ = Describe("DrainCheck", func() {
+	It("is down once the server drains", func() {
+		var draining atomic.Bool
+		check := health.DrainCheck(&draining)
+		Expect(check(context.Background()).Status).To(Equal(health.StatusOK))
+
+		draining.Store(true)
+		component := check(context.Background())
+		Expect(component.Status).To(Equal(health.StatusDown))
+		Expect(component.Message).To(Equal("draining"))
+	})
+})
+
+var _ = Describe("Prober", func() {
+	It("reports providers as not probed yet before the first probe", func() {
+		prober := health.NewProber(map[string]string{"openai": "http://127.0.0.1:1"})
+		component := prober.Check("openai")(context.Background())
+		Expect(component.Status).To(Equal(health.StatusOK))
+		Expect(component.Message).To(Equal("not probed yet"))
+	})
+
+	It("reports reachable providers ok, whatever their answer, and unreachable ones degraded", func() {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer upstream.Close()
+
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		prober := health.NewProber(map[string]string{"up": upstream.URL, "gone": closed.URL})
+		checker := health.NewChecker()
+		prober.AddChecks(checker)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go prober.Run(ctx, time.Hour, zap.NewNop())
+
+		Eventually(func() string {
+			return prober.Check("gone")(context.Background()).Status
+		}).Should(Equal(health.StatusDegraded))
+
+		overall, components := checker.Run(context.Background())
+		Expect(overall).To(Equal(health.StatusDegraded))
+		Expect(components["provider:up"].Status).To(Equal(health.StatusOK))
+		Expect(components["provider:up"].Message).To(HavePrefix("reachable"))
+		Expect(components["provider:gone"].Message).To(HavePrefix("unreachable"))
+	})
+})
@@ -0,0 +1,110 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// probeTimeout bounds each reachability probe.
+const probeTimeout = 5 * time.Second
+
+// Prober probes upstream providers in the background, so that health checks
+// report their reachability without waiting on the network. A provider is
+// reachable when its upstream answers an HTTP request with any status:
+// probes send no credentials, so errors such as 401 are expected.
+type Prober struct {
+	upstreams map[string]string
+	client    *http.Client
+
+	mu      sync.Mutex
+	results map[string]Component
+}
+
+// NewProber returns a Prober for upstream URLs by provider name.
+func NewProber(upstreams map[string]string) *Prober {
+	return &Prober{
+		upstreams: upstreams,
+		client:    &http.Client{Timeout: probeTimeout},
+		results:   map[string]Component{},
+	}
+}
+
+// Run probes every upstream, then again every interval, until ctx is done.
+// Providers that become unreachable, or reachable again, are logged.
+func (p *Prober) Run(ctx context.Context, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for name, upstream := range p.upstreams {
+			component := p.probe(ctx, upstream)
+			if ctx.Err() != nil {
+				return
+			}
+
+			p.mu.Lock()
+			previous, seen := p.results[name]
+			p.results[name] = component
+			p.mu.Unlock()
+
+			switch {
+			case component.Status != StatusOK && (!seen || previous.Status == StatusOK):
+				logger.Warn("upstream provider unreachable",
+					zap.String("provider", name),
+					zap.String("upstream", upstream),
+					zap.String("error", component.Message),
+				)
+			case component.Status == StatusOK && seen && previous.Status != StatusOK:
+				logger.Info("upstream provider reachable again", zap.String("provider", name))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe sends a request to upstream and reports whether it answered.
+func (p *Prober) probe(ctx context.Context, upstream string) Component {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, upstream, nil)
+	if err != nil {
+		return newComponent(StatusDegraded, fmt.Sprintf("invalid upstream: %v", err))
+	}
+
+	start := time.Now()
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return newComponent(StatusDegraded, fmt.Sprintf("unreachable: %v", err))
+	}
+	_ = resp.Body.Close()
+	return newComponent(StatusOK, fmt.Sprintf("reachable in %s", time.Since(start).Round(time.Millisecond)))
+}
+
+// Check reports the last probe of the named provider. An unreachable
+// provider degrades the server rather than taking it down: it still
+// records the turns of other providers.
+func (p *Prober) Check(name string) Check {
+	return func(context.Context) Component {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if component, probed := p.results[name]; probed {
+			return component
+		}
+		return newComponent(StatusOK, "not probed yet")
+	}
+}
+
+// AddChecks adds a check to c for each provider, named "provider:<name>".
+func (p *Prober) AddChecks(c *Checker) {
+	for name := range p.upstreams {
+		c.Add("provider:"+name, p.Check(name))
+	}
+}
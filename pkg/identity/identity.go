@@ -0,0 +1,33 @@
+// Package identity determines who captured turns are attributed to when
+// several people share one tapes server.
+package identity
+
+import (
+	"os"
+	"os/user"
+)
+
+// Unknown is the user reported when no identity can be determined.
+const Unknown = "unknown"
+
+// User returns the name of the OS user running tapes, falling back to the
+// USER or USERNAME environment variables and then to Unknown.
+func User() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	for _, env := range []string{"USER", "USERNAME"} {
+		if name := os.Getenv(env); name != "" {
+			return name
+		}
+	}
+	return Unknown
+}
+
+// Resolve returns configured when it is set, and the OS user otherwise.
+func Resolve(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	return User()
+}
@@ -0,0 +1,19 @@
+package identity_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/identity"
+)
+
+var _ = Describe("Resolve", func() {
+	It("prefers the configured user", func() {
+		Expect(identity.Resolve("alice")).To(Equal("alice"))
+	})
+
+	It("falls back to the OS user", func() {
+		Expect(identity.Resolve("")).To(Equal(identity.User()))
+		Expect(identity.User()).NotTo(BeEmpty())
+	})
+})
@@ -0,0 +1,324 @@
+// Package integrity checks that a tapes store still matches the Merkle DAG it
+// was built from: every node hashes to its ID, every parent link resolves,
+// and every node reaches a root. It can also check that every node carries a
+// valid signature of its hash, proving which installation stored it.
+package integrity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/share"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	entdriver "github.com/papercomputeco/tapes/pkg/storage/ent/driver"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/facet"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/rawcapture"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/sessiontool"
+)
+
+// deleteBatchSize bounds the number of IDs in a single IN clause.
+const deleteBatchSize = 500
+
+// IssueKind classifies a problem found by Verify.
+type IssueKind string
+
+const (
+	// IssueHashMismatch marks a node whose content or parent link no longer
+	// hashes to its ID.
+	IssueHashMismatch IssueKind = "hash_mismatch"
+
+	// IssueMissingParent marks a node whose parent is not in the store.
+	IssueMissingParent IssueKind = "missing_parent"
+
+	// IssueUnreachable marks a node whose ancestry loops instead of ending
+	// at a root.
+	IssueUnreachable IssueKind = "unreachable"
+
+	// IssueTainted marks an intact node with a corrupted node in its
+	// ancestry, so the session it belongs to can no longer be trusted.
+	IssueTainted IssueKind = "tainted"
+
+	// IssueUnsigned marks an intact node without a signature, found when
+	// signatures are checked.
+	IssueUnsigned IssueKind = "unsigned"
+
+	// IssueBadSignature marks an intact node whose signature does not
+	// verify against its signer key and the hash, user, run ID and creation
+	// time it signs.
+	IssueBadSignature IssueKind = "bad_signature"
+)
+
+// Issue is a single node that failed verification.
+type Issue struct {
+	Hash   string    `json:"hash"`
+	Kind   IssueKind `json:"kind"`
+	Detail string    `json:"detail,omitempty"`
+
+	// Node is the node as read from the store.
+	Node *merkle.Node `json:"node"`
+}
+
+// Report summarizes a Verify run.
+type Report struct {
+	// Nodes is the number of nodes checked.
+	Nodes int
+
+	// Roots is the number of root nodes found.
+	Roots int
+
+	// Issues lists failing nodes, corrupted nodes first, then the intact
+	// nodes they taint, and then the signature failures of the remaining
+	// nodes, each ordered by hash.
+	Issues []Issue
+
+	// Signers counts the nodes with a valid signature by the fingerprint of
+	// their signer key, when signatures were checked.
+	Signers map[string]int
+}
+
+// OK reports whether the store passed verification.
+func (r *Report) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// Count returns the number of issues of the given kind.
+func (r *Report) Count(kind IssueKind) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// Verify walks every node in driver, recomputing its hash and validating its
+// ancestry. Descendants of failing nodes are reported as tainted.
+func Verify(ctx context.Context, driver storage.Driver) (*Report, error) {
+	return verify(ctx, driver, false)
+}
+
+// VerifySigned verifies driver like Verify and additionally checks that every
+// node that passes carries a valid signature of its hash, user, run ID and
+// creation time, so that none of them was rewritten. A signature vouches for its own
+// node only, so signature failures do not taint descendants.
+func VerifySigned(ctx context.Context, driver storage.Driver) (*Report, error) {
+	return verify(ctx, driver, true)
+}
+
+func verify(ctx context.Context, driver storage.Driver, signed bool) (*Report, error) {
+	nodes, err := driver.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	byHash := make(map[string]*merkle.Node, len(nodes))
+	children := make(map[string][]string)
+	for _, n := range nodes {
+		byHash[n.Hash] = n
+		if n.ParentHash != nil {
+			children[*n.ParentHash] = append(children[*n.ParentHash], n.Hash)
+		}
+	}
+
+	report := &Report{Nodes: len(nodes)}
+	failed := make(map[string]bool)
+	reach := &rootReachability{byHash: byHash, known: make(map[string]bool)}
+
+	var corrupt []Issue
+	for _, n := range nodes {
+		if n.ParentHash == nil {
+			report.Roots++
+		}
+
+		switch {
+		case !n.Verify():
+			corrupt = append(corrupt, Issue{Hash: n.Hash, Kind: IssueHashMismatch, Node: n})
+		case n.ParentHash != nil && byHash[*n.ParentHash] == nil:
+			corrupt = append(corrupt, Issue{
+				Hash:   n.Hash,
+				Kind:   IssueMissingParent,
+				Detail: "parent " + *n.ParentHash + " not found",
+				Node:   n,
+			})
+		case !reach.reaches(n):
+			corrupt = append(corrupt, Issue{Hash: n.Hash, Kind: IssueUnreachable, Detail: "ancestry contains a cycle", Node: n})
+		default:
+			continue
+		}
+		failed[n.Hash] = true
+	}
+	sortIssues(corrupt)
+	report.Issues = corrupt
+
+	// Everything below a failing node inherits the failure.
+	var tainted []Issue
+	for _, issue := range corrupt {
+		stack := append([]string(nil), children[issue.Hash]...)
+		for len(stack) > 0 {
+			hash := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if failed[hash] {
+				continue
+			}
+			failed[hash] = true
+			tainted = append(tainted, Issue{
+				Hash:   hash,
+				Kind:   IssueTainted,
+				Detail: "descends from " + issue.Hash,
+				Node:   byHash[hash],
+			})
+			stack = append(stack, children[hash]...)
+		}
+	}
+	sortIssues(tainted)
+	report.Issues = append(report.Issues, tainted...)
+
+	if signed {
+		report.Signers = make(map[string]int)
+		var unsigned []Issue
+		for _, n := range nodes {
+			switch {
+			case failed[n.Hash]:
+			case !n.Signed():
+				unsigned = append(unsigned, Issue{Hash: n.Hash, Kind: IssueUnsigned, Node: n})
+			case !n.VerifySignature():
+				unsigned = append(unsigned, Issue{Hash: n.Hash, Kind: IssueBadSignature, Detail: "signature does not match signer key and signed fields", Node: n})
+			default:
+				report.Signers[share.Fingerprint(n.SignerKey)]++
+			}
+		}
+		sortIssues(unsigned)
+		report.Issues = append(report.Issues, unsigned...)
+	}
+
+	return report, nil
+}
+
+// Quarantine writes every node in report to w as JSON lines and then deletes
+// those nodes, along with any facets, raw captures, session tool rows and
+// search index entries keyed by them, in a single transaction. Blob and media
+// files the nodes referenced are deleted afterwards unless a surviving node
+// still references them.
+// Because tainted descendants are included in the report, no surviving node
+// is left with a missing parent.
+func Quarantine(ctx context.Context, client *ent.Client, report *Report, w io.Writer) (int, error) {
+	if len(report.Issues) == 0 {
+		return 0, nil
+	}
+
+	enc := json.NewEncoder(w)
+	ids := make([]string, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		if err := enc.Encode(issue); err != nil {
+			return 0, fmt.Errorf("failed to write quarantined node: %w", err)
+		}
+		ids = append(ids, issue.Hash)
+	}
+
+	files, err := entdriver.OffloadedFiles(ctx, client, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	deleted := 0
+	for start := 0; start < len(ids); start += deleteBatchSize {
+		batch := ids[start:min(start+deleteBatchSize, len(ids))]
+
+		if _, err := tx.Facet.Delete().Where(facet.SessionIDIn(batch...)).Exec(ctx); err != nil {
+			return 0, rollback(tx, fmt.Errorf("failed to delete facets: %w", err))
+		}
+
+		if _, err := tx.Session.Delete().Where(session.IDIn(batch...)).Exec(ctx); err != nil {
+			return 0, rollback(tx, fmt.Errorf("failed to delete sessions: %w", err))
+		}
+
+		if _, err := tx.RawCapture.Delete().Where(rawcapture.NodeHashIn(batch...)).Exec(ctx); err != nil {
+			return 0, rollback(tx, fmt.Errorf("failed to delete raw captures: %w", err))
+		}
+
+		if _, err := tx.SessionTool.Delete().Where(sessiontool.NodeHashIn(batch...)).Exec(ctx); err != nil {
+			return 0, rollback(tx, fmt.Errorf("failed to delete session tools: %w", err))
+		}
+
+		n, err := tx.Node.Delete().Where(node.IDIn(batch...)).Exec(ctx)
+		if err != nil {
+			return 0, rollback(tx, fmt.Errorf("failed to delete nodes: %w", err))
+		}
+		deleted += n
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit quarantine: %w", err)
+	}
+
+	if _, err := entdriver.RemoveUnreferencedFiles(ctx, client, files); err != nil {
+		return deleted, err
+	}
+	return deleted, nil
+}
+
+// rootReachability memoizes whether each node's ancestry ends at a root.
+type rootReachability struct {
+	byHash map[string]*merkle.Node
+	known  map[string]bool
+}
+
+// reaches follows parent links from n and reports whether they end at a
+// root rather than looping. Missing parents count as reaching a root since
+// they are reported separately.
+func (r *rootReachability) reaches(n *merkle.Node) bool {
+	var path []string
+	onPath := make(map[string]bool)
+
+	result := true
+	for cur := n; ; {
+		if ok, seen := r.known[cur.Hash]; seen {
+			result = ok
+			break
+		}
+		if onPath[cur.Hash] {
+			result = false
+			break
+		}
+		path = append(path, cur.Hash)
+		onPath[cur.Hash] = true
+
+		if cur.ParentHash == nil {
+			break
+		}
+		parent, ok := r.byHash[*cur.ParentHash]
+		if !ok {
+			break
+		}
+		cur = parent
+	}
+
+	for _, hash := range path {
+		r.known[hash] = result
+	}
+	return result
+}
+
+func sortIssues(issues []Issue) {
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Hash < issues[j].Hash })
+}
+
+func rollback(tx *ent.Tx, err error) error {
+	if rerr := tx.Rollback(); rerr != nil {
+		return fmt.Errorf("%w: rollback failed: %w", err, rerr)
+	}
+	return err
+}
@@ -0,0 +1,230 @@
+package integrity_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/integrity"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/share"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+func testBucket(text string) merkle.Bucket {
+	return merkle.Bucket{
+		Type:     "message",
+		Role:     "user",
+		Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+		Model:    "test-model",
+		Provider: "test-provider",
+	}
+}
+
+var _ = Describe("Verify", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+		driver *sqlite.Driver
+
+		root, middle, leaf, other *merkle.Node
+	)
+
+	// exec runs a statement on a separate connection with foreign keys off,
+	// simulating out-of-band edits to the database file.
+	exec := func(query string, args ...any) {
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+		_, err = db.ExecContext(ctx, query, args...)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		var err error
+		driver, err = sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		root = merkle.NewNode(testBucket("root"), nil)
+		middle = merkle.NewNode(testBucket("middle"), root)
+		leaf = merkle.NewNode(testBucket("leaf"), middle)
+		other = merkle.NewNode(testBucket("other"), nil)
+		for _, n := range []*merkle.Node{root, middle, leaf, other} {
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	AfterEach(func() {
+		Expect(driver.Close()).To(Succeed())
+	})
+
+	It("passes an untouched store", func() {
+		report, err := integrity.Verify(ctx, driver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.OK()).To(BeTrue())
+		Expect(report.Nodes).To(Equal(4))
+		Expect(report.Roots).To(Equal(2))
+	})
+
+	It("detects tampered content and taints descendants", func() {
+		bucket := strings.Replace(mustBucketJSON(middle), "middle", "edited", 1)
+		exec("UPDATE nodes SET bucket = ? WHERE hash = ?", bucket, middle.Hash)
+
+		report, err := integrity.Verify(ctx, driver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.OK()).To(BeFalse())
+		Expect(report.Issues).To(HaveLen(2))
+		Expect(report.Issues[0].Hash).To(Equal(middle.Hash))
+		Expect(report.Issues[0].Kind).To(Equal(integrity.IssueHashMismatch))
+		Expect(report.Issues[1].Hash).To(Equal(leaf.Hash))
+		Expect(report.Issues[1].Kind).To(Equal(integrity.IssueTainted))
+	})
+
+	It("detects missing parents", func() {
+		exec("DELETE FROM nodes WHERE hash = ?", root.Hash)
+
+		report, err := integrity.Verify(ctx, driver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Count(integrity.IssueMissingParent)).To(Equal(1))
+		Expect(report.Count(integrity.IssueTainted)).To(Equal(1))
+	})
+
+	It("detects ancestry cycles", func() {
+		exec("UPDATE nodes SET parent_hash = ? WHERE hash = ?", leaf.Hash, root.Hash)
+
+		report, err := integrity.Verify(ctx, driver)
+		Expect(err).NotTo(HaveOccurred())
+		// The root's parent link changed, so it also fails its hash check.
+		Expect(report.Count(integrity.IssueHashMismatch)).To(Equal(1))
+		Expect(report.Count(integrity.IssueUnreachable)).To(Equal(2))
+	})
+
+	It("requires valid signatures when verifying signed", func() {
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		signed := merkle.NewNode(testBucket("signed"), nil)
+		signed.Sign(key)
+		_, err = driver.Put(ctx, signed)
+		Expect(err).NotTo(HaveOccurred())
+
+		report, err := integrity.VerifySigned(ctx, driver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Count(integrity.IssueUnsigned)).To(Equal(4))
+		Expect(report.Signers).To(Equal(map[string]int{share.Fingerprint(pub): 1}))
+
+		exec("UPDATE nodes SET signature = ? WHERE hash = ?", make([]byte, ed25519.SignatureSize), signed.Hash)
+
+		report, err = integrity.VerifySigned(ctx, driver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Count(integrity.IssueBadSignature)).To(Equal(1))
+		Expect(report.Signers).To(BeEmpty())
+	})
+
+	It("rejects signed nodes whose user, run or time was rewritten", func() {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		signed := merkle.NewNode(testBucket("signed"), nil, merkle.NodeMeta{User: "alice", RunID: "run-1"})
+		signed.Sign(key)
+		_, err = driver.Put(ctx, signed)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, stmt := range []string{
+			`UPDATE nodes SET "user" = 'mallory' WHERE hash = ?`,
+			`UPDATE nodes SET run_id = 'run-2' WHERE hash = ?`,
+			`UPDATE nodes SET created_at = '2020-01-01 00:00:00+00:00' WHERE hash = ?`,
+		} {
+			exec(`UPDATE nodes SET "user" = 'alice', run_id = 'run-1', created_at = ? WHERE hash = ?`, signed.CreatedAt, signed.Hash)
+			report, err := integrity.VerifySigned(ctx, driver)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Count(integrity.IssueBadSignature)).To(Equal(0), stmt)
+
+			exec(stmt, signed.Hash)
+			report, err = integrity.VerifySigned(ctx, driver)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Count(integrity.IssueBadSignature)).To(Equal(1), stmt)
+		}
+	})
+
+	It("ignores signatures unless verifying signed", func() {
+		report, err := integrity.Verify(ctx, driver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.OK()).To(BeTrue())
+		Expect(report.Signers).To(BeNil())
+	})
+
+	It("quarantines failing nodes without orphaning survivors", func() {
+		bucket := strings.Replace(mustBucketJSON(middle), "middle", "edited", 1)
+		exec("UPDATE nodes SET bucket = ? WHERE hash = ?", bucket, middle.Hash)
+
+		for _, hash := range []string{leaf.Hash, root.Hash} {
+			_, err := driver.Client.RawCapture.Create().
+				SetNodeHash(hash).
+				SetProvider("anthropic").
+				SetRequest([]byte("{}")).
+				Save(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = driver.Client.SessionTool.Create().
+				SetSessionID("session").
+				SetToolHash(hash).
+				SetNodeHash(hash).
+				Save(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		report, err := integrity.Verify(ctx, driver)
+		Expect(err).NotTo(HaveOccurred())
+
+		buf := &bytes.Buffer{}
+		n, err := integrity.Quarantine(ctx, driver.Client, report, buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(Equal(2))
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+		var entry integrity.Issue
+		Expect(json.Unmarshal([]byte(lines[0]), &entry)).To(Succeed())
+		Expect(entry.Hash).To(Equal(middle.Hash))
+		Expect(entry.Node.Bucket.Content[0].Text).To(Equal("edited"))
+
+		after, err := integrity.Verify(ctx, driver)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(after.OK()).To(BeTrue())
+		Expect(after.Nodes).To(Equal(2))
+
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+		var indexed int
+		Expect(db.QueryRowContext(ctx, "SELECT COUNT(*) FROM node_search WHERE hash IN (?, ?)", middle.Hash, leaf.Hash).Scan(&indexed)).To(Succeed())
+		Expect(indexed).To(BeZero())
+
+		captures, err := driver.Client.RawCapture.Query().All(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(captures).To(HaveLen(1))
+		Expect(captures[0].NodeHash).To(Equal(root.Hash))
+
+		tools, err := driver.Client.SessionTool.Query().All(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tools).To(HaveLen(1))
+		Expect(tools[0].NodeHash).To(Equal(root.Hash))
+	})
+})
+
+func mustBucketJSON(n *merkle.Node) string {
+	data, err := json.Marshal(n.Bucket)
+	Expect(err).NotTo(HaveOccurred())
+	return string(data)
+}
@@ -6,3 +6,24 @@ package llm
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
+
+// UpstreamError describes an error response from the upstream provider. It is
+// stored in place of the response when a turn fails.
+type UpstreamError struct {
+	// Status is the HTTP status code of the response.
+	Status int `json:"status"`
+
+	// Type is the provider's error type or code, such as "rate_limit_error".
+	Type string `json:"type,omitempty"`
+
+	// Message is the provider's error message.
+	Message string `json:"message,omitempty"`
+
+	// RetryAfterSeconds is how long the provider asked clients to wait
+	// before retrying, from the Retry-After header.
+	RetryAfterSeconds int `json:"retry_after_seconds,omitempty"`
+
+	// Attempt numbers the attempt, starting at 1, when the proxy retries
+	// failed requests. It is zero when retries are disabled.
+	Attempt int `json:"attempt,omitempty"`
+}
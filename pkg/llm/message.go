@@ -13,15 +13,26 @@ type Message struct {
 // ContentBlock represents a single piece of content within a message.
 // The Type field determines which other fields are populated.
 type ContentBlock struct {
-	Type string `json:"type"` // "text", "image", "tool_use", "tool_result"
+	Type string `json:"type"` // "text", "thinking", "redacted_thinking", "image", "tool_use", "tool_result", "truncated"
 
 	// Text content (type="text")
 	Text string `json:"text,omitempty"`
 
-	// Image content (type="image")
-	ImageURL    string `json:"image_url,omitempty"`    // URL to image
-	ImageBase64 string `json:"image_base64,omitempty"` // Base64-encoded image data
+	// Reasoning content (type="thinking") - the model's extended thinking and
+	// the provider's signature over it. Redacted thinking
+	// (type="redacted_thinking") keeps only its encrypted payload in Signature.
+	Thinking  string `json:"thinking,omitempty"`
+	Signature string `json:"signature,omitempty"`
+
+	// Image and file content (type="image", "document", or "file")
+	ImageURL    string `json:"image_url,omitempty"`    // URL to image or file
+	ImageBase64 string `json:"image_base64,omitempty"` // Base64-encoded image or file data
 	MediaType   string `json:"media_type,omitempty"`   // MIME type (e.g., "image/png")
+	FileName    string `json:"file_name,omitempty"`    // Name of an attached file
+
+	// Media reference - set when the image or file was stored in the media
+	// store; ContentHash and OriginalBytes describe the stored bytes
+	MediaURI string `json:"media_uri,omitempty"`
 
 	// Tool use (type="tool_use") - assistant requesting tool execution
 	ToolUseID string         `json:"tool_use_id,omitempty"`
@@ -32,6 +43,32 @@ type ContentBlock struct {
 	ToolResultID string `json:"tool_result_id,omitempty"` // References the tool_use_id
 	ToolOutput   string `json:"tool_output,omitempty"`
 	IsError      bool   `json:"is_error,omitempty"`
+
+	// Truncation marker (type="truncated") - follows a block whose content was
+	// cut to the capture limit. Offloaded blocks also record their original size
+	// and hash.
+	OriginalBytes int    `json:"original_bytes,omitempty"` // Size of the content before truncation, offloading, or media storage
+	ContentHash   string `json:"content_hash,omitempty"`   // SHA-256 of the original content
+
+	// Blob reference - set when the block's payload was offloaded to a blob
+	// store and removed from the node
+	BlobURI string `json:"blob_uri,omitempty"`
+}
+
+// Payload returns the field holding the block's main payload: the inline data
+// of image blocks, the output of tool_result blocks, the reasoning of thinking
+// blocks, and the text of all others. It is what blob stores offload.
+func (b *ContentBlock) Payload() *string {
+	switch b.Type {
+	case "image":
+		return &b.ImageBase64
+	case "tool_result":
+		return &b.ToolOutput
+	case "thinking":
+		return &b.Thinking
+	default:
+		return &b.Text
+	}
 }
 
 // NewTextMessage creates a simple text message with the given role and content.
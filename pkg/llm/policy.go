@@ -0,0 +1,17 @@
+package llm
+
+// PolicyViolation records a guardrail rule that a turn broke. It is stored on
+// the turn's response node, or on the error node of a blocked turn.
+type PolicyViolation struct {
+	// Rule is the name of the rule that was broken.
+	Rule string `json:"rule"`
+
+	// Action is what the proxy did about it: "annotate" or "block".
+	Action string `json:"action"`
+
+	// Tool is the name of the tool call that broke the rule.
+	Tool string `json:"tool,omitempty"`
+
+	// Message describes the violation.
+	Message string `json:"message"`
+}
@@ -31,7 +31,7 @@ func (p *Provider) ParseRequest(payload []byte) (*llm.ChatRequest, error) {
 		return nil, err
 	}
 
-	system := parseAnthropicSystem(req.System)
+	system := parseAnthropicText(req.System)
 	messages := make([]llm.Message, 0, len(req.Messages))
 	for _, msg := range req.Messages {
 		converted := llm.Message{Role: msg.Role}
@@ -50,14 +50,37 @@ func (p *Provider) ParseRequest(payload []byte) (*llm.ChatRequest, error) {
 					if text, ok := block["text"].(string); ok {
 						cb.Text = text
 					}
+
+					// Extended thinking, echoed back on later turns
+					if thinking, ok := block["thinking"].(string); ok {
+						cb.Thinking = thinking
+					}
+					if signature, ok := block["signature"].(string); ok {
+						cb.Signature = signature
+					}
+					if data, ok := block["data"].(string); ok && cb.Type == "redacted_thinking" {
+						cb.Signature = data
+					}
+
+					// Image and document sources
 					if source, ok := block["source"].(map[string]any); ok {
 						if mt, ok := source["media_type"].(string); ok {
 							cb.MediaType = mt
 						}
 						if data, ok := source["data"].(string); ok {
-							cb.ImageBase64 = data
+							if source["type"] == "text" {
+								cb.Text = data
+							} else {
+								cb.ImageBase64 = data
+							}
+						}
+						if url, ok := source["url"].(string); ok {
+							cb.ImageURL = url
 						}
 					}
+					if title, ok := block["title"].(string); ok && cb.Type == "document" {
+						cb.FileName = title
+					}
 
 					// Tool use
 					if id, ok := block["id"].(string); ok {
@@ -69,6 +92,17 @@ func (p *Provider) ParseRequest(payload []byte) (*llm.ChatRequest, error) {
 					if input, ok := block["input"].(map[string]any); ok {
 						cb.ToolInput = input
 					}
+
+					// Tool result
+					if id, ok := block["tool_use_id"].(string); ok {
+						cb.ToolResultID = id
+					}
+					if isError, ok := block["is_error"].(bool); ok {
+						cb.IsError = isError
+					}
+					if cb.Type == "tool_result" {
+						cb.ToolOutput = parseAnthropicText(block["content"])
+					}
 					converted.Content = append(converted.Content, cb)
 				}
 			}
@@ -87,18 +121,74 @@ func (p *Provider) ParseRequest(payload []byte) (*llm.ChatRequest, error) {
 		TopK:        req.TopK,
 		Stop:        req.Stop,
 		Stream:      req.Stream,
+		Tools:       parseAnthropicTools(req.Tools),
 		RawRequest:  payload,
 	}
+	if req.Metadata != nil {
+		result.SessionID = sessionID(req.Metadata.UserID)
+	}
 
 	return result, nil
 }
 
-func parseAnthropicSystem(system any) string {
-	if system == nil {
+// parseAnthropicTools converts the tools of a request into tool definitions.
+// Client tools keep their input schema. Server and Anthropic-defined tools,
+// such as web_search or bash, have a versioned type and keep their
+// configuration as the schema.
+func parseAnthropicTools(tools []map[string]any) []llm.ToolDefinition {
+	var defs []llm.ToolDefinition
+	for _, tool := range tools {
+		def := llm.ToolDefinition{}
+		def.Type, _ = tool["type"].(string)
+		def.Name, _ = tool["name"].(string)
+		def.Description, _ = tool["description"].(string)
+
+		if schema, ok := tool["input_schema"].(map[string]any); ok {
+			def.Schema = schema
+		} else {
+			for key, value := range tool {
+				if key == "type" || key == "name" || key == "description" {
+					continue
+				}
+				if def.Schema == nil {
+					def.Schema = make(map[string]any)
+				}
+				def.Schema[key] = value
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs
+}
+
+// sessionID extracts the agent's session ID from a metadata user ID, or
+// returns "" when it carries none.
+func sessionID(userID string) string {
+	if strings.HasPrefix(userID, "{") {
+		var id struct {
+			SessionID string `json:"session_id"`
+		}
+		if err := json.Unmarshal([]byte(userID), &id); err != nil {
+			return ""
+		}
+		return id.SessionID
+	}
+
+	const marker = "_session_"
+	if i := strings.LastIndex(userID, marker); i >= 0 {
+		return userID[i+len(marker):]
+	}
+	return ""
+}
+
+// parseAnthropicText flattens a system prompt or tool result, which is either
+// a string or a list of content blocks, to its text.
+func parseAnthropicText(content any) string {
+	if content == nil {
 		return ""
 	}
 
-	switch value := system.(type) {
+	switch value := content.(type) {
 	case string:
 		return value
 	case []any:
@@ -136,6 +226,11 @@ func (p *Provider) ParseResponse(payload []byte) (*llm.ChatResponse, error) {
 		switch block.Type {
 		case "text":
 			cb.Text = block.Text
+		case "thinking":
+			cb.Thinking = block.Thinking
+			cb.Signature = block.Signature
+		case "redacted_thinking":
+			cb.Signature = block.Data
 		case "tool_use":
 			cb.ToolUseID = block.ID
 			cb.ToolName = block.Name
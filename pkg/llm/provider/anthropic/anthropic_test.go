@@ -4,6 +4,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/papercomputeco/tapes/pkg/llm"
 	"github.com/papercomputeco/tapes/pkg/llm/provider"
 	"github.com/papercomputeco/tapes/pkg/llm/provider/anthropic"
 )
@@ -93,6 +94,34 @@ var _ = Describe("Anthropic Provider", func() {
 				Expect(req.Messages[0].Content[1].MediaType).To(Equal("image/png"))
 				Expect(req.Messages[0].Content[1].ImageBase64).To(Equal("iVBORw0KGgo..."))
 			})
+
+			It("parses document and URL image content blocks", func() {
+				payload := []byte(`{
+					"model": "claude-sonnet-4-20250514",
+					"max_tokens": 1024,
+					"messages": [
+						{
+							"role": "user",
+							"content": [
+								{"type": "document", "title": "spec.pdf", "source": {"type": "base64", "media_type": "application/pdf", "data": "JVBERi0="}},
+								{"type": "document", "source": {"type": "text", "media_type": "text/plain", "data": "plain notes"}},
+								{"type": "image", "source": {"type": "url", "url": "https://example.com/cat.png"}}
+							]
+						}
+					]
+				}`)
+
+				req, err := p.ParseRequest(payload)
+				Expect(err).NotTo(HaveOccurred())
+				content := req.Messages[0].Content
+				Expect(content).To(HaveLen(3))
+				Expect(content[0].Type).To(Equal("document"))
+				Expect(content[0].FileName).To(Equal("spec.pdf"))
+				Expect(content[0].ImageBase64).To(Equal("JVBERi0="))
+				Expect(content[1].Text).To(Equal("plain notes"))
+				Expect(content[1].ImageBase64).To(BeEmpty())
+				Expect(content[2].ImageURL).To(Equal("https://example.com/cat.png"))
+			})
 		})
 
 		Context("with system prompt", func() {
@@ -198,6 +227,76 @@ var _ = Describe("Anthropic Provider", func() {
 				Expect(req.Messages[0].Content[0].ToolName).To(Equal("get_weather"))
 				Expect(req.Messages[0].Content[0].ToolInput).To(HaveKeyWithValue("location", "San Francisco"))
 			})
+
+			It("parses tool_result content blocks", func() {
+				payload := []byte(`{
+					"model": "claude-3-sonnet-20240229",
+					"max_tokens": 1024,
+					"messages": [
+						{
+							"role": "user",
+							"content": [
+								{
+									"type": "tool_result",
+									"tool_use_id": "toolu_123",
+									"is_error": true,
+									"content": "location not found"
+								}
+							]
+						}
+					]
+				}`)
+
+				req, err := p.ParseRequest(payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(req.Messages[0].Content).To(HaveLen(1))
+				Expect(req.Messages[0].Content[0].Type).To(Equal("tool_result"))
+				Expect(req.Messages[0].Content[0].ToolResultID).To(Equal("toolu_123"))
+				Expect(req.Messages[0].Content[0].IsError).To(BeTrue())
+				Expect(req.Messages[0].Content[0].ToolOutput).To(Equal("location not found"))
+			})
+		})
+
+		Context("with tools", func() {
+			It("parses client tools and server tools", func() {
+				payload := []byte(`{"model": "claude-sonnet-4-5", "max_tokens": 1024, "messages": [],
+					"tools": [
+						{"name": "get_weather", "description": "Get the weather", "input_schema": {"type": "object"}},
+						{"type": "web_search_20250305", "name": "web_search", "max_uses": 5}
+					]}`)
+				req, err := p.ParseRequest(payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(req.Tools).To(Equal([]llm.ToolDefinition{
+					{Name: "get_weather", Description: "Get the weather", Schema: map[string]any{"type": "object"}},
+					{Type: "web_search_20250305", Name: "web_search", Schema: map[string]any{"max_uses": float64(5)}},
+				}))
+			})
+		})
+
+		Context("with metadata", func() {
+			It("extracts the session ID from a marked user ID", func() {
+				payload := []byte(`{"model": "claude-sonnet-4-5", "max_tokens": 1024, "messages": [],
+					"metadata": {"user_id": "user_ab12_account_3f1c_session_9e0d-41a2"}}`)
+				req, err := p.ParseRequest(payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(req.SessionID).To(Equal("9e0d-41a2"))
+			})
+
+			It("extracts the session ID from a JSON user ID", func() {
+				payload := []byte(`{"model": "claude-sonnet-4-5", "max_tokens": 1024, "messages": [],
+					"metadata": {"user_id": "{\"device_id\":\"ab12\",\"session_id\":\"9e0d-41a2\"}"}}`)
+				req, err := p.ParseRequest(payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(req.SessionID).To(Equal("9e0d-41a2"))
+			})
+
+			It("leaves the session ID empty for other user IDs", func() {
+				payload := []byte(`{"model": "claude-sonnet-4-5", "max_tokens": 1024, "messages": [],
+					"metadata": {"user_id": "alice"}}`)
+				req, err := p.ParseRequest(payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(req.SessionID).To(BeEmpty())
+			})
 		})
 
 		Context("with invalid payload", func() {
@@ -300,6 +399,33 @@ var _ = Describe("Anthropic Provider", func() {
 			})
 		})
 
+		Context("with extended thinking", func() {
+			It("parses thinking and redacted_thinking content blocks", func() {
+				payload := []byte(`{
+					"id": "msg_123",
+					"type": "message",
+					"role": "assistant",
+					"content": [
+						{"type": "thinking", "thinking": "The user wants 6 times 7.", "signature": "sig_abc"},
+						{"type": "redacted_thinking", "data": "encrypted"},
+						{"type": "text", "text": "42"}
+					],
+					"model": "claude-sonnet-4-20250514",
+					"stop_reason": "end_turn"
+				}`)
+
+				resp, err := p.ParseResponse(payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Message.Content).To(HaveLen(3))
+				Expect(resp.Message.Content[0].Type).To(Equal("thinking"))
+				Expect(resp.Message.Content[0].Thinking).To(Equal("The user wants 6 times 7."))
+				Expect(resp.Message.Content[0].Signature).To(Equal("sig_abc"))
+				Expect(resp.Message.Content[1].Type).To(Equal("redacted_thinking"))
+				Expect(resp.Message.Content[1].Signature).To(Equal("encrypted"))
+				Expect(resp.Message.GetText()).To(Equal("42"))
+			})
+		})
+
 		Context("with Extra fields", func() {
 			It("stores id and type in Extra", func() {
 				payload := []byte(`{
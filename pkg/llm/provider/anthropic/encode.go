@@ -0,0 +1,176 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// defaultMaxTokens is the max_tokens of encoded requests that do not set
+// one, since the Messages API requires it.
+const defaultMaxTokens = 4096
+
+// EncodeRequest writes req as a Messages API request. System messages are
+// moved into the system prompt, tool messages are sent as user tool results,
+// and consecutive messages of the same role are merged, since the Messages
+// API expects user and assistant turns to alternate.
+func (p *Provider) EncodeRequest(req *llm.ChatRequest) ([]byte, error) {
+	out := map[string]any{
+		"model":      req.Model,
+		"max_tokens": defaultMaxTokens,
+	}
+	if system := req.SystemPrompt(); system != "" {
+		out["system"] = system
+	}
+
+	messages := []map[string]any{}
+	for _, msg := range req.Messages {
+		if msg.Role == "system" {
+			continue
+		}
+		role := "user"
+		if msg.Role == "assistant" {
+			role = "assistant"
+		}
+		blocks := encodeBlocks(msg.Content)
+		if len(blocks) == 0 {
+			continue
+		}
+		if last := len(messages) - 1; last >= 0 && messages[last]["role"] == role {
+			messages[last]["content"] = append(messages[last]["content"].([]map[string]any), blocks...)
+			continue
+		}
+		messages = append(messages, map[string]any{"role": role, "content": blocks})
+	}
+	out["messages"] = messages
+
+	if tools := encodeTools(req.Tools); len(tools) > 0 {
+		out["tools"] = tools
+	}
+	if req.MaxTokens != nil && *req.MaxTokens > 0 {
+		out["max_tokens"] = *req.MaxTokens
+	}
+	if req.Temperature != nil {
+		out["temperature"] = *req.Temperature
+	}
+	if req.TopP != nil {
+		out["top_p"] = *req.TopP
+	}
+	if req.TopK != nil {
+		out["top_k"] = *req.TopK
+	}
+	if len(req.Stop) > 0 {
+		out["stop_sequences"] = req.Stop
+	}
+	if req.Stream != nil {
+		out["stream"] = *req.Stream
+	}
+
+	return json.Marshal(out)
+}
+
+// encodeBlocks converts content blocks into Messages API content blocks,
+// leaving out media whose content was not captured.
+func encodeBlocks(content []llm.ContentBlock) []map[string]any {
+	var blocks []map[string]any
+	for _, block := range content {
+		switch block.Type {
+		case "text":
+			blocks = append(blocks, map[string]any{"type": "text", "text": block.Text})
+		case "thinking":
+			blocks = append(blocks, map[string]any{"type": "thinking", "thinking": block.Thinking, "signature": block.Signature})
+		case "redacted_thinking":
+			blocks = append(blocks, map[string]any{"type": "redacted_thinking", "data": block.Signature})
+		case "image", "document", "file":
+			if encoded := encodeMedia(block); encoded != nil {
+				blocks = append(blocks, encoded)
+			}
+		case "tool_use":
+			input := block.ToolInput
+			if input == nil {
+				input = map[string]any{}
+			}
+			blocks = append(blocks, map[string]any{
+				"type":  "tool_use",
+				"id":    block.ToolUseID,
+				"name":  block.ToolName,
+				"input": input,
+			})
+		case "tool_result":
+			result := map[string]any{
+				"type":        "tool_result",
+				"tool_use_id": block.ToolResultID,
+				"content":     block.ToolOutput,
+			}
+			if block.IsError {
+				result["is_error"] = true
+			}
+			blocks = append(blocks, result)
+		}
+	}
+	return blocks
+}
+
+// encodeMedia converts an image or document block, whose content is either
+// base64 data, a data URL, or a URL, into a Messages API block.
+func encodeMedia(block llm.ContentBlock) map[string]any {
+	blockType := "image"
+	if block.Type != "image" {
+		blockType = "document"
+	}
+
+	var source map[string]any
+	switch {
+	case block.ImageBase64 != "":
+		source = map[string]any{"type": "base64", "media_type": block.MediaType, "data": block.ImageBase64}
+	case strings.HasPrefix(block.ImageURL, "data:"):
+		meta, data, ok := strings.Cut(strings.TrimPrefix(block.ImageURL, "data:"), ",")
+		mediaType, base64 := strings.CutSuffix(meta, ";base64")
+		if !ok || !base64 {
+			return nil
+		}
+		source = map[string]any{"type": "base64", "media_type": mediaType, "data": data}
+	case block.ImageURL != "":
+		source = map[string]any{"type": "url", "url": block.ImageURL}
+	default:
+		return nil
+	}
+
+	encoded := map[string]any{"type": blockType, "source": source}
+	if blockType == "document" && block.FileName != "" {
+		encoded["title"] = block.FileName
+	}
+	return encoded
+}
+
+// encodeTools converts tool definitions into Messages API tools. Client
+// tools get an input schema; Anthropic-defined tools keep their versioned
+// type, such as bash_20250124, and configuration. Tools defined by other
+// providers are dropped.
+func encodeTools(defs []llm.ToolDefinition) []map[string]any {
+	tools := make([]map[string]any, 0, len(defs))
+	for _, def := range defs {
+		tool := map[string]any{"name": def.Name}
+		switch {
+		case def.Type == "" || def.Type == "custom" || def.Type == "function":
+			if def.Description != "" {
+				tool["description"] = def.Description
+			}
+			schema := def.Schema
+			if schema["type"] != "object" {
+				schema = map[string]any{"type": "object"}
+			}
+			tool["input_schema"] = schema
+		case strings.Contains(def.Type, "_20"):
+			tool["type"] = def.Type
+			for key, value := range def.Schema {
+				tool[key] = value
+			}
+		default:
+			continue
+		}
+		tools = append(tools, tool)
+	}
+	return tools
+}
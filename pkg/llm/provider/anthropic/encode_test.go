@@ -0,0 +1,95 @@
+package anthropic_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider/anthropic"
+)
+
+var _ = Describe("EncodeRequest", func() {
+	p := anthropic.New()
+
+	It("writes a conversation recorded from OpenAI as a Messages API request", func() {
+		body, err := p.EncodeRequest(&llm.ChatRequest{
+			Model: "claude-sonnet-4-5",
+			Messages: []llm.Message{
+				{Role: "system", Content: []llm.ContentBlock{{Type: "text", Text: "You are a coding agent."}}},
+				{Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "List the files."}}},
+				{Role: "assistant", Content: []llm.ContentBlock{
+					{Type: "tool_use", ToolUseID: "call_1", ToolName: "bash", ToolInput: map[string]any{"cmd": "ls"}},
+					{Type: "tool_use", ToolUseID: "call_2", ToolName: "bash"},
+				}},
+				{Role: "tool", Content: []llm.ContentBlock{{Type: "tool_result", ToolResultID: "call_1", ToolOutput: "main.go"}}},
+				{Role: "tool", Content: []llm.ContentBlock{{Type: "tool_result", ToolResultID: "call_2", ToolOutput: "denied", IsError: true}}},
+			},
+			Tools: []llm.ToolDefinition{
+				{Type: "function", Name: "bash", Description: "Run a command", Schema: map[string]any{"type": "object"}},
+				{Type: "bash_20250124", Name: "shell"},
+				{Type: "web_search", Name: "web_search"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(MatchJSON(`{
+			"model": "claude-sonnet-4-5",
+			"max_tokens": 4096,
+			"system": "You are a coding agent.",
+			"messages": [
+				{"role": "user", "content": [{"type": "text", "text": "List the files."}]},
+				{"role": "assistant", "content": [
+					{"type": "tool_use", "id": "call_1", "name": "bash", "input": {"cmd": "ls"}},
+					{"type": "tool_use", "id": "call_2", "name": "bash", "input": {}}
+				]},
+				{"role": "user", "content": [
+					{"type": "tool_result", "tool_use_id": "call_1", "content": "main.go"},
+					{"type": "tool_result", "tool_use_id": "call_2", "content": "denied", "is_error": true}
+				]}
+			],
+			"tools": [
+				{"name": "bash", "description": "Run a command", "input_schema": {"type": "object"}},
+				{"type": "bash_20250124", "name": "shell"}
+			]
+		}`))
+	})
+
+	It("round-trips through ParseRequest", func() {
+		maxTokens := 1024
+		req := &llm.ChatRequest{
+			Model:     "claude-sonnet-4-5",
+			System:    "Be brief.",
+			MaxTokens: &maxTokens,
+			Messages: []llm.Message{
+				{Role: "user", Content: []llm.ContentBlock{
+					{Type: "text", Text: "What is this?"},
+					{Type: "image", MediaType: "image/png", ImageBase64: "iVBORw0KGgo="},
+				}},
+				{Role: "assistant", Content: []llm.ContentBlock{
+					{Type: "thinking", Thinking: "A tiny image.", Signature: "sig"},
+					{Type: "text", Text: "A PNG."},
+				}},
+			},
+		}
+		body, err := p.EncodeRequest(req)
+		Expect(err).NotTo(HaveOccurred())
+
+		parsed, err := p.ParseRequest(body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.System).To(Equal(req.System))
+		Expect(*parsed.MaxTokens).To(Equal(maxTokens))
+		Expect(parsed.Messages).To(Equal(req.Messages))
+	})
+
+	It("converts image data URLs into base64 sources", func() {
+		body, err := p.EncodeRequest(&llm.ChatRequest{
+			Model: "claude-sonnet-4-5",
+			Messages: []llm.Message{{Role: "user", Content: []llm.ContentBlock{
+				{Type: "image", ImageURL: "data:image/png;base64,iVBORw0KGgo="},
+				{Type: "image", MediaURI: "media://stored"},
+			}}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(ContainSubstring(`"source":{"data":"iVBORw0KGgo=","media_type":"image/png","type":"base64"}`))
+		Expect(body).NotTo(ContainSubstring("media://"))
+	})
+})
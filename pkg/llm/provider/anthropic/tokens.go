@@ -0,0 +1,117 @@
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// CountTokensPath is the Messages API's token counting endpoint. Counting is
+// free and does not create a message.
+const CountTokensPath = "/v1/messages/count_tokens"
+
+// countTokensFields are the Messages API request fields the counting endpoint
+// accepts. Generation parameters such as max_tokens are rejected by it.
+var countTokensFields = []string{"model", "messages", "system", "tools", "tool_choice", "thinking", "mcp_servers"}
+
+// TokenCountEndpoint reports whether path is the token counting endpoint.
+func (p *Provider) TokenCountEndpoint(path string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(path, "/"), "/messages/count_tokens")
+}
+
+// CountTokensRequest reduces a Messages API request to the body accepted by
+// the token counting endpoint.
+func CountTokensRequest(payload []byte) ([]byte, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, fmt.Errorf("parsing request: %w", err)
+	}
+
+	count := make(map[string]json.RawMessage, len(countTokensFields))
+	for _, name := range countTokensFields {
+		if value, ok := fields[name]; ok {
+			count[name] = value
+		}
+	}
+	return json.Marshal(count)
+}
+
+// ParseTokenCount reads the input token count from a token counting response.
+func (p *Provider) ParseTokenCount(payload []byte) (int, error) {
+	return parseTokenCount(payload)
+}
+
+func parseTokenCount(payload []byte) (int, error) {
+	var resp struct {
+		InputTokens *int `json:"input_tokens"`
+	}
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		return 0, fmt.Errorf("parsing token count: %w", err)
+	}
+	if resp.InputTokens == nil {
+		return 0, errors.New("parsing token count: input_tokens missing")
+	}
+	return *resp.InputTokens, nil
+}
+
+// TokenCounter counts tokens exactly with the token counting endpoint,
+// sending the credentials and API version of the request being counted.
+type TokenCounter struct {
+	// BaseURL is the API base, e.g. https://api.anthropic.com.
+	BaseURL string
+
+	// Header is sent with each count, and carries the x-api-key or
+	// Authorization credentials and the anthropic-version header.
+	Header http.Header
+
+	// Client sends the requests (defaults to a client with a 30s timeout).
+	Client *http.Client
+}
+
+// CountTokens implements llm.TokenCounter. It requires the request's raw
+// payload, which providers keep in ChatRequest.RawRequest.
+func (t *TokenCounter) CountTokens(ctx context.Context, req *llm.ChatRequest) (int, error) {
+	if len(req.RawRequest) == 0 {
+		return 0, errors.New("counting tokens: request has no raw payload")
+	}
+	body, err := CountTokensRequest(req.RawRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(t.BaseURL, "/")+CountTokensPath, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("counting tokens: %w", err)
+	}
+	for name, values := range t.Header {
+		httpReq.Header[name] = values
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	client := t.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("counting tokens: %w", err)
+	}
+	defer resp.Body.Close()
+
+	payload, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("counting tokens: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("counting tokens: upstream returned %s", resp.Status)
+	}
+	return parseTokenCount(payload)
+}
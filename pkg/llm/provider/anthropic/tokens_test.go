@@ -0,0 +1,71 @@
+package anthropic_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider/anthropic"
+)
+
+var _ = Describe("Token counting", func() {
+	It("recognizes the token counting endpoint", func() {
+		p := anthropic.New()
+		Expect(p.TokenCountEndpoint("/v1/messages/count_tokens")).To(BeTrue())
+		Expect(p.TokenCountEndpoint("/v1/messages")).To(BeFalse())
+	})
+
+	It("keeps only the fields the counting endpoint accepts", func() {
+		body, err := anthropic.CountTokensRequest([]byte(`{"model":"claude-sonnet-4-5","max_tokens":1024,"stream":true,"system":"Be brief.","messages":[{"role":"user","content":"hi"}]}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		var fields map[string]any
+		Expect(json.Unmarshal(body, &fields)).To(Succeed())
+		Expect(fields).To(HaveKey("model"))
+		Expect(fields).To(HaveKey("system"))
+		Expect(fields).To(HaveKey("messages"))
+		Expect(fields).NotTo(HaveKey("max_tokens"))
+		Expect(fields).NotTo(HaveKey("stream"))
+	})
+
+	It("parses the input token count", func() {
+		tokens, err := anthropic.New().ParseTokenCount([]byte(`{"input_tokens":2095}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokens).To(Equal(2095))
+
+		_, err = anthropic.New().ParseTokenCount([]byte(`{}`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("counts a request with the upstream endpoint and its credentials", func() {
+		var received map[string]any
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal(anthropic.CountTokensPath))
+			Expect(r.Header.Get("X-Api-Key")).To(Equal("sk-test"))
+			body, _ := io.ReadAll(r.Body)
+			Expect(json.Unmarshal(body, &received)).To(Succeed())
+			w.Write([]byte(`{"input_tokens":42}`))
+		}))
+		defer upstream.Close()
+
+		req, err := anthropic.New().ParseRequest([]byte(`{"model":"claude-sonnet-4-5","max_tokens":1024,"messages":[{"role":"user","content":"hi"}]}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		counter := &anthropic.TokenCounter{BaseURL: upstream.URL, Header: http.Header{"X-Api-Key": {"sk-test"}}}
+		tokens, err := counter.CountTokens(GinkgoT().Context(), req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokens).To(Equal(42))
+		Expect(received).NotTo(HaveKey("max_tokens"))
+	})
+
+	It("requires the raw request", func() {
+		counter := &anthropic.TokenCounter{BaseURL: "http://localhost:0"}
+		_, err := counter.CountTokens(GinkgoT().Context(), &llm.ChatRequest{Model: "claude-sonnet-4-5"})
+		Expect(err).To(MatchError(ContainSubstring("no raw payload")))
+	})
+})
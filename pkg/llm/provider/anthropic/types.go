@@ -11,6 +11,14 @@ type anthropicRequest struct {
 	TopK        *int               `json:"top_k,omitempty"`
 	Stop        []string           `json:"stop_sequences,omitempty"`
 	Stream      *bool              `json:"stream,omitempty"`
+	Metadata    *anthropicMetadata `json:"metadata,omitempty"`
+	Tools       []map[string]any   `json:"tools,omitempty"`
+}
+
+// anthropicMetadata describes the request. Claude Code puts its session ID in
+// user_id, either after a "_session_" marker or as JSON with a session_id.
+type anthropicMetadata struct {
+	UserID string `json:"user_id,omitempty"`
 }
 
 // anthropicMessage represents a message in Anthropic's format.
@@ -23,12 +31,15 @@ type anthropicMessage struct {
 
 // anthropicContentBlock represents a content block in Anthropic's format.
 type anthropicContentBlock struct {
-	Type   string           `json:"type"`
-	Text   string           `json:"text,omitempty"`
-	Source *anthropicSource `json:"source,omitempty"`
-	ID     string           `json:"id,omitempty"`
-	Name   string           `json:"name,omitempty"`
-	Input  map[string]any   `json:"input,omitempty"`
+	Type      string           `json:"type"`
+	Text      string           `json:"text,omitempty"`
+	Thinking  string           `json:"thinking,omitempty"`
+	Signature string           `json:"signature,omitempty"`
+	Data      string           `json:"data,omitempty"`
+	Source    *anthropicSource `json:"source,omitempty"`
+	ID        string           `json:"id,omitempty"`
+	Name      string           `json:"name,omitempty"`
+	Input     map[string]any   `json:"input,omitempty"`
 }
 
 type anthropicSource struct {
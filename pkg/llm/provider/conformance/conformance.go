@@ -0,0 +1,311 @@
+// Package conformance checks that providers normalize their API payloads
+// into llm structures consistently, so new providers can be added and
+// upstream API drift caught without hand-written assertions per field.
+//
+// A fixture is a directory testdata/<provider>/<case> holding a real request
+// or response payload (request.json, response.json) alongside the golden
+// normalized structure it must parse to (request.golden.json,
+// response.golden.json). Equivalence cases, under testdata/equivalence/<case>,
+// hold the same turn in several providers' formats (<provider>.request.json,
+// <provider>.response.json) that must normalize to equivalent structures.
+package conformance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+)
+
+// Files of a fixture.
+const (
+	RequestFile        = "request.json"
+	ResponseFile       = "response.json"
+	GoldenRequestFile  = "request.golden.json"
+	GoldenResponseFile = "response.golden.json"
+)
+
+// EquivalenceDir is the testdata directory holding equivalence cases.
+const EquivalenceDir = "equivalence"
+
+// Fixture is a provider payload and its expected normalization.
+type Fixture struct {
+	Provider string
+	Name     string
+	Dir      string
+}
+
+// Load returns the fixtures under root, ordered by provider and name.
+func Load(root string) ([]Fixture, error) {
+	providers, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("reading fixtures: %w", err)
+	}
+
+	var fixtures []Fixture
+	for _, p := range providers {
+		if !p.IsDir() || p.Name() == EquivalenceDir {
+			continue
+		}
+		cases, err := os.ReadDir(filepath.Join(root, p.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading fixtures: %w", err)
+		}
+		for _, c := range cases {
+			if c.IsDir() {
+				fixtures = append(fixtures, Fixture{Provider: p.Name(), Name: c.Name(), Dir: filepath.Join(root, p.Name(), c.Name())})
+			}
+		}
+	}
+	return fixtures, nil
+}
+
+// Result is the canonical JSON of a fixture's parsed request and response.
+// Either is nil when the fixture has no such payload.
+type Result struct {
+	Request  []byte
+	Response []byte
+}
+
+// Run parses a fixture's payloads with its provider and returns their
+// canonical normalized form.
+func Run(f Fixture) (*Result, error) {
+	prov, err := provider.New(f.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	if payload, ok, err := readOptional(filepath.Join(f.Dir, RequestFile)); err != nil {
+		return nil, err
+	} else if ok {
+		req, err := prov.ParseRequest(payload)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", RequestFile, err)
+		}
+		if result.Request, err = marshal(CanonicalRequest(req)); err != nil {
+			return nil, err
+		}
+	}
+	if payload, ok, err := readOptional(filepath.Join(f.Dir, ResponseFile)); err != nil {
+		return nil, err
+	} else if ok {
+		resp, err := prov.ParseResponse(payload)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", ResponseFile, err)
+		}
+		if result.Response, err = marshal(CanonicalResponse(resp)); err != nil {
+			return nil, err
+		}
+	}
+	if result.Request == nil && result.Response == nil {
+		return nil, fmt.Errorf("fixture %s/%s has neither %s nor %s", f.Provider, f.Name, RequestFile, ResponseFile)
+	}
+	return result, nil
+}
+
+// Golden returns a fixture's golden request and response, nil when absent.
+func Golden(f Fixture) (*Result, error) {
+	req, _, err := readOptional(filepath.Join(f.Dir, GoldenRequestFile))
+	if err != nil {
+		return nil, err
+	}
+	resp, _, err := readOptional(filepath.Join(f.Dir, GoldenResponseFile))
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Request: req, Response: resp}, nil
+}
+
+// WriteGolden records result as the fixture's golden normalization.
+func WriteGolden(f Fixture, result *Result) error {
+	for name, data := range map[string][]byte{GoldenRequestFile: result.Request, GoldenResponseFile: result.Response} {
+		if data == nil {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(f.Dir, name), data, 0o644); err != nil { //nolint:gosec // fixtures are not secret
+			return fmt.Errorf("writing golden: %w", err)
+		}
+	}
+	return nil
+}
+
+// CanonicalRequest returns req without the raw payload, which golden files
+// would only duplicate.
+func CanonicalRequest(req *llm.ChatRequest) *llm.ChatRequest {
+	c := *req
+	c.RawRequest = nil
+	return &c
+}
+
+// CanonicalResponse returns resp without the raw payload and with the time
+// zeroed when the provider does not report one and the parser substitutes
+// the current time.
+func CanonicalResponse(resp *llm.ChatResponse) *llm.ChatResponse {
+	c := *resp
+	c.RawResponse = nil
+	if time.Since(c.CreatedAt) < time.Minute {
+		c.CreatedAt = time.Time{}
+	}
+	return &c
+}
+
+// EquivalentRequest reduces a request to what every provider can express, so
+// that one turn in different providers' formats compares equal: the system
+// prompt becomes a leading system message, tool results are carried by tool
+// messages, and provider-specific extras, tool call IDs (which Ollama lacks),
+// and generation parameters are dropped.
+func EquivalentRequest(req *llm.ChatRequest) *llm.ChatRequest {
+	c := &llm.ChatRequest{Model: req.Model}
+	if req.System != "" {
+		c.Messages = append(c.Messages, llm.NewTextMessage("system", req.System))
+	}
+	for _, msg := range req.Messages {
+		c.Messages = append(c.Messages, equivalentMessage(msg))
+	}
+	return c
+}
+
+// EquivalentResponse reduces a response to its model, message, and token
+// counts. Stop reasons are provider vocabulary and are not compared.
+func EquivalentResponse(resp *llm.ChatResponse) *llm.ChatResponse {
+	c := &llm.ChatResponse{Model: resp.Model, Message: equivalentMessage(resp.Message), Done: resp.Done}
+	if resp.Usage != nil {
+		c.Usage = &llm.Usage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
+		}
+	}
+	return c
+}
+
+func equivalentMessage(msg llm.Message) llm.Message {
+	c := llm.Message{Role: msg.Role}
+	toolResults := len(msg.Content) > 0
+	for _, block := range msg.Content {
+		block.ToolUseID = ""
+		block.ToolResultID = ""
+		if msg.Role == "tool" && block.Type == "text" {
+			// Ollama's tool messages carry the result as plain text.
+			block = llm.ContentBlock{Type: "tool_result", ToolOutput: block.Text}
+		}
+		toolResults = toolResults && block.Type == "tool_result"
+		c.Content = append(c.Content, block)
+	}
+	if toolResults {
+		// Anthropic returns tool results in user messages.
+		c.Role = "tool"
+	}
+	return c
+}
+
+// Equivalence is one turn in several providers' formats.
+type Equivalence struct {
+	Name string
+	Dir  string
+
+	// Providers that have a payload for the case, sorted.
+	Providers []string
+}
+
+// LoadEquivalences returns the equivalence cases under root.
+func LoadEquivalences(root string) ([]Equivalence, error) {
+	dir := filepath.Join(root, EquivalenceDir)
+	cases, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading equivalences: %w", err)
+	}
+
+	var equivalences []Equivalence
+	for _, c := range cases {
+		if !c.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(dir, c.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading equivalences: %w", err)
+		}
+		seen := map[string]bool{}
+		for _, f := range files {
+			name, _, ok := strings.Cut(f.Name(), ".")
+			if ok {
+				seen[name] = true
+			}
+		}
+		e := Equivalence{Name: c.Name(), Dir: filepath.Join(dir, c.Name())}
+		for name := range seen {
+			e.Providers = append(e.Providers, name)
+		}
+		sort.Strings(e.Providers)
+		equivalences = append(equivalences, e)
+	}
+	return equivalences, nil
+}
+
+// RunEquivalence parses each provider's payloads for the case and returns
+// their equivalent normalized form keyed by provider.
+func RunEquivalence(e Equivalence) (map[string]*Result, error) {
+	results := make(map[string]*Result, len(e.Providers))
+	for _, name := range e.Providers {
+		prov, err := provider.New(name)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &Result{}
+		if payload, ok, err := readOptional(filepath.Join(e.Dir, name+"."+RequestFile)); err != nil {
+			return nil, err
+		} else if ok {
+			req, err := prov.ParseRequest(payload)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s request: %w", name, err)
+			}
+			if result.Request, err = marshal(EquivalentRequest(req)); err != nil {
+				return nil, err
+			}
+		}
+		if payload, ok, err := readOptional(filepath.Join(e.Dir, name+"."+ResponseFile)); err != nil {
+			return nil, err
+		} else if ok {
+			resp, err := prov.ParseResponse(payload)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s response: %w", name, err)
+			}
+			if result.Response, err = marshal(EquivalentResponse(resp)); err != nil {
+				return nil, err
+			}
+		}
+		results[name] = result
+	}
+	return results, nil
+}
+
+func readOptional(path string) ([]byte, bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+func marshal(v any) ([]byte, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
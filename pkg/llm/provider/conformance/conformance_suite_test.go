@@ -0,0 +1,19 @@
+package conformance_test
+
+import (
+	"flag"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// update rewrites the golden files from the current parsers:
+//
+//	go test ./pkg/llm/provider/conformance -update
+var update = flag.Bool("update", false, "rewrite golden files")
+
+func TestConformance(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Provider Conformance Suite")
+}
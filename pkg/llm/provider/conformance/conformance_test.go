@@ -0,0 +1,76 @@
+package conformance_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+	"github.com/papercomputeco/tapes/pkg/llm/provider/conformance"
+)
+
+const testdata = "testdata"
+
+var _ = Describe("Fixtures", func() {
+	fixtures, err := conformance.Load(testdata)
+	if err != nil {
+		panic(err)
+	}
+
+	It("covers every supported provider", func() {
+		covered := map[string]bool{}
+		for _, f := range fixtures {
+			covered[f.Provider] = true
+		}
+		for _, name := range provider.SupportedProviders() {
+			Expect(covered).To(HaveKey(name), "no fixtures for provider %s", name)
+		}
+	})
+
+	for _, f := range fixtures {
+		It("normalizes "+f.Provider+"/"+f.Name+" to its golden structures", func() {
+			result, err := conformance.Run(f)
+			Expect(err).NotTo(HaveOccurred())
+
+			if *update {
+				Expect(conformance.WriteGolden(f, result)).To(Succeed())
+			}
+
+			golden, err := conformance.Golden(f)
+			Expect(err).NotTo(HaveOccurred())
+			if result.Request != nil {
+				Expect(golden.Request).NotTo(BeNil(), "missing %s; run with -update", conformance.GoldenRequestFile)
+				Expect(result.Request).To(MatchJSON(golden.Request))
+			}
+			if result.Response != nil {
+				Expect(golden.Response).NotTo(BeNil(), "missing %s; run with -update", conformance.GoldenResponseFile)
+				Expect(result.Response).To(MatchJSON(golden.Response))
+			}
+		})
+	}
+})
+
+var _ = Describe("Equivalences", func() {
+	equivalences, err := conformance.LoadEquivalences(testdata)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, e := range equivalences {
+		It("normalizes "+e.Name+" alike across providers", func() {
+			Expect(len(e.Providers)).To(BeNumerically(">=", 2))
+
+			results, err := conformance.RunEquivalence(e)
+			Expect(err).NotTo(HaveOccurred())
+
+			first := results[e.Providers[0]]
+			for _, name := range e.Providers[1:] {
+				if first.Request != nil && results[name].Request != nil {
+					Expect(results[name].Request).To(MatchJSON(first.Request), "%s request differs from %s", name, e.Providers[0])
+				}
+				if first.Response != nil && results[name].Response != nil {
+					Expect(results[name].Response).To(MatchJSON(first.Response), "%s response differs from %s", name, e.Providers[0])
+				}
+			}
+		})
+	}
+})
@@ -65,6 +65,14 @@ func (o *Provider) ParseRequest(payload []byte) (*llm.ChatRequest, error) {
 		Stream:     req.Stream,
 		RawRequest: payload,
 	}
+	for _, tool := range req.Tools {
+		result.Tools = append(result.Tools, llm.ToolDefinition{
+			Type:        tool.Type,
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			Schema:      tool.Function.Parameters,
+		})
+	}
 
 	// Map options to common fields
 	if req.Options != nil {
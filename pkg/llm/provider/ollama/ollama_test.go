@@ -4,6 +4,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/papercomputeco/tapes/pkg/llm"
 	"github.com/papercomputeco/tapes/pkg/llm/provider"
 	"github.com/papercomputeco/tapes/pkg/llm/provider/ollama"
 )
@@ -216,6 +217,20 @@ var _ = Describe("Ollama Provider", func() {
 			})
 		})
 
+		Context("with tools", func() {
+			It("parses function tools", func() {
+				payload := []byte(`{"model": "llama3.1", "messages": [], "tools": [
+					{"type": "function", "function": {"name": "get_weather", "description": "Get the weather",
+						"parameters": {"type": "object"}}}
+				]}`)
+				req, err := p.ParseRequest(payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(req.Tools).To(Equal([]llm.ToolDefinition{
+					{Type: "function", Name: "get_weather", Description: "Get the weather", Schema: map[string]any{"type": "object"}},
+				}))
+			})
+		})
+
 		Context("preserves raw request", func() {
 			It("stores the original payload in RawRequest", func() {
 				payload := []byte(`{"model": "llama2", "messages": []}`)
@@ -11,6 +11,17 @@ type ollamaRequest struct {
 	Format    string          `json:"format,omitempty"`
 	KeepAlive string          `json:"keep_alive,omitempty"`
 	Options   *ollamaOptions  `json:"options,omitempty"`
+	Tools     []ollamaTool    `json:"tools,omitempty"`
+}
+
+// ollamaTool is a function offered to the model in a chat request.
+type ollamaTool struct {
+	Type     string `json:"type"`
+	Function struct {
+		Name        string         `json:"name"`
+		Description string         `json:"description,omitempty"`
+		Parameters  map[string]any `json:"parameters,omitempty"`
+	} `json:"function"`
 }
 
 type ollamaMessage struct {
@@ -0,0 +1,173 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// EncodeRequest writes req as a Chat Completions request. Tool results are
+// sent as tool messages, and content Chat Completions cannot carry, such as
+// reasoning and server tools, is left out.
+func (o *Provider) EncodeRequest(req *llm.ChatRequest) ([]byte, error) {
+	out := map[string]any{"model": req.Model}
+
+	var messages []map[string]any
+	if req.System != "" {
+		messages = append(messages, map[string]any{"role": "system", "content": req.System})
+	}
+	for _, msg := range req.Messages {
+		encoded, err := encodeMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, encoded...)
+	}
+	out["messages"] = messages
+
+	if tools := encodeTools(req.Tools); len(tools) > 0 {
+		out["tools"] = tools
+	}
+	if req.MaxTokens != nil {
+		out["max_tokens"] = *req.MaxTokens
+	}
+	if req.Temperature != nil {
+		out["temperature"] = *req.Temperature
+	}
+	if req.TopP != nil {
+		out["top_p"] = *req.TopP
+	}
+	if len(req.Stop) > 0 {
+		out["stop"] = req.Stop
+	}
+	if req.Seed != nil {
+		out["seed"] = *req.Seed
+	}
+	if req.Stream != nil {
+		out["stream"] = *req.Stream
+	}
+
+	return json.Marshal(out)
+}
+
+// encodeMessage converts msg into Chat Completions messages. Each tool result
+// becomes a tool message of its own, followed by the rest of the content.
+func encodeMessage(msg llm.Message) ([]map[string]any, error) {
+	var (
+		messages  []map[string]any
+		parts     []map[string]any
+		toolCalls []map[string]any
+	)
+	for _, block := range msg.Content {
+		switch block.Type {
+		case "text":
+			parts = append(parts, map[string]any{"type": "text", "text": block.Text})
+		case "image", "document", "file":
+			if part := encodeMedia(block); part != nil {
+				parts = append(parts, part)
+			}
+		case "tool_use":
+			args, err := json.Marshal(block.ToolInput)
+			if err != nil {
+				return nil, fmt.Errorf("encoding arguments of tool call %s: %w", block.ToolUseID, err)
+			}
+			if block.ToolInput == nil {
+				args = []byte("{}")
+			}
+			toolCalls = append(toolCalls, map[string]any{
+				"id":   block.ToolUseID,
+				"type": "function",
+				"function": map[string]any{
+					"name":      block.ToolName,
+					"arguments": string(args),
+				},
+			})
+		case "tool_result":
+			messages = append(messages, map[string]any{
+				"role":         "tool",
+				"tool_call_id": block.ToolResultID,
+				"content":      block.ToolOutput,
+			})
+		}
+	}
+
+	if len(parts) == 0 && len(toolCalls) == 0 {
+		return messages, nil
+	}
+	role := msg.Role
+	if role == "tool" {
+		role = "user"
+	}
+	encoded := map[string]any{"role": role, "content": encodeParts(parts)}
+	if len(toolCalls) > 0 {
+		encoded["tool_calls"] = toolCalls
+	}
+	return append(messages, encoded), nil
+}
+
+// encodeParts returns the content of a message: a plain string when it is
+// text only, and content parts otherwise.
+func encodeParts(parts []map[string]any) any {
+	if len(parts) == 0 {
+		return nil
+	}
+	texts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part["type"] != "text" {
+			return parts
+		}
+		texts = append(texts, part["text"].(string))
+	}
+	return strings.Join(texts, "\n")
+}
+
+// encodeMedia converts an image or file block into a content part, or returns
+// nil for media whose content was not captured.
+func encodeMedia(block llm.ContentBlock) map[string]any {
+	url := block.ImageURL
+	if block.ImageBase64 != "" {
+		url = "data:" + block.MediaType + ";base64," + block.ImageBase64
+	}
+	if url == "" {
+		return nil
+	}
+	if block.Type == "image" {
+		return map[string]any{"type": "image_url", "image_url": map[string]any{"url": url}}
+	}
+	file := map[string]any{"file_data": url}
+	if block.FileName != "" {
+		file["filename"] = block.FileName
+	}
+	return map[string]any{"type": "file", "file": file}
+}
+
+// encodeTools converts tool definitions into function and custom tools.
+// Provider-defined tools have no Chat Completions equivalent and are dropped.
+func encodeTools(defs []llm.ToolDefinition) []map[string]any {
+	var tools []map[string]any
+	for _, def := range defs {
+		switch {
+		case def.Type == "custom" && def.Schema["type"] != "object":
+			custom := map[string]any{"name": def.Name}
+			if def.Description != "" {
+				custom["description"] = def.Description
+			}
+			if def.Schema != nil {
+				custom["format"] = def.Schema
+			}
+			tools = append(tools, map[string]any{"type": "custom", "custom": custom})
+		case def.Type == "function" || def.Type == "custom" || def.Type == "":
+			function := map[string]any{"name": def.Name}
+			if def.Description != "" {
+				function["description"] = def.Description
+			}
+			if def.Schema != nil {
+				function["parameters"] = def.Schema
+			}
+			tools = append(tools, map[string]any{"type": "function", "function": function})
+		}
+	}
+	return tools
+}
@@ -0,0 +1,76 @@
+package openai_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider/openai"
+)
+
+var _ = Describe("EncodeRequest", func() {
+	p := openai.New()
+
+	It("writes a conversation with tool calls as a Chat Completions request", func() {
+		maxTokens := 256
+		body, err := p.EncodeRequest(&llm.ChatRequest{
+			Model:     "gpt-4o",
+			System:    "You are a coding agent.",
+			MaxTokens: &maxTokens,
+			Messages: []llm.Message{
+				{Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "List the files."}}},
+				{Role: "assistant", Content: []llm.ContentBlock{
+					{Type: "thinking", Thinking: "I should run ls."},
+					{Type: "tool_use", ToolUseID: "call_1", ToolName: "bash", ToolInput: map[string]any{"cmd": "ls"}},
+				}},
+				{Role: "user", Content: []llm.ContentBlock{
+					{Type: "tool_result", ToolResultID: "call_1", ToolOutput: "main.go"},
+					{Type: "text", Text: "Now read it."},
+				}},
+			},
+			Tools: []llm.ToolDefinition{
+				{Type: "function", Name: "bash", Schema: map[string]any{"type": "object"}},
+				{Type: "web_search", Name: "web_search"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(MatchJSON(`{
+			"model": "gpt-4o",
+			"max_tokens": 256,
+			"messages": [
+				{"role": "system", "content": "You are a coding agent."},
+				{"role": "user", "content": "List the files."},
+				{"role": "assistant", "content": null, "tool_calls": [
+					{"id": "call_1", "type": "function", "function": {"name": "bash", "arguments": "{\"cmd\":\"ls\"}"}}
+				]},
+				{"role": "tool", "tool_call_id": "call_1", "content": "main.go"},
+				{"role": "user", "content": "Now read it."}
+			],
+			"tools": [{"type": "function", "function": {"name": "bash", "parameters": {"type": "object"}}}]
+		}`))
+	})
+
+	It("round-trips through ParseRequest", func() {
+		req := &llm.ChatRequest{
+			Model: "gpt-4o",
+			Messages: []llm.Message{
+				{Role: "user", Content: []llm.ContentBlock{
+					{Type: "text", Text: "What is this?"},
+					{Type: "image", ImageURL: "https://example.com/cat.png"},
+				}},
+			},
+		}
+		body, err := p.EncodeRequest(req)
+		Expect(err).NotTo(HaveOccurred())
+
+		parsed, err := p.ParseRequest(body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.Messages).To(Equal(req.Messages))
+
+		var raw map[string]any
+		Expect(json.Unmarshal(body, &raw)).To(Succeed())
+		Expect(raw).NotTo(HaveKey("tools"))
+	})
+})
@@ -8,7 +8,8 @@ import (
 	"github.com/papercomputeco/tapes/pkg/llm"
 )
 
-// Provider implements the Provider interface for OpenAI's Chat Completions API.
+// Provider implements the Provider interface for OpenAI's Chat Completions
+// API. Responses API requests and responses are parsed too.
 type Provider struct{}
 
 func New() *Provider { return &Provider{} }
@@ -22,7 +23,12 @@ func (o *Provider) DefaultStreaming() bool {
 	return false
 }
 
+// ParseRequest parses a Chat Completions or Responses API request.
 func (o *Provider) ParseRequest(payload []byte) (*llm.ChatRequest, error) {
+	if isResponsesRequest(payload) {
+		return parseResponsesRequest(payload)
+	}
+
 	var req openaiRequest
 	if err := json.Unmarshal(payload, &req); err != nil {
 		return nil, err
@@ -52,6 +58,16 @@ func (o *Provider) ParseRequest(payload []byte) (*llm.ChatRequest, error) {
 							cb.ImageURL = url
 						}
 					}
+					if file, ok := part["file"].(map[string]any); ok {
+						// file_data is a data: URL; file_id references an
+						// uploaded file and has no content to capture.
+						if data, ok := file["file_data"].(string); ok {
+							cb.ImageURL = data
+						}
+						if name, ok := file["filename"].(string); ok {
+							cb.FileName = name
+						}
+					}
 					converted.Content = append(converted.Content, cb)
 				}
 			}
@@ -111,6 +127,7 @@ func (o *Provider) ParseRequest(payload []byte) (*llm.ChatRequest, error) {
 		Stop:        stop,
 		Seed:        req.Seed,
 		Stream:      req.Stream,
+		Tools:       parseOpenAITools(req.Tools, req.Functions),
 		RawRequest:  payload,
 	}
 
@@ -131,7 +148,46 @@ func (o *Provider) ParseRequest(payload []byte) (*llm.ChatRequest, error) {
 	return result, nil
 }
 
+// parseOpenAITools converts the tools of a Chat Completions request, and the
+// functions of requests that predate tools, into tool definitions.
+func parseOpenAITools(tools []openaiTool, functions []openaiFunction) []llm.ToolDefinition {
+	var defs []llm.ToolDefinition
+	for _, tool := range tools {
+		switch {
+		case tool.Function != nil:
+			defs = append(defs, llm.ToolDefinition{
+				Type:        tool.Type,
+				Name:        tool.Function.Name,
+				Description: tool.Function.Description,
+				Schema:      tool.Function.Parameters,
+			})
+		case tool.Custom != nil:
+			defs = append(defs, llm.ToolDefinition{
+				Type:        tool.Type,
+				Name:        tool.Custom.Name,
+				Description: tool.Custom.Description,
+				Schema:      tool.Custom.Format,
+			})
+		}
+	}
+	for _, fn := range functions {
+		defs = append(defs, llm.ToolDefinition{
+			Type:        "function",
+			Name:        fn.Name,
+			Description: fn.Description,
+			Schema:      fn.Parameters,
+		})
+	}
+	return defs
+}
+
+// ParseResponse parses a Chat Completions response, or a Responses API
+// response or the event ending a Responses API stream.
 func (o *Provider) ParseResponse(payload []byte) (*llm.ChatResponse, error) {
+	if responses, ok := responsesPayload(payload); ok {
+		return parseResponsesResponse(responses, payload)
+	}
+
 	var resp openaiResponse
 	if err := json.Unmarshal(payload, &resp); err != nil {
 		return nil, err
@@ -194,6 +250,9 @@ func (o *Provider) ParseResponse(payload []byte) (*llm.ChatResponse, error) {
 		if resp.Usage.PromptTokensDetails != nil {
 			usage.CacheReadInputTokens = resp.Usage.PromptTokensDetails.CachedTokens
 		}
+		if resp.Usage.CompletionTokensDetails != nil {
+			usage.ReasoningTokens = resp.Usage.CompletionTokensDetails.ReasoningTokens
+		}
 	}
 
 	result := &llm.ChatResponse{
@@ -4,6 +4,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/papercomputeco/tapes/pkg/llm"
 	"github.com/papercomputeco/tapes/pkg/llm/provider"
 	"github.com/papercomputeco/tapes/pkg/llm/provider/openai"
 )
@@ -171,6 +172,27 @@ var _ = Describe("OpenAI Provider", func() {
 				Expect(req.Messages[0].Content[1].Type).To(Equal("image"))
 				Expect(req.Messages[0].Content[1].ImageURL).To(Equal("https://example.com/image.png"))
 			})
+
+			It("parses file content", func() {
+				payload := []byte(`{
+					"model": "gpt-4o",
+					"messages": [
+						{
+							"role": "user",
+							"content": [
+								{"type": "file", "file": {"filename": "notes.pdf", "file_data": "data:application/pdf;base64,JVBERi0="}}
+							]
+						}
+					]
+				}`)
+
+				req, err := p.ParseRequest(payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(req.Messages[0].Content).To(HaveLen(1))
+				Expect(req.Messages[0].Content[0].Type).To(Equal("file"))
+				Expect(req.Messages[0].Content[0].FileName).To(Equal("notes.pdf"))
+				Expect(req.Messages[0].Content[0].ImageURL).To(Equal("data:application/pdf;base64,JVBERi0="))
+			})
 		})
 
 		Context("with tool calls", func() {
@@ -225,6 +247,32 @@ var _ = Describe("OpenAI Provider", func() {
 			})
 		})
 
+		Context("with tools", func() {
+			It("parses function tools and legacy functions", func() {
+				payload := []byte(`{
+					"model": "gpt-4",
+					"messages": [],
+					"tools": [
+						{"type": "function", "function": {"name": "get_weather", "description": "Get the weather",
+							"parameters": {"type": "object", "properties": {"location": {"type": "string"}}}}},
+						{"type": "custom", "custom": {"name": "apply_patch", "format": {"type": "text"}}}
+					],
+					"functions": [{"name": "lookup", "parameters": {"type": "object"}}]
+				}`)
+
+				req, err := p.ParseRequest(payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(req.Tools).To(Equal([]llm.ToolDefinition{
+					{Type: "function", Name: "get_weather", Description: "Get the weather", Schema: map[string]any{
+						"type":       "object",
+						"properties": map[string]any{"location": map[string]any{"type": "string"}},
+					}},
+					{Type: "custom", Name: "apply_patch", Schema: map[string]any{"type": "text"}},
+					{Type: "function", Name: "lookup", Schema: map[string]any{"type": "object"}},
+				}))
+			})
+		})
+
 		Context("preserves raw request", func() {
 			It("stores the original payload in RawRequest", func() {
 				payload := []byte(`{"model": "gpt-4", "messages": []}`)
@@ -300,6 +348,26 @@ var _ = Describe("OpenAI Provider", func() {
 				Expect(resp.Usage.CompletionTokens).To(Equal(50))
 				Expect(resp.Usage.TotalTokens).To(Equal(150))
 			})
+
+			It("parses reasoning tokens from o-series models", func() {
+				payload := []byte(`{
+					"id": "chatcmpl-123",
+					"object": "chat.completion",
+					"model": "o3-mini",
+					"choices": [{"index": 0, "message": {"role": "assistant", "content": "42"}, "finish_reason": "stop"}],
+					"usage": {
+						"prompt_tokens": 20,
+						"completion_tokens": 500,
+						"total_tokens": 520,
+						"completion_tokens_details": {"reasoning_tokens": 448}
+					}
+				}`)
+
+				resp, err := p.ParseResponse(payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp.Usage.CompletionTokens).To(Equal(500))
+				Expect(resp.Usage.ReasoningTokens).To(Equal(448))
+			})
 		})
 
 		Context("with tool calls in response", func() {
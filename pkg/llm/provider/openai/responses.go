@@ -0,0 +1,412 @@
+package openai
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// Tool names of the Responses API's built-in tool calls, which carry an
+// action rather than a function name.
+const (
+	webSearchToolName = "web_search"
+	computerToolName  = "computer"
+)
+
+// responsesResponse represents a Responses API response, returned whole or
+// carried by the response.completed event ending a stream.
+type responsesResponse struct {
+	ID                string `json:"id"`
+	Object            string `json:"object"`
+	CreatedAt         int64  `json:"created_at"`
+	Model             string `json:"model"`
+	Status            string `json:"status"`
+	IncompleteDetails *struct {
+		Reason string `json:"reason"`
+	} `json:"incomplete_details,omitempty"`
+	Output []responsesOutputItem `json:"output"`
+	Usage  *responsesUsage       `json:"usage,omitempty"`
+}
+
+// responsesOutputItem is one item of a Responses API output, or of a
+// request's input, which replays earlier output. Which fields are set depends
+// on Type.
+type responsesOutputItem struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+
+	// message
+	Role    string           `json:"role"`
+	Content responsesContent `json:"content"`
+
+	// function_call and computer_call
+	CallID    string `json:"call_id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+
+	// function_call_output, in requests: a string or content parts
+	Output json.RawMessage `json:"output"`
+
+	// web_search_call and computer_call
+	Action map[string]any `json:"action"`
+
+	// reasoning
+	Summary []struct {
+		Text string `json:"text"`
+	} `json:"summary"`
+	EncryptedContent string `json:"encrypted_content"`
+}
+
+// responsesContent is the content of a message item: a string, which is
+// read as a single input_text part, or an array of content parts.
+type responsesContent []responsesContentPart
+
+// responsesContentPart is one part of a message's content. Which fields are
+// set depends on Type.
+type responsesContentPart struct {
+	Type    string `json:"type"`
+	Text    string `json:"text"`
+	Refusal string `json:"refusal"`
+
+	// input_image and input_file
+	ImageURL string `json:"image_url"`
+	FileData string `json:"file_data"`
+	FileURL  string `json:"file_url"`
+	Filename string `json:"filename"`
+}
+
+func (c *responsesContent) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*c = responsesContent{{Type: "input_text", Text: text}}
+		return nil
+	}
+	var parts []responsesContentPart
+	if err := json.Unmarshal(data, &parts); err != nil {
+		return err
+	}
+	*c = parts
+	return nil
+}
+
+type responsesUsage struct {
+	InputTokens        int `json:"input_tokens"`
+	OutputTokens       int `json:"output_tokens"`
+	TotalTokens        int `json:"total_tokens"`
+	InputTokensDetails *struct {
+		CachedTokens int `json:"cached_tokens"`
+	} `json:"input_tokens_details,omitempty"`
+	OutputTokensDetails *struct {
+		ReasoningTokens int `json:"reasoning_tokens"`
+	} `json:"output_tokens_details,omitempty"`
+}
+
+// responsesRequest represents a Responses API request.
+type responsesRequest struct {
+	Model           string          `json:"model"`
+	Instructions    string          `json:"instructions"`
+	Input           json.RawMessage `json:"input"`
+	MaxOutputTokens *int            `json:"max_output_tokens,omitempty"`
+	Temperature     *float64        `json:"temperature,omitempty"`
+	TopP            *float64        `json:"top_p,omitempty"`
+	Stream          *bool           `json:"stream,omitempty"`
+
+	Tools []map[string]any `json:"tools,omitempty"`
+}
+
+// isResponsesRequest reports whether payload is a Responses API request,
+// which has an input in place of messages.
+func isResponsesRequest(payload []byte) bool {
+	var probe struct {
+		Input    json.RawMessage `json:"input"`
+		Messages json.RawMessage `json:"messages"`
+	}
+	return json.Unmarshal(payload, &probe) == nil && len(probe.Input) > 0 && len(probe.Messages) == 0
+}
+
+// parseResponsesRequest converts a Responses API request into a ChatRequest,
+// with its instructions as the system prompt.
+func parseResponsesRequest(payload []byte) (*llm.ChatRequest, error) {
+	var req responsesRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil, err
+	}
+	messages, err := parseResponsesInput(req.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &llm.ChatRequest{
+		Model:       req.Model,
+		Messages:    messages,
+		System:      req.Instructions,
+		MaxTokens:   req.MaxOutputTokens,
+		Temperature: req.Temperature,
+		TopP:        req.TopP,
+		Stream:      req.Stream,
+		Tools:       parseResponsesTools(req.Tools),
+		RawRequest:  payload,
+	}, nil
+}
+
+// parseResponsesTools converts the tools of a Responses API request into tool
+// definitions. Function tools keep their parameters and custom tools their
+// input format as the schema. Built-in tools, such as web_search, are named
+// after their type and keep their configuration as the schema.
+func parseResponsesTools(tools []map[string]any) []llm.ToolDefinition {
+	var defs []llm.ToolDefinition
+	for _, tool := range tools {
+		def := llm.ToolDefinition{}
+		def.Type, _ = tool["type"].(string)
+		def.Name, _ = tool["name"].(string)
+		def.Description, _ = tool["description"].(string)
+
+		switch def.Type {
+		case "function":
+			def.Schema, _ = tool["parameters"].(map[string]any)
+		case "custom":
+			def.Schema, _ = tool["format"].(map[string]any)
+		default:
+			if def.Name == "" {
+				def.Name = def.Type
+			}
+			for key, value := range tool {
+				if key == "type" || key == "name" || key == "description" {
+					continue
+				}
+				if def.Schema == nil {
+					def.Schema = make(map[string]any)
+				}
+				def.Schema[key] = value
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs
+}
+
+// parseResponsesInput converts the input of a Responses API request, a
+// string or an array of items, into messages. Runs of items the model
+// produced, such as assistant messages, reasoning, and function calls, become
+// one assistant message shaped like the response they came from. Function
+// and computer call outputs become tool messages.
+func parseResponsesInput(input json.RawMessage) ([]llm.Message, error) {
+	var text string
+	if err := json.Unmarshal(input, &text); err == nil {
+		return []llm.Message{llm.NewTextMessage("user", text)}, nil
+	}
+	var items []responsesOutputItem
+	if err := json.Unmarshal(input, &items); err != nil {
+		return nil, err
+	}
+
+	var (
+		messages  []llm.Message
+		assistant []responsesOutputItem
+	)
+	flush := func() {
+		if len(assistant) > 0 {
+			messages = append(messages, llm.Message{Role: "assistant", Content: parseResponsesOutput(assistant)})
+			assistant = nil
+		}
+	}
+
+	for _, item := range items {
+		switch {
+		case item.Type == "function_call_output", item.Type == "computer_call_output":
+			flush()
+			messages = append(messages, llm.Message{
+				Role: "tool",
+				Content: []llm.ContentBlock{{
+					Type:         "tool_result",
+					ToolResultID: item.CallID,
+					ToolOutput:   responsesToolOutput(item.Output),
+				}},
+			})
+		case item.Role != "" && item.Role != "assistant":
+			flush()
+			messages = append(messages, llm.Message{Role: item.Role, Content: parseResponsesInputContent(item.Content)})
+		default:
+			assistant = append(assistant, item)
+		}
+	}
+	flush()
+
+	return messages, nil
+}
+
+// parseResponsesInputContent converts the content of a user, system, or
+// developer message into content blocks.
+func parseResponsesInputContent(parts responsesContent) []llm.ContentBlock {
+	content := []llm.ContentBlock{}
+	for _, part := range parts {
+		switch part.Type {
+		case "input_text", "output_text":
+			content = append(content, llm.ContentBlock{Type: "text", Text: part.Text})
+		case "input_image":
+			// A file_id references an uploaded file and has no content to
+			// capture.
+			content = append(content, llm.ContentBlock{Type: "image", ImageURL: part.ImageURL})
+		case "input_file":
+			url := part.FileData
+			if url == "" {
+				url = part.FileURL
+			}
+			content = append(content, llm.ContentBlock{Type: "file", ImageURL: url, FileName: part.Filename})
+		}
+	}
+	return content
+}
+
+// responsesToolOutput returns the output of a function call: a string, or
+// the text of its content parts.
+func responsesToolOutput(output json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(output, &text); err == nil {
+		return text
+	}
+	var parts responsesContent
+	if err := json.Unmarshal(output, &parts); err != nil {
+		return string(output)
+	}
+	texts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// responsesPayload returns the Responses API response in payload, which is
+// either a response object or an event carrying one, and reports whether
+// there is one.
+func responsesPayload(payload []byte) ([]byte, bool) {
+	var envelope struct {
+		Object   string          `json:"object"`
+		Type     string          `json:"type"`
+		Response json.RawMessage `json:"response"`
+	}
+	if err := json.Unmarshal(payload, &envelope); err != nil {
+		return nil, false
+	}
+	if envelope.Object == "response" {
+		return payload, true
+	}
+	if strings.HasPrefix(envelope.Type, "response.") && len(envelope.Response) > 0 {
+		return envelope.Response, true
+	}
+	return nil, false
+}
+
+// parseResponsesResponse converts a Responses API response into a
+// ChatResponse. raw is the payload it was found in.
+func parseResponsesResponse(payload, raw []byte) (*llm.ChatResponse, error) {
+	var resp responsesResponse
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		return nil, err
+	}
+
+	stopReason := resp.Status
+	if resp.IncompleteDetails != nil && resp.IncompleteDetails.Reason != "" {
+		stopReason = resp.IncompleteDetails.Reason
+	}
+
+	var usage *llm.Usage
+	if resp.Usage != nil {
+		usage = &llm.Usage{
+			PromptTokens:     resp.Usage.InputTokens,
+			CompletionTokens: resp.Usage.OutputTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
+		}
+		if resp.Usage.InputTokensDetails != nil {
+			usage.CacheReadInputTokens = resp.Usage.InputTokensDetails.CachedTokens
+		}
+		if resp.Usage.OutputTokensDetails != nil {
+			usage.ReasoningTokens = resp.Usage.OutputTokensDetails.ReasoningTokens
+		}
+	}
+
+	return &llm.ChatResponse{
+		Model: resp.Model,
+		Message: llm.Message{
+			Role:    "assistant",
+			Content: parseResponsesOutput(resp.Output),
+		},
+		Done:        true,
+		StopReason:  stopReason,
+		Usage:       usage,
+		CreatedAt:   time.Unix(resp.CreatedAt, 0),
+		RawResponse: raw,
+		Extra: map[string]any{
+			"id":     resp.ID,
+			"object": resp.Object,
+		},
+	}, nil
+}
+
+// parseResponsesOutput converts the output items of a Responses API response
+// into content blocks: message text, function, web search, and computer calls
+// as tool_use blocks, and reasoning as thinking blocks. Other items are
+// skipped.
+func parseResponsesOutput(items []responsesOutputItem) []llm.ContentBlock {
+	content := []llm.ContentBlock{}
+	for _, item := range items {
+		switch item.Type {
+		case "message":
+			for _, part := range item.Content {
+				switch part.Type {
+				case "output_text", "input_text":
+					content = append(content, llm.ContentBlock{Type: "text", Text: part.Text})
+				case "refusal":
+					content = append(content, llm.ContentBlock{Type: "text", Text: part.Refusal})
+				}
+			}
+
+		case "function_call":
+			cb := llm.ContentBlock{Type: "tool_use", ToolUseID: item.CallID, ToolName: item.Name}
+			// Arguments that are not a JSON object are kept out of the input
+			// rather than dropping the call.
+			var input map[string]any
+			if err := json.Unmarshal([]byte(item.Arguments), &input); err == nil {
+				cb.ToolInput = input
+			}
+			content = append(content, cb)
+
+		case "web_search_call":
+			content = append(content, llm.ContentBlock{
+				Type:      "tool_use",
+				ToolUseID: item.ID,
+				ToolName:  webSearchToolName,
+				ToolInput: item.Action,
+			})
+
+		case "computer_call":
+			content = append(content, llm.ContentBlock{
+				Type:      "tool_use",
+				ToolUseID: item.CallID,
+				ToolName:  computerToolName,
+				ToolInput: item.Action,
+			})
+
+		case "reasoning":
+			summary := make([]string, 0, len(item.Summary))
+			for _, s := range item.Summary {
+				summary = append(summary, s.Text)
+			}
+			switch {
+			case len(summary) > 0:
+				content = append(content, llm.ContentBlock{
+					Type:      "thinking",
+					Thinking:  strings.Join(summary, "\n\n"),
+					Signature: item.EncryptedContent,
+				})
+			case item.EncryptedContent != "":
+				content = append(content, llm.ContentBlock{Type: "redacted_thinking", Signature: item.EncryptedContent})
+			}
+		}
+	}
+	return content
+}
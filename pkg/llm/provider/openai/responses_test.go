@@ -0,0 +1,196 @@
+package openai_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider/openai"
+)
+
+const responsesPayload = `{
+	"id": "resp_123",
+	"object": "response",
+	"created_at": 1741476542,
+	"model": "gpt-5-codex",
+	"status": "completed",
+	"output": [
+		{
+			"type": "reasoning",
+			"id": "rs_1",
+			"summary": [{"type": "summary_text", "text": "Look at the tests."}, {"type": "summary_text", "text": "Then run them."}],
+			"encrypted_content": "gAAAA"
+		},
+		{"type": "reasoning", "id": "rs_2", "summary": [], "encrypted_content": "gBBBB"},
+		{
+			"type": "message",
+			"id": "msg_1",
+			"role": "assistant",
+			"content": [{"type": "output_text", "text": "Running the tests.", "annotations": []}]
+		},
+		{
+			"type": "function_call",
+			"id": "fc_1",
+			"call_id": "call_abc",
+			"name": "shell",
+			"arguments": "{\"command\":[\"go\",\"test\",\"./...\"]}"
+		},
+		{"type": "web_search_call", "id": "ws_1", "status": "completed", "action": {"type": "search", "query": "ginkgo focus"}},
+		{"type": "computer_call", "id": "cu_1", "call_id": "call_cu", "action": {"type": "click", "x": 10, "y": 20}},
+		{"type": "image_generation_call", "id": "ig_1"}
+	],
+	"usage": {
+		"input_tokens": 120,
+		"input_tokens_details": {"cached_tokens": 100},
+		"output_tokens": 40,
+		"output_tokens_details": {"reasoning_tokens": 30},
+		"total_tokens": 160
+	}
+}`
+
+var _ = Describe("Responses API", func() {
+	var p *openai.Provider
+
+	BeforeEach(func() {
+		p = openai.New()
+	})
+
+	It("parses text, tool calls, and reasoning from the output", func() {
+		resp, err := p.ParseResponse([]byte(responsesPayload))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Model).To(Equal("gpt-5-codex"))
+		Expect(resp.StopReason).To(Equal("completed"))
+		Expect(resp.Message.Role).To(Equal("assistant"))
+		Expect(resp.Message.Content).To(Equal([]llm.ContentBlock{
+			{Type: "thinking", Thinking: "Look at the tests.\n\nThen run them.", Signature: "gAAAA"},
+			{Type: "redacted_thinking", Signature: "gBBBB"},
+			{Type: "text", Text: "Running the tests."},
+			{
+				Type:      "tool_use",
+				ToolUseID: "call_abc",
+				ToolName:  "shell",
+				ToolInput: map[string]any{"command": []any{"go", "test", "./..."}},
+			},
+			{
+				Type:      "tool_use",
+				ToolUseID: "ws_1",
+				ToolName:  "web_search",
+				ToolInput: map[string]any{"type": "search", "query": "ginkgo focus"},
+			},
+			{
+				Type:      "tool_use",
+				ToolUseID: "call_cu",
+				ToolName:  "computer",
+				ToolInput: map[string]any{"type": "click", "x": float64(10), "y": float64(20)},
+			},
+		}))
+
+		Expect(resp.Usage).To(Equal(&llm.Usage{
+			PromptTokens:         120,
+			CompletionTokens:     40,
+			TotalTokens:          160,
+			CacheReadInputTokens: 100,
+			ReasoningTokens:      30,
+		}))
+		Expect(resp.Extra).To(HaveKeyWithValue("id", "resp_123"))
+	})
+
+	It("parses the response.completed event ending a stream", func() {
+		payload := []byte(`{"type":"response.completed","sequence_number":9,"response":` + responsesPayload + `}`)
+		resp, err := p.ParseResponse(payload)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.Message.Content).To(HaveLen(6))
+		Expect([]byte(resp.RawResponse)).To(Equal(payload))
+	})
+
+	It("keeps function calls whose arguments are not a JSON object", func() {
+		resp, err := p.ParseResponse([]byte(`{
+			"object": "response",
+			"status": "incomplete",
+			"incomplete_details": {"reason": "max_output_tokens"},
+			"output": [{"type": "function_call", "call_id": "call_1", "name": "shell", "arguments": "{\"command\":"}]
+		}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StopReason).To(Equal("max_output_tokens"))
+		Expect(resp.Message.Content).To(Equal([]llm.ContentBlock{
+			{Type: "tool_use", ToolUseID: "call_1", ToolName: "shell"},
+		}))
+	})
+})
+
+var _ = Describe("Responses API requests", func() {
+	var p *openai.Provider
+
+	BeforeEach(func() {
+		p = openai.New()
+	})
+
+	It("parses a string input as a user message", func() {
+		req, err := p.ParseRequest([]byte(`{"model":"gpt-5","instructions":"Be brief.","input":"hi","stream":true,"max_output_tokens":100}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Model).To(Equal("gpt-5"))
+		Expect(req.System).To(Equal("Be brief."))
+		Expect(*req.Stream).To(BeTrue())
+		Expect(*req.MaxTokens).To(Equal(100))
+		Expect(req.Messages).To(Equal([]llm.Message{llm.NewTextMessage("user", "hi")}))
+	})
+
+	It("reconstructs tool calls, their results, and images from the input items", func() {
+		req, err := p.ParseRequest([]byte(`{
+			"model": "gpt-5-codex",
+			"input": [
+				{"type": "message", "role": "developer", "content": [{"type": "input_text", "text": "Use the shell."}]},
+				{"role": "user", "content": [
+					{"type": "input_text", "text": "What is in this screenshot?"},
+					{"type": "input_image", "image_url": "data:image/png;base64,iVBO", "detail": "auto"},
+					{"type": "input_file", "filename": "notes.pdf", "file_data": "data:application/pdf;base64,JVBE"}
+				]},
+				{"type": "reasoning", "id": "rs_1", "summary": [{"type": "summary_text", "text": "Check the files."}], "encrypted_content": "gAAAA"},
+				{"type": "message", "role": "assistant", "content": [{"type": "output_text", "text": "Listing files."}]},
+				{"type": "function_call", "call_id": "call_1", "name": "shell", "arguments": "{\"command\":[\"ls\"]}"},
+				{"type": "function_call_output", "call_id": "call_1", "output": "main.go\n"},
+				{"type": "function_call", "call_id": "call_2", "name": "shell", "arguments": "{\"command\":[\"pwd\"]}"},
+				{"type": "function_call_output", "call_id": "call_2", "output": [{"type": "input_text", "text": "/src"}]}
+			]
+		}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Messages).To(Equal([]llm.Message{
+			{Role: "developer", Content: []llm.ContentBlock{{Type: "text", Text: "Use the shell."}}},
+			{Role: "user", Content: []llm.ContentBlock{
+				{Type: "text", Text: "What is in this screenshot?"},
+				{Type: "image", ImageURL: "data:image/png;base64,iVBO"},
+				{Type: "file", ImageURL: "data:application/pdf;base64,JVBE", FileName: "notes.pdf"},
+			}},
+			{Role: "assistant", Content: []llm.ContentBlock{
+				{Type: "thinking", Thinking: "Check the files.", Signature: "gAAAA"},
+				{Type: "text", Text: "Listing files."},
+				{Type: "tool_use", ToolUseID: "call_1", ToolName: "shell", ToolInput: map[string]any{"command": []any{"ls"}}},
+			}},
+			{Role: "tool", Content: []llm.ContentBlock{{Type: "tool_result", ToolResultID: "call_1", ToolOutput: "main.go\n"}}},
+			{Role: "assistant", Content: []llm.ContentBlock{
+				{Type: "tool_use", ToolUseID: "call_2", ToolName: "shell", ToolInput: map[string]any{"command": []any{"pwd"}}},
+			}},
+			{Role: "tool", Content: []llm.ContentBlock{{Type: "tool_result", ToolResultID: "call_2", ToolOutput: "/src"}}},
+		}))
+	})
+
+	It("parses function, custom, and built-in tools", func() {
+		req, err := p.ParseRequest([]byte(`{"model":"gpt-5","input":"hi","tools":[
+			{"type":"function","name":"shell","description":"Run a command","parameters":{"type":"object"},"strict":false},
+			{"type":"custom","name":"apply_patch","format":{"type":"grammar"}},
+			{"type":"web_search","search_context_size":"low"}
+		]}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Tools).To(Equal([]llm.ToolDefinition{
+			{Type: "function", Name: "shell", Description: "Run a command", Schema: map[string]any{"type": "object"}},
+			{Type: "custom", Name: "apply_patch", Schema: map[string]any{"type": "grammar"}},
+			{Type: "web_search", Name: "web_search", Schema: map[string]any{"search_context_size": "low"}},
+		}))
+	})
+
+	It("still parses Chat Completions requests", func() {
+		req, err := p.ParseRequest([]byte(`{"model":"gpt-4","messages":[{"role":"user","content":"hi"}]}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(req.Messages).To(Equal([]llm.Message{llm.NewTextMessage("user", "hi")}))
+	})
+})
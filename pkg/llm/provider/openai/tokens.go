@@ -0,0 +1,35 @@
+package openai
+
+import (
+	"context"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// Tokens Chat Completions adds around messages: each message is framed by
+// special tokens, and every reply is primed with the assistant's role.
+const (
+	tokensPerMessage = 3
+	tokensPerReply   = 3
+)
+
+// TokenCounter estimates the input tokens of Chat Completions requests
+// locally, approximating the model's tiktoken encoding and OpenAI's
+// per-message overhead. It makes no requests.
+type TokenCounter struct{}
+
+// NewTokenCounter creates a TokenCounter.
+func NewTokenCounter() *TokenCounter { return &TokenCounter{} }
+
+// CountTokens implements llm.TokenCounter.
+func (t *TokenCounter) CountTokens(_ context.Context, req *llm.ChatRequest) (int, error) {
+	enc := llm.EncodingForModel(req.Model)
+	tokens := tokensPerReply
+	if req.System != "" {
+		tokens += tokensPerMessage + enc.Count(req.System)
+	}
+	for _, msg := range req.Messages {
+		tokens += tokensPerMessage + enc.Count(msg.Role) + enc.CountMessage(msg)
+	}
+	return tokens, nil
+}
@@ -0,0 +1,45 @@
+package openai_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider/openai"
+)
+
+var _ = Describe("TokenCounter", func() {
+	It("estimates message text and per-message overhead", func() {
+		req := &llm.ChatRequest{
+			Model: "gpt-4o",
+			Messages: []llm.Message{
+				{Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "Hello world"}}},
+			},
+		}
+
+		tokens, err := openai.NewTokenCounter().CountTokens(GinkgoT().Context(), req)
+		Expect(err).NotTo(HaveOccurred())
+		// "Hello world" and "user" are three tokens, framed by three tokens
+		// for the message and three priming the reply.
+		Expect(tokens).To(Equal(9))
+	})
+
+	It("grows with the request and charges images", func() {
+		counter := openai.NewTokenCounter()
+		short := &llm.ChatRequest{Messages: []llm.Message{
+			{Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "Summarize this."}}},
+		}}
+		long := &llm.ChatRequest{System: "You are a careful reviewer.", Messages: []llm.Message{
+			{Role: "user", Content: []llm.ContentBlock{
+				{Type: "text", Text: "Summarize this."},
+				{Type: "image", ImageURL: "https://example.com/chart.png"},
+			}},
+		}}
+
+		shortTokens, err := counter.CountTokens(GinkgoT().Context(), short)
+		Expect(err).NotTo(HaveOccurred())
+		longTokens, err := counter.CountTokens(GinkgoT().Context(), long)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(longTokens).To(BeNumerically(">", shortTokens+1000))
+	})
+})
@@ -1,5 +1,7 @@
 package openai
 
+import "encoding/json"
+
 // openaiRequest represents OpenAI's request format.
 type openaiRequest struct {
 	Model       string          `json:"model"`
@@ -14,6 +16,29 @@ type openaiRequest struct {
 	FrequencyPenalty *float64       `json:"frequency_penalty,omitempty"`
 	PresencePenalty  *float64       `json:"presence_penalty,omitempty"`
 	ResponseFormat   map[string]any `json:"response_format,omitempty"`
+
+	Tools []openaiTool `json:"tools,omitempty"`
+	// Functions is the deprecated predecessor of Tools.
+	Functions []openaiFunction `json:"functions,omitempty"`
+}
+
+// openaiTool is a tool offered in a Chat Completions request: a function, or
+// a custom tool taking free-form text in a given format.
+type openaiTool struct {
+	Type     string          `json:"type"`
+	Function *openaiFunction `json:"function,omitempty"`
+	Custom   *struct {
+		Name        string         `json:"name"`
+		Description string         `json:"description,omitempty"`
+		Format      map[string]any `json:"format,omitempty"`
+	} `json:"custom,omitempty"`
+}
+
+// openaiFunction is the definition of a function tool.
+type openaiFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
 }
 
 // openaiMessage represents a message in OpenAI's format.
@@ -47,12 +72,47 @@ type openaiResponse struct {
 }
 
 type openaiUsage struct {
-	PromptTokens        int                        `json:"prompt_tokens"`
-	CompletionTokens    int                        `json:"completion_tokens"`
-	TotalTokens         int                        `json:"total_tokens"`
-	PromptTokensDetails *openaiPromptTokensDetails `json:"prompt_tokens_details,omitempty"`
+	PromptTokens            int                            `json:"prompt_tokens"`
+	CompletionTokens        int                            `json:"completion_tokens"`
+	TotalTokens             int                            `json:"total_tokens"`
+	PromptTokensDetails     *openaiPromptTokensDetails     `json:"prompt_tokens_details,omitempty"`
+	CompletionTokensDetails *openaiCompletionTokensDetails `json:"completion_tokens_details,omitempty"`
 }
 
 type openaiPromptTokensDetails struct {
 	CachedTokens int `json:"cached_tokens"`
 }
+
+type openaiCompletionTokensDetails struct {
+	ReasoningTokens int `json:"reasoning_tokens"`
+}
+
+// openaiEmbeddingRequest represents a request to the embeddings endpoint.
+// Input is a string, an array of strings, or token ID arrays.
+type openaiEmbeddingRequest struct {
+	Model string          `json:"model"`
+	Input json.RawMessage `json:"input"`
+}
+
+// openaiEmbeddingResponse represents the embeddings endpoint's response.
+// Vectors are kept raw, since only their length is recorded.
+type openaiEmbeddingResponse struct {
+	Model string `json:"model"`
+	Data  []struct {
+		Embedding json.RawMessage `json:"embedding"`
+	} `json:"data"`
+	Usage *openaiUsage `json:"usage,omitempty"`
+}
+
+// openaiTranscriptionResponse holds the usage fields of an audio
+// transcription response or of its final streamed event.
+type openaiTranscriptionResponse struct {
+	Duration float64 `json:"duration"`
+	Usage    *struct {
+		Type         string  `json:"type"`
+		InputTokens  int     `json:"input_tokens"`
+		OutputTokens int     `json:"output_tokens"`
+		TotalTokens  int     `json:"total_tokens"`
+		Seconds      float64 `json:"seconds"`
+	} `json:"usage,omitempty"`
+}
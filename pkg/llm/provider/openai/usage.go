@@ -0,0 +1,204 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// Paths of the billable endpoints other than chat, relative to the API base.
+const (
+	embeddingsPath     = "/embeddings"
+	transcriptionsPath = "/audio/transcriptions"
+	translationsPath   = "/audio/translations"
+)
+
+// UsageEndpoint reports whether path is the embeddings endpoint or one of the
+// audio transcription endpoints.
+func (o *Provider) UsageEndpoint(path string) bool {
+	return usageKind(path) != ""
+}
+
+func usageKind(path string) string {
+	path = strings.TrimSuffix(path, "/")
+	switch {
+	case strings.HasSuffix(path, embeddingsPath):
+		return llm.RecordEmbedding
+	case strings.HasSuffix(path, transcriptionsPath), strings.HasSuffix(path, translationsPath):
+		return llm.RecordTranscription
+	default:
+		return ""
+	}
+}
+
+// ParseUsage converts an embeddings or audio transcription request and its
+// response into a usage record. Vectors and audio are measured, not kept.
+func (o *Provider) ParseUsage(path, contentType string, req, resp []byte) (*llm.UsageRecord, error) {
+	switch usageKind(path) {
+	case llm.RecordEmbedding:
+		return parseEmbedding(req, resp)
+	case llm.RecordTranscription:
+		return parseTranscription(contentType, req, resp)
+	default:
+		return nil, fmt.Errorf("unsupported usage endpoint %q", path)
+	}
+}
+
+func parseEmbedding(req, resp []byte) (*llm.UsageRecord, error) {
+	var embReq openaiEmbeddingRequest
+	if err := json.Unmarshal(req, &embReq); err != nil {
+		return nil, fmt.Errorf("parsing embeddings request: %w", err)
+	}
+	var embResp openaiEmbeddingResponse
+	if err := json.Unmarshal(resp, &embResp); err != nil {
+		return nil, fmt.Errorf("parsing embeddings response: %w", err)
+	}
+
+	record := &llm.UsageRecord{
+		Kind:  llm.RecordEmbedding,
+		Model: embReq.Model,
+	}
+	if embResp.Model != "" {
+		record.Model = embResp.Model
+	}
+	record.Inputs, record.InputBytes = measureEmbeddingInput(embReq.Input)
+	if len(embResp.Data) > 0 {
+		record.Dimensions = embeddingDimensions(embResp.Data[0].Embedding)
+	}
+	if embResp.Usage != nil {
+		record.Usage = &llm.Usage{
+			PromptTokens: embResp.Usage.PromptTokens,
+			TotalTokens:  embResp.Usage.TotalTokens,
+		}
+	}
+	return record, nil
+}
+
+// measureEmbeddingInput counts the inputs of an embeddings request, which is a
+// string, an array of strings, or one or more arrays of token IDs.
+func measureEmbeddingInput(input json.RawMessage) (int, int) {
+	var text string
+	if err := json.Unmarshal(input, &text); err == nil {
+		return 1, len(text)
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(input, &items); err != nil {
+		return 0, 0
+	}
+	if len(items) > 0 && !bytes.HasPrefix(bytes.TrimSpace(items[0]), []byte("[")) &&
+		!bytes.HasPrefix(bytes.TrimSpace(items[0]), []byte(`"`)) {
+		// A single array of token IDs.
+		return 1, len(input)
+	}
+
+	size := 0
+	for _, item := range items {
+		if err := json.Unmarshal(item, &text); err == nil {
+			size += len(text)
+		} else {
+			size += len(item)
+		}
+	}
+	return len(items), size
+}
+
+// embeddingDimensions returns the length of a vector returned as a JSON array
+// of floats or, with encoding_format=base64, as base64 float32 data.
+func embeddingDimensions(embedding json.RawMessage) int {
+	var encoded string
+	if err := json.Unmarshal(embedding, &encoded); err == nil {
+		return base64.StdEncoding.DecodedLen(len(encoded)) / 4
+	}
+	var vector []json.Number
+	if err := json.Unmarshal(embedding, &vector); err != nil {
+		return 0
+	}
+	return len(vector)
+}
+
+func parseTranscription(contentType string, req, resp []byte) (*llm.UsageRecord, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, fmt.Errorf("parsing transcription request: expected multipart form, got %q", contentType)
+	}
+
+	record := &llm.UsageRecord{Kind: llm.RecordTranscription}
+	reader := multipart.NewReader(bytes.NewReader(req), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing transcription request: %w", err)
+		}
+
+		switch part.FormName() {
+		case "model":
+			model, err := io.ReadAll(part)
+			if err != nil {
+				return nil, fmt.Errorf("parsing transcription request: %w", err)
+			}
+			record.Model = strings.TrimSpace(string(model))
+		case "file":
+			n, err := io.Copy(io.Discard, part)
+			if err != nil {
+				return nil, fmt.Errorf("parsing transcription request: %w", err)
+			}
+			record.Inputs++
+			record.InputBytes += int(n)
+		}
+	}
+
+	// The response is JSON, plain text or subtitles for other response
+	// formats, or server-sent events when streamed. Usage is only reported in
+	// JSON and in the final event.
+	if !applyTranscriptionUsage(record, resp) {
+		scanner := bufio.NewScanner(bytes.NewReader(resp))
+		scanner.Buffer(make([]byte, 0, 64*1024), len(resp)+1)
+		for scanner.Scan() {
+			data, found := strings.CutPrefix(scanner.Text(), "data:")
+			if !found {
+				continue
+			}
+			applyTranscriptionUsage(record, []byte(strings.TrimSpace(data)))
+		}
+	}
+	return record, nil
+}
+
+// applyTranscriptionUsage copies the audio duration and token usage of a
+// transcription response into record. It reports false if payload is not JSON.
+func applyTranscriptionUsage(record *llm.UsageRecord, payload []byte) bool {
+	var resp openaiTranscriptionResponse
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		return false
+	}
+
+	if resp.Duration > 0 {
+		record.AudioSeconds = resp.Duration
+	}
+	if resp.Usage == nil {
+		return true
+	}
+	switch resp.Usage.Type {
+	case "duration":
+		record.AudioSeconds = resp.Usage.Seconds
+	default:
+		record.Usage = &llm.Usage{
+			PromptTokens:     resp.Usage.InputTokens,
+			CompletionTokens: resp.Usage.OutputTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
+		}
+	}
+	return true
+}
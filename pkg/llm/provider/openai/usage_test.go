@@ -0,0 +1,147 @@
+package openai_test
+
+import (
+	"bytes"
+	"mime/multipart"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+	"github.com/papercomputeco/tapes/pkg/llm/provider/openai"
+)
+
+// transcriptionForm builds a multipart transcription request with an audio
+// file of the given size.
+func transcriptionForm(model string, size int) ([]byte, string) {
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	Expect(form.WriteField("model", model)).To(Succeed())
+	file, err := form.CreateFormFile("file", "call.mp3")
+	Expect(err).NotTo(HaveOccurred())
+	_, err = file.Write(bytes.Repeat([]byte{0xff}, size))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(form.Close()).To(Succeed())
+	return buf.Bytes(), form.FormDataContentType()
+}
+
+var _ = Describe("OpenAI usage endpoints", func() {
+	var p provider.UsageParser
+
+	BeforeEach(func() {
+		p = openai.New()
+	})
+
+	DescribeTable("UsageEndpoint",
+		func(path string, expected bool) {
+			Expect(p.UsageEndpoint(path)).To(Equal(expected))
+		},
+		Entry("embeddings", "/v1/embeddings", true),
+		Entry("embeddings without version", "/embeddings", true),
+		Entry("transcriptions", "/v1/audio/transcriptions", true),
+		Entry("translations", "/v1/audio/translations", true),
+		Entry("chat completions", "/v1/chat/completions", false),
+		Entry("speech", "/v1/audio/speech", false),
+	)
+
+	Describe("embeddings", func() {
+		It("records the model, input sizes, dimensions, and usage", func() {
+			req := []byte(`{"model": "text-embedding-3-small", "input": ["hello", "tapes!"]}`)
+			resp := []byte(`{
+				"object": "list",
+				"data": [
+					{"object": "embedding", "index": 0, "embedding": [0.1, -0.2, 0.3, 0.4]},
+					{"object": "embedding", "index": 1, "embedding": [0.5, 0.6, 0.7, 0.8]}
+				],
+				"model": "text-embedding-3-small",
+				"usage": {"prompt_tokens": 3, "total_tokens": 3}
+			}`)
+
+			record, err := p.ParseUsage("/v1/embeddings", "application/json", req, resp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Kind).To(Equal(llm.RecordEmbedding))
+			Expect(record.Model).To(Equal("text-embedding-3-small"))
+			Expect(record.Inputs).To(Equal(2))
+			Expect(record.InputBytes).To(Equal(11))
+			Expect(record.Dimensions).To(Equal(4))
+			Expect(record.Usage.PromptTokens).To(Equal(3))
+			Expect(record.Usage.TotalTokens).To(Equal(3))
+		})
+
+		It("measures single strings and token ID inputs", func() {
+			resp := []byte(`{"data": [{"embedding": "AAAAAAAAAAAAAAAA"}], "usage": {"prompt_tokens": 3, "total_tokens": 3}}`)
+
+			record, err := p.ParseUsage("/v1/embeddings", "", []byte(`{"model": "m", "input": "hello"}`), resp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Inputs).To(Equal(1))
+			Expect(record.InputBytes).To(Equal(5))
+			Expect(record.Dimensions).To(Equal(3))
+
+			record, err = p.ParseUsage("/v1/embeddings", "", []byte(`{"model": "m", "input": [1, 2, 3]}`), resp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Model).To(Equal("m"))
+			Expect(record.Inputs).To(Equal(1))
+
+			record, err = p.ParseUsage("/v1/embeddings", "", []byte(`{"model": "m", "input": [[1, 2], [3]]}`), resp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Inputs).To(Equal(2))
+		})
+
+		It("returns an error for an invalid response", func() {
+			_, err := p.ParseUsage("/v1/embeddings", "", []byte(`{"model": "m", "input": "hi"}`), []byte("not json"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("transcriptions", func() {
+		It("records the model, file size, and token usage", func() {
+			req, contentType := transcriptionForm("gpt-4o-transcribe", 2048)
+			resp := []byte(`{"text": "hello", "usage": {"type": "tokens", "input_tokens": 14, "output_tokens": 45, "total_tokens": 59}}`)
+
+			record, err := p.ParseUsage("/v1/audio/transcriptions", contentType, req, resp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Kind).To(Equal(llm.RecordTranscription))
+			Expect(record.Model).To(Equal("gpt-4o-transcribe"))
+			Expect(record.Inputs).To(Equal(1))
+			Expect(record.InputBytes).To(Equal(2048))
+			Expect(record.Usage.PromptTokens).To(Equal(14))
+			Expect(record.Usage.CompletionTokens).To(Equal(45))
+			Expect(record.Usage.TotalTokens).To(Equal(59))
+		})
+
+		It("records the audio duration for whisper", func() {
+			req, contentType := transcriptionForm("whisper-1", 16)
+			resp := []byte(`{"text": "hello", "usage": {"type": "duration", "seconds": 9}}`)
+
+			record, err := p.ParseUsage("/v1/audio/translations", contentType, req, resp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.AudioSeconds).To(Equal(9.0))
+			Expect(record.Usage).To(BeNil())
+		})
+
+		It("reads usage from the final streamed event", func() {
+			req, contentType := transcriptionForm("gpt-4o-mini-transcribe", 16)
+			resp := []byte("data: {\"type\":\"transcript.text.delta\",\"delta\":\"hel\"}\n\n" +
+				"data: {\"type\":\"transcript.text.done\",\"text\":\"hello\",\"usage\":{\"type\":\"tokens\",\"input_tokens\":5,\"output_tokens\":2,\"total_tokens\":7}}\n\n")
+
+			record, err := p.ParseUsage("/v1/audio/transcriptions", contentType, req, resp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Usage.TotalTokens).To(Equal(7))
+		})
+
+		It("records plain text responses without usage", func() {
+			req, contentType := transcriptionForm("whisper-1", 16)
+
+			record, err := p.ParseUsage("/v1/audio/transcriptions", contentType, req, []byte("hello"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Model).To(Equal("whisper-1"))
+			Expect(record.Usage).To(BeNil())
+		})
+
+		It("returns an error for a request that is not a multipart form", func() {
+			_, err := p.ParseUsage("/v1/audio/transcriptions", "application/json", []byte(`{}`), []byte(`{}`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
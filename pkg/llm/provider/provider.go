@@ -35,3 +35,34 @@ type Provider interface {
 	// Returns (nil, nil) if the chunk should be skipped (e.g., keep-alive, comments).
 	ParseStreamChunk(payload []byte) (*llm.StreamChunk, error)
 }
+
+// UsageParser is implemented by providers with billable endpoints other than
+// chat, such as embeddings and audio transcription. Requests to these
+// endpoints are recorded for usage and cost rather than as conversations.
+type UsageParser interface {
+	// UsageEndpoint reports whether path is such an endpoint.
+	UsageEndpoint(path string) bool
+
+	// ParseUsage converts a request to path, sent with the given Content-Type,
+	// and its response into a usage record.
+	ParseUsage(path, contentType string, req, resp []byte) (*llm.UsageRecord, error)
+}
+
+// TokenCountProvider is implemented by providers with an endpoint that counts
+// a request's input tokens without running it. Requests to it are proxied but
+// not recorded.
+type TokenCountProvider interface {
+	// TokenCountEndpoint reports whether path is such an endpoint.
+	TokenCountEndpoint(path string) bool
+
+	// ParseTokenCount reads the input token count from its response.
+	ParseTokenCount(payload []byte) (int, error)
+}
+
+// RequestEncoder is implemented by providers that can write a request in the
+// internal format back out in their own API format, so that recorded
+// conversations can be sent again.
+type RequestEncoder interface {
+	// EncodeRequest converts req into a provider-specific request body.
+	EncodeRequest(req *llm.ChatRequest) ([]byte, error)
+}
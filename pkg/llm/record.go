@@ -0,0 +1,33 @@
+package llm
+
+// Kinds of billable requests other than chat.
+const (
+	RecordEmbedding     = "embedding"
+	RecordTranscription = "transcription"
+)
+
+// UsageRecord describes a billable request to an endpoint other than chat,
+// such as embeddings or audio transcription. Only the request's size and
+// usage are recorded; input text, vectors, and audio are not kept.
+type UsageRecord struct {
+	// Kind is RecordEmbedding or RecordTranscription.
+	Kind string `json:"kind"`
+
+	// Model that served the request
+	Model string `json:"model"`
+
+	// Inputs is the number of texts embedded or audio files transcribed.
+	Inputs int `json:"inputs"`
+
+	// InputBytes is the total size of the inputs.
+	InputBytes int `json:"input_bytes"`
+
+	// Dimensions is the length of each returned embedding vector.
+	Dimensions int `json:"dimensions,omitempty"`
+
+	// AudioSeconds is the duration of transcribed audio, when reported.
+	AudioSeconds float64 `json:"audio_seconds,omitempty"`
+
+	// Usage holds the token counts reported by the provider, if any.
+	Usage *Usage `json:"usage,omitempty"`
+}
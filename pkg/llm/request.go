@@ -1,6 +1,9 @@
 package llm
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+)
 
 // ChatRequest represents a provider-agnostic chat completion request.
 // This is the internal representation used by the proxy after parsing
@@ -26,6 +29,13 @@ type ChatRequest struct {
 	Stop        []string `json:"stop,omitempty"`
 	Seed        *int     `json:"seed,omitempty"`
 
+	// Tools are the tool definitions offered to the model
+	Tools []ToolDefinition `json:"tools,omitempty"`
+
+	// SessionID is the ID of the agent session the request belongs to, when
+	// the agent sends one in the request body
+	SessionID string `json:"session_id,omitempty"`
+
 	// Provider-specific fields that don't map to common parameters
 	Extra map[string]any `json:"extra,omitempty"`
 
@@ -33,3 +43,21 @@ type ChatRequest struct {
 	// parsing is incomplete or for debugging.
 	RawRequest json.RawMessage `json:"raw_request,omitempty"`
 }
+
+// SystemPrompt returns the request's system prompt joined with the text of
+// its system messages, one per line, for providers that send the prompt as
+// messages.
+func (r *ChatRequest) SystemPrompt() string {
+	var prompts []string
+	if r.System != "" {
+		prompts = append(prompts, r.System)
+	}
+	for _, msg := range r.Messages {
+		if msg.Role == "system" {
+			if text := msg.GetText(); text != "" {
+				prompts = append(prompts, text)
+			}
+		}
+	}
+	return strings.Join(prompts, "\n")
+}
@@ -46,7 +46,15 @@ type Usage struct {
 	CacheCreationInputTokens int `json:"cache_creation_input_tokens,omitempty"`
 	CacheReadInputTokens     int `json:"cache_read_input_tokens,omitempty"`
 
+	// Reasoning token count (OpenAI o-series). These tokens are billed as
+	// output and are already included in CompletionTokens.
+	ReasoningTokens int `json:"reasoning_tokens,omitempty"`
+
 	// Timing (provider-specific, but normalized to nanoseconds where possible)
 	TotalDurationNs  int64 `json:"total_duration_ns,omitempty"`
 	PromptDurationNs int64 `json:"prompt_duration_ns,omitempty"`
+
+	// Estimated is set when the token counts were estimated locally because
+	// the provider did not report them, as for interrupted streams.
+	Estimated bool `json:"estimated,omitempty"`
 }
@@ -0,0 +1,32 @@
+package llm
+
+import "time"
+
+// Timing records when the proxy forwarded a request upstream, received the
+// first streamed chunk, and finished receiving the response.
+type Timing struct {
+	RequestStartedAt time.Time `json:"request_started_at"`
+
+	// FirstChunkAt is zero for non-streaming responses.
+	FirstChunkAt time.Time `json:"first_chunk_at,omitzero"`
+
+	ResponseCompletedAt time.Time `json:"response_completed_at"`
+}
+
+// Latency returns the time from forwarding the request to receiving the
+// complete response, or zero if either end is unknown.
+func (t *Timing) Latency() time.Duration {
+	if t == nil || t.RequestStartedAt.IsZero() || t.ResponseCompletedAt.IsZero() {
+		return 0
+	}
+	return max(t.ResponseCompletedAt.Sub(t.RequestStartedAt), 0)
+}
+
+// TimeToFirstToken returns the time from forwarding the request to receiving
+// the first streamed chunk, or zero if the response was not streamed.
+func (t *Timing) TimeToFirstToken() time.Duration {
+	if t == nil || t.RequestStartedAt.IsZero() || t.FirstChunkAt.IsZero() {
+		return 0
+	}
+	return max(t.FirstChunkAt.Sub(t.RequestStartedAt), 0)
+}
@@ -0,0 +1,179 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenCounter counts the input tokens of a chat request before it is sent,
+// so that budgets and limits can be checked without paying for the request.
+type TokenCounter interface {
+	CountTokens(ctx context.Context, req *ChatRequest) (int, error)
+}
+
+// Tokens charged per image when its size is unknown. Providers bill images
+// by their dimensions, which the proxy does not decode.
+const imageTokens = 1000
+
+// pretokenize splits text the way tiktoken's regular expressions do before
+// byte-pair encoding: contractions, runs of letters with an optional leading
+// space, digits in groups of up to three, punctuation, and whitespace.
+var pretokenize = regexp.MustCompile(`'(?i:[sdmt]|ll|ve|re)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+`)
+
+// Encoding approximates the tokenizer of a family of models without shipping
+// its vocabulary. Words up to wordLetters long, with their leading space,
+// count as one token and longer words as one token per wordLetters letters;
+// non-ASCII text counts one token per nonASCIIBytes bytes. Its counts are
+// estimates, not the exact counts the provider bills: for English prose and
+// code they are typically within 10-15% of them, in either direction, and
+// may be further off for other text. Limits enforced on them should allow
+// for EstimateMargin.
+type Encoding struct {
+	Name string
+
+	wordLetters   int
+	nonASCIIBytes int
+}
+
+// EstimateMargin is the percentage by which an estimated token count may
+// exceed a limit before the limit rejects it, so that requests within the
+// limit are not turned away for the estimate's error.
+const EstimateMargin = 15
+
+// OverLimit reports whether tokens exceeds limit. A count that was estimated
+// only exceeds it by more than EstimateMargin percent.
+func OverLimit(tokens, limit int, estimated bool) bool {
+	if estimated {
+		return tokens*100 > limit*(100+EstimateMargin)
+	}
+	return tokens > limit
+}
+
+// Encodings of the model families tapes estimates tokens for.
+var (
+	// CL100K is used by GPT-4, GPT-3.5, and models of unknown family.
+	CL100K = Encoding{Name: "cl100k_base", wordLetters: 5, nonASCIIBytes: 3}
+
+	// O200K is used by GPT-4o, GPT-4.1, GPT-5, and the o-series. Its larger
+	// vocabulary encodes non-English text more compactly.
+	O200K = Encoding{Name: "o200k_base", wordLetters: 5, nonASCIIBytes: 4}
+
+	// ClaudeEncoding is Anthropic's tokenizer, which splits English into
+	// noticeably more tokens than OpenAI's.
+	ClaudeEncoding = Encoding{Name: "claude", wordLetters: 4, nonASCIIBytes: 3}
+)
+
+// EncodingForModel returns the encoding used by model.
+func EncodingForModel(model string) Encoding {
+	m := strings.ToLower(model)
+	if i := strings.LastIndex(m, "/"); i >= 0 {
+		m = m[i+1:]
+	}
+	switch {
+	case strings.HasPrefix(m, "claude"):
+		return ClaudeEncoding
+	case strings.HasPrefix(m, "gpt-4o"), strings.HasPrefix(m, "gpt-4.1"), strings.HasPrefix(m, "gpt-5"),
+		strings.HasPrefix(m, "chatgpt"), strings.HasPrefix(m, "o1"), strings.HasPrefix(m, "o3"), strings.HasPrefix(m, "o4"):
+		return O200K
+	default:
+		return CL100K
+	}
+}
+
+// Count approximates the number of tokens in text.
+func (e Encoding) Count(text string) int {
+	tokens := 0
+	for _, piece := range pretokenize.FindAllString(text, -1) {
+		if utf8.RuneCountInString(piece) != len(piece) {
+			tokens += (len(piece) + e.nonASCIIBytes - 1) / e.nonASCIIBytes
+			continue
+		}
+		tokens += max(1, (len(strings.TrimLeft(piece, " "))+e.wordLetters-1)/e.wordLetters)
+	}
+	return tokens
+}
+
+// CountMessage approximates the tokens of a single message's content: its
+// text, tool calls, and tool results, plus a flat charge per image.
+func (e Encoding) CountMessage(msg Message) int {
+	tokens := 0
+	for _, block := range msg.Content {
+		switch block.Type {
+		case "image", "document", "file":
+			tokens += imageTokens
+		case "tool_use":
+			tokens += e.Count(block.ToolName)
+			if input, err := json.Marshal(block.ToolInput); err == nil {
+				tokens += e.Count(string(input))
+			}
+		default:
+			tokens += e.Count(block.Text)
+			tokens += e.Count(block.Thinking)
+			tokens += e.Count(block.ToolOutput)
+		}
+	}
+	return tokens
+}
+
+// CountRequest approximates the input tokens of a request: its system prompt
+// and messages.
+func (e Encoding) CountRequest(req *ChatRequest) int {
+	tokens := e.Count(req.System)
+	for _, msg := range req.Messages {
+		tokens += e.CountMessage(msg)
+	}
+	return tokens
+}
+
+// EstimateTokens approximates the input tokens of a request with the
+// encoding of its model.
+func EstimateTokens(req *ChatRequest) int {
+	return EncodingForModel(req.Model).CountRequest(req)
+}
+
+// EstimateUsage approximates the usage of a turn whose response left token
+// counts out, counting its request and response with the encoding of the
+// model. A zero count is taken as missing only when the content it measures
+// is not empty, so counts the response did report, including a genuine zero,
+// are kept. The result is marked as estimated when any count was filled in.
+func EstimateUsage(req *ChatRequest, resp *ChatResponse) *Usage {
+	usage := &Usage{}
+	if resp.Usage != nil {
+		*usage = *resp.Usage
+	}
+
+	model := resp.Model
+	if model == "" {
+		model = req.Model
+	}
+	enc := EncodingForModel(model)
+	if usage.PromptTokens == 0 {
+		if n := enc.CountRequest(req); n > 0 {
+			usage.PromptTokens = n
+			usage.Estimated = true
+		}
+	}
+	if usage.CompletionTokens == 0 {
+		if n := enc.CountMessage(resp.Message); n > 0 {
+			usage.CompletionTokens = n
+			usage.Estimated = true
+		}
+	}
+	if !usage.Estimated {
+		return resp.Usage
+	}
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	return usage
+}
+
+// EstimateCounter is a TokenCounter for providers without a more precise
+// one. It estimates tokens locally with EstimateTokens.
+type EstimateCounter struct{}
+
+// CountTokens implements TokenCounter.
+func (EstimateCounter) CountTokens(_ context.Context, req *ChatRequest) (int, error) {
+	return EstimateTokens(req), nil
+}
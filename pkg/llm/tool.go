@@ -0,0 +1,35 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// ToolDefinition is a tool offered to the model in a request: a function the
+// agent implements, or a tool built into the provider.
+type ToolDefinition struct {
+	// Type is the provider's tool type, such as "function", or the type of
+	// a built-in tool, such as "web_search".
+	Type string `json:"type,omitempty"`
+
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Schema is the JSON schema of the tool's input. Built-in tools carry
+	// their configuration here instead.
+	Schema map[string]any `json:"schema,omitempty"`
+}
+
+// Hash returns the hex-encoded SHA-256 of the definition's JSON encoding, so
+// that identical definitions sent in many requests are stored once and a
+// changed definition can be told apart from the one it replaced.
+func (t ToolDefinition) Hash() string {
+	// Maps are encoded with sorted keys, so equal schemas hash the same.
+	data, err := json.Marshal(t)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -42,3 +42,25 @@ func NewLoggerWithWriters(debug bool, writers ...io.Writer) *zap.Logger {
 
 	return zap.New(core, zap.AddCaller())
 }
+
+// NewStructuredLogger logs entries at or above level to file as JSON lines,
+// which Tail reads back, and to console, when not nil, as colored text.
+// Changing level changes what is logged while the logger runs.
+func NewStructuredLogger(level zap.AtomicLevel, file io.Writer, console io.Writer) *zap.Logger {
+	jsonConfig := zap.NewProductionEncoderConfig()
+	jsonConfig.TimeKey = "time"
+	jsonConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+	cores := []zapcore.Core{
+		zapcore.NewCore(zapcore.NewJSONEncoder(jsonConfig), zapcore.AddSync(file), level),
+	}
+	if console != nil {
+		consoleConfig := zap.NewProductionEncoderConfig()
+		consoleConfig.TimeKey = "time"
+		consoleConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		consoleConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		cores = append(cores, zapcore.NewCore(zapcore.NewConsoleEncoder(consoleConfig), zapcore.AddSync(console), level))
+	}
+
+	return zap.New(zapcore.NewTee(cores...), zap.AddCaller())
+}
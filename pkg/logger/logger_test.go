@@ -0,0 +1,173 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/papercomputeco/tapes/pkg/logger"
+)
+
+// syncBuffer is a bytes.Buffer safe to read while Tail writes to it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+var _ = Describe("RotatingFile", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), logger.DirName, logger.FileName)
+	})
+
+	It("rotates files past the size limit and keeps a bounded number", func() {
+		file, err := logger.OpenRotatingFile(path, 10, 2)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(file.Close)
+
+		for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+			_, err := file.Write([]byte(line))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(os.ReadFile(path)).To(BeEquivalentTo("fourth\n"))
+		Expect(os.ReadFile(path + ".1")).To(BeEquivalentTo("third\n"))
+		Expect(os.ReadFile(path + ".2")).To(BeEquivalentTo("second\n"))
+		Expect(path + ".3").NotTo(BeAnExistingFile())
+	})
+
+	It("appends to an existing file", func() {
+		Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+		Expect(os.WriteFile(path, []byte("old\n"), 0o600)).To(Succeed())
+
+		file, err := logger.OpenRotatingFile(path, 0, 0)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = file.Write([]byte("new\n"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		Expect(os.ReadFile(path)).To(BeEquivalentTo("old\nnew\n"))
+	})
+})
+
+var _ = Describe("NewStructuredLogger", func() {
+	It("writes JSON entries at or above a level that changes at runtime", func() {
+		var file, console bytes.Buffer
+		level := zap.NewAtomicLevelAt(zap.InfoLevel)
+		log := logger.NewStructuredLogger(level, &file, &console)
+
+		log.Debug("hidden")
+		log.Info("started", zap.String("listen", "127.0.0.1:8080"))
+		level.SetLevel(zap.DebugLevel)
+		log.Debug("shown")
+
+		lines := strings.Split(strings.TrimSpace(file.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+
+		entry := logger.ParseEntry(lines[0])
+		Expect(entry.Raw).To(BeEmpty())
+		Expect(entry.Level).To(Equal(zapcore.InfoLevel))
+		Expect(entry.Message).To(Equal("started"))
+		Expect(entry.Fields).To(HaveKeyWithValue("listen", "127.0.0.1:8080"))
+		Expect(entry.Time).To(BeTemporally("~", time.Now(), time.Minute))
+		Expect(logger.ParseEntry(lines[1]).Message).To(Equal("shown"))
+
+		Expect(console.String()).To(ContainSubstring("started"))
+		Expect(console.String()).NotTo(ContainSubstring("hidden"))
+	})
+})
+
+var _ = Describe("Tail", func() {
+	var path string
+
+	entry := func(level, msg string) string {
+		return `{"level":"` + level + `","time":"2026-01-02T03:04:05.000Z","caller":"start/start.go:1","msg":"` + msg + `"}` + "\n"
+	}
+
+	appendTo := func(path, data string) {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = f.WriteString(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.Close()).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), logger.FileName)
+	})
+
+	It("shows the most recent entries at or above a level", func() {
+		Expect(os.WriteFile(path, []byte(
+			entry("info", "one")+entry("warn", "two")+entry("error", "three")+
+				"panic: boom\n"+entry("warn", "four"),
+		), 0o600)).To(Succeed())
+
+		var out bytes.Buffer
+		Expect(logger.Tail(context.Background(), path, &out, logger.TailOptions{Level: zapcore.WarnLevel, Lines: 3})).To(Succeed())
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[0]).To(ContainSubstring("ERROR\tstart/start.go:1\tthree"))
+		Expect(lines[1]).To(Equal("panic: boom"))
+		Expect(lines[2]).To(ContainSubstring("WARN\tstart/start.go:1\tfour"))
+	})
+
+	It("shows every entry with negative lines and none with zero", func() {
+		Expect(os.WriteFile(path, []byte(entry("info", "one")+entry("info", "two")), 0o600)).To(Succeed())
+
+		var out bytes.Buffer
+		Expect(logger.Tail(context.Background(), path, &out, logger.TailOptions{Lines: -1})).To(Succeed())
+		Expect(strings.Count(out.String(), "\n")).To(Equal(2))
+
+		out.Reset()
+		Expect(logger.Tail(context.Background(), path, &out, logger.TailOptions{})).To(Succeed())
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	It("follows new entries across a rotation", func() {
+		Expect(os.WriteFile(path, []byte(entry("info", "old")), 0o600)).To(Succeed())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+		out := &syncBuffer{}
+		errChan := make(chan error, 1)
+		go func() {
+			errChan <- logger.Tail(ctx, path, out, logger.TailOptions{Level: zapcore.InfoLevel, Follow: true})
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		appendTo(path, entry("debug", "quiet")+entry("info", "before"))
+		Eventually(out.String, 2*time.Second, 50*time.Millisecond).Should(ContainSubstring("before"))
+
+		Expect(os.Rename(path, path+".1")).To(Succeed())
+		appendTo(path, entry("warn", "after"))
+		Eventually(out.String, 2*time.Second, 50*time.Millisecond).Should(ContainSubstring("after"))
+
+		Expect(out.String()).NotTo(ContainSubstring("old"))
+		Expect(out.String()).NotTo(ContainSubstring("quiet"))
+		cancel()
+		Eventually(errChan, 2*time.Second, 50*time.Millisecond).Should(Receive(MatchError(context.Canceled)))
+	})
+})
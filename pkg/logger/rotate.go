@@ -0,0 +1,115 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Defaults for daemon log files.
+const (
+	// DirName is the directory under .tapes/ that holds log files, and
+	// FileName the current log file in it.
+	DirName  = "logs"
+	FileName = "tapes.log"
+
+	// DefaultMaxBytes is the size a log file is rotated at, and DefaultKeep
+	// the number of rotated files kept.
+	DefaultMaxBytes = 10 << 20
+	DefaultKeep     = 5
+)
+
+// RotatingFile appends to a log file, moving it aside once a write would
+// take it past its size limit: tapes.log becomes tapes.log.1, tapes.log.1
+// becomes tapes.log.2, and so on, dropping files beyond the number kept.
+// It is safe for concurrent use.
+type RotatingFile struct {
+	path     string
+	maxBytes int64
+	keep     int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// OpenRotatingFile opens the log file at path for appending, creating it and
+// its directory as needed. A maxBytes or keep of zero uses the default.
+func OpenRotatingFile(path string, maxBytes int64, keep int) (*RotatingFile, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	if keep <= 0 {
+		keep = DefaultKeep
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating log dir: %w", err)
+	}
+
+	f := &RotatingFile{path: path, maxBytes: maxBytes, keep: keep}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// Write implements io.Writer. An entry larger than the size limit is
+// written to a file of its own rather than split.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.size > 0 && f.size+int64(len(p)) > f.maxBytes {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("rotating log file: %w", err)
+	}
+
+	_ = os.Remove(fmt.Sprintf("%s.%d", f.path, f.keep))
+	for i := f.keep - 1; i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", f.path, i), fmt.Sprintf("%s.%d", f.path, i+1))
+	}
+	if err := os.Rename(f.path, f.path+".1"); err != nil {
+		return fmt.Errorf("rotating log file: %w", err)
+	}
+	return f.open()
+}
+
+// Sync flushes the file to disk.
+func (f *RotatingFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Sync()
+}
+
+// Close closes the file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
@@ -0,0 +1,216 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap/zapcore"
+)
+
+// timeLayout is how zapcore.ISO8601TimeEncoder writes times.
+const timeLayout = "2006-01-02T15:04:05.000Z0700"
+
+// Entry is an entry read back from a log file written by
+// NewStructuredLogger.
+type Entry struct {
+	Time    time.Time
+	Level   zapcore.Level
+	Caller  string
+	Message string
+	Stack   string
+	Fields  map[string]any
+
+	// Raw holds lines that are not JSON entries, such as the output of a
+	// panic. They are shown at every level.
+	Raw string
+}
+
+// ParseEntry parses a line of a log file.
+func ParseEntry(line string) Entry {
+	line = strings.TrimRight(line, "\r\n")
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return Entry{Raw: line}
+	}
+
+	var e Entry
+	take := func(key string) string {
+		v, _ := fields[key].(string)
+		delete(fields, key)
+		return v
+	}
+	e.Time, _ = time.Parse(timeLayout, take("time"))
+	if err := e.Level.UnmarshalText([]byte(take("level"))); err != nil {
+		e.Level = zapcore.InfoLevel
+	}
+	e.Caller = take("caller")
+	e.Message = take("msg")
+	e.Stack = take("stacktrace")
+	if len(fields) > 0 {
+		e.Fields = fields
+	}
+	return e
+}
+
+// Format formats the entry like zap's console encoder, in local time.
+func (e Entry) Format() string {
+	if e.Raw != "" {
+		return e.Raw
+	}
+
+	parts := []string{e.Time.Local().Format(timeLayout), e.Level.CapitalString()}
+	if e.Caller != "" {
+		parts = append(parts, e.Caller)
+	}
+	parts = append(parts, e.Message)
+	if len(e.Fields) > 0 {
+		// Maps marshal with sorted keys, so fields are in a stable order.
+		data, err := json.Marshal(e.Fields)
+		if err == nil {
+			parts = append(parts, string(data))
+		}
+	}
+
+	line := strings.Join(parts, "\t")
+	if e.Stack != "" {
+		line += "\n" + e.Stack
+	}
+	return line
+}
+
+// TailOptions selects the entries Tail shows.
+type TailOptions struct {
+	// Level hides entries below it.
+	Level zapcore.Level
+
+	// Lines is how many of the most recent entries are shown; a negative
+	// number shows them all.
+	Lines int
+
+	// Follow keeps showing entries as they are logged, across rotations,
+	// until the context is done.
+	Follow bool
+}
+
+// Tail writes the entries of the log file at path to w, formatted for
+// reading.
+func Tail(ctx context.Context, path string, w io.Writer, opts TailOptions) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	show := func(line string) (string, bool) {
+		if strings.TrimSpace(line) == "" {
+			return "", false
+		}
+		e := ParseEntry(line)
+		if e.Raw == "" && e.Level < opts.Level {
+			return "", false
+		}
+		return e.Format() + "\n", true
+	}
+
+	reader := bufio.NewReader(file)
+	var recent []string
+	pending, err := readLines(reader, "", func(line string) error {
+		if formatted, ok := show(line); ok && opts.Lines != 0 {
+			recent = append(recent, formatted)
+			if opts.Lines > 0 && len(recent) > opts.Lines {
+				recent = recent[1:]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, line := range recent {
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	if !opts.Follow {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating log watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("watching log dir: %w", err)
+	}
+
+	write := func(line string) error {
+		if formatted, ok := show(line); ok {
+			_, err := io.WriteString(w, formatted)
+			return err
+		}
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event := <-watcher.Events:
+			if filepath.Clean(event.Name) != filepath.Clean(path) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			// Finish the file being read before switching to the new one
+			// a rotation created.
+			if pending, err = readLines(reader, pending, write); err != nil {
+				return err
+			}
+			if event.Op&fsnotify.Create != 0 {
+				next, err := os.Open(path)
+				if err != nil {
+					return fmt.Errorf("opening log file: %w", err)
+				}
+				_ = file.Close()
+				file, reader, pending = next, bufio.NewReader(next), ""
+				if pending, err = readLines(reader, pending, write); err != nil {
+					return err
+				}
+			}
+		case err := <-watcher.Errors:
+			return fmt.Errorf("log watcher error: %w", err)
+		}
+	}
+}
+
+// readLines calls fn with each complete line available from reader,
+// continuing the partial line pending, and returns the partial line left at
+// the end.
+func readLines(reader *bufio.Reader, pending string, fn func(string) error) (string, error) {
+	for {
+		chunk, err := reader.ReadString('\n')
+		pending += chunk
+		if errors.Is(err, io.EOF) {
+			return pending, nil
+		}
+		if err != nil {
+			return pending, fmt.Errorf("reading log file: %w", err)
+		}
+		if err := fn(pending); err != nil {
+			return "", err
+		}
+		pending = ""
+	}
+}
@@ -1,6 +1,9 @@
 package merkle
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"strings"
 
@@ -28,6 +31,69 @@ type Bucket struct {
 
 	// AgentName identifies the agent harness (e.g., "claude", "opencode", "codex")
 	AgentName string `json:"agent_name,omitempty"`
+
+	// SessionID identifies the agent session the node was captured in, so
+	// that concurrent sessions with identical messages are stored apart
+	SessionID string `json:"session_id,omitempty"`
+}
+
+// hashed returns the bucket as it is hashed. Where a block's payload is stored
+// is left out, so that a turn keeps its hash whichever blob or media store, if
+// any, holds its payloads: a block carrying a ContentHash commits to its
+// payload through that hash and its size, and is hashed without the payload
+// or its blob or media URI, whether the payload was stored or read back
+// inline.
+func (b Bucket) hashed() Bucket {
+	var content []llm.ContentBlock
+	for i, block := range b.Content {
+		if block.ContentHash == "" && block.BlobURI == "" && block.MediaURI == "" {
+			continue
+		}
+		if content == nil {
+			content = make([]llm.ContentBlock, len(b.Content))
+			copy(content, b.Content)
+		}
+		block.BlobURI = ""
+		block.MediaURI = ""
+		if block.ContentHash != "" {
+			*block.Payload() = ""
+			block.ImageBase64 = ""
+		}
+		content[i] = block
+	}
+	if content != nil {
+		b.Content = content
+	}
+	return b
+}
+
+// payloadsMatch reports whether every payload kept inline beside a
+// ContentHash matches it. Hashing leaves those payloads out, so they are
+// checked against their content hash instead. Inline images match the hash of
+// either their base64 text or the bytes it encodes.
+func (b *Bucket) payloadsMatch() bool {
+	for _, block := range b.Content {
+		if block.ContentHash == "" {
+			continue
+		}
+		if payload := block.Payload(); payload != &block.ImageBase64 && *payload != "" && !matchesHash([]byte(*payload), block.ContentHash) {
+			return false
+		}
+		if block.ImageBase64 != "" && !matchesHash([]byte(block.ImageBase64), block.ContentHash) {
+			data, err := base64.StdEncoding.DecodeString(block.ImageBase64)
+			if err != nil || !matchesHash(data, block.ContentHash) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesHash reports whether data has the SHA-256 hash, written as
+// "sha256:<hex>".
+func matchesHash(data []byte, hash string) bool {
+	sum := sha256.Sum256(data)
+	return hash == "sha256:"+hex.EncodeToString(sum[:])
 }
 
 // ExtractText returns the concatenated text content from the bucket's content blocks.
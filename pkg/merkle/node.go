@@ -2,10 +2,12 @@
 package merkle
 
 import (
+	"crypto/ed25519"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json/jsontext"
 	"encoding/json/v2"
+	"time"
 
 	"github.com/papercomputeco/tapes/pkg/llm"
 )
@@ -22,6 +24,12 @@ type Node struct {
 	// Bucket is the hashable content for the node.
 	Bucket Bucket `json:"bucket"`
 
+	// TurnIndex is the node's position in its conversation: 0 for the root
+	// and one more than its parent's for every other node. Unlike CreatedAt,
+	// it orders a transcript correctly across clock changes, imports, and
+	// turns stored within the same instant.
+	TurnIndex int `json:"turn_index"`
+
 	// StopReason indicates why generation stopped (only for responses)
 	// Values: "stop", "length", "tool_use", "end_turn", etc.
 	StopReason string `json:"stop_reason,omitempty"`
@@ -29,8 +37,62 @@ type Node struct {
 	// Usage contains token counts and timing (only for responses)
 	Usage *llm.Usage `json:"usage,omitempty"`
 
+	// Timing holds the proxy's request and response timestamps (only for responses)
+	Timing *llm.Timing `json:"timing,omitempty"`
+
+	// Error is the upstream error response (only for error nodes)
+	Error *llm.UpstreamError `json:"error,omitempty"`
+
 	// Project is the git repository or project name that produced this node
 	Project string `json:"project,omitempty"`
+
+	// User is the identity of the person whose agent produced this node
+	User string `json:"user,omitempty"`
+
+	// TraceID is the OpenTelemetry trace ID of the request that first stored
+	// this node, for correlating turns with the agent's own traces
+	TraceID string `json:"trace_id,omitempty"`
+
+	// RunID identifies the agent run, such as one launch by tapes start,
+	// that first stored this node
+	RunID string `json:"run_id,omitempty"`
+
+	// AgentVersion is the version of the agent that first stored this node,
+	// when it was reported
+	AgentVersion string `json:"agent_version,omitempty"`
+
+	// SystemPromptHash is the hash of the system prompt of the request that
+	// first stored this node (only for responses). The prompt is stored apart,
+	// once per distinct prompt.
+	SystemPromptHash string `json:"system_prompt_hash,omitempty"`
+
+	// PolicyViolations are the guardrail rules the turn broke, recorded by
+	// the proxy's policy engine (only for responses and error nodes)
+	PolicyViolations []llm.PolicyViolation `json:"policy_violations,omitempty"`
+
+	// SignerKey and Signature sign the node's SignedPayload with the
+	// Ed25519 key of the installation that first stored this node, when it
+	// signs nodes. They prove which machine produced the node, for whom and
+	// when, once sessions are aggregated elsewhere.
+	SignerKey []byte `json:"signer_key,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+
+	// SharedBy names who shared this node, for nodes imported from a shared
+	// session bundle
+	SharedBy string `json:"shared_by,omitempty"`
+
+	// SupersededBy is the hash of the node that replaced this one when its
+	// turn was reprocessed from raw payloads with a fixed parser
+	SupersededBy string `json:"superseded_by,omitempty"`
+
+	// EndedAt and EndReason mark the end of the session rooted at this node,
+	// when it went idle or the agent running it exited (only for roots)
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+	EndReason string     `json:"end_reason,omitempty"`
+
+	// CreatedAt is when the node was first stored. It is set by storage
+	// drivers; nodes stored with it set keep their original time.
+	CreatedAt time.Time `json:"created_at,omitzero"`
 }
 
 // NodeMeta contains optional metadata for a node that is stored
@@ -38,7 +100,17 @@ type Node struct {
 type NodeMeta struct {
 	StopReason string
 	Usage      *llm.Usage
+	Timing     *llm.Timing
+	Error      *llm.UpstreamError
 	Project    string
+	User       string
+	TraceID    string
+	RunID      string
+
+	AgentVersion string
+
+	SystemPromptHash string
+	PolicyViolations []llm.PolicyViolation
 }
 
 // NewNode creates a new node with the computed hash for the provided bucket.
@@ -51,19 +123,87 @@ func NewNode(bucket Bucket, parent *Node, metas ...NodeMeta) *Node {
 
 	if parent != nil {
 		n.ParentHash = &parent.Hash
+		n.TurnIndex = parent.TurnIndex + 1
 	}
 
 	// Apply optional metadata if provided
 	if len(metas) > 0 {
 		n.StopReason = metas[0].StopReason
 		n.Usage = metas[0].Usage
+		n.Timing = metas[0].Timing
+		n.Error = metas[0].Error
 		n.Project = metas[0].Project
+		n.User = metas[0].User
+		n.TraceID = metas[0].TraceID
+		n.RunID = metas[0].RunID
+		n.AgentVersion = metas[0].AgentVersion
+		n.SystemPromptHash = metas[0].SystemPromptHash
+		n.PolicyViolations = metas[0].PolicyViolations
 	}
 
 	n.Hash = n.computeHash()
 	return n
 }
 
+// Verify reports whether the node's Hash matches its parent link and bucket
+// content, i.e. the node has not been altered since it was created. Payloads
+// kept inline beside a content hash must match it, since the hash covers them
+// through it.
+func (n *Node) Verify() bool {
+	return n.computeHash() == n.Hash && n.Bucket.payloadsMatch()
+}
+
+// signedFields are the fields of a node its signature covers. The hash
+// covers its content and parent link; the rest are stored beside it.
+type signedFields struct {
+	Hash      string `json:"hash"`
+	User      string `json:"user"`
+	RunID     string `json:"run_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// SignedPayload returns the canonical encoding of the fields Sign signs: the
+// node's hash, user, run ID and creation time.
+func (n *Node) SignedPayload() []byte {
+	payload, err := json.Marshal(signedFields{
+		Hash:      n.Hash,
+		User:      n.User,
+		RunID:     n.RunID,
+		CreatedAt: n.CreatedAt.UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		panic("failed to marshal signed payload: " + err.Error())
+	}
+	return payload
+}
+
+// Sign signs the node's SignedPayload with key. Call it after the node is
+// complete, since the hash covers its content and parent link. A node
+// without a CreatedAt is given the current time first, which storage
+// drivers keep, so that the time it is stored at is the one signed.
+func (n *Node) Sign(key ed25519.PrivateKey) {
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	}
+	n.SignerKey = key.Public().(ed25519.PublicKey)
+	n.Signature = ed25519.Sign(key, n.SignedPayload())
+}
+
+// Signed reports whether the node carries a signature.
+func (n *Node) Signed() bool {
+	return len(n.SignerKey) > 0 || len(n.Signature) > 0
+}
+
+// VerifySignature reports whether the node's signature is a valid signature
+// of its SignedPayload by its signer key, i.e. its hash, user, run ID and
+// creation time are those it was signed with. Unsigned nodes do not verify.
+func (n *Node) VerifySignature() bool {
+	if len(n.SignerKey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(ed25519.PublicKey(n.SignerKey), n.SignedPayload(), n.Signature)
+}
+
 // ComputeHash calculates the content-addressed hash for a node
 func (n *Node) computeHash() string {
 	parent := ""
@@ -77,7 +217,7 @@ func (n *Node) computeHash() string {
 		Content Bucket `json:"content"`
 	}{
 		Parent:  parent,
-		Content: n.Bucket,
+		Content: n.Bucket.hashed(),
 	})
 	if err != nil {
 		panic("failed to marshal hash input: " + err.Error())
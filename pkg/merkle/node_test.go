@@ -1,6 +1,12 @@
 package merkle_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -145,6 +151,15 @@ var _ = Describe("Node", func() {
 				Expect(*child3.ParentHash).To(Equal(child2.Hash))
 			})
 
+			It("numbers turns from the root", func() {
+				child1 := merkle.NewNode(testBucket("child 1"), parent)
+				child2 := merkle.NewNode(testBucket("child 2"), child1)
+
+				Expect(parent.TurnIndex).To(Equal(0))
+				Expect(child1.TurnIndex).To(Equal(1))
+				Expect(child2.TurnIndex).To(Equal(2))
+			})
+
 			It("produces different hashes for same bucket with different parents", func() {
 				parent2 := merkle.NewNode(testBucket("different parent"), nil)
 				bucket := testBucket("same content")
@@ -156,6 +171,62 @@ var _ = Describe("Node", func() {
 		})
 	})
 
+	Describe("Sign", func() {
+		It("signs the hash so that it verifies against the signer key", func() {
+			pub, key, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			node := merkle.NewNode(testBucket("signed"), nil)
+			Expect(node.Signed()).To(BeFalse())
+			Expect(node.VerifySignature()).To(BeFalse())
+
+			node.Sign(key)
+			Expect(node.Signed()).To(BeTrue())
+			Expect([]byte(node.SignerKey)).To(Equal([]byte(pub)))
+			Expect(node.VerifySignature()).To(BeTrue())
+			Expect(node.Verify()).To(BeTrue())
+		})
+
+		It("does not verify a signature of another hash", func() {
+			_, key, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			node := merkle.NewNode(testBucket("signed"), nil)
+			node.Sign(key)
+			other := merkle.NewNode(testBucket("other"), nil)
+			other.SignerKey, other.Signature = node.SignerKey, node.Signature
+
+			Expect(other.VerifySignature()).To(BeFalse())
+		})
+
+		It("covers the user, run and creation time", func() {
+			_, key, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			node := merkle.NewNode(testBucket("signed"), nil, merkle.NodeMeta{User: "alice", RunID: "run-1"})
+			node.Sign(key)
+			Expect(node.CreatedAt.IsZero()).To(BeFalse())
+			Expect(node.VerifySignature()).To(BeTrue())
+
+			tampered := *node
+			tampered.User = "mallory"
+			Expect(tampered.VerifySignature()).To(BeFalse())
+
+			tampered = *node
+			tampered.RunID = "run-2"
+			Expect(tampered.VerifySignature()).To(BeFalse())
+
+			tampered = *node
+			tampered.CreatedAt = node.CreatedAt.Add(time.Hour)
+			Expect(tampered.VerifySignature()).To(BeFalse())
+
+			// The time is compared as an instant, whatever its zone.
+			tampered = *node
+			tampered.CreatedAt = node.CreatedAt.In(time.FixedZone("X", 3600))
+			Expect(tampered.VerifySignature()).To(BeTrue())
+		})
+	})
+
 	Describe("Hash computation", func() {
 		It("produces a valid SHA-256 hex string (64 characters)", func() {
 			node := merkle.NewNode(testBucket("test"), nil)
@@ -164,6 +235,79 @@ var _ = Describe("Node", func() {
 			Expect(node.Hash).To(MatchRegexp("^[a-f0-9]{64}$"))
 		})
 	})
+
+	Describe("Verify", func() {
+		It("accepts an unmodified node", func() {
+			parent := merkle.NewNode(testBucket("parent"), nil)
+			child := merkle.NewNode(testBucket("child"), parent)
+
+			Expect(parent.Verify()).To(BeTrue())
+			Expect(child.Verify()).To(BeTrue())
+		})
+
+		It("rejects altered content", func() {
+			node := merkle.NewNode(testBucket("original"), nil)
+			node.Bucket.Content[0].Text = "tampered"
+
+			Expect(node.Verify()).To(BeFalse())
+		})
+
+		It("rejects an altered parent link", func() {
+			parent := merkle.NewNode(testBucket("parent"), nil)
+			child := merkle.NewNode(testBucket("child"), parent)
+			other := "0000"
+			child.ParentHash = &other
+
+			Expect(child.Verify()).To(BeFalse())
+		})
+
+		It("ignores metadata outside the bucket", func() {
+			node := merkle.NewNode(testBucket("text"), nil)
+			node.StopReason = "end_turn"
+			node.Project = "tapes"
+
+			Expect(node.Verify()).To(BeTrue())
+		})
+	})
+
+	Describe("stored content", func() {
+		payload := "a payload large enough to offload"
+		sum := sha256.Sum256([]byte(payload))
+		contentHash := "sha256:" + hex.EncodeToString(sum[:])
+
+		offloaded := func(uri string) merkle.Bucket {
+			bucket := testBucket("")
+			bucket.Content[0].BlobURI = uri
+			bucket.Content[0].ContentHash = contentHash
+			bucket.Content[0].OriginalBytes = len(payload)
+			return bucket
+		}
+
+		It("leaves where a payload is stored out of the hash", func() {
+			local := merkle.NewNode(offloaded("file:///home/alice/.tapes/blobs/ab/abc"), nil)
+			remote := merkle.NewNode(offloaded("s3://bucket/blobs/def"), nil)
+			Expect(remote.Hash).To(Equal(local.Hash))
+
+			media := offloaded("")
+			media.Content[0].MediaURI = "file:///home/bob/.tapes/media/abc"
+			Expect(merkle.NewNode(media, nil).Hash).To(Equal(local.Hash))
+		})
+
+		It("hashes a payload read back inline like its reference", func() {
+			inline := offloaded("")
+			inline.Content[0].Text = payload
+			node := merkle.NewNode(inline, nil)
+
+			Expect(node.Hash).To(Equal(merkle.NewNode(offloaded("file:///blobs/abc"), nil).Hash))
+			Expect(node.Verify()).To(BeTrue())
+		})
+
+		It("rejects an inline payload that does not match its content hash", func() {
+			inline := offloaded("")
+			inline.Content[0].Text = "something else"
+			Expect(merkle.NewNode(inline, nil).Verify()).To(BeFalse())
+		})
+	})
 })
 
 var _ = Describe("Bucket", func() {
@@ -0,0 +1,330 @@
+// Package notify sends notifications on events of captured sessions: a
+// session ending, the day's spend crossing a budget, a guardrail policy
+// violation, or a streak of upstream errors. Rules select the events and
+// where their notifications go: the desktop, a Slack webhook, or a shell
+// command.
+//
+// Notifications are built from the proxy's live events, so like other
+// subscribers the notifier may miss events while it falls behind.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/cliui"
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/events"
+)
+
+// Events rules trigger on.
+const (
+	EventSessionEnded    = "session_ended"
+	EventBudget          = "budget"
+	EventPolicyViolation = "policy_violation"
+	EventErrorStreak     = "error_streak"
+)
+
+// Channels notifications are sent to.
+const (
+	ChannelDesktop = "desktop"
+	ChannelSlack   = "slack"
+	ChannelCommand = "command"
+)
+
+// DefaultStreak is the number of consecutive upstream errors in a session
+// that trigger an error_streak rule without a streak set.
+const DefaultStreak = 3
+
+// sendTimeout bounds each notification's delivery.
+const sendTimeout = 10 * time.Second
+
+// ruleNamePattern constrains rule names, which are passed to commands.
+var ruleNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// RuleSpec routes one kind of event to a channel. It is the [[notify.rules]]
+// config entry that New compiles.
+type RuleSpec struct {
+	Name string `toml:"name"`
+
+	// Event is the event that triggers the rule: EventSessionEnded,
+	// EventBudget, EventPolicyViolation, or EventErrorStreak.
+	Event string `toml:"event"`
+
+	// Channel is where notifications go: ChannelDesktop, ChannelSlack to
+	// Webhook, or ChannelCommand to run Command.
+	Channel string `toml:"channel"`
+	Webhook string `toml:"webhook,omitempty"`
+	Command string `toml:"command,omitempty"`
+
+	// Agent optionally limits the rule to sessions of agents whose name
+	// matches this regular expression.
+	Agent string `toml:"agent,omitempty"`
+
+	// Threshold is the spend in USD within a day that triggers a budget
+	// rule, once per day.
+	Threshold float64 `toml:"threshold,omitempty"`
+
+	// Streak is the number of consecutive upstream errors in a session that
+	// triggers an error_streak rule (defaults to DefaultStreak).
+	Streak int `toml:"streak,omitempty"`
+}
+
+// Notification is a message sent for an event.
+type Notification struct {
+	Rule    string    `json:"rule"`
+	Event   string    `json:"event"`
+	Title   string    `json:"title"`
+	Message string    `json:"message"`
+	Session string    `json:"session,omitempty"`
+	Agent   string    `json:"agent,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// Sender delivers notifications to a channel.
+type Sender interface {
+	Send(ctx context.Context, n Notification) error
+}
+
+// rule is a compiled RuleSpec.
+type rule struct {
+	name      string
+	event     string
+	agent     *regexp.Regexp
+	threshold float64
+	streak    int
+}
+
+func compileRule(spec RuleSpec) (rule, Sender, error) {
+	if !ruleNamePattern.MatchString(spec.Name) {
+		return rule{}, nil, fmt.Errorf("invalid notify rule name %q: use lowercase letters, digits, and underscores", spec.Name)
+	}
+
+	compiled := rule{name: spec.Name, event: spec.Event, threshold: spec.Threshold, streak: spec.Streak}
+	switch spec.Event {
+	case EventSessionEnded, EventPolicyViolation:
+	case EventBudget:
+		if spec.Threshold <= 0 {
+			return rule{}, nil, fmt.Errorf("notify rule %q needs a positive threshold in USD", spec.Name)
+		}
+	case EventErrorStreak:
+		if spec.Streak < 0 {
+			return rule{}, nil, fmt.Errorf("notify rule %q has a negative streak", spec.Name)
+		}
+		if compiled.streak == 0 {
+			compiled.streak = DefaultStreak
+		}
+	default:
+		return rule{}, nil, fmt.Errorf("invalid event %q for notify rule %q (expected %s, %s, %s, or %s)",
+			spec.Event, spec.Name, EventSessionEnded, EventBudget, EventPolicyViolation, EventErrorStreak)
+	}
+
+	var sender Sender
+	switch spec.Channel {
+	case ChannelDesktop:
+		sender = DesktopSender{}
+	case ChannelSlack:
+		if spec.Webhook == "" {
+			return rule{}, nil, fmt.Errorf("notify rule %q sends to slack but sets no webhook", spec.Name)
+		}
+		sender = &SlackSender{Webhook: spec.Webhook}
+	case ChannelCommand:
+		if spec.Command == "" {
+			return rule{}, nil, fmt.Errorf("notify rule %q runs a command but sets none", spec.Name)
+		}
+		sender = CommandSender{Command: spec.Command}
+	default:
+		return rule{}, nil, fmt.Errorf("invalid channel %q for notify rule %q (expected %s, %s, or %s)",
+			spec.Channel, spec.Name, ChannelDesktop, ChannelSlack, ChannelCommand)
+	}
+
+	if spec.Agent != "" {
+		var err error
+		if compiled.agent, err = regexp.Compile(spec.Agent); err != nil {
+			return rule{}, nil, fmt.Errorf("invalid agent pattern for notify rule %q: %w", spec.Name, err)
+		}
+	}
+	return compiled, sender, nil
+}
+
+// Notifier turns live events into notifications. It keeps the state that
+// budget and error streak rules need, and is not safe for concurrent use.
+type Notifier struct {
+	rules   []rule
+	senders map[string]Sender
+	pricing deck.PricingTable
+
+	// day is the local date the spend was accumulated on, and budgetsHit
+	// the budget rules already triggered that day.
+	day        string
+	spend      float64
+	budgetsHit map[string]bool
+
+	// streaks counts the consecutive upstream errors per session.
+	streaks map[string]int
+}
+
+// New compiles rule specs into a Notifier that prices turns for budget rules
+// with pricing.
+func New(specs []RuleSpec, pricing deck.PricingTable) (*Notifier, error) {
+	n := &Notifier{
+		senders:    map[string]Sender{},
+		pricing:    pricing,
+		budgetsHit: map[string]bool{},
+		streaks:    map[string]int{},
+	}
+	for _, spec := range specs {
+		compiled, sender, err := compileRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := n.senders[compiled.name]; dup {
+			return nil, fmt.Errorf("duplicate notify rule name %q", compiled.name)
+		}
+		n.rules = append(n.rules, compiled)
+		n.senders[compiled.name] = sender
+	}
+	return n, nil
+}
+
+// SetSender replaces the sender of every rule, for tests and dry runs.
+func (n *Notifier) SetSender(sender Sender) {
+	for name := range n.senders {
+		n.senders[name] = sender
+	}
+}
+
+// Run sends the notifications for events on stream, until ctx is cancelled
+// or stream is closed. Failed deliveries are logged.
+func (n *Notifier) Run(ctx context.Context, stream <-chan events.Event, logger *zap.Logger) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-stream:
+			if !ok {
+				return
+			}
+			for _, notification := range n.Notifications(event) {
+				sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+				err := n.senders[notification.Rule].Send(sendCtx, notification)
+				cancel()
+				if err != nil && ctx.Err() == nil {
+					logger.Warn("notify: sending notification failed",
+						zap.String("rule", notification.Rule),
+						zap.String("event", notification.Event),
+						zap.Error(err),
+					)
+				}
+			}
+		}
+	}
+}
+
+// Notifications returns the notifications event triggers, updating the
+// spend and error streaks it affects.
+func (n *Notifier) Notifications(event events.Event) []Notification {
+	var notifications []Notification
+	notify := func(r rule, title, message string) {
+		notifications = append(notifications, Notification{
+			Rule:    r.name,
+			Event:   r.event,
+			Title:   title,
+			Message: message,
+			Session: event.RootHash,
+			Agent:   event.AgentName,
+			Time:    event.Time,
+		})
+	}
+
+	switch event.Type {
+	case events.TypeSessionEnded:
+		delete(n.streaks, event.RootHash)
+		for _, r := range n.matching(EventSessionEnded, event) {
+			notify(r, "Session ended", fmt.Sprintf("%s session %s ended (%s)", agentLabel(event), cliui.ShortHash(event.RootHash), event.EndReason))
+		}
+
+	case events.TypeNodeCreated:
+		for _, v := range event.PolicyViolations {
+			for _, r := range n.matching(EventPolicyViolation, event) {
+				notify(r, "Policy violation", fmt.Sprintf("%s: %s (rule %s, %s)", agentLabel(event), v.Message, v.Rule, v.Action))
+			}
+		}
+
+		if event.Role == "assistant" {
+			n.trackStreak(event, notify)
+		}
+
+		if event.Usage != nil {
+			n.trackSpend(event, notify)
+		}
+	}
+	return notifications
+}
+
+// trackStreak counts a session's consecutive upstream errors, notifying when
+// the count reaches a rule's streak.
+func (n *Notifier) trackStreak(event events.Event, notify func(rule, string, string)) {
+	if event.StopReason != "error" {
+		delete(n.streaks, event.RootHash)
+		return
+	}
+
+	n.streaks[event.RootHash]++
+	streak := n.streaks[event.RootHash]
+	for _, r := range n.matching(EventErrorStreak, event) {
+		if streak == r.streak {
+			notify(r, "Upstream errors", fmt.Sprintf("%s session %s failed %d turns in a row, last with %s", agentLabel(event), cliui.ShortHash(event.RootHash), streak, event.Text))
+		}
+	}
+}
+
+// trackSpend adds the cost of a turn to the day's spend, notifying once per
+// day when it crosses a rule's threshold.
+func (n *Notifier) trackSpend(event events.Event, notify func(rule, string, string)) {
+	day := event.Time.Local().Format(time.DateOnly)
+	if day != n.day {
+		n.day = day
+		n.spend = 0
+		clear(n.budgetsHit)
+	}
+
+	pricing, ok := deck.PricingForModel(n.pricing, event.Model)
+	if !ok {
+		return
+	}
+	u := event.Usage
+	_, _, cost := deck.CostForTokensWithCache(pricing, int64(u.PromptTokens), int64(u.CompletionTokens), int64(u.CacheCreationInputTokens), int64(u.CacheReadInputTokens))
+	n.spend += cost
+
+	// Budgets are on the day's total spend, so agent patterns do not apply.
+	for _, r := range n.rules {
+		if r.event != EventBudget || n.budgetsHit[r.name] || n.spend < r.threshold {
+			continue
+		}
+		n.budgetsHit[r.name] = true
+		notify(r, "Budget threshold crossed", fmt.Sprintf("Spend today reached $%.2f, over the $%.2f threshold", n.spend, r.threshold))
+	}
+}
+
+// matching returns the rules for kind whose agent pattern matches event.
+func (n *Notifier) matching(kind string, event events.Event) []rule {
+	var matched []rule
+	for _, r := range n.rules {
+		if r.event == kind && (r.agent == nil || r.agent.MatchString(event.AgentName)) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+func agentLabel(event events.Event) string {
+	if event.AgentName == "" {
+		return "Agent"
+	}
+	return event.AgentName
+}
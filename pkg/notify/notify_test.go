@@ -0,0 +1,218 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/notify"
+)
+
+var noon = time.Date(2026, 3, 14, 12, 0, 0, 0, time.Local)
+
+func assistant(root, stopReason string) events.Event {
+	return events.Event{
+		Type:       events.TypeNodeCreated,
+		Time:       noon,
+		RootHash:   root,
+		Role:       "assistant",
+		AgentName:  "claude",
+		StopReason: stopReason,
+		Text:       "529 overloaded_error: Overloaded",
+	}
+}
+
+type recorder struct {
+	mu   sync.Mutex
+	sent []notify.Notification
+}
+
+func (r *recorder) Send(_ context.Context, n notify.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, n)
+	return nil
+}
+
+func (r *recorder) notifications() []notify.Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sent
+}
+
+var _ = Describe("Notifier", func() {
+	newNotifier := func(specs ...notify.RuleSpec) *notify.Notifier {
+		n, err := notify.New(specs, deck.PricingTable{"claude-sonnet-4-5": {Input: 3, Output: 15}})
+		Expect(err).NotTo(HaveOccurred())
+		return n
+	}
+
+	It("notifies when sessions end", func() {
+		n := newNotifier(notify.RuleSpec{Name: "ended", Event: notify.EventSessionEnded, Channel: notify.ChannelDesktop})
+
+		notifications := n.Notifications(events.SessionEnded("0123456789abcdef", "claude", "run-1", "idle", noon))
+		Expect(notifications).To(Equal([]notify.Notification{{
+			Rule:    "ended",
+			Event:   notify.EventSessionEnded,
+			Title:   "Session ended",
+			Message: "claude session 0123456789ab ended (idle)",
+			Session: "0123456789abcdef",
+			Agent:   "claude",
+			Time:    noon,
+		}}))
+	})
+
+	It("notifies on each policy violation of rules matching the agent", func() {
+		n := newNotifier(
+			notify.RuleSpec{Name: "codex_only", Event: notify.EventPolicyViolation, Channel: notify.ChannelDesktop, Agent: "^codex$"},
+			notify.RuleSpec{Name: "all", Event: notify.EventPolicyViolation, Channel: notify.ChannelDesktop},
+		)
+
+		event := assistant("root", "tool_use")
+		event.PolicyViolations = []llm.PolicyViolation{{Rule: "no_web", Action: "annotate", Tool: "WebFetch", Message: "WebFetch is a blocked tool"}}
+		notifications := n.Notifications(event)
+		Expect(notifications).To(HaveLen(1))
+		Expect(notifications[0].Rule).To(Equal("all"))
+		Expect(notifications[0].Message).To(Equal("claude: WebFetch is a blocked tool (rule no_web, annotate)"))
+	})
+
+	It("notifies once when a session's upstream errors reach the streak", func() {
+		n := newNotifier(notify.RuleSpec{Name: "errors", Event: notify.EventErrorStreak, Channel: notify.ChannelDesktop, Streak: 2})
+
+		Expect(n.Notifications(assistant("a", "error"))).To(BeEmpty())
+		Expect(n.Notifications(assistant("b", "error"))).To(BeEmpty())
+		Expect(n.Notifications(assistant("a", "end_turn"))).To(BeEmpty())
+		Expect(n.Notifications(assistant("a", "error"))).To(BeEmpty())
+
+		notifications := n.Notifications(assistant("a", "error"))
+		Expect(notifications).To(HaveLen(1))
+		Expect(notifications[0].Message).To(Equal("claude session a failed 2 turns in a row, last with 529 overloaded_error: Overloaded"))
+		Expect(n.Notifications(assistant("a", "error"))).To(BeEmpty())
+	})
+
+	It("notifies once a day when the day's spend crosses a budget", func() {
+		n := newNotifier(notify.RuleSpec{Name: "daily", Event: notify.EventBudget, Channel: notify.ChannelDesktop, Threshold: 1})
+
+		turn := func(at time.Time) events.Event {
+			event := assistant("root", "end_turn")
+			event.Time = at
+			event.Model = "claude-sonnet-4-5"
+			// $0.60 per turn.
+			event.Usage = &llm.Usage{PromptTokens: 100_000, CompletionTokens: 20_000}
+			return event
+		}
+
+		Expect(n.Notifications(turn(noon))).To(BeEmpty())
+		notifications := n.Notifications(turn(noon.Add(time.Minute)))
+		Expect(notifications).To(HaveLen(1))
+		Expect(notifications[0].Message).To(Equal("Spend today reached $1.20, over the $1.00 threshold"))
+		Expect(n.Notifications(turn(noon.Add(2 * time.Minute)))).To(BeEmpty())
+
+		tomorrow := noon.AddDate(0, 0, 1)
+		Expect(n.Notifications(turn(tomorrow))).To(BeEmpty())
+		Expect(n.Notifications(turn(tomorrow.Add(time.Minute)))).To(HaveLen(1))
+	})
+
+	It("sends the notifications of streamed events", func() {
+		n := newNotifier(notify.RuleSpec{Name: "ended", Event: notify.EventSessionEnded, Channel: notify.ChannelDesktop})
+		sent := &recorder{}
+		n.SetSender(sent)
+
+		broker := events.NewBroker()
+		stream, unsubscribe := broker.Subscribe(events.Filter{})
+		defer unsubscribe()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			n.Run(context.Background(), stream, zap.NewNop())
+		}()
+
+		broker.Publish(events.SessionEnded("root", "codex", "", "exit", noon))
+		Eventually(sent.notifications).Should(HaveLen(1))
+		broker.Close()
+		Eventually(done).Should(BeClosed())
+	})
+
+	It("rejects invalid rules", func() {
+		_, err := notify.New([]notify.RuleSpec{{Name: "x", Event: "session_started", Channel: notify.ChannelDesktop}}, nil)
+		Expect(err).To(MatchError(ContainSubstring(`invalid event "session_started"`)))
+
+		_, err = notify.New([]notify.RuleSpec{{Name: "x", Event: notify.EventBudget, Channel: notify.ChannelDesktop}}, nil)
+		Expect(err).To(MatchError(`notify rule "x" needs a positive threshold in USD`))
+
+		_, err = notify.New([]notify.RuleSpec{{Name: "x", Event: notify.EventSessionEnded, Channel: notify.ChannelSlack}}, nil)
+		Expect(err).To(MatchError(`notify rule "x" sends to slack but sets no webhook`))
+
+		_, err = notify.New([]notify.RuleSpec{
+			{Name: "x", Event: notify.EventSessionEnded, Channel: notify.ChannelDesktop},
+			{Name: "x", Event: notify.EventPolicyViolation, Channel: notify.ChannelDesktop},
+		}, nil)
+		Expect(err).To(MatchError(`duplicate notify rule name "x"`))
+	})
+})
+
+var _ = Describe("Senders", func() {
+	notification := notify.Notification{
+		Rule:    "ended",
+		Event:   notify.EventSessionEnded,
+		Title:   "Session ended",
+		Message: "claude session abc ended (idle)",
+		Session: "abc",
+		Agent:   "claude",
+		Time:    noon,
+	}
+
+	It("posts to Slack webhooks", func() {
+		var body map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			Expect(json.Unmarshal(data, &body)).To(Succeed())
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		sender := &notify.SlackSender{Webhook: server.URL}
+		Expect(sender.Send(context.Background(), notification)).To(Succeed())
+		Expect(body).To(Equal(map[string]string{"text": "*Session ended*\nclaude session abc ended (idle)"}))
+	})
+
+	It("reports Slack errors", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "invalid_token", http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		err := notify.PostSlack(context.Background(), nil, server.URL, "hi")
+		Expect(err).To(MatchError("slack webhook returned 403 Forbidden: invalid_token"))
+	})
+
+	It("runs commands with the notification in the environment and on stdin", func() {
+		out := filepath.Join(GinkgoT().TempDir(), "out")
+		sender := notify.CommandSender{Command: `{ echo "$TAPES_NOTIFY_EVENT $TAPES_NOTIFY_SESSION"; cat; } > ` + out}
+		Expect(sender.Send(context.Background(), notification)).To(Succeed())
+
+		data, err := os.ReadFile(out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(HavePrefix("session_ended abc\n{"))
+		Expect(string(data)).To(ContainSubstring(`"message":"claude session abc ended (idle)"`))
+	})
+
+	It("reports failing commands", func() {
+		err := notify.CommandSender{Command: "echo nope >&2; exit 3"}.Send(context.Background(), notification)
+		Expect(err).To(MatchError("running notify command: exit status 3: nope"))
+	})
+})
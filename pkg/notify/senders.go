@@ -0,0 +1,116 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// DesktopSender shows notifications with the desktop's notification center:
+// through osascript on macOS and notify-send on Linux.
+type DesktopSender struct{}
+
+// Send implements Sender.
+func (DesktopSender) Send(ctx context.Context, n Notification) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(n.Message), appleScriptString("tapes: "+n.Title))
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	case "linux":
+		cmd = exec.CommandContext(ctx, "notify-send", "--app-name=tapes", "tapes: "+n.Title, n.Message)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("showing desktop notification: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// SlackSender posts notifications to a Slack incoming webhook.
+type SlackSender struct {
+	Webhook string
+
+	// Client sends the requests (defaults to http.DefaultClient).
+	Client *http.Client
+}
+
+// Send implements Sender.
+func (s *SlackSender) Send(ctx context.Context, n Notification) error {
+	return PostSlack(ctx, s.Client, s.Webhook, fmt.Sprintf("*%s*\n%s", n.Title, n.Message))
+}
+
+// PostSlack posts a message of Slack mrkdwn text to an incoming webhook.
+func PostSlack(ctx context.Context, client *http.Client, webhook, text string) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("posting to slack: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// CommandSender runs a shell command for each notification. The command
+// receives the notification as JSON on stdin, and its fields in the
+// TAPES_NOTIFY_RULE, TAPES_NOTIFY_EVENT, TAPES_NOTIFY_TITLE,
+// TAPES_NOTIFY_MESSAGE, TAPES_NOTIFY_SESSION, and TAPES_NOTIFY_AGENT
+// environment variables.
+type CommandSender struct {
+	Command string
+}
+
+// Send implements Sender.
+func (s CommandSender) Send(ctx context.Context, n Notification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", s.Command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"TAPES_NOTIFY_RULE="+n.Rule,
+		"TAPES_NOTIFY_EVENT="+n.Event,
+		"TAPES_NOTIFY_TITLE="+n.Title,
+		"TAPES_NOTIFY_MESSAGE="+n.Message,
+		"TAPES_NOTIFY_SESSION="+n.Session,
+		"TAPES_NOTIFY_AGENT="+n.Agent,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("running notify command: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
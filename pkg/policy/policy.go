@@ -0,0 +1,246 @@
+// Package policy checks the tool calls in proxied traffic against guardrail
+// rules: tools that must not be called, shell commands that must not be run,
+// and a cap on the size of file writes.
+//
+// Responses are checked for the tool calls the model makes. Requests are
+// checked for the calls of their last assistant message, whose results they
+// send back, which catches calls in streamed responses that were relayed to
+// the agent before the stream ended.
+package policy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// Rule actions, from least to most severe.
+const (
+	// ActionWarn logs violations.
+	ActionWarn = "warn"
+
+	// ActionAnnotate logs violations and records them on the turn.
+	ActionAnnotate = "annotate"
+
+	// ActionBlock answers the turn with an error in place of forwarding it,
+	// and records the violations on the turn.
+	ActionBlock = "block"
+)
+
+// ruleNamePattern constrains rule names, which are recorded on nodes.
+var ruleNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// commandArgs are the tool arguments holding shell commands, and writeArgs
+// the arguments holding content written to files, in the tools of common
+// agents.
+var (
+	commandArgs = []string{"command", "cmd"}
+	writeArgs   = []string{"content", "contents", "file_text", "new_string"}
+)
+
+// RuleSpec describes a tool-call guardrail from the [[policy.rules]] table.
+// A rule applies to calls of the tools whose name matches Tool, or to all
+// tools when Tool is empty. With only Tool set, it forbids calling them;
+// Command forbids shell commands matching it, and MaxWriteBytes forbids
+// writing more than that many bytes to a file in one call.
+type RuleSpec struct {
+	Name string `toml:"name"`
+
+	// Action is ActionWarn (the default), ActionAnnotate, or ActionBlock.
+	Action string `toml:"action,omitempty"`
+
+	Tool          string `toml:"tool,omitempty"`
+	Command       string `toml:"command,omitempty"`
+	MaxWriteBytes int    `toml:"max_write_bytes,omitempty"`
+}
+
+// rule is a compiled RuleSpec. A nil tool pattern matches any tool.
+type rule struct {
+	name          string
+	action        string
+	tool          *regexp.Regexp
+	command       *regexp.Regexp
+	maxWriteBytes int
+}
+
+// check returns a description of how call breaks the rule, or "" if it does
+// not.
+func (r rule) check(call llm.ContentBlock) string {
+	if r.tool != nil && !r.tool.MatchString(call.ToolName) {
+		return ""
+	}
+
+	switch {
+	case r.command != nil:
+		for _, cmd := range commands(call.ToolInput) {
+			if r.command.MatchString(cmd) {
+				return fmt.Sprintf("%s call runs forbidden command %q", call.ToolName, cmd)
+			}
+		}
+	case r.maxWriteBytes > 0:
+		for _, key := range writeArgs {
+			if s, ok := call.ToolInput[key].(string); ok && len(s) > r.maxWriteBytes {
+				return fmt.Sprintf("%s call writes %d bytes, limit is %d", call.ToolName, len(s), r.maxWriteBytes)
+			}
+		}
+	default:
+		return fmt.Sprintf("%s is a blocked tool", call.ToolName)
+	}
+	return ""
+}
+
+// commands returns the shell commands in a tool call's arguments. Commands
+// given as argument vectors are joined with spaces.
+func commands(input map[string]any) []string {
+	var cmds []string
+	for _, key := range commandArgs {
+		switch v := input[key].(type) {
+		case string:
+			cmds = append(cmds, v)
+		case []any:
+			args := make([]string, 0, len(v))
+			for _, arg := range v {
+				if s, ok := arg.(string); ok {
+					args = append(args, s)
+				}
+			}
+			cmds = append(cmds, strings.Join(args, " "))
+		}
+	}
+	return cmds
+}
+
+// Engine checks tool calls against an ordered set of rules. It is safe for
+// concurrent use.
+type Engine struct {
+	rules []rule
+}
+
+// New compiles rule specs into an Engine.
+func New(specs []RuleSpec) (*Engine, error) {
+	e := &Engine{}
+	for _, spec := range specs {
+		compiled, err := compileRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		e.rules = append(e.rules, compiled)
+	}
+	return e, nil
+}
+
+func compileRule(spec RuleSpec) (rule, error) {
+	if !ruleNamePattern.MatchString(spec.Name) {
+		return rule{}, fmt.Errorf("invalid policy rule name %q: use lowercase letters, digits, and underscores", spec.Name)
+	}
+
+	compiled := rule{name: spec.Name, action: spec.Action, maxWriteBytes: spec.MaxWriteBytes}
+	switch spec.Action {
+	case "":
+		compiled.action = ActionWarn
+	case ActionWarn, ActionAnnotate, ActionBlock:
+	default:
+		return rule{}, fmt.Errorf("invalid action %q for policy rule %q (expected %s, %s, or %s)", spec.Action, spec.Name, ActionWarn, ActionAnnotate, ActionBlock)
+	}
+
+	var err error
+	if spec.Tool != "" {
+		if compiled.tool, err = regexp.Compile(spec.Tool); err != nil {
+			return rule{}, fmt.Errorf("invalid tool pattern for policy rule %q: %w", spec.Name, err)
+		}
+	}
+	if spec.Command != "" {
+		if compiled.command, err = regexp.Compile(spec.Command); err != nil {
+			return rule{}, fmt.Errorf("invalid command pattern for policy rule %q: %w", spec.Name, err)
+		}
+	}
+
+	switch {
+	case spec.MaxWriteBytes < 0:
+		return rule{}, fmt.Errorf("policy rule %q has a negative max_write_bytes", spec.Name)
+	case spec.Command != "" && spec.MaxWriteBytes > 0:
+		return rule{}, fmt.Errorf("policy rule %q sets both command and max_write_bytes: use one rule for each", spec.Name)
+	case spec.Tool == "" && spec.Command == "" && spec.MaxWriteBytes == 0:
+		return rule{}, fmt.Errorf("policy rule %q sets nothing to check: set at least one of tool, command, or max_write_bytes", spec.Name)
+	}
+	return compiled, nil
+}
+
+// CheckRequest returns the violations of the tool calls in the last
+// assistant message of req. A nil Engine finds none.
+func (e *Engine) CheckRequest(req *llm.ChatRequest) []llm.PolicyViolation {
+	if e == nil || req == nil {
+		return nil
+	}
+	for i := len(req.Messages) - 1; i >= 0; i-- {
+		if req.Messages[i].Role == "assistant" {
+			return e.check(req.Messages[i].Content)
+		}
+	}
+	return nil
+}
+
+// CheckResponse returns the violations of the tool calls in resp. A nil
+// Engine finds none.
+func (e *Engine) CheckResponse(resp *llm.ChatResponse) []llm.PolicyViolation {
+	if e == nil || resp == nil {
+		return nil
+	}
+	return e.check(resp.Message.Content)
+}
+
+func (e *Engine) check(blocks []llm.ContentBlock) []llm.PolicyViolation {
+	var violations []llm.PolicyViolation
+	for _, block := range blocks {
+		if block.Type != "tool_use" {
+			continue
+		}
+		for _, r := range e.rules {
+			if msg := r.check(block); msg != "" {
+				violations = append(violations, llm.PolicyViolation{
+					Rule:    r.name,
+					Action:  r.action,
+					Tool:    block.ToolName,
+					Message: msg,
+				})
+			}
+		}
+	}
+	return violations
+}
+
+// Blocked reports whether any of violations blocks its turn.
+func Blocked(violations []llm.PolicyViolation) bool {
+	for _, v := range violations {
+		if v.Action == ActionBlock {
+			return true
+		}
+	}
+	return false
+}
+
+// Recorded returns the violations that are recorded on their turn, which
+// are all but warnings.
+func Recorded(violations []llm.PolicyViolation) []llm.PolicyViolation {
+	var recorded []llm.PolicyViolation
+	for _, v := range violations {
+		if v.Action != ActionWarn {
+			recorded = append(recorded, v)
+		}
+	}
+	return recorded
+}
+
+// BlockMessage describes the blocking violations, for the error a blocked
+// turn is answered with.
+func BlockMessage(violations []llm.PolicyViolation) string {
+	var msgs []string
+	for _, v := range violations {
+		if v.Action == ActionBlock {
+			msgs = append(msgs, fmt.Sprintf("%s (rule %s)", v.Message, v.Rule))
+		}
+	}
+	return "blocked by tapes policy: " + strings.Join(msgs, "; ")
+}
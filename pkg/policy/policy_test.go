@@ -0,0 +1,111 @@
+package policy_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/policy"
+)
+
+func call(tool string, input map[string]any) llm.ContentBlock {
+	return llm.ContentBlock{Type: "tool_use", ToolUseID: "call_" + tool, ToolName: tool, ToolInput: input}
+}
+
+func response(blocks ...llm.ContentBlock) *llm.ChatResponse {
+	return &llm.ChatResponse{Message: llm.Message{Role: "assistant", Content: blocks}}
+}
+
+var _ = Describe("Engine", func() {
+	var engine *policy.Engine
+
+	BeforeEach(func() {
+		var err error
+		engine, err = policy.New([]policy.RuleSpec{
+			{Name: "no_web", Action: policy.ActionAnnotate, Tool: `^Web(Fetch|Search)$`},
+			{Name: "no_force_push", Action: policy.ActionBlock, Tool: `^Bash$`, Command: `git push .*--force`},
+			{Name: "small_writes", MaxWriteBytes: 10},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("finds nothing in responses without tool calls", func() {
+		Expect(engine.CheckResponse(response(llm.ContentBlock{Type: "text", Text: "git push --force"}))).To(BeEmpty())
+	})
+
+	It("flags calls of blocked tools", func() {
+		violations := engine.CheckResponse(response(call("WebFetch", map[string]any{"url": "https://example.com"})))
+		Expect(violations).To(Equal([]llm.PolicyViolation{{
+			Rule:    "no_web",
+			Action:  policy.ActionAnnotate,
+			Tool:    "WebFetch",
+			Message: "WebFetch is a blocked tool",
+		}}))
+		Expect(policy.Blocked(violations)).To(BeFalse())
+	})
+
+	It("flags forbidden shell commands, including argument vectors", func() {
+		violations := engine.CheckResponse(response(call("Bash", map[string]any{"command": "git push origin main --force"})))
+		Expect(violations).To(HaveLen(1))
+		Expect(violations[0].Message).To(Equal(`Bash call runs forbidden command "git push origin main --force"`))
+		Expect(policy.Blocked(violations)).To(BeTrue())
+		Expect(policy.BlockMessage(violations)).To(Equal(`blocked by tapes policy: Bash call runs forbidden command "git push origin main --force" (rule no_force_push)`))
+
+		Expect(engine.CheckResponse(response(call("Bash", map[string]any{"cmd": []any{"git", "push", "--force"}})))).To(HaveLen(1))
+		Expect(engine.CheckResponse(response(call("shell", map[string]any{"command": "git push --force"})))).To(BeEmpty())
+		Expect(engine.CheckResponse(response(call("Bash", map[string]any{"command": "git push"})))).To(BeEmpty())
+	})
+
+	It("flags oversized file writes, warning by default", func() {
+		violations := engine.CheckResponse(response(
+			call("Write", map[string]any{"file_path": "a.txt", "content": strings.Repeat("x", 11)}),
+			call("Edit", map[string]any{"file_path": "a.txt", "new_string": "short"}),
+		))
+		Expect(violations).To(Equal([]llm.PolicyViolation{{
+			Rule:    "small_writes",
+			Action:  policy.ActionWarn,
+			Tool:    "Write",
+			Message: "Write call writes 11 bytes, limit is 10",
+		}}))
+		Expect(policy.Recorded(violations)).To(BeEmpty())
+	})
+
+	It("checks the calls of a request's last assistant message", func() {
+		req := &llm.ChatRequest{Messages: []llm.Message{
+			{Role: "assistant", Content: []llm.ContentBlock{call("WebSearch", nil)}},
+			{Role: "user", Content: []llm.ContentBlock{{Type: "tool_result", ToolResultID: "call_WebSearch"}}},
+			{Role: "assistant", Content: []llm.ContentBlock{call("Bash", map[string]any{"command": "git push -f --force"})}},
+			{Role: "user", Content: []llm.ContentBlock{{Type: "tool_result", ToolResultID: "call_Bash"}}},
+		}}
+		violations := engine.CheckRequest(req)
+		Expect(violations).To(HaveLen(1))
+		Expect(violations[0].Rule).To(Equal("no_force_push"))
+
+		Expect(engine.CheckRequest(&llm.ChatRequest{Messages: []llm.Message{llm.NewTextMessage("user", "Hi")}})).To(BeEmpty())
+	})
+
+	It("finds nothing when nil", func() {
+		var none *policy.Engine
+		Expect(none.CheckResponse(response(call("WebFetch", nil)))).To(BeEmpty())
+		Expect(none.CheckRequest(&llm.ChatRequest{})).To(BeEmpty())
+	})
+
+	It("rejects invalid rules", func() {
+		_, err := policy.New([]policy.RuleSpec{{Name: "Bad Name", Tool: "x"}})
+		Expect(err).To(MatchError(ContainSubstring("invalid policy rule name")))
+
+		_, err = policy.New([]policy.RuleSpec{{Name: "stop", Action: "deny", Tool: "x"}})
+		Expect(err).To(MatchError(`invalid action "deny" for policy rule "stop" (expected warn, annotate, or block)`))
+
+		_, err = policy.New([]policy.RuleSpec{{Name: "empty"}})
+		Expect(err).To(MatchError(ContainSubstring("sets nothing to check")))
+
+		_, err = policy.New([]policy.RuleSpec{{Name: "both", Command: "rm", MaxWriteBytes: 1}})
+		Expect(err).To(MatchError(ContainSubstring("sets both command and max_write_bytes")))
+
+		_, err = policy.New([]policy.RuleSpec{{Name: "bad_pattern", Command: "("}})
+		Expect(err).To(MatchError(ContainSubstring(`invalid command pattern for policy rule "bad_pattern"`)))
+	})
+})
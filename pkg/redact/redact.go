@@ -0,0 +1,224 @@
+// Package redact removes secrets and personal data from conversation content
+// before it is hashed and stored.
+//
+// Each match is replaced with a marker of the form "[REDACTED:<rule>]" so that
+// redactions remain visible, and countable, in recorded sessions.
+package redact
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// markerPattern matches redaction markers in stored content.
+var markerPattern = regexp.MustCompile(`\[REDACTED:([a-z0-9_]+)\]`)
+
+// ruleNamePattern constrains rule names so markers stay unambiguous.
+var ruleNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// RuleSpec is a custom secret pattern from [[redaction.rules]] or the
+// redaction rules file; CompileRule turns it into a Rule.
+// When the pattern has a capture group, only the first group is redacted,
+// which lets a rule match on surrounding context such as a key name.
+type RuleSpec struct {
+	Name    string `toml:"name"`
+	Pattern string `toml:"pattern"`
+}
+
+// Rule is a compiled redaction rule.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// builtinSpecs are the detectors applied by every Redactor. Order matters:
+// more specific patterns run first so their markers carry the precise name.
+var builtinSpecs = []RuleSpec{
+	{Name: "private_key", Pattern: `-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`},
+	{Name: "anthropic_api_key", Pattern: `sk-ant-[A-Za-z0-9_\-]{20,}`},
+	{Name: "openai_api_key", Pattern: `sk-(?:proj-|svcacct-)?[A-Za-z0-9_\-]{20,}`},
+	{Name: "github_token", Pattern: `\bgh[pousr]_[A-Za-z0-9]{36,}\b`},
+	{Name: "slack_token", Pattern: `\bxox[abprs]-[A-Za-z0-9\-]{10,}`},
+	{Name: "aws_access_key_id", Pattern: `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`},
+	{Name: "aws_secret_access_key", Pattern: `(?i)aws_secret_access_key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})`},
+	{Name: "jwt", Pattern: `\beyJ[A-Za-z0-9_\-]+\.eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+`},
+	{Name: "email", Pattern: `\b[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}\b`},
+}
+
+// BuiltinRuleNames returns the names of the built-in detectors in the order
+// they are applied.
+func BuiltinRuleNames() []string {
+	names := make([]string, 0, len(builtinSpecs))
+	for _, spec := range builtinSpecs {
+		names = append(names, spec.Name)
+	}
+	return names
+}
+
+// CompileRule validates and compiles a rule spec.
+func CompileRule(spec RuleSpec) (Rule, error) {
+	if !ruleNamePattern.MatchString(spec.Name) {
+		return Rule{}, fmt.Errorf("invalid redaction rule name %q: use lowercase letters, digits, and underscores", spec.Name)
+	}
+
+	re, err := regexp.Compile(spec.Pattern)
+	if err != nil {
+		return Rule{}, fmt.Errorf("invalid pattern for redaction rule %q: %w", spec.Name, err)
+	}
+
+	return Rule{Name: spec.Name, Pattern: re}, nil
+}
+
+// Redactor applies an ordered set of rules to content and counts matches per rule.
+// It is safe for concurrent use.
+type Redactor struct {
+	rules []Rule
+
+	mu     sync.Mutex
+	counts map[string]uint64
+}
+
+// NewRedactor creates a Redactor with the built-in detectors followed by the
+// given custom rules.
+func NewRedactor(custom []RuleSpec) (*Redactor, error) {
+	specs := slices.Concat(builtinSpecs, custom)
+
+	rules := make([]Rule, 0, len(specs))
+	for _, spec := range specs {
+		rule, err := CompileRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return &Redactor{
+		rules:  rules,
+		counts: make(map[string]uint64),
+	}, nil
+}
+
+// RedactString replaces every rule match in s with its marker.
+func (r *Redactor) RedactString(s string) string {
+	if s == "" {
+		return s
+	}
+
+	for _, rule := range r.rules {
+		var n int
+		s, n = replace(rule, s)
+		if n > 0 {
+			r.mu.Lock()
+			r.counts[rule.Name] += uint64(n)
+			r.mu.Unlock()
+		}
+	}
+	return s
+}
+
+// RedactPayload replaces every rule match in a raw payload with its marker.
+// Matches are not counted, since they are counted when the content parsed
+// from the payload is redacted.
+func (r *Redactor) RedactPayload(s string) string {
+	for _, rule := range r.rules {
+		s, _ = replace(rule, s)
+	}
+	return s
+}
+
+// RedactBlocks returns a copy of blocks with text, thinking, tool inputs, and
+// tool outputs redacted. The input slice is never modified.
+func (r *Redactor) RedactBlocks(blocks []llm.ContentBlock) []llm.ContentBlock {
+	if len(blocks) == 0 {
+		return blocks
+	}
+
+	result := make([]llm.ContentBlock, len(blocks))
+	for i, block := range blocks {
+		block.Text = r.RedactString(block.Text)
+		block.Thinking = r.RedactString(block.Thinking)
+		block.ToolOutput = r.RedactString(block.ToolOutput)
+		if len(block.ToolInput) > 0 {
+			block.ToolInput, _ = r.redactValue(block.ToolInput).(map[string]any)
+		}
+		result[i] = block
+	}
+	return result
+}
+
+// Counts returns a snapshot of the number of matches redacted per rule.
+func (r *Redactor) Counts() map[string]uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return maps.Clone(r.counts)
+}
+
+// redactValue redacts strings nested anywhere in decoded JSON, copying
+// containers rather than mutating them.
+func (r *Redactor) redactValue(v any) any {
+	switch val := v.(type) {
+	case string:
+		return r.RedactString(val)
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, item := range val {
+			out[k] = r.redactValue(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, item := range val {
+			out[i] = r.redactValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// replace substitutes the rule's marker for each match in s, or for the first
+// capture group when the pattern has one. It returns the number of matches.
+func replace(rule Rule, s string) (string, int) {
+	matches := rule.Pattern.FindAllStringSubmatchIndex(s, -1)
+	if len(matches) == 0 {
+		return s, 0
+	}
+
+	marker := Marker(rule.Name)
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if len(m) >= 4 && m[2] >= 0 {
+			start, end = m[2], m[3]
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(marker)
+		last = end
+	}
+	b.WriteString(s[last:])
+
+	return b.String(), len(matches)
+}
+
+// Marker returns the replacement text for a rule.
+func Marker(rule string) string {
+	return "[REDACTED:" + rule + "]"
+}
+
+// CountMarkers adds the number of redaction markers per rule found in s to counts.
+func CountMarkers(s string, counts map[string]int) {
+	if !strings.Contains(s, "[REDACTED:") {
+		return
+	}
+	for _, m := range markerPattern.FindAllStringSubmatch(s, -1) {
+		counts[m[1]]++
+	}
+}
@@ -0,0 +1,158 @@
+// Package report builds digests of the sessions recorded over a period, for
+// posting to chat tools like Slack where team leads follow agent usage
+// without a dashboard.
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+)
+
+// Limits on the lists in a digest, which are meant to be read at a glance.
+const (
+	MaxTopModels      = 3
+	MaxFailedSessions = 5
+)
+
+// Digest summarizes the sessions active in a period: those with a turn
+// between From and To. Their costs include turns outside the period.
+type Digest struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+
+	Sessions  int     `json:"sessions"`
+	Completed int     `json:"completed"`
+	Failed    int     `json:"failed"`
+	TotalCost float64 `json:"total_cost"`
+
+	// TopModels are the models with the highest cost, most expensive first.
+	TopModels []deck.ModelCost `json:"top_models"`
+
+	// Errors are the models and providers that answered requests with
+	// upstream errors, most errors first, and ErrorRate the share of all
+	// requests that failed.
+	Errors    []deck.ErrorMetric `json:"errors"`
+	ErrorRate float64            `json:"error_rate"`
+
+	// FailedSessions are the most expensive of the failed sessions.
+	FailedSessions []deck.SessionSummary `json:"failed_sessions"`
+}
+
+// Build builds the digest of the sessions active between from and to.
+func Build(ctx context.Context, query *deck.Query, from, to time.Time) (*Digest, error) {
+	filters := deck.Filters{From: &from, To: &to}
+	overview, err := query.Overview(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("loading sessions: %w", err)
+	}
+	analytics, err := query.AnalyticsOverview(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("loading analytics: %w", err)
+	}
+
+	d := &Digest{
+		From:      from,
+		To:        to,
+		Sessions:  overview.TotalSessions,
+		Completed: overview.Completed,
+		Failed:    overview.Failed,
+		TotalCost: overview.TotalCost,
+		ErrorRate: analytics.ErrorRate,
+	}
+
+	for _, cost := range overview.CostByModel {
+		d.TopModels = append(d.TopModels, cost)
+	}
+	sort.Slice(d.TopModels, func(i, j int) bool {
+		if d.TopModels[i].TotalCost != d.TopModels[j].TotalCost {
+			return d.TopModels[i].TotalCost > d.TopModels[j].TotalCost
+		}
+		return d.TopModels[i].Model < d.TopModels[j].Model
+	})
+	d.TopModels = d.TopModels[:min(len(d.TopModels), MaxTopModels)]
+
+	for _, metric := range analytics.Errors {
+		if metric.Errors > 0 {
+			d.Errors = append(d.Errors, metric)
+		}
+	}
+
+	for _, session := range overview.Sessions {
+		if session.Status == deck.StatusFailed {
+			d.FailedSessions = append(d.FailedSessions, session)
+		}
+	}
+	sort.SliceStable(d.FailedSessions, func(i, j int) bool {
+		return d.FailedSessions[i].TotalCost > d.FailedSessions[j].TotalCost
+	})
+	d.FailedSessions = d.FailedSessions[:min(len(d.FailedSessions), MaxFailedSessions)]
+
+	return d, nil
+}
+
+// SlackText formats the digest as Slack mrkdwn.
+func (d *Digest) SlackText() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*tapes digest* for %s to %s\n", d.From.Format("Jan 2 15:04"), d.To.Format("Jan 2 15:04 MST"))
+
+	if d.Sessions == 0 {
+		b.WriteString("No sessions recorded.")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "*Sessions:* %d (%d completed, %d failed)\n", d.Sessions, d.Completed, d.Failed)
+	fmt.Fprintf(&b, "*Total cost:* $%.2f\n", d.TotalCost)
+
+	if len(d.TopModels) > 0 {
+		b.WriteString("*Top models:*\n")
+		for _, m := range d.TopModels {
+			fmt.Fprintf(&b, "• %s: $%.2f across %d sessions\n", m.Model, m.TotalCost, m.SessionCount)
+		}
+	}
+
+	if len(d.Errors) > 0 {
+		fmt.Fprintf(&b, "*Upstream errors:* %.1f%% of requests\n", d.ErrorRate*100)
+		for _, e := range d.Errors {
+			fmt.Fprintf(&b, "• %s (%s): %d of %d requests%s\n", e.Model, e.Provider, e.Errors, e.Requests, formatStatuses(e.Statuses))
+		}
+	}
+
+	if len(d.FailedSessions) > 0 {
+		b.WriteString("*Failed sessions:*\n")
+		for _, s := range d.FailedSessions {
+			fmt.Fprintf(&b, "• `%s` %s: $%.2f\n", shortID(s.ID), s.DisplayLabel(), s.TotalCost)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// formatStatuses lists the HTTP statuses of errors by count, as in
+// " (429 ×3, 529 ×1)".
+func formatStatuses(statuses map[int]int) string {
+	if len(statuses) == 0 {
+		return ""
+	}
+	codes := make([]int, 0, len(statuses))
+	for code := range statuses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		parts = append(parts, fmt.Sprintf("%d ×%d", code, statuses[code]))
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -0,0 +1,142 @@
+package report_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/report"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("Digest", func() {
+	var (
+		ctx   context.Context
+		query *deck.Query
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		put := func(kind, role, text, model string, parent *merkle.Node, meta merkle.NodeMeta) *merkle.Node {
+			n := merkle.NewNode(merkle.Bucket{
+				Type:     kind,
+				Role:     role,
+				Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+				Model:    model,
+				Provider: "anthropic",
+			}, parent, meta)
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			return n
+		}
+
+		// A session that ends in an overloaded error.
+		prompt := put("message", "user", "fix the build", "claude-sonnet-4-5", nil, merkle.NodeMeta{})
+		reply := put("message", "assistant", "on it", "claude-sonnet-4-5", prompt, merkle.NodeMeta{
+			StopReason: "end_turn",
+			Usage:      &llm.Usage{PromptTokens: 100_000, CompletionTokens: 10_000},
+		})
+		next := put("message", "user", "and the tests?", "claude-sonnet-4-5", reply, merkle.NodeMeta{})
+		put("error", "assistant", "529 overloaded_error: Overloaded", "claude-sonnet-4-5", next, merkle.NodeMeta{
+			StopReason: "error",
+			Error:      &llm.UpstreamError{Status: 529, Type: "overloaded_error", Message: "Overloaded"},
+		})
+
+		// A cheaper session that completes.
+		other := put("message", "user", "rename a variable", "claude-haiku-4-5", nil, merkle.NodeMeta{})
+		put("message", "assistant", "done", "claude-haiku-4-5", other, merkle.NodeMeta{
+			StopReason: "end_turn",
+			Usage:      &llm.Usage{PromptTokens: 10_000, CompletionTokens: 1_000},
+		})
+		Expect(driver.Close()).To(Succeed())
+
+		var closeFn func() error
+		query, closeFn, err = deck.NewQuery(ctx, dbPath, deck.DefaultPricing())
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(closeFn)
+	})
+
+	It("summarizes the sessions active in the period", func() {
+		now := time.Now()
+		digest, err := report.Build(ctx, query, now.Add(-24*time.Hour), now.Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(digest.Sessions).To(Equal(2))
+		Expect(digest.Completed).To(Equal(1))
+		Expect(digest.Failed).To(Equal(1))
+		Expect(digest.TotalCost).To(BeNumerically("~", 0.45+0.015, 1e-9))
+
+		Expect(digest.TopModels).To(HaveLen(2))
+		Expect(digest.TopModels[0].Model).To(Equal("claude-sonnet-4.5"))
+		Expect(digest.TopModels[1].Model).To(Equal("claude-haiku-4.5"))
+
+		Expect(digest.Errors).To(HaveLen(1))
+		Expect(digest.Errors[0].Statuses).To(Equal(map[int]int{529: 1}))
+		Expect(digest.FailedSessions).To(HaveLen(1))
+
+		text := digest.SlackText()
+		Expect(text).To(ContainSubstring("*Sessions:* 2 (1 completed, 1 failed)\n"))
+		Expect(text).To(ContainSubstring("*Total cost:* $0.47\n"))
+		Expect(text).To(ContainSubstring("• claude-sonnet-4.5: $0.45 across 1 sessions\n"))
+		Expect(text).To(ContainSubstring("*Upstream errors:* 33.3% of requests\n"))
+		Expect(text).To(ContainSubstring("• claude-sonnet-4.5 (anthropic): 1 of 2 requests (529 ×1)\n"))
+		Expect(text).To(MatchRegexp("\\*Failed sessions:\\*\n• `\\S+` fix the build / and the tests\\?: \\$0\\.45$"))
+	})
+
+	It("says when no sessions were recorded", func() {
+		from := time.Now().Add(-48 * time.Hour)
+		digest, err := report.Build(ctx, query, from, from.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(digest.Sessions).To(BeZero())
+		Expect(digest.SlackText()).To(HaveSuffix("\nNo sessions recorded."))
+	})
+
+	It("posts the digest to Slack", func() {
+		var body map[string]string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			Expect(json.Unmarshal(data, &body)).To(Succeed())
+		}))
+		defer server.Close()
+
+		now := time.Now()
+		digest, err := report.PostSlack(ctx, query, server.URL, now.Add(-24*time.Hour), now.Add(time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body["text"]).To(Equal(digest.SlackText()))
+	})
+})
+
+var _ = Describe("DailyTime", func() {
+	It("parses 24-hour times of day", func() {
+		at, err := report.ParseDailyTime("09:30")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(at).To(Equal(report.DailyTime{Hour: 9, Minute: 30}))
+		Expect(at.String()).To(Equal("09:30"))
+
+		_, err = report.ParseDailyTime("9am")
+		Expect(err).To(MatchError(`invalid time of day "9am": use 24-hour HH:MM`))
+	})
+
+	It("finds the next occurrence", func() {
+		at := report.DailyTime{Hour: 9}
+		morning := time.Date(2026, 3, 14, 8, 0, 0, 0, time.UTC)
+		Expect(at.Next(morning)).To(Equal(time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)))
+		Expect(at.Next(morning.Add(time.Hour))).To(Equal(time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)))
+		Expect(at.Next(time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC))).To(Equal(time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)))
+	})
+})
@@ -0,0 +1,35 @@
+package report
+
+import (
+	"fmt"
+	"time"
+)
+
+// DailyTime is a local time of day that a report is posted at.
+type DailyTime struct {
+	Hour   int
+	Minute int
+}
+
+// ParseDailyTime parses a 24-hour "HH:MM" time of day.
+func ParseDailyTime(s string) (DailyTime, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return DailyTime{}, fmt.Errorf("invalid time of day %q: use 24-hour HH:MM", s)
+	}
+	return DailyTime{Hour: t.Hour(), Minute: t.Minute()}, nil
+}
+
+// Next returns the first time after t that falls on the time of day, in t's
+// location.
+func (d DailyTime) Next(t time.Time) time.Time {
+	next := time.Date(t.Year(), t.Month(), t.Day(), d.Hour, d.Minute, 0, 0, t.Location())
+	if !next.After(t) {
+		next = time.Date(t.Year(), t.Month(), t.Day()+1, d.Hour, d.Minute, 0, 0, t.Location())
+	}
+	return next
+}
+
+func (d DailyTime) String() string {
+	return fmt.Sprintf("%02d:%02d", d.Hour, d.Minute)
+}
@@ -0,0 +1,49 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/deck"
+	"github.com/papercomputeco/tapes/pkg/notify"
+)
+
+// PostSlack builds the digest of the sessions active between from and to and
+// posts it to a Slack incoming webhook.
+func PostSlack(ctx context.Context, query *deck.Query, webhook string, from, to time.Time) (*Digest, error) {
+	digest, err := Build(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return digest, notify.PostSlack(ctx, nil, webhook, digest.SlackText())
+}
+
+// RunDailySlack posts the digest of the preceding day to webhook every day
+// at the given time, until ctx is cancelled. Failed posts are logged and
+// not retried.
+func RunDailySlack(ctx context.Context, query *deck.Query, webhook string, at DailyTime, logger *zap.Logger) {
+	for {
+		next := at.Next(time.Now())
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		digest, err := PostSlack(ctx, query, webhook, next.AddDate(0, 0, -1), next)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Warn("posting slack digest failed", zap.Error(err))
+			}
+			continue
+		}
+		logger.Info("posted slack digest",
+			zap.Int("sessions", digest.Sessions),
+			zap.Float64("total_cost", digest.TotalCost),
+		)
+	}
+}
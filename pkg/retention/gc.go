@@ -0,0 +1,98 @@
+package retention
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+)
+
+// Reasons a node is an orphan.
+const (
+	// OrphanMissingParent marks a node whose parent is not stored.
+	OrphanMissingParent = "missing_parent"
+
+	// OrphanCycle marks a node whose ancestry loops back on itself.
+	OrphanCycle = "cycle"
+
+	// OrphanDescendant marks a node below a missing parent or a cycle.
+	OrphanDescendant = "orphaned"
+)
+
+// Orphan is a node whose ancestry no longer reaches a root, such as one left
+// behind by a crash or a partial import. No session can reach it, so it is
+// garbage collected rather than pruned with a session.
+type Orphan struct {
+	Hash      string
+	Reason    string
+	CreatedAt time.Time
+}
+
+// PlanGC finds the orphaned nodes in the store without modifying it. Apply
+// deletes them.
+func (p *Pruner) PlanGC(ctx context.Context) (*Plan, error) {
+	nodes, err := p.loadNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Plan{Orphans: findOrphans(nodes)}, nil
+}
+
+// findOrphans returns the nodes whose ancestry does not end at a stored
+// root, ordered by hash.
+func findOrphans(nodes []*ent.Node) []Orphan {
+	byID := make(map[string]*ent.Node, len(nodes))
+	for _, n := range nodes {
+		byID[n.ID] = n
+	}
+
+	reaches := make(map[string]bool, len(nodes))
+	reasons := make(map[string]string)
+	for _, n := range nodes {
+		var path []string
+		onPath := make(map[string]int)
+
+		ok := true
+		for cur := n; ; {
+			if known, seen := reaches[cur.ID]; seen {
+				ok = known
+				break
+			}
+			if i, loops := onPath[cur.ID]; loops {
+				ok = false
+				for _, id := range path[i:] {
+					reasons[id] = OrphanCycle
+				}
+				break
+			}
+			onPath[cur.ID] = len(path)
+			path = append(path, cur.ID)
+
+			if cur.ParentHash == nil || *cur.ParentHash == "" {
+				break
+			}
+			parent, stored := byID[*cur.ParentHash]
+			if !stored {
+				ok = false
+				reasons[cur.ID] = OrphanMissingParent
+				break
+			}
+			cur = parent
+		}
+
+		for _, id := range path {
+			reaches[id] = ok
+			if !ok && reasons[id] == "" {
+				reasons[id] = OrphanDescendant
+			}
+		}
+	}
+
+	orphans := make([]Orphan, 0, len(reasons))
+	for id, reason := range reasons {
+		orphans = append(orphans, Orphan{Hash: id, Reason: reason, CreatedAt: byID[id].CreatedAt})
+	}
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].Hash < orphans[j].Hash })
+	return orphans
+}
@@ -0,0 +1,452 @@
+// Package retention enforces limits on how much conversation history tapes
+// keeps. Whole session trees are deleted at once, so pruning never leaves a
+// node whose ancestry has been removed. Nodes orphaned some other way, such
+// as by a crash or a partial import, are garbage collected on request.
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/audit"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	entdriver "github.com/papercomputeco/tapes/pkg/storage/ent/driver"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/facet"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/rawcapture"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/sessiontool"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+// deleteBatchSize bounds the number of IDs in a single IN clause, keeping
+// well below SQLite's host parameter limit.
+const deleteBatchSize = 500
+
+// ErrStalePlan is returned by Apply when a turn was added to a planned
+// session after the plan was made. Deleting the session would orphan the new
+// turn, so nothing is deleted and the policy must be planned again.
+var ErrStalePlan = errors.New("sessions changed since the prune was planned")
+
+// Policy describes the limits a Pruner enforces. A zero value for any field
+// disables that limit.
+type Policy struct {
+	// MaxAge removes sessions whose most recent turn is older than this.
+	MaxAge time.Duration
+
+	// MaxDBSize removes the oldest sessions until the database is expected
+	// to fit within this many bytes.
+	MaxDBSize int64
+
+	// MaxSessions keeps only this many of the most recently active sessions.
+	MaxSessions int
+}
+
+// ParsePolicy builds a Policy from its config representation, e.g.
+// ("30d", "2GB", 1000). Empty strings leave the corresponding limit unset.
+func ParsePolicy(maxAge, maxDBSize string, maxSessions uint) (Policy, error) {
+	var p Policy
+	if maxAge != "" {
+		d, err := utils.ParseDuration(maxAge)
+		if err != nil {
+			return p, fmt.Errorf("invalid max age: %w", err)
+		}
+		p.MaxAge = d
+	}
+	if maxDBSize != "" {
+		n, err := utils.ParseSize(maxDBSize)
+		if err != nil {
+			return p, fmt.Errorf("invalid max database size: %w", err)
+		}
+		p.MaxDBSize = n
+	}
+	p.MaxSessions = int(maxSessions) //nolint:gosec // config values are small
+	return p, nil
+}
+
+// IsZero reports whether the policy enforces no limits.
+func (p Policy) IsZero() bool {
+	return p.MaxAge <= 0 && p.MaxDBSize <= 0 && p.MaxSessions <= 0
+}
+
+// Session is a tree of nodes sharing a single root, the unit of deletion.
+type Session struct {
+	// Root is the hash of the tree's root node.
+	Root string
+
+	// NodeIDs holds every node in the tree, root included.
+	NodeIDs []string
+
+	// LastActivity is the creation time of the newest node in the tree.
+	LastActivity time.Time
+
+	// Reason names the limit that selected the session for deletion.
+	Reason string
+}
+
+// Plan is the set of sessions and orphaned nodes a Pruner would delete.
+type Plan struct {
+	Sessions []Session
+
+	// Nodes is the total number of nodes across Sessions.
+	Nodes int
+
+	// TotalSessions is the number of sessions in the database.
+	TotalSessions int
+
+	// Orphans are the nodes whose ancestry does not reach a root. They
+	// belong to no session, so they are only planned by PlanGC, or by Plan
+	// when the Pruner's GC is set.
+	Orphans []Orphan
+}
+
+// Empty reports whether the plan deletes nothing.
+func (p *Plan) Empty() bool {
+	return len(p.Sessions) == 0 && len(p.Orphans) == 0
+}
+
+// Result summarizes an applied Plan.
+type Result struct {
+	Sessions int
+	Nodes    int
+	Facets   int
+
+	// Orphans is the number of orphaned nodes deleted, which are not
+	// counted in Nodes.
+	Orphans int
+
+	// Files is the number of blob and media files deleted because no
+	// surviving node references them.
+	Files int
+
+	// ReclaimedBytes is the reduction in database file size after vacuuming.
+	ReclaimedBytes int64
+}
+
+// AuditDetails describes the result for an audit log entry.
+func (r *Result) AuditDetails() map[string]string {
+	return map[string]string{
+		"sessions":        strconv.Itoa(r.Sessions),
+		"nodes":           strconv.Itoa(r.Nodes),
+		"facets":          strconv.Itoa(r.Facets),
+		"orphans":         strconv.Itoa(r.Orphans),
+		"files":           strconv.Itoa(r.Files),
+		"reclaimed_bytes": strconv.FormatInt(r.ReclaimedBytes, 10),
+	}
+}
+
+// Pruner deletes sessions from a SQLite store according to a Policy.
+type Pruner struct {
+	driver *sqlite.Driver
+	policy Policy
+
+	// Audit, when set, records each background prune that deletes sessions.
+	Audit *audit.Log
+
+	// GC, when set, makes Plan include orphaned nodes, so that pruning also
+	// garbage collects them.
+	GC bool
+
+	// now is overridable for tests.
+	now func() time.Time
+}
+
+// NewPruner creates a Pruner that enforces policy against driver.
+func NewPruner(driver *sqlite.Driver, policy Policy) *Pruner {
+	return &Pruner{
+		driver: driver,
+		policy: policy,
+		now:    time.Now,
+	}
+}
+
+// Plan determines which sessions violate the policy without modifying the
+// database. Sessions past MaxAge are selected first, then the oldest
+// sessions beyond MaxSessions, then the oldest remaining sessions until the
+// estimated database size is within MaxDBSize. Orphaned nodes are included
+// only when GC is set.
+func (p *Pruner) Plan(ctx context.Context) (*Plan, error) {
+	nodes, err := p.loadNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orphans := findOrphans(nodes)
+	sessions, totalNodes := groupSessions(nodes, orphans)
+
+	plan := &Plan{TotalSessions: len(sessions)}
+	if p.GC {
+		plan.Orphans = orphans
+	}
+	if p.policy.IsZero() || len(sessions) == 0 {
+		return plan, nil
+	}
+
+	// Oldest first.
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastActivity.Before(sessions[j].LastActivity)
+	})
+
+	selected := 0
+	if p.policy.MaxAge > 0 {
+		cutoff := p.now().Add(-p.policy.MaxAge)
+		for selected < len(sessions) && sessions[selected].LastActivity.Before(cutoff) {
+			sessions[selected].Reason = "max_age"
+			selected++
+		}
+	}
+
+	if p.policy.MaxSessions > 0 {
+		for selected < len(sessions)-p.policy.MaxSessions {
+			sessions[selected].Reason = "max_sessions"
+			selected++
+		}
+	}
+
+	if p.policy.MaxDBSize > 0 && totalNodes > 0 {
+		size, err := p.driver.Size(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Attribute database size to sessions in proportion to node count.
+		perNode := float64(size) / float64(totalNodes+len(orphans))
+		remaining := totalNodes
+		for i := range selected {
+			remaining -= len(sessions[i].NodeIDs)
+		}
+		for selected < len(sessions) && int64(float64(remaining)*perNode) > p.policy.MaxDBSize {
+			sessions[selected].Reason = "max_db_size"
+			remaining -= len(sessions[selected].NodeIDs)
+			selected++
+		}
+	}
+
+	plan.Sessions = sessions[:selected]
+	for _, s := range plan.Sessions {
+		plan.Nodes += len(s.NodeIDs)
+	}
+	return plan, nil
+}
+
+// Apply deletes every session and orphan in plan along with their facets, raw
+// captures, session tool rows and search index entries in a single
+// transaction, failing with ErrStalePlan if any of them gained a turn since
+// plan was made. It then deletes the blob and media files no surviving node
+// references and vacuums the database to return the space to the filesystem.
+func (p *Pruner) Apply(ctx context.Context, plan *Plan) (*Result, error) {
+	result := &Result{}
+	if plan == nil || plan.Empty() {
+		return result, nil
+	}
+
+	before, err := p.driver.FileSize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, plan.Nodes+len(plan.Orphans))
+	for _, s := range plan.Sessions {
+		ids = append(ids, s.NodeIDs...)
+	}
+	for _, o := range plan.Orphans {
+		ids = append(ids, o.Hash)
+	}
+
+	files, err := entdriver.OffloadedFiles(ctx, p.driver.Client, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := p.driver.Client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// The plan was made outside the transaction, so a turn appended since
+	// then would be left without its ancestry.
+	if err := checkChildren(ctx, tx, ids); err != nil {
+		return nil, rollback(tx, err)
+	}
+
+	for start := 0; start < len(ids); start += deleteBatchSize {
+		batch := ids[start:min(start+deleteBatchSize, len(ids))]
+
+		// Facets are keyed by leaf hash, so any node in the tree may own one.
+		n, err := tx.Facet.Delete().Where(facet.SessionIDIn(batch...)).Exec(ctx)
+		if err != nil {
+			return nil, rollback(tx, fmt.Errorf("failed to delete facets: %w", err))
+		}
+		result.Facets += n
+
+		if _, err := tx.Session.Delete().Where(session.IDIn(batch...)).Exec(ctx); err != nil {
+			return nil, rollback(tx, fmt.Errorf("failed to delete sessions: %w", err))
+		}
+
+		if _, err := tx.RawCapture.Delete().Where(rawcapture.NodeHashIn(batch...)).Exec(ctx); err != nil {
+			return nil, rollback(tx, fmt.Errorf("failed to delete raw captures: %w", err))
+		}
+
+		if _, err := tx.SessionTool.Delete().Where(sessiontool.NodeHashIn(batch...)).Exec(ctx); err != nil {
+			return nil, rollback(tx, fmt.Errorf("failed to delete session tools: %w", err))
+		}
+
+		n, err = tx.Node.Delete().Where(node.IDIn(batch...)).Exec(ctx)
+		if err != nil {
+			return nil, rollback(tx, fmt.Errorf("failed to delete nodes: %w", err))
+		}
+		result.Nodes += n
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit prune: %w", err)
+	}
+	result.Sessions = len(plan.Sessions)
+	result.Orphans = min(len(plan.Orphans), result.Nodes)
+	result.Nodes -= result.Orphans
+
+	// Content blocks may be shared with surviving sessions, so they are
+	// removed only once nothing references them.
+	if _, err := p.driver.PruneBlocks(ctx); err != nil {
+		return result, err
+	}
+
+	// Blob and media files are named by content and may be shared too.
+	result.Files, err = entdriver.RemoveUnreferencedFiles(ctx, p.driver.Client, files)
+	if err != nil {
+		return result, err
+	}
+
+	if err := p.driver.Vacuum(ctx); err != nil {
+		return result, err
+	}
+
+	after, err := p.driver.FileSize(ctx)
+	if err != nil {
+		return result, err
+	}
+	result.ReclaimedBytes = max(before-after, 0)
+
+	return result, nil
+}
+
+// Prune plans and applies the policy in one step, planning again once if a
+// session changed in between.
+func (p *Pruner) Prune(ctx context.Context) (*Result, error) {
+	plan, err := p.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := p.Apply(ctx, plan)
+	if !errors.Is(err, ErrStalePlan) {
+		return result, err
+	}
+
+	plan, err = p.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.Apply(ctx, plan)
+}
+
+// checkChildren returns ErrStalePlan if a node outside ids has its parent
+// among them.
+func checkChildren(ctx context.Context, tx *ent.Tx, ids []string) error {
+	planned := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		planned[id] = true
+	}
+
+	for start := 0; start < len(ids); start += deleteBatchSize {
+		batch := ids[start:min(start+deleteBatchSize, len(ids))]
+		children, err := tx.Node.Query().Where(node.ParentHashIn(batch...)).IDs(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check for new turns: %w", err)
+		}
+		for _, child := range children {
+			if !planned[child] {
+				return fmt.Errorf("%w: %s was added", ErrStalePlan, child)
+			}
+		}
+	}
+	return nil
+}
+
+// loadNodes loads the ancestry links and creation times of every node.
+func (p *Pruner) loadNodes(ctx context.Context) ([]*ent.Node, error) {
+	nodes, err := p.driver.Client.Node.Query().
+		Select(node.FieldID, node.FieldParentHash, node.FieldCreatedAt).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load nodes: %w", err)
+	}
+	return nodes, nil
+}
+
+// groupSessions groups every node but the orphans into trees by root,
+// returning the trees and their total node count.
+func groupSessions(nodes []*ent.Node, orphans []Orphan) ([]Session, int) {
+	orphaned := make(map[string]bool, len(orphans))
+	for _, o := range orphans {
+		orphaned[o.Hash] = true
+	}
+
+	parents := make(map[string]string, len(nodes))
+	for _, n := range nodes {
+		if n.ParentHash != nil {
+			parents[n.ID] = *n.ParentHash
+		}
+	}
+
+	rootOf := make(map[string]string, len(nodes))
+	var findRoot func(id string) string
+	findRoot = func(id string) string {
+		if root, ok := rootOf[id]; ok {
+			return root
+		}
+		root := id
+		if parent, ok := parents[id]; ok {
+			root = findRoot(parent)
+		}
+		rootOf[id] = root
+		return root
+	}
+
+	byRoot := make(map[string]*Session)
+	order := []string{}
+	total := 0
+	for _, n := range nodes {
+		if orphaned[n.ID] {
+			continue
+		}
+		total++
+		root := findRoot(n.ID)
+		s, ok := byRoot[root]
+		if !ok {
+			s = &Session{Root: root}
+			byRoot[root] = s
+			order = append(order, root)
+		}
+		s.NodeIDs = append(s.NodeIDs, n.ID)
+		if n.CreatedAt.After(s.LastActivity) {
+			s.LastActivity = n.CreatedAt
+		}
+	}
+
+	sessions := make([]Session, 0, len(order))
+	for _, root := range order {
+		sessions = append(sessions, *byRoot[root])
+	}
+	return sessions, total
+}
+
+func rollback(tx *ent.Tx, err error) error {
+	if rerr := tx.Rollback(); rerr != nil {
+		return fmt.Errorf("%w: rollback failed: %w", err, rerr)
+	}
+	return err
+}
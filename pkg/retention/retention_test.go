@@ -0,0 +1,393 @@
+package retention_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/retention"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("ParsePolicy", func() {
+	It("parses config values", func() {
+		p, err := retention.ParsePolicy("30d", "1MiB", 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.MaxAge).To(Equal(30 * 24 * time.Hour))
+		Expect(p.MaxDBSize).To(Equal(int64(1 << 20)))
+		Expect(p.MaxSessions).To(Equal(10))
+		Expect(p.IsZero()).To(BeFalse())
+	})
+
+	It("treats empty values as unset", func() {
+		p, err := retention.ParsePolicy("", "", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(p.IsZero()).To(BeTrue())
+	})
+
+	It("rejects invalid values", func() {
+		_, err := retention.ParsePolicy("soon", "", 0)
+		Expect(err).To(HaveOccurred())
+		_, err = retention.ParsePolicy("", "huge", 0)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Pruner", func() {
+	var (
+		ctx    context.Context
+		driver *sqlite.Driver
+		dbPath string
+		now    time.Time
+	)
+
+	// addChain stores a linear session of ids, each one turn newer than the
+	// last, with the final node created at last.
+	addChain := func(last time.Time, ids ...string) {
+		var parent *string
+		for i, id := range ids {
+			created := last.Add(-time.Duration(len(ids)-1-i) * time.Minute)
+			_, err := driver.Client.Node.Create().
+				SetID(id).
+				SetNillableParentHash(parent).
+				SetCreatedAt(created).
+				Save(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			parent = &id
+		}
+	}
+
+	remaining := func() []string {
+		ids, err := driver.Client.Node.Query().IDs(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		return ids
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Now()
+
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+
+		var err error
+		driver, err = sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		addChain(now.Add(-60*24*time.Hour), "old-1", "old-2", "old-3")
+		addChain(now.Add(-10*24*time.Hour), "mid-1", "mid-2")
+		addChain(now.Add(-time.Hour), "new-1", "new-2")
+	})
+
+	AfterEach(func() {
+		Expect(driver.Close()).To(Succeed())
+	})
+
+	It("selects sessions older than the max age", func() {
+		p := retention.NewPruner(driver, retention.Policy{MaxAge: 30 * 24 * time.Hour})
+		plan, err := p.Plan(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(plan.TotalSessions).To(Equal(3))
+		Expect(plan.Sessions).To(HaveLen(1))
+		Expect(plan.Sessions[0].Root).To(Equal("old-1"))
+		Expect(plan.Sessions[0].Reason).To(Equal("max_age"))
+		Expect(plan.Nodes).To(Equal(3))
+	})
+
+	It("does not modify the database when only planning", func() {
+		p := retention.NewPruner(driver, retention.Policy{MaxAge: time.Minute})
+		plan, err := p.Plan(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Sessions).To(HaveLen(3))
+
+		Expect(remaining()).To(HaveLen(7))
+	})
+
+	It("keeps only the most recent sessions", func() {
+		p := retention.NewPruner(driver, retention.Policy{MaxSessions: 1})
+		result, err := p.Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result.Sessions).To(Equal(2))
+		Expect(result.Nodes).To(Equal(5))
+		Expect(remaining()).To(ConsistOf("new-1", "new-2"))
+	})
+
+	It("deletes whole trees including branches", func() {
+		branch := "old-2"
+		_, err := driver.Client.Node.Create().
+			SetID("old-branch").
+			SetParentHash(branch).
+			SetCreatedAt(now.Add(-59 * 24 * time.Hour)).
+			Save(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		p := retention.NewPruner(driver, retention.Policy{MaxAge: 30 * 24 * time.Hour})
+		result, err := p.Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Nodes).To(Equal(4))
+
+		Expect(remaining()).To(ConsistOf("mid-1", "mid-2", "new-1", "new-2"))
+
+		orphans, err := driver.Client.Node.Query().
+			Where(node.ParentHashIsNil(), node.IDNEQ("mid-1"), node.IDNEQ("new-1")).
+			Count(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orphans).To(BeZero())
+	})
+
+	It("refuses a plan whose sessions gained a turn", func() {
+		p := retention.NewPruner(driver, retention.Policy{MaxAge: 30 * 24 * time.Hour})
+		plan, err := p.Plan(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Sessions).To(HaveLen(1))
+
+		_, err = driver.Client.Node.Create().
+			SetID("old-4").
+			SetParentHash("old-3").
+			SetCreatedAt(now).
+			Save(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = p.Apply(ctx, plan)
+		Expect(err).To(MatchError(retention.ErrStalePlan))
+		Expect(remaining()).To(HaveLen(8))
+
+		result, err := p.Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Sessions).To(BeZero())
+	})
+
+	It("keeps a session whose newest turn is recent", func() {
+		_, err := driver.Client.Node.Create().
+			SetID("old-revived").
+			SetParentHash("old-3").
+			SetCreatedAt(now).
+			Save(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		p := retention.NewPruner(driver, retention.Policy{MaxAge: 30 * 24 * time.Hour})
+		plan, err := p.Plan(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Sessions).To(BeEmpty())
+	})
+
+	It("removes facets belonging to pruned sessions", func() {
+		_, err := driver.Client.Facet.Create().
+			SetID("old-3").
+			SetSessionID("old-3").
+			SetFacets(map[string]any{"goal": "x"}).
+			SetCreatedAt(now).
+			Save(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		p := retention.NewPruner(driver, retention.Policy{MaxAge: 30 * 24 * time.Hour})
+		result, err := p.Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Facets).To(Equal(1))
+	})
+
+	It("removes pruned turns from the search index", func() {
+		turn := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: "rotate the launch codes"}},
+		}, nil)
+		turn.CreatedAt = now.Add(-60 * 24 * time.Hour)
+		_, err := driver.Put(ctx, turn)
+		Expect(err).NotTo(HaveOccurred())
+
+		matches, err := driver.Search(ctx, sqlite.SearchOptions{Query: "launch"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(HaveLen(1))
+
+		p := retention.NewPruner(driver, retention.Policy{MaxAge: 30 * 24 * time.Hour})
+		_, err = p.Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Search joins the index with the nodes, so the index is read directly.
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+		var indexed int
+		Expect(db.QueryRowContext(ctx, "SELECT COUNT(*) FROM node_search").Scan(&indexed)).To(Succeed())
+		Expect(indexed).To(BeZero())
+	})
+
+	It("removes raw captures and session tools of pruned turns", func() {
+		for _, id := range []string{"old-3", "new-2"} {
+			_, err := driver.Client.RawCapture.Create().
+				SetNodeHash(id).
+				SetProvider("anthropic").
+				SetRequest([]byte("{}")).
+				Save(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = driver.Client.SessionTool.Create().
+				SetSessionID("s-" + id).
+				SetToolHash("tool").
+				SetNodeHash(id).
+				Save(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		p := retention.NewPruner(driver, retention.Policy{MaxAge: 30 * 24 * time.Hour})
+		_, err := p.Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		captures, err := driver.Client.RawCapture.Query().All(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(captures).To(HaveLen(1))
+		Expect(captures[0].NodeHash).To(Equal("new-2"))
+
+		tools, err := driver.Client.SessionTool.Query().All(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tools).To(HaveLen(1))
+		Expect(tools[0].NodeHash).To(Equal("new-2"))
+	})
+
+	It("deletes offloaded files only pruned turns referenced", func() {
+		dir := GinkgoT().TempDir()
+		addTurn := func(id, file string, created time.Time) {
+			path := filepath.Join(dir, file)
+			Expect(os.WriteFile(path, []byte(file), 0o600)).To(Succeed())
+			_, err := driver.Client.Node.Create().
+				SetID(id).
+				SetContent([]map[string]any{{"type": "text", "blob_uri": "file://" + path}}).
+				SetCreatedAt(created).
+				Save(ctx)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		addTurn("old-blob", "old", now.Add(-60*24*time.Hour))
+		addTurn("old-shared", "shared", now.Add(-60*24*time.Hour))
+		addTurn("new-shared", "shared", now)
+
+		p := retention.NewPruner(driver, retention.Policy{MaxAge: 30 * 24 * time.Hour})
+		result, err := p.Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Files).To(Equal(1))
+
+		Expect(filepath.Join(dir, "old")).NotTo(BeAnExistingFile())
+		Expect(filepath.Join(dir, "shared")).To(BeAnExistingFile())
+	})
+
+	It("prunes the oldest sessions to fit the size limit", func() {
+		size, err := driver.Size(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Room for five of the seven nodes: dropping the oldest session is enough.
+		p := retention.NewPruner(driver, retention.Policy{MaxDBSize: size * 5 / 7})
+		plan, err := p.Plan(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(plan.Sessions).To(HaveLen(1))
+		Expect(plan.Sessions[0].Root).To(Equal("old-1"))
+		Expect(plan.Sessions[0].Reason).To(Equal("max_db_size"))
+	})
+
+	It("does nothing with an empty policy", func() {
+		p := retention.NewPruner(driver, retention.Policy{})
+		result, err := p.Prune(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Sessions).To(BeZero())
+		Expect(remaining()).To(HaveLen(7))
+	})
+
+	Describe("garbage collection", func() {
+		// orphan stores a node whose parent was never stored, as a crash or
+		// a partial import can leave behind. The insert goes through a
+		// separate connection because the driver enforces foreign keys.
+		orphan := func(id, parent string) {
+			db, err := sql.Open("sqlite3", dbPath)
+			Expect(err).NotTo(HaveOccurred())
+			defer db.Close()
+			_, err = db.ExecContext(ctx,
+				"INSERT INTO nodes (hash, parent_hash, created_at) VALUES (?, ?, ?)", id, parent, now)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("finds nodes whose ancestry does not reach a root", func() {
+			orphan("lost-1", "missing")
+			addChild := func(id, parent string) {
+				_, err := driver.Client.Node.Create().SetID(id).SetParentHash(parent).SetCreatedAt(now).Save(ctx)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			addChild("lost-2", "lost-1")
+
+			plan, err := retention.NewPruner(driver, retention.Policy{}).PlanGC(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Sessions).To(BeEmpty())
+			Expect(plan.Orphans).To(HaveLen(2))
+			Expect(plan.Orphans[0].Hash).To(Equal("lost-1"))
+			Expect(plan.Orphans[0].Reason).To(Equal(retention.OrphanMissingParent))
+			Expect(plan.Orphans[1].Hash).To(Equal("lost-2"))
+			Expect(plan.Orphans[1].Reason).To(Equal(retention.OrphanDescendant))
+
+			Expect(remaining()).To(HaveLen(9))
+		})
+
+		It("finds ancestry cycles without looping", func() {
+			Expect(driver.Client.Node.UpdateOneID("mid-1").SetParentHash("mid-2").Exec(ctx)).To(Succeed())
+
+			plan, err := retention.NewPruner(driver, retention.Policy{}).PlanGC(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Orphans).To(HaveLen(2))
+			for _, o := range plan.Orphans {
+				Expect(o.Reason).To(Equal(retention.OrphanCycle))
+			}
+
+			sessions, err := retention.NewPruner(driver, retention.Policy{MaxSessions: 5}).Plan(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sessions.TotalSessions).To(Equal(2))
+		})
+
+		It("deletes orphans and leaves sessions intact", func() {
+			orphan("lost-1", "missing")
+
+			p := retention.NewPruner(driver, retention.Policy{})
+			plan, err := p.PlanGC(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			result, err := p.Apply(ctx, plan)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Orphans).To(Equal(1))
+			Expect(result.Nodes).To(BeZero())
+			Expect(result.AuditDetails()).To(HaveKeyWithValue("orphans", "1"))
+
+			Expect(remaining()).To(HaveLen(7))
+			Expect(remaining()).NotTo(ContainElement("lost-1"))
+		})
+
+		It("leaves orphans to the policy unless GC is set", func() {
+			orphan("lost-1", "missing")
+
+			p := retention.NewPruner(driver, retention.Policy{MaxAge: 30 * 24 * time.Hour})
+			plan, err := p.Plan(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.Orphans).To(BeEmpty())
+			result, err := p.Apply(ctx, plan)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Orphans).To(BeZero())
+			Expect(remaining()).To(ContainElement("lost-1"))
+		})
+
+		It("collects orphans while pruning when GC is set", func() {
+			orphan("lost-1", "missing")
+
+			p := retention.NewPruner(driver, retention.Policy{MaxAge: 30 * 24 * time.Hour})
+			p.GC = true
+			result, err := p.Prune(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Sessions).To(Equal(1))
+			Expect(result.Nodes).To(Equal(3))
+			Expect(result.Orphans).To(Equal(1))
+			Expect(remaining()).To(ConsistOf("mid-1", "mid-2", "new-1", "new-2"))
+		})
+	})
+})
@@ -0,0 +1,63 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/audit"
+)
+
+// DefaultInterval is how often the daemon enforces the retention policy.
+const DefaultInterval = time.Hour
+
+// Run enforces the policy immediately and then every interval until ctx is
+// cancelled. Failures are logged and retried on the next tick.
+func (p *Pruner) Run(ctx context.Context, interval time.Duration, logger *zap.Logger) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.runOnce(ctx, logger)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Pruner) runOnce(ctx context.Context, logger *zap.Logger) {
+	result, err := p.Prune(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Error("retention prune failed", zap.Error(err))
+		}
+		return
+	}
+	if result.Sessions == 0 && result.Orphans == 0 {
+		return
+	}
+
+	logger.Info("pruned sessions",
+		zap.Int("sessions", result.Sessions),
+		zap.Int("nodes", result.Nodes),
+		zap.Int("facets", result.Facets),
+		zap.Int("orphans", result.Orphans),
+		zap.Int64("reclaimed_bytes", result.ReclaimedBytes),
+	)
+
+	if p.Audit != nil {
+		details := result.AuditDetails()
+		details["source"] = "daemon"
+		if err := p.Audit.Record(audit.ActionPrune, "", details); err != nil {
+			logger.Warn("failed to write audit log", zap.Error(err))
+		}
+	}
+}
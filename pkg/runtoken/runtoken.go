@@ -0,0 +1,220 @@
+// Package runtoken issues the short-lived tokens agents launched by tapes
+// start send to the proxy in place of provider API keys.
+//
+// Each token belongs to one run, can expire and carry a budget of requests
+// and input tokens, and can be revoked at any time. The service counts what
+// each token was used for, so that the traffic of every run can be accounted
+// for. The proxy records a request under its token's run, whatever run the
+// request names itself.
+//
+// Tokens are held in memory only, so that no usable credential is written to
+// disk. Restarting the daemon forgets every token it issued: agents still
+// holding one are refused with ErrUnknown, rather than having their token
+// forwarded upstream, until they are launched again and issued a new one.
+package runtoken
+
+import (
+	"crypto/rand"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Prefix starts every run token, so that a revoked or unknown run token is
+// rejected instead of being forwarded upstream as an API key.
+const Prefix = "tapes_run_"
+
+// idLength is the length of token IDs, which name tokens without revealing
+// them.
+const idLength = 8
+
+var (
+	// ErrUnknown is returned for tokens that were never issued, have been
+	// revoked, were purged after expiring, or were issued before a restart.
+	ErrUnknown = errors.New("unknown or revoked tapes run token")
+
+	// ErrExpired is returned for tokens past their TTL.
+	ErrExpired = errors.New("tapes run token has expired")
+
+	// ErrOverBudget is returned for tokens that used up their budget.
+	ErrOverBudget = errors.New("tapes run token is over its budget")
+
+	// ErrRunMismatch is returned for requests that name a run other than
+	// their token's.
+	ErrRunMismatch = errors.New("tapes run token belongs to another run")
+)
+
+// Limits bound what a token may be used for. Zero values are unlimited.
+type Limits struct {
+	TTL            time.Duration
+	MaxRequests    int
+	MaxInputTokens int
+}
+
+// Info describes an issued token, without the token itself.
+type Info struct {
+	ID       string    `json:"id"`
+	Run      string    `json:"run"`
+	IssuedAt time.Time `json:"issued_at"`
+
+	ExpiresAt      time.Time `json:"expires_at,omitzero"`
+	MaxRequests    int       `json:"max_requests,omitempty"`
+	MaxInputTokens int       `json:"max_input_tokens,omitempty"`
+
+	Requests    int `json:"requests"`
+	InputTokens int `json:"input_tokens"`
+}
+
+// expired reports whether the token described by info is past its TTL at now.
+func (info *Info) expired(now time.Time) bool {
+	return !info.ExpiresAt.IsZero() && !now.Before(info.ExpiresAt)
+}
+
+// Service issues, checks, and revokes run tokens. It is safe for concurrent
+// use.
+type Service struct {
+	now func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]*Info
+}
+
+// New creates an empty token service.
+func New() *Service {
+	return &Service{now: time.Now, tokens: make(map[string]*Info)}
+}
+
+// Issue returns a new token for run, bounded by limits, and its description.
+func (s *Service) Issue(run string, limits Limits) (string, Info) {
+	token := Prefix + strings.ToLower(rand.Text())
+	info := &Info{
+		ID:             strings.ToLower(rand.Text()[:idLength]),
+		Run:            run,
+		IssuedAt:       s.now(),
+		MaxRequests:    limits.MaxRequests,
+		MaxInputTokens: limits.MaxInputTokens,
+	}
+	if limits.TTL > 0 {
+		info.ExpiresAt = info.IssuedAt.Add(limits.TTL)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.purgeExpired()
+	s.tokens[token] = info
+	return token, *info
+}
+
+// Authorize checks that token may be used for one more request, counts the
+// request, and returns the token's description.
+func (s *Service) Authorize(token string) (Info, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, ok := s.tokens[token]
+	if !ok {
+		return Info{}, ErrUnknown
+	}
+	if info.expired(s.now()) {
+		delete(s.tokens, token)
+		return *info, ErrExpired
+	}
+	if info.MaxRequests > 0 && info.Requests >= info.MaxRequests {
+		return *info, ErrOverBudget
+	}
+	info.Requests++
+	return *info, nil
+}
+
+// LimitsInputTokens reports whether token has an input token budget, so
+// that input tokens only need to be counted for tokens that have one.
+func (s *Service) LimitsInputTokens(token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	info, ok := s.tokens[token]
+	return ok && info.MaxInputTokens > 0
+}
+
+// Spend charges inputTokens to token, refusing them when they would take it
+// over its budget.
+func (s *Service) Spend(token string, inputTokens int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, ok := s.tokens[token]
+	if !ok {
+		return ErrUnknown
+	}
+	if info.MaxInputTokens > 0 && info.InputTokens+inputTokens > info.MaxInputTokens {
+		return ErrOverBudget
+	}
+	info.InputTokens += inputTokens
+	return nil
+}
+
+// Revoke revokes the token with the given ID and reports whether there was
+// one.
+func (s *Service) Revoke(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for token, info := range s.tokens {
+		if info.ID == id {
+			delete(s.tokens, token)
+			return true
+		}
+	}
+	return false
+}
+
+// RevokeRun revokes every token of run and returns how many there were.
+func (s *Service) RevokeRun(run string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revoked := 0
+	for token, info := range s.tokens {
+		if info.Run == run {
+			delete(s.tokens, token)
+			revoked++
+		}
+	}
+	return revoked
+}
+
+// List returns the descriptions of the live tokens, oldest first. Expired
+// tokens are dropped from the service.
+func (s *Service) List() []Info {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	infos := make([]Info, 0, len(s.tokens))
+	for token, info := range s.tokens {
+		if info.expired(now) {
+			delete(s.tokens, token)
+			continue
+		}
+		infos = append(infos, *info)
+	}
+	slices.SortFunc(infos, func(a, b Info) int {
+		if c := a.IssuedAt.Compare(b.IssuedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return infos
+}
+
+// purgeExpired drops every expired token, so that tokens which are never
+// used again do not accumulate. s.mu must be held.
+func (s *Service) purgeExpired() {
+	now := s.now()
+	for token, info := range s.tokens {
+		if info.expired(now) {
+			delete(s.tokens, token)
+		}
+	}
+}
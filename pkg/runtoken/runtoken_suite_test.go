@@ -0,0 +1,13 @@
+package runtoken
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRunToken(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Run Token Suite")
+}
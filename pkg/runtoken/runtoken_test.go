@@ -0,0 +1,125 @@
+package runtoken
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Service", func() {
+	var (
+		svc *Service
+		now time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		svc = New()
+		svc.now = func() time.Time { return now }
+	})
+
+	It("issues tokens that authorize requests and count them", func() {
+		token, info := svc.Issue("run-1", Limits{})
+		Expect(token).To(HavePrefix(Prefix))
+		Expect(info.ID).To(HaveLen(idLength))
+		Expect(info.Run).To(Equal("run-1"))
+		Expect(info.IssuedAt).To(Equal(now))
+		Expect(info.ExpiresAt.IsZero()).To(BeTrue())
+
+		info, err := svc.Authorize(token)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Requests).To(Equal(1))
+	})
+
+	It("rejects unknown tokens", func() {
+		_, err := svc.Authorize(Prefix + "unknown")
+		Expect(err).To(MatchError(ErrUnknown))
+	})
+
+	It("rejects tokens past their TTL", func() {
+		token, info := svc.Issue("run-1", Limits{TTL: time.Hour})
+		Expect(info.ExpiresAt).To(Equal(now.Add(time.Hour)))
+
+		now = now.Add(time.Hour)
+		_, err := svc.Authorize(token)
+		Expect(err).To(MatchError(ErrExpired))
+	})
+
+	It("purges expired tokens", func() {
+		expiring, _ := svc.Issue("run-1", Limits{TTL: time.Hour})
+		_, unused := svc.Issue("run-2", Limits{TTL: time.Hour})
+		_, kept := svc.Issue("run-3", Limits{})
+
+		now = now.Add(time.Hour)
+		_, err := svc.Authorize(expiring)
+		Expect(err).To(MatchError(ErrExpired))
+		_, err = svc.Authorize(expiring)
+		Expect(err).To(MatchError(ErrUnknown))
+
+		infos := svc.List()
+		Expect(infos).To(HaveLen(1))
+		Expect(infos[0].ID).To(Equal(kept.ID))
+		Expect(svc.tokens).To(HaveLen(1))
+		Expect(svc.Revoke(unused.ID)).To(BeFalse())
+	})
+
+	It("enforces the request budget", func() {
+		token, _ := svc.Issue("run-1", Limits{MaxRequests: 2})
+		for range 2 {
+			_, err := svc.Authorize(token)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		_, err := svc.Authorize(token)
+		Expect(err).To(MatchError(ErrOverBudget))
+	})
+
+	It("enforces the input token budget", func() {
+		unlimited, _ := svc.Issue("run-1", Limits{})
+		Expect(svc.LimitsInputTokens(unlimited)).To(BeFalse())
+
+		token, _ := svc.Issue("run-1", Limits{MaxInputTokens: 100})
+		Expect(svc.LimitsInputTokens(token)).To(BeTrue())
+		Expect(svc.Spend(token, 60)).To(Succeed())
+		Expect(svc.Spend(token, 60)).To(MatchError(ErrOverBudget))
+		Expect(svc.Spend(token, 40)).To(Succeed())
+		info, err := svc.Authorize(token)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.InputTokens).To(Equal(100))
+	})
+
+	It("revokes tokens by ID and by run", func() {
+		first, info := svc.Issue("run-1", Limits{})
+		second, _ := svc.Issue("run-1", Limits{})
+		other, _ := svc.Issue("run-2", Limits{})
+
+		Expect(svc.Revoke(info.ID)).To(BeTrue())
+		Expect(svc.Revoke(info.ID)).To(BeFalse())
+		_, err := svc.Authorize(first)
+		Expect(err).To(MatchError(ErrUnknown))
+
+		Expect(svc.RevokeRun("run-1")).To(Equal(1))
+		_, err = svc.Authorize(second)
+		Expect(err).To(MatchError(ErrUnknown))
+		_, err = svc.Authorize(other)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("lists tokens oldest first", func() {
+		_, first := svc.Issue("run-1", Limits{})
+		now = now.Add(time.Minute)
+		_, second := svc.Issue("run-2", Limits{})
+
+		infos := svc.List()
+		Expect(infos).To(HaveLen(2))
+		Expect(infos[0].ID).To(Equal(first.ID))
+		Expect(infos[1].ID).To(Equal(second.ID))
+	})
+
+	It("does not recognize tokens issued before a restart", func() {
+		token, _ := svc.Issue("run-1", Limits{})
+
+		_, err := New().Authorize(token)
+		Expect(err).To(MatchError(ErrUnknown))
+	})
+})
@@ -0,0 +1,66 @@
+package share
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/dotdir"
+)
+
+// identityFile holds the base64 seed of the identity key in the .tapes/
+// directory.
+const identityFile = "share.key"
+
+// IdentityDir returns the .tapes/ directory the identity key is kept in: the
+// one configDir resolves to, or ~/.tapes when there is none.
+func IdentityDir(configDir string) (string, error) {
+	target, err := dotdir.NewManager().Target(configDir)
+	if err != nil {
+		return "", err
+	}
+	if target != "" {
+		return target, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("finding home directory: %w", err)
+	}
+	return filepath.Join(home, ".tapes"), nil
+}
+
+// LoadIdentity returns the identity key stored in dir, creating one the first
+// time it is needed.
+func LoadIdentity(dir string) (ed25519.PrivateKey, error) {
+	path := filepath.Join(dir, identityFile)
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		seed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("identity key %s is invalid", path)
+		}
+		return ed25519.NewKeyFromSeed(seed), nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading identity key: %w", err)
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating identity key: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("writing identity key: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(key.Seed()) + "\n"
+	if err := os.WriteFile(path, []byte(encoded), 0o600); err != nil {
+		return nil, fmt.Errorf("writing identity key: %w", err)
+	}
+	return key, nil
+}
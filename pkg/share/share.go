@@ -0,0 +1,294 @@
+// Package share packages sessions into signed bundles that can be handed to
+// another tapes user and imported into their database.
+//
+// A bundle holds every turn of a session from its root, together with who
+// shared it and when. It is signed with the sender's Ed25519 identity key, so
+// the receiver can check that it was not altered in transit and see which key
+// produced it. Bundles can additionally be encrypted with a random key that is
+// passed to the receiver separately. On import every turn is rehashed, so a
+// bundle can never introduce a turn whose content does not match its hash.
+//
+// Content the sender offloaded to a blob or media store is read back into the
+// bundle, since the receiver cannot reach the sender's stores, and is
+// offloaded again to the receiver's on import. A turn's hash leaves out where
+// its content is stored, so turns keep their hashes and signatures on either
+// side.
+package share
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+	"github.com/papercomputeco/tapes/pkg/storage/media"
+)
+
+// Format identifies version 1 of the bundle format.
+const Format = "tapes-share/v1"
+
+var (
+	// ErrBadSignature is returned when a bundle's signature does not verify.
+	ErrBadSignature = errors.New("bundle signature is invalid")
+
+	// ErrKeyRequired is returned when opening an encrypted bundle without a
+	// key.
+	ErrKeyRequired = errors.New("bundle is encrypted; a key is required")
+)
+
+// Bundle is a shared session.
+type Bundle struct {
+	// Session is the ID the shared session has on the sender's machine.
+	Session string `json:"session"`
+
+	// SharedBy names the sender, such as "alice@laptop".
+	SharedBy string `json:"shared_by"`
+
+	// SharedAt is when the bundle was created.
+	SharedAt time.Time `json:"shared_at"`
+
+	// Note is an optional message from the sender.
+	Note string `json:"note,omitempty"`
+
+	// Nodes holds the session's turns, parents before children.
+	Nodes []*merkle.Node `json:"nodes"`
+}
+
+// envelope is the file a bundle is written as.
+type envelope struct {
+	Format    string `json:"format"`
+	Encrypted bool   `json:"encrypted,omitempty"`
+	Payload   []byte `json:"payload"`
+
+	// SignerKey and Signature sign Payload as stored, so signatures are
+	// checked before anything is decrypted or parsed.
+	SignerKey []byte `json:"signer_key"`
+	Signature []byte `json:"signature"`
+}
+
+// Received is a bundle that was opened and verified.
+type Received struct {
+	*Bundle
+
+	// Signer is the fingerprint of the key that signed the bundle.
+	Signer string
+}
+
+// Seal signs the bundle with key and returns it encoded for writing to a
+// file. When cipher is set the bundle is encrypted first.
+func Seal(b *Bundle, key ed25519.PrivateKey, cipher *encryption.Cipher) ([]byte, error) {
+	if err := checkNodes(b.Nodes); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("encoding bundle: %w", err)
+	}
+
+	env := envelope{Format: Format}
+	if cipher != nil {
+		var sealed bytes.Buffer
+		if err := cipher.EncryptStream(&sealed, bytes.NewReader(payload)); err != nil {
+			return nil, fmt.Errorf("encrypting bundle: %w", err)
+		}
+		payload = sealed.Bytes()
+		env.Encrypted = true
+	}
+
+	env.Payload = payload
+	env.SignerKey = key.Public().(ed25519.PublicKey)
+	env.Signature = ed25519.Sign(key, payload)
+
+	return json.MarshalIndent(env, "", "  ")
+}
+
+// Open verifies the signature of an encoded bundle, decrypts it with
+// cipher when it is encrypted, and checks every turn against its hash.
+func Open(data []byte, cipher *encryption.Cipher) (*Received, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("decoding bundle: %w", err)
+	}
+	if env.Format != Format {
+		return nil, fmt.Errorf("unsupported bundle format %q", env.Format)
+	}
+	if len(env.SignerKey) != ed25519.PublicKeySize || !ed25519.Verify(env.SignerKey, env.Payload, env.Signature) {
+		return nil, ErrBadSignature
+	}
+
+	payload := env.Payload
+	if env.Encrypted {
+		if cipher == nil {
+			return nil, ErrKeyRequired
+		}
+		var plain bytes.Buffer
+		if err := cipher.DecryptStream(&plain, bytes.NewReader(payload)); err != nil {
+			return nil, fmt.Errorf("decrypting bundle: %w", err)
+		}
+		payload = plain.Bytes()
+	}
+
+	var b Bundle
+	if err := json.Unmarshal(payload, &b); err != nil {
+		return nil, fmt.Errorf("decoding bundle: %w", err)
+	}
+	if err := checkNodes(b.Nodes); err != nil {
+		return nil, err
+	}
+
+	return &Received{Bundle: &b, Signer: Fingerprint(env.SignerKey)}, nil
+}
+
+// checkNodes verifies that every node matches its hash, that signed nodes
+// carry a valid signature, and that parents come before their children, so
+// the nodes form complete sessions that can be stored in order.
+func checkNodes(nodes []*merkle.Node) error {
+	if len(nodes) == 0 {
+		return errors.New("bundle has no turns")
+	}
+
+	seen := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		if n == nil || !n.Verify() {
+			return errors.New("bundle has a turn that does not match its hash")
+		}
+		if n.Signed() && !n.VerifySignature() {
+			return fmt.Errorf("bundle turn %s has an invalid signature", short(n.Hash))
+		}
+		if n.ParentHash != nil && !seen[*n.ParentHash] {
+			return fmt.Errorf("bundle turn %s is missing its parent", short(n.Hash))
+		}
+		seen[n.Hash] = true
+	}
+	return nil
+}
+
+// Fingerprint returns a short, readable digest of a public key.
+func Fingerprint(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	digest := hex.EncodeToString(sum[:8])
+	parts := make([]string, 0, len(digest)/4)
+	for i := 0; i < len(digest); i += 4 {
+		parts = append(parts, digest[i:i+4])
+	}
+	return strings.Join(parts, ":")
+}
+
+func short(hash string) string {
+	return hash[:min(12, len(hash))]
+}
+
+// Collect returns the turns of the sessions ending at leaves, from their
+// roots, with parents before children and shared ancestors included once.
+// Offloaded content is read back inline into copies of the turns.
+func Collect(ctx context.Context, driver storage.Driver, leaves []string) ([]*merkle.Node, error) {
+	seen := map[string]bool{}
+	var nodes []*merkle.Node
+	for _, leaf := range leaves {
+		ancestry, err := driver.Ancestry(ctx, leaf)
+		if err != nil {
+			return nil, fmt.Errorf("loading session %s: %w", short(leaf), err)
+		}
+		for i := len(ancestry) - 1; i >= 0; i-- {
+			n := ancestry[i]
+			if seen[n.Hash] {
+				continue
+			}
+			seen[n.Hash] = true
+			nodes = append(nodes, n)
+		}
+	}
+
+	cipher := storage.CipherOf(driver)
+	for i, n := range nodes {
+		content, err := blob.Rehydrate(ctx, n.Bucket.Content, cipher)
+		if err != nil {
+			return nil, fmt.Errorf("reading offloaded content of turn %s: %w", short(n.Hash), err)
+		}
+		content, err = media.Inline(ctx, content, cipher)
+		if err != nil {
+			return nil, fmt.Errorf("reading stored media of turn %s: %w", short(n.Hash), err)
+		}
+
+		c := *n
+		c.Bucket.Content = keepReferences(n.Bucket.Content, content)
+		nodes[i] = &c
+	}
+	return nodes, nil
+}
+
+// keepReferences returns inlined, the blocks of stored read back inline, with
+// the content hash and size of each stored payload kept, since the turn's hash
+// covers the payload through them.
+func keepReferences(stored, inlined []llm.ContentBlock) []llm.ContentBlock {
+	for i, block := range stored {
+		if block.ContentHash == "" || (block.BlobURI == "" && block.MediaURI == "") ||
+			inlined[i].BlobURI != "" || inlined[i].MediaURI != "" {
+			continue
+		}
+		inlined[i].ContentHash = block.ContentHash
+		inlined[i].OriginalBytes = block.OriginalBytes
+	}
+	return inlined
+}
+
+// Offload moves imported content out of the database, as the proxy does
+// when it captures it. The zero value keeps all content inline.
+type Offload struct {
+	// Blobs receives payloads larger than BlobThreshold.
+	Blobs         blob.Store
+	BlobThreshold int
+
+	// Media receives images and files.
+	Media *media.Store
+}
+
+// Import stores the turns of a received bundle, recording who shared them.
+// Their content is offloaded with offload first, which leaves their hashes as
+// they are. Turns already in the database are left as they are. It returns
+// the number of turns added.
+func Import(ctx context.Context, driver storage.Driver, r *Received, offload Offload) (int, error) {
+	for _, n := range r.Nodes {
+		content := n.Bucket.Content
+		if offload.Media != nil {
+			var err error
+			content, err = offload.Media.Capture(ctx, content)
+			// Media over the size limit stays inline, as it does when captured.
+			if err != nil && !errors.Is(err, media.ErrTooLarge) {
+				return 0, fmt.Errorf("storing shared media of turn %s: %w", short(n.Hash), err)
+			}
+		}
+		content, err := blob.Offload(ctx, offload.Blobs, content, offload.BlobThreshold)
+		if err != nil {
+			return 0, fmt.Errorf("offloading shared content of turn %s: %w", short(n.Hash), err)
+		}
+		n.Bucket.Content = content
+	}
+
+	sharedBy := fmt.Sprintf("%s (%s)", r.SharedBy, r.Signer)
+
+	added := 0
+	for _, n := range r.Nodes {
+		n.SharedBy = sharedBy
+		ok, err := driver.Put(ctx, n)
+		if err != nil {
+			return added, fmt.Errorf("storing turn %s: %w", short(n.Hash), err)
+		}
+		if ok {
+			added++
+		}
+	}
+	return added, nil
+}
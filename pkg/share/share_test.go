@@ -0,0 +1,262 @@
+package share_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/share"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+var _ = Describe("Share", func() {
+	var (
+		ctx    context.Context
+		sender *sqlite.Driver
+		key    ed25519.PrivateKey
+		leaf   *merkle.Node
+		root   *merkle.Node
+	)
+
+	turn := func(role, text string, parent *merkle.Node) *merkle.Node {
+		n := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    role,
+			Content: []llm.ContentBlock{{Type: "text", Text: text}},
+			Model:   "gpt-4o",
+		}, parent)
+		n.CreatedAt = time.Date(2026, 1, 15, 9, 30, 0, 0, time.UTC)
+		_, err := sender.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+		return n
+	}
+
+	bundle := func() *share.Bundle {
+		nodes, err := share.Collect(ctx, sender, []string{leaf.Hash})
+		Expect(err).NotTo(HaveOccurred())
+		return &share.Bundle{
+			Session:  leaf.Hash,
+			SharedBy: "alice@laptop",
+			SharedAt: time.Now(),
+			Nodes:    nodes,
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		var err error
+		sender, err = sqlite.NewDriver(ctx, filepath.Join(GinkgoT().TempDir(), "sender.db"))
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(sender.Close)
+
+		key, err = share.LoadIdentity(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+
+		root = turn("user", "why does the login test fail?", nil)
+		leaf = turn("assistant", "The session cookie expires too early.", root)
+	})
+
+	It("collects a session from its root", func() {
+		b := bundle()
+		Expect(b.Nodes).To(HaveLen(2))
+		Expect(b.Nodes[0].Hash).To(Equal(root.Hash))
+		Expect(b.Nodes[1].Hash).To(Equal(leaf.Hash))
+	})
+
+	It("imports a sealed bundle with its provenance", func() {
+		data, err := share.Seal(bundle(), key, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		received, err := share.Open(data, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(received.SharedBy).To(Equal("alice@laptop"))
+		Expect(received.Signer).To(Equal(share.Fingerprint(key.Public().(ed25519.PublicKey))))
+
+		receiver, err := sqlite.NewDriver(ctx, filepath.Join(GinkgoT().TempDir(), "receiver.db"))
+		Expect(err).NotTo(HaveOccurred())
+		defer receiver.Close()
+
+		added, err := share.Import(ctx, receiver, received, share.Offload{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(added).To(Equal(2))
+
+		got, err := receiver.Get(ctx, leaf.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.SharedBy).To(Equal("alice@laptop (" + received.Signer + ")"))
+		Expect(got.CreatedAt.Equal(leaf.CreatedAt)).To(BeTrue())
+
+		added, err = share.Import(ctx, receiver, received, share.Offload{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(added).To(BeZero())
+	})
+
+	It("keeps the same identity key", func() {
+		dir := GinkgoT().TempDir()
+		first, err := share.LoadIdentity(dir)
+		Expect(err).NotTo(HaveOccurred())
+		second, err := share.LoadIdentity(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second.Equal(first)).To(BeTrue())
+	})
+
+	It("rejects bundles altered after signing", func() {
+		data, err := share.Seal(bundle(), key, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		var env map[string]any
+		Expect(json.Unmarshal(data, &env)).To(Succeed())
+		env["signature"] = env["signer_key"]
+		tampered, err := json.Marshal(env)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = share.Open(tampered, nil)
+		Expect(err).To(MatchError(share.ErrBadSignature))
+	})
+
+	It("rejects turns that do not match their hash", func() {
+		b := bundle()
+		b.Nodes[1] = &merkle.Node{Hash: leaf.Hash, ParentHash: leaf.ParentHash, Bucket: merkle.Bucket{Role: "assistant"}}
+
+		_, err := share.Seal(b, key, nil)
+		Expect(err).To(MatchError(ContainSubstring("does not match its hash")))
+	})
+
+	It("keeps turn signatures and rejects invalid ones", func() {
+		b := bundle()
+		b.Nodes[1].Sign(key)
+
+		data, err := share.Seal(b, key, nil)
+		Expect(err).NotTo(HaveOccurred())
+		received, err := share.Open(data, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(received.Nodes[1].VerifySignature()).To(BeTrue())
+
+		b.Nodes[1].Signature[0] ^= 0xff
+		_, err = share.Seal(b, key, nil)
+		Expect(err).To(MatchError(ContainSubstring("invalid signature")))
+	})
+
+	It("rejects bundles missing a parent", func() {
+		b := bundle()
+		b.Nodes = b.Nodes[1:]
+
+		_, err := share.Seal(b, key, nil)
+		Expect(err).To(MatchError(ContainSubstring("missing its parent")))
+	})
+
+	It("encrypts bundles", func() {
+		encoded, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		raw, err := encryption.ParseKey(encoded)
+		Expect(err).NotTo(HaveOccurred())
+		cipher, err := encryption.NewCipher(raw)
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := share.Seal(bundle(), key, cipher)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("cookie"))
+
+		_, err = share.Open(data, nil)
+		Expect(err).To(MatchError(share.ErrKeyRequired))
+
+		received, err := share.Open(data, cipher)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(received.Nodes).To(HaveLen(2))
+	})
+
+	It("carries offloaded content to the receiver's blob store", func() {
+		large := strings.Repeat("stack trace line\n", 64)
+		senderBlobs, err := blob.NewLocalStore(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		content, err := blob.Offload(ctx, senderBlobs, []llm.ContentBlock{{Type: "text", Text: large}}, 256)
+		Expect(err).NotTo(HaveOccurred())
+		offloaded := merkle.NewNode(merkle.Bucket{Type: "message", Role: "user", Content: content}, leaf)
+		_, err = sender.Put(ctx, offloaded)
+		Expect(err).NotTo(HaveOccurred())
+
+		nodes, err := share.Collect(ctx, sender, []string{offloaded.Hash})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes[2].Bucket.Content[0].Text).To(Equal(large))
+		Expect(nodes[2].Bucket.Content[0].BlobURI).To(BeEmpty())
+		Expect(nodes[2].Hash).To(Equal(offloaded.Hash))
+
+		seal := func(nodes []*merkle.Node) []byte {
+			data, err := share.Seal(&share.Bundle{
+				Session:  offloaded.Hash,
+				SharedBy: "alice@laptop",
+				SharedAt: time.Now(),
+				Nodes:    nodes,
+			}, key, nil)
+			Expect(err).NotTo(HaveOccurred())
+			return data
+		}
+		received, err := share.Open(seal(nodes), nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		// The inline payload is checked against the hash it is committed by.
+		tampered := *nodes[2]
+		tampered.Bucket.Content = []llm.ContentBlock{nodes[2].Bucket.Content[0]}
+		tampered.Bucket.Content[0].Text = "forged"
+		_, err = share.Seal(&share.Bundle{Session: offloaded.Hash, Nodes: []*merkle.Node{nodes[0], nodes[1], &tampered}}, key, nil)
+		Expect(err).To(MatchError(ContainSubstring("does not match its hash")))
+
+		receiver, err := sqlite.NewDriver(ctx, filepath.Join(GinkgoT().TempDir(), "receiver.db"))
+		Expect(err).NotTo(HaveOccurred())
+		defer receiver.Close()
+		receiverDir := GinkgoT().TempDir()
+		receiverBlobs, err := blob.NewLocalStore(receiverDir)
+		Expect(err).NotTo(HaveOccurred())
+
+		added, err := share.Import(ctx, receiver, received, share.Offload{Blobs: receiverBlobs, BlobThreshold: 256})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(added).To(Equal(3))
+
+		got, err := receiver.Get(ctx, offloaded.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Verify()).To(BeTrue())
+		Expect(got.Bucket.Content[0].BlobURI).To(ContainSubstring(filepath.ToSlash(receiverDir)))
+
+		blocks, err := blob.Rehydrate(ctx, got.Bucket.Content, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(blocks[0].Text).To(Equal(large))
+	})
+	It("keeps shared content inline for a receiver without stores", func() {
+		large := strings.Repeat("stack trace line\n", 64)
+		senderBlobs, err := blob.NewLocalStore(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		content, err := blob.Offload(ctx, senderBlobs, []llm.ContentBlock{{Type: "text", Text: large}}, 256)
+		Expect(err).NotTo(HaveOccurred())
+		offloaded := merkle.NewNode(merkle.Bucket{Type: "message", Role: "user", Content: content}, leaf)
+		_, err = sender.Put(ctx, offloaded)
+		Expect(err).NotTo(HaveOccurred())
+
+		nodes, err := share.Collect(ctx, sender, []string{offloaded.Hash})
+		Expect(err).NotTo(HaveOccurred())
+		data, err := share.Seal(&share.Bundle{Session: offloaded.Hash, SharedBy: "alice@laptop", SharedAt: time.Now(), Nodes: nodes}, key, nil)
+		Expect(err).NotTo(HaveOccurred())
+		received, err := share.Open(data, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		receiver, err := sqlite.NewDriver(ctx, filepath.Join(GinkgoT().TempDir(), "receiver.db"))
+		Expect(err).NotTo(HaveOccurred())
+		defer receiver.Close()
+		_, err = share.Import(ctx, receiver, received, share.Offload{})
+		Expect(err).NotTo(HaveOccurred())
+
+		got, err := receiver.Get(ctx, offloaded.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Verify()).To(BeTrue())
+		Expect(got.Bucket.Content[0].Text).To(Equal(large))
+	})
+})
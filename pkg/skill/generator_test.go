@@ -32,6 +32,26 @@ func (m *mockQuerier) SessionAnalytics(_ context.Context, _ string) (*deck.Sessi
 	return &deck.SessionAnalytics{}, nil
 }
 
+func (m *mockQuerier) Search(_ context.Context, _ deck.SearchOptions) ([]deck.SearchHit, error) {
+	return nil, nil
+}
+
+func (m *mockQuerier) SessionDetailPage(ctx context.Context, sessionID string, _ deck.Page) (*deck.SessionDetail, error) {
+	return m.SessionDetail(ctx, sessionID)
+}
+
+func (m *mockQuerier) SessionTree(_ context.Context, _ string) (*deck.SessionTree, error) {
+	return nil, nil
+}
+
+func (m *mockQuerier) DiffSessions(_ context.Context, _, _ string) (*deck.SessionDiff, error) {
+	return nil, nil
+}
+
+func (m *mockQuerier) Annotate(_ context.Context, _ string, _ *deck.SessionAnnotation) error {
+	return nil
+}
+
 var _ = Describe("Generator", func() {
 	It("generates a skill from a single conversation hash", func() {
 		querier := &mockQuerier{
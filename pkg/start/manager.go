@@ -6,21 +6,24 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"syscall"
 	"time"
 
+	"github.com/papercomputeco/tapes/pkg/crash"
 	"github.com/papercomputeco/tapes/pkg/dotdir"
+	"github.com/papercomputeco/tapes/pkg/logger"
 )
 
 const (
-	stateFileName = "start.json"
-	logFileName   = "start.log"
-	lockFileName  = "start.lock"
-	stateVersion  = 1
+	stateFileName   = "start.json"
+	outputFileName  = "daemon.out"
+	lockFileName    = "start.lock"
+	journalFileName = "start.journal"
+	stateVersion    = 1
 )
 
 type AgentSession struct {
 	Name      string    `json:"name"`
+	RunID     string    `json:"run_id,omitempty"`
 	PID       int       `json:"pid"`
 	StartedAt time.Time `json:"started_at"`
 }
@@ -39,8 +42,18 @@ type State struct {
 type Manager struct {
 	Dir       string
 	StatePath string
-	LogPath   string
-	LockPath  string
+
+	// LogPath is the daemon's rotated, structured log, and OutputPath
+	// receives what the daemon writes to stdout and stderr outside of it,
+	// such as the trace of a crash.
+	LogPath    string
+	OutputPath string
+
+	LockPath    string
+	JournalPath string
+
+	// CrashDir holds reports of panics the daemon recovered from.
+	CrashDir string
 }
 
 type Lock struct {
@@ -62,15 +75,19 @@ func NewManager(configDir string) (*Manager, error) {
 		dir = filepath.Join(home, ".tapes")
 	}
 
-	if err := os.MkdirAll(dir, 0o755); err != nil {
+	logDir := filepath.Join(dir, logger.DirName)
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
 		return nil, fmt.Errorf("creating tapes dir: %w", err)
 	}
 
 	return &Manager{
-		Dir:       dir,
-		StatePath: filepath.Join(dir, stateFileName),
-		LogPath:   filepath.Join(dir, logFileName),
-		LockPath:  filepath.Join(dir, lockFileName),
+		Dir:         dir,
+		StatePath:   filepath.Join(dir, stateFileName),
+		LogPath:     filepath.Join(logDir, logger.FileName),
+		OutputPath:  filepath.Join(logDir, outputFileName),
+		LockPath:    filepath.Join(dir, lockFileName),
+		JournalPath: filepath.Join(dir, journalFileName),
+		CrashDir:    filepath.Join(dir, crash.DirName),
 	}, nil
 }
 
@@ -80,7 +97,7 @@ func (m *Manager) Lock() (*Lock, error) {
 		return nil, fmt.Errorf("opening lock file: %w", err)
 	}
 
-	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+	if err := lockFile(file); err != nil {
 		file.Close()
 		return nil, fmt.Errorf("locking start file: %w", err)
 	}
@@ -92,7 +109,7 @@ func (l *Lock) Release() error {
 	if l == nil || l.file == nil {
 		return nil
 	}
-	if err := syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN); err != nil {
+	if err := unlockFile(l.file); err != nil {
 		_ = l.file.Close()
 		return fmt.Errorf("unlocking start file: %w", err)
 	}
@@ -51,7 +51,7 @@ var _ = Describe("Manager", func() {
 		Expect(loaded.Agents).To(HaveLen(1))
 		Expect(loaded.Agents[0].Name).To(Equal("claude"))
 		Expect(loaded.Agents[0].PID).To(Equal(456))
-		Expect(loaded.LogPath).To(Equal(filepath.Join(tempDir, "start.log")))
+		Expect(loaded.LogPath).To(Equal(filepath.Join(tempDir, "logs", "tapes.log")))
 	})
 
 	It("clears state", func() {
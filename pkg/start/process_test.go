@@ -0,0 +1,51 @@
+package start_test
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/start"
+)
+
+var _ = Describe("ProcessAlive", func() {
+	It("reports the current process as alive", func() {
+		Expect(start.ProcessAlive(os.Getpid())).To(BeTrue())
+	})
+
+	It("reports invalid PIDs as not alive", func() {
+		Expect(start.ProcessAlive(0)).To(BeFalse())
+		Expect(start.ProcessAlive(-1)).To(BeFalse())
+	})
+
+	It("reports exited processes as not alive", func() {
+		exe, err := os.Executable()
+		Expect(err).NotTo(HaveOccurred())
+
+		// Running the test binary with an unknown test pattern exits at once.
+		cmd := exec.Command(exe, "-test.run=^$")
+		Expect(cmd.Run()).To(Succeed())
+		Expect(start.ProcessAlive(cmd.Process.Pid)).To(BeFalse())
+	})
+})
+
+var _ = Describe("WrapCommand", func() {
+	It("runs executables directly", func() {
+		path, args := start.WrapCommand("/usr/local/bin/codex", []string{"--model", "o3"})
+		Expect(path).To(Equal("/usr/local/bin/codex"))
+		Expect(args).To(Equal([]string{"--model", "o3"}))
+	})
+
+	It("runs batch shims through cmd.exe on Windows", func() {
+		if runtime.GOOS != "windows" {
+			Skip("batch shims only exist on Windows")
+		}
+		path, args := start.WrapCommand(`C:\npm\codex.cmd`, []string{"--model", "o3"})
+		Expect(strings.ToLower(path)).To(HaveSuffix("cmd.exe"))
+		Expect(args).To(Equal([]string{"/d", "/s", "/c", `C:\npm\codex.cmd`, "--model", "o3"}))
+	})
+})
@@ -0,0 +1,48 @@
+//go:build !windows
+
+package start
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive lock on file, blocking until it is available.
+func lockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+}
+
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
+
+// ProcessAlive reports whether a process with the given PID is running.
+func ProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
+
+// DaemonSysProcAttr returns the process attributes of the start daemon.
+// The daemon already outlives its parent on Unix, so none are needed.
+func DaemonSysProcAttr() *syscall.SysProcAttr {
+	return nil
+}
+
+// WrapCommand returns the program and arguments that run the agent binary at
+// path. Agent binaries are executed directly on Unix.
+func WrapCommand(path string, args []string) (string, []string) {
+	return path, args
+}
+
+// Contain ties an agent process to tapes so that it does not outlive it. On
+// Unix the agent shares tapes' process group and terminal, which already
+// delivers interrupts to both, so this is a no-op.
+func Contain(*os.Process) (func() error, error) {
+	return func() error { return nil }, nil
+}
@@ -0,0 +1,109 @@
+//go:build windows
+
+package start
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockFile takes an exclusive lock on file, blocking until it is available.
+func lockFile(file *os.File) error {
+	var overlapped windows.Overlapped
+	return windows.LockFileEx(windows.Handle(file.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, &overlapped)
+}
+
+func unlockFile(file *os.File) error {
+	var overlapped windows.Overlapped
+	return windows.UnlockFileEx(windows.Handle(file.Fd()), 0, 1, 0, &overlapped)
+}
+
+// stillActive is the exit code GetExitCodeProcess reports for a running
+// process.
+const stillActive = 259
+
+// ProcessAlive reports whether a process with the given PID is running.
+// Windows has no signal 0, so the process is opened and its exit code read.
+func ProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer windows.CloseHandle(handle) //nolint:errcheck // nothing to do on failure
+
+	var code uint32
+	if err := windows.GetExitCodeProcess(handle, &code); err != nil {
+		return false
+	}
+	return code == stillActive
+}
+
+// DaemonSysProcAttr returns the process attributes of the start daemon. It is
+// detached from the console so that closing the terminal that launched the
+// first agent does not stop the daemon serving the others.
+func DaemonSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		CreationFlags: windows.CREATE_NEW_PROCESS_GROUP | windows.DETACHED_PROCESS,
+		HideWindow:    true,
+	}
+}
+
+// WrapCommand returns the program and arguments that run the agent binary at
+// path. npm installs agents such as codex and claude as .cmd shims, which
+// must run through cmd.exe.
+func WrapCommand(path string, args []string) (string, []string) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".cmd", ".bat":
+		comspec := os.Getenv("ComSpec")
+		if comspec == "" {
+			comspec = "cmd.exe"
+		}
+		return comspec, append([]string{"/d", "/s", "/c", path}, args...)
+	default:
+		return path, args
+	}
+}
+
+// Contain assigns an agent process to a job object that is killed when tapes
+// exits, so agents and the tools they spawn do not outlive the session. The
+// returned function closes the job.
+func Contain(proc *os.Process) (func() error, error) {
+	job, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating job object: %w", err)
+	}
+
+	info := windows.JOBOBJECT_EXTENDED_LIMIT_INFORMATION{
+		BasicLimitInformation: windows.JOBOBJECT_BASIC_LIMIT_INFORMATION{
+			LimitFlags: windows.JOB_OBJECT_LIMIT_KILL_ON_JOB_CLOSE,
+		},
+	}
+	if _, err := windows.SetInformationJobObject(job, windows.JobObjectExtendedLimitInformation,
+		uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info))); err != nil {
+		_ = windows.CloseHandle(job)
+		return nil, fmt.Errorf("configuring job object: %w", err)
+	}
+
+	handle, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(proc.Pid)) //nolint:gosec // PIDs fit in uint32
+	if err != nil {
+		_ = windows.CloseHandle(job)
+		return nil, fmt.Errorf("opening agent process: %w", err)
+	}
+	defer windows.CloseHandle(handle) //nolint:errcheck // nothing to do on failure
+
+	if err := windows.AssignProcessToJobObject(job, handle); err != nil {
+		_ = windows.CloseHandle(job)
+		return nil, fmt.Errorf("assigning agent to job object: %w", err)
+	}
+
+	return func() error { return windows.CloseHandle(job) }, nil
+}
@@ -0,0 +1,269 @@
+// Package blob offloads large content block payloads from the primary database
+// to a blob store.
+//
+// An offloaded block keeps its type and metadata but has its payload replaced
+// by a reference: the blob URI, the payload's SHA-256, and its original size.
+// The node hash commits to the payload through its SHA-256 and leaves the URI
+// out, so a turn hashes the same whichever store holds the blob and whichever
+// key sealed it. Rehydration verifies the fetched bytes against the hash.
+//
+// Blobs are kept in a local directory (LocalStore) or an S3 bucket (S3Store).
+// When storage encryption is enabled they are sealed before they leave the
+// process, so a blob store holds no more plaintext than the database does.
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+)
+
+// DefaultThreshold is the payload size above which blocks are offloaded when
+// no threshold is configured.
+const DefaultThreshold = 256 * 1024
+
+// hashPrefix prefixes content hashes, matching truncation markers.
+const hashPrefix = "sha256:"
+
+var (
+	// ErrUnsupportedScheme is returned when fetching a URI whose scheme has no
+	// available backend.
+	ErrUnsupportedScheme = errors.New("unsupported blob URI scheme")
+
+	// ErrChecksumMismatch is returned when fetched bytes do not match the
+	// referenced hash.
+	ErrChecksumMismatch = errors.New("blob checksum mismatch")
+
+	// ErrEncrypted is returned when fetching a sealed blob without a cipher.
+	ErrEncrypted = errors.New("blob is encrypted and no encryption key is configured")
+)
+
+// Store persists blobs outside of the primary database.
+type Store interface {
+	// Put stores data and returns a URI from which Fetch can retrieve it.
+	Put(ctx context.Context, data []byte) (string, error)
+}
+
+// NamedStore is a Store that can also keep data under a name chosen by the
+// caller, which sealed blobs need because their bytes differ on every seal.
+type NamedStore interface {
+	Store
+
+	// PutNamed stores data under name and returns its URI. A blob already
+	// stored under name is left as it is.
+	PutNamed(ctx context.Context, name string, data []byte) (string, error)
+}
+
+// NewStore opens the blob store at target: an S3 bucket given as
+// s3://bucket/prefix, configured from the environment as S3ConfigFromEnv
+// describes, or else a local directory. Blobs are sealed with c when it is
+// not nil.
+func NewStore(target string, c *encryption.Cipher) (Store, error) {
+	var (
+		store NamedStore
+		err   error
+	)
+	if bucket, ok := strings.CutPrefix(target, "s3://"); ok {
+		bucket, prefix, _ := strings.Cut(bucket, "/")
+		store, err = NewS3Store(bucket, prefix, S3ConfigFromEnv())
+	} else {
+		store, err = NewLocalStore(target)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Sealed(store, c), nil
+}
+
+// Sealed returns a Store that seals blobs with c before putting them in
+// store. Sealed blobs are named by the cipher's fingerprint of their
+// plaintext, so a payload keeps its URI, and is stored once, however often
+// it is put. It returns store itself when c is nil.
+func Sealed(store NamedStore, c *encryption.Cipher) Store {
+	if c == nil {
+		return store
+	}
+	return &sealedStore{store: store, cipher: c}
+}
+
+// sealedStore encrypts blobs on their way into another Store.
+type sealedStore struct {
+	store  NamedStore
+	cipher *encryption.Cipher
+}
+
+// Put seals data and stores the result under the fingerprint of data.
+func (s *sealedStore) Put(ctx context.Context, data []byte) (string, error) {
+	var sealed bytes.Buffer
+	if err := s.cipher.EncryptStream(&sealed, bytes.NewReader(data)); err != nil {
+		return "", fmt.Errorf("sealing blob: %w", err)
+	}
+	return s.store.PutNamed(ctx, hex.EncodeToString(s.cipher.Fingerprint(data)), sealed.Bytes())
+}
+
+// Sum returns the content hash recorded for data.
+func Sum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hashPrefix + hex.EncodeToString(sum[:])
+}
+
+// Fetch retrieves the blob at uri, opens it with c when it is sealed, and
+// verifies it against contentHash. Fetching depends only on the URI, the
+// cipher, and for S3 the environment, so readers such as the deck need no
+// store configuration.
+func Fetch(ctx context.Context, uri, contentHash string, c *encryption.Cipher) ([]byte, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("parsing blob URI: %w", err)
+	}
+
+	var data []byte
+	switch u.Scheme {
+	case "file":
+		data, err = os.ReadFile(u.Path)
+		if err != nil {
+			return nil, fmt.Errorf("reading blob: %w", err)
+		}
+	case "s3":
+		data, err = fetchS3(ctx, u, S3ConfigFromEnv())
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedScheme, u.Scheme)
+	}
+
+	// Blobs stored before encryption was enabled are read back unchanged.
+	if encryption.IsEncryptedStream(data) {
+		if c == nil {
+			return nil, fmt.Errorf("%w: %s", ErrEncrypted, uri)
+		}
+		var plain bytes.Buffer
+		if err := c.DecryptStream(&plain, bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("opening blob: %w", err)
+		}
+		data = plain.Bytes()
+	}
+
+	if Sum(data) != contentHash {
+		return nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, uri)
+	}
+	return data, nil
+}
+
+// Delete removes the blob at uri. A blob that is already gone is not an
+// error. Callers must make sure nothing references the blob any longer.
+func Delete(ctx context.Context, uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("parsing blob URI: %w", err)
+	}
+
+	switch u.Scheme {
+	case "file":
+		if err := os.Remove(u.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("deleting blob: %w", err)
+		}
+		return nil
+	case "s3":
+		return deleteS3(ctx, u, S3ConfigFromEnv())
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedScheme, u.Scheme)
+	}
+}
+
+// Offload writes each block payload larger than threshold to store and
+// replaces it with a reference. The payload is the text of text blocks, the
+// output of tool_result blocks, and the inline data of image blocks.
+//
+// The input slice is never modified. On error the original blocks are
+// returned alongside it, so callers can fall back to storing them inline.
+func Offload(ctx context.Context, store Store, blocks []llm.ContentBlock, threshold int) ([]llm.ContentBlock, error) {
+	if store == nil {
+		return blocks, nil
+	}
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+
+	var result []llm.ContentBlock
+	for i, block := range blocks {
+		payload := block.Payload()
+		// A block carrying the hash of other data, such as an image read
+		// back from a media store, keeps the reference it has.
+		if len(*payload) <= threshold || (block.ContentHash != "" && block.ContentHash != Sum([]byte(*payload))) {
+			if result != nil {
+				result = append(result, block)
+			}
+			continue
+		}
+
+		data := []byte(*payload)
+		uri, err := store.Put(ctx, data)
+		if err != nil {
+			return blocks, fmt.Errorf("offloading content block: %w", err)
+		}
+
+		if result == nil {
+			result = make([]llm.ContentBlock, i, len(blocks))
+			copy(result, blocks[:i])
+		}
+		*payload = ""
+		block.BlobURI = uri
+		block.ContentHash = Sum(data)
+		block.OriginalBytes = len(data)
+		result = append(result, block)
+	}
+
+	if result == nil {
+		return blocks, nil
+	}
+	return result, nil
+}
+
+// Rehydrate returns a copy of blocks with offloaded payloads fetched back
+// inline, opening sealed blobs with c. Blocks whose blob cannot be fetched or
+// verified keep their reference and the first such error is returned.
+func Rehydrate(ctx context.Context, blocks []llm.ContentBlock, c *encryption.Cipher) ([]llm.ContentBlock, error) {
+	var (
+		result   []llm.ContentBlock
+		firstErr error
+	)
+	for i, block := range blocks {
+		if block.BlobURI == "" {
+			continue
+		}
+
+		data, err := Fetch(ctx, block.BlobURI, block.ContentHash, c)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		if result == nil {
+			result = make([]llm.ContentBlock, len(blocks))
+			copy(result, blocks)
+		}
+		*block.Payload() = string(data)
+		block.BlobURI = ""
+		block.ContentHash = ""
+		block.OriginalBytes = 0
+		result[i] = block
+	}
+
+	if result == nil {
+		return blocks, firstErr
+	}
+	return result, firstErr
+}
@@ -0,0 +1,248 @@
+package blob_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+)
+
+type failingStore struct{}
+
+func (failingStore) Put(context.Context, []byte) (string, error) {
+	return "", errors.New("disk full")
+}
+
+var _ = Describe("LocalStore", func() {
+	var (
+		ctx   context.Context
+		store *blob.LocalStore
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		store, err = blob.NewLocalStore(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("stores blobs that can be fetched and verified", func() {
+		data := []byte("hello blob")
+		uri, err := store.Put(ctx, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uri).To(HavePrefix("file://"))
+
+		got, err := blob.Fetch(ctx, uri, blob.Sum(data), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got).To(Equal(data))
+	})
+
+	It("deduplicates identical payloads", func() {
+		a, err := store.Put(ctx, []byte("same"))
+		Expect(err).NotTo(HaveOccurred())
+		b, err := store.Put(ctx, []byte("same"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(a).To(Equal(b))
+	})
+
+	It("detects tampered blobs", func() {
+		uri, err := store.Put(ctx, []byte("original"))
+		Expect(err).NotTo(HaveOccurred())
+
+		u, err := url.Parse(uri)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(u.Path, []byte("tampered"), 0o600)).To(Succeed())
+
+		_, err = blob.Fetch(ctx, uri, blob.Sum([]byte("original")), nil)
+		Expect(err).To(MatchError(blob.ErrChecksumMismatch))
+	})
+
+	It("deletes blobs", func() {
+		data := []byte("hello blob")
+		uri, err := store.Put(ctx, data)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(blob.Delete(ctx, uri)).To(Succeed())
+		_, err = blob.Fetch(ctx, uri, blob.Sum(data), nil)
+		Expect(err).To(MatchError(os.ErrNotExist))
+		Expect(blob.Delete(ctx, uri)).To(Succeed())
+	})
+
+	It("rejects unsupported schemes", func() {
+		_, err := blob.Fetch(ctx, "gs://bucket/key", "sha256:00", nil)
+		Expect(err).To(MatchError(blob.ErrUnsupportedScheme))
+	})
+})
+
+var _ = Describe("Sealed", func() {
+	var (
+		ctx    context.Context
+		cipher *encryption.Cipher
+		store  blob.Store
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		cipher, err = encryption.NewCipher(bytes.Repeat([]byte{7}, encryption.KeySize))
+		Expect(err).NotTo(HaveOccurred())
+		store, err = blob.NewStore(GinkgoT().TempDir(), cipher)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("writes no plaintext and opens blobs with the cipher", func() {
+		data := []byte("secret payload")
+		uri, err := store.Put(ctx, data)
+		Expect(err).NotTo(HaveOccurred())
+
+		u, err := url.Parse(uri)
+		Expect(err).NotTo(HaveOccurred())
+		raw, err := os.ReadFile(u.Path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(raw)).NotTo(ContainSubstring("secret"))
+
+		got, err := blob.Fetch(ctx, uri, blob.Sum(data), cipher)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got).To(Equal(data))
+	})
+
+	It("names a payload the same way every time it is sealed", func() {
+		a, err := store.Put(ctx, []byte("secret payload"))
+		Expect(err).NotTo(HaveOccurred())
+		b, err := store.Put(ctx, []byte("secret payload"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(a).To(Equal(b))
+		Expect(a).NotTo(ContainSubstring(strings.TrimPrefix(blob.Sum([]byte("secret payload")), "sha256:")))
+	})
+
+	It("keeps node hashes stable across turns", func() {
+		message := merkle.Bucket{
+			Type:    "message",
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: strings.Repeat("x", 100)}},
+		}
+
+		var hashes []string
+		for range 2 {
+			bucket := message
+			var err error
+			bucket.Content, err = blob.Offload(ctx, store, message.Content, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bucket.Content[0].BlobURI).NotTo(BeEmpty())
+			hashes = append(hashes, merkle.NewNode(bucket, nil).Hash)
+		}
+		Expect(hashes[1]).To(Equal(hashes[0]))
+	})
+
+	It("hashes a turn the same whichever store holds its payload", func() {
+		other, err := blob.NewStore(GinkgoT().TempDir(), nil)
+		Expect(err).NotTo(HaveOccurred())
+		message := merkle.Bucket{
+			Type:    "message",
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: strings.Repeat("x", 100)}},
+		}
+
+		var hashes []string
+		for _, s := range []blob.Store{store, other} {
+			bucket := message
+			bucket.Content, err = blob.Offload(ctx, s, message.Content, 10)
+			Expect(err).NotTo(HaveOccurred())
+			hashes = append(hashes, merkle.NewNode(bucket, nil).Hash)
+		}
+		Expect(hashes[1]).To(Equal(hashes[0]))
+	})
+
+	It("refuses to fetch sealed blobs without a cipher", func() {
+		data := []byte("secret payload")
+		uri, err := store.Put(ctx, data)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = blob.Fetch(ctx, uri, blob.Sum(data), nil)
+		Expect(err).To(MatchError(blob.ErrEncrypted))
+	})
+
+	It("reads blobs stored before encryption was enabled", func() {
+		plain, err := blob.NewLocalStore(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		data := []byte("old payload")
+		uri, err := plain.Put(ctx, data)
+		Expect(err).NotTo(HaveOccurred())
+
+		got, err := blob.Fetch(ctx, uri, blob.Sum(data), cipher)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got).To(Equal(data))
+	})
+})
+
+var _ = Describe("Offload and Rehydrate", func() {
+	var (
+		ctx   context.Context
+		store *blob.LocalStore
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		store, err = blob.NewLocalStore(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("replaces oversized payloads with references and restores them", func() {
+		large := strings.Repeat("x", 100)
+		blocks := []llm.ContentBlock{
+			{Type: "text", Text: "small"},
+			{Type: "text", Text: large},
+			{Type: "tool_result", ToolResultID: "t1", ToolOutput: large},
+			{Type: "image", MediaType: "image/png", ImageBase64: large},
+		}
+
+		offloaded, err := blob.Offload(ctx, store, blocks, 10)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(offloaded[0]).To(Equal(blocks[0]))
+		for _, b := range offloaded[1:] {
+			Expect(b.BlobURI).To(HavePrefix("file://"))
+			Expect(b.ContentHash).To(Equal(blob.Sum([]byte(large))))
+			Expect(b.OriginalBytes).To(Equal(100))
+			Expect(b.Text + b.ToolOutput + b.ImageBase64).To(BeEmpty())
+		}
+		Expect(offloaded[2].ToolResultID).To(Equal("t1"))
+		Expect(blocks[1].Text).To(Equal(large))
+
+		restored, err := blob.Rehydrate(ctx, offloaded, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored).To(Equal(blocks))
+	})
+
+	It("returns blocks unchanged when nothing exceeds the threshold", func() {
+		blocks := []llm.ContentBlock{{Type: "text", Text: "small"}}
+		offloaded, err := blob.Offload(ctx, store, blocks, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offloaded).To(Equal(blocks))
+	})
+
+	It("returns the original blocks when the store fails", func() {
+		blocks := []llm.ContentBlock{{Type: "text", Text: strings.Repeat("x", 20)}}
+		offloaded, err := blob.Offload(ctx, failingStore{}, blocks, 10)
+		Expect(err).To(MatchError(ContainSubstring("disk full")))
+		Expect(offloaded).To(Equal(blocks))
+	})
+
+	It("keeps references it cannot fetch", func() {
+		blocks := []llm.ContentBlock{{Type: "text", BlobURI: "file:///nonexistent/blob", ContentHash: "sha256:00"}}
+		restored, err := blob.Rehydrate(ctx, blocks, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(restored).To(Equal(blocks))
+	})
+})
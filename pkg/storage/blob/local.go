@@ -0,0 +1,77 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore stores blobs as files in a directory, named by their SHA-256 and
+// sharded by the first two characters of the name. Identical payloads are
+// stored once.
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates a LocalStore rooted at dir, creating it if needed.
+func NewLocalStore(dir string) (*LocalStore, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving blob dir: %w", err)
+	}
+	if err := os.MkdirAll(abs, 0o755); err != nil {
+		return nil, fmt.Errorf("creating blob dir: %w", err)
+	}
+	return &LocalStore{dir: abs}, nil
+}
+
+// Put writes data to the store and returns its file:// URI.
+func (s *LocalStore) Put(ctx context.Context, data []byte) (string, error) {
+	return s.PutNamed(ctx, strings.TrimPrefix(Sum(data), hashPrefix), data)
+}
+
+// PutNamed writes data to the store under name, unless a blob of that name
+// exists, and returns its file:// URI.
+func (s *LocalStore) PutNamed(_ context.Context, name string, data []byte) (string, error) {
+	if len(name) < 2 || strings.ContainsAny(name, `/\.`) {
+		return "", fmt.Errorf("invalid blob name %q", name)
+	}
+	path := filepath.Join(s.dir, name[:2], name)
+	uri := (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+
+	if _, err := os.Stat(path); err == nil {
+		return uri, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("checking blob: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("creating blob shard dir: %w", err)
+	}
+
+	// Write to a temporary file and rename so readers never observe a
+	// partially written blob.
+	tmp, err := os.CreateTemp(filepath.Dir(path), name+".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("creating blob: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return "", fmt.Errorf("writing blob: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", fmt.Errorf("writing blob: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", fmt.Errorf("committing blob: %w", err)
+	}
+
+	return uri, nil
+}
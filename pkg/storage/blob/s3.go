@@ -0,0 +1,312 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// defaultS3Region is the region used when none is configured.
+const defaultS3Region = "us-east-1"
+
+// s3Timeout bounds a single S3 request.
+const s3Timeout = time.Minute
+
+// S3Config locates an S3 bucket and the credentials to reach it with.
+type S3Config struct {
+	// Endpoint is the base URL of an S3-compatible service, such as MinIO,
+	// whose buckets are addressed by path. When empty, AWS S3 in Region is
+	// used with virtual-hosted addressing.
+	Endpoint string
+
+	// Region signs requests, and selects the AWS endpoint (defaults to
+	// us-east-1).
+	Region string
+
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// HTTPClient sends requests (defaults to a client with a one minute
+	// timeout).
+	HTTPClient *http.Client
+}
+
+// S3ConfigFromEnv reads the standard AWS environment variables:
+// AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL, AWS_REGION or AWS_DEFAULT_REGION,
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and AWS_SESSION_TOKEN.
+func S3ConfigFromEnv() S3Config {
+	return S3Config{
+		Endpoint:        firstEnv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"),
+		Region:          firstEnv("AWS_REGION", "AWS_DEFAULT_REGION"),
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// firstEnv returns the first of the environment variables that is set.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// S3Store stores blobs as objects in an S3 bucket, or a bucket of any
+// S3-compatible service, keyed by their SHA-256 under a prefix.
+type S3Store struct {
+	bucket string
+	prefix string
+	client *s3Client
+}
+
+// NewS3Store creates an S3Store writing to bucket under prefix.
+func NewS3Store(bucket, prefix string, cfg S3Config) (*S3Store, error) {
+	if bucket == "" {
+		return nil, errors.New("creating S3 blob store: no bucket given")
+	}
+	client, err := newS3Client(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating S3 blob store: %w", err)
+	}
+	return &S3Store{bucket: bucket, prefix: strings.Trim(prefix, "/"), client: client}, nil
+}
+
+// Put uploads data and returns its s3:// URI. Objects are named by content,
+// so a payload already in the bucket is not uploaded again.
+func (s *S3Store) Put(ctx context.Context, data []byte) (string, error) {
+	return s.PutNamed(ctx, strings.TrimPrefix(Sum(data), hashPrefix), data)
+}
+
+// PutNamed uploads data under name, unless an object of that name exists,
+// and returns its s3:// URI.
+func (s *S3Store) PutNamed(ctx context.Context, name string, data []byte) (string, error) {
+	key := path.Join(s.prefix, name)
+	uri := (&url.URL{Scheme: "s3", Host: s.bucket, Path: "/" + key}).String()
+
+	exists, err := s.exists(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return uri, nil
+	}
+
+	resp, err := s.client.do(ctx, http.MethodPut, s.bucket, key, data)
+	if err != nil {
+		return "", fmt.Errorf("uploading blob: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("uploading blob: %s", s3Error(resp))
+	}
+	return uri, nil
+}
+
+// exists reports whether an object is stored under key. Only a successful
+// HEAD counts: S3 answers 403 rather than 404 for missing keys when the
+// credentials cannot list the bucket, so any other status means the object
+// is uploaded, and the upload reports what is wrong.
+func (s *S3Store) exists(ctx context.Context, key string) (bool, error) {
+	resp, err := s.client.do(ctx, http.MethodHead, s.bucket, key, nil)
+	if err != nil {
+		return false, fmt.Errorf("checking blob: %w", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusOK, nil
+}
+
+// fetchS3 downloads the object at an s3:// URI.
+func fetchS3(ctx context.Context, u *url.URL, cfg S3Config) ([]byte, error) {
+	client, err := newS3Client(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("reading blob: %w", err)
+	}
+	resp, err := client.do(ctx, http.MethodGet, u.Host, strings.TrimPrefix(u.Path, "/"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading blob: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reading blob: %s", s3Error(resp))
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading blob: %w", err)
+	}
+	return data, nil
+}
+
+// deleteS3 deletes the object at an s3:// URI.
+func deleteS3(ctx context.Context, u *url.URL, cfg S3Config) error {
+	client, err := newS3Client(cfg)
+	if err != nil {
+		return fmt.Errorf("deleting blob: %w", err)
+	}
+	resp, err := client.do(ctx, http.MethodDelete, u.Host, strings.TrimPrefix(u.Path, "/"), nil)
+	if err != nil {
+		return fmt.Errorf("deleting blob: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("deleting blob: %s", s3Error(resp))
+	}
+}
+
+// s3Error describes a failed S3 response by its status and the start of its
+// body, which carries the service's error code.
+func s3Error(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if msg := strings.TrimSpace(string(body)); msg != "" {
+		return resp.Status + ": " + msg
+	}
+	return resp.Status
+}
+
+// s3Client sends object requests signed with AWS Signature Version 4.
+type s3Client struct {
+	cfg  S3Config
+	http *http.Client
+
+	// now is the signing time; it is replaced in tests.
+	now func() time.Time
+}
+
+func newS3Client(cfg S3Config) (*s3Client, error) {
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("S3 credentials are not configured: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	if cfg.Region == "" {
+		cfg.Region = defaultS3Region
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: s3Timeout}
+	}
+	return &s3Client{cfg: cfg, http: httpClient, now: time.Now}, nil
+}
+
+// objectURL returns the URL of key in bucket.
+func (c *s3Client) objectURL(bucket, key string) string {
+	if c.cfg.Endpoint != "" {
+		return strings.TrimSuffix(c.cfg.Endpoint, "/") + "/" + bucket + "/" + s3Escape(key, false)
+	}
+	return "https://" + bucket + ".s3." + c.cfg.Region + ".amazonaws.com/" + s3Escape(key, false)
+}
+
+// do sends a signed request for key in bucket with body as its payload.
+func (c *s3Client) do(ctx context.Context, method, bucket, key string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.objectURL(bucket, key), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	c.sign(req, body)
+	return c.http.Do(req)
+}
+
+// sign adds the AWS Signature Version 4 headers to req, signing its host,
+// every header it carries, and body.
+func (c *s3Client) sign(req *http.Request, body []byte) {
+	now := c.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := now.Format("20060102") + "/" + c.cfg.Region + "/s3/aws4_request"
+
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	if c.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.cfg.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.Join(strings.Fields(strings.Join(values, ",")), " ")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + c.cfg.SecretAccessKey)
+	for _, part := range []string{now.Format("20060102"), c.cfg.Region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+c.cfg.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalQuery encodes query parameters sorted by name, as signed.
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, s3Escape(name, true)+"="+s3Escape(value, true))
+		}
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape percent-encodes s as SigV4 requires: every byte but unreserved
+// characters, and slashes too when escapeSlash is set.
+func s3Escape(s string, escapeSlash bool) string {
+	var b strings.Builder
+	for i := range len(s) {
+		ch := s[i]
+		switch {
+		case 'A' <= ch && ch <= 'Z', 'a' <= ch && ch <= 'z', '0' <= ch && ch <= '9',
+			ch == '-', ch == '_', ch == '.', ch == '~', ch == '/' && !escapeSlash:
+			b.WriteByte(ch)
+		default:
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
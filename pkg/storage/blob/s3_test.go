@@ -0,0 +1,157 @@
+package blob_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
+)
+
+// fakeS3 is an S3-compatible object store holding objects in memory, keyed
+// by request path.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	puts    int
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=test-key/") {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	sum := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+		http.Error(w, "<Error><Code>XAmzContentSHA256Mismatch</Code></Error>", http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = body
+		f.puts++
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodHead:
+		if _, ok := f.objects[r.URL.Path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	case http.MethodGet:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+var _ = Describe("S3Store", func() {
+	var (
+		ctx    context.Context
+		fake   *fakeS3
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fake = &fakeS3{objects: map[string][]byte{}}
+		server = httptest.NewServer(fake)
+		DeferCleanup(server.Close)
+
+		GinkgoT().Setenv("AWS_ENDPOINT_URL_S3", server.URL)
+		GinkgoT().Setenv("AWS_REGION", "eu-west-1")
+		GinkgoT().Setenv("AWS_ACCESS_KEY_ID", "test-key")
+		GinkgoT().Setenv("AWS_SECRET_ACCESS_KEY", "test-secret")
+	})
+
+	It("stores blobs under the prefix that can be fetched and verified", func() {
+		store, err := blob.NewStore("s3://tapes-blobs/captures", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		data := []byte("hello s3")
+		uri, err := store.Put(ctx, data)
+		Expect(err).NotTo(HaveOccurred())
+
+		name := strings.TrimPrefix(blob.Sum(data), "sha256:")
+		Expect(uri).To(Equal("s3://tapes-blobs/captures/" + name))
+		Expect(fake.objects).To(HaveKeyWithValue("/tapes-blobs/captures/"+name, data))
+
+		got, err := blob.Fetch(ctx, uri, blob.Sum(data), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got).To(Equal(data))
+	})
+
+	It("does not upload a payload the bucket already holds", func() {
+		store, err := blob.NewStore("s3://tapes-blobs", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		a, err := store.Put(ctx, []byte("same"))
+		Expect(err).NotTo(HaveOccurred())
+		b, err := store.Put(ctx, []byte("same"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(Equal(a))
+		Expect(fake.puts).To(Equal(1))
+	})
+
+	It("offloads and rehydrates blocks", func() {
+		store, err := blob.NewStore("s3://tapes-blobs", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		blocks := []llm.ContentBlock{{Type: "text", Text: strings.Repeat("x", 100)}}
+		offloaded, err := blob.Offload(ctx, store, blocks, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(offloaded[0].BlobURI).To(HavePrefix("s3://tapes-blobs/"))
+
+		restored, err := blob.Rehydrate(ctx, offloaded, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored).To(Equal(blocks))
+	})
+
+	It("deletes objects", func() {
+		store, err := blob.NewStore("s3://tapes-blobs", nil)
+		Expect(err).NotTo(HaveOccurred())
+		uri, err := store.Put(ctx, []byte("data"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(blob.Delete(ctx, uri)).To(Succeed())
+		Expect(fake.objects).To(BeEmpty())
+		Expect(blob.Delete(ctx, uri)).To(Succeed())
+	})
+
+	It("reports missing objects", func() {
+		_, err := blob.Fetch(ctx, "s3://tapes-blobs/missing", "sha256:00", nil)
+		Expect(err).To(MatchError(ContainSubstring("NoSuchKey")))
+	})
+
+	It("reports rejected credentials", func() {
+		GinkgoT().Setenv("AWS_ACCESS_KEY_ID", "other-key")
+		store, err := blob.NewStore("s3://tapes-blobs", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = store.Put(ctx, []byte("data"))
+		Expect(err).To(MatchError(ContainSubstring("AccessDenied")))
+	})
+
+	It("requires credentials", func() {
+		GinkgoT().Setenv("AWS_ACCESS_KEY_ID", "")
+		_, err := blob.NewStore("s3://tapes-blobs", nil)
+		Expect(err).To(MatchError(ContainSubstring("AWS_ACCESS_KEY_ID")))
+	})
+})
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// DeadLetterStage records which half of a conversation turn failed to parse.
+type DeadLetterStage string
+
+const (
+	// DeadLetterStageRequest marks a turn whose request payload failed to parse.
+	DeadLetterStageRequest DeadLetterStage = "request"
+
+	// DeadLetterStageResponse marks a turn whose response payload failed to parse.
+	DeadLetterStageResponse DeadLetterStage = "response"
+)
+
+// DeadLetter is a proxied turn that could not be parsed into a conversation
+// turn. The raw payloads are kept so the turn can be reprocessed once the
+// provider parser is fixed.
+type DeadLetter struct {
+	ID        int               `json:"id"`
+	Stage     DeadLetterStage   `json:"stage"`
+	Provider  string            `json:"provider"`
+	AgentName string            `json:"agent_name,omitempty"`
+	Path      string            `json:"path"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Request   string            `json:"request"`
+	Response  string            `json:"response,omitempty"`
+	Error     string            `json:"error"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// DeadLetterStore is implemented by drivers that can persist dead letters.
+type DeadLetterStore interface {
+	// AddDeadLetter stores a dead letter, assigning its ID and CreatedAt.
+	AddDeadLetter(ctx context.Context, dl *DeadLetter) error
+
+	// ListDeadLetters returns all dead letters, oldest first.
+	ListDeadLetters(ctx context.Context) ([]*DeadLetter, error)
+
+	// GetDeadLetter retrieves a dead letter by ID.
+	GetDeadLetter(ctx context.Context, id int) (*DeadLetter, error)
+
+	// DeleteDeadLetter removes a dead letter by ID.
+	DeleteDeadLetter(ctx context.Context, id int) error
+}
+
+// DeadLetterNotFoundError is returned when a dead letter doesn't exist in the store.
+type DeadLetterNotFoundError struct {
+	ID int
+}
+
+func (e DeadLetterNotFoundError) Error() string {
+	return "dead letter not found: " + strconv.Itoa(e.ID)
+}
@@ -1,4 +1,8 @@
-// Package storage
+// Package storage defines the Driver interface that persists Merkle DAG nodes,
+// along with the optional interfaces for what some drivers store besides
+// them, such as dead letters, raw captures, and session summaries. Drivers
+// need not implement the optional interfaces: callers type-assert a Driver to
+// discover whether it supports one.
 package storage
 
 import (
@@ -16,6 +20,12 @@ type Driver interface {
 	// a no-op. Put provides automatic deduplication via content-addressing in the dag.
 	Put(ctx context.Context, node *merkle.Node) (bool, error)
 
+	// InsertBatch stores nodes atomically, in order, so that a node may be the
+	// parent of one later in the batch. It reports for each node whether it
+	// was newly inserted, with the same deduplication as Put. Either every new
+	// node is stored or, on error, none are.
+	InsertBatch(ctx context.Context, nodes []*merkle.Node) ([]bool, error)
+
 	// Get retrieves a node by its hash.
 	Get(ctx context.Context, hash string) (*merkle.Node, error)
 
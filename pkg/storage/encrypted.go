@@ -0,0 +1,20 @@
+package storage
+
+import "github.com/papercomputeco/tapes/pkg/storage/encryption"
+
+// EncryptedStore is implemented by drivers that can encrypt content at rest.
+type EncryptedStore interface {
+	// Cipher returns the cipher content is sealed with, or nil when
+	// encryption is disabled.
+	Cipher() *encryption.Cipher
+}
+
+// CipherOf returns the cipher driver seals content with, or nil when it does
+// not encrypt content. Files kept beside the database, such as offloaded
+// blobs and media, are sealed with it too.
+func CipherOf(driver Driver) *encryption.Cipher {
+	if store, ok := driver.(EncryptedStore); ok {
+		return store.Cipher()
+	}
+	return nil
+}
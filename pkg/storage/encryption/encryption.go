@@ -0,0 +1,129 @@
+// Package encryption provides AES-GCM sealing of node content at rest.
+//
+// Only content is encrypted: node hashes are always computed over plaintext,
+// so Merkle integrity checks and content addressing are unaffected by whether
+// a database is encrypted.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeySize is the required key length in bytes (AES-256).
+const KeySize = 32
+
+// sealedPrefix versions the ciphertext format so that it can evolve.
+const sealedPrefix = "v1:"
+
+// fingerprintLabel derives the fingerprint key from the encryption key, so
+// fingerprints reveal nothing about the key used to seal.
+const fingerprintLabel = "tapes fingerprint v1"
+
+// ErrInvalidCiphertext is returned when sealed data is malformed or fails
+// authentication, for example because it was encrypted with a different key.
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// Cipher seals and opens content with AES-256-GCM. It is safe for concurrent use.
+type Cipher struct {
+	aead cipher.AEAD
+
+	// fingerprintKey keys Fingerprint.
+	fingerprintKey []byte
+}
+
+// NewCipher creates a Cipher from a 32-byte key.
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating AES cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("creating GCM: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(fingerprintLabel))
+	return &Cipher{aead: aead, fingerprintKey: mac.Sum(nil)}, nil
+}
+
+// Seal encrypts plaintext, binding it to additionalData (such as the node
+// hash) so that sealed content cannot be moved between records. The result is
+// a versioned, base64-encoded string.
+func (c *Cipher) Seal(plaintext, additionalData []byte) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating nonce: %w", err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, plaintext, additionalData)
+	return sealedPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts a string produced by Seal with the same additionalData.
+func (c *Cipher) Open(sealed string, additionalData []byte) ([]byte, error) {
+	encoded, ok := strings.CutPrefix(sealed, sealedPrefix)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown format", ErrInvalidCiphertext)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+	}
+
+	nonceSize := c.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidCiphertext)
+	}
+
+	plaintext, err := c.aead.Open(nil, data[:nonceSize], data[nonceSize:], additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+	}
+	return plaintext, nil
+}
+
+// Fingerprint returns a keyed SHA-256 MAC of data. Equal data always has an
+// equal fingerprint under the same key, so sealed content can be named
+// deterministically, but without the key a fingerprint cannot be used to
+// confirm a guess at the content the way a plain hash could.
+func (c *Cipher) Fingerprint(data []byte) []byte {
+	mac := hmac.New(sha256.New, c.fingerprintKey)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// GenerateKey returns a new random key encoded for use with ParseKey.
+func GenerateKey() (string, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("generating key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// ParseKey decodes a base64-encoded 32-byte key.
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("decoding encryption key: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encryption key must be %d bytes, got %d", KeySize, len(key))
+	}
+	return key, nil
+}
@@ -0,0 +1,113 @@
+package encryption_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+)
+
+var _ = Describe("Cipher", func() {
+	var c *encryption.Cipher
+
+	BeforeEach(func() {
+		var err error
+		c, err = encryption.NewCipher(bytes.Repeat([]byte{7}, encryption.KeySize))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("round-trips plaintext", func() {
+		sealed, err := c.Seal([]byte("secret code"), []byte("hash-1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sealed).To(HavePrefix("v1:"))
+		Expect(sealed).NotTo(ContainSubstring("secret"))
+
+		plaintext, err := c.Open(sealed, []byte("hash-1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(plaintext)).To(Equal("secret code"))
+	})
+
+	It("uses a fresh nonce for every seal", func() {
+		a, err := c.Seal([]byte("same"), nil)
+		Expect(err).NotTo(HaveOccurred())
+		b, err := c.Seal([]byte("same"), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(a).NotTo(Equal(b))
+	})
+
+	It("rejects ciphertext bound to different additional data", func() {
+		sealed, err := c.Seal([]byte("secret"), []byte("hash-1"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = c.Open(sealed, []byte("hash-2"))
+		Expect(err).To(MatchError(encryption.ErrInvalidCiphertext))
+	})
+
+	It("rejects ciphertext sealed with another key", func() {
+		other, err := encryption.NewCipher(bytes.Repeat([]byte{8}, encryption.KeySize))
+		Expect(err).NotTo(HaveOccurred())
+		sealed, err := other.Seal([]byte("secret"), nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = c.Open(sealed, nil)
+		Expect(err).To(MatchError(encryption.ErrInvalidCiphertext))
+	})
+
+	It("rejects malformed input", func() {
+		_, err := c.Open("plain text", nil)
+		Expect(err).To(MatchError(encryption.ErrInvalidCiphertext))
+		_, err = c.Open("v1:!!!", nil)
+		Expect(err).To(MatchError(encryption.ErrInvalidCiphertext))
+	})
+
+	It("fingerprints equal data equally, and differently under another key", func() {
+		Expect(c.Fingerprint([]byte("same"))).To(Equal(c.Fingerprint([]byte("same"))))
+		Expect(c.Fingerprint([]byte("same"))).NotTo(Equal(c.Fingerprint([]byte("other"))))
+
+		other, err := encryption.NewCipher(bytes.Repeat([]byte{8}, encryption.KeySize))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(other.Fingerprint([]byte("same"))).NotTo(Equal(c.Fingerprint([]byte("same"))))
+	})
+
+	It("requires a 32-byte key", func() {
+		_, err := encryption.NewCipher([]byte("short"))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Keys", func() {
+	It("parses generated keys", func() {
+		encoded, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+
+		key, err := encryption.ParseKey(encoded)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(key).To(HaveLen(encryption.KeySize))
+	})
+
+	It("rejects keys of the wrong length", func() {
+		_, err := encryption.ParseKey(base64.StdEncoding.EncodeToString([]byte("short")))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("loads the key from the environment", func() {
+		encoded, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv(encryption.KeyEnvVar, encoded)
+
+		key, err := encryption.LoadKey(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(base64.StdEncoding.EncodeToString(key)).To(Equal(encoded))
+	})
+
+	It("fails on an invalid key in the environment", func() {
+		GinkgoT().Setenv(encryption.KeyEnvVar, "not-a-key")
+
+		_, err := encryption.LoadKey(context.Background())
+		Expect(err).To(MatchError(ContainSubstring(encryption.KeyEnvVar)))
+	})
+})
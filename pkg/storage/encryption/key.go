@@ -0,0 +1,73 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	// KeyEnvVar holds a base64-encoded key. It takes precedence over the keychain.
+	KeyEnvVar = "TAPES_ENCRYPTION_KEY"
+
+	// KeychainService and KeychainAccount identify the key in the OS keychain
+	// (the macOS login keychain, or the Secret Service on Linux).
+	KeychainService = "tapes"
+	KeychainAccount = "storage-encryption-key"
+)
+
+// LoadKey resolves the storage encryption key from the TAPES_ENCRYPTION_KEY
+// environment variable, falling back to the OS keychain. It returns a nil key
+// when neither is configured, meaning encryption is disabled.
+func LoadKey(ctx context.Context) ([]byte, error) {
+	if encoded := os.Getenv(KeyEnvVar); encoded != "" {
+		key, err := ParseKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", KeyEnvVar, err)
+		}
+		return key, nil
+	}
+
+	encoded := lookupKeychain(ctx)
+	if encoded == "" {
+		return nil, nil
+	}
+
+	key, err := ParseKey(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("keychain item %s/%s: %w", KeychainService, KeychainAccount, err)
+	}
+	return key, nil
+}
+
+// lookupKeychain reads the key from the platform keychain tool. A missing
+// tool or item is not an error; it simply means no key is stored.
+func lookupKeychain(ctx context.Context) string {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "security", "find-generic-password",
+			"-s", KeychainService, "-a", KeychainAccount, "-w")
+	case "linux":
+		if _, err := exec.LookPath("secret-tool"); err != nil {
+			return ""
+		}
+		cmd = exec.CommandContext(ctx, "secret-tool", "lookup",
+			"service", KeychainService, "account", KeychainAccount)
+	default:
+		return ""
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
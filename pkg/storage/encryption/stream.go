@@ -0,0 +1,128 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// StreamMagic starts every stream written by EncryptStream.
+const StreamMagic = "tapesenc1"
+
+const (
+	// streamChunkSize is the plaintext size of each sealed chunk.
+	streamChunkSize = 64 * 1024
+
+	// streamPrefixSize is the random part of each chunk's nonce; the rest
+	// is the chunk counter.
+	streamPrefixSize = 8
+
+	// finalChunk marks the last chunk in its length field, so a truncated
+	// stream fails to decrypt instead of yielding a shorter plaintext.
+	finalChunk = uint32(1) << 31
+)
+
+// IsEncryptedStream reports whether header, the first bytes of a file, starts
+// a stream written by EncryptStream.
+func IsEncryptedStream(header []byte) bool {
+	return bytes.HasPrefix(header, []byte(StreamMagic))
+}
+
+// EncryptStream seals src into dst in fixed-size chunks, so files of any size
+// can be encrypted without holding them in memory. Each chunk is bound to its
+// position and the stream header, so chunks cannot be reordered, dropped, or
+// truncated without Open failing.
+func (c *Cipher) EncryptStream(dst io.Writer, src io.Reader) error {
+	header := make([]byte, len(StreamMagic)+streamPrefixSize)
+	copy(header, StreamMagic)
+	if _, err := rand.Read(header[len(StreamMagic):]); err != nil {
+		return fmt.Errorf("generating nonce: %w", err)
+	}
+	if _, err := dst.Write(header); err != nil {
+		return err
+	}
+
+	buf := make([]byte, streamChunkSize)
+	var counter uint32
+	for {
+		n, err := io.ReadFull(src, buf)
+		final := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !final {
+			return err
+		}
+
+		sealed := c.aead.Seal(nil, c.streamNonce(header, counter), buf[:n], streamAAD(header, final))
+		length := uint32(len(sealed)) //nolint:gosec // chunks are far below 2^31 bytes
+		if final {
+			length |= finalChunk
+		}
+		if err := binary.Write(dst, binary.BigEndian, length); err != nil {
+			return err
+		}
+		if _, err := dst.Write(sealed); err != nil {
+			return err
+		}
+
+		if final {
+			return nil
+		}
+		counter++
+	}
+}
+
+// DecryptStream opens a stream written by EncryptStream into dst.
+func (c *Cipher) DecryptStream(dst io.Writer, src io.Reader) error {
+	header := make([]byte, len(StreamMagic)+streamPrefixSize)
+	if _, err := io.ReadFull(src, header); err != nil || !IsEncryptedStream(header) {
+		return fmt.Errorf("%w: not an encrypted stream", ErrInvalidCiphertext)
+	}
+
+	maxSealed := uint32(streamChunkSize + c.aead.Overhead()) //nolint:gosec // constant size
+	var counter uint32
+	for {
+		var length uint32
+		if err := binary.Read(src, binary.BigEndian, &length); err != nil {
+			return fmt.Errorf("%w: truncated stream", ErrInvalidCiphertext)
+		}
+		final := length&finalChunk != 0
+		length &^= finalChunk
+		if length > maxSealed {
+			return fmt.Errorf("%w: chunk too large", ErrInvalidCiphertext)
+		}
+
+		sealed := make([]byte, length)
+		if _, err := io.ReadFull(src, sealed); err != nil {
+			return fmt.Errorf("%w: truncated stream", ErrInvalidCiphertext)
+		}
+		plaintext, err := c.aead.Open(nil, c.streamNonce(header, counter), sealed, streamAAD(header, final))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+		}
+		if _, err := dst.Write(plaintext); err != nil {
+			return err
+		}
+
+		if final {
+			return nil
+		}
+		counter++
+	}
+}
+
+func (c *Cipher) streamNonce(header []byte, counter uint32) []byte {
+	nonce := make([]byte, c.aead.NonceSize())
+	copy(nonce, header[len(StreamMagic):])
+	binary.BigEndian.PutUint32(nonce[streamPrefixSize:], counter)
+	return nonce
+}
+
+func streamAAD(header []byte, final bool) []byte {
+	aad := append([]byte{}, header...)
+	if final {
+		return append(aad, 1)
+	}
+	return append(aad, 0)
+}
@@ -0,0 +1,72 @@
+package encryption_test
+
+import (
+	"bytes"
+	"crypto/rand"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+)
+
+var _ = Describe("Stream encryption", func() {
+	var c *encryption.Cipher
+
+	BeforeEach(func() {
+		var err error
+		c, err = encryption.NewCipher(bytes.Repeat([]byte{7}, encryption.KeySize))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	encrypt := func(plaintext []byte) []byte {
+		var sealed bytes.Buffer
+		Expect(c.EncryptStream(&sealed, bytes.NewReader(plaintext))).To(Succeed())
+		return sealed.Bytes()
+	}
+
+	DescribeTable("round-trips streams",
+		func(size int) {
+			plaintext := make([]byte, size)
+			_, err := rand.Read(plaintext)
+			Expect(err).NotTo(HaveOccurred())
+
+			sealed := encrypt(plaintext)
+			Expect(encryption.IsEncryptedStream(sealed)).To(BeTrue())
+			if size > 0 {
+				Expect(bytes.Contains(sealed, plaintext)).To(BeFalse())
+			}
+
+			var opened bytes.Buffer
+			Expect(c.DecryptStream(&opened, bytes.NewReader(sealed))).To(Succeed())
+			Expect(opened.Bytes()).To(Equal(plaintext))
+		},
+		Entry("empty", 0),
+		Entry("smaller than a chunk", 1000),
+		Entry("exactly one chunk", 64*1024),
+		Entry("several chunks", 200*1024),
+	)
+
+	It("rejects truncated streams", func() {
+		sealed := encrypt(make([]byte, 200*1024))
+
+		var opened bytes.Buffer
+		err := c.DecryptStream(&opened, bytes.NewReader(sealed[:len(sealed)/2]))
+		Expect(err).To(MatchError(encryption.ErrInvalidCiphertext))
+	})
+
+	It("rejects streams sealed with another key", func() {
+		sealed := encrypt([]byte("backup"))
+
+		other, err := encryption.NewCipher(bytes.Repeat([]byte{8}, encryption.KeySize))
+		Expect(err).NotTo(HaveOccurred())
+		var opened bytes.Buffer
+		Expect(other.DecryptStream(&opened, bytes.NewReader(sealed))).To(MatchError(encryption.ErrInvalidCiphertext))
+	})
+
+	It("rejects plaintext", func() {
+		var opened bytes.Buffer
+		err := c.DecryptStream(&opened, bytes.NewReader([]byte("SQLite format 3\x00")))
+		Expect(err).To(MatchError(encryption.ErrInvalidCiphertext))
+	})
+})
@@ -0,0 +1,69 @@
+// Code generated by ent, DO NOT EDIT.
+
+package block
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the block type in the database.
+	Label = "block"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "hash"
+	// FieldData holds the string denoting the data field in the database.
+	FieldData = "data"
+	// FieldSize holds the string denoting the size field in the database.
+	FieldSize = "size"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// Table holds the table name of the block in the database.
+	Table = "blocks"
+)
+
+// Columns holds all SQL columns for block fields.
+var Columns = []string{
+	FieldID,
+	FieldData,
+	FieldSize,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// SizeValidator is a validator for the "size" field. It is called by the builders before save.
+	SizeValidator func(int) error
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+	// IDValidator is a validator for the "id" field. It is called by the builders before save.
+	IDValidator func(string) error
+)
+
+// OrderOption defines the ordering options for the Block queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// BySize orders the results by the size field.
+func BySize(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSize, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
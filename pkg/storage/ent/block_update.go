@@ -0,0 +1,273 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/block"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+)
+
+// BlockUpdate is the builder for updating Block entities.
+type BlockUpdate struct {
+	config
+	hooks    []Hook
+	mutation *BlockMutation
+}
+
+// Where appends a list predicates to the BlockUpdate builder.
+func (_u *BlockUpdate) Where(ps ...predicate.Block) *BlockUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetData sets the "data" field.
+func (_u *BlockUpdate) SetData(v map[string]interface{}) *BlockUpdate {
+	_u.mutation.SetData(v)
+	return _u
+}
+
+// SetSize sets the "size" field.
+func (_u *BlockUpdate) SetSize(v int) *BlockUpdate {
+	_u.mutation.ResetSize()
+	_u.mutation.SetSize(v)
+	return _u
+}
+
+// SetNillableSize sets the "size" field if the given value is not nil.
+func (_u *BlockUpdate) SetNillableSize(v *int) *BlockUpdate {
+	if v != nil {
+		_u.SetSize(*v)
+	}
+	return _u
+}
+
+// AddSize adds value to the "size" field.
+func (_u *BlockUpdate) AddSize(v int) *BlockUpdate {
+	_u.mutation.AddSize(v)
+	return _u
+}
+
+// Mutation returns the BlockMutation object of the builder.
+func (_u *BlockUpdate) Mutation() *BlockMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *BlockUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *BlockUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *BlockUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *BlockUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *BlockUpdate) check() error {
+	if v, ok := _u.mutation.Size(); ok {
+		if err := block.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "Block.size": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *BlockUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(block.Table, block.Columns, sqlgraph.NewFieldSpec(block.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Data(); ok {
+		_spec.SetField(block.FieldData, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.Size(); ok {
+		_spec.SetField(block.FieldSize, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedSize(); ok {
+		_spec.AddField(block.FieldSize, field.TypeInt, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{block.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// BlockUpdateOne is the builder for updating a single Block entity.
+type BlockUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *BlockMutation
+}
+
+// SetData sets the "data" field.
+func (_u *BlockUpdateOne) SetData(v map[string]interface{}) *BlockUpdateOne {
+	_u.mutation.SetData(v)
+	return _u
+}
+
+// SetSize sets the "size" field.
+func (_u *BlockUpdateOne) SetSize(v int) *BlockUpdateOne {
+	_u.mutation.ResetSize()
+	_u.mutation.SetSize(v)
+	return _u
+}
+
+// SetNillableSize sets the "size" field if the given value is not nil.
+func (_u *BlockUpdateOne) SetNillableSize(v *int) *BlockUpdateOne {
+	if v != nil {
+		_u.SetSize(*v)
+	}
+	return _u
+}
+
+// AddSize adds value to the "size" field.
+func (_u *BlockUpdateOne) AddSize(v int) *BlockUpdateOne {
+	_u.mutation.AddSize(v)
+	return _u
+}
+
+// Mutation returns the BlockMutation object of the builder.
+func (_u *BlockUpdateOne) Mutation() *BlockMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the BlockUpdate builder.
+func (_u *BlockUpdateOne) Where(ps ...predicate.Block) *BlockUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *BlockUpdateOne) Select(field string, fields ...string) *BlockUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Block entity.
+func (_u *BlockUpdateOne) Save(ctx context.Context) (*Block, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *BlockUpdateOne) SaveX(ctx context.Context) *Block {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *BlockUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *BlockUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *BlockUpdateOne) check() error {
+	if v, ok := _u.mutation.Size(); ok {
+		if err := block.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "Block.size": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *BlockUpdateOne) sqlSave(ctx context.Context) (_node *Block, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(block.Table, block.Columns, sqlgraph.NewFieldSpec(block.FieldID, field.TypeString))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Block.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, block.FieldID)
+		for _, f := range fields {
+			if !block.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != block.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Data(); ok {
+		_spec.SetField(block.FieldData, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.Size(); ok {
+		_spec.SetField(block.FieldSize, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedSize(); ok {
+		_spec.AddField(block.FieldSize, field.TypeInt, value)
+	}
+	_node = &Block{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{block.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -15,8 +15,17 @@ import (
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/block"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/deadletter"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/facet"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/rawcapture"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/sessiontool"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/systemprompt"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/tooldefinition"
+
+	stdsql "database/sql"
 )
 
 // Client is the client that holds all ent builders.
@@ -24,10 +33,24 @@ type Client struct {
 	config
 	// Schema is the client for creating, migrating and dropping schema.
 	Schema *migrate.Schema
+	// Block is the client for interacting with the Block builders.
+	Block *BlockClient
+	// DeadLetter is the client for interacting with the DeadLetter builders.
+	DeadLetter *DeadLetterClient
 	// Facet is the client for interacting with the Facet builders.
 	Facet *FacetClient
 	// Node is the client for interacting with the Node builders.
 	Node *NodeClient
+	// RawCapture is the client for interacting with the RawCapture builders.
+	RawCapture *RawCaptureClient
+	// Session is the client for interacting with the Session builders.
+	Session *SessionClient
+	// SessionTool is the client for interacting with the SessionTool builders.
+	SessionTool *SessionToolClient
+	// SystemPrompt is the client for interacting with the SystemPrompt builders.
+	SystemPrompt *SystemPromptClient
+	// ToolDefinition is the client for interacting with the ToolDefinition builders.
+	ToolDefinition *ToolDefinitionClient
 }
 
 // NewClient creates a new client configured with the given options.
@@ -39,8 +62,15 @@ func NewClient(opts ...Option) *Client {
 
 func (c *Client) init() {
 	c.Schema = migrate.NewSchema(c.driver)
+	c.Block = NewBlockClient(c.config)
+	c.DeadLetter = NewDeadLetterClient(c.config)
 	c.Facet = NewFacetClient(c.config)
 	c.Node = NewNodeClient(c.config)
+	c.RawCapture = NewRawCaptureClient(c.config)
+	c.Session = NewSessionClient(c.config)
+	c.SessionTool = NewSessionToolClient(c.config)
+	c.SystemPrompt = NewSystemPromptClient(c.config)
+	c.ToolDefinition = NewToolDefinitionClient(c.config)
 }
 
 type (
@@ -131,10 +161,17 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	cfg := c.config
 	cfg.driver = tx
 	return &Tx{
-		ctx:    ctx,
-		config: cfg,
-		Facet:  NewFacetClient(cfg),
-		Node:   NewNodeClient(cfg),
+		ctx:            ctx,
+		config:         cfg,
+		Block:          NewBlockClient(cfg),
+		DeadLetter:     NewDeadLetterClient(cfg),
+		Facet:          NewFacetClient(cfg),
+		Node:           NewNodeClient(cfg),
+		RawCapture:     NewRawCaptureClient(cfg),
+		Session:        NewSessionClient(cfg),
+		SessionTool:    NewSessionToolClient(cfg),
+		SystemPrompt:   NewSystemPromptClient(cfg),
+		ToolDefinition: NewToolDefinitionClient(cfg),
 	}, nil
 }
 
@@ -152,17 +189,24 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	cfg := c.config
 	cfg.driver = &txDriver{tx: tx, drv: c.driver}
 	return &Tx{
-		ctx:    ctx,
-		config: cfg,
-		Facet:  NewFacetClient(cfg),
-		Node:   NewNodeClient(cfg),
+		ctx:            ctx,
+		config:         cfg,
+		Block:          NewBlockClient(cfg),
+		DeadLetter:     NewDeadLetterClient(cfg),
+		Facet:          NewFacetClient(cfg),
+		Node:           NewNodeClient(cfg),
+		RawCapture:     NewRawCaptureClient(cfg),
+		Session:        NewSessionClient(cfg),
+		SessionTool:    NewSessionToolClient(cfg),
+		SystemPrompt:   NewSystemPromptClient(cfg),
+		ToolDefinition: NewToolDefinitionClient(cfg),
 	}, nil
 }
 
 // Debug returns a new debug-client. It's used to get verbose logging on specific operations.
 //
 //	client.Debug().
-//		Facet.
+//		Block.
 //		Query().
 //		Count(ctx)
 func (c *Client) Debug() *Client {
@@ -184,29 +228,317 @@ func (c *Client) Close() error {
 // Use adds the mutation hooks to all the entity clients.
 // In order to add hooks to a specific client, call: `client.Node.Use(...)`.
 func (c *Client) Use(hooks ...Hook) {
-	c.Facet.Use(hooks...)
-	c.Node.Use(hooks...)
+	for _, n := range []interface{ Use(...Hook) }{
+		c.Block, c.DeadLetter, c.Facet, c.Node, c.RawCapture, c.Session, c.SessionTool,
+		c.SystemPrompt, c.ToolDefinition,
+	} {
+		n.Use(hooks...)
+	}
 }
 
 // Intercept adds the query interceptors to all the entity clients.
 // In order to add interceptors to a specific client, call: `client.Node.Intercept(...)`.
 func (c *Client) Intercept(interceptors ...Interceptor) {
-	c.Facet.Intercept(interceptors...)
-	c.Node.Intercept(interceptors...)
+	for _, n := range []interface{ Intercept(...Interceptor) }{
+		c.Block, c.DeadLetter, c.Facet, c.Node, c.RawCapture, c.Session, c.SessionTool,
+		c.SystemPrompt, c.ToolDefinition,
+	} {
+		n.Intercept(interceptors...)
+	}
 }
 
 // Mutate implements the ent.Mutator interface.
 func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 	switch m := m.(type) {
+	case *BlockMutation:
+		return c.Block.mutate(ctx, m)
+	case *DeadLetterMutation:
+		return c.DeadLetter.mutate(ctx, m)
 	case *FacetMutation:
 		return c.Facet.mutate(ctx, m)
 	case *NodeMutation:
 		return c.Node.mutate(ctx, m)
+	case *RawCaptureMutation:
+		return c.RawCapture.mutate(ctx, m)
+	case *SessionMutation:
+		return c.Session.mutate(ctx, m)
+	case *SessionToolMutation:
+		return c.SessionTool.mutate(ctx, m)
+	case *SystemPromptMutation:
+		return c.SystemPrompt.mutate(ctx, m)
+	case *ToolDefinitionMutation:
+		return c.ToolDefinition.mutate(ctx, m)
 	default:
 		return nil, fmt.Errorf("ent: unknown mutation type %T", m)
 	}
 }
 
+// BlockClient is a client for the Block schema.
+type BlockClient struct {
+	config
+}
+
+// NewBlockClient returns a client for the Block from the given config.
+func NewBlockClient(c config) *BlockClient {
+	return &BlockClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `block.Hooks(f(g(h())))`.
+func (c *BlockClient) Use(hooks ...Hook) {
+	c.hooks.Block = append(c.hooks.Block, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `block.Intercept(f(g(h())))`.
+func (c *BlockClient) Intercept(interceptors ...Interceptor) {
+	c.inters.Block = append(c.inters.Block, interceptors...)
+}
+
+// Create returns a builder for creating a Block entity.
+func (c *BlockClient) Create() *BlockCreate {
+	mutation := newBlockMutation(c.config, OpCreate)
+	return &BlockCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of Block entities.
+func (c *BlockClient) CreateBulk(builders ...*BlockCreate) *BlockCreateBulk {
+	return &BlockCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *BlockClient) MapCreateBulk(slice any, setFunc func(*BlockCreate, int)) *BlockCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &BlockCreateBulk{err: fmt.Errorf("calling to BlockClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*BlockCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &BlockCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for Block.
+func (c *BlockClient) Update() *BlockUpdate {
+	mutation := newBlockMutation(c.config, OpUpdate)
+	return &BlockUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *BlockClient) UpdateOne(_m *Block) *BlockUpdateOne {
+	mutation := newBlockMutation(c.config, OpUpdateOne, withBlock(_m))
+	return &BlockUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *BlockClient) UpdateOneID(id string) *BlockUpdateOne {
+	mutation := newBlockMutation(c.config, OpUpdateOne, withBlockID(id))
+	return &BlockUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for Block.
+func (c *BlockClient) Delete() *BlockDelete {
+	mutation := newBlockMutation(c.config, OpDelete)
+	return &BlockDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *BlockClient) DeleteOne(_m *Block) *BlockDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *BlockClient) DeleteOneID(id string) *BlockDeleteOne {
+	builder := c.Delete().Where(block.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &BlockDeleteOne{builder}
+}
+
+// Query returns a query builder for Block.
+func (c *BlockClient) Query() *BlockQuery {
+	return &BlockQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeBlock},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a Block entity by its id.
+func (c *BlockClient) Get(ctx context.Context, id string) (*Block, error) {
+	return c.Query().Where(block.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *BlockClient) GetX(ctx context.Context, id string) *Block {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *BlockClient) Hooks() []Hook {
+	return c.hooks.Block
+}
+
+// Interceptors returns the client interceptors.
+func (c *BlockClient) Interceptors() []Interceptor {
+	return c.inters.Block
+}
+
+func (c *BlockClient) mutate(ctx context.Context, m *BlockMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&BlockCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&BlockUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&BlockUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&BlockDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown Block mutation op: %q", m.Op())
+	}
+}
+
+// DeadLetterClient is a client for the DeadLetter schema.
+type DeadLetterClient struct {
+	config
+}
+
+// NewDeadLetterClient returns a client for the DeadLetter from the given config.
+func NewDeadLetterClient(c config) *DeadLetterClient {
+	return &DeadLetterClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `deadletter.Hooks(f(g(h())))`.
+func (c *DeadLetterClient) Use(hooks ...Hook) {
+	c.hooks.DeadLetter = append(c.hooks.DeadLetter, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `deadletter.Intercept(f(g(h())))`.
+func (c *DeadLetterClient) Intercept(interceptors ...Interceptor) {
+	c.inters.DeadLetter = append(c.inters.DeadLetter, interceptors...)
+}
+
+// Create returns a builder for creating a DeadLetter entity.
+func (c *DeadLetterClient) Create() *DeadLetterCreate {
+	mutation := newDeadLetterMutation(c.config, OpCreate)
+	return &DeadLetterCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of DeadLetter entities.
+func (c *DeadLetterClient) CreateBulk(builders ...*DeadLetterCreate) *DeadLetterCreateBulk {
+	return &DeadLetterCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *DeadLetterClient) MapCreateBulk(slice any, setFunc func(*DeadLetterCreate, int)) *DeadLetterCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &DeadLetterCreateBulk{err: fmt.Errorf("calling to DeadLetterClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*DeadLetterCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &DeadLetterCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for DeadLetter.
+func (c *DeadLetterClient) Update() *DeadLetterUpdate {
+	mutation := newDeadLetterMutation(c.config, OpUpdate)
+	return &DeadLetterUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *DeadLetterClient) UpdateOne(_m *DeadLetter) *DeadLetterUpdateOne {
+	mutation := newDeadLetterMutation(c.config, OpUpdateOne, withDeadLetter(_m))
+	return &DeadLetterUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *DeadLetterClient) UpdateOneID(id int) *DeadLetterUpdateOne {
+	mutation := newDeadLetterMutation(c.config, OpUpdateOne, withDeadLetterID(id))
+	return &DeadLetterUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for DeadLetter.
+func (c *DeadLetterClient) Delete() *DeadLetterDelete {
+	mutation := newDeadLetterMutation(c.config, OpDelete)
+	return &DeadLetterDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *DeadLetterClient) DeleteOne(_m *DeadLetter) *DeadLetterDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *DeadLetterClient) DeleteOneID(id int) *DeadLetterDeleteOne {
+	builder := c.Delete().Where(deadletter.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &DeadLetterDeleteOne{builder}
+}
+
+// Query returns a query builder for DeadLetter.
+func (c *DeadLetterClient) Query() *DeadLetterQuery {
+	return &DeadLetterQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeDeadLetter},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a DeadLetter entity by its id.
+func (c *DeadLetterClient) Get(ctx context.Context, id int) (*DeadLetter, error) {
+	return c.Query().Where(deadletter.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *DeadLetterClient) GetX(ctx context.Context, id int) *DeadLetter {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *DeadLetterClient) Hooks() []Hook {
+	return c.hooks.DeadLetter
+}
+
+// Interceptors returns the client interceptors.
+func (c *DeadLetterClient) Interceptors() []Interceptor {
+	return c.inters.DeadLetter
+}
+
+func (c *DeadLetterClient) mutate(ctx context.Context, m *DeadLetterMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&DeadLetterCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&DeadLetterUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&DeadLetterUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&DeadLetterDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown DeadLetter mutation op: %q", m.Op())
+	}
+}
+
 // FacetClient is a client for the Facet schema.
 type FacetClient struct {
 	config
@@ -505,12 +837,703 @@ func (c *NodeClient) mutate(ctx context.Context, m *NodeMutation) (Value, error)
 	}
 }
 
-// hooks and interceptors per client, for fast access.
-type (
-	hooks struct {
-		Facet, Node []ent.Hook
+// RawCaptureClient is a client for the RawCapture schema.
+type RawCaptureClient struct {
+	config
+}
+
+// NewRawCaptureClient returns a client for the RawCapture from the given config.
+func NewRawCaptureClient(c config) *RawCaptureClient {
+	return &RawCaptureClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `rawcapture.Hooks(f(g(h())))`.
+func (c *RawCaptureClient) Use(hooks ...Hook) {
+	c.hooks.RawCapture = append(c.hooks.RawCapture, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `rawcapture.Intercept(f(g(h())))`.
+func (c *RawCaptureClient) Intercept(interceptors ...Interceptor) {
+	c.inters.RawCapture = append(c.inters.RawCapture, interceptors...)
+}
+
+// Create returns a builder for creating a RawCapture entity.
+func (c *RawCaptureClient) Create() *RawCaptureCreate {
+	mutation := newRawCaptureMutation(c.config, OpCreate)
+	return &RawCaptureCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of RawCapture entities.
+func (c *RawCaptureClient) CreateBulk(builders ...*RawCaptureCreate) *RawCaptureCreateBulk {
+	return &RawCaptureCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *RawCaptureClient) MapCreateBulk(slice any, setFunc func(*RawCaptureCreate, int)) *RawCaptureCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &RawCaptureCreateBulk{err: fmt.Errorf("calling to RawCaptureClient.MapCreateBulk with wrong type %T, need slice", slice)}
 	}
-	inters struct {
-		Facet, Node []ent.Interceptor
+	builders := make([]*RawCaptureCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
 	}
-)
+	return &RawCaptureCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for RawCapture.
+func (c *RawCaptureClient) Update() *RawCaptureUpdate {
+	mutation := newRawCaptureMutation(c.config, OpUpdate)
+	return &RawCaptureUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *RawCaptureClient) UpdateOne(_m *RawCapture) *RawCaptureUpdateOne {
+	mutation := newRawCaptureMutation(c.config, OpUpdateOne, withRawCapture(_m))
+	return &RawCaptureUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *RawCaptureClient) UpdateOneID(id int) *RawCaptureUpdateOne {
+	mutation := newRawCaptureMutation(c.config, OpUpdateOne, withRawCaptureID(id))
+	return &RawCaptureUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for RawCapture.
+func (c *RawCaptureClient) Delete() *RawCaptureDelete {
+	mutation := newRawCaptureMutation(c.config, OpDelete)
+	return &RawCaptureDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *RawCaptureClient) DeleteOne(_m *RawCapture) *RawCaptureDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *RawCaptureClient) DeleteOneID(id int) *RawCaptureDeleteOne {
+	builder := c.Delete().Where(rawcapture.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &RawCaptureDeleteOne{builder}
+}
+
+// Query returns a query builder for RawCapture.
+func (c *RawCaptureClient) Query() *RawCaptureQuery {
+	return &RawCaptureQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeRawCapture},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a RawCapture entity by its id.
+func (c *RawCaptureClient) Get(ctx context.Context, id int) (*RawCapture, error) {
+	return c.Query().Where(rawcapture.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *RawCaptureClient) GetX(ctx context.Context, id int) *RawCapture {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *RawCaptureClient) Hooks() []Hook {
+	return c.hooks.RawCapture
+}
+
+// Interceptors returns the client interceptors.
+func (c *RawCaptureClient) Interceptors() []Interceptor {
+	return c.inters.RawCapture
+}
+
+func (c *RawCaptureClient) mutate(ctx context.Context, m *RawCaptureMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&RawCaptureCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&RawCaptureUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&RawCaptureUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&RawCaptureDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown RawCapture mutation op: %q", m.Op())
+	}
+}
+
+// SessionClient is a client for the Session schema.
+type SessionClient struct {
+	config
+}
+
+// NewSessionClient returns a client for the Session from the given config.
+func NewSessionClient(c config) *SessionClient {
+	return &SessionClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `session.Hooks(f(g(h())))`.
+func (c *SessionClient) Use(hooks ...Hook) {
+	c.hooks.Session = append(c.hooks.Session, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `session.Intercept(f(g(h())))`.
+func (c *SessionClient) Intercept(interceptors ...Interceptor) {
+	c.inters.Session = append(c.inters.Session, interceptors...)
+}
+
+// Create returns a builder for creating a Session entity.
+func (c *SessionClient) Create() *SessionCreate {
+	mutation := newSessionMutation(c.config, OpCreate)
+	return &SessionCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of Session entities.
+func (c *SessionClient) CreateBulk(builders ...*SessionCreate) *SessionCreateBulk {
+	return &SessionCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *SessionClient) MapCreateBulk(slice any, setFunc func(*SessionCreate, int)) *SessionCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &SessionCreateBulk{err: fmt.Errorf("calling to SessionClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*SessionCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &SessionCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for Session.
+func (c *SessionClient) Update() *SessionUpdate {
+	mutation := newSessionMutation(c.config, OpUpdate)
+	return &SessionUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *SessionClient) UpdateOne(_m *Session) *SessionUpdateOne {
+	mutation := newSessionMutation(c.config, OpUpdateOne, withSession(_m))
+	return &SessionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *SessionClient) UpdateOneID(id string) *SessionUpdateOne {
+	mutation := newSessionMutation(c.config, OpUpdateOne, withSessionID(id))
+	return &SessionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for Session.
+func (c *SessionClient) Delete() *SessionDelete {
+	mutation := newSessionMutation(c.config, OpDelete)
+	return &SessionDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *SessionClient) DeleteOne(_m *Session) *SessionDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *SessionClient) DeleteOneID(id string) *SessionDeleteOne {
+	builder := c.Delete().Where(session.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &SessionDeleteOne{builder}
+}
+
+// Query returns a query builder for Session.
+func (c *SessionClient) Query() *SessionQuery {
+	return &SessionQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeSession},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a Session entity by its id.
+func (c *SessionClient) Get(ctx context.Context, id string) (*Session, error) {
+	return c.Query().Where(session.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *SessionClient) GetX(ctx context.Context, id string) *Session {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *SessionClient) Hooks() []Hook {
+	return c.hooks.Session
+}
+
+// Interceptors returns the client interceptors.
+func (c *SessionClient) Interceptors() []Interceptor {
+	return c.inters.Session
+}
+
+func (c *SessionClient) mutate(ctx context.Context, m *SessionMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&SessionCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&SessionUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&SessionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&SessionDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown Session mutation op: %q", m.Op())
+	}
+}
+
+// SessionToolClient is a client for the SessionTool schema.
+type SessionToolClient struct {
+	config
+}
+
+// NewSessionToolClient returns a client for the SessionTool from the given config.
+func NewSessionToolClient(c config) *SessionToolClient {
+	return &SessionToolClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `sessiontool.Hooks(f(g(h())))`.
+func (c *SessionToolClient) Use(hooks ...Hook) {
+	c.hooks.SessionTool = append(c.hooks.SessionTool, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `sessiontool.Intercept(f(g(h())))`.
+func (c *SessionToolClient) Intercept(interceptors ...Interceptor) {
+	c.inters.SessionTool = append(c.inters.SessionTool, interceptors...)
+}
+
+// Create returns a builder for creating a SessionTool entity.
+func (c *SessionToolClient) Create() *SessionToolCreate {
+	mutation := newSessionToolMutation(c.config, OpCreate)
+	return &SessionToolCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of SessionTool entities.
+func (c *SessionToolClient) CreateBulk(builders ...*SessionToolCreate) *SessionToolCreateBulk {
+	return &SessionToolCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *SessionToolClient) MapCreateBulk(slice any, setFunc func(*SessionToolCreate, int)) *SessionToolCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &SessionToolCreateBulk{err: fmt.Errorf("calling to SessionToolClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*SessionToolCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &SessionToolCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for SessionTool.
+func (c *SessionToolClient) Update() *SessionToolUpdate {
+	mutation := newSessionToolMutation(c.config, OpUpdate)
+	return &SessionToolUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *SessionToolClient) UpdateOne(_m *SessionTool) *SessionToolUpdateOne {
+	mutation := newSessionToolMutation(c.config, OpUpdateOne, withSessionTool(_m))
+	return &SessionToolUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *SessionToolClient) UpdateOneID(id int) *SessionToolUpdateOne {
+	mutation := newSessionToolMutation(c.config, OpUpdateOne, withSessionToolID(id))
+	return &SessionToolUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for SessionTool.
+func (c *SessionToolClient) Delete() *SessionToolDelete {
+	mutation := newSessionToolMutation(c.config, OpDelete)
+	return &SessionToolDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *SessionToolClient) DeleteOne(_m *SessionTool) *SessionToolDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *SessionToolClient) DeleteOneID(id int) *SessionToolDeleteOne {
+	builder := c.Delete().Where(sessiontool.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &SessionToolDeleteOne{builder}
+}
+
+// Query returns a query builder for SessionTool.
+func (c *SessionToolClient) Query() *SessionToolQuery {
+	return &SessionToolQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeSessionTool},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a SessionTool entity by its id.
+func (c *SessionToolClient) Get(ctx context.Context, id int) (*SessionTool, error) {
+	return c.Query().Where(sessiontool.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *SessionToolClient) GetX(ctx context.Context, id int) *SessionTool {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *SessionToolClient) Hooks() []Hook {
+	return c.hooks.SessionTool
+}
+
+// Interceptors returns the client interceptors.
+func (c *SessionToolClient) Interceptors() []Interceptor {
+	return c.inters.SessionTool
+}
+
+func (c *SessionToolClient) mutate(ctx context.Context, m *SessionToolMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&SessionToolCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&SessionToolUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&SessionToolUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&SessionToolDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown SessionTool mutation op: %q", m.Op())
+	}
+}
+
+// SystemPromptClient is a client for the SystemPrompt schema.
+type SystemPromptClient struct {
+	config
+}
+
+// NewSystemPromptClient returns a client for the SystemPrompt from the given config.
+func NewSystemPromptClient(c config) *SystemPromptClient {
+	return &SystemPromptClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `systemprompt.Hooks(f(g(h())))`.
+func (c *SystemPromptClient) Use(hooks ...Hook) {
+	c.hooks.SystemPrompt = append(c.hooks.SystemPrompt, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `systemprompt.Intercept(f(g(h())))`.
+func (c *SystemPromptClient) Intercept(interceptors ...Interceptor) {
+	c.inters.SystemPrompt = append(c.inters.SystemPrompt, interceptors...)
+}
+
+// Create returns a builder for creating a SystemPrompt entity.
+func (c *SystemPromptClient) Create() *SystemPromptCreate {
+	mutation := newSystemPromptMutation(c.config, OpCreate)
+	return &SystemPromptCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of SystemPrompt entities.
+func (c *SystemPromptClient) CreateBulk(builders ...*SystemPromptCreate) *SystemPromptCreateBulk {
+	return &SystemPromptCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *SystemPromptClient) MapCreateBulk(slice any, setFunc func(*SystemPromptCreate, int)) *SystemPromptCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &SystemPromptCreateBulk{err: fmt.Errorf("calling to SystemPromptClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*SystemPromptCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &SystemPromptCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for SystemPrompt.
+func (c *SystemPromptClient) Update() *SystemPromptUpdate {
+	mutation := newSystemPromptMutation(c.config, OpUpdate)
+	return &SystemPromptUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *SystemPromptClient) UpdateOne(_m *SystemPrompt) *SystemPromptUpdateOne {
+	mutation := newSystemPromptMutation(c.config, OpUpdateOne, withSystemPrompt(_m))
+	return &SystemPromptUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *SystemPromptClient) UpdateOneID(id string) *SystemPromptUpdateOne {
+	mutation := newSystemPromptMutation(c.config, OpUpdateOne, withSystemPromptID(id))
+	return &SystemPromptUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for SystemPrompt.
+func (c *SystemPromptClient) Delete() *SystemPromptDelete {
+	mutation := newSystemPromptMutation(c.config, OpDelete)
+	return &SystemPromptDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *SystemPromptClient) DeleteOne(_m *SystemPrompt) *SystemPromptDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *SystemPromptClient) DeleteOneID(id string) *SystemPromptDeleteOne {
+	builder := c.Delete().Where(systemprompt.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &SystemPromptDeleteOne{builder}
+}
+
+// Query returns a query builder for SystemPrompt.
+func (c *SystemPromptClient) Query() *SystemPromptQuery {
+	return &SystemPromptQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeSystemPrompt},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a SystemPrompt entity by its id.
+func (c *SystemPromptClient) Get(ctx context.Context, id string) (*SystemPrompt, error) {
+	return c.Query().Where(systemprompt.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *SystemPromptClient) GetX(ctx context.Context, id string) *SystemPrompt {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *SystemPromptClient) Hooks() []Hook {
+	return c.hooks.SystemPrompt
+}
+
+// Interceptors returns the client interceptors.
+func (c *SystemPromptClient) Interceptors() []Interceptor {
+	return c.inters.SystemPrompt
+}
+
+func (c *SystemPromptClient) mutate(ctx context.Context, m *SystemPromptMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&SystemPromptCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&SystemPromptUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&SystemPromptUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&SystemPromptDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown SystemPrompt mutation op: %q", m.Op())
+	}
+}
+
+// ToolDefinitionClient is a client for the ToolDefinition schema.
+type ToolDefinitionClient struct {
+	config
+}
+
+// NewToolDefinitionClient returns a client for the ToolDefinition from the given config.
+func NewToolDefinitionClient(c config) *ToolDefinitionClient {
+	return &ToolDefinitionClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `tooldefinition.Hooks(f(g(h())))`.
+func (c *ToolDefinitionClient) Use(hooks ...Hook) {
+	c.hooks.ToolDefinition = append(c.hooks.ToolDefinition, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `tooldefinition.Intercept(f(g(h())))`.
+func (c *ToolDefinitionClient) Intercept(interceptors ...Interceptor) {
+	c.inters.ToolDefinition = append(c.inters.ToolDefinition, interceptors...)
+}
+
+// Create returns a builder for creating a ToolDefinition entity.
+func (c *ToolDefinitionClient) Create() *ToolDefinitionCreate {
+	mutation := newToolDefinitionMutation(c.config, OpCreate)
+	return &ToolDefinitionCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of ToolDefinition entities.
+func (c *ToolDefinitionClient) CreateBulk(builders ...*ToolDefinitionCreate) *ToolDefinitionCreateBulk {
+	return &ToolDefinitionCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *ToolDefinitionClient) MapCreateBulk(slice any, setFunc func(*ToolDefinitionCreate, int)) *ToolDefinitionCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &ToolDefinitionCreateBulk{err: fmt.Errorf("calling to ToolDefinitionClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*ToolDefinitionCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &ToolDefinitionCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for ToolDefinition.
+func (c *ToolDefinitionClient) Update() *ToolDefinitionUpdate {
+	mutation := newToolDefinitionMutation(c.config, OpUpdate)
+	return &ToolDefinitionUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *ToolDefinitionClient) UpdateOne(_m *ToolDefinition) *ToolDefinitionUpdateOne {
+	mutation := newToolDefinitionMutation(c.config, OpUpdateOne, withToolDefinition(_m))
+	return &ToolDefinitionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *ToolDefinitionClient) UpdateOneID(id string) *ToolDefinitionUpdateOne {
+	mutation := newToolDefinitionMutation(c.config, OpUpdateOne, withToolDefinitionID(id))
+	return &ToolDefinitionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for ToolDefinition.
+func (c *ToolDefinitionClient) Delete() *ToolDefinitionDelete {
+	mutation := newToolDefinitionMutation(c.config, OpDelete)
+	return &ToolDefinitionDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *ToolDefinitionClient) DeleteOne(_m *ToolDefinition) *ToolDefinitionDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *ToolDefinitionClient) DeleteOneID(id string) *ToolDefinitionDeleteOne {
+	builder := c.Delete().Where(tooldefinition.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &ToolDefinitionDeleteOne{builder}
+}
+
+// Query returns a query builder for ToolDefinition.
+func (c *ToolDefinitionClient) Query() *ToolDefinitionQuery {
+	return &ToolDefinitionQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeToolDefinition},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a ToolDefinition entity by its id.
+func (c *ToolDefinitionClient) Get(ctx context.Context, id string) (*ToolDefinition, error) {
+	return c.Query().Where(tooldefinition.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *ToolDefinitionClient) GetX(ctx context.Context, id string) *ToolDefinition {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *ToolDefinitionClient) Hooks() []Hook {
+	return c.hooks.ToolDefinition
+}
+
+// Interceptors returns the client interceptors.
+func (c *ToolDefinitionClient) Interceptors() []Interceptor {
+	return c.inters.ToolDefinition
+}
+
+func (c *ToolDefinitionClient) mutate(ctx context.Context, m *ToolDefinitionMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&ToolDefinitionCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&ToolDefinitionUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&ToolDefinitionUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&ToolDefinitionDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown ToolDefinition mutation op: %q", m.Op())
+	}
+}
+
+// hooks and interceptors per client, for fast access.
+type (
+	hooks struct {
+		Block, DeadLetter, Facet, Node, RawCapture, Session, SessionTool, SystemPrompt,
+		ToolDefinition []ent.Hook
+	}
+	inters struct {
+		Block, DeadLetter, Facet, Node, RawCapture, Session, SessionTool, SystemPrompt,
+		ToolDefinition []ent.Interceptor
+	}
+)
+
+// ExecContext allows calling the underlying ExecContext method of the driver if it is supported by it.
+// See, database/sql#DB.ExecContext for more information.
+func (c *config) ExecContext(ctx context.Context, query string, args ...any) (stdsql.Result, error) {
+	ex, ok := c.driver.(interface {
+		ExecContext(context.Context, string, ...any) (stdsql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.ExecContext is not supported")
+	}
+	return ex.ExecContext(ctx, query, args...)
+}
+
+// QueryContext allows calling the underlying QueryContext method of the driver if it is supported by it.
+// See, database/sql#DB.QueryContext for more information.
+func (c *config) QueryContext(ctx context.Context, query string, args ...any) (*stdsql.Rows, error) {
+	q, ok := c.driver.(interface {
+		QueryContext(context.Context, string, ...any) (*stdsql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.QueryContext is not supported")
+	}
+	return q.QueryContext(ctx, query, args...)
+}
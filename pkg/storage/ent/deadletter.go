@@ -0,0 +1,212 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/deadletter"
+)
+
+// DeadLetter is the model entity for the DeadLetter schema.
+type DeadLetter struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Stage holds the value of the "stage" field.
+	Stage string `json:"stage,omitempty"`
+	// Provider holds the value of the "provider" field.
+	Provider string `json:"provider,omitempty"`
+	// AgentName holds the value of the "agent_name" field.
+	AgentName string `json:"agent_name,omitempty"`
+	// Path holds the value of the "path" field.
+	Path string `json:"path,omitempty"`
+	// Headers holds the value of the "headers" field.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Request holds the value of the "request" field.
+	Request string `json:"request,omitempty"`
+	// Response holds the value of the "response" field.
+	Response string `json:"response,omitempty"`
+	// Encrypted holds the value of the "encrypted" field.
+	Encrypted bool `json:"encrypted,omitempty"`
+	// Error holds the value of the "error" field.
+	Error string `json:"error,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*DeadLetter) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case deadletter.FieldHeaders:
+			values[i] = new([]byte)
+		case deadletter.FieldEncrypted:
+			values[i] = new(sql.NullBool)
+		case deadletter.FieldID:
+			values[i] = new(sql.NullInt64)
+		case deadletter.FieldStage, deadletter.FieldProvider, deadletter.FieldAgentName, deadletter.FieldPath, deadletter.FieldRequest, deadletter.FieldResponse, deadletter.FieldError:
+			values[i] = new(sql.NullString)
+		case deadletter.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the DeadLetter fields.
+func (_m *DeadLetter) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case deadletter.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case deadletter.FieldStage:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field stage", values[i])
+			} else if value.Valid {
+				_m.Stage = value.String
+			}
+		case deadletter.FieldProvider:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field provider", values[i])
+			} else if value.Valid {
+				_m.Provider = value.String
+			}
+		case deadletter.FieldAgentName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field agent_name", values[i])
+			} else if value.Valid {
+				_m.AgentName = value.String
+			}
+		case deadletter.FieldPath:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field path", values[i])
+			} else if value.Valid {
+				_m.Path = value.String
+			}
+		case deadletter.FieldHeaders:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field headers", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Headers); err != nil {
+					return fmt.Errorf("unmarshal field headers: %w", err)
+				}
+			}
+		case deadletter.FieldRequest:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field request", values[i])
+			} else if value.Valid {
+				_m.Request = value.String
+			}
+		case deadletter.FieldResponse:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field response", values[i])
+			} else if value.Valid {
+				_m.Response = value.String
+			}
+		case deadletter.FieldEncrypted:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field encrypted", values[i])
+			} else if value.Valid {
+				_m.Encrypted = value.Bool
+			}
+		case deadletter.FieldError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error", values[i])
+			} else if value.Valid {
+				_m.Error = value.String
+			}
+		case deadletter.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the DeadLetter.
+// This includes values selected through modifiers, order, etc.
+func (_m *DeadLetter) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this DeadLetter.
+// Note that you need to call DeadLetter.Unwrap() before calling this method if this DeadLetter
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *DeadLetter) Update() *DeadLetterUpdateOne {
+	return NewDeadLetterClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the DeadLetter entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *DeadLetter) Unwrap() *DeadLetter {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: DeadLetter is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *DeadLetter) String() string {
+	var builder strings.Builder
+	builder.WriteString("DeadLetter(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("stage=")
+	builder.WriteString(_m.Stage)
+	builder.WriteString(", ")
+	builder.WriteString("provider=")
+	builder.WriteString(_m.Provider)
+	builder.WriteString(", ")
+	builder.WriteString("agent_name=")
+	builder.WriteString(_m.AgentName)
+	builder.WriteString(", ")
+	builder.WriteString("path=")
+	builder.WriteString(_m.Path)
+	builder.WriteString(", ")
+	builder.WriteString("headers=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Headers))
+	builder.WriteString(", ")
+	builder.WriteString("request=")
+	builder.WriteString(_m.Request)
+	builder.WriteString(", ")
+	builder.WriteString("response=")
+	builder.WriteString(_m.Response)
+	builder.WriteString(", ")
+	builder.WriteString("encrypted=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Encrypted))
+	builder.WriteString(", ")
+	builder.WriteString("error=")
+	builder.WriteString(_m.Error)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// DeadLetters is a parsable slice of DeadLetter.
+type DeadLetters []*DeadLetter
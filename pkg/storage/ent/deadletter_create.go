@@ -0,0 +1,363 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/deadletter"
+)
+
+// DeadLetterCreate is the builder for creating a DeadLetter entity.
+type DeadLetterCreate struct {
+	config
+	mutation *DeadLetterMutation
+	hooks    []Hook
+}
+
+// SetStage sets the "stage" field.
+func (_c *DeadLetterCreate) SetStage(v string) *DeadLetterCreate {
+	_c.mutation.SetStage(v)
+	return _c
+}
+
+// SetProvider sets the "provider" field.
+func (_c *DeadLetterCreate) SetProvider(v string) *DeadLetterCreate {
+	_c.mutation.SetProvider(v)
+	return _c
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (_c *DeadLetterCreate) SetNillableProvider(v *string) *DeadLetterCreate {
+	if v != nil {
+		_c.SetProvider(*v)
+	}
+	return _c
+}
+
+// SetAgentName sets the "agent_name" field.
+func (_c *DeadLetterCreate) SetAgentName(v string) *DeadLetterCreate {
+	_c.mutation.SetAgentName(v)
+	return _c
+}
+
+// SetNillableAgentName sets the "agent_name" field if the given value is not nil.
+func (_c *DeadLetterCreate) SetNillableAgentName(v *string) *DeadLetterCreate {
+	if v != nil {
+		_c.SetAgentName(*v)
+	}
+	return _c
+}
+
+// SetPath sets the "path" field.
+func (_c *DeadLetterCreate) SetPath(v string) *DeadLetterCreate {
+	_c.mutation.SetPath(v)
+	return _c
+}
+
+// SetNillablePath sets the "path" field if the given value is not nil.
+func (_c *DeadLetterCreate) SetNillablePath(v *string) *DeadLetterCreate {
+	if v != nil {
+		_c.SetPath(*v)
+	}
+	return _c
+}
+
+// SetHeaders sets the "headers" field.
+func (_c *DeadLetterCreate) SetHeaders(v map[string]string) *DeadLetterCreate {
+	_c.mutation.SetHeaders(v)
+	return _c
+}
+
+// SetRequest sets the "request" field.
+func (_c *DeadLetterCreate) SetRequest(v string) *DeadLetterCreate {
+	_c.mutation.SetRequest(v)
+	return _c
+}
+
+// SetNillableRequest sets the "request" field if the given value is not nil.
+func (_c *DeadLetterCreate) SetNillableRequest(v *string) *DeadLetterCreate {
+	if v != nil {
+		_c.SetRequest(*v)
+	}
+	return _c
+}
+
+// SetResponse sets the "response" field.
+func (_c *DeadLetterCreate) SetResponse(v string) *DeadLetterCreate {
+	_c.mutation.SetResponse(v)
+	return _c
+}
+
+// SetNillableResponse sets the "response" field if the given value is not nil.
+func (_c *DeadLetterCreate) SetNillableResponse(v *string) *DeadLetterCreate {
+	if v != nil {
+		_c.SetResponse(*v)
+	}
+	return _c
+}
+
+// SetEncrypted sets the "encrypted" field.
+func (_c *DeadLetterCreate) SetEncrypted(v bool) *DeadLetterCreate {
+	_c.mutation.SetEncrypted(v)
+	return _c
+}
+
+// SetNillableEncrypted sets the "encrypted" field if the given value is not nil.
+func (_c *DeadLetterCreate) SetNillableEncrypted(v *bool) *DeadLetterCreate {
+	if v != nil {
+		_c.SetEncrypted(*v)
+	}
+	return _c
+}
+
+// SetError sets the "error" field.
+func (_c *DeadLetterCreate) SetError(v string) *DeadLetterCreate {
+	_c.mutation.SetError(v)
+	return _c
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (_c *DeadLetterCreate) SetNillableError(v *string) *DeadLetterCreate {
+	if v != nil {
+		_c.SetError(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *DeadLetterCreate) SetCreatedAt(v time.Time) *DeadLetterCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *DeadLetterCreate) SetNillableCreatedAt(v *time.Time) *DeadLetterCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// Mutation returns the DeadLetterMutation object of the builder.
+func (_c *DeadLetterCreate) Mutation() *DeadLetterMutation {
+	return _c.mutation
+}
+
+// Save creates the DeadLetter in the database.
+func (_c *DeadLetterCreate) Save(ctx context.Context) (*DeadLetter, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *DeadLetterCreate) SaveX(ctx context.Context) *DeadLetter {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *DeadLetterCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *DeadLetterCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *DeadLetterCreate) defaults() {
+	if _, ok := _c.mutation.Encrypted(); !ok {
+		v := deadletter.DefaultEncrypted
+		_c.mutation.SetEncrypted(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := deadletter.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *DeadLetterCreate) check() error {
+	if _, ok := _c.mutation.Stage(); !ok {
+		return &ValidationError{Name: "stage", err: errors.New(`ent: missing required field "DeadLetter.stage"`)}
+	}
+	if v, ok := _c.mutation.Stage(); ok {
+		if err := deadletter.StageValidator(v); err != nil {
+			return &ValidationError{Name: "stage", err: fmt.Errorf(`ent: validator failed for field "DeadLetter.stage": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Encrypted(); !ok {
+		return &ValidationError{Name: "encrypted", err: errors.New(`ent: missing required field "DeadLetter.encrypted"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "DeadLetter.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *DeadLetterCreate) sqlSave(ctx context.Context) (*DeadLetter, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *DeadLetterCreate) createSpec() (*DeadLetter, *sqlgraph.CreateSpec) {
+	var (
+		_node = &DeadLetter{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(deadletter.Table, sqlgraph.NewFieldSpec(deadletter.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Stage(); ok {
+		_spec.SetField(deadletter.FieldStage, field.TypeString, value)
+		_node.Stage = value
+	}
+	if value, ok := _c.mutation.Provider(); ok {
+		_spec.SetField(deadletter.FieldProvider, field.TypeString, value)
+		_node.Provider = value
+	}
+	if value, ok := _c.mutation.AgentName(); ok {
+		_spec.SetField(deadletter.FieldAgentName, field.TypeString, value)
+		_node.AgentName = value
+	}
+	if value, ok := _c.mutation.Path(); ok {
+		_spec.SetField(deadletter.FieldPath, field.TypeString, value)
+		_node.Path = value
+	}
+	if value, ok := _c.mutation.Headers(); ok {
+		_spec.SetField(deadletter.FieldHeaders, field.TypeJSON, value)
+		_node.Headers = value
+	}
+	if value, ok := _c.mutation.Request(); ok {
+		_spec.SetField(deadletter.FieldRequest, field.TypeString, value)
+		_node.Request = value
+	}
+	if value, ok := _c.mutation.Response(); ok {
+		_spec.SetField(deadletter.FieldResponse, field.TypeString, value)
+		_node.Response = value
+	}
+	if value, ok := _c.mutation.Encrypted(); ok {
+		_spec.SetField(deadletter.FieldEncrypted, field.TypeBool, value)
+		_node.Encrypted = value
+	}
+	if value, ok := _c.mutation.Error(); ok {
+		_spec.SetField(deadletter.FieldError, field.TypeString, value)
+		_node.Error = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(deadletter.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// DeadLetterCreateBulk is the builder for creating many DeadLetter entities in bulk.
+type DeadLetterCreateBulk struct {
+	config
+	err      error
+	builders []*DeadLetterCreate
+}
+
+// Save creates the DeadLetter entities in the database.
+func (_c *DeadLetterCreateBulk) Save(ctx context.Context) ([]*DeadLetter, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*DeadLetter, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*DeadLetterMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *DeadLetterCreateBulk) SaveX(ctx context.Context) []*DeadLetter {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *DeadLetterCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *DeadLetterCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,617 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/deadletter"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+)
+
+// DeadLetterUpdate is the builder for updating DeadLetter entities.
+type DeadLetterUpdate struct {
+	config
+	hooks    []Hook
+	mutation *DeadLetterMutation
+}
+
+// Where appends a list predicates to the DeadLetterUpdate builder.
+func (_u *DeadLetterUpdate) Where(ps ...predicate.DeadLetter) *DeadLetterUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetStage sets the "stage" field.
+func (_u *DeadLetterUpdate) SetStage(v string) *DeadLetterUpdate {
+	_u.mutation.SetStage(v)
+	return _u
+}
+
+// SetNillableStage sets the "stage" field if the given value is not nil.
+func (_u *DeadLetterUpdate) SetNillableStage(v *string) *DeadLetterUpdate {
+	if v != nil {
+		_u.SetStage(*v)
+	}
+	return _u
+}
+
+// SetProvider sets the "provider" field.
+func (_u *DeadLetterUpdate) SetProvider(v string) *DeadLetterUpdate {
+	_u.mutation.SetProvider(v)
+	return _u
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (_u *DeadLetterUpdate) SetNillableProvider(v *string) *DeadLetterUpdate {
+	if v != nil {
+		_u.SetProvider(*v)
+	}
+	return _u
+}
+
+// ClearProvider clears the value of the "provider" field.
+func (_u *DeadLetterUpdate) ClearProvider() *DeadLetterUpdate {
+	_u.mutation.ClearProvider()
+	return _u
+}
+
+// SetAgentName sets the "agent_name" field.
+func (_u *DeadLetterUpdate) SetAgentName(v string) *DeadLetterUpdate {
+	_u.mutation.SetAgentName(v)
+	return _u
+}
+
+// SetNillableAgentName sets the "agent_name" field if the given value is not nil.
+func (_u *DeadLetterUpdate) SetNillableAgentName(v *string) *DeadLetterUpdate {
+	if v != nil {
+		_u.SetAgentName(*v)
+	}
+	return _u
+}
+
+// ClearAgentName clears the value of the "agent_name" field.
+func (_u *DeadLetterUpdate) ClearAgentName() *DeadLetterUpdate {
+	_u.mutation.ClearAgentName()
+	return _u
+}
+
+// SetPath sets the "path" field.
+func (_u *DeadLetterUpdate) SetPath(v string) *DeadLetterUpdate {
+	_u.mutation.SetPath(v)
+	return _u
+}
+
+// SetNillablePath sets the "path" field if the given value is not nil.
+func (_u *DeadLetterUpdate) SetNillablePath(v *string) *DeadLetterUpdate {
+	if v != nil {
+		_u.SetPath(*v)
+	}
+	return _u
+}
+
+// ClearPath clears the value of the "path" field.
+func (_u *DeadLetterUpdate) ClearPath() *DeadLetterUpdate {
+	_u.mutation.ClearPath()
+	return _u
+}
+
+// SetHeaders sets the "headers" field.
+func (_u *DeadLetterUpdate) SetHeaders(v map[string]string) *DeadLetterUpdate {
+	_u.mutation.SetHeaders(v)
+	return _u
+}
+
+// ClearHeaders clears the value of the "headers" field.
+func (_u *DeadLetterUpdate) ClearHeaders() *DeadLetterUpdate {
+	_u.mutation.ClearHeaders()
+	return _u
+}
+
+// SetRequest sets the "request" field.
+func (_u *DeadLetterUpdate) SetRequest(v string) *DeadLetterUpdate {
+	_u.mutation.SetRequest(v)
+	return _u
+}
+
+// SetNillableRequest sets the "request" field if the given value is not nil.
+func (_u *DeadLetterUpdate) SetNillableRequest(v *string) *DeadLetterUpdate {
+	if v != nil {
+		_u.SetRequest(*v)
+	}
+	return _u
+}
+
+// ClearRequest clears the value of the "request" field.
+func (_u *DeadLetterUpdate) ClearRequest() *DeadLetterUpdate {
+	_u.mutation.ClearRequest()
+	return _u
+}
+
+// SetResponse sets the "response" field.
+func (_u *DeadLetterUpdate) SetResponse(v string) *DeadLetterUpdate {
+	_u.mutation.SetResponse(v)
+	return _u
+}
+
+// SetNillableResponse sets the "response" field if the given value is not nil.
+func (_u *DeadLetterUpdate) SetNillableResponse(v *string) *DeadLetterUpdate {
+	if v != nil {
+		_u.SetResponse(*v)
+	}
+	return _u
+}
+
+// ClearResponse clears the value of the "response" field.
+func (_u *DeadLetterUpdate) ClearResponse() *DeadLetterUpdate {
+	_u.mutation.ClearResponse()
+	return _u
+}
+
+// SetEncrypted sets the "encrypted" field.
+func (_u *DeadLetterUpdate) SetEncrypted(v bool) *DeadLetterUpdate {
+	_u.mutation.SetEncrypted(v)
+	return _u
+}
+
+// SetNillableEncrypted sets the "encrypted" field if the given value is not nil.
+func (_u *DeadLetterUpdate) SetNillableEncrypted(v *bool) *DeadLetterUpdate {
+	if v != nil {
+		_u.SetEncrypted(*v)
+	}
+	return _u
+}
+
+// SetError sets the "error" field.
+func (_u *DeadLetterUpdate) SetError(v string) *DeadLetterUpdate {
+	_u.mutation.SetError(v)
+	return _u
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (_u *DeadLetterUpdate) SetNillableError(v *string) *DeadLetterUpdate {
+	if v != nil {
+		_u.SetError(*v)
+	}
+	return _u
+}
+
+// ClearError clears the value of the "error" field.
+func (_u *DeadLetterUpdate) ClearError() *DeadLetterUpdate {
+	_u.mutation.ClearError()
+	return _u
+}
+
+// Mutation returns the DeadLetterMutation object of the builder.
+func (_u *DeadLetterUpdate) Mutation() *DeadLetterMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *DeadLetterUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DeadLetterUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *DeadLetterUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DeadLetterUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *DeadLetterUpdate) check() error {
+	if v, ok := _u.mutation.Stage(); ok {
+		if err := deadletter.StageValidator(v); err != nil {
+			return &ValidationError{Name: "stage", err: fmt.Errorf(`ent: validator failed for field "DeadLetter.stage": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *DeadLetterUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(deadletter.Table, deadletter.Columns, sqlgraph.NewFieldSpec(deadletter.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Stage(); ok {
+		_spec.SetField(deadletter.FieldStage, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Provider(); ok {
+		_spec.SetField(deadletter.FieldProvider, field.TypeString, value)
+	}
+	if _u.mutation.ProviderCleared() {
+		_spec.ClearField(deadletter.FieldProvider, field.TypeString)
+	}
+	if value, ok := _u.mutation.AgentName(); ok {
+		_spec.SetField(deadletter.FieldAgentName, field.TypeString, value)
+	}
+	if _u.mutation.AgentNameCleared() {
+		_spec.ClearField(deadletter.FieldAgentName, field.TypeString)
+	}
+	if value, ok := _u.mutation.Path(); ok {
+		_spec.SetField(deadletter.FieldPath, field.TypeString, value)
+	}
+	if _u.mutation.PathCleared() {
+		_spec.ClearField(deadletter.FieldPath, field.TypeString)
+	}
+	if value, ok := _u.mutation.Headers(); ok {
+		_spec.SetField(deadletter.FieldHeaders, field.TypeJSON, value)
+	}
+	if _u.mutation.HeadersCleared() {
+		_spec.ClearField(deadletter.FieldHeaders, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Request(); ok {
+		_spec.SetField(deadletter.FieldRequest, field.TypeString, value)
+	}
+	if _u.mutation.RequestCleared() {
+		_spec.ClearField(deadletter.FieldRequest, field.TypeString)
+	}
+	if value, ok := _u.mutation.Response(); ok {
+		_spec.SetField(deadletter.FieldResponse, field.TypeString, value)
+	}
+	if _u.mutation.ResponseCleared() {
+		_spec.ClearField(deadletter.FieldResponse, field.TypeString)
+	}
+	if value, ok := _u.mutation.Encrypted(); ok {
+		_spec.SetField(deadletter.FieldEncrypted, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Error(); ok {
+		_spec.SetField(deadletter.FieldError, field.TypeString, value)
+	}
+	if _u.mutation.ErrorCleared() {
+		_spec.ClearField(deadletter.FieldError, field.TypeString)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{deadletter.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// DeadLetterUpdateOne is the builder for updating a single DeadLetter entity.
+type DeadLetterUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *DeadLetterMutation
+}
+
+// SetStage sets the "stage" field.
+func (_u *DeadLetterUpdateOne) SetStage(v string) *DeadLetterUpdateOne {
+	_u.mutation.SetStage(v)
+	return _u
+}
+
+// SetNillableStage sets the "stage" field if the given value is not nil.
+func (_u *DeadLetterUpdateOne) SetNillableStage(v *string) *DeadLetterUpdateOne {
+	if v != nil {
+		_u.SetStage(*v)
+	}
+	return _u
+}
+
+// SetProvider sets the "provider" field.
+func (_u *DeadLetterUpdateOne) SetProvider(v string) *DeadLetterUpdateOne {
+	_u.mutation.SetProvider(v)
+	return _u
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (_u *DeadLetterUpdateOne) SetNillableProvider(v *string) *DeadLetterUpdateOne {
+	if v != nil {
+		_u.SetProvider(*v)
+	}
+	return _u
+}
+
+// ClearProvider clears the value of the "provider" field.
+func (_u *DeadLetterUpdateOne) ClearProvider() *DeadLetterUpdateOne {
+	_u.mutation.ClearProvider()
+	return _u
+}
+
+// SetAgentName sets the "agent_name" field.
+func (_u *DeadLetterUpdateOne) SetAgentName(v string) *DeadLetterUpdateOne {
+	_u.mutation.SetAgentName(v)
+	return _u
+}
+
+// SetNillableAgentName sets the "agent_name" field if the given value is not nil.
+func (_u *DeadLetterUpdateOne) SetNillableAgentName(v *string) *DeadLetterUpdateOne {
+	if v != nil {
+		_u.SetAgentName(*v)
+	}
+	return _u
+}
+
+// ClearAgentName clears the value of the "agent_name" field.
+func (_u *DeadLetterUpdateOne) ClearAgentName() *DeadLetterUpdateOne {
+	_u.mutation.ClearAgentName()
+	return _u
+}
+
+// SetPath sets the "path" field.
+func (_u *DeadLetterUpdateOne) SetPath(v string) *DeadLetterUpdateOne {
+	_u.mutation.SetPath(v)
+	return _u
+}
+
+// SetNillablePath sets the "path" field if the given value is not nil.
+func (_u *DeadLetterUpdateOne) SetNillablePath(v *string) *DeadLetterUpdateOne {
+	if v != nil {
+		_u.SetPath(*v)
+	}
+	return _u
+}
+
+// ClearPath clears the value of the "path" field.
+func (_u *DeadLetterUpdateOne) ClearPath() *DeadLetterUpdateOne {
+	_u.mutation.ClearPath()
+	return _u
+}
+
+// SetHeaders sets the "headers" field.
+func (_u *DeadLetterUpdateOne) SetHeaders(v map[string]string) *DeadLetterUpdateOne {
+	_u.mutation.SetHeaders(v)
+	return _u
+}
+
+// ClearHeaders clears the value of the "headers" field.
+func (_u *DeadLetterUpdateOne) ClearHeaders() *DeadLetterUpdateOne {
+	_u.mutation.ClearHeaders()
+	return _u
+}
+
+// SetRequest sets the "request" field.
+func (_u *DeadLetterUpdateOne) SetRequest(v string) *DeadLetterUpdateOne {
+	_u.mutation.SetRequest(v)
+	return _u
+}
+
+// SetNillableRequest sets the "request" field if the given value is not nil.
+func (_u *DeadLetterUpdateOne) SetNillableRequest(v *string) *DeadLetterUpdateOne {
+	if v != nil {
+		_u.SetRequest(*v)
+	}
+	return _u
+}
+
+// ClearRequest clears the value of the "request" field.
+func (_u *DeadLetterUpdateOne) ClearRequest() *DeadLetterUpdateOne {
+	_u.mutation.ClearRequest()
+	return _u
+}
+
+// SetResponse sets the "response" field.
+func (_u *DeadLetterUpdateOne) SetResponse(v string) *DeadLetterUpdateOne {
+	_u.mutation.SetResponse(v)
+	return _u
+}
+
+// SetNillableResponse sets the "response" field if the given value is not nil.
+func (_u *DeadLetterUpdateOne) SetNillableResponse(v *string) *DeadLetterUpdateOne {
+	if v != nil {
+		_u.SetResponse(*v)
+	}
+	return _u
+}
+
+// ClearResponse clears the value of the "response" field.
+func (_u *DeadLetterUpdateOne) ClearResponse() *DeadLetterUpdateOne {
+	_u.mutation.ClearResponse()
+	return _u
+}
+
+// SetEncrypted sets the "encrypted" field.
+func (_u *DeadLetterUpdateOne) SetEncrypted(v bool) *DeadLetterUpdateOne {
+	_u.mutation.SetEncrypted(v)
+	return _u
+}
+
+// SetNillableEncrypted sets the "encrypted" field if the given value is not nil.
+func (_u *DeadLetterUpdateOne) SetNillableEncrypted(v *bool) *DeadLetterUpdateOne {
+	if v != nil {
+		_u.SetEncrypted(*v)
+	}
+	return _u
+}
+
+// SetError sets the "error" field.
+func (_u *DeadLetterUpdateOne) SetError(v string) *DeadLetterUpdateOne {
+	_u.mutation.SetError(v)
+	return _u
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (_u *DeadLetterUpdateOne) SetNillableError(v *string) *DeadLetterUpdateOne {
+	if v != nil {
+		_u.SetError(*v)
+	}
+	return _u
+}
+
+// ClearError clears the value of the "error" field.
+func (_u *DeadLetterUpdateOne) ClearError() *DeadLetterUpdateOne {
+	_u.mutation.ClearError()
+	return _u
+}
+
+// Mutation returns the DeadLetterMutation object of the builder.
+func (_u *DeadLetterUpdateOne) Mutation() *DeadLetterMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the DeadLetterUpdate builder.
+func (_u *DeadLetterUpdateOne) Where(ps ...predicate.DeadLetter) *DeadLetterUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *DeadLetterUpdateOne) Select(field string, fields ...string) *DeadLetterUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated DeadLetter entity.
+func (_u *DeadLetterUpdateOne) Save(ctx context.Context) (*DeadLetter, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DeadLetterUpdateOne) SaveX(ctx context.Context) *DeadLetter {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *DeadLetterUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DeadLetterUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *DeadLetterUpdateOne) check() error {
+	if v, ok := _u.mutation.Stage(); ok {
+		if err := deadletter.StageValidator(v); err != nil {
+			return &ValidationError{Name: "stage", err: fmt.Errorf(`ent: validator failed for field "DeadLetter.stage": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *DeadLetterUpdateOne) sqlSave(ctx context.Context) (_node *DeadLetter, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(deadletter.Table, deadletter.Columns, sqlgraph.NewFieldSpec(deadletter.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "DeadLetter.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, deadletter.FieldID)
+		for _, f := range fields {
+			if !deadletter.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != deadletter.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Stage(); ok {
+		_spec.SetField(deadletter.FieldStage, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Provider(); ok {
+		_spec.SetField(deadletter.FieldProvider, field.TypeString, value)
+	}
+	if _u.mutation.ProviderCleared() {
+		_spec.ClearField(deadletter.FieldProvider, field.TypeString)
+	}
+	if value, ok := _u.mutation.AgentName(); ok {
+		_spec.SetField(deadletter.FieldAgentName, field.TypeString, value)
+	}
+	if _u.mutation.AgentNameCleared() {
+		_spec.ClearField(deadletter.FieldAgentName, field.TypeString)
+	}
+	if value, ok := _u.mutation.Path(); ok {
+		_spec.SetField(deadletter.FieldPath, field.TypeString, value)
+	}
+	if _u.mutation.PathCleared() {
+		_spec.ClearField(deadletter.FieldPath, field.TypeString)
+	}
+	if value, ok := _u.mutation.Headers(); ok {
+		_spec.SetField(deadletter.FieldHeaders, field.TypeJSON, value)
+	}
+	if _u.mutation.HeadersCleared() {
+		_spec.ClearField(deadletter.FieldHeaders, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Request(); ok {
+		_spec.SetField(deadletter.FieldRequest, field.TypeString, value)
+	}
+	if _u.mutation.RequestCleared() {
+		_spec.ClearField(deadletter.FieldRequest, field.TypeString)
+	}
+	if value, ok := _u.mutation.Response(); ok {
+		_spec.SetField(deadletter.FieldResponse, field.TypeString, value)
+	}
+	if _u.mutation.ResponseCleared() {
+		_spec.ClearField(deadletter.FieldResponse, field.TypeString)
+	}
+	if value, ok := _u.mutation.Encrypted(); ok {
+		_spec.SetField(deadletter.FieldEncrypted, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Error(); ok {
+		_spec.SetField(deadletter.FieldError, field.TypeString, value)
+	}
+	if _u.mutation.ErrorCleared() {
+		_spec.ClearField(deadletter.FieldError, field.TypeString)
+	}
+	_node = &DeadLetter{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{deadletter.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,117 @@
+package entdriver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+)
+
+// Ancestry returns the path from a node back to its root (node first, root
+// last). The path ends at the oldest stored ancestor when a parent is
+// missing, such as after pruning.
+func (ed *EntDriver) Ancestry(ctx context.Context, hash string) ([]*merkle.Node, error) {
+	entNodes, err := ed.AncestryNodes(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return ed.entNodesToMerkleNodes(entNodes)
+}
+
+// AncestryNodes is Ancestry for callers that read ent nodes directly. The
+// whole path is loaded in one query rather than one query per parent.
+func (ed *EntDriver) AncestryNodes(ctx context.Context, hash string) ([]*ent.Node, error) {
+	paths, err := ed.AncestryNodesOf(ctx, []string{hash})
+	if err != nil {
+		return nil, err
+	}
+	path, ok := paths[hash]
+	if !ok {
+		return nil, storage.NotFoundError{Hash: hash}
+	}
+	return path, nil
+}
+
+// AncestryNodesOf returns the ancestry of each of hashes, keyed by hash,
+// node first and root last. Ancestors shared by several paths, such as the
+// common prefix of forked conversations, are loaded once. Hashes that are
+// not stored are left out.
+func (ed *EntDriver) AncestryNodesOf(ctx context.Context, hashes []string) (map[string][]*ent.Node, error) {
+	return ancestryOf(ctx, ed.Client, hashes)
+}
+
+// ancestryOf is AncestryNodesOf through client, such as a transaction's.
+// When fields are given, only they and the parent links are loaded.
+func ancestryOf(ctx context.Context, client *ent.Client, hashes []string, fields ...string) (map[string][]*ent.Node, error) {
+	paths := make(map[string][]*ent.Node, len(hashes))
+	if len(hashes) == 0 {
+		return paths, nil
+	}
+
+	query := client.Node.Query().Where(inAncestry(hashes))
+	if len(fields) > 0 {
+		query.Select(append([]string{node.FieldParentHash}, fields...)...)
+	}
+	entNodes, err := query.All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ancestry: %w", err)
+	}
+
+	byID := make(map[string]*ent.Node, len(entNodes))
+	for _, n := range entNodes {
+		byID[n.ID] = n
+	}
+
+	for _, hash := range hashes {
+		current, ok := byID[hash]
+		if !ok {
+			continue
+		}
+
+		// The seen set stops the walk if parent links loop, which only a
+		// corrupted database can produce.
+		var path []*ent.Node
+		seen := map[string]bool{}
+		for current != nil && !seen[current.ID] {
+			seen[current.ID] = true
+			path = append(path, current)
+			if current.ParentHash == nil {
+				break
+			}
+			current = byID[*current.ParentHash]
+		}
+		paths[hash] = path
+	}
+	return paths, nil
+}
+
+// inAncestry matches hashes and all of their stored ancestors. The parent
+// links are followed by a recursive CTE over the primary key, so a path of
+// any length costs a single query. UNION rather than UNION ALL discards
+// rows already visited, which both shares common ancestors and ends the
+// recursion on a cycle.
+func inAncestry(hashes []string) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		args := make([]any, len(hashes))
+		for i, h := range hashes {
+			args[i] = h
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(hashes)), ", ")
+
+		s.Where(sql.ExprP(s.C(node.FieldID)+` IN (
+			WITH RECURSIVE ancestry(hash, parent_hash) AS (
+				SELECT hash, parent_hash FROM `+node.Table+` WHERE hash IN (`+placeholders+`)
+				UNION
+				SELECT n.hash, n.parent_hash FROM `+node.Table+` n
+				JOIN ancestry a ON n.hash = a.parent_hash
+			)
+			SELECT hash FROM ancestry
+		)`, args...))
+	}
+}
@@ -0,0 +1,244 @@
+package entdriver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/hook"
+)
+
+const (
+	// CompressMinSize is the smallest JSON-encoded content compressed at
+	// rest. Smaller content rarely shrinks enough to pay for the envelope.
+	CompressMinSize = 512
+
+	// compressedBlockType marks a content column holding compressed content.
+	// Like sealed content it is shaped as a content block, and its encoding
+	// key records the format so that others can be added later.
+	compressedBlockType = "compressed"
+	encodingKey         = "encoding"
+	encodingZstd        = "zstd"
+	compressedDataKey   = "data"
+)
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// skipDecompressionKey marks a context whose node queries should return
+// compressed content as stored.
+type skipDecompressionKey struct{}
+
+// WithoutDecompression returns a context whose node queries leave compressed
+// content as stored, for readers that only look at the content of some of
+// the nodes they load. Such readers decompress it on use with
+// DecompressContent.
+func WithoutDecompression(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipDecompressionKey{}, true)
+}
+
+// EnableCompression compresses node content and bucket content of at least
+// CompressMinSize with zstd on every write, and decompresses it on every read
+// made through the client. Content that references deduplicated blocks is
+// left as is, so that references stay visible to block resolution; blocks
+// are compressed on their own when stored.
+//
+// Call after EnableDeduplication and before EnableEncryption, so that blocks
+// are split out before compression and content is compressed before it is
+// sealed. Rows written before compression was enabled are read back
+// unchanged.
+func (ed *EntDriver) EnableCompression() {
+	ed.compress = true
+	ed.Client.Node.Use(compressNodeContent())
+	ed.Client.Node.Intercept(decompressNodeContent())
+}
+
+// compressNodeContent is an ent hook that compresses the content and bucket
+// fields of node mutations.
+func compressNodeContent() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return hook.NodeFunc(func(ctx context.Context, m *ent.NodeMutation) (ent.Value, error) {
+			if content, ok := m.Content(); ok {
+				compressed, err := compressContent(content)
+				if err != nil {
+					return nil, err
+				}
+				m.SetContent(compressed)
+			}
+
+			if bucket, ok := m.Bucket(); ok {
+				if raw, ok := bucket[bucketContentKey]; ok {
+					content, err := toSealed(raw)
+					if err != nil {
+						return nil, err
+					}
+					compressed, err := compressContent(content)
+					if err != nil {
+						return nil, err
+					}
+					copied := make(map[string]any, len(bucket))
+					for k, v := range bucket {
+						copied[k] = v
+					}
+					copied[bucketContentKey] = compressed
+					m.SetBucket(copied)
+				}
+			}
+
+			return next.Mutate(ctx, m)
+		})
+	}
+}
+
+// decompressNodeContent is an ent interceptor that decompresses node content
+// in query results.
+func decompressNodeContent() ent.Interceptor {
+	return ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, q ent.Query) (ent.Value, error) {
+			value, err := next.Query(ctx, q)
+			if err != nil {
+				return nil, err
+			}
+
+			nodes, ok := value.([]*ent.Node)
+			if !ok || ctx.Value(skipDecompressionKey{}) != nil {
+				return value, nil
+			}
+
+			for _, n := range nodes {
+				if err := decompressNode(n); err != nil {
+					return nil, err
+				}
+			}
+			return nodes, nil
+		})
+	})
+}
+
+// decompressNode decompresses a single node's content and bucket content in
+// place.
+func decompressNode(n *ent.Node) error {
+	content, err := DecompressContent(n.Content)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", n.ID, err)
+	}
+	n.Content = content
+
+	raw, ok := n.Bucket[bucketContentKey]
+	if !ok || !isCompressedValue(raw) {
+		return nil
+	}
+	compressed, err := toSealed(raw)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", n.ID, err)
+	}
+	content, err = DecompressContent(compressed)
+	if err != nil {
+		return fmt.Errorf("node %s: %w", n.ID, err)
+	}
+	items := make([]any, len(content))
+	for i, b := range content {
+		items[i] = b
+	}
+	n.Bucket[bucketContentKey] = items
+	return nil
+}
+
+// compressContent returns content as a compressed envelope when it is large
+// enough and shrinks, and unchanged otherwise.
+func compressContent(content []map[string]any) ([]map[string]any, error) {
+	if len(content) == 0 || isCompressed(content) || isSealed(content) {
+		return content, nil
+	}
+	for _, b := range content {
+		if isBlockRef(b) {
+			return content, nil
+		}
+	}
+
+	envelope, ok, err := compressValue(content)
+	if err != nil || !ok {
+		return content, err
+	}
+	return []map[string]any{envelope}, nil
+}
+
+// compressValue encodes v as JSON and compresses it into an envelope. It
+// reports false when v is smaller than CompressMinSize or does not shrink.
+func compressValue(v any) (map[string]any, bool, error) {
+	plaintext, err := json.Marshal(v)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal content for compression: %w", err)
+	}
+	if len(plaintext) < CompressMinSize {
+		return nil, false, nil
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(zstdEncoder.EncodeAll(plaintext, nil))
+	if len(encoded) >= len(plaintext) {
+		return nil, false, nil
+	}
+	return map[string]any{
+		"type":            compressedBlockType,
+		encodingKey:       encodingZstd,
+		compressedDataKey: encoded,
+	}, true, nil
+}
+
+// DecompressContent returns content decompressed when it is a compressed
+// envelope, and unchanged otherwise.
+func DecompressContent(content []map[string]any) ([]map[string]any, error) {
+	if !isCompressed(content) {
+		return content, nil
+	}
+	var out []map[string]any
+	if err := decompressValue(content[0], &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// decompressValue decodes a compressed envelope into dst.
+func decompressValue(envelope map[string]any, dst any) error {
+	if encoding := envelope[encodingKey]; encoding != encodingZstd {
+		return fmt.Errorf("unsupported content encoding %v", encoding)
+	}
+	data, _ := envelope[compressedDataKey].(string)
+	compressed, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return fmt.Errorf("failed to decode compressed content: %w", err)
+	}
+	plaintext, err := zstdDecoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return fmt.Errorf("failed to decompress content: %w", err)
+	}
+	if err := json.Unmarshal(plaintext, dst); err != nil {
+		return fmt.Errorf("failed to unmarshal decompressed content: %w", err)
+	}
+	return nil
+}
+
+// isCompressed reports whether content is a compressed envelope.
+func isCompressed(content []map[string]any) bool {
+	return len(content) == 1 && isCompressedBlock(content[0])
+}
+
+// isCompressedBlock reports whether a single content entry or block row is a
+// compressed envelope.
+func isCompressedBlock(b map[string]any) bool {
+	_, ok := b[compressedDataKey].(string)
+	return ok && b["type"] == compressedBlockType
+}
+
+// isCompressedValue reports whether a decoded bucket content value is a
+// compressed envelope.
+func isCompressedValue(raw any) bool {
+	content, err := toSealed(raw)
+	return err == nil && isCompressed(content)
+}
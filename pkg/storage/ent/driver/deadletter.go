@@ -0,0 +1,167 @@
+package entdriver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/deadletter"
+)
+
+// sealedHeadersKey holds a dead letter's sealed headers in its headers
+// column when it is encrypted.
+const sealedHeadersKey = "sealed"
+
+// AddDeadLetter stores a dead letter, assigning its ID and CreatedAt. With
+// encryption enabled, the payloads and headers are sealed like raw captures.
+func (ed *EntDriver) AddDeadLetter(ctx context.Context, dl *storage.DeadLetter) error {
+	if dl == nil {
+		return errors.New("cannot store nil dead letter")
+	}
+
+	headers, request, response := dl.Headers, dl.Request, dl.Response
+	if ed.cipher != nil {
+		data, err := json.Marshal(dl.Headers)
+		if err != nil {
+			return fmt.Errorf("failed to encode dead letter headers: %w", err)
+		}
+		sealed, err := ed.sealDeadLetterField("headers", string(data))
+		if err != nil {
+			return err
+		}
+		headers = map[string]string{sealedHeadersKey: sealed}
+		if request, err = ed.sealDeadLetterField("request", dl.Request); err != nil {
+			return err
+		}
+		if response, err = ed.sealDeadLetterField("response", dl.Response); err != nil {
+			return err
+		}
+	}
+
+	created, err := ed.Client.DeadLetter.Create().
+		SetStage(string(dl.Stage)).
+		SetProvider(dl.Provider).
+		SetAgentName(dl.AgentName).
+		SetPath(dl.Path).
+		SetHeaders(headers).
+		SetRequest(request).
+		SetResponse(response).
+		SetEncrypted(ed.cipher != nil).
+		SetError(dl.Error).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create dead letter: %w", err)
+	}
+
+	dl.ID = created.ID
+	dl.CreatedAt = created.CreatedAt
+	return nil
+}
+
+// ListDeadLetters returns all dead letters, oldest first.
+func (ed *EntDriver) ListDeadLetters(ctx context.Context) ([]*storage.DeadLetter, error) {
+	entries, err := ed.Client.DeadLetter.Query().
+		Order(ent.Asc(deadletter.FieldCreatedAt), ent.Asc(deadletter.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	result := make([]*storage.DeadLetter, 0, len(entries))
+	for _, entry := range entries {
+		dl, err := ed.entDeadLetterToDeadLetter(entry)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, dl)
+	}
+	return result, nil
+}
+
+// GetDeadLetter retrieves a dead letter by ID.
+func (ed *EntDriver) GetDeadLetter(ctx context.Context, id int) (*storage.DeadLetter, error) {
+	entry, err := ed.Client.DeadLetter.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, storage.DeadLetterNotFoundError{ID: id}
+		}
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+	return ed.entDeadLetterToDeadLetter(entry)
+}
+
+// DeleteDeadLetter removes a dead letter by ID.
+func (ed *EntDriver) DeleteDeadLetter(ctx context.Context, id int) error {
+	err := ed.Client.DeadLetter.DeleteOneID(id).Exec(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return storage.DeadLetterNotFoundError{ID: id}
+		}
+		return fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+	return nil
+}
+
+// entDeadLetterToDeadLetter converts a stored dead letter, opening its
+// payloads and headers when they are sealed.
+func (ed *EntDriver) entDeadLetterToDeadLetter(entry *ent.DeadLetter) (*storage.DeadLetter, error) {
+	dl := &storage.DeadLetter{
+		ID:        entry.ID,
+		Stage:     storage.DeadLetterStage(entry.Stage),
+		Provider:  entry.Provider,
+		AgentName: entry.AgentName,
+		Path:      entry.Path,
+		Headers:   entry.Headers,
+		Request:   entry.Request,
+		Response:  entry.Response,
+		Error:     entry.Error,
+		CreatedAt: entry.CreatedAt,
+	}
+	if !entry.Encrypted {
+		return dl, nil
+	}
+
+	var err error
+	if dl.Request, err = ed.openDeadLetterField("request", entry.Request); err != nil {
+		return nil, fmt.Errorf("dead letter %d: %w", entry.ID, err)
+	}
+	if dl.Response, err = ed.openDeadLetterField("response", entry.Response); err != nil {
+		return nil, fmt.Errorf("dead letter %d: %w", entry.ID, err)
+	}
+	headers, err := ed.openDeadLetterField("headers", entry.Headers[sealedHeadersKey])
+	if err != nil {
+		return nil, fmt.Errorf("dead letter %d: %w", entry.ID, err)
+	}
+	dl.Headers = nil
+	if headers != "" {
+		if err := json.Unmarshal([]byte(headers), &dl.Headers); err != nil {
+			return nil, fmt.Errorf("dead letter %d: decoding headers: %w", entry.ID, err)
+		}
+	}
+	return dl, nil
+}
+
+// sealDeadLetterField seals a dead letter field with packPayload. The field
+// name is bound to the sealed value so that fields cannot be swapped.
+func (ed *EntDriver) sealDeadLetterField(field, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	packed, err := ed.packPayload("dead_letter."+field, []byte(value))
+	if err != nil {
+		return "", err
+	}
+	return string(packed), nil
+}
+
+// openDeadLetterField reverses sealDeadLetterField.
+func (ed *EntDriver) openDeadLetterField(field, value string) (string, error) {
+	opened, err := ed.unpackPayload("dead_letter."+field, []byte(value), true)
+	if err != nil {
+		return "", fmt.Errorf("opening %s: %w", field, err)
+	}
+	return string(opened), nil
+}
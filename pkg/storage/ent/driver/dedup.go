@@ -0,0 +1,446 @@
+package entdriver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/block"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/hook"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+)
+
+const (
+	// DedupMinBlockSize is the smallest JSON-encoded content block moved to
+	// the blocks table. Smaller blocks are cheaper to store inline than as a
+	// reference.
+	DedupMinBlockSize = 1024
+
+	// blockRefType marks a content entry that references a stored block.
+	blockRefType = "block_ref"
+	blockHashKey = "block_hash"
+
+	// dedupBatchSize bounds the number of IDs in a single IN clause and the
+	// number of nodes loaded per migration page.
+	dedupBatchSize = 500
+)
+
+// skipBlockResolutionKey marks a context whose node queries should return
+// block references as stored rather than resolving them.
+type skipBlockResolutionKey struct{}
+
+// EnableDeduplication stores large content blocks once in the blocks table,
+// keyed by content hash, and replaces them in node content and bucket content
+// with references. References are resolved on every read made through the
+// client, so callers always see complete content.
+//
+// Call before EnableEncryption: the hook then runs ahead of sealing on writes
+// and the interceptor runs after opening on reads. Blocks are sealed with the
+// encryption key, if any.
+func (ed *EntDriver) EnableDeduplication() {
+	ed.Client.Node.Use(ed.dedupNodeContent())
+	ed.Client.Node.Intercept(ed.resolveNodeBlocks())
+}
+
+// dedupNodeContent is an ent hook that moves large blocks out of node
+// mutations into the blocks table.
+func (ed *EntDriver) dedupNodeContent() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return hook.NodeFunc(func(ctx context.Context, m *ent.NodeMutation) (ent.Value, error) {
+			blocks := make(map[string]blockData)
+
+			if content, ok := m.Content(); ok && !isSealed(content) && !isCompressed(content) {
+				deduped, err := dedupContent(content, blocks)
+				if err != nil {
+					return nil, err
+				}
+				m.SetContent(deduped)
+			}
+
+			if bucket, ok := m.Bucket(); ok {
+				if raw, ok := bucket[bucketContentKey]; ok && !isSealedValue(raw) && !isCompressedValue(raw) {
+					content, err := toSealed(raw)
+					if err != nil {
+						return nil, err
+					}
+					deduped, err := dedupContent(content, blocks)
+					if err != nil {
+						return nil, err
+					}
+					copied := make(map[string]any, len(bucket))
+					for k, v := range bucket {
+						copied[k] = v
+					}
+					copied[bucketContentKey] = deduped
+					m.SetBucket(copied)
+				}
+			}
+
+			if err := ed.storeBlocks(ctx, m.Client(), blocks); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+}
+
+// resolveNodeBlocks is an ent interceptor that replaces block references in
+// query results with the referenced blocks.
+func (ed *EntDriver) resolveNodeBlocks() ent.Interceptor {
+	return ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, q ent.Query) (ent.Value, error) {
+			value, err := next.Query(ctx, q)
+			if err != nil {
+				return nil, err
+			}
+
+			nodes, ok := value.([]*ent.Node)
+			if !ok || ctx.Value(skipBlockResolutionKey{}) != nil {
+				return value, nil
+			}
+
+			if err := ed.resolveBlocks(ctx, nodes); err != nil {
+				return nil, err
+			}
+			return nodes, nil
+		})
+	})
+}
+
+// blockData is a block pending storage along with its encoded size.
+type blockData struct {
+	data map[string]any
+	size int
+}
+
+// dedupContent returns a copy of content with blocks of at least
+// DedupMinBlockSize replaced by references, recording the blocks by hash.
+func dedupContent(content []map[string]any, blocks map[string]blockData) ([]map[string]any, error) {
+	out := make([]map[string]any, 0, len(content))
+	for _, b := range content {
+		if isBlockRef(b) {
+			out = append(out, b)
+			continue
+		}
+
+		encoded, err := json.Marshal(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal content block: %w", err)
+		}
+		if len(encoded) < DedupMinBlockSize {
+			out = append(out, b)
+			continue
+		}
+
+		sum := sha256.Sum256(encoded)
+		hash := hex.EncodeToString(sum[:])
+		blocks[hash] = blockData{data: b, size: len(encoded)}
+		out = append(out, map[string]any{"type": blockRefType, blockHashKey: hash})
+	}
+	return out, nil
+}
+
+// storeBlocks inserts the blocks not already present in the blocks table.
+func (ed *EntDriver) storeBlocks(ctx context.Context, client *ent.Client, blocks map[string]blockData) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	hashes := make([]string, 0, len(blocks))
+	for hash := range blocks {
+		hashes = append(hashes, hash)
+	}
+
+	existing, err := client.Block.Query().Where(block.IDIn(hashes...)).IDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check existing blocks: %w", err)
+	}
+	for _, hash := range existing {
+		delete(blocks, hash)
+	}
+
+	creates := make([]*ent.BlockCreate, 0, len(blocks))
+	for hash, b := range blocks {
+		data := b.data
+		if ed.compress {
+			envelope, ok, err := compressValue(data)
+			if err != nil {
+				return err
+			}
+			if ok {
+				data = envelope
+			}
+		}
+		if ed.cipher != nil {
+			sealed, err := sealContent(ed.cipher, hash, data)
+			if err != nil {
+				return err
+			}
+			data = sealed[0]
+		}
+		creates = append(creates, client.Block.Create().SetID(hash).SetData(data).SetSize(b.size))
+	}
+
+	if err := client.Block.CreateBulk(creates...).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to store blocks: %w", err)
+	}
+	return nil
+}
+
+// resolveBlocks replaces block references in the content and bucket content
+// of nodes, in place.
+func (ed *EntDriver) resolveBlocks(ctx context.Context, nodes []*ent.Node) error {
+	wanted := make(map[string]bool)
+	for _, n := range nodes {
+		collectBlockRefs(n.Content, wanted)
+		if raw, ok := n.Bucket[bucketContentKey]; ok {
+			if content, err := toSealed(raw); err == nil {
+				collectBlockRefs(content, wanted)
+			}
+		}
+	}
+	if len(wanted) == 0 {
+		return nil
+	}
+
+	blocks, err := ed.loadBlocks(ctx, wanted)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range nodes {
+		if n.Content != nil {
+			resolved, err := resolveContent(n.Content, blocks)
+			if err != nil {
+				return fmt.Errorf("node %s: %w", n.ID, err)
+			}
+			n.Content = resolved
+		}
+
+		raw, ok := n.Bucket[bucketContentKey]
+		if !ok {
+			continue
+		}
+		content, err := toSealed(raw)
+		if err != nil {
+			continue
+		}
+		resolved, err := resolveContent(content, blocks)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", n.ID, err)
+		}
+		items := make([]any, len(resolved))
+		for i, b := range resolved {
+			items[i] = b
+		}
+		n.Bucket[bucketContentKey] = items
+	}
+	return nil
+}
+
+// loadBlocks fetches and, when encrypted or compressed, opens the requested
+// blocks.
+func (ed *EntDriver) loadBlocks(ctx context.Context, wanted map[string]bool) (map[string]map[string]any, error) {
+	hashes := make([]string, 0, len(wanted))
+	for hash := range wanted {
+		hashes = append(hashes, hash)
+	}
+
+	blocks := make(map[string]map[string]any, len(hashes))
+	for start := 0; start < len(hashes); start += dedupBatchSize {
+		batch := hashes[start:min(start+dedupBatchSize, len(hashes))]
+		rows, err := ed.Client.Block.Query().Where(block.IDIn(batch...)).All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load blocks: %w", err)
+		}
+		for _, row := range rows {
+			data := row.Data
+			if isSealed([]map[string]any{data}) {
+				if ed.cipher == nil {
+					return nil, fmt.Errorf("block %s is encrypted and no key is configured", row.ID)
+				}
+				data = nil
+				if err := openContent(ed.cipher, row.ID, []map[string]any{row.Data}, &data); err != nil {
+					return nil, err
+				}
+			}
+			if isCompressedBlock(data) {
+				var decompressed map[string]any
+				if err := decompressValue(data, &decompressed); err != nil {
+					return nil, fmt.Errorf("block %s: %w", row.ID, err)
+				}
+				data = decompressed
+			}
+			blocks[row.ID] = data
+		}
+	}
+	return blocks, nil
+}
+
+// DedupResult summarizes a DeduplicateBlocks migration.
+type DedupResult struct {
+	// Nodes is the number of nodes rewritten to reference blocks.
+	Nodes int
+
+	// Blocks is the number of distinct blocks in the blocks table afterwards.
+	Blocks int
+}
+
+// DeduplicateBlocks rewrites nodes stored before deduplication was enabled so
+// that their large blocks are moved to the blocks table. It is safe to run
+// repeatedly; nodes that are already deduplicated are left untouched.
+func (ed *EntDriver) DeduplicateBlocks(ctx context.Context) (*DedupResult, error) {
+	result := &DedupResult{}
+	raw := context.WithValue(ctx, skipBlockResolutionKey{}, true)
+
+	last := ""
+	for {
+		page, err := ed.Client.Node.Query().
+			Where(node.IDGT(last)).
+			Order(ent.Asc(node.FieldID)).
+			Limit(dedupBatchSize).
+			Select(node.FieldID, node.FieldContent, node.FieldBucket).
+			All(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load nodes: %w", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		last = page[len(page)-1].ID
+
+		for _, n := range page {
+			if !hasInlineBlocks(n) {
+				continue
+			}
+			update := ed.Client.Node.UpdateOneID(n.ID).SetContent(n.Content)
+			if n.Bucket != nil {
+				update.SetBucket(n.Bucket)
+			}
+			if err := update.Exec(ctx); err != nil {
+				return nil, fmt.Errorf("failed to deduplicate node %s: %w", n.ID, err)
+			}
+			result.Nodes++
+		}
+	}
+
+	count, err := ed.Client.Block.Query().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count blocks: %w", err)
+	}
+	result.Blocks = count
+	return result, nil
+}
+
+// PruneBlocks deletes blocks no longer referenced by any node, returning the
+// number removed. Deleting nodes leaves their blocks behind, since other
+// nodes may share them.
+func (ed *EntDriver) PruneBlocks(ctx context.Context) (int, error) {
+	raw := context.WithValue(ctx, skipBlockResolutionKey{}, true)
+
+	referenced := make(map[string]bool)
+	last := ""
+	for {
+		page, err := ed.Client.Node.Query().
+			Where(node.IDGT(last)).
+			Order(ent.Asc(node.FieldID)).
+			Limit(dedupBatchSize).
+			Select(node.FieldID, node.FieldContent, node.FieldBucket).
+			All(raw)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load nodes: %w", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		last = page[len(page)-1].ID
+
+		for _, n := range page {
+			collectBlockRefs(n.Content, referenced)
+			if content, err := toSealed(n.Bucket[bucketContentKey]); err == nil {
+				collectBlockRefs(content, referenced)
+			}
+		}
+	}
+
+	all, err := ed.Client.Block.Query().IDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list blocks: %w", err)
+	}
+
+	var unused []string
+	for _, hash := range all {
+		if !referenced[hash] {
+			unused = append(unused, hash)
+		}
+	}
+
+	deleted := 0
+	for start := 0; start < len(unused); start += dedupBatchSize {
+		batch := unused[start:min(start+dedupBatchSize, len(unused))]
+		n, err := ed.Client.Block.Delete().Where(block.IDIn(batch...)).Exec(ctx)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete blocks: %w", err)
+		}
+		deleted += n
+	}
+	return deleted, nil
+}
+
+// hasInlineBlocks reports whether a node's stored content still embeds a
+// block large enough to deduplicate.
+func hasInlineBlocks(n *ent.Node) bool {
+	if isSealed(n.Content) {
+		// Content is only still sealed here when no key is configured, in
+		// which case it cannot be rewritten.
+		return false
+	}
+	for _, b := range n.Content {
+		if isBlockRef(b) {
+			continue
+		}
+		encoded, err := json.Marshal(b)
+		if err == nil && len(encoded) >= DedupMinBlockSize {
+			return true
+		}
+	}
+	return false
+}
+
+func collectBlockRefs(content []map[string]any, refs map[string]bool) {
+	for _, b := range content {
+		if isBlockRef(b) {
+			refs[b[blockHashKey].(string)] = true
+		}
+	}
+}
+
+func resolveContent(content []map[string]any, blocks map[string]map[string]any) ([]map[string]any, error) {
+	out := make([]map[string]any, len(content))
+	for i, b := range content {
+		if !isBlockRef(b) {
+			out[i] = b
+			continue
+		}
+		hash := b[blockHashKey].(string)
+		data, ok := blocks[hash]
+		if !ok {
+			return nil, errors.New("missing content block " + hash)
+		}
+		out[i] = data
+	}
+	return out, nil
+}
+
+// isBlockRef reports whether a content entry references a stored block.
+func isBlockRef(b map[string]any) bool {
+	if b["type"] != blockRefType {
+		return false
+	}
+	_, ok := b[blockHashKey].(string)
+	return ok
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/papercomputeco/tapes/pkg/llm"
 	"github.com/papercomputeco/tapes/pkg/merkle"
 	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
 	"github.com/papercomputeco/tapes/pkg/storage/ent"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
 )
@@ -18,6 +19,15 @@ import (
 // It is database-agnostic and can be embedded by specific drivers.
 type EntDriver struct {
 	Client *ent.Client
+
+	// cipher seals deduplicated blocks when encryption is enabled.
+	cipher *encryption.Cipher
+
+	// compress compresses deduplicated blocks when compression is enabled.
+	compress bool
+
+	// sessionCost prices turns for the session summaries. See SetSessionCost.
+	sessionCost func(model string, usage llm.Usage) float64
 }
 
 // Put stores a node. Returns true if the node was newly inserted,
@@ -38,44 +48,183 @@ func (ed *EntDriver) Put(ctx context.Context, n *merkle.Node) (bool, error) {
 		return false, nil
 	}
 
-	create := ed.Client.Node.Create().
+	// The node and its session summary are stored together, so the summary
+	// never misses a node.
+	tx, err := ed.Client.Tx(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	if err := ed.create(ctx, tx.Client(), n); err != nil {
+		return false, rollback(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit node: %w", err)
+	}
+
+	return true, nil
+}
+
+// create stores n and updates its session summary through client.
+func (ed *EntDriver) create(ctx context.Context, client *ent.Client, n *merkle.Node) error {
+	create, err := nodeCreate(client, n)
+	if err != nil {
+		return err
+	}
+	created, err := create.Save(ctx)
+	if err != nil {
+		return fmt.Errorf("could not execute node creation: %w", err)
+	}
+	return ed.recordSession(ctx, client, n, created.CreatedAt)
+}
+
+// InsertBatch stores nodes in a single transaction. Existence is checked
+// with one query for the whole batch, and nodes repeated within the batch
+// are stored once.
+func (ed *EntDriver) InsertBatch(ctx context.Context, nodes []*merkle.Node) ([]bool, error) {
+	inserted := make([]bool, len(nodes))
+	if len(nodes) == 0 {
+		return inserted, nil
+	}
+
+	hashes := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		if n == nil {
+			return nil, errors.New("cannot store nil node")
+		}
+		hashes = append(hashes, n.Hash)
+	}
+
+	tx, err := ed.Client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	existing, err := tx.Node.Query().Where(node.IDIn(hashes...)).IDs(ctx)
+	if err != nil {
+		return nil, rollback(tx, fmt.Errorf("failed to check existence: %w", err))
+	}
+	stored := make(map[string]bool, len(nodes))
+	for _, hash := range existing {
+		stored[hash] = true
+	}
+
+	for i, n := range nodes {
+		if stored[n.Hash] {
+			continue
+		}
+		if err := ed.create(ctx, tx.Client(), n); err != nil {
+			return nil, rollback(tx, err)
+		}
+		stored[n.Hash] = true
+		inserted[i] = true
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit nodes: %w", err)
+	}
+	return inserted, nil
+}
+
+// rollback rolls back tx after err, keeping err as the cause.
+func rollback(tx *ent.Tx, err error) error {
+	if rerr := tx.Rollback(); rerr != nil {
+		return fmt.Errorf("%w (rollback failed: %v)", err, rerr)
+	}
+	return err
+}
+
+// nodeCreate builds the creation of n with client.
+func nodeCreate(client *ent.Client, n *merkle.Node) (*ent.NodeCreate, error) {
+	create := client.Node.Create().
 		SetID(n.Hash).
 		SetNillableParentHash(n.ParentHash).
 		SetType(n.Bucket.Type).
 		SetRole(n.Bucket.Role).
 		SetModel(n.Bucket.Model).
 		SetProvider(n.Bucket.Provider).
-		SetStopReason(n.StopReason)
+		SetStopReason(n.StopReason).
+		SetTurnIndex(n.TurnIndex)
 
 	if n.Project != "" {
 		create.SetProject(n.Project)
 	}
 
+	if n.User != "" {
+		create.SetUser(n.User)
+	}
+
+	if n.TraceID != "" {
+		create.SetTraceID(n.TraceID)
+	}
+
+	if n.RunID != "" {
+		create.SetRunID(n.RunID)
+	}
+
+	if n.AgentVersion != "" {
+		create.SetAgentVersion(n.AgentVersion)
+	}
+
+	if n.SystemPromptHash != "" {
+		create.SetSystemPromptHash(n.SystemPromptHash)
+	}
+
+	if len(n.PolicyViolations) > 0 {
+		create.SetPolicyViolations(n.PolicyViolations)
+	}
+
+	if n.Signed() {
+		create.SetSignerKey(n.SignerKey)
+		create.SetSignature(n.Signature)
+	}
+
+	if n.SharedBy != "" {
+		create.SetSharedBy(n.SharedBy)
+	}
+
+	if n.SupersededBy != "" {
+		create.SetSupersededBy(n.SupersededBy)
+	}
+
+	if n.EndedAt != nil {
+		create.SetEndedAt(*n.EndedAt)
+		create.SetEndReason(n.EndReason)
+	}
+
+	if !n.CreatedAt.IsZero() {
+		create.SetCreatedAt(n.CreatedAt)
+	}
+
 	if n.Bucket.AgentName != "" {
 		create.SetAgentName(n.Bucket.AgentName)
 	}
 
+	if n.Bucket.SessionID != "" {
+		create.SetSessionID(n.Bucket.SessionID)
+	}
+
 	// Marshal bucket to JSON for storage
 	bucketJSON, err := json.Marshal(n.Bucket)
 	if err != nil {
-		return false, fmt.Errorf("failed to marshal bucket: %w", err)
+		return nil, fmt.Errorf("failed to marshal bucket: %w", err)
 	}
 	var bucketMap map[string]any
 	if err := json.Unmarshal(bucketJSON, &bucketMap); err != nil {
-		return false, fmt.Errorf("failed to unmarshal bucket to map: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal bucket to map: %w", err)
 	}
 	create.SetBucket(bucketMap)
 
 	// Marshal content blocks
 	contentJSON, err := json.Marshal(n.Bucket.Content)
 	if err != nil {
-		return false, fmt.Errorf("failed to marshal content: %w", err)
+		return nil, fmt.Errorf("failed to marshal content: %w", err)
 	}
 	var contentSlice []map[string]any
 	if err := json.Unmarshal(contentJSON, &contentSlice); err != nil {
-		return false, fmt.Errorf("failed to unmarshal content to slice: %w", err)
+		return nil, fmt.Errorf("failed to unmarshal content to slice: %w", err)
 	}
 	create.SetContent(contentSlice)
+	create.SetToolNames(ToolNames(contentSlice))
 
 	// Set usage fields if available
 	if n.Usage != nil {
@@ -94,20 +243,49 @@ func (ed *EntDriver) Put(ctx context.Context, n *merkle.Node) (bool, error) {
 		if n.Usage.CacheReadInputTokens > 0 {
 			create.SetCacheReadInputTokens(n.Usage.CacheReadInputTokens)
 		}
+		if n.Usage.ReasoningTokens > 0 {
+			create.SetReasoningTokens(n.Usage.ReasoningTokens)
+		}
 		if n.Usage.TotalDurationNs > 0 {
 			create.SetTotalDurationNs(n.Usage.TotalDurationNs)
 		}
 		if n.Usage.PromptDurationNs > 0 {
 			create.SetPromptDurationNs(n.Usage.PromptDurationNs)
 		}
+		if n.Usage.Estimated {
+			create.SetUsageEstimated(true)
+		}
 	}
 
-	err = create.Exec(ctx)
-	if err != nil {
-		return false, fmt.Errorf("could not execute node creation: %w", err)
+	if n.Timing != nil {
+		if !n.Timing.RequestStartedAt.IsZero() {
+			create.SetRequestStartedAt(n.Timing.RequestStartedAt)
+		}
+		if !n.Timing.FirstChunkAt.IsZero() {
+			create.SetFirstChunkAt(n.Timing.FirstChunkAt)
+		}
+		if !n.Timing.ResponseCompletedAt.IsZero() {
+			create.SetResponseCompletedAt(n.Timing.ResponseCompletedAt)
+		}
 	}
 
-	return true, nil
+	if n.Error != nil {
+		create.SetErrorStatus(n.Error.Status)
+		if n.Error.Type != "" {
+			create.SetErrorType(n.Error.Type)
+		}
+		if n.Error.Message != "" {
+			create.SetErrorMessage(n.Error.Message)
+		}
+		if n.Error.RetryAfterSeconds > 0 {
+			create.SetRetryAfterSeconds(n.Error.RetryAfterSeconds)
+		}
+		if n.Error.Attempt > 0 {
+			create.SetErrorAttempt(n.Error.Attempt)
+		}
+	}
+
+	return create, nil
 }
 
 // Get retrieves a node by its hash.
@@ -182,40 +360,6 @@ func (ed *EntDriver) Leaves(ctx context.Context) ([]*merkle.Node, error) {
 	return ed.entNodesToMerkleNodes(entNodes)
 }
 
-// Ancestry returns the path from a node back to its root (node first, root last).
-// Uses the parent edge for traversal.
-func (ed *EntDriver) Ancestry(ctx context.Context, hash string) ([]*merkle.Node, error) {
-	var path []*merkle.Node
-
-	current, err := ed.Client.Node.Get(ctx, hash)
-	if err != nil {
-		if ent.IsNotFound(err) {
-			return nil, storage.NotFoundError{Hash: hash}
-		}
-		return nil, fmt.Errorf("failed to get node: %w", err)
-	}
-
-	for current != nil {
-		n, err := ed.entNodeToMerkleNode(current)
-		if err != nil {
-			return nil, err
-		}
-		path = append(path, n)
-
-		// Use the parent edge to traverse up
-		parent, err := current.QueryParent().Only(ctx)
-		if ent.IsNotFound(err) {
-			break // Reached root
-		}
-		if err != nil {
-			return nil, fmt.Errorf("failed to query parent: %w", err)
-		}
-		current = parent
-	}
-
-	return path, nil
-}
-
 // Depth returns the depth of a node (0 for roots).
 func (ed *EntDriver) Depth(ctx context.Context, hash string) (int, error) {
 	path, err := ed.Ancestry(ctx, hash)
@@ -248,8 +392,15 @@ func (ed *EntDriver) UpdateUsage(ctx context.Context, hash string, usage *llm.Us
 	if usage.CacheReadInputTokens > 0 {
 		update.SetCacheReadInputTokens(usage.CacheReadInputTokens)
 	}
+	if usage.ReasoningTokens > 0 {
+		update.SetReasoningTokens(usage.ReasoningTokens)
+	}
+	update.SetUsageEstimated(usage.Estimated)
 
-	return update.Exec(ctx)
+	if err := update.Exec(ctx); err != nil {
+		return err
+	}
+	return ed.refreshSessions(ctx, hash)
 }
 
 // Close closes the database connection.
@@ -275,18 +426,66 @@ func (ed *EntDriver) entNodeToMerkleNode(entNode *ent.Node) (*merkle.Node, error
 		ParentHash: entNode.ParentHash,
 		Bucket:     bucket,
 		StopReason: entNode.StopReason,
+		CreatedAt:  entNode.CreatedAt,
+	}
+
+	if entNode.TurnIndex != nil {
+		node.TurnIndex = *entNode.TurnIndex
 	}
 
 	if entNode.Project != nil {
 		node.Project = *entNode.Project
 	}
 
+	if entNode.User != nil {
+		node.User = *entNode.User
+	}
+
+	if entNode.TraceID != nil {
+		node.TraceID = *entNode.TraceID
+	}
+
+	if entNode.RunID != nil {
+		node.RunID = *entNode.RunID
+	}
+
+	if entNode.AgentVersion != nil {
+		node.AgentVersion = *entNode.AgentVersion
+	}
+
+	if entNode.SystemPromptHash != nil {
+		node.SystemPromptHash = *entNode.SystemPromptHash
+	}
+
+	node.PolicyViolations = entNode.PolicyViolations
+
+	if entNode.SignerKey != nil {
+		node.SignerKey = *entNode.SignerKey
+	}
+	if entNode.Signature != nil {
+		node.Signature = *entNode.Signature
+	}
+
+	if entNode.SharedBy != nil {
+		node.SharedBy = *entNode.SharedBy
+	}
+
+	if entNode.SupersededBy != nil {
+		node.SupersededBy = *entNode.SupersededBy
+	}
+
+	node.EndedAt = entNode.EndedAt
+	if entNode.EndReason != nil {
+		node.EndReason = *entNode.EndReason
+	}
+
 	// Rebuild usage metrics if they exist.
 	if entNode.PromptTokens != nil ||
 		entNode.CompletionTokens != nil ||
 		entNode.TotalTokens != nil ||
 		entNode.CacheCreationInputTokens != nil ||
 		entNode.CacheReadInputTokens != nil ||
+		entNode.ReasoningTokens != nil ||
 		entNode.TotalDurationNs != nil ||
 		entNode.PromptDurationNs != nil {
 		node.Usage = &llm.Usage{}
@@ -311,6 +510,10 @@ func (ed *EntDriver) entNodeToMerkleNode(entNode *ent.Node) (*merkle.Node, error
 			node.Usage.CacheReadInputTokens = *entNode.CacheReadInputTokens
 		}
 
+		if entNode.ReasoningTokens != nil {
+			node.Usage.ReasoningTokens = *entNode.ReasoningTokens
+		}
+
 		if entNode.TotalDurationNs != nil {
 			node.Usage.TotalDurationNs = *entNode.TotalDurationNs
 		}
@@ -318,6 +521,46 @@ func (ed *EntDriver) entNodeToMerkleNode(entNode *ent.Node) (*merkle.Node, error
 		if entNode.PromptDurationNs != nil {
 			node.Usage.PromptDurationNs = *entNode.PromptDurationNs
 		}
+
+		node.Usage.Estimated = entNode.UsageEstimated
+	}
+
+	// Rebuild proxy timing if it was captured.
+	if entNode.RequestStartedAt != nil || entNode.FirstChunkAt != nil || entNode.ResponseCompletedAt != nil {
+		node.Timing = &llm.Timing{}
+
+		if entNode.RequestStartedAt != nil {
+			node.Timing.RequestStartedAt = *entNode.RequestStartedAt
+		}
+
+		if entNode.FirstChunkAt != nil {
+			node.Timing.FirstChunkAt = *entNode.FirstChunkAt
+		}
+
+		if entNode.ResponseCompletedAt != nil {
+			node.Timing.ResponseCompletedAt = *entNode.ResponseCompletedAt
+		}
+	}
+
+	// Rebuild the upstream error of error nodes.
+	if entNode.ErrorStatus != nil {
+		node.Error = &llm.UpstreamError{Status: *entNode.ErrorStatus}
+
+		if entNode.ErrorType != nil {
+			node.Error.Type = *entNode.ErrorType
+		}
+
+		if entNode.ErrorMessage != nil {
+			node.Error.Message = *entNode.ErrorMessage
+		}
+
+		if entNode.RetryAfterSeconds != nil {
+			node.Error.RetryAfterSeconds = *entNode.RetryAfterSeconds
+		}
+
+		if entNode.ErrorAttempt != nil {
+			node.Error.Attempt = *entNode.ErrorAttempt
+		}
 	}
 
 	return node, nil
@@ -0,0 +1,296 @@
+package entdriver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/hook"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+)
+
+const (
+	// encryptedBlockType marks a content column holding sealed content. It is
+	// shaped like a content block so readers without the key see an opaque
+	// "encrypted" block rather than malformed data.
+	encryptedBlockType = "encrypted"
+	ciphertextKey      = "ciphertext"
+	bucketContentKey   = "content"
+
+	// sealedTextPrefix marks a sealed annotation, telling it apart from one
+	// written before encryption was enabled.
+	sealedTextPrefix = "sealed:"
+)
+
+// annotationFields are the node columns holding text derived from a session's
+// content, which are sealed along with it.
+var annotationFields = []string{node.FieldTitle, node.FieldSummary, node.FieldOutcome}
+
+// EnableEncryption seals node content with c on every write and opens it on
+// every read made through the client, including queries issued outside of the
+// driver. The bucket's content and the session annotations derived from it are
+// sealed too, while the node hash, which is computed over plaintext, is left
+// as is and bound to the ciphertext so sealed content cannot be moved between
+// nodes.
+//
+// Rows written before encryption was enabled are read back unchanged, so an
+// existing database can be encrypted incrementally.
+func (ed *EntDriver) EnableEncryption(c *encryption.Cipher) {
+	ed.cipher = c
+	ed.Client.Node.Use(sealNodeContent(c))
+	ed.Client.Node.Intercept(openNodeContent(c))
+}
+
+// Cipher returns the cipher content is sealed with, or nil when encryption is
+// disabled.
+func (ed *EntDriver) Cipher() *encryption.Cipher {
+	return ed.cipher
+}
+
+// sealNodeContent is an ent hook that encrypts the content, bucket and
+// annotation fields of node mutations.
+func sealNodeContent(c *encryption.Cipher) ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return hook.NodeFunc(func(ctx context.Context, m *ent.NodeMutation) (ent.Value, error) {
+			if err := sealAnnotations(ctx, c, m); err != nil {
+				return nil, err
+			}
+
+			content, hasContent := m.Content()
+			bucket, hasBucket := m.Bucket()
+			if !hasContent && !hasBucket {
+				return next.Mutate(ctx, m)
+			}
+
+			id, ok := m.ID()
+			if !ok {
+				return nil, errors.New("encrypting node content requires a node hash")
+			}
+
+			if hasContent && !isSealed(content) {
+				sealed, err := sealContent(c, id, content)
+				if err != nil {
+					return nil, err
+				}
+				m.SetContent(sealed)
+			}
+
+			if hasBucket {
+				if raw, ok := bucket[bucketContentKey]; ok && !isSealedValue(raw) {
+					sealed, err := sealContent(c, id, raw)
+					if err != nil {
+						return nil, err
+					}
+					copied := make(map[string]any, len(bucket))
+					for k, v := range bucket {
+						copied[k] = v
+					}
+					copied[bucketContentKey] = sealed
+					m.SetBucket(copied)
+				}
+			}
+
+			return next.Mutate(ctx, m)
+		})
+	}
+}
+
+// sealAnnotations seals the annotation fields set by a node mutation, bound
+// to the node's hash and the field name. Bulk updates may set them on a
+// single node only, since each is sealed for its node.
+func sealAnnotations(ctx context.Context, c *encryption.Cipher, m *ent.NodeMutation) error {
+	values := map[string]string{}
+	for _, field := range annotationFields {
+		value, ok := m.Field(field)
+		if text, _ := value.(string); ok && text != "" && !strings.HasPrefix(text, sealedTextPrefix) {
+			values[field] = text
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	id, ok := m.ID()
+	if !ok {
+		ids, err := m.IDs(ctx)
+		if err != nil {
+			return fmt.Errorf("resolving annotated node: %w", err)
+		}
+		switch len(ids) {
+		case 0:
+			// The update matches no node, so there is nothing to seal.
+			return nil
+		case 1:
+			id = ids[0]
+		default:
+			return errors.New("encrypting annotations requires updating one node at a time")
+		}
+	}
+
+	for field, text := range values {
+		sealed, err := c.Seal([]byte(text), annotationAAD(id, field))
+		if err != nil {
+			return fmt.Errorf("failed to encrypt %s: %w", field, err)
+		}
+		if err := m.SetField(field, sealedTextPrefix+sealed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openAnnotation opens a sealed annotation value in place. Values written
+// before encryption was enabled are left as they are.
+func openAnnotation(c *encryption.Cipher, id, field string, value *string) error {
+	if value == nil {
+		return nil
+	}
+	sealed, ok := strings.CutPrefix(*value, sealedTextPrefix)
+	if !ok {
+		return nil
+	}
+	plaintext, err := c.Open(sealed, annotationAAD(id, field))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt %s of node %s: %w", field, id, err)
+	}
+	*value = string(plaintext)
+	return nil
+}
+
+func annotationAAD(id, field string) []byte {
+	return []byte(id + "." + field)
+}
+
+// openNodeContent is an ent interceptor that decrypts node content in query
+// results.
+func openNodeContent(c *encryption.Cipher) ent.Interceptor {
+	return ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, q ent.Query) (ent.Value, error) {
+			value, err := next.Query(ctx, q)
+			if err != nil {
+				return nil, err
+			}
+
+			nodes, ok := value.([]*ent.Node)
+			if !ok {
+				return value, nil
+			}
+
+			for _, n := range nodes {
+				if err := openNode(c, n); err != nil {
+					return nil, err
+				}
+			}
+			return nodes, nil
+		})
+	})
+}
+
+// openNode decrypts a single node's content, bucket content and annotations
+// in place.
+func openNode(c *encryption.Cipher, n *ent.Node) error {
+	for field, value := range map[string]*string{
+		node.FieldTitle:   n.Title,
+		node.FieldSummary: n.Summary,
+		node.FieldOutcome: n.Outcome,
+	} {
+		if err := openAnnotation(c, n.ID, field, value); err != nil {
+			return err
+		}
+	}
+
+	if isSealed(n.Content) {
+		var content []map[string]any
+		if err := openContent(c, n.ID, n.Content, &content); err != nil {
+			return err
+		}
+		n.Content = content
+	}
+
+	if raw, ok := n.Bucket[bucketContentKey]; ok && isSealedValue(raw) {
+		sealed, err := toSealed(raw)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", n.ID, err)
+		}
+		var content []any
+		if err := openContent(c, n.ID, sealed, &content); err != nil {
+			return err
+		}
+		n.Bucket[bucketContentKey] = content
+	}
+
+	return nil
+}
+
+func sealContent(c *encryption.Cipher, id string, content any) ([]map[string]any, error) {
+	plaintext, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal content for encryption: %w", err)
+	}
+
+	ciphertext, err := c.Seal(plaintext, []byte(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt content: %w", err)
+	}
+
+	return []map[string]any{{
+		"type":        encryptedBlockType,
+		ciphertextKey: ciphertext,
+	}}, nil
+}
+
+func openContent(c *encryption.Cipher, id string, sealed []map[string]any, dst any) error {
+	ciphertext, _ := sealed[0][ciphertextKey].(string)
+	plaintext, err := c.Open(ciphertext, []byte(id))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt content of node %s: %w", id, err)
+	}
+	if err := json.Unmarshal(plaintext, dst); err != nil {
+		return fmt.Errorf("failed to unmarshal decrypted content of node %s: %w", id, err)
+	}
+	return nil
+}
+
+// isSealed reports whether content is a sealed envelope.
+func isSealed(content []map[string]any) bool {
+	if len(content) != 1 {
+		return false
+	}
+	_, ok := content[0][ciphertextKey].(string)
+	return ok && content[0]["type"] == encryptedBlockType
+}
+
+// isSealedValue reports whether a decoded bucket content value is a sealed
+// envelope.
+func isSealedValue(raw any) bool {
+	sealed, err := toSealed(raw)
+	return err == nil && isSealed(sealed)
+}
+
+// toSealed converts a bucket content value, which is []map[string]any when
+// set by a mutation and []any when decoded from the database, to the content
+// column shape. Nodes without content blocks store a null value.
+func toSealed(raw any) ([]map[string]any, error) {
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case []map[string]any:
+		return v, nil
+	case []any:
+		out := make([]map[string]any, 0, len(v))
+		for _, item := range v {
+			m, ok := item.(map[string]any)
+			if !ok {
+				return nil, errors.New("unexpected bucket content shape")
+			}
+			out = append(out, m)
+		}
+		return out, nil
+	default:
+		return nil, errors.New("unexpected bucket content shape")
+	}
+}
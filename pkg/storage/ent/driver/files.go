@@ -0,0 +1,103 @@
+package entdriver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+)
+
+// filesBatchSize bounds the number of IDs in a single IN clause and the
+// number of nodes loaded per page when looking for file references.
+const filesBatchSize = 500
+
+// OffloadedFiles returns the blob and media URIs referenced by the content of
+// the nodes with the given hashes. Collect them before deleting the nodes, and
+// pass them to RemoveUnreferencedFiles afterwards.
+func OffloadedFiles(ctx context.Context, client *ent.Client, hashes []string) (map[string]bool, error) {
+	uris := map[string]bool{}
+	for start := 0; start < len(hashes); start += filesBatchSize {
+		batch := hashes[start:min(start+filesBatchSize, len(hashes))]
+		nodes, err := client.Node.Query().
+			Where(node.IDIn(batch...)).
+			Select(node.FieldID, node.FieldContent, node.FieldBucket).
+			All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load offloaded files: %w", err)
+		}
+		for _, n := range nodes {
+			for _, uri := range fileReferences(n) {
+				uris[uri] = true
+			}
+		}
+	}
+	return uris, nil
+}
+
+// RemoveUnreferencedFiles deletes the files among uris that no stored node
+// references any longer, returning how many it deleted. Blobs are named by
+// content, so a file may be shared by several nodes, and every node is checked
+// before any file goes.
+func RemoveUnreferencedFiles(ctx context.Context, client *ent.Client, uris map[string]bool) (int, error) {
+	if len(uris) == 0 {
+		return 0, nil
+	}
+
+	unreferenced := make(map[string]bool, len(uris))
+	for uri := range uris {
+		unreferenced[uri] = true
+	}
+
+	last := ""
+	for len(unreferenced) > 0 {
+		page, err := client.Node.Query().
+			Where(node.IDGT(last)).
+			Order(ent.Asc(node.FieldID)).
+			Limit(filesBatchSize).
+			Select(node.FieldID, node.FieldContent, node.FieldBucket).
+			All(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load file references: %w", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		last = page[len(page)-1].ID
+
+		for _, n := range page {
+			for _, uri := range fileReferences(n) {
+				delete(unreferenced, uri)
+			}
+		}
+	}
+
+	removed := 0
+	for uri := range unreferenced {
+		if err := blob.Delete(ctx, uri); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// fileReferences returns the blob and media URIs in a node's content and
+// bucket content.
+func fileReferences(n *ent.Node) []string {
+	blocks := n.Content
+	if content, err := toSealed(n.Bucket[bucketContentKey]); err == nil {
+		blocks = append(blocks[:len(blocks):len(blocks)], content...)
+	}
+
+	var uris []string
+	for _, block := range blocks {
+		for _, key := range []string{"blob_uri", "media_uri"} {
+			if uri, ok := block[key].(string); ok && uri != "" {
+				uris = append(uris, uri)
+			}
+		}
+	}
+	return uris
+}
@@ -0,0 +1,58 @@
+package entdriver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/systemprompt"
+)
+
+// AddSystemPrompt stores a system prompt unless it is already stored, and
+// returns its hash. The prompt is compressed, and sealed when encryption is
+// enabled.
+func (ed *EntDriver) AddSystemPrompt(ctx context.Context, text string) (string, error) {
+	hash := storage.SystemPromptHash(text)
+
+	exists, err := ed.Client.SystemPrompt.Query().Where(systemprompt.ID(hash)).Exist(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to check existing system prompt: %w", err)
+	}
+	if exists {
+		return hash, nil
+	}
+
+	packed, err := ed.packPayload(hash, []byte(text))
+	if err != nil {
+		return "", err
+	}
+	err = ed.Client.SystemPrompt.Create().
+		SetID(hash).
+		SetText(packed).
+		SetEncrypted(ed.cipher != nil).
+		SetSize(len(text)).
+		Exec(ctx)
+	// A concurrent turn may have stored the same prompt.
+	if err != nil && !ent.IsConstraintError(err) {
+		return "", fmt.Errorf("failed to store system prompt: %w", err)
+	}
+	return hash, nil
+}
+
+// GetSystemPrompt returns the system prompt with the given hash.
+func (ed *EntDriver) GetSystemPrompt(ctx context.Context, hash string) (string, error) {
+	entry, err := ed.Client.SystemPrompt.Get(ctx, hash)
+	if ent.IsNotFound(err) {
+		return "", fmt.Errorf("system prompt %s not found", hash)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get system prompt: %w", err)
+	}
+
+	text, err := ed.unpackPayload(hash, entry.Text, entry.Encrypted)
+	if err != nil {
+		return "", fmt.Errorf("reading system prompt %s: %w", hash, err)
+	}
+	return string(text), nil
+}
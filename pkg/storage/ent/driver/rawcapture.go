@@ -0,0 +1,178 @@
+package entdriver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/rawcapture"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+)
+
+// AddRawCapture stores a raw capture, assigning its ID and CreatedAt. The
+// payloads are compressed, and sealed when encryption is enabled.
+func (ed *EntDriver) AddRawCapture(ctx context.Context, rc *storage.RawCapture) error {
+	if rc == nil {
+		return errors.New("cannot store nil raw capture")
+	}
+
+	request, err := ed.packPayload(rc.NodeHash, rc.Request)
+	if err != nil {
+		return err
+	}
+	create := ed.Client.RawCapture.Create().
+		SetNodeHash(rc.NodeHash).
+		SetProvider(rc.Provider).
+		SetAgentName(rc.AgentName).
+		SetSessionID(rc.SessionID).
+		SetRunID(rc.RunID).
+		SetProject(rc.Project).
+		SetUser(rc.User).
+		SetPath(rc.Path).
+		SetStreamed(rc.Streamed).
+		SetEncrypted(ed.cipher != nil).
+		SetRequest(request)
+	if len(rc.Response) > 0 {
+		response, err := ed.packPayload(rc.NodeHash, rc.Response)
+		if err != nil {
+			return err
+		}
+		create.SetResponse(response)
+	}
+
+	created, err := create.Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create raw capture: %w", err)
+	}
+
+	rc.ID = created.ID
+	rc.CreatedAt = created.CreatedAt
+	return nil
+}
+
+// ListRawCaptures returns the raw captures made at or after since, oldest
+// first. A zero since returns all of them.
+func (ed *EntDriver) ListRawCaptures(ctx context.Context, since time.Time) ([]*storage.RawCapture, error) {
+	query := ed.Client.RawCapture.Query()
+	if !since.IsZero() {
+		query.Where(rawcapture.CreatedAtGTE(since))
+	}
+	entries, err := query.
+		Order(ent.Asc(rawcapture.FieldCreatedAt), ent.Asc(rawcapture.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list raw captures: %w", err)
+	}
+
+	result := make([]*storage.RawCapture, 0, len(entries))
+	for _, entry := range entries {
+		rc := &storage.RawCapture{
+			ID:        entry.ID,
+			NodeHash:  entry.NodeHash,
+			Provider:  entry.Provider,
+			AgentName: entry.AgentName,
+			SessionID: entry.SessionID,
+			RunID:     entry.RunID,
+			Project:   entry.Project,
+			User:      entry.User,
+			Path:      entry.Path,
+			Streamed:  entry.Streamed,
+			CreatedAt: entry.CreatedAt,
+		}
+		if rc.Request, err = ed.unpackPayload(entry.NodeHash, entry.Request, entry.Encrypted); err != nil {
+			return nil, fmt.Errorf("reading raw capture %d: %w", entry.ID, err)
+		}
+		if rc.Response, err = ed.unpackPayload(entry.NodeHash, entry.Response, entry.Encrypted); err != nil {
+			return nil, fmt.Errorf("reading raw capture %d: %w", entry.ID, err)
+		}
+		result = append(result, rc)
+	}
+	return result, nil
+}
+
+// ReconcileRawCapture points a raw capture at its reprocessed turn and marks
+// the nodes it replaced as superseded.
+func (ed *EntDriver) ReconcileRawCapture(ctx context.Context, id int, nodeHash string, superseded map[string]string) error {
+	if err := ed.Client.RawCapture.UpdateOneID(id).SetNodeHash(nodeHash).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update raw capture %d: %w", id, err)
+	}
+
+	for hash, by := range superseded {
+		err := ed.Client.Node.Update().
+			Where(node.ID(hash)).
+			SetSupersededBy(by).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to mark node %s superseded: %w", hash, err)
+		}
+	}
+
+	hashes := slices.Collect(maps.Keys(superseded))
+	err := ed.Client.Session.Update().
+		Where(session.IDIn(hashes...)).
+		AddRevision(1).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to touch superseded sessions: %w", err)
+	}
+	return nil
+}
+
+// packPayload compresses a payload and seals it, bound to the node hash, when
+// encryption is enabled.
+func (ed *EntDriver) packPayload(nodeHash string, payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(payload); err != nil {
+		return nil, fmt.Errorf("compressing payload: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compressing payload: %w", err)
+	}
+
+	if ed.cipher == nil {
+		return buf.Bytes(), nil
+	}
+	sealed, err := ed.cipher.Seal(buf.Bytes(), []byte(nodeHash))
+	if err != nil {
+		return nil, fmt.Errorf("encrypting payload: %w", err)
+	}
+	return []byte(sealed), nil
+}
+
+// unpackPayload reverses packPayload.
+func (ed *EntDriver) unpackPayload(nodeHash string, packed []byte, encrypted bool) ([]byte, error) {
+	if len(packed) == 0 {
+		return nil, nil
+	}
+	if encrypted {
+		if ed.cipher == nil {
+			return nil, errors.New("payload is encrypted and no key is configured")
+		}
+		opened, err := ed.cipher.Open(string(packed), []byte(nodeHash))
+		if err != nil {
+			return nil, fmt.Errorf("decrypting payload: %w", err)
+		}
+		packed = opened
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(packed))
+	if err != nil {
+		return nil, fmt.Errorf("decompressing payload: %w", err)
+	}
+	defer zr.Close()
+	payload, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing payload: %w", err)
+	}
+	return payload, nil
+}
@@ -0,0 +1,25 @@
+package entdriver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+)
+
+// EndSession marks the session rooted at rootHash as ended.
+func (ed *EntDriver) EndSession(ctx context.Context, rootHash string, at time.Time, reason string) error {
+	err := ed.Client.Node.UpdateOneID(rootHash).
+		SetEndedAt(at).
+		SetEndReason(reason).
+		Exec(ctx)
+	if ent.IsNotFound(err) {
+		return storage.NotFoundError{Hash: rootHash}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to end session %s: %w", rootHash, err)
+	}
+	return ed.TouchSessions(ctx, rootHash)
+}
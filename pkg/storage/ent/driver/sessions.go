@@ -0,0 +1,357 @@
+package entdriver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+)
+
+// sessionFields are the node fields that session summaries are built from.
+var sessionFields = []string{
+	node.FieldType, node.FieldModel, node.FieldCreatedAt,
+	node.FieldPromptTokens, node.FieldCompletionTokens, node.FieldTotalTokens,
+	node.FieldCacheCreationInputTokens, node.FieldCacheReadInputTokens,
+}
+
+// SetSessionCost sets how session summaries price a turn from its model and
+// token usage. Without it, summaries are stored with a cost of zero.
+func (ed *EntDriver) SetSessionCost(cost func(model string, usage llm.Usage) float64) {
+	ed.sessionCost = cost
+}
+
+// TouchSessions marks every session of the conversations rooted at roots as
+// changed, for changes made to their roots, such as annotations.
+func (ed *EntDriver) TouchSessions(ctx context.Context, roots ...string) error {
+	if len(roots) == 0 {
+		return nil
+	}
+	err := ed.Client.Session.Update().
+		Where(session.RootHashIn(roots...)).
+		AddRevision(1).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to touch sessions: %w", err)
+	}
+	return nil
+}
+
+// RebuildSessions replaces the session summaries with ones built from every
+// stored node, returning the number of sessions. It fills the table for
+// nodes stored before it existed or by writers that do not maintain it.
+func (ed *EntDriver) RebuildSessions(ctx context.Context) (int, error) {
+	entNodes, err := ed.Client.Node.Query().
+		Where(node.Or(node.TypeIsNil(), node.TypeNotIn(llm.RecordEmbedding, llm.RecordTranscription))).
+		Select(append([]string{node.FieldParentHash}, sessionFields...)...).
+		All(WithoutDecompression(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load nodes: %w", err)
+	}
+
+	byID := make(map[string]*ent.Node, len(entNodes))
+	hasChildren := make(map[string]bool)
+	for _, n := range entNodes {
+		byID[n.ID] = n
+		if n.ParentHash != nil {
+			hasChildren[*n.ParentHash] = true
+		}
+	}
+
+	// Totals are accumulated from the root down once per node, so that
+	// branches share the work of their common prefix.
+	totals := make(map[string]*sessionTotals, len(entNodes))
+	var totalsOf func(n *ent.Node, visiting map[string]bool) *sessionTotals
+	totalsOf = func(n *ent.Node, visiting map[string]bool) *sessionTotals {
+		if t, ok := totals[n.ID]; ok {
+			return t
+		}
+		t := &sessionTotals{root: n.ID}
+		visiting[n.ID] = true
+		if n.ParentHash != nil {
+			if parent, ok := byID[*n.ParentHash]; ok && !visiting[parent.ID] {
+				*t = *totalsOf(parent, visiting)
+			}
+		}
+		t.add(n.Model, entUsage(n), n.CreatedAt, ed.sessionCost)
+		totals[n.ID] = t
+		return t
+	}
+
+	tx, err := ed.Client.Tx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	if _, err := tx.Session.Delete().Exec(ctx); err != nil {
+		return 0, rollback(tx, fmt.Errorf("failed to clear sessions: %w", err))
+	}
+
+	count := 0
+	var batch []*ent.SessionCreate
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := tx.Session.CreateBulk(batch...).Exec(ctx)
+		batch = batch[:0]
+		return err
+	}
+	for _, n := range entNodes {
+		if hasChildren[n.ID] {
+			continue
+		}
+		batch = append(batch, totalsOf(n, map[string]bool{}).create(tx.Client(), n.ID))
+		count++
+		if len(batch) == 500 {
+			if err := flush(); err != nil {
+				return 0, rollback(tx, fmt.Errorf("failed to store sessions: %w", err))
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return 0, rollback(tx, fmt.Errorf("failed to store sessions: %w", err))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit sessions: %w", err)
+	}
+	return count, nil
+}
+
+// BackfillSessions builds the session summaries of a database stored before
+// the table existed, which has conversation turns but no summaries. Tables
+// that have summaries are left to RebuildSessions.
+func (ed *EntDriver) BackfillSessions(ctx context.Context) error {
+	summarized, err := ed.Client.Session.Query().Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query sessions: %w", err)
+	}
+	if summarized {
+		return nil
+	}
+	stored, err := ed.Client.Node.Query().
+		Where(node.Or(node.TypeIsNil(), node.TypeNotIn(llm.RecordEmbedding, llm.RecordTranscription))).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query nodes: %w", err)
+	}
+	if !stored {
+		return nil
+	}
+	_, err = ed.RebuildSessions(ctx)
+	return err
+}
+
+// recordSession updates the session summaries for n, newly stored through
+// client. n becomes the leaf of its conversation, replacing its parent, or
+// starts a new branch when its parent already has children.
+func (ed *EntDriver) recordSession(ctx context.Context, client *ent.Client, n *merkle.Node, createdAt time.Time) error {
+	if n.Bucket.Type == llm.RecordEmbedding || n.Bucket.Type == llm.RecordTranscription {
+		return nil
+	}
+
+	totals := &sessionTotals{root: n.Hash}
+	if n.ParentHash != nil && *n.ParentHash != "" {
+		parent := *n.ParentHash
+		row, err := client.Session.Get(ctx, parent)
+		switch {
+		case err == nil:
+			// The row carries its revision forward, one higher per turn,
+			// so that readers can tell a session that only gained turns
+			// from one that also changed in place.
+			totals = totalsFromRow(row)
+			totals.revision++
+			if err := client.Session.DeleteOneID(parent).Exec(ctx); err != nil {
+				return fmt.Errorf("failed to replace session %s: %w", parent, err)
+			}
+		case ent.IsNotFound(err):
+			totals, err = ed.totalsFromAncestry(ctx, client, parent)
+			if err != nil {
+				return err
+			}
+			if totals.root == "" {
+				// The parent is not stored, so n is the oldest node.
+				totals.root = n.Hash
+			}
+		default:
+			return fmt.Errorf("failed to get session %s: %w", parent, err)
+		}
+
+		// A new branch can change how its siblings read, such as a retry
+		// following a failed request, so readers reload them.
+		siblings, err := client.Node.Query().
+			Where(node.ParentHash(parent), node.IDNEQ(n.Hash)).
+			IDs(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to query siblings: %w", err)
+		}
+		if len(siblings) > 0 {
+			err := client.Session.Update().
+				Where(session.IDIn(siblings...)).
+				AddRevision(1).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to touch sessions: %w", err)
+			}
+		}
+	}
+
+	var usage llm.Usage
+	if n.Usage != nil {
+		usage = *n.Usage
+	}
+	totals.add(n.Bucket.Model, usage, createdAt, ed.sessionCost)
+	if err := totals.create(client, n.Hash).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to store session %s: %w", n.Hash, err)
+	}
+	return nil
+}
+
+// refreshSessions rebuilds the summaries of every session that includes
+// hash, after the node was changed in place.
+func (ed *EntDriver) refreshSessions(ctx context.Context, hash string) error {
+	paths, err := ancestryOf(ctx, ed.Client, []string{hash}, node.FieldCreatedAt)
+	if err != nil {
+		return err
+	}
+	path := paths[hash]
+	if len(path) == 0 {
+		return nil
+	}
+	root := path[len(path)-1].ID
+
+	leaves, err := ed.Client.Session.Query().
+		Where(session.RootHash(root)).
+		IDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query sessions: %w", err)
+	}
+	paths, err = ancestryOf(ctx, ed.Client, leaves, sessionFields...)
+	if err != nil {
+		return err
+	}
+	for leaf, path := range paths {
+		t := ed.totalsFromPath(path)
+		err := ed.Client.Session.UpdateOneID(leaf).
+			SetTurnCount(t.turns).
+			SetPromptTokens(t.promptTokens).
+			SetCompletionTokens(t.completionTokens).
+			SetTotalTokens(t.totalTokens).
+			SetCacheCreationInputTokens(t.cacheCreationTokens).
+			SetCacheReadInputTokens(t.cacheReadTokens).
+			SetCost(t.cost).
+			AddRevision(1).
+			Exec(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			return fmt.Errorf("failed to update session %s: %w", leaf, err)
+		}
+	}
+	return nil
+}
+
+// totalsFromAncestry sums the conversation ending at hash, for a node that
+// branches off it.
+func (ed *EntDriver) totalsFromAncestry(ctx context.Context, client *ent.Client, hash string) (*sessionTotals, error) {
+	paths, err := ancestryOf(ctx, client, []string{hash}, sessionFields...)
+	if err != nil {
+		return nil, err
+	}
+	return ed.totalsFromPath(paths[hash]), nil
+}
+
+// totalsFromPath sums a conversation given leaf first and root last.
+func (ed *EntDriver) totalsFromPath(path []*ent.Node) *sessionTotals {
+	t := &sessionTotals{}
+	for i := len(path) - 1; i >= 0; i-- {
+		n := path[i]
+		if t.root == "" {
+			t.root = n.ID
+		}
+		t.add(n.Model, entUsage(n), n.CreatedAt, ed.sessionCost)
+	}
+	return t
+}
+
+// sessionTotals accumulates the summary of a conversation from its root.
+type sessionTotals struct {
+	root                string
+	turns               int
+	promptTokens        int64
+	completionTokens    int64
+	totalTokens         int64
+	cacheCreationTokens int64
+	cacheReadTokens     int64
+	cost                float64
+	lastActivity        time.Time
+	revision            int64
+}
+
+func totalsFromRow(row *ent.Session) *sessionTotals {
+	return &sessionTotals{
+		root:                row.RootHash,
+		turns:               row.TurnCount,
+		promptTokens:        row.PromptTokens,
+		completionTokens:    row.CompletionTokens,
+		totalTokens:         row.TotalTokens,
+		cacheCreationTokens: row.CacheCreationInputTokens,
+		cacheReadTokens:     row.CacheReadInputTokens,
+		cost:                row.Cost,
+		lastActivity:        row.LastActivity,
+		revision:            row.Revision,
+	}
+}
+
+// add counts one more turn.
+func (t *sessionTotals) add(model string, usage llm.Usage, createdAt time.Time, cost func(string, llm.Usage) float64) {
+	t.turns++
+	t.promptTokens += int64(usage.PromptTokens)
+	t.completionTokens += int64(usage.CompletionTokens)
+	t.totalTokens += int64(usage.TotalTokens)
+	t.cacheCreationTokens += int64(usage.CacheCreationInputTokens)
+	t.cacheReadTokens += int64(usage.CacheReadInputTokens)
+	if cost != nil {
+		t.cost += cost(model, usage)
+	}
+	t.lastActivity = createdAt
+}
+
+// create builds the row for the conversation ending at leaf.
+func (t *sessionTotals) create(client *ent.Client, leaf string) *ent.SessionCreate {
+	return client.Session.Create().
+		SetID(leaf).
+		SetRootHash(t.root).
+		SetTurnCount(t.turns).
+		SetPromptTokens(t.promptTokens).
+		SetCompletionTokens(t.completionTokens).
+		SetTotalTokens(t.totalTokens).
+		SetCacheCreationInputTokens(t.cacheCreationTokens).
+		SetCacheReadInputTokens(t.cacheReadTokens).
+		SetCost(t.cost).
+		SetLastActivity(t.lastActivity).
+		SetRevision(t.revision)
+}
+
+// entUsage returns the token usage stored on n.
+func entUsage(n *ent.Node) llm.Usage {
+	var usage llm.Usage
+	if n.PromptTokens != nil {
+		usage.PromptTokens = *n.PromptTokens
+	}
+	if n.CompletionTokens != nil {
+		usage.CompletionTokens = *n.CompletionTokens
+	}
+	if n.TotalTokens != nil {
+		usage.TotalTokens = *n.TotalTokens
+	}
+	if n.CacheCreationInputTokens != nil {
+		usage.CacheCreationInputTokens = *n.CacheCreationInputTokens
+	}
+	if n.CacheReadInputTokens != nil {
+		usage.CacheReadInputTokens = *n.CacheReadInputTokens
+	}
+	return usage
+}
@@ -0,0 +1,65 @@
+package entdriver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+)
+
+const toolNamesBackfillBatch = 500
+
+// ToolNames formats the tools called in content for the tool_names column:
+// each name wrapped in commas, e.g. ",Read,Bash,", or empty when no tools
+// were called. Names repeat in call order.
+func ToolNames(content []map[string]any) string {
+	var b strings.Builder
+	for _, block := range content {
+		if blockType, _ := block["type"].(string); blockType != "tool_use" {
+			continue
+		}
+		name, _ := block["tool_name"].(string)
+		if name == "" {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(name)
+		b.WriteByte(',')
+	}
+	return b.String()
+}
+
+// BackfillToolNames fills tool_names for nodes stored before the column
+// existed. It reads content through the client, so it must run after block
+// resolution and decryption are enabled.
+func (ed *EntDriver) BackfillToolNames(ctx context.Context) error {
+	last := ""
+	for {
+		page, err := ed.Client.Node.Query().
+			Where(node.IDGT(last), node.ToolNamesIsNil()).
+			Order(ent.Asc(node.FieldID)).
+			Limit(toolNamesBackfillBatch).
+			Select(node.FieldID, node.FieldContent).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load nodes for tool names: %w", err)
+		}
+		if len(page) == 0 {
+			return nil
+		}
+		last = page[len(page)-1].ID
+
+		for _, n := range page {
+			err := ed.Client.Node.UpdateOneID(n.ID).
+				SetToolNames(ToolNames(n.Content)).
+				Exec(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to set tool names for node %s: %w", n.ID, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package entdriver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/sessiontool"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/tooldefinition"
+)
+
+// AddSessionTools stores tool definitions offered in sessions. Each distinct
+// definition is stored once, and linked to each session that offered it.
+// Turns of a session stored concurrently may both add a definition or link,
+// so ones that already exist are skipped rather than failing.
+func (ed *EntDriver) AddSessionTools(ctx context.Context, tools []*storage.SessionTool) error {
+	if len(tools) == 0 {
+		return nil
+	}
+
+	hashes := make([]string, 0, len(tools))
+	sessions := make([]string, 0, len(tools))
+	for _, t := range tools {
+		if t == nil {
+			return errors.New("cannot store nil session tool")
+		}
+		t.Hash = t.Tool.Hash()
+		hashes = append(hashes, t.Hash)
+		sessions = append(sessions, t.SessionID)
+	}
+
+	existing, err := ed.Client.ToolDefinition.Query().Where(tooldefinition.IDIn(hashes...)).IDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check existing tool definitions: %w", err)
+	}
+	stored := make(map[string]bool, len(tools))
+	for _, hash := range existing {
+		stored[hash] = true
+	}
+	for _, t := range tools {
+		if stored[t.Hash] {
+			continue
+		}
+		create := ed.Client.ToolDefinition.Create().
+			SetID(t.Hash).
+			SetType(t.Tool.Type).
+			SetName(t.Tool.Name).
+			SetDescription(t.Tool.Description)
+		if t.Tool.Schema != nil {
+			create.SetSchema(t.Tool.Schema)
+		}
+		if err := create.Exec(ctx); err != nil && !ent.IsConstraintError(err) {
+			return fmt.Errorf("failed to store tool definition %s: %w", t.Tool.Name, err)
+		}
+		stored[t.Hash] = true
+	}
+
+	links, err := ed.Client.SessionTool.Query().
+		Where(sessiontool.SessionIDIn(sessions...), sessiontool.ToolHashIn(hashes...)).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check existing session tools: %w", err)
+	}
+	linked := make(map[[2]string]bool, len(links))
+	for _, link := range links {
+		linked[[2]string{link.SessionID, link.ToolHash}] = true
+	}
+	for _, t := range tools {
+		key := [2]string{t.SessionID, t.Hash}
+		if linked[key] {
+			continue
+		}
+		created, err := ed.Client.SessionTool.Create().
+			SetSessionID(t.SessionID).
+			SetToolHash(t.Hash).
+			SetRunID(t.RunID).
+			SetAgentName(t.AgentName).
+			SetNodeHash(t.NodeHash).
+			Save(ctx)
+		switch {
+		case ent.IsConstraintError(err):
+		case err != nil:
+			return fmt.Errorf("failed to link tool definition %s to session %s: %w", t.Tool.Name, t.SessionID, err)
+		default:
+			t.CreatedAt = created.CreatedAt
+		}
+		linked[key] = true
+	}
+	return nil
+}
+
+// ListSessionTools returns the tool definitions offered in a session, in the
+// order they were first offered.
+func (ed *EntDriver) ListSessionTools(ctx context.Context, sessionID string) ([]*storage.SessionTool, error) {
+	links, err := ed.Client.SessionTool.Query().
+		Where(sessiontool.SessionID(sessionID)).
+		Order(ent.Asc(sessiontool.FieldCreatedAt), ent.Asc(sessiontool.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session tools: %w", err)
+	}
+	if len(links) == 0 {
+		return []*storage.SessionTool{}, nil
+	}
+
+	hashes := make([]string, 0, len(links))
+	for _, link := range links {
+		hashes = append(hashes, link.ToolHash)
+	}
+	defs, err := ed.Client.ToolDefinition.Query().Where(tooldefinition.IDIn(hashes...)).All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tool definitions: %w", err)
+	}
+	byHash := make(map[string]*ent.ToolDefinition, len(defs))
+	for _, def := range defs {
+		byHash[def.ID] = def
+	}
+
+	result := make([]*storage.SessionTool, 0, len(links))
+	for _, link := range links {
+		def, ok := byHash[link.ToolHash]
+		if !ok {
+			return nil, fmt.Errorf("tool definition %s of session %s not found", link.ToolHash, sessionID)
+		}
+		result = append(result, &storage.SessionTool{
+			Hash:      link.ToolHash,
+			SessionID: link.SessionID,
+			RunID:     link.RunID,
+			AgentName: link.AgentName,
+			NodeHash:  link.NodeHash,
+			Tool: llm.ToolDefinition{
+				Type:        def.Type,
+				Name:        def.Name,
+				Description: def.Description,
+				Schema:      def.Schema,
+			},
+			CreatedAt: link.CreatedAt,
+		})
+	}
+	return result, nil
+}
@@ -12,8 +12,15 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/block"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/deadletter"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/facet"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/rawcapture"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/sessiontool"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/systemprompt"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/tooldefinition"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -74,8 +81,15 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			facet.Table: facet.ValidColumn,
-			node.Table:  node.ValidColumn,
+			block.Table:          block.ValidColumn,
+			deadletter.Table:     deadletter.ValidColumn,
+			facet.Table:          facet.ValidColumn,
+			node.Table:           node.ValidColumn,
+			rawcapture.Table:     rawcapture.ValidColumn,
+			session.Table:        session.ValidColumn,
+			sessiontool.Table:    sessiontool.ValidColumn,
+			systemprompt.Table:   systemprompt.ValidColumn,
+			tooldefinition.Table: tooldefinition.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -9,6 +9,30 @@ import (
 	"github.com/papercomputeco/tapes/pkg/storage/ent"
 )
 
+// The BlockFunc type is an adapter to allow the use of ordinary
+// function as Block mutator.
+type BlockFunc func(context.Context, *ent.BlockMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f BlockFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.BlockMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.BlockMutation", m)
+}
+
+// The DeadLetterFunc type is an adapter to allow the use of ordinary
+// function as DeadLetter mutator.
+type DeadLetterFunc func(context.Context, *ent.DeadLetterMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f DeadLetterFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.DeadLetterMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.DeadLetterMutation", m)
+}
+
 // The FacetFunc type is an adapter to allow the use of ordinary
 // function as Facet mutator.
 type FacetFunc func(context.Context, *ent.FacetMutation) (ent.Value, error)
@@ -33,6 +57,66 @@ func (f NodeFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error)
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.NodeMutation", m)
 }
 
+// The RawCaptureFunc type is an adapter to allow the use of ordinary
+// function as RawCapture mutator.
+type RawCaptureFunc func(context.Context, *ent.RawCaptureMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f RawCaptureFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.RawCaptureMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.RawCaptureMutation", m)
+}
+
+// The SessionFunc type is an adapter to allow the use of ordinary
+// function as Session mutator.
+type SessionFunc func(context.Context, *ent.SessionMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f SessionFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.SessionMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.SessionMutation", m)
+}
+
+// The SessionToolFunc type is an adapter to allow the use of ordinary
+// function as SessionTool mutator.
+type SessionToolFunc func(context.Context, *ent.SessionToolMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f SessionToolFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.SessionToolMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.SessionToolMutation", m)
+}
+
+// The SystemPromptFunc type is an adapter to allow the use of ordinary
+// function as SystemPrompt mutator.
+type SystemPromptFunc func(context.Context, *ent.SystemPromptMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f SystemPromptFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.SystemPromptMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.SystemPromptMutation", m)
+}
+
+// The ToolDefinitionFunc type is an adapter to allow the use of ordinary
+// function as ToolDefinition mutator.
+type ToolDefinitionFunc func(context.Context, *ent.ToolDefinitionMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f ToolDefinitionFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.ToolDefinitionMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.ToolDefinitionMutation", m)
+}
+
 // Condition is a hook condition function.
 type Condition func(context.Context, ent.Mutation) bool
 
@@ -8,6 +8,46 @@ import (
 )
 
 var (
+	// BlocksColumns holds the columns for the "blocks" table.
+	BlocksColumns = []*schema.Column{
+		{Name: "hash", Type: field.TypeString, Unique: true},
+		{Name: "data", Type: field.TypeJSON},
+		{Name: "size", Type: field.TypeInt},
+		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+	}
+	// BlocksTable holds the schema information for the "blocks" table.
+	BlocksTable = &schema.Table{
+		Name:       "blocks",
+		Columns:    BlocksColumns,
+		PrimaryKey: []*schema.Column{BlocksColumns[0]},
+	}
+	// DeadLettersColumns holds the columns for the "dead_letters" table.
+	DeadLettersColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "stage", Type: field.TypeString},
+		{Name: "provider", Type: field.TypeString, Nullable: true},
+		{Name: "agent_name", Type: field.TypeString, Nullable: true},
+		{Name: "path", Type: field.TypeString, Nullable: true},
+		{Name: "headers", Type: field.TypeJSON, Nullable: true},
+		{Name: "request", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "response", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "encrypted", Type: field.TypeBool, Default: false},
+		{Name: "error", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+	}
+	// DeadLettersTable holds the schema information for the "dead_letters" table.
+	DeadLettersTable = &schema.Table{
+		Name:       "dead_letters",
+		Columns:    DeadLettersColumns,
+		PrimaryKey: []*schema.Column{DeadLettersColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "deadletter_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{DeadLettersColumns[10]},
+			},
+		},
+	}
 	// FacetsColumns holds the columns for the "facets" table.
 	FacetsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeString, Unique: true},
@@ -38,15 +78,44 @@ var (
 		{Name: "model", Type: field.TypeString, Nullable: true},
 		{Name: "provider", Type: field.TypeString, Nullable: true},
 		{Name: "agent_name", Type: field.TypeString, Nullable: true},
+		{Name: "session_id", Type: field.TypeString, Nullable: true},
 		{Name: "stop_reason", Type: field.TypeString, Nullable: true},
+		{Name: "tool_names", Type: field.TypeString, Nullable: true},
 		{Name: "prompt_tokens", Type: field.TypeInt, Nullable: true},
 		{Name: "completion_tokens", Type: field.TypeInt, Nullable: true},
 		{Name: "total_tokens", Type: field.TypeInt, Nullable: true},
 		{Name: "cache_creation_input_tokens", Type: field.TypeInt, Nullable: true},
 		{Name: "cache_read_input_tokens", Type: field.TypeInt, Nullable: true},
+		{Name: "reasoning_tokens", Type: field.TypeInt, Nullable: true},
 		{Name: "total_duration_ns", Type: field.TypeInt64, Nullable: true},
 		{Name: "prompt_duration_ns", Type: field.TypeInt64, Nullable: true},
+		{Name: "usage_estimated", Type: field.TypeBool, Nullable: true},
+		{Name: "request_started_at", Type: field.TypeTime, Nullable: true},
+		{Name: "first_chunk_at", Type: field.TypeTime, Nullable: true},
+		{Name: "response_completed_at", Type: field.TypeTime, Nullable: true},
+		{Name: "error_status", Type: field.TypeInt, Nullable: true},
+		{Name: "error_type", Type: field.TypeString, Nullable: true},
+		{Name: "error_message", Type: field.TypeString, Nullable: true},
+		{Name: "retry_after_seconds", Type: field.TypeInt, Nullable: true},
+		{Name: "error_attempt", Type: field.TypeInt, Nullable: true},
 		{Name: "project", Type: field.TypeString, Nullable: true},
+		{Name: "user", Type: field.TypeString, Nullable: true},
+		{Name: "trace_id", Type: field.TypeString, Nullable: true},
+		{Name: "run_id", Type: field.TypeString, Nullable: true},
+		{Name: "agent_version", Type: field.TypeString, Nullable: true},
+		{Name: "system_prompt_hash", Type: field.TypeString, Nullable: true},
+		{Name: "policy_violations", Type: field.TypeJSON, Nullable: true},
+		{Name: "signer_key", Type: field.TypeBytes, Nullable: true},
+		{Name: "signature", Type: field.TypeBytes, Nullable: true},
+		{Name: "shared_by", Type: field.TypeString, Nullable: true},
+		{Name: "superseded_by", Type: field.TypeString, Nullable: true},
+		{Name: "title", Type: field.TypeString, Nullable: true},
+		{Name: "summary", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "outcome", Type: field.TypeString, Nullable: true},
+		{Name: "summarized_at", Type: field.TypeTime, Nullable: true},
+		{Name: "ended_at", Type: field.TypeTime, Nullable: true},
+		{Name: "end_reason", Type: field.TypeString, Nullable: true},
+		{Name: "turn_index", Type: field.TypeInt, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
 		{Name: "parent_hash", Type: field.TypeString, Nullable: true},
 	}
@@ -58,7 +127,7 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "nodes_nodes_parent",
-				Columns:    []*schema.Column{NodesColumns[18]},
+				Columns:    []*schema.Column{NodesColumns[47]},
 				RefColumns: []*schema.Column{NodesColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
@@ -67,7 +136,7 @@ var (
 			{
 				Name:    "node_parent_hash",
 				Unique:  false,
-				Columns: []*schema.Column{NodesColumns[18]},
+				Columns: []*schema.Column{NodesColumns[47]},
 			},
 			{
 				Name:    "node_role",
@@ -97,14 +166,165 @@ var (
 			{
 				Name:    "node_project",
 				Unique:  false,
-				Columns: []*schema.Column{NodesColumns[16]},
+				Columns: []*schema.Column{NodesColumns[28]},
+			},
+			{
+				Name:    "node_user",
+				Unique:  false,
+				Columns: []*schema.Column{NodesColumns[29]},
+			},
+			{
+				Name:    "node_trace_id",
+				Unique:  false,
+				Columns: []*schema.Column{NodesColumns[30]},
+			},
+			{
+				Name:    "node_run_id",
+				Unique:  false,
+				Columns: []*schema.Column{NodesColumns[31]},
+			},
+			{
+				Name:    "node_system_prompt_hash",
+				Unique:  false,
+				Columns: []*schema.Column{NodesColumns[33]},
 			},
 		},
 	}
+	// RawCapturesColumns holds the columns for the "raw_captures" table.
+	RawCapturesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "node_hash", Type: field.TypeString},
+		{Name: "provider", Type: field.TypeString},
+		{Name: "agent_name", Type: field.TypeString, Nullable: true},
+		{Name: "session_id", Type: field.TypeString, Nullable: true},
+		{Name: "run_id", Type: field.TypeString, Nullable: true},
+		{Name: "project", Type: field.TypeString, Nullable: true},
+		{Name: "user", Type: field.TypeString, Nullable: true},
+		{Name: "path", Type: field.TypeString, Nullable: true},
+		{Name: "streamed", Type: field.TypeBool, Default: false},
+		{Name: "encrypted", Type: field.TypeBool, Default: false},
+		{Name: "request", Type: field.TypeBytes},
+		{Name: "response", Type: field.TypeBytes, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+	}
+	// RawCapturesTable holds the schema information for the "raw_captures" table.
+	RawCapturesTable = &schema.Table{
+		Name:       "raw_captures",
+		Columns:    RawCapturesColumns,
+		PrimaryKey: []*schema.Column{RawCapturesColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "rawcapture_node_hash",
+				Unique:  false,
+				Columns: []*schema.Column{RawCapturesColumns[1]},
+			},
+			{
+				Name:    "rawcapture_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{RawCapturesColumns[13]},
+			},
+		},
+	}
+	// SessionsColumns holds the columns for the "sessions" table.
+	SessionsColumns = []*schema.Column{
+		{Name: "leaf_hash", Type: field.TypeString, Unique: true},
+		{Name: "root_hash", Type: field.TypeString},
+		{Name: "turn_count", Type: field.TypeInt, Default: 0},
+		{Name: "prompt_tokens", Type: field.TypeInt64, Default: 0},
+		{Name: "completion_tokens", Type: field.TypeInt64, Default: 0},
+		{Name: "total_tokens", Type: field.TypeInt64, Default: 0},
+		{Name: "cache_creation_input_tokens", Type: field.TypeInt64, Default: 0},
+		{Name: "cache_read_input_tokens", Type: field.TypeInt64, Default: 0},
+		{Name: "cost", Type: field.TypeFloat64, Default: 0},
+		{Name: "last_activity", Type: field.TypeTime},
+		{Name: "revision", Type: field.TypeInt64, Default: 0},
+	}
+	// SessionsTable holds the schema information for the "sessions" table.
+	SessionsTable = &schema.Table{
+		Name:       "sessions",
+		Columns:    SessionsColumns,
+		PrimaryKey: []*schema.Column{SessionsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "session_root_hash",
+				Unique:  false,
+				Columns: []*schema.Column{SessionsColumns[1]},
+			},
+			{
+				Name:    "session_last_activity",
+				Unique:  false,
+				Columns: []*schema.Column{SessionsColumns[9]},
+			},
+		},
+	}
+	// SessionToolsColumns holds the columns for the "session_tools" table.
+	SessionToolsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "session_id", Type: field.TypeString},
+		{Name: "tool_hash", Type: field.TypeString},
+		{Name: "run_id", Type: field.TypeString, Nullable: true},
+		{Name: "agent_name", Type: field.TypeString, Nullable: true},
+		{Name: "node_hash", Type: field.TypeString},
+		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+	}
+	// SessionToolsTable holds the schema information for the "session_tools" table.
+	SessionToolsTable = &schema.Table{
+		Name:       "session_tools",
+		Columns:    SessionToolsColumns,
+		PrimaryKey: []*schema.Column{SessionToolsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "sessiontool_session_id_tool_hash",
+				Unique:  true,
+				Columns: []*schema.Column{SessionToolsColumns[1], SessionToolsColumns[2]},
+			},
+			{
+				Name:    "sessiontool_tool_hash",
+				Unique:  false,
+				Columns: []*schema.Column{SessionToolsColumns[2]},
+			},
+		},
+	}
+	// SystemPromptsColumns holds the columns for the "system_prompts" table.
+	SystemPromptsColumns = []*schema.Column{
+		{Name: "hash", Type: field.TypeString, Unique: true},
+		{Name: "text", Type: field.TypeBytes},
+		{Name: "encrypted", Type: field.TypeBool, Default: false},
+		{Name: "size", Type: field.TypeInt},
+		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+	}
+	// SystemPromptsTable holds the schema information for the "system_prompts" table.
+	SystemPromptsTable = &schema.Table{
+		Name:       "system_prompts",
+		Columns:    SystemPromptsColumns,
+		PrimaryKey: []*schema.Column{SystemPromptsColumns[0]},
+	}
+	// ToolDefinitionsColumns holds the columns for the "tool_definitions" table.
+	ToolDefinitionsColumns = []*schema.Column{
+		{Name: "hash", Type: field.TypeString, Unique: true},
+		{Name: "type", Type: field.TypeString, Nullable: true},
+		{Name: "name", Type: field.TypeString},
+		{Name: "description", Type: field.TypeString, Nullable: true},
+		{Name: "schema", Type: field.TypeJSON, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime, Default: "CURRENT_TIMESTAMP"},
+	}
+	// ToolDefinitionsTable holds the schema information for the "tool_definitions" table.
+	ToolDefinitionsTable = &schema.Table{
+		Name:       "tool_definitions",
+		Columns:    ToolDefinitionsColumns,
+		PrimaryKey: []*schema.Column{ToolDefinitionsColumns[0]},
+	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		BlocksTable,
+		DeadLettersTable,
 		FacetsTable,
 		NodesTable,
+		RawCapturesTable,
+		SessionsTable,
+		SessionToolsTable,
+		SystemPromptsTable,
+		ToolDefinitionsTable,
 	}
 )
 
@@ -11,9 +11,17 @@ import (
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/block"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/deadletter"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/facet"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/rawcapture"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/sessiontool"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/systemprompt"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/tooldefinition"
 )
 
 const (
@@ -25,36 +33,44 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypeFacet = "Facet"
-	TypeNode  = "Node"
+	TypeBlock          = "Block"
+	TypeDeadLetter     = "DeadLetter"
+	TypeFacet          = "Facet"
+	TypeNode           = "Node"
+	TypeRawCapture     = "RawCapture"
+	TypeSession        = "Session"
+	TypeSessionTool    = "SessionTool"
+	TypeSystemPrompt   = "SystemPrompt"
+	TypeToolDefinition = "ToolDefinition"
 )
 
-// FacetMutation represents an operation that mutates the Facet nodes in the graph.
-type FacetMutation struct {
+// BlockMutation represents an operation that mutates the Block nodes in the graph.
+type BlockMutation struct {
 	config
 	op            Op
 	typ           string
 	id            *string
-	session_id    *string
-	facets        *map[string]interface{}
+	data          *map[string]interface{}
+	size          *int
+	addsize       *int
 	created_at    *time.Time
 	clearedFields map[string]struct{}
 	done          bool
-	oldValue      func(context.Context) (*Facet, error)
-	predicates    []predicate.Facet
+	oldValue      func(context.Context) (*Block, error)
+	predicates    []predicate.Block
 }
 
-var _ ent.Mutation = (*FacetMutation)(nil)
+var _ ent.Mutation = (*BlockMutation)(nil)
 
-// facetOption allows management of the mutation configuration using functional options.
-type facetOption func(*FacetMutation)
+// blockOption allows management of the mutation configuration using functional options.
+type blockOption func(*BlockMutation)
 
-// newFacetMutation creates new mutation for the Facet entity.
-func newFacetMutation(c config, op Op, opts ...facetOption) *FacetMutation {
-	m := &FacetMutation{
+// newBlockMutation creates new mutation for the Block entity.
+func newBlockMutation(c config, op Op, opts ...blockOption) *BlockMutation {
+	m := &BlockMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeFacet,
+		typ:           TypeBlock,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -63,20 +79,20 @@ func newFacetMutation(c config, op Op, opts ...facetOption) *FacetMutation {
 	return m
 }
 
-// withFacetID sets the ID field of the mutation.
-func withFacetID(id string) facetOption {
-	return func(m *FacetMutation) {
+// withBlockID sets the ID field of the mutation.
+func withBlockID(id string) blockOption {
+	return func(m *BlockMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Facet
+			value *Block
 		)
-		m.oldValue = func(ctx context.Context) (*Facet, error) {
+		m.oldValue = func(ctx context.Context) (*Block, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Facet.Get(ctx, id)
+					value, err = m.Client().Block.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -85,10 +101,10 @@ func withFacetID(id string) facetOption {
 	}
 }
 
-// withFacet sets the old Facet of the mutation.
-func withFacet(node *Facet) facetOption {
-	return func(m *FacetMutation) {
-		m.oldValue = func(context.Context) (*Facet, error) {
+// withBlock sets the old Block of the mutation.
+func withBlock(node *Block) blockOption {
+	return func(m *BlockMutation) {
+		m.oldValue = func(context.Context) (*Block, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -97,7 +113,7 @@ func withFacet(node *Facet) facetOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m FacetMutation) Client() *Client {
+func (m BlockMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -105,7 +121,7 @@ func (m FacetMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m FacetMutation) Tx() (*Tx, error) {
+func (m BlockMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -115,14 +131,14 @@ func (m FacetMutation) Tx() (*Tx, error) {
 }
 
 // SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Facet entities.
-func (m *FacetMutation) SetID(id string) {
+// operation is only accepted on creation of Block entities.
+func (m *BlockMutation) SetID(id string) {
 	m.id = &id
 }
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *FacetMutation) ID() (id string, exists bool) {
+func (m *BlockMutation) ID() (id string, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -133,7 +149,7 @@ func (m *FacetMutation) ID() (id string, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *FacetMutation) IDs(ctx context.Context) ([]string, error) {
+func (m *BlockMutation) IDs(ctx context.Context) ([]string, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -142,104 +158,111 @@ func (m *FacetMutation) IDs(ctx context.Context) ([]string, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Facet.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Block.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetSessionID sets the "session_id" field.
-func (m *FacetMutation) SetSessionID(s string) {
-	m.session_id = &s
+// SetData sets the "data" field.
+func (m *BlockMutation) SetData(value map[string]interface{}) {
+	m.data = &value
 }
 
-// SessionID returns the value of the "session_id" field in the mutation.
-func (m *FacetMutation) SessionID() (r string, exists bool) {
-	v := m.session_id
+// Data returns the value of the "data" field in the mutation.
+func (m *BlockMutation) Data() (r map[string]interface{}, exists bool) {
+	v := m.data
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldSessionID returns the old "session_id" field's value of the Facet entity.
-// If the Facet object wasn't provided to the builder, the object is fetched from the database.
+// OldData returns the old "data" field's value of the Block entity.
+// If the Block object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *FacetMutation) OldSessionID(ctx context.Context) (v string, err error) {
+func (m *BlockMutation) OldData(ctx context.Context) (v map[string]interface{}, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
+		return v, errors.New("OldData is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldSessionID requires an ID field in the mutation")
+		return v, errors.New("OldData requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
+		return v, fmt.Errorf("querying old value for OldData: %w", err)
 	}
-	return oldValue.SessionID, nil
+	return oldValue.Data, nil
 }
 
-// ResetSessionID resets all changes to the "session_id" field.
-func (m *FacetMutation) ResetSessionID() {
-	m.session_id = nil
+// ResetData resets all changes to the "data" field.
+func (m *BlockMutation) ResetData() {
+	m.data = nil
 }
 
-// SetFacets sets the "facets" field.
-func (m *FacetMutation) SetFacets(value map[string]interface{}) {
-	m.facets = &value
+// SetSize sets the "size" field.
+func (m *BlockMutation) SetSize(i int) {
+	m.size = &i
+	m.addsize = nil
 }
 
-// Facets returns the value of the "facets" field in the mutation.
-func (m *FacetMutation) Facets() (r map[string]interface{}, exists bool) {
-	v := m.facets
+// Size returns the value of the "size" field in the mutation.
+func (m *BlockMutation) Size() (r int, exists bool) {
+	v := m.size
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldFacets returns the old "facets" field's value of the Facet entity.
-// If the Facet object wasn't provided to the builder, the object is fetched from the database.
+// OldSize returns the old "size" field's value of the Block entity.
+// If the Block object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *FacetMutation) OldFacets(ctx context.Context) (v map[string]interface{}, err error) {
+func (m *BlockMutation) OldSize(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldFacets is only allowed on UpdateOne operations")
+		return v, errors.New("OldSize is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldFacets requires an ID field in the mutation")
+		return v, errors.New("OldSize requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldFacets: %w", err)
+		return v, fmt.Errorf("querying old value for OldSize: %w", err)
 	}
-	return oldValue.Facets, nil
+	return oldValue.Size, nil
 }
 
-// ClearFacets clears the value of the "facets" field.
-func (m *FacetMutation) ClearFacets() {
-	m.facets = nil
-	m.clearedFields[facet.FieldFacets] = struct{}{}
+// AddSize adds i to the "size" field.
+func (m *BlockMutation) AddSize(i int) {
+	if m.addsize != nil {
+		*m.addsize += i
+	} else {
+		m.addsize = &i
+	}
 }
 
-// FacetsCleared returns if the "facets" field was cleared in this mutation.
-func (m *FacetMutation) FacetsCleared() bool {
-	_, ok := m.clearedFields[facet.FieldFacets]
-	return ok
+// AddedSize returns the value that was added to the "size" field in this mutation.
+func (m *BlockMutation) AddedSize() (r int, exists bool) {
+	v := m.addsize
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ResetFacets resets all changes to the "facets" field.
-func (m *FacetMutation) ResetFacets() {
-	m.facets = nil
-	delete(m.clearedFields, facet.FieldFacets)
+// ResetSize resets all changes to the "size" field.
+func (m *BlockMutation) ResetSize() {
+	m.size = nil
+	m.addsize = nil
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *FacetMutation) SetCreatedAt(t time.Time) {
+func (m *BlockMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *FacetMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *BlockMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -247,10 +270,10 @@ func (m *FacetMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Facet entity.
-// If the Facet object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the Block entity.
+// If the Block object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *FacetMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *BlockMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -265,19 +288,19 @@ func (m *FacetMutation) OldCreatedAt(ctx context.Context) (v time.Time, err erro
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *FacetMutation) ResetCreatedAt() {
+func (m *BlockMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
-// Where appends a list predicates to the FacetMutation builder.
-func (m *FacetMutation) Where(ps ...predicate.Facet) {
+// Where appends a list predicates to the BlockMutation builder.
+func (m *BlockMutation) Where(ps ...predicate.Block) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the FacetMutation builder. Using this method,
+// WhereP appends storage-level predicates to the BlockMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *FacetMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Facet, len(ps))
+func (m *BlockMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Block, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -285,33 +308,33 @@ func (m *FacetMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *FacetMutation) Op() Op {
+func (m *BlockMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *FacetMutation) SetOp(op Op) {
+func (m *BlockMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Facet).
-func (m *FacetMutation) Type() string {
+// Type returns the node type of this mutation (Block).
+func (m *BlockMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *FacetMutation) Fields() []string {
+func (m *BlockMutation) Fields() []string {
 	fields := make([]string, 0, 3)
-	if m.session_id != nil {
-		fields = append(fields, facet.FieldSessionID)
+	if m.data != nil {
+		fields = append(fields, block.FieldData)
 	}
-	if m.facets != nil {
-		fields = append(fields, facet.FieldFacets)
+	if m.size != nil {
+		fields = append(fields, block.FieldSize)
 	}
 	if m.created_at != nil {
-		fields = append(fields, facet.FieldCreatedAt)
+		fields = append(fields, block.FieldCreatedAt)
 	}
 	return fields
 }
@@ -319,13 +342,13 @@ func (m *FacetMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *FacetMutation) Field(name string) (ent.Value, bool) {
+func (m *BlockMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case facet.FieldSessionID:
-		return m.SessionID()
-	case facet.FieldFacets:
-		return m.Facets()
-	case facet.FieldCreatedAt:
+	case block.FieldData:
+		return m.Data()
+	case block.FieldSize:
+		return m.Size()
+	case block.FieldCreatedAt:
 		return m.CreatedAt()
 	}
 	return nil, false
@@ -334,38 +357,38 @@ func (m *FacetMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *FacetMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *BlockMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case facet.FieldSessionID:
-		return m.OldSessionID(ctx)
-	case facet.FieldFacets:
-		return m.OldFacets(ctx)
-	case facet.FieldCreatedAt:
+	case block.FieldData:
+		return m.OldData(ctx)
+	case block.FieldSize:
+		return m.OldSize(ctx)
+	case block.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown Facet field %s", name)
+	return nil, fmt.Errorf("unknown Block field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *FacetMutation) SetField(name string, value ent.Value) error {
+func (m *BlockMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case facet.FieldSessionID:
-		v, ok := value.(string)
+	case block.FieldData:
+		v, ok := value.(map[string]interface{})
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetSessionID(v)
+		m.SetData(v)
 		return nil
-	case facet.FieldFacets:
-		v, ok := value.(map[string]interface{})
+	case block.FieldSize:
+		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetFacets(v)
+		m.SetSize(v)
 		return nil
-	case facet.FieldCreatedAt:
+	case block.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
@@ -373,177 +396,163 @@ func (m *FacetMutation) SetField(name string, value ent.Value) error {
 		m.SetCreatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Facet field %s", name)
+	return fmt.Errorf("unknown Block field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *FacetMutation) AddedFields() []string {
-	return nil
+func (m *BlockMutation) AddedFields() []string {
+	var fields []string
+	if m.addsize != nil {
+		fields = append(fields, block.FieldSize)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *FacetMutation) AddedField(name string) (ent.Value, bool) {
+func (m *BlockMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case block.FieldSize:
+		return m.AddedSize()
+	}
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *FacetMutation) AddField(name string, value ent.Value) error {
+func (m *BlockMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case block.FieldSize:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddSize(v)
+		return nil
 	}
-	return fmt.Errorf("unknown Facet numeric field %s", name)
+	return fmt.Errorf("unknown Block numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *FacetMutation) ClearedFields() []string {
-	var fields []string
-	if m.FieldCleared(facet.FieldFacets) {
-		fields = append(fields, facet.FieldFacets)
-	}
-	return fields
+func (m *BlockMutation) ClearedFields() []string {
+	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *FacetMutation) FieldCleared(name string) bool {
+func (m *BlockMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *FacetMutation) ClearField(name string) error {
-	switch name {
-	case facet.FieldFacets:
-		m.ClearFacets()
-		return nil
-	}
-	return fmt.Errorf("unknown Facet nullable field %s", name)
+func (m *BlockMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Block nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *FacetMutation) ResetField(name string) error {
+func (m *BlockMutation) ResetField(name string) error {
 	switch name {
-	case facet.FieldSessionID:
-		m.ResetSessionID()
+	case block.FieldData:
+		m.ResetData()
 		return nil
-	case facet.FieldFacets:
-		m.ResetFacets()
+	case block.FieldSize:
+		m.ResetSize()
 		return nil
-	case facet.FieldCreatedAt:
+	case block.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Facet field %s", name)
+	return fmt.Errorf("unknown Block field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *FacetMutation) AddedEdges() []string {
+func (m *BlockMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *FacetMutation) AddedIDs(name string) []ent.Value {
+func (m *BlockMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *FacetMutation) RemovedEdges() []string {
+func (m *BlockMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *FacetMutation) RemovedIDs(name string) []ent.Value {
+func (m *BlockMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *FacetMutation) ClearedEdges() []string {
+func (m *BlockMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *FacetMutation) EdgeCleared(name string) bool {
+func (m *BlockMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *FacetMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown Facet unique edge %s", name)
+func (m *BlockMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Block unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *FacetMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown Facet edge %s", name)
+func (m *BlockMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Block edge %s", name)
 }
 
-// NodeMutation represents an operation that mutates the Node nodes in the graph.
-type NodeMutation struct {
+// DeadLetterMutation represents an operation that mutates the DeadLetter nodes in the graph.
+type DeadLetterMutation struct {
 	config
-	op                             Op
-	typ                            string
-	id                             *string
-	bucket                         *map[string]interface{}
-	_type                          *string
-	role                           *string
-	content                        *[]map[string]interface{}
-	appendcontent                  []map[string]interface{}
-	model                          *string
-	provider                       *string
-	agent_name                     *string
-	stop_reason                    *string
-	prompt_tokens                  *int
-	addprompt_tokens               *int
-	completion_tokens              *int
-	addcompletion_tokens           *int
-	total_tokens                   *int
-	addtotal_tokens                *int
-	cache_creation_input_tokens    *int
-	addcache_creation_input_tokens *int
-	cache_read_input_tokens        *int
-	addcache_read_input_tokens     *int
-	total_duration_ns              *int64
-	addtotal_duration_ns           *int64
-	prompt_duration_ns             *int64
-	addprompt_duration_ns          *int64
-	project                        *string
-	created_at                     *time.Time
-	clearedFields                  map[string]struct{}
-	parent                         *string
-	clearedparent                  bool
-	children                       map[string]struct{}
-	removedchildren                map[string]struct{}
-	clearedchildren                bool
-	done                           bool
-	oldValue                       func(context.Context) (*Node, error)
-	predicates                     []predicate.Node
+	op            Op
+	typ           string
+	id            *int
+	stage         *string
+	provider      *string
+	agent_name    *string
+	_path         *string
+	headers       *map[string]string
+	request       *string
+	response      *string
+	encrypted     *bool
+	error         *string
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*DeadLetter, error)
+	predicates    []predicate.DeadLetter
 }
 
-var _ ent.Mutation = (*NodeMutation)(nil)
+var _ ent.Mutation = (*DeadLetterMutation)(nil)
 
-// nodeOption allows management of the mutation configuration using functional options.
-type nodeOption func(*NodeMutation)
+// deadletterOption allows management of the mutation configuration using functional options.
+type deadletterOption func(*DeadLetterMutation)
 
-// newNodeMutation creates new mutation for the Node entity.
-func newNodeMutation(c config, op Op, opts ...nodeOption) *NodeMutation {
-	m := &NodeMutation{
+// newDeadLetterMutation creates new mutation for the DeadLetter entity.
+func newDeadLetterMutation(c config, op Op, opts ...deadletterOption) *DeadLetterMutation {
+	m := &DeadLetterMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeNode,
+		typ:           TypeDeadLetter,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -552,20 +561,20 @@ func newNodeMutation(c config, op Op, opts ...nodeOption) *NodeMutation {
 	return m
 }
 
-// withNodeID sets the ID field of the mutation.
-func withNodeID(id string) nodeOption {
-	return func(m *NodeMutation) {
+// withDeadLetterID sets the ID field of the mutation.
+func withDeadLetterID(id int) deadletterOption {
+	return func(m *DeadLetterMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Node
+			value *DeadLetter
 		)
-		m.oldValue = func(ctx context.Context) (*Node, error) {
+		m.oldValue = func(ctx context.Context) (*DeadLetter, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Node.Get(ctx, id)
+					value, err = m.Client().DeadLetter.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -574,10 +583,10 @@ func withNodeID(id string) nodeOption {
 	}
 }
 
-// withNode sets the old Node of the mutation.
-func withNode(node *Node) nodeOption {
-	return func(m *NodeMutation) {
-		m.oldValue = func(context.Context) (*Node, error) {
+// withDeadLetter sets the old DeadLetter of the mutation.
+func withDeadLetter(node *DeadLetter) deadletterOption {
+	return func(m *DeadLetterMutation) {
+		m.oldValue = func(context.Context) (*DeadLetter, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -586,7 +595,7 @@ func withNode(node *Node) nodeOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m NodeMutation) Client() *Client {
+func (m DeadLetterMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -594,7 +603,7 @@ func (m NodeMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m NodeMutation) Tx() (*Tx, error) {
+func (m DeadLetterMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -603,15 +612,9 @@ func (m NodeMutation) Tx() (*Tx, error) {
 	return tx, nil
 }
 
-// SetID sets the value of the id field. Note that this
-// operation is only accepted on creation of Node entities.
-func (m *NodeMutation) SetID(id string) {
-	m.id = &id
-}
-
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *NodeMutation) ID() (id string, exists bool) {
+func (m *DeadLetterMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -622,1857 +625,9567 @@ func (m *NodeMutation) ID() (id string, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *NodeMutation) IDs(ctx context.Context) ([]string, error) {
+func (m *DeadLetterMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
 		if exists {
-			return []string{id}, nil
+			return []int{id}, nil
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Node.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().DeadLetter.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetParentHash sets the "parent_hash" field.
-func (m *NodeMutation) SetParentHash(s string) {
-	m.parent = &s
+// SetStage sets the "stage" field.
+func (m *DeadLetterMutation) SetStage(s string) {
+	m.stage = &s
 }
 
-// ParentHash returns the value of the "parent_hash" field in the mutation.
-func (m *NodeMutation) ParentHash() (r string, exists bool) {
-	v := m.parent
+// Stage returns the value of the "stage" field in the mutation.
+func (m *DeadLetterMutation) Stage() (r string, exists bool) {
+	v := m.stage
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldParentHash returns the old "parent_hash" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldStage returns the old "stage" field's value of the DeadLetter entity.
+// If the DeadLetter object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldParentHash(ctx context.Context) (v *string, err error) {
+func (m *DeadLetterMutation) OldStage(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldParentHash is only allowed on UpdateOne operations")
+		return v, errors.New("OldStage is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldParentHash requires an ID field in the mutation")
+		return v, errors.New("OldStage requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldParentHash: %w", err)
+		return v, fmt.Errorf("querying old value for OldStage: %w", err)
 	}
-	return oldValue.ParentHash, nil
+	return oldValue.Stage, nil
 }
 
-// ClearParentHash clears the value of the "parent_hash" field.
-func (m *NodeMutation) ClearParentHash() {
-	m.parent = nil
-	m.clearedFields[node.FieldParentHash] = struct{}{}
-}
-
-// ParentHashCleared returns if the "parent_hash" field was cleared in this mutation.
-func (m *NodeMutation) ParentHashCleared() bool {
-	_, ok := m.clearedFields[node.FieldParentHash]
-	return ok
-}
-
-// ResetParentHash resets all changes to the "parent_hash" field.
-func (m *NodeMutation) ResetParentHash() {
-	m.parent = nil
-	delete(m.clearedFields, node.FieldParentHash)
+// ResetStage resets all changes to the "stage" field.
+func (m *DeadLetterMutation) ResetStage() {
+	m.stage = nil
 }
 
-// SetBucket sets the "bucket" field.
-func (m *NodeMutation) SetBucket(value map[string]interface{}) {
-	m.bucket = &value
+// SetProvider sets the "provider" field.
+func (m *DeadLetterMutation) SetProvider(s string) {
+	m.provider = &s
 }
 
-// Bucket returns the value of the "bucket" field in the mutation.
-func (m *NodeMutation) Bucket() (r map[string]interface{}, exists bool) {
-	v := m.bucket
+// Provider returns the value of the "provider" field in the mutation.
+func (m *DeadLetterMutation) Provider() (r string, exists bool) {
+	v := m.provider
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldBucket returns the old "bucket" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldProvider returns the old "provider" field's value of the DeadLetter entity.
+// If the DeadLetter object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldBucket(ctx context.Context) (v map[string]interface{}, err error) {
+func (m *DeadLetterMutation) OldProvider(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldBucket is only allowed on UpdateOne operations")
+		return v, errors.New("OldProvider is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldBucket requires an ID field in the mutation")
+		return v, errors.New("OldProvider requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldBucket: %w", err)
+		return v, fmt.Errorf("querying old value for OldProvider: %w", err)
 	}
-	return oldValue.Bucket, nil
+	return oldValue.Provider, nil
 }
 
-// ClearBucket clears the value of the "bucket" field.
-func (m *NodeMutation) ClearBucket() {
-	m.bucket = nil
-	m.clearedFields[node.FieldBucket] = struct{}{}
+// ClearProvider clears the value of the "provider" field.
+func (m *DeadLetterMutation) ClearProvider() {
+	m.provider = nil
+	m.clearedFields[deadletter.FieldProvider] = struct{}{}
 }
 
-// BucketCleared returns if the "bucket" field was cleared in this mutation.
-func (m *NodeMutation) BucketCleared() bool {
-	_, ok := m.clearedFields[node.FieldBucket]
+// ProviderCleared returns if the "provider" field was cleared in this mutation.
+func (m *DeadLetterMutation) ProviderCleared() bool {
+	_, ok := m.clearedFields[deadletter.FieldProvider]
 	return ok
 }
 
-// ResetBucket resets all changes to the "bucket" field.
-func (m *NodeMutation) ResetBucket() {
-	m.bucket = nil
-	delete(m.clearedFields, node.FieldBucket)
+// ResetProvider resets all changes to the "provider" field.
+func (m *DeadLetterMutation) ResetProvider() {
+	m.provider = nil
+	delete(m.clearedFields, deadletter.FieldProvider)
 }
 
-// SetType sets the "type" field.
-func (m *NodeMutation) SetType(s string) {
-	m._type = &s
+// SetAgentName sets the "agent_name" field.
+func (m *DeadLetterMutation) SetAgentName(s string) {
+	m.agent_name = &s
 }
 
-// GetType returns the value of the "type" field in the mutation.
-func (m *NodeMutation) GetType() (r string, exists bool) {
-	v := m._type
+// AgentName returns the value of the "agent_name" field in the mutation.
+func (m *DeadLetterMutation) AgentName() (r string, exists bool) {
+	v := m.agent_name
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldType returns the old "type" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldAgentName returns the old "agent_name" field's value of the DeadLetter entity.
+// If the DeadLetter object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldType(ctx context.Context) (v string, err error) {
+func (m *DeadLetterMutation) OldAgentName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldType is only allowed on UpdateOne operations")
+		return v, errors.New("OldAgentName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldType requires an ID field in the mutation")
+		return v, errors.New("OldAgentName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldType: %w", err)
+		return v, fmt.Errorf("querying old value for OldAgentName: %w", err)
 	}
-	return oldValue.Type, nil
+	return oldValue.AgentName, nil
 }
 
-// ClearType clears the value of the "type" field.
-func (m *NodeMutation) ClearType() {
-	m._type = nil
-	m.clearedFields[node.FieldType] = struct{}{}
+// ClearAgentName clears the value of the "agent_name" field.
+func (m *DeadLetterMutation) ClearAgentName() {
+	m.agent_name = nil
+	m.clearedFields[deadletter.FieldAgentName] = struct{}{}
 }
 
-// TypeCleared returns if the "type" field was cleared in this mutation.
-func (m *NodeMutation) TypeCleared() bool {
-	_, ok := m.clearedFields[node.FieldType]
+// AgentNameCleared returns if the "agent_name" field was cleared in this mutation.
+func (m *DeadLetterMutation) AgentNameCleared() bool {
+	_, ok := m.clearedFields[deadletter.FieldAgentName]
 	return ok
 }
 
-// ResetType resets all changes to the "type" field.
-func (m *NodeMutation) ResetType() {
-	m._type = nil
-	delete(m.clearedFields, node.FieldType)
+// ResetAgentName resets all changes to the "agent_name" field.
+func (m *DeadLetterMutation) ResetAgentName() {
+	m.agent_name = nil
+	delete(m.clearedFields, deadletter.FieldAgentName)
 }
 
-// SetRole sets the "role" field.
-func (m *NodeMutation) SetRole(s string) {
-	m.role = &s
+// SetPath sets the "path" field.
+func (m *DeadLetterMutation) SetPath(s string) {
+	m._path = &s
 }
 
-// Role returns the value of the "role" field in the mutation.
-func (m *NodeMutation) Role() (r string, exists bool) {
-	v := m.role
+// Path returns the value of the "path" field in the mutation.
+func (m *DeadLetterMutation) Path() (r string, exists bool) {
+	v := m._path
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldRole returns the old "role" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldPath returns the old "path" field's value of the DeadLetter entity.
+// If the DeadLetter object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldRole(ctx context.Context) (v string, err error) {
+func (m *DeadLetterMutation) OldPath(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldRole is only allowed on UpdateOne operations")
+		return v, errors.New("OldPath is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldRole requires an ID field in the mutation")
+		return v, errors.New("OldPath requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldRole: %w", err)
+		return v, fmt.Errorf("querying old value for OldPath: %w", err)
 	}
-	return oldValue.Role, nil
+	return oldValue.Path, nil
 }
 
-// ClearRole clears the value of the "role" field.
-func (m *NodeMutation) ClearRole() {
-	m.role = nil
-	m.clearedFields[node.FieldRole] = struct{}{}
+// ClearPath clears the value of the "path" field.
+func (m *DeadLetterMutation) ClearPath() {
+	m._path = nil
+	m.clearedFields[deadletter.FieldPath] = struct{}{}
 }
 
-// RoleCleared returns if the "role" field was cleared in this mutation.
-func (m *NodeMutation) RoleCleared() bool {
-	_, ok := m.clearedFields[node.FieldRole]
+// PathCleared returns if the "path" field was cleared in this mutation.
+func (m *DeadLetterMutation) PathCleared() bool {
+	_, ok := m.clearedFields[deadletter.FieldPath]
 	return ok
 }
 
-// ResetRole resets all changes to the "role" field.
-func (m *NodeMutation) ResetRole() {
-	m.role = nil
-	delete(m.clearedFields, node.FieldRole)
+// ResetPath resets all changes to the "path" field.
+func (m *DeadLetterMutation) ResetPath() {
+	m._path = nil
+	delete(m.clearedFields, deadletter.FieldPath)
 }
 
-// SetContent sets the "content" field.
-func (m *NodeMutation) SetContent(value []map[string]interface{}) {
-	m.content = &value
-	m.appendcontent = nil
+// SetHeaders sets the "headers" field.
+func (m *DeadLetterMutation) SetHeaders(value map[string]string) {
+	m.headers = &value
 }
 
-// Content returns the value of the "content" field in the mutation.
-func (m *NodeMutation) Content() (r []map[string]interface{}, exists bool) {
-	v := m.content
+// Headers returns the value of the "headers" field in the mutation.
+func (m *DeadLetterMutation) Headers() (r map[string]string, exists bool) {
+	v := m.headers
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldContent returns the old "content" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldHeaders returns the old "headers" field's value of the DeadLetter entity.
+// If the DeadLetter object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldContent(ctx context.Context) (v []map[string]interface{}, err error) {
+func (m *DeadLetterMutation) OldHeaders(ctx context.Context) (v map[string]string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldContent is only allowed on UpdateOne operations")
+		return v, errors.New("OldHeaders is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldContent requires an ID field in the mutation")
+		return v, errors.New("OldHeaders requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldContent: %w", err)
-	}
-	return oldValue.Content, nil
-}
-
-// AppendContent adds value to the "content" field.
-func (m *NodeMutation) AppendContent(value []map[string]interface{}) {
-	m.appendcontent = append(m.appendcontent, value...)
-}
-
-// AppendedContent returns the list of values that were appended to the "content" field in this mutation.
-func (m *NodeMutation) AppendedContent() ([]map[string]interface{}, bool) {
-	if len(m.appendcontent) == 0 {
-		return nil, false
+		return v, fmt.Errorf("querying old value for OldHeaders: %w", err)
 	}
-	return m.appendcontent, true
+	return oldValue.Headers, nil
 }
 
-// ClearContent clears the value of the "content" field.
-func (m *NodeMutation) ClearContent() {
-	m.content = nil
-	m.appendcontent = nil
-	m.clearedFields[node.FieldContent] = struct{}{}
+// ClearHeaders clears the value of the "headers" field.
+func (m *DeadLetterMutation) ClearHeaders() {
+	m.headers = nil
+	m.clearedFields[deadletter.FieldHeaders] = struct{}{}
 }
 
-// ContentCleared returns if the "content" field was cleared in this mutation.
-func (m *NodeMutation) ContentCleared() bool {
-	_, ok := m.clearedFields[node.FieldContent]
+// HeadersCleared returns if the "headers" field was cleared in this mutation.
+func (m *DeadLetterMutation) HeadersCleared() bool {
+	_, ok := m.clearedFields[deadletter.FieldHeaders]
 	return ok
 }
 
-// ResetContent resets all changes to the "content" field.
-func (m *NodeMutation) ResetContent() {
-	m.content = nil
-	m.appendcontent = nil
-	delete(m.clearedFields, node.FieldContent)
+// ResetHeaders resets all changes to the "headers" field.
+func (m *DeadLetterMutation) ResetHeaders() {
+	m.headers = nil
+	delete(m.clearedFields, deadletter.FieldHeaders)
 }
 
-// SetModel sets the "model" field.
-func (m *NodeMutation) SetModel(s string) {
-	m.model = &s
+// SetRequest sets the "request" field.
+func (m *DeadLetterMutation) SetRequest(s string) {
+	m.request = &s
 }
 
-// Model returns the value of the "model" field in the mutation.
-func (m *NodeMutation) Model() (r string, exists bool) {
-	v := m.model
+// Request returns the value of the "request" field in the mutation.
+func (m *DeadLetterMutation) Request() (r string, exists bool) {
+	v := m.request
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldModel returns the old "model" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldRequest returns the old "request" field's value of the DeadLetter entity.
+// If the DeadLetter object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldModel(ctx context.Context) (v string, err error) {
+func (m *DeadLetterMutation) OldRequest(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldModel is only allowed on UpdateOne operations")
+		return v, errors.New("OldRequest is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldModel requires an ID field in the mutation")
+		return v, errors.New("OldRequest requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldModel: %w", err)
+		return v, fmt.Errorf("querying old value for OldRequest: %w", err)
 	}
-	return oldValue.Model, nil
+	return oldValue.Request, nil
 }
 
-// ClearModel clears the value of the "model" field.
-func (m *NodeMutation) ClearModel() {
-	m.model = nil
-	m.clearedFields[node.FieldModel] = struct{}{}
+// ClearRequest clears the value of the "request" field.
+func (m *DeadLetterMutation) ClearRequest() {
+	m.request = nil
+	m.clearedFields[deadletter.FieldRequest] = struct{}{}
 }
 
-// ModelCleared returns if the "model" field was cleared in this mutation.
-func (m *NodeMutation) ModelCleared() bool {
-	_, ok := m.clearedFields[node.FieldModel]
+// RequestCleared returns if the "request" field was cleared in this mutation.
+func (m *DeadLetterMutation) RequestCleared() bool {
+	_, ok := m.clearedFields[deadletter.FieldRequest]
 	return ok
 }
 
-// ResetModel resets all changes to the "model" field.
-func (m *NodeMutation) ResetModel() {
-	m.model = nil
-	delete(m.clearedFields, node.FieldModel)
+// ResetRequest resets all changes to the "request" field.
+func (m *DeadLetterMutation) ResetRequest() {
+	m.request = nil
+	delete(m.clearedFields, deadletter.FieldRequest)
 }
 
-// SetProvider sets the "provider" field.
-func (m *NodeMutation) SetProvider(s string) {
-	m.provider = &s
+// SetResponse sets the "response" field.
+func (m *DeadLetterMutation) SetResponse(s string) {
+	m.response = &s
 }
 
-// Provider returns the value of the "provider" field in the mutation.
-func (m *NodeMutation) Provider() (r string, exists bool) {
-	v := m.provider
+// Response returns the value of the "response" field in the mutation.
+func (m *DeadLetterMutation) Response() (r string, exists bool) {
+	v := m.response
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldProvider returns the old "provider" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldResponse returns the old "response" field's value of the DeadLetter entity.
+// If the DeadLetter object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldProvider(ctx context.Context) (v string, err error) {
+func (m *DeadLetterMutation) OldResponse(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldProvider is only allowed on UpdateOne operations")
+		return v, errors.New("OldResponse is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldProvider requires an ID field in the mutation")
+		return v, errors.New("OldResponse requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldProvider: %w", err)
+		return v, fmt.Errorf("querying old value for OldResponse: %w", err)
 	}
-	return oldValue.Provider, nil
+	return oldValue.Response, nil
 }
 
-// ClearProvider clears the value of the "provider" field.
-func (m *NodeMutation) ClearProvider() {
-	m.provider = nil
-	m.clearedFields[node.FieldProvider] = struct{}{}
+// ClearResponse clears the value of the "response" field.
+func (m *DeadLetterMutation) ClearResponse() {
+	m.response = nil
+	m.clearedFields[deadletter.FieldResponse] = struct{}{}
 }
 
-// ProviderCleared returns if the "provider" field was cleared in this mutation.
-func (m *NodeMutation) ProviderCleared() bool {
-	_, ok := m.clearedFields[node.FieldProvider]
+// ResponseCleared returns if the "response" field was cleared in this mutation.
+func (m *DeadLetterMutation) ResponseCleared() bool {
+	_, ok := m.clearedFields[deadletter.FieldResponse]
 	return ok
 }
 
-// ResetProvider resets all changes to the "provider" field.
-func (m *NodeMutation) ResetProvider() {
-	m.provider = nil
-	delete(m.clearedFields, node.FieldProvider)
+// ResetResponse resets all changes to the "response" field.
+func (m *DeadLetterMutation) ResetResponse() {
+	m.response = nil
+	delete(m.clearedFields, deadletter.FieldResponse)
 }
 
-// SetAgentName sets the "agent_name" field.
-func (m *NodeMutation) SetAgentName(s string) {
-	m.agent_name = &s
+// SetEncrypted sets the "encrypted" field.
+func (m *DeadLetterMutation) SetEncrypted(b bool) {
+	m.encrypted = &b
 }
 
-// AgentName returns the value of the "agent_name" field in the mutation.
-func (m *NodeMutation) AgentName() (r string, exists bool) {
-	v := m.agent_name
+// Encrypted returns the value of the "encrypted" field in the mutation.
+func (m *DeadLetterMutation) Encrypted() (r bool, exists bool) {
+	v := m.encrypted
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAgentName returns the old "agent_name" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldEncrypted returns the old "encrypted" field's value of the DeadLetter entity.
+// If the DeadLetter object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldAgentName(ctx context.Context) (v string, err error) {
+func (m *DeadLetterMutation) OldEncrypted(ctx context.Context) (v bool, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAgentName is only allowed on UpdateOne operations")
+		return v, errors.New("OldEncrypted is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAgentName requires an ID field in the mutation")
+		return v, errors.New("OldEncrypted requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAgentName: %w", err)
+		return v, fmt.Errorf("querying old value for OldEncrypted: %w", err)
 	}
-	return oldValue.AgentName, nil
-}
-
-// ClearAgentName clears the value of the "agent_name" field.
-func (m *NodeMutation) ClearAgentName() {
-	m.agent_name = nil
-	m.clearedFields[node.FieldAgentName] = struct{}{}
-}
-
-// AgentNameCleared returns if the "agent_name" field was cleared in this mutation.
-func (m *NodeMutation) AgentNameCleared() bool {
-	_, ok := m.clearedFields[node.FieldAgentName]
-	return ok
+	return oldValue.Encrypted, nil
 }
 
-// ResetAgentName resets all changes to the "agent_name" field.
-func (m *NodeMutation) ResetAgentName() {
-	m.agent_name = nil
-	delete(m.clearedFields, node.FieldAgentName)
+// ResetEncrypted resets all changes to the "encrypted" field.
+func (m *DeadLetterMutation) ResetEncrypted() {
+	m.encrypted = nil
 }
 
-// SetStopReason sets the "stop_reason" field.
-func (m *NodeMutation) SetStopReason(s string) {
-	m.stop_reason = &s
+// SetError sets the "error" field.
+func (m *DeadLetterMutation) SetError(s string) {
+	m.error = &s
 }
 
-// StopReason returns the value of the "stop_reason" field in the mutation.
-func (m *NodeMutation) StopReason() (r string, exists bool) {
-	v := m.stop_reason
+// Error returns the value of the "error" field in the mutation.
+func (m *DeadLetterMutation) Error() (r string, exists bool) {
+	v := m.error
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldStopReason returns the old "stop_reason" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldError returns the old "error" field's value of the DeadLetter entity.
+// If the DeadLetter object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldStopReason(ctx context.Context) (v string, err error) {
+func (m *DeadLetterMutation) OldError(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldStopReason is only allowed on UpdateOne operations")
+		return v, errors.New("OldError is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldStopReason requires an ID field in the mutation")
+		return v, errors.New("OldError requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldStopReason: %w", err)
+		return v, fmt.Errorf("querying old value for OldError: %w", err)
 	}
-	return oldValue.StopReason, nil
+	return oldValue.Error, nil
 }
 
-// ClearStopReason clears the value of the "stop_reason" field.
-func (m *NodeMutation) ClearStopReason() {
-	m.stop_reason = nil
-	m.clearedFields[node.FieldStopReason] = struct{}{}
+// ClearError clears the value of the "error" field.
+func (m *DeadLetterMutation) ClearError() {
+	m.error = nil
+	m.clearedFields[deadletter.FieldError] = struct{}{}
 }
 
-// StopReasonCleared returns if the "stop_reason" field was cleared in this mutation.
-func (m *NodeMutation) StopReasonCleared() bool {
-	_, ok := m.clearedFields[node.FieldStopReason]
+// ErrorCleared returns if the "error" field was cleared in this mutation.
+func (m *DeadLetterMutation) ErrorCleared() bool {
+	_, ok := m.clearedFields[deadletter.FieldError]
 	return ok
 }
 
-// ResetStopReason resets all changes to the "stop_reason" field.
-func (m *NodeMutation) ResetStopReason() {
-	m.stop_reason = nil
-	delete(m.clearedFields, node.FieldStopReason)
+// ResetError resets all changes to the "error" field.
+func (m *DeadLetterMutation) ResetError() {
+	m.error = nil
+	delete(m.clearedFields, deadletter.FieldError)
 }
 
-// SetPromptTokens sets the "prompt_tokens" field.
-func (m *NodeMutation) SetPromptTokens(i int) {
-	m.prompt_tokens = &i
-	m.addprompt_tokens = nil
+// SetCreatedAt sets the "created_at" field.
+func (m *DeadLetterMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
 }
 
-// PromptTokens returns the value of the "prompt_tokens" field in the mutation.
-func (m *NodeMutation) PromptTokens() (r int, exists bool) {
-	v := m.prompt_tokens
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *DeadLetterMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPromptTokens returns the old "prompt_tokens" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the DeadLetter entity.
+// If the DeadLetter object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldPromptTokens(ctx context.Context) (v *int, err error) {
+func (m *DeadLetterMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPromptTokens is only allowed on UpdateOne operations")
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPromptTokens requires an ID field in the mutation")
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPromptTokens: %w", err)
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
-	return oldValue.PromptTokens, nil
+	return oldValue.CreatedAt, nil
 }
 
-// AddPromptTokens adds i to the "prompt_tokens" field.
-func (m *NodeMutation) AddPromptTokens(i int) {
-	if m.addprompt_tokens != nil {
-		*m.addprompt_tokens += i
-	} else {
-		m.addprompt_tokens = &i
-	}
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *DeadLetterMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// AddedPromptTokens returns the value that was added to the "prompt_tokens" field in this mutation.
-func (m *NodeMutation) AddedPromptTokens() (r int, exists bool) {
-	v := m.addprompt_tokens
-	if v == nil {
-		return
-	}
-	return *v, true
+// Where appends a list predicates to the DeadLetterMutation builder.
+func (m *DeadLetterMutation) Where(ps ...predicate.DeadLetter) {
+	m.predicates = append(m.predicates, ps...)
 }
 
-// ClearPromptTokens clears the value of the "prompt_tokens" field.
-func (m *NodeMutation) ClearPromptTokens() {
-	m.prompt_tokens = nil
-	m.addprompt_tokens = nil
-	m.clearedFields[node.FieldPromptTokens] = struct{}{}
+// WhereP appends storage-level predicates to the DeadLetterMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *DeadLetterMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.DeadLetter, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
 }
 
-// PromptTokensCleared returns if the "prompt_tokens" field was cleared in this mutation.
-func (m *NodeMutation) PromptTokensCleared() bool {
-	_, ok := m.clearedFields[node.FieldPromptTokens]
-	return ok
+// Op returns the operation name.
+func (m *DeadLetterMutation) Op() Op {
+	return m.op
 }
 
-// ResetPromptTokens resets all changes to the "prompt_tokens" field.
-func (m *NodeMutation) ResetPromptTokens() {
-	m.prompt_tokens = nil
-	m.addprompt_tokens = nil
-	delete(m.clearedFields, node.FieldPromptTokens)
+// SetOp allows setting the mutation operation.
+func (m *DeadLetterMutation) SetOp(op Op) {
+	m.op = op
 }
 
-// SetCompletionTokens sets the "completion_tokens" field.
-func (m *NodeMutation) SetCompletionTokens(i int) {
-	m.completion_tokens = &i
-	m.addcompletion_tokens = nil
+// Type returns the node type of this mutation (DeadLetter).
+func (m *DeadLetterMutation) Type() string {
+	return m.typ
 }
 
-// CompletionTokens returns the value of the "completion_tokens" field in the mutation.
-func (m *NodeMutation) CompletionTokens() (r int, exists bool) {
-	v := m.completion_tokens
-	if v == nil {
-		return
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *DeadLetterMutation) Fields() []string {
+	fields := make([]string, 0, 10)
+	if m.stage != nil {
+		fields = append(fields, deadletter.FieldStage)
 	}
-	return *v, true
-}
-
-// OldCompletionTokens returns the old "completion_tokens" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldCompletionTokens(ctx context.Context) (v *int, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCompletionTokens is only allowed on UpdateOne operations")
+	if m.provider != nil {
+		fields = append(fields, deadletter.FieldProvider)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCompletionTokens requires an ID field in the mutation")
+	if m.agent_name != nil {
+		fields = append(fields, deadletter.FieldAgentName)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCompletionTokens: %w", err)
+	if m._path != nil {
+		fields = append(fields, deadletter.FieldPath)
 	}
-	return oldValue.CompletionTokens, nil
-}
-
-// AddCompletionTokens adds i to the "completion_tokens" field.
-func (m *NodeMutation) AddCompletionTokens(i int) {
-	if m.addcompletion_tokens != nil {
-		*m.addcompletion_tokens += i
-	} else {
-		m.addcompletion_tokens = &i
+	if m.headers != nil {
+		fields = append(fields, deadletter.FieldHeaders)
+	}
+	if m.request != nil {
+		fields = append(fields, deadletter.FieldRequest)
+	}
+	if m.response != nil {
+		fields = append(fields, deadletter.FieldResponse)
+	}
+	if m.encrypted != nil {
+		fields = append(fields, deadletter.FieldEncrypted)
+	}
+	if m.error != nil {
+		fields = append(fields, deadletter.FieldError)
+	}
+	if m.created_at != nil {
+		fields = append(fields, deadletter.FieldCreatedAt)
 	}
+	return fields
 }
 
-// AddedCompletionTokens returns the value that was added to the "completion_tokens" field in this mutation.
-func (m *NodeMutation) AddedCompletionTokens() (r int, exists bool) {
-	v := m.addcompletion_tokens
-	if v == nil {
-		return
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *DeadLetterMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case deadletter.FieldStage:
+		return m.Stage()
+	case deadletter.FieldProvider:
+		return m.Provider()
+	case deadletter.FieldAgentName:
+		return m.AgentName()
+	case deadletter.FieldPath:
+		return m.Path()
+	case deadletter.FieldHeaders:
+		return m.Headers()
+	case deadletter.FieldRequest:
+		return m.Request()
+	case deadletter.FieldResponse:
+		return m.Response()
+	case deadletter.FieldEncrypted:
+		return m.Encrypted()
+	case deadletter.FieldError:
+		return m.Error()
+	case deadletter.FieldCreatedAt:
+		return m.CreatedAt()
 	}
-	return *v, true
+	return nil, false
 }
 
-// ClearCompletionTokens clears the value of the "completion_tokens" field.
-func (m *NodeMutation) ClearCompletionTokens() {
-	m.completion_tokens = nil
-	m.addcompletion_tokens = nil
-	m.clearedFields[node.FieldCompletionTokens] = struct{}{}
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *DeadLetterMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case deadletter.FieldStage:
+		return m.OldStage(ctx)
+	case deadletter.FieldProvider:
+		return m.OldProvider(ctx)
+	case deadletter.FieldAgentName:
+		return m.OldAgentName(ctx)
+	case deadletter.FieldPath:
+		return m.OldPath(ctx)
+	case deadletter.FieldHeaders:
+		return m.OldHeaders(ctx)
+	case deadletter.FieldRequest:
+		return m.OldRequest(ctx)
+	case deadletter.FieldResponse:
+		return m.OldResponse(ctx)
+	case deadletter.FieldEncrypted:
+		return m.OldEncrypted(ctx)
+	case deadletter.FieldError:
+		return m.OldError(ctx)
+	case deadletter.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown DeadLetter field %s", name)
 }
 
-// CompletionTokensCleared returns if the "completion_tokens" field was cleared in this mutation.
-func (m *NodeMutation) CompletionTokensCleared() bool {
-	_, ok := m.clearedFields[node.FieldCompletionTokens]
-	return ok
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *DeadLetterMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case deadletter.FieldStage:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStage(v)
+		return nil
+	case deadletter.FieldProvider:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProvider(v)
+		return nil
+	case deadletter.FieldAgentName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAgentName(v)
+		return nil
+	case deadletter.FieldPath:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPath(v)
+		return nil
+	case deadletter.FieldHeaders:
+		v, ok := value.(map[string]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHeaders(v)
+		return nil
+	case deadletter.FieldRequest:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRequest(v)
+		return nil
+	case deadletter.FieldResponse:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResponse(v)
+		return nil
+	case deadletter.FieldEncrypted:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEncrypted(v)
+		return nil
+	case deadletter.FieldError:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetError(v)
+		return nil
+	case deadletter.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown DeadLetter field %s", name)
 }
 
-// ResetCompletionTokens resets all changes to the "completion_tokens" field.
-func (m *NodeMutation) ResetCompletionTokens() {
-	m.completion_tokens = nil
-	m.addcompletion_tokens = nil
-	delete(m.clearedFields, node.FieldCompletionTokens)
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *DeadLetterMutation) AddedFields() []string {
+	return nil
 }
 
-// SetTotalTokens sets the "total_tokens" field.
-func (m *NodeMutation) SetTotalTokens(i int) {
-	m.total_tokens = &i
-	m.addtotal_tokens = nil
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *DeadLetterMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
 }
 
-// TotalTokens returns the value of the "total_tokens" field in the mutation.
-func (m *NodeMutation) TotalTokens() (r int, exists bool) {
-	v := m.total_tokens
-	if v == nil {
-		return
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *DeadLetterMutation) AddField(name string, value ent.Value) error {
+	switch name {
 	}
-	return *v, true
+	return fmt.Errorf("unknown DeadLetter numeric field %s", name)
 }
 
-// OldTotalTokens returns the old "total_tokens" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldTotalTokens(ctx context.Context) (v *int, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldTotalTokens is only allowed on UpdateOne operations")
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *DeadLetterMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(deadletter.FieldProvider) {
+		fields = append(fields, deadletter.FieldProvider)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldTotalTokens requires an ID field in the mutation")
+	if m.FieldCleared(deadletter.FieldAgentName) {
+		fields = append(fields, deadletter.FieldAgentName)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldTotalTokens: %w", err)
+	if m.FieldCleared(deadletter.FieldPath) {
+		fields = append(fields, deadletter.FieldPath)
 	}
-	return oldValue.TotalTokens, nil
-}
-
-// AddTotalTokens adds i to the "total_tokens" field.
-func (m *NodeMutation) AddTotalTokens(i int) {
-	if m.addtotal_tokens != nil {
-		*m.addtotal_tokens += i
-	} else {
-		m.addtotal_tokens = &i
+	if m.FieldCleared(deadletter.FieldHeaders) {
+		fields = append(fields, deadletter.FieldHeaders)
+	}
+	if m.FieldCleared(deadletter.FieldRequest) {
+		fields = append(fields, deadletter.FieldRequest)
+	}
+	if m.FieldCleared(deadletter.FieldResponse) {
+		fields = append(fields, deadletter.FieldResponse)
+	}
+	if m.FieldCleared(deadletter.FieldError) {
+		fields = append(fields, deadletter.FieldError)
 	}
+	return fields
 }
 
-// AddedTotalTokens returns the value that was added to the "total_tokens" field in this mutation.
-func (m *NodeMutation) AddedTotalTokens() (r int, exists bool) {
-	v := m.addtotal_tokens
-	if v == nil {
-		return
-	}
-	return *v, true
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *DeadLetterMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
 }
 
-// ClearTotalTokens clears the value of the "total_tokens" field.
-func (m *NodeMutation) ClearTotalTokens() {
-	m.total_tokens = nil
-	m.addtotal_tokens = nil
-	m.clearedFields[node.FieldTotalTokens] = struct{}{}
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *DeadLetterMutation) ClearField(name string) error {
+	switch name {
+	case deadletter.FieldProvider:
+		m.ClearProvider()
+		return nil
+	case deadletter.FieldAgentName:
+		m.ClearAgentName()
+		return nil
+	case deadletter.FieldPath:
+		m.ClearPath()
+		return nil
+	case deadletter.FieldHeaders:
+		m.ClearHeaders()
+		return nil
+	case deadletter.FieldRequest:
+		m.ClearRequest()
+		return nil
+	case deadletter.FieldResponse:
+		m.ClearResponse()
+		return nil
+	case deadletter.FieldError:
+		m.ClearError()
+		return nil
+	}
+	return fmt.Errorf("unknown DeadLetter nullable field %s", name)
 }
 
-// TotalTokensCleared returns if the "total_tokens" field was cleared in this mutation.
-func (m *NodeMutation) TotalTokensCleared() bool {
-	_, ok := m.clearedFields[node.FieldTotalTokens]
-	return ok
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *DeadLetterMutation) ResetField(name string) error {
+	switch name {
+	case deadletter.FieldStage:
+		m.ResetStage()
+		return nil
+	case deadletter.FieldProvider:
+		m.ResetProvider()
+		return nil
+	case deadletter.FieldAgentName:
+		m.ResetAgentName()
+		return nil
+	case deadletter.FieldPath:
+		m.ResetPath()
+		return nil
+	case deadletter.FieldHeaders:
+		m.ResetHeaders()
+		return nil
+	case deadletter.FieldRequest:
+		m.ResetRequest()
+		return nil
+	case deadletter.FieldResponse:
+		m.ResetResponse()
+		return nil
+	case deadletter.FieldEncrypted:
+		m.ResetEncrypted()
+		return nil
+	case deadletter.FieldError:
+		m.ResetError()
+		return nil
+	case deadletter.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown DeadLetter field %s", name)
 }
 
-// ResetTotalTokens resets all changes to the "total_tokens" field.
-func (m *NodeMutation) ResetTotalTokens() {
-	m.total_tokens = nil
-	m.addtotal_tokens = nil
-	delete(m.clearedFields, node.FieldTotalTokens)
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *DeadLetterMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// SetCacheCreationInputTokens sets the "cache_creation_input_tokens" field.
-func (m *NodeMutation) SetCacheCreationInputTokens(i int) {
-	m.cache_creation_input_tokens = &i
-	m.addcache_creation_input_tokens = nil
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *DeadLetterMutation) AddedIDs(name string) []ent.Value {
+	return nil
 }
 
-// CacheCreationInputTokens returns the value of the "cache_creation_input_tokens" field in the mutation.
-func (m *NodeMutation) CacheCreationInputTokens() (r int, exists bool) {
-	v := m.cache_creation_input_tokens
-	if v == nil {
-		return
-	}
-	return *v, true
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *DeadLetterMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// OldCacheCreationInputTokens returns the old "cache_creation_input_tokens" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldCacheCreationInputTokens(ctx context.Context) (v *int, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCacheCreationInputTokens is only allowed on UpdateOne operations")
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *DeadLetterMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *DeadLetterMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *DeadLetterMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *DeadLetterMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown DeadLetter unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *DeadLetterMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown DeadLetter edge %s", name)
+}
+
+// FacetMutation represents an operation that mutates the Facet nodes in the graph.
+type FacetMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *string
+	session_id    *string
+	facets        *map[string]interface{}
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*Facet, error)
+	predicates    []predicate.Facet
+}
+
+var _ ent.Mutation = (*FacetMutation)(nil)
+
+// facetOption allows management of the mutation configuration using functional options.
+type facetOption func(*FacetMutation)
+
+// newFacetMutation creates new mutation for the Facet entity.
+func newFacetMutation(c config, op Op, opts ...facetOption) *FacetMutation {
+	m := &FacetMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeFacet,
+		clearedFields: make(map[string]struct{}),
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCacheCreationInputTokens requires an ID field in the mutation")
+	for _, opt := range opts {
+		opt(m)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCacheCreationInputTokens: %w", err)
+	return m
+}
+
+// withFacetID sets the ID field of the mutation.
+func withFacetID(id string) facetOption {
+	return func(m *FacetMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Facet
+		)
+		m.oldValue = func(ctx context.Context) (*Facet, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Facet.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
 	}
-	return oldValue.CacheCreationInputTokens, nil
 }
 
-// AddCacheCreationInputTokens adds i to the "cache_creation_input_tokens" field.
-func (m *NodeMutation) AddCacheCreationInputTokens(i int) {
-	if m.addcache_creation_input_tokens != nil {
-		*m.addcache_creation_input_tokens += i
-	} else {
-		m.addcache_creation_input_tokens = &i
+// withFacet sets the old Facet of the mutation.
+func withFacet(node *Facet) facetOption {
+	return func(m *FacetMutation) {
+		m.oldValue = func(context.Context) (*Facet, error) {
+			return node, nil
+		}
+		m.id = &node.ID
 	}
 }
 
-// AddedCacheCreationInputTokens returns the value that was added to the "cache_creation_input_tokens" field in this mutation.
-func (m *NodeMutation) AddedCacheCreationInputTokens() (r int, exists bool) {
-	v := m.addcache_creation_input_tokens
-	if v == nil {
-		return
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m FacetMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m FacetMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
-	return *v, true
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
 }
 
-// ClearCacheCreationInputTokens clears the value of the "cache_creation_input_tokens" field.
-func (m *NodeMutation) ClearCacheCreationInputTokens() {
-	m.cache_creation_input_tokens = nil
-	m.addcache_creation_input_tokens = nil
-	m.clearedFields[node.FieldCacheCreationInputTokens] = struct{}{}
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Facet entities.
+func (m *FacetMutation) SetID(id string) {
+	m.id = &id
 }
 
-// CacheCreationInputTokensCleared returns if the "cache_creation_input_tokens" field was cleared in this mutation.
-func (m *NodeMutation) CacheCreationInputTokensCleared() bool {
-	_, ok := m.clearedFields[node.FieldCacheCreationInputTokens]
-	return ok
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *FacetMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
 }
 
-// ResetCacheCreationInputTokens resets all changes to the "cache_creation_input_tokens" field.
-func (m *NodeMutation) ResetCacheCreationInputTokens() {
-	m.cache_creation_input_tokens = nil
-	m.addcache_creation_input_tokens = nil
-	delete(m.clearedFields, node.FieldCacheCreationInputTokens)
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *FacetMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Facet.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
 }
 
-// SetCacheReadInputTokens sets the "cache_read_input_tokens" field.
-func (m *NodeMutation) SetCacheReadInputTokens(i int) {
-	m.cache_read_input_tokens = &i
-	m.addcache_read_input_tokens = nil
+// SetSessionID sets the "session_id" field.
+func (m *FacetMutation) SetSessionID(s string) {
+	m.session_id = &s
 }
 
-// CacheReadInputTokens returns the value of the "cache_read_input_tokens" field in the mutation.
-func (m *NodeMutation) CacheReadInputTokens() (r int, exists bool) {
-	v := m.cache_read_input_tokens
+// SessionID returns the value of the "session_id" field in the mutation.
+func (m *FacetMutation) SessionID() (r string, exists bool) {
+	v := m.session_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCacheReadInputTokens returns the old "cache_read_input_tokens" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldSessionID returns the old "session_id" field's value of the Facet entity.
+// If the Facet object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldCacheReadInputTokens(ctx context.Context) (v *int, err error) {
+func (m *FacetMutation) OldSessionID(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCacheReadInputTokens is only allowed on UpdateOne operations")
+		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCacheReadInputTokens requires an ID field in the mutation")
+		return v, errors.New("OldSessionID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCacheReadInputTokens: %w", err)
+		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
 	}
-	return oldValue.CacheReadInputTokens, nil
+	return oldValue.SessionID, nil
 }
 
-// AddCacheReadInputTokens adds i to the "cache_read_input_tokens" field.
-func (m *NodeMutation) AddCacheReadInputTokens(i int) {
-	if m.addcache_read_input_tokens != nil {
-		*m.addcache_read_input_tokens += i
-	} else {
-		m.addcache_read_input_tokens = &i
-	}
-}
-
-// AddedCacheReadInputTokens returns the value that was added to the "cache_read_input_tokens" field in this mutation.
-func (m *NodeMutation) AddedCacheReadInputTokens() (r int, exists bool) {
-	v := m.addcache_read_input_tokens
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// ClearCacheReadInputTokens clears the value of the "cache_read_input_tokens" field.
-func (m *NodeMutation) ClearCacheReadInputTokens() {
-	m.cache_read_input_tokens = nil
-	m.addcache_read_input_tokens = nil
-	m.clearedFields[node.FieldCacheReadInputTokens] = struct{}{}
-}
-
-// CacheReadInputTokensCleared returns if the "cache_read_input_tokens" field was cleared in this mutation.
-func (m *NodeMutation) CacheReadInputTokensCleared() bool {
-	_, ok := m.clearedFields[node.FieldCacheReadInputTokens]
-	return ok
-}
-
-// ResetCacheReadInputTokens resets all changes to the "cache_read_input_tokens" field.
-func (m *NodeMutation) ResetCacheReadInputTokens() {
-	m.cache_read_input_tokens = nil
-	m.addcache_read_input_tokens = nil
-	delete(m.clearedFields, node.FieldCacheReadInputTokens)
+// ResetSessionID resets all changes to the "session_id" field.
+func (m *FacetMutation) ResetSessionID() {
+	m.session_id = nil
 }
 
-// SetTotalDurationNs sets the "total_duration_ns" field.
-func (m *NodeMutation) SetTotalDurationNs(i int64) {
-	m.total_duration_ns = &i
-	m.addtotal_duration_ns = nil
+// SetFacets sets the "facets" field.
+func (m *FacetMutation) SetFacets(value map[string]interface{}) {
+	m.facets = &value
 }
 
-// TotalDurationNs returns the value of the "total_duration_ns" field in the mutation.
-func (m *NodeMutation) TotalDurationNs() (r int64, exists bool) {
-	v := m.total_duration_ns
+// Facets returns the value of the "facets" field in the mutation.
+func (m *FacetMutation) Facets() (r map[string]interface{}, exists bool) {
+	v := m.facets
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldTotalDurationNs returns the old "total_duration_ns" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldFacets returns the old "facets" field's value of the Facet entity.
+// If the Facet object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldTotalDurationNs(ctx context.Context) (v *int64, err error) {
+func (m *FacetMutation) OldFacets(ctx context.Context) (v map[string]interface{}, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldTotalDurationNs is only allowed on UpdateOne operations")
+		return v, errors.New("OldFacets is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldTotalDurationNs requires an ID field in the mutation")
+		return v, errors.New("OldFacets requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldTotalDurationNs: %w", err)
-	}
-	return oldValue.TotalDurationNs, nil
-}
-
-// AddTotalDurationNs adds i to the "total_duration_ns" field.
-func (m *NodeMutation) AddTotalDurationNs(i int64) {
-	if m.addtotal_duration_ns != nil {
-		*m.addtotal_duration_ns += i
-	} else {
-		m.addtotal_duration_ns = &i
-	}
-}
-
-// AddedTotalDurationNs returns the value that was added to the "total_duration_ns" field in this mutation.
-func (m *NodeMutation) AddedTotalDurationNs() (r int64, exists bool) {
-	v := m.addtotal_duration_ns
-	if v == nil {
-		return
+		return v, fmt.Errorf("querying old value for OldFacets: %w", err)
 	}
-	return *v, true
+	return oldValue.Facets, nil
 }
 
-// ClearTotalDurationNs clears the value of the "total_duration_ns" field.
-func (m *NodeMutation) ClearTotalDurationNs() {
-	m.total_duration_ns = nil
-	m.addtotal_duration_ns = nil
-	m.clearedFields[node.FieldTotalDurationNs] = struct{}{}
+// ClearFacets clears the value of the "facets" field.
+func (m *FacetMutation) ClearFacets() {
+	m.facets = nil
+	m.clearedFields[facet.FieldFacets] = struct{}{}
 }
 
-// TotalDurationNsCleared returns if the "total_duration_ns" field was cleared in this mutation.
-func (m *NodeMutation) TotalDurationNsCleared() bool {
-	_, ok := m.clearedFields[node.FieldTotalDurationNs]
+// FacetsCleared returns if the "facets" field was cleared in this mutation.
+func (m *FacetMutation) FacetsCleared() bool {
+	_, ok := m.clearedFields[facet.FieldFacets]
 	return ok
 }
 
-// ResetTotalDurationNs resets all changes to the "total_duration_ns" field.
-func (m *NodeMutation) ResetTotalDurationNs() {
-	m.total_duration_ns = nil
-	m.addtotal_duration_ns = nil
-	delete(m.clearedFields, node.FieldTotalDurationNs)
+// ResetFacets resets all changes to the "facets" field.
+func (m *FacetMutation) ResetFacets() {
+	m.facets = nil
+	delete(m.clearedFields, facet.FieldFacets)
 }
 
-// SetPromptDurationNs sets the "prompt_duration_ns" field.
-func (m *NodeMutation) SetPromptDurationNs(i int64) {
-	m.prompt_duration_ns = &i
-	m.addprompt_duration_ns = nil
+// SetCreatedAt sets the "created_at" field.
+func (m *FacetMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
 }
 
-// PromptDurationNs returns the value of the "prompt_duration_ns" field in the mutation.
-func (m *NodeMutation) PromptDurationNs() (r int64, exists bool) {
-	v := m.prompt_duration_ns
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *FacetMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPromptDurationNs returns the old "prompt_duration_ns" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the Facet entity.
+// If the Facet object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldPromptDurationNs(ctx context.Context) (v *int64, err error) {
+func (m *FacetMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPromptDurationNs is only allowed on UpdateOne operations")
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPromptDurationNs requires an ID field in the mutation")
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPromptDurationNs: %w", err)
-	}
-	return oldValue.PromptDurationNs, nil
-}
-
-// AddPromptDurationNs adds i to the "prompt_duration_ns" field.
-func (m *NodeMutation) AddPromptDurationNs(i int64) {
-	if m.addprompt_duration_ns != nil {
-		*m.addprompt_duration_ns += i
-	} else {
-		m.addprompt_duration_ns = &i
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
+	return oldValue.CreatedAt, nil
 }
 
-// AddedPromptDurationNs returns the value that was added to the "prompt_duration_ns" field in this mutation.
-func (m *NodeMutation) AddedPromptDurationNs() (r int64, exists bool) {
-	v := m.addprompt_duration_ns
-	if v == nil {
-		return
-	}
-	return *v, true
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *FacetMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// ClearPromptDurationNs clears the value of the "prompt_duration_ns" field.
-func (m *NodeMutation) ClearPromptDurationNs() {
-	m.prompt_duration_ns = nil
-	m.addprompt_duration_ns = nil
-	m.clearedFields[node.FieldPromptDurationNs] = struct{}{}
+// Where appends a list predicates to the FacetMutation builder.
+func (m *FacetMutation) Where(ps ...predicate.Facet) {
+	m.predicates = append(m.predicates, ps...)
 }
 
-// PromptDurationNsCleared returns if the "prompt_duration_ns" field was cleared in this mutation.
-func (m *NodeMutation) PromptDurationNsCleared() bool {
-	_, ok := m.clearedFields[node.FieldPromptDurationNs]
-	return ok
+// WhereP appends storage-level predicates to the FacetMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *FacetMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Facet, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
 }
 
-// ResetPromptDurationNs resets all changes to the "prompt_duration_ns" field.
-func (m *NodeMutation) ResetPromptDurationNs() {
-	m.prompt_duration_ns = nil
-	m.addprompt_duration_ns = nil
-	delete(m.clearedFields, node.FieldPromptDurationNs)
+// Op returns the operation name.
+func (m *FacetMutation) Op() Op {
+	return m.op
 }
 
-// SetProject sets the "project" field.
-func (m *NodeMutation) SetProject(s string) {
-	m.project = &s
+// SetOp allows setting the mutation operation.
+func (m *FacetMutation) SetOp(op Op) {
+	m.op = op
 }
 
-// Project returns the value of the "project" field in the mutation.
-func (m *NodeMutation) Project() (r string, exists bool) {
-	v := m.project
-	if v == nil {
-		return
-	}
-	return *v, true
+// Type returns the node type of this mutation (Facet).
+func (m *FacetMutation) Type() string {
+	return m.typ
 }
 
-// OldProject returns the old "project" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldProject(ctx context.Context) (v *string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldProject is only allowed on UpdateOne operations")
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *FacetMutation) Fields() []string {
+	fields := make([]string, 0, 3)
+	if m.session_id != nil {
+		fields = append(fields, facet.FieldSessionID)
 	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldProject requires an ID field in the mutation")
+	if m.facets != nil {
+		fields = append(fields, facet.FieldFacets)
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldProject: %w", err)
+	if m.created_at != nil {
+		fields = append(fields, facet.FieldCreatedAt)
 	}
-	return oldValue.Project, nil
+	return fields
 }
 
-// ClearProject clears the value of the "project" field.
-func (m *NodeMutation) ClearProject() {
-	m.project = nil
-	m.clearedFields[node.FieldProject] = struct{}{}
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *FacetMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case facet.FieldSessionID:
+		return m.SessionID()
+	case facet.FieldFacets:
+		return m.Facets()
+	case facet.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
 }
 
-// ProjectCleared returns if the "project" field was cleared in this mutation.
-func (m *NodeMutation) ProjectCleared() bool {
-	_, ok := m.clearedFields[node.FieldProject]
-	return ok
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *FacetMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case facet.FieldSessionID:
+		return m.OldSessionID(ctx)
+	case facet.FieldFacets:
+		return m.OldFacets(ctx)
+	case facet.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown Facet field %s", name)
 }
 
-// ResetProject resets all changes to the "project" field.
-func (m *NodeMutation) ResetProject() {
-	m.project = nil
-	delete(m.clearedFields, node.FieldProject)
-}
-
-// SetCreatedAt sets the "created_at" field.
-func (m *NodeMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
-}
-
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *NodeMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldCreatedAt returns the old "created_at" field's value of the Node entity.
-// If the Node object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *NodeMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *FacetMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case facet.FieldSessionID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionID(v)
+		return nil
+	case facet.FieldFacets:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFacets(v)
+		return nil
+	case facet.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
 	}
-	return oldValue.CreatedAt, nil
-}
-
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *NodeMutation) ResetCreatedAt() {
-	m.created_at = nil
-}
-
-// SetParentID sets the "parent" edge to the Node entity by id.
-func (m *NodeMutation) SetParentID(id string) {
-	m.parent = &id
+	return fmt.Errorf("unknown Facet field %s", name)
 }
 
-// ClearParent clears the "parent" edge to the Node entity.
-func (m *NodeMutation) ClearParent() {
-	m.clearedparent = true
-	m.clearedFields[node.FieldParentHash] = struct{}{}
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *FacetMutation) AddedFields() []string {
+	return nil
 }
 
-// ParentCleared reports if the "parent" edge to the Node entity was cleared.
-func (m *NodeMutation) ParentCleared() bool {
-	return m.ParentHashCleared() || m.clearedparent
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *FacetMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
 }
 
-// ParentID returns the "parent" edge ID in the mutation.
-func (m *NodeMutation) ParentID() (id string, exists bool) {
-	if m.parent != nil {
-		return *m.parent, true
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *FacetMutation) AddField(name string, value ent.Value) error {
+	switch name {
 	}
-	return
+	return fmt.Errorf("unknown Facet numeric field %s", name)
 }
 
-// ParentIDs returns the "parent" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// ParentID instead. It exists only for internal usage by the builders.
-func (m *NodeMutation) ParentIDs() (ids []string) {
-	if id := m.parent; id != nil {
-		ids = append(ids, *id)
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *FacetMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(facet.FieldFacets) {
+		fields = append(fields, facet.FieldFacets)
 	}
-	return
+	return fields
 }
 
-// ResetParent resets all changes to the "parent" edge.
-func (m *NodeMutation) ResetParent() {
-	m.parent = nil
-	m.clearedparent = false
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *FacetMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
 }
 
-// AddChildIDs adds the "children" edge to the Node entity by ids.
-func (m *NodeMutation) AddChildIDs(ids ...string) {
-	if m.children == nil {
-		m.children = make(map[string]struct{})
-	}
-	for i := range ids {
-		m.children[ids[i]] = struct{}{}
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *FacetMutation) ClearField(name string) error {
+	switch name {
+	case facet.FieldFacets:
+		m.ClearFacets()
+		return nil
 	}
+	return fmt.Errorf("unknown Facet nullable field %s", name)
 }
 
-// ClearChildren clears the "children" edge to the Node entity.
-func (m *NodeMutation) ClearChildren() {
-	m.clearedchildren = true
-}
-
-// ChildrenCleared reports if the "children" edge to the Node entity was cleared.
-func (m *NodeMutation) ChildrenCleared() bool {
-	return m.clearedchildren
-}
-
-// RemoveChildIDs removes the "children" edge to the Node entity by IDs.
-func (m *NodeMutation) RemoveChildIDs(ids ...string) {
-	if m.removedchildren == nil {
-		m.removedchildren = make(map[string]struct{})
-	}
-	for i := range ids {
-		delete(m.children, ids[i])
-		m.removedchildren[ids[i]] = struct{}{}
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *FacetMutation) ResetField(name string) error {
+	switch name {
+	case facet.FieldSessionID:
+		m.ResetSessionID()
+		return nil
+	case facet.FieldFacets:
+		m.ResetFacets()
+		return nil
+	case facet.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
 	}
+	return fmt.Errorf("unknown Facet field %s", name)
 }
 
-// RemovedChildren returns the removed IDs of the "children" edge to the Node entity.
-func (m *NodeMutation) RemovedChildrenIDs() (ids []string) {
-	for id := range m.removedchildren {
-		ids = append(ids, id)
-	}
-	return
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *FacetMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// ChildrenIDs returns the "children" edge IDs in the mutation.
-func (m *NodeMutation) ChildrenIDs() (ids []string) {
-	for id := range m.children {
-		ids = append(ids, id)
-	}
-	return
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *FacetMutation) AddedIDs(name string) []ent.Value {
+	return nil
 }
 
-// ResetChildren resets all changes to the "children" edge.
-func (m *NodeMutation) ResetChildren() {
-	m.children = nil
-	m.clearedchildren = false
-	m.removedchildren = nil
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *FacetMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// Where appends a list predicates to the NodeMutation builder.
-func (m *NodeMutation) Where(ps ...predicate.Node) {
-	m.predicates = append(m.predicates, ps...)
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *FacetMutation) RemovedIDs(name string) []ent.Value {
+	return nil
 }
 
-// WhereP appends storage-level predicates to the NodeMutation builder. Using this method,
-// users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *NodeMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Node, len(ps))
-	for i := range ps {
-		p[i] = ps[i]
-	}
-	m.Where(p...)
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *FacetMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// Op returns the operation name.
-func (m *NodeMutation) Op() Op {
-	return m.op
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *FacetMutation) EdgeCleared(name string) bool {
+	return false
 }
 
-// SetOp allows setting the mutation operation.
-func (m *NodeMutation) SetOp(op Op) {
-	m.op = op
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *FacetMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Facet unique edge %s", name)
 }
 
-// Type returns the node type of this mutation (Node).
-func (m *NodeMutation) Type() string {
-	return m.typ
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *FacetMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Facet edge %s", name)
 }
 
-// Fields returns all fields that were changed during this mutation. Note that in
-// order to get all numeric fields that were incremented/decremented, call
-// AddedFields().
-func (m *NodeMutation) Fields() []string {
-	fields := make([]string, 0, 18)
-	if m.parent != nil {
-		fields = append(fields, node.FieldParentHash)
-	}
-	if m.bucket != nil {
-		fields = append(fields, node.FieldBucket)
-	}
-	if m._type != nil {
-		fields = append(fields, node.FieldType)
-	}
-	if m.role != nil {
-		fields = append(fields, node.FieldRole)
-	}
-	if m.content != nil {
-		fields = append(fields, node.FieldContent)
-	}
-	if m.model != nil {
-		fields = append(fields, node.FieldModel)
+// NodeMutation represents an operation that mutates the Node nodes in the graph.
+type NodeMutation struct {
+	config
+	op                             Op
+	typ                            string
+	id                             *string
+	bucket                         *map[string]interface{}
+	_type                          *string
+	role                           *string
+	content                        *[]map[string]interface{}
+	appendcontent                  []map[string]interface{}
+	model                          *string
+	provider                       *string
+	agent_name                     *string
+	session_id                     *string
+	stop_reason                    *string
+	tool_names                     *string
+	prompt_tokens                  *int
+	addprompt_tokens               *int
+	completion_tokens              *int
+	addcompletion_tokens           *int
+	total_tokens                   *int
+	addtotal_tokens                *int
+	cache_creation_input_tokens    *int
+	addcache_creation_input_tokens *int
+	cache_read_input_tokens        *int
+	addcache_read_input_tokens     *int
+	reasoning_tokens               *int
+	addreasoning_tokens            *int
+	total_duration_ns              *int64
+	addtotal_duration_ns           *int64
+	prompt_duration_ns             *int64
+	addprompt_duration_ns          *int64
+	usage_estimated                *bool
+	request_started_at             *time.Time
+	first_chunk_at                 *time.Time
+	response_completed_at          *time.Time
+	error_status                   *int
+	adderror_status                *int
+	error_type                     *string
+	error_message                  *string
+	retry_after_seconds            *int
+	addretry_after_seconds         *int
+	error_attempt                  *int
+	adderror_attempt               *int
+	project                        *string
+	user                           *string
+	trace_id                       *string
+	run_id                         *string
+	agent_version                  *string
+	system_prompt_hash             *string
+	policy_violations              *[]llm.PolicyViolation
+	appendpolicy_violations        []llm.PolicyViolation
+	signer_key                     *[]byte
+	signature                      *[]byte
+	shared_by                      *string
+	superseded_by                  *string
+	title                          *string
+	summary                        *string
+	outcome                        *string
+	summarized_at                  *time.Time
+	ended_at                       *time.Time
+	end_reason                     *string
+	turn_index                     *int
+	addturn_index                  *int
+	created_at                     *time.Time
+	clearedFields                  map[string]struct{}
+	parent                         *string
+	clearedparent                  bool
+	children                       map[string]struct{}
+	removedchildren                map[string]struct{}
+	clearedchildren                bool
+	done                           bool
+	oldValue                       func(context.Context) (*Node, error)
+	predicates                     []predicate.Node
+}
+
+var _ ent.Mutation = (*NodeMutation)(nil)
+
+// nodeOption allows management of the mutation configuration using functional options.
+type nodeOption func(*NodeMutation)
+
+// newNodeMutation creates new mutation for the Node entity.
+func newNodeMutation(c config, op Op, opts ...nodeOption) *NodeMutation {
+	m := &NodeMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeNode,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withNodeID sets the ID field of the mutation.
+func withNodeID(id string) nodeOption {
+	return func(m *NodeMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Node
+		)
+		m.oldValue = func(ctx context.Context) (*Node, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Node.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withNode sets the old Node of the mutation.
+func withNode(node *Node) nodeOption {
+	return func(m *NodeMutation) {
+		m.oldValue = func(context.Context) (*Node, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m NodeMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m NodeMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Node entities.
+func (m *NodeMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *NodeMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *NodeMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Node.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetParentHash sets the "parent_hash" field.
+func (m *NodeMutation) SetParentHash(s string) {
+	m.parent = &s
+}
+
+// ParentHash returns the value of the "parent_hash" field in the mutation.
+func (m *NodeMutation) ParentHash() (r string, exists bool) {
+	v := m.parent
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldParentHash returns the old "parent_hash" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldParentHash(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldParentHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldParentHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldParentHash: %w", err)
+	}
+	return oldValue.ParentHash, nil
+}
+
+// ClearParentHash clears the value of the "parent_hash" field.
+func (m *NodeMutation) ClearParentHash() {
+	m.parent = nil
+	m.clearedFields[node.FieldParentHash] = struct{}{}
+}
+
+// ParentHashCleared returns if the "parent_hash" field was cleared in this mutation.
+func (m *NodeMutation) ParentHashCleared() bool {
+	_, ok := m.clearedFields[node.FieldParentHash]
+	return ok
+}
+
+// ResetParentHash resets all changes to the "parent_hash" field.
+func (m *NodeMutation) ResetParentHash() {
+	m.parent = nil
+	delete(m.clearedFields, node.FieldParentHash)
+}
+
+// SetBucket sets the "bucket" field.
+func (m *NodeMutation) SetBucket(value map[string]interface{}) {
+	m.bucket = &value
+}
+
+// Bucket returns the value of the "bucket" field in the mutation.
+func (m *NodeMutation) Bucket() (r map[string]interface{}, exists bool) {
+	v := m.bucket
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldBucket returns the old "bucket" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldBucket(ctx context.Context) (v map[string]interface{}, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldBucket is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldBucket requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldBucket: %w", err)
+	}
+	return oldValue.Bucket, nil
+}
+
+// ClearBucket clears the value of the "bucket" field.
+func (m *NodeMutation) ClearBucket() {
+	m.bucket = nil
+	m.clearedFields[node.FieldBucket] = struct{}{}
+}
+
+// BucketCleared returns if the "bucket" field was cleared in this mutation.
+func (m *NodeMutation) BucketCleared() bool {
+	_, ok := m.clearedFields[node.FieldBucket]
+	return ok
+}
+
+// ResetBucket resets all changes to the "bucket" field.
+func (m *NodeMutation) ResetBucket() {
+	m.bucket = nil
+	delete(m.clearedFields, node.FieldBucket)
+}
+
+// SetType sets the "type" field.
+func (m *NodeMutation) SetType(s string) {
+	m._type = &s
+}
+
+// GetType returns the value of the "type" field in the mutation.
+func (m *NodeMutation) GetType() (r string, exists bool) {
+	v := m._type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldType returns the old "type" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
+	}
+	return oldValue.Type, nil
+}
+
+// ClearType clears the value of the "type" field.
+func (m *NodeMutation) ClearType() {
+	m._type = nil
+	m.clearedFields[node.FieldType] = struct{}{}
+}
+
+// TypeCleared returns if the "type" field was cleared in this mutation.
+func (m *NodeMutation) TypeCleared() bool {
+	_, ok := m.clearedFields[node.FieldType]
+	return ok
+}
+
+// ResetType resets all changes to the "type" field.
+func (m *NodeMutation) ResetType() {
+	m._type = nil
+	delete(m.clearedFields, node.FieldType)
+}
+
+// SetRole sets the "role" field.
+func (m *NodeMutation) SetRole(s string) {
+	m.role = &s
+}
+
+// Role returns the value of the "role" field in the mutation.
+func (m *NodeMutation) Role() (r string, exists bool) {
+	v := m.role
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRole returns the old "role" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldRole(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRole is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRole requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRole: %w", err)
+	}
+	return oldValue.Role, nil
+}
+
+// ClearRole clears the value of the "role" field.
+func (m *NodeMutation) ClearRole() {
+	m.role = nil
+	m.clearedFields[node.FieldRole] = struct{}{}
+}
+
+// RoleCleared returns if the "role" field was cleared in this mutation.
+func (m *NodeMutation) RoleCleared() bool {
+	_, ok := m.clearedFields[node.FieldRole]
+	return ok
+}
+
+// ResetRole resets all changes to the "role" field.
+func (m *NodeMutation) ResetRole() {
+	m.role = nil
+	delete(m.clearedFields, node.FieldRole)
+}
+
+// SetContent sets the "content" field.
+func (m *NodeMutation) SetContent(value []map[string]interface{}) {
+	m.content = &value
+	m.appendcontent = nil
+}
+
+// Content returns the value of the "content" field in the mutation.
+func (m *NodeMutation) Content() (r []map[string]interface{}, exists bool) {
+	v := m.content
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldContent returns the old "content" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldContent(ctx context.Context) (v []map[string]interface{}, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldContent is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldContent requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldContent: %w", err)
+	}
+	return oldValue.Content, nil
+}
+
+// AppendContent adds value to the "content" field.
+func (m *NodeMutation) AppendContent(value []map[string]interface{}) {
+	m.appendcontent = append(m.appendcontent, value...)
+}
+
+// AppendedContent returns the list of values that were appended to the "content" field in this mutation.
+func (m *NodeMutation) AppendedContent() ([]map[string]interface{}, bool) {
+	if len(m.appendcontent) == 0 {
+		return nil, false
+	}
+	return m.appendcontent, true
+}
+
+// ClearContent clears the value of the "content" field.
+func (m *NodeMutation) ClearContent() {
+	m.content = nil
+	m.appendcontent = nil
+	m.clearedFields[node.FieldContent] = struct{}{}
+}
+
+// ContentCleared returns if the "content" field was cleared in this mutation.
+func (m *NodeMutation) ContentCleared() bool {
+	_, ok := m.clearedFields[node.FieldContent]
+	return ok
+}
+
+// ResetContent resets all changes to the "content" field.
+func (m *NodeMutation) ResetContent() {
+	m.content = nil
+	m.appendcontent = nil
+	delete(m.clearedFields, node.FieldContent)
+}
+
+// SetModel sets the "model" field.
+func (m *NodeMutation) SetModel(s string) {
+	m.model = &s
+}
+
+// Model returns the value of the "model" field in the mutation.
+func (m *NodeMutation) Model() (r string, exists bool) {
+	v := m.model
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldModel returns the old "model" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldModel(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldModel is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldModel requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldModel: %w", err)
+	}
+	return oldValue.Model, nil
+}
+
+// ClearModel clears the value of the "model" field.
+func (m *NodeMutation) ClearModel() {
+	m.model = nil
+	m.clearedFields[node.FieldModel] = struct{}{}
+}
+
+// ModelCleared returns if the "model" field was cleared in this mutation.
+func (m *NodeMutation) ModelCleared() bool {
+	_, ok := m.clearedFields[node.FieldModel]
+	return ok
+}
+
+// ResetModel resets all changes to the "model" field.
+func (m *NodeMutation) ResetModel() {
+	m.model = nil
+	delete(m.clearedFields, node.FieldModel)
+}
+
+// SetProvider sets the "provider" field.
+func (m *NodeMutation) SetProvider(s string) {
+	m.provider = &s
+}
+
+// Provider returns the value of the "provider" field in the mutation.
+func (m *NodeMutation) Provider() (r string, exists bool) {
+	v := m.provider
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProvider returns the old "provider" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldProvider(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProvider is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProvider requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProvider: %w", err)
+	}
+	return oldValue.Provider, nil
+}
+
+// ClearProvider clears the value of the "provider" field.
+func (m *NodeMutation) ClearProvider() {
+	m.provider = nil
+	m.clearedFields[node.FieldProvider] = struct{}{}
+}
+
+// ProviderCleared returns if the "provider" field was cleared in this mutation.
+func (m *NodeMutation) ProviderCleared() bool {
+	_, ok := m.clearedFields[node.FieldProvider]
+	return ok
+}
+
+// ResetProvider resets all changes to the "provider" field.
+func (m *NodeMutation) ResetProvider() {
+	m.provider = nil
+	delete(m.clearedFields, node.FieldProvider)
+}
+
+// SetAgentName sets the "agent_name" field.
+func (m *NodeMutation) SetAgentName(s string) {
+	m.agent_name = &s
+}
+
+// AgentName returns the value of the "agent_name" field in the mutation.
+func (m *NodeMutation) AgentName() (r string, exists bool) {
+	v := m.agent_name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAgentName returns the old "agent_name" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldAgentName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAgentName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAgentName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAgentName: %w", err)
+	}
+	return oldValue.AgentName, nil
+}
+
+// ClearAgentName clears the value of the "agent_name" field.
+func (m *NodeMutation) ClearAgentName() {
+	m.agent_name = nil
+	m.clearedFields[node.FieldAgentName] = struct{}{}
+}
+
+// AgentNameCleared returns if the "agent_name" field was cleared in this mutation.
+func (m *NodeMutation) AgentNameCleared() bool {
+	_, ok := m.clearedFields[node.FieldAgentName]
+	return ok
+}
+
+// ResetAgentName resets all changes to the "agent_name" field.
+func (m *NodeMutation) ResetAgentName() {
+	m.agent_name = nil
+	delete(m.clearedFields, node.FieldAgentName)
+}
+
+// SetSessionID sets the "session_id" field.
+func (m *NodeMutation) SetSessionID(s string) {
+	m.session_id = &s
+}
+
+// SessionID returns the value of the "session_id" field in the mutation.
+func (m *NodeMutation) SessionID() (r string, exists bool) {
+	v := m.session_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSessionID returns the old "session_id" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldSessionID(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSessionID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
+	}
+	return oldValue.SessionID, nil
+}
+
+// ClearSessionID clears the value of the "session_id" field.
+func (m *NodeMutation) ClearSessionID() {
+	m.session_id = nil
+	m.clearedFields[node.FieldSessionID] = struct{}{}
+}
+
+// SessionIDCleared returns if the "session_id" field was cleared in this mutation.
+func (m *NodeMutation) SessionIDCleared() bool {
+	_, ok := m.clearedFields[node.FieldSessionID]
+	return ok
+}
+
+// ResetSessionID resets all changes to the "session_id" field.
+func (m *NodeMutation) ResetSessionID() {
+	m.session_id = nil
+	delete(m.clearedFields, node.FieldSessionID)
+}
+
+// SetStopReason sets the "stop_reason" field.
+func (m *NodeMutation) SetStopReason(s string) {
+	m.stop_reason = &s
+}
+
+// StopReason returns the value of the "stop_reason" field in the mutation.
+func (m *NodeMutation) StopReason() (r string, exists bool) {
+	v := m.stop_reason
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStopReason returns the old "stop_reason" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldStopReason(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStopReason is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStopReason requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStopReason: %w", err)
+	}
+	return oldValue.StopReason, nil
+}
+
+// ClearStopReason clears the value of the "stop_reason" field.
+func (m *NodeMutation) ClearStopReason() {
+	m.stop_reason = nil
+	m.clearedFields[node.FieldStopReason] = struct{}{}
+}
+
+// StopReasonCleared returns if the "stop_reason" field was cleared in this mutation.
+func (m *NodeMutation) StopReasonCleared() bool {
+	_, ok := m.clearedFields[node.FieldStopReason]
+	return ok
+}
+
+// ResetStopReason resets all changes to the "stop_reason" field.
+func (m *NodeMutation) ResetStopReason() {
+	m.stop_reason = nil
+	delete(m.clearedFields, node.FieldStopReason)
+}
+
+// SetToolNames sets the "tool_names" field.
+func (m *NodeMutation) SetToolNames(s string) {
+	m.tool_names = &s
+}
+
+// ToolNames returns the value of the "tool_names" field in the mutation.
+func (m *NodeMutation) ToolNames() (r string, exists bool) {
+	v := m.tool_names
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldToolNames returns the old "tool_names" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldToolNames(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldToolNames is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldToolNames requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldToolNames: %w", err)
+	}
+	return oldValue.ToolNames, nil
+}
+
+// ClearToolNames clears the value of the "tool_names" field.
+func (m *NodeMutation) ClearToolNames() {
+	m.tool_names = nil
+	m.clearedFields[node.FieldToolNames] = struct{}{}
+}
+
+// ToolNamesCleared returns if the "tool_names" field was cleared in this mutation.
+func (m *NodeMutation) ToolNamesCleared() bool {
+	_, ok := m.clearedFields[node.FieldToolNames]
+	return ok
+}
+
+// ResetToolNames resets all changes to the "tool_names" field.
+func (m *NodeMutation) ResetToolNames() {
+	m.tool_names = nil
+	delete(m.clearedFields, node.FieldToolNames)
+}
+
+// SetPromptTokens sets the "prompt_tokens" field.
+func (m *NodeMutation) SetPromptTokens(i int) {
+	m.prompt_tokens = &i
+	m.addprompt_tokens = nil
+}
+
+// PromptTokens returns the value of the "prompt_tokens" field in the mutation.
+func (m *NodeMutation) PromptTokens() (r int, exists bool) {
+	v := m.prompt_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPromptTokens returns the old "prompt_tokens" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldPromptTokens(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPromptTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPromptTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPromptTokens: %w", err)
+	}
+	return oldValue.PromptTokens, nil
+}
+
+// AddPromptTokens adds i to the "prompt_tokens" field.
+func (m *NodeMutation) AddPromptTokens(i int) {
+	if m.addprompt_tokens != nil {
+		*m.addprompt_tokens += i
+	} else {
+		m.addprompt_tokens = &i
+	}
+}
+
+// AddedPromptTokens returns the value that was added to the "prompt_tokens" field in this mutation.
+func (m *NodeMutation) AddedPromptTokens() (r int, exists bool) {
+	v := m.addprompt_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearPromptTokens clears the value of the "prompt_tokens" field.
+func (m *NodeMutation) ClearPromptTokens() {
+	m.prompt_tokens = nil
+	m.addprompt_tokens = nil
+	m.clearedFields[node.FieldPromptTokens] = struct{}{}
+}
+
+// PromptTokensCleared returns if the "prompt_tokens" field was cleared in this mutation.
+func (m *NodeMutation) PromptTokensCleared() bool {
+	_, ok := m.clearedFields[node.FieldPromptTokens]
+	return ok
+}
+
+// ResetPromptTokens resets all changes to the "prompt_tokens" field.
+func (m *NodeMutation) ResetPromptTokens() {
+	m.prompt_tokens = nil
+	m.addprompt_tokens = nil
+	delete(m.clearedFields, node.FieldPromptTokens)
+}
+
+// SetCompletionTokens sets the "completion_tokens" field.
+func (m *NodeMutation) SetCompletionTokens(i int) {
+	m.completion_tokens = &i
+	m.addcompletion_tokens = nil
+}
+
+// CompletionTokens returns the value of the "completion_tokens" field in the mutation.
+func (m *NodeMutation) CompletionTokens() (r int, exists bool) {
+	v := m.completion_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCompletionTokens returns the old "completion_tokens" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldCompletionTokens(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCompletionTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCompletionTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCompletionTokens: %w", err)
+	}
+	return oldValue.CompletionTokens, nil
+}
+
+// AddCompletionTokens adds i to the "completion_tokens" field.
+func (m *NodeMutation) AddCompletionTokens(i int) {
+	if m.addcompletion_tokens != nil {
+		*m.addcompletion_tokens += i
+	} else {
+		m.addcompletion_tokens = &i
+	}
+}
+
+// AddedCompletionTokens returns the value that was added to the "completion_tokens" field in this mutation.
+func (m *NodeMutation) AddedCompletionTokens() (r int, exists bool) {
+	v := m.addcompletion_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearCompletionTokens clears the value of the "completion_tokens" field.
+func (m *NodeMutation) ClearCompletionTokens() {
+	m.completion_tokens = nil
+	m.addcompletion_tokens = nil
+	m.clearedFields[node.FieldCompletionTokens] = struct{}{}
+}
+
+// CompletionTokensCleared returns if the "completion_tokens" field was cleared in this mutation.
+func (m *NodeMutation) CompletionTokensCleared() bool {
+	_, ok := m.clearedFields[node.FieldCompletionTokens]
+	return ok
+}
+
+// ResetCompletionTokens resets all changes to the "completion_tokens" field.
+func (m *NodeMutation) ResetCompletionTokens() {
+	m.completion_tokens = nil
+	m.addcompletion_tokens = nil
+	delete(m.clearedFields, node.FieldCompletionTokens)
+}
+
+// SetTotalTokens sets the "total_tokens" field.
+func (m *NodeMutation) SetTotalTokens(i int) {
+	m.total_tokens = &i
+	m.addtotal_tokens = nil
+}
+
+// TotalTokens returns the value of the "total_tokens" field in the mutation.
+func (m *NodeMutation) TotalTokens() (r int, exists bool) {
+	v := m.total_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTotalTokens returns the old "total_tokens" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldTotalTokens(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTotalTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTotalTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTotalTokens: %w", err)
+	}
+	return oldValue.TotalTokens, nil
+}
+
+// AddTotalTokens adds i to the "total_tokens" field.
+func (m *NodeMutation) AddTotalTokens(i int) {
+	if m.addtotal_tokens != nil {
+		*m.addtotal_tokens += i
+	} else {
+		m.addtotal_tokens = &i
+	}
+}
+
+// AddedTotalTokens returns the value that was added to the "total_tokens" field in this mutation.
+func (m *NodeMutation) AddedTotalTokens() (r int, exists bool) {
+	v := m.addtotal_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearTotalTokens clears the value of the "total_tokens" field.
+func (m *NodeMutation) ClearTotalTokens() {
+	m.total_tokens = nil
+	m.addtotal_tokens = nil
+	m.clearedFields[node.FieldTotalTokens] = struct{}{}
+}
+
+// TotalTokensCleared returns if the "total_tokens" field was cleared in this mutation.
+func (m *NodeMutation) TotalTokensCleared() bool {
+	_, ok := m.clearedFields[node.FieldTotalTokens]
+	return ok
+}
+
+// ResetTotalTokens resets all changes to the "total_tokens" field.
+func (m *NodeMutation) ResetTotalTokens() {
+	m.total_tokens = nil
+	m.addtotal_tokens = nil
+	delete(m.clearedFields, node.FieldTotalTokens)
+}
+
+// SetCacheCreationInputTokens sets the "cache_creation_input_tokens" field.
+func (m *NodeMutation) SetCacheCreationInputTokens(i int) {
+	m.cache_creation_input_tokens = &i
+	m.addcache_creation_input_tokens = nil
+}
+
+// CacheCreationInputTokens returns the value of the "cache_creation_input_tokens" field in the mutation.
+func (m *NodeMutation) CacheCreationInputTokens() (r int, exists bool) {
+	v := m.cache_creation_input_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCacheCreationInputTokens returns the old "cache_creation_input_tokens" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldCacheCreationInputTokens(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCacheCreationInputTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCacheCreationInputTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCacheCreationInputTokens: %w", err)
+	}
+	return oldValue.CacheCreationInputTokens, nil
+}
+
+// AddCacheCreationInputTokens adds i to the "cache_creation_input_tokens" field.
+func (m *NodeMutation) AddCacheCreationInputTokens(i int) {
+	if m.addcache_creation_input_tokens != nil {
+		*m.addcache_creation_input_tokens += i
+	} else {
+		m.addcache_creation_input_tokens = &i
+	}
+}
+
+// AddedCacheCreationInputTokens returns the value that was added to the "cache_creation_input_tokens" field in this mutation.
+func (m *NodeMutation) AddedCacheCreationInputTokens() (r int, exists bool) {
+	v := m.addcache_creation_input_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearCacheCreationInputTokens clears the value of the "cache_creation_input_tokens" field.
+func (m *NodeMutation) ClearCacheCreationInputTokens() {
+	m.cache_creation_input_tokens = nil
+	m.addcache_creation_input_tokens = nil
+	m.clearedFields[node.FieldCacheCreationInputTokens] = struct{}{}
+}
+
+// CacheCreationInputTokensCleared returns if the "cache_creation_input_tokens" field was cleared in this mutation.
+func (m *NodeMutation) CacheCreationInputTokensCleared() bool {
+	_, ok := m.clearedFields[node.FieldCacheCreationInputTokens]
+	return ok
+}
+
+// ResetCacheCreationInputTokens resets all changes to the "cache_creation_input_tokens" field.
+func (m *NodeMutation) ResetCacheCreationInputTokens() {
+	m.cache_creation_input_tokens = nil
+	m.addcache_creation_input_tokens = nil
+	delete(m.clearedFields, node.FieldCacheCreationInputTokens)
+}
+
+// SetCacheReadInputTokens sets the "cache_read_input_tokens" field.
+func (m *NodeMutation) SetCacheReadInputTokens(i int) {
+	m.cache_read_input_tokens = &i
+	m.addcache_read_input_tokens = nil
+}
+
+// CacheReadInputTokens returns the value of the "cache_read_input_tokens" field in the mutation.
+func (m *NodeMutation) CacheReadInputTokens() (r int, exists bool) {
+	v := m.cache_read_input_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCacheReadInputTokens returns the old "cache_read_input_tokens" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldCacheReadInputTokens(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCacheReadInputTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCacheReadInputTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCacheReadInputTokens: %w", err)
+	}
+	return oldValue.CacheReadInputTokens, nil
+}
+
+// AddCacheReadInputTokens adds i to the "cache_read_input_tokens" field.
+func (m *NodeMutation) AddCacheReadInputTokens(i int) {
+	if m.addcache_read_input_tokens != nil {
+		*m.addcache_read_input_tokens += i
+	} else {
+		m.addcache_read_input_tokens = &i
+	}
+}
+
+// AddedCacheReadInputTokens returns the value that was added to the "cache_read_input_tokens" field in this mutation.
+func (m *NodeMutation) AddedCacheReadInputTokens() (r int, exists bool) {
+	v := m.addcache_read_input_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearCacheReadInputTokens clears the value of the "cache_read_input_tokens" field.
+func (m *NodeMutation) ClearCacheReadInputTokens() {
+	m.cache_read_input_tokens = nil
+	m.addcache_read_input_tokens = nil
+	m.clearedFields[node.FieldCacheReadInputTokens] = struct{}{}
+}
+
+// CacheReadInputTokensCleared returns if the "cache_read_input_tokens" field was cleared in this mutation.
+func (m *NodeMutation) CacheReadInputTokensCleared() bool {
+	_, ok := m.clearedFields[node.FieldCacheReadInputTokens]
+	return ok
+}
+
+// ResetCacheReadInputTokens resets all changes to the "cache_read_input_tokens" field.
+func (m *NodeMutation) ResetCacheReadInputTokens() {
+	m.cache_read_input_tokens = nil
+	m.addcache_read_input_tokens = nil
+	delete(m.clearedFields, node.FieldCacheReadInputTokens)
+}
+
+// SetReasoningTokens sets the "reasoning_tokens" field.
+func (m *NodeMutation) SetReasoningTokens(i int) {
+	m.reasoning_tokens = &i
+	m.addreasoning_tokens = nil
+}
+
+// ReasoningTokens returns the value of the "reasoning_tokens" field in the mutation.
+func (m *NodeMutation) ReasoningTokens() (r int, exists bool) {
+	v := m.reasoning_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldReasoningTokens returns the old "reasoning_tokens" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldReasoningTokens(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldReasoningTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldReasoningTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldReasoningTokens: %w", err)
+	}
+	return oldValue.ReasoningTokens, nil
+}
+
+// AddReasoningTokens adds i to the "reasoning_tokens" field.
+func (m *NodeMutation) AddReasoningTokens(i int) {
+	if m.addreasoning_tokens != nil {
+		*m.addreasoning_tokens += i
+	} else {
+		m.addreasoning_tokens = &i
+	}
+}
+
+// AddedReasoningTokens returns the value that was added to the "reasoning_tokens" field in this mutation.
+func (m *NodeMutation) AddedReasoningTokens() (r int, exists bool) {
+	v := m.addreasoning_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearReasoningTokens clears the value of the "reasoning_tokens" field.
+func (m *NodeMutation) ClearReasoningTokens() {
+	m.reasoning_tokens = nil
+	m.addreasoning_tokens = nil
+	m.clearedFields[node.FieldReasoningTokens] = struct{}{}
+}
+
+// ReasoningTokensCleared returns if the "reasoning_tokens" field was cleared in this mutation.
+func (m *NodeMutation) ReasoningTokensCleared() bool {
+	_, ok := m.clearedFields[node.FieldReasoningTokens]
+	return ok
+}
+
+// ResetReasoningTokens resets all changes to the "reasoning_tokens" field.
+func (m *NodeMutation) ResetReasoningTokens() {
+	m.reasoning_tokens = nil
+	m.addreasoning_tokens = nil
+	delete(m.clearedFields, node.FieldReasoningTokens)
+}
+
+// SetTotalDurationNs sets the "total_duration_ns" field.
+func (m *NodeMutation) SetTotalDurationNs(i int64) {
+	m.total_duration_ns = &i
+	m.addtotal_duration_ns = nil
+}
+
+// TotalDurationNs returns the value of the "total_duration_ns" field in the mutation.
+func (m *NodeMutation) TotalDurationNs() (r int64, exists bool) {
+	v := m.total_duration_ns
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTotalDurationNs returns the old "total_duration_ns" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldTotalDurationNs(ctx context.Context) (v *int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTotalDurationNs is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTotalDurationNs requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTotalDurationNs: %w", err)
+	}
+	return oldValue.TotalDurationNs, nil
+}
+
+// AddTotalDurationNs adds i to the "total_duration_ns" field.
+func (m *NodeMutation) AddTotalDurationNs(i int64) {
+	if m.addtotal_duration_ns != nil {
+		*m.addtotal_duration_ns += i
+	} else {
+		m.addtotal_duration_ns = &i
+	}
+}
+
+// AddedTotalDurationNs returns the value that was added to the "total_duration_ns" field in this mutation.
+func (m *NodeMutation) AddedTotalDurationNs() (r int64, exists bool) {
+	v := m.addtotal_duration_ns
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearTotalDurationNs clears the value of the "total_duration_ns" field.
+func (m *NodeMutation) ClearTotalDurationNs() {
+	m.total_duration_ns = nil
+	m.addtotal_duration_ns = nil
+	m.clearedFields[node.FieldTotalDurationNs] = struct{}{}
+}
+
+// TotalDurationNsCleared returns if the "total_duration_ns" field was cleared in this mutation.
+func (m *NodeMutation) TotalDurationNsCleared() bool {
+	_, ok := m.clearedFields[node.FieldTotalDurationNs]
+	return ok
+}
+
+// ResetTotalDurationNs resets all changes to the "total_duration_ns" field.
+func (m *NodeMutation) ResetTotalDurationNs() {
+	m.total_duration_ns = nil
+	m.addtotal_duration_ns = nil
+	delete(m.clearedFields, node.FieldTotalDurationNs)
+}
+
+// SetPromptDurationNs sets the "prompt_duration_ns" field.
+func (m *NodeMutation) SetPromptDurationNs(i int64) {
+	m.prompt_duration_ns = &i
+	m.addprompt_duration_ns = nil
+}
+
+// PromptDurationNs returns the value of the "prompt_duration_ns" field in the mutation.
+func (m *NodeMutation) PromptDurationNs() (r int64, exists bool) {
+	v := m.prompt_duration_ns
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPromptDurationNs returns the old "prompt_duration_ns" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldPromptDurationNs(ctx context.Context) (v *int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPromptDurationNs is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPromptDurationNs requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPromptDurationNs: %w", err)
+	}
+	return oldValue.PromptDurationNs, nil
+}
+
+// AddPromptDurationNs adds i to the "prompt_duration_ns" field.
+func (m *NodeMutation) AddPromptDurationNs(i int64) {
+	if m.addprompt_duration_ns != nil {
+		*m.addprompt_duration_ns += i
+	} else {
+		m.addprompt_duration_ns = &i
+	}
+}
+
+// AddedPromptDurationNs returns the value that was added to the "prompt_duration_ns" field in this mutation.
+func (m *NodeMutation) AddedPromptDurationNs() (r int64, exists bool) {
+	v := m.addprompt_duration_ns
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearPromptDurationNs clears the value of the "prompt_duration_ns" field.
+func (m *NodeMutation) ClearPromptDurationNs() {
+	m.prompt_duration_ns = nil
+	m.addprompt_duration_ns = nil
+	m.clearedFields[node.FieldPromptDurationNs] = struct{}{}
+}
+
+// PromptDurationNsCleared returns if the "prompt_duration_ns" field was cleared in this mutation.
+func (m *NodeMutation) PromptDurationNsCleared() bool {
+	_, ok := m.clearedFields[node.FieldPromptDurationNs]
+	return ok
+}
+
+// ResetPromptDurationNs resets all changes to the "prompt_duration_ns" field.
+func (m *NodeMutation) ResetPromptDurationNs() {
+	m.prompt_duration_ns = nil
+	m.addprompt_duration_ns = nil
+	delete(m.clearedFields, node.FieldPromptDurationNs)
+}
+
+// SetUsageEstimated sets the "usage_estimated" field.
+func (m *NodeMutation) SetUsageEstimated(b bool) {
+	m.usage_estimated = &b
+}
+
+// UsageEstimated returns the value of the "usage_estimated" field in the mutation.
+func (m *NodeMutation) UsageEstimated() (r bool, exists bool) {
+	v := m.usage_estimated
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUsageEstimated returns the old "usage_estimated" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldUsageEstimated(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUsageEstimated is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUsageEstimated requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUsageEstimated: %w", err)
+	}
+	return oldValue.UsageEstimated, nil
+}
+
+// ClearUsageEstimated clears the value of the "usage_estimated" field.
+func (m *NodeMutation) ClearUsageEstimated() {
+	m.usage_estimated = nil
+	m.clearedFields[node.FieldUsageEstimated] = struct{}{}
+}
+
+// UsageEstimatedCleared returns if the "usage_estimated" field was cleared in this mutation.
+func (m *NodeMutation) UsageEstimatedCleared() bool {
+	_, ok := m.clearedFields[node.FieldUsageEstimated]
+	return ok
+}
+
+// ResetUsageEstimated resets all changes to the "usage_estimated" field.
+func (m *NodeMutation) ResetUsageEstimated() {
+	m.usage_estimated = nil
+	delete(m.clearedFields, node.FieldUsageEstimated)
+}
+
+// SetRequestStartedAt sets the "request_started_at" field.
+func (m *NodeMutation) SetRequestStartedAt(t time.Time) {
+	m.request_started_at = &t
+}
+
+// RequestStartedAt returns the value of the "request_started_at" field in the mutation.
+func (m *NodeMutation) RequestStartedAt() (r time.Time, exists bool) {
+	v := m.request_started_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRequestStartedAt returns the old "request_started_at" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldRequestStartedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRequestStartedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRequestStartedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRequestStartedAt: %w", err)
+	}
+	return oldValue.RequestStartedAt, nil
+}
+
+// ClearRequestStartedAt clears the value of the "request_started_at" field.
+func (m *NodeMutation) ClearRequestStartedAt() {
+	m.request_started_at = nil
+	m.clearedFields[node.FieldRequestStartedAt] = struct{}{}
+}
+
+// RequestStartedAtCleared returns if the "request_started_at" field was cleared in this mutation.
+func (m *NodeMutation) RequestStartedAtCleared() bool {
+	_, ok := m.clearedFields[node.FieldRequestStartedAt]
+	return ok
+}
+
+// ResetRequestStartedAt resets all changes to the "request_started_at" field.
+func (m *NodeMutation) ResetRequestStartedAt() {
+	m.request_started_at = nil
+	delete(m.clearedFields, node.FieldRequestStartedAt)
+}
+
+// SetFirstChunkAt sets the "first_chunk_at" field.
+func (m *NodeMutation) SetFirstChunkAt(t time.Time) {
+	m.first_chunk_at = &t
+}
+
+// FirstChunkAt returns the value of the "first_chunk_at" field in the mutation.
+func (m *NodeMutation) FirstChunkAt() (r time.Time, exists bool) {
+	v := m.first_chunk_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFirstChunkAt returns the old "first_chunk_at" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldFirstChunkAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFirstChunkAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFirstChunkAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFirstChunkAt: %w", err)
+	}
+	return oldValue.FirstChunkAt, nil
+}
+
+// ClearFirstChunkAt clears the value of the "first_chunk_at" field.
+func (m *NodeMutation) ClearFirstChunkAt() {
+	m.first_chunk_at = nil
+	m.clearedFields[node.FieldFirstChunkAt] = struct{}{}
+}
+
+// FirstChunkAtCleared returns if the "first_chunk_at" field was cleared in this mutation.
+func (m *NodeMutation) FirstChunkAtCleared() bool {
+	_, ok := m.clearedFields[node.FieldFirstChunkAt]
+	return ok
+}
+
+// ResetFirstChunkAt resets all changes to the "first_chunk_at" field.
+func (m *NodeMutation) ResetFirstChunkAt() {
+	m.first_chunk_at = nil
+	delete(m.clearedFields, node.FieldFirstChunkAt)
+}
+
+// SetResponseCompletedAt sets the "response_completed_at" field.
+func (m *NodeMutation) SetResponseCompletedAt(t time.Time) {
+	m.response_completed_at = &t
+}
+
+// ResponseCompletedAt returns the value of the "response_completed_at" field in the mutation.
+func (m *NodeMutation) ResponseCompletedAt() (r time.Time, exists bool) {
+	v := m.response_completed_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResponseCompletedAt returns the old "response_completed_at" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldResponseCompletedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResponseCompletedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResponseCompletedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResponseCompletedAt: %w", err)
+	}
+	return oldValue.ResponseCompletedAt, nil
+}
+
+// ClearResponseCompletedAt clears the value of the "response_completed_at" field.
+func (m *NodeMutation) ClearResponseCompletedAt() {
+	m.response_completed_at = nil
+	m.clearedFields[node.FieldResponseCompletedAt] = struct{}{}
+}
+
+// ResponseCompletedAtCleared returns if the "response_completed_at" field was cleared in this mutation.
+func (m *NodeMutation) ResponseCompletedAtCleared() bool {
+	_, ok := m.clearedFields[node.FieldResponseCompletedAt]
+	return ok
+}
+
+// ResetResponseCompletedAt resets all changes to the "response_completed_at" field.
+func (m *NodeMutation) ResetResponseCompletedAt() {
+	m.response_completed_at = nil
+	delete(m.clearedFields, node.FieldResponseCompletedAt)
+}
+
+// SetErrorStatus sets the "error_status" field.
+func (m *NodeMutation) SetErrorStatus(i int) {
+	m.error_status = &i
+	m.adderror_status = nil
+}
+
+// ErrorStatus returns the value of the "error_status" field in the mutation.
+func (m *NodeMutation) ErrorStatus() (r int, exists bool) {
+	v := m.error_status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldErrorStatus returns the old "error_status" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldErrorStatus(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldErrorStatus is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldErrorStatus requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldErrorStatus: %w", err)
+	}
+	return oldValue.ErrorStatus, nil
+}
+
+// AddErrorStatus adds i to the "error_status" field.
+func (m *NodeMutation) AddErrorStatus(i int) {
+	if m.adderror_status != nil {
+		*m.adderror_status += i
+	} else {
+		m.adderror_status = &i
+	}
+}
+
+// AddedErrorStatus returns the value that was added to the "error_status" field in this mutation.
+func (m *NodeMutation) AddedErrorStatus() (r int, exists bool) {
+	v := m.adderror_status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearErrorStatus clears the value of the "error_status" field.
+func (m *NodeMutation) ClearErrorStatus() {
+	m.error_status = nil
+	m.adderror_status = nil
+	m.clearedFields[node.FieldErrorStatus] = struct{}{}
+}
+
+// ErrorStatusCleared returns if the "error_status" field was cleared in this mutation.
+func (m *NodeMutation) ErrorStatusCleared() bool {
+	_, ok := m.clearedFields[node.FieldErrorStatus]
+	return ok
+}
+
+// ResetErrorStatus resets all changes to the "error_status" field.
+func (m *NodeMutation) ResetErrorStatus() {
+	m.error_status = nil
+	m.adderror_status = nil
+	delete(m.clearedFields, node.FieldErrorStatus)
+}
+
+// SetErrorType sets the "error_type" field.
+func (m *NodeMutation) SetErrorType(s string) {
+	m.error_type = &s
+}
+
+// ErrorType returns the value of the "error_type" field in the mutation.
+func (m *NodeMutation) ErrorType() (r string, exists bool) {
+	v := m.error_type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldErrorType returns the old "error_type" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldErrorType(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldErrorType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldErrorType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldErrorType: %w", err)
+	}
+	return oldValue.ErrorType, nil
+}
+
+// ClearErrorType clears the value of the "error_type" field.
+func (m *NodeMutation) ClearErrorType() {
+	m.error_type = nil
+	m.clearedFields[node.FieldErrorType] = struct{}{}
+}
+
+// ErrorTypeCleared returns if the "error_type" field was cleared in this mutation.
+func (m *NodeMutation) ErrorTypeCleared() bool {
+	_, ok := m.clearedFields[node.FieldErrorType]
+	return ok
+}
+
+// ResetErrorType resets all changes to the "error_type" field.
+func (m *NodeMutation) ResetErrorType() {
+	m.error_type = nil
+	delete(m.clearedFields, node.FieldErrorType)
+}
+
+// SetErrorMessage sets the "error_message" field.
+func (m *NodeMutation) SetErrorMessage(s string) {
+	m.error_message = &s
+}
+
+// ErrorMessage returns the value of the "error_message" field in the mutation.
+func (m *NodeMutation) ErrorMessage() (r string, exists bool) {
+	v := m.error_message
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldErrorMessage returns the old "error_message" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldErrorMessage(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldErrorMessage is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldErrorMessage requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldErrorMessage: %w", err)
+	}
+	return oldValue.ErrorMessage, nil
+}
+
+// ClearErrorMessage clears the value of the "error_message" field.
+func (m *NodeMutation) ClearErrorMessage() {
+	m.error_message = nil
+	m.clearedFields[node.FieldErrorMessage] = struct{}{}
+}
+
+// ErrorMessageCleared returns if the "error_message" field was cleared in this mutation.
+func (m *NodeMutation) ErrorMessageCleared() bool {
+	_, ok := m.clearedFields[node.FieldErrorMessage]
+	return ok
+}
+
+// ResetErrorMessage resets all changes to the "error_message" field.
+func (m *NodeMutation) ResetErrorMessage() {
+	m.error_message = nil
+	delete(m.clearedFields, node.FieldErrorMessage)
+}
+
+// SetRetryAfterSeconds sets the "retry_after_seconds" field.
+func (m *NodeMutation) SetRetryAfterSeconds(i int) {
+	m.retry_after_seconds = &i
+	m.addretry_after_seconds = nil
+}
+
+// RetryAfterSeconds returns the value of the "retry_after_seconds" field in the mutation.
+func (m *NodeMutation) RetryAfterSeconds() (r int, exists bool) {
+	v := m.retry_after_seconds
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRetryAfterSeconds returns the old "retry_after_seconds" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldRetryAfterSeconds(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRetryAfterSeconds is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRetryAfterSeconds requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRetryAfterSeconds: %w", err)
+	}
+	return oldValue.RetryAfterSeconds, nil
+}
+
+// AddRetryAfterSeconds adds i to the "retry_after_seconds" field.
+func (m *NodeMutation) AddRetryAfterSeconds(i int) {
+	if m.addretry_after_seconds != nil {
+		*m.addretry_after_seconds += i
+	} else {
+		m.addretry_after_seconds = &i
+	}
+}
+
+// AddedRetryAfterSeconds returns the value that was added to the "retry_after_seconds" field in this mutation.
+func (m *NodeMutation) AddedRetryAfterSeconds() (r int, exists bool) {
+	v := m.addretry_after_seconds
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearRetryAfterSeconds clears the value of the "retry_after_seconds" field.
+func (m *NodeMutation) ClearRetryAfterSeconds() {
+	m.retry_after_seconds = nil
+	m.addretry_after_seconds = nil
+	m.clearedFields[node.FieldRetryAfterSeconds] = struct{}{}
+}
+
+// RetryAfterSecondsCleared returns if the "retry_after_seconds" field was cleared in this mutation.
+func (m *NodeMutation) RetryAfterSecondsCleared() bool {
+	_, ok := m.clearedFields[node.FieldRetryAfterSeconds]
+	return ok
+}
+
+// ResetRetryAfterSeconds resets all changes to the "retry_after_seconds" field.
+func (m *NodeMutation) ResetRetryAfterSeconds() {
+	m.retry_after_seconds = nil
+	m.addretry_after_seconds = nil
+	delete(m.clearedFields, node.FieldRetryAfterSeconds)
+}
+
+// SetErrorAttempt sets the "error_attempt" field.
+func (m *NodeMutation) SetErrorAttempt(i int) {
+	m.error_attempt = &i
+	m.adderror_attempt = nil
+}
+
+// ErrorAttempt returns the value of the "error_attempt" field in the mutation.
+func (m *NodeMutation) ErrorAttempt() (r int, exists bool) {
+	v := m.error_attempt
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldErrorAttempt returns the old "error_attempt" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldErrorAttempt(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldErrorAttempt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldErrorAttempt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldErrorAttempt: %w", err)
+	}
+	return oldValue.ErrorAttempt, nil
+}
+
+// AddErrorAttempt adds i to the "error_attempt" field.
+func (m *NodeMutation) AddErrorAttempt(i int) {
+	if m.adderror_attempt != nil {
+		*m.adderror_attempt += i
+	} else {
+		m.adderror_attempt = &i
+	}
+}
+
+// AddedErrorAttempt returns the value that was added to the "error_attempt" field in this mutation.
+func (m *NodeMutation) AddedErrorAttempt() (r int, exists bool) {
+	v := m.adderror_attempt
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearErrorAttempt clears the value of the "error_attempt" field.
+func (m *NodeMutation) ClearErrorAttempt() {
+	m.error_attempt = nil
+	m.adderror_attempt = nil
+	m.clearedFields[node.FieldErrorAttempt] = struct{}{}
+}
+
+// ErrorAttemptCleared returns if the "error_attempt" field was cleared in this mutation.
+func (m *NodeMutation) ErrorAttemptCleared() bool {
+	_, ok := m.clearedFields[node.FieldErrorAttempt]
+	return ok
+}
+
+// ResetErrorAttempt resets all changes to the "error_attempt" field.
+func (m *NodeMutation) ResetErrorAttempt() {
+	m.error_attempt = nil
+	m.adderror_attempt = nil
+	delete(m.clearedFields, node.FieldErrorAttempt)
+}
+
+// SetProject sets the "project" field.
+func (m *NodeMutation) SetProject(s string) {
+	m.project = &s
+}
+
+// Project returns the value of the "project" field in the mutation.
+func (m *NodeMutation) Project() (r string, exists bool) {
+	v := m.project
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProject returns the old "project" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldProject(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProject is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProject requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProject: %w", err)
+	}
+	return oldValue.Project, nil
+}
+
+// ClearProject clears the value of the "project" field.
+func (m *NodeMutation) ClearProject() {
+	m.project = nil
+	m.clearedFields[node.FieldProject] = struct{}{}
+}
+
+// ProjectCleared returns if the "project" field was cleared in this mutation.
+func (m *NodeMutation) ProjectCleared() bool {
+	_, ok := m.clearedFields[node.FieldProject]
+	return ok
+}
+
+// ResetProject resets all changes to the "project" field.
+func (m *NodeMutation) ResetProject() {
+	m.project = nil
+	delete(m.clearedFields, node.FieldProject)
+}
+
+// SetUser sets the "user" field.
+func (m *NodeMutation) SetUser(s string) {
+	m.user = &s
+}
+
+// User returns the value of the "user" field in the mutation.
+func (m *NodeMutation) User() (r string, exists bool) {
+	v := m.user
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUser returns the old "user" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldUser(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUser is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUser requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUser: %w", err)
+	}
+	return oldValue.User, nil
+}
+
+// ClearUser clears the value of the "user" field.
+func (m *NodeMutation) ClearUser() {
+	m.user = nil
+	m.clearedFields[node.FieldUser] = struct{}{}
+}
+
+// UserCleared returns if the "user" field was cleared in this mutation.
+func (m *NodeMutation) UserCleared() bool {
+	_, ok := m.clearedFields[node.FieldUser]
+	return ok
+}
+
+// ResetUser resets all changes to the "user" field.
+func (m *NodeMutation) ResetUser() {
+	m.user = nil
+	delete(m.clearedFields, node.FieldUser)
+}
+
+// SetTraceID sets the "trace_id" field.
+func (m *NodeMutation) SetTraceID(s string) {
+	m.trace_id = &s
+}
+
+// TraceID returns the value of the "trace_id" field in the mutation.
+func (m *NodeMutation) TraceID() (r string, exists bool) {
+	v := m.trace_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTraceID returns the old "trace_id" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldTraceID(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTraceID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTraceID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTraceID: %w", err)
+	}
+	return oldValue.TraceID, nil
+}
+
+// ClearTraceID clears the value of the "trace_id" field.
+func (m *NodeMutation) ClearTraceID() {
+	m.trace_id = nil
+	m.clearedFields[node.FieldTraceID] = struct{}{}
+}
+
+// TraceIDCleared returns if the "trace_id" field was cleared in this mutation.
+func (m *NodeMutation) TraceIDCleared() bool {
+	_, ok := m.clearedFields[node.FieldTraceID]
+	return ok
+}
+
+// ResetTraceID resets all changes to the "trace_id" field.
+func (m *NodeMutation) ResetTraceID() {
+	m.trace_id = nil
+	delete(m.clearedFields, node.FieldTraceID)
+}
+
+// SetRunID sets the "run_id" field.
+func (m *NodeMutation) SetRunID(s string) {
+	m.run_id = &s
+}
+
+// RunID returns the value of the "run_id" field in the mutation.
+func (m *NodeMutation) RunID() (r string, exists bool) {
+	v := m.run_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRunID returns the old "run_id" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldRunID(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRunID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRunID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRunID: %w", err)
+	}
+	return oldValue.RunID, nil
+}
+
+// ClearRunID clears the value of the "run_id" field.
+func (m *NodeMutation) ClearRunID() {
+	m.run_id = nil
+	m.clearedFields[node.FieldRunID] = struct{}{}
+}
+
+// RunIDCleared returns if the "run_id" field was cleared in this mutation.
+func (m *NodeMutation) RunIDCleared() bool {
+	_, ok := m.clearedFields[node.FieldRunID]
+	return ok
+}
+
+// ResetRunID resets all changes to the "run_id" field.
+func (m *NodeMutation) ResetRunID() {
+	m.run_id = nil
+	delete(m.clearedFields, node.FieldRunID)
+}
+
+// SetAgentVersion sets the "agent_version" field.
+func (m *NodeMutation) SetAgentVersion(s string) {
+	m.agent_version = &s
+}
+
+// AgentVersion returns the value of the "agent_version" field in the mutation.
+func (m *NodeMutation) AgentVersion() (r string, exists bool) {
+	v := m.agent_version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAgentVersion returns the old "agent_version" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldAgentVersion(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAgentVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAgentVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAgentVersion: %w", err)
+	}
+	return oldValue.AgentVersion, nil
+}
+
+// ClearAgentVersion clears the value of the "agent_version" field.
+func (m *NodeMutation) ClearAgentVersion() {
+	m.agent_version = nil
+	m.clearedFields[node.FieldAgentVersion] = struct{}{}
+}
+
+// AgentVersionCleared returns if the "agent_version" field was cleared in this mutation.
+func (m *NodeMutation) AgentVersionCleared() bool {
+	_, ok := m.clearedFields[node.FieldAgentVersion]
+	return ok
+}
+
+// ResetAgentVersion resets all changes to the "agent_version" field.
+func (m *NodeMutation) ResetAgentVersion() {
+	m.agent_version = nil
+	delete(m.clearedFields, node.FieldAgentVersion)
+}
+
+// SetSystemPromptHash sets the "system_prompt_hash" field.
+func (m *NodeMutation) SetSystemPromptHash(s string) {
+	m.system_prompt_hash = &s
+}
+
+// SystemPromptHash returns the value of the "system_prompt_hash" field in the mutation.
+func (m *NodeMutation) SystemPromptHash() (r string, exists bool) {
+	v := m.system_prompt_hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSystemPromptHash returns the old "system_prompt_hash" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldSystemPromptHash(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSystemPromptHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSystemPromptHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSystemPromptHash: %w", err)
+	}
+	return oldValue.SystemPromptHash, nil
+}
+
+// ClearSystemPromptHash clears the value of the "system_prompt_hash" field.
+func (m *NodeMutation) ClearSystemPromptHash() {
+	m.system_prompt_hash = nil
+	m.clearedFields[node.FieldSystemPromptHash] = struct{}{}
+}
+
+// SystemPromptHashCleared returns if the "system_prompt_hash" field was cleared in this mutation.
+func (m *NodeMutation) SystemPromptHashCleared() bool {
+	_, ok := m.clearedFields[node.FieldSystemPromptHash]
+	return ok
+}
+
+// ResetSystemPromptHash resets all changes to the "system_prompt_hash" field.
+func (m *NodeMutation) ResetSystemPromptHash() {
+	m.system_prompt_hash = nil
+	delete(m.clearedFields, node.FieldSystemPromptHash)
+}
+
+// SetPolicyViolations sets the "policy_violations" field.
+func (m *NodeMutation) SetPolicyViolations(lv []llm.PolicyViolation) {
+	m.policy_violations = &lv
+	m.appendpolicy_violations = nil
+}
+
+// PolicyViolations returns the value of the "policy_violations" field in the mutation.
+func (m *NodeMutation) PolicyViolations() (r []llm.PolicyViolation, exists bool) {
+	v := m.policy_violations
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPolicyViolations returns the old "policy_violations" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldPolicyViolations(ctx context.Context) (v []llm.PolicyViolation, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPolicyViolations is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPolicyViolations requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPolicyViolations: %w", err)
+	}
+	return oldValue.PolicyViolations, nil
+}
+
+// AppendPolicyViolations adds lv to the "policy_violations" field.
+func (m *NodeMutation) AppendPolicyViolations(lv []llm.PolicyViolation) {
+	m.appendpolicy_violations = append(m.appendpolicy_violations, lv...)
+}
+
+// AppendedPolicyViolations returns the list of values that were appended to the "policy_violations" field in this mutation.
+func (m *NodeMutation) AppendedPolicyViolations() ([]llm.PolicyViolation, bool) {
+	if len(m.appendpolicy_violations) == 0 {
+		return nil, false
+	}
+	return m.appendpolicy_violations, true
+}
+
+// ClearPolicyViolations clears the value of the "policy_violations" field.
+func (m *NodeMutation) ClearPolicyViolations() {
+	m.policy_violations = nil
+	m.appendpolicy_violations = nil
+	m.clearedFields[node.FieldPolicyViolations] = struct{}{}
+}
+
+// PolicyViolationsCleared returns if the "policy_violations" field was cleared in this mutation.
+func (m *NodeMutation) PolicyViolationsCleared() bool {
+	_, ok := m.clearedFields[node.FieldPolicyViolations]
+	return ok
+}
+
+// ResetPolicyViolations resets all changes to the "policy_violations" field.
+func (m *NodeMutation) ResetPolicyViolations() {
+	m.policy_violations = nil
+	m.appendpolicy_violations = nil
+	delete(m.clearedFields, node.FieldPolicyViolations)
+}
+
+// SetSignerKey sets the "signer_key" field.
+func (m *NodeMutation) SetSignerKey(b []byte) {
+	m.signer_key = &b
+}
+
+// SignerKey returns the value of the "signer_key" field in the mutation.
+func (m *NodeMutation) SignerKey() (r []byte, exists bool) {
+	v := m.signer_key
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSignerKey returns the old "signer_key" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldSignerKey(ctx context.Context) (v *[]byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSignerKey is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSignerKey requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSignerKey: %w", err)
+	}
+	return oldValue.SignerKey, nil
+}
+
+// ClearSignerKey clears the value of the "signer_key" field.
+func (m *NodeMutation) ClearSignerKey() {
+	m.signer_key = nil
+	m.clearedFields[node.FieldSignerKey] = struct{}{}
+}
+
+// SignerKeyCleared returns if the "signer_key" field was cleared in this mutation.
+func (m *NodeMutation) SignerKeyCleared() bool {
+	_, ok := m.clearedFields[node.FieldSignerKey]
+	return ok
+}
+
+// ResetSignerKey resets all changes to the "signer_key" field.
+func (m *NodeMutation) ResetSignerKey() {
+	m.signer_key = nil
+	delete(m.clearedFields, node.FieldSignerKey)
+}
+
+// SetSignature sets the "signature" field.
+func (m *NodeMutation) SetSignature(b []byte) {
+	m.signature = &b
+}
+
+// Signature returns the value of the "signature" field in the mutation.
+func (m *NodeMutation) Signature() (r []byte, exists bool) {
+	v := m.signature
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSignature returns the old "signature" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldSignature(ctx context.Context) (v *[]byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSignature is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSignature requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSignature: %w", err)
+	}
+	return oldValue.Signature, nil
+}
+
+// ClearSignature clears the value of the "signature" field.
+func (m *NodeMutation) ClearSignature() {
+	m.signature = nil
+	m.clearedFields[node.FieldSignature] = struct{}{}
+}
+
+// SignatureCleared returns if the "signature" field was cleared in this mutation.
+func (m *NodeMutation) SignatureCleared() bool {
+	_, ok := m.clearedFields[node.FieldSignature]
+	return ok
+}
+
+// ResetSignature resets all changes to the "signature" field.
+func (m *NodeMutation) ResetSignature() {
+	m.signature = nil
+	delete(m.clearedFields, node.FieldSignature)
+}
+
+// SetSharedBy sets the "shared_by" field.
+func (m *NodeMutation) SetSharedBy(s string) {
+	m.shared_by = &s
+}
+
+// SharedBy returns the value of the "shared_by" field in the mutation.
+func (m *NodeMutation) SharedBy() (r string, exists bool) {
+	v := m.shared_by
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSharedBy returns the old "shared_by" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldSharedBy(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSharedBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSharedBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSharedBy: %w", err)
+	}
+	return oldValue.SharedBy, nil
+}
+
+// ClearSharedBy clears the value of the "shared_by" field.
+func (m *NodeMutation) ClearSharedBy() {
+	m.shared_by = nil
+	m.clearedFields[node.FieldSharedBy] = struct{}{}
+}
+
+// SharedByCleared returns if the "shared_by" field was cleared in this mutation.
+func (m *NodeMutation) SharedByCleared() bool {
+	_, ok := m.clearedFields[node.FieldSharedBy]
+	return ok
+}
+
+// ResetSharedBy resets all changes to the "shared_by" field.
+func (m *NodeMutation) ResetSharedBy() {
+	m.shared_by = nil
+	delete(m.clearedFields, node.FieldSharedBy)
+}
+
+// SetSupersededBy sets the "superseded_by" field.
+func (m *NodeMutation) SetSupersededBy(s string) {
+	m.superseded_by = &s
+}
+
+// SupersededBy returns the value of the "superseded_by" field in the mutation.
+func (m *NodeMutation) SupersededBy() (r string, exists bool) {
+	v := m.superseded_by
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSupersededBy returns the old "superseded_by" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldSupersededBy(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSupersededBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSupersededBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSupersededBy: %w", err)
+	}
+	return oldValue.SupersededBy, nil
+}
+
+// ClearSupersededBy clears the value of the "superseded_by" field.
+func (m *NodeMutation) ClearSupersededBy() {
+	m.superseded_by = nil
+	m.clearedFields[node.FieldSupersededBy] = struct{}{}
+}
+
+// SupersededByCleared returns if the "superseded_by" field was cleared in this mutation.
+func (m *NodeMutation) SupersededByCleared() bool {
+	_, ok := m.clearedFields[node.FieldSupersededBy]
+	return ok
+}
+
+// ResetSupersededBy resets all changes to the "superseded_by" field.
+func (m *NodeMutation) ResetSupersededBy() {
+	m.superseded_by = nil
+	delete(m.clearedFields, node.FieldSupersededBy)
+}
+
+// SetTitle sets the "title" field.
+func (m *NodeMutation) SetTitle(s string) {
+	m.title = &s
+}
+
+// Title returns the value of the "title" field in the mutation.
+func (m *NodeMutation) Title() (r string, exists bool) {
+	v := m.title
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTitle returns the old "title" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldTitle(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTitle is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTitle requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTitle: %w", err)
+	}
+	return oldValue.Title, nil
+}
+
+// ClearTitle clears the value of the "title" field.
+func (m *NodeMutation) ClearTitle() {
+	m.title = nil
+	m.clearedFields[node.FieldTitle] = struct{}{}
+}
+
+// TitleCleared returns if the "title" field was cleared in this mutation.
+func (m *NodeMutation) TitleCleared() bool {
+	_, ok := m.clearedFields[node.FieldTitle]
+	return ok
+}
+
+// ResetTitle resets all changes to the "title" field.
+func (m *NodeMutation) ResetTitle() {
+	m.title = nil
+	delete(m.clearedFields, node.FieldTitle)
+}
+
+// SetSummary sets the "summary" field.
+func (m *NodeMutation) SetSummary(s string) {
+	m.summary = &s
+}
+
+// Summary returns the value of the "summary" field in the mutation.
+func (m *NodeMutation) Summary() (r string, exists bool) {
+	v := m.summary
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSummary returns the old "summary" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldSummary(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSummary is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSummary requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSummary: %w", err)
+	}
+	return oldValue.Summary, nil
+}
+
+// ClearSummary clears the value of the "summary" field.
+func (m *NodeMutation) ClearSummary() {
+	m.summary = nil
+	m.clearedFields[node.FieldSummary] = struct{}{}
+}
+
+// SummaryCleared returns if the "summary" field was cleared in this mutation.
+func (m *NodeMutation) SummaryCleared() bool {
+	_, ok := m.clearedFields[node.FieldSummary]
+	return ok
+}
+
+// ResetSummary resets all changes to the "summary" field.
+func (m *NodeMutation) ResetSummary() {
+	m.summary = nil
+	delete(m.clearedFields, node.FieldSummary)
+}
+
+// SetOutcome sets the "outcome" field.
+func (m *NodeMutation) SetOutcome(s string) {
+	m.outcome = &s
+}
+
+// Outcome returns the value of the "outcome" field in the mutation.
+func (m *NodeMutation) Outcome() (r string, exists bool) {
+	v := m.outcome
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOutcome returns the old "outcome" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldOutcome(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOutcome is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOutcome requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOutcome: %w", err)
+	}
+	return oldValue.Outcome, nil
+}
+
+// ClearOutcome clears the value of the "outcome" field.
+func (m *NodeMutation) ClearOutcome() {
+	m.outcome = nil
+	m.clearedFields[node.FieldOutcome] = struct{}{}
+}
+
+// OutcomeCleared returns if the "outcome" field was cleared in this mutation.
+func (m *NodeMutation) OutcomeCleared() bool {
+	_, ok := m.clearedFields[node.FieldOutcome]
+	return ok
+}
+
+// ResetOutcome resets all changes to the "outcome" field.
+func (m *NodeMutation) ResetOutcome() {
+	m.outcome = nil
+	delete(m.clearedFields, node.FieldOutcome)
+}
+
+// SetSummarizedAt sets the "summarized_at" field.
+func (m *NodeMutation) SetSummarizedAt(t time.Time) {
+	m.summarized_at = &t
+}
+
+// SummarizedAt returns the value of the "summarized_at" field in the mutation.
+func (m *NodeMutation) SummarizedAt() (r time.Time, exists bool) {
+	v := m.summarized_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSummarizedAt returns the old "summarized_at" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldSummarizedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSummarizedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSummarizedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSummarizedAt: %w", err)
+	}
+	return oldValue.SummarizedAt, nil
+}
+
+// ClearSummarizedAt clears the value of the "summarized_at" field.
+func (m *NodeMutation) ClearSummarizedAt() {
+	m.summarized_at = nil
+	m.clearedFields[node.FieldSummarizedAt] = struct{}{}
+}
+
+// SummarizedAtCleared returns if the "summarized_at" field was cleared in this mutation.
+func (m *NodeMutation) SummarizedAtCleared() bool {
+	_, ok := m.clearedFields[node.FieldSummarizedAt]
+	return ok
+}
+
+// ResetSummarizedAt resets all changes to the "summarized_at" field.
+func (m *NodeMutation) ResetSummarizedAt() {
+	m.summarized_at = nil
+	delete(m.clearedFields, node.FieldSummarizedAt)
+}
+
+// SetEndedAt sets the "ended_at" field.
+func (m *NodeMutation) SetEndedAt(t time.Time) {
+	m.ended_at = &t
+}
+
+// EndedAt returns the value of the "ended_at" field in the mutation.
+func (m *NodeMutation) EndedAt() (r time.Time, exists bool) {
+	v := m.ended_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEndedAt returns the old "ended_at" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldEndedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEndedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEndedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEndedAt: %w", err)
+	}
+	return oldValue.EndedAt, nil
+}
+
+// ClearEndedAt clears the value of the "ended_at" field.
+func (m *NodeMutation) ClearEndedAt() {
+	m.ended_at = nil
+	m.clearedFields[node.FieldEndedAt] = struct{}{}
+}
+
+// EndedAtCleared returns if the "ended_at" field was cleared in this mutation.
+func (m *NodeMutation) EndedAtCleared() bool {
+	_, ok := m.clearedFields[node.FieldEndedAt]
+	return ok
+}
+
+// ResetEndedAt resets all changes to the "ended_at" field.
+func (m *NodeMutation) ResetEndedAt() {
+	m.ended_at = nil
+	delete(m.clearedFields, node.FieldEndedAt)
+}
+
+// SetEndReason sets the "end_reason" field.
+func (m *NodeMutation) SetEndReason(s string) {
+	m.end_reason = &s
+}
+
+// EndReason returns the value of the "end_reason" field in the mutation.
+func (m *NodeMutation) EndReason() (r string, exists bool) {
+	v := m.end_reason
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEndReason returns the old "end_reason" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldEndReason(ctx context.Context) (v *string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEndReason is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEndReason requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEndReason: %w", err)
+	}
+	return oldValue.EndReason, nil
+}
+
+// ClearEndReason clears the value of the "end_reason" field.
+func (m *NodeMutation) ClearEndReason() {
+	m.end_reason = nil
+	m.clearedFields[node.FieldEndReason] = struct{}{}
+}
+
+// EndReasonCleared returns if the "end_reason" field was cleared in this mutation.
+func (m *NodeMutation) EndReasonCleared() bool {
+	_, ok := m.clearedFields[node.FieldEndReason]
+	return ok
+}
+
+// ResetEndReason resets all changes to the "end_reason" field.
+func (m *NodeMutation) ResetEndReason() {
+	m.end_reason = nil
+	delete(m.clearedFields, node.FieldEndReason)
+}
+
+// SetTurnIndex sets the "turn_index" field.
+func (m *NodeMutation) SetTurnIndex(i int) {
+	m.turn_index = &i
+	m.addturn_index = nil
+}
+
+// TurnIndex returns the value of the "turn_index" field in the mutation.
+func (m *NodeMutation) TurnIndex() (r int, exists bool) {
+	v := m.turn_index
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTurnIndex returns the old "turn_index" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldTurnIndex(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTurnIndex is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTurnIndex requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTurnIndex: %w", err)
+	}
+	return oldValue.TurnIndex, nil
+}
+
+// AddTurnIndex adds i to the "turn_index" field.
+func (m *NodeMutation) AddTurnIndex(i int) {
+	if m.addturn_index != nil {
+		*m.addturn_index += i
+	} else {
+		m.addturn_index = &i
+	}
+}
+
+// AddedTurnIndex returns the value that was added to the "turn_index" field in this mutation.
+func (m *NodeMutation) AddedTurnIndex() (r int, exists bool) {
+	v := m.addturn_index
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearTurnIndex clears the value of the "turn_index" field.
+func (m *NodeMutation) ClearTurnIndex() {
+	m.turn_index = nil
+	m.addturn_index = nil
+	m.clearedFields[node.FieldTurnIndex] = struct{}{}
+}
+
+// TurnIndexCleared returns if the "turn_index" field was cleared in this mutation.
+func (m *NodeMutation) TurnIndexCleared() bool {
+	_, ok := m.clearedFields[node.FieldTurnIndex]
+	return ok
+}
+
+// ResetTurnIndex resets all changes to the "turn_index" field.
+func (m *NodeMutation) ResetTurnIndex() {
+	m.turn_index = nil
+	m.addturn_index = nil
+	delete(m.clearedFields, node.FieldTurnIndex)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *NodeMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *NodeMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Node entity.
+// If the Node object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NodeMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *NodeMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetParentID sets the "parent" edge to the Node entity by id.
+func (m *NodeMutation) SetParentID(id string) {
+	m.parent = &id
+}
+
+// ClearParent clears the "parent" edge to the Node entity.
+func (m *NodeMutation) ClearParent() {
+	m.clearedparent = true
+	m.clearedFields[node.FieldParentHash] = struct{}{}
+}
+
+// ParentCleared reports if the "parent" edge to the Node entity was cleared.
+func (m *NodeMutation) ParentCleared() bool {
+	return m.ParentHashCleared() || m.clearedparent
+}
+
+// ParentID returns the "parent" edge ID in the mutation.
+func (m *NodeMutation) ParentID() (id string, exists bool) {
+	if m.parent != nil {
+		return *m.parent, true
+	}
+	return
+}
+
+// ParentIDs returns the "parent" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ParentID instead. It exists only for internal usage by the builders.
+func (m *NodeMutation) ParentIDs() (ids []string) {
+	if id := m.parent; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetParent resets all changes to the "parent" edge.
+func (m *NodeMutation) ResetParent() {
+	m.parent = nil
+	m.clearedparent = false
+}
+
+// AddChildIDs adds the "children" edge to the Node entity by ids.
+func (m *NodeMutation) AddChildIDs(ids ...string) {
+	if m.children == nil {
+		m.children = make(map[string]struct{})
+	}
+	for i := range ids {
+		m.children[ids[i]] = struct{}{}
+	}
+}
+
+// ClearChildren clears the "children" edge to the Node entity.
+func (m *NodeMutation) ClearChildren() {
+	m.clearedchildren = true
+}
+
+// ChildrenCleared reports if the "children" edge to the Node entity was cleared.
+func (m *NodeMutation) ChildrenCleared() bool {
+	return m.clearedchildren
+}
+
+// RemoveChildIDs removes the "children" edge to the Node entity by IDs.
+func (m *NodeMutation) RemoveChildIDs(ids ...string) {
+	if m.removedchildren == nil {
+		m.removedchildren = make(map[string]struct{})
+	}
+	for i := range ids {
+		delete(m.children, ids[i])
+		m.removedchildren[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedChildren returns the removed IDs of the "children" edge to the Node entity.
+func (m *NodeMutation) RemovedChildrenIDs() (ids []string) {
+	for id := range m.removedchildren {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ChildrenIDs returns the "children" edge IDs in the mutation.
+func (m *NodeMutation) ChildrenIDs() (ids []string) {
+	for id := range m.children {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetChildren resets all changes to the "children" edge.
+func (m *NodeMutation) ResetChildren() {
+	m.children = nil
+	m.clearedchildren = false
+	m.removedchildren = nil
+}
+
+// Where appends a list predicates to the NodeMutation builder.
+func (m *NodeMutation) Where(ps ...predicate.Node) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the NodeMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *NodeMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Node, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *NodeMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *NodeMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Node).
+func (m *NodeMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *NodeMutation) Fields() []string {
+	fields := make([]string, 0, 47)
+	if m.parent != nil {
+		fields = append(fields, node.FieldParentHash)
+	}
+	if m.bucket != nil {
+		fields = append(fields, node.FieldBucket)
+	}
+	if m._type != nil {
+		fields = append(fields, node.FieldType)
+	}
+	if m.role != nil {
+		fields = append(fields, node.FieldRole)
+	}
+	if m.content != nil {
+		fields = append(fields, node.FieldContent)
+	}
+	if m.model != nil {
+		fields = append(fields, node.FieldModel)
+	}
+	if m.provider != nil {
+		fields = append(fields, node.FieldProvider)
+	}
+	if m.agent_name != nil {
+		fields = append(fields, node.FieldAgentName)
+	}
+	if m.session_id != nil {
+		fields = append(fields, node.FieldSessionID)
+	}
+	if m.stop_reason != nil {
+		fields = append(fields, node.FieldStopReason)
+	}
+	if m.tool_names != nil {
+		fields = append(fields, node.FieldToolNames)
+	}
+	if m.prompt_tokens != nil {
+		fields = append(fields, node.FieldPromptTokens)
+	}
+	if m.completion_tokens != nil {
+		fields = append(fields, node.FieldCompletionTokens)
+	}
+	if m.total_tokens != nil {
+		fields = append(fields, node.FieldTotalTokens)
+	}
+	if m.cache_creation_input_tokens != nil {
+		fields = append(fields, node.FieldCacheCreationInputTokens)
+	}
+	if m.cache_read_input_tokens != nil {
+		fields = append(fields, node.FieldCacheReadInputTokens)
+	}
+	if m.reasoning_tokens != nil {
+		fields = append(fields, node.FieldReasoningTokens)
+	}
+	if m.total_duration_ns != nil {
+		fields = append(fields, node.FieldTotalDurationNs)
+	}
+	if m.prompt_duration_ns != nil {
+		fields = append(fields, node.FieldPromptDurationNs)
+	}
+	if m.usage_estimated != nil {
+		fields = append(fields, node.FieldUsageEstimated)
+	}
+	if m.request_started_at != nil {
+		fields = append(fields, node.FieldRequestStartedAt)
+	}
+	if m.first_chunk_at != nil {
+		fields = append(fields, node.FieldFirstChunkAt)
+	}
+	if m.response_completed_at != nil {
+		fields = append(fields, node.FieldResponseCompletedAt)
+	}
+	if m.error_status != nil {
+		fields = append(fields, node.FieldErrorStatus)
+	}
+	if m.error_type != nil {
+		fields = append(fields, node.FieldErrorType)
+	}
+	if m.error_message != nil {
+		fields = append(fields, node.FieldErrorMessage)
+	}
+	if m.retry_after_seconds != nil {
+		fields = append(fields, node.FieldRetryAfterSeconds)
+	}
+	if m.error_attempt != nil {
+		fields = append(fields, node.FieldErrorAttempt)
+	}
+	if m.project != nil {
+		fields = append(fields, node.FieldProject)
+	}
+	if m.user != nil {
+		fields = append(fields, node.FieldUser)
+	}
+	if m.trace_id != nil {
+		fields = append(fields, node.FieldTraceID)
+	}
+	if m.run_id != nil {
+		fields = append(fields, node.FieldRunID)
+	}
+	if m.agent_version != nil {
+		fields = append(fields, node.FieldAgentVersion)
+	}
+	if m.system_prompt_hash != nil {
+		fields = append(fields, node.FieldSystemPromptHash)
+	}
+	if m.policy_violations != nil {
+		fields = append(fields, node.FieldPolicyViolations)
+	}
+	if m.signer_key != nil {
+		fields = append(fields, node.FieldSignerKey)
+	}
+	if m.signature != nil {
+		fields = append(fields, node.FieldSignature)
+	}
+	if m.shared_by != nil {
+		fields = append(fields, node.FieldSharedBy)
+	}
+	if m.superseded_by != nil {
+		fields = append(fields, node.FieldSupersededBy)
+	}
+	if m.title != nil {
+		fields = append(fields, node.FieldTitle)
+	}
+	if m.summary != nil {
+		fields = append(fields, node.FieldSummary)
+	}
+	if m.outcome != nil {
+		fields = append(fields, node.FieldOutcome)
+	}
+	if m.summarized_at != nil {
+		fields = append(fields, node.FieldSummarizedAt)
+	}
+	if m.ended_at != nil {
+		fields = append(fields, node.FieldEndedAt)
+	}
+	if m.end_reason != nil {
+		fields = append(fields, node.FieldEndReason)
+	}
+	if m.turn_index != nil {
+		fields = append(fields, node.FieldTurnIndex)
+	}
+	if m.created_at != nil {
+		fields = append(fields, node.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *NodeMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case node.FieldParentHash:
+		return m.ParentHash()
+	case node.FieldBucket:
+		return m.Bucket()
+	case node.FieldType:
+		return m.GetType()
+	case node.FieldRole:
+		return m.Role()
+	case node.FieldContent:
+		return m.Content()
+	case node.FieldModel:
+		return m.Model()
+	case node.FieldProvider:
+		return m.Provider()
+	case node.FieldAgentName:
+		return m.AgentName()
+	case node.FieldSessionID:
+		return m.SessionID()
+	case node.FieldStopReason:
+		return m.StopReason()
+	case node.FieldToolNames:
+		return m.ToolNames()
+	case node.FieldPromptTokens:
+		return m.PromptTokens()
+	case node.FieldCompletionTokens:
+		return m.CompletionTokens()
+	case node.FieldTotalTokens:
+		return m.TotalTokens()
+	case node.FieldCacheCreationInputTokens:
+		return m.CacheCreationInputTokens()
+	case node.FieldCacheReadInputTokens:
+		return m.CacheReadInputTokens()
+	case node.FieldReasoningTokens:
+		return m.ReasoningTokens()
+	case node.FieldTotalDurationNs:
+		return m.TotalDurationNs()
+	case node.FieldPromptDurationNs:
+		return m.PromptDurationNs()
+	case node.FieldUsageEstimated:
+		return m.UsageEstimated()
+	case node.FieldRequestStartedAt:
+		return m.RequestStartedAt()
+	case node.FieldFirstChunkAt:
+		return m.FirstChunkAt()
+	case node.FieldResponseCompletedAt:
+		return m.ResponseCompletedAt()
+	case node.FieldErrorStatus:
+		return m.ErrorStatus()
+	case node.FieldErrorType:
+		return m.ErrorType()
+	case node.FieldErrorMessage:
+		return m.ErrorMessage()
+	case node.FieldRetryAfterSeconds:
+		return m.RetryAfterSeconds()
+	case node.FieldErrorAttempt:
+		return m.ErrorAttempt()
+	case node.FieldProject:
+		return m.Project()
+	case node.FieldUser:
+		return m.User()
+	case node.FieldTraceID:
+		return m.TraceID()
+	case node.FieldRunID:
+		return m.RunID()
+	case node.FieldAgentVersion:
+		return m.AgentVersion()
+	case node.FieldSystemPromptHash:
+		return m.SystemPromptHash()
+	case node.FieldPolicyViolations:
+		return m.PolicyViolations()
+	case node.FieldSignerKey:
+		return m.SignerKey()
+	case node.FieldSignature:
+		return m.Signature()
+	case node.FieldSharedBy:
+		return m.SharedBy()
+	case node.FieldSupersededBy:
+		return m.SupersededBy()
+	case node.FieldTitle:
+		return m.Title()
+	case node.FieldSummary:
+		return m.Summary()
+	case node.FieldOutcome:
+		return m.Outcome()
+	case node.FieldSummarizedAt:
+		return m.SummarizedAt()
+	case node.FieldEndedAt:
+		return m.EndedAt()
+	case node.FieldEndReason:
+		return m.EndReason()
+	case node.FieldTurnIndex:
+		return m.TurnIndex()
+	case node.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *NodeMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case node.FieldParentHash:
+		return m.OldParentHash(ctx)
+	case node.FieldBucket:
+		return m.OldBucket(ctx)
+	case node.FieldType:
+		return m.OldType(ctx)
+	case node.FieldRole:
+		return m.OldRole(ctx)
+	case node.FieldContent:
+		return m.OldContent(ctx)
+	case node.FieldModel:
+		return m.OldModel(ctx)
+	case node.FieldProvider:
+		return m.OldProvider(ctx)
+	case node.FieldAgentName:
+		return m.OldAgentName(ctx)
+	case node.FieldSessionID:
+		return m.OldSessionID(ctx)
+	case node.FieldStopReason:
+		return m.OldStopReason(ctx)
+	case node.FieldToolNames:
+		return m.OldToolNames(ctx)
+	case node.FieldPromptTokens:
+		return m.OldPromptTokens(ctx)
+	case node.FieldCompletionTokens:
+		return m.OldCompletionTokens(ctx)
+	case node.FieldTotalTokens:
+		return m.OldTotalTokens(ctx)
+	case node.FieldCacheCreationInputTokens:
+		return m.OldCacheCreationInputTokens(ctx)
+	case node.FieldCacheReadInputTokens:
+		return m.OldCacheReadInputTokens(ctx)
+	case node.FieldReasoningTokens:
+		return m.OldReasoningTokens(ctx)
+	case node.FieldTotalDurationNs:
+		return m.OldTotalDurationNs(ctx)
+	case node.FieldPromptDurationNs:
+		return m.OldPromptDurationNs(ctx)
+	case node.FieldUsageEstimated:
+		return m.OldUsageEstimated(ctx)
+	case node.FieldRequestStartedAt:
+		return m.OldRequestStartedAt(ctx)
+	case node.FieldFirstChunkAt:
+		return m.OldFirstChunkAt(ctx)
+	case node.FieldResponseCompletedAt:
+		return m.OldResponseCompletedAt(ctx)
+	case node.FieldErrorStatus:
+		return m.OldErrorStatus(ctx)
+	case node.FieldErrorType:
+		return m.OldErrorType(ctx)
+	case node.FieldErrorMessage:
+		return m.OldErrorMessage(ctx)
+	case node.FieldRetryAfterSeconds:
+		return m.OldRetryAfterSeconds(ctx)
+	case node.FieldErrorAttempt:
+		return m.OldErrorAttempt(ctx)
+	case node.FieldProject:
+		return m.OldProject(ctx)
+	case node.FieldUser:
+		return m.OldUser(ctx)
+	case node.FieldTraceID:
+		return m.OldTraceID(ctx)
+	case node.FieldRunID:
+		return m.OldRunID(ctx)
+	case node.FieldAgentVersion:
+		return m.OldAgentVersion(ctx)
+	case node.FieldSystemPromptHash:
+		return m.OldSystemPromptHash(ctx)
+	case node.FieldPolicyViolations:
+		return m.OldPolicyViolations(ctx)
+	case node.FieldSignerKey:
+		return m.OldSignerKey(ctx)
+	case node.FieldSignature:
+		return m.OldSignature(ctx)
+	case node.FieldSharedBy:
+		return m.OldSharedBy(ctx)
+	case node.FieldSupersededBy:
+		return m.OldSupersededBy(ctx)
+	case node.FieldTitle:
+		return m.OldTitle(ctx)
+	case node.FieldSummary:
+		return m.OldSummary(ctx)
+	case node.FieldOutcome:
+		return m.OldOutcome(ctx)
+	case node.FieldSummarizedAt:
+		return m.OldSummarizedAt(ctx)
+	case node.FieldEndedAt:
+		return m.OldEndedAt(ctx)
+	case node.FieldEndReason:
+		return m.OldEndReason(ctx)
+	case node.FieldTurnIndex:
+		return m.OldTurnIndex(ctx)
+	case node.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown Node field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *NodeMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case node.FieldParentHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetParentHash(v)
+		return nil
+	case node.FieldBucket:
+		v, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetBucket(v)
+		return nil
+	case node.FieldType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetType(v)
+		return nil
+	case node.FieldRole:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRole(v)
+		return nil
+	case node.FieldContent:
+		v, ok := value.([]map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetContent(v)
+		return nil
+	case node.FieldModel:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetModel(v)
+		return nil
+	case node.FieldProvider:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProvider(v)
+		return nil
+	case node.FieldAgentName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAgentName(v)
+		return nil
+	case node.FieldSessionID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionID(v)
+		return nil
+	case node.FieldStopReason:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStopReason(v)
+		return nil
+	case node.FieldToolNames:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetToolNames(v)
+		return nil
+	case node.FieldPromptTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPromptTokens(v)
+		return nil
+	case node.FieldCompletionTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCompletionTokens(v)
+		return nil
+	case node.FieldTotalTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTotalTokens(v)
+		return nil
+	case node.FieldCacheCreationInputTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCacheCreationInputTokens(v)
+		return nil
+	case node.FieldCacheReadInputTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCacheReadInputTokens(v)
+		return nil
+	case node.FieldReasoningTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetReasoningTokens(v)
+		return nil
+	case node.FieldTotalDurationNs:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTotalDurationNs(v)
+		return nil
+	case node.FieldPromptDurationNs:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPromptDurationNs(v)
+		return nil
+	case node.FieldUsageEstimated:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUsageEstimated(v)
+		return nil
+	case node.FieldRequestStartedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRequestStartedAt(v)
+		return nil
+	case node.FieldFirstChunkAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFirstChunkAt(v)
+		return nil
+	case node.FieldResponseCompletedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResponseCompletedAt(v)
+		return nil
+	case node.FieldErrorStatus:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetErrorStatus(v)
+		return nil
+	case node.FieldErrorType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetErrorType(v)
+		return nil
+	case node.FieldErrorMessage:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetErrorMessage(v)
+		return nil
+	case node.FieldRetryAfterSeconds:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRetryAfterSeconds(v)
+		return nil
+	case node.FieldErrorAttempt:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetErrorAttempt(v)
+		return nil
+	case node.FieldProject:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProject(v)
+		return nil
+	case node.FieldUser:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUser(v)
+		return nil
+	case node.FieldTraceID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTraceID(v)
+		return nil
+	case node.FieldRunID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRunID(v)
+		return nil
+	case node.FieldAgentVersion:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAgentVersion(v)
+		return nil
+	case node.FieldSystemPromptHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSystemPromptHash(v)
+		return nil
+	case node.FieldPolicyViolations:
+		v, ok := value.([]llm.PolicyViolation)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPolicyViolations(v)
+		return nil
+	case node.FieldSignerKey:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSignerKey(v)
+		return nil
+	case node.FieldSignature:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSignature(v)
+		return nil
+	case node.FieldSharedBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSharedBy(v)
+		return nil
+	case node.FieldSupersededBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSupersededBy(v)
+		return nil
+	case node.FieldTitle:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTitle(v)
+		return nil
+	case node.FieldSummary:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSummary(v)
+		return nil
+	case node.FieldOutcome:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOutcome(v)
+		return nil
+	case node.FieldSummarizedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSummarizedAt(v)
+		return nil
+	case node.FieldEndedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEndedAt(v)
+		return nil
+	case node.FieldEndReason:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEndReason(v)
+		return nil
+	case node.FieldTurnIndex:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTurnIndex(v)
+		return nil
+	case node.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Node field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *NodeMutation) AddedFields() []string {
+	var fields []string
+	if m.addprompt_tokens != nil {
+		fields = append(fields, node.FieldPromptTokens)
+	}
+	if m.addcompletion_tokens != nil {
+		fields = append(fields, node.FieldCompletionTokens)
+	}
+	if m.addtotal_tokens != nil {
+		fields = append(fields, node.FieldTotalTokens)
+	}
+	if m.addcache_creation_input_tokens != nil {
+		fields = append(fields, node.FieldCacheCreationInputTokens)
+	}
+	if m.addcache_read_input_tokens != nil {
+		fields = append(fields, node.FieldCacheReadInputTokens)
+	}
+	if m.addreasoning_tokens != nil {
+		fields = append(fields, node.FieldReasoningTokens)
+	}
+	if m.addtotal_duration_ns != nil {
+		fields = append(fields, node.FieldTotalDurationNs)
+	}
+	if m.addprompt_duration_ns != nil {
+		fields = append(fields, node.FieldPromptDurationNs)
+	}
+	if m.adderror_status != nil {
+		fields = append(fields, node.FieldErrorStatus)
+	}
+	if m.addretry_after_seconds != nil {
+		fields = append(fields, node.FieldRetryAfterSeconds)
+	}
+	if m.adderror_attempt != nil {
+		fields = append(fields, node.FieldErrorAttempt)
+	}
+	if m.addturn_index != nil {
+		fields = append(fields, node.FieldTurnIndex)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *NodeMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case node.FieldPromptTokens:
+		return m.AddedPromptTokens()
+	case node.FieldCompletionTokens:
+		return m.AddedCompletionTokens()
+	case node.FieldTotalTokens:
+		return m.AddedTotalTokens()
+	case node.FieldCacheCreationInputTokens:
+		return m.AddedCacheCreationInputTokens()
+	case node.FieldCacheReadInputTokens:
+		return m.AddedCacheReadInputTokens()
+	case node.FieldReasoningTokens:
+		return m.AddedReasoningTokens()
+	case node.FieldTotalDurationNs:
+		return m.AddedTotalDurationNs()
+	case node.FieldPromptDurationNs:
+		return m.AddedPromptDurationNs()
+	case node.FieldErrorStatus:
+		return m.AddedErrorStatus()
+	case node.FieldRetryAfterSeconds:
+		return m.AddedRetryAfterSeconds()
+	case node.FieldErrorAttempt:
+		return m.AddedErrorAttempt()
+	case node.FieldTurnIndex:
+		return m.AddedTurnIndex()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *NodeMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case node.FieldPromptTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPromptTokens(v)
+		return nil
+	case node.FieldCompletionTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCompletionTokens(v)
+		return nil
+	case node.FieldTotalTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTotalTokens(v)
+		return nil
+	case node.FieldCacheCreationInputTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCacheCreationInputTokens(v)
+		return nil
+	case node.FieldCacheReadInputTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCacheReadInputTokens(v)
+		return nil
+	case node.FieldReasoningTokens:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddReasoningTokens(v)
+		return nil
+	case node.FieldTotalDurationNs:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTotalDurationNs(v)
+		return nil
+	case node.FieldPromptDurationNs:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPromptDurationNs(v)
+		return nil
+	case node.FieldErrorStatus:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddErrorStatus(v)
+		return nil
+	case node.FieldRetryAfterSeconds:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRetryAfterSeconds(v)
+		return nil
+	case node.FieldErrorAttempt:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddErrorAttempt(v)
+		return nil
+	case node.FieldTurnIndex:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTurnIndex(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Node numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *NodeMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(node.FieldParentHash) {
+		fields = append(fields, node.FieldParentHash)
+	}
+	if m.FieldCleared(node.FieldBucket) {
+		fields = append(fields, node.FieldBucket)
+	}
+	if m.FieldCleared(node.FieldType) {
+		fields = append(fields, node.FieldType)
+	}
+	if m.FieldCleared(node.FieldRole) {
+		fields = append(fields, node.FieldRole)
+	}
+	if m.FieldCleared(node.FieldContent) {
+		fields = append(fields, node.FieldContent)
+	}
+	if m.FieldCleared(node.FieldModel) {
+		fields = append(fields, node.FieldModel)
+	}
+	if m.FieldCleared(node.FieldProvider) {
+		fields = append(fields, node.FieldProvider)
+	}
+	if m.FieldCleared(node.FieldAgentName) {
+		fields = append(fields, node.FieldAgentName)
+	}
+	if m.FieldCleared(node.FieldSessionID) {
+		fields = append(fields, node.FieldSessionID)
+	}
+	if m.FieldCleared(node.FieldStopReason) {
+		fields = append(fields, node.FieldStopReason)
+	}
+	if m.FieldCleared(node.FieldToolNames) {
+		fields = append(fields, node.FieldToolNames)
+	}
+	if m.FieldCleared(node.FieldPromptTokens) {
+		fields = append(fields, node.FieldPromptTokens)
+	}
+	if m.FieldCleared(node.FieldCompletionTokens) {
+		fields = append(fields, node.FieldCompletionTokens)
+	}
+	if m.FieldCleared(node.FieldTotalTokens) {
+		fields = append(fields, node.FieldTotalTokens)
+	}
+	if m.FieldCleared(node.FieldCacheCreationInputTokens) {
+		fields = append(fields, node.FieldCacheCreationInputTokens)
+	}
+	if m.FieldCleared(node.FieldCacheReadInputTokens) {
+		fields = append(fields, node.FieldCacheReadInputTokens)
+	}
+	if m.FieldCleared(node.FieldReasoningTokens) {
+		fields = append(fields, node.FieldReasoningTokens)
+	}
+	if m.FieldCleared(node.FieldTotalDurationNs) {
+		fields = append(fields, node.FieldTotalDurationNs)
+	}
+	if m.FieldCleared(node.FieldPromptDurationNs) {
+		fields = append(fields, node.FieldPromptDurationNs)
+	}
+	if m.FieldCleared(node.FieldUsageEstimated) {
+		fields = append(fields, node.FieldUsageEstimated)
+	}
+	if m.FieldCleared(node.FieldRequestStartedAt) {
+		fields = append(fields, node.FieldRequestStartedAt)
+	}
+	if m.FieldCleared(node.FieldFirstChunkAt) {
+		fields = append(fields, node.FieldFirstChunkAt)
+	}
+	if m.FieldCleared(node.FieldResponseCompletedAt) {
+		fields = append(fields, node.FieldResponseCompletedAt)
+	}
+	if m.FieldCleared(node.FieldErrorStatus) {
+		fields = append(fields, node.FieldErrorStatus)
+	}
+	if m.FieldCleared(node.FieldErrorType) {
+		fields = append(fields, node.FieldErrorType)
+	}
+	if m.FieldCleared(node.FieldErrorMessage) {
+		fields = append(fields, node.FieldErrorMessage)
+	}
+	if m.FieldCleared(node.FieldRetryAfterSeconds) {
+		fields = append(fields, node.FieldRetryAfterSeconds)
+	}
+	if m.FieldCleared(node.FieldErrorAttempt) {
+		fields = append(fields, node.FieldErrorAttempt)
+	}
+	if m.FieldCleared(node.FieldProject) {
+		fields = append(fields, node.FieldProject)
+	}
+	if m.FieldCleared(node.FieldUser) {
+		fields = append(fields, node.FieldUser)
+	}
+	if m.FieldCleared(node.FieldTraceID) {
+		fields = append(fields, node.FieldTraceID)
+	}
+	if m.FieldCleared(node.FieldRunID) {
+		fields = append(fields, node.FieldRunID)
+	}
+	if m.FieldCleared(node.FieldAgentVersion) {
+		fields = append(fields, node.FieldAgentVersion)
+	}
+	if m.FieldCleared(node.FieldSystemPromptHash) {
+		fields = append(fields, node.FieldSystemPromptHash)
+	}
+	if m.FieldCleared(node.FieldPolicyViolations) {
+		fields = append(fields, node.FieldPolicyViolations)
+	}
+	if m.FieldCleared(node.FieldSignerKey) {
+		fields = append(fields, node.FieldSignerKey)
+	}
+	if m.FieldCleared(node.FieldSignature) {
+		fields = append(fields, node.FieldSignature)
+	}
+	if m.FieldCleared(node.FieldSharedBy) {
+		fields = append(fields, node.FieldSharedBy)
+	}
+	if m.FieldCleared(node.FieldSupersededBy) {
+		fields = append(fields, node.FieldSupersededBy)
+	}
+	if m.FieldCleared(node.FieldTitle) {
+		fields = append(fields, node.FieldTitle)
+	}
+	if m.FieldCleared(node.FieldSummary) {
+		fields = append(fields, node.FieldSummary)
+	}
+	if m.FieldCleared(node.FieldOutcome) {
+		fields = append(fields, node.FieldOutcome)
+	}
+	if m.FieldCleared(node.FieldSummarizedAt) {
+		fields = append(fields, node.FieldSummarizedAt)
+	}
+	if m.FieldCleared(node.FieldEndedAt) {
+		fields = append(fields, node.FieldEndedAt)
+	}
+	if m.FieldCleared(node.FieldEndReason) {
+		fields = append(fields, node.FieldEndReason)
+	}
+	if m.FieldCleared(node.FieldTurnIndex) {
+		fields = append(fields, node.FieldTurnIndex)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *NodeMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *NodeMutation) ClearField(name string) error {
+	switch name {
+	case node.FieldParentHash:
+		m.ClearParentHash()
+		return nil
+	case node.FieldBucket:
+		m.ClearBucket()
+		return nil
+	case node.FieldType:
+		m.ClearType()
+		return nil
+	case node.FieldRole:
+		m.ClearRole()
+		return nil
+	case node.FieldContent:
+		m.ClearContent()
+		return nil
+	case node.FieldModel:
+		m.ClearModel()
+		return nil
+	case node.FieldProvider:
+		m.ClearProvider()
+		return nil
+	case node.FieldAgentName:
+		m.ClearAgentName()
+		return nil
+	case node.FieldSessionID:
+		m.ClearSessionID()
+		return nil
+	case node.FieldStopReason:
+		m.ClearStopReason()
+		return nil
+	case node.FieldToolNames:
+		m.ClearToolNames()
+		return nil
+	case node.FieldPromptTokens:
+		m.ClearPromptTokens()
+		return nil
+	case node.FieldCompletionTokens:
+		m.ClearCompletionTokens()
+		return nil
+	case node.FieldTotalTokens:
+		m.ClearTotalTokens()
+		return nil
+	case node.FieldCacheCreationInputTokens:
+		m.ClearCacheCreationInputTokens()
+		return nil
+	case node.FieldCacheReadInputTokens:
+		m.ClearCacheReadInputTokens()
+		return nil
+	case node.FieldReasoningTokens:
+		m.ClearReasoningTokens()
+		return nil
+	case node.FieldTotalDurationNs:
+		m.ClearTotalDurationNs()
+		return nil
+	case node.FieldPromptDurationNs:
+		m.ClearPromptDurationNs()
+		return nil
+	case node.FieldUsageEstimated:
+		m.ClearUsageEstimated()
+		return nil
+	case node.FieldRequestStartedAt:
+		m.ClearRequestStartedAt()
+		return nil
+	case node.FieldFirstChunkAt:
+		m.ClearFirstChunkAt()
+		return nil
+	case node.FieldResponseCompletedAt:
+		m.ClearResponseCompletedAt()
+		return nil
+	case node.FieldErrorStatus:
+		m.ClearErrorStatus()
+		return nil
+	case node.FieldErrorType:
+		m.ClearErrorType()
+		return nil
+	case node.FieldErrorMessage:
+		m.ClearErrorMessage()
+		return nil
+	case node.FieldRetryAfterSeconds:
+		m.ClearRetryAfterSeconds()
+		return nil
+	case node.FieldErrorAttempt:
+		m.ClearErrorAttempt()
+		return nil
+	case node.FieldProject:
+		m.ClearProject()
+		return nil
+	case node.FieldUser:
+		m.ClearUser()
+		return nil
+	case node.FieldTraceID:
+		m.ClearTraceID()
+		return nil
+	case node.FieldRunID:
+		m.ClearRunID()
+		return nil
+	case node.FieldAgentVersion:
+		m.ClearAgentVersion()
+		return nil
+	case node.FieldSystemPromptHash:
+		m.ClearSystemPromptHash()
+		return nil
+	case node.FieldPolicyViolations:
+		m.ClearPolicyViolations()
+		return nil
+	case node.FieldSignerKey:
+		m.ClearSignerKey()
+		return nil
+	case node.FieldSignature:
+		m.ClearSignature()
+		return nil
+	case node.FieldSharedBy:
+		m.ClearSharedBy()
+		return nil
+	case node.FieldSupersededBy:
+		m.ClearSupersededBy()
+		return nil
+	case node.FieldTitle:
+		m.ClearTitle()
+		return nil
+	case node.FieldSummary:
+		m.ClearSummary()
+		return nil
+	case node.FieldOutcome:
+		m.ClearOutcome()
+		return nil
+	case node.FieldSummarizedAt:
+		m.ClearSummarizedAt()
+		return nil
+	case node.FieldEndedAt:
+		m.ClearEndedAt()
+		return nil
+	case node.FieldEndReason:
+		m.ClearEndReason()
+		return nil
+	case node.FieldTurnIndex:
+		m.ClearTurnIndex()
+		return nil
+	}
+	return fmt.Errorf("unknown Node nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *NodeMutation) ResetField(name string) error {
+	switch name {
+	case node.FieldParentHash:
+		m.ResetParentHash()
+		return nil
+	case node.FieldBucket:
+		m.ResetBucket()
+		return nil
+	case node.FieldType:
+		m.ResetType()
+		return nil
+	case node.FieldRole:
+		m.ResetRole()
+		return nil
+	case node.FieldContent:
+		m.ResetContent()
+		return nil
+	case node.FieldModel:
+		m.ResetModel()
+		return nil
+	case node.FieldProvider:
+		m.ResetProvider()
+		return nil
+	case node.FieldAgentName:
+		m.ResetAgentName()
+		return nil
+	case node.FieldSessionID:
+		m.ResetSessionID()
+		return nil
+	case node.FieldStopReason:
+		m.ResetStopReason()
+		return nil
+	case node.FieldToolNames:
+		m.ResetToolNames()
+		return nil
+	case node.FieldPromptTokens:
+		m.ResetPromptTokens()
+		return nil
+	case node.FieldCompletionTokens:
+		m.ResetCompletionTokens()
+		return nil
+	case node.FieldTotalTokens:
+		m.ResetTotalTokens()
+		return nil
+	case node.FieldCacheCreationInputTokens:
+		m.ResetCacheCreationInputTokens()
+		return nil
+	case node.FieldCacheReadInputTokens:
+		m.ResetCacheReadInputTokens()
+		return nil
+	case node.FieldReasoningTokens:
+		m.ResetReasoningTokens()
+		return nil
+	case node.FieldTotalDurationNs:
+		m.ResetTotalDurationNs()
+		return nil
+	case node.FieldPromptDurationNs:
+		m.ResetPromptDurationNs()
+		return nil
+	case node.FieldUsageEstimated:
+		m.ResetUsageEstimated()
+		return nil
+	case node.FieldRequestStartedAt:
+		m.ResetRequestStartedAt()
+		return nil
+	case node.FieldFirstChunkAt:
+		m.ResetFirstChunkAt()
+		return nil
+	case node.FieldResponseCompletedAt:
+		m.ResetResponseCompletedAt()
+		return nil
+	case node.FieldErrorStatus:
+		m.ResetErrorStatus()
+		return nil
+	case node.FieldErrorType:
+		m.ResetErrorType()
+		return nil
+	case node.FieldErrorMessage:
+		m.ResetErrorMessage()
+		return nil
+	case node.FieldRetryAfterSeconds:
+		m.ResetRetryAfterSeconds()
+		return nil
+	case node.FieldErrorAttempt:
+		m.ResetErrorAttempt()
+		return nil
+	case node.FieldProject:
+		m.ResetProject()
+		return nil
+	case node.FieldUser:
+		m.ResetUser()
+		return nil
+	case node.FieldTraceID:
+		m.ResetTraceID()
+		return nil
+	case node.FieldRunID:
+		m.ResetRunID()
+		return nil
+	case node.FieldAgentVersion:
+		m.ResetAgentVersion()
+		return nil
+	case node.FieldSystemPromptHash:
+		m.ResetSystemPromptHash()
+		return nil
+	case node.FieldPolicyViolations:
+		m.ResetPolicyViolations()
+		return nil
+	case node.FieldSignerKey:
+		m.ResetSignerKey()
+		return nil
+	case node.FieldSignature:
+		m.ResetSignature()
+		return nil
+	case node.FieldSharedBy:
+		m.ResetSharedBy()
+		return nil
+	case node.FieldSupersededBy:
+		m.ResetSupersededBy()
+		return nil
+	case node.FieldTitle:
+		m.ResetTitle()
+		return nil
+	case node.FieldSummary:
+		m.ResetSummary()
+		return nil
+	case node.FieldOutcome:
+		m.ResetOutcome()
+		return nil
+	case node.FieldSummarizedAt:
+		m.ResetSummarizedAt()
+		return nil
+	case node.FieldEndedAt:
+		m.ResetEndedAt()
+		return nil
+	case node.FieldEndReason:
+		m.ResetEndReason()
+		return nil
+	case node.FieldTurnIndex:
+		m.ResetTurnIndex()
+		return nil
+	case node.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown Node field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *NodeMutation) AddedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.parent != nil {
+		edges = append(edges, node.EdgeParent)
+	}
+	if m.children != nil {
+		edges = append(edges, node.EdgeChildren)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *NodeMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case node.EdgeParent:
+		if id := m.parent; id != nil {
+			return []ent.Value{*id}
+		}
+	case node.EdgeChildren:
+		ids := make([]ent.Value, 0, len(m.children))
+		for id := range m.children {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *NodeMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.removedchildren != nil {
+		edges = append(edges, node.EdgeChildren)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *NodeMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case node.EdgeChildren:
+		ids := make([]ent.Value, 0, len(m.removedchildren))
+		for id := range m.removedchildren {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *NodeMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.clearedparent {
+		edges = append(edges, node.EdgeParent)
+	}
+	if m.clearedchildren {
+		edges = append(edges, node.EdgeChildren)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *NodeMutation) EdgeCleared(name string) bool {
+	switch name {
+	case node.EdgeParent:
+		return m.clearedparent
+	case node.EdgeChildren:
+		return m.clearedchildren
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *NodeMutation) ClearEdge(name string) error {
+	switch name {
+	case node.EdgeParent:
+		m.ClearParent()
+		return nil
+	}
+	return fmt.Errorf("unknown Node unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *NodeMutation) ResetEdge(name string) error {
+	switch name {
+	case node.EdgeParent:
+		m.ResetParent()
+		return nil
+	case node.EdgeChildren:
+		m.ResetChildren()
+		return nil
+	}
+	return fmt.Errorf("unknown Node edge %s", name)
+}
+
+// RawCaptureMutation represents an operation that mutates the RawCapture nodes in the graph.
+type RawCaptureMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	node_hash     *string
+	provider      *string
+	agent_name    *string
+	session_id    *string
+	run_id        *string
+	project       *string
+	user          *string
+	_path         *string
+	streamed      *bool
+	encrypted     *bool
+	request       *[]byte
+	response      *[]byte
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*RawCapture, error)
+	predicates    []predicate.RawCapture
+}
+
+var _ ent.Mutation = (*RawCaptureMutation)(nil)
+
+// rawcaptureOption allows management of the mutation configuration using functional options.
+type rawcaptureOption func(*RawCaptureMutation)
+
+// newRawCaptureMutation creates new mutation for the RawCapture entity.
+func newRawCaptureMutation(c config, op Op, opts ...rawcaptureOption) *RawCaptureMutation {
+	m := &RawCaptureMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeRawCapture,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withRawCaptureID sets the ID field of the mutation.
+func withRawCaptureID(id int) rawcaptureOption {
+	return func(m *RawCaptureMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *RawCapture
+		)
+		m.oldValue = func(ctx context.Context) (*RawCapture, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().RawCapture.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withRawCapture sets the old RawCapture of the mutation.
+func withRawCapture(node *RawCapture) rawcaptureOption {
+	return func(m *RawCaptureMutation) {
+		m.oldValue = func(context.Context) (*RawCapture, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m RawCaptureMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m RawCaptureMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *RawCaptureMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *RawCaptureMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().RawCapture.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetNodeHash sets the "node_hash" field.
+func (m *RawCaptureMutation) SetNodeHash(s string) {
+	m.node_hash = &s
+}
+
+// NodeHash returns the value of the "node_hash" field in the mutation.
+func (m *RawCaptureMutation) NodeHash() (r string, exists bool) {
+	v := m.node_hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNodeHash returns the old "node_hash" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldNodeHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNodeHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNodeHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNodeHash: %w", err)
+	}
+	return oldValue.NodeHash, nil
+}
+
+// ResetNodeHash resets all changes to the "node_hash" field.
+func (m *RawCaptureMutation) ResetNodeHash() {
+	m.node_hash = nil
+}
+
+// SetProvider sets the "provider" field.
+func (m *RawCaptureMutation) SetProvider(s string) {
+	m.provider = &s
+}
+
+// Provider returns the value of the "provider" field in the mutation.
+func (m *RawCaptureMutation) Provider() (r string, exists bool) {
+	v := m.provider
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProvider returns the old "provider" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldProvider(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProvider is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProvider requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProvider: %w", err)
+	}
+	return oldValue.Provider, nil
+}
+
+// ResetProvider resets all changes to the "provider" field.
+func (m *RawCaptureMutation) ResetProvider() {
+	m.provider = nil
+}
+
+// SetAgentName sets the "agent_name" field.
+func (m *RawCaptureMutation) SetAgentName(s string) {
+	m.agent_name = &s
+}
+
+// AgentName returns the value of the "agent_name" field in the mutation.
+func (m *RawCaptureMutation) AgentName() (r string, exists bool) {
+	v := m.agent_name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAgentName returns the old "agent_name" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldAgentName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAgentName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAgentName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAgentName: %w", err)
+	}
+	return oldValue.AgentName, nil
+}
+
+// ClearAgentName clears the value of the "agent_name" field.
+func (m *RawCaptureMutation) ClearAgentName() {
+	m.agent_name = nil
+	m.clearedFields[rawcapture.FieldAgentName] = struct{}{}
+}
+
+// AgentNameCleared returns if the "agent_name" field was cleared in this mutation.
+func (m *RawCaptureMutation) AgentNameCleared() bool {
+	_, ok := m.clearedFields[rawcapture.FieldAgentName]
+	return ok
+}
+
+// ResetAgentName resets all changes to the "agent_name" field.
+func (m *RawCaptureMutation) ResetAgentName() {
+	m.agent_name = nil
+	delete(m.clearedFields, rawcapture.FieldAgentName)
+}
+
+// SetSessionID sets the "session_id" field.
+func (m *RawCaptureMutation) SetSessionID(s string) {
+	m.session_id = &s
+}
+
+// SessionID returns the value of the "session_id" field in the mutation.
+func (m *RawCaptureMutation) SessionID() (r string, exists bool) {
+	v := m.session_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSessionID returns the old "session_id" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldSessionID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSessionID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
+	}
+	return oldValue.SessionID, nil
+}
+
+// ClearSessionID clears the value of the "session_id" field.
+func (m *RawCaptureMutation) ClearSessionID() {
+	m.session_id = nil
+	m.clearedFields[rawcapture.FieldSessionID] = struct{}{}
+}
+
+// SessionIDCleared returns if the "session_id" field was cleared in this mutation.
+func (m *RawCaptureMutation) SessionIDCleared() bool {
+	_, ok := m.clearedFields[rawcapture.FieldSessionID]
+	return ok
+}
+
+// ResetSessionID resets all changes to the "session_id" field.
+func (m *RawCaptureMutation) ResetSessionID() {
+	m.session_id = nil
+	delete(m.clearedFields, rawcapture.FieldSessionID)
+}
+
+// SetRunID sets the "run_id" field.
+func (m *RawCaptureMutation) SetRunID(s string) {
+	m.run_id = &s
+}
+
+// RunID returns the value of the "run_id" field in the mutation.
+func (m *RawCaptureMutation) RunID() (r string, exists bool) {
+	v := m.run_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRunID returns the old "run_id" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldRunID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRunID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRunID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRunID: %w", err)
+	}
+	return oldValue.RunID, nil
+}
+
+// ClearRunID clears the value of the "run_id" field.
+func (m *RawCaptureMutation) ClearRunID() {
+	m.run_id = nil
+	m.clearedFields[rawcapture.FieldRunID] = struct{}{}
+}
+
+// RunIDCleared returns if the "run_id" field was cleared in this mutation.
+func (m *RawCaptureMutation) RunIDCleared() bool {
+	_, ok := m.clearedFields[rawcapture.FieldRunID]
+	return ok
+}
+
+// ResetRunID resets all changes to the "run_id" field.
+func (m *RawCaptureMutation) ResetRunID() {
+	m.run_id = nil
+	delete(m.clearedFields, rawcapture.FieldRunID)
+}
+
+// SetProject sets the "project" field.
+func (m *RawCaptureMutation) SetProject(s string) {
+	m.project = &s
+}
+
+// Project returns the value of the "project" field in the mutation.
+func (m *RawCaptureMutation) Project() (r string, exists bool) {
+	v := m.project
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldProject returns the old "project" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldProject(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldProject is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldProject requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldProject: %w", err)
+	}
+	return oldValue.Project, nil
+}
+
+// ClearProject clears the value of the "project" field.
+func (m *RawCaptureMutation) ClearProject() {
+	m.project = nil
+	m.clearedFields[rawcapture.FieldProject] = struct{}{}
+}
+
+// ProjectCleared returns if the "project" field was cleared in this mutation.
+func (m *RawCaptureMutation) ProjectCleared() bool {
+	_, ok := m.clearedFields[rawcapture.FieldProject]
+	return ok
+}
+
+// ResetProject resets all changes to the "project" field.
+func (m *RawCaptureMutation) ResetProject() {
+	m.project = nil
+	delete(m.clearedFields, rawcapture.FieldProject)
+}
+
+// SetUser sets the "user" field.
+func (m *RawCaptureMutation) SetUser(s string) {
+	m.user = &s
+}
+
+// User returns the value of the "user" field in the mutation.
+func (m *RawCaptureMutation) User() (r string, exists bool) {
+	v := m.user
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUser returns the old "user" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldUser(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUser is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUser requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUser: %w", err)
+	}
+	return oldValue.User, nil
+}
+
+// ClearUser clears the value of the "user" field.
+func (m *RawCaptureMutation) ClearUser() {
+	m.user = nil
+	m.clearedFields[rawcapture.FieldUser] = struct{}{}
+}
+
+// UserCleared returns if the "user" field was cleared in this mutation.
+func (m *RawCaptureMutation) UserCleared() bool {
+	_, ok := m.clearedFields[rawcapture.FieldUser]
+	return ok
+}
+
+// ResetUser resets all changes to the "user" field.
+func (m *RawCaptureMutation) ResetUser() {
+	m.user = nil
+	delete(m.clearedFields, rawcapture.FieldUser)
+}
+
+// SetPath sets the "path" field.
+func (m *RawCaptureMutation) SetPath(s string) {
+	m._path = &s
+}
+
+// Path returns the value of the "path" field in the mutation.
+func (m *RawCaptureMutation) Path() (r string, exists bool) {
+	v := m._path
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPath returns the old "path" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldPath(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPath is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPath requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPath: %w", err)
+	}
+	return oldValue.Path, nil
+}
+
+// ClearPath clears the value of the "path" field.
+func (m *RawCaptureMutation) ClearPath() {
+	m._path = nil
+	m.clearedFields[rawcapture.FieldPath] = struct{}{}
+}
+
+// PathCleared returns if the "path" field was cleared in this mutation.
+func (m *RawCaptureMutation) PathCleared() bool {
+	_, ok := m.clearedFields[rawcapture.FieldPath]
+	return ok
+}
+
+// ResetPath resets all changes to the "path" field.
+func (m *RawCaptureMutation) ResetPath() {
+	m._path = nil
+	delete(m.clearedFields, rawcapture.FieldPath)
+}
+
+// SetStreamed sets the "streamed" field.
+func (m *RawCaptureMutation) SetStreamed(b bool) {
+	m.streamed = &b
+}
+
+// Streamed returns the value of the "streamed" field in the mutation.
+func (m *RawCaptureMutation) Streamed() (r bool, exists bool) {
+	v := m.streamed
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStreamed returns the old "streamed" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldStreamed(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStreamed is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStreamed requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStreamed: %w", err)
+	}
+	return oldValue.Streamed, nil
+}
+
+// ResetStreamed resets all changes to the "streamed" field.
+func (m *RawCaptureMutation) ResetStreamed() {
+	m.streamed = nil
+}
+
+// SetEncrypted sets the "encrypted" field.
+func (m *RawCaptureMutation) SetEncrypted(b bool) {
+	m.encrypted = &b
+}
+
+// Encrypted returns the value of the "encrypted" field in the mutation.
+func (m *RawCaptureMutation) Encrypted() (r bool, exists bool) {
+	v := m.encrypted
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEncrypted returns the old "encrypted" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldEncrypted(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEncrypted is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEncrypted requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEncrypted: %w", err)
+	}
+	return oldValue.Encrypted, nil
+}
+
+// ResetEncrypted resets all changes to the "encrypted" field.
+func (m *RawCaptureMutation) ResetEncrypted() {
+	m.encrypted = nil
+}
+
+// SetRequest sets the "request" field.
+func (m *RawCaptureMutation) SetRequest(b []byte) {
+	m.request = &b
+}
+
+// Request returns the value of the "request" field in the mutation.
+func (m *RawCaptureMutation) Request() (r []byte, exists bool) {
+	v := m.request
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRequest returns the old "request" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldRequest(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRequest is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRequest requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRequest: %w", err)
+	}
+	return oldValue.Request, nil
+}
+
+// ResetRequest resets all changes to the "request" field.
+func (m *RawCaptureMutation) ResetRequest() {
+	m.request = nil
+}
+
+// SetResponse sets the "response" field.
+func (m *RawCaptureMutation) SetResponse(b []byte) {
+	m.response = &b
+}
+
+// Response returns the value of the "response" field in the mutation.
+func (m *RawCaptureMutation) Response() (r []byte, exists bool) {
+	v := m.response
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResponse returns the old "response" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldResponse(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResponse is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResponse requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResponse: %w", err)
+	}
+	return oldValue.Response, nil
+}
+
+// ClearResponse clears the value of the "response" field.
+func (m *RawCaptureMutation) ClearResponse() {
+	m.response = nil
+	m.clearedFields[rawcapture.FieldResponse] = struct{}{}
+}
+
+// ResponseCleared returns if the "response" field was cleared in this mutation.
+func (m *RawCaptureMutation) ResponseCleared() bool {
+	_, ok := m.clearedFields[rawcapture.FieldResponse]
+	return ok
+}
+
+// ResetResponse resets all changes to the "response" field.
+func (m *RawCaptureMutation) ResetResponse() {
+	m.response = nil
+	delete(m.clearedFields, rawcapture.FieldResponse)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *RawCaptureMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *RawCaptureMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the RawCapture entity.
+// If the RawCapture object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *RawCaptureMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *RawCaptureMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// Where appends a list predicates to the RawCaptureMutation builder.
+func (m *RawCaptureMutation) Where(ps ...predicate.RawCapture) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the RawCaptureMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *RawCaptureMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.RawCapture, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *RawCaptureMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *RawCaptureMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (RawCapture).
+func (m *RawCaptureMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *RawCaptureMutation) Fields() []string {
+	fields := make([]string, 0, 13)
+	if m.node_hash != nil {
+		fields = append(fields, rawcapture.FieldNodeHash)
+	}
+	if m.provider != nil {
+		fields = append(fields, rawcapture.FieldProvider)
+	}
+	if m.agent_name != nil {
+		fields = append(fields, rawcapture.FieldAgentName)
+	}
+	if m.session_id != nil {
+		fields = append(fields, rawcapture.FieldSessionID)
+	}
+	if m.run_id != nil {
+		fields = append(fields, rawcapture.FieldRunID)
+	}
+	if m.project != nil {
+		fields = append(fields, rawcapture.FieldProject)
+	}
+	if m.user != nil {
+		fields = append(fields, rawcapture.FieldUser)
+	}
+	if m._path != nil {
+		fields = append(fields, rawcapture.FieldPath)
+	}
+	if m.streamed != nil {
+		fields = append(fields, rawcapture.FieldStreamed)
+	}
+	if m.encrypted != nil {
+		fields = append(fields, rawcapture.FieldEncrypted)
+	}
+	if m.request != nil {
+		fields = append(fields, rawcapture.FieldRequest)
+	}
+	if m.response != nil {
+		fields = append(fields, rawcapture.FieldResponse)
+	}
+	if m.created_at != nil {
+		fields = append(fields, rawcapture.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *RawCaptureMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case rawcapture.FieldNodeHash:
+		return m.NodeHash()
+	case rawcapture.FieldProvider:
+		return m.Provider()
+	case rawcapture.FieldAgentName:
+		return m.AgentName()
+	case rawcapture.FieldSessionID:
+		return m.SessionID()
+	case rawcapture.FieldRunID:
+		return m.RunID()
+	case rawcapture.FieldProject:
+		return m.Project()
+	case rawcapture.FieldUser:
+		return m.User()
+	case rawcapture.FieldPath:
+		return m.Path()
+	case rawcapture.FieldStreamed:
+		return m.Streamed()
+	case rawcapture.FieldEncrypted:
+		return m.Encrypted()
+	case rawcapture.FieldRequest:
+		return m.Request()
+	case rawcapture.FieldResponse:
+		return m.Response()
+	case rawcapture.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *RawCaptureMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case rawcapture.FieldNodeHash:
+		return m.OldNodeHash(ctx)
+	case rawcapture.FieldProvider:
+		return m.OldProvider(ctx)
+	case rawcapture.FieldAgentName:
+		return m.OldAgentName(ctx)
+	case rawcapture.FieldSessionID:
+		return m.OldSessionID(ctx)
+	case rawcapture.FieldRunID:
+		return m.OldRunID(ctx)
+	case rawcapture.FieldProject:
+		return m.OldProject(ctx)
+	case rawcapture.FieldUser:
+		return m.OldUser(ctx)
+	case rawcapture.FieldPath:
+		return m.OldPath(ctx)
+	case rawcapture.FieldStreamed:
+		return m.OldStreamed(ctx)
+	case rawcapture.FieldEncrypted:
+		return m.OldEncrypted(ctx)
+	case rawcapture.FieldRequest:
+		return m.OldRequest(ctx)
+	case rawcapture.FieldResponse:
+		return m.OldResponse(ctx)
+	case rawcapture.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown RawCapture field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *RawCaptureMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case rawcapture.FieldNodeHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNodeHash(v)
+		return nil
+	case rawcapture.FieldProvider:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProvider(v)
+		return nil
+	case rawcapture.FieldAgentName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAgentName(v)
+		return nil
+	case rawcapture.FieldSessionID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionID(v)
+		return nil
+	case rawcapture.FieldRunID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRunID(v)
+		return nil
+	case rawcapture.FieldProject:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetProject(v)
+		return nil
+	case rawcapture.FieldUser:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUser(v)
+		return nil
+	case rawcapture.FieldPath:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPath(v)
+		return nil
+	case rawcapture.FieldStreamed:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStreamed(v)
+		return nil
+	case rawcapture.FieldEncrypted:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEncrypted(v)
+		return nil
+	case rawcapture.FieldRequest:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRequest(v)
+		return nil
+	case rawcapture.FieldResponse:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResponse(v)
+		return nil
+	case rawcapture.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown RawCapture field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *RawCaptureMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *RawCaptureMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *RawCaptureMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown RawCapture numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *RawCaptureMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(rawcapture.FieldAgentName) {
+		fields = append(fields, rawcapture.FieldAgentName)
+	}
+	if m.FieldCleared(rawcapture.FieldSessionID) {
+		fields = append(fields, rawcapture.FieldSessionID)
+	}
+	if m.FieldCleared(rawcapture.FieldRunID) {
+		fields = append(fields, rawcapture.FieldRunID)
+	}
+	if m.FieldCleared(rawcapture.FieldProject) {
+		fields = append(fields, rawcapture.FieldProject)
+	}
+	if m.FieldCleared(rawcapture.FieldUser) {
+		fields = append(fields, rawcapture.FieldUser)
+	}
+	if m.FieldCleared(rawcapture.FieldPath) {
+		fields = append(fields, rawcapture.FieldPath)
+	}
+	if m.FieldCleared(rawcapture.FieldResponse) {
+		fields = append(fields, rawcapture.FieldResponse)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *RawCaptureMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *RawCaptureMutation) ClearField(name string) error {
+	switch name {
+	case rawcapture.FieldAgentName:
+		m.ClearAgentName()
+		return nil
+	case rawcapture.FieldSessionID:
+		m.ClearSessionID()
+		return nil
+	case rawcapture.FieldRunID:
+		m.ClearRunID()
+		return nil
+	case rawcapture.FieldProject:
+		m.ClearProject()
+		return nil
+	case rawcapture.FieldUser:
+		m.ClearUser()
+		return nil
+	case rawcapture.FieldPath:
+		m.ClearPath()
+		return nil
+	case rawcapture.FieldResponse:
+		m.ClearResponse()
+		return nil
+	}
+	return fmt.Errorf("unknown RawCapture nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *RawCaptureMutation) ResetField(name string) error {
+	switch name {
+	case rawcapture.FieldNodeHash:
+		m.ResetNodeHash()
+		return nil
+	case rawcapture.FieldProvider:
+		m.ResetProvider()
+		return nil
+	case rawcapture.FieldAgentName:
+		m.ResetAgentName()
+		return nil
+	case rawcapture.FieldSessionID:
+		m.ResetSessionID()
+		return nil
+	case rawcapture.FieldRunID:
+		m.ResetRunID()
+		return nil
+	case rawcapture.FieldProject:
+		m.ResetProject()
+		return nil
+	case rawcapture.FieldUser:
+		m.ResetUser()
+		return nil
+	case rawcapture.FieldPath:
+		m.ResetPath()
+		return nil
+	case rawcapture.FieldStreamed:
+		m.ResetStreamed()
+		return nil
+	case rawcapture.FieldEncrypted:
+		m.ResetEncrypted()
+		return nil
+	case rawcapture.FieldRequest:
+		m.ResetRequest()
+		return nil
+	case rawcapture.FieldResponse:
+		m.ResetResponse()
+		return nil
+	case rawcapture.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown RawCapture field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *RawCaptureMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *RawCaptureMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *RawCaptureMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *RawCaptureMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *RawCaptureMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *RawCaptureMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *RawCaptureMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown RawCapture unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *RawCaptureMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown RawCapture edge %s", name)
+}
+
+// SessionMutation represents an operation that mutates the Session nodes in the graph.
+type SessionMutation struct {
+	config
+	op                             Op
+	typ                            string
+	id                             *string
+	root_hash                      *string
+	turn_count                     *int
+	addturn_count                  *int
+	prompt_tokens                  *int64
+	addprompt_tokens               *int64
+	completion_tokens              *int64
+	addcompletion_tokens           *int64
+	total_tokens                   *int64
+	addtotal_tokens                *int64
+	cache_creation_input_tokens    *int64
+	addcache_creation_input_tokens *int64
+	cache_read_input_tokens        *int64
+	addcache_read_input_tokens     *int64
+	cost                           *float64
+	addcost                        *float64
+	last_activity                  *time.Time
+	revision                       *int64
+	addrevision                    *int64
+	clearedFields                  map[string]struct{}
+	done                           bool
+	oldValue                       func(context.Context) (*Session, error)
+	predicates                     []predicate.Session
+}
+
+var _ ent.Mutation = (*SessionMutation)(nil)
+
+// sessionOption allows management of the mutation configuration using functional options.
+type sessionOption func(*SessionMutation)
+
+// newSessionMutation creates new mutation for the Session entity.
+func newSessionMutation(c config, op Op, opts ...sessionOption) *SessionMutation {
+	m := &SessionMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeSession,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withSessionID sets the ID field of the mutation.
+func withSessionID(id string) sessionOption {
+	return func(m *SessionMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Session
+		)
+		m.oldValue = func(ctx context.Context) (*Session, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Session.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withSession sets the old Session of the mutation.
+func withSession(node *Session) sessionOption {
+	return func(m *SessionMutation) {
+		m.oldValue = func(context.Context) (*Session, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m SessionMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m SessionMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of Session entities.
+func (m *SessionMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *SessionMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *SessionMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Session.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetRootHash sets the "root_hash" field.
+func (m *SessionMutation) SetRootHash(s string) {
+	m.root_hash = &s
+}
+
+// RootHash returns the value of the "root_hash" field in the mutation.
+func (m *SessionMutation) RootHash() (r string, exists bool) {
+	v := m.root_hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRootHash returns the old "root_hash" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldRootHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRootHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRootHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRootHash: %w", err)
+	}
+	return oldValue.RootHash, nil
+}
+
+// ResetRootHash resets all changes to the "root_hash" field.
+func (m *SessionMutation) ResetRootHash() {
+	m.root_hash = nil
+}
+
+// SetTurnCount sets the "turn_count" field.
+func (m *SessionMutation) SetTurnCount(i int) {
+	m.turn_count = &i
+	m.addturn_count = nil
+}
+
+// TurnCount returns the value of the "turn_count" field in the mutation.
+func (m *SessionMutation) TurnCount() (r int, exists bool) {
+	v := m.turn_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTurnCount returns the old "turn_count" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldTurnCount(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTurnCount is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTurnCount requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTurnCount: %w", err)
+	}
+	return oldValue.TurnCount, nil
+}
+
+// AddTurnCount adds i to the "turn_count" field.
+func (m *SessionMutation) AddTurnCount(i int) {
+	if m.addturn_count != nil {
+		*m.addturn_count += i
+	} else {
+		m.addturn_count = &i
+	}
+}
+
+// AddedTurnCount returns the value that was added to the "turn_count" field in this mutation.
+func (m *SessionMutation) AddedTurnCount() (r int, exists bool) {
+	v := m.addturn_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetTurnCount resets all changes to the "turn_count" field.
+func (m *SessionMutation) ResetTurnCount() {
+	m.turn_count = nil
+	m.addturn_count = nil
+}
+
+// SetPromptTokens sets the "prompt_tokens" field.
+func (m *SessionMutation) SetPromptTokens(i int64) {
+	m.prompt_tokens = &i
+	m.addprompt_tokens = nil
+}
+
+// PromptTokens returns the value of the "prompt_tokens" field in the mutation.
+func (m *SessionMutation) PromptTokens() (r int64, exists bool) {
+	v := m.prompt_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPromptTokens returns the old "prompt_tokens" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldPromptTokens(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPromptTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPromptTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPromptTokens: %w", err)
+	}
+	return oldValue.PromptTokens, nil
+}
+
+// AddPromptTokens adds i to the "prompt_tokens" field.
+func (m *SessionMutation) AddPromptTokens(i int64) {
+	if m.addprompt_tokens != nil {
+		*m.addprompt_tokens += i
+	} else {
+		m.addprompt_tokens = &i
+	}
+}
+
+// AddedPromptTokens returns the value that was added to the "prompt_tokens" field in this mutation.
+func (m *SessionMutation) AddedPromptTokens() (r int64, exists bool) {
+	v := m.addprompt_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetPromptTokens resets all changes to the "prompt_tokens" field.
+func (m *SessionMutation) ResetPromptTokens() {
+	m.prompt_tokens = nil
+	m.addprompt_tokens = nil
+}
+
+// SetCompletionTokens sets the "completion_tokens" field.
+func (m *SessionMutation) SetCompletionTokens(i int64) {
+	m.completion_tokens = &i
+	m.addcompletion_tokens = nil
+}
+
+// CompletionTokens returns the value of the "completion_tokens" field in the mutation.
+func (m *SessionMutation) CompletionTokens() (r int64, exists bool) {
+	v := m.completion_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCompletionTokens returns the old "completion_tokens" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldCompletionTokens(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCompletionTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCompletionTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCompletionTokens: %w", err)
+	}
+	return oldValue.CompletionTokens, nil
+}
+
+// AddCompletionTokens adds i to the "completion_tokens" field.
+func (m *SessionMutation) AddCompletionTokens(i int64) {
+	if m.addcompletion_tokens != nil {
+		*m.addcompletion_tokens += i
+	} else {
+		m.addcompletion_tokens = &i
+	}
+}
+
+// AddedCompletionTokens returns the value that was added to the "completion_tokens" field in this mutation.
+func (m *SessionMutation) AddedCompletionTokens() (r int64, exists bool) {
+	v := m.addcompletion_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetCompletionTokens resets all changes to the "completion_tokens" field.
+func (m *SessionMutation) ResetCompletionTokens() {
+	m.completion_tokens = nil
+	m.addcompletion_tokens = nil
+}
+
+// SetTotalTokens sets the "total_tokens" field.
+func (m *SessionMutation) SetTotalTokens(i int64) {
+	m.total_tokens = &i
+	m.addtotal_tokens = nil
+}
+
+// TotalTokens returns the value of the "total_tokens" field in the mutation.
+func (m *SessionMutation) TotalTokens() (r int64, exists bool) {
+	v := m.total_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTotalTokens returns the old "total_tokens" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldTotalTokens(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTotalTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTotalTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTotalTokens: %w", err)
+	}
+	return oldValue.TotalTokens, nil
+}
+
+// AddTotalTokens adds i to the "total_tokens" field.
+func (m *SessionMutation) AddTotalTokens(i int64) {
+	if m.addtotal_tokens != nil {
+		*m.addtotal_tokens += i
+	} else {
+		m.addtotal_tokens = &i
+	}
+}
+
+// AddedTotalTokens returns the value that was added to the "total_tokens" field in this mutation.
+func (m *SessionMutation) AddedTotalTokens() (r int64, exists bool) {
+	v := m.addtotal_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetTotalTokens resets all changes to the "total_tokens" field.
+func (m *SessionMutation) ResetTotalTokens() {
+	m.total_tokens = nil
+	m.addtotal_tokens = nil
+}
+
+// SetCacheCreationInputTokens sets the "cache_creation_input_tokens" field.
+func (m *SessionMutation) SetCacheCreationInputTokens(i int64) {
+	m.cache_creation_input_tokens = &i
+	m.addcache_creation_input_tokens = nil
+}
+
+// CacheCreationInputTokens returns the value of the "cache_creation_input_tokens" field in the mutation.
+func (m *SessionMutation) CacheCreationInputTokens() (r int64, exists bool) {
+	v := m.cache_creation_input_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCacheCreationInputTokens returns the old "cache_creation_input_tokens" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldCacheCreationInputTokens(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCacheCreationInputTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCacheCreationInputTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCacheCreationInputTokens: %w", err)
+	}
+	return oldValue.CacheCreationInputTokens, nil
+}
+
+// AddCacheCreationInputTokens adds i to the "cache_creation_input_tokens" field.
+func (m *SessionMutation) AddCacheCreationInputTokens(i int64) {
+	if m.addcache_creation_input_tokens != nil {
+		*m.addcache_creation_input_tokens += i
+	} else {
+		m.addcache_creation_input_tokens = &i
+	}
+}
+
+// AddedCacheCreationInputTokens returns the value that was added to the "cache_creation_input_tokens" field in this mutation.
+func (m *SessionMutation) AddedCacheCreationInputTokens() (r int64, exists bool) {
+	v := m.addcache_creation_input_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetCacheCreationInputTokens resets all changes to the "cache_creation_input_tokens" field.
+func (m *SessionMutation) ResetCacheCreationInputTokens() {
+	m.cache_creation_input_tokens = nil
+	m.addcache_creation_input_tokens = nil
+}
+
+// SetCacheReadInputTokens sets the "cache_read_input_tokens" field.
+func (m *SessionMutation) SetCacheReadInputTokens(i int64) {
+	m.cache_read_input_tokens = &i
+	m.addcache_read_input_tokens = nil
+}
+
+// CacheReadInputTokens returns the value of the "cache_read_input_tokens" field in the mutation.
+func (m *SessionMutation) CacheReadInputTokens() (r int64, exists bool) {
+	v := m.cache_read_input_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCacheReadInputTokens returns the old "cache_read_input_tokens" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldCacheReadInputTokens(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCacheReadInputTokens is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCacheReadInputTokens requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCacheReadInputTokens: %w", err)
+	}
+	return oldValue.CacheReadInputTokens, nil
+}
+
+// AddCacheReadInputTokens adds i to the "cache_read_input_tokens" field.
+func (m *SessionMutation) AddCacheReadInputTokens(i int64) {
+	if m.addcache_read_input_tokens != nil {
+		*m.addcache_read_input_tokens += i
+	} else {
+		m.addcache_read_input_tokens = &i
+	}
+}
+
+// AddedCacheReadInputTokens returns the value that was added to the "cache_read_input_tokens" field in this mutation.
+func (m *SessionMutation) AddedCacheReadInputTokens() (r int64, exists bool) {
+	v := m.addcache_read_input_tokens
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetCacheReadInputTokens resets all changes to the "cache_read_input_tokens" field.
+func (m *SessionMutation) ResetCacheReadInputTokens() {
+	m.cache_read_input_tokens = nil
+	m.addcache_read_input_tokens = nil
+}
+
+// SetCost sets the "cost" field.
+func (m *SessionMutation) SetCost(f float64) {
+	m.cost = &f
+	m.addcost = nil
+}
+
+// Cost returns the value of the "cost" field in the mutation.
+func (m *SessionMutation) Cost() (r float64, exists bool) {
+	v := m.cost
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCost returns the old "cost" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldCost(ctx context.Context) (v float64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCost is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCost requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCost: %w", err)
+	}
+	return oldValue.Cost, nil
+}
+
+// AddCost adds f to the "cost" field.
+func (m *SessionMutation) AddCost(f float64) {
+	if m.addcost != nil {
+		*m.addcost += f
+	} else {
+		m.addcost = &f
+	}
+}
+
+// AddedCost returns the value that was added to the "cost" field in this mutation.
+func (m *SessionMutation) AddedCost() (r float64, exists bool) {
+	v := m.addcost
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetCost resets all changes to the "cost" field.
+func (m *SessionMutation) ResetCost() {
+	m.cost = nil
+	m.addcost = nil
+}
+
+// SetLastActivity sets the "last_activity" field.
+func (m *SessionMutation) SetLastActivity(t time.Time) {
+	m.last_activity = &t
+}
+
+// LastActivity returns the value of the "last_activity" field in the mutation.
+func (m *SessionMutation) LastActivity() (r time.Time, exists bool) {
+	v := m.last_activity
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLastActivity returns the old "last_activity" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldLastActivity(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastActivity is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastActivity requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastActivity: %w", err)
+	}
+	return oldValue.LastActivity, nil
+}
+
+// ResetLastActivity resets all changes to the "last_activity" field.
+func (m *SessionMutation) ResetLastActivity() {
+	m.last_activity = nil
+}
+
+// SetRevision sets the "revision" field.
+func (m *SessionMutation) SetRevision(i int64) {
+	m.revision = &i
+	m.addrevision = nil
+}
+
+// Revision returns the value of the "revision" field in the mutation.
+func (m *SessionMutation) Revision() (r int64, exists bool) {
+	v := m.revision
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRevision returns the old "revision" field's value of the Session entity.
+// If the Session object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionMutation) OldRevision(ctx context.Context) (v int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRevision is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRevision requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRevision: %w", err)
+	}
+	return oldValue.Revision, nil
+}
+
+// AddRevision adds i to the "revision" field.
+func (m *SessionMutation) AddRevision(i int64) {
+	if m.addrevision != nil {
+		*m.addrevision += i
+	} else {
+		m.addrevision = &i
+	}
+}
+
+// AddedRevision returns the value that was added to the "revision" field in this mutation.
+func (m *SessionMutation) AddedRevision() (r int64, exists bool) {
+	v := m.addrevision
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetRevision resets all changes to the "revision" field.
+func (m *SessionMutation) ResetRevision() {
+	m.revision = nil
+	m.addrevision = nil
+}
+
+// Where appends a list predicates to the SessionMutation builder.
+func (m *SessionMutation) Where(ps ...predicate.Session) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the SessionMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *SessionMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Session, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *SessionMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *SessionMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Session).
+func (m *SessionMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *SessionMutation) Fields() []string {
+	fields := make([]string, 0, 10)
+	if m.root_hash != nil {
+		fields = append(fields, session.FieldRootHash)
+	}
+	if m.turn_count != nil {
+		fields = append(fields, session.FieldTurnCount)
+	}
+	if m.prompt_tokens != nil {
+		fields = append(fields, session.FieldPromptTokens)
+	}
+	if m.completion_tokens != nil {
+		fields = append(fields, session.FieldCompletionTokens)
+	}
+	if m.total_tokens != nil {
+		fields = append(fields, session.FieldTotalTokens)
+	}
+	if m.cache_creation_input_tokens != nil {
+		fields = append(fields, session.FieldCacheCreationInputTokens)
+	}
+	if m.cache_read_input_tokens != nil {
+		fields = append(fields, session.FieldCacheReadInputTokens)
+	}
+	if m.cost != nil {
+		fields = append(fields, session.FieldCost)
+	}
+	if m.last_activity != nil {
+		fields = append(fields, session.FieldLastActivity)
+	}
+	if m.revision != nil {
+		fields = append(fields, session.FieldRevision)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *SessionMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case session.FieldRootHash:
+		return m.RootHash()
+	case session.FieldTurnCount:
+		return m.TurnCount()
+	case session.FieldPromptTokens:
+		return m.PromptTokens()
+	case session.FieldCompletionTokens:
+		return m.CompletionTokens()
+	case session.FieldTotalTokens:
+		return m.TotalTokens()
+	case session.FieldCacheCreationInputTokens:
+		return m.CacheCreationInputTokens()
+	case session.FieldCacheReadInputTokens:
+		return m.CacheReadInputTokens()
+	case session.FieldCost:
+		return m.Cost()
+	case session.FieldLastActivity:
+		return m.LastActivity()
+	case session.FieldRevision:
+		return m.Revision()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *SessionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case session.FieldRootHash:
+		return m.OldRootHash(ctx)
+	case session.FieldTurnCount:
+		return m.OldTurnCount(ctx)
+	case session.FieldPromptTokens:
+		return m.OldPromptTokens(ctx)
+	case session.FieldCompletionTokens:
+		return m.OldCompletionTokens(ctx)
+	case session.FieldTotalTokens:
+		return m.OldTotalTokens(ctx)
+	case session.FieldCacheCreationInputTokens:
+		return m.OldCacheCreationInputTokens(ctx)
+	case session.FieldCacheReadInputTokens:
+		return m.OldCacheReadInputTokens(ctx)
+	case session.FieldCost:
+		return m.OldCost(ctx)
+	case session.FieldLastActivity:
+		return m.OldLastActivity(ctx)
+	case session.FieldRevision:
+		return m.OldRevision(ctx)
+	}
+	return nil, fmt.Errorf("unknown Session field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SessionMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case session.FieldRootHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRootHash(v)
+		return nil
+	case session.FieldTurnCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTurnCount(v)
+		return nil
+	case session.FieldPromptTokens:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPromptTokens(v)
+		return nil
+	case session.FieldCompletionTokens:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCompletionTokens(v)
+		return nil
+	case session.FieldTotalTokens:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTotalTokens(v)
+		return nil
+	case session.FieldCacheCreationInputTokens:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCacheCreationInputTokens(v)
+		return nil
+	case session.FieldCacheReadInputTokens:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCacheReadInputTokens(v)
+		return nil
+	case session.FieldCost:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCost(v)
+		return nil
+	case session.FieldLastActivity:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastActivity(v)
+		return nil
+	case session.FieldRevision:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRevision(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Session field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *SessionMutation) AddedFields() []string {
+	var fields []string
+	if m.addturn_count != nil {
+		fields = append(fields, session.FieldTurnCount)
+	}
+	if m.addprompt_tokens != nil {
+		fields = append(fields, session.FieldPromptTokens)
+	}
+	if m.addcompletion_tokens != nil {
+		fields = append(fields, session.FieldCompletionTokens)
+	}
+	if m.addtotal_tokens != nil {
+		fields = append(fields, session.FieldTotalTokens)
+	}
+	if m.addcache_creation_input_tokens != nil {
+		fields = append(fields, session.FieldCacheCreationInputTokens)
+	}
+	if m.addcache_read_input_tokens != nil {
+		fields = append(fields, session.FieldCacheReadInputTokens)
+	}
+	if m.addcost != nil {
+		fields = append(fields, session.FieldCost)
+	}
+	if m.addrevision != nil {
+		fields = append(fields, session.FieldRevision)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *SessionMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case session.FieldTurnCount:
+		return m.AddedTurnCount()
+	case session.FieldPromptTokens:
+		return m.AddedPromptTokens()
+	case session.FieldCompletionTokens:
+		return m.AddedCompletionTokens()
+	case session.FieldTotalTokens:
+		return m.AddedTotalTokens()
+	case session.FieldCacheCreationInputTokens:
+		return m.AddedCacheCreationInputTokens()
+	case session.FieldCacheReadInputTokens:
+		return m.AddedCacheReadInputTokens()
+	case session.FieldCost:
+		return m.AddedCost()
+	case session.FieldRevision:
+		return m.AddedRevision()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SessionMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case session.FieldTurnCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTurnCount(v)
+		return nil
+	case session.FieldPromptTokens:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddPromptTokens(v)
+		return nil
+	case session.FieldCompletionTokens:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCompletionTokens(v)
+		return nil
+	case session.FieldTotalTokens:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTotalTokens(v)
+		return nil
+	case session.FieldCacheCreationInputTokens:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCacheCreationInputTokens(v)
+		return nil
+	case session.FieldCacheReadInputTokens:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCacheReadInputTokens(v)
+		return nil
+	case session.FieldCost:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddCost(v)
+		return nil
+	case session.FieldRevision:
+		v, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRevision(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Session numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *SessionMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *SessionMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *SessionMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Session nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *SessionMutation) ResetField(name string) error {
+	switch name {
+	case session.FieldRootHash:
+		m.ResetRootHash()
+		return nil
+	case session.FieldTurnCount:
+		m.ResetTurnCount()
+		return nil
+	case session.FieldPromptTokens:
+		m.ResetPromptTokens()
+		return nil
+	case session.FieldCompletionTokens:
+		m.ResetCompletionTokens()
+		return nil
+	case session.FieldTotalTokens:
+		m.ResetTotalTokens()
+		return nil
+	case session.FieldCacheCreationInputTokens:
+		m.ResetCacheCreationInputTokens()
+		return nil
+	case session.FieldCacheReadInputTokens:
+		m.ResetCacheReadInputTokens()
+		return nil
+	case session.FieldCost:
+		m.ResetCost()
+		return nil
+	case session.FieldLastActivity:
+		m.ResetLastActivity()
+		return nil
+	case session.FieldRevision:
+		m.ResetRevision()
+		return nil
+	}
+	return fmt.Errorf("unknown Session field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *SessionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *SessionMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *SessionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *SessionMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *SessionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *SessionMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *SessionMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Session unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *SessionMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Session edge %s", name)
+}
+
+// SessionToolMutation represents an operation that mutates the SessionTool nodes in the graph.
+type SessionToolMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	session_id    *string
+	tool_hash     *string
+	run_id        *string
+	agent_name    *string
+	node_hash     *string
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*SessionTool, error)
+	predicates    []predicate.SessionTool
+}
+
+var _ ent.Mutation = (*SessionToolMutation)(nil)
+
+// sessiontoolOption allows management of the mutation configuration using functional options.
+type sessiontoolOption func(*SessionToolMutation)
+
+// newSessionToolMutation creates new mutation for the SessionTool entity.
+func newSessionToolMutation(c config, op Op, opts ...sessiontoolOption) *SessionToolMutation {
+	m := &SessionToolMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeSessionTool,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withSessionToolID sets the ID field of the mutation.
+func withSessionToolID(id int) sessiontoolOption {
+	return func(m *SessionToolMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *SessionTool
+		)
+		m.oldValue = func(ctx context.Context) (*SessionTool, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().SessionTool.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withSessionTool sets the old SessionTool of the mutation.
+func withSessionTool(node *SessionTool) sessiontoolOption {
+	return func(m *SessionToolMutation) {
+		m.oldValue = func(context.Context) (*SessionTool, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m SessionToolMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m SessionToolMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *SessionToolMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *SessionToolMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().SessionTool.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetSessionID sets the "session_id" field.
+func (m *SessionToolMutation) SetSessionID(s string) {
+	m.session_id = &s
+}
+
+// SessionID returns the value of the "session_id" field in the mutation.
+func (m *SessionToolMutation) SessionID() (r string, exists bool) {
+	v := m.session_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSessionID returns the old "session_id" field's value of the SessionTool entity.
+// If the SessionTool object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionToolMutation) OldSessionID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSessionID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSessionID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSessionID: %w", err)
+	}
+	return oldValue.SessionID, nil
+}
+
+// ResetSessionID resets all changes to the "session_id" field.
+func (m *SessionToolMutation) ResetSessionID() {
+	m.session_id = nil
+}
+
+// SetToolHash sets the "tool_hash" field.
+func (m *SessionToolMutation) SetToolHash(s string) {
+	m.tool_hash = &s
+}
+
+// ToolHash returns the value of the "tool_hash" field in the mutation.
+func (m *SessionToolMutation) ToolHash() (r string, exists bool) {
+	v := m.tool_hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldToolHash returns the old "tool_hash" field's value of the SessionTool entity.
+// If the SessionTool object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionToolMutation) OldToolHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldToolHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldToolHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldToolHash: %w", err)
+	}
+	return oldValue.ToolHash, nil
+}
+
+// ResetToolHash resets all changes to the "tool_hash" field.
+func (m *SessionToolMutation) ResetToolHash() {
+	m.tool_hash = nil
+}
+
+// SetRunID sets the "run_id" field.
+func (m *SessionToolMutation) SetRunID(s string) {
+	m.run_id = &s
+}
+
+// RunID returns the value of the "run_id" field in the mutation.
+func (m *SessionToolMutation) RunID() (r string, exists bool) {
+	v := m.run_id
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRunID returns the old "run_id" field's value of the SessionTool entity.
+// If the SessionTool object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionToolMutation) OldRunID(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRunID is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRunID requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRunID: %w", err)
+	}
+	return oldValue.RunID, nil
+}
+
+// ClearRunID clears the value of the "run_id" field.
+func (m *SessionToolMutation) ClearRunID() {
+	m.run_id = nil
+	m.clearedFields[sessiontool.FieldRunID] = struct{}{}
+}
+
+// RunIDCleared returns if the "run_id" field was cleared in this mutation.
+func (m *SessionToolMutation) RunIDCleared() bool {
+	_, ok := m.clearedFields[sessiontool.FieldRunID]
+	return ok
+}
+
+// ResetRunID resets all changes to the "run_id" field.
+func (m *SessionToolMutation) ResetRunID() {
+	m.run_id = nil
+	delete(m.clearedFields, sessiontool.FieldRunID)
+}
+
+// SetAgentName sets the "agent_name" field.
+func (m *SessionToolMutation) SetAgentName(s string) {
+	m.agent_name = &s
+}
+
+// AgentName returns the value of the "agent_name" field in the mutation.
+func (m *SessionToolMutation) AgentName() (r string, exists bool) {
+	v := m.agent_name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAgentName returns the old "agent_name" field's value of the SessionTool entity.
+// If the SessionTool object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionToolMutation) OldAgentName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAgentName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAgentName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAgentName: %w", err)
+	}
+	return oldValue.AgentName, nil
+}
+
+// ClearAgentName clears the value of the "agent_name" field.
+func (m *SessionToolMutation) ClearAgentName() {
+	m.agent_name = nil
+	m.clearedFields[sessiontool.FieldAgentName] = struct{}{}
+}
+
+// AgentNameCleared returns if the "agent_name" field was cleared in this mutation.
+func (m *SessionToolMutation) AgentNameCleared() bool {
+	_, ok := m.clearedFields[sessiontool.FieldAgentName]
+	return ok
+}
+
+// ResetAgentName resets all changes to the "agent_name" field.
+func (m *SessionToolMutation) ResetAgentName() {
+	m.agent_name = nil
+	delete(m.clearedFields, sessiontool.FieldAgentName)
+}
+
+// SetNodeHash sets the "node_hash" field.
+func (m *SessionToolMutation) SetNodeHash(s string) {
+	m.node_hash = &s
+}
+
+// NodeHash returns the value of the "node_hash" field in the mutation.
+func (m *SessionToolMutation) NodeHash() (r string, exists bool) {
+	v := m.node_hash
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNodeHash returns the old "node_hash" field's value of the SessionTool entity.
+// If the SessionTool object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionToolMutation) OldNodeHash(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNodeHash is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNodeHash requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNodeHash: %w", err)
+	}
+	return oldValue.NodeHash, nil
+}
+
+// ResetNodeHash resets all changes to the "node_hash" field.
+func (m *SessionToolMutation) ResetNodeHash() {
+	m.node_hash = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *SessionToolMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *SessionToolMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the SessionTool entity.
+// If the SessionTool object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SessionToolMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *SessionToolMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// Where appends a list predicates to the SessionToolMutation builder.
+func (m *SessionToolMutation) Where(ps ...predicate.SessionTool) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the SessionToolMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *SessionToolMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.SessionTool, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *SessionToolMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *SessionToolMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (SessionTool).
+func (m *SessionToolMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *SessionToolMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.session_id != nil {
+		fields = append(fields, sessiontool.FieldSessionID)
+	}
+	if m.tool_hash != nil {
+		fields = append(fields, sessiontool.FieldToolHash)
+	}
+	if m.run_id != nil {
+		fields = append(fields, sessiontool.FieldRunID)
+	}
+	if m.agent_name != nil {
+		fields = append(fields, sessiontool.FieldAgentName)
+	}
+	if m.node_hash != nil {
+		fields = append(fields, sessiontool.FieldNodeHash)
+	}
+	if m.created_at != nil {
+		fields = append(fields, sessiontool.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *SessionToolMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case sessiontool.FieldSessionID:
+		return m.SessionID()
+	case sessiontool.FieldToolHash:
+		return m.ToolHash()
+	case sessiontool.FieldRunID:
+		return m.RunID()
+	case sessiontool.FieldAgentName:
+		return m.AgentName()
+	case sessiontool.FieldNodeHash:
+		return m.NodeHash()
+	case sessiontool.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *SessionToolMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case sessiontool.FieldSessionID:
+		return m.OldSessionID(ctx)
+	case sessiontool.FieldToolHash:
+		return m.OldToolHash(ctx)
+	case sessiontool.FieldRunID:
+		return m.OldRunID(ctx)
+	case sessiontool.FieldAgentName:
+		return m.OldAgentName(ctx)
+	case sessiontool.FieldNodeHash:
+		return m.OldNodeHash(ctx)
+	case sessiontool.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown SessionTool field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SessionToolMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case sessiontool.FieldSessionID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSessionID(v)
+		return nil
+	case sessiontool.FieldToolHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetToolHash(v)
+		return nil
+	case sessiontool.FieldRunID:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRunID(v)
+		return nil
+	case sessiontool.FieldAgentName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAgentName(v)
+		return nil
+	case sessiontool.FieldNodeHash:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNodeHash(v)
+		return nil
+	case sessiontool.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown SessionTool field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *SessionToolMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *SessionToolMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SessionToolMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown SessionTool numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *SessionToolMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(sessiontool.FieldRunID) {
+		fields = append(fields, sessiontool.FieldRunID)
+	}
+	if m.FieldCleared(sessiontool.FieldAgentName) {
+		fields = append(fields, sessiontool.FieldAgentName)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *SessionToolMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *SessionToolMutation) ClearField(name string) error {
+	switch name {
+	case sessiontool.FieldRunID:
+		m.ClearRunID()
+		return nil
+	case sessiontool.FieldAgentName:
+		m.ClearAgentName()
+		return nil
+	}
+	return fmt.Errorf("unknown SessionTool nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *SessionToolMutation) ResetField(name string) error {
+	switch name {
+	case sessiontool.FieldSessionID:
+		m.ResetSessionID()
+		return nil
+	case sessiontool.FieldToolHash:
+		m.ResetToolHash()
+		return nil
+	case sessiontool.FieldRunID:
+		m.ResetRunID()
+		return nil
+	case sessiontool.FieldAgentName:
+		m.ResetAgentName()
+		return nil
+	case sessiontool.FieldNodeHash:
+		m.ResetNodeHash()
+		return nil
+	case sessiontool.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown SessionTool field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *SessionToolMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *SessionToolMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *SessionToolMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *SessionToolMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *SessionToolMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *SessionToolMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *SessionToolMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown SessionTool unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *SessionToolMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown SessionTool edge %s", name)
+}
+
+// SystemPromptMutation represents an operation that mutates the SystemPrompt nodes in the graph.
+type SystemPromptMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *string
+	text          *[]byte
+	encrypted     *bool
+	size          *int
+	addsize       *int
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*SystemPrompt, error)
+	predicates    []predicate.SystemPrompt
+}
+
+var _ ent.Mutation = (*SystemPromptMutation)(nil)
+
+// systempromptOption allows management of the mutation configuration using functional options.
+type systempromptOption func(*SystemPromptMutation)
+
+// newSystemPromptMutation creates new mutation for the SystemPrompt entity.
+func newSystemPromptMutation(c config, op Op, opts ...systempromptOption) *SystemPromptMutation {
+	m := &SystemPromptMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeSystemPrompt,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withSystemPromptID sets the ID field of the mutation.
+func withSystemPromptID(id string) systempromptOption {
+	return func(m *SystemPromptMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *SystemPrompt
+		)
+		m.oldValue = func(ctx context.Context) (*SystemPrompt, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().SystemPrompt.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withSystemPrompt sets the old SystemPrompt of the mutation.
+func withSystemPrompt(node *SystemPrompt) systempromptOption {
+	return func(m *SystemPromptMutation) {
+		m.oldValue = func(context.Context) (*SystemPrompt, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m SystemPromptMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m SystemPromptMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of SystemPrompt entities.
+func (m *SystemPromptMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *SystemPromptMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *SystemPromptMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().SystemPrompt.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetText sets the "text" field.
+func (m *SystemPromptMutation) SetText(b []byte) {
+	m.text = &b
+}
+
+// Text returns the value of the "text" field in the mutation.
+func (m *SystemPromptMutation) Text() (r []byte, exists bool) {
+	v := m.text
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldText returns the old "text" field's value of the SystemPrompt entity.
+// If the SystemPrompt object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SystemPromptMutation) OldText(ctx context.Context) (v []byte, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldText is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldText requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldText: %w", err)
+	}
+	return oldValue.Text, nil
+}
+
+// ResetText resets all changes to the "text" field.
+func (m *SystemPromptMutation) ResetText() {
+	m.text = nil
+}
+
+// SetEncrypted sets the "encrypted" field.
+func (m *SystemPromptMutation) SetEncrypted(b bool) {
+	m.encrypted = &b
+}
+
+// Encrypted returns the value of the "encrypted" field in the mutation.
+func (m *SystemPromptMutation) Encrypted() (r bool, exists bool) {
+	v := m.encrypted
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEncrypted returns the old "encrypted" field's value of the SystemPrompt entity.
+// If the SystemPrompt object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SystemPromptMutation) OldEncrypted(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEncrypted is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEncrypted requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEncrypted: %w", err)
+	}
+	return oldValue.Encrypted, nil
+}
+
+// ResetEncrypted resets all changes to the "encrypted" field.
+func (m *SystemPromptMutation) ResetEncrypted() {
+	m.encrypted = nil
+}
+
+// SetSize sets the "size" field.
+func (m *SystemPromptMutation) SetSize(i int) {
+	m.size = &i
+	m.addsize = nil
+}
+
+// Size returns the value of the "size" field in the mutation.
+func (m *SystemPromptMutation) Size() (r int, exists bool) {
+	v := m.size
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSize returns the old "size" field's value of the SystemPrompt entity.
+// If the SystemPrompt object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SystemPromptMutation) OldSize(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSize is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSize requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSize: %w", err)
+	}
+	return oldValue.Size, nil
+}
+
+// AddSize adds i to the "size" field.
+func (m *SystemPromptMutation) AddSize(i int) {
+	if m.addsize != nil {
+		*m.addsize += i
+	} else {
+		m.addsize = &i
+	}
+}
+
+// AddedSize returns the value that was added to the "size" field in this mutation.
+func (m *SystemPromptMutation) AddedSize() (r int, exists bool) {
+	v := m.addsize
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetSize resets all changes to the "size" field.
+func (m *SystemPromptMutation) ResetSize() {
+	m.size = nil
+	m.addsize = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *SystemPromptMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *SystemPromptMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the SystemPrompt entity.
+// If the SystemPrompt object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *SystemPromptMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *SystemPromptMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// Where appends a list predicates to the SystemPromptMutation builder.
+func (m *SystemPromptMutation) Where(ps ...predicate.SystemPrompt) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the SystemPromptMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *SystemPromptMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.SystemPrompt, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *SystemPromptMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *SystemPromptMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (SystemPrompt).
+func (m *SystemPromptMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *SystemPromptMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.text != nil {
+		fields = append(fields, systemprompt.FieldText)
+	}
+	if m.encrypted != nil {
+		fields = append(fields, systemprompt.FieldEncrypted)
+	}
+	if m.size != nil {
+		fields = append(fields, systemprompt.FieldSize)
+	}
+	if m.created_at != nil {
+		fields = append(fields, systemprompt.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *SystemPromptMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case systemprompt.FieldText:
+		return m.Text()
+	case systemprompt.FieldEncrypted:
+		return m.Encrypted()
+	case systemprompt.FieldSize:
+		return m.Size()
+	case systemprompt.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *SystemPromptMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case systemprompt.FieldText:
+		return m.OldText(ctx)
+	case systemprompt.FieldEncrypted:
+		return m.OldEncrypted(ctx)
+	case systemprompt.FieldSize:
+		return m.OldSize(ctx)
+	case systemprompt.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown SystemPrompt field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SystemPromptMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case systemprompt.FieldText:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetText(v)
+		return nil
+	case systemprompt.FieldEncrypted:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEncrypted(v)
+		return nil
+	case systemprompt.FieldSize:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSize(v)
+		return nil
+	case systemprompt.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown SystemPrompt field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *SystemPromptMutation) AddedFields() []string {
+	var fields []string
+	if m.addsize != nil {
+		fields = append(fields, systemprompt.FieldSize)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *SystemPromptMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case systemprompt.FieldSize:
+		return m.AddedSize()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *SystemPromptMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case systemprompt.FieldSize:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddSize(v)
+		return nil
+	}
+	return fmt.Errorf("unknown SystemPrompt numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *SystemPromptMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *SystemPromptMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *SystemPromptMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown SystemPrompt nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *SystemPromptMutation) ResetField(name string) error {
+	switch name {
+	case systemprompt.FieldText:
+		m.ResetText()
+		return nil
+	case systemprompt.FieldEncrypted:
+		m.ResetEncrypted()
+		return nil
+	case systemprompt.FieldSize:
+		m.ResetSize()
+		return nil
+	case systemprompt.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown SystemPrompt field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *SystemPromptMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *SystemPromptMutation) AddedIDs(name string) []ent.Value {
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *SystemPromptMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *SystemPromptMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *SystemPromptMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *SystemPromptMutation) EdgeCleared(name string) bool {
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *SystemPromptMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown SystemPrompt unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *SystemPromptMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown SystemPrompt edge %s", name)
+}
+
+// ToolDefinitionMutation represents an operation that mutates the ToolDefinition nodes in the graph.
+type ToolDefinitionMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *string
+	_type         *string
+	name          *string
+	description   *string
+	schema        *map[string]interface{}
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*ToolDefinition, error)
+	predicates    []predicate.ToolDefinition
+}
+
+var _ ent.Mutation = (*ToolDefinitionMutation)(nil)
+
+// tooldefinitionOption allows management of the mutation configuration using functional options.
+type tooldefinitionOption func(*ToolDefinitionMutation)
+
+// newToolDefinitionMutation creates new mutation for the ToolDefinition entity.
+func newToolDefinitionMutation(c config, op Op, opts ...tooldefinitionOption) *ToolDefinitionMutation {
+	m := &ToolDefinitionMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeToolDefinition,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withToolDefinitionID sets the ID field of the mutation.
+func withToolDefinitionID(id string) tooldefinitionOption {
+	return func(m *ToolDefinitionMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *ToolDefinition
+		)
+		m.oldValue = func(ctx context.Context) (*ToolDefinition, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().ToolDefinition.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
 	}
-	if m.provider != nil {
-		fields = append(fields, node.FieldProvider)
+}
+
+// withToolDefinition sets the old ToolDefinition of the mutation.
+func withToolDefinition(node *ToolDefinition) tooldefinitionOption {
+	return func(m *ToolDefinitionMutation) {
+		m.oldValue = func(context.Context) (*ToolDefinition, error) {
+			return node, nil
+		}
+		m.id = &node.ID
 	}
-	if m.agent_name != nil {
-		fields = append(fields, node.FieldAgentName)
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m ToolDefinitionMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m ToolDefinitionMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
-	if m.stop_reason != nil {
-		fields = append(fields, node.FieldStopReason)
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// SetID sets the value of the id field. Note that this
+// operation is only accepted on creation of ToolDefinition entities.
+func (m *ToolDefinitionMutation) SetID(id string) {
+	m.id = &id
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *ToolDefinitionMutation) ID() (id string, exists bool) {
+	if m.id == nil {
+		return
 	}
-	if m.prompt_tokens != nil {
-		fields = append(fields, node.FieldPromptTokens)
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *ToolDefinitionMutation) IDs(ctx context.Context) ([]string, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []string{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().ToolDefinition.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
-	if m.completion_tokens != nil {
-		fields = append(fields, node.FieldCompletionTokens)
+}
+
+// SetType sets the "type" field.
+func (m *ToolDefinitionMutation) SetType(s string) {
+	m._type = &s
+}
+
+// GetType returns the value of the "type" field in the mutation.
+func (m *ToolDefinitionMutation) GetType() (r string, exists bool) {
+	v := m._type
+	if v == nil {
+		return
 	}
-	if m.total_tokens != nil {
-		fields = append(fields, node.FieldTotalTokens)
+	return *v, true
+}
+
+// OldType returns the old "type" field's value of the ToolDefinition entity.
+// If the ToolDefinition object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ToolDefinitionMutation) OldType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldType is only allowed on UpdateOne operations")
 	}
-	if m.cache_creation_input_tokens != nil {
-		fields = append(fields, node.FieldCacheCreationInputTokens)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldType requires an ID field in the mutation")
 	}
-	if m.cache_read_input_tokens != nil {
-		fields = append(fields, node.FieldCacheReadInputTokens)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldType: %w", err)
 	}
-	if m.total_duration_ns != nil {
-		fields = append(fields, node.FieldTotalDurationNs)
+	return oldValue.Type, nil
+}
+
+// ClearType clears the value of the "type" field.
+func (m *ToolDefinitionMutation) ClearType() {
+	m._type = nil
+	m.clearedFields[tooldefinition.FieldType] = struct{}{}
+}
+
+// TypeCleared returns if the "type" field was cleared in this mutation.
+func (m *ToolDefinitionMutation) TypeCleared() bool {
+	_, ok := m.clearedFields[tooldefinition.FieldType]
+	return ok
+}
+
+// ResetType resets all changes to the "type" field.
+func (m *ToolDefinitionMutation) ResetType() {
+	m._type = nil
+	delete(m.clearedFields, tooldefinition.FieldType)
+}
+
+// SetName sets the "name" field.
+func (m *ToolDefinitionMutation) SetName(s string) {
+	m.name = &s
+}
+
+// Name returns the value of the "name" field in the mutation.
+func (m *ToolDefinitionMutation) Name() (r string, exists bool) {
+	v := m.name
+	if v == nil {
+		return
 	}
-	if m.prompt_duration_ns != nil {
-		fields = append(fields, node.FieldPromptDurationNs)
+	return *v, true
+}
+
+// OldName returns the old "name" field's value of the ToolDefinition entity.
+// If the ToolDefinition object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ToolDefinitionMutation) OldName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
-	if m.project != nil {
-		fields = append(fields, node.FieldProject)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldName requires an ID field in the mutation")
 	}
-	if m.created_at != nil {
-		fields = append(fields, node.FieldCreatedAt)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
 	}
-	return fields
+	return oldValue.Name, nil
 }
 
-// Field returns the value of a field with the given name. The second boolean
-// return value indicates that this field was not set, or was not defined in the
-// schema.
-func (m *NodeMutation) Field(name string) (ent.Value, bool) {
-	switch name {
-	case node.FieldParentHash:
-		return m.ParentHash()
-	case node.FieldBucket:
-		return m.Bucket()
-	case node.FieldType:
-		return m.GetType()
-	case node.FieldRole:
-		return m.Role()
-	case node.FieldContent:
-		return m.Content()
-	case node.FieldModel:
-		return m.Model()
-	case node.FieldProvider:
-		return m.Provider()
-	case node.FieldAgentName:
-		return m.AgentName()
-	case node.FieldStopReason:
-		return m.StopReason()
-	case node.FieldPromptTokens:
-		return m.PromptTokens()
-	case node.FieldCompletionTokens:
-		return m.CompletionTokens()
-	case node.FieldTotalTokens:
-		return m.TotalTokens()
-	case node.FieldCacheCreationInputTokens:
-		return m.CacheCreationInputTokens()
-	case node.FieldCacheReadInputTokens:
-		return m.CacheReadInputTokens()
-	case node.FieldTotalDurationNs:
-		return m.TotalDurationNs()
-	case node.FieldPromptDurationNs:
-		return m.PromptDurationNs()
-	case node.FieldProject:
-		return m.Project()
-	case node.FieldCreatedAt:
-		return m.CreatedAt()
+// ResetName resets all changes to the "name" field.
+func (m *ToolDefinitionMutation) ResetName() {
+	m.name = nil
+}
+
+// SetDescription sets the "description" field.
+func (m *ToolDefinitionMutation) SetDescription(s string) {
+	m.description = &s
+}
+
+// Description returns the value of the "description" field in the mutation.
+func (m *ToolDefinitionMutation) Description() (r string, exists bool) {
+	v := m.description
+	if v == nil {
+		return
 	}
-	return nil, false
+	return *v, true
 }
 
-// OldField returns the old value of the field from the database. An error is
-// returned if the mutation operation is not UpdateOne, or the query to the
-// database failed.
-func (m *NodeMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
-	switch name {
-	case node.FieldParentHash:
-		return m.OldParentHash(ctx)
-	case node.FieldBucket:
-		return m.OldBucket(ctx)
-	case node.FieldType:
-		return m.OldType(ctx)
-	case node.FieldRole:
-		return m.OldRole(ctx)
-	case node.FieldContent:
-		return m.OldContent(ctx)
-	case node.FieldModel:
-		return m.OldModel(ctx)
-	case node.FieldProvider:
-		return m.OldProvider(ctx)
-	case node.FieldAgentName:
-		return m.OldAgentName(ctx)
-	case node.FieldStopReason:
-		return m.OldStopReason(ctx)
-	case node.FieldPromptTokens:
-		return m.OldPromptTokens(ctx)
-	case node.FieldCompletionTokens:
-		return m.OldCompletionTokens(ctx)
-	case node.FieldTotalTokens:
-		return m.OldTotalTokens(ctx)
-	case node.FieldCacheCreationInputTokens:
-		return m.OldCacheCreationInputTokens(ctx)
-	case node.FieldCacheReadInputTokens:
-		return m.OldCacheReadInputTokens(ctx)
-	case node.FieldTotalDurationNs:
-		return m.OldTotalDurationNs(ctx)
-	case node.FieldPromptDurationNs:
-		return m.OldPromptDurationNs(ctx)
-	case node.FieldProject:
-		return m.OldProject(ctx)
-	case node.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
+// OldDescription returns the old "description" field's value of the ToolDefinition entity.
+// If the ToolDefinition object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ToolDefinitionMutation) OldDescription(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDescription is only allowed on UpdateOne operations")
 	}
-	return nil, fmt.Errorf("unknown Node field %s", name)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDescription requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDescription: %w", err)
+	}
+	return oldValue.Description, nil
+}
+
+// ClearDescription clears the value of the "description" field.
+func (m *ToolDefinitionMutation) ClearDescription() {
+	m.description = nil
+	m.clearedFields[tooldefinition.FieldDescription] = struct{}{}
+}
+
+// DescriptionCleared returns if the "description" field was cleared in this mutation.
+func (m *ToolDefinitionMutation) DescriptionCleared() bool {
+	_, ok := m.clearedFields[tooldefinition.FieldDescription]
+	return ok
+}
+
+// ResetDescription resets all changes to the "description" field.
+func (m *ToolDefinitionMutation) ResetDescription() {
+	m.description = nil
+	delete(m.clearedFields, tooldefinition.FieldDescription)
+}
+
+// SetSchema sets the "schema" field.
+func (m *ToolDefinitionMutation) SetSchema(value map[string]interface{}) {
+	m.schema = &value
+}
+
+// Schema returns the value of the "schema" field in the mutation.
+func (m *ToolDefinitionMutation) Schema() (r map[string]interface{}, exists bool) {
+	v := m.schema
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSchema returns the old "schema" field's value of the ToolDefinition entity.
+// If the ToolDefinition object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ToolDefinitionMutation) OldSchema(ctx context.Context) (v map[string]interface{}, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSchema is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSchema requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSchema: %w", err)
+	}
+	return oldValue.Schema, nil
+}
+
+// ClearSchema clears the value of the "schema" field.
+func (m *ToolDefinitionMutation) ClearSchema() {
+	m.schema = nil
+	m.clearedFields[tooldefinition.FieldSchema] = struct{}{}
+}
+
+// SchemaCleared returns if the "schema" field was cleared in this mutation.
+func (m *ToolDefinitionMutation) SchemaCleared() bool {
+	_, ok := m.clearedFields[tooldefinition.FieldSchema]
+	return ok
 }
 
-// SetField sets the value of a field with the given name. It returns an error if
-// the field is not defined in the schema, or if the type mismatched the field
-// type.
-func (m *NodeMutation) SetField(name string, value ent.Value) error {
-	switch name {
-	case node.FieldParentHash:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetParentHash(v)
-		return nil
-	case node.FieldBucket:
-		v, ok := value.(map[string]interface{})
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetBucket(v)
-		return nil
-	case node.FieldType:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetType(v)
-		return nil
-	case node.FieldRole:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetRole(v)
-		return nil
-	case node.FieldContent:
-		v, ok := value.([]map[string]interface{})
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetContent(v)
-		return nil
-	case node.FieldModel:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetModel(v)
-		return nil
-	case node.FieldProvider:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetProvider(v)
-		return nil
-	case node.FieldAgentName:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetAgentName(v)
-		return nil
-	case node.FieldStopReason:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetStopReason(v)
-		return nil
-	case node.FieldPromptTokens:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetPromptTokens(v)
-		return nil
-	case node.FieldCompletionTokens:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCompletionTokens(v)
-		return nil
-	case node.FieldTotalTokens:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetTotalTokens(v)
-		return nil
-	case node.FieldCacheCreationInputTokens:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCacheCreationInputTokens(v)
-		return nil
-	case node.FieldCacheReadInputTokens:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCacheReadInputTokens(v)
-		return nil
-	case node.FieldTotalDurationNs:
-		v, ok := value.(int64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetTotalDurationNs(v)
-		return nil
-	case node.FieldPromptDurationNs:
-		v, ok := value.(int64)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetPromptDurationNs(v)
-		return nil
-	case node.FieldProject:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetProject(v)
-		return nil
-	case node.FieldCreatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetCreatedAt(v)
-		return nil
+// ResetSchema resets all changes to the "schema" field.
+func (m *ToolDefinitionMutation) ResetSchema() {
+	m.schema = nil
+	delete(m.clearedFields, tooldefinition.FieldSchema)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *ToolDefinitionMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *ToolDefinitionMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
 	}
-	return fmt.Errorf("unknown Node field %s", name)
+	return *v, true
 }
 
-// AddedFields returns all numeric fields that were incremented/decremented during
-// this mutation.
-func (m *NodeMutation) AddedFields() []string {
-	var fields []string
-	if m.addprompt_tokens != nil {
-		fields = append(fields, node.FieldPromptTokens)
+// OldCreatedAt returns the old "created_at" field's value of the ToolDefinition entity.
+// If the ToolDefinition object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *ToolDefinitionMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
-	if m.addcompletion_tokens != nil {
-		fields = append(fields, node.FieldCompletionTokens)
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
-	if m.addtotal_tokens != nil {
-		fields = append(fields, node.FieldTotalTokens)
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
-	if m.addcache_creation_input_tokens != nil {
-		fields = append(fields, node.FieldCacheCreationInputTokens)
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *ToolDefinitionMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// Where appends a list predicates to the ToolDefinitionMutation builder.
+func (m *ToolDefinitionMutation) Where(ps ...predicate.ToolDefinition) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the ToolDefinitionMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *ToolDefinitionMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.ToolDefinition, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
 	}
-	if m.addcache_read_input_tokens != nil {
-		fields = append(fields, node.FieldCacheReadInputTokens)
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *ToolDefinitionMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *ToolDefinitionMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (ToolDefinition).
+func (m *ToolDefinitionMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *ToolDefinitionMutation) Fields() []string {
+	fields := make([]string, 0, 5)
+	if m._type != nil {
+		fields = append(fields, tooldefinition.FieldType)
 	}
-	if m.addtotal_duration_ns != nil {
-		fields = append(fields, node.FieldTotalDurationNs)
+	if m.name != nil {
+		fields = append(fields, tooldefinition.FieldName)
 	}
-	if m.addprompt_duration_ns != nil {
-		fields = append(fields, node.FieldPromptDurationNs)
+	if m.description != nil {
+		fields = append(fields, tooldefinition.FieldDescription)
+	}
+	if m.schema != nil {
+		fields = append(fields, tooldefinition.FieldSchema)
+	}
+	if m.created_at != nil {
+		fields = append(fields, tooldefinition.FieldCreatedAt)
 	}
 	return fields
 }
 
-// AddedField returns the numeric value that was incremented/decremented on a field
-// with the given name. The second boolean return value indicates that this field
-// was not set, or was not defined in the schema.
-func (m *NodeMutation) AddedField(name string) (ent.Value, bool) {
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *ToolDefinitionMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case node.FieldPromptTokens:
-		return m.AddedPromptTokens()
-	case node.FieldCompletionTokens:
-		return m.AddedCompletionTokens()
-	case node.FieldTotalTokens:
-		return m.AddedTotalTokens()
-	case node.FieldCacheCreationInputTokens:
-		return m.AddedCacheCreationInputTokens()
-	case node.FieldCacheReadInputTokens:
-		return m.AddedCacheReadInputTokens()
-	case node.FieldTotalDurationNs:
-		return m.AddedTotalDurationNs()
-	case node.FieldPromptDurationNs:
-		return m.AddedPromptDurationNs()
+	case tooldefinition.FieldType:
+		return m.GetType()
+	case tooldefinition.FieldName:
+		return m.Name()
+	case tooldefinition.FieldDescription:
+		return m.Description()
+	case tooldefinition.FieldSchema:
+		return m.Schema()
+	case tooldefinition.FieldCreatedAt:
+		return m.CreatedAt()
 	}
 	return nil, false
 }
 
-// AddField adds the value to the field with the given name. It returns an error if
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *ToolDefinitionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case tooldefinition.FieldType:
+		return m.OldType(ctx)
+	case tooldefinition.FieldName:
+		return m.OldName(ctx)
+	case tooldefinition.FieldDescription:
+		return m.OldDescription(ctx)
+	case tooldefinition.FieldSchema:
+		return m.OldSchema(ctx)
+	case tooldefinition.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown ToolDefinition field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *NodeMutation) AddField(name string, value ent.Value) error {
+func (m *ToolDefinitionMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case node.FieldPromptTokens:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddPromptTokens(v)
-		return nil
-	case node.FieldCompletionTokens:
-		v, ok := value.(int)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.AddCompletionTokens(v)
-		return nil
-	case node.FieldTotalTokens:
-		v, ok := value.(int)
+	case tooldefinition.FieldType:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddTotalTokens(v)
+		m.SetType(v)
 		return nil
-	case node.FieldCacheCreationInputTokens:
-		v, ok := value.(int)
+	case tooldefinition.FieldName:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddCacheCreationInputTokens(v)
+		m.SetName(v)
 		return nil
-	case node.FieldCacheReadInputTokens:
-		v, ok := value.(int)
+	case tooldefinition.FieldDescription:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddCacheReadInputTokens(v)
+		m.SetDescription(v)
 		return nil
-	case node.FieldTotalDurationNs:
-		v, ok := value.(int64)
+	case tooldefinition.FieldSchema:
+		v, ok := value.(map[string]interface{})
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddTotalDurationNs(v)
+		m.SetSchema(v)
 		return nil
-	case node.FieldPromptDurationNs:
-		v, ok := value.(int64)
+	case tooldefinition.FieldCreatedAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddPromptDurationNs(v)
+		m.SetCreatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Node numeric field %s", name)
+	return fmt.Errorf("unknown ToolDefinition field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *ToolDefinitionMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *ToolDefinitionMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *ToolDefinitionMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown ToolDefinition numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *NodeMutation) ClearedFields() []string {
+func (m *ToolDefinitionMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(node.FieldParentHash) {
-		fields = append(fields, node.FieldParentHash)
-	}
-	if m.FieldCleared(node.FieldBucket) {
-		fields = append(fields, node.FieldBucket)
-	}
-	if m.FieldCleared(node.FieldType) {
-		fields = append(fields, node.FieldType)
-	}
-	if m.FieldCleared(node.FieldRole) {
-		fields = append(fields, node.FieldRole)
-	}
-	if m.FieldCleared(node.FieldContent) {
-		fields = append(fields, node.FieldContent)
-	}
-	if m.FieldCleared(node.FieldModel) {
-		fields = append(fields, node.FieldModel)
-	}
-	if m.FieldCleared(node.FieldProvider) {
-		fields = append(fields, node.FieldProvider)
-	}
-	if m.FieldCleared(node.FieldAgentName) {
-		fields = append(fields, node.FieldAgentName)
-	}
-	if m.FieldCleared(node.FieldStopReason) {
-		fields = append(fields, node.FieldStopReason)
-	}
-	if m.FieldCleared(node.FieldPromptTokens) {
-		fields = append(fields, node.FieldPromptTokens)
-	}
-	if m.FieldCleared(node.FieldCompletionTokens) {
-		fields = append(fields, node.FieldCompletionTokens)
-	}
-	if m.FieldCleared(node.FieldTotalTokens) {
-		fields = append(fields, node.FieldTotalTokens)
-	}
-	if m.FieldCleared(node.FieldCacheCreationInputTokens) {
-		fields = append(fields, node.FieldCacheCreationInputTokens)
-	}
-	if m.FieldCleared(node.FieldCacheReadInputTokens) {
-		fields = append(fields, node.FieldCacheReadInputTokens)
+	if m.FieldCleared(tooldefinition.FieldType) {
+		fields = append(fields, tooldefinition.FieldType)
 	}
-	if m.FieldCleared(node.FieldTotalDurationNs) {
-		fields = append(fields, node.FieldTotalDurationNs)
-	}
-	if m.FieldCleared(node.FieldPromptDurationNs) {
-		fields = append(fields, node.FieldPromptDurationNs)
+	if m.FieldCleared(tooldefinition.FieldDescription) {
+		fields = append(fields, tooldefinition.FieldDescription)
 	}
-	if m.FieldCleared(node.FieldProject) {
-		fields = append(fields, node.FieldProject)
+	if m.FieldCleared(tooldefinition.FieldSchema) {
+		fields = append(fields, tooldefinition.FieldSchema)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *NodeMutation) FieldCleared(name string) bool {
+func (m *ToolDefinitionMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *NodeMutation) ClearField(name string) error {
+func (m *ToolDefinitionMutation) ClearField(name string) error {
 	switch name {
-	case node.FieldParentHash:
-		m.ClearParentHash()
-		return nil
-	case node.FieldBucket:
-		m.ClearBucket()
-		return nil
-	case node.FieldType:
+	case tooldefinition.FieldType:
 		m.ClearType()
 		return nil
-	case node.FieldRole:
-		m.ClearRole()
-		return nil
-	case node.FieldContent:
-		m.ClearContent()
-		return nil
-	case node.FieldModel:
-		m.ClearModel()
-		return nil
-	case node.FieldProvider:
-		m.ClearProvider()
-		return nil
-	case node.FieldAgentName:
-		m.ClearAgentName()
-		return nil
-	case node.FieldStopReason:
-		m.ClearStopReason()
-		return nil
-	case node.FieldPromptTokens:
-		m.ClearPromptTokens()
-		return nil
-	case node.FieldCompletionTokens:
-		m.ClearCompletionTokens()
-		return nil
-	case node.FieldTotalTokens:
-		m.ClearTotalTokens()
-		return nil
-	case node.FieldCacheCreationInputTokens:
-		m.ClearCacheCreationInputTokens()
-		return nil
-	case node.FieldCacheReadInputTokens:
-		m.ClearCacheReadInputTokens()
-		return nil
-	case node.FieldTotalDurationNs:
-		m.ClearTotalDurationNs()
-		return nil
-	case node.FieldPromptDurationNs:
-		m.ClearPromptDurationNs()
+	case tooldefinition.FieldDescription:
+		m.ClearDescription()
 		return nil
-	case node.FieldProject:
-		m.ClearProject()
+	case tooldefinition.FieldSchema:
+		m.ClearSchema()
 		return nil
 	}
-	return fmt.Errorf("unknown Node nullable field %s", name)
+	return fmt.Errorf("unknown ToolDefinition nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *NodeMutation) ResetField(name string) error {
+func (m *ToolDefinitionMutation) ResetField(name string) error {
 	switch name {
-	case node.FieldParentHash:
-		m.ResetParentHash()
-		return nil
-	case node.FieldBucket:
-		m.ResetBucket()
-		return nil
-	case node.FieldType:
+	case tooldefinition.FieldType:
 		m.ResetType()
 		return nil
-	case node.FieldRole:
-		m.ResetRole()
-		return nil
-	case node.FieldContent:
-		m.ResetContent()
-		return nil
-	case node.FieldModel:
-		m.ResetModel()
-		return nil
-	case node.FieldProvider:
-		m.ResetProvider()
-		return nil
-	case node.FieldAgentName:
-		m.ResetAgentName()
-		return nil
-	case node.FieldStopReason:
-		m.ResetStopReason()
-		return nil
-	case node.FieldPromptTokens:
-		m.ResetPromptTokens()
-		return nil
-	case node.FieldCompletionTokens:
-		m.ResetCompletionTokens()
-		return nil
-	case node.FieldTotalTokens:
-		m.ResetTotalTokens()
-		return nil
-	case node.FieldCacheCreationInputTokens:
-		m.ResetCacheCreationInputTokens()
-		return nil
-	case node.FieldCacheReadInputTokens:
-		m.ResetCacheReadInputTokens()
-		return nil
-	case node.FieldTotalDurationNs:
-		m.ResetTotalDurationNs()
+	case tooldefinition.FieldName:
+		m.ResetName()
 		return nil
-	case node.FieldPromptDurationNs:
-		m.ResetPromptDurationNs()
+	case tooldefinition.FieldDescription:
+		m.ResetDescription()
 		return nil
-	case node.FieldProject:
-		m.ResetProject()
+	case tooldefinition.FieldSchema:
+		m.ResetSchema()
 		return nil
-	case node.FieldCreatedAt:
+	case tooldefinition.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Node field %s", name)
+	return fmt.Errorf("unknown ToolDefinition field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *NodeMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.parent != nil {
-		edges = append(edges, node.EdgeParent)
-	}
-	if m.children != nil {
-		edges = append(edges, node.EdgeChildren)
-	}
+func (m *ToolDefinitionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *NodeMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case node.EdgeParent:
-		if id := m.parent; id != nil {
-			return []ent.Value{*id}
-		}
-	case node.EdgeChildren:
-		ids := make([]ent.Value, 0, len(m.children))
-		for id := range m.children {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *ToolDefinitionMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *NodeMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.removedchildren != nil {
-		edges = append(edges, node.EdgeChildren)
-	}
+func (m *ToolDefinitionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *NodeMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case node.EdgeChildren:
-		ids := make([]ent.Value, 0, len(m.removedchildren))
-		for id := range m.removedchildren {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *ToolDefinitionMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *NodeMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.clearedparent {
-		edges = append(edges, node.EdgeParent)
-	}
-	if m.clearedchildren {
-		edges = append(edges, node.EdgeChildren)
-	}
+func (m *ToolDefinitionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *NodeMutation) EdgeCleared(name string) bool {
-	switch name {
-	case node.EdgeParent:
-		return m.clearedparent
-	case node.EdgeChildren:
-		return m.clearedchildren
-	}
+func (m *ToolDefinitionMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *NodeMutation) ClearEdge(name string) error {
-	switch name {
-	case node.EdgeParent:
-		m.ClearParent()
-		return nil
-	}
-	return fmt.Errorf("unknown Node unique edge %s", name)
+func (m *ToolDefinitionMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown ToolDefinition unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *NodeMutation) ResetEdge(name string) error {
-	switch name {
-	case node.EdgeParent:
-		m.ResetParent()
-		return nil
-	case node.EdgeChildren:
-		m.ResetChildren()
-		return nil
-	}
-	return fmt.Errorf("unknown Node edge %s", name)
+func (m *ToolDefinitionMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown ToolDefinition edge %s", name)
 }
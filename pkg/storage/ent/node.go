@@ -10,6 +10,7 @@ import (
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
+	"github.com/papercomputeco/tapes/pkg/llm"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
 )
 
@@ -34,8 +35,12 @@ type Node struct {
 	Provider string `json:"provider,omitempty"`
 	// AgentName holds the value of the "agent_name" field.
 	AgentName string `json:"agent_name,omitempty"`
+	// SessionID holds the value of the "session_id" field.
+	SessionID *string `json:"session_id,omitempty"`
 	// StopReason holds the value of the "stop_reason" field.
 	StopReason string `json:"stop_reason,omitempty"`
+	// ToolNames holds the value of the "tool_names" field.
+	ToolNames *string `json:"tool_names,omitempty"`
 	// PromptTokens holds the value of the "prompt_tokens" field.
 	PromptTokens *int `json:"prompt_tokens,omitempty"`
 	// CompletionTokens holds the value of the "completion_tokens" field.
@@ -46,12 +51,66 @@ type Node struct {
 	CacheCreationInputTokens *int `json:"cache_creation_input_tokens,omitempty"`
 	// CacheReadInputTokens holds the value of the "cache_read_input_tokens" field.
 	CacheReadInputTokens *int `json:"cache_read_input_tokens,omitempty"`
+	// ReasoningTokens holds the value of the "reasoning_tokens" field.
+	ReasoningTokens *int `json:"reasoning_tokens,omitempty"`
 	// TotalDurationNs holds the value of the "total_duration_ns" field.
 	TotalDurationNs *int64 `json:"total_duration_ns,omitempty"`
 	// PromptDurationNs holds the value of the "prompt_duration_ns" field.
 	PromptDurationNs *int64 `json:"prompt_duration_ns,omitempty"`
+	// UsageEstimated holds the value of the "usage_estimated" field.
+	UsageEstimated bool `json:"usage_estimated,omitempty"`
+	// RequestStartedAt holds the value of the "request_started_at" field.
+	RequestStartedAt *time.Time `json:"request_started_at,omitempty"`
+	// FirstChunkAt holds the value of the "first_chunk_at" field.
+	FirstChunkAt *time.Time `json:"first_chunk_at,omitempty"`
+	// ResponseCompletedAt holds the value of the "response_completed_at" field.
+	ResponseCompletedAt *time.Time `json:"response_completed_at,omitempty"`
+	// ErrorStatus holds the value of the "error_status" field.
+	ErrorStatus *int `json:"error_status,omitempty"`
+	// ErrorType holds the value of the "error_type" field.
+	ErrorType *string `json:"error_type,omitempty"`
+	// ErrorMessage holds the value of the "error_message" field.
+	ErrorMessage *string `json:"error_message,omitempty"`
+	// RetryAfterSeconds holds the value of the "retry_after_seconds" field.
+	RetryAfterSeconds *int `json:"retry_after_seconds,omitempty"`
+	// ErrorAttempt holds the value of the "error_attempt" field.
+	ErrorAttempt *int `json:"error_attempt,omitempty"`
 	// Project holds the value of the "project" field.
 	Project *string `json:"project,omitempty"`
+	// User holds the value of the "user" field.
+	User *string `json:"user,omitempty"`
+	// TraceID holds the value of the "trace_id" field.
+	TraceID *string `json:"trace_id,omitempty"`
+	// RunID holds the value of the "run_id" field.
+	RunID *string `json:"run_id,omitempty"`
+	// AgentVersion holds the value of the "agent_version" field.
+	AgentVersion *string `json:"agent_version,omitempty"`
+	// SystemPromptHash holds the value of the "system_prompt_hash" field.
+	SystemPromptHash *string `json:"system_prompt_hash,omitempty"`
+	// PolicyViolations holds the value of the "policy_violations" field.
+	PolicyViolations []llm.PolicyViolation `json:"policy_violations,omitempty"`
+	// SignerKey holds the value of the "signer_key" field.
+	SignerKey *[]byte `json:"signer_key,omitempty"`
+	// Signature holds the value of the "signature" field.
+	Signature *[]byte `json:"signature,omitempty"`
+	// SharedBy holds the value of the "shared_by" field.
+	SharedBy *string `json:"shared_by,omitempty"`
+	// SupersededBy holds the value of the "superseded_by" field.
+	SupersededBy *string `json:"superseded_by,omitempty"`
+	// Title holds the value of the "title" field.
+	Title *string `json:"title,omitempty"`
+	// Summary holds the value of the "summary" field.
+	Summary *string `json:"summary,omitempty"`
+	// Outcome holds the value of the "outcome" field.
+	Outcome *string `json:"outcome,omitempty"`
+	// SummarizedAt holds the value of the "summarized_at" field.
+	SummarizedAt *time.Time `json:"summarized_at,omitempty"`
+	// EndedAt holds the value of the "ended_at" field.
+	EndedAt *time.Time `json:"ended_at,omitempty"`
+	// EndReason holds the value of the "end_reason" field.
+	EndReason *string `json:"end_reason,omitempty"`
+	// TurnIndex holds the value of the "turn_index" field.
+	TurnIndex *int `json:"turn_index,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
@@ -96,13 +155,15 @@ func (*Node) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case node.FieldBucket, node.FieldContent:
+		case node.FieldBucket, node.FieldContent, node.FieldPolicyViolations, node.FieldSignerKey, node.FieldSignature:
 			values[i] = new([]byte)
-		case node.FieldPromptTokens, node.FieldCompletionTokens, node.FieldTotalTokens, node.FieldCacheCreationInputTokens, node.FieldCacheReadInputTokens, node.FieldTotalDurationNs, node.FieldPromptDurationNs:
+		case node.FieldUsageEstimated:
+			values[i] = new(sql.NullBool)
+		case node.FieldPromptTokens, node.FieldCompletionTokens, node.FieldTotalTokens, node.FieldCacheCreationInputTokens, node.FieldCacheReadInputTokens, node.FieldReasoningTokens, node.FieldTotalDurationNs, node.FieldPromptDurationNs, node.FieldErrorStatus, node.FieldRetryAfterSeconds, node.FieldErrorAttempt, node.FieldTurnIndex:
 			values[i] = new(sql.NullInt64)
-		case node.FieldID, node.FieldParentHash, node.FieldType, node.FieldRole, node.FieldModel, node.FieldProvider, node.FieldAgentName, node.FieldStopReason, node.FieldProject:
+		case node.FieldID, node.FieldParentHash, node.FieldType, node.FieldRole, node.FieldModel, node.FieldProvider, node.FieldAgentName, node.FieldSessionID, node.FieldStopReason, node.FieldToolNames, node.FieldErrorType, node.FieldErrorMessage, node.FieldProject, node.FieldUser, node.FieldTraceID, node.FieldRunID, node.FieldAgentVersion, node.FieldSystemPromptHash, node.FieldSharedBy, node.FieldSupersededBy, node.FieldTitle, node.FieldSummary, node.FieldOutcome, node.FieldEndReason:
 			values[i] = new(sql.NullString)
-		case node.FieldCreatedAt:
+		case node.FieldRequestStartedAt, node.FieldFirstChunkAt, node.FieldResponseCompletedAt, node.FieldSummarizedAt, node.FieldEndedAt, node.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -178,12 +239,26 @@ func (_m *Node) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.AgentName = value.String
 			}
+		case node.FieldSessionID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field session_id", values[i])
+			} else if value.Valid {
+				_m.SessionID = new(string)
+				*_m.SessionID = value.String
+			}
 		case node.FieldStopReason:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field stop_reason", values[i])
 			} else if value.Valid {
 				_m.StopReason = value.String
 			}
+		case node.FieldToolNames:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field tool_names", values[i])
+			} else if value.Valid {
+				_m.ToolNames = new(string)
+				*_m.ToolNames = value.String
+			}
 		case node.FieldPromptTokens:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field prompt_tokens", values[i])
@@ -219,6 +294,13 @@ func (_m *Node) assignValues(columns []string, values []any) error {
 				_m.CacheReadInputTokens = new(int)
 				*_m.CacheReadInputTokens = int(value.Int64)
 			}
+		case node.FieldReasoningTokens:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field reasoning_tokens", values[i])
+			} else if value.Valid {
+				_m.ReasoningTokens = new(int)
+				*_m.ReasoningTokens = int(value.Int64)
+			}
 		case node.FieldTotalDurationNs:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for field total_duration_ns", values[i])
@@ -233,6 +315,68 @@ func (_m *Node) assignValues(columns []string, values []any) error {
 				_m.PromptDurationNs = new(int64)
 				*_m.PromptDurationNs = value.Int64
 			}
+		case node.FieldUsageEstimated:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field usage_estimated", values[i])
+			} else if value.Valid {
+				_m.UsageEstimated = value.Bool
+			}
+		case node.FieldRequestStartedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field request_started_at", values[i])
+			} else if value.Valid {
+				_m.RequestStartedAt = new(time.Time)
+				*_m.RequestStartedAt = value.Time
+			}
+		case node.FieldFirstChunkAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field first_chunk_at", values[i])
+			} else if value.Valid {
+				_m.FirstChunkAt = new(time.Time)
+				*_m.FirstChunkAt = value.Time
+			}
+		case node.FieldResponseCompletedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field response_completed_at", values[i])
+			} else if value.Valid {
+				_m.ResponseCompletedAt = new(time.Time)
+				*_m.ResponseCompletedAt = value.Time
+			}
+		case node.FieldErrorStatus:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field error_status", values[i])
+			} else if value.Valid {
+				_m.ErrorStatus = new(int)
+				*_m.ErrorStatus = int(value.Int64)
+			}
+		case node.FieldErrorType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error_type", values[i])
+			} else if value.Valid {
+				_m.ErrorType = new(string)
+				*_m.ErrorType = value.String
+			}
+		case node.FieldErrorMessage:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error_message", values[i])
+			} else if value.Valid {
+				_m.ErrorMessage = new(string)
+				*_m.ErrorMessage = value.String
+			}
+		case node.FieldRetryAfterSeconds:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field retry_after_seconds", values[i])
+			} else if value.Valid {
+				_m.RetryAfterSeconds = new(int)
+				*_m.RetryAfterSeconds = int(value.Int64)
+			}
+		case node.FieldErrorAttempt:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field error_attempt", values[i])
+			} else if value.Valid {
+				_m.ErrorAttempt = new(int)
+				*_m.ErrorAttempt = int(value.Int64)
+			}
 		case node.FieldProject:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field project", values[i])
@@ -240,6 +384,124 @@ func (_m *Node) assignValues(columns []string, values []any) error {
 				_m.Project = new(string)
 				*_m.Project = value.String
 			}
+		case node.FieldUser:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user", values[i])
+			} else if value.Valid {
+				_m.User = new(string)
+				*_m.User = value.String
+			}
+		case node.FieldTraceID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field trace_id", values[i])
+			} else if value.Valid {
+				_m.TraceID = new(string)
+				*_m.TraceID = value.String
+			}
+		case node.FieldRunID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field run_id", values[i])
+			} else if value.Valid {
+				_m.RunID = new(string)
+				*_m.RunID = value.String
+			}
+		case node.FieldAgentVersion:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field agent_version", values[i])
+			} else if value.Valid {
+				_m.AgentVersion = new(string)
+				*_m.AgentVersion = value.String
+			}
+		case node.FieldSystemPromptHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field system_prompt_hash", values[i])
+			} else if value.Valid {
+				_m.SystemPromptHash = new(string)
+				*_m.SystemPromptHash = value.String
+			}
+		case node.FieldPolicyViolations:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field policy_violations", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.PolicyViolations); err != nil {
+					return fmt.Errorf("unmarshal field policy_violations: %w", err)
+				}
+			}
+		case node.FieldSignerKey:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field signer_key", values[i])
+			} else if value != nil {
+				_m.SignerKey = value
+			}
+		case node.FieldSignature:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field signature", values[i])
+			} else if value != nil {
+				_m.Signature = value
+			}
+		case node.FieldSharedBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field shared_by", values[i])
+			} else if value.Valid {
+				_m.SharedBy = new(string)
+				*_m.SharedBy = value.String
+			}
+		case node.FieldSupersededBy:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field superseded_by", values[i])
+			} else if value.Valid {
+				_m.SupersededBy = new(string)
+				*_m.SupersededBy = value.String
+			}
+		case node.FieldTitle:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field title", values[i])
+			} else if value.Valid {
+				_m.Title = new(string)
+				*_m.Title = value.String
+			}
+		case node.FieldSummary:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field summary", values[i])
+			} else if value.Valid {
+				_m.Summary = new(string)
+				*_m.Summary = value.String
+			}
+		case node.FieldOutcome:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field outcome", values[i])
+			} else if value.Valid {
+				_m.Outcome = new(string)
+				*_m.Outcome = value.String
+			}
+		case node.FieldSummarizedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field summarized_at", values[i])
+			} else if value.Valid {
+				_m.SummarizedAt = new(time.Time)
+				*_m.SummarizedAt = value.Time
+			}
+		case node.FieldEndedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field ended_at", values[i])
+			} else if value.Valid {
+				_m.EndedAt = new(time.Time)
+				*_m.EndedAt = value.Time
+			}
+		case node.FieldEndReason:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field end_reason", values[i])
+			} else if value.Valid {
+				_m.EndReason = new(string)
+				*_m.EndReason = value.String
+			}
+		case node.FieldTurnIndex:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field turn_index", values[i])
+			} else if value.Valid {
+				_m.TurnIndex = new(int)
+				*_m.TurnIndex = int(value.Int64)
+			}
 		case node.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -318,9 +580,19 @@ func (_m *Node) String() string {
 	builder.WriteString("agent_name=")
 	builder.WriteString(_m.AgentName)
 	builder.WriteString(", ")
+	if v := _m.SessionID; v != nil {
+		builder.WriteString("session_id=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
 	builder.WriteString("stop_reason=")
 	builder.WriteString(_m.StopReason)
 	builder.WriteString(", ")
+	if v := _m.ToolNames; v != nil {
+		builder.WriteString("tool_names=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
 	if v := _m.PromptTokens; v != nil {
 		builder.WriteString("prompt_tokens=")
 		builder.WriteString(fmt.Sprintf("%v", *v))
@@ -346,6 +618,11 @@ func (_m *Node) String() string {
 		builder.WriteString(fmt.Sprintf("%v", *v))
 	}
 	builder.WriteString(", ")
+	if v := _m.ReasoningTokens; v != nil {
+		builder.WriteString("reasoning_tokens=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
 	if v := _m.TotalDurationNs; v != nil {
 		builder.WriteString("total_duration_ns=")
 		builder.WriteString(fmt.Sprintf("%v", *v))
@@ -356,11 +633,137 @@ func (_m *Node) String() string {
 		builder.WriteString(fmt.Sprintf("%v", *v))
 	}
 	builder.WriteString(", ")
+	builder.WriteString("usage_estimated=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UsageEstimated))
+	builder.WriteString(", ")
+	if v := _m.RequestStartedAt; v != nil {
+		builder.WriteString("request_started_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.FirstChunkAt; v != nil {
+		builder.WriteString("first_chunk_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.ResponseCompletedAt; v != nil {
+		builder.WriteString("response_completed_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.ErrorStatus; v != nil {
+		builder.WriteString("error_status=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.ErrorType; v != nil {
+		builder.WriteString("error_type=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.ErrorMessage; v != nil {
+		builder.WriteString("error_message=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.RetryAfterSeconds; v != nil {
+		builder.WriteString("retry_after_seconds=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.ErrorAttempt; v != nil {
+		builder.WriteString("error_attempt=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
 	if v := _m.Project; v != nil {
 		builder.WriteString("project=")
 		builder.WriteString(*v)
 	}
 	builder.WriteString(", ")
+	if v := _m.User; v != nil {
+		builder.WriteString("user=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.TraceID; v != nil {
+		builder.WriteString("trace_id=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.RunID; v != nil {
+		builder.WriteString("run_id=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.AgentVersion; v != nil {
+		builder.WriteString("agent_version=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.SystemPromptHash; v != nil {
+		builder.WriteString("system_prompt_hash=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	builder.WriteString("policy_violations=")
+	builder.WriteString(fmt.Sprintf("%v", _m.PolicyViolations))
+	builder.WriteString(", ")
+	if v := _m.SignerKey; v != nil {
+		builder.WriteString("signer_key=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.Signature; v != nil {
+		builder.WriteString("signature=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.SharedBy; v != nil {
+		builder.WriteString("shared_by=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.SupersededBy; v != nil {
+		builder.WriteString("superseded_by=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.Title; v != nil {
+		builder.WriteString("title=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.Summary; v != nil {
+		builder.WriteString("summary=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.Outcome; v != nil {
+		builder.WriteString("outcome=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.SummarizedAt; v != nil {
+		builder.WriteString("summarized_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.EndedAt; v != nil {
+		builder.WriteString("ended_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.EndReason; v != nil {
+		builder.WriteString("end_reason=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.TurnIndex; v != nil {
+		builder.WriteString("turn_index=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteByte(')')
@@ -30,8 +30,12 @@ const (
 	FieldProvider = "provider"
 	// FieldAgentName holds the string denoting the agent_name field in the database.
 	FieldAgentName = "agent_name"
+	// FieldSessionID holds the string denoting the session_id field in the database.
+	FieldSessionID = "session_id"
 	// FieldStopReason holds the string denoting the stop_reason field in the database.
 	FieldStopReason = "stop_reason"
+	// FieldToolNames holds the string denoting the tool_names field in the database.
+	FieldToolNames = "tool_names"
 	// FieldPromptTokens holds the string denoting the prompt_tokens field in the database.
 	FieldPromptTokens = "prompt_tokens"
 	// FieldCompletionTokens holds the string denoting the completion_tokens field in the database.
@@ -42,12 +46,66 @@ const (
 	FieldCacheCreationInputTokens = "cache_creation_input_tokens"
 	// FieldCacheReadInputTokens holds the string denoting the cache_read_input_tokens field in the database.
 	FieldCacheReadInputTokens = "cache_read_input_tokens"
+	// FieldReasoningTokens holds the string denoting the reasoning_tokens field in the database.
+	FieldReasoningTokens = "reasoning_tokens"
 	// FieldTotalDurationNs holds the string denoting the total_duration_ns field in the database.
 	FieldTotalDurationNs = "total_duration_ns"
 	// FieldPromptDurationNs holds the string denoting the prompt_duration_ns field in the database.
 	FieldPromptDurationNs = "prompt_duration_ns"
+	// FieldUsageEstimated holds the string denoting the usage_estimated field in the database.
+	FieldUsageEstimated = "usage_estimated"
+	// FieldRequestStartedAt holds the string denoting the request_started_at field in the database.
+	FieldRequestStartedAt = "request_started_at"
+	// FieldFirstChunkAt holds the string denoting the first_chunk_at field in the database.
+	FieldFirstChunkAt = "first_chunk_at"
+	// FieldResponseCompletedAt holds the string denoting the response_completed_at field in the database.
+	FieldResponseCompletedAt = "response_completed_at"
+	// FieldErrorStatus holds the string denoting the error_status field in the database.
+	FieldErrorStatus = "error_status"
+	// FieldErrorType holds the string denoting the error_type field in the database.
+	FieldErrorType = "error_type"
+	// FieldErrorMessage holds the string denoting the error_message field in the database.
+	FieldErrorMessage = "error_message"
+	// FieldRetryAfterSeconds holds the string denoting the retry_after_seconds field in the database.
+	FieldRetryAfterSeconds = "retry_after_seconds"
+	// FieldErrorAttempt holds the string denoting the error_attempt field in the database.
+	FieldErrorAttempt = "error_attempt"
 	// FieldProject holds the string denoting the project field in the database.
 	FieldProject = "project"
+	// FieldUser holds the string denoting the user field in the database.
+	FieldUser = "user"
+	// FieldTraceID holds the string denoting the trace_id field in the database.
+	FieldTraceID = "trace_id"
+	// FieldRunID holds the string denoting the run_id field in the database.
+	FieldRunID = "run_id"
+	// FieldAgentVersion holds the string denoting the agent_version field in the database.
+	FieldAgentVersion = "agent_version"
+	// FieldSystemPromptHash holds the string denoting the system_prompt_hash field in the database.
+	FieldSystemPromptHash = "system_prompt_hash"
+	// FieldPolicyViolations holds the string denoting the policy_violations field in the database.
+	FieldPolicyViolations = "policy_violations"
+	// FieldSignerKey holds the string denoting the signer_key field in the database.
+	FieldSignerKey = "signer_key"
+	// FieldSignature holds the string denoting the signature field in the database.
+	FieldSignature = "signature"
+	// FieldSharedBy holds the string denoting the shared_by field in the database.
+	FieldSharedBy = "shared_by"
+	// FieldSupersededBy holds the string denoting the superseded_by field in the database.
+	FieldSupersededBy = "superseded_by"
+	// FieldTitle holds the string denoting the title field in the database.
+	FieldTitle = "title"
+	// FieldSummary holds the string denoting the summary field in the database.
+	FieldSummary = "summary"
+	// FieldOutcome holds the string denoting the outcome field in the database.
+	FieldOutcome = "outcome"
+	// FieldSummarizedAt holds the string denoting the summarized_at field in the database.
+	FieldSummarizedAt = "summarized_at"
+	// FieldEndedAt holds the string denoting the ended_at field in the database.
+	FieldEndedAt = "ended_at"
+	// FieldEndReason holds the string denoting the end_reason field in the database.
+	FieldEndReason = "end_reason"
+	// FieldTurnIndex holds the string denoting the turn_index field in the database.
+	FieldTurnIndex = "turn_index"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// EdgeParent holds the string denoting the parent edge name in mutations.
@@ -77,15 +135,44 @@ var Columns = []string{
 	FieldModel,
 	FieldProvider,
 	FieldAgentName,
+	FieldSessionID,
 	FieldStopReason,
+	FieldToolNames,
 	FieldPromptTokens,
 	FieldCompletionTokens,
 	FieldTotalTokens,
 	FieldCacheCreationInputTokens,
 	FieldCacheReadInputTokens,
+	FieldReasoningTokens,
 	FieldTotalDurationNs,
 	FieldPromptDurationNs,
+	FieldUsageEstimated,
+	FieldRequestStartedAt,
+	FieldFirstChunkAt,
+	FieldResponseCompletedAt,
+	FieldErrorStatus,
+	FieldErrorType,
+	FieldErrorMessage,
+	FieldRetryAfterSeconds,
+	FieldErrorAttempt,
 	FieldProject,
+	FieldUser,
+	FieldTraceID,
+	FieldRunID,
+	FieldAgentVersion,
+	FieldSystemPromptHash,
+	FieldPolicyViolations,
+	FieldSignerKey,
+	FieldSignature,
+	FieldSharedBy,
+	FieldSupersededBy,
+	FieldTitle,
+	FieldSummary,
+	FieldOutcome,
+	FieldSummarizedAt,
+	FieldEndedAt,
+	FieldEndReason,
+	FieldTurnIndex,
 	FieldCreatedAt,
 }
 
@@ -144,11 +231,21 @@ func ByAgentName(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldAgentName, opts...).ToFunc()
 }
 
+// BySessionID orders the results by the session_id field.
+func BySessionID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSessionID, opts...).ToFunc()
+}
+
 // ByStopReason orders the results by the stop_reason field.
 func ByStopReason(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldStopReason, opts...).ToFunc()
 }
 
+// ByToolNames orders the results by the tool_names field.
+func ByToolNames(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldToolNames, opts...).ToFunc()
+}
+
 // ByPromptTokens orders the results by the prompt_tokens field.
 func ByPromptTokens(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldPromptTokens, opts...).ToFunc()
@@ -174,6 +271,11 @@ func ByCacheReadInputTokens(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCacheReadInputTokens, opts...).ToFunc()
 }
 
+// ByReasoningTokens orders the results by the reasoning_tokens field.
+func ByReasoningTokens(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldReasoningTokens, opts...).ToFunc()
+}
+
 // ByTotalDurationNs orders the results by the total_duration_ns field.
 func ByTotalDurationNs(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldTotalDurationNs, opts...).ToFunc()
@@ -184,11 +286,126 @@ func ByPromptDurationNs(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldPromptDurationNs, opts...).ToFunc()
 }
 
+// ByUsageEstimated orders the results by the usage_estimated field.
+func ByUsageEstimated(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUsageEstimated, opts...).ToFunc()
+}
+
+// ByRequestStartedAt orders the results by the request_started_at field.
+func ByRequestStartedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRequestStartedAt, opts...).ToFunc()
+}
+
+// ByFirstChunkAt orders the results by the first_chunk_at field.
+func ByFirstChunkAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldFirstChunkAt, opts...).ToFunc()
+}
+
+// ByResponseCompletedAt orders the results by the response_completed_at field.
+func ByResponseCompletedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResponseCompletedAt, opts...).ToFunc()
+}
+
+// ByErrorStatus orders the results by the error_status field.
+func ByErrorStatus(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldErrorStatus, opts...).ToFunc()
+}
+
+// ByErrorType orders the results by the error_type field.
+func ByErrorType(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldErrorType, opts...).ToFunc()
+}
+
+// ByErrorMessage orders the results by the error_message field.
+func ByErrorMessage(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldErrorMessage, opts...).ToFunc()
+}
+
+// ByRetryAfterSeconds orders the results by the retry_after_seconds field.
+func ByRetryAfterSeconds(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRetryAfterSeconds, opts...).ToFunc()
+}
+
+// ByErrorAttempt orders the results by the error_attempt field.
+func ByErrorAttempt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldErrorAttempt, opts...).ToFunc()
+}
+
 // ByProject orders the results by the project field.
 func ByProject(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldProject, opts...).ToFunc()
 }
 
+// ByUser orders the results by the user field.
+func ByUser(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUser, opts...).ToFunc()
+}
+
+// ByTraceID orders the results by the trace_id field.
+func ByTraceID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTraceID, opts...).ToFunc()
+}
+
+// ByRunID orders the results by the run_id field.
+func ByRunID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRunID, opts...).ToFunc()
+}
+
+// ByAgentVersion orders the results by the agent_version field.
+func ByAgentVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAgentVersion, opts...).ToFunc()
+}
+
+// BySystemPromptHash orders the results by the system_prompt_hash field.
+func BySystemPromptHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSystemPromptHash, opts...).ToFunc()
+}
+
+// BySharedBy orders the results by the shared_by field.
+func BySharedBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSharedBy, opts...).ToFunc()
+}
+
+// BySupersededBy orders the results by the superseded_by field.
+func BySupersededBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSupersededBy, opts...).ToFunc()
+}
+
+// ByTitle orders the results by the title field.
+func ByTitle(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTitle, opts...).ToFunc()
+}
+
+// BySummary orders the results by the summary field.
+func BySummary(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSummary, opts...).ToFunc()
+}
+
+// ByOutcome orders the results by the outcome field.
+func ByOutcome(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOutcome, opts...).ToFunc()
+}
+
+// BySummarizedAt orders the results by the summarized_at field.
+func BySummarizedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSummarizedAt, opts...).ToFunc()
+}
+
+// ByEndedAt orders the results by the ended_at field.
+func ByEndedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEndedAt, opts...).ToFunc()
+}
+
+// ByEndReason orders the results by the end_reason field.
+func ByEndReason(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEndReason, opts...).ToFunc()
+}
+
+// ByTurnIndex orders the results by the turn_index field.
+func ByTurnIndex(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTurnIndex, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -95,11 +95,21 @@ func AgentName(v string) predicate.Node {
 	return predicate.Node(sql.FieldEQ(FieldAgentName, v))
 }
 
+// SessionID applies equality check predicate on the "session_id" field. It's identical to SessionIDEQ.
+func SessionID(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSessionID, v))
+}
+
 // StopReason applies equality check predicate on the "stop_reason" field. It's identical to StopReasonEQ.
 func StopReason(v string) predicate.Node {
 	return predicate.Node(sql.FieldEQ(FieldStopReason, v))
 }
 
+// ToolNames applies equality check predicate on the "tool_names" field. It's identical to ToolNamesEQ.
+func ToolNames(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldToolNames, v))
+}
+
 // PromptTokens applies equality check predicate on the "prompt_tokens" field. It's identical to PromptTokensEQ.
 func PromptTokens(v int) predicate.Node {
 	return predicate.Node(sql.FieldEQ(FieldPromptTokens, v))
@@ -125,6 +135,11 @@ func CacheReadInputTokens(v int) predicate.Node {
 	return predicate.Node(sql.FieldEQ(FieldCacheReadInputTokens, v))
 }
 
+// ReasoningTokens applies equality check predicate on the "reasoning_tokens" field. It's identical to ReasoningTokensEQ.
+func ReasoningTokens(v int) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldReasoningTokens, v))
+}
+
 // TotalDurationNs applies equality check predicate on the "total_duration_ns" field. It's identical to TotalDurationNsEQ.
 func TotalDurationNs(v int64) predicate.Node {
 	return predicate.Node(sql.FieldEQ(FieldTotalDurationNs, v))
@@ -135,11 +150,136 @@ func PromptDurationNs(v int64) predicate.Node {
 	return predicate.Node(sql.FieldEQ(FieldPromptDurationNs, v))
 }
 
+// UsageEstimated applies equality check predicate on the "usage_estimated" field. It's identical to UsageEstimatedEQ.
+func UsageEstimated(v bool) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldUsageEstimated, v))
+}
+
+// RequestStartedAt applies equality check predicate on the "request_started_at" field. It's identical to RequestStartedAtEQ.
+func RequestStartedAt(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldRequestStartedAt, v))
+}
+
+// FirstChunkAt applies equality check predicate on the "first_chunk_at" field. It's identical to FirstChunkAtEQ.
+func FirstChunkAt(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldFirstChunkAt, v))
+}
+
+// ResponseCompletedAt applies equality check predicate on the "response_completed_at" field. It's identical to ResponseCompletedAtEQ.
+func ResponseCompletedAt(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldResponseCompletedAt, v))
+}
+
+// ErrorStatus applies equality check predicate on the "error_status" field. It's identical to ErrorStatusEQ.
+func ErrorStatus(v int) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldErrorStatus, v))
+}
+
+// ErrorType applies equality check predicate on the "error_type" field. It's identical to ErrorTypeEQ.
+func ErrorType(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldErrorType, v))
+}
+
+// ErrorMessage applies equality check predicate on the "error_message" field. It's identical to ErrorMessageEQ.
+func ErrorMessage(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldErrorMessage, v))
+}
+
+// RetryAfterSeconds applies equality check predicate on the "retry_after_seconds" field. It's identical to RetryAfterSecondsEQ.
+func RetryAfterSeconds(v int) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldRetryAfterSeconds, v))
+}
+
+// ErrorAttempt applies equality check predicate on the "error_attempt" field. It's identical to ErrorAttemptEQ.
+func ErrorAttempt(v int) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldErrorAttempt, v))
+}
+
 // Project applies equality check predicate on the "project" field. It's identical to ProjectEQ.
 func Project(v string) predicate.Node {
 	return predicate.Node(sql.FieldEQ(FieldProject, v))
 }
 
+// User applies equality check predicate on the "user" field. It's identical to UserEQ.
+func User(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldUser, v))
+}
+
+// TraceID applies equality check predicate on the "trace_id" field. It's identical to TraceIDEQ.
+func TraceID(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldTraceID, v))
+}
+
+// RunID applies equality check predicate on the "run_id" field. It's identical to RunIDEQ.
+func RunID(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldRunID, v))
+}
+
+// AgentVersion applies equality check predicate on the "agent_version" field. It's identical to AgentVersionEQ.
+func AgentVersion(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldAgentVersion, v))
+}
+
+// SystemPromptHash applies equality check predicate on the "system_prompt_hash" field. It's identical to SystemPromptHashEQ.
+func SystemPromptHash(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSystemPromptHash, v))
+}
+
+// SignerKey applies equality check predicate on the "signer_key" field. It's identical to SignerKeyEQ.
+func SignerKey(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSignerKey, v))
+}
+
+// Signature applies equality check predicate on the "signature" field. It's identical to SignatureEQ.
+func Signature(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSignature, v))
+}
+
+// SharedBy applies equality check predicate on the "shared_by" field. It's identical to SharedByEQ.
+func SharedBy(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSharedBy, v))
+}
+
+// SupersededBy applies equality check predicate on the "superseded_by" field. It's identical to SupersededByEQ.
+func SupersededBy(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSupersededBy, v))
+}
+
+// Title applies equality check predicate on the "title" field. It's identical to TitleEQ.
+func Title(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldTitle, v))
+}
+
+// Summary applies equality check predicate on the "summary" field. It's identical to SummaryEQ.
+func Summary(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSummary, v))
+}
+
+// Outcome applies equality check predicate on the "outcome" field. It's identical to OutcomeEQ.
+func Outcome(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldOutcome, v))
+}
+
+// SummarizedAt applies equality check predicate on the "summarized_at" field. It's identical to SummarizedAtEQ.
+func SummarizedAt(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSummarizedAt, v))
+}
+
+// EndedAt applies equality check predicate on the "ended_at" field. It's identical to EndedAtEQ.
+func EndedAt(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldEndedAt, v))
+}
+
+// EndReason applies equality check predicate on the "end_reason" field. It's identical to EndReasonEQ.
+func EndReason(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldEndReason, v))
+}
+
+// TurnIndex applies equality check predicate on the "turn_index" field. It's identical to TurnIndexEQ.
+func TurnIndex(v int) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldTurnIndex, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.Node {
 	return predicate.Node(sql.FieldEQ(FieldCreatedAt, v))
@@ -615,6 +755,81 @@ func AgentNameContainsFold(v string) predicate.Node {
 	return predicate.Node(sql.FieldContainsFold(FieldAgentName, v))
 }
 
+// SessionIDEQ applies the EQ predicate on the "session_id" field.
+func SessionIDEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSessionID, v))
+}
+
+// SessionIDNEQ applies the NEQ predicate on the "session_id" field.
+func SessionIDNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldSessionID, v))
+}
+
+// SessionIDIn applies the In predicate on the "session_id" field.
+func SessionIDIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldSessionID, vs...))
+}
+
+// SessionIDNotIn applies the NotIn predicate on the "session_id" field.
+func SessionIDNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldSessionID, vs...))
+}
+
+// SessionIDGT applies the GT predicate on the "session_id" field.
+func SessionIDGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldSessionID, v))
+}
+
+// SessionIDGTE applies the GTE predicate on the "session_id" field.
+func SessionIDGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldSessionID, v))
+}
+
+// SessionIDLT applies the LT predicate on the "session_id" field.
+func SessionIDLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldSessionID, v))
+}
+
+// SessionIDLTE applies the LTE predicate on the "session_id" field.
+func SessionIDLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldSessionID, v))
+}
+
+// SessionIDContains applies the Contains predicate on the "session_id" field.
+func SessionIDContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldSessionID, v))
+}
+
+// SessionIDHasPrefix applies the HasPrefix predicate on the "session_id" field.
+func SessionIDHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldSessionID, v))
+}
+
+// SessionIDHasSuffix applies the HasSuffix predicate on the "session_id" field.
+func SessionIDHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldSessionID, v))
+}
+
+// SessionIDIsNil applies the IsNil predicate on the "session_id" field.
+func SessionIDIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldSessionID))
+}
+
+// SessionIDNotNil applies the NotNil predicate on the "session_id" field.
+func SessionIDNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldSessionID))
+}
+
+// SessionIDEqualFold applies the EqualFold predicate on the "session_id" field.
+func SessionIDEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldSessionID, v))
+}
+
+// SessionIDContainsFold applies the ContainsFold predicate on the "session_id" field.
+func SessionIDContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldSessionID, v))
+}
+
 // StopReasonEQ applies the EQ predicate on the "stop_reason" field.
 func StopReasonEQ(v string) predicate.Node {
 	return predicate.Node(sql.FieldEQ(FieldStopReason, v))
@@ -690,6 +905,81 @@ func StopReasonContainsFold(v string) predicate.Node {
 	return predicate.Node(sql.FieldContainsFold(FieldStopReason, v))
 }
 
+// ToolNamesEQ applies the EQ predicate on the "tool_names" field.
+func ToolNamesEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldToolNames, v))
+}
+
+// ToolNamesNEQ applies the NEQ predicate on the "tool_names" field.
+func ToolNamesNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldToolNames, v))
+}
+
+// ToolNamesIn applies the In predicate on the "tool_names" field.
+func ToolNamesIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldToolNames, vs...))
+}
+
+// ToolNamesNotIn applies the NotIn predicate on the "tool_names" field.
+func ToolNamesNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldToolNames, vs...))
+}
+
+// ToolNamesGT applies the GT predicate on the "tool_names" field.
+func ToolNamesGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldToolNames, v))
+}
+
+// ToolNamesGTE applies the GTE predicate on the "tool_names" field.
+func ToolNamesGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldToolNames, v))
+}
+
+// ToolNamesLT applies the LT predicate on the "tool_names" field.
+func ToolNamesLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldToolNames, v))
+}
+
+// ToolNamesLTE applies the LTE predicate on the "tool_names" field.
+func ToolNamesLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldToolNames, v))
+}
+
+// ToolNamesContains applies the Contains predicate on the "tool_names" field.
+func ToolNamesContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldToolNames, v))
+}
+
+// ToolNamesHasPrefix applies the HasPrefix predicate on the "tool_names" field.
+func ToolNamesHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldToolNames, v))
+}
+
+// ToolNamesHasSuffix applies the HasSuffix predicate on the "tool_names" field.
+func ToolNamesHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldToolNames, v))
+}
+
+// ToolNamesIsNil applies the IsNil predicate on the "tool_names" field.
+func ToolNamesIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldToolNames))
+}
+
+// ToolNamesNotNil applies the NotNil predicate on the "tool_names" field.
+func ToolNamesNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldToolNames))
+}
+
+// ToolNamesEqualFold applies the EqualFold predicate on the "tool_names" field.
+func ToolNamesEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldToolNames, v))
+}
+
+// ToolNamesContainsFold applies the ContainsFold predicate on the "tool_names" field.
+func ToolNamesContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldToolNames, v))
+}
+
 // PromptTokensEQ applies the EQ predicate on the "prompt_tokens" field.
 func PromptTokensEQ(v int) predicate.Node {
 	return predicate.Node(sql.FieldEQ(FieldPromptTokens, v))
@@ -940,6 +1230,56 @@ func CacheReadInputTokensNotNil() predicate.Node {
 	return predicate.Node(sql.FieldNotNull(FieldCacheReadInputTokens))
 }
 
+// ReasoningTokensEQ applies the EQ predicate on the "reasoning_tokens" field.
+func ReasoningTokensEQ(v int) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldReasoningTokens, v))
+}
+
+// ReasoningTokensNEQ applies the NEQ predicate on the "reasoning_tokens" field.
+func ReasoningTokensNEQ(v int) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldReasoningTokens, v))
+}
+
+// ReasoningTokensIn applies the In predicate on the "reasoning_tokens" field.
+func ReasoningTokensIn(vs ...int) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldReasoningTokens, vs...))
+}
+
+// ReasoningTokensNotIn applies the NotIn predicate on the "reasoning_tokens" field.
+func ReasoningTokensNotIn(vs ...int) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldReasoningTokens, vs...))
+}
+
+// ReasoningTokensGT applies the GT predicate on the "reasoning_tokens" field.
+func ReasoningTokensGT(v int) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldReasoningTokens, v))
+}
+
+// ReasoningTokensGTE applies the GTE predicate on the "reasoning_tokens" field.
+func ReasoningTokensGTE(v int) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldReasoningTokens, v))
+}
+
+// ReasoningTokensLT applies the LT predicate on the "reasoning_tokens" field.
+func ReasoningTokensLT(v int) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldReasoningTokens, v))
+}
+
+// ReasoningTokensLTE applies the LTE predicate on the "reasoning_tokens" field.
+func ReasoningTokensLTE(v int) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldReasoningTokens, v))
+}
+
+// ReasoningTokensIsNil applies the IsNil predicate on the "reasoning_tokens" field.
+func ReasoningTokensIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldReasoningTokens))
+}
+
+// ReasoningTokensNotNil applies the NotNil predicate on the "reasoning_tokens" field.
+func ReasoningTokensNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldReasoningTokens))
+}
+
 // TotalDurationNsEQ applies the EQ predicate on the "total_duration_ns" field.
 func TotalDurationNsEQ(v int64) predicate.Node {
 	return predicate.Node(sql.FieldEQ(FieldTotalDurationNs, v))
@@ -1040,79 +1380,1634 @@ func PromptDurationNsNotNil() predicate.Node {
 	return predicate.Node(sql.FieldNotNull(FieldPromptDurationNs))
 }
 
-// ProjectEQ applies the EQ predicate on the "project" field.
-func ProjectEQ(v string) predicate.Node {
-	return predicate.Node(sql.FieldEQ(FieldProject, v))
+// UsageEstimatedEQ applies the EQ predicate on the "usage_estimated" field.
+func UsageEstimatedEQ(v bool) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldUsageEstimated, v))
 }
 
-// ProjectNEQ applies the NEQ predicate on the "project" field.
-func ProjectNEQ(v string) predicate.Node {
-	return predicate.Node(sql.FieldNEQ(FieldProject, v))
+// UsageEstimatedNEQ applies the NEQ predicate on the "usage_estimated" field.
+func UsageEstimatedNEQ(v bool) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldUsageEstimated, v))
 }
 
-// ProjectIn applies the In predicate on the "project" field.
-func ProjectIn(vs ...string) predicate.Node {
-	return predicate.Node(sql.FieldIn(FieldProject, vs...))
+// UsageEstimatedIsNil applies the IsNil predicate on the "usage_estimated" field.
+func UsageEstimatedIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldUsageEstimated))
 }
 
-// ProjectNotIn applies the NotIn predicate on the "project" field.
-func ProjectNotIn(vs ...string) predicate.Node {
-	return predicate.Node(sql.FieldNotIn(FieldProject, vs...))
+// UsageEstimatedNotNil applies the NotNil predicate on the "usage_estimated" field.
+func UsageEstimatedNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldUsageEstimated))
 }
 
-// ProjectGT applies the GT predicate on the "project" field.
-func ProjectGT(v string) predicate.Node {
-	return predicate.Node(sql.FieldGT(FieldProject, v))
+// RequestStartedAtEQ applies the EQ predicate on the "request_started_at" field.
+func RequestStartedAtEQ(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldRequestStartedAt, v))
 }
 
-// ProjectGTE applies the GTE predicate on the "project" field.
-func ProjectGTE(v string) predicate.Node {
-	return predicate.Node(sql.FieldGTE(FieldProject, v))
+// RequestStartedAtNEQ applies the NEQ predicate on the "request_started_at" field.
+func RequestStartedAtNEQ(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldRequestStartedAt, v))
 }
 
-// ProjectLT applies the LT predicate on the "project" field.
-func ProjectLT(v string) predicate.Node {
-	return predicate.Node(sql.FieldLT(FieldProject, v))
+// RequestStartedAtIn applies the In predicate on the "request_started_at" field.
+func RequestStartedAtIn(vs ...time.Time) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldRequestStartedAt, vs...))
 }
 
-// ProjectLTE applies the LTE predicate on the "project" field.
-func ProjectLTE(v string) predicate.Node {
-	return predicate.Node(sql.FieldLTE(FieldProject, v))
+// RequestStartedAtNotIn applies the NotIn predicate on the "request_started_at" field.
+func RequestStartedAtNotIn(vs ...time.Time) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldRequestStartedAt, vs...))
 }
 
-// ProjectContains applies the Contains predicate on the "project" field.
-func ProjectContains(v string) predicate.Node {
-	return predicate.Node(sql.FieldContains(FieldProject, v))
+// RequestStartedAtGT applies the GT predicate on the "request_started_at" field.
+func RequestStartedAtGT(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldRequestStartedAt, v))
 }
 
-// ProjectHasPrefix applies the HasPrefix predicate on the "project" field.
-func ProjectHasPrefix(v string) predicate.Node {
-	return predicate.Node(sql.FieldHasPrefix(FieldProject, v))
+// RequestStartedAtGTE applies the GTE predicate on the "request_started_at" field.
+func RequestStartedAtGTE(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldRequestStartedAt, v))
 }
 
-// ProjectHasSuffix applies the HasSuffix predicate on the "project" field.
-func ProjectHasSuffix(v string) predicate.Node {
-	return predicate.Node(sql.FieldHasSuffix(FieldProject, v))
+// RequestStartedAtLT applies the LT predicate on the "request_started_at" field.
+func RequestStartedAtLT(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldRequestStartedAt, v))
 }
 
-// ProjectIsNil applies the IsNil predicate on the "project" field.
-func ProjectIsNil() predicate.Node {
-	return predicate.Node(sql.FieldIsNull(FieldProject))
+// RequestStartedAtLTE applies the LTE predicate on the "request_started_at" field.
+func RequestStartedAtLTE(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldRequestStartedAt, v))
 }
 
-// ProjectNotNil applies the NotNil predicate on the "project" field.
-func ProjectNotNil() predicate.Node {
-	return predicate.Node(sql.FieldNotNull(FieldProject))
+// RequestStartedAtIsNil applies the IsNil predicate on the "request_started_at" field.
+func RequestStartedAtIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldRequestStartedAt))
 }
 
-// ProjectEqualFold applies the EqualFold predicate on the "project" field.
-func ProjectEqualFold(v string) predicate.Node {
-	return predicate.Node(sql.FieldEqualFold(FieldProject, v))
+// RequestStartedAtNotNil applies the NotNil predicate on the "request_started_at" field.
+func RequestStartedAtNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldRequestStartedAt))
 }
 
-// ProjectContainsFold applies the ContainsFold predicate on the "project" field.
-func ProjectContainsFold(v string) predicate.Node {
-	return predicate.Node(sql.FieldContainsFold(FieldProject, v))
+// FirstChunkAtEQ applies the EQ predicate on the "first_chunk_at" field.
+func FirstChunkAtEQ(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldFirstChunkAt, v))
+}
+
+// FirstChunkAtNEQ applies the NEQ predicate on the "first_chunk_at" field.
+func FirstChunkAtNEQ(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldFirstChunkAt, v))
+}
+
+// FirstChunkAtIn applies the In predicate on the "first_chunk_at" field.
+func FirstChunkAtIn(vs ...time.Time) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldFirstChunkAt, vs...))
+}
+
+// FirstChunkAtNotIn applies the NotIn predicate on the "first_chunk_at" field.
+func FirstChunkAtNotIn(vs ...time.Time) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldFirstChunkAt, vs...))
+}
+
+// FirstChunkAtGT applies the GT predicate on the "first_chunk_at" field.
+func FirstChunkAtGT(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldFirstChunkAt, v))
+}
+
+// FirstChunkAtGTE applies the GTE predicate on the "first_chunk_at" field.
+func FirstChunkAtGTE(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldFirstChunkAt, v))
+}
+
+// FirstChunkAtLT applies the LT predicate on the "first_chunk_at" field.
+func FirstChunkAtLT(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldFirstChunkAt, v))
+}
+
+// FirstChunkAtLTE applies the LTE predicate on the "first_chunk_at" field.
+func FirstChunkAtLTE(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldFirstChunkAt, v))
+}
+
+// FirstChunkAtIsNil applies the IsNil predicate on the "first_chunk_at" field.
+func FirstChunkAtIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldFirstChunkAt))
+}
+
+// FirstChunkAtNotNil applies the NotNil predicate on the "first_chunk_at" field.
+func FirstChunkAtNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldFirstChunkAt))
+}
+
+// ResponseCompletedAtEQ applies the EQ predicate on the "response_completed_at" field.
+func ResponseCompletedAtEQ(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldResponseCompletedAt, v))
+}
+
+// ResponseCompletedAtNEQ applies the NEQ predicate on the "response_completed_at" field.
+func ResponseCompletedAtNEQ(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldResponseCompletedAt, v))
+}
+
+// ResponseCompletedAtIn applies the In predicate on the "response_completed_at" field.
+func ResponseCompletedAtIn(vs ...time.Time) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldResponseCompletedAt, vs...))
+}
+
+// ResponseCompletedAtNotIn applies the NotIn predicate on the "response_completed_at" field.
+func ResponseCompletedAtNotIn(vs ...time.Time) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldResponseCompletedAt, vs...))
+}
+
+// ResponseCompletedAtGT applies the GT predicate on the "response_completed_at" field.
+func ResponseCompletedAtGT(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldResponseCompletedAt, v))
+}
+
+// ResponseCompletedAtGTE applies the GTE predicate on the "response_completed_at" field.
+func ResponseCompletedAtGTE(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldResponseCompletedAt, v))
+}
+
+// ResponseCompletedAtLT applies the LT predicate on the "response_completed_at" field.
+func ResponseCompletedAtLT(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldResponseCompletedAt, v))
+}
+
+// ResponseCompletedAtLTE applies the LTE predicate on the "response_completed_at" field.
+func ResponseCompletedAtLTE(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldResponseCompletedAt, v))
+}
+
+// ResponseCompletedAtIsNil applies the IsNil predicate on the "response_completed_at" field.
+func ResponseCompletedAtIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldResponseCompletedAt))
+}
+
+// ResponseCompletedAtNotNil applies the NotNil predicate on the "response_completed_at" field.
+func ResponseCompletedAtNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldResponseCompletedAt))
+}
+
+// ErrorStatusEQ applies the EQ predicate on the "error_status" field.
+func ErrorStatusEQ(v int) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldErrorStatus, v))
+}
+
+// ErrorStatusNEQ applies the NEQ predicate on the "error_status" field.
+func ErrorStatusNEQ(v int) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldErrorStatus, v))
+}
+
+// ErrorStatusIn applies the In predicate on the "error_status" field.
+func ErrorStatusIn(vs ...int) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldErrorStatus, vs...))
+}
+
+// ErrorStatusNotIn applies the NotIn predicate on the "error_status" field.
+func ErrorStatusNotIn(vs ...int) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldErrorStatus, vs...))
+}
+
+// ErrorStatusGT applies the GT predicate on the "error_status" field.
+func ErrorStatusGT(v int) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldErrorStatus, v))
+}
+
+// ErrorStatusGTE applies the GTE predicate on the "error_status" field.
+func ErrorStatusGTE(v int) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldErrorStatus, v))
+}
+
+// ErrorStatusLT applies the LT predicate on the "error_status" field.
+func ErrorStatusLT(v int) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldErrorStatus, v))
+}
+
+// ErrorStatusLTE applies the LTE predicate on the "error_status" field.
+func ErrorStatusLTE(v int) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldErrorStatus, v))
+}
+
+// ErrorStatusIsNil applies the IsNil predicate on the "error_status" field.
+func ErrorStatusIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldErrorStatus))
+}
+
+// ErrorStatusNotNil applies the NotNil predicate on the "error_status" field.
+func ErrorStatusNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldErrorStatus))
+}
+
+// ErrorTypeEQ applies the EQ predicate on the "error_type" field.
+func ErrorTypeEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldErrorType, v))
+}
+
+// ErrorTypeNEQ applies the NEQ predicate on the "error_type" field.
+func ErrorTypeNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldErrorType, v))
+}
+
+// ErrorTypeIn applies the In predicate on the "error_type" field.
+func ErrorTypeIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldErrorType, vs...))
+}
+
+// ErrorTypeNotIn applies the NotIn predicate on the "error_type" field.
+func ErrorTypeNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldErrorType, vs...))
+}
+
+// ErrorTypeGT applies the GT predicate on the "error_type" field.
+func ErrorTypeGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldErrorType, v))
+}
+
+// ErrorTypeGTE applies the GTE predicate on the "error_type" field.
+func ErrorTypeGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldErrorType, v))
+}
+
+// ErrorTypeLT applies the LT predicate on the "error_type" field.
+func ErrorTypeLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldErrorType, v))
+}
+
+// ErrorTypeLTE applies the LTE predicate on the "error_type" field.
+func ErrorTypeLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldErrorType, v))
+}
+
+// ErrorTypeContains applies the Contains predicate on the "error_type" field.
+func ErrorTypeContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldErrorType, v))
+}
+
+// ErrorTypeHasPrefix applies the HasPrefix predicate on the "error_type" field.
+func ErrorTypeHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldErrorType, v))
+}
+
+// ErrorTypeHasSuffix applies the HasSuffix predicate on the "error_type" field.
+func ErrorTypeHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldErrorType, v))
+}
+
+// ErrorTypeIsNil applies the IsNil predicate on the "error_type" field.
+func ErrorTypeIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldErrorType))
+}
+
+// ErrorTypeNotNil applies the NotNil predicate on the "error_type" field.
+func ErrorTypeNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldErrorType))
+}
+
+// ErrorTypeEqualFold applies the EqualFold predicate on the "error_type" field.
+func ErrorTypeEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldErrorType, v))
+}
+
+// ErrorTypeContainsFold applies the ContainsFold predicate on the "error_type" field.
+func ErrorTypeContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldErrorType, v))
+}
+
+// ErrorMessageEQ applies the EQ predicate on the "error_message" field.
+func ErrorMessageEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldErrorMessage, v))
+}
+
+// ErrorMessageNEQ applies the NEQ predicate on the "error_message" field.
+func ErrorMessageNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldErrorMessage, v))
+}
+
+// ErrorMessageIn applies the In predicate on the "error_message" field.
+func ErrorMessageIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldErrorMessage, vs...))
+}
+
+// ErrorMessageNotIn applies the NotIn predicate on the "error_message" field.
+func ErrorMessageNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldErrorMessage, vs...))
+}
+
+// ErrorMessageGT applies the GT predicate on the "error_message" field.
+func ErrorMessageGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldErrorMessage, v))
+}
+
+// ErrorMessageGTE applies the GTE predicate on the "error_message" field.
+func ErrorMessageGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldErrorMessage, v))
+}
+
+// ErrorMessageLT applies the LT predicate on the "error_message" field.
+func ErrorMessageLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldErrorMessage, v))
+}
+
+// ErrorMessageLTE applies the LTE predicate on the "error_message" field.
+func ErrorMessageLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldErrorMessage, v))
+}
+
+// ErrorMessageContains applies the Contains predicate on the "error_message" field.
+func ErrorMessageContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldErrorMessage, v))
+}
+
+// ErrorMessageHasPrefix applies the HasPrefix predicate on the "error_message" field.
+func ErrorMessageHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldErrorMessage, v))
+}
+
+// ErrorMessageHasSuffix applies the HasSuffix predicate on the "error_message" field.
+func ErrorMessageHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldErrorMessage, v))
+}
+
+// ErrorMessageIsNil applies the IsNil predicate on the "error_message" field.
+func ErrorMessageIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldErrorMessage))
+}
+
+// ErrorMessageNotNil applies the NotNil predicate on the "error_message" field.
+func ErrorMessageNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldErrorMessage))
+}
+
+// ErrorMessageEqualFold applies the EqualFold predicate on the "error_message" field.
+func ErrorMessageEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldErrorMessage, v))
+}
+
+// ErrorMessageContainsFold applies the ContainsFold predicate on the "error_message" field.
+func ErrorMessageContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldErrorMessage, v))
+}
+
+// RetryAfterSecondsEQ applies the EQ predicate on the "retry_after_seconds" field.
+func RetryAfterSecondsEQ(v int) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldRetryAfterSeconds, v))
+}
+
+// RetryAfterSecondsNEQ applies the NEQ predicate on the "retry_after_seconds" field.
+func RetryAfterSecondsNEQ(v int) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldRetryAfterSeconds, v))
+}
+
+// RetryAfterSecondsIn applies the In predicate on the "retry_after_seconds" field.
+func RetryAfterSecondsIn(vs ...int) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldRetryAfterSeconds, vs...))
+}
+
+// RetryAfterSecondsNotIn applies the NotIn predicate on the "retry_after_seconds" field.
+func RetryAfterSecondsNotIn(vs ...int) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldRetryAfterSeconds, vs...))
+}
+
+// RetryAfterSecondsGT applies the GT predicate on the "retry_after_seconds" field.
+func RetryAfterSecondsGT(v int) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldRetryAfterSeconds, v))
+}
+
+// RetryAfterSecondsGTE applies the GTE predicate on the "retry_after_seconds" field.
+func RetryAfterSecondsGTE(v int) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldRetryAfterSeconds, v))
+}
+
+// RetryAfterSecondsLT applies the LT predicate on the "retry_after_seconds" field.
+func RetryAfterSecondsLT(v int) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldRetryAfterSeconds, v))
+}
+
+// RetryAfterSecondsLTE applies the LTE predicate on the "retry_after_seconds" field.
+func RetryAfterSecondsLTE(v int) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldRetryAfterSeconds, v))
+}
+
+// RetryAfterSecondsIsNil applies the IsNil predicate on the "retry_after_seconds" field.
+func RetryAfterSecondsIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldRetryAfterSeconds))
+}
+
+// RetryAfterSecondsNotNil applies the NotNil predicate on the "retry_after_seconds" field.
+func RetryAfterSecondsNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldRetryAfterSeconds))
+}
+
+// ErrorAttemptEQ applies the EQ predicate on the "error_attempt" field.
+func ErrorAttemptEQ(v int) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldErrorAttempt, v))
+}
+
+// ErrorAttemptNEQ applies the NEQ predicate on the "error_attempt" field.
+func ErrorAttemptNEQ(v int) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldErrorAttempt, v))
+}
+
+// ErrorAttemptIn applies the In predicate on the "error_attempt" field.
+func ErrorAttemptIn(vs ...int) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldErrorAttempt, vs...))
+}
+
+// ErrorAttemptNotIn applies the NotIn predicate on the "error_attempt" field.
+func ErrorAttemptNotIn(vs ...int) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldErrorAttempt, vs...))
+}
+
+// ErrorAttemptGT applies the GT predicate on the "error_attempt" field.
+func ErrorAttemptGT(v int) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldErrorAttempt, v))
+}
+
+// ErrorAttemptGTE applies the GTE predicate on the "error_attempt" field.
+func ErrorAttemptGTE(v int) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldErrorAttempt, v))
+}
+
+// ErrorAttemptLT applies the LT predicate on the "error_attempt" field.
+func ErrorAttemptLT(v int) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldErrorAttempt, v))
+}
+
+// ErrorAttemptLTE applies the LTE predicate on the "error_attempt" field.
+func ErrorAttemptLTE(v int) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldErrorAttempt, v))
+}
+
+// ErrorAttemptIsNil applies the IsNil predicate on the "error_attempt" field.
+func ErrorAttemptIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldErrorAttempt))
+}
+
+// ErrorAttemptNotNil applies the NotNil predicate on the "error_attempt" field.
+func ErrorAttemptNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldErrorAttempt))
+}
+
+// ProjectEQ applies the EQ predicate on the "project" field.
+func ProjectEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldProject, v))
+}
+
+// ProjectNEQ applies the NEQ predicate on the "project" field.
+func ProjectNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldProject, v))
+}
+
+// ProjectIn applies the In predicate on the "project" field.
+func ProjectIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldProject, vs...))
+}
+
+// ProjectNotIn applies the NotIn predicate on the "project" field.
+func ProjectNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldProject, vs...))
+}
+
+// ProjectGT applies the GT predicate on the "project" field.
+func ProjectGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldProject, v))
+}
+
+// ProjectGTE applies the GTE predicate on the "project" field.
+func ProjectGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldProject, v))
+}
+
+// ProjectLT applies the LT predicate on the "project" field.
+func ProjectLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldProject, v))
+}
+
+// ProjectLTE applies the LTE predicate on the "project" field.
+func ProjectLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldProject, v))
+}
+
+// ProjectContains applies the Contains predicate on the "project" field.
+func ProjectContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldProject, v))
+}
+
+// ProjectHasPrefix applies the HasPrefix predicate on the "project" field.
+func ProjectHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldProject, v))
+}
+
+// ProjectHasSuffix applies the HasSuffix predicate on the "project" field.
+func ProjectHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldProject, v))
+}
+
+// ProjectIsNil applies the IsNil predicate on the "project" field.
+func ProjectIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldProject))
+}
+
+// ProjectNotNil applies the NotNil predicate on the "project" field.
+func ProjectNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldProject))
+}
+
+// ProjectEqualFold applies the EqualFold predicate on the "project" field.
+func ProjectEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldProject, v))
+}
+
+// ProjectContainsFold applies the ContainsFold predicate on the "project" field.
+func ProjectContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldProject, v))
+}
+
+// UserEQ applies the EQ predicate on the "user" field.
+func UserEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldUser, v))
+}
+
+// UserNEQ applies the NEQ predicate on the "user" field.
+func UserNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldUser, v))
+}
+
+// UserIn applies the In predicate on the "user" field.
+func UserIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldUser, vs...))
+}
+
+// UserNotIn applies the NotIn predicate on the "user" field.
+func UserNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldUser, vs...))
+}
+
+// UserGT applies the GT predicate on the "user" field.
+func UserGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldUser, v))
+}
+
+// UserGTE applies the GTE predicate on the "user" field.
+func UserGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldUser, v))
+}
+
+// UserLT applies the LT predicate on the "user" field.
+func UserLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldUser, v))
+}
+
+// UserLTE applies the LTE predicate on the "user" field.
+func UserLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldUser, v))
+}
+
+// UserContains applies the Contains predicate on the "user" field.
+func UserContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldUser, v))
+}
+
+// UserHasPrefix applies the HasPrefix predicate on the "user" field.
+func UserHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldUser, v))
+}
+
+// UserHasSuffix applies the HasSuffix predicate on the "user" field.
+func UserHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldUser, v))
+}
+
+// UserIsNil applies the IsNil predicate on the "user" field.
+func UserIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldUser))
+}
+
+// UserNotNil applies the NotNil predicate on the "user" field.
+func UserNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldUser))
+}
+
+// UserEqualFold applies the EqualFold predicate on the "user" field.
+func UserEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldUser, v))
+}
+
+// UserContainsFold applies the ContainsFold predicate on the "user" field.
+func UserContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldUser, v))
+}
+
+// TraceIDEQ applies the EQ predicate on the "trace_id" field.
+func TraceIDEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldTraceID, v))
+}
+
+// TraceIDNEQ applies the NEQ predicate on the "trace_id" field.
+func TraceIDNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldTraceID, v))
+}
+
+// TraceIDIn applies the In predicate on the "trace_id" field.
+func TraceIDIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldTraceID, vs...))
+}
+
+// TraceIDNotIn applies the NotIn predicate on the "trace_id" field.
+func TraceIDNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldTraceID, vs...))
+}
+
+// TraceIDGT applies the GT predicate on the "trace_id" field.
+func TraceIDGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldTraceID, v))
+}
+
+// TraceIDGTE applies the GTE predicate on the "trace_id" field.
+func TraceIDGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldTraceID, v))
+}
+
+// TraceIDLT applies the LT predicate on the "trace_id" field.
+func TraceIDLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldTraceID, v))
+}
+
+// TraceIDLTE applies the LTE predicate on the "trace_id" field.
+func TraceIDLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldTraceID, v))
+}
+
+// TraceIDContains applies the Contains predicate on the "trace_id" field.
+func TraceIDContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldTraceID, v))
+}
+
+// TraceIDHasPrefix applies the HasPrefix predicate on the "trace_id" field.
+func TraceIDHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldTraceID, v))
+}
+
+// TraceIDHasSuffix applies the HasSuffix predicate on the "trace_id" field.
+func TraceIDHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldTraceID, v))
+}
+
+// TraceIDIsNil applies the IsNil predicate on the "trace_id" field.
+func TraceIDIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldTraceID))
+}
+
+// TraceIDNotNil applies the NotNil predicate on the "trace_id" field.
+func TraceIDNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldTraceID))
+}
+
+// TraceIDEqualFold applies the EqualFold predicate on the "trace_id" field.
+func TraceIDEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldTraceID, v))
+}
+
+// TraceIDContainsFold applies the ContainsFold predicate on the "trace_id" field.
+func TraceIDContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldTraceID, v))
+}
+
+// RunIDEQ applies the EQ predicate on the "run_id" field.
+func RunIDEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldRunID, v))
+}
+
+// RunIDNEQ applies the NEQ predicate on the "run_id" field.
+func RunIDNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldRunID, v))
+}
+
+// RunIDIn applies the In predicate on the "run_id" field.
+func RunIDIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldRunID, vs...))
+}
+
+// RunIDNotIn applies the NotIn predicate on the "run_id" field.
+func RunIDNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldRunID, vs...))
+}
+
+// RunIDGT applies the GT predicate on the "run_id" field.
+func RunIDGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldRunID, v))
+}
+
+// RunIDGTE applies the GTE predicate on the "run_id" field.
+func RunIDGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldRunID, v))
+}
+
+// RunIDLT applies the LT predicate on the "run_id" field.
+func RunIDLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldRunID, v))
+}
+
+// RunIDLTE applies the LTE predicate on the "run_id" field.
+func RunIDLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldRunID, v))
+}
+
+// RunIDContains applies the Contains predicate on the "run_id" field.
+func RunIDContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldRunID, v))
+}
+
+// RunIDHasPrefix applies the HasPrefix predicate on the "run_id" field.
+func RunIDHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldRunID, v))
+}
+
+// RunIDHasSuffix applies the HasSuffix predicate on the "run_id" field.
+func RunIDHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldRunID, v))
+}
+
+// RunIDIsNil applies the IsNil predicate on the "run_id" field.
+func RunIDIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldRunID))
+}
+
+// RunIDNotNil applies the NotNil predicate on the "run_id" field.
+func RunIDNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldRunID))
+}
+
+// RunIDEqualFold applies the EqualFold predicate on the "run_id" field.
+func RunIDEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldRunID, v))
+}
+
+// RunIDContainsFold applies the ContainsFold predicate on the "run_id" field.
+func RunIDContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldRunID, v))
+}
+
+// AgentVersionEQ applies the EQ predicate on the "agent_version" field.
+func AgentVersionEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldAgentVersion, v))
+}
+
+// AgentVersionNEQ applies the NEQ predicate on the "agent_version" field.
+func AgentVersionNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldAgentVersion, v))
+}
+
+// AgentVersionIn applies the In predicate on the "agent_version" field.
+func AgentVersionIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldAgentVersion, vs...))
+}
+
+// AgentVersionNotIn applies the NotIn predicate on the "agent_version" field.
+func AgentVersionNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldAgentVersion, vs...))
+}
+
+// AgentVersionGT applies the GT predicate on the "agent_version" field.
+func AgentVersionGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldAgentVersion, v))
+}
+
+// AgentVersionGTE applies the GTE predicate on the "agent_version" field.
+func AgentVersionGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldAgentVersion, v))
+}
+
+// AgentVersionLT applies the LT predicate on the "agent_version" field.
+func AgentVersionLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldAgentVersion, v))
+}
+
+// AgentVersionLTE applies the LTE predicate on the "agent_version" field.
+func AgentVersionLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldAgentVersion, v))
+}
+
+// AgentVersionContains applies the Contains predicate on the "agent_version" field.
+func AgentVersionContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldAgentVersion, v))
+}
+
+// AgentVersionHasPrefix applies the HasPrefix predicate on the "agent_version" field.
+func AgentVersionHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldAgentVersion, v))
+}
+
+// AgentVersionHasSuffix applies the HasSuffix predicate on the "agent_version" field.
+func AgentVersionHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldAgentVersion, v))
+}
+
+// AgentVersionIsNil applies the IsNil predicate on the "agent_version" field.
+func AgentVersionIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldAgentVersion))
+}
+
+// AgentVersionNotNil applies the NotNil predicate on the "agent_version" field.
+func AgentVersionNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldAgentVersion))
+}
+
+// AgentVersionEqualFold applies the EqualFold predicate on the "agent_version" field.
+func AgentVersionEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldAgentVersion, v))
+}
+
+// AgentVersionContainsFold applies the ContainsFold predicate on the "agent_version" field.
+func AgentVersionContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldAgentVersion, v))
+}
+
+// SystemPromptHashEQ applies the EQ predicate on the "system_prompt_hash" field.
+func SystemPromptHashEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSystemPromptHash, v))
+}
+
+// SystemPromptHashNEQ applies the NEQ predicate on the "system_prompt_hash" field.
+func SystemPromptHashNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldSystemPromptHash, v))
+}
+
+// SystemPromptHashIn applies the In predicate on the "system_prompt_hash" field.
+func SystemPromptHashIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldSystemPromptHash, vs...))
+}
+
+// SystemPromptHashNotIn applies the NotIn predicate on the "system_prompt_hash" field.
+func SystemPromptHashNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldSystemPromptHash, vs...))
+}
+
+// SystemPromptHashGT applies the GT predicate on the "system_prompt_hash" field.
+func SystemPromptHashGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldSystemPromptHash, v))
+}
+
+// SystemPromptHashGTE applies the GTE predicate on the "system_prompt_hash" field.
+func SystemPromptHashGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldSystemPromptHash, v))
+}
+
+// SystemPromptHashLT applies the LT predicate on the "system_prompt_hash" field.
+func SystemPromptHashLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldSystemPromptHash, v))
+}
+
+// SystemPromptHashLTE applies the LTE predicate on the "system_prompt_hash" field.
+func SystemPromptHashLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldSystemPromptHash, v))
+}
+
+// SystemPromptHashContains applies the Contains predicate on the "system_prompt_hash" field.
+func SystemPromptHashContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldSystemPromptHash, v))
+}
+
+// SystemPromptHashHasPrefix applies the HasPrefix predicate on the "system_prompt_hash" field.
+func SystemPromptHashHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldSystemPromptHash, v))
+}
+
+// SystemPromptHashHasSuffix applies the HasSuffix predicate on the "system_prompt_hash" field.
+func SystemPromptHashHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldSystemPromptHash, v))
+}
+
+// SystemPromptHashIsNil applies the IsNil predicate on the "system_prompt_hash" field.
+func SystemPromptHashIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldSystemPromptHash))
+}
+
+// SystemPromptHashNotNil applies the NotNil predicate on the "system_prompt_hash" field.
+func SystemPromptHashNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldSystemPromptHash))
+}
+
+// SystemPromptHashEqualFold applies the EqualFold predicate on the "system_prompt_hash" field.
+func SystemPromptHashEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldSystemPromptHash, v))
+}
+
+// SystemPromptHashContainsFold applies the ContainsFold predicate on the "system_prompt_hash" field.
+func SystemPromptHashContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldSystemPromptHash, v))
+}
+
+// PolicyViolationsIsNil applies the IsNil predicate on the "policy_violations" field.
+func PolicyViolationsIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldPolicyViolations))
+}
+
+// PolicyViolationsNotNil applies the NotNil predicate on the "policy_violations" field.
+func PolicyViolationsNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldPolicyViolations))
+}
+
+// SignerKeyEQ applies the EQ predicate on the "signer_key" field.
+func SignerKeyEQ(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSignerKey, v))
+}
+
+// SignerKeyNEQ applies the NEQ predicate on the "signer_key" field.
+func SignerKeyNEQ(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldSignerKey, v))
+}
+
+// SignerKeyIn applies the In predicate on the "signer_key" field.
+func SignerKeyIn(vs ...[]byte) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldSignerKey, vs...))
+}
+
+// SignerKeyNotIn applies the NotIn predicate on the "signer_key" field.
+func SignerKeyNotIn(vs ...[]byte) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldSignerKey, vs...))
+}
+
+// SignerKeyGT applies the GT predicate on the "signer_key" field.
+func SignerKeyGT(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldSignerKey, v))
+}
+
+// SignerKeyGTE applies the GTE predicate on the "signer_key" field.
+func SignerKeyGTE(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldSignerKey, v))
+}
+
+// SignerKeyLT applies the LT predicate on the "signer_key" field.
+func SignerKeyLT(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldSignerKey, v))
+}
+
+// SignerKeyLTE applies the LTE predicate on the "signer_key" field.
+func SignerKeyLTE(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldSignerKey, v))
+}
+
+// SignerKeyIsNil applies the IsNil predicate on the "signer_key" field.
+func SignerKeyIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldSignerKey))
+}
+
+// SignerKeyNotNil applies the NotNil predicate on the "signer_key" field.
+func SignerKeyNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldSignerKey))
+}
+
+// SignatureEQ applies the EQ predicate on the "signature" field.
+func SignatureEQ(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSignature, v))
+}
+
+// SignatureNEQ applies the NEQ predicate on the "signature" field.
+func SignatureNEQ(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldSignature, v))
+}
+
+// SignatureIn applies the In predicate on the "signature" field.
+func SignatureIn(vs ...[]byte) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldSignature, vs...))
+}
+
+// SignatureNotIn applies the NotIn predicate on the "signature" field.
+func SignatureNotIn(vs ...[]byte) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldSignature, vs...))
+}
+
+// SignatureGT applies the GT predicate on the "signature" field.
+func SignatureGT(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldSignature, v))
+}
+
+// SignatureGTE applies the GTE predicate on the "signature" field.
+func SignatureGTE(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldSignature, v))
+}
+
+// SignatureLT applies the LT predicate on the "signature" field.
+func SignatureLT(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldSignature, v))
+}
+
+// SignatureLTE applies the LTE predicate on the "signature" field.
+func SignatureLTE(v []byte) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldSignature, v))
+}
+
+// SignatureIsNil applies the IsNil predicate on the "signature" field.
+func SignatureIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldSignature))
+}
+
+// SignatureNotNil applies the NotNil predicate on the "signature" field.
+func SignatureNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldSignature))
+}
+
+// SharedByEQ applies the EQ predicate on the "shared_by" field.
+func SharedByEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSharedBy, v))
+}
+
+// SharedByNEQ applies the NEQ predicate on the "shared_by" field.
+func SharedByNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldSharedBy, v))
+}
+
+// SharedByIn applies the In predicate on the "shared_by" field.
+func SharedByIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldSharedBy, vs...))
+}
+
+// SharedByNotIn applies the NotIn predicate on the "shared_by" field.
+func SharedByNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldSharedBy, vs...))
+}
+
+// SharedByGT applies the GT predicate on the "shared_by" field.
+func SharedByGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldSharedBy, v))
+}
+
+// SharedByGTE applies the GTE predicate on the "shared_by" field.
+func SharedByGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldSharedBy, v))
+}
+
+// SharedByLT applies the LT predicate on the "shared_by" field.
+func SharedByLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldSharedBy, v))
+}
+
+// SharedByLTE applies the LTE predicate on the "shared_by" field.
+func SharedByLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldSharedBy, v))
+}
+
+// SharedByContains applies the Contains predicate on the "shared_by" field.
+func SharedByContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldSharedBy, v))
+}
+
+// SharedByHasPrefix applies the HasPrefix predicate on the "shared_by" field.
+func SharedByHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldSharedBy, v))
+}
+
+// SharedByHasSuffix applies the HasSuffix predicate on the "shared_by" field.
+func SharedByHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldSharedBy, v))
+}
+
+// SharedByIsNil applies the IsNil predicate on the "shared_by" field.
+func SharedByIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldSharedBy))
+}
+
+// SharedByNotNil applies the NotNil predicate on the "shared_by" field.
+func SharedByNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldSharedBy))
+}
+
+// SharedByEqualFold applies the EqualFold predicate on the "shared_by" field.
+func SharedByEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldSharedBy, v))
+}
+
+// SharedByContainsFold applies the ContainsFold predicate on the "shared_by" field.
+func SharedByContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldSharedBy, v))
+}
+
+// SupersededByEQ applies the EQ predicate on the "superseded_by" field.
+func SupersededByEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSupersededBy, v))
+}
+
+// SupersededByNEQ applies the NEQ predicate on the "superseded_by" field.
+func SupersededByNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldSupersededBy, v))
+}
+
+// SupersededByIn applies the In predicate on the "superseded_by" field.
+func SupersededByIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldSupersededBy, vs...))
+}
+
+// SupersededByNotIn applies the NotIn predicate on the "superseded_by" field.
+func SupersededByNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldSupersededBy, vs...))
+}
+
+// SupersededByGT applies the GT predicate on the "superseded_by" field.
+func SupersededByGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldSupersededBy, v))
+}
+
+// SupersededByGTE applies the GTE predicate on the "superseded_by" field.
+func SupersededByGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldSupersededBy, v))
+}
+
+// SupersededByLT applies the LT predicate on the "superseded_by" field.
+func SupersededByLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldSupersededBy, v))
+}
+
+// SupersededByLTE applies the LTE predicate on the "superseded_by" field.
+func SupersededByLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldSupersededBy, v))
+}
+
+// SupersededByContains applies the Contains predicate on the "superseded_by" field.
+func SupersededByContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldSupersededBy, v))
+}
+
+// SupersededByHasPrefix applies the HasPrefix predicate on the "superseded_by" field.
+func SupersededByHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldSupersededBy, v))
+}
+
+// SupersededByHasSuffix applies the HasSuffix predicate on the "superseded_by" field.
+func SupersededByHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldSupersededBy, v))
+}
+
+// SupersededByIsNil applies the IsNil predicate on the "superseded_by" field.
+func SupersededByIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldSupersededBy))
+}
+
+// SupersededByNotNil applies the NotNil predicate on the "superseded_by" field.
+func SupersededByNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldSupersededBy))
+}
+
+// SupersededByEqualFold applies the EqualFold predicate on the "superseded_by" field.
+func SupersededByEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldSupersededBy, v))
+}
+
+// SupersededByContainsFold applies the ContainsFold predicate on the "superseded_by" field.
+func SupersededByContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldSupersededBy, v))
+}
+
+// TitleEQ applies the EQ predicate on the "title" field.
+func TitleEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldTitle, v))
+}
+
+// TitleNEQ applies the NEQ predicate on the "title" field.
+func TitleNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldTitle, v))
+}
+
+// TitleIn applies the In predicate on the "title" field.
+func TitleIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldTitle, vs...))
+}
+
+// TitleNotIn applies the NotIn predicate on the "title" field.
+func TitleNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldTitle, vs...))
+}
+
+// TitleGT applies the GT predicate on the "title" field.
+func TitleGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldTitle, v))
+}
+
+// TitleGTE applies the GTE predicate on the "title" field.
+func TitleGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldTitle, v))
+}
+
+// TitleLT applies the LT predicate on the "title" field.
+func TitleLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldTitle, v))
+}
+
+// TitleLTE applies the LTE predicate on the "title" field.
+func TitleLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldTitle, v))
+}
+
+// TitleContains applies the Contains predicate on the "title" field.
+func TitleContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldTitle, v))
+}
+
+// TitleHasPrefix applies the HasPrefix predicate on the "title" field.
+func TitleHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldTitle, v))
+}
+
+// TitleHasSuffix applies the HasSuffix predicate on the "title" field.
+func TitleHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldTitle, v))
+}
+
+// TitleIsNil applies the IsNil predicate on the "title" field.
+func TitleIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldTitle))
+}
+
+// TitleNotNil applies the NotNil predicate on the "title" field.
+func TitleNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldTitle))
+}
+
+// TitleEqualFold applies the EqualFold predicate on the "title" field.
+func TitleEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldTitle, v))
+}
+
+// TitleContainsFold applies the ContainsFold predicate on the "title" field.
+func TitleContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldTitle, v))
+}
+
+// SummaryEQ applies the EQ predicate on the "summary" field.
+func SummaryEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSummary, v))
+}
+
+// SummaryNEQ applies the NEQ predicate on the "summary" field.
+func SummaryNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldSummary, v))
+}
+
+// SummaryIn applies the In predicate on the "summary" field.
+func SummaryIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldSummary, vs...))
+}
+
+// SummaryNotIn applies the NotIn predicate on the "summary" field.
+func SummaryNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldSummary, vs...))
+}
+
+// SummaryGT applies the GT predicate on the "summary" field.
+func SummaryGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldSummary, v))
+}
+
+// SummaryGTE applies the GTE predicate on the "summary" field.
+func SummaryGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldSummary, v))
+}
+
+// SummaryLT applies the LT predicate on the "summary" field.
+func SummaryLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldSummary, v))
+}
+
+// SummaryLTE applies the LTE predicate on the "summary" field.
+func SummaryLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldSummary, v))
+}
+
+// SummaryContains applies the Contains predicate on the "summary" field.
+func SummaryContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldSummary, v))
+}
+
+// SummaryHasPrefix applies the HasPrefix predicate on the "summary" field.
+func SummaryHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldSummary, v))
+}
+
+// SummaryHasSuffix applies the HasSuffix predicate on the "summary" field.
+func SummaryHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldSummary, v))
+}
+
+// SummaryIsNil applies the IsNil predicate on the "summary" field.
+func SummaryIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldSummary))
+}
+
+// SummaryNotNil applies the NotNil predicate on the "summary" field.
+func SummaryNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldSummary))
+}
+
+// SummaryEqualFold applies the EqualFold predicate on the "summary" field.
+func SummaryEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldSummary, v))
+}
+
+// SummaryContainsFold applies the ContainsFold predicate on the "summary" field.
+func SummaryContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldSummary, v))
+}
+
+// OutcomeEQ applies the EQ predicate on the "outcome" field.
+func OutcomeEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldOutcome, v))
+}
+
+// OutcomeNEQ applies the NEQ predicate on the "outcome" field.
+func OutcomeNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldOutcome, v))
+}
+
+// OutcomeIn applies the In predicate on the "outcome" field.
+func OutcomeIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldOutcome, vs...))
+}
+
+// OutcomeNotIn applies the NotIn predicate on the "outcome" field.
+func OutcomeNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldOutcome, vs...))
+}
+
+// OutcomeGT applies the GT predicate on the "outcome" field.
+func OutcomeGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldOutcome, v))
+}
+
+// OutcomeGTE applies the GTE predicate on the "outcome" field.
+func OutcomeGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldOutcome, v))
+}
+
+// OutcomeLT applies the LT predicate on the "outcome" field.
+func OutcomeLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldOutcome, v))
+}
+
+// OutcomeLTE applies the LTE predicate on the "outcome" field.
+func OutcomeLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldOutcome, v))
+}
+
+// OutcomeContains applies the Contains predicate on the "outcome" field.
+func OutcomeContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldOutcome, v))
+}
+
+// OutcomeHasPrefix applies the HasPrefix predicate on the "outcome" field.
+func OutcomeHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldOutcome, v))
+}
+
+// OutcomeHasSuffix applies the HasSuffix predicate on the "outcome" field.
+func OutcomeHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldOutcome, v))
+}
+
+// OutcomeIsNil applies the IsNil predicate on the "outcome" field.
+func OutcomeIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldOutcome))
+}
+
+// OutcomeNotNil applies the NotNil predicate on the "outcome" field.
+func OutcomeNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldOutcome))
+}
+
+// OutcomeEqualFold applies the EqualFold predicate on the "outcome" field.
+func OutcomeEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldOutcome, v))
+}
+
+// OutcomeContainsFold applies the ContainsFold predicate on the "outcome" field.
+func OutcomeContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldOutcome, v))
+}
+
+// SummarizedAtEQ applies the EQ predicate on the "summarized_at" field.
+func SummarizedAtEQ(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldSummarizedAt, v))
+}
+
+// SummarizedAtNEQ applies the NEQ predicate on the "summarized_at" field.
+func SummarizedAtNEQ(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldSummarizedAt, v))
+}
+
+// SummarizedAtIn applies the In predicate on the "summarized_at" field.
+func SummarizedAtIn(vs ...time.Time) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldSummarizedAt, vs...))
+}
+
+// SummarizedAtNotIn applies the NotIn predicate on the "summarized_at" field.
+func SummarizedAtNotIn(vs ...time.Time) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldSummarizedAt, vs...))
+}
+
+// SummarizedAtGT applies the GT predicate on the "summarized_at" field.
+func SummarizedAtGT(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldSummarizedAt, v))
+}
+
+// SummarizedAtGTE applies the GTE predicate on the "summarized_at" field.
+func SummarizedAtGTE(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldSummarizedAt, v))
+}
+
+// SummarizedAtLT applies the LT predicate on the "summarized_at" field.
+func SummarizedAtLT(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldSummarizedAt, v))
+}
+
+// SummarizedAtLTE applies the LTE predicate on the "summarized_at" field.
+func SummarizedAtLTE(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldSummarizedAt, v))
+}
+
+// SummarizedAtIsNil applies the IsNil predicate on the "summarized_at" field.
+func SummarizedAtIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldSummarizedAt))
+}
+
+// SummarizedAtNotNil applies the NotNil predicate on the "summarized_at" field.
+func SummarizedAtNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldSummarizedAt))
+}
+
+// EndedAtEQ applies the EQ predicate on the "ended_at" field.
+func EndedAtEQ(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldEndedAt, v))
+}
+
+// EndedAtNEQ applies the NEQ predicate on the "ended_at" field.
+func EndedAtNEQ(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldEndedAt, v))
+}
+
+// EndedAtIn applies the In predicate on the "ended_at" field.
+func EndedAtIn(vs ...time.Time) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldEndedAt, vs...))
+}
+
+// EndedAtNotIn applies the NotIn predicate on the "ended_at" field.
+func EndedAtNotIn(vs ...time.Time) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldEndedAt, vs...))
+}
+
+// EndedAtGT applies the GT predicate on the "ended_at" field.
+func EndedAtGT(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldEndedAt, v))
+}
+
+// EndedAtGTE applies the GTE predicate on the "ended_at" field.
+func EndedAtGTE(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldEndedAt, v))
+}
+
+// EndedAtLT applies the LT predicate on the "ended_at" field.
+func EndedAtLT(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldEndedAt, v))
+}
+
+// EndedAtLTE applies the LTE predicate on the "ended_at" field.
+func EndedAtLTE(v time.Time) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldEndedAt, v))
+}
+
+// EndedAtIsNil applies the IsNil predicate on the "ended_at" field.
+func EndedAtIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldEndedAt))
+}
+
+// EndedAtNotNil applies the NotNil predicate on the "ended_at" field.
+func EndedAtNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldEndedAt))
+}
+
+// EndReasonEQ applies the EQ predicate on the "end_reason" field.
+func EndReasonEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldEndReason, v))
+}
+
+// EndReasonNEQ applies the NEQ predicate on the "end_reason" field.
+func EndReasonNEQ(v string) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldEndReason, v))
+}
+
+// EndReasonIn applies the In predicate on the "end_reason" field.
+func EndReasonIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldEndReason, vs...))
+}
+
+// EndReasonNotIn applies the NotIn predicate on the "end_reason" field.
+func EndReasonNotIn(vs ...string) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldEndReason, vs...))
+}
+
+// EndReasonGT applies the GT predicate on the "end_reason" field.
+func EndReasonGT(v string) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldEndReason, v))
+}
+
+// EndReasonGTE applies the GTE predicate on the "end_reason" field.
+func EndReasonGTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldEndReason, v))
+}
+
+// EndReasonLT applies the LT predicate on the "end_reason" field.
+func EndReasonLT(v string) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldEndReason, v))
+}
+
+// EndReasonLTE applies the LTE predicate on the "end_reason" field.
+func EndReasonLTE(v string) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldEndReason, v))
+}
+
+// EndReasonContains applies the Contains predicate on the "end_reason" field.
+func EndReasonContains(v string) predicate.Node {
+	return predicate.Node(sql.FieldContains(FieldEndReason, v))
+}
+
+// EndReasonHasPrefix applies the HasPrefix predicate on the "end_reason" field.
+func EndReasonHasPrefix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasPrefix(FieldEndReason, v))
+}
+
+// EndReasonHasSuffix applies the HasSuffix predicate on the "end_reason" field.
+func EndReasonHasSuffix(v string) predicate.Node {
+	return predicate.Node(sql.FieldHasSuffix(FieldEndReason, v))
+}
+
+// EndReasonIsNil applies the IsNil predicate on the "end_reason" field.
+func EndReasonIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldEndReason))
+}
+
+// EndReasonNotNil applies the NotNil predicate on the "end_reason" field.
+func EndReasonNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldEndReason))
+}
+
+// EndReasonEqualFold applies the EqualFold predicate on the "end_reason" field.
+func EndReasonEqualFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldEqualFold(FieldEndReason, v))
+}
+
+// EndReasonContainsFold applies the ContainsFold predicate on the "end_reason" field.
+func EndReasonContainsFold(v string) predicate.Node {
+	return predicate.Node(sql.FieldContainsFold(FieldEndReason, v))
+}
+
+// TurnIndexEQ applies the EQ predicate on the "turn_index" field.
+func TurnIndexEQ(v int) predicate.Node {
+	return predicate.Node(sql.FieldEQ(FieldTurnIndex, v))
+}
+
+// TurnIndexNEQ applies the NEQ predicate on the "turn_index" field.
+func TurnIndexNEQ(v int) predicate.Node {
+	return predicate.Node(sql.FieldNEQ(FieldTurnIndex, v))
+}
+
+// TurnIndexIn applies the In predicate on the "turn_index" field.
+func TurnIndexIn(vs ...int) predicate.Node {
+	return predicate.Node(sql.FieldIn(FieldTurnIndex, vs...))
+}
+
+// TurnIndexNotIn applies the NotIn predicate on the "turn_index" field.
+func TurnIndexNotIn(vs ...int) predicate.Node {
+	return predicate.Node(sql.FieldNotIn(FieldTurnIndex, vs...))
+}
+
+// TurnIndexGT applies the GT predicate on the "turn_index" field.
+func TurnIndexGT(v int) predicate.Node {
+	return predicate.Node(sql.FieldGT(FieldTurnIndex, v))
+}
+
+// TurnIndexGTE applies the GTE predicate on the "turn_index" field.
+func TurnIndexGTE(v int) predicate.Node {
+	return predicate.Node(sql.FieldGTE(FieldTurnIndex, v))
+}
+
+// TurnIndexLT applies the LT predicate on the "turn_index" field.
+func TurnIndexLT(v int) predicate.Node {
+	return predicate.Node(sql.FieldLT(FieldTurnIndex, v))
+}
+
+// TurnIndexLTE applies the LTE predicate on the "turn_index" field.
+func TurnIndexLTE(v int) predicate.Node {
+	return predicate.Node(sql.FieldLTE(FieldTurnIndex, v))
+}
+
+// TurnIndexIsNil applies the IsNil predicate on the "turn_index" field.
+func TurnIndexIsNil() predicate.Node {
+	return predicate.Node(sql.FieldIsNull(FieldTurnIndex))
+}
+
+// TurnIndexNotNil applies the NotNil predicate on the "turn_index" field.
+func TurnIndexNotNil() predicate.Node {
+	return predicate.Node(sql.FieldNotNull(FieldTurnIndex))
 }
 
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
@@ -10,6 +10,7 @@ import (
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/llm"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
 )
 
@@ -116,6 +117,20 @@ func (_c *NodeCreate) SetNillableAgentName(v *string) *NodeCreate {
 	return _c
 }
 
+// SetSessionID sets the "session_id" field.
+func (_c *NodeCreate) SetSessionID(v string) *NodeCreate {
+	_c.mutation.SetSessionID(v)
+	return _c
+}
+
+// SetNillableSessionID sets the "session_id" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableSessionID(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetSessionID(*v)
+	}
+	return _c
+}
+
 // SetStopReason sets the "stop_reason" field.
 func (_c *NodeCreate) SetStopReason(v string) *NodeCreate {
 	_c.mutation.SetStopReason(v)
@@ -130,6 +145,20 @@ func (_c *NodeCreate) SetNillableStopReason(v *string) *NodeCreate {
 	return _c
 }
 
+// SetToolNames sets the "tool_names" field.
+func (_c *NodeCreate) SetToolNames(v string) *NodeCreate {
+	_c.mutation.SetToolNames(v)
+	return _c
+}
+
+// SetNillableToolNames sets the "tool_names" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableToolNames(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetToolNames(*v)
+	}
+	return _c
+}
+
 // SetPromptTokens sets the "prompt_tokens" field.
 func (_c *NodeCreate) SetPromptTokens(v int) *NodeCreate {
 	_c.mutation.SetPromptTokens(v)
@@ -200,6 +229,20 @@ func (_c *NodeCreate) SetNillableCacheReadInputTokens(v *int) *NodeCreate {
 	return _c
 }
 
+// SetReasoningTokens sets the "reasoning_tokens" field.
+func (_c *NodeCreate) SetReasoningTokens(v int) *NodeCreate {
+	_c.mutation.SetReasoningTokens(v)
+	return _c
+}
+
+// SetNillableReasoningTokens sets the "reasoning_tokens" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableReasoningTokens(v *int) *NodeCreate {
+	if v != nil {
+		_c.SetReasoningTokens(*v)
+	}
+	return _c
+}
+
 // SetTotalDurationNs sets the "total_duration_ns" field.
 func (_c *NodeCreate) SetTotalDurationNs(v int64) *NodeCreate {
 	_c.mutation.SetTotalDurationNs(v)
@@ -228,6 +271,132 @@ func (_c *NodeCreate) SetNillablePromptDurationNs(v *int64) *NodeCreate {
 	return _c
 }
 
+// SetUsageEstimated sets the "usage_estimated" field.
+func (_c *NodeCreate) SetUsageEstimated(v bool) *NodeCreate {
+	_c.mutation.SetUsageEstimated(v)
+	return _c
+}
+
+// SetNillableUsageEstimated sets the "usage_estimated" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableUsageEstimated(v *bool) *NodeCreate {
+	if v != nil {
+		_c.SetUsageEstimated(*v)
+	}
+	return _c
+}
+
+// SetRequestStartedAt sets the "request_started_at" field.
+func (_c *NodeCreate) SetRequestStartedAt(v time.Time) *NodeCreate {
+	_c.mutation.SetRequestStartedAt(v)
+	return _c
+}
+
+// SetNillableRequestStartedAt sets the "request_started_at" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableRequestStartedAt(v *time.Time) *NodeCreate {
+	if v != nil {
+		_c.SetRequestStartedAt(*v)
+	}
+	return _c
+}
+
+// SetFirstChunkAt sets the "first_chunk_at" field.
+func (_c *NodeCreate) SetFirstChunkAt(v time.Time) *NodeCreate {
+	_c.mutation.SetFirstChunkAt(v)
+	return _c
+}
+
+// SetNillableFirstChunkAt sets the "first_chunk_at" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableFirstChunkAt(v *time.Time) *NodeCreate {
+	if v != nil {
+		_c.SetFirstChunkAt(*v)
+	}
+	return _c
+}
+
+// SetResponseCompletedAt sets the "response_completed_at" field.
+func (_c *NodeCreate) SetResponseCompletedAt(v time.Time) *NodeCreate {
+	_c.mutation.SetResponseCompletedAt(v)
+	return _c
+}
+
+// SetNillableResponseCompletedAt sets the "response_completed_at" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableResponseCompletedAt(v *time.Time) *NodeCreate {
+	if v != nil {
+		_c.SetResponseCompletedAt(*v)
+	}
+	return _c
+}
+
+// SetErrorStatus sets the "error_status" field.
+func (_c *NodeCreate) SetErrorStatus(v int) *NodeCreate {
+	_c.mutation.SetErrorStatus(v)
+	return _c
+}
+
+// SetNillableErrorStatus sets the "error_status" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableErrorStatus(v *int) *NodeCreate {
+	if v != nil {
+		_c.SetErrorStatus(*v)
+	}
+	return _c
+}
+
+// SetErrorType sets the "error_type" field.
+func (_c *NodeCreate) SetErrorType(v string) *NodeCreate {
+	_c.mutation.SetErrorType(v)
+	return _c
+}
+
+// SetNillableErrorType sets the "error_type" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableErrorType(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetErrorType(*v)
+	}
+	return _c
+}
+
+// SetErrorMessage sets the "error_message" field.
+func (_c *NodeCreate) SetErrorMessage(v string) *NodeCreate {
+	_c.mutation.SetErrorMessage(v)
+	return _c
+}
+
+// SetNillableErrorMessage sets the "error_message" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableErrorMessage(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetErrorMessage(*v)
+	}
+	return _c
+}
+
+// SetRetryAfterSeconds sets the "retry_after_seconds" field.
+func (_c *NodeCreate) SetRetryAfterSeconds(v int) *NodeCreate {
+	_c.mutation.SetRetryAfterSeconds(v)
+	return _c
+}
+
+// SetNillableRetryAfterSeconds sets the "retry_after_seconds" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableRetryAfterSeconds(v *int) *NodeCreate {
+	if v != nil {
+		_c.SetRetryAfterSeconds(*v)
+	}
+	return _c
+}
+
+// SetErrorAttempt sets the "error_attempt" field.
+func (_c *NodeCreate) SetErrorAttempt(v int) *NodeCreate {
+	_c.mutation.SetErrorAttempt(v)
+	return _c
+}
+
+// SetNillableErrorAttempt sets the "error_attempt" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableErrorAttempt(v *int) *NodeCreate {
+	if v != nil {
+		_c.SetErrorAttempt(*v)
+	}
+	return _c
+}
+
 // SetProject sets the "project" field.
 func (_c *NodeCreate) SetProject(v string) *NodeCreate {
 	_c.mutation.SetProject(v)
@@ -242,6 +411,220 @@ func (_c *NodeCreate) SetNillableProject(v *string) *NodeCreate {
 	return _c
 }
 
+// SetUser sets the "user" field.
+func (_c *NodeCreate) SetUser(v string) *NodeCreate {
+	_c.mutation.SetUser(v)
+	return _c
+}
+
+// SetNillableUser sets the "user" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableUser(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetUser(*v)
+	}
+	return _c
+}
+
+// SetTraceID sets the "trace_id" field.
+func (_c *NodeCreate) SetTraceID(v string) *NodeCreate {
+	_c.mutation.SetTraceID(v)
+	return _c
+}
+
+// SetNillableTraceID sets the "trace_id" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableTraceID(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetTraceID(*v)
+	}
+	return _c
+}
+
+// SetRunID sets the "run_id" field.
+func (_c *NodeCreate) SetRunID(v string) *NodeCreate {
+	_c.mutation.SetRunID(v)
+	return _c
+}
+
+// SetNillableRunID sets the "run_id" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableRunID(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetRunID(*v)
+	}
+	return _c
+}
+
+// SetAgentVersion sets the "agent_version" field.
+func (_c *NodeCreate) SetAgentVersion(v string) *NodeCreate {
+	_c.mutation.SetAgentVersion(v)
+	return _c
+}
+
+// SetNillableAgentVersion sets the "agent_version" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableAgentVersion(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetAgentVersion(*v)
+	}
+	return _c
+}
+
+// SetSystemPromptHash sets the "system_prompt_hash" field.
+func (_c *NodeCreate) SetSystemPromptHash(v string) *NodeCreate {
+	_c.mutation.SetSystemPromptHash(v)
+	return _c
+}
+
+// SetNillableSystemPromptHash sets the "system_prompt_hash" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableSystemPromptHash(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetSystemPromptHash(*v)
+	}
+	return _c
+}
+
+// SetPolicyViolations sets the "policy_violations" field.
+func (_c *NodeCreate) SetPolicyViolations(v []llm.PolicyViolation) *NodeCreate {
+	_c.mutation.SetPolicyViolations(v)
+	return _c
+}
+
+// SetSignerKey sets the "signer_key" field.
+func (_c *NodeCreate) SetSignerKey(v []byte) *NodeCreate {
+	_c.mutation.SetSignerKey(v)
+	return _c
+}
+
+// SetSignature sets the "signature" field.
+func (_c *NodeCreate) SetSignature(v []byte) *NodeCreate {
+	_c.mutation.SetSignature(v)
+	return _c
+}
+
+// SetSharedBy sets the "shared_by" field.
+func (_c *NodeCreate) SetSharedBy(v string) *NodeCreate {
+	_c.mutation.SetSharedBy(v)
+	return _c
+}
+
+// SetNillableSharedBy sets the "shared_by" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableSharedBy(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetSharedBy(*v)
+	}
+	return _c
+}
+
+// SetSupersededBy sets the "superseded_by" field.
+func (_c *NodeCreate) SetSupersededBy(v string) *NodeCreate {
+	_c.mutation.SetSupersededBy(v)
+	return _c
+}
+
+// SetNillableSupersededBy sets the "superseded_by" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableSupersededBy(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetSupersededBy(*v)
+	}
+	return _c
+}
+
+// SetTitle sets the "title" field.
+func (_c *NodeCreate) SetTitle(v string) *NodeCreate {
+	_c.mutation.SetTitle(v)
+	return _c
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableTitle(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetTitle(*v)
+	}
+	return _c
+}
+
+// SetSummary sets the "summary" field.
+func (_c *NodeCreate) SetSummary(v string) *NodeCreate {
+	_c.mutation.SetSummary(v)
+	return _c
+}
+
+// SetNillableSummary sets the "summary" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableSummary(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetSummary(*v)
+	}
+	return _c
+}
+
+// SetOutcome sets the "outcome" field.
+func (_c *NodeCreate) SetOutcome(v string) *NodeCreate {
+	_c.mutation.SetOutcome(v)
+	return _c
+}
+
+// SetNillableOutcome sets the "outcome" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableOutcome(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetOutcome(*v)
+	}
+	return _c
+}
+
+// SetSummarizedAt sets the "summarized_at" field.
+func (_c *NodeCreate) SetSummarizedAt(v time.Time) *NodeCreate {
+	_c.mutation.SetSummarizedAt(v)
+	return _c
+}
+
+// SetNillableSummarizedAt sets the "summarized_at" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableSummarizedAt(v *time.Time) *NodeCreate {
+	if v != nil {
+		_c.SetSummarizedAt(*v)
+	}
+	return _c
+}
+
+// SetEndedAt sets the "ended_at" field.
+func (_c *NodeCreate) SetEndedAt(v time.Time) *NodeCreate {
+	_c.mutation.SetEndedAt(v)
+	return _c
+}
+
+// SetNillableEndedAt sets the "ended_at" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableEndedAt(v *time.Time) *NodeCreate {
+	if v != nil {
+		_c.SetEndedAt(*v)
+	}
+	return _c
+}
+
+// SetEndReason sets the "end_reason" field.
+func (_c *NodeCreate) SetEndReason(v string) *NodeCreate {
+	_c.mutation.SetEndReason(v)
+	return _c
+}
+
+// SetNillableEndReason sets the "end_reason" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableEndReason(v *string) *NodeCreate {
+	if v != nil {
+		_c.SetEndReason(*v)
+	}
+	return _c
+}
+
+// SetTurnIndex sets the "turn_index" field.
+func (_c *NodeCreate) SetTurnIndex(v int) *NodeCreate {
+	_c.mutation.SetTurnIndex(v)
+	return _c
+}
+
+// SetNillableTurnIndex sets the "turn_index" field if the given value is not nil.
+func (_c *NodeCreate) SetNillableTurnIndex(v *int) *NodeCreate {
+	if v != nil {
+		_c.SetTurnIndex(*v)
+	}
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *NodeCreate) SetCreatedAt(v time.Time) *NodeCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -410,10 +793,18 @@ func (_c *NodeCreate) createSpec() (*Node, *sqlgraph.CreateSpec) {
 		_spec.SetField(node.FieldAgentName, field.TypeString, value)
 		_node.AgentName = value
 	}
+	if value, ok := _c.mutation.SessionID(); ok {
+		_spec.SetField(node.FieldSessionID, field.TypeString, value)
+		_node.SessionID = &value
+	}
 	if value, ok := _c.mutation.StopReason(); ok {
 		_spec.SetField(node.FieldStopReason, field.TypeString, value)
 		_node.StopReason = value
 	}
+	if value, ok := _c.mutation.ToolNames(); ok {
+		_spec.SetField(node.FieldToolNames, field.TypeString, value)
+		_node.ToolNames = &value
+	}
 	if value, ok := _c.mutation.PromptTokens(); ok {
 		_spec.SetField(node.FieldPromptTokens, field.TypeInt, value)
 		_node.PromptTokens = &value
@@ -434,6 +825,10 @@ func (_c *NodeCreate) createSpec() (*Node, *sqlgraph.CreateSpec) {
 		_spec.SetField(node.FieldCacheReadInputTokens, field.TypeInt, value)
 		_node.CacheReadInputTokens = &value
 	}
+	if value, ok := _c.mutation.ReasoningTokens(); ok {
+		_spec.SetField(node.FieldReasoningTokens, field.TypeInt, value)
+		_node.ReasoningTokens = &value
+	}
 	if value, ok := _c.mutation.TotalDurationNs(); ok {
 		_spec.SetField(node.FieldTotalDurationNs, field.TypeInt64, value)
 		_node.TotalDurationNs = &value
@@ -442,10 +837,114 @@ func (_c *NodeCreate) createSpec() (*Node, *sqlgraph.CreateSpec) {
 		_spec.SetField(node.FieldPromptDurationNs, field.TypeInt64, value)
 		_node.PromptDurationNs = &value
 	}
+	if value, ok := _c.mutation.UsageEstimated(); ok {
+		_spec.SetField(node.FieldUsageEstimated, field.TypeBool, value)
+		_node.UsageEstimated = value
+	}
+	if value, ok := _c.mutation.RequestStartedAt(); ok {
+		_spec.SetField(node.FieldRequestStartedAt, field.TypeTime, value)
+		_node.RequestStartedAt = &value
+	}
+	if value, ok := _c.mutation.FirstChunkAt(); ok {
+		_spec.SetField(node.FieldFirstChunkAt, field.TypeTime, value)
+		_node.FirstChunkAt = &value
+	}
+	if value, ok := _c.mutation.ResponseCompletedAt(); ok {
+		_spec.SetField(node.FieldResponseCompletedAt, field.TypeTime, value)
+		_node.ResponseCompletedAt = &value
+	}
+	if value, ok := _c.mutation.ErrorStatus(); ok {
+		_spec.SetField(node.FieldErrorStatus, field.TypeInt, value)
+		_node.ErrorStatus = &value
+	}
+	if value, ok := _c.mutation.ErrorType(); ok {
+		_spec.SetField(node.FieldErrorType, field.TypeString, value)
+		_node.ErrorType = &value
+	}
+	if value, ok := _c.mutation.ErrorMessage(); ok {
+		_spec.SetField(node.FieldErrorMessage, field.TypeString, value)
+		_node.ErrorMessage = &value
+	}
+	if value, ok := _c.mutation.RetryAfterSeconds(); ok {
+		_spec.SetField(node.FieldRetryAfterSeconds, field.TypeInt, value)
+		_node.RetryAfterSeconds = &value
+	}
+	if value, ok := _c.mutation.ErrorAttempt(); ok {
+		_spec.SetField(node.FieldErrorAttempt, field.TypeInt, value)
+		_node.ErrorAttempt = &value
+	}
 	if value, ok := _c.mutation.Project(); ok {
 		_spec.SetField(node.FieldProject, field.TypeString, value)
 		_node.Project = &value
 	}
+	if value, ok := _c.mutation.User(); ok {
+		_spec.SetField(node.FieldUser, field.TypeString, value)
+		_node.User = &value
+	}
+	if value, ok := _c.mutation.TraceID(); ok {
+		_spec.SetField(node.FieldTraceID, field.TypeString, value)
+		_node.TraceID = &value
+	}
+	if value, ok := _c.mutation.RunID(); ok {
+		_spec.SetField(node.FieldRunID, field.TypeString, value)
+		_node.RunID = &value
+	}
+	if value, ok := _c.mutation.AgentVersion(); ok {
+		_spec.SetField(node.FieldAgentVersion, field.TypeString, value)
+		_node.AgentVersion = &value
+	}
+	if value, ok := _c.mutation.SystemPromptHash(); ok {
+		_spec.SetField(node.FieldSystemPromptHash, field.TypeString, value)
+		_node.SystemPromptHash = &value
+	}
+	if value, ok := _c.mutation.PolicyViolations(); ok {
+		_spec.SetField(node.FieldPolicyViolations, field.TypeJSON, value)
+		_node.PolicyViolations = value
+	}
+	if value, ok := _c.mutation.SignerKey(); ok {
+		_spec.SetField(node.FieldSignerKey, field.TypeBytes, value)
+		_node.SignerKey = &value
+	}
+	if value, ok := _c.mutation.Signature(); ok {
+		_spec.SetField(node.FieldSignature, field.TypeBytes, value)
+		_node.Signature = &value
+	}
+	if value, ok := _c.mutation.SharedBy(); ok {
+		_spec.SetField(node.FieldSharedBy, field.TypeString, value)
+		_node.SharedBy = &value
+	}
+	if value, ok := _c.mutation.SupersededBy(); ok {
+		_spec.SetField(node.FieldSupersededBy, field.TypeString, value)
+		_node.SupersededBy = &value
+	}
+	if value, ok := _c.mutation.Title(); ok {
+		_spec.SetField(node.FieldTitle, field.TypeString, value)
+		_node.Title = &value
+	}
+	if value, ok := _c.mutation.Summary(); ok {
+		_spec.SetField(node.FieldSummary, field.TypeString, value)
+		_node.Summary = &value
+	}
+	if value, ok := _c.mutation.Outcome(); ok {
+		_spec.SetField(node.FieldOutcome, field.TypeString, value)
+		_node.Outcome = &value
+	}
+	if value, ok := _c.mutation.SummarizedAt(); ok {
+		_spec.SetField(node.FieldSummarizedAt, field.TypeTime, value)
+		_node.SummarizedAt = &value
+	}
+	if value, ok := _c.mutation.EndedAt(); ok {
+		_spec.SetField(node.FieldEndedAt, field.TypeTime, value)
+		_node.EndedAt = &value
+	}
+	if value, ok := _c.mutation.EndReason(); ok {
+		_spec.SetField(node.FieldEndReason, field.TypeString, value)
+		_node.EndReason = &value
+	}
+	if value, ok := _c.mutation.TurnIndex(); ok {
+		_spec.SetField(node.FieldTurnIndex, field.TypeInt, value)
+		_node.TurnIndex = &value
+	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(node.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -6,11 +6,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/llm"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
 )
@@ -178,6 +180,26 @@ func (_u *NodeUpdate) ClearAgentName() *NodeUpdate {
 	return _u
 }
 
+// SetSessionID sets the "session_id" field.
+func (_u *NodeUpdate) SetSessionID(v string) *NodeUpdate {
+	_u.mutation.SetSessionID(v)
+	return _u
+}
+
+// SetNillableSessionID sets the "session_id" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableSessionID(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetSessionID(*v)
+	}
+	return _u
+}
+
+// ClearSessionID clears the value of the "session_id" field.
+func (_u *NodeUpdate) ClearSessionID() *NodeUpdate {
+	_u.mutation.ClearSessionID()
+	return _u
+}
+
 // SetStopReason sets the "stop_reason" field.
 func (_u *NodeUpdate) SetStopReason(v string) *NodeUpdate {
 	_u.mutation.SetStopReason(v)
@@ -198,6 +220,26 @@ func (_u *NodeUpdate) ClearStopReason() *NodeUpdate {
 	return _u
 }
 
+// SetToolNames sets the "tool_names" field.
+func (_u *NodeUpdate) SetToolNames(v string) *NodeUpdate {
+	_u.mutation.SetToolNames(v)
+	return _u
+}
+
+// SetNillableToolNames sets the "tool_names" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableToolNames(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetToolNames(*v)
+	}
+	return _u
+}
+
+// ClearToolNames clears the value of the "tool_names" field.
+func (_u *NodeUpdate) ClearToolNames() *NodeUpdate {
+	_u.mutation.ClearToolNames()
+	return _u
+}
+
 // SetPromptTokens sets the "prompt_tokens" field.
 func (_u *NodeUpdate) SetPromptTokens(v int) *NodeUpdate {
 	_u.mutation.ResetPromptTokens()
@@ -333,6 +375,33 @@ func (_u *NodeUpdate) ClearCacheReadInputTokens() *NodeUpdate {
 	return _u
 }
 
+// SetReasoningTokens sets the "reasoning_tokens" field.
+func (_u *NodeUpdate) SetReasoningTokens(v int) *NodeUpdate {
+	_u.mutation.ResetReasoningTokens()
+	_u.mutation.SetReasoningTokens(v)
+	return _u
+}
+
+// SetNillableReasoningTokens sets the "reasoning_tokens" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableReasoningTokens(v *int) *NodeUpdate {
+	if v != nil {
+		_u.SetReasoningTokens(*v)
+	}
+	return _u
+}
+
+// AddReasoningTokens adds value to the "reasoning_tokens" field.
+func (_u *NodeUpdate) AddReasoningTokens(v int) *NodeUpdate {
+	_u.mutation.AddReasoningTokens(v)
+	return _u
+}
+
+// ClearReasoningTokens clears the value of the "reasoning_tokens" field.
+func (_u *NodeUpdate) ClearReasoningTokens() *NodeUpdate {
+	_u.mutation.ClearReasoningTokens()
+	return _u
+}
+
 // SetTotalDurationNs sets the "total_duration_ns" field.
 func (_u *NodeUpdate) SetTotalDurationNs(v int64) *NodeUpdate {
 	_u.mutation.ResetTotalDurationNs()
@@ -387,6 +456,207 @@ func (_u *NodeUpdate) ClearPromptDurationNs() *NodeUpdate {
 	return _u
 }
 
+// SetUsageEstimated sets the "usage_estimated" field.
+func (_u *NodeUpdate) SetUsageEstimated(v bool) *NodeUpdate {
+	_u.mutation.SetUsageEstimated(v)
+	return _u
+}
+
+// SetNillableUsageEstimated sets the "usage_estimated" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableUsageEstimated(v *bool) *NodeUpdate {
+	if v != nil {
+		_u.SetUsageEstimated(*v)
+	}
+	return _u
+}
+
+// ClearUsageEstimated clears the value of the "usage_estimated" field.
+func (_u *NodeUpdate) ClearUsageEstimated() *NodeUpdate {
+	_u.mutation.ClearUsageEstimated()
+	return _u
+}
+
+// SetRequestStartedAt sets the "request_started_at" field.
+func (_u *NodeUpdate) SetRequestStartedAt(v time.Time) *NodeUpdate {
+	_u.mutation.SetRequestStartedAt(v)
+	return _u
+}
+
+// SetNillableRequestStartedAt sets the "request_started_at" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableRequestStartedAt(v *time.Time) *NodeUpdate {
+	if v != nil {
+		_u.SetRequestStartedAt(*v)
+	}
+	return _u
+}
+
+// ClearRequestStartedAt clears the value of the "request_started_at" field.
+func (_u *NodeUpdate) ClearRequestStartedAt() *NodeUpdate {
+	_u.mutation.ClearRequestStartedAt()
+	return _u
+}
+
+// SetFirstChunkAt sets the "first_chunk_at" field.
+func (_u *NodeUpdate) SetFirstChunkAt(v time.Time) *NodeUpdate {
+	_u.mutation.SetFirstChunkAt(v)
+	return _u
+}
+
+// SetNillableFirstChunkAt sets the "first_chunk_at" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableFirstChunkAt(v *time.Time) *NodeUpdate {
+	if v != nil {
+		_u.SetFirstChunkAt(*v)
+	}
+	return _u
+}
+
+// ClearFirstChunkAt clears the value of the "first_chunk_at" field.
+func (_u *NodeUpdate) ClearFirstChunkAt() *NodeUpdate {
+	_u.mutation.ClearFirstChunkAt()
+	return _u
+}
+
+// SetResponseCompletedAt sets the "response_completed_at" field.
+func (_u *NodeUpdate) SetResponseCompletedAt(v time.Time) *NodeUpdate {
+	_u.mutation.SetResponseCompletedAt(v)
+	return _u
+}
+
+// SetNillableResponseCompletedAt sets the "response_completed_at" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableResponseCompletedAt(v *time.Time) *NodeUpdate {
+	if v != nil {
+		_u.SetResponseCompletedAt(*v)
+	}
+	return _u
+}
+
+// ClearResponseCompletedAt clears the value of the "response_completed_at" field.
+func (_u *NodeUpdate) ClearResponseCompletedAt() *NodeUpdate {
+	_u.mutation.ClearResponseCompletedAt()
+	return _u
+}
+
+// SetErrorStatus sets the "error_status" field.
+func (_u *NodeUpdate) SetErrorStatus(v int) *NodeUpdate {
+	_u.mutation.ResetErrorStatus()
+	_u.mutation.SetErrorStatus(v)
+	return _u
+}
+
+// SetNillableErrorStatus sets the "error_status" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableErrorStatus(v *int) *NodeUpdate {
+	if v != nil {
+		_u.SetErrorStatus(*v)
+	}
+	return _u
+}
+
+// AddErrorStatus adds value to the "error_status" field.
+func (_u *NodeUpdate) AddErrorStatus(v int) *NodeUpdate {
+	_u.mutation.AddErrorStatus(v)
+	return _u
+}
+
+// ClearErrorStatus clears the value of the "error_status" field.
+func (_u *NodeUpdate) ClearErrorStatus() *NodeUpdate {
+	_u.mutation.ClearErrorStatus()
+	return _u
+}
+
+// SetErrorType sets the "error_type" field.
+func (_u *NodeUpdate) SetErrorType(v string) *NodeUpdate {
+	_u.mutation.SetErrorType(v)
+	return _u
+}
+
+// SetNillableErrorType sets the "error_type" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableErrorType(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetErrorType(*v)
+	}
+	return _u
+}
+
+// ClearErrorType clears the value of the "error_type" field.
+func (_u *NodeUpdate) ClearErrorType() *NodeUpdate {
+	_u.mutation.ClearErrorType()
+	return _u
+}
+
+// SetErrorMessage sets the "error_message" field.
+func (_u *NodeUpdate) SetErrorMessage(v string) *NodeUpdate {
+	_u.mutation.SetErrorMessage(v)
+	return _u
+}
+
+// SetNillableErrorMessage sets the "error_message" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableErrorMessage(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetErrorMessage(*v)
+	}
+	return _u
+}
+
+// ClearErrorMessage clears the value of the "error_message" field.
+func (_u *NodeUpdate) ClearErrorMessage() *NodeUpdate {
+	_u.mutation.ClearErrorMessage()
+	return _u
+}
+
+// SetRetryAfterSeconds sets the "retry_after_seconds" field.
+func (_u *NodeUpdate) SetRetryAfterSeconds(v int) *NodeUpdate {
+	_u.mutation.ResetRetryAfterSeconds()
+	_u.mutation.SetRetryAfterSeconds(v)
+	return _u
+}
+
+// SetNillableRetryAfterSeconds sets the "retry_after_seconds" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableRetryAfterSeconds(v *int) *NodeUpdate {
+	if v != nil {
+		_u.SetRetryAfterSeconds(*v)
+	}
+	return _u
+}
+
+// AddRetryAfterSeconds adds value to the "retry_after_seconds" field.
+func (_u *NodeUpdate) AddRetryAfterSeconds(v int) *NodeUpdate {
+	_u.mutation.AddRetryAfterSeconds(v)
+	return _u
+}
+
+// ClearRetryAfterSeconds clears the value of the "retry_after_seconds" field.
+func (_u *NodeUpdate) ClearRetryAfterSeconds() *NodeUpdate {
+	_u.mutation.ClearRetryAfterSeconds()
+	return _u
+}
+
+// SetErrorAttempt sets the "error_attempt" field.
+func (_u *NodeUpdate) SetErrorAttempt(v int) *NodeUpdate {
+	_u.mutation.ResetErrorAttempt()
+	_u.mutation.SetErrorAttempt(v)
+	return _u
+}
+
+// SetNillableErrorAttempt sets the "error_attempt" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableErrorAttempt(v *int) *NodeUpdate {
+	if v != nil {
+		_u.SetErrorAttempt(*v)
+	}
+	return _u
+}
+
+// AddErrorAttempt adds value to the "error_attempt" field.
+func (_u *NodeUpdate) AddErrorAttempt(v int) *NodeUpdate {
+	_u.mutation.AddErrorAttempt(v)
+	return _u
+}
+
+// ClearErrorAttempt clears the value of the "error_attempt" field.
+func (_u *NodeUpdate) ClearErrorAttempt() *NodeUpdate {
+	_u.mutation.ClearErrorAttempt()
+	return _u
+}
+
 // SetProject sets the "project" field.
 func (_u *NodeUpdate) SetProject(v string) *NodeUpdate {
 	_u.mutation.SetProject(v)
@@ -407,115 +677,444 @@ func (_u *NodeUpdate) ClearProject() *NodeUpdate {
 	return _u
 }
 
-// SetParentID sets the "parent" edge to the Node entity by ID.
-func (_u *NodeUpdate) SetParentID(id string) *NodeUpdate {
-	_u.mutation.SetParentID(id)
+// SetUser sets the "user" field.
+func (_u *NodeUpdate) SetUser(v string) *NodeUpdate {
+	_u.mutation.SetUser(v)
 	return _u
 }
 
-// SetNillableParentID sets the "parent" edge to the Node entity by ID if the given value is not nil.
-func (_u *NodeUpdate) SetNillableParentID(id *string) *NodeUpdate {
-	if id != nil {
-		_u = _u.SetParentID(*id)
+// SetNillableUser sets the "user" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableUser(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetUser(*v)
 	}
 	return _u
 }
 
-// SetParent sets the "parent" edge to the Node entity.
-func (_u *NodeUpdate) SetParent(v *Node) *NodeUpdate {
-	return _u.SetParentID(v.ID)
+// ClearUser clears the value of the "user" field.
+func (_u *NodeUpdate) ClearUser() *NodeUpdate {
+	_u.mutation.ClearUser()
+	return _u
 }
 
-// AddChildIDs adds the "children" edge to the Node entity by IDs.
-func (_u *NodeUpdate) AddChildIDs(ids ...string) *NodeUpdate {
-	_u.mutation.AddChildIDs(ids...)
+// SetTraceID sets the "trace_id" field.
+func (_u *NodeUpdate) SetTraceID(v string) *NodeUpdate {
+	_u.mutation.SetTraceID(v)
 	return _u
 }
 
-// AddChildren adds the "children" edges to the Node entity.
-func (_u *NodeUpdate) AddChildren(v ...*Node) *NodeUpdate {
-	ids := make([]string, len(v))
-	for i := range v {
-		ids[i] = v[i].ID
+// SetNillableTraceID sets the "trace_id" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableTraceID(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetTraceID(*v)
 	}
-	return _u.AddChildIDs(ids...)
+	return _u
 }
 
-// Mutation returns the NodeMutation object of the builder.
-func (_u *NodeUpdate) Mutation() *NodeMutation {
-	return _u.mutation
+// ClearTraceID clears the value of the "trace_id" field.
+func (_u *NodeUpdate) ClearTraceID() *NodeUpdate {
+	_u.mutation.ClearTraceID()
+	return _u
 }
 
-// ClearParent clears the "parent" edge to the Node entity.
-func (_u *NodeUpdate) ClearParent() *NodeUpdate {
-	_u.mutation.ClearParent()
+// SetRunID sets the "run_id" field.
+func (_u *NodeUpdate) SetRunID(v string) *NodeUpdate {
+	_u.mutation.SetRunID(v)
 	return _u
 }
 
-// ClearChildren clears all "children" edges to the Node entity.
-func (_u *NodeUpdate) ClearChildren() *NodeUpdate {
-	_u.mutation.ClearChildren()
+// SetNillableRunID sets the "run_id" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableRunID(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetRunID(*v)
+	}
 	return _u
 }
 
-// RemoveChildIDs removes the "children" edge to Node entities by IDs.
-func (_u *NodeUpdate) RemoveChildIDs(ids ...string) *NodeUpdate {
-	_u.mutation.RemoveChildIDs(ids...)
+// ClearRunID clears the value of the "run_id" field.
+func (_u *NodeUpdate) ClearRunID() *NodeUpdate {
+	_u.mutation.ClearRunID()
 	return _u
 }
 
-// RemoveChildren removes "children" edges to Node entities.
-func (_u *NodeUpdate) RemoveChildren(v ...*Node) *NodeUpdate {
-	ids := make([]string, len(v))
-	for i := range v {
-		ids[i] = v[i].ID
+// SetAgentVersion sets the "agent_version" field.
+func (_u *NodeUpdate) SetAgentVersion(v string) *NodeUpdate {
+	_u.mutation.SetAgentVersion(v)
+	return _u
+}
+
+// SetNillableAgentVersion sets the "agent_version" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableAgentVersion(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetAgentVersion(*v)
 	}
-	return _u.RemoveChildIDs(ids...)
+	return _u
 }
 
-// Save executes the query and returns the number of nodes affected by the update operation.
-func (_u *NodeUpdate) Save(ctx context.Context) (int, error) {
-	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+// ClearAgentVersion clears the value of the "agent_version" field.
+func (_u *NodeUpdate) ClearAgentVersion() *NodeUpdate {
+	_u.mutation.ClearAgentVersion()
+	return _u
 }
 
-// SaveX is like Save, but panics if an error occurs.
-func (_u *NodeUpdate) SaveX(ctx context.Context) int {
-	affected, err := _u.Save(ctx)
-	if err != nil {
-		panic(err)
+// SetSystemPromptHash sets the "system_prompt_hash" field.
+func (_u *NodeUpdate) SetSystemPromptHash(v string) *NodeUpdate {
+	_u.mutation.SetSystemPromptHash(v)
+	return _u
+}
+
+// SetNillableSystemPromptHash sets the "system_prompt_hash" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableSystemPromptHash(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetSystemPromptHash(*v)
 	}
-	return affected
+	return _u
 }
 
-// Exec executes the query.
-func (_u *NodeUpdate) Exec(ctx context.Context) error {
-	_, err := _u.Save(ctx)
-	return err
+// ClearSystemPromptHash clears the value of the "system_prompt_hash" field.
+func (_u *NodeUpdate) ClearSystemPromptHash() *NodeUpdate {
+	_u.mutation.ClearSystemPromptHash()
+	return _u
 }
 
-// ExecX is like Exec, but panics if an error occurs.
-func (_u *NodeUpdate) ExecX(ctx context.Context) {
-	if err := _u.Exec(ctx); err != nil {
-		panic(err)
-	}
+// SetPolicyViolations sets the "policy_violations" field.
+func (_u *NodeUpdate) SetPolicyViolations(v []llm.PolicyViolation) *NodeUpdate {
+	_u.mutation.SetPolicyViolations(v)
+	return _u
 }
 
-func (_u *NodeUpdate) sqlSave(ctx context.Context) (_node int, err error) {
-	_spec := sqlgraph.NewUpdateSpec(node.Table, node.Columns, sqlgraph.NewFieldSpec(node.FieldID, field.TypeString))
-	if ps := _u.mutation.predicates; len(ps) > 0 {
-		_spec.Predicate = func(selector *sql.Selector) {
-			for i := range ps {
-				ps[i](selector)
-			}
-		}
-	}
-	if value, ok := _u.mutation.Bucket(); ok {
-		_spec.SetField(node.FieldBucket, field.TypeJSON, value)
-	}
-	if _u.mutation.BucketCleared() {
-		_spec.ClearField(node.FieldBucket, field.TypeJSON)
+// AppendPolicyViolations appends value to the "policy_violations" field.
+func (_u *NodeUpdate) AppendPolicyViolations(v []llm.PolicyViolation) *NodeUpdate {
+	_u.mutation.AppendPolicyViolations(v)
+	return _u
+}
+
+// ClearPolicyViolations clears the value of the "policy_violations" field.
+func (_u *NodeUpdate) ClearPolicyViolations() *NodeUpdate {
+	_u.mutation.ClearPolicyViolations()
+	return _u
+}
+
+// SetSignerKey sets the "signer_key" field.
+func (_u *NodeUpdate) SetSignerKey(v []byte) *NodeUpdate {
+	_u.mutation.SetSignerKey(v)
+	return _u
+}
+
+// ClearSignerKey clears the value of the "signer_key" field.
+func (_u *NodeUpdate) ClearSignerKey() *NodeUpdate {
+	_u.mutation.ClearSignerKey()
+	return _u
+}
+
+// SetSignature sets the "signature" field.
+func (_u *NodeUpdate) SetSignature(v []byte) *NodeUpdate {
+	_u.mutation.SetSignature(v)
+	return _u
+}
+
+// ClearSignature clears the value of the "signature" field.
+func (_u *NodeUpdate) ClearSignature() *NodeUpdate {
+	_u.mutation.ClearSignature()
+	return _u
+}
+
+// SetSharedBy sets the "shared_by" field.
+func (_u *NodeUpdate) SetSharedBy(v string) *NodeUpdate {
+	_u.mutation.SetSharedBy(v)
+	return _u
+}
+
+// SetNillableSharedBy sets the "shared_by" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableSharedBy(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetSharedBy(*v)
 	}
-	if value, ok := _u.mutation.GetType(); ok {
+	return _u
+}
+
+// ClearSharedBy clears the value of the "shared_by" field.
+func (_u *NodeUpdate) ClearSharedBy() *NodeUpdate {
+	_u.mutation.ClearSharedBy()
+	return _u
+}
+
+// SetSupersededBy sets the "superseded_by" field.
+func (_u *NodeUpdate) SetSupersededBy(v string) *NodeUpdate {
+	_u.mutation.SetSupersededBy(v)
+	return _u
+}
+
+// SetNillableSupersededBy sets the "superseded_by" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableSupersededBy(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetSupersededBy(*v)
+	}
+	return _u
+}
+
+// ClearSupersededBy clears the value of the "superseded_by" field.
+func (_u *NodeUpdate) ClearSupersededBy() *NodeUpdate {
+	_u.mutation.ClearSupersededBy()
+	return _u
+}
+
+// SetTitle sets the "title" field.
+func (_u *NodeUpdate) SetTitle(v string) *NodeUpdate {
+	_u.mutation.SetTitle(v)
+	return _u
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableTitle(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetTitle(*v)
+	}
+	return _u
+}
+
+// ClearTitle clears the value of the "title" field.
+func (_u *NodeUpdate) ClearTitle() *NodeUpdate {
+	_u.mutation.ClearTitle()
+	return _u
+}
+
+// SetSummary sets the "summary" field.
+func (_u *NodeUpdate) SetSummary(v string) *NodeUpdate {
+	_u.mutation.SetSummary(v)
+	return _u
+}
+
+// SetNillableSummary sets the "summary" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableSummary(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetSummary(*v)
+	}
+	return _u
+}
+
+// ClearSummary clears the value of the "summary" field.
+func (_u *NodeUpdate) ClearSummary() *NodeUpdate {
+	_u.mutation.ClearSummary()
+	return _u
+}
+
+// SetOutcome sets the "outcome" field.
+func (_u *NodeUpdate) SetOutcome(v string) *NodeUpdate {
+	_u.mutation.SetOutcome(v)
+	return _u
+}
+
+// SetNillableOutcome sets the "outcome" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableOutcome(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetOutcome(*v)
+	}
+	return _u
+}
+
+// ClearOutcome clears the value of the "outcome" field.
+func (_u *NodeUpdate) ClearOutcome() *NodeUpdate {
+	_u.mutation.ClearOutcome()
+	return _u
+}
+
+// SetSummarizedAt sets the "summarized_at" field.
+func (_u *NodeUpdate) SetSummarizedAt(v time.Time) *NodeUpdate {
+	_u.mutation.SetSummarizedAt(v)
+	return _u
+}
+
+// SetNillableSummarizedAt sets the "summarized_at" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableSummarizedAt(v *time.Time) *NodeUpdate {
+	if v != nil {
+		_u.SetSummarizedAt(*v)
+	}
+	return _u
+}
+
+// ClearSummarizedAt clears the value of the "summarized_at" field.
+func (_u *NodeUpdate) ClearSummarizedAt() *NodeUpdate {
+	_u.mutation.ClearSummarizedAt()
+	return _u
+}
+
+// SetEndedAt sets the "ended_at" field.
+func (_u *NodeUpdate) SetEndedAt(v time.Time) *NodeUpdate {
+	_u.mutation.SetEndedAt(v)
+	return _u
+}
+
+// SetNillableEndedAt sets the "ended_at" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableEndedAt(v *time.Time) *NodeUpdate {
+	if v != nil {
+		_u.SetEndedAt(*v)
+	}
+	return _u
+}
+
+// ClearEndedAt clears the value of the "ended_at" field.
+func (_u *NodeUpdate) ClearEndedAt() *NodeUpdate {
+	_u.mutation.ClearEndedAt()
+	return _u
+}
+
+// SetEndReason sets the "end_reason" field.
+func (_u *NodeUpdate) SetEndReason(v string) *NodeUpdate {
+	_u.mutation.SetEndReason(v)
+	return _u
+}
+
+// SetNillableEndReason sets the "end_reason" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableEndReason(v *string) *NodeUpdate {
+	if v != nil {
+		_u.SetEndReason(*v)
+	}
+	return _u
+}
+
+// ClearEndReason clears the value of the "end_reason" field.
+func (_u *NodeUpdate) ClearEndReason() *NodeUpdate {
+	_u.mutation.ClearEndReason()
+	return _u
+}
+
+// SetTurnIndex sets the "turn_index" field.
+func (_u *NodeUpdate) SetTurnIndex(v int) *NodeUpdate {
+	_u.mutation.ResetTurnIndex()
+	_u.mutation.SetTurnIndex(v)
+	return _u
+}
+
+// SetNillableTurnIndex sets the "turn_index" field if the given value is not nil.
+func (_u *NodeUpdate) SetNillableTurnIndex(v *int) *NodeUpdate {
+	if v != nil {
+		_u.SetTurnIndex(*v)
+	}
+	return _u
+}
+
+// AddTurnIndex adds value to the "turn_index" field.
+func (_u *NodeUpdate) AddTurnIndex(v int) *NodeUpdate {
+	_u.mutation.AddTurnIndex(v)
+	return _u
+}
+
+// ClearTurnIndex clears the value of the "turn_index" field.
+func (_u *NodeUpdate) ClearTurnIndex() *NodeUpdate {
+	_u.mutation.ClearTurnIndex()
+	return _u
+}
+
+// SetParentID sets the "parent" edge to the Node entity by ID.
+func (_u *NodeUpdate) SetParentID(id string) *NodeUpdate {
+	_u.mutation.SetParentID(id)
+	return _u
+}
+
+// SetNillableParentID sets the "parent" edge to the Node entity by ID if the given value is not nil.
+func (_u *NodeUpdate) SetNillableParentID(id *string) *NodeUpdate {
+	if id != nil {
+		_u = _u.SetParentID(*id)
+	}
+	return _u
+}
+
+// SetParent sets the "parent" edge to the Node entity.
+func (_u *NodeUpdate) SetParent(v *Node) *NodeUpdate {
+	return _u.SetParentID(v.ID)
+}
+
+// AddChildIDs adds the "children" edge to the Node entity by IDs.
+func (_u *NodeUpdate) AddChildIDs(ids ...string) *NodeUpdate {
+	_u.mutation.AddChildIDs(ids...)
+	return _u
+}
+
+// AddChildren adds the "children" edges to the Node entity.
+func (_u *NodeUpdate) AddChildren(v ...*Node) *NodeUpdate {
+	ids := make([]string, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddChildIDs(ids...)
+}
+
+// Mutation returns the NodeMutation object of the builder.
+func (_u *NodeUpdate) Mutation() *NodeMutation {
+	return _u.mutation
+}
+
+// ClearParent clears the "parent" edge to the Node entity.
+func (_u *NodeUpdate) ClearParent() *NodeUpdate {
+	_u.mutation.ClearParent()
+	return _u
+}
+
+// ClearChildren clears all "children" edges to the Node entity.
+func (_u *NodeUpdate) ClearChildren() *NodeUpdate {
+	_u.mutation.ClearChildren()
+	return _u
+}
+
+// RemoveChildIDs removes the "children" edge to Node entities by IDs.
+func (_u *NodeUpdate) RemoveChildIDs(ids ...string) *NodeUpdate {
+	_u.mutation.RemoveChildIDs(ids...)
+	return _u
+}
+
+// RemoveChildren removes "children" edges to Node entities.
+func (_u *NodeUpdate) RemoveChildren(v ...*Node) *NodeUpdate {
+	ids := make([]string, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveChildIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *NodeUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *NodeUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *NodeUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *NodeUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *NodeUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(node.Table, node.Columns, sqlgraph.NewFieldSpec(node.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Bucket(); ok {
+		_spec.SetField(node.FieldBucket, field.TypeJSON, value)
+	}
+	if _u.mutation.BucketCleared() {
+		_spec.ClearField(node.FieldBucket, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.GetType(); ok {
 		_spec.SetField(node.FieldType, field.TypeString, value)
 	}
 	if _u.mutation.TypeCleared() {
@@ -556,12 +1155,24 @@ func (_u *NodeUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.AgentNameCleared() {
 		_spec.ClearField(node.FieldAgentName, field.TypeString)
 	}
+	if value, ok := _u.mutation.SessionID(); ok {
+		_spec.SetField(node.FieldSessionID, field.TypeString, value)
+	}
+	if _u.mutation.SessionIDCleared() {
+		_spec.ClearField(node.FieldSessionID, field.TypeString)
+	}
 	if value, ok := _u.mutation.StopReason(); ok {
 		_spec.SetField(node.FieldStopReason, field.TypeString, value)
 	}
 	if _u.mutation.StopReasonCleared() {
 		_spec.ClearField(node.FieldStopReason, field.TypeString)
 	}
+	if value, ok := _u.mutation.ToolNames(); ok {
+		_spec.SetField(node.FieldToolNames, field.TypeString, value)
+	}
+	if _u.mutation.ToolNamesCleared() {
+		_spec.ClearField(node.FieldToolNames, field.TypeString)
+	}
 	if value, ok := _u.mutation.PromptTokens(); ok {
 		_spec.SetField(node.FieldPromptTokens, field.TypeInt, value)
 	}
@@ -607,6 +1218,15 @@ func (_u *NodeUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.CacheReadInputTokensCleared() {
 		_spec.ClearField(node.FieldCacheReadInputTokens, field.TypeInt)
 	}
+	if value, ok := _u.mutation.ReasoningTokens(); ok {
+		_spec.SetField(node.FieldReasoningTokens, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedReasoningTokens(); ok {
+		_spec.AddField(node.FieldReasoningTokens, field.TypeInt, value)
+	}
+	if _u.mutation.ReasoningTokensCleared() {
+		_spec.ClearField(node.FieldReasoningTokens, field.TypeInt)
+	}
 	if value, ok := _u.mutation.TotalDurationNs(); ok {
 		_spec.SetField(node.FieldTotalDurationNs, field.TypeInt64, value)
 	}
@@ -625,35 +1245,208 @@ func (_u *NodeUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.PromptDurationNsCleared() {
 		_spec.ClearField(node.FieldPromptDurationNs, field.TypeInt64)
 	}
+	if value, ok := _u.mutation.UsageEstimated(); ok {
+		_spec.SetField(node.FieldUsageEstimated, field.TypeBool, value)
+	}
+	if _u.mutation.UsageEstimatedCleared() {
+		_spec.ClearField(node.FieldUsageEstimated, field.TypeBool)
+	}
+	if value, ok := _u.mutation.RequestStartedAt(); ok {
+		_spec.SetField(node.FieldRequestStartedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RequestStartedAtCleared() {
+		_spec.ClearField(node.FieldRequestStartedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.FirstChunkAt(); ok {
+		_spec.SetField(node.FieldFirstChunkAt, field.TypeTime, value)
+	}
+	if _u.mutation.FirstChunkAtCleared() {
+		_spec.ClearField(node.FieldFirstChunkAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.ResponseCompletedAt(); ok {
+		_spec.SetField(node.FieldResponseCompletedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ResponseCompletedAtCleared() {
+		_spec.ClearField(node.FieldResponseCompletedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.ErrorStatus(); ok {
+		_spec.SetField(node.FieldErrorStatus, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedErrorStatus(); ok {
+		_spec.AddField(node.FieldErrorStatus, field.TypeInt, value)
+	}
+	if _u.mutation.ErrorStatusCleared() {
+		_spec.ClearField(node.FieldErrorStatus, field.TypeInt)
+	}
+	if value, ok := _u.mutation.ErrorType(); ok {
+		_spec.SetField(node.FieldErrorType, field.TypeString, value)
+	}
+	if _u.mutation.ErrorTypeCleared() {
+		_spec.ClearField(node.FieldErrorType, field.TypeString)
+	}
+	if value, ok := _u.mutation.ErrorMessage(); ok {
+		_spec.SetField(node.FieldErrorMessage, field.TypeString, value)
+	}
+	if _u.mutation.ErrorMessageCleared() {
+		_spec.ClearField(node.FieldErrorMessage, field.TypeString)
+	}
+	if value, ok := _u.mutation.RetryAfterSeconds(); ok {
+		_spec.SetField(node.FieldRetryAfterSeconds, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRetryAfterSeconds(); ok {
+		_spec.AddField(node.FieldRetryAfterSeconds, field.TypeInt, value)
+	}
+	if _u.mutation.RetryAfterSecondsCleared() {
+		_spec.ClearField(node.FieldRetryAfterSeconds, field.TypeInt)
+	}
+	if value, ok := _u.mutation.ErrorAttempt(); ok {
+		_spec.SetField(node.FieldErrorAttempt, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedErrorAttempt(); ok {
+		_spec.AddField(node.FieldErrorAttempt, field.TypeInt, value)
+	}
+	if _u.mutation.ErrorAttemptCleared() {
+		_spec.ClearField(node.FieldErrorAttempt, field.TypeInt)
+	}
 	if value, ok := _u.mutation.Project(); ok {
 		_spec.SetField(node.FieldProject, field.TypeString, value)
 	}
 	if _u.mutation.ProjectCleared() {
 		_spec.ClearField(node.FieldProject, field.TypeString)
 	}
-	if _u.mutation.ParentCleared() {
-		edge := &sqlgraph.EdgeSpec{
-			Rel:     sqlgraph.M2O,
-			Inverse: false,
-			Table:   node.ParentTable,
-			Columns: []string{node.ParentColumn},
-			Bidi:    true,
-			Target: &sqlgraph.EdgeTarget{
-				IDSpec: sqlgraph.NewFieldSpec(node.FieldID, field.TypeString),
-			},
-		}
-		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	if value, ok := _u.mutation.User(); ok {
+		_spec.SetField(node.FieldUser, field.TypeString, value)
 	}
-	if nodes := _u.mutation.ParentIDs(); len(nodes) > 0 {
-		edge := &sqlgraph.EdgeSpec{
-			Rel:     sqlgraph.M2O,
-			Inverse: false,
-			Table:   node.ParentTable,
-			Columns: []string{node.ParentColumn},
-			Bidi:    true,
-			Target: &sqlgraph.EdgeTarget{
-				IDSpec: sqlgraph.NewFieldSpec(node.FieldID, field.TypeString),
-			},
+	if _u.mutation.UserCleared() {
+		_spec.ClearField(node.FieldUser, field.TypeString)
+	}
+	if value, ok := _u.mutation.TraceID(); ok {
+		_spec.SetField(node.FieldTraceID, field.TypeString, value)
+	}
+	if _u.mutation.TraceIDCleared() {
+		_spec.ClearField(node.FieldTraceID, field.TypeString)
+	}
+	if value, ok := _u.mutation.RunID(); ok {
+		_spec.SetField(node.FieldRunID, field.TypeString, value)
+	}
+	if _u.mutation.RunIDCleared() {
+		_spec.ClearField(node.FieldRunID, field.TypeString)
+	}
+	if value, ok := _u.mutation.AgentVersion(); ok {
+		_spec.SetField(node.FieldAgentVersion, field.TypeString, value)
+	}
+	if _u.mutation.AgentVersionCleared() {
+		_spec.ClearField(node.FieldAgentVersion, field.TypeString)
+	}
+	if value, ok := _u.mutation.SystemPromptHash(); ok {
+		_spec.SetField(node.FieldSystemPromptHash, field.TypeString, value)
+	}
+	if _u.mutation.SystemPromptHashCleared() {
+		_spec.ClearField(node.FieldSystemPromptHash, field.TypeString)
+	}
+	if value, ok := _u.mutation.PolicyViolations(); ok {
+		_spec.SetField(node.FieldPolicyViolations, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedPolicyViolations(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, node.FieldPolicyViolations, value)
+		})
+	}
+	if _u.mutation.PolicyViolationsCleared() {
+		_spec.ClearField(node.FieldPolicyViolations, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.SignerKey(); ok {
+		_spec.SetField(node.FieldSignerKey, field.TypeBytes, value)
+	}
+	if _u.mutation.SignerKeyCleared() {
+		_spec.ClearField(node.FieldSignerKey, field.TypeBytes)
+	}
+	if value, ok := _u.mutation.Signature(); ok {
+		_spec.SetField(node.FieldSignature, field.TypeBytes, value)
+	}
+	if _u.mutation.SignatureCleared() {
+		_spec.ClearField(node.FieldSignature, field.TypeBytes)
+	}
+	if value, ok := _u.mutation.SharedBy(); ok {
+		_spec.SetField(node.FieldSharedBy, field.TypeString, value)
+	}
+	if _u.mutation.SharedByCleared() {
+		_spec.ClearField(node.FieldSharedBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.SupersededBy(); ok {
+		_spec.SetField(node.FieldSupersededBy, field.TypeString, value)
+	}
+	if _u.mutation.SupersededByCleared() {
+		_spec.ClearField(node.FieldSupersededBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.Title(); ok {
+		_spec.SetField(node.FieldTitle, field.TypeString, value)
+	}
+	if _u.mutation.TitleCleared() {
+		_spec.ClearField(node.FieldTitle, field.TypeString)
+	}
+	if value, ok := _u.mutation.Summary(); ok {
+		_spec.SetField(node.FieldSummary, field.TypeString, value)
+	}
+	if _u.mutation.SummaryCleared() {
+		_spec.ClearField(node.FieldSummary, field.TypeString)
+	}
+	if value, ok := _u.mutation.Outcome(); ok {
+		_spec.SetField(node.FieldOutcome, field.TypeString, value)
+	}
+	if _u.mutation.OutcomeCleared() {
+		_spec.ClearField(node.FieldOutcome, field.TypeString)
+	}
+	if value, ok := _u.mutation.SummarizedAt(); ok {
+		_spec.SetField(node.FieldSummarizedAt, field.TypeTime, value)
+	}
+	if _u.mutation.SummarizedAtCleared() {
+		_spec.ClearField(node.FieldSummarizedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.EndedAt(); ok {
+		_spec.SetField(node.FieldEndedAt, field.TypeTime, value)
+	}
+	if _u.mutation.EndedAtCleared() {
+		_spec.ClearField(node.FieldEndedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.EndReason(); ok {
+		_spec.SetField(node.FieldEndReason, field.TypeString, value)
+	}
+	if _u.mutation.EndReasonCleared() {
+		_spec.ClearField(node.FieldEndReason, field.TypeString)
+	}
+	if value, ok := _u.mutation.TurnIndex(); ok {
+		_spec.SetField(node.FieldTurnIndex, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTurnIndex(); ok {
+		_spec.AddField(node.FieldTurnIndex, field.TypeInt, value)
+	}
+	if _u.mutation.TurnIndexCleared() {
+		_spec.ClearField(node.FieldTurnIndex, field.TypeInt)
+	}
+	if _u.mutation.ParentCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   node.ParentTable,
+			Columns: []string{node.ParentColumn},
+			Bidi:    true,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(node.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ParentIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   node.ParentTable,
+			Columns: []string{node.ParentColumn},
+			Bidi:    true,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(node.FieldID, field.TypeString),
+			},
 		}
 		for _, k := range nodes {
 			edge.Target.Nodes = append(edge.Target.Nodes, k)
@@ -771,336 +1564,933 @@ func (_u *NodeUpdateOne) SetNillableType(v *string) *NodeUpdateOne {
 	return _u
 }
 
-// ClearType clears the value of the "type" field.
-func (_u *NodeUpdateOne) ClearType() *NodeUpdateOne {
-	_u.mutation.ClearType()
+// ClearType clears the value of the "type" field.
+func (_u *NodeUpdateOne) ClearType() *NodeUpdateOne {
+	_u.mutation.ClearType()
+	return _u
+}
+
+// SetRole sets the "role" field.
+func (_u *NodeUpdateOne) SetRole(v string) *NodeUpdateOne {
+	_u.mutation.SetRole(v)
+	return _u
+}
+
+// SetNillableRole sets the "role" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableRole(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetRole(*v)
+	}
+	return _u
+}
+
+// ClearRole clears the value of the "role" field.
+func (_u *NodeUpdateOne) ClearRole() *NodeUpdateOne {
+	_u.mutation.ClearRole()
+	return _u
+}
+
+// SetContent sets the "content" field.
+func (_u *NodeUpdateOne) SetContent(v []map[string]interface{}) *NodeUpdateOne {
+	_u.mutation.SetContent(v)
+	return _u
+}
+
+// AppendContent appends value to the "content" field.
+func (_u *NodeUpdateOne) AppendContent(v []map[string]interface{}) *NodeUpdateOne {
+	_u.mutation.AppendContent(v)
+	return _u
+}
+
+// ClearContent clears the value of the "content" field.
+func (_u *NodeUpdateOne) ClearContent() *NodeUpdateOne {
+	_u.mutation.ClearContent()
+	return _u
+}
+
+// SetModel sets the "model" field.
+func (_u *NodeUpdateOne) SetModel(v string) *NodeUpdateOne {
+	_u.mutation.SetModel(v)
+	return _u
+}
+
+// SetNillableModel sets the "model" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableModel(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetModel(*v)
+	}
+	return _u
+}
+
+// ClearModel clears the value of the "model" field.
+func (_u *NodeUpdateOne) ClearModel() *NodeUpdateOne {
+	_u.mutation.ClearModel()
+	return _u
+}
+
+// SetProvider sets the "provider" field.
+func (_u *NodeUpdateOne) SetProvider(v string) *NodeUpdateOne {
+	_u.mutation.SetProvider(v)
+	return _u
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableProvider(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetProvider(*v)
+	}
+	return _u
+}
+
+// ClearProvider clears the value of the "provider" field.
+func (_u *NodeUpdateOne) ClearProvider() *NodeUpdateOne {
+	_u.mutation.ClearProvider()
+	return _u
+}
+
+// SetAgentName sets the "agent_name" field.
+func (_u *NodeUpdateOne) SetAgentName(v string) *NodeUpdateOne {
+	_u.mutation.SetAgentName(v)
+	return _u
+}
+
+// SetNillableAgentName sets the "agent_name" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableAgentName(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetAgentName(*v)
+	}
+	return _u
+}
+
+// ClearAgentName clears the value of the "agent_name" field.
+func (_u *NodeUpdateOne) ClearAgentName() *NodeUpdateOne {
+	_u.mutation.ClearAgentName()
+	return _u
+}
+
+// SetSessionID sets the "session_id" field.
+func (_u *NodeUpdateOne) SetSessionID(v string) *NodeUpdateOne {
+	_u.mutation.SetSessionID(v)
+	return _u
+}
+
+// SetNillableSessionID sets the "session_id" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableSessionID(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetSessionID(*v)
+	}
+	return _u
+}
+
+// ClearSessionID clears the value of the "session_id" field.
+func (_u *NodeUpdateOne) ClearSessionID() *NodeUpdateOne {
+	_u.mutation.ClearSessionID()
+	return _u
+}
+
+// SetStopReason sets the "stop_reason" field.
+func (_u *NodeUpdateOne) SetStopReason(v string) *NodeUpdateOne {
+	_u.mutation.SetStopReason(v)
+	return _u
+}
+
+// SetNillableStopReason sets the "stop_reason" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableStopReason(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetStopReason(*v)
+	}
+	return _u
+}
+
+// ClearStopReason clears the value of the "stop_reason" field.
+func (_u *NodeUpdateOne) ClearStopReason() *NodeUpdateOne {
+	_u.mutation.ClearStopReason()
+	return _u
+}
+
+// SetToolNames sets the "tool_names" field.
+func (_u *NodeUpdateOne) SetToolNames(v string) *NodeUpdateOne {
+	_u.mutation.SetToolNames(v)
+	return _u
+}
+
+// SetNillableToolNames sets the "tool_names" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableToolNames(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetToolNames(*v)
+	}
+	return _u
+}
+
+// ClearToolNames clears the value of the "tool_names" field.
+func (_u *NodeUpdateOne) ClearToolNames() *NodeUpdateOne {
+	_u.mutation.ClearToolNames()
+	return _u
+}
+
+// SetPromptTokens sets the "prompt_tokens" field.
+func (_u *NodeUpdateOne) SetPromptTokens(v int) *NodeUpdateOne {
+	_u.mutation.ResetPromptTokens()
+	_u.mutation.SetPromptTokens(v)
+	return _u
+}
+
+// SetNillablePromptTokens sets the "prompt_tokens" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillablePromptTokens(v *int) *NodeUpdateOne {
+	if v != nil {
+		_u.SetPromptTokens(*v)
+	}
+	return _u
+}
+
+// AddPromptTokens adds value to the "prompt_tokens" field.
+func (_u *NodeUpdateOne) AddPromptTokens(v int) *NodeUpdateOne {
+	_u.mutation.AddPromptTokens(v)
+	return _u
+}
+
+// ClearPromptTokens clears the value of the "prompt_tokens" field.
+func (_u *NodeUpdateOne) ClearPromptTokens() *NodeUpdateOne {
+	_u.mutation.ClearPromptTokens()
+	return _u
+}
+
+// SetCompletionTokens sets the "completion_tokens" field.
+func (_u *NodeUpdateOne) SetCompletionTokens(v int) *NodeUpdateOne {
+	_u.mutation.ResetCompletionTokens()
+	_u.mutation.SetCompletionTokens(v)
+	return _u
+}
+
+// SetNillableCompletionTokens sets the "completion_tokens" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableCompletionTokens(v *int) *NodeUpdateOne {
+	if v != nil {
+		_u.SetCompletionTokens(*v)
+	}
+	return _u
+}
+
+// AddCompletionTokens adds value to the "completion_tokens" field.
+func (_u *NodeUpdateOne) AddCompletionTokens(v int) *NodeUpdateOne {
+	_u.mutation.AddCompletionTokens(v)
+	return _u
+}
+
+// ClearCompletionTokens clears the value of the "completion_tokens" field.
+func (_u *NodeUpdateOne) ClearCompletionTokens() *NodeUpdateOne {
+	_u.mutation.ClearCompletionTokens()
+	return _u
+}
+
+// SetTotalTokens sets the "total_tokens" field.
+func (_u *NodeUpdateOne) SetTotalTokens(v int) *NodeUpdateOne {
+	_u.mutation.ResetTotalTokens()
+	_u.mutation.SetTotalTokens(v)
+	return _u
+}
+
+// SetNillableTotalTokens sets the "total_tokens" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableTotalTokens(v *int) *NodeUpdateOne {
+	if v != nil {
+		_u.SetTotalTokens(*v)
+	}
+	return _u
+}
+
+// AddTotalTokens adds value to the "total_tokens" field.
+func (_u *NodeUpdateOne) AddTotalTokens(v int) *NodeUpdateOne {
+	_u.mutation.AddTotalTokens(v)
+	return _u
+}
+
+// ClearTotalTokens clears the value of the "total_tokens" field.
+func (_u *NodeUpdateOne) ClearTotalTokens() *NodeUpdateOne {
+	_u.mutation.ClearTotalTokens()
+	return _u
+}
+
+// SetCacheCreationInputTokens sets the "cache_creation_input_tokens" field.
+func (_u *NodeUpdateOne) SetCacheCreationInputTokens(v int) *NodeUpdateOne {
+	_u.mutation.ResetCacheCreationInputTokens()
+	_u.mutation.SetCacheCreationInputTokens(v)
+	return _u
+}
+
+// SetNillableCacheCreationInputTokens sets the "cache_creation_input_tokens" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableCacheCreationInputTokens(v *int) *NodeUpdateOne {
+	if v != nil {
+		_u.SetCacheCreationInputTokens(*v)
+	}
+	return _u
+}
+
+// AddCacheCreationInputTokens adds value to the "cache_creation_input_tokens" field.
+func (_u *NodeUpdateOne) AddCacheCreationInputTokens(v int) *NodeUpdateOne {
+	_u.mutation.AddCacheCreationInputTokens(v)
+	return _u
+}
+
+// ClearCacheCreationInputTokens clears the value of the "cache_creation_input_tokens" field.
+func (_u *NodeUpdateOne) ClearCacheCreationInputTokens() *NodeUpdateOne {
+	_u.mutation.ClearCacheCreationInputTokens()
+	return _u
+}
+
+// SetCacheReadInputTokens sets the "cache_read_input_tokens" field.
+func (_u *NodeUpdateOne) SetCacheReadInputTokens(v int) *NodeUpdateOne {
+	_u.mutation.ResetCacheReadInputTokens()
+	_u.mutation.SetCacheReadInputTokens(v)
+	return _u
+}
+
+// SetNillableCacheReadInputTokens sets the "cache_read_input_tokens" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableCacheReadInputTokens(v *int) *NodeUpdateOne {
+	if v != nil {
+		_u.SetCacheReadInputTokens(*v)
+	}
+	return _u
+}
+
+// AddCacheReadInputTokens adds value to the "cache_read_input_tokens" field.
+func (_u *NodeUpdateOne) AddCacheReadInputTokens(v int) *NodeUpdateOne {
+	_u.mutation.AddCacheReadInputTokens(v)
+	return _u
+}
+
+// ClearCacheReadInputTokens clears the value of the "cache_read_input_tokens" field.
+func (_u *NodeUpdateOne) ClearCacheReadInputTokens() *NodeUpdateOne {
+	_u.mutation.ClearCacheReadInputTokens()
+	return _u
+}
+
+// SetReasoningTokens sets the "reasoning_tokens" field.
+func (_u *NodeUpdateOne) SetReasoningTokens(v int) *NodeUpdateOne {
+	_u.mutation.ResetReasoningTokens()
+	_u.mutation.SetReasoningTokens(v)
+	return _u
+}
+
+// SetNillableReasoningTokens sets the "reasoning_tokens" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableReasoningTokens(v *int) *NodeUpdateOne {
+	if v != nil {
+		_u.SetReasoningTokens(*v)
+	}
+	return _u
+}
+
+// AddReasoningTokens adds value to the "reasoning_tokens" field.
+func (_u *NodeUpdateOne) AddReasoningTokens(v int) *NodeUpdateOne {
+	_u.mutation.AddReasoningTokens(v)
+	return _u
+}
+
+// ClearReasoningTokens clears the value of the "reasoning_tokens" field.
+func (_u *NodeUpdateOne) ClearReasoningTokens() *NodeUpdateOne {
+	_u.mutation.ClearReasoningTokens()
+	return _u
+}
+
+// SetTotalDurationNs sets the "total_duration_ns" field.
+func (_u *NodeUpdateOne) SetTotalDurationNs(v int64) *NodeUpdateOne {
+	_u.mutation.ResetTotalDurationNs()
+	_u.mutation.SetTotalDurationNs(v)
+	return _u
+}
+
+// SetNillableTotalDurationNs sets the "total_duration_ns" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableTotalDurationNs(v *int64) *NodeUpdateOne {
+	if v != nil {
+		_u.SetTotalDurationNs(*v)
+	}
+	return _u
+}
+
+// AddTotalDurationNs adds value to the "total_duration_ns" field.
+func (_u *NodeUpdateOne) AddTotalDurationNs(v int64) *NodeUpdateOne {
+	_u.mutation.AddTotalDurationNs(v)
+	return _u
+}
+
+// ClearTotalDurationNs clears the value of the "total_duration_ns" field.
+func (_u *NodeUpdateOne) ClearTotalDurationNs() *NodeUpdateOne {
+	_u.mutation.ClearTotalDurationNs()
+	return _u
+}
+
+// SetPromptDurationNs sets the "prompt_duration_ns" field.
+func (_u *NodeUpdateOne) SetPromptDurationNs(v int64) *NodeUpdateOne {
+	_u.mutation.ResetPromptDurationNs()
+	_u.mutation.SetPromptDurationNs(v)
+	return _u
+}
+
+// SetNillablePromptDurationNs sets the "prompt_duration_ns" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillablePromptDurationNs(v *int64) *NodeUpdateOne {
+	if v != nil {
+		_u.SetPromptDurationNs(*v)
+	}
+	return _u
+}
+
+// AddPromptDurationNs adds value to the "prompt_duration_ns" field.
+func (_u *NodeUpdateOne) AddPromptDurationNs(v int64) *NodeUpdateOne {
+	_u.mutation.AddPromptDurationNs(v)
+	return _u
+}
+
+// ClearPromptDurationNs clears the value of the "prompt_duration_ns" field.
+func (_u *NodeUpdateOne) ClearPromptDurationNs() *NodeUpdateOne {
+	_u.mutation.ClearPromptDurationNs()
+	return _u
+}
+
+// SetUsageEstimated sets the "usage_estimated" field.
+func (_u *NodeUpdateOne) SetUsageEstimated(v bool) *NodeUpdateOne {
+	_u.mutation.SetUsageEstimated(v)
+	return _u
+}
+
+// SetNillableUsageEstimated sets the "usage_estimated" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableUsageEstimated(v *bool) *NodeUpdateOne {
+	if v != nil {
+		_u.SetUsageEstimated(*v)
+	}
+	return _u
+}
+
+// ClearUsageEstimated clears the value of the "usage_estimated" field.
+func (_u *NodeUpdateOne) ClearUsageEstimated() *NodeUpdateOne {
+	_u.mutation.ClearUsageEstimated()
+	return _u
+}
+
+// SetRequestStartedAt sets the "request_started_at" field.
+func (_u *NodeUpdateOne) SetRequestStartedAt(v time.Time) *NodeUpdateOne {
+	_u.mutation.SetRequestStartedAt(v)
+	return _u
+}
+
+// SetNillableRequestStartedAt sets the "request_started_at" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableRequestStartedAt(v *time.Time) *NodeUpdateOne {
+	if v != nil {
+		_u.SetRequestStartedAt(*v)
+	}
+	return _u
+}
+
+// ClearRequestStartedAt clears the value of the "request_started_at" field.
+func (_u *NodeUpdateOne) ClearRequestStartedAt() *NodeUpdateOne {
+	_u.mutation.ClearRequestStartedAt()
+	return _u
+}
+
+// SetFirstChunkAt sets the "first_chunk_at" field.
+func (_u *NodeUpdateOne) SetFirstChunkAt(v time.Time) *NodeUpdateOne {
+	_u.mutation.SetFirstChunkAt(v)
+	return _u
+}
+
+// SetNillableFirstChunkAt sets the "first_chunk_at" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableFirstChunkAt(v *time.Time) *NodeUpdateOne {
+	if v != nil {
+		_u.SetFirstChunkAt(*v)
+	}
+	return _u
+}
+
+// ClearFirstChunkAt clears the value of the "first_chunk_at" field.
+func (_u *NodeUpdateOne) ClearFirstChunkAt() *NodeUpdateOne {
+	_u.mutation.ClearFirstChunkAt()
+	return _u
+}
+
+// SetResponseCompletedAt sets the "response_completed_at" field.
+func (_u *NodeUpdateOne) SetResponseCompletedAt(v time.Time) *NodeUpdateOne {
+	_u.mutation.SetResponseCompletedAt(v)
+	return _u
+}
+
+// SetNillableResponseCompletedAt sets the "response_completed_at" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableResponseCompletedAt(v *time.Time) *NodeUpdateOne {
+	if v != nil {
+		_u.SetResponseCompletedAt(*v)
+	}
+	return _u
+}
+
+// ClearResponseCompletedAt clears the value of the "response_completed_at" field.
+func (_u *NodeUpdateOne) ClearResponseCompletedAt() *NodeUpdateOne {
+	_u.mutation.ClearResponseCompletedAt()
+	return _u
+}
+
+// SetErrorStatus sets the "error_status" field.
+func (_u *NodeUpdateOne) SetErrorStatus(v int) *NodeUpdateOne {
+	_u.mutation.ResetErrorStatus()
+	_u.mutation.SetErrorStatus(v)
+	return _u
+}
+
+// SetNillableErrorStatus sets the "error_status" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableErrorStatus(v *int) *NodeUpdateOne {
+	if v != nil {
+		_u.SetErrorStatus(*v)
+	}
+	return _u
+}
+
+// AddErrorStatus adds value to the "error_status" field.
+func (_u *NodeUpdateOne) AddErrorStatus(v int) *NodeUpdateOne {
+	_u.mutation.AddErrorStatus(v)
+	return _u
+}
+
+// ClearErrorStatus clears the value of the "error_status" field.
+func (_u *NodeUpdateOne) ClearErrorStatus() *NodeUpdateOne {
+	_u.mutation.ClearErrorStatus()
+	return _u
+}
+
+// SetErrorType sets the "error_type" field.
+func (_u *NodeUpdateOne) SetErrorType(v string) *NodeUpdateOne {
+	_u.mutation.SetErrorType(v)
+	return _u
+}
+
+// SetNillableErrorType sets the "error_type" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableErrorType(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetErrorType(*v)
+	}
+	return _u
+}
+
+// ClearErrorType clears the value of the "error_type" field.
+func (_u *NodeUpdateOne) ClearErrorType() *NodeUpdateOne {
+	_u.mutation.ClearErrorType()
+	return _u
+}
+
+// SetErrorMessage sets the "error_message" field.
+func (_u *NodeUpdateOne) SetErrorMessage(v string) *NodeUpdateOne {
+	_u.mutation.SetErrorMessage(v)
+	return _u
+}
+
+// SetNillableErrorMessage sets the "error_message" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableErrorMessage(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetErrorMessage(*v)
+	}
+	return _u
+}
+
+// ClearErrorMessage clears the value of the "error_message" field.
+func (_u *NodeUpdateOne) ClearErrorMessage() *NodeUpdateOne {
+	_u.mutation.ClearErrorMessage()
+	return _u
+}
+
+// SetRetryAfterSeconds sets the "retry_after_seconds" field.
+func (_u *NodeUpdateOne) SetRetryAfterSeconds(v int) *NodeUpdateOne {
+	_u.mutation.ResetRetryAfterSeconds()
+	_u.mutation.SetRetryAfterSeconds(v)
+	return _u
+}
+
+// SetNillableRetryAfterSeconds sets the "retry_after_seconds" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableRetryAfterSeconds(v *int) *NodeUpdateOne {
+	if v != nil {
+		_u.SetRetryAfterSeconds(*v)
+	}
+	return _u
+}
+
+// AddRetryAfterSeconds adds value to the "retry_after_seconds" field.
+func (_u *NodeUpdateOne) AddRetryAfterSeconds(v int) *NodeUpdateOne {
+	_u.mutation.AddRetryAfterSeconds(v)
+	return _u
+}
+
+// ClearRetryAfterSeconds clears the value of the "retry_after_seconds" field.
+func (_u *NodeUpdateOne) ClearRetryAfterSeconds() *NodeUpdateOne {
+	_u.mutation.ClearRetryAfterSeconds()
+	return _u
+}
+
+// SetErrorAttempt sets the "error_attempt" field.
+func (_u *NodeUpdateOne) SetErrorAttempt(v int) *NodeUpdateOne {
+	_u.mutation.ResetErrorAttempt()
+	_u.mutation.SetErrorAttempt(v)
+	return _u
+}
+
+// SetNillableErrorAttempt sets the "error_attempt" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableErrorAttempt(v *int) *NodeUpdateOne {
+	if v != nil {
+		_u.SetErrorAttempt(*v)
+	}
+	return _u
+}
+
+// AddErrorAttempt adds value to the "error_attempt" field.
+func (_u *NodeUpdateOne) AddErrorAttempt(v int) *NodeUpdateOne {
+	_u.mutation.AddErrorAttempt(v)
+	return _u
+}
+
+// ClearErrorAttempt clears the value of the "error_attempt" field.
+func (_u *NodeUpdateOne) ClearErrorAttempt() *NodeUpdateOne {
+	_u.mutation.ClearErrorAttempt()
+	return _u
+}
+
+// SetProject sets the "project" field.
+func (_u *NodeUpdateOne) SetProject(v string) *NodeUpdateOne {
+	_u.mutation.SetProject(v)
+	return _u
+}
+
+// SetNillableProject sets the "project" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableProject(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetProject(*v)
+	}
+	return _u
+}
+
+// ClearProject clears the value of the "project" field.
+func (_u *NodeUpdateOne) ClearProject() *NodeUpdateOne {
+	_u.mutation.ClearProject()
 	return _u
 }
 
-// SetRole sets the "role" field.
-func (_u *NodeUpdateOne) SetRole(v string) *NodeUpdateOne {
-	_u.mutation.SetRole(v)
+// SetUser sets the "user" field.
+func (_u *NodeUpdateOne) SetUser(v string) *NodeUpdateOne {
+	_u.mutation.SetUser(v)
 	return _u
 }
 
-// SetNillableRole sets the "role" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillableRole(v *string) *NodeUpdateOne {
+// SetNillableUser sets the "user" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableUser(v *string) *NodeUpdateOne {
 	if v != nil {
-		_u.SetRole(*v)
+		_u.SetUser(*v)
 	}
 	return _u
 }
 
-// ClearRole clears the value of the "role" field.
-func (_u *NodeUpdateOne) ClearRole() *NodeUpdateOne {
-	_u.mutation.ClearRole()
+// ClearUser clears the value of the "user" field.
+func (_u *NodeUpdateOne) ClearUser() *NodeUpdateOne {
+	_u.mutation.ClearUser()
 	return _u
 }
 
-// SetContent sets the "content" field.
-func (_u *NodeUpdateOne) SetContent(v []map[string]interface{}) *NodeUpdateOne {
-	_u.mutation.SetContent(v)
+// SetTraceID sets the "trace_id" field.
+func (_u *NodeUpdateOne) SetTraceID(v string) *NodeUpdateOne {
+	_u.mutation.SetTraceID(v)
 	return _u
 }
 
-// AppendContent appends value to the "content" field.
-func (_u *NodeUpdateOne) AppendContent(v []map[string]interface{}) *NodeUpdateOne {
-	_u.mutation.AppendContent(v)
+// SetNillableTraceID sets the "trace_id" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableTraceID(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetTraceID(*v)
+	}
 	return _u
 }
 
-// ClearContent clears the value of the "content" field.
-func (_u *NodeUpdateOne) ClearContent() *NodeUpdateOne {
-	_u.mutation.ClearContent()
+// ClearTraceID clears the value of the "trace_id" field.
+func (_u *NodeUpdateOne) ClearTraceID() *NodeUpdateOne {
+	_u.mutation.ClearTraceID()
 	return _u
 }
 
-// SetModel sets the "model" field.
-func (_u *NodeUpdateOne) SetModel(v string) *NodeUpdateOne {
-	_u.mutation.SetModel(v)
+// SetRunID sets the "run_id" field.
+func (_u *NodeUpdateOne) SetRunID(v string) *NodeUpdateOne {
+	_u.mutation.SetRunID(v)
 	return _u
 }
 
-// SetNillableModel sets the "model" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillableModel(v *string) *NodeUpdateOne {
+// SetNillableRunID sets the "run_id" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableRunID(v *string) *NodeUpdateOne {
 	if v != nil {
-		_u.SetModel(*v)
+		_u.SetRunID(*v)
 	}
 	return _u
 }
 
-// ClearModel clears the value of the "model" field.
-func (_u *NodeUpdateOne) ClearModel() *NodeUpdateOne {
-	_u.mutation.ClearModel()
+// ClearRunID clears the value of the "run_id" field.
+func (_u *NodeUpdateOne) ClearRunID() *NodeUpdateOne {
+	_u.mutation.ClearRunID()
 	return _u
 }
 
-// SetProvider sets the "provider" field.
-func (_u *NodeUpdateOne) SetProvider(v string) *NodeUpdateOne {
-	_u.mutation.SetProvider(v)
+// SetAgentVersion sets the "agent_version" field.
+func (_u *NodeUpdateOne) SetAgentVersion(v string) *NodeUpdateOne {
+	_u.mutation.SetAgentVersion(v)
 	return _u
 }
 
-// SetNillableProvider sets the "provider" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillableProvider(v *string) *NodeUpdateOne {
+// SetNillableAgentVersion sets the "agent_version" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableAgentVersion(v *string) *NodeUpdateOne {
 	if v != nil {
-		_u.SetProvider(*v)
+		_u.SetAgentVersion(*v)
 	}
 	return _u
 }
 
-// ClearProvider clears the value of the "provider" field.
-func (_u *NodeUpdateOne) ClearProvider() *NodeUpdateOne {
-	_u.mutation.ClearProvider()
+// ClearAgentVersion clears the value of the "agent_version" field.
+func (_u *NodeUpdateOne) ClearAgentVersion() *NodeUpdateOne {
+	_u.mutation.ClearAgentVersion()
 	return _u
 }
 
-// SetAgentName sets the "agent_name" field.
-func (_u *NodeUpdateOne) SetAgentName(v string) *NodeUpdateOne {
-	_u.mutation.SetAgentName(v)
+// SetSystemPromptHash sets the "system_prompt_hash" field.
+func (_u *NodeUpdateOne) SetSystemPromptHash(v string) *NodeUpdateOne {
+	_u.mutation.SetSystemPromptHash(v)
 	return _u
 }
 
-// SetNillableAgentName sets the "agent_name" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillableAgentName(v *string) *NodeUpdateOne {
+// SetNillableSystemPromptHash sets the "system_prompt_hash" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableSystemPromptHash(v *string) *NodeUpdateOne {
 	if v != nil {
-		_u.SetAgentName(*v)
+		_u.SetSystemPromptHash(*v)
 	}
 	return _u
 }
 
-// ClearAgentName clears the value of the "agent_name" field.
-func (_u *NodeUpdateOne) ClearAgentName() *NodeUpdateOne {
-	_u.mutation.ClearAgentName()
+// ClearSystemPromptHash clears the value of the "system_prompt_hash" field.
+func (_u *NodeUpdateOne) ClearSystemPromptHash() *NodeUpdateOne {
+	_u.mutation.ClearSystemPromptHash()
 	return _u
 }
 
-// SetStopReason sets the "stop_reason" field.
-func (_u *NodeUpdateOne) SetStopReason(v string) *NodeUpdateOne {
-	_u.mutation.SetStopReason(v)
+// SetPolicyViolations sets the "policy_violations" field.
+func (_u *NodeUpdateOne) SetPolicyViolations(v []llm.PolicyViolation) *NodeUpdateOne {
+	_u.mutation.SetPolicyViolations(v)
 	return _u
 }
 
-// SetNillableStopReason sets the "stop_reason" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillableStopReason(v *string) *NodeUpdateOne {
-	if v != nil {
-		_u.SetStopReason(*v)
-	}
+// AppendPolicyViolations appends value to the "policy_violations" field.
+func (_u *NodeUpdateOne) AppendPolicyViolations(v []llm.PolicyViolation) *NodeUpdateOne {
+	_u.mutation.AppendPolicyViolations(v)
 	return _u
 }
 
-// ClearStopReason clears the value of the "stop_reason" field.
-func (_u *NodeUpdateOne) ClearStopReason() *NodeUpdateOne {
-	_u.mutation.ClearStopReason()
+// ClearPolicyViolations clears the value of the "policy_violations" field.
+func (_u *NodeUpdateOne) ClearPolicyViolations() *NodeUpdateOne {
+	_u.mutation.ClearPolicyViolations()
 	return _u
 }
 
-// SetPromptTokens sets the "prompt_tokens" field.
-func (_u *NodeUpdateOne) SetPromptTokens(v int) *NodeUpdateOne {
-	_u.mutation.ResetPromptTokens()
-	_u.mutation.SetPromptTokens(v)
+// SetSignerKey sets the "signer_key" field.
+func (_u *NodeUpdateOne) SetSignerKey(v []byte) *NodeUpdateOne {
+	_u.mutation.SetSignerKey(v)
 	return _u
 }
 
-// SetNillablePromptTokens sets the "prompt_tokens" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillablePromptTokens(v *int) *NodeUpdateOne {
-	if v != nil {
-		_u.SetPromptTokens(*v)
-	}
+// ClearSignerKey clears the value of the "signer_key" field.
+func (_u *NodeUpdateOne) ClearSignerKey() *NodeUpdateOne {
+	_u.mutation.ClearSignerKey()
 	return _u
 }
 
-// AddPromptTokens adds value to the "prompt_tokens" field.
-func (_u *NodeUpdateOne) AddPromptTokens(v int) *NodeUpdateOne {
-	_u.mutation.AddPromptTokens(v)
+// SetSignature sets the "signature" field.
+func (_u *NodeUpdateOne) SetSignature(v []byte) *NodeUpdateOne {
+	_u.mutation.SetSignature(v)
 	return _u
 }
 
-// ClearPromptTokens clears the value of the "prompt_tokens" field.
-func (_u *NodeUpdateOne) ClearPromptTokens() *NodeUpdateOne {
-	_u.mutation.ClearPromptTokens()
+// ClearSignature clears the value of the "signature" field.
+func (_u *NodeUpdateOne) ClearSignature() *NodeUpdateOne {
+	_u.mutation.ClearSignature()
 	return _u
 }
 
-// SetCompletionTokens sets the "completion_tokens" field.
-func (_u *NodeUpdateOne) SetCompletionTokens(v int) *NodeUpdateOne {
-	_u.mutation.ResetCompletionTokens()
-	_u.mutation.SetCompletionTokens(v)
+// SetSharedBy sets the "shared_by" field.
+func (_u *NodeUpdateOne) SetSharedBy(v string) *NodeUpdateOne {
+	_u.mutation.SetSharedBy(v)
 	return _u
 }
 
-// SetNillableCompletionTokens sets the "completion_tokens" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillableCompletionTokens(v *int) *NodeUpdateOne {
+// SetNillableSharedBy sets the "shared_by" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableSharedBy(v *string) *NodeUpdateOne {
 	if v != nil {
-		_u.SetCompletionTokens(*v)
+		_u.SetSharedBy(*v)
 	}
 	return _u
 }
 
-// AddCompletionTokens adds value to the "completion_tokens" field.
-func (_u *NodeUpdateOne) AddCompletionTokens(v int) *NodeUpdateOne {
-	_u.mutation.AddCompletionTokens(v)
+// ClearSharedBy clears the value of the "shared_by" field.
+func (_u *NodeUpdateOne) ClearSharedBy() *NodeUpdateOne {
+	_u.mutation.ClearSharedBy()
 	return _u
 }
 
-// ClearCompletionTokens clears the value of the "completion_tokens" field.
-func (_u *NodeUpdateOne) ClearCompletionTokens() *NodeUpdateOne {
-	_u.mutation.ClearCompletionTokens()
+// SetSupersededBy sets the "superseded_by" field.
+func (_u *NodeUpdateOne) SetSupersededBy(v string) *NodeUpdateOne {
+	_u.mutation.SetSupersededBy(v)
 	return _u
 }
 
-// SetTotalTokens sets the "total_tokens" field.
-func (_u *NodeUpdateOne) SetTotalTokens(v int) *NodeUpdateOne {
-	_u.mutation.ResetTotalTokens()
-	_u.mutation.SetTotalTokens(v)
+// SetNillableSupersededBy sets the "superseded_by" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableSupersededBy(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetSupersededBy(*v)
+	}
 	return _u
 }
 
-// SetNillableTotalTokens sets the "total_tokens" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillableTotalTokens(v *int) *NodeUpdateOne {
-	if v != nil {
-		_u.SetTotalTokens(*v)
-	}
+// ClearSupersededBy clears the value of the "superseded_by" field.
+func (_u *NodeUpdateOne) ClearSupersededBy() *NodeUpdateOne {
+	_u.mutation.ClearSupersededBy()
 	return _u
 }
 
-// AddTotalTokens adds value to the "total_tokens" field.
-func (_u *NodeUpdateOne) AddTotalTokens(v int) *NodeUpdateOne {
-	_u.mutation.AddTotalTokens(v)
+// SetTitle sets the "title" field.
+func (_u *NodeUpdateOne) SetTitle(v string) *NodeUpdateOne {
+	_u.mutation.SetTitle(v)
 	return _u
 }
 
-// ClearTotalTokens clears the value of the "total_tokens" field.
-func (_u *NodeUpdateOne) ClearTotalTokens() *NodeUpdateOne {
-	_u.mutation.ClearTotalTokens()
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableTitle(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetTitle(*v)
+	}
 	return _u
 }
 
-// SetCacheCreationInputTokens sets the "cache_creation_input_tokens" field.
-func (_u *NodeUpdateOne) SetCacheCreationInputTokens(v int) *NodeUpdateOne {
-	_u.mutation.ResetCacheCreationInputTokens()
-	_u.mutation.SetCacheCreationInputTokens(v)
+// ClearTitle clears the value of the "title" field.
+func (_u *NodeUpdateOne) ClearTitle() *NodeUpdateOne {
+	_u.mutation.ClearTitle()
 	return _u
 }
 
-// SetNillableCacheCreationInputTokens sets the "cache_creation_input_tokens" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillableCacheCreationInputTokens(v *int) *NodeUpdateOne {
-	if v != nil {
-		_u.SetCacheCreationInputTokens(*v)
-	}
+// SetSummary sets the "summary" field.
+func (_u *NodeUpdateOne) SetSummary(v string) *NodeUpdateOne {
+	_u.mutation.SetSummary(v)
 	return _u
 }
 
-// AddCacheCreationInputTokens adds value to the "cache_creation_input_tokens" field.
-func (_u *NodeUpdateOne) AddCacheCreationInputTokens(v int) *NodeUpdateOne {
-	_u.mutation.AddCacheCreationInputTokens(v)
+// SetNillableSummary sets the "summary" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableSummary(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetSummary(*v)
+	}
 	return _u
 }
 
-// ClearCacheCreationInputTokens clears the value of the "cache_creation_input_tokens" field.
-func (_u *NodeUpdateOne) ClearCacheCreationInputTokens() *NodeUpdateOne {
-	_u.mutation.ClearCacheCreationInputTokens()
+// ClearSummary clears the value of the "summary" field.
+func (_u *NodeUpdateOne) ClearSummary() *NodeUpdateOne {
+	_u.mutation.ClearSummary()
 	return _u
 }
 
-// SetCacheReadInputTokens sets the "cache_read_input_tokens" field.
-func (_u *NodeUpdateOne) SetCacheReadInputTokens(v int) *NodeUpdateOne {
-	_u.mutation.ResetCacheReadInputTokens()
-	_u.mutation.SetCacheReadInputTokens(v)
+// SetOutcome sets the "outcome" field.
+func (_u *NodeUpdateOne) SetOutcome(v string) *NodeUpdateOne {
+	_u.mutation.SetOutcome(v)
 	return _u
 }
 
-// SetNillableCacheReadInputTokens sets the "cache_read_input_tokens" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillableCacheReadInputTokens(v *int) *NodeUpdateOne {
+// SetNillableOutcome sets the "outcome" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableOutcome(v *string) *NodeUpdateOne {
 	if v != nil {
-		_u.SetCacheReadInputTokens(*v)
+		_u.SetOutcome(*v)
 	}
 	return _u
 }
 
-// AddCacheReadInputTokens adds value to the "cache_read_input_tokens" field.
-func (_u *NodeUpdateOne) AddCacheReadInputTokens(v int) *NodeUpdateOne {
-	_u.mutation.AddCacheReadInputTokens(v)
+// ClearOutcome clears the value of the "outcome" field.
+func (_u *NodeUpdateOne) ClearOutcome() *NodeUpdateOne {
+	_u.mutation.ClearOutcome()
 	return _u
 }
 
-// ClearCacheReadInputTokens clears the value of the "cache_read_input_tokens" field.
-func (_u *NodeUpdateOne) ClearCacheReadInputTokens() *NodeUpdateOne {
-	_u.mutation.ClearCacheReadInputTokens()
+// SetSummarizedAt sets the "summarized_at" field.
+func (_u *NodeUpdateOne) SetSummarizedAt(v time.Time) *NodeUpdateOne {
+	_u.mutation.SetSummarizedAt(v)
 	return _u
 }
 
-// SetTotalDurationNs sets the "total_duration_ns" field.
-func (_u *NodeUpdateOne) SetTotalDurationNs(v int64) *NodeUpdateOne {
-	_u.mutation.ResetTotalDurationNs()
-	_u.mutation.SetTotalDurationNs(v)
+// SetNillableSummarizedAt sets the "summarized_at" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableSummarizedAt(v *time.Time) *NodeUpdateOne {
+	if v != nil {
+		_u.SetSummarizedAt(*v)
+	}
 	return _u
 }
 
-// SetNillableTotalDurationNs sets the "total_duration_ns" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillableTotalDurationNs(v *int64) *NodeUpdateOne {
-	if v != nil {
-		_u.SetTotalDurationNs(*v)
-	}
+// ClearSummarizedAt clears the value of the "summarized_at" field.
+func (_u *NodeUpdateOne) ClearSummarizedAt() *NodeUpdateOne {
+	_u.mutation.ClearSummarizedAt()
 	return _u
 }
 
-// AddTotalDurationNs adds value to the "total_duration_ns" field.
-func (_u *NodeUpdateOne) AddTotalDurationNs(v int64) *NodeUpdateOne {
-	_u.mutation.AddTotalDurationNs(v)
+// SetEndedAt sets the "ended_at" field.
+func (_u *NodeUpdateOne) SetEndedAt(v time.Time) *NodeUpdateOne {
+	_u.mutation.SetEndedAt(v)
 	return _u
 }
 
-// ClearTotalDurationNs clears the value of the "total_duration_ns" field.
-func (_u *NodeUpdateOne) ClearTotalDurationNs() *NodeUpdateOne {
-	_u.mutation.ClearTotalDurationNs()
+// SetNillableEndedAt sets the "ended_at" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableEndedAt(v *time.Time) *NodeUpdateOne {
+	if v != nil {
+		_u.SetEndedAt(*v)
+	}
 	return _u
 }
 
-// SetPromptDurationNs sets the "prompt_duration_ns" field.
-func (_u *NodeUpdateOne) SetPromptDurationNs(v int64) *NodeUpdateOne {
-	_u.mutation.ResetPromptDurationNs()
-	_u.mutation.SetPromptDurationNs(v)
+// ClearEndedAt clears the value of the "ended_at" field.
+func (_u *NodeUpdateOne) ClearEndedAt() *NodeUpdateOne {
+	_u.mutation.ClearEndedAt()
 	return _u
 }
 
-// SetNillablePromptDurationNs sets the "prompt_duration_ns" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillablePromptDurationNs(v *int64) *NodeUpdateOne {
-	if v != nil {
-		_u.SetPromptDurationNs(*v)
-	}
+// SetEndReason sets the "end_reason" field.
+func (_u *NodeUpdateOne) SetEndReason(v string) *NodeUpdateOne {
+	_u.mutation.SetEndReason(v)
 	return _u
 }
 
-// AddPromptDurationNs adds value to the "prompt_duration_ns" field.
-func (_u *NodeUpdateOne) AddPromptDurationNs(v int64) *NodeUpdateOne {
-	_u.mutation.AddPromptDurationNs(v)
+// SetNillableEndReason sets the "end_reason" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableEndReason(v *string) *NodeUpdateOne {
+	if v != nil {
+		_u.SetEndReason(*v)
+	}
 	return _u
 }
 
-// ClearPromptDurationNs clears the value of the "prompt_duration_ns" field.
-func (_u *NodeUpdateOne) ClearPromptDurationNs() *NodeUpdateOne {
-	_u.mutation.ClearPromptDurationNs()
+// ClearEndReason clears the value of the "end_reason" field.
+func (_u *NodeUpdateOne) ClearEndReason() *NodeUpdateOne {
+	_u.mutation.ClearEndReason()
 	return _u
 }
 
-// SetProject sets the "project" field.
-func (_u *NodeUpdateOne) SetProject(v string) *NodeUpdateOne {
-	_u.mutation.SetProject(v)
+// SetTurnIndex sets the "turn_index" field.
+func (_u *NodeUpdateOne) SetTurnIndex(v int) *NodeUpdateOne {
+	_u.mutation.ResetTurnIndex()
+	_u.mutation.SetTurnIndex(v)
 	return _u
 }
 
-// SetNillableProject sets the "project" field if the given value is not nil.
-func (_u *NodeUpdateOne) SetNillableProject(v *string) *NodeUpdateOne {
+// SetNillableTurnIndex sets the "turn_index" field if the given value is not nil.
+func (_u *NodeUpdateOne) SetNillableTurnIndex(v *int) *NodeUpdateOne {
 	if v != nil {
-		_u.SetProject(*v)
+		_u.SetTurnIndex(*v)
 	}
 	return _u
 }
 
-// ClearProject clears the value of the "project" field.
-func (_u *NodeUpdateOne) ClearProject() *NodeUpdateOne {
-	_u.mutation.ClearProject()
+// AddTurnIndex adds value to the "turn_index" field.
+func (_u *NodeUpdateOne) AddTurnIndex(v int) *NodeUpdateOne {
+	_u.mutation.AddTurnIndex(v)
+	return _u
+}
+
+// ClearTurnIndex clears the value of the "turn_index" field.
+func (_u *NodeUpdateOne) ClearTurnIndex() *NodeUpdateOne {
+	_u.mutation.ClearTurnIndex()
 	return _u
 }
 
@@ -1283,12 +2673,24 @@ func (_u *NodeUpdateOne) sqlSave(ctx context.Context) (_node *Node, err error) {
 	if _u.mutation.AgentNameCleared() {
 		_spec.ClearField(node.FieldAgentName, field.TypeString)
 	}
+	if value, ok := _u.mutation.SessionID(); ok {
+		_spec.SetField(node.FieldSessionID, field.TypeString, value)
+	}
+	if _u.mutation.SessionIDCleared() {
+		_spec.ClearField(node.FieldSessionID, field.TypeString)
+	}
 	if value, ok := _u.mutation.StopReason(); ok {
 		_spec.SetField(node.FieldStopReason, field.TypeString, value)
 	}
 	if _u.mutation.StopReasonCleared() {
 		_spec.ClearField(node.FieldStopReason, field.TypeString)
 	}
+	if value, ok := _u.mutation.ToolNames(); ok {
+		_spec.SetField(node.FieldToolNames, field.TypeString, value)
+	}
+	if _u.mutation.ToolNamesCleared() {
+		_spec.ClearField(node.FieldToolNames, field.TypeString)
+	}
 	if value, ok := _u.mutation.PromptTokens(); ok {
 		_spec.SetField(node.FieldPromptTokens, field.TypeInt, value)
 	}
@@ -1334,6 +2736,15 @@ func (_u *NodeUpdateOne) sqlSave(ctx context.Context) (_node *Node, err error) {
 	if _u.mutation.CacheReadInputTokensCleared() {
 		_spec.ClearField(node.FieldCacheReadInputTokens, field.TypeInt)
 	}
+	if value, ok := _u.mutation.ReasoningTokens(); ok {
+		_spec.SetField(node.FieldReasoningTokens, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedReasoningTokens(); ok {
+		_spec.AddField(node.FieldReasoningTokens, field.TypeInt, value)
+	}
+	if _u.mutation.ReasoningTokensCleared() {
+		_spec.ClearField(node.FieldReasoningTokens, field.TypeInt)
+	}
 	if value, ok := _u.mutation.TotalDurationNs(); ok {
 		_spec.SetField(node.FieldTotalDurationNs, field.TypeInt64, value)
 	}
@@ -1352,12 +2763,185 @@ func (_u *NodeUpdateOne) sqlSave(ctx context.Context) (_node *Node, err error) {
 	if _u.mutation.PromptDurationNsCleared() {
 		_spec.ClearField(node.FieldPromptDurationNs, field.TypeInt64)
 	}
+	if value, ok := _u.mutation.UsageEstimated(); ok {
+		_spec.SetField(node.FieldUsageEstimated, field.TypeBool, value)
+	}
+	if _u.mutation.UsageEstimatedCleared() {
+		_spec.ClearField(node.FieldUsageEstimated, field.TypeBool)
+	}
+	if value, ok := _u.mutation.RequestStartedAt(); ok {
+		_spec.SetField(node.FieldRequestStartedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RequestStartedAtCleared() {
+		_spec.ClearField(node.FieldRequestStartedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.FirstChunkAt(); ok {
+		_spec.SetField(node.FieldFirstChunkAt, field.TypeTime, value)
+	}
+	if _u.mutation.FirstChunkAtCleared() {
+		_spec.ClearField(node.FieldFirstChunkAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.ResponseCompletedAt(); ok {
+		_spec.SetField(node.FieldResponseCompletedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ResponseCompletedAtCleared() {
+		_spec.ClearField(node.FieldResponseCompletedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.ErrorStatus(); ok {
+		_spec.SetField(node.FieldErrorStatus, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedErrorStatus(); ok {
+		_spec.AddField(node.FieldErrorStatus, field.TypeInt, value)
+	}
+	if _u.mutation.ErrorStatusCleared() {
+		_spec.ClearField(node.FieldErrorStatus, field.TypeInt)
+	}
+	if value, ok := _u.mutation.ErrorType(); ok {
+		_spec.SetField(node.FieldErrorType, field.TypeString, value)
+	}
+	if _u.mutation.ErrorTypeCleared() {
+		_spec.ClearField(node.FieldErrorType, field.TypeString)
+	}
+	if value, ok := _u.mutation.ErrorMessage(); ok {
+		_spec.SetField(node.FieldErrorMessage, field.TypeString, value)
+	}
+	if _u.mutation.ErrorMessageCleared() {
+		_spec.ClearField(node.FieldErrorMessage, field.TypeString)
+	}
+	if value, ok := _u.mutation.RetryAfterSeconds(); ok {
+		_spec.SetField(node.FieldRetryAfterSeconds, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRetryAfterSeconds(); ok {
+		_spec.AddField(node.FieldRetryAfterSeconds, field.TypeInt, value)
+	}
+	if _u.mutation.RetryAfterSecondsCleared() {
+		_spec.ClearField(node.FieldRetryAfterSeconds, field.TypeInt)
+	}
+	if value, ok := _u.mutation.ErrorAttempt(); ok {
+		_spec.SetField(node.FieldErrorAttempt, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedErrorAttempt(); ok {
+		_spec.AddField(node.FieldErrorAttempt, field.TypeInt, value)
+	}
+	if _u.mutation.ErrorAttemptCleared() {
+		_spec.ClearField(node.FieldErrorAttempt, field.TypeInt)
+	}
 	if value, ok := _u.mutation.Project(); ok {
 		_spec.SetField(node.FieldProject, field.TypeString, value)
 	}
 	if _u.mutation.ProjectCleared() {
 		_spec.ClearField(node.FieldProject, field.TypeString)
 	}
+	if value, ok := _u.mutation.User(); ok {
+		_spec.SetField(node.FieldUser, field.TypeString, value)
+	}
+	if _u.mutation.UserCleared() {
+		_spec.ClearField(node.FieldUser, field.TypeString)
+	}
+	if value, ok := _u.mutation.TraceID(); ok {
+		_spec.SetField(node.FieldTraceID, field.TypeString, value)
+	}
+	if _u.mutation.TraceIDCleared() {
+		_spec.ClearField(node.FieldTraceID, field.TypeString)
+	}
+	if value, ok := _u.mutation.RunID(); ok {
+		_spec.SetField(node.FieldRunID, field.TypeString, value)
+	}
+	if _u.mutation.RunIDCleared() {
+		_spec.ClearField(node.FieldRunID, field.TypeString)
+	}
+	if value, ok := _u.mutation.AgentVersion(); ok {
+		_spec.SetField(node.FieldAgentVersion, field.TypeString, value)
+	}
+	if _u.mutation.AgentVersionCleared() {
+		_spec.ClearField(node.FieldAgentVersion, field.TypeString)
+	}
+	if value, ok := _u.mutation.SystemPromptHash(); ok {
+		_spec.SetField(node.FieldSystemPromptHash, field.TypeString, value)
+	}
+	if _u.mutation.SystemPromptHashCleared() {
+		_spec.ClearField(node.FieldSystemPromptHash, field.TypeString)
+	}
+	if value, ok := _u.mutation.PolicyViolations(); ok {
+		_spec.SetField(node.FieldPolicyViolations, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedPolicyViolations(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, node.FieldPolicyViolations, value)
+		})
+	}
+	if _u.mutation.PolicyViolationsCleared() {
+		_spec.ClearField(node.FieldPolicyViolations, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.SignerKey(); ok {
+		_spec.SetField(node.FieldSignerKey, field.TypeBytes, value)
+	}
+	if _u.mutation.SignerKeyCleared() {
+		_spec.ClearField(node.FieldSignerKey, field.TypeBytes)
+	}
+	if value, ok := _u.mutation.Signature(); ok {
+		_spec.SetField(node.FieldSignature, field.TypeBytes, value)
+	}
+	if _u.mutation.SignatureCleared() {
+		_spec.ClearField(node.FieldSignature, field.TypeBytes)
+	}
+	if value, ok := _u.mutation.SharedBy(); ok {
+		_spec.SetField(node.FieldSharedBy, field.TypeString, value)
+	}
+	if _u.mutation.SharedByCleared() {
+		_spec.ClearField(node.FieldSharedBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.SupersededBy(); ok {
+		_spec.SetField(node.FieldSupersededBy, field.TypeString, value)
+	}
+	if _u.mutation.SupersededByCleared() {
+		_spec.ClearField(node.FieldSupersededBy, field.TypeString)
+	}
+	if value, ok := _u.mutation.Title(); ok {
+		_spec.SetField(node.FieldTitle, field.TypeString, value)
+	}
+	if _u.mutation.TitleCleared() {
+		_spec.ClearField(node.FieldTitle, field.TypeString)
+	}
+	if value, ok := _u.mutation.Summary(); ok {
+		_spec.SetField(node.FieldSummary, field.TypeString, value)
+	}
+	if _u.mutation.SummaryCleared() {
+		_spec.ClearField(node.FieldSummary, field.TypeString)
+	}
+	if value, ok := _u.mutation.Outcome(); ok {
+		_spec.SetField(node.FieldOutcome, field.TypeString, value)
+	}
+	if _u.mutation.OutcomeCleared() {
+		_spec.ClearField(node.FieldOutcome, field.TypeString)
+	}
+	if value, ok := _u.mutation.SummarizedAt(); ok {
+		_spec.SetField(node.FieldSummarizedAt, field.TypeTime, value)
+	}
+	if _u.mutation.SummarizedAtCleared() {
+		_spec.ClearField(node.FieldSummarizedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.EndedAt(); ok {
+		_spec.SetField(node.FieldEndedAt, field.TypeTime, value)
+	}
+	if _u.mutation.EndedAtCleared() {
+		_spec.ClearField(node.FieldEndedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.EndReason(); ok {
+		_spec.SetField(node.FieldEndReason, field.TypeString, value)
+	}
+	if _u.mutation.EndReasonCleared() {
+		_spec.ClearField(node.FieldEndReason, field.TypeString)
+	}
+	if value, ok := _u.mutation.TurnIndex(); ok {
+		_spec.SetField(node.FieldTurnIndex, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTurnIndex(); ok {
+		_spec.AddField(node.FieldTurnIndex, field.TypeInt, value)
+	}
+	if _u.mutation.TurnIndexCleared() {
+		_spec.ClearField(node.FieldTurnIndex, field.TypeInt)
+	}
 	if _u.mutation.ParentCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -6,8 +6,29 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// Block is the predicate function for block builders.
+type Block func(*sql.Selector)
+
+// DeadLetter is the predicate function for deadletter builders.
+type DeadLetter func(*sql.Selector)
+
 // Facet is the predicate function for facet builders.
 type Facet func(*sql.Selector)
 
 // Node is the predicate function for node builders.
 type Node func(*sql.Selector)
+
+// RawCapture is the predicate function for rawcapture builders.
+type RawCapture func(*sql.Selector)
+
+// Session is the predicate function for session builders.
+type Session func(*sql.Selector)
+
+// SessionTool is the predicate function for sessiontool builders.
+type SessionTool func(*sql.Selector)
+
+// SystemPrompt is the predicate function for systemprompt builders.
+type SystemPrompt func(*sql.Selector)
+
+// ToolDefinition is the predicate function for tooldefinition builders.
+type ToolDefinition func(*sql.Selector)
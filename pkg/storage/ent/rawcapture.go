@@ -0,0 +1,242 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/rawcapture"
+)
+
+// RawCapture is the model entity for the RawCapture schema.
+type RawCapture struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// NodeHash holds the value of the "node_hash" field.
+	NodeHash string `json:"node_hash,omitempty"`
+	// Provider holds the value of the "provider" field.
+	Provider string `json:"provider,omitempty"`
+	// AgentName holds the value of the "agent_name" field.
+	AgentName string `json:"agent_name,omitempty"`
+	// SessionID holds the value of the "session_id" field.
+	SessionID string `json:"session_id,omitempty"`
+	// RunID holds the value of the "run_id" field.
+	RunID string `json:"run_id,omitempty"`
+	// Project holds the value of the "project" field.
+	Project string `json:"project,omitempty"`
+	// User holds the value of the "user" field.
+	User string `json:"user,omitempty"`
+	// Path holds the value of the "path" field.
+	Path string `json:"path,omitempty"`
+	// Streamed holds the value of the "streamed" field.
+	Streamed bool `json:"streamed,omitempty"`
+	// Encrypted holds the value of the "encrypted" field.
+	Encrypted bool `json:"encrypted,omitempty"`
+	// Request holds the value of the "request" field.
+	Request []byte `json:"request,omitempty"`
+	// Response holds the value of the "response" field.
+	Response []byte `json:"response,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*RawCapture) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case rawcapture.FieldRequest, rawcapture.FieldResponse:
+			values[i] = new([]byte)
+		case rawcapture.FieldStreamed, rawcapture.FieldEncrypted:
+			values[i] = new(sql.NullBool)
+		case rawcapture.FieldID:
+			values[i] = new(sql.NullInt64)
+		case rawcapture.FieldNodeHash, rawcapture.FieldProvider, rawcapture.FieldAgentName, rawcapture.FieldSessionID, rawcapture.FieldRunID, rawcapture.FieldProject, rawcapture.FieldUser, rawcapture.FieldPath:
+			values[i] = new(sql.NullString)
+		case rawcapture.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the RawCapture fields.
+func (_m *RawCapture) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case rawcapture.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case rawcapture.FieldNodeHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field node_hash", values[i])
+			} else if value.Valid {
+				_m.NodeHash = value.String
+			}
+		case rawcapture.FieldProvider:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field provider", values[i])
+			} else if value.Valid {
+				_m.Provider = value.String
+			}
+		case rawcapture.FieldAgentName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field agent_name", values[i])
+			} else if value.Valid {
+				_m.AgentName = value.String
+			}
+		case rawcapture.FieldSessionID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field session_id", values[i])
+			} else if value.Valid {
+				_m.SessionID = value.String
+			}
+		case rawcapture.FieldRunID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field run_id", values[i])
+			} else if value.Valid {
+				_m.RunID = value.String
+			}
+		case rawcapture.FieldProject:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field project", values[i])
+			} else if value.Valid {
+				_m.Project = value.String
+			}
+		case rawcapture.FieldUser:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user", values[i])
+			} else if value.Valid {
+				_m.User = value.String
+			}
+		case rawcapture.FieldPath:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field path", values[i])
+			} else if value.Valid {
+				_m.Path = value.String
+			}
+		case rawcapture.FieldStreamed:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field streamed", values[i])
+			} else if value.Valid {
+				_m.Streamed = value.Bool
+			}
+		case rawcapture.FieldEncrypted:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field encrypted", values[i])
+			} else if value.Valid {
+				_m.Encrypted = value.Bool
+			}
+		case rawcapture.FieldRequest:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field request", values[i])
+			} else if value != nil {
+				_m.Request = *value
+			}
+		case rawcapture.FieldResponse:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field response", values[i])
+			} else if value != nil {
+				_m.Response = *value
+			}
+		case rawcapture.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the RawCapture.
+// This includes values selected through modifiers, order, etc.
+func (_m *RawCapture) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this RawCapture.
+// Note that you need to call RawCapture.Unwrap() before calling this method if this RawCapture
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *RawCapture) Update() *RawCaptureUpdateOne {
+	return NewRawCaptureClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the RawCapture entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *RawCapture) Unwrap() *RawCapture {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: RawCapture is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *RawCapture) String() string {
+	var builder strings.Builder
+	builder.WriteString("RawCapture(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("node_hash=")
+	builder.WriteString(_m.NodeHash)
+	builder.WriteString(", ")
+	builder.WriteString("provider=")
+	builder.WriteString(_m.Provider)
+	builder.WriteString(", ")
+	builder.WriteString("agent_name=")
+	builder.WriteString(_m.AgentName)
+	builder.WriteString(", ")
+	builder.WriteString("session_id=")
+	builder.WriteString(_m.SessionID)
+	builder.WriteString(", ")
+	builder.WriteString("run_id=")
+	builder.WriteString(_m.RunID)
+	builder.WriteString(", ")
+	builder.WriteString("project=")
+	builder.WriteString(_m.Project)
+	builder.WriteString(", ")
+	builder.WriteString("user=")
+	builder.WriteString(_m.User)
+	builder.WriteString(", ")
+	builder.WriteString("path=")
+	builder.WriteString(_m.Path)
+	builder.WriteString(", ")
+	builder.WriteString("streamed=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Streamed))
+	builder.WriteString(", ")
+	builder.WriteString("encrypted=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Encrypted))
+	builder.WriteString(", ")
+	builder.WriteString("request=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Request))
+	builder.WriteString(", ")
+	builder.WriteString("response=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Response))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// RawCaptures is a parsable slice of RawCapture.
+type RawCaptures []*RawCapture
@@ -0,0 +1,146 @@
+// Code generated by ent, DO NOT EDIT.
+
+package rawcapture
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the rawcapture type in the database.
+	Label = "raw_capture"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldNodeHash holds the string denoting the node_hash field in the database.
+	FieldNodeHash = "node_hash"
+	// FieldProvider holds the string denoting the provider field in the database.
+	FieldProvider = "provider"
+	// FieldAgentName holds the string denoting the agent_name field in the database.
+	FieldAgentName = "agent_name"
+	// FieldSessionID holds the string denoting the session_id field in the database.
+	FieldSessionID = "session_id"
+	// FieldRunID holds the string denoting the run_id field in the database.
+	FieldRunID = "run_id"
+	// FieldProject holds the string denoting the project field in the database.
+	FieldProject = "project"
+	// FieldUser holds the string denoting the user field in the database.
+	FieldUser = "user"
+	// FieldPath holds the string denoting the path field in the database.
+	FieldPath = "path"
+	// FieldStreamed holds the string denoting the streamed field in the database.
+	FieldStreamed = "streamed"
+	// FieldEncrypted holds the string denoting the encrypted field in the database.
+	FieldEncrypted = "encrypted"
+	// FieldRequest holds the string denoting the request field in the database.
+	FieldRequest = "request"
+	// FieldResponse holds the string denoting the response field in the database.
+	FieldResponse = "response"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// Table holds the table name of the rawcapture in the database.
+	Table = "raw_captures"
+)
+
+// Columns holds all SQL columns for rawcapture fields.
+var Columns = []string{
+	FieldID,
+	FieldNodeHash,
+	FieldProvider,
+	FieldAgentName,
+	FieldSessionID,
+	FieldRunID,
+	FieldProject,
+	FieldUser,
+	FieldPath,
+	FieldStreamed,
+	FieldEncrypted,
+	FieldRequest,
+	FieldResponse,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// NodeHashValidator is a validator for the "node_hash" field. It is called by the builders before save.
+	NodeHashValidator func(string) error
+	// DefaultStreamed holds the default value on creation for the "streamed" field.
+	DefaultStreamed bool
+	// DefaultEncrypted holds the default value on creation for the "encrypted" field.
+	DefaultEncrypted bool
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the RawCapture queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByNodeHash orders the results by the node_hash field.
+func ByNodeHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNodeHash, opts...).ToFunc()
+}
+
+// ByProvider orders the results by the provider field.
+func ByProvider(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldProvider, opts...).ToFunc()
+}
+
+// ByAgentName orders the results by the agent_name field.
+func ByAgentName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAgentName, opts...).ToFunc()
+}
+
+// BySessionID orders the results by the session_id field.
+func BySessionID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSessionID, opts...).ToFunc()
+}
+
+// ByRunID orders the results by the run_id field.
+func ByRunID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRunID, opts...).ToFunc()
+}
+
+// ByProject orders the results by the project field.
+func ByProject(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldProject, opts...).ToFunc()
+}
+
+// ByUser orders the results by the user field.
+func ByUser(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUser, opts...).ToFunc()
+}
+
+// ByPath orders the results by the path field.
+func ByPath(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPath, opts...).ToFunc()
+}
+
+// ByStreamed orders the results by the streamed field.
+func ByStreamed(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStreamed, opts...).ToFunc()
+}
+
+// ByEncrypted orders the results by the encrypted field.
+func ByEncrypted(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEncrypted, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
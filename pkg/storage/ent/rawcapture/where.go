@@ -0,0 +1,865 @@
+// Code generated by ent, DO NOT EDIT.
+
+package rawcapture
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLTE(FieldID, id))
+}
+
+// NodeHash applies equality check predicate on the "node_hash" field. It's identical to NodeHashEQ.
+func NodeHash(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldNodeHash, v))
+}
+
+// Provider applies equality check predicate on the "provider" field. It's identical to ProviderEQ.
+func Provider(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldProvider, v))
+}
+
+// AgentName applies equality check predicate on the "agent_name" field. It's identical to AgentNameEQ.
+func AgentName(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldAgentName, v))
+}
+
+// SessionID applies equality check predicate on the "session_id" field. It's identical to SessionIDEQ.
+func SessionID(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldSessionID, v))
+}
+
+// RunID applies equality check predicate on the "run_id" field. It's identical to RunIDEQ.
+func RunID(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldRunID, v))
+}
+
+// Project applies equality check predicate on the "project" field. It's identical to ProjectEQ.
+func Project(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldProject, v))
+}
+
+// User applies equality check predicate on the "user" field. It's identical to UserEQ.
+func User(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldUser, v))
+}
+
+// Path applies equality check predicate on the "path" field. It's identical to PathEQ.
+func Path(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldPath, v))
+}
+
+// Streamed applies equality check predicate on the "streamed" field. It's identical to StreamedEQ.
+func Streamed(v bool) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldStreamed, v))
+}
+
+// Encrypted applies equality check predicate on the "encrypted" field. It's identical to EncryptedEQ.
+func Encrypted(v bool) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldEncrypted, v))
+}
+
+// Request applies equality check predicate on the "request" field. It's identical to RequestEQ.
+func Request(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldRequest, v))
+}
+
+// Response applies equality check predicate on the "response" field. It's identical to ResponseEQ.
+func Response(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldResponse, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// NodeHashEQ applies the EQ predicate on the "node_hash" field.
+func NodeHashEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldNodeHash, v))
+}
+
+// NodeHashNEQ applies the NEQ predicate on the "node_hash" field.
+func NodeHashNEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldNodeHash, v))
+}
+
+// NodeHashIn applies the In predicate on the "node_hash" field.
+func NodeHashIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIn(FieldNodeHash, vs...))
+}
+
+// NodeHashNotIn applies the NotIn predicate on the "node_hash" field.
+func NodeHashNotIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotIn(FieldNodeHash, vs...))
+}
+
+// NodeHashGT applies the GT predicate on the "node_hash" field.
+func NodeHashGT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGT(FieldNodeHash, v))
+}
+
+// NodeHashGTE applies the GTE predicate on the "node_hash" field.
+func NodeHashGTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGTE(FieldNodeHash, v))
+}
+
+// NodeHashLT applies the LT predicate on the "node_hash" field.
+func NodeHashLT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLT(FieldNodeHash, v))
+}
+
+// NodeHashLTE applies the LTE predicate on the "node_hash" field.
+func NodeHashLTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLTE(FieldNodeHash, v))
+}
+
+// NodeHashContains applies the Contains predicate on the "node_hash" field.
+func NodeHashContains(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContains(FieldNodeHash, v))
+}
+
+// NodeHashHasPrefix applies the HasPrefix predicate on the "node_hash" field.
+func NodeHashHasPrefix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasPrefix(FieldNodeHash, v))
+}
+
+// NodeHashHasSuffix applies the HasSuffix predicate on the "node_hash" field.
+func NodeHashHasSuffix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasSuffix(FieldNodeHash, v))
+}
+
+// NodeHashEqualFold applies the EqualFold predicate on the "node_hash" field.
+func NodeHashEqualFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEqualFold(FieldNodeHash, v))
+}
+
+// NodeHashContainsFold applies the ContainsFold predicate on the "node_hash" field.
+func NodeHashContainsFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContainsFold(FieldNodeHash, v))
+}
+
+// ProviderEQ applies the EQ predicate on the "provider" field.
+func ProviderEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldProvider, v))
+}
+
+// ProviderNEQ applies the NEQ predicate on the "provider" field.
+func ProviderNEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldProvider, v))
+}
+
+// ProviderIn applies the In predicate on the "provider" field.
+func ProviderIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIn(FieldProvider, vs...))
+}
+
+// ProviderNotIn applies the NotIn predicate on the "provider" field.
+func ProviderNotIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotIn(FieldProvider, vs...))
+}
+
+// ProviderGT applies the GT predicate on the "provider" field.
+func ProviderGT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGT(FieldProvider, v))
+}
+
+// ProviderGTE applies the GTE predicate on the "provider" field.
+func ProviderGTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGTE(FieldProvider, v))
+}
+
+// ProviderLT applies the LT predicate on the "provider" field.
+func ProviderLT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLT(FieldProvider, v))
+}
+
+// ProviderLTE applies the LTE predicate on the "provider" field.
+func ProviderLTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLTE(FieldProvider, v))
+}
+
+// ProviderContains applies the Contains predicate on the "provider" field.
+func ProviderContains(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContains(FieldProvider, v))
+}
+
+// ProviderHasPrefix applies the HasPrefix predicate on the "provider" field.
+func ProviderHasPrefix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasPrefix(FieldProvider, v))
+}
+
+// ProviderHasSuffix applies the HasSuffix predicate on the "provider" field.
+func ProviderHasSuffix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasSuffix(FieldProvider, v))
+}
+
+// ProviderEqualFold applies the EqualFold predicate on the "provider" field.
+func ProviderEqualFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEqualFold(FieldProvider, v))
+}
+
+// ProviderContainsFold applies the ContainsFold predicate on the "provider" field.
+func ProviderContainsFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContainsFold(FieldProvider, v))
+}
+
+// AgentNameEQ applies the EQ predicate on the "agent_name" field.
+func AgentNameEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldAgentName, v))
+}
+
+// AgentNameNEQ applies the NEQ predicate on the "agent_name" field.
+func AgentNameNEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldAgentName, v))
+}
+
+// AgentNameIn applies the In predicate on the "agent_name" field.
+func AgentNameIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIn(FieldAgentName, vs...))
+}
+
+// AgentNameNotIn applies the NotIn predicate on the "agent_name" field.
+func AgentNameNotIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotIn(FieldAgentName, vs...))
+}
+
+// AgentNameGT applies the GT predicate on the "agent_name" field.
+func AgentNameGT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGT(FieldAgentName, v))
+}
+
+// AgentNameGTE applies the GTE predicate on the "agent_name" field.
+func AgentNameGTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGTE(FieldAgentName, v))
+}
+
+// AgentNameLT applies the LT predicate on the "agent_name" field.
+func AgentNameLT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLT(FieldAgentName, v))
+}
+
+// AgentNameLTE applies the LTE predicate on the "agent_name" field.
+func AgentNameLTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLTE(FieldAgentName, v))
+}
+
+// AgentNameContains applies the Contains predicate on the "agent_name" field.
+func AgentNameContains(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContains(FieldAgentName, v))
+}
+
+// AgentNameHasPrefix applies the HasPrefix predicate on the "agent_name" field.
+func AgentNameHasPrefix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasPrefix(FieldAgentName, v))
+}
+
+// AgentNameHasSuffix applies the HasSuffix predicate on the "agent_name" field.
+func AgentNameHasSuffix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasSuffix(FieldAgentName, v))
+}
+
+// AgentNameIsNil applies the IsNil predicate on the "agent_name" field.
+func AgentNameIsNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIsNull(FieldAgentName))
+}
+
+// AgentNameNotNil applies the NotNil predicate on the "agent_name" field.
+func AgentNameNotNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotNull(FieldAgentName))
+}
+
+// AgentNameEqualFold applies the EqualFold predicate on the "agent_name" field.
+func AgentNameEqualFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEqualFold(FieldAgentName, v))
+}
+
+// AgentNameContainsFold applies the ContainsFold predicate on the "agent_name" field.
+func AgentNameContainsFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContainsFold(FieldAgentName, v))
+}
+
+// SessionIDEQ applies the EQ predicate on the "session_id" field.
+func SessionIDEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldSessionID, v))
+}
+
+// SessionIDNEQ applies the NEQ predicate on the "session_id" field.
+func SessionIDNEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldSessionID, v))
+}
+
+// SessionIDIn applies the In predicate on the "session_id" field.
+func SessionIDIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIn(FieldSessionID, vs...))
+}
+
+// SessionIDNotIn applies the NotIn predicate on the "session_id" field.
+func SessionIDNotIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotIn(FieldSessionID, vs...))
+}
+
+// SessionIDGT applies the GT predicate on the "session_id" field.
+func SessionIDGT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGT(FieldSessionID, v))
+}
+
+// SessionIDGTE applies the GTE predicate on the "session_id" field.
+func SessionIDGTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGTE(FieldSessionID, v))
+}
+
+// SessionIDLT applies the LT predicate on the "session_id" field.
+func SessionIDLT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLT(FieldSessionID, v))
+}
+
+// SessionIDLTE applies the LTE predicate on the "session_id" field.
+func SessionIDLTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLTE(FieldSessionID, v))
+}
+
+// SessionIDContains applies the Contains predicate on the "session_id" field.
+func SessionIDContains(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContains(FieldSessionID, v))
+}
+
+// SessionIDHasPrefix applies the HasPrefix predicate on the "session_id" field.
+func SessionIDHasPrefix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasPrefix(FieldSessionID, v))
+}
+
+// SessionIDHasSuffix applies the HasSuffix predicate on the "session_id" field.
+func SessionIDHasSuffix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasSuffix(FieldSessionID, v))
+}
+
+// SessionIDIsNil applies the IsNil predicate on the "session_id" field.
+func SessionIDIsNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIsNull(FieldSessionID))
+}
+
+// SessionIDNotNil applies the NotNil predicate on the "session_id" field.
+func SessionIDNotNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotNull(FieldSessionID))
+}
+
+// SessionIDEqualFold applies the EqualFold predicate on the "session_id" field.
+func SessionIDEqualFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEqualFold(FieldSessionID, v))
+}
+
+// SessionIDContainsFold applies the ContainsFold predicate on the "session_id" field.
+func SessionIDContainsFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContainsFold(FieldSessionID, v))
+}
+
+// RunIDEQ applies the EQ predicate on the "run_id" field.
+func RunIDEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldRunID, v))
+}
+
+// RunIDNEQ applies the NEQ predicate on the "run_id" field.
+func RunIDNEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldRunID, v))
+}
+
+// RunIDIn applies the In predicate on the "run_id" field.
+func RunIDIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIn(FieldRunID, vs...))
+}
+
+// RunIDNotIn applies the NotIn predicate on the "run_id" field.
+func RunIDNotIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotIn(FieldRunID, vs...))
+}
+
+// RunIDGT applies the GT predicate on the "run_id" field.
+func RunIDGT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGT(FieldRunID, v))
+}
+
+// RunIDGTE applies the GTE predicate on the "run_id" field.
+func RunIDGTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGTE(FieldRunID, v))
+}
+
+// RunIDLT applies the LT predicate on the "run_id" field.
+func RunIDLT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLT(FieldRunID, v))
+}
+
+// RunIDLTE applies the LTE predicate on the "run_id" field.
+func RunIDLTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLTE(FieldRunID, v))
+}
+
+// RunIDContains applies the Contains predicate on the "run_id" field.
+func RunIDContains(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContains(FieldRunID, v))
+}
+
+// RunIDHasPrefix applies the HasPrefix predicate on the "run_id" field.
+func RunIDHasPrefix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasPrefix(FieldRunID, v))
+}
+
+// RunIDHasSuffix applies the HasSuffix predicate on the "run_id" field.
+func RunIDHasSuffix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasSuffix(FieldRunID, v))
+}
+
+// RunIDIsNil applies the IsNil predicate on the "run_id" field.
+func RunIDIsNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIsNull(FieldRunID))
+}
+
+// RunIDNotNil applies the NotNil predicate on the "run_id" field.
+func RunIDNotNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotNull(FieldRunID))
+}
+
+// RunIDEqualFold applies the EqualFold predicate on the "run_id" field.
+func RunIDEqualFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEqualFold(FieldRunID, v))
+}
+
+// RunIDContainsFold applies the ContainsFold predicate on the "run_id" field.
+func RunIDContainsFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContainsFold(FieldRunID, v))
+}
+
+// ProjectEQ applies the EQ predicate on the "project" field.
+func ProjectEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldProject, v))
+}
+
+// ProjectNEQ applies the NEQ predicate on the "project" field.
+func ProjectNEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldProject, v))
+}
+
+// ProjectIn applies the In predicate on the "project" field.
+func ProjectIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIn(FieldProject, vs...))
+}
+
+// ProjectNotIn applies the NotIn predicate on the "project" field.
+func ProjectNotIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotIn(FieldProject, vs...))
+}
+
+// ProjectGT applies the GT predicate on the "project" field.
+func ProjectGT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGT(FieldProject, v))
+}
+
+// ProjectGTE applies the GTE predicate on the "project" field.
+func ProjectGTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGTE(FieldProject, v))
+}
+
+// ProjectLT applies the LT predicate on the "project" field.
+func ProjectLT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLT(FieldProject, v))
+}
+
+// ProjectLTE applies the LTE predicate on the "project" field.
+func ProjectLTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLTE(FieldProject, v))
+}
+
+// ProjectContains applies the Contains predicate on the "project" field.
+func ProjectContains(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContains(FieldProject, v))
+}
+
+// ProjectHasPrefix applies the HasPrefix predicate on the "project" field.
+func ProjectHasPrefix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasPrefix(FieldProject, v))
+}
+
+// ProjectHasSuffix applies the HasSuffix predicate on the "project" field.
+func ProjectHasSuffix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasSuffix(FieldProject, v))
+}
+
+// ProjectIsNil applies the IsNil predicate on the "project" field.
+func ProjectIsNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIsNull(FieldProject))
+}
+
+// ProjectNotNil applies the NotNil predicate on the "project" field.
+func ProjectNotNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotNull(FieldProject))
+}
+
+// ProjectEqualFold applies the EqualFold predicate on the "project" field.
+func ProjectEqualFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEqualFold(FieldProject, v))
+}
+
+// ProjectContainsFold applies the ContainsFold predicate on the "project" field.
+func ProjectContainsFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContainsFold(FieldProject, v))
+}
+
+// UserEQ applies the EQ predicate on the "user" field.
+func UserEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldUser, v))
+}
+
+// UserNEQ applies the NEQ predicate on the "user" field.
+func UserNEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldUser, v))
+}
+
+// UserIn applies the In predicate on the "user" field.
+func UserIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIn(FieldUser, vs...))
+}
+
+// UserNotIn applies the NotIn predicate on the "user" field.
+func UserNotIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotIn(FieldUser, vs...))
+}
+
+// UserGT applies the GT predicate on the "user" field.
+func UserGT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGT(FieldUser, v))
+}
+
+// UserGTE applies the GTE predicate on the "user" field.
+func UserGTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGTE(FieldUser, v))
+}
+
+// UserLT applies the LT predicate on the "user" field.
+func UserLT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLT(FieldUser, v))
+}
+
+// UserLTE applies the LTE predicate on the "user" field.
+func UserLTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLTE(FieldUser, v))
+}
+
+// UserContains applies the Contains predicate on the "user" field.
+func UserContains(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContains(FieldUser, v))
+}
+
+// UserHasPrefix applies the HasPrefix predicate on the "user" field.
+func UserHasPrefix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasPrefix(FieldUser, v))
+}
+
+// UserHasSuffix applies the HasSuffix predicate on the "user" field.
+func UserHasSuffix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasSuffix(FieldUser, v))
+}
+
+// UserIsNil applies the IsNil predicate on the "user" field.
+func UserIsNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIsNull(FieldUser))
+}
+
+// UserNotNil applies the NotNil predicate on the "user" field.
+func UserNotNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotNull(FieldUser))
+}
+
+// UserEqualFold applies the EqualFold predicate on the "user" field.
+func UserEqualFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEqualFold(FieldUser, v))
+}
+
+// UserContainsFold applies the ContainsFold predicate on the "user" field.
+func UserContainsFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContainsFold(FieldUser, v))
+}
+
+// PathEQ applies the EQ predicate on the "path" field.
+func PathEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldPath, v))
+}
+
+// PathNEQ applies the NEQ predicate on the "path" field.
+func PathNEQ(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldPath, v))
+}
+
+// PathIn applies the In predicate on the "path" field.
+func PathIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIn(FieldPath, vs...))
+}
+
+// PathNotIn applies the NotIn predicate on the "path" field.
+func PathNotIn(vs ...string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotIn(FieldPath, vs...))
+}
+
+// PathGT applies the GT predicate on the "path" field.
+func PathGT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGT(FieldPath, v))
+}
+
+// PathGTE applies the GTE predicate on the "path" field.
+func PathGTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGTE(FieldPath, v))
+}
+
+// PathLT applies the LT predicate on the "path" field.
+func PathLT(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLT(FieldPath, v))
+}
+
+// PathLTE applies the LTE predicate on the "path" field.
+func PathLTE(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLTE(FieldPath, v))
+}
+
+// PathContains applies the Contains predicate on the "path" field.
+func PathContains(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContains(FieldPath, v))
+}
+
+// PathHasPrefix applies the HasPrefix predicate on the "path" field.
+func PathHasPrefix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasPrefix(FieldPath, v))
+}
+
+// PathHasSuffix applies the HasSuffix predicate on the "path" field.
+func PathHasSuffix(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldHasSuffix(FieldPath, v))
+}
+
+// PathIsNil applies the IsNil predicate on the "path" field.
+func PathIsNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIsNull(FieldPath))
+}
+
+// PathNotNil applies the NotNil predicate on the "path" field.
+func PathNotNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotNull(FieldPath))
+}
+
+// PathEqualFold applies the EqualFold predicate on the "path" field.
+func PathEqualFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEqualFold(FieldPath, v))
+}
+
+// PathContainsFold applies the ContainsFold predicate on the "path" field.
+func PathContainsFold(v string) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldContainsFold(FieldPath, v))
+}
+
+// StreamedEQ applies the EQ predicate on the "streamed" field.
+func StreamedEQ(v bool) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldStreamed, v))
+}
+
+// StreamedNEQ applies the NEQ predicate on the "streamed" field.
+func StreamedNEQ(v bool) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldStreamed, v))
+}
+
+// EncryptedEQ applies the EQ predicate on the "encrypted" field.
+func EncryptedEQ(v bool) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldEncrypted, v))
+}
+
+// EncryptedNEQ applies the NEQ predicate on the "encrypted" field.
+func EncryptedNEQ(v bool) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldEncrypted, v))
+}
+
+// RequestEQ applies the EQ predicate on the "request" field.
+func RequestEQ(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldRequest, v))
+}
+
+// RequestNEQ applies the NEQ predicate on the "request" field.
+func RequestNEQ(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldRequest, v))
+}
+
+// RequestIn applies the In predicate on the "request" field.
+func RequestIn(vs ...[]byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIn(FieldRequest, vs...))
+}
+
+// RequestNotIn applies the NotIn predicate on the "request" field.
+func RequestNotIn(vs ...[]byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotIn(FieldRequest, vs...))
+}
+
+// RequestGT applies the GT predicate on the "request" field.
+func RequestGT(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGT(FieldRequest, v))
+}
+
+// RequestGTE applies the GTE predicate on the "request" field.
+func RequestGTE(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGTE(FieldRequest, v))
+}
+
+// RequestLT applies the LT predicate on the "request" field.
+func RequestLT(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLT(FieldRequest, v))
+}
+
+// RequestLTE applies the LTE predicate on the "request" field.
+func RequestLTE(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLTE(FieldRequest, v))
+}
+
+// ResponseEQ applies the EQ predicate on the "response" field.
+func ResponseEQ(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldResponse, v))
+}
+
+// ResponseNEQ applies the NEQ predicate on the "response" field.
+func ResponseNEQ(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldResponse, v))
+}
+
+// ResponseIn applies the In predicate on the "response" field.
+func ResponseIn(vs ...[]byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIn(FieldResponse, vs...))
+}
+
+// ResponseNotIn applies the NotIn predicate on the "response" field.
+func ResponseNotIn(vs ...[]byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotIn(FieldResponse, vs...))
+}
+
+// ResponseGT applies the GT predicate on the "response" field.
+func ResponseGT(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGT(FieldResponse, v))
+}
+
+// ResponseGTE applies the GTE predicate on the "response" field.
+func ResponseGTE(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGTE(FieldResponse, v))
+}
+
+// ResponseLT applies the LT predicate on the "response" field.
+func ResponseLT(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLT(FieldResponse, v))
+}
+
+// ResponseLTE applies the LTE predicate on the "response" field.
+func ResponseLTE(v []byte) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLTE(FieldResponse, v))
+}
+
+// ResponseIsNil applies the IsNil predicate on the "response" field.
+func ResponseIsNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIsNull(FieldResponse))
+}
+
+// ResponseNotNil applies the NotNil predicate on the "response" field.
+func ResponseNotNil() predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotNull(FieldResponse))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.RawCapture {
+	return predicate.RawCapture(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.RawCapture) predicate.RawCapture {
+	return predicate.RawCapture(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.RawCapture) predicate.RawCapture {
+	return predicate.RawCapture(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.RawCapture) predicate.RawCapture {
+	return predicate.RawCapture(sql.NotPredicates(p))
+}
@@ -0,0 +1,414 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/rawcapture"
+)
+
+// RawCaptureCreate is the builder for creating a RawCapture entity.
+type RawCaptureCreate struct {
+	config
+	mutation *RawCaptureMutation
+	hooks    []Hook
+}
+
+// SetNodeHash sets the "node_hash" field.
+func (_c *RawCaptureCreate) SetNodeHash(v string) *RawCaptureCreate {
+	_c.mutation.SetNodeHash(v)
+	return _c
+}
+
+// SetProvider sets the "provider" field.
+func (_c *RawCaptureCreate) SetProvider(v string) *RawCaptureCreate {
+	_c.mutation.SetProvider(v)
+	return _c
+}
+
+// SetAgentName sets the "agent_name" field.
+func (_c *RawCaptureCreate) SetAgentName(v string) *RawCaptureCreate {
+	_c.mutation.SetAgentName(v)
+	return _c
+}
+
+// SetNillableAgentName sets the "agent_name" field if the given value is not nil.
+func (_c *RawCaptureCreate) SetNillableAgentName(v *string) *RawCaptureCreate {
+	if v != nil {
+		_c.SetAgentName(*v)
+	}
+	return _c
+}
+
+// SetSessionID sets the "session_id" field.
+func (_c *RawCaptureCreate) SetSessionID(v string) *RawCaptureCreate {
+	_c.mutation.SetSessionID(v)
+	return _c
+}
+
+// SetNillableSessionID sets the "session_id" field if the given value is not nil.
+func (_c *RawCaptureCreate) SetNillableSessionID(v *string) *RawCaptureCreate {
+	if v != nil {
+		_c.SetSessionID(*v)
+	}
+	return _c
+}
+
+// SetRunID sets the "run_id" field.
+func (_c *RawCaptureCreate) SetRunID(v string) *RawCaptureCreate {
+	_c.mutation.SetRunID(v)
+	return _c
+}
+
+// SetNillableRunID sets the "run_id" field if the given value is not nil.
+func (_c *RawCaptureCreate) SetNillableRunID(v *string) *RawCaptureCreate {
+	if v != nil {
+		_c.SetRunID(*v)
+	}
+	return _c
+}
+
+// SetProject sets the "project" field.
+func (_c *RawCaptureCreate) SetProject(v string) *RawCaptureCreate {
+	_c.mutation.SetProject(v)
+	return _c
+}
+
+// SetNillableProject sets the "project" field if the given value is not nil.
+func (_c *RawCaptureCreate) SetNillableProject(v *string) *RawCaptureCreate {
+	if v != nil {
+		_c.SetProject(*v)
+	}
+	return _c
+}
+
+// SetUser sets the "user" field.
+func (_c *RawCaptureCreate) SetUser(v string) *RawCaptureCreate {
+	_c.mutation.SetUser(v)
+	return _c
+}
+
+// SetNillableUser sets the "user" field if the given value is not nil.
+func (_c *RawCaptureCreate) SetNillableUser(v *string) *RawCaptureCreate {
+	if v != nil {
+		_c.SetUser(*v)
+	}
+	return _c
+}
+
+// SetPath sets the "path" field.
+func (_c *RawCaptureCreate) SetPath(v string) *RawCaptureCreate {
+	_c.mutation.SetPath(v)
+	return _c
+}
+
+// SetNillablePath sets the "path" field if the given value is not nil.
+func (_c *RawCaptureCreate) SetNillablePath(v *string) *RawCaptureCreate {
+	if v != nil {
+		_c.SetPath(*v)
+	}
+	return _c
+}
+
+// SetStreamed sets the "streamed" field.
+func (_c *RawCaptureCreate) SetStreamed(v bool) *RawCaptureCreate {
+	_c.mutation.SetStreamed(v)
+	return _c
+}
+
+// SetNillableStreamed sets the "streamed" field if the given value is not nil.
+func (_c *RawCaptureCreate) SetNillableStreamed(v *bool) *RawCaptureCreate {
+	if v != nil {
+		_c.SetStreamed(*v)
+	}
+	return _c
+}
+
+// SetEncrypted sets the "encrypted" field.
+func (_c *RawCaptureCreate) SetEncrypted(v bool) *RawCaptureCreate {
+	_c.mutation.SetEncrypted(v)
+	return _c
+}
+
+// SetNillableEncrypted sets the "encrypted" field if the given value is not nil.
+func (_c *RawCaptureCreate) SetNillableEncrypted(v *bool) *RawCaptureCreate {
+	if v != nil {
+		_c.SetEncrypted(*v)
+	}
+	return _c
+}
+
+// SetRequest sets the "request" field.
+func (_c *RawCaptureCreate) SetRequest(v []byte) *RawCaptureCreate {
+	_c.mutation.SetRequest(v)
+	return _c
+}
+
+// SetResponse sets the "response" field.
+func (_c *RawCaptureCreate) SetResponse(v []byte) *RawCaptureCreate {
+	_c.mutation.SetResponse(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *RawCaptureCreate) SetCreatedAt(v time.Time) *RawCaptureCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *RawCaptureCreate) SetNillableCreatedAt(v *time.Time) *RawCaptureCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// Mutation returns the RawCaptureMutation object of the builder.
+func (_c *RawCaptureCreate) Mutation() *RawCaptureMutation {
+	return _c.mutation
+}
+
+// Save creates the RawCapture in the database.
+func (_c *RawCaptureCreate) Save(ctx context.Context) (*RawCapture, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *RawCaptureCreate) SaveX(ctx context.Context) *RawCapture {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *RawCaptureCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *RawCaptureCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *RawCaptureCreate) defaults() {
+	if _, ok := _c.mutation.Streamed(); !ok {
+		v := rawcapture.DefaultStreamed
+		_c.mutation.SetStreamed(v)
+	}
+	if _, ok := _c.mutation.Encrypted(); !ok {
+		v := rawcapture.DefaultEncrypted
+		_c.mutation.SetEncrypted(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := rawcapture.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *RawCaptureCreate) check() error {
+	if _, ok := _c.mutation.NodeHash(); !ok {
+		return &ValidationError{Name: "node_hash", err: errors.New(`ent: missing required field "RawCapture.node_hash"`)}
+	}
+	if v, ok := _c.mutation.NodeHash(); ok {
+		if err := rawcapture.NodeHashValidator(v); err != nil {
+			return &ValidationError{Name: "node_hash", err: fmt.Errorf(`ent: validator failed for field "RawCapture.node_hash": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Provider(); !ok {
+		return &ValidationError{Name: "provider", err: errors.New(`ent: missing required field "RawCapture.provider"`)}
+	}
+	if _, ok := _c.mutation.Streamed(); !ok {
+		return &ValidationError{Name: "streamed", err: errors.New(`ent: missing required field "RawCapture.streamed"`)}
+	}
+	if _, ok := _c.mutation.Encrypted(); !ok {
+		return &ValidationError{Name: "encrypted", err: errors.New(`ent: missing required field "RawCapture.encrypted"`)}
+	}
+	if _, ok := _c.mutation.Request(); !ok {
+		return &ValidationError{Name: "request", err: errors.New(`ent: missing required field "RawCapture.request"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "RawCapture.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *RawCaptureCreate) sqlSave(ctx context.Context) (*RawCapture, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *RawCaptureCreate) createSpec() (*RawCapture, *sqlgraph.CreateSpec) {
+	var (
+		_node = &RawCapture{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(rawcapture.Table, sqlgraph.NewFieldSpec(rawcapture.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.NodeHash(); ok {
+		_spec.SetField(rawcapture.FieldNodeHash, field.TypeString, value)
+		_node.NodeHash = value
+	}
+	if value, ok := _c.mutation.Provider(); ok {
+		_spec.SetField(rawcapture.FieldProvider, field.TypeString, value)
+		_node.Provider = value
+	}
+	if value, ok := _c.mutation.AgentName(); ok {
+		_spec.SetField(rawcapture.FieldAgentName, field.TypeString, value)
+		_node.AgentName = value
+	}
+	if value, ok := _c.mutation.SessionID(); ok {
+		_spec.SetField(rawcapture.FieldSessionID, field.TypeString, value)
+		_node.SessionID = value
+	}
+	if value, ok := _c.mutation.RunID(); ok {
+		_spec.SetField(rawcapture.FieldRunID, field.TypeString, value)
+		_node.RunID = value
+	}
+	if value, ok := _c.mutation.Project(); ok {
+		_spec.SetField(rawcapture.FieldProject, field.TypeString, value)
+		_node.Project = value
+	}
+	if value, ok := _c.mutation.User(); ok {
+		_spec.SetField(rawcapture.FieldUser, field.TypeString, value)
+		_node.User = value
+	}
+	if value, ok := _c.mutation.Path(); ok {
+		_spec.SetField(rawcapture.FieldPath, field.TypeString, value)
+		_node.Path = value
+	}
+	if value, ok := _c.mutation.Streamed(); ok {
+		_spec.SetField(rawcapture.FieldStreamed, field.TypeBool, value)
+		_node.Streamed = value
+	}
+	if value, ok := _c.mutation.Encrypted(); ok {
+		_spec.SetField(rawcapture.FieldEncrypted, field.TypeBool, value)
+		_node.Encrypted = value
+	}
+	if value, ok := _c.mutation.Request(); ok {
+		_spec.SetField(rawcapture.FieldRequest, field.TypeBytes, value)
+		_node.Request = value
+	}
+	if value, ok := _c.mutation.Response(); ok {
+		_spec.SetField(rawcapture.FieldResponse, field.TypeBytes, value)
+		_node.Response = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(rawcapture.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// RawCaptureCreateBulk is the builder for creating many RawCapture entities in bulk.
+type RawCaptureCreateBulk struct {
+	config
+	err      error
+	builders []*RawCaptureCreate
+}
+
+// Save creates the RawCapture entities in the database.
+func (_c *RawCaptureCreateBulk) Save(ctx context.Context) ([]*RawCapture, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*RawCapture, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*RawCaptureMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *RawCaptureCreateBulk) SaveX(ctx context.Context) []*RawCapture {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *RawCaptureCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *RawCaptureCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
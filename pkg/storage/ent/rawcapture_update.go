@@ -0,0 +1,703 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/rawcapture"
+)
+
+// RawCaptureUpdate is the builder for updating RawCapture entities.
+type RawCaptureUpdate struct {
+	config
+	hooks    []Hook
+	mutation *RawCaptureMutation
+}
+
+// Where appends a list predicates to the RawCaptureUpdate builder.
+func (_u *RawCaptureUpdate) Where(ps ...predicate.RawCapture) *RawCaptureUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetNodeHash sets the "node_hash" field.
+func (_u *RawCaptureUpdate) SetNodeHash(v string) *RawCaptureUpdate {
+	_u.mutation.SetNodeHash(v)
+	return _u
+}
+
+// SetNillableNodeHash sets the "node_hash" field if the given value is not nil.
+func (_u *RawCaptureUpdate) SetNillableNodeHash(v *string) *RawCaptureUpdate {
+	if v != nil {
+		_u.SetNodeHash(*v)
+	}
+	return _u
+}
+
+// SetProvider sets the "provider" field.
+func (_u *RawCaptureUpdate) SetProvider(v string) *RawCaptureUpdate {
+	_u.mutation.SetProvider(v)
+	return _u
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (_u *RawCaptureUpdate) SetNillableProvider(v *string) *RawCaptureUpdate {
+	if v != nil {
+		_u.SetProvider(*v)
+	}
+	return _u
+}
+
+// SetAgentName sets the "agent_name" field.
+func (_u *RawCaptureUpdate) SetAgentName(v string) *RawCaptureUpdate {
+	_u.mutation.SetAgentName(v)
+	return _u
+}
+
+// SetNillableAgentName sets the "agent_name" field if the given value is not nil.
+func (_u *RawCaptureUpdate) SetNillableAgentName(v *string) *RawCaptureUpdate {
+	if v != nil {
+		_u.SetAgentName(*v)
+	}
+	return _u
+}
+
+// ClearAgentName clears the value of the "agent_name" field.
+func (_u *RawCaptureUpdate) ClearAgentName() *RawCaptureUpdate {
+	_u.mutation.ClearAgentName()
+	return _u
+}
+
+// SetSessionID sets the "session_id" field.
+func (_u *RawCaptureUpdate) SetSessionID(v string) *RawCaptureUpdate {
+	_u.mutation.SetSessionID(v)
+	return _u
+}
+
+// SetNillableSessionID sets the "session_id" field if the given value is not nil.
+func (_u *RawCaptureUpdate) SetNillableSessionID(v *string) *RawCaptureUpdate {
+	if v != nil {
+		_u.SetSessionID(*v)
+	}
+	return _u
+}
+
+// ClearSessionID clears the value of the "session_id" field.
+func (_u *RawCaptureUpdate) ClearSessionID() *RawCaptureUpdate {
+	_u.mutation.ClearSessionID()
+	return _u
+}
+
+// SetRunID sets the "run_id" field.
+func (_u *RawCaptureUpdate) SetRunID(v string) *RawCaptureUpdate {
+	_u.mutation.SetRunID(v)
+	return _u
+}
+
+// SetNillableRunID sets the "run_id" field if the given value is not nil.
+func (_u *RawCaptureUpdate) SetNillableRunID(v *string) *RawCaptureUpdate {
+	if v != nil {
+		_u.SetRunID(*v)
+	}
+	return _u
+}
+
+// ClearRunID clears the value of the "run_id" field.
+func (_u *RawCaptureUpdate) ClearRunID() *RawCaptureUpdate {
+	_u.mutation.ClearRunID()
+	return _u
+}
+
+// SetProject sets the "project" field.
+func (_u *RawCaptureUpdate) SetProject(v string) *RawCaptureUpdate {
+	_u.mutation.SetProject(v)
+	return _u
+}
+
+// SetNillableProject sets the "project" field if the given value is not nil.
+func (_u *RawCaptureUpdate) SetNillableProject(v *string) *RawCaptureUpdate {
+	if v != nil {
+		_u.SetProject(*v)
+	}
+	return _u
+}
+
+// ClearProject clears the value of the "project" field.
+func (_u *RawCaptureUpdate) ClearProject() *RawCaptureUpdate {
+	_u.mutation.ClearProject()
+	return _u
+}
+
+// SetUser sets the "user" field.
+func (_u *RawCaptureUpdate) SetUser(v string) *RawCaptureUpdate {
+	_u.mutation.SetUser(v)
+	return _u
+}
+
+// SetNillableUser sets the "user" field if the given value is not nil.
+func (_u *RawCaptureUpdate) SetNillableUser(v *string) *RawCaptureUpdate {
+	if v != nil {
+		_u.SetUser(*v)
+	}
+	return _u
+}
+
+// ClearUser clears the value of the "user" field.
+func (_u *RawCaptureUpdate) ClearUser() *RawCaptureUpdate {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// SetPath sets the "path" field.
+func (_u *RawCaptureUpdate) SetPath(v string) *RawCaptureUpdate {
+	_u.mutation.SetPath(v)
+	return _u
+}
+
+// SetNillablePath sets the "path" field if the given value is not nil.
+func (_u *RawCaptureUpdate) SetNillablePath(v *string) *RawCaptureUpdate {
+	if v != nil {
+		_u.SetPath(*v)
+	}
+	return _u
+}
+
+// ClearPath clears the value of the "path" field.
+func (_u *RawCaptureUpdate) ClearPath() *RawCaptureUpdate {
+	_u.mutation.ClearPath()
+	return _u
+}
+
+// SetStreamed sets the "streamed" field.
+func (_u *RawCaptureUpdate) SetStreamed(v bool) *RawCaptureUpdate {
+	_u.mutation.SetStreamed(v)
+	return _u
+}
+
+// SetNillableStreamed sets the "streamed" field if the given value is not nil.
+func (_u *RawCaptureUpdate) SetNillableStreamed(v *bool) *RawCaptureUpdate {
+	if v != nil {
+		_u.SetStreamed(*v)
+	}
+	return _u
+}
+
+// SetEncrypted sets the "encrypted" field.
+func (_u *RawCaptureUpdate) SetEncrypted(v bool) *RawCaptureUpdate {
+	_u.mutation.SetEncrypted(v)
+	return _u
+}
+
+// SetNillableEncrypted sets the "encrypted" field if the given value is not nil.
+func (_u *RawCaptureUpdate) SetNillableEncrypted(v *bool) *RawCaptureUpdate {
+	if v != nil {
+		_u.SetEncrypted(*v)
+	}
+	return _u
+}
+
+// SetRequest sets the "request" field.
+func (_u *RawCaptureUpdate) SetRequest(v []byte) *RawCaptureUpdate {
+	_u.mutation.SetRequest(v)
+	return _u
+}
+
+// SetResponse sets the "response" field.
+func (_u *RawCaptureUpdate) SetResponse(v []byte) *RawCaptureUpdate {
+	_u.mutation.SetResponse(v)
+	return _u
+}
+
+// ClearResponse clears the value of the "response" field.
+func (_u *RawCaptureUpdate) ClearResponse() *RawCaptureUpdate {
+	_u.mutation.ClearResponse()
+	return _u
+}
+
+// Mutation returns the RawCaptureMutation object of the builder.
+func (_u *RawCaptureUpdate) Mutation() *RawCaptureMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *RawCaptureUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *RawCaptureUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *RawCaptureUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *RawCaptureUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *RawCaptureUpdate) check() error {
+	if v, ok := _u.mutation.NodeHash(); ok {
+		if err := rawcapture.NodeHashValidator(v); err != nil {
+			return &ValidationError{Name: "node_hash", err: fmt.Errorf(`ent: validator failed for field "RawCapture.node_hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *RawCaptureUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(rawcapture.Table, rawcapture.Columns, sqlgraph.NewFieldSpec(rawcapture.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.NodeHash(); ok {
+		_spec.SetField(rawcapture.FieldNodeHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Provider(); ok {
+		_spec.SetField(rawcapture.FieldProvider, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.AgentName(); ok {
+		_spec.SetField(rawcapture.FieldAgentName, field.TypeString, value)
+	}
+	if _u.mutation.AgentNameCleared() {
+		_spec.ClearField(rawcapture.FieldAgentName, field.TypeString)
+	}
+	if value, ok := _u.mutation.SessionID(); ok {
+		_spec.SetField(rawcapture.FieldSessionID, field.TypeString, value)
+	}
+	if _u.mutation.SessionIDCleared() {
+		_spec.ClearField(rawcapture.FieldSessionID, field.TypeString)
+	}
+	if value, ok := _u.mutation.RunID(); ok {
+		_spec.SetField(rawcapture.FieldRunID, field.TypeString, value)
+	}
+	if _u.mutation.RunIDCleared() {
+		_spec.ClearField(rawcapture.FieldRunID, field.TypeString)
+	}
+	if value, ok := _u.mutation.Project(); ok {
+		_spec.SetField(rawcapture.FieldProject, field.TypeString, value)
+	}
+	if _u.mutation.ProjectCleared() {
+		_spec.ClearField(rawcapture.FieldProject, field.TypeString)
+	}
+	if value, ok := _u.mutation.User(); ok {
+		_spec.SetField(rawcapture.FieldUser, field.TypeString, value)
+	}
+	if _u.mutation.UserCleared() {
+		_spec.ClearField(rawcapture.FieldUser, field.TypeString)
+	}
+	if value, ok := _u.mutation.Path(); ok {
+		_spec.SetField(rawcapture.FieldPath, field.TypeString, value)
+	}
+	if _u.mutation.PathCleared() {
+		_spec.ClearField(rawcapture.FieldPath, field.TypeString)
+	}
+	if value, ok := _u.mutation.Streamed(); ok {
+		_spec.SetField(rawcapture.FieldStreamed, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Encrypted(); ok {
+		_spec.SetField(rawcapture.FieldEncrypted, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Request(); ok {
+		_spec.SetField(rawcapture.FieldRequest, field.TypeBytes, value)
+	}
+	if value, ok := _u.mutation.Response(); ok {
+		_spec.SetField(rawcapture.FieldResponse, field.TypeBytes, value)
+	}
+	if _u.mutation.ResponseCleared() {
+		_spec.ClearField(rawcapture.FieldResponse, field.TypeBytes)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{rawcapture.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// RawCaptureUpdateOne is the builder for updating a single RawCapture entity.
+type RawCaptureUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *RawCaptureMutation
+}
+
+// SetNodeHash sets the "node_hash" field.
+func (_u *RawCaptureUpdateOne) SetNodeHash(v string) *RawCaptureUpdateOne {
+	_u.mutation.SetNodeHash(v)
+	return _u
+}
+
+// SetNillableNodeHash sets the "node_hash" field if the given value is not nil.
+func (_u *RawCaptureUpdateOne) SetNillableNodeHash(v *string) *RawCaptureUpdateOne {
+	if v != nil {
+		_u.SetNodeHash(*v)
+	}
+	return _u
+}
+
+// SetProvider sets the "provider" field.
+func (_u *RawCaptureUpdateOne) SetProvider(v string) *RawCaptureUpdateOne {
+	_u.mutation.SetProvider(v)
+	return _u
+}
+
+// SetNillableProvider sets the "provider" field if the given value is not nil.
+func (_u *RawCaptureUpdateOne) SetNillableProvider(v *string) *RawCaptureUpdateOne {
+	if v != nil {
+		_u.SetProvider(*v)
+	}
+	return _u
+}
+
+// SetAgentName sets the "agent_name" field.
+func (_u *RawCaptureUpdateOne) SetAgentName(v string) *RawCaptureUpdateOne {
+	_u.mutation.SetAgentName(v)
+	return _u
+}
+
+// SetNillableAgentName sets the "agent_name" field if the given value is not nil.
+func (_u *RawCaptureUpdateOne) SetNillableAgentName(v *string) *RawCaptureUpdateOne {
+	if v != nil {
+		_u.SetAgentName(*v)
+	}
+	return _u
+}
+
+// ClearAgentName clears the value of the "agent_name" field.
+func (_u *RawCaptureUpdateOne) ClearAgentName() *RawCaptureUpdateOne {
+	_u.mutation.ClearAgentName()
+	return _u
+}
+
+// SetSessionID sets the "session_id" field.
+func (_u *RawCaptureUpdateOne) SetSessionID(v string) *RawCaptureUpdateOne {
+	_u.mutation.SetSessionID(v)
+	return _u
+}
+
+// SetNillableSessionID sets the "session_id" field if the given value is not nil.
+func (_u *RawCaptureUpdateOne) SetNillableSessionID(v *string) *RawCaptureUpdateOne {
+	if v != nil {
+		_u.SetSessionID(*v)
+	}
+	return _u
+}
+
+// ClearSessionID clears the value of the "session_id" field.
+func (_u *RawCaptureUpdateOne) ClearSessionID() *RawCaptureUpdateOne {
+	_u.mutation.ClearSessionID()
+	return _u
+}
+
+// SetRunID sets the "run_id" field.
+func (_u *RawCaptureUpdateOne) SetRunID(v string) *RawCaptureUpdateOne {
+	_u.mutation.SetRunID(v)
+	return _u
+}
+
+// SetNillableRunID sets the "run_id" field if the given value is not nil.
+func (_u *RawCaptureUpdateOne) SetNillableRunID(v *string) *RawCaptureUpdateOne {
+	if v != nil {
+		_u.SetRunID(*v)
+	}
+	return _u
+}
+
+// ClearRunID clears the value of the "run_id" field.
+func (_u *RawCaptureUpdateOne) ClearRunID() *RawCaptureUpdateOne {
+	_u.mutation.ClearRunID()
+	return _u
+}
+
+// SetProject sets the "project" field.
+func (_u *RawCaptureUpdateOne) SetProject(v string) *RawCaptureUpdateOne {
+	_u.mutation.SetProject(v)
+	return _u
+}
+
+// SetNillableProject sets the "project" field if the given value is not nil.
+func (_u *RawCaptureUpdateOne) SetNillableProject(v *string) *RawCaptureUpdateOne {
+	if v != nil {
+		_u.SetProject(*v)
+	}
+	return _u
+}
+
+// ClearProject clears the value of the "project" field.
+func (_u *RawCaptureUpdateOne) ClearProject() *RawCaptureUpdateOne {
+	_u.mutation.ClearProject()
+	return _u
+}
+
+// SetUser sets the "user" field.
+func (_u *RawCaptureUpdateOne) SetUser(v string) *RawCaptureUpdateOne {
+	_u.mutation.SetUser(v)
+	return _u
+}
+
+// SetNillableUser sets the "user" field if the given value is not nil.
+func (_u *RawCaptureUpdateOne) SetNillableUser(v *string) *RawCaptureUpdateOne {
+	if v != nil {
+		_u.SetUser(*v)
+	}
+	return _u
+}
+
+// ClearUser clears the value of the "user" field.
+func (_u *RawCaptureUpdateOne) ClearUser() *RawCaptureUpdateOne {
+	_u.mutation.ClearUser()
+	return _u
+}
+
+// SetPath sets the "path" field.
+func (_u *RawCaptureUpdateOne) SetPath(v string) *RawCaptureUpdateOne {
+	_u.mutation.SetPath(v)
+	return _u
+}
+
+// SetNillablePath sets the "path" field if the given value is not nil.
+func (_u *RawCaptureUpdateOne) SetNillablePath(v *string) *RawCaptureUpdateOne {
+	if v != nil {
+		_u.SetPath(*v)
+	}
+	return _u
+}
+
+// ClearPath clears the value of the "path" field.
+func (_u *RawCaptureUpdateOne) ClearPath() *RawCaptureUpdateOne {
+	_u.mutation.ClearPath()
+	return _u
+}
+
+// SetStreamed sets the "streamed" field.
+func (_u *RawCaptureUpdateOne) SetStreamed(v bool) *RawCaptureUpdateOne {
+	_u.mutation.SetStreamed(v)
+	return _u
+}
+
+// SetNillableStreamed sets the "streamed" field if the given value is not nil.
+func (_u *RawCaptureUpdateOne) SetNillableStreamed(v *bool) *RawCaptureUpdateOne {
+	if v != nil {
+		_u.SetStreamed(*v)
+	}
+	return _u
+}
+
+// SetEncrypted sets the "encrypted" field.
+func (_u *RawCaptureUpdateOne) SetEncrypted(v bool) *RawCaptureUpdateOne {
+	_u.mutation.SetEncrypted(v)
+	return _u
+}
+
+// SetNillableEncrypted sets the "encrypted" field if the given value is not nil.
+func (_u *RawCaptureUpdateOne) SetNillableEncrypted(v *bool) *RawCaptureUpdateOne {
+	if v != nil {
+		_u.SetEncrypted(*v)
+	}
+	return _u
+}
+
+// SetRequest sets the "request" field.
+func (_u *RawCaptureUpdateOne) SetRequest(v []byte) *RawCaptureUpdateOne {
+	_u.mutation.SetRequest(v)
+	return _u
+}
+
+// SetResponse sets the "response" field.
+func (_u *RawCaptureUpdateOne) SetResponse(v []byte) *RawCaptureUpdateOne {
+	_u.mutation.SetResponse(v)
+	return _u
+}
+
+// ClearResponse clears the value of the "response" field.
+func (_u *RawCaptureUpdateOne) ClearResponse() *RawCaptureUpdateOne {
+	_u.mutation.ClearResponse()
+	return _u
+}
+
+// Mutation returns the RawCaptureMutation object of the builder.
+func (_u *RawCaptureUpdateOne) Mutation() *RawCaptureMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the RawCaptureUpdate builder.
+func (_u *RawCaptureUpdateOne) Where(ps ...predicate.RawCapture) *RawCaptureUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *RawCaptureUpdateOne) Select(field string, fields ...string) *RawCaptureUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated RawCapture entity.
+func (_u *RawCaptureUpdateOne) Save(ctx context.Context) (*RawCapture, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *RawCaptureUpdateOne) SaveX(ctx context.Context) *RawCapture {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *RawCaptureUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *RawCaptureUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *RawCaptureUpdateOne) check() error {
+	if v, ok := _u.mutation.NodeHash(); ok {
+		if err := rawcapture.NodeHashValidator(v); err != nil {
+			return &ValidationError{Name: "node_hash", err: fmt.Errorf(`ent: validator failed for field "RawCapture.node_hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *RawCaptureUpdateOne) sqlSave(ctx context.Context) (_node *RawCapture, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(rawcapture.Table, rawcapture.Columns, sqlgraph.NewFieldSpec(rawcapture.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "RawCapture.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, rawcapture.FieldID)
+		for _, f := range fields {
+			if !rawcapture.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != rawcapture.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.NodeHash(); ok {
+		_spec.SetField(rawcapture.FieldNodeHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Provider(); ok {
+		_spec.SetField(rawcapture.FieldProvider, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.AgentName(); ok {
+		_spec.SetField(rawcapture.FieldAgentName, field.TypeString, value)
+	}
+	if _u.mutation.AgentNameCleared() {
+		_spec.ClearField(rawcapture.FieldAgentName, field.TypeString)
+	}
+	if value, ok := _u.mutation.SessionID(); ok {
+		_spec.SetField(rawcapture.FieldSessionID, field.TypeString, value)
+	}
+	if _u.mutation.SessionIDCleared() {
+		_spec.ClearField(rawcapture.FieldSessionID, field.TypeString)
+	}
+	if value, ok := _u.mutation.RunID(); ok {
+		_spec.SetField(rawcapture.FieldRunID, field.TypeString, value)
+	}
+	if _u.mutation.RunIDCleared() {
+		_spec.ClearField(rawcapture.FieldRunID, field.TypeString)
+	}
+	if value, ok := _u.mutation.Project(); ok {
+		_spec.SetField(rawcapture.FieldProject, field.TypeString, value)
+	}
+	if _u.mutation.ProjectCleared() {
+		_spec.ClearField(rawcapture.FieldProject, field.TypeString)
+	}
+	if value, ok := _u.mutation.User(); ok {
+		_spec.SetField(rawcapture.FieldUser, field.TypeString, value)
+	}
+	if _u.mutation.UserCleared() {
+		_spec.ClearField(rawcapture.FieldUser, field.TypeString)
+	}
+	if value, ok := _u.mutation.Path(); ok {
+		_spec.SetField(rawcapture.FieldPath, field.TypeString, value)
+	}
+	if _u.mutation.PathCleared() {
+		_spec.ClearField(rawcapture.FieldPath, field.TypeString)
+	}
+	if value, ok := _u.mutation.Streamed(); ok {
+		_spec.SetField(rawcapture.FieldStreamed, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Encrypted(); ok {
+		_spec.SetField(rawcapture.FieldEncrypted, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Request(); ok {
+		_spec.SetField(rawcapture.FieldRequest, field.TypeBytes, value)
+	}
+	if value, ok := _u.mutation.Response(); ok {
+		_spec.SetField(rawcapture.FieldResponse, field.TypeBytes, value)
+	}
+	if _u.mutation.ResponseCleared() {
+		_spec.ClearField(rawcapture.FieldResponse, field.TypeBytes)
+	}
+	_node = &RawCapture{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{rawcapture.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -5,15 +5,50 @@ package ent
 import (
 	"time"
 
+	"github.com/papercomputeco/tapes/pkg/storage/ent/block"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/deadletter"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/facet"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/rawcapture"
 	"github.com/papercomputeco/tapes/pkg/storage/ent/schema"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/sessiontool"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/systemprompt"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/tooldefinition"
 )
 
 // The init function reads all schema descriptors with runtime code
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	blockFields := schema.Block{}.Fields()
+	_ = blockFields
+	// blockDescSize is the schema descriptor for size field.
+	blockDescSize := blockFields[2].Descriptor()
+	// block.SizeValidator is a validator for the "size" field. It is called by the builders before save.
+	block.SizeValidator = blockDescSize.Validators[0].(func(int) error)
+	// blockDescCreatedAt is the schema descriptor for created_at field.
+	blockDescCreatedAt := blockFields[3].Descriptor()
+	// block.DefaultCreatedAt holds the default value on creation for the created_at field.
+	block.DefaultCreatedAt = blockDescCreatedAt.Default.(func() time.Time)
+	// blockDescID is the schema descriptor for id field.
+	blockDescID := blockFields[0].Descriptor()
+	// block.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	block.IDValidator = blockDescID.Validators[0].(func(string) error)
+	deadletterFields := schema.DeadLetter{}.Fields()
+	_ = deadletterFields
+	// deadletterDescStage is the schema descriptor for stage field.
+	deadletterDescStage := deadletterFields[0].Descriptor()
+	// deadletter.StageValidator is a validator for the "stage" field. It is called by the builders before save.
+	deadletter.StageValidator = deadletterDescStage.Validators[0].(func(string) error)
+	// deadletterDescEncrypted is the schema descriptor for encrypted field.
+	deadletterDescEncrypted := deadletterFields[7].Descriptor()
+	// deadletter.DefaultEncrypted holds the default value on creation for the encrypted field.
+	deadletter.DefaultEncrypted = deadletterDescEncrypted.Default.(bool)
+	// deadletterDescCreatedAt is the schema descriptor for created_at field.
+	deadletterDescCreatedAt := deadletterFields[9].Descriptor()
+	// deadletter.DefaultCreatedAt holds the default value on creation for the created_at field.
+	deadletter.DefaultCreatedAt = deadletterDescCreatedAt.Default.(func() time.Time)
 	facetFields := schema.Facet{}.Fields()
 	_ = facetFields
 	// facetDescSessionID is the schema descriptor for session_id field.
@@ -31,11 +66,117 @@ func init() {
 	nodeFields := schema.Node{}.Fields()
 	_ = nodeFields
 	// nodeDescCreatedAt is the schema descriptor for created_at field.
-	nodeDescCreatedAt := nodeFields[18].Descriptor()
+	nodeDescCreatedAt := nodeFields[47].Descriptor()
 	// node.DefaultCreatedAt holds the default value on creation for the created_at field.
 	node.DefaultCreatedAt = nodeDescCreatedAt.Default.(func() time.Time)
 	// nodeDescID is the schema descriptor for id field.
 	nodeDescID := nodeFields[0].Descriptor()
 	// node.IDValidator is a validator for the "id" field. It is called by the builders before save.
 	node.IDValidator = nodeDescID.Validators[0].(func(string) error)
+	rawcaptureFields := schema.RawCapture{}.Fields()
+	_ = rawcaptureFields
+	// rawcaptureDescNodeHash is the schema descriptor for node_hash field.
+	rawcaptureDescNodeHash := rawcaptureFields[0].Descriptor()
+	// rawcapture.NodeHashValidator is a validator for the "node_hash" field. It is called by the builders before save.
+	rawcapture.NodeHashValidator = rawcaptureDescNodeHash.Validators[0].(func(string) error)
+	// rawcaptureDescStreamed is the schema descriptor for streamed field.
+	rawcaptureDescStreamed := rawcaptureFields[8].Descriptor()
+	// rawcapture.DefaultStreamed holds the default value on creation for the streamed field.
+	rawcapture.DefaultStreamed = rawcaptureDescStreamed.Default.(bool)
+	// rawcaptureDescEncrypted is the schema descriptor for encrypted field.
+	rawcaptureDescEncrypted := rawcaptureFields[9].Descriptor()
+	// rawcapture.DefaultEncrypted holds the default value on creation for the encrypted field.
+	rawcapture.DefaultEncrypted = rawcaptureDescEncrypted.Default.(bool)
+	// rawcaptureDescCreatedAt is the schema descriptor for created_at field.
+	rawcaptureDescCreatedAt := rawcaptureFields[12].Descriptor()
+	// rawcapture.DefaultCreatedAt holds the default value on creation for the created_at field.
+	rawcapture.DefaultCreatedAt = rawcaptureDescCreatedAt.Default.(func() time.Time)
+	sessionFields := schema.Session{}.Fields()
+	_ = sessionFields
+	// sessionDescRootHash is the schema descriptor for root_hash field.
+	sessionDescRootHash := sessionFields[1].Descriptor()
+	// session.RootHashValidator is a validator for the "root_hash" field. It is called by the builders before save.
+	session.RootHashValidator = sessionDescRootHash.Validators[0].(func(string) error)
+	// sessionDescTurnCount is the schema descriptor for turn_count field.
+	sessionDescTurnCount := sessionFields[2].Descriptor()
+	// session.DefaultTurnCount holds the default value on creation for the turn_count field.
+	session.DefaultTurnCount = sessionDescTurnCount.Default.(int)
+	// sessionDescPromptTokens is the schema descriptor for prompt_tokens field.
+	sessionDescPromptTokens := sessionFields[3].Descriptor()
+	// session.DefaultPromptTokens holds the default value on creation for the prompt_tokens field.
+	session.DefaultPromptTokens = sessionDescPromptTokens.Default.(int64)
+	// sessionDescCompletionTokens is the schema descriptor for completion_tokens field.
+	sessionDescCompletionTokens := sessionFields[4].Descriptor()
+	// session.DefaultCompletionTokens holds the default value on creation for the completion_tokens field.
+	session.DefaultCompletionTokens = sessionDescCompletionTokens.Default.(int64)
+	// sessionDescTotalTokens is the schema descriptor for total_tokens field.
+	sessionDescTotalTokens := sessionFields[5].Descriptor()
+	// session.DefaultTotalTokens holds the default value on creation for the total_tokens field.
+	session.DefaultTotalTokens = sessionDescTotalTokens.Default.(int64)
+	// sessionDescCacheCreationInputTokens is the schema descriptor for cache_creation_input_tokens field.
+	sessionDescCacheCreationInputTokens := sessionFields[6].Descriptor()
+	// session.DefaultCacheCreationInputTokens holds the default value on creation for the cache_creation_input_tokens field.
+	session.DefaultCacheCreationInputTokens = sessionDescCacheCreationInputTokens.Default.(int64)
+	// sessionDescCacheReadInputTokens is the schema descriptor for cache_read_input_tokens field.
+	sessionDescCacheReadInputTokens := sessionFields[7].Descriptor()
+	// session.DefaultCacheReadInputTokens holds the default value on creation for the cache_read_input_tokens field.
+	session.DefaultCacheReadInputTokens = sessionDescCacheReadInputTokens.Default.(int64)
+	// sessionDescCost is the schema descriptor for cost field.
+	sessionDescCost := sessionFields[8].Descriptor()
+	// session.DefaultCost holds the default value on creation for the cost field.
+	session.DefaultCost = sessionDescCost.Default.(float64)
+	// sessionDescRevision is the schema descriptor for revision field.
+	sessionDescRevision := sessionFields[10].Descriptor()
+	// session.DefaultRevision holds the default value on creation for the revision field.
+	session.DefaultRevision = sessionDescRevision.Default.(int64)
+	// sessionDescID is the schema descriptor for id field.
+	sessionDescID := sessionFields[0].Descriptor()
+	// session.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	session.IDValidator = sessionDescID.Validators[0].(func(string) error)
+	sessiontoolFields := schema.SessionTool{}.Fields()
+	_ = sessiontoolFields
+	// sessiontoolDescSessionID is the schema descriptor for session_id field.
+	sessiontoolDescSessionID := sessiontoolFields[0].Descriptor()
+	// sessiontool.SessionIDValidator is a validator for the "session_id" field. It is called by the builders before save.
+	sessiontool.SessionIDValidator = sessiontoolDescSessionID.Validators[0].(func(string) error)
+	// sessiontoolDescToolHash is the schema descriptor for tool_hash field.
+	sessiontoolDescToolHash := sessiontoolFields[1].Descriptor()
+	// sessiontool.ToolHashValidator is a validator for the "tool_hash" field. It is called by the builders before save.
+	sessiontool.ToolHashValidator = sessiontoolDescToolHash.Validators[0].(func(string) error)
+	// sessiontoolDescNodeHash is the schema descriptor for node_hash field.
+	sessiontoolDescNodeHash := sessiontoolFields[4].Descriptor()
+	// sessiontool.NodeHashValidator is a validator for the "node_hash" field. It is called by the builders before save.
+	sessiontool.NodeHashValidator = sessiontoolDescNodeHash.Validators[0].(func(string) error)
+	// sessiontoolDescCreatedAt is the schema descriptor for created_at field.
+	sessiontoolDescCreatedAt := sessiontoolFields[5].Descriptor()
+	// sessiontool.DefaultCreatedAt holds the default value on creation for the created_at field.
+	sessiontool.DefaultCreatedAt = sessiontoolDescCreatedAt.Default.(func() time.Time)
+	systempromptFields := schema.SystemPrompt{}.Fields()
+	_ = systempromptFields
+	// systempromptDescEncrypted is the schema descriptor for encrypted field.
+	systempromptDescEncrypted := systempromptFields[2].Descriptor()
+	// systemprompt.DefaultEncrypted holds the default value on creation for the encrypted field.
+	systemprompt.DefaultEncrypted = systempromptDescEncrypted.Default.(bool)
+	// systempromptDescSize is the schema descriptor for size field.
+	systempromptDescSize := systempromptFields[3].Descriptor()
+	// systemprompt.SizeValidator is a validator for the "size" field. It is called by the builders before save.
+	systemprompt.SizeValidator = systempromptDescSize.Validators[0].(func(int) error)
+	// systempromptDescCreatedAt is the schema descriptor for created_at field.
+	systempromptDescCreatedAt := systempromptFields[4].Descriptor()
+	// systemprompt.DefaultCreatedAt holds the default value on creation for the created_at field.
+	systemprompt.DefaultCreatedAt = systempromptDescCreatedAt.Default.(func() time.Time)
+	// systempromptDescID is the schema descriptor for id field.
+	systempromptDescID := systempromptFields[0].Descriptor()
+	// systemprompt.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	systemprompt.IDValidator = systempromptDescID.Validators[0].(func(string) error)
+	tooldefinitionFields := schema.ToolDefinition{}.Fields()
+	_ = tooldefinitionFields
+	// tooldefinitionDescCreatedAt is the schema descriptor for created_at field.
+	tooldefinitionDescCreatedAt := tooldefinitionFields[5].Descriptor()
+	// tooldefinition.DefaultCreatedAt holds the default value on creation for the created_at field.
+	tooldefinition.DefaultCreatedAt = tooldefinitionDescCreatedAt.Default.(func() time.Time)
+	// tooldefinitionDescID is the schema descriptor for id field.
+	tooldefinitionDescID := tooldefinitionFields[0].Descriptor()
+	// tooldefinition.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	tooldefinition.IDValidator = tooldefinitionDescID.Validators[0].(func(string) error)
 }
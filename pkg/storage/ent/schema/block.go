@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+)
+
+// Block holds the schema definition for the Block entity.
+// This stores large content blocks once by content hash so that repeated
+// system prompts and file contents are not re-stored on every turn. Nodes
+// reference blocks from their content instead of embedding them.
+type Block struct {
+	ent.Schema
+}
+
+// Fields of the Block.
+func (Block) Fields() []ent.Field {
+	return []ent.Field{
+		// id is the SHA-256 hash of the block's JSON encoding
+		field.String("id").
+			StorageKey("hash").
+			Unique().
+			Immutable().
+			NotEmpty(),
+
+		// data is the content block, or a sealed envelope when encrypted
+		field.JSON("data", map[string]any{}),
+
+		// size is the length of the block's JSON encoding in bytes
+		field.Int("size").
+			NonNegative(),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entsql.Default("CURRENT_TIMESTAMP")),
+	}
+}
@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// DeadLetter holds the schema definition for the DeadLetter entity.
+// This stores raw proxy payloads that a provider failed to parse so they can
+// be diagnosed and reprocessed.
+type DeadLetter struct {
+	ent.Schema
+}
+
+// Fields of the DeadLetter.
+func (DeadLetter) Fields() []ent.Field {
+	return []ent.Field{
+		// stage is the half of the turn that failed to parse ("request", "response")
+		field.String("stage").
+			NotEmpty(),
+
+		// provider is the provider whose parser was used
+		field.String("provider").
+			Optional(),
+
+		// agent_name identifies the agent harness, if routed through one
+		field.String("agent_name").
+			Optional(),
+
+		// path is the upstream request path
+		field.String("path").
+			Optional(),
+
+		// headers holds the request headers with credentials removed, or
+		// only the sealed headers under "sealed" when encrypted
+		field.JSON("headers", map[string]string{}).
+			Optional(),
+
+		// request is the raw request body, sealed when encrypted
+		field.Text("request").
+			Optional(),
+
+		// response is the raw response body, when one was captured, sealed
+		// when encrypted
+		field.Text("response").
+			Optional(),
+
+		// encrypted is set when the request, response, and headers are
+		// sealed with the database key
+		field.Bool("encrypted").
+			Default(false),
+
+		// error is the parse error message
+		field.Text("error").
+			Optional(),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entsql.Default("CURRENT_TIMESTAMP")),
+	}
+}
+
+// Indexes of the DeadLetter.
+func (DeadLetter) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("created_at"),
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
 )
 
 // Node holds the schema definition for the Node entity.
@@ -62,10 +64,22 @@ func (Node) Fields() []ent.Field {
 		field.String("agent_name").
 			Optional(),
 
+		// session_id identifies the agent session the node was captured in
+		field.String("session_id").
+			Optional().
+			Nillable(),
+
 		// stop_reason indicates why generation stopped (only for responses)
 		field.String("stop_reason").
 			Optional(),
 
+		// tool_names lists the tools called in content as ",Read,Bash,"
+		// so conversations can be filtered by tool without reading content.
+		// Empty when no tools were called; null for nodes not yet indexed.
+		field.String("tool_names").
+			Optional().
+			Nillable(),
+
 		// prompt_tokens is the number of prompt tokens used
 		field.Int("prompt_tokens").
 			Optional().
@@ -91,6 +105,12 @@ func (Node) Fields() []ent.Field {
 			Optional().
 			Nillable(),
 
+		// reasoning_tokens is the number of completion tokens spent on hidden
+		// reasoning (OpenAI o-series), included in completion_tokens
+		field.Int("reasoning_tokens").
+			Optional().
+			Nillable(),
+
 		// total_duration_ns is the total duration in nanoseconds
 		field.Int64("total_duration_ns").
 			Optional().
@@ -101,11 +121,144 @@ func (Node) Fields() []ent.Field {
 			Optional().
 			Nillable(),
 
+		// usage_estimated is set when the token counts were estimated locally
+		// because the provider did not report usage
+		field.Bool("usage_estimated").
+			Optional(),
+
+		// request_started_at is when the proxy forwarded the request upstream
+		field.Time("request_started_at").
+			Optional().
+			Nillable(),
+
+		// first_chunk_at is when the proxy received the first streamed chunk
+		field.Time("first_chunk_at").
+			Optional().
+			Nillable(),
+
+		// response_completed_at is when the proxy finished receiving the response
+		field.Time("response_completed_at").
+			Optional().
+			Nillable(),
+
+		// error_status is the HTTP status of an upstream error (only for error nodes)
+		field.Int("error_status").
+			Optional().
+			Nillable(),
+
+		// error_type is the provider's error type or code (only for error nodes)
+		field.String("error_type").
+			Optional().
+			Nillable(),
+
+		// error_message is the provider's error message (only for error nodes)
+		field.String("error_message").
+			Optional().
+			Nillable(),
+
+		// retry_after_seconds is the upstream Retry-After delay (only for error nodes)
+		field.Int("retry_after_seconds").
+			Optional().
+			Nillable(),
+
+		// error_attempt numbers the attempt of a retried request (only for error nodes)
+		field.Int("error_attempt").
+			Optional().
+			Nillable(),
+
 		// project is the git repository or project name that produced this node
 		field.String("project").
 			Optional().
 			Nillable(),
 
+		// user is the identity of the person whose agent produced this node
+		field.String("user").
+			Optional().
+			Nillable(),
+
+		// trace_id is the OpenTelemetry trace ID of the request that stored this node
+		field.String("trace_id").
+			Optional().
+			Nillable(),
+
+		// run_id identifies the agent run, such as one launch by tapes start,
+		// that stored this node
+		field.String("run_id").
+			Optional().
+			Nillable(),
+
+		// agent_version is the version of the agent that stored this node,
+		// when it reported one
+		field.String("agent_version").
+			Optional().
+			Nillable(),
+
+		// system_prompt_hash is the hash of the SystemPrompt of the request
+		// that stored this node (only for responses)
+		field.String("system_prompt_hash").
+			Optional().
+			Nillable(),
+
+		// policy_violations are the guardrail rules the turn broke, as
+		// recorded by the proxy's policy engine
+		field.JSON("policy_violations", []llm.PolicyViolation{}).
+			Optional(),
+
+		// signer_key and signature sign the node's hash with the Ed25519
+		// key of the installation that stored it, when it signs nodes
+		field.Bytes("signer_key").
+			Optional().
+			Nillable(),
+		field.Bytes("signature").
+			Optional().
+			Nillable(),
+
+		// shared_by names who shared this node, for nodes received in a
+		// shared session bundle
+		field.String("shared_by").
+			Optional().
+			Nillable(),
+
+		// superseded_by is the hash of the node that replaced this one when
+		// its turn was reprocessed from raw payloads
+		field.String("superseded_by").
+			Optional().
+			Nillable(),
+
+		// title, summary, and outcome annotate the root node of a session
+		// once the summarizer has read its transcript
+		field.String("title").
+			Optional().
+			Nillable(),
+		field.Text("summary").
+			Optional().
+			Nillable(),
+		field.String("outcome").
+			Optional().
+			Nillable(),
+
+		// summarized_at is when the session rooted at this node was last summarized
+		field.Time("summarized_at").
+			Optional().
+			Nillable(),
+
+		// ended_at and end_reason mark the end of the session rooted at this
+		// node: when it went idle or when the agent running it exited
+		field.Time("ended_at").
+			Optional().
+			Nillable(),
+		field.String("end_reason").
+			Optional().
+			Nillable(),
+
+		// turn_index is the node's position in its conversation: 0 for the
+		// root and one more than its parent's for every other node. It orders
+		// transcripts without relying on clocks; null for nodes stored before
+		// it was recorded
+		field.Int("turn_index").
+			Optional().
+			Nillable(),
+
 		// created_at is the timestamp when the node was created
 		field.Time("created_at").
 			Default(time.Now).
@@ -137,6 +290,18 @@ func (Node) Indexes() []ent.Index {
 
 		// Index on project for filtering by project
 		index.Fields("project"),
+
+		// Index on user for filtering by user
+		index.Fields("user"),
+
+		// Index on trace_id for looking up the turns of a trace
+		index.Fields("trace_id"),
+
+		// Index on run_id for looking up the turns of an agent run
+		index.Fields("run_id"),
+
+		// Index on system_prompt_hash for looking up the turns of a prompt
+		index.Fields("system_prompt_hash"),
 	}
 }
 
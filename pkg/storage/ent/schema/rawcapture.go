@@ -0,0 +1,81 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// RawCapture holds the schema definition for the RawCapture entity.
+// This stores the original, compressed payloads of stored turns so they can
+// be re-parsed after parser fixes.
+type RawCapture struct {
+	ent.Schema
+}
+
+// Fields of the RawCapture.
+func (RawCapture) Fields() []ent.Field {
+	return []ent.Field{
+		// node_hash is the hash of the turn's response node
+		field.String("node_hash").
+			NotEmpty(),
+
+		// provider is the provider whose parser reads the payloads
+		field.String("provider"),
+
+		// agent_name identifies the agent harness, if routed through one
+		field.String("agent_name").
+			Optional(),
+
+		// session_id identifies the agent session the turn was captured in
+		field.String("session_id").
+			Optional(),
+
+		// run_id identifies the agent run the turn was captured in
+		field.String("run_id").
+			Optional(),
+
+		// project is the project the turn was attributed to
+		field.String("project").
+			Optional(),
+
+		// user is the person the turn was attributed to
+		field.String("user").
+			Optional(),
+
+		// path is the upstream request path
+		field.String("path").
+			Optional(),
+
+		// streamed is set when response holds a stream's data payloads
+		field.Bool("streamed").
+			Default(false),
+
+		// encrypted is set when the payloads are sealed with the database key
+		field.Bool("encrypted").
+			Default(false),
+
+		// request is the gzip-compressed raw request body
+		field.Bytes("request"),
+
+		// response is the gzip-compressed raw response body
+		field.Bytes("response").
+			Optional(),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entsql.Default("CURRENT_TIMESTAMP")),
+	}
+}
+
+// Indexes of the RawCapture.
+func (RawCapture) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("node_hash"),
+		index.Fields("created_at"),
+	}
+}
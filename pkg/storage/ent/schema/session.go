@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Session holds the schema definition for the Session entity.
+// This is a summary of one conversation, from its root to a leaf, kept up
+// to date as turns are stored so that listing sessions does not read
+// every node.
+type Session struct {
+	ent.Schema
+}
+
+// Fields of the Session.
+func (Session) Fields() []ent.Field {
+	return []ent.Field{
+		// id is the hash of the conversation's leaf node
+		field.String("id").
+			StorageKey("leaf_hash").
+			Unique().
+			Immutable().
+			NotEmpty(),
+
+		// root_hash is the hash of the conversation's oldest stored node
+		field.String("root_hash").
+			NotEmpty(),
+
+		// turn_count is the number of nodes from the root to the leaf
+		field.Int("turn_count").
+			Default(0),
+
+		// Token totals over the conversation's nodes
+		field.Int64("prompt_tokens").
+			Default(0),
+		field.Int64("completion_tokens").
+			Default(0),
+		field.Int64("total_tokens").
+			Default(0),
+		field.Int64("cache_creation_input_tokens").
+			Default(0),
+		field.Int64("cache_read_input_tokens").
+			Default(0),
+
+		// cost is the conversation's cost in USD, as priced by the process
+		// that stored its turns
+		field.Float("cost").
+			Default(0),
+
+		// last_activity is when the leaf was created
+		field.Time("last_activity"),
+
+		// revision increases whenever the conversation changes in a way its
+		// leaf does not show, such as its root being annotated or ended, so
+		// that readers caching per-session data can tell what to reload
+		field.Int64("revision").
+			Default(0),
+	}
+}
+
+// Indexes of the Session.
+func (Session) Indexes() []ent.Index {
+	return []ent.Index{
+		// Index on root_hash for updating every branch of a conversation
+		index.Fields("root_hash"),
+
+		// Index on last_activity for listing recent sessions
+		index.Fields("last_activity"),
+	}
+}
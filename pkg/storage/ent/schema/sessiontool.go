@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// SessionTool holds the schema definition for the SessionTool entity.
+// This links agent sessions to the tool definitions they offered the model,
+// once per session and definition.
+type SessionTool struct {
+	ent.Schema
+}
+
+// Fields of the SessionTool.
+func (SessionTool) Fields() []ent.Field {
+	return []ent.Field{
+		// session_id identifies the agent session
+		field.String("session_id").
+			NotEmpty(),
+
+		// tool_hash is the hash of the ToolDefinition offered
+		field.String("tool_hash").
+			NotEmpty(),
+
+		// run_id identifies the agent run the session belongs to
+		field.String("run_id").
+			Optional(),
+
+		// agent_name identifies the agent harness, if routed through one
+		field.String("agent_name").
+			Optional(),
+
+		// node_hash is the hash of the response node of the first turn
+		// that offered the definition
+		field.String("node_hash").
+			NotEmpty(),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entsql.Default("CURRENT_TIMESTAMP")),
+	}
+}
+
+// Indexes of the SessionTool.
+func (SessionTool) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("session_id", "tool_hash").
+			Unique(),
+		index.Fields("tool_hash"),
+	}
+}
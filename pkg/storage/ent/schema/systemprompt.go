@@ -0,0 +1,45 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+)
+
+// SystemPrompt holds the schema definition for the SystemPrompt entity.
+// This stores each distinct system prompt once by hash, so that the many
+// turns sent with the same prompt reference it from their response node's
+// system_prompt_hash instead of storing it again.
+type SystemPrompt struct {
+	ent.Schema
+}
+
+// Fields of the SystemPrompt.
+func (SystemPrompt) Fields() []ent.Field {
+	return []ent.Field{
+		// id is the SHA-256 hash of the prompt text
+		field.String("id").
+			StorageKey("hash").
+			Unique().
+			Immutable().
+			NotEmpty(),
+
+		// text is the gzip-compressed prompt, sealed when encrypted
+		field.Bytes("text"),
+
+		// encrypted is set when text is sealed with the database key
+		field.Bool("encrypted").
+			Default(false),
+
+		// size is the length of the prompt in bytes
+		field.Int("size").
+			NonNegative(),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entsql.Default("CURRENT_TIMESTAMP")),
+	}
+}
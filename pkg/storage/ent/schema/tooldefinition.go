@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+)
+
+// ToolDefinition holds the schema definition for the ToolDefinition entity.
+// This stores each distinct tool definition offered to a model once by
+// hash, so that agents sending the same tools on every turn do not re-store
+// them. Sessions reference definitions through SessionTool.
+type ToolDefinition struct {
+	ent.Schema
+}
+
+// Fields of the ToolDefinition.
+func (ToolDefinition) Fields() []ent.Field {
+	return []ent.Field{
+		// id is the SHA-256 hash of the definition's JSON encoding
+		field.String("id").
+			StorageKey("hash").
+			Unique().
+			Immutable().
+			NotEmpty(),
+
+		// type is the provider's tool type, such as function or web_search
+		field.String("type").
+			Optional(),
+
+		field.String("name"),
+
+		field.String("description").
+			Optional(),
+
+		// schema is the JSON schema of the tool's input
+		field.JSON("schema", map[string]any{}).
+			Optional(),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Annotations(entsql.Default("CURRENT_TIMESTAMP")),
+	}
+}
@@ -0,0 +1,207 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+)
+
+// Session is the model entity for the Session schema.
+type Session struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID string `json:"id,omitempty"`
+	// RootHash holds the value of the "root_hash" field.
+	RootHash string `json:"root_hash,omitempty"`
+	// TurnCount holds the value of the "turn_count" field.
+	TurnCount int `json:"turn_count,omitempty"`
+	// PromptTokens holds the value of the "prompt_tokens" field.
+	PromptTokens int64 `json:"prompt_tokens,omitempty"`
+	// CompletionTokens holds the value of the "completion_tokens" field.
+	CompletionTokens int64 `json:"completion_tokens,omitempty"`
+	// TotalTokens holds the value of the "total_tokens" field.
+	TotalTokens int64 `json:"total_tokens,omitempty"`
+	// CacheCreationInputTokens holds the value of the "cache_creation_input_tokens" field.
+	CacheCreationInputTokens int64 `json:"cache_creation_input_tokens,omitempty"`
+	// CacheReadInputTokens holds the value of the "cache_read_input_tokens" field.
+	CacheReadInputTokens int64 `json:"cache_read_input_tokens,omitempty"`
+	// Cost holds the value of the "cost" field.
+	Cost float64 `json:"cost,omitempty"`
+	// LastActivity holds the value of the "last_activity" field.
+	LastActivity time.Time `json:"last_activity,omitempty"`
+	// Revision holds the value of the "revision" field.
+	Revision     int64 `json:"revision,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Session) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case session.FieldCost:
+			values[i] = new(sql.NullFloat64)
+		case session.FieldTurnCount, session.FieldPromptTokens, session.FieldCompletionTokens, session.FieldTotalTokens, session.FieldCacheCreationInputTokens, session.FieldCacheReadInputTokens, session.FieldRevision:
+			values[i] = new(sql.NullInt64)
+		case session.FieldID, session.FieldRootHash:
+			values[i] = new(sql.NullString)
+		case session.FieldLastActivity:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Session fields.
+func (_m *Session) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case session.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				_m.ID = value.String
+			}
+		case session.FieldRootHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field root_hash", values[i])
+			} else if value.Valid {
+				_m.RootHash = value.String
+			}
+		case session.FieldTurnCount:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field turn_count", values[i])
+			} else if value.Valid {
+				_m.TurnCount = int(value.Int64)
+			}
+		case session.FieldPromptTokens:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field prompt_tokens", values[i])
+			} else if value.Valid {
+				_m.PromptTokens = value.Int64
+			}
+		case session.FieldCompletionTokens:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field completion_tokens", values[i])
+			} else if value.Valid {
+				_m.CompletionTokens = value.Int64
+			}
+		case session.FieldTotalTokens:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field total_tokens", values[i])
+			} else if value.Valid {
+				_m.TotalTokens = value.Int64
+			}
+		case session.FieldCacheCreationInputTokens:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field cache_creation_input_tokens", values[i])
+			} else if value.Valid {
+				_m.CacheCreationInputTokens = value.Int64
+			}
+		case session.FieldCacheReadInputTokens:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field cache_read_input_tokens", values[i])
+			} else if value.Valid {
+				_m.CacheReadInputTokens = value.Int64
+			}
+		case session.FieldCost:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field cost", values[i])
+			} else if value.Valid {
+				_m.Cost = value.Float64
+			}
+		case session.FieldLastActivity:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_activity", values[i])
+			} else if value.Valid {
+				_m.LastActivity = value.Time
+			}
+		case session.FieldRevision:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field revision", values[i])
+			} else if value.Valid {
+				_m.Revision = value.Int64
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Session.
+// This includes values selected through modifiers, order, etc.
+func (_m *Session) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Session.
+// Note that you need to call Session.Unwrap() before calling this method if this Session
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Session) Update() *SessionUpdateOne {
+	return NewSessionClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Session entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Session) Unwrap() *Session {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Session is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Session) String() string {
+	var builder strings.Builder
+	builder.WriteString("Session(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("root_hash=")
+	builder.WriteString(_m.RootHash)
+	builder.WriteString(", ")
+	builder.WriteString("turn_count=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TurnCount))
+	builder.WriteString(", ")
+	builder.WriteString("prompt_tokens=")
+	builder.WriteString(fmt.Sprintf("%v", _m.PromptTokens))
+	builder.WriteString(", ")
+	builder.WriteString("completion_tokens=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CompletionTokens))
+	builder.WriteString(", ")
+	builder.WriteString("total_tokens=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TotalTokens))
+	builder.WriteString(", ")
+	builder.WriteString("cache_creation_input_tokens=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CacheCreationInputTokens))
+	builder.WriteString(", ")
+	builder.WriteString("cache_read_input_tokens=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CacheReadInputTokens))
+	builder.WriteString(", ")
+	builder.WriteString("cost=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Cost))
+	builder.WriteString(", ")
+	builder.WriteString("last_activity=")
+	builder.WriteString(_m.LastActivity.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("revision=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Revision))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Sessions is a parsable slice of Session.
+type Sessions []*Session
@@ -0,0 +1,142 @@
+// Code generated by ent, DO NOT EDIT.
+
+package session
+
+import (
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the session type in the database.
+	Label = "session"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "leaf_hash"
+	// FieldRootHash holds the string denoting the root_hash field in the database.
+	FieldRootHash = "root_hash"
+	// FieldTurnCount holds the string denoting the turn_count field in the database.
+	FieldTurnCount = "turn_count"
+	// FieldPromptTokens holds the string denoting the prompt_tokens field in the database.
+	FieldPromptTokens = "prompt_tokens"
+	// FieldCompletionTokens holds the string denoting the completion_tokens field in the database.
+	FieldCompletionTokens = "completion_tokens"
+	// FieldTotalTokens holds the string denoting the total_tokens field in the database.
+	FieldTotalTokens = "total_tokens"
+	// FieldCacheCreationInputTokens holds the string denoting the cache_creation_input_tokens field in the database.
+	FieldCacheCreationInputTokens = "cache_creation_input_tokens"
+	// FieldCacheReadInputTokens holds the string denoting the cache_read_input_tokens field in the database.
+	FieldCacheReadInputTokens = "cache_read_input_tokens"
+	// FieldCost holds the string denoting the cost field in the database.
+	FieldCost = "cost"
+	// FieldLastActivity holds the string denoting the last_activity field in the database.
+	FieldLastActivity = "last_activity"
+	// FieldRevision holds the string denoting the revision field in the database.
+	FieldRevision = "revision"
+	// Table holds the table name of the session in the database.
+	Table = "sessions"
+)
+
+// Columns holds all SQL columns for session fields.
+var Columns = []string{
+	FieldID,
+	FieldRootHash,
+	FieldTurnCount,
+	FieldPromptTokens,
+	FieldCompletionTokens,
+	FieldTotalTokens,
+	FieldCacheCreationInputTokens,
+	FieldCacheReadInputTokens,
+	FieldCost,
+	FieldLastActivity,
+	FieldRevision,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// RootHashValidator is a validator for the "root_hash" field. It is called by the builders before save.
+	RootHashValidator func(string) error
+	// DefaultTurnCount holds the default value on creation for the "turn_count" field.
+	DefaultTurnCount int
+	// DefaultPromptTokens holds the default value on creation for the "prompt_tokens" field.
+	DefaultPromptTokens int64
+	// DefaultCompletionTokens holds the default value on creation for the "completion_tokens" field.
+	DefaultCompletionTokens int64
+	// DefaultTotalTokens holds the default value on creation for the "total_tokens" field.
+	DefaultTotalTokens int64
+	// DefaultCacheCreationInputTokens holds the default value on creation for the "cache_creation_input_tokens" field.
+	DefaultCacheCreationInputTokens int64
+	// DefaultCacheReadInputTokens holds the default value on creation for the "cache_read_input_tokens" field.
+	DefaultCacheReadInputTokens int64
+	// DefaultCost holds the default value on creation for the "cost" field.
+	DefaultCost float64
+	// DefaultRevision holds the default value on creation for the "revision" field.
+	DefaultRevision int64
+	// IDValidator is a validator for the "id" field. It is called by the builders before save.
+	IDValidator func(string) error
+)
+
+// OrderOption defines the ordering options for the Session queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByRootHash orders the results by the root_hash field.
+func ByRootHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRootHash, opts...).ToFunc()
+}
+
+// ByTurnCount orders the results by the turn_count field.
+func ByTurnCount(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTurnCount, opts...).ToFunc()
+}
+
+// ByPromptTokens orders the results by the prompt_tokens field.
+func ByPromptTokens(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPromptTokens, opts...).ToFunc()
+}
+
+// ByCompletionTokens orders the results by the completion_tokens field.
+func ByCompletionTokens(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCompletionTokens, opts...).ToFunc()
+}
+
+// ByTotalTokens orders the results by the total_tokens field.
+func ByTotalTokens(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTotalTokens, opts...).ToFunc()
+}
+
+// ByCacheCreationInputTokens orders the results by the cache_creation_input_tokens field.
+func ByCacheCreationInputTokens(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCacheCreationInputTokens, opts...).ToFunc()
+}
+
+// ByCacheReadInputTokens orders the results by the cache_read_input_tokens field.
+func ByCacheReadInputTokens(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCacheReadInputTokens, opts...).ToFunc()
+}
+
+// ByCost orders the results by the cost field.
+func ByCost(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCost, opts...).ToFunc()
+}
+
+// ByLastActivity orders the results by the last_activity field.
+func ByLastActivity(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastActivity, opts...).ToFunc()
+}
+
+// ByRevision orders the results by the revision field.
+func ByRevision(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRevision, opts...).ToFunc()
+}
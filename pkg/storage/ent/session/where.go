@@ -0,0 +1,555 @@
+// Code generated by ent, DO NOT EDIT.
+
+package session
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id string) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id string) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id string) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...string) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...string) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id string) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id string) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id string) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id string) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldID, id))
+}
+
+// IDEqualFold applies the EqualFold predicate on the ID field.
+func IDEqualFold(id string) predicate.Session {
+	return predicate.Session(sql.FieldEqualFold(FieldID, id))
+}
+
+// IDContainsFold applies the ContainsFold predicate on the ID field.
+func IDContainsFold(id string) predicate.Session {
+	return predicate.Session(sql.FieldContainsFold(FieldID, id))
+}
+
+// RootHash applies equality check predicate on the "root_hash" field. It's identical to RootHashEQ.
+func RootHash(v string) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldRootHash, v))
+}
+
+// TurnCount applies equality check predicate on the "turn_count" field. It's identical to TurnCountEQ.
+func TurnCount(v int) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldTurnCount, v))
+}
+
+// PromptTokens applies equality check predicate on the "prompt_tokens" field. It's identical to PromptTokensEQ.
+func PromptTokens(v int64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldPromptTokens, v))
+}
+
+// CompletionTokens applies equality check predicate on the "completion_tokens" field. It's identical to CompletionTokensEQ.
+func CompletionTokens(v int64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldCompletionTokens, v))
+}
+
+// TotalTokens applies equality check predicate on the "total_tokens" field. It's identical to TotalTokensEQ.
+func TotalTokens(v int64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldTotalTokens, v))
+}
+
+// CacheCreationInputTokens applies equality check predicate on the "cache_creation_input_tokens" field. It's identical to CacheCreationInputTokensEQ.
+func CacheCreationInputTokens(v int64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldCacheCreationInputTokens, v))
+}
+
+// CacheReadInputTokens applies equality check predicate on the "cache_read_input_tokens" field. It's identical to CacheReadInputTokensEQ.
+func CacheReadInputTokens(v int64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldCacheReadInputTokens, v))
+}
+
+// Cost applies equality check predicate on the "cost" field. It's identical to CostEQ.
+func Cost(v float64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldCost, v))
+}
+
+// LastActivity applies equality check predicate on the "last_activity" field. It's identical to LastActivityEQ.
+func LastActivity(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldLastActivity, v))
+}
+
+// Revision applies equality check predicate on the "revision" field. It's identical to RevisionEQ.
+func Revision(v int64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldRevision, v))
+}
+
+// RootHashEQ applies the EQ predicate on the "root_hash" field.
+func RootHashEQ(v string) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldRootHash, v))
+}
+
+// RootHashNEQ applies the NEQ predicate on the "root_hash" field.
+func RootHashNEQ(v string) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldRootHash, v))
+}
+
+// RootHashIn applies the In predicate on the "root_hash" field.
+func RootHashIn(vs ...string) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldRootHash, vs...))
+}
+
+// RootHashNotIn applies the NotIn predicate on the "root_hash" field.
+func RootHashNotIn(vs ...string) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldRootHash, vs...))
+}
+
+// RootHashGT applies the GT predicate on the "root_hash" field.
+func RootHashGT(v string) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldRootHash, v))
+}
+
+// RootHashGTE applies the GTE predicate on the "root_hash" field.
+func RootHashGTE(v string) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldRootHash, v))
+}
+
+// RootHashLT applies the LT predicate on the "root_hash" field.
+func RootHashLT(v string) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldRootHash, v))
+}
+
+// RootHashLTE applies the LTE predicate on the "root_hash" field.
+func RootHashLTE(v string) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldRootHash, v))
+}
+
+// RootHashContains applies the Contains predicate on the "root_hash" field.
+func RootHashContains(v string) predicate.Session {
+	return predicate.Session(sql.FieldContains(FieldRootHash, v))
+}
+
+// RootHashHasPrefix applies the HasPrefix predicate on the "root_hash" field.
+func RootHashHasPrefix(v string) predicate.Session {
+	return predicate.Session(sql.FieldHasPrefix(FieldRootHash, v))
+}
+
+// RootHashHasSuffix applies the HasSuffix predicate on the "root_hash" field.
+func RootHashHasSuffix(v string) predicate.Session {
+	return predicate.Session(sql.FieldHasSuffix(FieldRootHash, v))
+}
+
+// RootHashEqualFold applies the EqualFold predicate on the "root_hash" field.
+func RootHashEqualFold(v string) predicate.Session {
+	return predicate.Session(sql.FieldEqualFold(FieldRootHash, v))
+}
+
+// RootHashContainsFold applies the ContainsFold predicate on the "root_hash" field.
+func RootHashContainsFold(v string) predicate.Session {
+	return predicate.Session(sql.FieldContainsFold(FieldRootHash, v))
+}
+
+// TurnCountEQ applies the EQ predicate on the "turn_count" field.
+func TurnCountEQ(v int) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldTurnCount, v))
+}
+
+// TurnCountNEQ applies the NEQ predicate on the "turn_count" field.
+func TurnCountNEQ(v int) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldTurnCount, v))
+}
+
+// TurnCountIn applies the In predicate on the "turn_count" field.
+func TurnCountIn(vs ...int) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldTurnCount, vs...))
+}
+
+// TurnCountNotIn applies the NotIn predicate on the "turn_count" field.
+func TurnCountNotIn(vs ...int) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldTurnCount, vs...))
+}
+
+// TurnCountGT applies the GT predicate on the "turn_count" field.
+func TurnCountGT(v int) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldTurnCount, v))
+}
+
+// TurnCountGTE applies the GTE predicate on the "turn_count" field.
+func TurnCountGTE(v int) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldTurnCount, v))
+}
+
+// TurnCountLT applies the LT predicate on the "turn_count" field.
+func TurnCountLT(v int) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldTurnCount, v))
+}
+
+// TurnCountLTE applies the LTE predicate on the "turn_count" field.
+func TurnCountLTE(v int) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldTurnCount, v))
+}
+
+// PromptTokensEQ applies the EQ predicate on the "prompt_tokens" field.
+func PromptTokensEQ(v int64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldPromptTokens, v))
+}
+
+// PromptTokensNEQ applies the NEQ predicate on the "prompt_tokens" field.
+func PromptTokensNEQ(v int64) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldPromptTokens, v))
+}
+
+// PromptTokensIn applies the In predicate on the "prompt_tokens" field.
+func PromptTokensIn(vs ...int64) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldPromptTokens, vs...))
+}
+
+// PromptTokensNotIn applies the NotIn predicate on the "prompt_tokens" field.
+func PromptTokensNotIn(vs ...int64) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldPromptTokens, vs...))
+}
+
+// PromptTokensGT applies the GT predicate on the "prompt_tokens" field.
+func PromptTokensGT(v int64) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldPromptTokens, v))
+}
+
+// PromptTokensGTE applies the GTE predicate on the "prompt_tokens" field.
+func PromptTokensGTE(v int64) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldPromptTokens, v))
+}
+
+// PromptTokensLT applies the LT predicate on the "prompt_tokens" field.
+func PromptTokensLT(v int64) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldPromptTokens, v))
+}
+
+// PromptTokensLTE applies the LTE predicate on the "prompt_tokens" field.
+func PromptTokensLTE(v int64) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldPromptTokens, v))
+}
+
+// CompletionTokensEQ applies the EQ predicate on the "completion_tokens" field.
+func CompletionTokensEQ(v int64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldCompletionTokens, v))
+}
+
+// CompletionTokensNEQ applies the NEQ predicate on the "completion_tokens" field.
+func CompletionTokensNEQ(v int64) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldCompletionTokens, v))
+}
+
+// CompletionTokensIn applies the In predicate on the "completion_tokens" field.
+func CompletionTokensIn(vs ...int64) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldCompletionTokens, vs...))
+}
+
+// CompletionTokensNotIn applies the NotIn predicate on the "completion_tokens" field.
+func CompletionTokensNotIn(vs ...int64) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldCompletionTokens, vs...))
+}
+
+// CompletionTokensGT applies the GT predicate on the "completion_tokens" field.
+func CompletionTokensGT(v int64) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldCompletionTokens, v))
+}
+
+// CompletionTokensGTE applies the GTE predicate on the "completion_tokens" field.
+func CompletionTokensGTE(v int64) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldCompletionTokens, v))
+}
+
+// CompletionTokensLT applies the LT predicate on the "completion_tokens" field.
+func CompletionTokensLT(v int64) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldCompletionTokens, v))
+}
+
+// CompletionTokensLTE applies the LTE predicate on the "completion_tokens" field.
+func CompletionTokensLTE(v int64) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldCompletionTokens, v))
+}
+
+// TotalTokensEQ applies the EQ predicate on the "total_tokens" field.
+func TotalTokensEQ(v int64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldTotalTokens, v))
+}
+
+// TotalTokensNEQ applies the NEQ predicate on the "total_tokens" field.
+func TotalTokensNEQ(v int64) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldTotalTokens, v))
+}
+
+// TotalTokensIn applies the In predicate on the "total_tokens" field.
+func TotalTokensIn(vs ...int64) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldTotalTokens, vs...))
+}
+
+// TotalTokensNotIn applies the NotIn predicate on the "total_tokens" field.
+func TotalTokensNotIn(vs ...int64) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldTotalTokens, vs...))
+}
+
+// TotalTokensGT applies the GT predicate on the "total_tokens" field.
+func TotalTokensGT(v int64) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldTotalTokens, v))
+}
+
+// TotalTokensGTE applies the GTE predicate on the "total_tokens" field.
+func TotalTokensGTE(v int64) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldTotalTokens, v))
+}
+
+// TotalTokensLT applies the LT predicate on the "total_tokens" field.
+func TotalTokensLT(v int64) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldTotalTokens, v))
+}
+
+// TotalTokensLTE applies the LTE predicate on the "total_tokens" field.
+func TotalTokensLTE(v int64) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldTotalTokens, v))
+}
+
+// CacheCreationInputTokensEQ applies the EQ predicate on the "cache_creation_input_tokens" field.
+func CacheCreationInputTokensEQ(v int64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldCacheCreationInputTokens, v))
+}
+
+// CacheCreationInputTokensNEQ applies the NEQ predicate on the "cache_creation_input_tokens" field.
+func CacheCreationInputTokensNEQ(v int64) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldCacheCreationInputTokens, v))
+}
+
+// CacheCreationInputTokensIn applies the In predicate on the "cache_creation_input_tokens" field.
+func CacheCreationInputTokensIn(vs ...int64) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldCacheCreationInputTokens, vs...))
+}
+
+// CacheCreationInputTokensNotIn applies the NotIn predicate on the "cache_creation_input_tokens" field.
+func CacheCreationInputTokensNotIn(vs ...int64) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldCacheCreationInputTokens, vs...))
+}
+
+// CacheCreationInputTokensGT applies the GT predicate on the "cache_creation_input_tokens" field.
+func CacheCreationInputTokensGT(v int64) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldCacheCreationInputTokens, v))
+}
+
+// CacheCreationInputTokensGTE applies the GTE predicate on the "cache_creation_input_tokens" field.
+func CacheCreationInputTokensGTE(v int64) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldCacheCreationInputTokens, v))
+}
+
+// CacheCreationInputTokensLT applies the LT predicate on the "cache_creation_input_tokens" field.
+func CacheCreationInputTokensLT(v int64) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldCacheCreationInputTokens, v))
+}
+
+// CacheCreationInputTokensLTE applies the LTE predicate on the "cache_creation_input_tokens" field.
+func CacheCreationInputTokensLTE(v int64) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldCacheCreationInputTokens, v))
+}
+
+// CacheReadInputTokensEQ applies the EQ predicate on the "cache_read_input_tokens" field.
+func CacheReadInputTokensEQ(v int64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldCacheReadInputTokens, v))
+}
+
+// CacheReadInputTokensNEQ applies the NEQ predicate on the "cache_read_input_tokens" field.
+func CacheReadInputTokensNEQ(v int64) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldCacheReadInputTokens, v))
+}
+
+// CacheReadInputTokensIn applies the In predicate on the "cache_read_input_tokens" field.
+func CacheReadInputTokensIn(vs ...int64) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldCacheReadInputTokens, vs...))
+}
+
+// CacheReadInputTokensNotIn applies the NotIn predicate on the "cache_read_input_tokens" field.
+func CacheReadInputTokensNotIn(vs ...int64) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldCacheReadInputTokens, vs...))
+}
+
+// CacheReadInputTokensGT applies the GT predicate on the "cache_read_input_tokens" field.
+func CacheReadInputTokensGT(v int64) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldCacheReadInputTokens, v))
+}
+
+// CacheReadInputTokensGTE applies the GTE predicate on the "cache_read_input_tokens" field.
+func CacheReadInputTokensGTE(v int64) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldCacheReadInputTokens, v))
+}
+
+// CacheReadInputTokensLT applies the LT predicate on the "cache_read_input_tokens" field.
+func CacheReadInputTokensLT(v int64) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldCacheReadInputTokens, v))
+}
+
+// CacheReadInputTokensLTE applies the LTE predicate on the "cache_read_input_tokens" field.
+func CacheReadInputTokensLTE(v int64) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldCacheReadInputTokens, v))
+}
+
+// CostEQ applies the EQ predicate on the "cost" field.
+func CostEQ(v float64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldCost, v))
+}
+
+// CostNEQ applies the NEQ predicate on the "cost" field.
+func CostNEQ(v float64) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldCost, v))
+}
+
+// CostIn applies the In predicate on the "cost" field.
+func CostIn(vs ...float64) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldCost, vs...))
+}
+
+// CostNotIn applies the NotIn predicate on the "cost" field.
+func CostNotIn(vs ...float64) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldCost, vs...))
+}
+
+// CostGT applies the GT predicate on the "cost" field.
+func CostGT(v float64) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldCost, v))
+}
+
+// CostGTE applies the GTE predicate on the "cost" field.
+func CostGTE(v float64) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldCost, v))
+}
+
+// CostLT applies the LT predicate on the "cost" field.
+func CostLT(v float64) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldCost, v))
+}
+
+// CostLTE applies the LTE predicate on the "cost" field.
+func CostLTE(v float64) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldCost, v))
+}
+
+// LastActivityEQ applies the EQ predicate on the "last_activity" field.
+func LastActivityEQ(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldLastActivity, v))
+}
+
+// LastActivityNEQ applies the NEQ predicate on the "last_activity" field.
+func LastActivityNEQ(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldLastActivity, v))
+}
+
+// LastActivityIn applies the In predicate on the "last_activity" field.
+func LastActivityIn(vs ...time.Time) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldLastActivity, vs...))
+}
+
+// LastActivityNotIn applies the NotIn predicate on the "last_activity" field.
+func LastActivityNotIn(vs ...time.Time) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldLastActivity, vs...))
+}
+
+// LastActivityGT applies the GT predicate on the "last_activity" field.
+func LastActivityGT(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldLastActivity, v))
+}
+
+// LastActivityGTE applies the GTE predicate on the "last_activity" field.
+func LastActivityGTE(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldLastActivity, v))
+}
+
+// LastActivityLT applies the LT predicate on the "last_activity" field.
+func LastActivityLT(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldLastActivity, v))
+}
+
+// LastActivityLTE applies the LTE predicate on the "last_activity" field.
+func LastActivityLTE(v time.Time) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldLastActivity, v))
+}
+
+// RevisionEQ applies the EQ predicate on the "revision" field.
+func RevisionEQ(v int64) predicate.Session {
+	return predicate.Session(sql.FieldEQ(FieldRevision, v))
+}
+
+// RevisionNEQ applies the NEQ predicate on the "revision" field.
+func RevisionNEQ(v int64) predicate.Session {
+	return predicate.Session(sql.FieldNEQ(FieldRevision, v))
+}
+
+// RevisionIn applies the In predicate on the "revision" field.
+func RevisionIn(vs ...int64) predicate.Session {
+	return predicate.Session(sql.FieldIn(FieldRevision, vs...))
+}
+
+// RevisionNotIn applies the NotIn predicate on the "revision" field.
+func RevisionNotIn(vs ...int64) predicate.Session {
+	return predicate.Session(sql.FieldNotIn(FieldRevision, vs...))
+}
+
+// RevisionGT applies the GT predicate on the "revision" field.
+func RevisionGT(v int64) predicate.Session {
+	return predicate.Session(sql.FieldGT(FieldRevision, v))
+}
+
+// RevisionGTE applies the GTE predicate on the "revision" field.
+func RevisionGTE(v int64) predicate.Session {
+	return predicate.Session(sql.FieldGTE(FieldRevision, v))
+}
+
+// RevisionLT applies the LT predicate on the "revision" field.
+func RevisionLT(v int64) predicate.Session {
+	return predicate.Session(sql.FieldLT(FieldRevision, v))
+}
+
+// RevisionLTE applies the LTE predicate on the "revision" field.
+func RevisionLTE(v int64) predicate.Session {
+	return predicate.Session(sql.FieldLTE(FieldRevision, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Session) predicate.Session {
+	return predicate.Session(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Session) predicate.Session {
+	return predicate.Session(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Session) predicate.Session {
+	return predicate.Session(sql.NotPredicates(p))
+}
@@ -0,0 +1,424 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+)
+
+// SessionCreate is the builder for creating a Session entity.
+type SessionCreate struct {
+	config
+	mutation *SessionMutation
+	hooks    []Hook
+}
+
+// SetRootHash sets the "root_hash" field.
+func (_c *SessionCreate) SetRootHash(v string) *SessionCreate {
+	_c.mutation.SetRootHash(v)
+	return _c
+}
+
+// SetTurnCount sets the "turn_count" field.
+func (_c *SessionCreate) SetTurnCount(v int) *SessionCreate {
+	_c.mutation.SetTurnCount(v)
+	return _c
+}
+
+// SetNillableTurnCount sets the "turn_count" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableTurnCount(v *int) *SessionCreate {
+	if v != nil {
+		_c.SetTurnCount(*v)
+	}
+	return _c
+}
+
+// SetPromptTokens sets the "prompt_tokens" field.
+func (_c *SessionCreate) SetPromptTokens(v int64) *SessionCreate {
+	_c.mutation.SetPromptTokens(v)
+	return _c
+}
+
+// SetNillablePromptTokens sets the "prompt_tokens" field if the given value is not nil.
+func (_c *SessionCreate) SetNillablePromptTokens(v *int64) *SessionCreate {
+	if v != nil {
+		_c.SetPromptTokens(*v)
+	}
+	return _c
+}
+
+// SetCompletionTokens sets the "completion_tokens" field.
+func (_c *SessionCreate) SetCompletionTokens(v int64) *SessionCreate {
+	_c.mutation.SetCompletionTokens(v)
+	return _c
+}
+
+// SetNillableCompletionTokens sets the "completion_tokens" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableCompletionTokens(v *int64) *SessionCreate {
+	if v != nil {
+		_c.SetCompletionTokens(*v)
+	}
+	return _c
+}
+
+// SetTotalTokens sets the "total_tokens" field.
+func (_c *SessionCreate) SetTotalTokens(v int64) *SessionCreate {
+	_c.mutation.SetTotalTokens(v)
+	return _c
+}
+
+// SetNillableTotalTokens sets the "total_tokens" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableTotalTokens(v *int64) *SessionCreate {
+	if v != nil {
+		_c.SetTotalTokens(*v)
+	}
+	return _c
+}
+
+// SetCacheCreationInputTokens sets the "cache_creation_input_tokens" field.
+func (_c *SessionCreate) SetCacheCreationInputTokens(v int64) *SessionCreate {
+	_c.mutation.SetCacheCreationInputTokens(v)
+	return _c
+}
+
+// SetNillableCacheCreationInputTokens sets the "cache_creation_input_tokens" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableCacheCreationInputTokens(v *int64) *SessionCreate {
+	if v != nil {
+		_c.SetCacheCreationInputTokens(*v)
+	}
+	return _c
+}
+
+// SetCacheReadInputTokens sets the "cache_read_input_tokens" field.
+func (_c *SessionCreate) SetCacheReadInputTokens(v int64) *SessionCreate {
+	_c.mutation.SetCacheReadInputTokens(v)
+	return _c
+}
+
+// SetNillableCacheReadInputTokens sets the "cache_read_input_tokens" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableCacheReadInputTokens(v *int64) *SessionCreate {
+	if v != nil {
+		_c.SetCacheReadInputTokens(*v)
+	}
+	return _c
+}
+
+// SetCost sets the "cost" field.
+func (_c *SessionCreate) SetCost(v float64) *SessionCreate {
+	_c.mutation.SetCost(v)
+	return _c
+}
+
+// SetNillableCost sets the "cost" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableCost(v *float64) *SessionCreate {
+	if v != nil {
+		_c.SetCost(*v)
+	}
+	return _c
+}
+
+// SetLastActivity sets the "last_activity" field.
+func (_c *SessionCreate) SetLastActivity(v time.Time) *SessionCreate {
+	_c.mutation.SetLastActivity(v)
+	return _c
+}
+
+// SetRevision sets the "revision" field.
+func (_c *SessionCreate) SetRevision(v int64) *SessionCreate {
+	_c.mutation.SetRevision(v)
+	return _c
+}
+
+// SetNillableRevision sets the "revision" field if the given value is not nil.
+func (_c *SessionCreate) SetNillableRevision(v *int64) *SessionCreate {
+	if v != nil {
+		_c.SetRevision(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *SessionCreate) SetID(v string) *SessionCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// Mutation returns the SessionMutation object of the builder.
+func (_c *SessionCreate) Mutation() *SessionMutation {
+	return _c.mutation
+}
+
+// Save creates the Session in the database.
+func (_c *SessionCreate) Save(ctx context.Context) (*Session, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *SessionCreate) SaveX(ctx context.Context) *Session {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SessionCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SessionCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *SessionCreate) defaults() {
+	if _, ok := _c.mutation.TurnCount(); !ok {
+		v := session.DefaultTurnCount
+		_c.mutation.SetTurnCount(v)
+	}
+	if _, ok := _c.mutation.PromptTokens(); !ok {
+		v := session.DefaultPromptTokens
+		_c.mutation.SetPromptTokens(v)
+	}
+	if _, ok := _c.mutation.CompletionTokens(); !ok {
+		v := session.DefaultCompletionTokens
+		_c.mutation.SetCompletionTokens(v)
+	}
+	if _, ok := _c.mutation.TotalTokens(); !ok {
+		v := session.DefaultTotalTokens
+		_c.mutation.SetTotalTokens(v)
+	}
+	if _, ok := _c.mutation.CacheCreationInputTokens(); !ok {
+		v := session.DefaultCacheCreationInputTokens
+		_c.mutation.SetCacheCreationInputTokens(v)
+	}
+	if _, ok := _c.mutation.CacheReadInputTokens(); !ok {
+		v := session.DefaultCacheReadInputTokens
+		_c.mutation.SetCacheReadInputTokens(v)
+	}
+	if _, ok := _c.mutation.Cost(); !ok {
+		v := session.DefaultCost
+		_c.mutation.SetCost(v)
+	}
+	if _, ok := _c.mutation.Revision(); !ok {
+		v := session.DefaultRevision
+		_c.mutation.SetRevision(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *SessionCreate) check() error {
+	if _, ok := _c.mutation.RootHash(); !ok {
+		return &ValidationError{Name: "root_hash", err: errors.New(`ent: missing required field "Session.root_hash"`)}
+	}
+	if v, ok := _c.mutation.RootHash(); ok {
+		if err := session.RootHashValidator(v); err != nil {
+			return &ValidationError{Name: "root_hash", err: fmt.Errorf(`ent: validator failed for field "Session.root_hash": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.TurnCount(); !ok {
+		return &ValidationError{Name: "turn_count", err: errors.New(`ent: missing required field "Session.turn_count"`)}
+	}
+	if _, ok := _c.mutation.PromptTokens(); !ok {
+		return &ValidationError{Name: "prompt_tokens", err: errors.New(`ent: missing required field "Session.prompt_tokens"`)}
+	}
+	if _, ok := _c.mutation.CompletionTokens(); !ok {
+		return &ValidationError{Name: "completion_tokens", err: errors.New(`ent: missing required field "Session.completion_tokens"`)}
+	}
+	if _, ok := _c.mutation.TotalTokens(); !ok {
+		return &ValidationError{Name: "total_tokens", err: errors.New(`ent: missing required field "Session.total_tokens"`)}
+	}
+	if _, ok := _c.mutation.CacheCreationInputTokens(); !ok {
+		return &ValidationError{Name: "cache_creation_input_tokens", err: errors.New(`ent: missing required field "Session.cache_creation_input_tokens"`)}
+	}
+	if _, ok := _c.mutation.CacheReadInputTokens(); !ok {
+		return &ValidationError{Name: "cache_read_input_tokens", err: errors.New(`ent: missing required field "Session.cache_read_input_tokens"`)}
+	}
+	if _, ok := _c.mutation.Cost(); !ok {
+		return &ValidationError{Name: "cost", err: errors.New(`ent: missing required field "Session.cost"`)}
+	}
+	if _, ok := _c.mutation.LastActivity(); !ok {
+		return &ValidationError{Name: "last_activity", err: errors.New(`ent: missing required field "Session.last_activity"`)}
+	}
+	if _, ok := _c.mutation.Revision(); !ok {
+		return &ValidationError{Name: "revision", err: errors.New(`ent: missing required field "Session.revision"`)}
+	}
+	if v, ok := _c.mutation.ID(); ok {
+		if err := session.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "Session.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_c *SessionCreate) sqlSave(ctx context.Context) (*Session, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected Session.ID type: %T", _spec.ID.Value)
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *SessionCreate) createSpec() (*Session, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Session{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(session.Table, sqlgraph.NewFieldSpec(session.FieldID, field.TypeString))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.RootHash(); ok {
+		_spec.SetField(session.FieldRootHash, field.TypeString, value)
+		_node.RootHash = value
+	}
+	if value, ok := _c.mutation.TurnCount(); ok {
+		_spec.SetField(session.FieldTurnCount, field.TypeInt, value)
+		_node.TurnCount = value
+	}
+	if value, ok := _c.mutation.PromptTokens(); ok {
+		_spec.SetField(session.FieldPromptTokens, field.TypeInt64, value)
+		_node.PromptTokens = value
+	}
+	if value, ok := _c.mutation.CompletionTokens(); ok {
+		_spec.SetField(session.FieldCompletionTokens, field.TypeInt64, value)
+		_node.CompletionTokens = value
+	}
+	if value, ok := _c.mutation.TotalTokens(); ok {
+		_spec.SetField(session.FieldTotalTokens, field.TypeInt64, value)
+		_node.TotalTokens = value
+	}
+	if value, ok := _c.mutation.CacheCreationInputTokens(); ok {
+		_spec.SetField(session.FieldCacheCreationInputTokens, field.TypeInt64, value)
+		_node.CacheCreationInputTokens = value
+	}
+	if value, ok := _c.mutation.CacheReadInputTokens(); ok {
+		_spec.SetField(session.FieldCacheReadInputTokens, field.TypeInt64, value)
+		_node.CacheReadInputTokens = value
+	}
+	if value, ok := _c.mutation.Cost(); ok {
+		_spec.SetField(session.FieldCost, field.TypeFloat64, value)
+		_node.Cost = value
+	}
+	if value, ok := _c.mutation.LastActivity(); ok {
+		_spec.SetField(session.FieldLastActivity, field.TypeTime, value)
+		_node.LastActivity = value
+	}
+	if value, ok := _c.mutation.Revision(); ok {
+		_spec.SetField(session.FieldRevision, field.TypeInt64, value)
+		_node.Revision = value
+	}
+	return _node, _spec
+}
+
+// SessionCreateBulk is the builder for creating many Session entities in bulk.
+type SessionCreateBulk struct {
+	config
+	err      error
+	builders []*SessionCreate
+}
+
+// Save creates the Session entities in the database.
+func (_c *SessionCreateBulk) Save(ctx context.Context) ([]*Session, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Session, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SessionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *SessionCreateBulk) SaveX(ctx context.Context) []*Session {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SessionCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SessionCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,702 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/session"
+)
+
+// SessionUpdate is the builder for updating Session entities.
+type SessionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *SessionMutation
+}
+
+// Where appends a list predicates to the SessionUpdate builder.
+func (_u *SessionUpdate) Where(ps ...predicate.Session) *SessionUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetRootHash sets the "root_hash" field.
+func (_u *SessionUpdate) SetRootHash(v string) *SessionUpdate {
+	_u.mutation.SetRootHash(v)
+	return _u
+}
+
+// SetNillableRootHash sets the "root_hash" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableRootHash(v *string) *SessionUpdate {
+	if v != nil {
+		_u.SetRootHash(*v)
+	}
+	return _u
+}
+
+// SetTurnCount sets the "turn_count" field.
+func (_u *SessionUpdate) SetTurnCount(v int) *SessionUpdate {
+	_u.mutation.ResetTurnCount()
+	_u.mutation.SetTurnCount(v)
+	return _u
+}
+
+// SetNillableTurnCount sets the "turn_count" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableTurnCount(v *int) *SessionUpdate {
+	if v != nil {
+		_u.SetTurnCount(*v)
+	}
+	return _u
+}
+
+// AddTurnCount adds value to the "turn_count" field.
+func (_u *SessionUpdate) AddTurnCount(v int) *SessionUpdate {
+	_u.mutation.AddTurnCount(v)
+	return _u
+}
+
+// SetPromptTokens sets the "prompt_tokens" field.
+func (_u *SessionUpdate) SetPromptTokens(v int64) *SessionUpdate {
+	_u.mutation.ResetPromptTokens()
+	_u.mutation.SetPromptTokens(v)
+	return _u
+}
+
+// SetNillablePromptTokens sets the "prompt_tokens" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillablePromptTokens(v *int64) *SessionUpdate {
+	if v != nil {
+		_u.SetPromptTokens(*v)
+	}
+	return _u
+}
+
+// AddPromptTokens adds value to the "prompt_tokens" field.
+func (_u *SessionUpdate) AddPromptTokens(v int64) *SessionUpdate {
+	_u.mutation.AddPromptTokens(v)
+	return _u
+}
+
+// SetCompletionTokens sets the "completion_tokens" field.
+func (_u *SessionUpdate) SetCompletionTokens(v int64) *SessionUpdate {
+	_u.mutation.ResetCompletionTokens()
+	_u.mutation.SetCompletionTokens(v)
+	return _u
+}
+
+// SetNillableCompletionTokens sets the "completion_tokens" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableCompletionTokens(v *int64) *SessionUpdate {
+	if v != nil {
+		_u.SetCompletionTokens(*v)
+	}
+	return _u
+}
+
+// AddCompletionTokens adds value to the "completion_tokens" field.
+func (_u *SessionUpdate) AddCompletionTokens(v int64) *SessionUpdate {
+	_u.mutation.AddCompletionTokens(v)
+	return _u
+}
+
+// SetTotalTokens sets the "total_tokens" field.
+func (_u *SessionUpdate) SetTotalTokens(v int64) *SessionUpdate {
+	_u.mutation.ResetTotalTokens()
+	_u.mutation.SetTotalTokens(v)
+	return _u
+}
+
+// SetNillableTotalTokens sets the "total_tokens" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableTotalTokens(v *int64) *SessionUpdate {
+	if v != nil {
+		_u.SetTotalTokens(*v)
+	}
+	return _u
+}
+
+// AddTotalTokens adds value to the "total_tokens" field.
+func (_u *SessionUpdate) AddTotalTokens(v int64) *SessionUpdate {
+	_u.mutation.AddTotalTokens(v)
+	return _u
+}
+
+// SetCacheCreationInputTokens sets the "cache_creation_input_tokens" field.
+func (_u *SessionUpdate) SetCacheCreationInputTokens(v int64) *SessionUpdate {
+	_u.mutation.ResetCacheCreationInputTokens()
+	_u.mutation.SetCacheCreationInputTokens(v)
+	return _u
+}
+
+// SetNillableCacheCreationInputTokens sets the "cache_creation_input_tokens" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableCacheCreationInputTokens(v *int64) *SessionUpdate {
+	if v != nil {
+		_u.SetCacheCreationInputTokens(*v)
+	}
+	return _u
+}
+
+// AddCacheCreationInputTokens adds value to the "cache_creation_input_tokens" field.
+func (_u *SessionUpdate) AddCacheCreationInputTokens(v int64) *SessionUpdate {
+	_u.mutation.AddCacheCreationInputTokens(v)
+	return _u
+}
+
+// SetCacheReadInputTokens sets the "cache_read_input_tokens" field.
+func (_u *SessionUpdate) SetCacheReadInputTokens(v int64) *SessionUpdate {
+	_u.mutation.ResetCacheReadInputTokens()
+	_u.mutation.SetCacheReadInputTokens(v)
+	return _u
+}
+
+// SetNillableCacheReadInputTokens sets the "cache_read_input_tokens" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableCacheReadInputTokens(v *int64) *SessionUpdate {
+	if v != nil {
+		_u.SetCacheReadInputTokens(*v)
+	}
+	return _u
+}
+
+// AddCacheReadInputTokens adds value to the "cache_read_input_tokens" field.
+func (_u *SessionUpdate) AddCacheReadInputTokens(v int64) *SessionUpdate {
+	_u.mutation.AddCacheReadInputTokens(v)
+	return _u
+}
+
+// SetCost sets the "cost" field.
+func (_u *SessionUpdate) SetCost(v float64) *SessionUpdate {
+	_u.mutation.ResetCost()
+	_u.mutation.SetCost(v)
+	return _u
+}
+
+// SetNillableCost sets the "cost" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableCost(v *float64) *SessionUpdate {
+	if v != nil {
+		_u.SetCost(*v)
+	}
+	return _u
+}
+
+// AddCost adds value to the "cost" field.
+func (_u *SessionUpdate) AddCost(v float64) *SessionUpdate {
+	_u.mutation.AddCost(v)
+	return _u
+}
+
+// SetLastActivity sets the "last_activity" field.
+func (_u *SessionUpdate) SetLastActivity(v time.Time) *SessionUpdate {
+	_u.mutation.SetLastActivity(v)
+	return _u
+}
+
+// SetNillableLastActivity sets the "last_activity" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableLastActivity(v *time.Time) *SessionUpdate {
+	if v != nil {
+		_u.SetLastActivity(*v)
+	}
+	return _u
+}
+
+// SetRevision sets the "revision" field.
+func (_u *SessionUpdate) SetRevision(v int64) *SessionUpdate {
+	_u.mutation.ResetRevision()
+	_u.mutation.SetRevision(v)
+	return _u
+}
+
+// SetNillableRevision sets the "revision" field if the given value is not nil.
+func (_u *SessionUpdate) SetNillableRevision(v *int64) *SessionUpdate {
+	if v != nil {
+		_u.SetRevision(*v)
+	}
+	return _u
+}
+
+// AddRevision adds value to the "revision" field.
+func (_u *SessionUpdate) AddRevision(v int64) *SessionUpdate {
+	_u.mutation.AddRevision(v)
+	return _u
+}
+
+// Mutation returns the SessionMutation object of the builder.
+func (_u *SessionUpdate) Mutation() *SessionMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *SessionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *SessionUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *SessionUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *SessionUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *SessionUpdate) check() error {
+	if v, ok := _u.mutation.RootHash(); ok {
+		if err := session.RootHashValidator(v); err != nil {
+			return &ValidationError{Name: "root_hash", err: fmt.Errorf(`ent: validator failed for field "Session.root_hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *SessionUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(session.Table, session.Columns, sqlgraph.NewFieldSpec(session.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.RootHash(); ok {
+		_spec.SetField(session.FieldRootHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.TurnCount(); ok {
+		_spec.SetField(session.FieldTurnCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTurnCount(); ok {
+		_spec.AddField(session.FieldTurnCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.PromptTokens(); ok {
+		_spec.SetField(session.FieldPromptTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedPromptTokens(); ok {
+		_spec.AddField(session.FieldPromptTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.CompletionTokens(); ok {
+		_spec.SetField(session.FieldCompletionTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedCompletionTokens(); ok {
+		_spec.AddField(session.FieldCompletionTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.TotalTokens(); ok {
+		_spec.SetField(session.FieldTotalTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedTotalTokens(); ok {
+		_spec.AddField(session.FieldTotalTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.CacheCreationInputTokens(); ok {
+		_spec.SetField(session.FieldCacheCreationInputTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedCacheCreationInputTokens(); ok {
+		_spec.AddField(session.FieldCacheCreationInputTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.CacheReadInputTokens(); ok {
+		_spec.SetField(session.FieldCacheReadInputTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedCacheReadInputTokens(); ok {
+		_spec.AddField(session.FieldCacheReadInputTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.Cost(); ok {
+		_spec.SetField(session.FieldCost, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedCost(); ok {
+		_spec.AddField(session.FieldCost, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.LastActivity(); ok {
+		_spec.SetField(session.FieldLastActivity, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.Revision(); ok {
+		_spec.SetField(session.FieldRevision, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedRevision(); ok {
+		_spec.AddField(session.FieldRevision, field.TypeInt64, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{session.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// SessionUpdateOne is the builder for updating a single Session entity.
+type SessionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *SessionMutation
+}
+
+// SetRootHash sets the "root_hash" field.
+func (_u *SessionUpdateOne) SetRootHash(v string) *SessionUpdateOne {
+	_u.mutation.SetRootHash(v)
+	return _u
+}
+
+// SetNillableRootHash sets the "root_hash" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableRootHash(v *string) *SessionUpdateOne {
+	if v != nil {
+		_u.SetRootHash(*v)
+	}
+	return _u
+}
+
+// SetTurnCount sets the "turn_count" field.
+func (_u *SessionUpdateOne) SetTurnCount(v int) *SessionUpdateOne {
+	_u.mutation.ResetTurnCount()
+	_u.mutation.SetTurnCount(v)
+	return _u
+}
+
+// SetNillableTurnCount sets the "turn_count" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableTurnCount(v *int) *SessionUpdateOne {
+	if v != nil {
+		_u.SetTurnCount(*v)
+	}
+	return _u
+}
+
+// AddTurnCount adds value to the "turn_count" field.
+func (_u *SessionUpdateOne) AddTurnCount(v int) *SessionUpdateOne {
+	_u.mutation.AddTurnCount(v)
+	return _u
+}
+
+// SetPromptTokens sets the "prompt_tokens" field.
+func (_u *SessionUpdateOne) SetPromptTokens(v int64) *SessionUpdateOne {
+	_u.mutation.ResetPromptTokens()
+	_u.mutation.SetPromptTokens(v)
+	return _u
+}
+
+// SetNillablePromptTokens sets the "prompt_tokens" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillablePromptTokens(v *int64) *SessionUpdateOne {
+	if v != nil {
+		_u.SetPromptTokens(*v)
+	}
+	return _u
+}
+
+// AddPromptTokens adds value to the "prompt_tokens" field.
+func (_u *SessionUpdateOne) AddPromptTokens(v int64) *SessionUpdateOne {
+	_u.mutation.AddPromptTokens(v)
+	return _u
+}
+
+// SetCompletionTokens sets the "completion_tokens" field.
+func (_u *SessionUpdateOne) SetCompletionTokens(v int64) *SessionUpdateOne {
+	_u.mutation.ResetCompletionTokens()
+	_u.mutation.SetCompletionTokens(v)
+	return _u
+}
+
+// SetNillableCompletionTokens sets the "completion_tokens" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableCompletionTokens(v *int64) *SessionUpdateOne {
+	if v != nil {
+		_u.SetCompletionTokens(*v)
+	}
+	return _u
+}
+
+// AddCompletionTokens adds value to the "completion_tokens" field.
+func (_u *SessionUpdateOne) AddCompletionTokens(v int64) *SessionUpdateOne {
+	_u.mutation.AddCompletionTokens(v)
+	return _u
+}
+
+// SetTotalTokens sets the "total_tokens" field.
+func (_u *SessionUpdateOne) SetTotalTokens(v int64) *SessionUpdateOne {
+	_u.mutation.ResetTotalTokens()
+	_u.mutation.SetTotalTokens(v)
+	return _u
+}
+
+// SetNillableTotalTokens sets the "total_tokens" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableTotalTokens(v *int64) *SessionUpdateOne {
+	if v != nil {
+		_u.SetTotalTokens(*v)
+	}
+	return _u
+}
+
+// AddTotalTokens adds value to the "total_tokens" field.
+func (_u *SessionUpdateOne) AddTotalTokens(v int64) *SessionUpdateOne {
+	_u.mutation.AddTotalTokens(v)
+	return _u
+}
+
+// SetCacheCreationInputTokens sets the "cache_creation_input_tokens" field.
+func (_u *SessionUpdateOne) SetCacheCreationInputTokens(v int64) *SessionUpdateOne {
+	_u.mutation.ResetCacheCreationInputTokens()
+	_u.mutation.SetCacheCreationInputTokens(v)
+	return _u
+}
+
+// SetNillableCacheCreationInputTokens sets the "cache_creation_input_tokens" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableCacheCreationInputTokens(v *int64) *SessionUpdateOne {
+	if v != nil {
+		_u.SetCacheCreationInputTokens(*v)
+	}
+	return _u
+}
+
+// AddCacheCreationInputTokens adds value to the "cache_creation_input_tokens" field.
+func (_u *SessionUpdateOne) AddCacheCreationInputTokens(v int64) *SessionUpdateOne {
+	_u.mutation.AddCacheCreationInputTokens(v)
+	return _u
+}
+
+// SetCacheReadInputTokens sets the "cache_read_input_tokens" field.
+func (_u *SessionUpdateOne) SetCacheReadInputTokens(v int64) *SessionUpdateOne {
+	_u.mutation.ResetCacheReadInputTokens()
+	_u.mutation.SetCacheReadInputTokens(v)
+	return _u
+}
+
+// SetNillableCacheReadInputTokens sets the "cache_read_input_tokens" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableCacheReadInputTokens(v *int64) *SessionUpdateOne {
+	if v != nil {
+		_u.SetCacheReadInputTokens(*v)
+	}
+	return _u
+}
+
+// AddCacheReadInputTokens adds value to the "cache_read_input_tokens" field.
+func (_u *SessionUpdateOne) AddCacheReadInputTokens(v int64) *SessionUpdateOne {
+	_u.mutation.AddCacheReadInputTokens(v)
+	return _u
+}
+
+// SetCost sets the "cost" field.
+func (_u *SessionUpdateOne) SetCost(v float64) *SessionUpdateOne {
+	_u.mutation.ResetCost()
+	_u.mutation.SetCost(v)
+	return _u
+}
+
+// SetNillableCost sets the "cost" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableCost(v *float64) *SessionUpdateOne {
+	if v != nil {
+		_u.SetCost(*v)
+	}
+	return _u
+}
+
+// AddCost adds value to the "cost" field.
+func (_u *SessionUpdateOne) AddCost(v float64) *SessionUpdateOne {
+	_u.mutation.AddCost(v)
+	return _u
+}
+
+// SetLastActivity sets the "last_activity" field.
+func (_u *SessionUpdateOne) SetLastActivity(v time.Time) *SessionUpdateOne {
+	_u.mutation.SetLastActivity(v)
+	return _u
+}
+
+// SetNillableLastActivity sets the "last_activity" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableLastActivity(v *time.Time) *SessionUpdateOne {
+	if v != nil {
+		_u.SetLastActivity(*v)
+	}
+	return _u
+}
+
+// SetRevision sets the "revision" field.
+func (_u *SessionUpdateOne) SetRevision(v int64) *SessionUpdateOne {
+	_u.mutation.ResetRevision()
+	_u.mutation.SetRevision(v)
+	return _u
+}
+
+// SetNillableRevision sets the "revision" field if the given value is not nil.
+func (_u *SessionUpdateOne) SetNillableRevision(v *int64) *SessionUpdateOne {
+	if v != nil {
+		_u.SetRevision(*v)
+	}
+	return _u
+}
+
+// AddRevision adds value to the "revision" field.
+func (_u *SessionUpdateOne) AddRevision(v int64) *SessionUpdateOne {
+	_u.mutation.AddRevision(v)
+	return _u
+}
+
+// Mutation returns the SessionMutation object of the builder.
+func (_u *SessionUpdateOne) Mutation() *SessionMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the SessionUpdate builder.
+func (_u *SessionUpdateOne) Where(ps ...predicate.Session) *SessionUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *SessionUpdateOne) Select(field string, fields ...string) *SessionUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Session entity.
+func (_u *SessionUpdateOne) Save(ctx context.Context) (*Session, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *SessionUpdateOne) SaveX(ctx context.Context) *Session {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *SessionUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *SessionUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *SessionUpdateOne) check() error {
+	if v, ok := _u.mutation.RootHash(); ok {
+		if err := session.RootHashValidator(v); err != nil {
+			return &ValidationError{Name: "root_hash", err: fmt.Errorf(`ent: validator failed for field "Session.root_hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *SessionUpdateOne) sqlSave(ctx context.Context) (_node *Session, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(session.Table, session.Columns, sqlgraph.NewFieldSpec(session.FieldID, field.TypeString))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Session.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, session.FieldID)
+		for _, f := range fields {
+			if !session.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != session.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.RootHash(); ok {
+		_spec.SetField(session.FieldRootHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.TurnCount(); ok {
+		_spec.SetField(session.FieldTurnCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTurnCount(); ok {
+		_spec.AddField(session.FieldTurnCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.PromptTokens(); ok {
+		_spec.SetField(session.FieldPromptTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedPromptTokens(); ok {
+		_spec.AddField(session.FieldPromptTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.CompletionTokens(); ok {
+		_spec.SetField(session.FieldCompletionTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedCompletionTokens(); ok {
+		_spec.AddField(session.FieldCompletionTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.TotalTokens(); ok {
+		_spec.SetField(session.FieldTotalTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedTotalTokens(); ok {
+		_spec.AddField(session.FieldTotalTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.CacheCreationInputTokens(); ok {
+		_spec.SetField(session.FieldCacheCreationInputTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedCacheCreationInputTokens(); ok {
+		_spec.AddField(session.FieldCacheCreationInputTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.CacheReadInputTokens(); ok {
+		_spec.SetField(session.FieldCacheReadInputTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedCacheReadInputTokens(); ok {
+		_spec.AddField(session.FieldCacheReadInputTokens, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.Cost(); ok {
+		_spec.SetField(session.FieldCost, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedCost(); ok {
+		_spec.AddField(session.FieldCost, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.LastActivity(); ok {
+		_spec.SetField(session.FieldLastActivity, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.Revision(); ok {
+		_spec.SetField(session.FieldRevision, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedRevision(); ok {
+		_spec.AddField(session.FieldRevision, field.TypeInt64, value)
+	}
+	_node = &Session{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{session.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,161 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/sessiontool"
+)
+
+// SessionTool is the model entity for the SessionTool schema.
+type SessionTool struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// SessionID holds the value of the "session_id" field.
+	SessionID string `json:"session_id,omitempty"`
+	// ToolHash holds the value of the "tool_hash" field.
+	ToolHash string `json:"tool_hash,omitempty"`
+	// RunID holds the value of the "run_id" field.
+	RunID string `json:"run_id,omitempty"`
+	// AgentName holds the value of the "agent_name" field.
+	AgentName string `json:"agent_name,omitempty"`
+	// NodeHash holds the value of the "node_hash" field.
+	NodeHash string `json:"node_hash,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*SessionTool) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case sessiontool.FieldID:
+			values[i] = new(sql.NullInt64)
+		case sessiontool.FieldSessionID, sessiontool.FieldToolHash, sessiontool.FieldRunID, sessiontool.FieldAgentName, sessiontool.FieldNodeHash:
+			values[i] = new(sql.NullString)
+		case sessiontool.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the SessionTool fields.
+func (_m *SessionTool) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case sessiontool.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case sessiontool.FieldSessionID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field session_id", values[i])
+			} else if value.Valid {
+				_m.SessionID = value.String
+			}
+		case sessiontool.FieldToolHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field tool_hash", values[i])
+			} else if value.Valid {
+				_m.ToolHash = value.String
+			}
+		case sessiontool.FieldRunID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field run_id", values[i])
+			} else if value.Valid {
+				_m.RunID = value.String
+			}
+		case sessiontool.FieldAgentName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field agent_name", values[i])
+			} else if value.Valid {
+				_m.AgentName = value.String
+			}
+		case sessiontool.FieldNodeHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field node_hash", values[i])
+			} else if value.Valid {
+				_m.NodeHash = value.String
+			}
+		case sessiontool.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the SessionTool.
+// This includes values selected through modifiers, order, etc.
+func (_m *SessionTool) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this SessionTool.
+// Note that you need to call SessionTool.Unwrap() before calling this method if this SessionTool
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *SessionTool) Update() *SessionToolUpdateOne {
+	return NewSessionToolClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the SessionTool entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *SessionTool) Unwrap() *SessionTool {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: SessionTool is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *SessionTool) String() string {
+	var builder strings.Builder
+	builder.WriteString("SessionTool(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("session_id=")
+	builder.WriteString(_m.SessionID)
+	builder.WriteString(", ")
+	builder.WriteString("tool_hash=")
+	builder.WriteString(_m.ToolHash)
+	builder.WriteString(", ")
+	builder.WriteString("run_id=")
+	builder.WriteString(_m.RunID)
+	builder.WriteString(", ")
+	builder.WriteString("agent_name=")
+	builder.WriteString(_m.AgentName)
+	builder.WriteString(", ")
+	builder.WriteString("node_hash=")
+	builder.WriteString(_m.NodeHash)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// SessionTools is a parsable slice of SessionTool.
+type SessionTools []*SessionTool
@@ -0,0 +1,100 @@
+// Code generated by ent, DO NOT EDIT.
+
+package sessiontool
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the sessiontool type in the database.
+	Label = "session_tool"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldSessionID holds the string denoting the session_id field in the database.
+	FieldSessionID = "session_id"
+	// FieldToolHash holds the string denoting the tool_hash field in the database.
+	FieldToolHash = "tool_hash"
+	// FieldRunID holds the string denoting the run_id field in the database.
+	FieldRunID = "run_id"
+	// FieldAgentName holds the string denoting the agent_name field in the database.
+	FieldAgentName = "agent_name"
+	// FieldNodeHash holds the string denoting the node_hash field in the database.
+	FieldNodeHash = "node_hash"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// Table holds the table name of the sessiontool in the database.
+	Table = "session_tools"
+)
+
+// Columns holds all SQL columns for sessiontool fields.
+var Columns = []string{
+	FieldID,
+	FieldSessionID,
+	FieldToolHash,
+	FieldRunID,
+	FieldAgentName,
+	FieldNodeHash,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// SessionIDValidator is a validator for the "session_id" field. It is called by the builders before save.
+	SessionIDValidator func(string) error
+	// ToolHashValidator is a validator for the "tool_hash" field. It is called by the builders before save.
+	ToolHashValidator func(string) error
+	// NodeHashValidator is a validator for the "node_hash" field. It is called by the builders before save.
+	NodeHashValidator func(string) error
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the SessionTool queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// BySessionID orders the results by the session_id field.
+func BySessionID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSessionID, opts...).ToFunc()
+}
+
+// ByToolHash orders the results by the tool_hash field.
+func ByToolHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldToolHash, opts...).ToFunc()
+}
+
+// ByRunID orders the results by the run_id field.
+func ByRunID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRunID, opts...).ToFunc()
+}
+
+// ByAgentName orders the results by the agent_name field.
+func ByAgentName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAgentName, opts...).ToFunc()
+}
+
+// ByNodeHash orders the results by the node_hash field.
+func ByNodeHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNodeHash, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
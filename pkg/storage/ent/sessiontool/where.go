@@ -0,0 +1,485 @@
+// Code generated by ent, DO NOT EDIT.
+
+package sessiontool
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLTE(FieldID, id))
+}
+
+// SessionID applies equality check predicate on the "session_id" field. It's identical to SessionIDEQ.
+func SessionID(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldSessionID, v))
+}
+
+// ToolHash applies equality check predicate on the "tool_hash" field. It's identical to ToolHashEQ.
+func ToolHash(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldToolHash, v))
+}
+
+// RunID applies equality check predicate on the "run_id" field. It's identical to RunIDEQ.
+func RunID(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldRunID, v))
+}
+
+// AgentName applies equality check predicate on the "agent_name" field. It's identical to AgentNameEQ.
+func AgentName(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldAgentName, v))
+}
+
+// NodeHash applies equality check predicate on the "node_hash" field. It's identical to NodeHashEQ.
+func NodeHash(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldNodeHash, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// SessionIDEQ applies the EQ predicate on the "session_id" field.
+func SessionIDEQ(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldSessionID, v))
+}
+
+// SessionIDNEQ applies the NEQ predicate on the "session_id" field.
+func SessionIDNEQ(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNEQ(FieldSessionID, v))
+}
+
+// SessionIDIn applies the In predicate on the "session_id" field.
+func SessionIDIn(vs ...string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldIn(FieldSessionID, vs...))
+}
+
+// SessionIDNotIn applies the NotIn predicate on the "session_id" field.
+func SessionIDNotIn(vs ...string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNotIn(FieldSessionID, vs...))
+}
+
+// SessionIDGT applies the GT predicate on the "session_id" field.
+func SessionIDGT(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGT(FieldSessionID, v))
+}
+
+// SessionIDGTE applies the GTE predicate on the "session_id" field.
+func SessionIDGTE(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGTE(FieldSessionID, v))
+}
+
+// SessionIDLT applies the LT predicate on the "session_id" field.
+func SessionIDLT(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLT(FieldSessionID, v))
+}
+
+// SessionIDLTE applies the LTE predicate on the "session_id" field.
+func SessionIDLTE(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLTE(FieldSessionID, v))
+}
+
+// SessionIDContains applies the Contains predicate on the "session_id" field.
+func SessionIDContains(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldContains(FieldSessionID, v))
+}
+
+// SessionIDHasPrefix applies the HasPrefix predicate on the "session_id" field.
+func SessionIDHasPrefix(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldHasPrefix(FieldSessionID, v))
+}
+
+// SessionIDHasSuffix applies the HasSuffix predicate on the "session_id" field.
+func SessionIDHasSuffix(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldHasSuffix(FieldSessionID, v))
+}
+
+// SessionIDEqualFold applies the EqualFold predicate on the "session_id" field.
+func SessionIDEqualFold(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEqualFold(FieldSessionID, v))
+}
+
+// SessionIDContainsFold applies the ContainsFold predicate on the "session_id" field.
+func SessionIDContainsFold(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldContainsFold(FieldSessionID, v))
+}
+
+// ToolHashEQ applies the EQ predicate on the "tool_hash" field.
+func ToolHashEQ(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldToolHash, v))
+}
+
+// ToolHashNEQ applies the NEQ predicate on the "tool_hash" field.
+func ToolHashNEQ(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNEQ(FieldToolHash, v))
+}
+
+// ToolHashIn applies the In predicate on the "tool_hash" field.
+func ToolHashIn(vs ...string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldIn(FieldToolHash, vs...))
+}
+
+// ToolHashNotIn applies the NotIn predicate on the "tool_hash" field.
+func ToolHashNotIn(vs ...string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNotIn(FieldToolHash, vs...))
+}
+
+// ToolHashGT applies the GT predicate on the "tool_hash" field.
+func ToolHashGT(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGT(FieldToolHash, v))
+}
+
+// ToolHashGTE applies the GTE predicate on the "tool_hash" field.
+func ToolHashGTE(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGTE(FieldToolHash, v))
+}
+
+// ToolHashLT applies the LT predicate on the "tool_hash" field.
+func ToolHashLT(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLT(FieldToolHash, v))
+}
+
+// ToolHashLTE applies the LTE predicate on the "tool_hash" field.
+func ToolHashLTE(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLTE(FieldToolHash, v))
+}
+
+// ToolHashContains applies the Contains predicate on the "tool_hash" field.
+func ToolHashContains(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldContains(FieldToolHash, v))
+}
+
+// ToolHashHasPrefix applies the HasPrefix predicate on the "tool_hash" field.
+func ToolHashHasPrefix(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldHasPrefix(FieldToolHash, v))
+}
+
+// ToolHashHasSuffix applies the HasSuffix predicate on the "tool_hash" field.
+func ToolHashHasSuffix(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldHasSuffix(FieldToolHash, v))
+}
+
+// ToolHashEqualFold applies the EqualFold predicate on the "tool_hash" field.
+func ToolHashEqualFold(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEqualFold(FieldToolHash, v))
+}
+
+// ToolHashContainsFold applies the ContainsFold predicate on the "tool_hash" field.
+func ToolHashContainsFold(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldContainsFold(FieldToolHash, v))
+}
+
+// RunIDEQ applies the EQ predicate on the "run_id" field.
+func RunIDEQ(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldRunID, v))
+}
+
+// RunIDNEQ applies the NEQ predicate on the "run_id" field.
+func RunIDNEQ(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNEQ(FieldRunID, v))
+}
+
+// RunIDIn applies the In predicate on the "run_id" field.
+func RunIDIn(vs ...string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldIn(FieldRunID, vs...))
+}
+
+// RunIDNotIn applies the NotIn predicate on the "run_id" field.
+func RunIDNotIn(vs ...string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNotIn(FieldRunID, vs...))
+}
+
+// RunIDGT applies the GT predicate on the "run_id" field.
+func RunIDGT(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGT(FieldRunID, v))
+}
+
+// RunIDGTE applies the GTE predicate on the "run_id" field.
+func RunIDGTE(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGTE(FieldRunID, v))
+}
+
+// RunIDLT applies the LT predicate on the "run_id" field.
+func RunIDLT(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLT(FieldRunID, v))
+}
+
+// RunIDLTE applies the LTE predicate on the "run_id" field.
+func RunIDLTE(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLTE(FieldRunID, v))
+}
+
+// RunIDContains applies the Contains predicate on the "run_id" field.
+func RunIDContains(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldContains(FieldRunID, v))
+}
+
+// RunIDHasPrefix applies the HasPrefix predicate on the "run_id" field.
+func RunIDHasPrefix(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldHasPrefix(FieldRunID, v))
+}
+
+// RunIDHasSuffix applies the HasSuffix predicate on the "run_id" field.
+func RunIDHasSuffix(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldHasSuffix(FieldRunID, v))
+}
+
+// RunIDIsNil applies the IsNil predicate on the "run_id" field.
+func RunIDIsNil() predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldIsNull(FieldRunID))
+}
+
+// RunIDNotNil applies the NotNil predicate on the "run_id" field.
+func RunIDNotNil() predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNotNull(FieldRunID))
+}
+
+// RunIDEqualFold applies the EqualFold predicate on the "run_id" field.
+func RunIDEqualFold(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEqualFold(FieldRunID, v))
+}
+
+// RunIDContainsFold applies the ContainsFold predicate on the "run_id" field.
+func RunIDContainsFold(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldContainsFold(FieldRunID, v))
+}
+
+// AgentNameEQ applies the EQ predicate on the "agent_name" field.
+func AgentNameEQ(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldAgentName, v))
+}
+
+// AgentNameNEQ applies the NEQ predicate on the "agent_name" field.
+func AgentNameNEQ(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNEQ(FieldAgentName, v))
+}
+
+// AgentNameIn applies the In predicate on the "agent_name" field.
+func AgentNameIn(vs ...string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldIn(FieldAgentName, vs...))
+}
+
+// AgentNameNotIn applies the NotIn predicate on the "agent_name" field.
+func AgentNameNotIn(vs ...string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNotIn(FieldAgentName, vs...))
+}
+
+// AgentNameGT applies the GT predicate on the "agent_name" field.
+func AgentNameGT(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGT(FieldAgentName, v))
+}
+
+// AgentNameGTE applies the GTE predicate on the "agent_name" field.
+func AgentNameGTE(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGTE(FieldAgentName, v))
+}
+
+// AgentNameLT applies the LT predicate on the "agent_name" field.
+func AgentNameLT(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLT(FieldAgentName, v))
+}
+
+// AgentNameLTE applies the LTE predicate on the "agent_name" field.
+func AgentNameLTE(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLTE(FieldAgentName, v))
+}
+
+// AgentNameContains applies the Contains predicate on the "agent_name" field.
+func AgentNameContains(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldContains(FieldAgentName, v))
+}
+
+// AgentNameHasPrefix applies the HasPrefix predicate on the "agent_name" field.
+func AgentNameHasPrefix(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldHasPrefix(FieldAgentName, v))
+}
+
+// AgentNameHasSuffix applies the HasSuffix predicate on the "agent_name" field.
+func AgentNameHasSuffix(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldHasSuffix(FieldAgentName, v))
+}
+
+// AgentNameIsNil applies the IsNil predicate on the "agent_name" field.
+func AgentNameIsNil() predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldIsNull(FieldAgentName))
+}
+
+// AgentNameNotNil applies the NotNil predicate on the "agent_name" field.
+func AgentNameNotNil() predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNotNull(FieldAgentName))
+}
+
+// AgentNameEqualFold applies the EqualFold predicate on the "agent_name" field.
+func AgentNameEqualFold(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEqualFold(FieldAgentName, v))
+}
+
+// AgentNameContainsFold applies the ContainsFold predicate on the "agent_name" field.
+func AgentNameContainsFold(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldContainsFold(FieldAgentName, v))
+}
+
+// NodeHashEQ applies the EQ predicate on the "node_hash" field.
+func NodeHashEQ(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldNodeHash, v))
+}
+
+// NodeHashNEQ applies the NEQ predicate on the "node_hash" field.
+func NodeHashNEQ(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNEQ(FieldNodeHash, v))
+}
+
+// NodeHashIn applies the In predicate on the "node_hash" field.
+func NodeHashIn(vs ...string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldIn(FieldNodeHash, vs...))
+}
+
+// NodeHashNotIn applies the NotIn predicate on the "node_hash" field.
+func NodeHashNotIn(vs ...string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNotIn(FieldNodeHash, vs...))
+}
+
+// NodeHashGT applies the GT predicate on the "node_hash" field.
+func NodeHashGT(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGT(FieldNodeHash, v))
+}
+
+// NodeHashGTE applies the GTE predicate on the "node_hash" field.
+func NodeHashGTE(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGTE(FieldNodeHash, v))
+}
+
+// NodeHashLT applies the LT predicate on the "node_hash" field.
+func NodeHashLT(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLT(FieldNodeHash, v))
+}
+
+// NodeHashLTE applies the LTE predicate on the "node_hash" field.
+func NodeHashLTE(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLTE(FieldNodeHash, v))
+}
+
+// NodeHashContains applies the Contains predicate on the "node_hash" field.
+func NodeHashContains(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldContains(FieldNodeHash, v))
+}
+
+// NodeHashHasPrefix applies the HasPrefix predicate on the "node_hash" field.
+func NodeHashHasPrefix(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldHasPrefix(FieldNodeHash, v))
+}
+
+// NodeHashHasSuffix applies the HasSuffix predicate on the "node_hash" field.
+func NodeHashHasSuffix(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldHasSuffix(FieldNodeHash, v))
+}
+
+// NodeHashEqualFold applies the EqualFold predicate on the "node_hash" field.
+func NodeHashEqualFold(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEqualFold(FieldNodeHash, v))
+}
+
+// NodeHashContainsFold applies the ContainsFold predicate on the "node_hash" field.
+func NodeHashContainsFold(v string) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldContainsFold(FieldNodeHash, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.SessionTool {
+	return predicate.SessionTool(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.SessionTool) predicate.SessionTool {
+	return predicate.SessionTool(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.SessionTool) predicate.SessionTool {
+	return predicate.SessionTool(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.SessionTool) predicate.SessionTool {
+	return predicate.SessionTool(sql.NotPredicates(p))
+}
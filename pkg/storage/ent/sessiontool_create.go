@@ -0,0 +1,292 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/sessiontool"
+)
+
+// SessionToolCreate is the builder for creating a SessionTool entity.
+type SessionToolCreate struct {
+	config
+	mutation *SessionToolMutation
+	hooks    []Hook
+}
+
+// SetSessionID sets the "session_id" field.
+func (_c *SessionToolCreate) SetSessionID(v string) *SessionToolCreate {
+	_c.mutation.SetSessionID(v)
+	return _c
+}
+
+// SetToolHash sets the "tool_hash" field.
+func (_c *SessionToolCreate) SetToolHash(v string) *SessionToolCreate {
+	_c.mutation.SetToolHash(v)
+	return _c
+}
+
+// SetRunID sets the "run_id" field.
+func (_c *SessionToolCreate) SetRunID(v string) *SessionToolCreate {
+	_c.mutation.SetRunID(v)
+	return _c
+}
+
+// SetNillableRunID sets the "run_id" field if the given value is not nil.
+func (_c *SessionToolCreate) SetNillableRunID(v *string) *SessionToolCreate {
+	if v != nil {
+		_c.SetRunID(*v)
+	}
+	return _c
+}
+
+// SetAgentName sets the "agent_name" field.
+func (_c *SessionToolCreate) SetAgentName(v string) *SessionToolCreate {
+	_c.mutation.SetAgentName(v)
+	return _c
+}
+
+// SetNillableAgentName sets the "agent_name" field if the given value is not nil.
+func (_c *SessionToolCreate) SetNillableAgentName(v *string) *SessionToolCreate {
+	if v != nil {
+		_c.SetAgentName(*v)
+	}
+	return _c
+}
+
+// SetNodeHash sets the "node_hash" field.
+func (_c *SessionToolCreate) SetNodeHash(v string) *SessionToolCreate {
+	_c.mutation.SetNodeHash(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *SessionToolCreate) SetCreatedAt(v time.Time) *SessionToolCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *SessionToolCreate) SetNillableCreatedAt(v *time.Time) *SessionToolCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// Mutation returns the SessionToolMutation object of the builder.
+func (_c *SessionToolCreate) Mutation() *SessionToolMutation {
+	return _c.mutation
+}
+
+// Save creates the SessionTool in the database.
+func (_c *SessionToolCreate) Save(ctx context.Context) (*SessionTool, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *SessionToolCreate) SaveX(ctx context.Context) *SessionTool {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SessionToolCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SessionToolCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *SessionToolCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := sessiontool.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *SessionToolCreate) check() error {
+	if _, ok := _c.mutation.SessionID(); !ok {
+		return &ValidationError{Name: "session_id", err: errors.New(`ent: missing required field "SessionTool.session_id"`)}
+	}
+	if v, ok := _c.mutation.SessionID(); ok {
+		if err := sessiontool.SessionIDValidator(v); err != nil {
+			return &ValidationError{Name: "session_id", err: fmt.Errorf(`ent: validator failed for field "SessionTool.session_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ToolHash(); !ok {
+		return &ValidationError{Name: "tool_hash", err: errors.New(`ent: missing required field "SessionTool.tool_hash"`)}
+	}
+	if v, ok := _c.mutation.ToolHash(); ok {
+		if err := sessiontool.ToolHashValidator(v); err != nil {
+			return &ValidationError{Name: "tool_hash", err: fmt.Errorf(`ent: validator failed for field "SessionTool.tool_hash": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.NodeHash(); !ok {
+		return &ValidationError{Name: "node_hash", err: errors.New(`ent: missing required field "SessionTool.node_hash"`)}
+	}
+	if v, ok := _c.mutation.NodeHash(); ok {
+		if err := sessiontool.NodeHashValidator(v); err != nil {
+			return &ValidationError{Name: "node_hash", err: fmt.Errorf(`ent: validator failed for field "SessionTool.node_hash": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "SessionTool.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *SessionToolCreate) sqlSave(ctx context.Context) (*SessionTool, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *SessionToolCreate) createSpec() (*SessionTool, *sqlgraph.CreateSpec) {
+	var (
+		_node = &SessionTool{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(sessiontool.Table, sqlgraph.NewFieldSpec(sessiontool.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.SessionID(); ok {
+		_spec.SetField(sessiontool.FieldSessionID, field.TypeString, value)
+		_node.SessionID = value
+	}
+	if value, ok := _c.mutation.ToolHash(); ok {
+		_spec.SetField(sessiontool.FieldToolHash, field.TypeString, value)
+		_node.ToolHash = value
+	}
+	if value, ok := _c.mutation.RunID(); ok {
+		_spec.SetField(sessiontool.FieldRunID, field.TypeString, value)
+		_node.RunID = value
+	}
+	if value, ok := _c.mutation.AgentName(); ok {
+		_spec.SetField(sessiontool.FieldAgentName, field.TypeString, value)
+		_node.AgentName = value
+	}
+	if value, ok := _c.mutation.NodeHash(); ok {
+		_spec.SetField(sessiontool.FieldNodeHash, field.TypeString, value)
+		_node.NodeHash = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(sessiontool.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// SessionToolCreateBulk is the builder for creating many SessionTool entities in bulk.
+type SessionToolCreateBulk struct {
+	config
+	err      error
+	builders []*SessionToolCreate
+}
+
+// Save creates the SessionTool entities in the database.
+func (_c *SessionToolCreateBulk) Save(ctx context.Context) ([]*SessionTool, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*SessionTool, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SessionToolMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *SessionToolCreateBulk) SaveX(ctx context.Context) []*SessionTool {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SessionToolCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SessionToolCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,427 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/sessiontool"
+)
+
+// SessionToolUpdate is the builder for updating SessionTool entities.
+type SessionToolUpdate struct {
+	config
+	hooks    []Hook
+	mutation *SessionToolMutation
+}
+
+// Where appends a list predicates to the SessionToolUpdate builder.
+func (_u *SessionToolUpdate) Where(ps ...predicate.SessionTool) *SessionToolUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetSessionID sets the "session_id" field.
+func (_u *SessionToolUpdate) SetSessionID(v string) *SessionToolUpdate {
+	_u.mutation.SetSessionID(v)
+	return _u
+}
+
+// SetNillableSessionID sets the "session_id" field if the given value is not nil.
+func (_u *SessionToolUpdate) SetNillableSessionID(v *string) *SessionToolUpdate {
+	if v != nil {
+		_u.SetSessionID(*v)
+	}
+	return _u
+}
+
+// SetToolHash sets the "tool_hash" field.
+func (_u *SessionToolUpdate) SetToolHash(v string) *SessionToolUpdate {
+	_u.mutation.SetToolHash(v)
+	return _u
+}
+
+// SetNillableToolHash sets the "tool_hash" field if the given value is not nil.
+func (_u *SessionToolUpdate) SetNillableToolHash(v *string) *SessionToolUpdate {
+	if v != nil {
+		_u.SetToolHash(*v)
+	}
+	return _u
+}
+
+// SetRunID sets the "run_id" field.
+func (_u *SessionToolUpdate) SetRunID(v string) *SessionToolUpdate {
+	_u.mutation.SetRunID(v)
+	return _u
+}
+
+// SetNillableRunID sets the "run_id" field if the given value is not nil.
+func (_u *SessionToolUpdate) SetNillableRunID(v *string) *SessionToolUpdate {
+	if v != nil {
+		_u.SetRunID(*v)
+	}
+	return _u
+}
+
+// ClearRunID clears the value of the "run_id" field.
+func (_u *SessionToolUpdate) ClearRunID() *SessionToolUpdate {
+	_u.mutation.ClearRunID()
+	return _u
+}
+
+// SetAgentName sets the "agent_name" field.
+func (_u *SessionToolUpdate) SetAgentName(v string) *SessionToolUpdate {
+	_u.mutation.SetAgentName(v)
+	return _u
+}
+
+// SetNillableAgentName sets the "agent_name" field if the given value is not nil.
+func (_u *SessionToolUpdate) SetNillableAgentName(v *string) *SessionToolUpdate {
+	if v != nil {
+		_u.SetAgentName(*v)
+	}
+	return _u
+}
+
+// ClearAgentName clears the value of the "agent_name" field.
+func (_u *SessionToolUpdate) ClearAgentName() *SessionToolUpdate {
+	_u.mutation.ClearAgentName()
+	return _u
+}
+
+// SetNodeHash sets the "node_hash" field.
+func (_u *SessionToolUpdate) SetNodeHash(v string) *SessionToolUpdate {
+	_u.mutation.SetNodeHash(v)
+	return _u
+}
+
+// SetNillableNodeHash sets the "node_hash" field if the given value is not nil.
+func (_u *SessionToolUpdate) SetNillableNodeHash(v *string) *SessionToolUpdate {
+	if v != nil {
+		_u.SetNodeHash(*v)
+	}
+	return _u
+}
+
+// Mutation returns the SessionToolMutation object of the builder.
+func (_u *SessionToolUpdate) Mutation() *SessionToolMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *SessionToolUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *SessionToolUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *SessionToolUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *SessionToolUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *SessionToolUpdate) check() error {
+	if v, ok := _u.mutation.SessionID(); ok {
+		if err := sessiontool.SessionIDValidator(v); err != nil {
+			return &ValidationError{Name: "session_id", err: fmt.Errorf(`ent: validator failed for field "SessionTool.session_id": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ToolHash(); ok {
+		if err := sessiontool.ToolHashValidator(v); err != nil {
+			return &ValidationError{Name: "tool_hash", err: fmt.Errorf(`ent: validator failed for field "SessionTool.tool_hash": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.NodeHash(); ok {
+		if err := sessiontool.NodeHashValidator(v); err != nil {
+			return &ValidationError{Name: "node_hash", err: fmt.Errorf(`ent: validator failed for field "SessionTool.node_hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *SessionToolUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(sessiontool.Table, sessiontool.Columns, sqlgraph.NewFieldSpec(sessiontool.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.SessionID(); ok {
+		_spec.SetField(sessiontool.FieldSessionID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ToolHash(); ok {
+		_spec.SetField(sessiontool.FieldToolHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RunID(); ok {
+		_spec.SetField(sessiontool.FieldRunID, field.TypeString, value)
+	}
+	if _u.mutation.RunIDCleared() {
+		_spec.ClearField(sessiontool.FieldRunID, field.TypeString)
+	}
+	if value, ok := _u.mutation.AgentName(); ok {
+		_spec.SetField(sessiontool.FieldAgentName, field.TypeString, value)
+	}
+	if _u.mutation.AgentNameCleared() {
+		_spec.ClearField(sessiontool.FieldAgentName, field.TypeString)
+	}
+	if value, ok := _u.mutation.NodeHash(); ok {
+		_spec.SetField(sessiontool.FieldNodeHash, field.TypeString, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{sessiontool.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// SessionToolUpdateOne is the builder for updating a single SessionTool entity.
+type SessionToolUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *SessionToolMutation
+}
+
+// SetSessionID sets the "session_id" field.
+func (_u *SessionToolUpdateOne) SetSessionID(v string) *SessionToolUpdateOne {
+	_u.mutation.SetSessionID(v)
+	return _u
+}
+
+// SetNillableSessionID sets the "session_id" field if the given value is not nil.
+func (_u *SessionToolUpdateOne) SetNillableSessionID(v *string) *SessionToolUpdateOne {
+	if v != nil {
+		_u.SetSessionID(*v)
+	}
+	return _u
+}
+
+// SetToolHash sets the "tool_hash" field.
+func (_u *SessionToolUpdateOne) SetToolHash(v string) *SessionToolUpdateOne {
+	_u.mutation.SetToolHash(v)
+	return _u
+}
+
+// SetNillableToolHash sets the "tool_hash" field if the given value is not nil.
+func (_u *SessionToolUpdateOne) SetNillableToolHash(v *string) *SessionToolUpdateOne {
+	if v != nil {
+		_u.SetToolHash(*v)
+	}
+	return _u
+}
+
+// SetRunID sets the "run_id" field.
+func (_u *SessionToolUpdateOne) SetRunID(v string) *SessionToolUpdateOne {
+	_u.mutation.SetRunID(v)
+	return _u
+}
+
+// SetNillableRunID sets the "run_id" field if the given value is not nil.
+func (_u *SessionToolUpdateOne) SetNillableRunID(v *string) *SessionToolUpdateOne {
+	if v != nil {
+		_u.SetRunID(*v)
+	}
+	return _u
+}
+
+// ClearRunID clears the value of the "run_id" field.
+func (_u *SessionToolUpdateOne) ClearRunID() *SessionToolUpdateOne {
+	_u.mutation.ClearRunID()
+	return _u
+}
+
+// SetAgentName sets the "agent_name" field.
+func (_u *SessionToolUpdateOne) SetAgentName(v string) *SessionToolUpdateOne {
+	_u.mutation.SetAgentName(v)
+	return _u
+}
+
+// SetNillableAgentName sets the "agent_name" field if the given value is not nil.
+func (_u *SessionToolUpdateOne) SetNillableAgentName(v *string) *SessionToolUpdateOne {
+	if v != nil {
+		_u.SetAgentName(*v)
+	}
+	return _u
+}
+
+// ClearAgentName clears the value of the "agent_name" field.
+func (_u *SessionToolUpdateOne) ClearAgentName() *SessionToolUpdateOne {
+	_u.mutation.ClearAgentName()
+	return _u
+}
+
+// SetNodeHash sets the "node_hash" field.
+func (_u *SessionToolUpdateOne) SetNodeHash(v string) *SessionToolUpdateOne {
+	_u.mutation.SetNodeHash(v)
+	return _u
+}
+
+// SetNillableNodeHash sets the "node_hash" field if the given value is not nil.
+func (_u *SessionToolUpdateOne) SetNillableNodeHash(v *string) *SessionToolUpdateOne {
+	if v != nil {
+		_u.SetNodeHash(*v)
+	}
+	return _u
+}
+
+// Mutation returns the SessionToolMutation object of the builder.
+func (_u *SessionToolUpdateOne) Mutation() *SessionToolMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the SessionToolUpdate builder.
+func (_u *SessionToolUpdateOne) Where(ps ...predicate.SessionTool) *SessionToolUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *SessionToolUpdateOne) Select(field string, fields ...string) *SessionToolUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated SessionTool entity.
+func (_u *SessionToolUpdateOne) Save(ctx context.Context) (*SessionTool, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *SessionToolUpdateOne) SaveX(ctx context.Context) *SessionTool {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *SessionToolUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *SessionToolUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *SessionToolUpdateOne) check() error {
+	if v, ok := _u.mutation.SessionID(); ok {
+		if err := sessiontool.SessionIDValidator(v); err != nil {
+			return &ValidationError{Name: "session_id", err: fmt.Errorf(`ent: validator failed for field "SessionTool.session_id": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ToolHash(); ok {
+		if err := sessiontool.ToolHashValidator(v); err != nil {
+			return &ValidationError{Name: "tool_hash", err: fmt.Errorf(`ent: validator failed for field "SessionTool.tool_hash": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.NodeHash(); ok {
+		if err := sessiontool.NodeHashValidator(v); err != nil {
+			return &ValidationError{Name: "node_hash", err: fmt.Errorf(`ent: validator failed for field "SessionTool.node_hash": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *SessionToolUpdateOne) sqlSave(ctx context.Context) (_node *SessionTool, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(sessiontool.Table, sessiontool.Columns, sqlgraph.NewFieldSpec(sessiontool.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "SessionTool.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, sessiontool.FieldID)
+		for _, f := range fields {
+			if !sessiontool.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != sessiontool.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.SessionID(); ok {
+		_spec.SetField(sessiontool.FieldSessionID, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ToolHash(); ok {
+		_spec.SetField(sessiontool.FieldToolHash, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.RunID(); ok {
+		_spec.SetField(sessiontool.FieldRunID, field.TypeString, value)
+	}
+	if _u.mutation.RunIDCleared() {
+		_spec.ClearField(sessiontool.FieldRunID, field.TypeString)
+	}
+	if value, ok := _u.mutation.AgentName(); ok {
+		_spec.SetField(sessiontool.FieldAgentName, field.TypeString, value)
+	}
+	if _u.mutation.AgentNameCleared() {
+		_spec.ClearField(sessiontool.FieldAgentName, field.TypeString)
+	}
+	if value, ok := _u.mutation.NodeHash(); ok {
+		_spec.SetField(sessiontool.FieldNodeHash, field.TypeString, value)
+	}
+	_node = &SessionTool{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{sessiontool.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
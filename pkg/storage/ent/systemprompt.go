@@ -0,0 +1,143 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/systemprompt"
+)
+
+// SystemPrompt is the model entity for the SystemPrompt schema.
+type SystemPrompt struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID string `json:"id,omitempty"`
+	// Text holds the value of the "text" field.
+	Text []byte `json:"text,omitempty"`
+	// Encrypted holds the value of the "encrypted" field.
+	Encrypted bool `json:"encrypted,omitempty"`
+	// Size holds the value of the "size" field.
+	Size int `json:"size,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*SystemPrompt) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case systemprompt.FieldText:
+			values[i] = new([]byte)
+		case systemprompt.FieldEncrypted:
+			values[i] = new(sql.NullBool)
+		case systemprompt.FieldSize:
+			values[i] = new(sql.NullInt64)
+		case systemprompt.FieldID:
+			values[i] = new(sql.NullString)
+		case systemprompt.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the SystemPrompt fields.
+func (_m *SystemPrompt) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case systemprompt.FieldID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				_m.ID = value.String
+			}
+		case systemprompt.FieldText:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field text", values[i])
+			} else if value != nil {
+				_m.Text = *value
+			}
+		case systemprompt.FieldEncrypted:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field encrypted", values[i])
+			} else if value.Valid {
+				_m.Encrypted = value.Bool
+			}
+		case systemprompt.FieldSize:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field size", values[i])
+			} else if value.Valid {
+				_m.Size = int(value.Int64)
+			}
+		case systemprompt.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the SystemPrompt.
+// This includes values selected through modifiers, order, etc.
+func (_m *SystemPrompt) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this SystemPrompt.
+// Note that you need to call SystemPrompt.Unwrap() before calling this method if this SystemPrompt
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *SystemPrompt) Update() *SystemPromptUpdateOne {
+	return NewSystemPromptClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the SystemPrompt entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *SystemPrompt) Unwrap() *SystemPrompt {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: SystemPrompt is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *SystemPrompt) String() string {
+	var builder strings.Builder
+	builder.WriteString("SystemPrompt(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("text=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Text))
+	builder.WriteString(", ")
+	builder.WriteString("encrypted=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Encrypted))
+	builder.WriteString(", ")
+	builder.WriteString("size=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Size))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// SystemPrompts is a parsable slice of SystemPrompt.
+type SystemPrompts []*SystemPrompt
@@ -0,0 +1,230 @@
+// Code generated by ent, DO NOT EDIT.
+
+package systemprompt
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id string) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id string) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id string) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...string) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...string) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id string) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id string) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id string) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id string) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldLTE(FieldID, id))
+}
+
+// IDEqualFold applies the EqualFold predicate on the ID field.
+func IDEqualFold(id string) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldEqualFold(FieldID, id))
+}
+
+// IDContainsFold applies the ContainsFold predicate on the ID field.
+func IDContainsFold(id string) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldContainsFold(FieldID, id))
+}
+
+// Text applies equality check predicate on the "text" field. It's identical to TextEQ.
+func Text(v []byte) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldEQ(FieldText, v))
+}
+
+// Encrypted applies equality check predicate on the "encrypted" field. It's identical to EncryptedEQ.
+func Encrypted(v bool) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldEQ(FieldEncrypted, v))
+}
+
+// Size applies equality check predicate on the "size" field. It's identical to SizeEQ.
+func Size(v int) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldEQ(FieldSize, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// TextEQ applies the EQ predicate on the "text" field.
+func TextEQ(v []byte) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldEQ(FieldText, v))
+}
+
+// TextNEQ applies the NEQ predicate on the "text" field.
+func TextNEQ(v []byte) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldNEQ(FieldText, v))
+}
+
+// TextIn applies the In predicate on the "text" field.
+func TextIn(vs ...[]byte) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldIn(FieldText, vs...))
+}
+
+// TextNotIn applies the NotIn predicate on the "text" field.
+func TextNotIn(vs ...[]byte) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldNotIn(FieldText, vs...))
+}
+
+// TextGT applies the GT predicate on the "text" field.
+func TextGT(v []byte) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldGT(FieldText, v))
+}
+
+// TextGTE applies the GTE predicate on the "text" field.
+func TextGTE(v []byte) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldGTE(FieldText, v))
+}
+
+// TextLT applies the LT predicate on the "text" field.
+func TextLT(v []byte) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldLT(FieldText, v))
+}
+
+// TextLTE applies the LTE predicate on the "text" field.
+func TextLTE(v []byte) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldLTE(FieldText, v))
+}
+
+// EncryptedEQ applies the EQ predicate on the "encrypted" field.
+func EncryptedEQ(v bool) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldEQ(FieldEncrypted, v))
+}
+
+// EncryptedNEQ applies the NEQ predicate on the "encrypted" field.
+func EncryptedNEQ(v bool) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldNEQ(FieldEncrypted, v))
+}
+
+// SizeEQ applies the EQ predicate on the "size" field.
+func SizeEQ(v int) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldEQ(FieldSize, v))
+}
+
+// SizeNEQ applies the NEQ predicate on the "size" field.
+func SizeNEQ(v int) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldNEQ(FieldSize, v))
+}
+
+// SizeIn applies the In predicate on the "size" field.
+func SizeIn(vs ...int) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldIn(FieldSize, vs...))
+}
+
+// SizeNotIn applies the NotIn predicate on the "size" field.
+func SizeNotIn(vs ...int) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldNotIn(FieldSize, vs...))
+}
+
+// SizeGT applies the GT predicate on the "size" field.
+func SizeGT(v int) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldGT(FieldSize, v))
+}
+
+// SizeGTE applies the GTE predicate on the "size" field.
+func SizeGTE(v int) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldGTE(FieldSize, v))
+}
+
+// SizeLT applies the LT predicate on the "size" field.
+func SizeLT(v int) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldLT(FieldSize, v))
+}
+
+// SizeLTE applies the LTE predicate on the "size" field.
+func SizeLTE(v int) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldLTE(FieldSize, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.SystemPrompt) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.SystemPrompt) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.SystemPrompt) predicate.SystemPrompt {
+	return predicate.SystemPrompt(sql.NotPredicates(p))
+}
@@ -0,0 +1,274 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/systemprompt"
+)
+
+// SystemPromptCreate is the builder for creating a SystemPrompt entity.
+type SystemPromptCreate struct {
+	config
+	mutation *SystemPromptMutation
+	hooks    []Hook
+}
+
+// SetText sets the "text" field.
+func (_c *SystemPromptCreate) SetText(v []byte) *SystemPromptCreate {
+	_c.mutation.SetText(v)
+	return _c
+}
+
+// SetEncrypted sets the "encrypted" field.
+func (_c *SystemPromptCreate) SetEncrypted(v bool) *SystemPromptCreate {
+	_c.mutation.SetEncrypted(v)
+	return _c
+}
+
+// SetNillableEncrypted sets the "encrypted" field if the given value is not nil.
+func (_c *SystemPromptCreate) SetNillableEncrypted(v *bool) *SystemPromptCreate {
+	if v != nil {
+		_c.SetEncrypted(*v)
+	}
+	return _c
+}
+
+// SetSize sets the "size" field.
+func (_c *SystemPromptCreate) SetSize(v int) *SystemPromptCreate {
+	_c.mutation.SetSize(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *SystemPromptCreate) SetCreatedAt(v time.Time) *SystemPromptCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *SystemPromptCreate) SetNillableCreatedAt(v *time.Time) *SystemPromptCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *SystemPromptCreate) SetID(v string) *SystemPromptCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// Mutation returns the SystemPromptMutation object of the builder.
+func (_c *SystemPromptCreate) Mutation() *SystemPromptMutation {
+	return _c.mutation
+}
+
+// Save creates the SystemPrompt in the database.
+func (_c *SystemPromptCreate) Save(ctx context.Context) (*SystemPrompt, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *SystemPromptCreate) SaveX(ctx context.Context) *SystemPrompt {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SystemPromptCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SystemPromptCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *SystemPromptCreate) defaults() {
+	if _, ok := _c.mutation.Encrypted(); !ok {
+		v := systemprompt.DefaultEncrypted
+		_c.mutation.SetEncrypted(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := systemprompt.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *SystemPromptCreate) check() error {
+	if _, ok := _c.mutation.Text(); !ok {
+		return &ValidationError{Name: "text", err: errors.New(`ent: missing required field "SystemPrompt.text"`)}
+	}
+	if _, ok := _c.mutation.Encrypted(); !ok {
+		return &ValidationError{Name: "encrypted", err: errors.New(`ent: missing required field "SystemPrompt.encrypted"`)}
+	}
+	if _, ok := _c.mutation.Size(); !ok {
+		return &ValidationError{Name: "size", err: errors.New(`ent: missing required field "SystemPrompt.size"`)}
+	}
+	if v, ok := _c.mutation.Size(); ok {
+		if err := systemprompt.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "SystemPrompt.size": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "SystemPrompt.created_at"`)}
+	}
+	if v, ok := _c.mutation.ID(); ok {
+		if err := systemprompt.IDValidator(v); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`ent: validator failed for field "SystemPrompt.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_c *SystemPromptCreate) sqlSave(ctx context.Context) (*SystemPrompt, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(string); ok {
+			_node.ID = id
+		} else {
+			return nil, fmt.Errorf("unexpected SystemPrompt.ID type: %T", _spec.ID.Value)
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *SystemPromptCreate) createSpec() (*SystemPrompt, *sqlgraph.CreateSpec) {
+	var (
+		_node = &SystemPrompt{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(systemprompt.Table, sqlgraph.NewFieldSpec(systemprompt.FieldID, field.TypeString))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.Text(); ok {
+		_spec.SetField(systemprompt.FieldText, field.TypeBytes, value)
+		_node.Text = value
+	}
+	if value, ok := _c.mutation.Encrypted(); ok {
+		_spec.SetField(systemprompt.FieldEncrypted, field.TypeBool, value)
+		_node.Encrypted = value
+	}
+	if value, ok := _c.mutation.Size(); ok {
+		_spec.SetField(systemprompt.FieldSize, field.TypeInt, value)
+		_node.Size = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(systemprompt.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// SystemPromptCreateBulk is the builder for creating many SystemPrompt entities in bulk.
+type SystemPromptCreateBulk struct {
+	config
+	err      error
+	builders []*SystemPromptCreate
+}
+
+// Save creates the SystemPrompt entities in the database.
+func (_c *SystemPromptCreateBulk) Save(ctx context.Context) ([]*SystemPrompt, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*SystemPrompt, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SystemPromptMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *SystemPromptCreateBulk) SaveX(ctx context.Context) []*SystemPrompt {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *SystemPromptCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *SystemPromptCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,307 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/systemprompt"
+)
+
+// SystemPromptUpdate is the builder for updating SystemPrompt entities.
+type SystemPromptUpdate struct {
+	config
+	hooks    []Hook
+	mutation *SystemPromptMutation
+}
+
+// Where appends a list predicates to the SystemPromptUpdate builder.
+func (_u *SystemPromptUpdate) Where(ps ...predicate.SystemPrompt) *SystemPromptUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetText sets the "text" field.
+func (_u *SystemPromptUpdate) SetText(v []byte) *SystemPromptUpdate {
+	_u.mutation.SetText(v)
+	return _u
+}
+
+// SetEncrypted sets the "encrypted" field.
+func (_u *SystemPromptUpdate) SetEncrypted(v bool) *SystemPromptUpdate {
+	_u.mutation.SetEncrypted(v)
+	return _u
+}
+
+// SetNillableEncrypted sets the "encrypted" field if the given value is not nil.
+func (_u *SystemPromptUpdate) SetNillableEncrypted(v *bool) *SystemPromptUpdate {
+	if v != nil {
+		_u.SetEncrypted(*v)
+	}
+	return _u
+}
+
+// SetSize sets the "size" field.
+func (_u *SystemPromptUpdate) SetSize(v int) *SystemPromptUpdate {
+	_u.mutation.ResetSize()
+	_u.mutation.SetSize(v)
+	return _u
+}
+
+// SetNillableSize sets the "size" field if the given value is not nil.
+func (_u *SystemPromptUpdate) SetNillableSize(v *int) *SystemPromptUpdate {
+	if v != nil {
+		_u.SetSize(*v)
+	}
+	return _u
+}
+
+// AddSize adds value to the "size" field.
+func (_u *SystemPromptUpdate) AddSize(v int) *SystemPromptUpdate {
+	_u.mutation.AddSize(v)
+	return _u
+}
+
+// Mutation returns the SystemPromptMutation object of the builder.
+func (_u *SystemPromptUpdate) Mutation() *SystemPromptMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *SystemPromptUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *SystemPromptUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *SystemPromptUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *SystemPromptUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *SystemPromptUpdate) check() error {
+	if v, ok := _u.mutation.Size(); ok {
+		if err := systemprompt.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "SystemPrompt.size": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *SystemPromptUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(systemprompt.Table, systemprompt.Columns, sqlgraph.NewFieldSpec(systemprompt.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Text(); ok {
+		_spec.SetField(systemprompt.FieldText, field.TypeBytes, value)
+	}
+	if value, ok := _u.mutation.Encrypted(); ok {
+		_spec.SetField(systemprompt.FieldEncrypted, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Size(); ok {
+		_spec.SetField(systemprompt.FieldSize, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedSize(); ok {
+		_spec.AddField(systemprompt.FieldSize, field.TypeInt, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{systemprompt.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// SystemPromptUpdateOne is the builder for updating a single SystemPrompt entity.
+type SystemPromptUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *SystemPromptMutation
+}
+
+// SetText sets the "text" field.
+func (_u *SystemPromptUpdateOne) SetText(v []byte) *SystemPromptUpdateOne {
+	_u.mutation.SetText(v)
+	return _u
+}
+
+// SetEncrypted sets the "encrypted" field.
+func (_u *SystemPromptUpdateOne) SetEncrypted(v bool) *SystemPromptUpdateOne {
+	_u.mutation.SetEncrypted(v)
+	return _u
+}
+
+// SetNillableEncrypted sets the "encrypted" field if the given value is not nil.
+func (_u *SystemPromptUpdateOne) SetNillableEncrypted(v *bool) *SystemPromptUpdateOne {
+	if v != nil {
+		_u.SetEncrypted(*v)
+	}
+	return _u
+}
+
+// SetSize sets the "size" field.
+func (_u *SystemPromptUpdateOne) SetSize(v int) *SystemPromptUpdateOne {
+	_u.mutation.ResetSize()
+	_u.mutation.SetSize(v)
+	return _u
+}
+
+// SetNillableSize sets the "size" field if the given value is not nil.
+func (_u *SystemPromptUpdateOne) SetNillableSize(v *int) *SystemPromptUpdateOne {
+	if v != nil {
+		_u.SetSize(*v)
+	}
+	return _u
+}
+
+// AddSize adds value to the "size" field.
+func (_u *SystemPromptUpdateOne) AddSize(v int) *SystemPromptUpdateOne {
+	_u.mutation.AddSize(v)
+	return _u
+}
+
+// Mutation returns the SystemPromptMutation object of the builder.
+func (_u *SystemPromptUpdateOne) Mutation() *SystemPromptMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the SystemPromptUpdate builder.
+func (_u *SystemPromptUpdateOne) Where(ps ...predicate.SystemPrompt) *SystemPromptUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *SystemPromptUpdateOne) Select(field string, fields ...string) *SystemPromptUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated SystemPrompt entity.
+func (_u *SystemPromptUpdateOne) Save(ctx context.Context) (*SystemPrompt, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *SystemPromptUpdateOne) SaveX(ctx context.Context) *SystemPrompt {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *SystemPromptUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *SystemPromptUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *SystemPromptUpdateOne) check() error {
+	if v, ok := _u.mutation.Size(); ok {
+		if err := systemprompt.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "SystemPrompt.size": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *SystemPromptUpdateOne) sqlSave(ctx context.Context) (_node *SystemPrompt, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(systemprompt.Table, systemprompt.Columns, sqlgraph.NewFieldSpec(systemprompt.FieldID, field.TypeString))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "SystemPrompt.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, systemprompt.FieldID)
+		for _, f := range fields {
+			if !systemprompt.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != systemprompt.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Text(); ok {
+		_spec.SetField(systemprompt.FieldText, field.TypeBytes, value)
+	}
+	if value, ok := _u.mutation.Encrypted(); ok {
+		_spec.SetField(systemprompt.FieldEncrypted, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.Size(); ok {
+		_spec.SetField(systemprompt.FieldSize, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedSize(); ok {
+		_spec.AddField(systemprompt.FieldSize, field.TypeInt, value)
+	}
+	_node = &SystemPrompt{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{systemprompt.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
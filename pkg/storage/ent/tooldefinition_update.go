@@ -0,0 +1,349 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/predicate"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/tooldefinition"
+)
+
+// ToolDefinitionUpdate is the builder for updating ToolDefinition entities.
+type ToolDefinitionUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ToolDefinitionMutation
+}
+
+// Where appends a list predicates to the ToolDefinitionUpdate builder.
+func (_u *ToolDefinitionUpdate) Where(ps ...predicate.ToolDefinition) *ToolDefinitionUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetType sets the "type" field.
+func (_u *ToolDefinitionUpdate) SetType(v string) *ToolDefinitionUpdate {
+	_u.mutation.SetType(v)
+	return _u
+}
+
+// SetNillableType sets the "type" field if the given value is not nil.
+func (_u *ToolDefinitionUpdate) SetNillableType(v *string) *ToolDefinitionUpdate {
+	if v != nil {
+		_u.SetType(*v)
+	}
+	return _u
+}
+
+// ClearType clears the value of the "type" field.
+func (_u *ToolDefinitionUpdate) ClearType() *ToolDefinitionUpdate {
+	_u.mutation.ClearType()
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *ToolDefinitionUpdate) SetName(v string) *ToolDefinitionUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *ToolDefinitionUpdate) SetNillableName(v *string) *ToolDefinitionUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *ToolDefinitionUpdate) SetDescription(v string) *ToolDefinitionUpdate {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *ToolDefinitionUpdate) SetNillableDescription(v *string) *ToolDefinitionUpdate {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *ToolDefinitionUpdate) ClearDescription() *ToolDefinitionUpdate {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetSchema sets the "schema" field.
+func (_u *ToolDefinitionUpdate) SetSchema(v map[string]interface{}) *ToolDefinitionUpdate {
+	_u.mutation.SetSchema(v)
+	return _u
+}
+
+// ClearSchema clears the value of the "schema" field.
+func (_u *ToolDefinitionUpdate) ClearSchema() *ToolDefinitionUpdate {
+	_u.mutation.ClearSchema()
+	return _u
+}
+
+// Mutation returns the ToolDefinitionMutation object of the builder.
+func (_u *ToolDefinitionUpdate) Mutation() *ToolDefinitionMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *ToolDefinitionUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *ToolDefinitionUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *ToolDefinitionUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *ToolDefinitionUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *ToolDefinitionUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(tooldefinition.Table, tooldefinition.Columns, sqlgraph.NewFieldSpec(tooldefinition.FieldID, field.TypeString))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.GetType(); ok {
+		_spec.SetField(tooldefinition.FieldType, field.TypeString, value)
+	}
+	if _u.mutation.TypeCleared() {
+		_spec.ClearField(tooldefinition.FieldType, field.TypeString)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(tooldefinition.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(tooldefinition.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(tooldefinition.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.Schema(); ok {
+		_spec.SetField(tooldefinition.FieldSchema, field.TypeJSON, value)
+	}
+	if _u.mutation.SchemaCleared() {
+		_spec.ClearField(tooldefinition.FieldSchema, field.TypeJSON)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tooldefinition.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// ToolDefinitionUpdateOne is the builder for updating a single ToolDefinition entity.
+type ToolDefinitionUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ToolDefinitionMutation
+}
+
+// SetType sets the "type" field.
+func (_u *ToolDefinitionUpdateOne) SetType(v string) *ToolDefinitionUpdateOne {
+	_u.mutation.SetType(v)
+	return _u
+}
+
+// SetNillableType sets the "type" field if the given value is not nil.
+func (_u *ToolDefinitionUpdateOne) SetNillableType(v *string) *ToolDefinitionUpdateOne {
+	if v != nil {
+		_u.SetType(*v)
+	}
+	return _u
+}
+
+// ClearType clears the value of the "type" field.
+func (_u *ToolDefinitionUpdateOne) ClearType() *ToolDefinitionUpdateOne {
+	_u.mutation.ClearType()
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *ToolDefinitionUpdateOne) SetName(v string) *ToolDefinitionUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *ToolDefinitionUpdateOne) SetNillableName(v *string) *ToolDefinitionUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *ToolDefinitionUpdateOne) SetDescription(v string) *ToolDefinitionUpdateOne {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *ToolDefinitionUpdateOne) SetNillableDescription(v *string) *ToolDefinitionUpdateOne {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *ToolDefinitionUpdateOne) ClearDescription() *ToolDefinitionUpdateOne {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetSchema sets the "schema" field.
+func (_u *ToolDefinitionUpdateOne) SetSchema(v map[string]interface{}) *ToolDefinitionUpdateOne {
+	_u.mutation.SetSchema(v)
+	return _u
+}
+
+// ClearSchema clears the value of the "schema" field.
+func (_u *ToolDefinitionUpdateOne) ClearSchema() *ToolDefinitionUpdateOne {
+	_u.mutation.ClearSchema()
+	return _u
+}
+
+// Mutation returns the ToolDefinitionMutation object of the builder.
+func (_u *ToolDefinitionUpdateOne) Mutation() *ToolDefinitionMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the ToolDefinitionUpdate builder.
+func (_u *ToolDefinitionUpdateOne) Where(ps ...predicate.ToolDefinition) *ToolDefinitionUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *ToolDefinitionUpdateOne) Select(field string, fields ...string) *ToolDefinitionUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated ToolDefinition entity.
+func (_u *ToolDefinitionUpdateOne) Save(ctx context.Context) (*ToolDefinition, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *ToolDefinitionUpdateOne) SaveX(ctx context.Context) *ToolDefinition {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *ToolDefinitionUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *ToolDefinitionUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (_u *ToolDefinitionUpdateOne) sqlSave(ctx context.Context) (_node *ToolDefinition, err error) {
+	_spec := sqlgraph.NewUpdateSpec(tooldefinition.Table, tooldefinition.Columns, sqlgraph.NewFieldSpec(tooldefinition.FieldID, field.TypeString))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ToolDefinition.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, tooldefinition.FieldID)
+		for _, f := range fields {
+			if !tooldefinition.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != tooldefinition.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.GetType(); ok {
+		_spec.SetField(tooldefinition.FieldType, field.TypeString, value)
+	}
+	if _u.mutation.TypeCleared() {
+		_spec.ClearField(tooldefinition.FieldType, field.TypeString)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(tooldefinition.FieldName, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Description(); ok {
+		_spec.SetField(tooldefinition.FieldDescription, field.TypeString, value)
+	}
+	if _u.mutation.DescriptionCleared() {
+		_spec.ClearField(tooldefinition.FieldDescription, field.TypeString)
+	}
+	if value, ok := _u.mutation.Schema(); ok {
+		_spec.SetField(tooldefinition.FieldSchema, field.TypeJSON, value)
+	}
+	if _u.mutation.SchemaCleared() {
+		_spec.ClearField(tooldefinition.FieldSchema, field.TypeJSON)
+	}
+	_node = &ToolDefinition{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{tooldefinition.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
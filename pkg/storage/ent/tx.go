@@ -4,6 +4,8 @@ package ent
 
 import (
 	"context"
+	stdsql "database/sql"
+	"fmt"
 	"sync"
 
 	"entgo.io/ent/dialect"
@@ -12,10 +14,24 @@ import (
 // Tx is a transactional client that is created by calling Client.Tx().
 type Tx struct {
 	config
+	// Block is the client for interacting with the Block builders.
+	Block *BlockClient
+	// DeadLetter is the client for interacting with the DeadLetter builders.
+	DeadLetter *DeadLetterClient
 	// Facet is the client for interacting with the Facet builders.
 	Facet *FacetClient
 	// Node is the client for interacting with the Node builders.
 	Node *NodeClient
+	// RawCapture is the client for interacting with the RawCapture builders.
+	RawCapture *RawCaptureClient
+	// Session is the client for interacting with the Session builders.
+	Session *SessionClient
+	// SessionTool is the client for interacting with the SessionTool builders.
+	SessionTool *SessionToolClient
+	// SystemPrompt is the client for interacting with the SystemPrompt builders.
+	SystemPrompt *SystemPromptClient
+	// ToolDefinition is the client for interacting with the ToolDefinition builders.
+	ToolDefinition *ToolDefinitionClient
 
 	// lazily loaded.
 	client     *Client
@@ -147,8 +163,15 @@ func (tx *Tx) Client() *Client {
 }
 
 func (tx *Tx) init() {
+	tx.Block = NewBlockClient(tx.config)
+	tx.DeadLetter = NewDeadLetterClient(tx.config)
 	tx.Facet = NewFacetClient(tx.config)
 	tx.Node = NewNodeClient(tx.config)
+	tx.RawCapture = NewRawCaptureClient(tx.config)
+	tx.Session = NewSessionClient(tx.config)
+	tx.SessionTool = NewSessionToolClient(tx.config)
+	tx.SystemPrompt = NewSystemPromptClient(tx.config)
+	tx.ToolDefinition = NewToolDefinitionClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -158,7 +181,7 @@ func (tx *Tx) init() {
 // of them in order to commit or rollback the transaction.
 //
 // If a closed transaction is embedded in one of the generated entities, and the entity
-// applies a query, for example: Facet.QueryXXX(), the query will be executed
+// applies a query, for example: Block.QueryXXX(), the query will be executed
 // through the driver which created this transaction.
 //
 // Note that txDriver is not goroutine safe.
@@ -211,3 +234,27 @@ func (tx *txDriver) Query(ctx context.Context, query string, args, v any) error
 }
 
 var _ dialect.Driver = (*txDriver)(nil)
+
+// ExecContext allows calling the underlying ExecContext method of the transaction if it is supported by it.
+// See, database/sql#Tx.ExecContext for more information.
+func (tx *txDriver) ExecContext(ctx context.Context, query string, args ...any) (stdsql.Result, error) {
+	ex, ok := tx.tx.(interface {
+		ExecContext(context.Context, string, ...any) (stdsql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.ExecContext is not supported")
+	}
+	return ex.ExecContext(ctx, query, args...)
+}
+
+// QueryContext allows calling the underlying QueryContext method of the transaction if it is supported by it.
+// See, database/sql#Tx.QueryContext for more information.
+func (tx *txDriver) QueryContext(ctx context.Context, query string, args ...any) (*stdsql.Rows, error) {
+	q, ok := tx.tx.(interface {
+		QueryContext(context.Context, string, ...any) (*stdsql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.QueryContext is not supported")
+	}
+	return q.QueryContext(ctx, query, args...)
+}
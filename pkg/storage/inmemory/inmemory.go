@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/papercomputeco/tapes/pkg/merkle"
 	"github.com/papercomputeco/tapes/pkg/storage"
@@ -18,12 +20,34 @@ type Driver struct {
 	// nodes is the in memory map of nodes where the key is the content-addressed
 	// hash for the node
 	nodes map[string]*merkle.Node
+
+	// deadLetters holds unparseable turns in insertion order
+	deadLetters  []*storage.DeadLetter
+	nextLetterID int
+
+	// rawCaptures holds raw turn payloads in insertion order
+	rawCaptures []*storage.RawCapture
+
+	// sessionTools holds the tool definitions of sessions in the order
+	// they were first offered
+	sessionTools []*storage.SessionTool
+
+	// systemPrompts maps the hash of each stored system prompt to its text
+	systemPrompts map[string]string
+
+	// snapshotPath is where a persistent driver saves its snapshot; stop
+	// ends its periodic snapshots.
+	snapshotPath string
+	stop         chan struct{}
+	wg           sync.WaitGroup
+	closeOnce    sync.Once
 }
 
 // NewDriver creates a new in-memory storer.
 func NewDriver() *Driver {
 	return &Driver{
-		nodes: make(map[string]*merkle.Node),
+		nodes:         make(map[string]*merkle.Node),
+		systemPrompts: make(map[string]string),
 	}
 }
 
@@ -37,14 +61,44 @@ func (s *Driver) Put(_ context.Context, node *merkle.Node) (bool, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	return s.put(node), nil
+}
+
+// InsertBatch stores nodes under a single lock, so that readers see either
+// none or all of the batch.
+func (s *Driver) InsertBatch(_ context.Context, nodes []*merkle.Node) ([]bool, error) {
+	for _, node := range nodes {
+		if node == nil {
+			return nil, errors.New("cannot store nil node")
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	inserted := make([]bool, len(nodes))
+	for i, node := range nodes {
+		inserted[i] = s.put(node)
+	}
+	return inserted, nil
+}
+
+// put stores a node unless it already exists. The caller must hold s.mu.
+func (s *Driver) put(node *merkle.Node) bool {
 	// Idempotent insert - deduplication via content-addressing
 	_, ok := s.nodes[node.Hash]
 	if ok {
-		return false, nil
+		return false
 	}
 
-	s.nodes[node.Hash] = node
-	return true, nil
+	// Like the SQLite driver, record when the node was first stored without
+	// touching the caller's node.
+	stored := *node
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = time.Now()
+	}
+	s.nodes[node.Hash] = &stored
+	return true
 }
 
 // Get retrieves a node by its hash.
@@ -87,20 +141,16 @@ func (s *Driver) GetByParent(_ context.Context, parentHash *string) ([]*merkle.N
 			}
 		}
 	}
+	sortNodes(result)
 	return result, nil
 }
 
-// List returns all nodes in the store.
+// List returns all nodes in the store, oldest first.
 func (s *Driver) List(_ context.Context) ([]*merkle.Node, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	nodes := make([]*merkle.Node, 0, len(s.nodes))
-	for _, node := range s.nodes {
-		nodes = append(nodes, node)
-	}
-
-	return nodes, nil
+	return s.sortedNodes(), nil
 }
 
 // Roots returns all root nodes
@@ -128,59 +178,233 @@ func (s *Driver) Leaves(_ context.Context) ([]*merkle.Node, error) {
 			leaves = append(leaves, node)
 		}
 	}
+	sortNodes(leaves)
 
 	return leaves, nil
 }
 
-// Ancestry returns the path from a node back to its root (node first, root last).
-func (s *Driver) Ancestry(ctx context.Context, hash string) ([]*merkle.Node, error) {
-	var path []*merkle.Node
-	current := hash
+// Ancestry returns the path from a node back to its root (node first, root
+// last). Like the SQLite driver, the path ends at the oldest stored ancestor
+// when a parent is missing, such as after pruning.
+func (s *Driver) Ancestry(_ context.Context, hash string) ([]*merkle.Node, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	for {
-		node, err := s.Get(ctx, current)
-		if err != nil {
-			return nil, fmt.Errorf("getting node %s: %w", current, err)
-		}
-		path = append(path, node)
+	node, ok := s.nodes[hash]
+	if !ok {
+		return nil, storage.NotFoundError{Hash: hash}
+	}
 
-		if node.ParentHash == nil {
+	path := []*merkle.Node{node}
+	for node.ParentHash != nil {
+		node, ok = s.nodes[*node.ParentHash]
+		if !ok {
 			break
 		}
-		current = *node.ParentHash
+		path = append(path, node)
 	}
-
 	return path, nil
 }
 
 // Depth returns the depth of a node (0 for roots).
 func (s *Driver) Depth(ctx context.Context, hash string) (int, error) {
-	depth := 0
-	current := hash
+	path, err := s.Ancestry(ctx, hash)
+	if err != nil {
+		return 0, err
+	}
+	return len(path) - 1, nil
+}
+
+// Count returns the number of nodes in the in-memory store.
+func (s *Driver) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.nodes)
+}
 
-	for {
-		node, err := s.Get(ctx, current)
-		if err != nil {
-			return 0, err
+// AddDeadLetter stores a dead letter, assigning its ID and CreatedAt.
+func (s *Driver) AddDeadLetter(_ context.Context, dl *storage.DeadLetter) error {
+	if dl == nil {
+		return errors.New("cannot store nil dead letter")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextLetterID++
+	dl.ID = s.nextLetterID
+	dl.CreatedAt = time.Now()
+
+	stored := *dl
+	s.deadLetters = append(s.deadLetters, &stored)
+	return nil
+}
+
+// ListDeadLetters returns all dead letters, oldest first.
+func (s *Driver) ListDeadLetters(_ context.Context) ([]*storage.DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*storage.DeadLetter, 0, len(s.deadLetters))
+	for _, dl := range s.deadLetters {
+		letter := *dl
+		result = append(result, &letter)
+	}
+	return result, nil
+}
+
+// GetDeadLetter retrieves a dead letter by ID.
+func (s *Driver) GetDeadLetter(_ context.Context, id int) (*storage.DeadLetter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, dl := range s.deadLetters {
+		if dl.ID == id {
+			letter := *dl
+			return &letter, nil
 		}
-		if node.ParentHash == nil {
-			break
+	}
+	return nil, storage.DeadLetterNotFoundError{ID: id}
+}
+
+// DeleteDeadLetter removes a dead letter by ID.
+func (s *Driver) DeleteDeadLetter(_ context.Context, id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := slices.IndexFunc(s.deadLetters, func(dl *storage.DeadLetter) bool { return dl.ID == id })
+	if idx < 0 {
+		return storage.DeadLetterNotFoundError{ID: id}
+	}
+	s.deadLetters = slices.Delete(s.deadLetters, idx, idx+1)
+	return nil
+}
+
+// AddRawCapture stores a raw capture, assigning its ID and CreatedAt.
+func (s *Driver) AddRawCapture(_ context.Context, rc *storage.RawCapture) error {
+	if rc == nil {
+		return errors.New("cannot store nil raw capture")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rc.ID = len(s.rawCaptures) + 1
+	rc.CreatedAt = time.Now()
+
+	stored := *rc
+	s.rawCaptures = append(s.rawCaptures, &stored)
+	return nil
+}
+
+// ListRawCaptures returns the raw captures made at or after since, oldest
+// first.
+func (s *Driver) ListRawCaptures(_ context.Context, since time.Time) ([]*storage.RawCapture, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]*storage.RawCapture, 0, len(s.rawCaptures))
+	for _, rc := range s.rawCaptures {
+		if rc.CreatedAt.Before(since) {
+			continue
 		}
-		depth++
-		current = *node.ParentHash
+		capture := *rc
+		result = append(result, &capture)
 	}
+	return result, nil
+}
+
+// ReconcileRawCapture points a raw capture at its reprocessed turn and marks
+// the nodes it replaced as superseded.
+func (s *Driver) ReconcileRawCapture(_ context.Context, id int, nodeHash string, superseded map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	return depth, nil
+	idx := slices.IndexFunc(s.rawCaptures, func(rc *storage.RawCapture) bool { return rc.ID == id })
+	if idx < 0 {
+		return fmt.Errorf("raw capture %d not found", id)
+	}
+	s.rawCaptures[idx].NodeHash = nodeHash
+
+	for hash, by := range superseded {
+		if node, ok := s.nodes[hash]; ok {
+			node.SupersededBy = by
+		}
+	}
+	return nil
 }
 
-// Count returns the number of nodes in the in-memory store.
-func (s *Driver) Count() int {
+// AddSessionTools stores tool definitions offered in sessions, skipping those
+// a session already offered.
+func (s *Driver) AddSessionTools(_ context.Context, tools []*storage.SessionTool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, t := range tools {
+		if t == nil {
+			return errors.New("cannot store nil session tool")
+		}
+		t.Hash = t.Tool.Hash()
+		if slices.ContainsFunc(s.sessionTools, func(st *storage.SessionTool) bool {
+			return st.SessionID == t.SessionID && st.Hash == t.Hash
+		}) {
+			continue
+		}
+		t.CreatedAt = time.Now()
+		stored := *t
+		s.sessionTools = append(s.sessionTools, &stored)
+	}
+	return nil
+}
+
+// ListSessionTools returns the tool definitions offered in a session, in the
+// order they were first offered.
+func (s *Driver) ListSessionTools(_ context.Context, sessionID string) ([]*storage.SessionTool, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return len(s.nodes)
+
+	result := []*storage.SessionTool{}
+	for _, st := range s.sessionTools {
+		if st.SessionID == sessionID {
+			tool := *st
+			result = append(result, &tool)
+		}
+	}
+	return result, nil
 }
 
-// Close is a no-op for the in-memory storer.
-func (s *Driver) Close() error {
+// AddSystemPrompt stores a system prompt and returns its hash.
+func (s *Driver) AddSystemPrompt(_ context.Context, text string) (string, error) {
+	hash := storage.SystemPromptHash(text)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.systemPrompts[hash] = text
+	return hash, nil
+}
+
+// GetSystemPrompt returns the system prompt with the given hash.
+func (s *Driver) GetSystemPrompt(_ context.Context, hash string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	text, ok := s.systemPrompts[hash]
+	if !ok {
+		return "", fmt.Errorf("system prompt %s not found", hash)
+	}
+	return text, nil
+}
+
+// EndSession marks the session rooted at rootHash as ended.
+func (s *Driver) EndSession(_ context.Context, rootHash string, at time.Time, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node, ok := s.nodes[rootHash]
+	if !ok {
+		return storage.NotFoundError{Hash: rootHash}
+	}
+	node.EndedAt = &at
+	node.EndReason = reason
 	return nil
 }
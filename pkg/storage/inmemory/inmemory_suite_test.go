@@ -0,0 +1,13 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestInMemory(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "In-memory Storer Suite")
+}
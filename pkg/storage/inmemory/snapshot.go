@@ -0,0 +1,229 @@
+package inmemory
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage"
+)
+
+// snapshotVersion is the format version written to snapshots. Restore
+// rejects snapshots written by a newer version.
+const snapshotVersion = 1
+
+// DefaultSnapshotInterval is how often a persistent driver writes its
+// snapshot when no interval is configured.
+const DefaultSnapshotInterval = time.Minute
+
+// snapshot is the JSON document a driver is saved to.
+type snapshot struct {
+	Version     int                   `json:"version"`
+	CreatedAt   time.Time             `json:"created_at"`
+	Nodes       []*merkle.Node        `json:"nodes"`
+	DeadLetters []*storage.DeadLetter `json:"dead_letters,omitempty"`
+	RawCaptures []*storage.RawCapture `json:"raw_captures,omitempty"`
+
+	SessionTools  []*storage.SessionTool `json:"session_tools,omitempty"`
+	SystemPrompts map[string]string      `json:"system_prompts,omitempty"`
+}
+
+// SnapshotOptions configures a persistent driver.
+type SnapshotOptions struct {
+	// Interval is how often the snapshot is written (defaults to
+	// DefaultSnapshotInterval). A negative interval only writes it on Close.
+	Interval time.Duration
+
+	// Logger receives errors from periodic snapshots. Nil discards them.
+	Logger *zap.Logger
+}
+
+// NewPersistentDriver creates an in-memory driver that is restored from the
+// snapshot at path, if one exists, and saved back to it periodically and on
+// Close. It suits ephemeral capture runs, such as CI jobs, that want a file
+// of the session at the end without running a database.
+func NewPersistentDriver(path string, opts SnapshotOptions) (*Driver, error) {
+	s := NewDriver()
+
+	f, err := os.Open(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("opening snapshot: %w", err)
+	default:
+		err := s.Restore(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Interval == 0 {
+		opts.Interval = DefaultSnapshotInterval
+	}
+	logger := opts.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	s.snapshotPath = path
+	s.stop = make(chan struct{})
+	if opts.Interval > 0 {
+		s.wg.Add(1)
+		go s.snapshotLoop(opts.Interval, logger)
+	}
+	return s, nil
+}
+
+// snapshotLoop saves the snapshot every interval until the driver is closed.
+func (s *Driver) snapshotLoop(interval time.Duration, logger *zap.Logger) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			if err := s.SaveSnapshot(s.snapshotPath); err != nil {
+				logger.Warn("failed to save in-memory snapshot",
+					zap.String("path", s.snapshotPath),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+// Snapshot writes the driver's nodes, dead letters, raw captures, session
+// tools, and system prompts to w as JSON. Nodes are written oldest first.
+func (s *Driver) Snapshot(w io.Writer) error {
+	s.mu.RLock()
+	snap := snapshot{
+		Version:     snapshotVersion,
+		CreatedAt:   time.Now(),
+		Nodes:       s.sortedNodes(),
+		DeadLetters: slices.Clone(s.deadLetters),
+		RawCaptures: slices.Clone(s.rawCaptures),
+
+		SessionTools:  slices.Clone(s.sessionTools),
+		SystemPrompts: maps.Clone(s.systemPrompts),
+	}
+	data, err := json.Marshal(snap)
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	return nil
+}
+
+// SaveSnapshot writes a snapshot to path atomically, so that a crash while
+// saving leaves the previous snapshot in place.
+func (s *Driver) SaveSnapshot(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := s.Snapshot(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("saving snapshot: %w", err)
+	}
+	return nil
+}
+
+// Restore adds the contents of a snapshot read from r to the driver. Nodes
+// are verified against their hashes, so a corrupted or edited snapshot is
+// rejected rather than loaded.
+func (s *Driver) Restore(r io.Reader) error {
+	var snap snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return fmt.Errorf("decoding snapshot: %w", err)
+	}
+	if snap.Version > snapshotVersion {
+		return fmt.Errorf("snapshot version %d is newer than supported version %d", snap.Version, snapshotVersion)
+	}
+	for _, n := range snap.Nodes {
+		if !n.Verify() {
+			return fmt.Errorf("snapshot node %s does not match its hash", n.Hash)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, n := range snap.Nodes {
+		if _, ok := s.nodes[n.Hash]; !ok {
+			s.nodes[n.Hash] = n
+		}
+	}
+	for _, dl := range snap.DeadLetters {
+		s.deadLetters = append(s.deadLetters, dl)
+		s.nextLetterID = max(s.nextLetterID, dl.ID)
+	}
+	s.rawCaptures = append(s.rawCaptures, snap.RawCaptures...)
+	s.sessionTools = append(s.sessionTools, snap.SessionTools...)
+	maps.Copy(s.systemPrompts, snap.SystemPrompts)
+	return nil
+}
+
+// Close stops periodic snapshots and, for a persistent driver, saves the
+// final snapshot.
+func (s *Driver) Close() error {
+	if s.stop == nil {
+		return nil
+	}
+
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.stop)
+		s.wg.Wait()
+		err = s.SaveSnapshot(s.snapshotPath)
+	})
+	return err
+}
+
+// sortedNodes returns the nodes oldest first, breaking ties by hash. The
+// caller must hold s.mu.
+func (s *Driver) sortedNodes() []*merkle.Node {
+	nodes := make([]*merkle.Node, 0, len(s.nodes))
+	for _, n := range s.nodes {
+		nodes = append(nodes, n)
+	}
+	sortNodes(nodes)
+	return nodes
+}
+
+// sortNodes orders nodes oldest first, breaking ties by hash, matching the
+// order the SQLite driver lists them in.
+func sortNodes(nodes []*merkle.Node) {
+	slices.SortFunc(nodes, func(a, b *merkle.Node) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Hash, b.Hash)
+	})
+}
@@ -0,0 +1,145 @@
+package inmemory_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+func testBucket(text string) merkle.Bucket {
+	return merkle.Bucket{
+		Type:     "message",
+		Role:     "user",
+		Content:  []llm.ContentBlock{{Type: "text", Text: text}},
+		Model:    "test-model",
+		Provider: "test-provider",
+	}
+}
+
+var _ = Describe("Snapshots", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "tapes.json")
+	})
+
+	It("restores nodes, dead letters, raw captures, session tools, and system prompts saved on Close", func() {
+		ctx := GinkgoT().Context()
+		driver, err := inmemory.NewPersistentDriver(path, inmemory.SnapshotOptions{Interval: -1})
+		Expect(err).NotTo(HaveOccurred())
+
+		root := merkle.NewNode(testBucket("hello"), nil)
+		child := merkle.NewNode(testBucket("world"), root)
+		_, err = driver.Put(ctx, root)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = driver.Put(ctx, child)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.AddDeadLetter(ctx, &storage.DeadLetter{Provider: "openai", Error: "bad"})).To(Succeed())
+		Expect(driver.AddRawCapture(ctx, &storage.RawCapture{NodeHash: child.Hash, Provider: "openai", Request: []byte(`{}`)})).To(Succeed())
+		tool := llm.ToolDefinition{Type: "function", Name: "read_file", Schema: map[string]any{"type": "object"}}
+		Expect(driver.AddSessionTools(ctx, []*storage.SessionTool{
+			{SessionID: "s1", NodeHash: child.Hash, Tool: tool},
+			{SessionID: "s1", NodeHash: child.Hash, Tool: tool},
+		})).To(Succeed())
+		promptHash, err := driver.AddSystemPrompt(ctx, "You are a coding agent.")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Close()).To(Succeed())
+
+		restored, err := inmemory.NewPersistentDriver(path, inmemory.SnapshotOptions{Interval: -1})
+		Expect(err).NotTo(HaveOccurred())
+		defer restored.Close()
+
+		ancestry, err := restored.Ancestry(ctx, child.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ancestry).To(HaveLen(2))
+		Expect(ancestry[1].Hash).To(Equal(root.Hash))
+
+		letters, err := restored.ListDeadLetters(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters).To(HaveLen(1))
+		Expect(restored.AddDeadLetter(ctx, &storage.DeadLetter{Provider: "openai"})).To(Succeed())
+		letters, err = restored.ListDeadLetters(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters[1].ID).To(BeNumerically(">", letters[0].ID))
+
+		captures, err := restored.ListRawCaptures(ctx, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(captures).To(HaveLen(1))
+		Expect(captures[0].Request).To(Equal([]byte(`{}`)))
+
+		tools, err := restored.ListSessionTools(ctx, "s1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tools).To(HaveLen(1))
+		Expect(tools[0].Tool).To(Equal(tool))
+		Expect(tools[0].Hash).To(Equal(tool.Hash()))
+
+		prompt, err := restored.GetSystemPrompt(ctx, promptHash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prompt).To(Equal("You are a coding agent."))
+	})
+
+	It("rejects a snapshot whose nodes do not match their hashes", func() {
+		driver := inmemory.NewDriver()
+		_, err := driver.Put(GinkgoT().Context(), merkle.NewNode(testBucket("original"), nil))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.SaveSnapshot(path)).To(Succeed())
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(path, []byte(strings.Replace(string(data), "original", "tampered", 1)), 0o600)).To(Succeed())
+
+		_, err = inmemory.NewPersistentDriver(path, inmemory.SnapshotOptions{Interval: -1})
+		Expect(err).To(MatchError(ContainSubstring("does not match its hash")))
+	})
+
+	It("saves periodically", func() {
+		driver, err := inmemory.NewPersistentDriver(path, inmemory.SnapshotOptions{Interval: 10 * time.Millisecond})
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		_, err = driver.Put(GinkgoT().Context(), merkle.NewNode(testBucket("hello"), nil))
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() (string, error) {
+			data, err := os.ReadFile(path)
+			return string(data), err
+		}).Should(ContainSubstring("hello"))
+	})
+})
+
+var _ = Describe("Driver", func() {
+	It("returns NotFoundError for the ancestry of a missing node", func() {
+		_, err := inmemory.NewDriver().Ancestry(GinkgoT().Context(), "missing")
+		Expect(err).To(MatchError(storage.NotFoundError{Hash: "missing"}))
+	})
+
+	It("lists leaves oldest first", func() {
+		ctx := GinkgoT().Context()
+		driver := inmemory.NewDriver()
+		var hashes []string
+		for _, text := range []string{"c", "a", "b"} {
+			n := merkle.NewNode(testBucket(text), nil)
+			n.CreatedAt = time.Now()
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			hashes = append(hashes, n.Hash)
+			time.Sleep(time.Millisecond)
+		}
+
+		leaves, err := driver.Leaves(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		var got []string
+		for _, n := range leaves {
+			got = append(got, n.Hash)
+		}
+		Expect(got).To(Equal(hashes))
+	})
+})
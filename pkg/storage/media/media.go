@@ -0,0 +1,338 @@
+// Package media persists the images and files attached to captured messages,
+// so multimodal sessions can be reviewed after the original request is gone.
+//
+// Inline base64 data and data: URLs are decoded, and remote http(s) URLs are
+// optionally downloaded. The bytes are written content-addressed to a local
+// directory, and the content block keeps a reference in their place: the
+// media URI, the SHA-256 of the bytes, and their size. The node hash commits
+// to the bytes through their SHA-256 and leaves the URI out, so a turn hashes
+// the same whichever directory holds the file and whichever key sealed it.
+// Payloads larger than the size limit are left as they are. With storage
+// encryption enabled the files are sealed like the rest of the captured
+// content, and named by a keyed fingerprint so that a file is stored once
+// however often it is captured.
+package media
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+)
+
+// DefaultMaxBytes is the largest image or file stored when no limit is
+// configured.
+const DefaultMaxBytes = 20 * 1024 * 1024
+
+// downloadTimeout bounds fetching a single remote image or file.
+const downloadTimeout = 30 * time.Second
+
+// maxDownloaded bounds how many downloaded URLs a Store remembers.
+const maxDownloaded = 1024
+
+// ErrTooLarge is returned when an image or file exceeds the size limit.
+var ErrTooLarge = errors.New("media exceeds size limit")
+
+// Options configures a Store.
+type Options struct {
+	// MaxBytes is the largest image or file stored (defaults to
+	// DefaultMaxBytes). Larger payloads stay in the content block.
+	MaxBytes int
+
+	// Download enables fetching images and files referenced by http(s) URL.
+	// Without it only inline data is stored.
+	Download bool
+
+	// Cipher, when set, seals stored files, so that media is encrypted at
+	// rest along with the rest of the captured content.
+	Cipher *encryption.Cipher
+}
+
+// Store writes the images and files of content blocks to a directory.
+type Store struct {
+	files    blob.Store
+	maxBytes int
+
+	// client downloads remote media; nil when downloading is disabled.
+	client *http.Client
+
+	// downloaded remembers the reference stored for each downloaded URL.
+	// Every turn resends the conversation so far, and without it the images
+	// of earlier messages would be downloaded again on each one.
+	downloadedMu sync.Mutex
+	downloaded   map[string]reference
+}
+
+// reference is what a content block keeps in place of its stored media.
+type reference struct {
+	uri       string
+	hash      string
+	size      int
+	mediaType string
+}
+
+// NewStore creates a Store rooted at dir, creating it if needed.
+func NewStore(dir string, opts Options) (*Store, error) {
+	files, err := blob.NewLocalStore(dir)
+	if err != nil {
+		return nil, fmt.Errorf("creating media store: %w", err)
+	}
+
+	s := &Store{files: blob.Sealed(files, opts.Cipher), maxBytes: opts.MaxBytes}
+	if s.maxBytes <= 0 {
+		s.maxBytes = DefaultMaxBytes
+	}
+	if opts.Download {
+		s.client = &http.Client{Timeout: downloadTimeout}
+		s.downloaded = make(map[string]reference)
+	}
+	return s, nil
+}
+
+// Capture stores the image or file of each block that carries one and
+// replaces its inline data with a reference. Remote URLs are kept alongside
+// the reference so the origin is not lost.
+//
+// The input slice is never modified. Blocks whose media cannot be stored keep
+// their inline data or URL and the first such error is returned.
+func (s *Store) Capture(ctx context.Context, blocks []llm.ContentBlock) ([]llm.ContentBlock, error) {
+	var (
+		result   []llm.ContentBlock
+		firstErr error
+	)
+	for i, block := range blocks {
+		if block.MediaURI != "" || (block.ImageBase64 == "" && block.ImageURL == "") {
+			continue
+		}
+
+		captured, ok, err := s.capture(ctx, block)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		if result == nil {
+			result = make([]llm.ContentBlock, len(blocks))
+			copy(result, blocks)
+		}
+		result[i] = captured
+	}
+
+	if result == nil {
+		return blocks, firstErr
+	}
+	return result, firstErr
+}
+
+// capture stores the media of a single block. It reports false for blocks
+// whose media is out of reach, such as remote URLs when downloading is
+// disabled.
+func (s *Store) capture(ctx context.Context, block llm.ContentBlock) (llm.ContentBlock, bool, error) {
+	original := block
+	var (
+		data      []byte
+		mediaType string
+		remote    string
+		err       error
+	)
+	switch {
+	case block.ImageBase64 != "":
+		data, err = s.decode(block.ImageBase64)
+		block.ImageBase64 = ""
+	case strings.HasPrefix(block.ImageURL, "data:"):
+		data, mediaType, err = s.decodeDataURL(block.ImageURL)
+		block.ImageURL = ""
+	case s.client != nil && (strings.HasPrefix(block.ImageURL, "https://") || strings.HasPrefix(block.ImageURL, "http://")):
+		remote = block.ImageURL
+		if ref, ok := s.lookupDownloaded(remote); ok {
+			return withReference(block, ref), true, nil
+		}
+		data, mediaType, err = s.download(ctx, remote)
+	default:
+		return block, false, nil
+	}
+	if err != nil {
+		return block, false, err
+	}
+	// A block carrying the hash of other data, such as an image offloaded
+	// to a blob store and read back, keeps the reference it has.
+	if block.ContentHash != "" && block.ContentHash != blob.Sum(data) {
+		return original, false, nil
+	}
+
+	uri, err := s.files.Put(ctx, data)
+	if err != nil {
+		return block, false, fmt.Errorf("storing media: %w", err)
+	}
+
+	ref := reference{uri: uri, hash: blob.Sum(data), size: len(data), mediaType: mediaType}
+	if remote != "" {
+		s.rememberDownloaded(remote, ref)
+	}
+	return withReference(block, ref), true, nil
+}
+
+// withReference returns block referencing its stored media by ref.
+func withReference(block llm.ContentBlock, ref reference) llm.ContentBlock {
+	block.MediaURI = ref.uri
+	block.ContentHash = ref.hash
+	block.OriginalBytes = ref.size
+	if block.MediaType == "" {
+		block.MediaType = ref.mediaType
+	}
+	return block
+}
+
+// lookupDownloaded returns the reference stored for a downloaded URL.
+func (s *Store) lookupDownloaded(url string) (reference, bool) {
+	s.downloadedMu.Lock()
+	defer s.downloadedMu.Unlock()
+	ref, ok := s.downloaded[url]
+	return ref, ok
+}
+
+// rememberDownloaded records the reference stored for a downloaded URL,
+// forgetting every URL once the limit is reached.
+func (s *Store) rememberDownloaded(url string, ref reference) {
+	s.downloadedMu.Lock()
+	defer s.downloadedMu.Unlock()
+	if len(s.downloaded) >= maxDownloaded {
+		clear(s.downloaded)
+	}
+	s.downloaded[url] = ref
+}
+
+// decode decodes base64 data, rejecting payloads over the size limit before
+// decoding them.
+func (s *Store) decode(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if base64.StdEncoding.DecodedLen(len(encoded)) > s.maxBytes+2 {
+		return nil, fmt.Errorf("%w: %d bytes encoded", ErrTooLarge, len(encoded))
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		data, err = base64.RawStdEncoding.DecodeString(encoded)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding media: %w", err)
+	}
+	if len(data) > s.maxBytes {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTooLarge, len(data))
+	}
+	return data, nil
+}
+
+// decodeDataURL decodes a data: URL of the form data:[<type>][;base64],<data>.
+func (s *Store) decodeDataURL(dataURL string) ([]byte, string, error) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(dataURL, "data:"), ",")
+	if !ok {
+		return nil, "", errors.New("decoding media: malformed data URL")
+	}
+
+	mediaType, isBase64 := strings.CutSuffix(header, ";base64")
+	if !isBase64 {
+		if len(payload) > s.maxBytes {
+			return nil, "", fmt.Errorf("%w: %d bytes", ErrTooLarge, len(payload))
+		}
+		return []byte(payload), mediaType, nil
+	}
+
+	data, err := s.decode(payload)
+	return data, mediaType, err
+}
+
+// download fetches a remote image or file, reading at most the size limit.
+func (s *Store) download(ctx context.Context, url string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading media: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading media: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("downloading media: %s returned %s", url, resp.Status)
+	}
+	if resp.ContentLength > int64(s.maxBytes) {
+		return nil, "", fmt.Errorf("%w: %d bytes", ErrTooLarge, resp.ContentLength)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(s.maxBytes)+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading media: %w", err)
+	}
+	if len(data) > s.maxBytes {
+		return nil, "", fmt.Errorf("%w: more than %d bytes", ErrTooLarge, s.maxBytes)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return data, mediaType, nil
+}
+
+// Read returns the stored image or file referenced by block, opened with c
+// when it was sealed and verified against its content hash. Reading depends
+// only on the reference and the cipher, so readers need no store
+// configuration.
+func Read(ctx context.Context, block llm.ContentBlock, c *encryption.Cipher) ([]byte, error) {
+	if block.MediaURI == "" {
+		return nil, errors.New("content block has no stored media")
+	}
+	return blob.Fetch(ctx, block.MediaURI, block.ContentHash, c)
+}
+
+// Inline returns a copy of blocks with stored images and files read back as
+// inline base64 data, opened with c when they were sealed. Blocks whose media
+// cannot be read keep their reference and the first such error is returned.
+func Inline(ctx context.Context, blocks []llm.ContentBlock, c *encryption.Cipher) ([]llm.ContentBlock, error) {
+	var (
+		result   []llm.ContentBlock
+		firstErr error
+	)
+	for i, block := range blocks {
+		if block.MediaURI == "" {
+			continue
+		}
+
+		data, err := Read(ctx, block, c)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		if result == nil {
+			result = make([]llm.ContentBlock, len(blocks))
+			copy(result, blocks)
+		}
+		block.ImageBase64 = base64.StdEncoding.EncodeToString(data)
+		block.MediaURI = ""
+		block.ContentHash = ""
+		block.OriginalBytes = 0
+		result[i] = block
+	}
+
+	if result == nil {
+		return blocks, firstErr
+	}
+	return result, firstErr
+}
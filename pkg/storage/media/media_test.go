@@ -0,0 +1,222 @@
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+	"github.com/papercomputeco/tapes/pkg/storage/media"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		ctx   context.Context
+		png   []byte
+		store *media.Store
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		png = []byte("\x89PNG\r\n\x1a\nfake image bytes")
+
+		var err error
+		store, err = media.NewStore(GinkgoT().TempDir(), media.Options{MaxBytes: 1024})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("stores inline base64 images and references them", func() {
+		blocks := []llm.ContentBlock{
+			{Type: "text", Text: "what is this?"},
+			{Type: "image", MediaType: "image/png", ImageBase64: base64.StdEncoding.EncodeToString(png)},
+		}
+
+		result, err := store.Capture(ctx, blocks)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result[0]).To(Equal(blocks[0]))
+
+		image := result[1]
+		Expect(image.ImageBase64).To(BeEmpty())
+		Expect(image.MediaURI).To(HavePrefix("file://"))
+		Expect(image.MediaType).To(Equal("image/png"))
+		Expect(image.ContentHash).To(Equal(blob.Sum(png)))
+		Expect(image.OriginalBytes).To(Equal(len(png)))
+
+		data, err := media.Read(ctx, image, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal(png))
+
+		// The caller's blocks are untouched.
+		Expect(blocks[1].ImageBase64).NotTo(BeEmpty())
+	})
+
+	It("seals stored files when given a cipher", func() {
+		cipher, err := encryption.NewCipher(bytes.Repeat([]byte{7}, encryption.KeySize))
+		Expect(err).NotTo(HaveOccurred())
+		sealing, err := media.NewStore(GinkgoT().TempDir(), media.Options{Cipher: cipher})
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := sealing.Capture(ctx, []llm.ContentBlock{
+			{Type: "image", MediaType: "image/png", ImageBase64: base64.StdEncoding.EncodeToString(png)},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		u, err := url.Parse(result[0].MediaURI)
+		Expect(err).NotTo(HaveOccurred())
+		raw, err := os.ReadFile(u.Path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(raw).NotTo(ContainSubstring("fake image bytes"))
+
+		data, err := media.Read(ctx, result[0], cipher)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal(png))
+
+		_, err = media.Read(ctx, result[0], nil)
+		Expect(err).To(MatchError(blob.ErrEncrypted))
+	})
+
+	It("references a sealed image the same way on every turn", func() {
+		cipher, err := encryption.NewCipher(bytes.Repeat([]byte{7}, encryption.KeySize))
+		Expect(err).NotTo(HaveOccurred())
+		sealing, err := media.NewStore(GinkgoT().TempDir(), media.Options{Cipher: cipher})
+		Expect(err).NotTo(HaveOccurred())
+
+		block := llm.ContentBlock{Type: "image", MediaType: "image/png", ImageBase64: base64.StdEncoding.EncodeToString(png)}
+		first, err := sealing.Capture(ctx, []llm.ContentBlock{block})
+		Expect(err).NotTo(HaveOccurred())
+		second, err := sealing.Capture(ctx, []llm.ContentBlock{block})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(Equal(first))
+	})
+
+	It("hashes a turn the same whichever directory holds its image", func() {
+		other, err := media.NewStore(GinkgoT().TempDir(), media.Options{MaxBytes: 1024})
+		Expect(err).NotTo(HaveOccurred())
+		blocks := []llm.ContentBlock{{Type: "image", MediaType: "image/png", ImageBase64: base64.StdEncoding.EncodeToString(png)}}
+
+		var hashes []string
+		for _, s := range []*media.Store{store, other} {
+			captured, err := s.Capture(ctx, blocks)
+			Expect(err).NotTo(HaveOccurred())
+			hashes = append(hashes, merkle.NewNode(merkle.Bucket{Type: "message", Role: "user", Content: captured}, nil).Hash)
+		}
+		Expect(hashes[1]).To(Equal(hashes[0]))
+	})
+
+	It("inlines stored images again", func() {
+		encoded := base64.StdEncoding.EncodeToString(png)
+		blocks := []llm.ContentBlock{{Type: "image", MediaType: "image/png", ImageBase64: encoded}}
+		captured, err := store.Capture(ctx, blocks)
+		Expect(err).NotTo(HaveOccurred())
+
+		inlined, err := media.Inline(ctx, captured, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inlined).To(Equal(blocks))
+	})
+
+	It("stores files sent as data URLs", func() {
+		result, err := store.Capture(ctx, []llm.ContentBlock{{
+			Type:     "file",
+			FileName: "notes.pdf",
+			ImageURL: "data:application/pdf;base64," + base64.StdEncoding.EncodeToString([]byte("%PDF-1.7")),
+		}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result[0].ImageURL).To(BeEmpty())
+		Expect(result[0].MediaType).To(Equal("application/pdf"))
+		Expect(result[0].FileName).To(Equal("notes.pdf"))
+
+		data, err := media.Read(ctx, result[0], nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("%PDF-1.7"))
+	})
+
+	It("stores identical images once", func() {
+		block := llm.ContentBlock{Type: "image", ImageBase64: base64.StdEncoding.EncodeToString(png)}
+		first, err := store.Capture(ctx, []llm.ContentBlock{block})
+		Expect(err).NotTo(HaveOccurred())
+		second, err := store.Capture(ctx, []llm.ContentBlock{block})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second[0].MediaURI).To(Equal(first[0].MediaURI))
+	})
+
+	It("keeps images over the size limit inline", func() {
+		large := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("x", 2048)))
+		blocks := []llm.ContentBlock{{Type: "image", ImageBase64: large}}
+
+		result, err := store.Capture(ctx, blocks)
+		Expect(err).To(MatchError(media.ErrTooLarge))
+		Expect(result[0].ImageBase64).To(Equal(large))
+		Expect(result[0].MediaURI).To(BeEmpty())
+	})
+
+	It("leaves remote URLs alone unless downloading is enabled", func() {
+		blocks := []llm.ContentBlock{{Type: "image", ImageURL: "https://example.com/cat.png"}}
+		result, err := store.Capture(ctx, blocks)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(blocks))
+	})
+
+	Context("with downloading enabled", func() {
+		var (
+			server    *httptest.Server
+			downloads int
+		)
+
+		BeforeEach(func() {
+			downloads = 0
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/cat.png" {
+					http.NotFound(w, r)
+					return
+				}
+				downloads++
+				w.Header().Set("Content-Type", "image/png")
+				_, _ = w.Write(png)
+			}))
+			DeferCleanup(server.Close)
+
+			var err error
+			store, err = media.NewStore(GinkgoT().TempDir(), media.Options{MaxBytes: 1024, Download: true})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("downloads remote images and keeps their URL", func() {
+			result, err := store.Capture(ctx, []llm.ContentBlock{{Type: "image", ImageURL: server.URL + "/cat.png"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result[0].ImageURL).To(Equal(server.URL + "/cat.png"))
+			Expect(result[0].MediaType).To(Equal("image/png"))
+
+			data, err := media.Read(ctx, result[0], nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal(png))
+		})
+
+		It("downloads a URL once however many turns resend it", func() {
+			blocks := []llm.ContentBlock{{Type: "image", ImageURL: server.URL + "/cat.png"}}
+			first, err := store.Capture(ctx, blocks)
+			Expect(err).NotTo(HaveOccurred())
+			second, err := store.Capture(ctx, blocks)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second).To(Equal(first))
+			Expect(downloads).To(Equal(1))
+		})
+
+		It("keeps the URL when the download fails", func() {
+			blocks := []llm.ContentBlock{{Type: "image", ImageURL: server.URL + "/missing.png"}}
+			result, err := store.Capture(ctx, blocks)
+			Expect(err).To(MatchError(ContainSubstring("404")))
+			Expect(result).To(Equal(blocks))
+		})
+	})
+})
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SystemPromptHash returns the hex-encoded SHA-256 of a system prompt, which
+// identifies it across turns and sessions.
+func SystemPromptHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// SystemPromptStore is implemented by drivers that can persist system
+// prompts. Response nodes reference the prompt of their request by its
+// SystemPromptHash.
+type SystemPromptStore interface {
+	// AddSystemPrompt stores a system prompt unless it is already stored,
+	// and returns its hash.
+	AddSystemPrompt(ctx context.Context, text string) (string, error)
+
+	// GetSystemPrompt returns the system prompt with the given hash.
+	GetSystemPrompt(ctx context.Context, hash string) (string, error)
+}
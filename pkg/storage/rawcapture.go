@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// RawCapture holds the original request and response payloads of a stored
+// conversation turn. Captures are kept when raw capture is enabled so that
+// turns can be re-parsed after a provider parser is fixed.
+type RawCapture struct {
+	ID int `json:"id"`
+
+	// NodeHash is the hash of the turn's response node when it was captured.
+	NodeHash string `json:"node_hash"`
+
+	Provider  string `json:"provider"`
+	AgentName string `json:"agent_name,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	RunID     string `json:"run_id,omitempty"`
+	Project   string `json:"project,omitempty"`
+	User      string `json:"user,omitempty"`
+	Path      string `json:"path,omitempty"`
+
+	// Streamed is set when the response was streamed. Response then holds
+	// the stream's data payloads, one per line.
+	Streamed bool `json:"streamed,omitempty"`
+
+	Request   []byte    `json:"request"`
+	Response  []byte    `json:"response,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RawCaptureStore is implemented by drivers that can persist raw captures.
+type RawCaptureStore interface {
+	// AddRawCapture stores a raw capture, assigning its ID and CreatedAt.
+	AddRawCapture(ctx context.Context, rc *RawCapture) error
+
+	// ListRawCaptures returns the raw captures made at or after since,
+	// oldest first. A zero since returns all of them.
+	ListRawCaptures(ctx context.Context, since time.Time) ([]*RawCapture, error)
+
+	// ReconcileRawCapture records that a capture was reprocessed into the
+	// turn ending at nodeHash. Each key of superseded is marked as replaced
+	// by the node hash it maps to.
+	ReconcileRawCapture(ctx context.Context, id int, nodeHash string, superseded map[string]string) error
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// Reasons a session ended, recorded on its root node.
+const (
+	// SessionEndIdle marks a session closed after a period without turns.
+	SessionEndIdle = "idle"
+
+	// SessionEndExit marks a session whose agent exited under "tapes start".
+	SessionEndExit = "exit"
+)
+
+// SessionStore is implemented by drivers that can mark where sessions end.
+type SessionStore interface {
+	// EndSession records that the session rooted at rootHash ended at the
+	// given time for reason. A later end replaces an earlier one, so a
+	// session that resumes after going idle is marked again when it ends.
+	EndSession(ctx context.Context, rootHash string, at time.Time, reason string) error
+}
+
+// SessionIndex is implemented by drivers that keep a summary of every
+// session, with its turn count, token totals, and cost, up to date as its
+// turns are stored.
+type SessionIndex interface {
+	// SetSessionCost sets how the summaries price a turn from its model and
+	// token usage.
+	SetSessionCost(cost func(model string, usage llm.Usage) float64)
+}
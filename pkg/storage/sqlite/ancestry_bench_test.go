@@ -0,0 +1,67 @@
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
+)
+
+// benchmarkSession stores a linear session of turns and returns the driver
+// and the session's leaf.
+func benchmarkSession(b *testing.B, turns int) (*sqlite.Driver, *merkle.Node) {
+	b.Helper()
+	ctx := context.Background()
+
+	driver, err := sqlite.NewDriver(ctx, filepath.Join(b.TempDir(), "tapes.db"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { driver.Close() })
+
+	nodes := make([]*merkle.Node, 0, turns)
+	var parent *merkle.Node
+	for i := range turns {
+		parent = merkle.NewNode(sqliteTestBucket(fmt.Sprintf("turn %d", i)), parent)
+		nodes = append(nodes, parent)
+	}
+	if _, err := driver.InsertBatch(ctx, nodes); err != nil {
+		b.Fatal(err)
+	}
+	return driver, parent
+}
+
+func BenchmarkAncestry(b *testing.B) {
+	for _, turns := range []int{10, 100, 500} {
+		b.Run(fmt.Sprintf("turns=%d", turns), func(b *testing.B) {
+			driver, leaf := benchmarkSession(b, turns)
+			ctx := context.Background()
+
+			b.ResetTimer()
+			for range b.N {
+				path, err := driver.Ancestry(ctx, leaf.Hash)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(path) != turns {
+					b.Fatalf("got %d nodes, want %d", len(path), turns)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkLeaves(b *testing.B) {
+	driver, _ := benchmarkSession(b, 500)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for range b.N {
+		if _, err := driver.Leaves(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
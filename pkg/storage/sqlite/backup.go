@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	// backupStepPages is the number of pages copied per backup step. Between
+	// steps the source is unlocked, so a running daemon keeps capturing.
+	backupStepPages = 256
+
+	// backupStepPause is how long a backup yields between steps.
+	backupStepPause = 5 * time.Millisecond
+)
+
+// Backup copies the database at srcPath to destPath with SQLite's online
+// backup API. The source may be in use by other processes, such as the tapes
+// daemon; the copy is a consistent snapshot of it. destPath is overwritten.
+func Backup(ctx context.Context, srcPath, destPath string) error {
+	if err := copyDatabase(ctx, srcPath, destPath); err != nil {
+		return fmt.Errorf("backing up database: %w", err)
+	}
+	return nil
+}
+
+// Restore replaces the contents of the database at dbPath with the backup at
+// backupPath, after checking that the backup is intact. The replacement is a
+// single write transaction, so other connections see either the old database
+// or the restored one.
+func Restore(ctx context.Context, backupPath, dbPath string) error {
+	if err := CheckIntegrity(ctx, backupPath); err != nil {
+		return err
+	}
+	if err := copyDatabase(ctx, backupPath, dbPath); err != nil {
+		return fmt.Errorf("restoring database: %w", err)
+	}
+	return nil
+}
+
+// CheckIntegrity runs SQLite's integrity check on the database at path.
+func CheckIntegrity(ctx context.Context, path string) error {
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer db.Close()
+
+	var result string
+	if err := db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&result); err != nil {
+		return fmt.Errorf("checking %s: %w", path, err)
+	}
+	if result != "ok" {
+		return fmt.Errorf("%s failed integrity check: %s", path, result)
+	}
+	return nil
+}
+
+// copyDatabase copies src over dest a few pages at a time.
+func copyDatabase(ctx context.Context, srcPath, destPath string) error {
+	src, err := sql.Open("sqlite3", srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	srcConn, err := src.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcConn.Close()
+
+	dest, err := sql.Open("sqlite3", destPath)
+	if err != nil {
+		return err
+	}
+	defer dest.Close()
+	destConn, err := dest.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer destConn.Close()
+
+	return destConn.Raw(func(destRaw any) error {
+		return srcConn.Raw(func(srcRaw any) error {
+			destSQLite, ok := destRaw.(*sqlite3.SQLiteConn)
+			if !ok {
+				return errors.New("unexpected destination connection type")
+			}
+			srcSQLite, ok := srcRaw.(*sqlite3.SQLiteConn)
+			if !ok {
+				return errors.New("unexpected source connection type")
+			}
+
+			backup, err := destSQLite.Backup("main", srcSQLite, "main")
+			if err != nil {
+				return err
+			}
+			for {
+				done, err := backup.Step(backupStepPages)
+				if err != nil {
+					_ = backup.Finish()
+					return err
+				}
+				if done {
+					return backup.Finish()
+				}
+
+				select {
+				case <-ctx.Done():
+					_ = backup.Finish()
+					return ctx.Err()
+				case <-time.After(backupStepPause):
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+)
+
+// LeafFilter selects conversations, identified by their leaf node, by when
+// they ran and what happened in them. Zero fields are ignored; when both
+// StopReason and Tool are set, a single turn must match both.
+type LeafFilter struct {
+	// EndedAfter keeps conversations whose last turn is at or after it.
+	EndedAfter time.Time
+
+	// StartedBefore keeps conversations whose first turn is at or before it.
+	StartedBefore time.Time
+
+	// StopReason keeps conversations with a turn that stopped for this reason.
+	StopReason string
+
+	// Tool keeps conversations with a turn that called a tool whose name
+	// contains this text, ignoring case.
+	Tool string
+}
+
+// IsZero reports whether f selects every conversation.
+func (f LeafFilter) IsZero() bool {
+	return f == LeafFilter{}
+}
+
+// FilterLeaves returns the hashes of the leaf nodes whose conversations match
+// f. It walks every conversation from its root in a single query, carrying
+// the root's timestamp and whether a matching turn has been seen so far.
+func (d *Driver) FilterLeaves(ctx context.Context, f LeafFilter) (map[string]bool, error) {
+	// Tool names are stored comma-separated, so a comma could only match
+	// across two names.
+	if strings.Contains(f.Tool, ",") {
+		return map[string]bool{}, nil
+	}
+
+	var turn []string
+	var turnArgs []any
+	if f.StopReason != "" {
+		turn = append(turn, "n.stop_reason = ?")
+		turnArgs = append(turnArgs, f.StopReason)
+	}
+	if f.Tool != "" {
+		turn = append(turn, `n.tool_names LIKE ? ESCAPE '\'`)
+		turnArgs = append(turnArgs, "%"+escapeLike(f.Tool)+"%")
+	}
+	matched := "1"
+	if len(turn) > 0 {
+		matched = strings.Join(turn, " AND ")
+	}
+
+	var leaf []string
+	var leafArgs []any
+	if len(turn) > 0 {
+		leaf = append(leaf, "t.matched")
+	}
+	if !f.EndedAfter.IsZero() {
+		leaf = append(leaf, "julianday(t.created_at) >= julianday(?)")
+		leafArgs = append(leafArgs, sqliteTime(f.EndedAfter))
+	}
+	if !f.StartedBefore.IsZero() {
+		leaf = append(leaf, "julianday(t.root_created_at) <= julianday(?)")
+		leafArgs = append(leafArgs, sqliteTime(f.StartedBefore))
+	}
+
+	query := `WITH RECURSIVE tree(hash, created_at, root_created_at, matched) AS (
+			SELECT n.hash, n.created_at, n.created_at, ` + matched + `
+			FROM ` + node.Table + ` n
+			WHERE n.parent_hash IS NULL OR n.parent_hash = ''
+				OR n.parent_hash NOT IN (SELECT hash FROM ` + node.Table + `)
+			UNION ALL
+			SELECT n.hash, n.created_at, t.root_created_at, t.matched OR (` + matched + `)
+			FROM ` + node.Table + ` n
+			JOIN tree t ON n.parent_hash = t.hash
+		)
+		SELECT t.hash FROM tree t
+		WHERE NOT EXISTS (SELECT 1 FROM ` + node.Table + ` c WHERE c.parent_hash = t.hash)`
+	for _, cond := range leaf {
+		query += " AND " + cond
+	}
+
+	args := append(append(append([]any{}, turnArgs...), turnArgs...), leafArgs...)
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter conversations: %w", err)
+	}
+	defer rows.Close()
+
+	leaves := map[string]bool{}
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("failed to read conversation: %w", err)
+		}
+		leaves[hash] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to filter conversations: %w", err)
+	}
+	return leaves, nil
+}
+
+// escapeLike escapes the LIKE wildcards in s for use with ESCAPE '\'.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// sqliteTime formats t so that SQLite's date functions can parse it.
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05.000000000Z07:00")
+}
@@ -0,0 +1,355 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/hook"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
+)
+
+// searchTable is the full-text index over node text. It uses FTS4, which is
+// compiled into go-sqlite3 by default, and ranks matches with BM25 computed
+// from matchinfo.
+const searchTable = "node_search"
+
+const (
+	// bm25K1 and bm25B are the standard Okapi BM25 parameters.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// searchTextColumn is the index of the text column in searchTable.
+	searchTextColumn = 1
+
+	searchBackfillBatch = 500
+)
+
+// ErrSearchDisabled is returned by Search when the full-text index is not
+// maintained, which is the case when content is encrypted at rest.
+var ErrSearchDisabled = errors.New("full-text search is unavailable when content encryption is enabled")
+
+// SearchOptions filters a full-text search.
+type SearchOptions struct {
+	// Query is an FTS query, e.g. `"connection refused"` or `build OR test`.
+	Query string
+
+	// Provider restricts matches to a provider, if set.
+	Provider string
+
+	// Since restricts matches to turns created at or after this time, if set.
+	Since time.Time
+
+	// Limit caps the number of matches returned. Zero returns all matches.
+	Limit int
+}
+
+// SearchMatch is a single turn matching a full-text search.
+type SearchMatch struct {
+	Hash      string
+	Role      string
+	Provider  string
+	Model     string
+	CreatedAt time.Time
+
+	// Snippet is an excerpt of the matching text with matches in [brackets].
+	Snippet string
+
+	// Score is the BM25 relevance of the match; higher is better.
+	Score float64
+}
+
+// enableSearchIndex creates the full-text index if needed, reporting whether
+// it is new and so must be backfilled, and keeps it up to date as nodes are
+// created and deleted. It must be enabled before deduplication so that it indexes complete
+// content.
+func (d *Driver) enableSearchIndex(ctx context.Context) (bool, error) {
+	exists, err := d.searchIndexExists(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if !exists {
+		_, err := d.db.ExecContext(ctx,
+			"CREATE VIRTUAL TABLE "+searchTable+" USING fts4(hash, text, notindexed=hash, tokenize=unicode61)")
+		if err != nil {
+			return false, fmt.Errorf("failed to create search index: %w", err)
+		}
+	}
+
+	d.searchEnabled = true
+	d.Client.Node.Use(d.indexNodeText(), d.unindexDeletedNodes())
+	return !exists, nil
+}
+
+// searchIndexExists reports whether the full-text index has been created.
+func (d *Driver) searchIndexExists(ctx context.Context) (bool, error) {
+	var count int
+	err := d.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", searchTable,
+	).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check search index: %w", err)
+	}
+	return count > 0, nil
+}
+
+// disableSearchIndex drops the full-text index so that no plaintext copy of
+// content remains once encryption is enabled.
+func (d *Driver) disableSearchIndex(ctx context.Context) error {
+	if _, err := d.db.ExecContext(ctx, "DROP TABLE IF EXISTS "+searchTable); err != nil {
+		return fmt.Errorf("failed to drop search index: %w", err)
+	}
+	return nil
+}
+
+// indexNodeText is an ent hook that adds the text of newly created nodes to
+// the full-text index.
+func (d *Driver) indexNodeText() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return hook.NodeFunc(func(ctx context.Context, m *ent.NodeMutation) (ent.Value, error) {
+			if !m.Op().Is(ent.OpCreate) {
+				return next.Mutate(ctx, m)
+			}
+
+			id, _ := m.ID()
+			bucket, _ := m.Bucket()
+			text, err := bucketText(bucket)
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := next.Mutate(ctx, m)
+			if err != nil || text == "" {
+				return value, err
+			}
+
+			// Index through the mutation's client so that nodes created
+			// in a transaction are indexed in it.
+			if err := indexText(ctx, m.Client(), id, text); err != nil {
+				return nil, err
+			}
+			return value, nil
+		})
+	}
+}
+
+// unindexDeletedNodes is an ent hook that removes deleted nodes from the
+// full-text index, in the deleting transaction, so that the text of pruned
+// or quarantined sessions stops being searchable along with them.
+func (d *Driver) unindexDeletedNodes() ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return hook.NodeFunc(func(ctx context.Context, m *ent.NodeMutation) (ent.Value, error) {
+			if !m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+				return next.Mutate(ctx, m)
+			}
+
+			ids, err := m.IDs(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve deleted nodes: %w", err)
+			}
+
+			value, err := next.Mutate(ctx, m)
+			if err != nil {
+				return value, err
+			}
+
+			for start := 0; start < len(ids); start += searchBackfillBatch {
+				batch := ids[start:min(start+searchBackfillBatch, len(ids))]
+				args := make([]any, len(batch))
+				for i, id := range batch {
+					args[i] = id
+				}
+				_, err := m.Client().ExecContext(ctx,
+					"DELETE FROM "+searchTable+" WHERE hash IN (?"+strings.Repeat(", ?", len(batch)-1)+")", args...)
+				if err != nil {
+					return nil, fmt.Errorf("failed to remove deleted nodes from search index: %w", err)
+				}
+			}
+			return value, nil
+		})
+	}
+}
+
+// execer runs a statement on a database, transaction, or ent client.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func indexText(ctx context.Context, db execer, hash, text string) error {
+	_, err := db.ExecContext(ctx, "INSERT INTO "+searchTable+" (hash, text) VALUES (?, ?)", hash, text)
+	if err != nil {
+		return fmt.Errorf("failed to index node %s: %w", hash, err)
+	}
+	return nil
+}
+
+// backfillSearchIndex indexes every node already in the database.
+func (d *Driver) backfillSearchIndex(ctx context.Context) error {
+	last := ""
+	for {
+		page, err := d.Client.Node.Query().
+			Where(node.IDGT(last)).
+			Order(ent.Asc(node.FieldID)).
+			Limit(searchBackfillBatch).
+			Select(node.FieldID, node.FieldBucket).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load nodes for search index: %w", err)
+		}
+		if len(page) == 0 {
+			return nil
+		}
+		last = page[len(page)-1].ID
+
+		for _, n := range page {
+			text, err := bucketText(n.Bucket)
+			if err != nil {
+				return err
+			}
+			if text == "" {
+				continue
+			}
+			if err := indexText(ctx, d.db, n.ID, text); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// pruneSearchIndex removes index entries for nodes that no longer exist.
+func (d *Driver) pruneSearchIndex(ctx context.Context) error {
+	if !d.searchEnabled {
+		return nil
+	}
+	_, err := d.db.ExecContext(ctx,
+		"DELETE FROM "+searchTable+" WHERE hash NOT IN (SELECT hash FROM "+node.Table+")")
+	if err != nil {
+		return fmt.Errorf("failed to prune search index: %w", err)
+	}
+	return nil
+}
+
+// Search returns turns whose text matches opts.Query, most relevant first.
+func (d *Driver) Search(ctx context.Context, opts SearchOptions) ([]SearchMatch, error) {
+	if !d.searchEnabled {
+		return nil, ErrSearchDisabled
+	}
+
+	// Entries of nodes deleted before the index tracked deletes are dropped
+	// by the join until the index is pruned during compaction.
+	query := `SELECT n.hash, n.role, n.provider, n.model, n.created_at,
+			snippet(` + searchTable + `, '[', ']', '...', 1, 16),
+			matchinfo(` + searchTable + `, 'pcnalx')
+		FROM ` + searchTable + `
+		JOIN ` + node.Table + ` n ON n.hash = ` + searchTable + `.hash
+		WHERE ` + searchTable + ` MATCH ?`
+	args := []any{opts.Query}
+	if opts.Provider != "" {
+		query += " AND n.provider = ?"
+		args = append(args, opts.Provider)
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []SearchMatch
+	for rows.Next() {
+		var (
+			m                     SearchMatch
+			role, provider, model sql.NullString
+			info                  []byte
+		)
+		if err := rows.Scan(&m.Hash, &role, &provider, &model, &m.CreatedAt, &m.Snippet, &info); err != nil {
+			return nil, fmt.Errorf("failed to read search match: %w", err)
+		}
+		if !opts.Since.IsZero() && m.CreatedAt.Before(opts.Since) {
+			continue
+		}
+		m.Role, m.Provider, m.Model = role.String, provider.String, model.String
+		m.Score = bm25(info, searchTextColumn)
+		matches = append(matches, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].CreatedAt.After(matches[j].CreatedAt)
+	})
+	if opts.Limit > 0 && len(matches) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+	return matches, nil
+}
+
+// bm25 scores a row from an FTS4 matchinfo 'pcnalx' blob for a single column.
+// See https://www.sqlite.org/fts3.html#matchinfo for the blob layout.
+func bm25(info []byte, column int) float64 {
+	if len(info)%4 != 0 || len(info) < 12 {
+		return 0
+	}
+	values := make([]uint32, len(info)/4)
+	for i := range values {
+		values[i] = binary.NativeEndian.Uint32(info[i*4:])
+	}
+
+	phrases, columns := int(values[0]), int(values[1])
+	if len(values) < 3+2*columns+3*columns*phrases {
+		return 0
+	}
+	docs := float64(values[2])
+	avgLen := float64(values[3+column])
+	rowLen := float64(values[3+columns+column])
+	hits := values[3+2*columns:]
+
+	score := 0.0
+	for p := range phrases {
+		base := 3 * (p*columns + column)
+		tf := float64(hits[base])
+		docsWithHit := float64(hits[base+2])
+		if tf == 0 {
+			continue
+		}
+
+		idf := math.Log(1 + (docs-docsWithHit+0.5)/(docsWithHit+0.5))
+		norm := 1 - bm25B
+		if avgLen > 0 {
+			norm += bm25B * rowLen / avgLen
+		}
+		score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+	}
+	return score
+}
+
+// bucketText extracts the searchable text of a stored bucket: message text,
+// tool calls with their inputs, and tool outputs.
+func bucketText(bucket map[string]any) (string, error) {
+	if bucket == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(bucket)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal bucket for search index: %w", err)
+	}
+	var b merkle.Bucket
+	if err := json.Unmarshal(data, &b); err != nil {
+		return "", fmt.Errorf("failed to unmarshal bucket for search index: %w", err)
+	}
+	return b.ExtractText(), nil
+}
@@ -5,23 +5,58 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
+	"os"
+	"time"
 
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
 	_ "github.com/mattn/go-sqlite3" // load up the sqlite3 CGO libs
 
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
 	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/block"
 	entdriver "github.com/papercomputeco/tapes/pkg/storage/ent/driver"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/facet"
+	"github.com/papercomputeco/tapes/pkg/storage/ent/node"
 )
 
 // Driver implements storage.Driver using SQLite via the ent driver
 type Driver struct {
 	*entdriver.EntDriver
+
+	db *sql.DB
+
+	// searchEnabled reports whether the full-text index is maintained.
+	searchEnabled bool
+}
+
+// Option configures how NewDriver and NewReadOnlyDriver open a database.
+type Option func(*options)
+
+type options struct {
+	encryption bool
+}
+
+// WithEncryption enables encryption at rest when enabled is set, sealing
+// content with the key from the TAPES_ENCRYPTION_KEY environment variable or
+// the OS keychain. Without it the key is never looked up, and reading
+// content that was stored encrypted fails.
+func WithEncryption(enabled bool) Option {
+	return func(o *options) {
+		o.encryption = enabled
+	}
 }
 
 // NewDriver creates a new SQLite-backed storer.
 // The dbPath can be a file path or ":memory:" for an in-memory database.
-func NewDriver(ctx context.Context, dbPath string) (*Driver, error) {
+// Node content is encrypted at rest when WithEncryption is given.
+func NewDriver(ctx context.Context, dbPath string, opts ...Option) (*Driver, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// Open the database using the github.com/mattn/go-sqlite3 driver (registered as "sqlite3")
 	db, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
@@ -45,9 +80,262 @@ func NewDriver(ctx context.Context, dbPath string) (*Driver, error) {
 		return nil, fmt.Errorf("failed to create schema: %w", err)
 	}
 
-	return &Driver{
+	driver := &Driver{
 		EntDriver: &entdriver.EntDriver{
 			Client: client,
 		},
-	}, nil
+		db: db,
+	}
+
+	if err := driver.enableChangeTracking(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+	if err := driver.createAnalyticsViews(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	c, err := loadCipher(ctx, o)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	// Hooks run in the order they are enabled: the search index must see
+	// plaintext content, and deduplication must split blocks out of content
+	// before it is compressed and sealed. The index would keep a plaintext copy of content,
+	// so it is dropped when encryption is enabled.
+	var newIndex bool
+	if c == nil {
+		newIndex, err = driver.enableSearchIndex(ctx)
+	} else {
+		err = driver.disableSearchIndex(ctx)
+	}
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	driver.EnableDeduplication()
+	driver.EnableCompression()
+
+	// Encrypt node content at rest when encryption is enabled.
+	if c != nil {
+		driver.EnableEncryption(c)
+	}
+
+	// Backfill once every read interceptor is in place, so existing nodes
+	// are indexed with their full content.
+	if err := driver.BackfillToolNames(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+	if err := driver.BackfillSessions(ctx); err != nil {
+		client.Close()
+		return nil, err
+	}
+	if newIndex {
+		if err := driver.backfillSearchIndex(ctx); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	return driver, nil
+}
+
+// readOnlyBusyTimeout is how long a read-only driver waits for a writer to
+// finish committing before a query fails with "database is locked".
+const readOnlyBusyTimeout = 5 * time.Second
+
+// NewReadOnlyDriver opens an existing SQLite database for reading only, for
+// querying a database that another process, such as the tapes daemon, is
+// writing. Unlike NewDriver it neither migrates the schema nor backfills, so
+// it never takes the write lock, and queries wait out a writer's commit
+// instead of failing. Writes through the driver fail.
+func NewReadOnlyDriver(ctx context.Context, dbPath string, opts ...Option) (*Driver, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	dsn := fmt.Sprintf("file:%s?mode=ro&_busy_timeout=%d",
+		(&url.URL{Path: dbPath}).EscapedPath(), readOnlyBusyTimeout.Milliseconds())
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	drv := entsql.OpenDB(dialect.SQLite, db)
+	client := ent.NewClient(ent.Driver(drv))
+
+	driver := &Driver{
+		EntDriver: &entdriver.EntDriver{
+			Client: client,
+		},
+		db: db,
+	}
+
+	// The version table is created along with the schema, so a database
+	// without it has never been opened for writing.
+	if _, err := driver.Version(ctx); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("%s is not a tapes database: %w", dbPath, err)
+	}
+
+	c, err := loadCipher(ctx, o)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	if c == nil {
+		driver.searchEnabled, err = driver.searchIndexExists(ctx)
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	driver.EnableDeduplication()
+	driver.EnableCompression()
+	if c != nil {
+		driver.EnableEncryption(c)
+	}
+
+	return driver, nil
+}
+
+// loadCipher returns the cipher for the configured encryption key when
+// encryption is enabled, or nil when it is disabled. It fails when encryption
+// is enabled but no key is configured, rather than storing plaintext.
+func loadCipher(ctx context.Context, o options) (*encryption.Cipher, error) {
+	if !o.encryption {
+		return nil, nil
+	}
+	key, err := encryption.LoadKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encryption key: %w", err)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("encryption is enabled but no key is configured: set %s or store a key in the OS keychain", encryption.KeyEnvVar)
+	}
+
+	c, err := encryption.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return c, nil
+}
+
+// CheckWritable checks that the database accepts writes by creating a table
+// in a transaction that is rolled back, leaving the database unchanged.
+func (d *Driver) CheckWritable(ctx context.Context) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning write check: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, "CREATE TABLE tapes_write_check (id INTEGER)"); err != nil {
+		return fmt.Errorf("writing to database: %w", err)
+	}
+	return nil
+}
+
+// Size returns the size of the database in bytes, excluding free pages that
+// Vacuum would release.
+func (d *Driver) Size(ctx context.Context) (int64, error) {
+	var pageCount, freePages, pageSize int64
+	if err := d.db.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pageCount); err != nil {
+		return 0, fmt.Errorf("failed to read page count: %w", err)
+	}
+	if err := d.db.QueryRowContext(ctx, "PRAGMA freelist_count").Scan(&freePages); err != nil {
+		return 0, fmt.Errorf("failed to read free page count: %w", err)
+	}
+	if err := d.db.QueryRowContext(ctx, "PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("failed to read page size: %w", err)
+	}
+	return (pageCount - freePages) * pageSize, nil
+}
+
+// FileSize returns the size of the database in bytes including free pages,
+// which is what it occupies on disk.
+func (d *Driver) FileSize(ctx context.Context) (int64, error) {
+	var pageCount, pageSize int64
+	if err := d.db.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pageCount); err != nil {
+		return 0, fmt.Errorf("failed to read page count: %w", err)
+	}
+	if err := d.db.QueryRowContext(ctx, "PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("failed to read page size: %w", err)
+	}
+	return pageCount * pageSize, nil
+}
+
+// Vacuum rebuilds the database file, returning space freed by deletions to
+// the filesystem.
+func (d *Driver) Vacuum(ctx context.Context) error {
+	if _, err := d.db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	return nil
+}
+
+// CompactResult reports the database file size around a Compact.
+type CompactResult struct {
+	SizeBefore int64
+	SizeAfter  int64
+
+	// Blocks is the number of unreferenced content blocks removed.
+	Blocks int
+}
+
+// Reclaimed returns the number of bytes returned to the filesystem.
+func (r *CompactResult) Reclaimed() int64 {
+	return max(r.SizeBefore-r.SizeAfter, 0)
+}
+
+// Compact removes unreferenced content blocks and search index entries,
+// vacuums the database, rebuilds the indexes the deck queries rely on, and
+// refreshes the query planner statistics.
+func (d *Driver) Compact(ctx context.Context) (*CompactResult, error) {
+	before, err := d.FileSize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := d.PruneBlocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.pruneSearchIndex(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := d.Vacuum(ctx); err != nil {
+		return nil, err
+	}
+
+	for _, table := range []string{node.Table, facet.Table, block.Table} {
+		if _, err := d.db.ExecContext(ctx, "REINDEX "+table); err != nil {
+			return nil, fmt.Errorf("failed to reindex %s: %w", table, err)
+		}
+	}
+
+	if _, err := d.db.ExecContext(ctx, "ANALYZE"); err != nil {
+		return nil, fmt.Errorf("failed to analyze database: %w", err)
+	}
+
+	after, err := d.FileSize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompactResult{SizeBefore: before, SizeAfter: after, Blocks: blocks}, nil
 }
@@ -2,15 +2,25 @@ package sqlite_test
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/papercomputeco/tapes/pkg/llm"
 	"github.com/papercomputeco/tapes/pkg/merkle"
 	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+	"github.com/papercomputeco/tapes/pkg/storage/ent"
+	entdriver "github.com/papercomputeco/tapes/pkg/storage/ent/driver"
+	entnode "github.com/papercomputeco/tapes/pkg/storage/ent/node"
 	"github.com/papercomputeco/tapes/pkg/storage/sqlite"
 )
 
@@ -59,6 +69,85 @@ var _ = Describe("Driver", func() {
 		})
 	})
 
+	Describe("NewReadOnlyDriver", func() {
+		var dbPath string
+
+		BeforeEach(func() {
+			dbPath = filepath.Join(GinkgoT().TempDir(), "test.db")
+			writer, err := sqlite.NewDriver(ctx, dbPath)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = writer.Put(ctx, merkle.NewNode(sqliteTestBucket("stored"), nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Close()).To(Succeed())
+		})
+
+		It("reads but does not write", func() {
+			reader, err := sqlite.NewReadOnlyDriver(ctx, dbPath)
+			Expect(err).NotTo(HaveOccurred())
+			defer reader.Close()
+
+			nodes, err := reader.List(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(HaveLen(1))
+			Expect(nodes[0].Bucket.ExtractText()).To(Equal("stored"))
+
+			_, err = reader.Put(ctx, merkle.NewNode(sqliteTestBucket("rejected"), nil))
+			Expect(err).To(MatchError(ContainSubstring("readonly")))
+		})
+
+		It("opens while another connection holds the write lock", func() {
+			writer, err := sqlite.NewDriver(ctx, dbPath)
+			Expect(err).NotTo(HaveOccurred())
+			defer writer.Close()
+
+			tx, err := writer.Client.Tx(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() { _ = tx.Rollback() }()
+			_, err = tx.Node.Delete().Exec(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			reader, err := sqlite.NewReadOnlyDriver(ctx, dbPath)
+			Expect(err).NotTo(HaveOccurred())
+			defer reader.Close()
+
+			nodes, err := reader.List(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(HaveLen(1))
+		})
+
+		It("does not create a missing database", func() {
+			missing := filepath.Join(GinkgoT().TempDir(), "missing.db")
+			_, err := sqlite.NewReadOnlyDriver(ctx, missing)
+			Expect(err).To(HaveOccurred())
+
+			_, err = os.Stat(missing)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+
+	Describe("CheckWritable", func() {
+		It("succeeds on a writable database without storing anything", func() {
+			Expect(driver.CheckWritable(ctx)).To(Succeed())
+
+			nodes, err := driver.List(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(BeEmpty())
+		})
+
+		It("fails on a read-only database", func() {
+			dbPath := filepath.Join(GinkgoT().TempDir(), "test.db")
+			writer, err := sqlite.NewDriver(ctx, dbPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Close()).To(Succeed())
+
+			reader, err := sqlite.NewReadOnlyDriver(ctx, dbPath)
+			Expect(err).NotTo(HaveOccurred())
+			defer reader.Close()
+
+			Expect(reader.CheckWritable(ctx)).NotTo(Succeed())
+		})
+	})
+
 	Describe("Put and Get", func() {
 		It("stores and retrieves a node", func() {
 			node := merkle.NewNode(sqliteTestBucket("test content"), nil)
@@ -87,6 +176,7 @@ var _ = Describe("Driver", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(retrieved.ParentHash).NotTo(BeNil())
 			Expect(*retrieved.ParentHash).To(Equal(parent.Hash))
+			Expect(retrieved.TurnIndex).To(Equal(1))
 		})
 
 		It("returns NotFoundError for non-existent hash", func() {
@@ -119,6 +209,63 @@ var _ = Describe("Driver", func() {
 		})
 	})
 
+	Describe("EndSession", func() {
+		It("records when and why the session ended", func() {
+			root := merkle.NewNode(sqliteTestBucket("session root"), nil)
+			_, err := driver.Put(ctx, root)
+			Expect(err).NotTo(HaveOccurred())
+			at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+			Expect(driver.EndSession(ctx, root.Hash, at, storage.SessionEndIdle)).To(Succeed())
+
+			stored, err := driver.Get(ctx, root.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stored.EndedAt).NotTo(BeNil())
+			Expect(*stored.EndedAt).To(BeTemporally("==", at))
+			Expect(stored.EndReason).To(Equal(storage.SessionEndIdle))
+		})
+
+		It("returns NotFoundError for a missing session", func() {
+			err := driver.EndSession(ctx, "nonexistent", time.Now(), storage.SessionEndIdle)
+			Expect(err).To(BeAssignableToTypeOf(storage.NotFoundError{}))
+		})
+	})
+
+	Describe("InsertBatch", func() {
+		It("stores a turn's nodes and reports which are new", func() {
+			root := merkle.NewNode(sqliteTestBucket("batched root"), nil)
+			_, err := driver.Put(ctx, root)
+			Expect(err).NotTo(HaveOccurred())
+
+			child := merkle.NewNode(sqliteTestBucket("batched child"), root)
+			grandchild := merkle.NewNode(sqliteTestBucket("batched grandchild"), child)
+			inserted, err := driver.InsertBatch(ctx, []*merkle.Node{root, child, grandchild, child})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(inserted).To(Equal([]bool{false, true, true, false}))
+
+			ancestry, err := driver.Ancestry(ctx, grandchild.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ancestry).To(HaveLen(3))
+
+			matches, err := driver.Search(ctx, sqlite.SearchOptions{Query: "grandchild"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matches).To(HaveLen(1))
+		})
+
+		It("stores nothing when a node fails", func() {
+			orphanParent := merkle.NewNode(sqliteTestBucket("never stored"), nil)
+			first := merkle.NewNode(sqliteTestBucket("first"), nil)
+			orphan := merkle.NewNode(sqliteTestBucket("orphan"), orphanParent)
+
+			_, err := driver.InsertBatch(ctx, []*merkle.Node{first, orphan})
+			Expect(err).To(HaveOccurred())
+
+			exists, err := driver.Has(ctx, first.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		})
+	})
+
 	Describe("Has", func() {
 		It("returns true for existing node", func() {
 			node := merkle.NewNode(sqliteTestBucket("test"), nil)
@@ -242,6 +389,59 @@ var _ = Describe("Driver", func() {
 			Expect(ancestry[1].Bucket).To(Equal(childBucket))
 			Expect(ancestry[2].Bucket).To(Equal(rootBucket))
 		})
+
+		It("returns long paths in order", func() {
+			var parent *merkle.Node
+			for i := range 500 {
+				n := merkle.NewNode(sqliteTestBucket(fmt.Sprintf("turn %d", i)), parent)
+				_, err := driver.Put(ctx, n)
+				Expect(err).NotTo(HaveOccurred())
+				parent = n
+			}
+
+			ancestry, err := driver.Ancestry(ctx, parent.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ancestry).To(HaveLen(500))
+			for i, n := range ancestry {
+				Expect(n.TurnIndex).To(Equal(499 - i))
+			}
+		})
+
+		It("returns NotFoundError for unknown hashes", func() {
+			_, err := driver.Ancestry(ctx, "missing")
+			Expect(err).To(BeAssignableToTypeOf(storage.NotFoundError{}))
+		})
+
+		It("stops when parent links loop", func() {
+			root := merkle.NewNode(sqliteTestBucket("root"), nil)
+			child := merkle.NewNode(sqliteTestBucket("child"), root)
+			driver.Put(ctx, root)
+			driver.Put(ctx, child)
+			Expect(driver.Client.Node.UpdateOneID(root.Hash).SetParentHash(child.Hash).Exec(ctx)).To(Succeed())
+
+			ancestry, err := driver.Ancestry(ctx, child.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ancestry).To(HaveLen(2))
+		})
+
+		It("loads the ancestry of several nodes at once", func() {
+			root := merkle.NewNode(sqliteTestBucket("root"), nil)
+			left := merkle.NewNode(sqliteTestBucket("left"), root)
+			right := merkle.NewNode(sqliteTestBucket("right"), root)
+			other := merkle.NewNode(sqliteTestBucket("other"), nil)
+			for _, n := range []*merkle.Node{root, left, right, other} {
+				driver.Put(ctx, n)
+			}
+
+			paths, err := driver.AncestryNodesOf(ctx, []string{left.Hash, right.Hash, other.Hash, "missing"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paths).To(HaveLen(3))
+			Expect(paths[left.Hash]).To(HaveLen(2))
+			Expect(paths[left.Hash][1].ID).To(Equal(root.Hash))
+			Expect(paths[right.Hash][0].ID).To(Equal(right.Hash))
+			Expect(paths[right.Hash][1].ID).To(Equal(root.Hash))
+			Expect(paths[other.Hash]).To(HaveLen(1))
+		})
 	})
 
 	Describe("LoadDag (merkle.LoadDag with driver as BranchLoader)", func() {
@@ -441,6 +641,78 @@ var _ = Describe("Driver", func() {
 			Expect(retrieved.StopReason).To(Equal("stop"))
 			Expect(retrieved.Usage).NotTo(BeNil())
 			Expect(retrieved.Usage.TotalTokens).To(Equal(15))
+			Expect(retrieved.Timing).To(BeNil())
+		})
+
+		It("stores and retrieves proxy timing", func() {
+			started := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+			node := merkle.NewNode(sqliteTestBucket("timed"), nil, merkle.NodeMeta{
+				Timing: &llm.Timing{
+					RequestStartedAt:    started,
+					FirstChunkAt:        started.Add(400 * time.Millisecond),
+					ResponseCompletedAt: started.Add(3 * time.Second),
+				},
+			})
+
+			_, err := driver.Put(ctx, node)
+			Expect(err).NotTo(HaveOccurred())
+
+			retrieved, err := driver.Get(ctx, node.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retrieved.Timing).NotTo(BeNil())
+			Expect(retrieved.Timing.Latency()).To(Equal(3 * time.Second))
+			Expect(retrieved.Timing.TimeToFirstToken()).To(Equal(400 * time.Millisecond))
+		})
+
+		It("stores and retrieves the trace ID", func() {
+			node := merkle.NewNode(sqliteTestBucket("traced"), nil, merkle.NodeMeta{
+				TraceID: "4bf92f3577b34a736d9e2b3ad0e3c1a2",
+			})
+
+			_, err := driver.Put(ctx, node)
+			Expect(err).NotTo(HaveOccurred())
+
+			retrieved, err := driver.Get(ctx, node.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retrieved.TraceID).To(Equal("4bf92f3577b34a736d9e2b3ad0e3c1a2"))
+		})
+
+		It("stores and retrieves an upstream error", func() {
+			node := merkle.NewNode(sqliteTestBucket("failed"), nil, merkle.NodeMeta{
+				StopReason: "error",
+				Error: &llm.UpstreamError{
+					Status:            429,
+					Type:              "rate_limit_error",
+					Message:           "Too many requests",
+					RetryAfterSeconds: 30,
+					Attempt:           2,
+				},
+			})
+
+			_, err := driver.Put(ctx, node)
+			Expect(err).NotTo(HaveOccurred())
+
+			retrieved, err := driver.Get(ctx, node.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retrieved.Error).To(Equal(node.Error))
+		})
+
+		It("stores and retrieves policy violations", func() {
+			node := merkle.NewNode(sqliteTestBucket("flagged"), nil, merkle.NodeMeta{
+				PolicyViolations: []llm.PolicyViolation{{
+					Rule:    "no_web",
+					Action:  "annotate",
+					Tool:    "WebFetch",
+					Message: "WebFetch is a blocked tool",
+				}},
+			})
+
+			_, err := driver.Put(ctx, node)
+			Expect(err).NotTo(HaveOccurred())
+
+			retrieved, err := driver.Get(ctx, node.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retrieved.PolicyViolations).To(Equal(node.PolicyViolations))
 		})
 	})
 
@@ -479,3 +751,1253 @@ var _ = Describe("Driver", func() {
 		})
 	})
 })
+
+var _ = Describe("Encryption", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "encrypted.db")
+	})
+
+	useKey := func() {
+		key, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv(encryption.KeyEnvVar, key)
+	}
+
+	rawColumns := func(hash string) (string, string) {
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+
+		var content, bucket string
+		Expect(db.QueryRowContext(ctx, "SELECT content, bucket FROM nodes WHERE hash = ?", hash).Scan(&content, &bucket)).To(Succeed())
+		return content, bucket
+	}
+
+	It("stores content encrypted and reads it back as plaintext", func() {
+		useKey()
+		driver, err := sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		node := merkle.NewNode(sqliteTestBucket("the launch codes"), nil)
+		_, err = driver.Put(ctx, node)
+		Expect(err).NotTo(HaveOccurred())
+
+		content, bucket := rawColumns(node.Hash)
+		Expect(content).NotTo(ContainSubstring("launch codes"))
+		Expect(bucket).NotTo(ContainSubstring("launch codes"))
+		Expect(content).To(ContainSubstring(`"encrypted"`))
+
+		got, err := driver.Get(ctx, node.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Bucket.Content).To(Equal(node.Bucket.Content))
+
+		// The hash is computed over plaintext, so it still verifies.
+		Expect(merkle.NewNode(got.Bucket, nil).Hash).To(Equal(node.Hash))
+
+		entNode, err := driver.Client.Node.Get(ctx, node.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entNode.Content[0]).To(HaveKeyWithValue("text", "the launch codes"))
+	})
+
+	It("stores plaintext unless encryption is enabled", func() {
+		useKey()
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		node := merkle.NewNode(sqliteTestBucket("the launch codes"), nil)
+		_, err = driver.Put(ctx, node)
+		Expect(err).NotTo(HaveOccurred())
+
+		content, _ := rawColumns(node.Hash)
+		Expect(content).NotTo(ContainSubstring(`"encrypted"`))
+	})
+
+	It("reads rows written before encryption was enabled", func() {
+		plain, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		legacy := merkle.NewNode(sqliteTestBucket("legacy"), nil)
+		_, err = plain.Put(ctx, legacy)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plain.Close()).To(Succeed())
+
+		useKey()
+		driver, err := sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		fresh := merkle.NewNode(sqliteTestBucket("fresh"), legacy)
+		_, err = driver.Put(ctx, fresh)
+		Expect(err).NotTo(HaveOccurred())
+
+		path, err := driver.Ancestry(ctx, fresh.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(HaveLen(2))
+		Expect(path[0].Bucket.Content[0].Text).To(Equal("fresh"))
+		Expect(path[1].Bucket.Content[0].Text).To(Equal("legacy"))
+	})
+
+	It("seals session titles and opens them on read", func() {
+		useKey()
+		driver, err := sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		node := merkle.NewNode(sqliteTestBucket("the launch codes"), nil)
+		_, err = driver.Put(ctx, node)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Titles are set with a conditional bulk update.
+		updated, err := driver.Client.Node.Update().
+			Where(entnode.ID(node.Hash), entnode.TitleIsNil()).
+			SetTitle("Fetch the launch codes").
+			Save(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated).To(Equal(1))
+
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+		var title string
+		Expect(db.QueryRowContext(ctx, "SELECT title FROM nodes WHERE hash = ?", node.Hash).Scan(&title)).To(Succeed())
+		Expect(title).NotTo(ContainSubstring("launch codes"))
+
+		entNode, err := driver.Client.Node.Get(ctx, node.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entNode.Title).To(HaveValue(Equal("Fetch the launch codes")))
+	})
+
+	It("seals session summaries and outcomes", func() {
+		useKey()
+		driver, err := sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		node := merkle.NewNode(sqliteTestBucket("the launch codes"), nil)
+		_, err = driver.Put(ctx, node)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(driver.Client.Node.UpdateOneID(node.Hash).
+			SetTitle("Launch codes").
+			SetSummary("The user asked for the launch codes.").
+			SetOutcome("refused to share the launch codes").
+			Exec(ctx)).To(Succeed())
+
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+		var title, summary, outcome string
+		Expect(db.QueryRowContext(ctx, "SELECT title, summary, outcome FROM nodes WHERE hash = ?", node.Hash).
+			Scan(&title, &summary, &outcome)).To(Succeed())
+		Expect(title + summary + outcome).NotTo(ContainSubstring("aunch codes"))
+
+		entNode, err := driver.Client.Node.Get(ctx, node.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entNode.Summary).To(HaveValue(Equal("The user asked for the launch codes.")))
+		Expect(entNode.Outcome).To(HaveValue(Equal("refused to share the launch codes")))
+	})
+
+	It("seals dead letters and opens them on read", func() {
+		useKey()
+		driver, err := sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		Expect(driver.AddDeadLetter(ctx, &storage.DeadLetter{
+			Stage:    storage.DeadLetterStageResponse,
+			Provider: "openai",
+			Path:     "/v1/chat/completions",
+			Headers:  map[string]string{"X-Request-Id": "req-launch"},
+			Request:  `{"prompt":"the launch codes"}`,
+			Response: `{"answer":"the launch codes are`,
+			Error:    "unexpected EOF",
+		})).To(Succeed())
+
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+		var headers, request, response string
+		Expect(db.QueryRowContext(ctx, "SELECT headers, request, response FROM dead_letters").Scan(&headers, &request, &response)).To(Succeed())
+		Expect(headers).NotTo(ContainSubstring("req-launch"))
+		Expect(request).NotTo(ContainSubstring("launch codes"))
+		Expect(response).NotTo(ContainSubstring("launch codes"))
+
+		letters, err := driver.ListDeadLetters(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters).To(HaveLen(1))
+		Expect(letters[0].Request).To(Equal(`{"prompt":"the launch codes"}`))
+		Expect(letters[0].Response).To(Equal(`{"answer":"the launch codes are`))
+		Expect(letters[0].Headers).To(HaveKeyWithValue("X-Request-Id", "req-launch"))
+
+		got, err := driver.GetDeadLetter(ctx, letters[0].ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got).To(Equal(letters[0]))
+	})
+
+	It("fails to read encrypted content with the wrong key", func() {
+		useKey()
+		driver, err := sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		node := merkle.NewNode(sqliteTestBucket("secret"), nil)
+		_, err = driver.Put(ctx, node)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.Close()).To(Succeed())
+
+		useKey()
+		other, err := sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		defer other.Close()
+
+		_, err = other.Get(ctx, node.Hash)
+		Expect(err).To(MatchError(encryption.ErrInvalidCiphertext))
+	})
+})
+
+var _ = Describe("Compact", func() {
+	It("reclaims space freed by deletions and keeps remaining data", func() {
+		ctx := context.Background()
+		driver, err := sqlite.NewDriver(ctx, filepath.Join(GinkgoT().TempDir(), "compact.db"))
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		keep := merkle.NewNode(sqliteTestBucket("keep"), nil)
+		_, err = driver.Put(ctx, keep)
+		Expect(err).NotTo(HaveOccurred())
+
+		var hashes []string
+		for i := range 50 {
+			n := merkle.NewNode(sqliteTestBucket(fmt.Sprintf("%d %s", i, strings.Repeat("x", 8192))), nil)
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+			hashes = append(hashes, n.Hash)
+		}
+		_, err = driver.Client.Node.Delete().Where(entnode.IDIn(hashes...)).Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := driver.Compact(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.SizeAfter).To(BeNumerically("<", result.SizeBefore))
+		Expect(result.Reclaimed()).To(Equal(result.SizeBefore - result.SizeAfter))
+
+		got, err := driver.Get(ctx, keep.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Bucket.Content[0].Text).To(Equal("keep"))
+	})
+})
+
+var _ = Describe("Backup", func() {
+	It("snapshots a database in use and restores it", func() {
+		ctx := context.Background()
+		dir := GinkgoT().TempDir()
+		dbPath := filepath.Join(dir, "tapes.db")
+		backupPath := filepath.Join(dir, "backup.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		before := merkle.NewNode(sqliteTestBucket("before backup"), nil)
+		_, err = driver.Put(ctx, before)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(sqlite.Backup(ctx, dbPath, backupPath)).To(Succeed())
+		Expect(sqlite.CheckIntegrity(ctx, backupPath)).To(Succeed())
+
+		after := merkle.NewNode(sqliteTestBucket("after backup"), nil)
+		_, err = driver.Put(ctx, after)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(sqlite.Restore(ctx, backupPath, dbPath)).To(Succeed())
+
+		has, err := driver.Has(ctx, before.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has).To(BeTrue())
+		has, err = driver.Has(ctx, after.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has).To(BeFalse())
+	})
+
+	It("refuses to restore a file that is not a database", func() {
+		ctx := context.Background()
+		dir := GinkgoT().TempDir()
+		bogus := filepath.Join(dir, "bogus.db")
+		Expect(os.WriteFile(bogus, []byte(strings.Repeat("not a database ", 100)), 0o600)).To(Succeed())
+
+		Expect(sqlite.Restore(ctx, bogus, filepath.Join(dir, "tapes.db"))).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("Version", func() {
+	It("increases when nodes are written by any connection", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "version.db")
+		reader, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer reader.Close()
+		writer, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer writer.Close()
+
+		initial, err := reader.Version(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		n := merkle.NewNode(sqliteTestBucket("versioned"), nil)
+		_, err = writer.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+		afterInsert, err := reader.Version(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(afterInsert).To(BeNumerically(">", initial))
+
+		// Putting an existing node is a no-op and leaves the version alone.
+		_, err = writer.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reader.Version(ctx)).To(Equal(afterInsert))
+
+		_, err = writer.Client.Node.Delete().Where(entnode.IDIn(n.Hash)).Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reader.Version(ctx)).To(BeNumerically(">", afterInsert))
+	})
+})
+
+var _ = Describe("Deduplication", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+		large  string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "dedup.db")
+		large = "You are a helpful coding agent. " + strings.Repeat("Follow the project conventions. ", 100)
+	})
+
+	rawContent := func(hash string) string {
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+
+		var content string
+		Expect(db.QueryRowContext(ctx, "SELECT content FROM nodes WHERE hash = ?", hash).Scan(&content)).To(Succeed())
+		return content
+	}
+
+	countBlocks := func(driver *sqlite.Driver) int {
+		n, err := driver.Client.Block.Query().Count(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		return n
+	}
+
+	It("stores turns without content blocks", func() {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		n := merkle.NewNode(merkle.Bucket{Type: "message", Role: "assistant"}, nil)
+		_, err = driver.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+
+		got, err := driver.Get(ctx, n.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Bucket.Content).To(BeEmpty())
+	})
+
+	It("stores a repeated large block once and reads it back in place", func() {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		first := merkle.NewNode(sqliteTestBucket(large), nil)
+		second := merkle.NewNode(merkle.Bucket{
+			Type:    "message",
+			Role:    "user",
+			Content: []llm.ContentBlock{{Type: "text", Text: large}, {Type: "text", Text: "and a short question"}},
+		}, first)
+		for _, n := range []*merkle.Node{first, second} {
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(countBlocks(driver)).To(Equal(1))
+		Expect(rawContent(second.Hash)).To(ContainSubstring("block_ref"))
+		Expect(rawContent(second.Hash)).NotTo(ContainSubstring("project conventions"))
+		Expect(rawContent(second.Hash)).To(ContainSubstring("and a short question"))
+
+		path, err := driver.Ancestry(ctx, second.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path[0].Bucket.Content).To(Equal(second.Bucket.Content))
+		Expect(path[1].Bucket.Content).To(Equal(first.Bucket.Content))
+		Expect(path[0].Verify()).To(BeTrue())
+	})
+
+	It("seals blocks when encryption is enabled", func() {
+		key, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv(encryption.KeyEnvVar, key)
+
+		driver, err := sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		n := merkle.NewNode(sqliteTestBucket(large), nil)
+		_, err = driver.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+		var data string
+		Expect(db.QueryRowContext(ctx, "SELECT data FROM blocks").Scan(&data)).To(Succeed())
+		Expect(data).NotTo(ContainSubstring("project conventions"))
+
+		got, err := driver.Get(ctx, n.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Bucket.Content[0].Text).To(Equal(large))
+	})
+
+	It("migrates nodes stored before deduplication", func() {
+		// Write through a bare ent client, as older versions did.
+		db, err := sql.Open("sqlite3", dbPath+"?_fk=1")
+		Expect(err).NotTo(HaveOccurred())
+		client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.SQLite, db)))
+		Expect(client.Schema.Create(ctx)).To(Succeed())
+		legacy := &entdriver.EntDriver{Client: client}
+		n := merkle.NewNode(sqliteTestBucket(large), nil)
+		_, err = legacy.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.Close()).To(Succeed())
+		Expect(rawContent(n.Hash)).To(ContainSubstring("project conventions"))
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		result, err := driver.DeduplicateBlocks(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Nodes).To(Equal(1))
+		Expect(result.Blocks).To(Equal(1))
+		Expect(rawContent(n.Hash)).To(ContainSubstring("block_ref"))
+
+		again, err := driver.DeduplicateBlocks(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again.Nodes).To(BeZero())
+
+		got, err := driver.Get(ctx, n.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Verify()).To(BeTrue())
+	})
+
+	It("prunes blocks once no node references them", func() {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		first := merkle.NewNode(sqliteTestBucket(large), nil)
+		second := merkle.NewNode(sqliteTestBucket(large), first)
+		for _, n := range []*merkle.Node{first, second} {
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		_, err = driver.Client.Node.Delete().Where(entnode.ID(second.Hash)).Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		pruned, err := driver.PruneBlocks(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pruned).To(BeZero())
+
+		_, err = driver.Client.Node.Delete().Where(entnode.ID(first.Hash)).Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		pruned, err = driver.PruneBlocks(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pruned).To(Equal(1))
+		Expect(countBlocks(driver)).To(BeZero())
+	})
+})
+
+var _ = Describe("Search", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+		driver *sqlite.Driver
+	)
+
+	put := func(text, provider string, parent *merkle.Node) *merkle.Node {
+		bucket := sqliteTestBucket(text)
+		bucket.Provider = provider
+		n := merkle.NewNode(bucket, parent)
+		_, err := driver.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+		return n
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "search.db")
+
+		var err error
+		driver, err = sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		driver.Close()
+	})
+
+	It("ranks turns by relevance", func() {
+		weak := put("the deploy step reported connection refused once among many other unrelated log lines about caching and retries", "openai", nil)
+		strong := put("connection refused: connection refused while dialing the database", "openai", nil)
+		put("everything passed", "openai", nil)
+
+		matches, err := driver.Search(ctx, sqlite.SearchOptions{Query: `"connection refused"`})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(HaveLen(2))
+		Expect(matches[0].Hash).To(Equal(strong.Hash))
+		Expect(matches[1].Hash).To(Equal(weak.Hash))
+		Expect(matches[0].Score).To(BeNumerically(">", matches[1].Score))
+		Expect(matches[0].Snippet).To(ContainSubstring("[connection]"))
+	})
+
+	It("filters by provider and time", func() {
+		put("build failed on lint", "openai", nil)
+		put("build failed on tests", "anthropic", nil)
+
+		matches, err := driver.Search(ctx, sqlite.SearchOptions{Query: "build", Provider: "anthropic"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(HaveLen(1))
+		Expect(matches[0].Provider).To(Equal("anthropic"))
+
+		matches, err = driver.Search(ctx, sqlite.SearchOptions{Query: "build", Since: time.Now().Add(time.Hour)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(BeEmpty())
+	})
+
+	It("indexes tool inputs and outputs", func() {
+		n := merkle.NewNode(merkle.Bucket{
+			Type: "message",
+			Role: "assistant",
+			Content: []llm.ContentBlock{
+				{Type: "tool_use", ToolName: "bash", ToolInput: map[string]any{"command": "make lint"}},
+			},
+		}, nil)
+		_, err := driver.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+
+		matches, err := driver.Search(ctx, sqlite.SearchOptions{Query: "lint"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(HaveLen(1))
+	})
+
+	It("backfills existing nodes, including deduplicated content", func() {
+		large := "segfault in the parser " + strings.Repeat("padding ", 200)
+		put(large, "openai", nil)
+		Expect(driver.Close()).To(Succeed())
+
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = db.ExecContext(ctx, "DROP TABLE node_search")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(db.Close()).To(Succeed())
+
+		driver, err = sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		matches, err := driver.Search(ctx, sqlite.SearchOptions{Query: "segfault"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(HaveLen(1))
+	})
+
+	It("ignores deleted nodes", func() {
+		n := put("flaky test", "openai", nil)
+		_, err := driver.Client.Node.Delete().Where(entnode.ID(n.Hash)).Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		matches, err := driver.Search(ctx, sqlite.SearchOptions{Query: "flaky"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(matches).To(BeEmpty())
+
+		_, err = driver.Compact(ctx)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("is unavailable when content is encrypted", func() {
+		put("secret plans", "openai", nil)
+		Expect(driver.Close()).To(Succeed())
+
+		key, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv(encryption.KeyEnvVar, key)
+
+		driver, err = sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = driver.Search(ctx, sqlite.SearchOptions{Query: "secret"})
+		Expect(err).To(MatchError(sqlite.ErrSearchDisabled))
+
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+		var tables int
+		Expect(db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE name = 'node_search'").Scan(&tables)).To(Succeed())
+		Expect(tables).To(BeZero())
+	})
+})
+
+var _ = Describe("FilterLeaves", func() {
+	var (
+		ctx    context.Context
+		driver *sqlite.Driver
+		db     *sql.DB
+
+		toolLeaf, stopLeaf, oldLeaf *merkle.Node
+	)
+
+	assistant := func(parent *merkle.Node, stopReason string, blocks ...llm.ContentBlock) *merkle.Node {
+		bucket := merkle.Bucket{Type: "message", Role: "assistant", Content: blocks, Model: "test-model", Provider: "test-provider"}
+		return merkle.NewNode(bucket, parent, merkle.NodeMeta{StopReason: stopReason})
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "filter.db")
+		var err error
+		driver, err = sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		db, err = sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		// One conversation forks after its first prompt into a tool call
+		// and a truncated reply; another ran a week ago.
+		root := merkle.NewNode(sqliteTestBucket("fix the build"), nil)
+		toolCall := assistant(root, "tool_use", llm.ContentBlock{Type: "tool_use", ToolName: "Bash", ToolInput: map[string]any{"command": "make"}})
+		toolLeaf = merkle.NewNode(sqliteTestBucket("thanks"), toolCall)
+		stopLeaf = assistant(root, "max_tokens", llm.ContentBlock{Type: "text", Text: "The build fails because"})
+		oldRoot := merkle.NewNode(sqliteTestBucket("old question"), nil)
+		oldLeaf = assistant(oldRoot, "end_turn", llm.ContentBlock{Type: "tool_use", ToolName: "Read"})
+
+		for _, n := range []*merkle.Node{root, toolCall, toolLeaf, stopLeaf, oldRoot, oldLeaf} {
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+		_, err = db.ExecContext(ctx, "UPDATE nodes SET created_at = ? WHERE hash IN (?, ?)", weekAgo, oldRoot.Hash, oldLeaf.Hash)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		db.Close()
+		driver.Close()
+	})
+
+	It("records the tools each node called", func() {
+		var toolNames string
+		Expect(db.QueryRowContext(ctx, "SELECT tool_names FROM nodes WHERE hash = ?", oldLeaf.Hash).Scan(&toolNames)).To(Succeed())
+		Expect(toolNames).To(Equal(",Read,"))
+		Expect(db.QueryRowContext(ctx, "SELECT tool_names FROM nodes WHERE hash = ?", stopLeaf.Hash).Scan(&toolNames)).To(Succeed())
+		Expect(toolNames).To(BeEmpty())
+	})
+
+	It("matches conversations with a turn that called a tool", func() {
+		leaves, err := driver.FilterLeaves(ctx, sqlite.LeafFilter{Tool: "bash"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(Equal(map[string]bool{toolLeaf.Hash: true}))
+
+		leaves, err = driver.FilterLeaves(ctx, sqlite.LeafFilter{Tool: "a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(Equal(map[string]bool{toolLeaf.Hash: true, oldLeaf.Hash: true}))
+
+		leaves, err = driver.FilterLeaves(ctx, sqlite.LeafFilter{Tool: "h,R"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(BeEmpty())
+	})
+
+	It("matches conversations with a turn that stopped for a reason", func() {
+		leaves, err := driver.FilterLeaves(ctx, sqlite.LeafFilter{StopReason: "max_tokens"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(Equal(map[string]bool{stopLeaf.Hash: true}))
+	})
+
+	It("bounds conversations by when they ran", func() {
+		dayAgo := time.Now().Add(-24 * time.Hour)
+
+		leaves, err := driver.FilterLeaves(ctx, sqlite.LeafFilter{EndedAfter: dayAgo})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(Equal(map[string]bool{toolLeaf.Hash: true, stopLeaf.Hash: true}))
+
+		leaves, err = driver.FilterLeaves(ctx, sqlite.LeafFilter{StartedBefore: dayAgo, Tool: "Read"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(Equal(map[string]bool{oldLeaf.Hash: true}))
+	})
+
+	It("backfills tool names for nodes stored before they were recorded", func() {
+		_, err := db.ExecContext(ctx, "UPDATE nodes SET tool_names = NULL")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(driver.BackfillToolNames(ctx)).To(Succeed())
+
+		leaves, err := driver.FilterLeaves(ctx, sqlite.LeafFilter{Tool: "Read"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(Equal(map[string]bool{oldLeaf.Hash: true}))
+	})
+})
+
+var _ = Describe("Raw captures", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+	})
+
+	capture := func() *storage.RawCapture {
+		return &storage.RawCapture{
+			NodeHash:  "abc123",
+			Provider:  "ollama",
+			AgentName: "claude",
+			Project:   "tapes",
+			Path:      "/api/chat",
+			Streamed:  true,
+			Request:   []byte(`{"model":"llama3","messages":[{"role":"user","content":"the launch codes"}]}`),
+			Response:  []byte("{\"done\":false}\n{\"done\":true}"),
+		}
+	}
+
+	It("stores payloads compressed and reads them back", func() {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		rc := capture()
+		Expect(driver.AddRawCapture(ctx, rc)).To(Succeed())
+		Expect(rc.ID).NotTo(BeZero())
+		Expect(rc.CreatedAt).NotTo(BeZero())
+
+		entry, err := driver.Client.RawCapture.Get(ctx, rc.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entry.Encrypted).To(BeFalse())
+		Expect(entry.Request).NotTo(Equal(rc.Request))
+
+		captures, err := driver.ListRawCaptures(ctx, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(captures).To(HaveLen(1))
+		Expect(captures[0].Request).To(Equal(rc.Request))
+		Expect(captures[0].Response).To(Equal(rc.Response))
+		Expect(captures[0].Streamed).To(BeTrue())
+		Expect(captures[0].Project).To(Equal("tapes"))
+
+		captures, err = driver.ListRawCaptures(ctx, time.Now().Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(captures).To(BeEmpty())
+	})
+
+	It("seals payloads when encryption is enabled", func() {
+		key, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv(encryption.KeyEnvVar, key)
+
+		driver, err := sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		rc := capture()
+		Expect(driver.AddRawCapture(ctx, rc)).To(Succeed())
+
+		captures, err := driver.ListRawCaptures(ctx, time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(captures[0].Request).To(Equal(rc.Request))
+		Expect(driver.Close()).To(Succeed())
+
+		other, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv(encryption.KeyEnvVar, other)
+
+		driver, err = sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		_, err = driver.ListRawCaptures(ctx, time.Time{})
+		Expect(err).To(MatchError(encryption.ErrInvalidCiphertext))
+	})
+})
+
+var _ = Describe("Session tools", func() {
+	var (
+		ctx    context.Context
+		driver *sqlite.Driver
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		driver, err = sqlite.NewDriver(ctx, filepath.Join(GinkgoT().TempDir(), "tapes.db"))
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(driver.Close)
+	})
+
+	readFile := llm.ToolDefinition{
+		Type:        "function",
+		Name:        "read_file",
+		Description: "Read a file",
+		Schema: map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"path": map[string]any{"type": "string"}},
+		},
+	}
+	sessionTools := func(session, node string, defs ...llm.ToolDefinition) []*storage.SessionTool {
+		tools := make([]*storage.SessionTool, 0, len(defs))
+		for _, def := range defs {
+			tools = append(tools, &storage.SessionTool{SessionID: session, RunID: "run-1", NodeHash: node, Tool: def})
+		}
+		return tools
+	}
+
+	It("stores each definition once and links it to every session that offered it", func() {
+		webSearch := llm.ToolDefinition{Type: "web_search", Name: "web_search"}
+		Expect(driver.AddSessionTools(ctx, sessionTools("s1", "n1", readFile, webSearch))).To(Succeed())
+		Expect(driver.AddSessionTools(ctx, sessionTools("s1", "n2", readFile))).To(Succeed())
+		Expect(driver.AddSessionTools(ctx, sessionTools("s2", "n3", readFile))).To(Succeed())
+
+		Expect(driver.Client.ToolDefinition.Query().CountX(ctx)).To(Equal(2))
+
+		tools, err := driver.ListSessionTools(ctx, "s1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tools).To(HaveLen(2))
+		Expect(tools[0].Tool).To(Equal(readFile))
+		Expect(tools[0].Hash).To(Equal(readFile.Hash()))
+		Expect(tools[0].NodeHash).To(Equal("n1"))
+		Expect(tools[0].RunID).To(Equal("run-1"))
+		Expect(tools[1].Tool.Name).To(Equal("web_search"))
+
+		tools, err = driver.ListSessionTools(ctx, "s2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tools).To(HaveLen(1))
+		Expect(tools[0].NodeHash).To(Equal("n3"))
+	})
+
+	It("stores a changed definition separately", func() {
+		changed := readFile
+		changed.Description = "Read a file from the workspace"
+		Expect(driver.AddSessionTools(ctx, sessionTools("s1", "n1", readFile))).To(Succeed())
+		Expect(driver.AddSessionTools(ctx, sessionTools("s2", "n2", changed))).To(Succeed())
+
+		first, err := driver.ListSessionTools(ctx, "s1")
+		Expect(err).NotTo(HaveOccurred())
+		second, err := driver.ListSessionTools(ctx, "s2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first[0].Hash).NotTo(Equal(second[0].Hash))
+		Expect(second[0].Tool.Description).To(Equal("Read a file from the workspace"))
+	})
+
+	It("returns no tools for unknown sessions", func() {
+		tools, err := driver.ListSessionTools(ctx, "missing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tools).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Session summaries", func() {
+	var (
+		ctx    context.Context
+		driver *sqlite.Driver
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		var err error
+		driver, err = sqlite.NewDriver(ctx, filepath.Join(GinkgoT().TempDir(), "tapes.db"))
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(driver.Close)
+		driver.SetSessionCost(func(_ string, usage llm.Usage) float64 {
+			return float64(usage.TotalTokens) / 1000
+		})
+	})
+
+	turn := func(text string, parent *merkle.Node, tokens int) *merkle.Node {
+		return merkle.NewNode(sqliteTestBucket(text), parent, merkle.NodeMeta{
+			Usage: &llm.Usage{PromptTokens: tokens, CompletionTokens: tokens, TotalTokens: 2 * tokens},
+		})
+	}
+	session := func(leaf string) *ent.Session {
+		row, err := driver.Client.Session.Get(ctx, leaf)
+		Expect(err).NotTo(HaveOccurred())
+		return row
+	}
+
+	It("keeps one row per leaf as turns are added", func() {
+		root := turn("root", nil, 10)
+		child := turn("child", root, 20)
+		_, err := driver.Put(ctx, root)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = driver.Put(ctx, child)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(driver.Client.Session.Query().CountX(ctx)).To(Equal(1))
+		row := session(child.Hash)
+		Expect(row.RootHash).To(Equal(root.Hash))
+		Expect(row.TurnCount).To(Equal(2))
+		Expect(row.PromptTokens).To(Equal(int64(30)))
+		Expect(row.TotalTokens).To(Equal(int64(60)))
+		Expect(row.Cost).To(BeNumerically("~", 0.06))
+	})
+
+	It("starts a row for each branch and touches its siblings", func() {
+		root := turn("root", nil, 10)
+		first := turn("first", root, 20)
+		second := turn("second", root, 30)
+		_, err := driver.InsertBatch(ctx, []*merkle.Node{root, first})
+		Expect(err).NotTo(HaveOccurred())
+		before := session(first.Hash).Revision
+
+		_, err = driver.Put(ctx, second)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(driver.Client.Session.Query().CountX(ctx)).To(Equal(2))
+		Expect(session(first.Hash).Revision).To(BeNumerically(">", before))
+		row := session(second.Hash)
+		Expect(row.RootHash).To(Equal(root.Hash))
+		Expect(row.TurnCount).To(Equal(2))
+		Expect(row.TotalTokens).To(Equal(int64(80)))
+	})
+
+	It("refreshes the rows that include a turn whose usage changed", func() {
+		root := turn("root", nil, 10)
+		child := turn("child", root, 20)
+		_, err := driver.InsertBatch(ctx, []*merkle.Node{root, child})
+		Expect(err).NotTo(HaveOccurred())
+		before := session(child.Hash).Revision
+
+		Expect(driver.UpdateUsage(ctx, root.Hash, &llm.Usage{PromptTokens: 50, CompletionTokens: 50, TotalTokens: 100})).To(Succeed())
+
+		row := session(child.Hash)
+		Expect(row.TotalTokens).To(Equal(int64(140)))
+		Expect(row.Revision).To(BeNumerically(">", before))
+	})
+
+	It("touches the rows of a session when it ends", func() {
+		root := turn("root", nil, 10)
+		_, err := driver.Put(ctx, root)
+		Expect(err).NotTo(HaveOccurred())
+		before := session(root.Hash).Revision
+
+		Expect(driver.EndSession(ctx, root.Hash, time.Now(), storage.SessionEndExit)).To(Succeed())
+		Expect(session(root.Hash).Revision).To(BeNumerically(">", before))
+	})
+
+	It("rebuilds the rows from the stored nodes", func() {
+		root := turn("root", nil, 10)
+		first := turn("first", root, 20)
+		second := turn("second", root, 30)
+		_, err := driver.InsertBatch(ctx, []*merkle.Node{root, first, second})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = driver.Client.Session.Delete().Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		count, err := driver.RebuildSessions(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(2))
+		Expect(session(first.Hash).TotalTokens).To(Equal(int64(60)))
+		Expect(session(second.Hash).TotalTokens).To(Equal(int64(80)))
+		Expect(session(second.Hash).RootHash).To(Equal(root.Hash))
+	})
+
+	It("carries the revision forward one step per appended turn", func() {
+		root := turn("root", nil, 10)
+		child := turn("child", root, 20)
+		grandchild := turn("grandchild", child, 30)
+		_, err := driver.Put(ctx, root)
+		Expect(err).NotTo(HaveOccurred())
+		before := session(root.Hash).Revision
+
+		_, err = driver.Put(ctx, child)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = driver.Put(ctx, grandchild)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(driver.Client.Session.Query().CountX(ctx)).To(Equal(1))
+		Expect(session(grandchild.Hash).Revision).To(Equal(before + 2))
+	})
+
+	It("backfills an empty table when the database is reopened", func() {
+		dbPath := filepath.Join(GinkgoT().TempDir(), "tapes.db")
+		first, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		root := turn("root", nil, 10)
+		child := turn("child", root, 20)
+		_, err = first.InsertBatch(ctx, []*merkle.Node{root, child})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = first.Client.Session.Delete().Exec(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first.Close()).To(Succeed())
+
+		reopened, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(reopened.Close)
+
+		row, err := reopened.Client.Session.Get(ctx, child.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(row.RootHash).To(Equal(root.Hash))
+		Expect(row.TurnCount).To(Equal(2))
+	})
+})
+
+var _ = Describe("System prompts", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "tapes.db")
+	})
+
+	It("stores each distinct prompt once under its hash", func() {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		hash, err := driver.AddSystemPrompt(ctx, "You are a coding agent.")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hash).To(Equal(storage.SystemPromptHash("You are a coding agent.")))
+
+		again, err := driver.AddSystemPrompt(ctx, "You are a coding agent.")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(Equal(hash))
+		Expect(driver.Client.SystemPrompt.Query().CountX(ctx)).To(Equal(1))
+
+		text, err := driver.GetSystemPrompt(ctx, hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(text).To(Equal("You are a coding agent."))
+
+		_, err = driver.GetSystemPrompt(ctx, "missing")
+		Expect(err).To(MatchError(ContainSubstring("system prompt missing not found")))
+	})
+
+	It("stamps the prompt hash on nodes", func() {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		node := merkle.NewNode(sqliteTestBucket("hello"), nil, merkle.NodeMeta{SystemPromptHash: "abc"})
+		_, err = driver.Put(ctx, node)
+		Expect(err).NotTo(HaveOccurred())
+
+		got, err := driver.Get(ctx, node.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.SystemPromptHash).To(Equal("abc"))
+	})
+
+	It("seals prompts when encryption is enabled", func() {
+		key, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv(encryption.KeyEnvVar, key)
+
+		driver, err := sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		hash, err := driver.AddSystemPrompt(ctx, "Keep this secret.")
+		Expect(err).NotTo(HaveOccurred())
+		text, err := driver.GetSystemPrompt(ctx, hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(text).To(Equal("Keep this secret."))
+		Expect(driver.Client.SystemPrompt.GetX(ctx, hash).Encrypted).To(BeTrue())
+		Expect(driver.Close()).To(Succeed())
+
+		other, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv(encryption.KeyEnvVar, other)
+
+		driver, err = sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		_, err = driver.GetSystemPrompt(ctx, hash)
+		Expect(err).To(MatchError(encryption.ErrInvalidCiphertext))
+	})
+})
+
+var _ = Describe("Compression", func() {
+	var (
+		ctx    context.Context
+		dbPath string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dbPath = filepath.Join(GinkgoT().TempDir(), "compressed.db")
+	})
+
+	rawColumn := func(column, table, hash string) string {
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+
+		var value string
+		Expect(db.QueryRowContext(ctx, "SELECT "+column+" FROM "+table+" WHERE hash = ?", hash).Scan(&value)).To(Succeed())
+		return value
+	}
+
+	// toolTurn has several tool results, each too small to deduplicate but
+	// together large enough to compress.
+	toolTurn := func() merkle.Bucket {
+		var blocks []llm.ContentBlock
+		for i := range 6 {
+			blocks = append(blocks, llm.ContentBlock{
+				Type:       "tool_result",
+				ToolUseID:  fmt.Sprintf("toolu_%d", i),
+				ToolOutput: strings.Repeat("ok  github.com/papercomputeco/tapes/pkg ", 5),
+			})
+		}
+		return merkle.Bucket{Type: "message", Role: "user", Content: blocks, Model: "test-model", Provider: "test-provider"}
+	}
+
+	It("stores large content compressed and reads it back", func() {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		n := merkle.NewNode(toolTurn(), nil)
+		_, err = driver.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+
+		content := rawColumn("content", "nodes", n.Hash)
+		Expect(content).To(ContainSubstring(`"encoding":"zstd"`))
+		Expect(content).NotTo(ContainSubstring("papercomputeco"))
+		Expect(rawColumn("bucket", "nodes", n.Hash)).NotTo(ContainSubstring("papercomputeco"))
+
+		got, err := driver.Get(ctx, n.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Bucket.Content).To(Equal(n.Bucket.Content))
+		Expect(got.Verify()).To(BeTrue())
+	})
+
+	It("leaves small content inline", func() {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		n := merkle.NewNode(sqliteTestBucket("hello"), nil)
+		_, err = driver.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rawColumn("content", "nodes", n.Hash)).To(ContainSubstring("hello"))
+	})
+
+	It("compresses deduplicated blocks", func() {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		large := strings.Repeat("Follow the project conventions. ", 100)
+		n := merkle.NewNode(sqliteTestBucket(large), nil)
+		_, err = driver.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+
+		ids, err := driver.Client.Block.Query().IDs(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ids).To(HaveLen(1))
+		Expect(rawColumn("data", "blocks", ids[0])).To(ContainSubstring(`"encoding":"zstd"`))
+
+		got, err := driver.Get(ctx, n.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Bucket.Content[0].Text).To(Equal(large))
+	})
+
+	It("compresses content before sealing it", func() {
+		key, err := encryption.GenerateKey()
+		Expect(err).NotTo(HaveOccurred())
+		GinkgoT().Setenv(encryption.KeyEnvVar, key)
+
+		driver, err := sqlite.NewDriver(ctx, dbPath, sqlite.WithEncryption(true))
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		n := merkle.NewNode(toolTurn(), nil)
+		_, err = driver.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rawColumn("content", "nodes", n.Hash)).To(ContainSubstring(`"encrypted"`))
+
+		got, err := driver.Get(ctx, n.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Bucket.Content).To(Equal(n.Bucket.Content))
+	})
+
+	It("leaves content compressed for readers that decompress it on use", func() {
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		n := merkle.NewNode(toolTurn(), nil)
+		_, err = driver.Put(ctx, n)
+		Expect(err).NotTo(HaveOccurred())
+
+		stored, err := driver.Client.Node.Get(entdriver.WithoutDecompression(ctx), n.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stored.Content).To(HaveLen(1))
+		Expect(stored.Content[0]).To(HaveKeyWithValue("type", "compressed"))
+
+		content, err := entdriver.DecompressContent(stored.Content)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(content).To(HaveLen(6))
+		Expect(content[0]).To(HaveKeyWithValue("tool_use_id", "toolu_0"))
+	})
+})
+
+var _ = Describe("Analytics views", func() {
+	It("exposes assistant turns and their daily usage", func() {
+		ctx := context.Background()
+		dbPath := filepath.Join(GinkgoT().TempDir(), "views.db")
+
+		driver, err := sqlite.NewDriver(ctx, dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer driver.Close()
+
+		prompt := merkle.NewNode(sqliteTestBucket("hello"), nil, merkle.NodeMeta{Project: "api"})
+		reply := merkle.NewNode(merkle.Bucket{
+			Type:     "message",
+			Role:     "assistant",
+			Content:  []llm.ContentBlock{{Type: "text", Text: "hi"}},
+			Model:    "test-model",
+			Provider: "test-provider",
+		}, prompt, merkle.NodeMeta{Project: "api", Usage: &llm.Usage{PromptTokens: 100, CompletionTokens: 20, CacheReadInputTokens: 80}})
+		failed := merkle.NewNode(merkle.Bucket{
+			Type:     "error",
+			Role:     "assistant",
+			Content:  []llm.ContentBlock{{Type: "text", Text: "529 overloaded_error"}},
+			Model:    "test-model",
+			Provider: "test-provider",
+		}, prompt, merkle.NodeMeta{Project: "api", StopReason: "error", Error: &llm.UpstreamError{Status: 529}})
+		for _, n := range []*merkle.Node{prompt, reply, failed} {
+			_, err := driver.Put(ctx, n)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		db, err := sql.Open("sqlite3", dbPath)
+		Expect(err).NotTo(HaveOccurred())
+		defer db.Close()
+
+		var turns, errs int
+		Expect(db.QueryRowContext(ctx, "SELECT COUNT(*), SUM(is_error) FROM tapes_turns").Scan(&turns, &errs)).To(Succeed())
+		Expect(turns).To(Equal(2))
+		Expect(errs).To(Equal(1))
+
+		var (
+			day, model, project         string
+			requests, input, cacheReads int
+		)
+		Expect(db.QueryRowContext(ctx,
+			"SELECT day, model, project, requests, errors, input_tokens, cache_read_tokens FROM tapes_usage_daily",
+		).Scan(&day, &model, &project, &requests, &errs, &input, &cacheReads)).To(Succeed())
+		Expect(day).To(Equal(time.Now().UTC().Format(time.DateOnly)))
+		Expect(model).To(Equal("test-model"))
+		Expect(project).To(Equal("api"))
+		Expect(requests).To(Equal(2))
+		Expect(errs).To(Equal(1))
+		Expect(input).To(Equal(100))
+		Expect(cacheReads).To(Equal(80))
+	})
+})
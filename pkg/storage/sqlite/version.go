@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+)
+
+// versionTable holds a single counter that triggers bump whenever a node is
+// inserted, updated, or deleted, by this process or any other.
+const versionTable = "tapes_version"
+
+var versionStatements = []string{
+	"CREATE TABLE IF NOT EXISTS " + versionTable + " (id INTEGER PRIMARY KEY CHECK (id = 1), version INTEGER NOT NULL)",
+	"INSERT OR IGNORE INTO " + versionTable + " (id, version) VALUES (1, 0)",
+	versionTrigger("insert", "INSERT"),
+	versionTrigger("update", "UPDATE"),
+	versionTrigger("delete", "DELETE"),
+}
+
+func versionTrigger(name, event string) string {
+	return "CREATE TRIGGER IF NOT EXISTS nodes_version_" + name + " AFTER " + event + " ON nodes " +
+		"BEGIN UPDATE " + versionTable + " SET version = version + 1 WHERE id = 1; END"
+}
+
+// enableChangeTracking creates the version counter and the triggers that
+// maintain it.
+func (d *Driver) enableChangeTracking(ctx context.Context) error {
+	for _, stmt := range versionStatements {
+		if _, err := d.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to enable change tracking: %w", err)
+		}
+	}
+	return nil
+}
+
+// Version returns a counter that increases whenever a node is inserted,
+// updated, or deleted. Readers that cache derived data can compare versions
+// to tell whether the cache is stale.
+func (d *Driver) Version(ctx context.Context) (int64, error) {
+	var version int64
+	err := d.db.QueryRowContext(ctx, "SELECT version FROM "+versionTable+" WHERE id = 1").Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read version: %w", err)
+	}
+	return version, nil
+}
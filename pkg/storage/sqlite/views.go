@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+)
+
+// Analytics views give dashboards like Grafana and Metabase a stable shape to
+// chart from, independent of the nodes table's columns. They are recreated
+// each time the database is opened for writing, so they follow schema
+// changes. Costs are not stored and so are not in the views; use
+// "tapes analytics export" for them.
+//
+// tapes_turns has one row per assistant turn: hash, created_at, day
+// (YYYY-MM-DD, UTC), model, provider, project, user, agent_name, run_id,
+// stop_reason, is_error (1 for upstream errors), error_status, the token
+// counts input_tokens, output_tokens, reasoning_tokens, cache_read_tokens,
+// and cache_write_tokens, and duration_ms.
+//
+// tapes_usage_daily sums tapes_turns by day, model, provider, and project
+// into requests, errors, and the token counts.
+var analyticsViews = []struct {
+	name  string
+	query string
+}{
+	{
+		name: "tapes_turns",
+		query: `SELECT
+	hash,
+	created_at,
+	date(created_at) AS day,
+	COALESCE(model, '') AS model,
+	COALESCE(provider, '') AS provider,
+	COALESCE(project, '') AS project,
+	COALESCE("user", '') AS "user",
+	COALESCE(agent_name, '') AS agent_name,
+	COALESCE(run_id, '') AS run_id,
+	COALESCE(stop_reason, '') AS stop_reason,
+	type = 'error' AS is_error,
+	error_status,
+	COALESCE(prompt_tokens, 0) AS input_tokens,
+	COALESCE(completion_tokens, 0) AS output_tokens,
+	COALESCE(reasoning_tokens, 0) AS reasoning_tokens,
+	COALESCE(cache_read_input_tokens, 0) AS cache_read_tokens,
+	COALESCE(cache_creation_input_tokens, 0) AS cache_write_tokens,
+	total_duration_ns / 1000000 AS duration_ms
+FROM nodes
+WHERE role = 'assistant'`,
+	},
+	{
+		name: "tapes_usage_daily",
+		query: `SELECT
+	day,
+	model,
+	provider,
+	project,
+	COUNT(*) AS requests,
+	SUM(is_error) AS errors,
+	SUM(input_tokens) AS input_tokens,
+	SUM(output_tokens) AS output_tokens,
+	SUM(reasoning_tokens) AS reasoning_tokens,
+	SUM(cache_read_tokens) AS cache_read_tokens,
+	SUM(cache_write_tokens) AS cache_write_tokens
+FROM tapes_turns
+GROUP BY day, model, provider, project`,
+	},
+}
+
+// createAnalyticsViews replaces the analytics views with their current
+// definitions.
+func (d *Driver) createAnalyticsViews(ctx context.Context) error {
+	// Drop in reverse so views are dropped before the views they read.
+	for i := len(analyticsViews) - 1; i >= 0; i-- {
+		if _, err := d.db.ExecContext(ctx, "DROP VIEW IF EXISTS "+analyticsViews[i].name); err != nil {
+			return fmt.Errorf("failed to drop view %s: %w", analyticsViews[i].name, err)
+		}
+	}
+	for _, view := range analyticsViews {
+		if _, err := d.db.ExecContext(ctx, "CREATE VIEW "+view.name+" AS "+view.query); err != nil {
+			return fmt.Errorf("failed to create view %s: %w", view.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// SessionTool is a tool definition offered to the model in an agent session.
+// Definitions are stored once by hash, so that the tools of every session can
+// be compared, such as to find which definitions changed between runs.
+type SessionTool struct {
+	// Hash identifies the definition. See llm.ToolDefinition.Hash.
+	Hash string `json:"hash"`
+
+	SessionID string `json:"session_id"`
+	RunID     string `json:"run_id,omitempty"`
+	AgentName string `json:"agent_name,omitempty"`
+
+	// NodeHash is the hash of the response node of the first turn in the
+	// session the definition was offered in.
+	NodeHash string `json:"node_hash"`
+
+	Tool llm.ToolDefinition `json:"tool"`
+
+	// CreatedAt is when the session first offered the definition.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToolStore is implemented by drivers that can persist the tool definitions
+// of sessions.
+type ToolStore interface {
+	// AddSessionTools stores tool definitions offered in a session,
+	// assigning their Hash, and the CreatedAt of those it adds. Definitions
+	// the session already offered are skipped, so a session keeps the turn
+	// it first offered each one in.
+	AddSessionTools(ctx context.Context, tools []*SessionTool) error
+
+	// ListSessionTools returns the tool definitions offered in a session,
+	// in the order they were first offered.
+	ListSessionTools(ctx context.Context, sessionID string) ([]*SessionTool, error)
+}
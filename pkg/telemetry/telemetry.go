@@ -0,0 +1,145 @@
+// Package telemetry configures OpenTelemetry tracing for tapes.
+//
+// The proxy continues the W3C trace context sent by agents, so captured turns
+// appear in the agent's own traces: the proxy's span becomes the parent of the
+// upstream provider request, and the trace ID is stored on every node written
+// for the turn. Spans are exported over OTLP/HTTP only when an endpoint is
+// configured; otherwise trace context is still propagated and recorded.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+// instrumentationName identifies the tapes tracer.
+const instrumentationName = "github.com/papercomputeco/tapes"
+
+// traceParentHeader is the W3C trace context header.
+const traceParentHeader = "traceparent"
+
+// propagator reads and writes W3C trace context and baggage headers.
+var propagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// Config selects where spans are exported.
+type Config struct {
+	// Endpoint is the OTLP/HTTP collector URL, e.g. http://localhost:4318.
+	// When empty, the standard OTEL_EXPORTER_OTLP_ENDPOINT and
+	// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variables are used, and
+	// with neither set no spans are exported.
+	Endpoint string
+
+	// ServiceName is reported as the service.name resource attribute
+	// (defaults to "tapes").
+	ServiceName string
+}
+
+// Setup installs the global propagator and, when an endpoint is configured,
+// a tracer provider that exports spans over OTLP/HTTP. The returned function
+// flushes and stops the exporter.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagator)
+
+	if cfg.Endpoint == "" &&
+		os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" &&
+		os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var opts []otlptracehttp.Option
+	if cfg.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "tapes"
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(utils.Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("creating trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tapes tracer from the global tracer provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Extract returns ctx carrying the trace context read from request headers
+// with get.
+func Extract(ctx context.Context, get func(key string) string) context.Context {
+	carrier := propagation.MapCarrier{}
+	for _, key := range propagator.Fields() {
+		if value := get(key); value != "" {
+			carrier[key] = value
+		}
+	}
+	return propagator.Extract(ctx, carrier)
+}
+
+// Inject writes the trace context in ctx to set.
+func Inject(ctx context.Context, set func(key, value string)) {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	for key, value := range carrier {
+		set(key, value)
+	}
+}
+
+// TraceParent serializes the span context in ctx as a W3C traceparent value,
+// or returns "" when ctx carries no valid span context. Use it to hand trace
+// context across queues and journals.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier[traceParentHeader]
+}
+
+// ContextWithTraceParent returns ctx continuing the trace in traceParent, as
+// produced by TraceParent. An empty or malformed value returns ctx unchanged.
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{traceParentHeader: traceParent})
+}
+
+// TraceID returns the hex trace ID of the span context in ctx, or "" when
+// there is none.
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
@@ -0,0 +1,67 @@
+package telemetry_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/telemetry"
+)
+
+const (
+	traceParent = "00-4bf92f3577b34a736d9e2b3ad0e3c1a2-00f067aa0ba902b7-01"
+	traceID     = "4bf92f3577b34a736d9e2b3ad0e3c1a2"
+)
+
+var _ = Describe("Trace context", func() {
+	It("round-trips a traceparent through a context", func() {
+		ctx := telemetry.ContextWithTraceParent(context.Background(), traceParent)
+		Expect(telemetry.TraceID(ctx)).To(Equal(traceID))
+		Expect(telemetry.TraceParent(ctx)).To(Equal(traceParent))
+	})
+
+	It("ignores empty and malformed traceparents", func() {
+		for _, value := range []string{"", "not-a-traceparent"} {
+			ctx := telemetry.ContextWithTraceParent(context.Background(), value)
+			Expect(telemetry.TraceID(ctx)).To(BeEmpty())
+			Expect(telemetry.TraceParent(ctx)).To(BeEmpty())
+		}
+	})
+
+	It("extracts from and injects into headers", func() {
+		incoming := map[string]string{"traceparent": traceParent}
+		ctx := telemetry.Extract(context.Background(), func(key string) string { return incoming[key] })
+		Expect(telemetry.TraceID(ctx)).To(Equal(traceID))
+
+		outgoing := map[string]string{}
+		telemetry.Inject(ctx, func(key, value string) { outgoing[key] = value })
+		Expect(outgoing).To(HaveKeyWithValue("traceparent", traceParent))
+	})
+
+	It("continues the incoming trace in spans from the default tracer", func() {
+		ctx := telemetry.ContextWithTraceParent(context.Background(), traceParent)
+		ctx, span := telemetry.Tracer().Start(ctx, "test")
+		defer span.End()
+		Expect(telemetry.TraceID(ctx)).To(Equal(traceID))
+	})
+})
+
+var _ = Describe("Setup", func() {
+	It("exports nothing without an endpoint", func() {
+		GinkgoT().Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+		GinkgoT().Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+		shutdown, err := telemetry.Setup(context.Background(), telemetry.Config{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shutdown(context.Background())).To(Succeed())
+	})
+
+	It("creates an exporter for a configured endpoint", func() {
+		shutdown, err := telemetry.Setup(context.Background(), telemetry.Config{Endpoint: "http://127.0.0.1:1"})
+		Expect(err).NotTo(HaveOccurred())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_ = shutdown(ctx)
+	})
+})
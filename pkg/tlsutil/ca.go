@@ -0,0 +1,242 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// caValidity is how long a generated CA certificate is valid.
+	caValidity = 5 * 365 * 24 * time.Hour
+
+	// leafValidity is how long a certificate issued by a CA is valid. Issued
+	// certificates are kept in memory only, so they are short-lived.
+	leafValidity = 7 * 24 * time.Hour
+
+	caCertFileName = "ca.pem"
+	caKeyFileName  = "ca-key.pem"
+)
+
+// CA is a local certificate authority issuing certificates for the hosts a
+// forward proxy intercepts. Clients must trust its certificate, in CertFile.
+type CA struct {
+	certFile string
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+
+	// replaced is set when LoadCA generated the CA in place of another.
+	replaced bool
+
+	mu     sync.Mutex
+	issued map[string]*tls.Certificate
+}
+
+// LoadCA loads the CA kept in dir, generating it when it is missing or about
+// to expire. The CA is name constrained to hosts, so that a client trusting
+// it cannot be served certificates for any other site; a CA constrained to
+// other hosts, or not at all, is regenerated, and Replaced then reports it.
+//
+// The certificate and key are each written to a temporary file and renamed
+// into place, so that a reader never sees a partly written file.
+func LoadCA(dir string, hosts []string) (*CA, error) {
+	hosts = slices.Sorted(slices.Values(hosts))
+	certFile := filepath.Join(dir, caCertFileName)
+	keyFile := filepath.Join(dir, caKeyFileName)
+	replaced := false
+	if !valid(certFile, keyFile, time.Now()) || !constrainedTo(certFile, hosts) {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("creating CA directory: %w", err)
+		}
+		certPEM, keyPEM, err := generateCA(time.Now(), hosts)
+		if err != nil {
+			return nil, err
+		}
+		_, err = os.Stat(certFile)
+		replaced = err == nil
+		if err := writeFileAtomic(keyFile, keyPEM, 0o600); err != nil {
+			return nil, fmt.Errorf("writing CA key: %w", err)
+		}
+		if err := writeFileAtomic(certFile, certPEM, 0o644); err != nil {
+			return nil, fmt.Errorf("writing CA certificate: %w", err)
+		}
+	}
+
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading CA: %w", err)
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("parsing CA certificate: %w", err)
+	}
+	key, ok := pair.PrivateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("CA key in %s is not an ECDSA key", keyFile)
+	}
+	return &CA{
+		certFile: certFile,
+		cert:     cert,
+		key:      key,
+		replaced: replaced,
+		issued:   make(map[string]*tls.Certificate),
+	}, nil
+}
+
+// CertFile returns the path of the CA's PEM-encoded certificate.
+func (ca *CA) CertFile() string {
+	return ca.certFile
+}
+
+// Replaced reports whether LoadCA generated the CA in place of an existing
+// one. Clients that were told to trust the old certificate, such as a system
+// trust store it was installed in, reject the new one until it is trusted in
+// turn.
+func (ca *CA) Replaced() bool {
+	return ca.replaced
+}
+
+// Certificate returns a certificate for host signed by the CA, issuing it on
+// first use. It fails for hosts outside the CA's name constraints, which
+// clients would reject.
+func (ca *CA) Certificate(host string) (*tls.Certificate, error) {
+	if !slices.Contains(ca.cert.PermittedDNSDomains, host) {
+		return nil, fmt.Errorf("host %s is not permitted by the CA", host)
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	if cert, ok := ca.issued[host]; ok && time.Now().Before(cert.Leaf.NotAfter) {
+		return cert, nil
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating key for %s: %w", host, err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("generating certificate serial: %w", err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"tapes"}, CommonName: host},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(leafValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{host},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, fmt.Errorf("issuing certificate for %s: %w", host, err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate for %s: %w", host, err)
+	}
+
+	cert := &tls.Certificate{
+		Certificate: [][]byte{der, ca.cert.Raw},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+	ca.issued[host] = cert
+	return cert, nil
+}
+
+// writeFileAtomic writes data to a temporary file next to name and renames it
+// over name.
+func writeFileAtomic(name string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// constrainedTo reports whether the CA certificate in certFile is name
+// constrained to exactly hosts, which must be sorted.
+func constrainedTo(certFile string, hosts []string) bool {
+	data, err := os.ReadFile(certFile)
+	if err != nil {
+		return false
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+	return cert.PermittedDNSDomainsCritical &&
+		slices.Equal(slices.Sorted(slices.Values(cert.PermittedDNSDomains)), hosts)
+}
+
+// generateCA creates a CA certificate and key in PEM form, name constrained
+// to hosts.
+func generateCA(now time.Time, hosts []string) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating CA key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating CA certificate serial: %w", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"tapes"}, CommonName: "tapes local CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+
+		PermittedDNSDomainsCritical: true,
+		PermittedDNSDomains:         hosts,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating CA certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding CA key: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
@@ -0,0 +1,92 @@
+package tlsutil
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CA", func() {
+	var dir string
+	hosts := []string{"api.openai.com", "api.anthropic.com"}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("issues host certificates that verify against its certificate", func() {
+		ca, err := LoadCA(dir, hosts)
+		Expect(err).NotTo(HaveOccurred())
+
+		caPEM, err := os.ReadFile(ca.CertFile())
+		Expect(err).NotTo(HaveOccurred())
+		roots := x509.NewCertPool()
+		Expect(roots.AppendCertsFromPEM(caPEM)).To(BeTrue())
+
+		cert, err := ca.Certificate("api.openai.com")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cert.Leaf.Verify(x509.VerifyOptions{DNSName: "api.openai.com", Roots: roots})
+		Expect(err).NotTo(HaveOccurred())
+
+		again, err := ca.Certificate("api.openai.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(BeIdenticalTo(cert))
+	})
+
+	It("keeps the CA across loads", func() {
+		first, err := LoadCA(dir, hosts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first.Replaced()).To(BeFalse())
+		second, err := LoadCA(dir, hosts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second.cert.Raw).To(Equal(first.cert.Raw))
+		Expect(second.Replaced()).To(BeFalse())
+	})
+
+	It("writes its files without leaving temporary files behind", func() {
+		_, err := LoadCA(dir, hosts)
+		Expect(err).NotTo(HaveOccurred())
+
+		entries, err := os.ReadDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		names := []string{}
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		Expect(names).To(ConsistOf(caCertFileName, caKeyFileName))
+
+		info, err := os.Stat(filepath.Join(dir, caKeyFileName))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+	})
+
+	It("is name constrained to its hosts", func() {
+		ca, err := LoadCA(dir, hosts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ca.cert.PermittedDNSDomainsCritical).To(BeTrue())
+		Expect(ca.cert.PermittedDNSDomains).To(ConsistOf(hosts))
+
+		_, err = ca.Certificate("example.com")
+		Expect(err).To(MatchError(ContainSubstring("not permitted")))
+	})
+
+	It("regenerates a CA that is not constrained to its hosts", func() {
+		certPEM, keyPEM, err := generateCA(time.Now(), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(dir, caCertFileName), certPEM, 0o600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, caKeyFileName), keyPEM, 0o600)).To(Succeed())
+
+		ca, err := LoadCA(dir, hosts)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ca.cert.PermittedDNSDomains).To(ConsistOf(hosts))
+		Expect(ca.Replaced()).To(BeTrue())
+
+		narrower, err := LoadCA(dir, hosts[:1])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(narrower.cert.PermittedDNSDomains).To(Equal(hosts[:1]))
+	})
+})
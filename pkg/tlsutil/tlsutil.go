@@ -0,0 +1,196 @@
+// Package tlsutil builds the TLS configuration of the proxy and API
+// listeners. Certificates are loaded from files, or generated and self-signed
+// when none are provided, and client certificates can be required for mutual
+// TLS. A local CA issues the certificates of the hosts a forward proxy
+// intercepts.
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// selfSignedValidity is how long a generated certificate is valid.
+	selfSignedValidity = 365 * 24 * time.Hour
+
+	// renewBefore regenerates a self-signed certificate this long before it
+	// expires.
+	renewBefore = 30 * 24 * time.Hour
+
+	certFileName = "cert.pem"
+	keyFileName  = "key.pem"
+)
+
+// Options configures the server TLS configuration.
+type Options struct {
+	// CertFile and KeyFile are PEM-encoded certificate and key files. When
+	// both are empty, a self-signed certificate is generated in Dir.
+	CertFile string
+	KeyFile  string
+
+	// Dir is where the self-signed certificate and its key are kept, so that
+	// clients which trust it keep working across restarts.
+	Dir string
+
+	// ClientCAFile optionally holds PEM-encoded CA certificates. When set,
+	// clients must present a certificate signed by one of them.
+	ClientCAFile string
+}
+
+// ServerConfig returns the TLS configuration for a listener.
+func ServerConfig(opts Options) (*tls.Config, error) {
+	certFile, keyFile := opts.CertFile, opts.KeyFile
+	switch {
+	case certFile == "" && keyFile == "":
+		if opts.Dir == "" {
+			return nil, errors.New("no TLS certificate configured and no directory for a self-signed one")
+		}
+		var err error
+		certFile, keyFile, err = SelfSigned(opts.Dir)
+		if err != nil {
+			return nil, err
+		}
+	case certFile == "" || keyFile == "":
+		return nil, errors.New("TLS certificate and key must be set together")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS certificate: %w", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if opts.ClientCAFile != "" {
+		caPEM, err := os.ReadFile(opts.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in client CA %s", opts.ClientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// SelfSigned returns the paths of a self-signed certificate and key in dir,
+// generating them when they are missing or about to expire. The certificate
+// covers localhost, the host name, and the addresses of the host's network
+// interfaces.
+func SelfSigned(dir string) (certFile, keyFile string, err error) {
+	certFile = filepath.Join(dir, certFileName)
+	keyFile = filepath.Join(dir, keyFileName)
+	if valid(certFile, keyFile, time.Now()) {
+		return certFile, keyFile, nil
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", "", fmt.Errorf("creating TLS directory: %w", err)
+	}
+
+	certPEM, keyPEM, err := generate(time.Now())
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0o600); err != nil {
+		return "", "", fmt.Errorf("writing TLS key: %w", err)
+	}
+	if err := os.WriteFile(certFile, certPEM, 0o644); err != nil { //nolint:gosec // certificates are public
+		return "", "", fmt.Errorf("writing TLS certificate: %w", err)
+	}
+	return certFile, keyFile, nil
+}
+
+// valid reports whether an existing key pair can be reused.
+func valid(certFile, keyFile string, now time.Time) bool {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return false
+	}
+	return now.Add(renewBefore).Before(cert.NotAfter)
+}
+
+// generate creates a self-signed ECDSA certificate and key in PEM form.
+func generate(now time.Time) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating TLS key: %w", err)
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating TLS certificate serial: %w", err)
+	}
+
+	dnsNames, ips := hostNames()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"tapes"}, CommonName: "tapes"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              dnsNames,
+		IPAddresses:           ips,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating TLS certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding TLS key: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
+
+// hostNames returns the names and addresses a self-signed certificate covers.
+func hostNames() ([]string, []net.IP) {
+	dnsNames := []string{"localhost"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" && hostname != "localhost" {
+		dnsNames = append(dnsNames, hostname)
+	}
+
+	ips := []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return dnsNames, ips
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		ips = append(ips, ipNet.IP)
+	}
+	return dnsNames, ips
+}
@@ -0,0 +1,13 @@
+package tlsutil
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestTLSUtil(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "TLS Util Suite")
+}
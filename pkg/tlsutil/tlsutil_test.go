@@ -0,0 +1,62 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServerConfig", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("generates a self-signed certificate and reuses it", func() {
+		cfg, err := ServerConfig(Options{Dir: dir})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Certificates).To(HaveLen(1))
+		Expect(cfg.ClientAuth).To(Equal(tls.NoClientCert))
+
+		cert, err := x509.ParseCertificate(cfg.Certificates[0].Certificate[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cert.DNSNames).To(ContainElement("localhost"))
+		Expect(cert.VerifyHostname("127.0.0.1")).To(Succeed())
+
+		info, err := os.Stat(filepath.Join(dir, keyFileName))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+
+		again, err := ServerConfig(Options{Dir: dir})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again.Certificates[0].Certificate[0]).To(Equal(cfg.Certificates[0].Certificate[0]))
+	})
+
+	It("loads a provided certificate and requires client certificates", func() {
+		certFile, keyFile, err := SelfSigned(dir)
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg, err := ServerConfig(Options{CertFile: certFile, KeyFile: keyFile, ClientCAFile: certFile})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.ClientAuth).To(Equal(tls.RequireAndVerifyClientCert))
+		Expect(cfg.ClientCAs).NotTo(BeNil())
+	})
+
+	It("rejects a certificate without its key", func() {
+		_, err := ServerConfig(Options{CertFile: filepath.Join(dir, "cert.pem")})
+		Expect(err).To(MatchError(ContainSubstring("must be set together")))
+	})
+
+	It("rejects a client CA file without certificates", func() {
+		caFile := filepath.Join(dir, "ca.pem")
+		Expect(os.WriteFile(caFile, []byte("not a certificate"), 0o600)).To(Succeed())
+
+		_, err := ServerConfig(Options{Dir: dir, ClientCAFile: caFile})
+		Expect(err).To(MatchError(ContainSubstring("no certificates found")))
+	})
+})
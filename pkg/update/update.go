@@ -0,0 +1,290 @@
+// Package update replaces the running tapes binary with the latest GitHub
+// release.
+//
+// Every release publishes, per platform, the binary, a SHA-256 checksum file,
+// and an Ed25519 signature of that checksum file. The checksum file names the
+// binary tapes-<tag>-<os>-<arch>, so the signature covers the release and
+// platform it was made for. A downloaded binary is only installed when the
+// signature verifies against the release public key compiled into tapes, the
+// checksum file names the requested release and platform, and the binary
+// matches the checksum.
+package update
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// DefaultAPIURL is the GitHub API endpoint for the latest tapes release.
+	DefaultAPIURL = "https://api.github.com/repos/papercomputeco/tapes/releases/latest"
+
+	// DefaultDownloadURL is the base URL of tapes release assets.
+	DefaultDownloadURL = "https://github.com/papercomputeco/tapes/releases/download"
+
+	// maxBinaryBytes bounds the size of a downloaded binary.
+	maxBinaryBytes = 512 * 1024 * 1024
+)
+
+// PublicKey is the base64 Ed25519 public key release checksums are signed
+// with. Release builds set it with -ldflags; development builds leave it empty
+// and cannot update themselves.
+var PublicKey = ""
+
+var (
+	// ErrNoPublicKey is returned when tapes was built without a release
+	// signing key.
+	ErrNoPublicKey = errors.New("this build of tapes has no release signing key")
+
+	// ErrBadSignature is returned when a checksum signature does not verify.
+	ErrBadSignature = errors.New("release checksum signature is invalid")
+
+	// ErrChecksumMismatch is returned when a downloaded binary does not match
+	// its checksum.
+	ErrChecksumMismatch = errors.New("downloaded binary does not match its checksum")
+)
+
+// Updater downloads and verifies tapes releases.
+type Updater struct {
+	// Client fetches releases (defaults to a client with a timeout).
+	Client *http.Client
+
+	// APIURL returns the latest release as GitHub API JSON (defaults to
+	// DefaultAPIURL).
+	APIURL string
+
+	// DownloadURL is the base URL of release assets, followed by
+	// /<tag>/<asset> (defaults to DefaultDownloadURL).
+	DownloadURL string
+
+	// PublicKey verifies checksum signatures (defaults to the decoded
+	// PublicKey variable).
+	PublicKey ed25519.PublicKey
+
+	// GOOS and GOARCH select the release asset (default to the running
+	// platform).
+	GOOS   string
+	GOARCH string
+}
+
+// NewUpdater creates an Updater for the official releases of the running
+// platform.
+func NewUpdater() (*Updater, error) {
+	if PublicKey == "" {
+		return nil, ErrNoPublicKey
+	}
+	key, err := base64.StdEncoding.DecodeString(PublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("decoding release signing key: invalid Ed25519 public key")
+	}
+
+	return &Updater{
+		Client:      &http.Client{Timeout: 5 * time.Minute},
+		APIURL:      DefaultAPIURL,
+		DownloadURL: DefaultDownloadURL,
+		PublicKey:   key,
+		GOOS:        runtime.GOOS,
+		GOARCH:      runtime.GOARCH,
+	}, nil
+}
+
+// Latest returns the tag of the latest release, such as "v0.4.0".
+func (u *Updater) Latest(ctx context.Context) (string, error) {
+	body, err := u.get(ctx, u.APIURL, 1024*1024)
+	if err != nil {
+		return "", fmt.Errorf("checking latest release: %w", err)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.Unmarshal(body, &release); err != nil {
+		return "", fmt.Errorf("decoding latest release: %w", err)
+	}
+	if !semver.IsValid(release.TagName) {
+		return "", fmt.Errorf("latest release has an invalid tag %q", release.TagName)
+	}
+	return release.TagName, nil
+}
+
+// Download fetches the binary of the release tagged tag and verifies it
+// against its signed checksum.
+func (u *Updater) Download(ctx context.Context, tag string) ([]byte, error) {
+	asset := fmt.Sprintf("tapes-%s-%s", u.GOOS, u.GOARCH)
+	base := strings.TrimRight(u.DownloadURL, "/") + "/" + tag + "/" + asset
+
+	sums, err := u.get(ctx, base+".sha256", 64*1024)
+	if err != nil {
+		return nil, fmt.Errorf("downloading checksum: %w", err)
+	}
+	sig, err := u.get(ctx, base+".sha256.sig", 64*1024)
+	if err != nil {
+		return nil, fmt.Errorf("downloading checksum signature: %w", err)
+	}
+	if err := u.verifySignature(sums, sig); err != nil {
+		return nil, err
+	}
+
+	want, err := parseChecksum(sums, fmt.Sprintf("tapes-%s-%s-%s", tag, u.GOOS, u.GOARCH))
+	if err != nil {
+		return nil, err
+	}
+
+	binary, err := u.get(ctx, base, maxBinaryBytes)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", asset, err)
+	}
+	got := sha256.Sum256(binary)
+	if hex.EncodeToString(got[:]) != want {
+		return nil, ErrChecksumMismatch
+	}
+	return binary, nil
+}
+
+// verifySignature checks sig over the checksum file. Signatures are accepted
+// raw or base64 encoded.
+func (u *Updater) verifySignature(sums, sig []byte) error {
+	if len(u.PublicKey) != ed25519.PublicKeySize {
+		return ErrNoPublicKey
+	}
+	if len(sig) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+		if err != nil {
+			return ErrBadSignature
+		}
+		sig = decoded
+	}
+	if !ed25519.Verify(u.PublicKey, sums, sig) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// parseChecksum returns the hex digest of a sha256sum line for the file
+// name. A checksum for any other file, such as the binary of an older release
+// replayed with its valid signature, is rejected.
+func parseChecksum(sums []byte, name string) (string, error) {
+	fields := strings.Fields(string(sums))
+	if len(fields) == 0 {
+		return "", errors.New("checksum file is empty")
+	}
+	if len(fields) != 2 {
+		return "", errors.New("checksum file must hold a single checksum")
+	}
+	digest := strings.ToLower(fields[0])
+	if _, err := hex.DecodeString(digest); err != nil || len(digest) != sha256.Size*2 {
+		return "", fmt.Errorf("checksum file has an invalid digest %q", fields[0])
+	}
+	// sha256sum marks files read in binary mode with a leading "*".
+	if got := strings.TrimPrefix(fields[1], "*"); got != name {
+		return "", fmt.Errorf("checksum file is for %q, not %q", got, name)
+	}
+	return digest, nil
+}
+
+func (u *Updater) get(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := u.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s is larger than %d bytes", url, limit)
+	}
+	return data, nil
+}
+
+// Newer reports whether the release tagged latest is newer than the current
+// version. Versions that are not semantic versions, such as "dev" and
+// "nightly", are never considered older.
+func Newer(current, latest string) bool {
+	if !semver.IsValid(current) || !semver.IsValid(latest) {
+		return false
+	}
+	return semver.Compare(latest, current) > 0
+}
+
+// Replace atomically replaces the executable at path with binary. The new
+// binary is written next to the old one and renamed over it, so the
+// executable is never left half written. On Windows, where a running
+// executable cannot be overwritten, the old binary is first moved aside to
+// path + ".old".
+func Replace(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("reading current binary: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tapes-update-*")
+	if err != nil {
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(binary); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	// #nosec G302 -- the binary must stay executable.
+	if err := os.Chmod(tmpPath, info.Mode().Perm()|0o111); err != nil {
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+
+	if runtime.GOOS != "windows" {
+		if err := os.Rename(tmpPath, path); err != nil {
+			return fmt.Errorf("replacing binary: %w", err)
+		}
+		return nil
+	}
+
+	old := path + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(path, old); err != nil {
+		return fmt.Errorf("moving current binary aside: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Rename(old, path)
+		return fmt.Errorf("replacing binary: %w", err)
+	}
+	return nil
+}
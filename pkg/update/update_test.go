@@ -0,0 +1,147 @@
+package update_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/update"
+)
+
+var _ = Describe("Newer", func() {
+	DescribeTable("compares release tags",
+		func(current, latest string, expected bool) {
+			Expect(update.Newer(current, latest)).To(Equal(expected))
+		},
+		Entry("newer patch", "v0.3.1", "v0.3.2", true),
+		Entry("newer minor", "v0.3.9", "v0.10.0", true),
+		Entry("same version", "v0.3.1", "v0.3.1", false),
+		Entry("older release", "v0.4.0", "v0.3.1", false),
+		Entry("development build", "dev", "v0.3.1", false),
+		Entry("nightly build", "nightly", "v0.3.1", false),
+	)
+})
+
+var _ = Describe("Updater", func() {
+	const tag = "v0.4.0"
+
+	var (
+		ctx     context.Context
+		binary  []byte
+		sums    []byte
+		sig     []byte
+		priv    ed25519.PrivateKey
+		updater *update.Updater
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		pub, key, err := ed25519.GenerateKey(nil)
+		Expect(err).NotTo(HaveOccurred())
+		priv = key
+
+		binary = []byte("new tapes binary")
+		digest := sha256.Sum256(binary)
+		sums = []byte(hex.EncodeToString(digest[:]) + "  tapes-" + tag + "-linux-amd64\n")
+		sig = []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, sums)))
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/latest", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"tag_name":"` + tag + `"}`))
+		})
+		mux.HandleFunc("/download/"+tag+"/tapes-linux-amd64", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(binary)
+		})
+		mux.HandleFunc("/download/"+tag+"/tapes-linux-amd64.sha256", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(sums)
+		})
+		mux.HandleFunc("/download/"+tag+"/tapes-linux-amd64.sha256.sig", func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write(sig)
+		})
+		server := httptest.NewServer(mux)
+		DeferCleanup(server.Close)
+
+		updater = &update.Updater{
+			APIURL:      server.URL + "/latest",
+			DownloadURL: server.URL + "/download",
+			PublicKey:   pub,
+			GOOS:        "linux",
+			GOARCH:      "amd64",
+		}
+	})
+
+	It("finds the latest release", func() {
+		latest, err := updater.Latest(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(latest).To(Equal(tag))
+	})
+
+	It("downloads a binary with a valid signed checksum", func() {
+		data, err := updater.Download(ctx, tag)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal(binary))
+	})
+
+	It("rejects a checksum signed by another key", func() {
+		otherPub, _, err := ed25519.GenerateKey(nil)
+		Expect(err).NotTo(HaveOccurred())
+		updater.PublicKey = otherPub
+
+		_, err = updater.Download(ctx, tag)
+		Expect(err).To(MatchError(update.ErrBadSignature))
+	})
+
+	It("rejects a binary that does not match its checksum", func() {
+		binary = []byte("tampered binary")
+
+		_, err := updater.Download(ctx, tag)
+		Expect(err).To(MatchError(update.ErrChecksumMismatch))
+	})
+
+	It("rejects a signed checksum of another release", func() {
+		digest := sha256.Sum256(binary)
+		sums = []byte(hex.EncodeToString(digest[:]) + "  tapes-v0.3.0-linux-amd64\n")
+		sig = []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, sums)))
+
+		_, err := updater.Download(ctx, tag)
+		Expect(err).To(MatchError(ContainSubstring(`checksum file is for "tapes-v0.3.0-linux-amd64"`)))
+	})
+
+	It("fails when the release has no binary for the platform", func() {
+		updater.GOARCH = "riscv64"
+
+		_, err := updater.Download(ctx, tag)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Replace", func() {
+	It("swaps the binary in place and keeps it executable", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "tapes")
+		Expect(os.WriteFile(path, []byte("old"), 0o755)).To(Succeed())
+
+		Expect(update.Replace(path, []byte("new"))).To(Succeed())
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("new"))
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm() & 0o100).NotTo(BeZero())
+
+		entries, err := os.ReadDir(filepath.Dir(path))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseDuration parses a duration like time.ParseDuration, additionally
+// accepting whole days ("30d") and weeks ("2w").
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("empty duration")
+	}
+
+	unit := map[byte]time.Duration{'d': 24 * time.Hour, 'w': 7 * 24 * time.Hour}[s[len(s)-1]]
+	if unit == 0 {
+		return time.ParseDuration(s)
+	}
+
+	n, err := strconv.ParseUint(s[:len(s)-1], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// sizeUnits are the byte size suffixes accepted by ParseSize, longest first so
+// that "MB" is matched before "B".
+var sizeUnits = []struct {
+	suffix string
+	factor int64
+}{
+	{"KIB", 1 << 10}, {"MIB", 1 << 20}, {"GIB", 1 << 30}, {"TIB", 1 << 40},
+	{"KB", 1000}, {"MB", 1000 * 1000}, {"GB", 1000 * 1000 * 1000}, {"TB", 1000 * 1000 * 1000 * 1000},
+	{"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30}, {"T", 1 << 40},
+	{"B", 1},
+}
+
+// ParseSize parses a byte size such as "1048576", "500MB", or "2GiB".
+// Decimal (KB, MB) and binary (KiB, MiB, or the bare K, M) units are accepted.
+func ParseSize(s string) (int64, error) {
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	if upper == "" {
+		return 0, errors.New("empty size")
+	}
+
+	factor := int64(1)
+	for _, u := range sizeUnits {
+		if trimmed, ok := strings.CutSuffix(upper, u.suffix); ok {
+			upper = strings.TrimSpace(trimmed)
+			factor = u.factor
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(upper, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(n * float64(factor)), nil
+}
+
+// FormatSize renders a byte count with a binary unit, e.g. "1.5 MiB".
+func FormatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseDuration", func() {
+	DescribeTable("parses durations",
+		func(input string, expected time.Duration) {
+			d, err := ParseDuration(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d).To(Equal(expected))
+		},
+		Entry("days", "30d", 30*24*time.Hour),
+		Entry("weeks", "2w", 14*24*time.Hour),
+		Entry("Go durations", "90m", 90*time.Minute),
+	)
+
+	It("rejects invalid durations", func() {
+		for _, input := range []string{"", "d", "-1d", "soon"} {
+			_, err := ParseDuration(input)
+			Expect(err).To(HaveOccurred(), input)
+		}
+	})
+})
+
+var _ = Describe("ParseSize", func() {
+	DescribeTable("parses sizes",
+		func(input string, expected int64) {
+			n, err := ParseSize(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(expected))
+		},
+		Entry("bytes", "1048576", int64(1048576)),
+		Entry("decimal units", "500MB", int64(500_000_000)),
+		Entry("binary units", "2GiB", int64(2<<30)),
+		Entry("short binary units", "1.5k", int64(1536)),
+		Entry("explicit bytes", "10 B", int64(10)),
+	)
+
+	It("rejects invalid sizes", func() {
+		for _, input := range []string{"", "MB", "-1MB", "lots"} {
+			_, err := ParseSize(input)
+			Expect(err).To(HaveOccurred(), input)
+		}
+	})
+})
+
+var _ = Describe("FormatSize", func() {
+	DescribeTable("formats sizes",
+		func(input int64, expected string) {
+			Expect(FormatSize(input)).To(Equal(expected))
+		},
+		Entry("bytes", int64(512), "512 B"),
+		Entry("kibibytes", int64(1536), "1.5 KiB"),
+		Entry("mebibytes", int64(3<<20), "3.0 MiB"),
+		Entry("gibibytes", int64(2<<30), "2.0 GiB"),
+	)
+})
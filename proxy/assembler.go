@@ -0,0 +1,294 @@
+package proxy
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// streamAssembler buffers the chunks of a single streamed response and
+// reassembles the structured content that streaming splits apart: text deltas,
+// tool-call argument fragments, and usage spread across events.
+//
+// The assembled content mirrors what each provider's ParseResponse produces for
+// the equivalent non-streamed response, so streamed and non-streamed turns are
+// stored as identical Merkle nodes.
+type streamAssembler struct {
+	provider string
+
+	// chunks are the raw data payloads in arrival order
+	chunks [][]byte
+
+	// content is the concatenated text of all text deltas
+	content strings.Builder
+
+	usage llm.Usage
+	meta  streamMeta
+
+	// firstChunkAt is when the first data payload arrived
+	firstChunkAt time.Time
+
+	// blocks are the structured content blocks keyed by the provider's block
+	// or tool-call index. Text blocks are only tracked for Anthropic, which
+	// indexes every block; other providers carry text outside of blocks.
+	blocks map[int]*assembledBlock
+
+	// sawText records whether any OpenAI text delta arrived, since the
+	// non-streamed parser emits a text block even for empty content strings.
+	sawText bool
+}
+
+// assembledBlock is a content block under construction.
+type assembledBlock struct {
+	block llm.ContentBlock
+
+	// args accumulates streamed tool-call argument JSON fragments
+	args strings.Builder
+}
+
+func newStreamAssembler(providerName string) *streamAssembler {
+	return &streamAssembler{
+		provider: providerName,
+		blocks:   make(map[int]*assembledBlock),
+	}
+}
+
+// add records a single data payload from the stream.
+func (a *streamAssembler) add(data []byte) {
+	if a.firstChunkAt.IsZero() {
+		a.firstChunkAt = time.Now()
+	}
+
+	chunkCopy := make([]byte, len(data))
+	copy(chunkCopy, data)
+	a.chunks = append(a.chunks, chunkCopy)
+
+	var chunk map[string]any
+	if err := json.Unmarshal(data, &chunk); err != nil {
+		return
+	}
+
+	switch a.provider {
+	case providerAnthropic:
+		a.addAnthropic(chunk)
+	case providerOpenAI:
+		a.addOpenAI(chunk)
+	case providerOllama:
+		a.addOllama(chunk)
+	}
+}
+
+// addAnthropic tracks content_block_start and content_block_delta events.
+// Each block is announced with its type (and tool id/name) and then filled by
+// text_delta, thinking_delta, signature_delta, or input_json_delta fragments.
+func (a *streamAssembler) addAnthropic(chunk map[string]any) {
+	index := jsonInt(chunk, "index")
+
+	switch chunk["type"] {
+	case "content_block_start":
+		cb, ok := chunk["content_block"].(map[string]any)
+		if !ok {
+			return
+		}
+		blockType, _ := cb["type"].(string)
+		ab := &assembledBlock{block: llm.ContentBlock{Type: blockType}}
+		if blockType == "tool_use" {
+			ab.block.ToolUseID, _ = cb["id"].(string)
+			ab.block.ToolName, _ = cb["name"].(string)
+			if input, ok := cb["input"].(map[string]any); ok && len(input) > 0 {
+				ab.block.ToolInput = input
+			}
+		}
+		if text, ok := cb["text"].(string); ok && blockType == "text" {
+			ab.block.Text = text
+		}
+		if thinking, ok := cb["thinking"].(string); ok && blockType == "thinking" {
+			ab.block.Thinking = thinking
+		}
+		if data, ok := cb["data"].(string); ok && blockType == "redacted_thinking" {
+			ab.block.Signature = data
+		}
+		a.blocks[index] = ab
+
+	case "content_block_delta":
+		ab, ok := a.blocks[index]
+		if !ok {
+			return
+		}
+		delta, ok := chunk["delta"].(map[string]any)
+		if !ok {
+			return
+		}
+		switch delta["type"] {
+		case "text_delta":
+			text, _ := delta["text"].(string)
+			ab.block.Text += text
+		case "thinking_delta":
+			thinking, _ := delta["thinking"].(string)
+			ab.block.Thinking += thinking
+		case "signature_delta":
+			signature, _ := delta["signature"].(string)
+			ab.block.Signature += signature
+		case "input_json_delta":
+			fragment, _ := delta["partial_json"].(string)
+			ab.args.WriteString(fragment)
+		}
+	}
+}
+
+// addOpenAI tracks choices[0].delta.tool_calls fragments, which arrive keyed by
+// index with the id and function name in the first fragment and the arguments
+// spread across the rest.
+func (a *streamAssembler) addOpenAI(chunk map[string]any) {
+	choices, ok := chunk["choices"].([]any)
+	if !ok || len(choices) == 0 {
+		return
+	}
+	choice, ok := choices[0].(map[string]any)
+	if !ok {
+		return
+	}
+
+	if reason, ok := choice["finish_reason"].(string); ok && reason != "" {
+		a.meta.StopReason = reason
+	}
+
+	delta, ok := choice["delta"].(map[string]any)
+	if !ok {
+		return
+	}
+	if _, ok := delta["content"].(string); ok {
+		a.sawText = true
+	}
+
+	toolCalls, _ := delta["tool_calls"].([]any)
+	for _, raw := range toolCalls {
+		tc, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		index := jsonInt(tc, "index")
+		ab, ok := a.blocks[index]
+		if !ok {
+			ab = &assembledBlock{block: llm.ContentBlock{Type: "tool_use"}}
+			a.blocks[index] = ab
+		}
+		if id, ok := tc["id"].(string); ok && id != "" {
+			ab.block.ToolUseID = id
+		}
+		if fn, ok := tc["function"].(map[string]any); ok {
+			if name, ok := fn["name"].(string); ok && name != "" {
+				ab.block.ToolName = name
+			}
+			if args, ok := fn["arguments"].(string); ok {
+				ab.args.WriteString(args)
+			}
+		}
+	}
+}
+
+// addOllama tracks message.tool_calls, which Ollama sends whole rather than as
+// fragments.
+func (a *streamAssembler) addOllama(chunk map[string]any) {
+	msg, ok := chunk["message"].(map[string]any)
+	if !ok {
+		return
+	}
+
+	toolCalls, _ := msg["tool_calls"].([]any)
+	for _, raw := range toolCalls {
+		tc, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		cb := llm.ContentBlock{Type: "tool_use"}
+		cb.ToolUseID, _ = tc["id"].(string)
+		if fn, ok := tc["function"].(map[string]any); ok {
+			cb.ToolName, _ = fn["name"].(string)
+			cb.ToolInput, _ = fn["arguments"].(map[string]any)
+		}
+		a.blocks[len(a.blocks)] = &assembledBlock{block: cb}
+	}
+}
+
+// hasBlocks reports whether any structured content was assembled.
+func (a *streamAssembler) hasBlocks() bool {
+	return len(a.blocks) > 0
+}
+
+// contentBlocks returns the assembled message content in the same order and
+// shape the provider's non-streamed parser produces.
+func (a *streamAssembler) contentBlocks() []llm.ContentBlock {
+	indexes := make([]int, 0, len(a.blocks))
+	for i := range a.blocks {
+		indexes = append(indexes, i)
+	}
+	slices.Sort(indexes)
+
+	var content []llm.ContentBlock
+	switch a.provider {
+	case providerOpenAI:
+		if a.sawText {
+			content = append(content, llm.ContentBlock{Type: "text", Text: a.content.String()})
+		}
+	case providerOllama:
+		if text := a.content.String(); text != "" {
+			content = append(content, llm.ContentBlock{Type: "text", Text: text})
+		}
+	}
+
+	for _, i := range indexes {
+		ab := a.blocks[i]
+		cb := ab.block
+		if cb.Type == "tool_use" && ab.args.Len() > 0 {
+			var input map[string]any
+			if err := json.Unmarshal([]byte(ab.args.String()), &input); err != nil {
+				// Mirror the non-streamed OpenAI parser, which drops tool calls
+				// whose arguments are not valid JSON objects.
+				if a.provider == providerOpenAI {
+					continue
+				}
+			} else {
+				cb.ToolInput = input
+			}
+		}
+		content = append(content, cb)
+	}
+
+	return content
+}
+
+// apply replaces the content of a response reconstructed from text deltas with
+// the assembled structured content. A nil response is created when the stream
+// carried only structured content, such as a tool-call-only turn.
+func (a *streamAssembler) apply(resp *llm.ChatResponse) *llm.ChatResponse {
+	if !a.hasBlocks() {
+		return resp
+	}
+
+	if resp == nil {
+		resp = &llm.ChatResponse{
+			Model: a.meta.Model,
+			Done:  true,
+		}
+		if a.usage.PromptTokens > 0 || a.usage.CompletionTokens > 0 {
+			usage := a.usage
+			usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+			resp.Usage = &usage
+		}
+	}
+
+	if resp.Message.Role == "" {
+		resp.Message.Role = "assistant"
+	}
+	resp.Message.Content = a.contentBlocks()
+
+	if resp.StopReason == "" {
+		resp.StopReason = a.meta.StopReason
+	}
+
+	return resp
+}
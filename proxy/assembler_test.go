@@ -0,0 +1,198 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+// assemble feeds each chunk through the same path the stream handlers use.
+func assemble(p *Proxy, providerName string, chunks []string) *streamAssembler {
+	asm := newStreamAssembler(providerName)
+	for _, chunk := range chunks {
+		asm.add([]byte(chunk))
+		p.extractContentFromJSON([]byte(chunk), providerName, &asm.content)
+		p.extractUsageFromSSE([]byte(chunk), providerName, &asm.usage, &asm.meta)
+	}
+	return asm
+}
+
+// parsedContent returns the content blocks the provider's non-streamed parser
+// produces for payload.
+func parsedContent(providerName, payload string) any {
+	prov, err := provider.New(providerName)
+	Expect(err).NotTo(HaveOccurred())
+	resp, err := prov.ParseResponse([]byte(payload))
+	Expect(err).NotTo(HaveOccurred())
+	return resp.Message.Content
+}
+
+var anthropicToolStream = []string{
+	`{"type":"message_start","message":{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-5","content":[],"usage":{"input_tokens":12,"output_tokens":1}}}`,
+	`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+	`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Let me "}}`,
+	`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"check."}}`,
+	`{"type":"content_block_stop","index":0}`,
+	`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"read_file","input":{}}}`,
+	`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"path\":"}}`,
+	`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"\"main.go\"}"}}`,
+	`{"type":"content_block_stop","index":1}`,
+	`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":20}}`,
+	`{"type":"message_stop"}`,
+}
+
+const anthropicToolResponse = `{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-5",` +
+	`"content":[{"type":"text","text":"Let me check."},{"type":"tool_use","id":"toolu_1","name":"read_file","input":{"path":"main.go"}}],` +
+	`"stop_reason":"tool_use","usage":{"input_tokens":12,"output_tokens":20}}`
+
+var _ = Describe("streamAssembler", func() {
+	var p *Proxy
+
+	BeforeEach(func() {
+		p, _ = newTestProxy("http://localhost:0")
+	})
+
+	AfterEach(func() {
+		p.Close()
+	})
+
+	It("reassembles Anthropic text and tool_use argument fragments", func() {
+		asm := assemble(p, providerAnthropic, anthropicToolStream)
+		resp := asm.apply(p.reconstructStreamedResponse(asm.chunks, asm.content.String(), &asm.usage, &asm.meta, mustProvider(providerAnthropic)))
+
+		Expect(resp.Message.Role).To(Equal("assistant"))
+		Expect(resp.Message.Content).To(Equal(parsedContent(providerAnthropic, anthropicToolResponse)))
+		Expect(resp.StopReason).To(Equal("tool_use"))
+		Expect(resp.Model).To(Equal("claude-sonnet-4-5"))
+		Expect(resp.Usage.PromptTokens).To(Equal(12))
+		Expect(resp.Usage.CompletionTokens).To(Equal(20))
+	})
+
+	It("reassembles Anthropic thinking and signature fragments", func() {
+		chunks := []string{
+			`{"type":"message_start","message":{"id":"msg_2","type":"message","role":"assistant","model":"claude-sonnet-4-5","content":[],"usage":{"input_tokens":8,"output_tokens":1}}}`,
+			`{"type":"content_block_start","index":0,"content_block":{"type":"thinking","thinking":""}}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"thinking_delta","thinking":"Six times "}}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"thinking_delta","thinking":"seven."}}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"signature_delta","signature":"sig_abc"}}`,
+			`{"type":"content_block_stop","index":0}`,
+			`{"type":"content_block_start","index":1,"content_block":{"type":"text","text":""}}`,
+			`{"type":"content_block_delta","index":1,"delta":{"type":"text_delta","text":"42"}}`,
+			`{"type":"content_block_stop","index":1}`,
+			`{"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":30}}`,
+		}
+		nonStreamed := `{"id":"msg_2","type":"message","role":"assistant","model":"claude-sonnet-4-5",` +
+			`"content":[{"type":"thinking","thinking":"Six times seven.","signature":"sig_abc"},{"type":"text","text":"42"}],"stop_reason":"end_turn"}`
+
+		asm := assemble(p, providerAnthropic, chunks)
+		resp := asm.apply(p.reconstructStreamedResponse(asm.chunks, asm.content.String(), &asm.usage, &asm.meta, mustProvider(providerAnthropic)))
+
+		Expect(resp.Message.Content).To(Equal(parsedContent(providerAnthropic, nonStreamed)))
+		Expect(resp.Message.GetText()).To(Equal("42"))
+	})
+
+	It("reassembles OpenAI tool_calls argument fragments", func() {
+		chunks := []string{
+			`{"id":"c1","model":"gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","content":null,"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":""}}]}}]}`,
+			`{"id":"c1","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"city\":"}}]}}]}`,
+			`{"id":"c1","model":"gpt-4o","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Oslo\"}"}}]}}]}`,
+			`{"id":"c1","model":"gpt-4o","choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`,
+			`{"id":"c1","model":"gpt-4o","choices":[],"usage":{"prompt_tokens":30,"completion_tokens":9,"total_tokens":39}}`,
+		}
+		nonStreamed := `{"id":"c1","model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":null,` +
+			`"tool_calls":[{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Oslo\"}"}}]},"finish_reason":"tool_calls"}]}`
+
+		asm := assemble(p, providerOpenAI, chunks)
+		resp := asm.apply(p.reconstructStreamedResponse(asm.chunks, asm.content.String(), &asm.usage, &asm.meta, mustProvider(providerOpenAI)))
+
+		Expect(resp.Message.Content).To(Equal(parsedContent(providerOpenAI, nonStreamed)))
+		Expect(resp.StopReason).To(Equal("tool_calls"))
+		Expect(resp.Usage.TotalTokens).To(Equal(39))
+	})
+
+	It("captures OpenAI reasoning tokens from the final usage chunk", func() {
+		asm := assemble(p, providerOpenAI, []string{
+			`{"id":"c2","model":"o3-mini","choices":[{"index":0,"delta":{"content":"42"}}]}`,
+			`{"id":"c2","model":"o3-mini","choices":[],"usage":{"prompt_tokens":20,"completion_tokens":500,"completion_tokens_details":{"reasoning_tokens":448}}}`,
+		})
+		Expect(asm.usage.CompletionTokens).To(Equal(500))
+		Expect(asm.usage.ReasoningTokens).To(Equal(448))
+	})
+
+	It("collects whole Ollama tool calls after the text", func() {
+		chunks := []string{
+			`{"model":"llama3","message":{"role":"assistant","content":"Checking"},"done":false}`,
+			`{"model":"llama3","message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"ls","arguments":{"dir":"."}}}]},"done":false}`,
+			`{"model":"llama3","message":{"role":"assistant","content":""},"done":true,"done_reason":"stop","prompt_eval_count":5,"eval_count":3}`,
+		}
+		nonStreamed := `{"model":"llama3","message":{"role":"assistant","content":"Checking","tool_calls":[{"function":{"name":"ls","arguments":{"dir":"."}}}]},"done":true}`
+
+		asm := assemble(p, providerOllama, chunks)
+		resp := asm.apply(p.reconstructStreamedResponse(asm.chunks, asm.content.String(), &asm.usage, &asm.meta, mustProvider(providerOllama)))
+
+		Expect(resp.Message.Content).To(Equal(parsedContent(providerOllama, nonStreamed)))
+	})
+
+	It("leaves text-only streams untouched", func() {
+		asm := assemble(p, providerOpenAI, []string{
+			`{"choices":[{"index":0,"delta":{"content":"hi"}}]}`,
+		})
+		Expect(asm.hasBlocks()).To(BeFalse())
+		Expect(asm.apply(nil)).To(BeNil())
+	})
+
+	It("stores a streamed Anthropic turn as the same node as a non-streamed one", func() {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			for _, chunk := range anthropicToolStream {
+				w.Write([]byte("data: " + chunk + "\n\n"))
+			}
+		}))
+		defer upstream.Close()
+
+		logger, _ := zap.NewDevelopment()
+		driver := inmemory.NewDriver()
+		sp, err := New(Config{ListenAddr: ":0", UpstreamURL: upstream.URL, ProviderType: providerAnthropic}, driver, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		reqBody := `{"model":"claude-sonnet-4-5","max_tokens":100,"stream":true,"messages":[{"role":"user","content":"read main.go"}]}`
+		resp, err := sp.server.Test(httptest.NewRequest(http.MethodPost, "/v1/messages", strings.NewReader(reqBody)), -1)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(sp.Close()).To(Succeed())
+
+		prov := mustProvider(providerAnthropic)
+		req, err := prov.ParseRequest([]byte(reqBody))
+		Expect(err).NotTo(HaveOccurred())
+		parsed, err := prov.ParseResponse([]byte(anthropicToolResponse))
+		Expect(err).NotTo(HaveOccurred())
+
+		userNode := merkle.NewNode(merkle.Bucket{
+			Type: "message", Role: req.Messages[0].Role, Content: req.Messages[0].Content,
+			Model: req.Model, Provider: providerAnthropic,
+		}, nil, merkle.NodeMeta{})
+		expected := merkle.NewNode(merkle.Bucket{
+			Type: "message", Role: parsed.Message.Role, Content: parsed.Message.Content,
+			Model: parsed.Model, Provider: providerAnthropic,
+		}, userNode, merkle.NodeMeta{})
+
+		Eventually(func() (bool, error) {
+			return driver.Has(GinkgoT().Context(), expected.Hash)
+		}).Should(BeTrue())
+	})
+})
+
+func mustProvider(name string) provider.Provider {
+	prov, err := provider.New(name)
+	Expect(err).NotTo(HaveOccurred())
+	return prov
+}
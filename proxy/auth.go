@@ -0,0 +1,59 @@
+package proxy
+
+import (
+	"crypto/subtle"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/proxy/header"
+)
+
+// requireToken rejects requests that do not carry the configured token, or
+// one of the per-user tokens, in the X-Tapes-Token header. Rejected requests
+// are neither forwarded nor stored.
+func (p *Proxy) requireToken(c *fiber.Ctx) error {
+	token := c.Get(header.AuthTokenHeader)
+	if _, ok := p.tokenUser(token); ok {
+		return c.Next()
+	}
+	if p.config.AuthToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(p.config.AuthToken)) != 1 {
+		return c.Status(fiber.StatusUnauthorized).JSON(llm.ErrorResponse{Error: "missing or invalid tapes proxy token"})
+	}
+	return c.Next()
+}
+
+// requiresToken reports whether requests must carry a token.
+func (p *Proxy) requiresToken() bool {
+	return p.config.AuthToken != "" || len(p.config.UserTokens) > 0
+}
+
+// tokenUser returns the user whose per-user token is token.
+func (p *Proxy) tokenUser(token string) (string, bool) {
+	if token == "" {
+		return "", false
+	}
+	for user, userToken := range p.config.UserTokens {
+		if userToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(userToken)) == 1 {
+			return user, true
+		}
+	}
+	return "", false
+}
+
+// requestUser returns the user a request is attributed to. A per-user token
+// identifies its holder. The shared auth token does not, since every client
+// holds it, so the X-Tapes-User header cannot be trusted either and the
+// proxy's user is used instead. Without tokens, the header names the user.
+func (p *Proxy) requestUser(c *fiber.Ctx) string {
+	if user, ok := p.tokenUser(c.Get(header.AuthTokenHeader)); ok {
+		return user
+	}
+	if p.requiresToken() {
+		return p.config.User
+	}
+	if user := c.Get(header.UserHeader); user != "" {
+		return user
+	}
+	return p.config.User
+}
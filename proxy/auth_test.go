@@ -0,0 +1,191 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+	"github.com/papercomputeco/tapes/pkg/tlsutil"
+	"github.com/papercomputeco/tapes/proxy/header"
+)
+
+var _ = Describe("Listener protection", func() {
+	var (
+		p        *Proxy
+		upstream *httptest.Server
+		received http.Header
+	)
+
+	newProtectedProxy := func(config Config) {
+		logger, _ := zap.NewDevelopment()
+		config.ListenAddr = ":0"
+		config.UpstreamURL = upstream.URL
+		config.ProviderType = "ollama"
+
+		var err error
+		p, err = New(config, inmemory.NewDriver(), logger)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		received = nil
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r.Header.Clone()
+			w.Write([]byte(`{"models":[]}`))
+		}))
+	})
+
+	AfterEach(func() {
+		if p != nil {
+			p.Close()
+		}
+		upstream.Close()
+	})
+
+	Describe("auth token", func() {
+		BeforeEach(func() {
+			newProtectedProxy(Config{AuthToken: "secret"})
+		})
+
+		It("rejects requests without the token before forwarding them", func() {
+			resp, err := p.server.Test(httptest.NewRequest(http.MethodGet, "/api/tags", nil))
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			Expect(received).To(BeNil())
+		})
+
+		It("rejects a wrong token", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/tags", nil)
+			req.Header.Set(header.AuthTokenHeader, "nope")
+			resp, err := p.server.Test(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("forwards requests with the token without passing it upstream", func() {
+			req := httptest.NewRequest(http.MethodGet, "/api/tags", nil)
+			req.Header.Set(header.AuthTokenHeader, "secret")
+			req.Header.Set("Authorization", "Bearer sk-upstream")
+			resp, err := p.server.Test(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			Expect(received.Get("Authorization")).To(Equal("Bearer sk-upstream"))
+			Expect(received.Get(header.AuthTokenHeader)).To(BeEmpty())
+		})
+	})
+
+	It("ignores the user header when the token is required", func() {
+		upstream.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"model":"test-model","message":{"role":"assistant","content":"hi"},"done":true}`))
+		})
+		driver := inmemory.NewDriver()
+		logger, _ := zap.NewDevelopment()
+		var err error
+		p, err = New(Config{
+			ListenAddr:   ":0",
+			UpstreamURL:  upstream.URL,
+			ProviderType: "ollama",
+			AuthToken:    "secret",
+			User:         "owner",
+		}, driver, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(`{"model":"test-model","messages":[{"role":"user","content":"hello"}],"stream":false}`))
+		req.Header.Set(header.AuthTokenHeader, "secret")
+		req.Header.Set(header.UserHeader, "mallory")
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		p.Close()
+		p = nil
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(2))
+		for _, node := range nodes {
+			Expect(node.User).To(Equal("owner"))
+		}
+	})
+
+	It("attributes turns to the user whose token they carry", func() {
+		upstream.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"model":"test-model","message":{"role":"assistant","content":"hi"},"done":true}`))
+		})
+		driver := inmemory.NewDriver()
+		logger, _ := zap.NewDevelopment()
+		var err error
+		p, err = New(Config{
+			ListenAddr:   ":0",
+			UpstreamURL:  upstream.URL,
+			ProviderType: "ollama",
+			UserTokens:   map[string]string{"alice": "alice-token", "bob": "bob-token"},
+			User:         "owner",
+		}, driver, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		send := func(token, user string) int {
+			req := httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(`{"model":"test-model","messages":[{"role":"user","content":"hello `+token+`"}],"stream":false}`))
+			req.Header.Set(header.AuthTokenHeader, token)
+			req.Header.Set(header.UserHeader, user)
+			resp, err := p.server.Test(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			return resp.StatusCode
+		}
+		Expect(send("alice-token", "mallory")).To(Equal(http.StatusOK))
+		Expect(send("bob-token", "")).To(Equal(http.StatusOK))
+		Expect(send("", "alice")).To(Equal(http.StatusUnauthorized))
+
+		p.Close()
+		p = nil
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		users := map[string]int{}
+		for _, node := range nodes {
+			users[node.User]++
+		}
+		Expect(users).To(Equal(map[string]int{"alice": 2, "bob": 2}))
+	})
+
+	It("serves HTTPS when TLS is configured", func() {
+		tlsConfig, err := tlsutil.ServerConfig(tlsutil.Options{Dir: GinkgoT().TempDir()})
+		Expect(err).NotTo(HaveOccurred())
+		newProtectedProxy(Config{TLS: tlsConfig})
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		go p.RunWithListener(listener)
+
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec // self-signed test certificate
+		}}
+		url := "https://" + listener.Addr().String() + "/api/tags"
+		Eventually(func() (int, error) {
+			resp, err := client.Get(url)
+			if err != nil {
+				return 0, err
+			}
+			defer resp.Body.Close()
+			return resp.StatusCode, nil
+		}).Should(Equal(http.StatusOK))
+
+		_, err = http.Get(strings.Replace(url, "https://", "http://", 1))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,52 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// ErrOverBudget is returned by a Budget that rejects a request.
+var ErrOverBudget = errors.New("request exceeds budget")
+
+// BudgetRequest describes a chat request about to be forwarded upstream.
+type BudgetRequest struct {
+	Provider  string
+	Model     string
+	AgentName string
+	User      string
+
+	// InputTokens is the request's input size, counted by the provider's
+	// token counting endpoint when it has one and estimated otherwise.
+	InputTokens int
+
+	// Estimated reports whether InputTokens was estimated locally rather
+	// than counted by the provider.
+	Estimated bool
+}
+
+// Budget decides whether a chat request may be forwarded before it is sent,
+// so that requests over a limit cost nothing. Rejected requests are answered
+// with 402 Payment Required and are not recorded.
+type Budget interface {
+	// Check returns an error wrapping ErrOverBudget to reject the request.
+	Check(ctx context.Context, req BudgetRequest) error
+}
+
+// MaxInputTokens is a Budget that rejects requests with more input tokens
+// than its value. Estimated counts are only rejected once they exceed it by
+// llm.EstimateMargin percent.
+type MaxInputTokens int
+
+// Check implements Budget.
+func (m MaxInputTokens) Check(_ context.Context, req BudgetRequest) error {
+	if !llm.OverLimit(req.InputTokens, int(m), req.Estimated) {
+		return nil
+	}
+	if req.Estimated {
+		return fmt.Errorf("%w: an estimated %d input tokens, limit is %d", ErrOverBudget, req.InputTokens, int(m))
+	}
+	return fmt.Errorf("%w: %d input tokens, limit is %d", ErrOverBudget, req.InputTokens, int(m))
+}
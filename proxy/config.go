@@ -1,8 +1,23 @@
 package proxy
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/capture"
+	"github.com/papercomputeco/tapes/pkg/crash"
 	"github.com/papercomputeco/tapes/pkg/embeddings"
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/policy"
+	"github.com/papercomputeco/tapes/pkg/redact"
+	"github.com/papercomputeco/tapes/pkg/runtoken"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
+	"github.com/papercomputeco/tapes/pkg/storage/media"
 	"github.com/papercomputeco/tapes/pkg/vector"
+	"github.com/papercomputeco/tapes/proxy/worker"
 )
 
 // Config is the proxy server configuration.
@@ -31,8 +46,118 @@ type Config struct {
 	// Required if VectorDriver is set.
 	Embedder embeddings.Embedder
 
+	// AuthToken, when set, must be sent in the X-Tapes-Token header on every
+	// request, so that a proxy listening beyond loopback is not an open relay
+	AuthToken string
+
+	// UserTokens maps users to tokens of their own, accepted in place of
+	// AuthToken. Turns sent with one are attributed to its user
+	UserTokens map[string]string
+
+	// RunTokens, when set, checks the run tokens agents send in place of API
+	// keys and swaps them for the keys ProviderKeys returns for the providers
+	// their requests go to
+	RunTokens    *runtoken.Service
+	ProviderKeys func(provider string) (string, error)
+
+	// RefreshOAuth, when set, is asked for a fresh access token when the
+	// upstream rejects a request's bearer token with a 401, and the request
+	// is retried once with it. It returns "" for tokens it does not manage
+	RefreshOAuth func(ctx context.Context, expired string) (string, error)
+
+	// TLS, when set, serves the proxy over HTTPS
+	TLS *tls.Config
+
 	// Project is the git repository or project name to tag on stored nodes.
 	Project string
+
+	// User is the identity to attribute stored nodes to. Requests carrying
+	// the X-Tapes-User header are attributed to that user instead, unless a
+	// token is required, and requests carrying a per-user token to its user.
+	User string
+
+	// MaxCaptureBytes caps the size of each captured content block (text, tool
+	// output, inline images). Oversized content is stored truncated with a
+	// marker recording its original length and hash. Zero disables the limit.
+	MaxCaptureBytes int
+
+	// Redactor optionally scrubs secrets and personal data from captured
+	// content before it is stored. Nil disables redaction.
+	Redactor *redact.Redactor
+
+	// CaptureRules optionally selects which turns are recorded, by path,
+	// provider, agent, model, or system prompt. Skipped turns are still
+	// forwarded. Nil records every turn.
+	CaptureRules *capture.Rules
+
+	// BlobStore optionally receives captured content payloads larger than
+	// BlobThreshold so that they are kept out of the primary database.
+	BlobStore blob.Store
+
+	// BlobThreshold is the payload size in bytes above which content is
+	// offloaded to BlobStore (defaults to blob.DefaultThreshold).
+	BlobThreshold int
+
+	// MediaStore optionally stores captured images and attached files, which
+	// are replaced by references in the stored nodes. Nil keeps them inline.
+	MediaStore *media.Store
+
+	// RawCapture keeps each stored turn's original request and response
+	// payloads, compressed, so turns can be re-parsed with `tapes reprocess`
+	// after a parser fix. Streamed responses keep their data payloads.
+	RawCapture bool
+
+	// SigningKey optionally signs the hash of every stored node with the
+	// installation's identity key. Nil stores nodes unsigned.
+	SigningKey ed25519.PrivateKey
+
+	// TurnCost optionally prices turns for the session summaries kept in
+	// the database. Nil leaves their costs at zero.
+	TurnCost func(model string, usage llm.Usage) float64
+
+	// SessionIdleTimeout closes sessions that go this long without a turn,
+	// recording when they ended on their root node. Zero disables it.
+	SessionIdleTimeout time.Duration
+
+	// Queue sizes the queue of captured turns waiting to be stored and sets
+	// what happens when it is full. Zero values keep the worker defaults.
+	Queue QueuePolicy
+
+	// Middleware is run, in order, on each captured turn before it is stored.
+	// Middleware can drop, rewrite, or enrich turns; see worker.Middleware.
+	Middleware []worker.Middleware
+
+	// DrainTimeout bounds how long Close waits for queued conversation turns
+	// to be stored (defaults to 30 seconds).
+	DrainTimeout time.Duration
+
+	// JournalPath is an optional file where turns that could not be stored
+	// before the drain deadline are persisted and replayed on the next start.
+	JournalPath string
+
+	// Retry configures retrying throttled and overloaded upstream requests.
+	// Retries are disabled unless Retry.MaxAttempts is at least 2.
+	Retry RetryPolicy
+
+	// Budget optionally checks each chat request's input tokens before it is
+	// forwarded. Nil forwards every request.
+	Budget Budget
+
+	// Policy optionally checks the tool calls in requests and responses
+	// against guardrail rules, which warn, record violations on the turn, or
+	// block it. Nil checks nothing.
+	Policy *policy.Engine
+
+	// ProviderRetries optionally overrides Retry per provider type.
+	ProviderRetries map[string]RetryPolicy
+
+	// Events optionally receives an event for each newly stored node so that
+	// clients can watch captures live. Nil disables publishing.
+	Events *events.Broker
+
+	// Crashes optionally records a report for each panic recovered in a
+	// request handler or storage worker. Panics are recovered either way.
+	Crashes *crash.Recorder
 }
 
 // AgentRoute defines proxy routing for a specific agent.
@@ -40,3 +165,26 @@ type AgentRoute struct {
 	ProviderType string
 	UpstreamURL  string
 }
+
+// QueuePolicy configures the worker pool that stores captured turns off the
+// request path.
+type QueuePolicy struct {
+	// Workers is the number of turns stored concurrently (defaults to 3).
+	Workers uint
+
+	// Size is the number of captured turns that can wait to be stored
+	// (defaults to 256).
+	Size uint
+
+	// Overflow determines what happens to a turn captured while the queue
+	// is full (defaults to worker.OverflowDropNew).
+	Overflow worker.OverflowPolicy
+
+	// EnqueueTimeout bounds how long the worker.OverflowBlock policy holds
+	// up a response waiting for room in the queue. Zero waits indefinitely.
+	EnqueueTimeout time.Duration
+
+	// JobTimeout bounds how long storing a single turn may take. Zero
+	// disables the timeout.
+	JobTimeout time.Duration
+}
@@ -0,0 +1,258 @@
+// Package forward provides an HTTPS forward proxy for tools that ignore base
+// URL settings but honor HTTPS_PROXY.
+//
+// Connections to allowlisted provider hosts are intercepted: the proxy
+// terminates their TLS with a certificate issued by a local CA and sends the
+// requests through the tapes proxy, which records them. Connections to any
+// other host are tunneled untouched.
+package forward
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/tlsutil"
+)
+
+// dialTimeout bounds connecting to the hosts of tunneled connections.
+const dialTimeout = 30 * time.Second
+
+// Route is where intercepted requests to a host are sent: the tapes proxy's
+// /providers/<Provider> prefix, with BasePath trimmed from their paths.
+// BasePath is the path of the provider's upstream URL in the tapes proxy,
+// such as /v1 for OpenAI.
+type Route struct {
+	Provider string
+	BasePath string
+}
+
+// DefaultHosts returns the provider hosts intercepted by default.
+func DefaultHosts() map[string]Route {
+	return map[string]Route{
+		"api.openai.com":    {Provider: "openai", BasePath: "/v1"},
+		"api.anthropic.com": {Provider: "anthropic"},
+	}
+}
+
+// HostNames returns the host names of hosts, sorted, such as to constrain
+// the CA issuing their certificates to them.
+func HostNames(hosts map[string]Route) []string {
+	return slices.Sorted(maps.Keys(hosts))
+}
+
+// Config is the forward proxy configuration.
+type Config struct {
+	// Target is the tapes proxy URL intercepted requests are sent to, such
+	// as an agent base URL with its run and agent prefixes.
+	Target string
+
+	// CA issues the certificates of intercepted hosts.
+	CA *tlsutil.CA
+
+	// Hosts maps the host names intercepted to their routes. Defaults to
+	// DefaultHosts.
+	Hosts map[string]Route
+
+	// Transport sends intercepted requests to Target. Defaults to
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// Proxy is an HTTPS forward proxy. It only handles CONNECT requests.
+type Proxy struct {
+	config Config
+	target string
+	logger *zap.Logger
+
+	wg sync.WaitGroup
+}
+
+// New creates a forward proxy.
+func New(config Config, logger *zap.Logger) (*Proxy, error) {
+	if config.Target == "" {
+		return nil, errors.New("forward proxy target is required")
+	}
+	if config.CA == nil {
+		return nil, errors.New("forward proxy CA is required")
+	}
+	if config.Hosts == nil {
+		config.Hosts = DefaultHosts()
+	}
+	if config.Transport == nil {
+		config.Transport = http.DefaultTransport
+	}
+	return &Proxy{
+		config: config,
+		target: strings.TrimRight(config.Target, "/"),
+		logger: logger,
+	}, nil
+}
+
+// ServeHTTP handles a CONNECT request, intercepting it when its host is
+// allowlisted and tunneling it otherwise.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodConnect {
+		http.Error(w, "the tapes forward proxy only handles CONNECT, for HTTPS_PROXY", http.StatusMethodNotAllowed)
+		return
+	}
+
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+	route, intercept := p.config.Hosts[strings.ToLower(host)]
+
+	var upstream net.Conn
+	if !intercept {
+		upstream, err = net.DialTimeout("tcp", r.Host, dialTimeout)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection cannot be hijacked", http.StatusInternalServerError)
+		return
+	}
+	hijacked, buffered, err := hijacker.Hijack()
+	if err != nil {
+		if upstream != nil {
+			_ = upstream.Close()
+		}
+		return
+	}
+	client := &bufferedConn{Conn: hijacked, reader: buffered.Reader}
+	if _, err := io.WriteString(client, "HTTP/1.1 200 Connection Established\r\n\r\n"); err != nil {
+		_ = client.Close()
+		if upstream != nil {
+			_ = upstream.Close()
+		}
+		return
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		if intercept {
+			p.intercept(client, host, route)
+		} else {
+			tunnel(client, upstream)
+		}
+	}()
+}
+
+// Wait waits for hijacked connections to finish.
+func (p *Proxy) Wait() {
+	p.wg.Wait()
+}
+
+// bufferedConn reads through the buffer of a hijacked connection, which can
+// hold bytes the client sent before the CONNECT response.
+type bufferedConn struct {
+	net.Conn
+	reader io.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// tunnel copies bytes both ways between client and upstream.
+func tunnel(client, upstream net.Conn) {
+	defer func() { _ = client.Close() }()
+	defer func() { _ = upstream.Close() }()
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(upstream, client)
+		if tcp, ok := upstream.(*net.TCPConn); ok {
+			_ = tcp.CloseWrite()
+		}
+		close(done)
+	}()
+	_, _ = io.Copy(client, upstream)
+	<-done
+}
+
+// intercept terminates the client's TLS for host and sends its requests to
+// the tapes proxy, one at a time.
+func (p *Proxy) intercept(client net.Conn, host string, route Route) {
+	defer func() { _ = client.Close() }()
+
+	conn := tls.Server(client, &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return p.config.CA.Certificate(host)
+		},
+		// Requests are read one at a time, so HTTP/2 is not offered.
+		NextProtos: []string{"http/1.1"},
+		MinVersion: tls.VersionTLS12,
+	})
+	if err := conn.Handshake(); err != nil {
+		p.logger.Debug("forward proxy TLS handshake failed", zap.String("host", host), zap.Error(err))
+		return
+	}
+
+	reader := bufio.NewReader(conn)
+	for {
+		req, err := http.ReadRequest(reader)
+		if err != nil {
+			return
+		}
+		resp, err := p.forward(req, route)
+		if err != nil {
+			p.logger.Warn("forward proxy request failed", zap.String("host", host), zap.Error(err))
+			resp = &http.Response{
+				StatusCode: http.StatusBadGateway,
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Body:       io.NopCloser(strings.NewReader(err.Error())),
+				Close:      true,
+			}
+		}
+		err = resp.Write(conn)
+		_ = resp.Body.Close()
+		if err != nil || req.Close || resp.Close {
+			return
+		}
+	}
+}
+
+// forward sends an intercepted request to the tapes proxy.
+func (p *Proxy) forward(req *http.Request, route Route) (*http.Response, error) {
+	path := req.URL.Path
+	if route.BasePath != "" {
+		trimmed, ok := strings.CutPrefix(path, route.BasePath)
+		if !ok || (trimmed != "" && !strings.HasPrefix(trimmed, "/")) {
+			return nil, fmt.Errorf("path %s is outside %s", path, route.BasePath)
+		}
+		path = trimmed
+	}
+	target := p.target + "/providers/" + route.Provider + path
+	if req.URL.RawQuery != "" {
+		target += "?" + req.URL.RawQuery
+	}
+
+	out, err := http.NewRequestWithContext(req.Context(), req.Method, target, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	out.Header = req.Header.Clone()
+	out.Header.Del("Proxy-Connection")
+	out.Header.Del("Proxy-Authorization")
+	out.ContentLength = req.ContentLength
+	return p.config.Transport.RoundTrip(out)
+}
@@ -0,0 +1,13 @@
+package forward_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestForward(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Forward Suite")
+}
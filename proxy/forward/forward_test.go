@@ -0,0 +1,103 @@
+package forward_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/tlsutil"
+	"github.com/papercomputeco/tapes/proxy/forward"
+)
+
+var _ = Describe("Proxy", func() {
+	var (
+		tapes          *httptest.Server
+		server         *httptest.Server
+		fwd            *forward.Proxy
+		ca             *tlsutil.CA
+		roots          *x509.CertPool
+		client         *http.Client
+		forwardedPaths chan string
+	)
+
+	BeforeEach(func() {
+		forwardedPaths = make(chan string, 1)
+		tapes = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			forwardedPaths <- r.URL.RequestURI()
+			_, _ = io.WriteString(w, "recorded")
+		}))
+
+		var err error
+		ca, err = tlsutil.LoadCA(GinkgoT().TempDir(), forward.HostNames(forward.DefaultHosts()))
+		Expect(err).NotTo(HaveOccurred())
+		caPEM, err := os.ReadFile(ca.CertFile())
+		Expect(err).NotTo(HaveOccurred())
+		roots = x509.NewCertPool()
+		Expect(roots.AppendCertsFromPEM(caPEM)).To(BeTrue())
+
+		fwd, err = forward.New(forward.Config{Target: tapes.URL + "/runs/r1/agents/tool", CA: ca}, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+		server = httptest.NewServer(fwd)
+
+		proxyURL, err := url.Parse(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		client = &http.Client{Transport: &http.Transport{
+			Proxy:           http.ProxyURL(proxyURL),
+			TLSClientConfig: &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12},
+		}}
+	})
+
+	AfterEach(func() {
+		client.CloseIdleConnections()
+		server.Close()
+		fwd.Wait()
+		tapes.Close()
+	})
+
+	It("sends requests to allowlisted hosts through the tapes proxy", func() {
+		resp, err := client.Post("https://api.openai.com/v1/chat/completions?x=1", "application/json", strings.NewReader("{}"))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(Equal("recorded"))
+		Expect(<-forwardedPaths).To(Equal("/runs/r1/agents/tool/providers/openai/chat/completions?x=1"))
+
+		resp, err = client.Post("https://api.anthropic.com/v1/messages", "application/json", strings.NewReader("{}"))
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(<-forwardedPaths).To(Equal("/runs/r1/agents/tool/providers/anthropic/v1/messages"))
+	})
+
+	It("tunnels connections to other hosts untouched", func() {
+		other := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, "direct")
+		}))
+		defer other.Close()
+		roots.AddCert(other.Certificate())
+
+		resp, err := client.Get(other.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(Equal("direct"))
+		Expect(forwardedPaths).To(BeEmpty())
+	})
+
+	It("rejects requests other than CONNECT", func() {
+		resp, err := http.Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
@@ -26,6 +26,49 @@ func NewHandler() *Handler {
 // AgentNameHeader is the optional header used to tag agent requests.
 const AgentNameHeader = "X-Tapes-Agent-Name"
 
+// AgentRunHeader optionally identifies the run of an agent a request belongs
+// to, such as one launch by tapes start. It is recorded on the turn's nodes,
+// and serves as the session when SessionHeader is not set.
+const AgentRunHeader = "X-Tapes-Agent-Run"
+
+// AgentVersionHeader optionally names the version of the agent a request
+// came from. It is recorded on the turn's nodes.
+const AgentVersionHeader = "X-Tapes-Agent-Version"
+
+// ProjectHeader optionally names the project a request was made in, such as
+// the git repository an agent was launched in. It overrides the proxy's
+// project.
+const ProjectHeader = "X-Tapes-Project"
+
+// RecordHeader set to RecordOff has a request forwarded without its turn
+// being recorded: nothing is stored or published for it, and only aggregate
+// counts are kept.
+const RecordHeader = "X-Tapes-Record"
+
+// RecordOff is the RecordHeader value that turns recording off.
+const RecordOff = "off"
+
+// SessionHeader optionally identifies the agent session a request belongs
+// to. Turns of different sessions are stored apart even when their messages
+// are identical.
+const SessionHeader = "X-Tapes-Session"
+
+// CodexSessionHeader is sent by Codex with the ID of its conversation, and is
+// used as the session when SessionHeader is not set.
+const CodexSessionHeader = "Session_id"
+
+// UserHeader optionally names the person a request is attributed to, so that
+// teammates sharing one proxy are told apart. It overrides the proxy's user,
+// unless the proxy requires AuthTokenHeader: a shared token does not prove
+// who a client is, so the header is then ignored, and per-user tokens name
+// the user instead.
+const UserHeader = "X-Tapes-User"
+
+// AuthTokenHeader carries the proxy's auth token, or a per-user token, when
+// one is configured.
+// The Authorization header is left to the agent's upstream credentials.
+const AuthTokenHeader = "X-Tapes-Token"
+
 // skipRequest is the set of request headers (client --> proxy --> upstream)
 // that are not forwarded to the upstream LLM provider.
 var skipRequest = map[string]struct{}{
@@ -42,8 +85,17 @@ var skipRequest = map[string]struct{}{
 	// response.
 	"Accept-Encoding": {},
 
-	// Internal agent routing header.
-	AgentNameHeader: {},
+	// Internal agent routing and attribution headers.
+	AgentNameHeader:    {},
+	AgentRunHeader:     {},
+	AgentVersionHeader: {},
+	ProjectHeader:      {},
+	RecordHeader:       {},
+	SessionHeader:      {},
+	UserHeader:         {},
+
+	// Proxy auth token, which is meaningless to the upstream.
+	AuthTokenHeader: {},
 }
 
 // skipResponse is the set of upstream response headers (client <-- proxy <-- upstream)
@@ -70,6 +122,18 @@ var skipResponse = map[string]struct{}{
 	"Content-Length": {},
 }
 
+// sensitiveRequest is the set of request headers carrying credentials. These
+// are never persisted when request headers are captured for diagnostics.
+var sensitiveRequest = map[string]struct{}{
+	"Authorization":       {},
+	"Proxy-Authorization": {},
+	"Cookie":              {},
+	"X-Api-Key":           {},
+	"Api-Key":             {},
+	"X-Goog-Api-Key":      {},
+	AuthTokenHeader:       {},
+}
+
 // SetUpstreamRequestHeaders copies request headers from the Fiber context to
 // the outgoing http.Request, filtering headers that the proxy should not forward
 // to the upstream API.
@@ -92,3 +156,16 @@ func (h *Handler) SetClientResponseHeaders(c *fiber.Ctx, resp *http.Response) {
 		}
 	}
 }
+
+// CaptureRequestHeaders returns the client's request headers with credential
+// headers removed, suitable for persisting alongside diagnostics.
+func (h *Handler) CaptureRequestHeaders(c *fiber.Ctx) map[string]string {
+	headers := make(map[string]string)
+	c.Request().Header.VisitAll(func(key, value []byte) {
+		k := http.CanonicalHeaderKey(string(key))
+		if _, sensitive := sensitiveRequest[k]; !sensitive {
+			headers[k] = string(value)
+		}
+	})
+	return headers
+}
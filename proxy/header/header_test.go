@@ -68,6 +68,36 @@ var _ = Describe("SetUpstreamRequestHeaders", func() {
 		Expect(got.Get("Connection")).To(BeEmpty())
 	})
 
+	It("strips the tapes session, agent run, agent version, project, and record headers", func() {
+		var got http.Header
+
+		app.Post("/test", func(c *fiber.Ctx) error {
+			req, _ := http.NewRequest(http.MethodPost, "http://upstream/test", nil)
+			hh.SetUpstreamRequestHeaders(c, req)
+			got = req.Header
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		req.Header.Set(SessionHeader, "session-1")
+		req.Header.Set(AgentRunHeader, "run-1")
+		req.Header.Set(AgentVersionHeader, "0.86.1")
+		req.Header.Set(ProjectHeader, "tapes")
+		req.Header.Set(RecordHeader, RecordOff)
+		req.Header.Set("Session_id", "codex-1")
+
+		resp, err := app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		Expect(got.Get(SessionHeader)).To(BeEmpty())
+		Expect(got.Get(AgentRunHeader)).To(BeEmpty())
+		Expect(got.Get(AgentVersionHeader)).To(BeEmpty())
+		Expect(got.Get(ProjectHeader)).To(BeEmpty())
+		Expect(got.Get(RecordHeader)).To(BeEmpty())
+		Expect(got.Get("Session_id")).To(Equal("codex-1"))
+	})
+
 	It("strips the Host header", func() {
 		var got http.Header
 
@@ -253,3 +283,34 @@ var _ = Describe("SetClientResponseHeaders", func() {
 		Expect(resp.Header.Get("X-Multi")).To(Equal("value1, value2"))
 	})
 })
+
+var _ = Describe("CaptureRequestHeaders", func() {
+	It("keeps diagnostic headers and drops credentials", func() {
+		app := fiber.New()
+		defer app.Shutdown()
+		hh := NewHandler()
+
+		var got map[string]string
+		app.Post("/test", func(c *fiber.Ctx) error {
+			got = hh.CaptureRequestHeaders(c)
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		req.Header.Set("Authorization", "Bearer token123")
+		req.Header.Set("X-Api-Key", "secret")
+		req.Header.Set("Cookie", "session=abc")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Anthropic-Version", "2023-06-01")
+
+		resp, err := app.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		Expect(got).To(HaveKeyWithValue("Content-Type", "application/json"))
+		Expect(got).To(HaveKeyWithValue("Anthropic-Version", "2023-06-01"))
+		Expect(got).NotTo(HaveKey("Authorization"))
+		Expect(got).NotTo(HaveKey("X-Api-Key"))
+		Expect(got).NotTo(HaveKey("Cookie"))
+	})
+})
@@ -0,0 +1,65 @@
+package proxy
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+)
+
+const (
+	// chatGPTAccountHeader is sent by codex signed in with a ChatGPT account,
+	// whose requests go to the ChatGPT backend rather than the OpenAI API.
+	chatGPTAccountHeader = "Chatgpt-Account-Id"
+
+	chatGPTUpstream = "https://chatgpt.com/backend-api/codex"
+
+	// providerChatGPT is the ProviderUpstreams key overriding chatGPTUpstream.
+	providerChatGPT = "chatgpt"
+)
+
+// resolveChatGPTUpstream sends the OpenAI requests of codex signed in with a
+// ChatGPT account to the ChatGPT backend, since their OAuth tokens are not
+// accepted by the OpenAI API.
+func (p *Proxy) resolveChatGPTUpstream(c *fiber.Ctx, agentName string, prov provider.Provider, path, upstream string) string {
+	if agentName != "codex" || prov.Name() != providerOpenAI || isOpenAIAuthPath(path) || c.Get(chatGPTAccountHeader) == "" {
+		return upstream
+	}
+	return p.providerUpstream(providerChatGPT, chatGPTUpstream)
+}
+
+// refreshOAuthToken swaps the request's bearer token for a fresh one after
+// the upstream rejected it, and reports whether the request should be
+// retried.
+func (p *Proxy) refreshOAuthToken(ctx context.Context, c *fiber.Ctx, prov provider.Provider) bool {
+	if p.config.RefreshOAuth == nil {
+		return false
+	}
+	// The header value is copied, since fasthttp reuses its buffer when the
+	// header is replaced.
+	expired, ok := strings.CutPrefix(strings.Clone(c.Get(fiber.HeaderAuthorization)), "Bearer ")
+	if !ok || expired == "" {
+		return false
+	}
+
+	fresh, err := p.config.RefreshOAuth(ctx, expired)
+	if err != nil {
+		p.logger.Warn("failed to refresh OAuth access token",
+			zap.String("provider", prov.Name()),
+			zap.Error(err),
+		)
+		return false
+	}
+	if fresh == "" || fresh == expired {
+		return false
+	}
+
+	p.logger.Info("retrying upstream request with refreshed OAuth access token",
+		zap.String("provider", prov.Name()),
+	)
+	c.Request().Header.Set(fiber.HeaderAuthorization, "Bearer "+fresh)
+	return true
+}
@@ -0,0 +1,103 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+var _ = Describe("codex ChatGPT accounts", func() {
+	var (
+		p          *Proxy
+		openai     *httptest.Server
+		chatgpt    *httptest.Server
+		openaiHits int
+		sent       []string
+		refreshes  []string
+	)
+
+	BeforeEach(func() {
+		openaiHits, sent, refreshes = 0, nil, nil
+		openai = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			openaiHits++
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		chatgpt = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			sent = append(sent, r.URL.Path+" "+token)
+			if token != "fresh" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"object":"list","data":[]}`))
+		}))
+
+		var err error
+		p, err = New(Config{
+			ListenAddr:   ":0",
+			UpstreamURL:  openai.URL,
+			ProviderType: "openai",
+			ProviderUpstreams: map[string]string{
+				providerOpenAI:  openai.URL,
+				providerChatGPT: chatgpt.URL,
+			},
+			RefreshOAuth: func(_ context.Context, expired string) (string, error) {
+				refreshes = append(refreshes, expired)
+				if expired == "expired" {
+					return "fresh", nil
+				}
+				return "", nil
+			},
+		}, inmemory.NewDriver(), zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		p.Close()
+		openai.Close()
+		chatgpt.Close()
+	})
+
+	get := func(path, token string, account bool) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if account {
+			req.Header.Set("ChatGPT-Account-Id", "acct")
+		}
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	It("sends codex requests with a ChatGPT account to the ChatGPT backend", func() {
+		Expect(get("/agents/codex/providers/openai/models", "fresh", true)).To(Equal(http.StatusOK))
+		Expect(sent).To(Equal([]string{"/models fresh"}))
+		Expect(openaiHits).To(BeZero())
+	})
+
+	It("refreshes an expired access token and retries once", func() {
+		Expect(get("/agents/codex/providers/openai/models", "expired", true)).To(Equal(http.StatusOK))
+		Expect(refreshes).To(Equal([]string{"expired"}))
+		Expect(sent).To(Equal([]string{"/models expired", "/models fresh"}))
+	})
+
+	It("returns the 401 when the token is not refreshed", func() {
+		Expect(get("/agents/codex/providers/openai/models", "revoked", true)).To(Equal(http.StatusUnauthorized))
+		Expect(refreshes).To(Equal([]string{"revoked"}))
+		Expect(sent).To(HaveLen(1))
+	})
+
+	It("leaves other requests on the OpenAI API", func() {
+		Expect(get("/agents/codex/providers/openai/models", "sk-key", false)).To(Equal(http.StatusUnauthorized))
+		Expect(openaiHits).To(Equal(1))
+		Expect(sent).To(BeEmpty())
+	})
+})
@@ -0,0 +1,51 @@
+package proxy
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+	"github.com/papercomputeco/tapes/pkg/policy"
+	"github.com/papercomputeco/tapes/proxy/worker"
+)
+
+// policyViolationType is the error type of the error nodes of blocked turns.
+const policyViolationType = "policy_violation"
+
+// errPolicyBlocked is recorded on the spans of turns blocked by the policy.
+var errPolicyBlocked = errors.New("blocked by policy")
+
+// logViolations logs the policy violations found in the request or response
+// of a turn.
+func (p *Proxy) logViolations(violations []llm.PolicyViolation, stage string, prov provider.Provider, agentName string) {
+	for _, v := range violations {
+		p.logger.Warn("policy violation",
+			zap.String("rule", v.Rule),
+			zap.String("action", v.Action),
+			zap.String("tool", v.Tool),
+			zap.String("message", v.Message),
+			zap.String("stage", stage),
+			zap.String("provider", prov.Name()),
+			zap.String("agent", agentName),
+		)
+	}
+}
+
+// blockTurn answers a turn the policy blocked with a 403 error in place of
+// the upstream's response, and stores the turn with that error. The job's
+// violations are recorded on its error node.
+func (p *Proxy) blockTurn(c *fiber.Ctx, job worker.Job, violations []llm.PolicyViolation, timing *llm.Timing) error {
+	msg := policy.BlockMessage(violations)
+	job.Error = &llm.UpstreamError{
+		Status:  fiber.StatusForbidden,
+		Type:    policyViolationType,
+		Message: msg,
+	}
+	job.Timing = timing
+	p.workerPool.Enqueue(job)
+
+	return c.Status(fiber.StatusForbidden).JSON(llm.ErrorResponse{Error: msg})
+}
@@ -0,0 +1,142 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/policy"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+var _ = Describe("Policy", func() {
+	var (
+		p        *Proxy
+		driver   *inmemory.Driver
+		upstream *httptest.Server
+		hits     atomic.Int32
+		command  string
+	)
+
+	BeforeEach(func() {
+		hits.Store(0)
+		command = "git push origin main --force"
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			hits.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"model":"test-model","done":true,"done_reason":"stop",
+				"message":{"role":"assistant","content":"","tool_calls":[
+					{"id":"call_1","function":{"name":"Bash","arguments":{"command":"`+command+`"}}}]}}`)
+		}))
+		driver = inmemory.NewDriver()
+	})
+
+	AfterEach(func() {
+		if p != nil {
+			p.Close()
+		}
+		upstream.Close()
+	})
+
+	start := func(specs ...policy.RuleSpec) {
+		engine, err := policy.New(specs)
+		Expect(err).NotTo(HaveOccurred())
+		p, err = New(Config{
+			ListenAddr:   ":0",
+			UpstreamURL:  upstream.URL,
+			ProviderType: "ollama",
+			Policy:       engine,
+		}, driver, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	send := func(messages string) (int, string) {
+		reqBody := `{"model":"test-model","stream":false,"messages":[` + messages + `]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(reqBody))
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return resp.StatusCode, string(body)
+	}
+
+	// head drains the worker pool and returns the single stored leaf.
+	head := func() *merkle.Node {
+		p.Close()
+		p = nil
+		leaves, err := driver.Leaves(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(HaveLen(1))
+		return leaves[0]
+	}
+
+	const prompt = `{"role":"user","content":"Ship it."}`
+
+	forcePush := func(action string) policy.RuleSpec {
+		return policy.RuleSpec{Name: "no_force_push", Action: action, Tool: `^Bash$`, Command: `git push .*--force`}
+	}
+
+	It("blocks responses with forbidden calls and stores the turn as an error", func() {
+		start(forcePush(policy.ActionBlock))
+
+		status, body := send(prompt)
+		Expect(status).To(Equal(http.StatusForbidden))
+		Expect(body).To(ContainSubstring(`blocked by tapes policy: Bash call runs forbidden command \"git push origin main --force\" (rule no_force_push)`))
+
+		node := head()
+		Expect(node.Bucket.Type).To(Equal("error"))
+		Expect(node.Error.Type).To(Equal(policyViolationType))
+		Expect(node.PolicyViolations).To(Equal([]llm.PolicyViolation{{
+			Rule:    "no_force_push",
+			Action:  policy.ActionBlock,
+			Tool:    "Bash",
+			Message: `Bash call runs forbidden command "git push origin main --force"`,
+		}}))
+	})
+
+	It("annotates responses and forwards them", func() {
+		start(forcePush(policy.ActionAnnotate))
+
+		status, _ := send(prompt)
+		Expect(status).To(Equal(http.StatusOK))
+
+		node := head()
+		Expect(node.Bucket.Role).To(Equal("assistant"))
+		Expect(node.PolicyViolations).To(HaveLen(1))
+		Expect(node.PolicyViolations[0].Action).To(Equal(policy.ActionAnnotate))
+	})
+
+	It("only logs warnings", func() {
+		start(forcePush(""))
+
+		status, _ := send(prompt)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(head().PolicyViolations).To(BeEmpty())
+	})
+
+	It("blocks requests sending back the results of forbidden calls", func() {
+		command = "ls"
+		start(policy.RuleSpec{Name: "no_web", Action: policy.ActionBlock, Tool: `^WebFetch$`})
+
+		status, body := send(prompt + `,
+			{"role":"assistant","content":"","tool_calls":[{"function":{"name":"WebFetch","arguments":{"url":"https://example.com"}}}]},
+			{"role":"tool","content":"<html></html>"}`)
+		Expect(status).To(Equal(http.StatusForbidden))
+		Expect(body).To(ContainSubstring("WebFetch is a blocked tool (rule no_web)"))
+		Expect(hits.Load()).To(BeZero())
+
+		node := head()
+		Expect(node.Bucket.Type).To(Equal("error"))
+		Expect(node.PolicyViolations).To(HaveLen(1))
+		Expect(node.PolicyViolations[0].Rule).To(Equal("no_web"))
+	})
+})
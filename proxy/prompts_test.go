@@ -0,0 +1,80 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+var _ = Describe("System prompts", func() {
+	var (
+		p        *Proxy
+		driver   *inmemory.Driver
+		upstream *httptest.Server
+	)
+
+	BeforeEach(func() {
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(makeOllamaResponseBody("test-model", "assistant", "It is sunny."))
+		}))
+		driver = inmemory.NewDriver()
+		var err error
+		p, err = New(Config{
+			ListenAddr:   ":0",
+			UpstreamURL:  upstream.URL,
+			ProviderType: "ollama",
+		}, driver, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if p != nil {
+			p.Close()
+		}
+		upstream.Close()
+	})
+
+	send := func(messages string) {
+		reqBody := `{"model":"test-model","stream":false,"messages":[` + messages + `]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(reqBody))
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	}
+
+	It("stores the system prompt and stamps its hash on the response", func() {
+		send(`{"role":"system","content":"You are a weather bot."},{"role":"user","content":"What is the weather?"}`)
+		p.Close()
+		p = nil
+
+		leaves, err := driver.Leaves(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(HaveLen(1))
+		Expect(leaves[0].Bucket.Role).To(Equal("assistant"))
+		Expect(leaves[0].SystemPromptHash).To(Equal(storage.SystemPromptHash("You are a weather bot.")))
+
+		text, err := driver.GetSystemPrompt(GinkgoT().Context(), leaves[0].SystemPromptHash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(text).To(Equal("You are a weather bot."))
+	})
+
+	It("stamps no hash on turns without a system prompt", func() {
+		send(`{"role":"user","content":"What is the weather?"}`)
+		p.Close()
+		p = nil
+
+		leaves, err := driver.Leaves(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(HaveLen(1))
+		Expect(leaves[0].SystemPromptHash).To(BeEmpty())
+	})
+})
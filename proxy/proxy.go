@@ -5,32 +5,46 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
+	"github.com/papercomputeco/tapes/pkg/health"
 	"github.com/papercomputeco/tapes/pkg/llm"
 	"github.com/papercomputeco/tapes/pkg/llm/provider"
+	"github.com/papercomputeco/tapes/pkg/policy"
 	"github.com/papercomputeco/tapes/pkg/sse"
 	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/telemetry"
 	"github.com/papercomputeco/tapes/proxy/header"
 	"github.com/papercomputeco/tapes/proxy/worker"
 )
 
 const (
 	agentPathPrefix   = "/agents/"
+	runPathPrefix     = "/runs/"
+	projectPathPrefix = "/projects/"
+	recordOffPath     = "/record/off"
 	providerOpenAI    = "openai"
 	providerAnthropic = "anthropic"
 	providerOllama    = "ollama"
+
+	defaultDrainTimeout = 30 * time.Second
 )
 
 // Proxy is a client, LLM inference proxy that instruments storing sessions as Merkle DAGs.
@@ -46,6 +60,15 @@ type Proxy struct {
 	providers     map[string]provider.Provider
 	defaultProv   provider.Provider
 	headerHandler *header.Handler
+
+	// deadLetters persists unparseable turns when the storage driver supports it.
+	deadLetters storage.DeadLetterStore
+
+	// deadLetterWG tracks the dead letters being stored in the background.
+	deadLetterWG sync.WaitGroup
+
+	// tokenCounts caches recent token counting results.
+	tokenCounts *tokenCountCache
 }
 
 // New creates a new Proxy.
@@ -84,15 +107,39 @@ func New(config Config, driver storage.Driver, logger *zap.Logger) (*Proxy, erro
 		StreamRequestBody: true,
 	})
 
+	// Recover panics first so that a malformed payload fails one request
+	app.Use(config.Crashes.Middleware(logger, "proxy"))
+
 	// Add compression middleware to handle responses
 	app.Use(compress.New())
 
 	wp, err := worker.NewPool(&worker.Config{
-		Driver:       driver,
-		VectorDriver: config.VectorDriver,
-		Embedder:     config.Embedder,
-		Project:      config.Project,
-		Logger:       logger,
+		Driver:          driver,
+		VectorDriver:    config.VectorDriver,
+		Embedder:        config.Embedder,
+		MaxContentBytes: config.MaxCaptureBytes,
+		Redactor:        config.Redactor,
+		CaptureRules:    config.CaptureRules,
+		BlobStore:       config.BlobStore,
+		BlobThreshold:   config.BlobThreshold,
+		MediaStore:      config.MediaStore,
+		Middleware:      config.Middleware,
+		JournalPath:     config.JournalPath,
+		Project:         config.Project,
+		User:            config.User,
+		Events:          config.Events,
+		Crashes:         config.Crashes,
+		SigningKey:      config.SigningKey,
+		TurnCost:        config.TurnCost,
+		Logger:          logger,
+
+		SessionIdleTimeout: config.SessionIdleTimeout,
+
+		NumWorkers:     config.Queue.Workers,
+		QueueSize:      config.Queue.Size,
+		OverflowPolicy: config.Queue.Overflow,
+		EnqueueTimeout: config.Queue.EnqueueTimeout,
+		JobTimeout:     config.Queue.JobTimeout,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("could not create worker pool: %w", err)
@@ -107,12 +154,21 @@ func New(config Config, driver storage.Driver, logger *zap.Logger) (*Proxy, erro
 		providers:     providers,
 		defaultProv:   defaultProv,
 		headerHandler: header.NewHandler(),
+		tokenCounts:   newTokenCountCache(),
 		httpClient: &http.Client{
 			// LLM requests can be slow, especially with thinking blocks
 			Timeout: 5 * time.Minute,
 		},
 	}
 
+	if dls, ok := driver.(storage.DeadLetterStore); ok {
+		p.deadLetters = dls
+	}
+
+	if p.requiresToken() {
+		app.Use(p.requireToken)
+	}
+
 	// Register transparent proxy route - forwards any path to upstream
 	app.All("/*", p.handleProxy)
 
@@ -121,12 +177,11 @@ func New(config Config, driver storage.Driver, logger *zap.Logger) (*Proxy, erro
 
 // Run starts the proxy server on the given listening address
 func (p *Proxy) Run() error {
-	p.logger.Info("starting proxy server",
-		zap.String("listen", p.config.ListenAddr),
-		zap.String("upstream", p.config.UpstreamURL),
-	)
-
-	return p.server.Listen(p.config.ListenAddr)
+	listener, err := net.Listen("tcp", p.config.ListenAddr)
+	if err != nil {
+		return err
+	}
+	return p.RunWithListener(listener)
 }
 
 // RunWithListener starts the proxy server using the provided listener.
@@ -134,15 +189,84 @@ func (p *Proxy) RunWithListener(listener net.Listener) error {
 	p.logger.Info("starting proxy server",
 		zap.String("listen", listener.Addr().String()),
 		zap.String("upstream", p.config.UpstreamURL),
+		zap.Bool("tls", p.config.TLS != nil),
 	)
 
+	if p.config.TLS != nil {
+		listener = tls.NewListener(listener, p.config.TLS)
+	}
 	return p.server.Listener(listener)
 }
 
-// Close gracefully shuts down the proxy and waits for the worker pool to drain
+// Close gracefully shuts down the proxy: it stops accepting connections,
+// lets in-flight requests finish, and waits for the worker pool to drain the
+// turns they captured, all within Config.DrainTimeout.
 func (p *Proxy) Close() error {
-	p.workerPool.Close()
-	return p.server.Shutdown()
+	timeout := p.config.DrainTimeout
+	if timeout <= 0 {
+		timeout = defaultDrainTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	shutdownErr := p.server.ShutdownWithContext(ctx)
+	p.waitDeadLetters(ctx)
+
+	report, err := p.workerPool.Close(ctx)
+	stats := p.workerPool.Stats()
+	p.logger.Info("worker pool drained",
+		zap.Uint64("flushed", report.Flushed),
+		zap.Int("abandoned", report.Abandoned),
+		zap.Int("journaled", report.Journaled),
+		zap.Uint64("unrecorded", stats.Unrecorded),
+		zap.Uint64("unrecorded_tokens", stats.UnrecordedTokens),
+		zap.Any("skipped", stats.Skipped),
+	)
+
+	return errors.Join(err, shutdownErr)
+}
+
+// Stats returns a snapshot of the worker pool's counters, including the
+// turns skipped by capture rules.
+func (p *Proxy) Stats() worker.Stats {
+	return p.workerPool.Stats()
+}
+
+// Metrics returns the worker pool's counters by Prometheus metric name.
+func (p *Proxy) Metrics() map[string]float64 {
+	stats := p.workerPool.Stats()
+	return map[string]float64{
+		"tapes_queue_depth":             float64(stats.Queued),
+		"tapes_queue_capacity":          float64(stats.Capacity),
+		"tapes_turns_enqueued_total":    float64(stats.Enqueued),
+		"tapes_turns_processed_total":   float64(stats.Processed),
+		"tapes_turns_duplicate_total":   float64(stats.Duplicates),
+		"tapes_turns_failed_total":      float64(stats.Failed),
+		"tapes_turns_crashed_total":     float64(stats.Crashed),
+		"tapes_turns_dropped_total":     float64(stats.DroppedNew + stats.DroppedOldest),
+		"tapes_turns_filtered_total":    float64(stats.Filtered),
+		"tapes_turns_unrecorded_total":  float64(stats.Unrecorded),
+		"tapes_unrecorded_tokens_total": float64(stats.UnrecordedTokens),
+	}
+}
+
+// AddHealthChecks adds checks of the proxy's storage, event publishing, and
+// storage queue to checker.
+func (p *Proxy) AddHealthChecks(checker *health.Checker) {
+	checker.Add("storage", health.StorageCheck(p.driver))
+	if p.config.Events != nil {
+		checker.Add("events", health.EventsCheck(p.config.Events))
+	}
+	checker.Add("queue", health.QueueCheck(func() (int, int) {
+		stats := p.workerPool.Stats()
+		return stats.Queued, stats.Capacity
+	}))
+}
+
+// EndSessions marks the open sessions of an agent run as ended by the agent
+// exiting, returning how many were ended. See worker.Pool.EndSessions.
+func (p *Proxy) EndSessions(ctx context.Context, agent, run string, since time.Time) int {
+	return p.workerPool.EndSessions(ctx, agent, run, since)
 }
 
 // handleProxy is a transparent proxy handler that forwards requests to upstream
@@ -151,20 +275,68 @@ func (p *Proxy) handleProxy(c *fiber.Ctx) error {
 	startTime := time.Now()
 
 	// Get the request path and method
-	agentName, providerName, path := p.resolveAgent(c.Path(), c.Get(header.AgentNameHeader))
+	_, path := resolveRecord(c.Path(), c.Get(header.RecordHeader))
+	run, path := resolveRun(path, c.Get(header.AgentRunHeader))
+	_, path = resolveProject(path, c.Get(header.ProjectHeader))
+	agentName, providerName, path := p.resolveAgent(path, c.Get(header.AgentNameHeader))
 	prov, upstreamURL := p.resolveProvider(agentName, providerName, path)
+	upstreamURL = p.resolveChatGPTUpstream(c, agentName, prov, path, upstreamURL)
 	method := c.Method()
 
-	// Only process POST requests that look like chat/completion endpoints
+	runToken, runTokenInfo, err := p.swapRunToken(c, prov.Name(), run)
+	if err != nil {
+		p.logger.Info("rejected request with run token",
+			zap.Error(err),
+			zap.String("token_id", runTokenInfo.ID),
+			zap.String("run", runTokenInfo.Run),
+			zap.String("requested_run", run),
+			zap.String("provider", prov.Name()),
+			zap.String("agent", agentName),
+		)
+		return c.Status(runTokenStatus(err)).JSON(llm.ErrorResponse{Error: err.Error()})
+	}
+	if runToken != "" {
+		p.logger.Debug("swapped run token",
+			zap.String("token_id", runTokenInfo.ID),
+			zap.String("run", runTokenInfo.Run),
+			zap.String("provider", prov.Name()),
+			zap.String("path", path),
+		)
+	}
+
+	// The span outlives the handler for streamed responses, so it must not
+	// derive from the fasthttp context, which is recycled on return.
+	ctx, span := telemetry.Tracer().Start(
+		telemetry.Extract(context.Background(), func(key string) string { return c.Get(key) }),
+		"proxy.request",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("http.request.method", method),
+			attribute.String("url.path", path),
+			attribute.String("tapes.provider", prov.Name()),
+			attribute.String("tapes.agent", agentName),
+		),
+	)
+
+	if method == "POST" && isTokenCountEndpoint(prov, path) {
+		defer span.End()
+		return p.handleCountTokens(ctx, c, path, upstreamURL, prov, agentName)
+	}
+
+	// Only process POST requests that look like chat/completion endpoints.
+	// Billable endpoints other than chat, such as embeddings, are recorded
+	// from the response instead.
 	body := c.Body()
-	isChatRequest := method == "POST" && len(body) > 0
+	isChatRequest := method == "POST" && len(body) > 0 && !isUsageEndpoint(prov, path)
 
 	// Parse request using configured provider
 	var parsedReq *llm.ChatRequest
+	var reqErr error
 	if isChatRequest {
 		var err error
 		parsedReq, err = prov.ParseRequest(body)
 		if err != nil {
+			reqErr = err
 			p.logger.Warn("failed to parse request",
 				zap.Error(err),
 				zap.String("provider", prov.Name()),
@@ -180,6 +352,45 @@ func (p *Proxy) handleProxy(c *fiber.Ctx) error {
 		}
 	}
 
+	if parsedReq != nil && runToken != "" && p.config.RunTokens.LimitsInputTokens(runToken) {
+		tokens, _ := p.countTokens(ctx, c, prov, upstreamURL, parsedReq)
+		if err := p.config.RunTokens.Spend(runToken, tokens); err != nil {
+			p.logger.Info("rejected request over run token budget",
+				zap.Error(err),
+				zap.String("token_id", runTokenInfo.ID),
+				zap.String("run", runTokenInfo.Run),
+			)
+			recordSpanError(ctx, err)
+			span.End()
+			return c.Status(runTokenStatus(err)).JSON(llm.ErrorResponse{Error: err.Error()})
+		}
+	}
+
+	if parsedReq != nil && p.config.Budget != nil {
+		if err := p.checkBudget(ctx, c, prov, agentName, upstreamURL, parsedReq); err != nil {
+			p.logger.Info("rejected request over budget",
+				zap.Error(err),
+				zap.String("provider", prov.Name()),
+				zap.String("agent", agentName),
+			)
+			recordSpanError(ctx, err)
+			span.End()
+			return c.Status(fiber.StatusPaymentRequired).JSON(llm.ErrorResponse{Error: err.Error()})
+		}
+	}
+
+	if parsedReq != nil && p.config.Policy != nil {
+		violations := p.config.Policy.CheckRequest(parsedReq)
+		p.logViolations(violations, "request", prov, agentName)
+		if policy.Blocked(violations) {
+			job := p.newJob(ctx, c, prov, agentName, path, parsedReq)
+			err := p.blockTurn(c, job, violations, &llm.Timing{RequestStartedAt: startTime, ResponseCompletedAt: time.Now()})
+			recordSpanError(ctx, errPolicyBlocked)
+			span.End()
+			return err
+		}
+	}
+
 	// Determine if streaming: check the parsed request's explicit Stream field,
 	// fall back to raw JSON, and finally consult the provider's default.
 	// Some providers (e.g. Ollama) stream by default when "stream" is omitted.
@@ -199,30 +410,35 @@ func (p *Proxy) handleProxy(c *fiber.Ctx) error {
 	}
 
 	if streaming && isChatRequest {
-		return p.handleStreamingProxy(c, path, upstreamURL, prov, agentName, body, parsedReq, startTime)
+		// handleStreamingProxy ends the span once the stream completes.
+		return p.handleStreamingProxy(ctx, c, path, upstreamURL, prov, agentName, body, parsedReq, reqErr, startTime)
 	}
 
-	return p.handleNonStreamingProxy(c, path, method, upstreamURL, prov, agentName, body, parsedReq, startTime)
+	defer span.End()
+	return p.handleNonStreamingProxy(ctx, c, path, method, upstreamURL, prov, agentName, body, parsedReq, reqErr, startTime)
 }
 
 // handleNonStreamingProxy handles non-streaming requests.
-func (p *Proxy) handleNonStreamingProxy(c *fiber.Ctx, path, method, upstreamURL string, prov provider.Provider, agentName string, body []byte, parsedReq *llm.ChatRequest, startTime time.Time) error {
+func (p *Proxy) handleNonStreamingProxy(ctx context.Context, c *fiber.Ctx, path, method, upstreamURL string, prov provider.Provider, agentName string, body []byte, parsedReq *llm.ChatRequest, reqErr error, startTime time.Time) error {
 	// Build upstream URL
 	upstreamURL += path
 
-	// Create upstream request
-	var reqBody io.Reader
-	if len(body) > 0 {
-		reqBody = bytes.NewReader(body)
-	}
+	// Create upstream requests, one per attempt
+	newRequest := func() (*http.Request, error) {
+		var reqBody io.Reader
+		if len(body) > 0 {
+			reqBody = bytes.NewReader(body)
+		}
 
-	httpReq, err := http.NewRequestWithContext(c.Context(), method, upstreamURL, reqBody)
-	if err != nil {
-		p.logger.Error("failed to create upstream request", zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(llm.ErrorResponse{Error: "internal error"})
-	}
+		httpReq, err := http.NewRequestWithContext(c.Context(), method, upstreamURL, reqBody)
+		if err != nil {
+			return nil, err
+		}
 
-	p.headerHandler.SetUpstreamRequestHeaders(c, httpReq)
+		p.headerHandler.SetUpstreamRequestHeaders(c, httpReq)
+		telemetry.Inject(ctx, httpReq.Header.Set)
+		return httpReq, nil
+	}
 
 	p.logger.Debug("forwarding request to upstream",
 		zap.String("method", method),
@@ -230,12 +446,18 @@ func (p *Proxy) handleNonStreamingProxy(c *fiber.Ctx, path, method, upstreamURL
 	)
 
 	// Make the request
-	httpResp, err := p.httpClient.Do(httpReq)
+	httpResp, attempt, err := p.sendUpstream(ctx, c, prov, agentName, path, parsedReq, newRequest)
+	if errors.Is(err, errBuildRequest) {
+		p.logger.Error("failed to create upstream request", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(llm.ErrorResponse{Error: "internal error"})
+	}
 	if err != nil {
 		p.logger.Error("upstream request failed", zap.Error(err))
+		recordSpanError(ctx, err)
 		return c.Status(fiber.StatusBadGateway).JSON(llm.ErrorResponse{Error: "upstream request failed"})
 	}
 	defer httpResp.Body.Close()
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("http.response.status_code", httpResp.StatusCode))
 
 	// Read response body
 	respBody, err := io.ReadAll(httpResp.Body)
@@ -243,9 +465,14 @@ func (p *Proxy) handleNonStreamingProxy(c *fiber.Ctx, path, method, upstreamURL
 		p.logger.Error("failed to read upstream response", zap.Error(err))
 		return c.Status(fiber.StatusBadGateway).JSON(llm.ErrorResponse{Error: "failed to read upstream response"})
 	}
+	completedAt := time.Now()
 
 	p.headerHandler.SetClientResponseHeaders(c, httpResp)
 
+	if reqErr != nil && httpResp.StatusCode == http.StatusOK {
+		p.recordDeadLetter(c, storage.DeadLetterStageRequest, prov, agentName, path, body, respBody, reqErr)
+	}
+
 	// If this was a chat request, enqueue for async storage
 	if parsedReq != nil && httpResp.StatusCode == http.StatusOK {
 		parsedResp, err := prov.ParseResponse(respBody)
@@ -255,6 +482,7 @@ func (p *Proxy) handleNonStreamingProxy(c *fiber.Ctx, path, method, upstreamURL
 				zap.String("provider", prov.Name()),
 				zap.String("agent", agentName),
 			)
+			p.recordDeadLetter(c, storage.DeadLetterStageResponse, prov, agentName, path, body, respBody, err)
 		} else {
 			p.logger.Debug("received response from upstream",
 				zap.String("model", parsedResp.Model),
@@ -264,21 +492,229 @@ func (p *Proxy) handleNonStreamingProxy(c *fiber.Ctx, path, method, upstreamURL
 			)
 
 			// Non-blocking enqueue for async storage
-			p.workerPool.Enqueue(worker.Job{
-				Provider:  prov.Name(),
-				AgentName: agentName,
-				Req:       parsedReq,
-				Resp:      parsedResp,
-			})
+			job := p.newJob(ctx, c, prov, agentName, path, parsedReq)
+			if p.config.RawCapture {
+				job.RawRequest = bytes.Clone(body)
+				job.RawResponse = respBody
+			}
+			timing := &llm.Timing{RequestStartedAt: startTime, ResponseCompletedAt: completedAt}
+
+			violations := p.config.Policy.CheckResponse(parsedResp)
+			p.logViolations(violations, "response", prov, agentName)
+			job.PolicyViolations = append(job.PolicyViolations, policy.Recorded(violations)...)
+			if policy.Blocked(violations) {
+				recordSpanError(ctx, errPolicyBlocked)
+				return p.blockTurn(c, job, violations, timing)
+			}
+
+			job.Resp = parsedResp
+			job.Timing = timing
+			p.workerPool.Enqueue(job)
 		}
 	}
 
+	if parsedReq != nil && httpResp.StatusCode >= http.StatusBadRequest {
+		p.enqueueUpstreamError(ctx, c, prov, agentName, path, parsedReq, httpResp, respBody, attempt,
+			&llm.Timing{RequestStartedAt: startTime, ResponseCompletedAt: completedAt})
+	}
+
+	if parsedReq == nil && reqErr == nil && method == "POST" && httpResp.StatusCode == http.StatusOK {
+		p.recordUsage(ctx, c, prov, agentName, path, body, respBody, &llm.Timing{RequestStartedAt: startTime, ResponseCompletedAt: completedAt})
+	}
+
 	// Return response to client immediately
 	return c.Status(httpResp.StatusCode).Send(respBody)
 }
 
+// newJob builds the storage job for a captured turn. The response is filled in
+// once it has been parsed.
+func (p *Proxy) newJob(ctx context.Context, c *fiber.Ctx, prov provider.Provider, agentName, path string, req *llm.ChatRequest) worker.Job {
+	record, rest := resolveRecord(c.Path(), c.Get(header.RecordHeader))
+	run, rest := resolveRun(rest, c.Get(header.AgentRunHeader))
+	project, rest := resolveProject(rest, c.Get(header.ProjectHeader))
+	job := worker.Job{
+		Unrecorded:   !record,
+		Provider:     prov.Name(),
+		AgentName:    agentName,
+		AgentVersion: resolveAgentVersion(rest, c.Get(header.AgentVersionHeader)),
+		RunID:        run,
+		SessionID:    sessionID(c, run, req),
+		Project:      project,
+		User:         strings.Clone(p.requestUser(c)),
+		Req:          req,
+		Path:         path,
+		Headers:      p.headerHandler.CaptureRequestHeaders(c),
+		TraceParent:  telemetry.TraceParent(ctx),
+	}
+	// The violations of a request's calls are recorded on its turn, whether
+	// it succeeds or fails upstream.
+	job.PolicyViolations = policy.Recorded(p.config.Policy.CheckRequest(req))
+	return job
+}
+
+// sessionID returns the agent session a request belongs to: the one named by
+// the session header, or else its agent run, or else the one the agent sent
+// itself. Requests of no known session return "", and their turns are stored
+// by content alone.
+func sessionID(c *fiber.Ctx, run string, req *llm.ChatRequest) string {
+	// Header values are copied, as fiber reuses their buffers once the
+	// handler returns and the job outlives it.
+	if id := strings.TrimSpace(c.Get(header.SessionHeader)); id != "" {
+		return strings.Clone(id)
+	}
+	if run != "" {
+		return run
+	}
+	if id := strings.TrimSpace(c.Get(header.CodexSessionHeader)); id != "" {
+		return strings.Clone(id)
+	}
+	if req != nil {
+		return req.SessionID
+	}
+	return ""
+}
+
+// enqueueUpstreamError stores a turn the upstream rejected, with the parsed
+// error payload in place of the response, so failed turns are not lost.
+// Attempt numbers the attempt when retries are enabled and is zero otherwise.
+func (p *Proxy) enqueueUpstreamError(ctx context.Context, c *fiber.Ctx, prov provider.Provider, agentName, path string, req *llm.ChatRequest, httpResp *http.Response, respBody []byte, attempt int, timing *llm.Timing) {
+	job := p.newJob(ctx, c, prov, agentName, path, req)
+	job.Error = parseUpstreamError(httpResp.StatusCode, httpResp.Header.Get("Retry-After"), respBody, timing.ResponseCompletedAt)
+	job.Error.Attempt = attempt
+	job.Timing = timing
+	p.workerPool.Enqueue(job)
+}
+
+// isUsageEndpoint reports whether path is a billable endpoint other than chat
+// for prov, such as embeddings or audio transcription.
+func isUsageEndpoint(prov provider.Provider, path string) bool {
+	parser, ok := prov.(provider.UsageParser)
+	return ok && parser.UsageEndpoint(path)
+}
+
+// recordUsage enqueues the usage of a request to a billable endpoint other
+// than chat. It is a no-op for other paths. Failures are logged and never
+// affect the proxied response.
+func (p *Proxy) recordUsage(ctx context.Context, c *fiber.Ctx, prov provider.Provider, agentName, path string, reqBody, respBody []byte, timing *llm.Timing) {
+	parser, ok := prov.(provider.UsageParser)
+	if !ok || !parser.UsageEndpoint(path) {
+		return
+	}
+
+	record, err := parser.ParseUsage(path, c.Get(fiber.HeaderContentType), reqBody, respBody)
+	if err != nil {
+		p.logger.Warn("failed to parse usage",
+			zap.Error(err),
+			zap.String("provider", prov.Name()),
+			zap.String("agent", agentName),
+			zap.String("path", path),
+		)
+		return
+	}
+
+	p.logger.Debug("recorded usage",
+		zap.String("kind", record.Kind),
+		zap.String("model", record.Model),
+		zap.String("provider", prov.Name()),
+		zap.String("agent", agentName),
+	)
+
+	job := p.newJob(ctx, c, prov, agentName, path, nil)
+	job.Record = record
+	job.Timing = timing
+	p.workerPool.Enqueue(job)
+}
+
+// recordDeadLetter persists a turn that failed to parse so it can be diagnosed
+// and retried later. It is a no-op when the storage driver has no dead-letter
+// support. The dead letter is stored in the background, so failures are
+// logged and never affect or delay the proxied response.
+func (p *Proxy) recordDeadLetter(c *fiber.Ctx, stage storage.DeadLetterStage, prov provider.Provider, agentName, path string, reqBody, respBody []byte, parseErr error) {
+	dl := p.newDeadLetter(c, prov, agentName, path, reqBody)
+	if dl == nil {
+		return
+	}
+	dl.Stage = stage
+	dl.Response = string(respBody)
+	dl.Error = parseErr.Error()
+	p.storeDeadLetter(dl)
+}
+
+// newDeadLetter captures the request of a turn that may be dead-lettered, or
+// returns nil when the storage driver has no dead-letter support or the turn
+// is not recorded. Everything is copied from c, as fiber reuses its buffers
+// once the handler returns.
+func (p *Proxy) newDeadLetter(c *fiber.Ctx, prov provider.Provider, agentName, path string, reqBody []byte) *storage.DeadLetter {
+	if p.deadLetters == nil {
+		return nil
+	}
+	if record, _ := resolveRecord(c.Path(), c.Get(header.RecordHeader)); !record {
+		return nil
+	}
+
+	return &storage.DeadLetter{
+		Provider:  prov.Name(),
+		AgentName: agentName,
+		Path:      path,
+		Headers:   p.headerHandler.CaptureRequestHeaders(c),
+		Request:   string(reqBody),
+	}
+}
+
+// storeDeadLetter redacts and stores dl in the background. Close waits for
+// the dead letters being stored.
+func (p *Proxy) storeDeadLetter(dl *storage.DeadLetter) {
+	p.deadLetterWG.Go(func() {
+		if p.config.Redactor != nil {
+			dl.Request = p.config.Redactor.RedactString(dl.Request)
+			dl.Response = p.config.Redactor.RedactString(dl.Response)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := p.deadLetters.AddDeadLetter(ctx, dl); err != nil {
+			p.logger.Error("failed to record dead letter",
+				zap.String("provider", dl.Provider),
+				zap.String("stage", string(dl.Stage)),
+				zap.Error(err),
+			)
+			return
+		}
+
+		p.logger.Info("recorded dead letter",
+			zap.Int("id", dl.ID),
+			zap.String("provider", dl.Provider),
+			zap.String("stage", string(dl.Stage)),
+		)
+	})
+}
+
+// waitDeadLetters waits for the dead letters being stored, until ctx is done.
+func (p *Proxy) waitDeadLetters(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		p.deadLetterWG.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		p.logger.Warn("gave up waiting for dead letters to be stored", zap.Error(ctx.Err()))
+	}
+}
+
 // handleStreamingProxy handles streaming requests.
-func (p *Proxy) handleStreamingProxy(c *fiber.Ctx, path, upstreamURL string, prov provider.Provider, agentName string, body []byte, parsedReq *llm.ChatRequest, startTime time.Time) error {
+func (p *Proxy) handleStreamingProxy(ctx context.Context, c *fiber.Ctx, path, upstreamURL string, prov provider.Provider, agentName string, body []byte, parsedReq *llm.ChatRequest, reqErr error, startTime time.Time) error {
+	// The span ends here unless the stream is handed off to be relayed.
+	span := trace.SpanFromContext(ctx)
+	relaying := false
+	defer func() {
+		if !relaying {
+			span.End()
+		}
+	}()
+
 	// Build upstream URL
 	upstreamURL += path
 
@@ -286,24 +722,33 @@ func (p *Proxy) handleStreamingProxy(c *fiber.Ctx, path, upstreamURL string, pro
 	// its RequestCtx after the handler returns, but the streaming callback runs
 	// asynchronously in a separate goroutine and needs the upstream connection
 	// to remain open.
-	httpReq, err := http.NewRequestWithContext(context.Background(), http.MethodPost, upstreamURL, bytes.NewReader(body))
-	if err != nil {
-		p.logger.Error("failed to create upstream request", zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(llm.ErrorResponse{Error: "internal error"})
-	}
+	newRequest := func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(context.Background(), http.MethodPost, upstreamURL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
 
-	p.headerHandler.SetUpstreamRequestHeaders(c, httpReq)
+		p.headerHandler.SetUpstreamRequestHeaders(c, httpReq)
+		telemetry.Inject(ctx, httpReq.Header.Set)
+		return httpReq, nil
+	}
 
 	p.logger.Debug("forwarding streaming request to upstream",
 		zap.String("url", upstreamURL),
 	)
 
 	// Make the request
-	httpResp, err := p.httpClient.Do(httpReq)
+	httpResp, attempt, err := p.sendUpstream(ctx, c, prov, agentName, path, parsedReq, newRequest)
+	if errors.Is(err, errBuildRequest) {
+		p.logger.Error("failed to create upstream request", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(llm.ErrorResponse{Error: "internal error"})
+	}
 	if err != nil {
 		p.logger.Error("upstream request failed", zap.Error(err))
+		recordSpanError(ctx, err)
 		return c.Status(fiber.StatusBadGateway).JSON(llm.ErrorResponse{Error: "upstream request failed"})
 	}
+	span.SetAttributes(attribute.Int("http.response.status_code", httpResp.StatusCode))
 	if httpResp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(httpResp.Body)
 		httpResp.Body.Close()
@@ -311,6 +756,10 @@ func (p *Proxy) handleStreamingProxy(c *fiber.Ctx, path, upstreamURL string, pro
 			zap.Int("status", httpResp.StatusCode),
 			zap.String("body", string(respBody)),
 		)
+		if parsedReq != nil && httpResp.StatusCode >= http.StatusBadRequest {
+			p.enqueueUpstreamError(ctx, c, prov, agentName, path, parsedReq, httpResp, respBody, attempt,
+				&llm.Timing{RequestStartedAt: startTime, ResponseCompletedAt: time.Now()})
+		}
 		return c.Status(httpResp.StatusCode).Send(respBody)
 	}
 
@@ -326,8 +775,25 @@ func (p *Proxy) handleStreamingProxy(c *fiber.Ctx, path, upstreamURL string, pro
 	// the reader is fasthttp's writeBodyChunked which flushes to TCP after
 	// every chunk. This gives direct backpressure and true per-chunk streaming
 	// for LLM based.
+	// Capture the job before returning: fasthttp recycles the request context
+	// once the handler returns, while the stream is relayed asynchronously.
+	job := p.newJob(ctx, c, prov, agentName, path, parsedReq)
+	if p.config.RawCapture {
+		job.RawRequest = bytes.Clone(body)
+		job.Streamed = true
+	}
+
+	// The turn is dead-lettered once the stream is relayed if its request
+	// failed to parse, or if its response cannot be assembled.
+	deadLetter := p.newDeadLetter(c, prov, agentName, path, body)
+	if deadLetter != nil && reqErr != nil {
+		deadLetter.Stage = storage.DeadLetterStageRequest
+		deadLetter.Error = reqErr.Error()
+	}
+
 	pr, pw := io.Pipe()
-	go p.handleHTTPRespToPipeWriter(httpResp, pw, parsedReq, prov, agentName, startTime)
+	relaying = true
+	go p.handleHTTPRespToPipeWriter(span, httpResp, pw, job, deadLetter, prov, startTime)
 
 	// Set the pipe reader as the body stream with unknown size (-1),
 	// which triggers chunked transfer encoding in fasthttp.
@@ -336,27 +802,34 @@ func (p *Proxy) handleStreamingProxy(c *fiber.Ctx, path, upstreamURL string, pro
 	return nil
 }
 
-func (p *Proxy) handleHTTPRespToPipeWriter(httpResp *http.Response, pw *io.PipeWriter, parsedReq *llm.ChatRequest, prov provider.Provider, agentName string, startTime time.Time) {
+func (p *Proxy) handleHTTPRespToPipeWriter(span trace.Span, httpResp *http.Response, pw *io.PipeWriter, job worker.Job, deadLetter *storage.DeadLetter, prov provider.Provider, startTime time.Time) {
 	// Close the upstream response body once streaming is complete.
+	defer span.End()
 	defer httpResp.Body.Close()
 	defer pw.Close()
 
+	// The relay runs outside the handler, so the crash middleware does not
+	// cover it: a panic here ends the client's stream with an error.
+	defer func() {
+		if v := recover(); v != nil {
+			p.config.Crashes.Log(p.logger, "proxy stream", v, job.Metadata())
+			_ = pw.CloseWithError(errors.New("relaying stream: recovered from a crash"))
+		}
+	}()
+
 	switch ct := httpResp.Header.Get("Content-Type"); {
 	case strings.HasPrefix(ct, "text/event-stream"):
-		p.handleSSEStream(httpResp, pw, parsedReq, prov, agentName, startTime)
+		p.handleSSEStream(httpResp, pw, job, deadLetter, prov, startTime)
 	default:
-		p.handleNDJSONStream(httpResp, pw, parsedReq, prov, agentName, startTime)
+		p.handleNDJSONStream(httpResp, pw, job, deadLetter, prov, startTime)
 	}
 }
 
 // handleSSEStream reads an SSE-formatted upstream response (used by OpenAI
 // and Anthropic), forwarding raw bytes verbatim to the pipe writer while
 // parsing events for telemetry accumulation.
-func (p *Proxy) handleSSEStream(httpResp *http.Response, pw *io.PipeWriter, parsedReq *llm.ChatRequest, prov provider.Provider, agentName string, startTime time.Time) {
-	var allChunks [][]byte
-	var fullContent strings.Builder
-	var streamUsage llm.Usage
-	var meta streamMeta
+func (p *Proxy) handleSSEStream(httpResp *http.Response, pw *io.PipeWriter, job worker.Job, deadLetter *storage.DeadLetter, prov provider.Provider, startTime time.Time) {
+	asm := newStreamAssembler(prov.Name())
 
 	tr := sse.NewTeeReader(httpResp.Body, pw)
 
@@ -375,28 +848,19 @@ func (p *Proxy) handleSSEStream(httpResp *http.Response, pw *io.PipeWriter, pars
 			continue
 		}
 
-		// Store the data payload for later reconstruction
-		chunkCopy := []byte(ev.Data)
-		allChunks = append(allChunks, chunkCopy)
-
-		// Best-effort content extraction from the JSON payload
-		p.extractContentFromJSON([]byte(ev.Data), prov.Name(), &fullContent)
+		data := []byte(ev.Data)
 
-		// Accumulate usage from SSE events (Anthropic splits usage across events)
-		p.extractUsageFromSSE([]byte(ev.Data), prov.Name(), &streamUsage, &meta)
+		p.addChunk(asm, data, prov)
 	}
 
-	p.enqueueStreamedResponse(allChunks, fullContent.String(), &streamUsage, &meta, parsedReq, prov, agentName, startTime)
+	p.enqueueStreamedResponse(asm, job, deadLetter, prov, startTime)
 }
 
 // handleNDJSONStream reads a newline-delimited JSON upstream response (used by
 // Ollama), forwarding raw bytes to the pipe writer while accumulating chunks
 // for telemetry.
-func (p *Proxy) handleNDJSONStream(httpResp *http.Response, pw *io.PipeWriter, parsedReq *llm.ChatRequest, prov provider.Provider, agentName string, startTime time.Time) {
-	var allChunks [][]byte
-	var fullContent strings.Builder
-	var streamUsage llm.Usage
-	var meta streamMeta
+func (p *Proxy) handleNDJSONStream(httpResp *http.Response, pw *io.PipeWriter, job worker.Job, deadLetter *storage.DeadLetter, prov provider.Provider, startTime time.Time) {
+	asm := newStreamAssembler(prov.Name())
 
 	scanner := bufio.NewScanner(httpResp.Body)
 	// Increase buffer size for large chunks
@@ -408,16 +872,7 @@ func (p *Proxy) handleNDJSONStream(httpResp *http.Response, pw *io.PipeWriter, p
 			continue
 		}
 
-		// Store chunk for later parsing
-		chunkCopy := make([]byte, len(line))
-		copy(chunkCopy, line)
-		allChunks = append(allChunks, chunkCopy)
-
-		// Best-effort content extraction from the raw chunk
-		p.extractContentFromJSON(line, prov.Name(), &fullContent)
-
-		// Accumulate usage from NDJSON events
-		p.extractUsageFromSSE(line, prov.Name(), &streamUsage, &meta)
+		p.addChunk(asm, line, prov)
 
 		// Write chunk to client — pw.Write blocks until fasthttp reads
 		// from the pipe reader and flushes to the TCP socket.
@@ -436,7 +891,7 @@ func (p *Proxy) handleNDJSONStream(httpResp *http.Response, pw *io.PipeWriter, p
 		p.logger.Error("error reading NDJSON stream", zap.Error(err))
 	}
 
-	p.enqueueStreamedResponse(allChunks, fullContent.String(), &streamUsage, &meta, parsedReq, prov, agentName, startTime)
+	p.enqueueStreamedResponse(asm, job, deadLetter, prov, startTime)
 }
 
 // extractContentFromJSON performs best-effort content extraction from a JSON
@@ -528,6 +983,9 @@ func (p *Proxy) extractUsageFromSSE(data []byte, providerName string, usage *llm
 		if u, ok := chunkData["usage"].(map[string]any); ok {
 			usage.PromptTokens = jsonInt(u, "prompt_tokens")
 			usage.CompletionTokens = jsonInt(u, "completion_tokens")
+			if details, ok := u["completion_tokens_details"].(map[string]any); ok {
+				usage.ReasoningTokens = jsonInt(details, "reasoning_tokens")
+			}
 		}
 	case providerOllama:
 		// Ollama includes usage in the final NDJSON line (done=true)
@@ -547,26 +1005,102 @@ func jsonInt(m map[string]any, key string) int {
 }
 
 // enqueueStreamedResponse handles post-stream telemetry: logging and
-// enqueuing the reconstructed response for async storage.
-func (p *Proxy) enqueueStreamedResponse(allChunks [][]byte, fullContent string, streamUsage *llm.Usage, meta *streamMeta, parsedReq *llm.ChatRequest, prov provider.Provider, agentName string, startTime time.Time) {
-	if parsedReq != nil && len(allChunks) > 0 {
-		p.logger.Debug("streaming complete",
-			zap.String("content_preview", fullContent),
-			zap.Int("chunk_count", len(allChunks)),
-			zap.String("agent", agentName),
-			zap.Duration("duration", time.Since(startTime)),
-		)
+// enqueuing the reassembled response onto job for async storage. A turn whose
+// request failed to parse, or whose response cannot be assembled, is stored
+// as deadLetter along with the relayed stream instead.
+func (p *Proxy) enqueueStreamedResponse(asm *streamAssembler, job worker.Job, deadLetter *storage.DeadLetter, prov provider.Provider, startTime time.Time) {
+	if len(asm.chunks) == 0 {
+		return
+	}
+	if job.Req == nil {
+		p.storeStreamDeadLetter(deadLetter, asm)
+		return
+	}
+
+	p.logger.Debug("streaming complete",
+		zap.String("content_preview", asm.content.String()),
+		zap.Int("chunk_count", len(asm.chunks)),
+		zap.Int("block_count", len(asm.blocks)),
+		zap.String("agent", job.AgentName),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+
+	finalResp := p.assemble(asm, prov)
+	if finalResp == nil {
+		if deadLetter != nil {
+			deadLetter.Stage = storage.DeadLetterStageResponse
+			deadLetter.Error = errStreamAssembly.Error()
+		}
+		p.storeStreamDeadLetter(deadLetter, asm)
+		return
+	}
+
+	if job.Streamed {
+		job.RawResponse = bytes.Join(asm.chunks, []byte("\n"))
+	}
+	// The stream has been relayed, so violations of blocking rules can only
+	// be recorded; the agent's next request is blocked when it sends back
+	// the results of the offending calls.
+	violations := p.config.Policy.CheckResponse(finalResp)
+	p.logViolations(violations, "streamed response", prov, job.AgentName)
+	job.PolicyViolations = append(job.PolicyViolations, policy.Recorded(violations)...)
+
+	job.Resp = finalResp
+	job.Timing = &llm.Timing{
+		RequestStartedAt:    startTime,
+		FirstChunkAt:        asm.firstChunkAt,
+		ResponseCompletedAt: time.Now(),
+	}
+	p.workerPool.Enqueue(job)
+}
+
+// errStreamAssembly is the error of dead letters of streamed responses from
+// which no response could be assembled.
+var errStreamAssembly = errors.New("no response could be assembled from the stream")
+
+// storeStreamDeadLetter stores deadLetter, when set and given a stage, with
+// the payloads of the relayed stream as its response.
+func (p *Proxy) storeStreamDeadLetter(deadLetter *storage.DeadLetter, asm *streamAssembler) {
+	if deadLetter == nil || deadLetter.Stage == "" {
+		return
+	}
+	deadLetter.Response = string(bytes.Join(asm.chunks, []byte("\n")))
+	p.storeDeadLetter(deadLetter)
+}
+
+// addChunk adds a streamed data payload to asm: the chunk is kept, its
+// structured content tracked, and its text and usage accumulated.
+func (p *Proxy) addChunk(asm *streamAssembler, data []byte, prov provider.Provider) {
+	asm.add(data)
+
+	// Best-effort content extraction from the JSON payload
+	p.extractContentFromJSON(data, prov.Name(), &asm.content)
+
+	// Accumulate usage from stream events (Anthropic splits usage across events)
+	p.extractUsageFromSSE(data, prov.Name(), &asm.usage, &asm.meta)
+}
 
-		finalResp := p.reconstructStreamedResponse(allChunks, fullContent, streamUsage, meta, prov)
-		if finalResp != nil {
-			p.workerPool.Enqueue(worker.Job{
-				Provider:  prov.Name(),
-				AgentName: agentName,
-				Req:       parsedReq,
-				Resp:      finalResp,
-			})
+// assemble builds the response of a completed stream.
+func (p *Proxy) assemble(asm *streamAssembler, prov provider.Provider) *llm.ChatResponse {
+	resp := p.reconstructStreamedResponse(asm.chunks, asm.content.String(), &asm.usage, &asm.meta, prov)
+	return asm.apply(resp)
+}
+
+// ReassembleStream rebuilds a streamed response from its data payloads, one
+// per line, the way the proxy does while relaying the stream. It is used to
+// re-parse raw captures of streamed turns.
+func ReassembleStream(prov provider.Provider, payloads []byte) *llm.ChatResponse {
+	p := &Proxy{logger: zap.NewNop()}
+	asm := newStreamAssembler(prov.Name())
+	for line := range bytes.SplitSeq(payloads, []byte("\n")) {
+		if len(line) > 0 {
+			p.addChunk(asm, line, prov)
 		}
 	}
+	if len(asm.chunks) == 0 {
+		return nil
+	}
+	return p.assemble(asm, prov)
 }
 
 // reconstructStreamedResponse attempts to build a ChatResponse from accumulated stream chunks.
@@ -622,6 +1156,79 @@ func (p *Proxy) reconstructStreamedResponse(chunks [][]byte, fullContent string,
 	return nil
 }
 
+// resolveRecord reports whether a request's turn is recorded and returns the
+// request path without its record prefix. Recording is turned off by the
+// record header, or by a "/record/off" path prefix, which tapes start
+// --record=off wraps agents' base URLs in.
+func resolveRecord(path, headerValue string) (bool, string) {
+	record := !strings.EqualFold(strings.TrimSpace(headerValue), header.RecordOff)
+
+	if rest, ok := strings.CutPrefix(path, recordOffPath); ok && (rest == "" || rest[0] == '/') {
+		return false, "/" + strings.TrimPrefix(rest, "/")
+	}
+	return record, path
+}
+
+// resolveRun returns the agent run a request belongs to and the request path
+// without its run prefix. The run is named by the run header, or else by a
+// "/runs/<id>" path prefix, which tapes start wraps agents' base URLs in for
+// agents that cannot send custom headers. The run is copied, as fiber reuses
+// the buffers of the path and headers once the handler returns.
+func resolveRun(path, headerValue string) (string, string) {
+	run := strings.TrimSpace(headerValue)
+
+	if remainder, ok := strings.CutPrefix(path, runPathPrefix); ok {
+		id, rest, _ := strings.Cut(remainder, "/")
+		if id = strings.TrimSpace(id); id != "" {
+			if run == "" {
+				run = id
+			}
+			path = "/" + rest
+		}
+	}
+	return strings.Clone(run), path
+}
+
+// resolveProject returns the project a request was made in and the request
+// path without its project prefix. The project is named by the project
+// header, or else by a "/projects/<name>" path prefix with the name path
+// escaped, which tapes start wraps agents' base URLs in. It is copied like
+// the run in resolveRun.
+func resolveProject(path, headerValue string) (string, string) {
+	project := strings.TrimSpace(headerValue)
+
+	if remainder, ok := strings.CutPrefix(path, projectPathPrefix); ok {
+		name, rest, _ := strings.Cut(remainder, "/")
+		if unescaped, err := url.PathUnescape(name); err == nil {
+			name = unescaped
+		}
+		if name = strings.TrimSpace(name); name != "" {
+			if project == "" {
+				project = name
+			}
+			path = "/" + rest
+		}
+	}
+	return strings.Clone(project), path
+}
+
+// resolveAgentVersion returns the version of the agent a request came from,
+// named by the agent version header, or else by an "@<version>" suffix on
+// the agent in an "/agents/<name>@<version>" path prefix.
+func resolveAgentVersion(path, headerValue string) string {
+	if version := strings.TrimSpace(headerValue); version != "" {
+		return strings.Clone(version)
+	}
+
+	remainder, ok := strings.CutPrefix(path, agentPathPrefix)
+	if !ok {
+		return ""
+	}
+	agent, _, _ := strings.Cut(remainder, "/")
+	_, version, _ := strings.Cut(agent, "@")
+	return strings.Clone(strings.TrimSpace(version))
+}
+
 func (p *Proxy) resolveAgent(path, headerValue string) (string, string, string) {
 	agent := strings.TrimSpace(headerValue)
 	if agent != "" {
@@ -638,7 +1245,8 @@ func (p *Proxy) resolveAgent(path, headerValue string) (string, string, string)
 	}
 
 	parts := strings.SplitN(remainder, "/", 2)
-	agent = strings.TrimSpace(parts[0])
+	agent, _, _ = strings.Cut(parts[0], "@")
+	agent = strings.TrimSpace(agent)
 	if agent == "" {
 		return "", "", path
 	}
@@ -745,3 +1353,10 @@ func isOpenAIAuthPath(path string) bool {
 	}
 	return false
 }
+
+// recordSpanError marks the span in ctx as failed with err.
+func recordSpanError(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -164,6 +164,12 @@ var _ = Describe("SSE Streaming Proxy", func() {
 			Expect(leaves[0].Bucket.Role).To(Equal("assistant"))
 			// The accumulated content from all SSE chunks
 			Expect(leaves[0].Bucket.ExtractText()).To(Equal("Hello world!"))
+
+			timing := leaves[0].Timing
+			Expect(timing).NotTo(BeNil())
+			Expect(timing.FirstChunkAt).To(BeTemporally(">=", timing.RequestStartedAt))
+			Expect(timing.ResponseCompletedAt).To(BeTemporally(">=", timing.FirstChunkAt))
+			Expect(nodes[0].Timing == nil || nodes[1].Timing == nil).To(BeTrue(), "only the response node has timing")
 		})
 	})
 
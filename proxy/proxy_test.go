@@ -1,6 +1,7 @@
 package proxy
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,9 +14,12 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/zap"
 
+	"github.com/papercomputeco/tapes/pkg/capture"
 	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/storage"
 	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
 	"github.com/papercomputeco/tapes/proxy/header"
+	"github.com/papercomputeco/tapes/proxy/worker"
 )
 
 // ollamaTestRequest is a minimal Ollama-format request for test fixtures.
@@ -227,7 +231,7 @@ var _ = Describe("Non-Streaming Proxy", func() {
 			Expect(string(body)).To(ContainSubstring("model not found"))
 		})
 
-		It("does not store any nodes", func() {
+		It("stores the request with an error node in place of the response", func() {
 			reqBody := makeOllamaRequestBody("nonexistent", []ollamaTestMessage{
 				{Role: "user", Content: "hello"},
 			}, boolPtr(false))
@@ -240,9 +244,21 @@ var _ = Describe("Non-Streaming Proxy", func() {
 			p = nil
 
 			ctx := GinkgoT().Context()
-			nodes, err := driver.List(ctx)
+			leaves, err := driver.Leaves(ctx)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(nodes).To(BeEmpty())
+			Expect(leaves).To(HaveLen(1))
+
+			errorNode := leaves[0]
+			Expect(errorNode.Bucket.Type).To(Equal("error"))
+			Expect(errorNode.Bucket.Model).To(Equal("nonexistent"))
+			Expect(errorNode.StopReason).To(Equal("error"))
+			Expect(errorNode.Error).To(Equal(&llm.UpstreamError{Status: http.StatusInternalServerError, Message: "model not found"}))
+			Expect(errorNode.Timing).NotTo(BeNil())
+
+			ancestry, err := driver.Ancestry(ctx, errorNode.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ancestry).To(HaveLen(2))
+			Expect(ancestry[1].Bucket.ExtractText()).To(Equal("hello"))
 		})
 	})
 
@@ -346,6 +362,245 @@ var _ = Describe("Non-Streaming Proxy", func() {
 
 			Expect(receivedHeaders.Get(header.AgentNameHeader)).To(BeEmpty())
 		})
+
+		It("continues the client's trace upstream and records its trace ID", func() {
+			reqBody := makeOllamaRequestBody("test-model", []ollamaTestMessage{
+				{Role: "user", Content: "hello"},
+			}, boolPtr(false))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(string(reqBody)))
+			req.Header.Set("Traceparent", "00-4bf92f3577b34a736d9e2b3ad0e3c1a2-00f067aa0ba902b7-01")
+
+			resp, err := p.server.Test(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(receivedHeaders.Get("Traceparent")).To(HavePrefix("00-4bf92f3577b34a736d9e2b3ad0e3c1a2-"))
+
+			p.Close()
+			p = nil
+
+			nodes, err := driver.List(GinkgoT().Context())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(HaveLen(2))
+			for _, node := range nodes {
+				Expect(node.TraceID).To(Equal("4bf92f3577b34a736d9e2b3ad0e3c1a2"))
+			}
+		})
+
+		It("attributes the turn to the user header without forwarding it", func() {
+			reqBody := makeOllamaRequestBody("test-model", []ollamaTestMessage{
+				{Role: "user", Content: "hello"},
+			}, boolPtr(false))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(string(reqBody)))
+			req.Header.Set(header.UserHeader, "alice")
+
+			resp, err := p.server.Test(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+
+			Expect(receivedHeaders.Get(header.UserHeader)).To(BeEmpty())
+
+			p.Close()
+			p = nil
+
+			nodes, err := driver.List(GinkgoT().Context())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(HaveLen(2))
+			for _, node := range nodes {
+				Expect(node.User).To(Equal("alice"))
+			}
+		})
+	})
+})
+
+var _ = Describe("Dead Letters", func() {
+	var (
+		p        *Proxy
+		driver   *inmemory.Driver
+		upstream *httptest.Server
+	)
+
+	AfterEach(func() {
+		if p != nil {
+			p.Close()
+		}
+		if upstream != nil {
+			upstream.Close()
+		}
+	})
+
+	It("records an unparseable response with its request and headers", func() {
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("not json"))
+		}))
+		p, driver = newTestProxy(upstream.URL)
+
+		reqBody := makeOllamaRequestBody("test-model", []ollamaTestMessage{
+			{Role: "user", Content: "hello"},
+		}, boolPtr(false))
+		req := httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(string(reqBody)))
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		// Dead letters are stored in the background until the proxy closes.
+		p.Close()
+		p = nil
+
+		letters, err := driver.ListDeadLetters(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters).To(HaveLen(1))
+		Expect(letters[0].Stage).To(Equal(storage.DeadLetterStageResponse))
+		Expect(letters[0].Provider).To(Equal("ollama"))
+		Expect(letters[0].Path).To(Equal("/api/chat"))
+		Expect(letters[0].Request).To(Equal(string(reqBody)))
+		Expect(letters[0].Response).To(Equal("not json"))
+		Expect(letters[0].Error).NotTo(BeEmpty())
+		Expect(letters[0].Headers).To(HaveKeyWithValue("Content-Type", "application/json"))
+		Expect(letters[0].Headers).NotTo(HaveKey("Authorization"))
+	})
+
+	It("records an unparseable request", func() {
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write(makeOllamaResponseBody("test-model", "assistant", "hi"))
+		}))
+		p, driver = newTestProxy(upstream.URL)
+
+		resp, err := p.server.Test(httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader("{broken")))
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		p.Close()
+		p = nil
+
+		letters, err := driver.ListDeadLetters(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters).To(HaveLen(1))
+		Expect(letters[0].Stage).To(Equal(storage.DeadLetterStageRequest))
+		Expect(letters[0].Request).To(Equal("{broken"))
+	})
+
+	It("records an unparseable streamed request with the stream it was answered with", func() {
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			fmt.Fprintln(w, `{"model":"test-model","message":{"role":"assistant","content":"hi"},"done":true}`)
+		}))
+		p, driver = newTestProxy(upstream.URL)
+
+		// Ollama streams by default, so the unparseable request is streamed.
+		resp, err := p.server.Test(httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader("{broken")), -1)
+		Expect(err).NotTo(HaveOccurred())
+		body, err := io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(string(body)).To(ContainSubstring(`"content":"hi"`))
+
+		p.Close()
+		p = nil
+
+		letters, err := driver.ListDeadLetters(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters).To(HaveLen(1))
+		Expect(letters[0].Stage).To(Equal(storage.DeadLetterStageRequest))
+		Expect(letters[0].Request).To(Equal("{broken"))
+		Expect(letters[0].Response).To(ContainSubstring(`"content":"hi"`))
+	})
+
+	It("records a streamed response that cannot be assembled", func() {
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			fmt.Fprintln(w, "not json")
+		}))
+		p, driver = newTestProxy(upstream.URL)
+
+		reqBody := makeOllamaRequestBody("test-model", []ollamaTestMessage{
+			{Role: "user", Content: "hello"},
+		}, boolPtr(true))
+		resp, err := p.server.Test(httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(string(reqBody))), -1)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = io.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+
+		p.Close()
+		p = nil
+
+		letters, err := driver.ListDeadLetters(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters).To(HaveLen(1))
+		Expect(letters[0].Stage).To(Equal(storage.DeadLetterStageResponse))
+		Expect(letters[0].Request).To(Equal(string(reqBody)))
+		Expect(letters[0].Response).To(Equal("not json"))
+		Expect(letters[0].Error).To(Equal(errStreamAssembly.Error()))
+	})
+})
+
+var _ = Describe("Usage Endpoints", func() {
+	var (
+		p        *Proxy
+		driver   *inmemory.Driver
+		upstream *httptest.Server
+	)
+
+	BeforeEach(func() {
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/v1/embeddings"))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"object":"list","data":[{"object":"embedding","index":0,"embedding":[0.1,0.2,0.3]}],"model":"text-embedding-3-small","usage":{"prompt_tokens":4,"total_tokens":4}}`))
+		}))
+
+		var err error
+		driver = inmemory.NewDriver()
+		p, err = New(Config{ListenAddr: ":0", UpstreamURL: upstream.URL, ProviderType: "openai"}, driver, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if p != nil {
+			p.Close()
+		}
+		upstream.Close()
+	})
+
+	It("records embeddings usage without storing the vectors", func() {
+		req := httptest.NewRequest(http.MethodPost, "/v1/embeddings",
+			strings.NewReader(`{"model":"text-embedding-3-small","input":"hello tapes"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(string(body)).To(ContainSubstring("0.1,0.2,0.3"))
+
+		p.Close()
+		p = nil
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(1))
+		Expect(nodes[0].Bucket.Type).To(Equal(llm.RecordEmbedding))
+		Expect(nodes[0].Bucket.Model).To(Equal("text-embedding-3-small"))
+		Expect(nodes[0].Bucket.Provider).To(Equal("openai"))
+		Expect(nodes[0].Bucket.ExtractText()).To(ContainSubstring("1 input(s), 11 bytes, 3 dimensions"))
+		Expect(nodes[0].Bucket.ExtractText()).NotTo(ContainSubstring("hello tapes"))
+		Expect(nodes[0].Usage).NotTo(BeNil())
+		Expect(nodes[0].Usage.PromptTokens).To(Equal(4))
+		Expect(nodes[0].Timing).NotTo(BeNil())
+
+		letters, err := driver.ListDeadLetters(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters).To(BeEmpty())
 	})
 })
 
@@ -446,7 +701,7 @@ var _ = Describe("Streaming Proxy", func() {
 			p, driver = newTestProxy(upstream.URL)
 		})
 
-		It("returns the error to the client without storing", func() {
+		It("returns the error to the client and stores an error node", func() {
 			reqBody := makeOllamaRequestBody("bad-model", []ollamaTestMessage{
 				{Role: "user", Content: "hello"},
 			}, boolPtr(true))
@@ -465,9 +720,12 @@ var _ = Describe("Streaming Proxy", func() {
 			p = nil
 
 			ctx := GinkgoT().Context()
-			nodes, err := driver.List(ctx)
+			leaves, err := driver.Leaves(ctx)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(nodes).To(BeEmpty())
+			Expect(leaves).To(HaveLen(1))
+			Expect(leaves[0].Error).NotTo(BeNil())
+			Expect(leaves[0].Error.Status).To(Equal(http.StatusBadRequest))
+			Expect(leaves[0].Error.Message).To(Equal("invalid model"))
 		})
 	})
 
@@ -725,6 +983,21 @@ var _ = Describe("New", func() {
 		Expect(p.defaultProv.Name()).To(Equal("ollama"))
 		p.Close()
 	})
+
+	It("sizes the storage queue from the queue policy", func() {
+		logger, _ := zap.NewDevelopment()
+		driver := inmemory.NewDriver()
+
+		p, err := New(Config{
+			ListenAddr:   ":0",
+			UpstreamURL:  "http://localhost:11434",
+			ProviderType: "ollama",
+			Queue:        QueuePolicy{Workers: 1, Size: 8, Overflow: worker.OverflowBlock},
+		}, driver, logger)
+		Expect(err).NotTo(HaveOccurred())
+		defer p.Close()
+		Expect(p.Stats().Capacity).To(Equal(8))
+	})
 })
 
 var _ = Describe("End-to-End Multi-Turn Proxy", func() {
@@ -815,6 +1088,264 @@ var _ = Describe("End-to-End Multi-Turn Proxy", func() {
 	})
 })
 
+var _ = Describe("Concurrent Sessions", func() {
+	var (
+		p        *Proxy
+		driver   *inmemory.Driver
+		upstream *httptest.Server
+	)
+
+	BeforeEach(func() {
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(makeOllamaResponseBody("test-model", "assistant", "2+2 equals 4."))
+		}))
+		p, driver = newTestProxy(upstream.URL)
+	})
+
+	AfterEach(func() {
+		if p != nil {
+			p.Close()
+		}
+		upstream.Close()
+	})
+
+	send := func(headerName, session string, messages []ollamaTestMessage) {
+		req := httptest.NewRequest(http.MethodPost, "/api/chat",
+			strings.NewReader(string(makeOllamaRequestBody("test-model", messages, boolPtr(false)))))
+		req.Header.Set(headerName, session)
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+	}
+
+	It("stores identical conversations of different sessions apart", func() {
+		first := []ollamaTestMessage{{Role: "user", Content: "What is 2+2?"}}
+		send(header.SessionHeader, "run-a", first)
+		send(header.SessionHeader, "run-b", first)
+		send(header.SessionHeader, "run-a", append(first,
+			ollamaTestMessage{Role: "assistant", Content: "2+2 equals 4."},
+			ollamaTestMessage{Role: "user", Content: "And 3+3?"},
+		))
+
+		p.Close()
+		p = nil
+
+		ctx := GinkgoT().Context()
+		roots, err := driver.Roots(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roots).To(HaveLen(2))
+
+		leaves, err := driver.Leaves(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(HaveLen(2))
+		for _, leaf := range leaves {
+			ancestry, err := driver.Ancestry(ctx, leaf.Hash)
+			Expect(err).NotTo(HaveOccurred())
+			for _, n := range ancestry {
+				Expect(n.Bucket.SessionID).To(Equal(leaf.Bucket.SessionID))
+			}
+			if leaf.Bucket.SessionID == "run-a" {
+				Expect(ancestry).To(HaveLen(4))
+			} else {
+				Expect(ancestry).To(HaveLen(2))
+			}
+		}
+	})
+
+	It("falls back to the session ID Codex sends", func() {
+		send(header.CodexSessionHeader, "codex-1", []ollamaTestMessage{{Role: "user", Content: "What is 2+2?"}})
+
+		p.Close()
+		p = nil
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(2))
+		for _, n := range nodes {
+			Expect(n.Bucket.SessionID).To(Equal("codex-1"))
+		}
+	})
+
+	It("records the agent run named by a run path prefix on every node", func() {
+		req := httptest.NewRequest(http.MethodPost, "/runs/run-1/agents/claude/api/chat",
+			strings.NewReader(string(makeOllamaRequestBody("test-model",
+				[]ollamaTestMessage{{Role: "user", Content: "What is 2+2?"}}, boolPtr(false)))))
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		p.Close()
+		p = nil
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(2))
+		for _, n := range nodes {
+			Expect(n.RunID).To(Equal("run-1"))
+			Expect(n.Bucket.AgentName).To(Equal("claude"))
+			Expect(n.Bucket.SessionID).To(Equal("run-1"))
+		}
+	})
+
+	It("prefers the run header to a run path prefix", func() {
+		req := httptest.NewRequest(http.MethodPost, "/runs/run-1/api/chat",
+			strings.NewReader(string(makeOllamaRequestBody("test-model",
+				[]ollamaTestMessage{{Role: "user", Content: "What is 2+2?"}}, boolPtr(false)))))
+		req.Header.Set(header.AgentRunHeader, "run-2")
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		p.Close()
+		p = nil
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(2))
+		for _, n := range nodes {
+			Expect(n.RunID).To(Equal("run-2"))
+		}
+	})
+
+	It("records the project and agent version named by the path on every node", func() {
+		req := httptest.NewRequest(http.MethodPost, "/runs/run-1/projects/my%20repo/agents/aider@0.86.1/api/chat",
+			strings.NewReader(string(makeOllamaRequestBody("test-model",
+				[]ollamaTestMessage{{Role: "user", Content: "What is 2+2?"}}, boolPtr(false)))))
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		p.Close()
+		p = nil
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(2))
+		for _, n := range nodes {
+			Expect(n.Project).To(Equal("my repo"))
+			Expect(n.AgentVersion).To(Equal("0.86.1"))
+			Expect(n.Bucket.AgentName).To(Equal("aider"))
+		}
+	})
+
+	It("prefers the project and agent version headers to the path", func() {
+		req := httptest.NewRequest(http.MethodPost, "/projects/tapes/agents/aider@0.86.1/api/chat",
+			strings.NewReader(string(makeOllamaRequestBody("test-model",
+				[]ollamaTestMessage{{Role: "user", Content: "What is 2+2?"}}, boolPtr(false)))))
+		req.Header.Set(header.ProjectHeader, "other")
+		req.Header.Set(header.AgentVersionHeader, "0.87.0")
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		p.Close()
+		p = nil
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(2))
+		for _, n := range nodes {
+			Expect(n.Project).To(Equal("other"))
+			Expect(n.AgentVersion).To(Equal("0.87.0"))
+		}
+	})
+})
+
+var _ = Describe("Unrecorded Requests", func() {
+	var (
+		p        *Proxy
+		driver   *inmemory.Driver
+		upstream *httptest.Server
+	)
+
+	BeforeEach(func() {
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/api/chat"))
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(makeOllamaResponseBody("test-model", "assistant", "2+2 equals 4."))
+		}))
+		p, driver = newTestProxy(upstream.URL)
+	})
+
+	AfterEach(func() {
+		upstream.Close()
+	})
+
+	send := func(path, record string) {
+		req := httptest.NewRequest(http.MethodPost, path,
+			strings.NewReader(string(makeOllamaRequestBody("test-model",
+				[]ollamaTestMessage{{Role: "user", Content: "What is 2+2?"}}, boolPtr(false)))))
+		if record != "" {
+			req.Header.Set(header.RecordHeader, record)
+		}
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(string(body)).To(ContainSubstring("2+2 equals 4."))
+	}
+
+	It("forwards requests under the record off prefix without storing them", func() {
+		send("/record/off/runs/run-1/agents/claude/api/chat", "")
+		send("/api/chat", header.RecordOff)
+		Expect(p.Close()).To(Succeed())
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(BeEmpty())
+		Expect(p.workerPool.Stats().Unrecorded).To(Equal(uint64(2)))
+		Expect(p.Metrics()).To(HaveKeyWithValue("tapes_turns_unrecorded_total", 2.0))
+		Expect(p.Metrics()).To(HaveKeyWithValue("tapes_turns_processed_total", 0.0))
+	})
+})
+
+var _ = Describe("Capture Rules", func() {
+	It("forwards turns of an excluded agent without storing them", func() {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(makeOllamaResponseBody("test-model", "assistant", "2+2 equals 4."))
+		}))
+		defer upstream.Close()
+
+		rules, err := capture.NewRules([]capture.RuleSpec{{Name: "no_codex", Agent: `^codex$`}})
+		Expect(err).NotTo(HaveOccurred())
+		driver := inmemory.NewDriver()
+		p, err := New(Config{
+			ListenAddr:   ":0",
+			UpstreamURL:  upstream.URL,
+			ProviderType: "ollama",
+			CaptureRules: rules,
+		}, driver, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, path := range []string{"/agents/codex/api/chat", "/agents/claude/api/chat"} {
+			req := httptest.NewRequest(http.MethodPost, path,
+				strings.NewReader(string(makeOllamaRequestBody("test-model",
+					[]ollamaTestMessage{{Role: "user", Content: "What is 2+2?"}}, boolPtr(false)))))
+			resp, err := p.server.Test(req)
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		}
+		Expect(p.Close()).To(Succeed())
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(2))
+		for _, node := range nodes {
+			Expect(node.Bucket.AgentName).To(Equal("claude"))
+		}
+		Expect(p.Stats().Skipped).To(Equal(map[string]uint64{"no_codex": 1}))
+	})
+})
+
 var _ = Describe("Storage Provider Metadata", func() {
 	var (
 		p        *Proxy
@@ -928,3 +1459,49 @@ var _ = Describe("Storage Provider Metadata", func() {
 		Expect(leaves[0].StopReason).To(Equal("stop"))
 	})
 })
+
+var _ = Describe("Middleware", func() {
+	It("passes the request path and captured headers to middleware", func() {
+		upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"model":"test-model","message":{"role":"assistant","content":"hi"},"done":true}`))
+		}))
+		defer upstream.Close()
+
+		seen := make(chan worker.Job, 1)
+		logger, _ := zap.NewDevelopment()
+		driver := inmemory.NewDriver()
+		p, err := New(Config{
+			ListenAddr:   ":0",
+			UpstreamURL:  upstream.URL,
+			ProviderType: "ollama",
+			Middleware: []worker.Middleware{func(_ context.Context, job *worker.Job) (*worker.Job, error) {
+				seen <- *job
+				return nil, nil
+			}},
+		}, driver, logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		reqBody := makeOllamaRequestBody("test-model", []ollamaTestMessage{
+			{Role: "user", Content: "hello"},
+		}, boolPtr(false))
+		req := httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(string(reqBody)))
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("X-Team", "infra")
+
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(p.Close()).To(Succeed())
+
+		var job worker.Job
+		Eventually(seen).Should(Receive(&job))
+		Expect(job.Path).To(Equal("/api/chat"))
+		Expect(job.Headers).To(HaveKeyWithValue("X-Team", "infra"))
+		Expect(job.Headers).NotTo(HaveKey("Authorization"))
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(BeEmpty())
+	})
+})
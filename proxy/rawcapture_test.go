@@ -0,0 +1,146 @@
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+var _ = Describe("Raw capture", func() {
+	var (
+		p        *Proxy
+		driver   *inmemory.Driver
+		upstream *httptest.Server
+	)
+
+	newRawCaptureProxy := func(providerType string, rawCapture bool) {
+		driver = inmemory.NewDriver()
+		var err error
+		p, err = New(Config{
+			ListenAddr:   ":0",
+			UpstreamURL:  upstream.URL,
+			ProviderType: providerType,
+			RawCapture:   rawCapture,
+		}, driver, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	AfterEach(func() {
+		if p != nil {
+			p.Close()
+		}
+		upstream.Close()
+	})
+
+	Context("with a non-streamed response", func() {
+		respBody := makeOllamaResponseBody("test-model", "assistant", "2+2 equals 4.")
+
+		BeforeEach(func() {
+			upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write(respBody)
+			}))
+		})
+
+		send := func() []byte {
+			reqBody := makeOllamaRequestBody("test-model", []ollamaTestMessage{
+				{Role: "user", Content: "What is 2+2?"},
+			}, boolPtr(false))
+			resp, err := p.server.Test(httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(string(reqBody))))
+			Expect(err).NotTo(HaveOccurred())
+			resp.Body.Close()
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			p.Close()
+			p = nil
+			return reqBody
+		}
+
+		It("stores the original payloads keyed by the response node", func() {
+			newRawCaptureProxy("ollama", true)
+			reqBody := send()
+
+			captures, err := driver.ListRawCaptures(GinkgoT().Context(), time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(captures).To(HaveLen(1))
+
+			rc := captures[0]
+			Expect(rc.Provider).To(Equal("ollama"))
+			Expect(rc.Path).To(Equal("/api/chat"))
+			Expect(rc.Streamed).To(BeFalse())
+			Expect(rc.Request).To(Equal(reqBody))
+			Expect(rc.Response).To(Equal(respBody))
+
+			node, err := driver.Get(GinkgoT().Context(), rc.NodeHash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(node.Bucket.Role).To(Equal("assistant"))
+		})
+
+		It("stores nothing when raw capture is disabled", func() {
+			newRawCaptureProxy("ollama", false)
+			send()
+
+			captures, err := driver.ListRawCaptures(GinkgoT().Context(), time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(captures).To(BeEmpty())
+		})
+	})
+
+	Context("with a streamed response", func() {
+		BeforeEach(func() {
+			upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				for _, event := range []string{
+					`data: {"id":"chatcmpl-1","object":"chat.completion.chunk","model":"gpt-4","choices":[{"index":0,"delta":{"role":"assistant","content":"Hello"}}]}`,
+					`data: {"id":"chatcmpl-1","object":"chat.completion.chunk","model":"gpt-4","choices":[{"index":0,"delta":{"content":" world"},"finish_reason":"stop"}]}`,
+					`data: [DONE]`,
+				} {
+					fmt.Fprint(w, event+"\n\n")
+				}
+			}))
+			newRawCaptureProxy("openai", true)
+		})
+
+		It("stores the stream's data payloads, which reassemble to the stored response", func() {
+			reqBody := makeOpenAIRequestBody("gpt-4", []openaiTestMsgEntry{
+				{Role: "user", Content: "Say hello"},
+			}, boolPtr(true))
+			resp, err := p.server.Test(httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(string(reqBody))), -1)
+			Expect(err).NotTo(HaveOccurred())
+			_, _ = io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			p.Close()
+			p = nil
+
+			captures, err := driver.ListRawCaptures(GinkgoT().Context(), time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(captures).To(HaveLen(1))
+
+			rc := captures[0]
+			Expect(rc.Streamed).To(BeTrue())
+			Expect(rc.Request).To(Equal(reqBody))
+			Expect(strings.Count(string(rc.Response), "\n")).To(Equal(1))
+
+			prov, err := provider.New("openai")
+			Expect(err).NotTo(HaveOccurred())
+			reassembled := ReassembleStream(prov, rc.Response)
+			Expect(reassembled).NotTo(BeNil())
+			Expect(reassembled.Message.GetText()).To(Equal("Hello world"))
+
+			node, err := driver.Get(GinkgoT().Context(), rc.NodeHash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(node.Bucket.ExtractText()).To(Equal("Hello world"))
+		})
+	})
+})
@@ -0,0 +1,202 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+)
+
+// Default backoff bounds used when a RetryPolicy leaves them unset.
+const (
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay  = 30 * time.Second
+)
+
+// errBuildRequest wraps failures to construct an upstream request, which are
+// reported to the client as internal errors rather than upstream failures.
+var errBuildRequest = errors.New("building upstream request")
+
+// RetryPolicy configures retrying upstream requests that were throttled or
+// hit an overloaded or failing provider.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// BaseDelay is the backoff before the first retry, doubled for each
+	// later retry (defaults to DefaultRetryBaseDelay).
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff between attempts (defaults to
+	// DefaultRetryMaxDelay). A Retry-After longer than MaxDelay is not
+	// waited out; the error is returned to the client instead.
+	MaxDelay time.Duration
+}
+
+func (r RetryPolicy) enabled() bool {
+	return r.MaxAttempts > 1
+}
+
+// delay returns how long to wait before the given retry, starting at 1. It
+// honors retryAfter when the upstream sent one and reports false when that is
+// longer than the policy allows.
+func (r RetryPolicy) delay(retry int, retryAfter time.Duration) (time.Duration, bool) {
+	maxDelay := r.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+	if retryAfter > 0 {
+		return retryAfter, retryAfter <= maxDelay
+	}
+
+	d := r.BaseDelay
+	if d <= 0 {
+		d = DefaultRetryBaseDelay
+	}
+	for i := 1; i < retry && d < maxDelay; i++ {
+		d *= 2
+	}
+	d = min(d, maxDelay)
+
+	// Jitter keeps concurrent agents from retrying in lockstep.
+	return d/2 + rand.N(d/2+1), true
+}
+
+// retryPolicy returns the retry policy for a provider type.
+func (p *Proxy) retryPolicy(providerName string) RetryPolicy {
+	if policy, ok := p.config.ProviderRetries[providerName]; ok {
+		return policy
+	}
+	return p.config.Retry
+}
+
+// shouldRetry reports whether an upstream error is transient. Anthropic's
+// overloaded_error and rate limits are retried, but OpenAI's
+// insufficient_quota 429 is not, since waiting does not restore credit.
+func shouldRetry(upstreamErr *llm.UpstreamError) bool {
+	switch upstreamErr.Type {
+	case "overloaded_error":
+		return true
+	case "insufficient_quota":
+		return false
+	}
+
+	switch upstreamErr.Status {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+		529: // Anthropic overloaded
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the delay requested by the upstream. OpenAI's
+// retry-after-ms header is preferred for its precision.
+func retryAfter(h http.Header, now time.Time) time.Duration {
+	if ms, err := strconv.ParseFloat(strings.TrimSpace(h.Get("retry-after-ms")), 64); err == nil && ms > 0 {
+		return time.Duration(ms * float64(time.Millisecond))
+	}
+	return time.Duration(parseRetryAfter(h.Get("Retry-After"), now)) * time.Second
+}
+
+// sendUpstream sends the request built by newRequest, retrying transient
+// upstream errors according to the provider's retry policy. Each failed
+// attempt is stored as an error node so throttling shows up in analytics.
+// A 401 is retried once when RefreshOAuth refreshes the request's token.
+//
+// The returned attempt numbers the final attempt, or is zero when retries are
+// disabled. The final response, successful or not, is returned to the caller
+// with its body unread.
+func (p *Proxy) sendUpstream(ctx context.Context, c *fiber.Ctx, prov provider.Provider, agentName, path string, parsedReq *llm.ChatRequest, newRequest func() (*http.Request, error)) (*http.Response, int, error) {
+	policy := p.retryPolicy(prov.Name())
+	refreshed := false
+
+	for attempt := 1; ; attempt++ {
+		httpReq, err := newRequest()
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %w", errBuildRequest, err)
+		}
+
+		startTime := time.Now()
+		httpResp, err := p.httpClient.Do(httpReq)
+		if err != nil {
+			return nil, 0, err
+		}
+		if httpResp.StatusCode == http.StatusUnauthorized && !refreshed {
+			refreshed = true
+			if p.refreshOAuthToken(ctx, c, prov) {
+				httpResp.Body.Close()
+				// Retrying with a refreshed token is not a retry attempt.
+				attempt--
+				continue
+			}
+		}
+		if !policy.enabled() {
+			return httpResp, 0, nil
+		}
+		if httpResp.StatusCode < http.StatusBadRequest || attempt >= policy.MaxAttempts {
+			return httpResp, attempt, nil
+		}
+
+		respBody, err := io.ReadAll(httpResp.Body)
+		httpResp.Body.Close()
+		if err != nil {
+			return nil, 0, err
+		}
+		httpResp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+		now := time.Now()
+		upstreamErr := parseUpstreamError(httpResp.StatusCode, httpResp.Header.Get("Retry-After"), respBody, now)
+		if !shouldRetry(upstreamErr) {
+			return httpResp, attempt, nil
+		}
+		wait, ok := policy.delay(attempt, retryAfter(httpResp.Header, now))
+		if !ok {
+			return httpResp, attempt, nil
+		}
+
+		p.logger.Info("retrying upstream request",
+			zap.String("provider", prov.Name()),
+			zap.Int("status", httpResp.StatusCode),
+			zap.String("error_type", upstreamErr.Type),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", wait),
+		)
+		trace.SpanFromContext(ctx).AddEvent("upstream retry", trace.WithAttributes(
+			attribute.Int("http.response.status_code", httpResp.StatusCode),
+			attribute.Int("tapes.retry.attempt", attempt),
+			attribute.Int64("tapes.retry.delay_ms", wait.Milliseconds()),
+		))
+		if parsedReq != nil {
+			p.enqueueUpstreamError(ctx, c, prov, agentName, path, parsedReq, httpResp, respBody, attempt,
+				&llm.Timing{RequestStartedAt: startTime, ResponseCompletedAt: now})
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-httpReq.Context().Done():
+			timer.Stop()
+			return nil, 0, httpReq.Context().Err()
+		}
+	}
+}
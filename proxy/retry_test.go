@@ -0,0 +1,190 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+var _ = Describe("shouldRetry", func() {
+	DescribeTable("classifies upstream errors",
+		func(upstreamErr llm.UpstreamError, expected bool) {
+			Expect(shouldRetry(&upstreamErr)).To(Equal(expected))
+		},
+		Entry("rate limit", llm.UpstreamError{Status: 429, Type: "rate_limit_error"}, true),
+		Entry("Anthropic overloaded", llm.UpstreamError{Status: 529, Type: "overloaded_error"}, true),
+		Entry("overloaded under another status", llm.UpstreamError{Status: 400, Type: "overloaded_error"}, true),
+		Entry("server error", llm.UpstreamError{Status: 503}, true),
+		Entry("exhausted quota", llm.UpstreamError{Status: 429, Type: "insufficient_quota"}, false),
+		Entry("bad request", llm.UpstreamError{Status: 400, Type: "invalid_request_error"}, false),
+		Entry("unauthorized", llm.UpstreamError{Status: 401}, false),
+	)
+})
+
+var _ = Describe("RetryPolicy", func() {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	It("backs off exponentially with jitter up to the maximum delay", func() {
+		for retry, ceiling := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second} {
+			d, ok := policy.delay(retry, 0)
+			Expect(ok).To(BeTrue())
+			Expect(d).To(BeNumerically(">=", ceiling/2))
+			Expect(d).To(BeNumerically("<=", ceiling))
+		}
+	})
+
+	It("honors Retry-After within the maximum delay", func() {
+		d, ok := policy.delay(1, 3*time.Second)
+		Expect(ok).To(BeTrue())
+		Expect(d).To(Equal(3 * time.Second))
+
+		_, ok = policy.delay(1, time.Minute)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("prefers retry-after-ms", func() {
+		h := http.Header{}
+		h.Set("Retry-After", "2")
+		Expect(retryAfter(h, time.Now())).To(Equal(2 * time.Second))
+
+		h.Set("retry-after-ms", "150")
+		Expect(retryAfter(h, time.Now())).To(Equal(150 * time.Millisecond))
+	})
+})
+
+var _ = Describe("Retrying upstream requests", func() {
+	var (
+		p        *Proxy
+		driver   *inmemory.Driver
+		upstream *httptest.Server
+		calls    atomic.Int32
+	)
+
+	newRetryingProxy := func(upstreamURL string) {
+		logger, _ := zap.NewDevelopment()
+		driver = inmemory.NewDriver()
+
+		var err error
+		p, err = New(
+			Config{
+				ListenAddr:   ":0",
+				UpstreamURL:  upstreamURL,
+				ProviderType: "ollama",
+				Retry:        RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second},
+			},
+			driver,
+			logger,
+		)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	// failThen answers the first failures requests with a throttling error
+	// and the rest with a reply.
+	failThen := func(failures int32, status int, body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			if calls.Add(1) <= failures {
+				w.Header().Set("retry-after-ms", "1")
+				w.WriteHeader(status)
+				w.Write([]byte(body))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(makeOllamaResponseBody("llama3", "assistant", "hi there"))
+		}
+	}
+
+	send := func(stream bool) *http.Response {
+		reqBody := makeOllamaRequestBody("llama3", []ollamaTestMessage{
+			{Role: "user", Content: "hello"},
+		}, boolPtr(stream))
+		resp, err := p.server.Test(httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(string(reqBody))), -1)
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	BeforeEach(func() {
+		calls.Store(0)
+	})
+
+	AfterEach(func() {
+		if p != nil {
+			p.Close()
+		}
+		if upstream != nil {
+			upstream.Close()
+		}
+	})
+
+	It("retries a throttled request and records the failed attempt", func() {
+		upstream = httptest.NewServer(failThen(1, http.StatusTooManyRequests, `{"error":"slow down"}`))
+		newRetryingProxy(upstream.URL)
+
+		resp := send(false)
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(calls.Load()).To(Equal(int32(2)))
+
+		p.Close()
+		p = nil
+
+		leaves, err := driver.Leaves(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(HaveLen(2))
+
+		var errorNodes []*llm.UpstreamError
+		for _, leaf := range leaves {
+			if leaf.Bucket.Type == "error" {
+				errorNodes = append(errorNodes, leaf.Error)
+			} else {
+				Expect(leaf.Bucket.ExtractText()).To(Equal("hi there"))
+			}
+		}
+		Expect(errorNodes).To(ConsistOf(&llm.UpstreamError{Status: http.StatusTooManyRequests, Message: "slow down", Attempt: 1}))
+	})
+
+	It("retries streaming requests", func() {
+		upstream = httptest.NewServer(failThen(2, 529, `{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`))
+		newRetryingProxy(upstream.URL)
+
+		resp := send(true)
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(calls.Load()).To(Equal(int32(3)))
+
+		p.Close()
+		p = nil
+
+		leaves, err := driver.Leaves(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(leaves).To(HaveLen(3))
+	})
+
+	It("returns the last error once attempts are exhausted", func() {
+		upstream = httptest.NewServer(failThen(5, http.StatusServiceUnavailable, `{"error":"unavailable"}`))
+		newRetryingProxy(upstream.URL)
+
+		resp := send(false)
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(calls.Load()).To(Equal(int32(3)))
+	})
+
+	It("does not retry an exhausted quota", func() {
+		upstream = httptest.NewServer(failThen(5, http.StatusTooManyRequests, `{"error":{"message":"You exceeded your current quota","type":"insufficient_quota"}}`))
+		newRetryingProxy(upstream.URL)
+
+		resp := send(false)
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusTooManyRequests))
+		Expect(calls.Load()).To(Equal(int32(1)))
+	})
+})
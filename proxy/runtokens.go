@@ -0,0 +1,93 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/papercomputeco/tapes/pkg/runtoken"
+	"github.com/papercomputeco/tapes/proxy/header"
+)
+
+// swapRunToken replaces a run token sent in the Authorization or X-Api-Key
+// header with the API key of provider, after checking that the token may be
+// used for the request. It returns the token, or "" for requests without
+// one, which are left as they are, as are all requests when run tokens are
+// not configured.
+//
+// The token's run is authoritative: a request naming another run, by run
+// header or path, is refused, and the run header is set to the token's run so
+// that the turn is recorded under it.
+func (p *Proxy) swapRunToken(c *fiber.Ctx, provider, run string) (string, runtoken.Info, error) {
+	if p.config.RunTokens == nil {
+		return "", runtoken.Info{}, nil
+	}
+
+	var (
+		found string
+		info  runtoken.Info
+	)
+	for _, h := range []struct {
+		name   string
+		prefix string
+	}{
+		{fiber.HeaderAuthorization, "Bearer "},
+		{"X-Api-Key", ""},
+	} {
+		token, ok := strings.CutPrefix(c.Get(h.name), h.prefix)
+		if !ok || !strings.HasPrefix(token, runtoken.Prefix) {
+			continue
+		}
+		// A token sent in both headers is only counted once.
+		if token != found {
+			var err error
+			if info, err = p.config.RunTokens.Authorize(token); err != nil {
+				return token, info, err
+			}
+			found = token
+		}
+		key, err := p.providerKey(provider)
+		if err != nil {
+			return token, info, err
+		}
+		c.Request().Header.Set(h.name, h.prefix+key)
+	}
+	if found == "" {
+		return "", info, nil
+	}
+	if run != "" && run != info.Run {
+		return found, info, runtoken.ErrRunMismatch
+	}
+	c.Request().Header.Set(header.AgentRunHeader, info.Run)
+	return found, info, nil
+}
+
+// providerKey returns the stored API key of provider that run tokens are
+// swapped for.
+func (p *Proxy) providerKey(provider string) (string, error) {
+	if p.config.ProviderKeys == nil {
+		return "", errors.New("no API keys are available for tapes run tokens")
+	}
+	key, err := p.config.ProviderKeys(provider)
+	if err != nil {
+		return "", fmt.Errorf("loading %s API key: %w", provider, err)
+	}
+	if key == "" {
+		return "", fmt.Errorf("no %s API key is stored for tapes run tokens: run 'tapes auth %s'", provider, provider)
+	}
+	return key, nil
+}
+
+// runTokenStatus is the status requests are refused with for err, returned
+// when checking their run token.
+func runTokenStatus(err error) int {
+	switch {
+	case errors.Is(err, runtoken.ErrOverBudget):
+		return fiber.StatusPaymentRequired
+	case errors.Is(err, runtoken.ErrRunMismatch):
+		return fiber.StatusForbidden
+	}
+	return fiber.StatusUnauthorized
+}
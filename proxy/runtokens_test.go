@@ -0,0 +1,157 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/runtoken"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+	"github.com/papercomputeco/tapes/proxy/header"
+)
+
+var _ = Describe("Run tokens", func() {
+	var (
+		p        *Proxy
+		driver   *inmemory.Driver
+		tokens   *runtoken.Service
+		upstream *httptest.Server
+		received http.Header
+	)
+
+	BeforeEach(func() {
+		received = nil
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r.Header.Clone()
+			if r.URL.Path == "/api/chat" {
+				w.Write(makeOllamaResponseBody("test-model", "assistant", "4"))
+				return
+			}
+			w.Write([]byte(`{"models":[]}`))
+		}))
+
+		tokens = runtoken.New()
+		driver = inmemory.NewDriver()
+		var err error
+		p, err = New(Config{
+			ListenAddr:   ":0",
+			UpstreamURL:  upstream.URL,
+			ProviderType: "ollama",
+			RunTokens:    tokens,
+			ProviderKeys: func(provider string) (string, error) {
+				return map[string]string{"ollama": "sk-stored"}[provider], nil
+			},
+		}, driver, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if p != nil {
+			p.Close()
+		}
+		upstream.Close()
+	})
+
+	get := func(name, value string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/tags", nil)
+		req.Header.Set(name, value)
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	chat := func(path, token, run string) int {
+		req := httptest.NewRequest(http.MethodPost, path,
+			strings.NewReader(string(makeOllamaRequestBody("test-model",
+				[]ollamaTestMessage{{Role: "user", Content: "What is 2+2?"}}, boolPtr(false)))))
+		req.Header.Set("Authorization", "Bearer "+token)
+		if run != "" {
+			req.Header.Set(header.AgentRunHeader, run)
+		}
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	It("swaps a run token for the provider's key", func() {
+		token, _ := tokens.Issue("run-1", runtoken.Limits{})
+
+		Expect(get("Authorization", "Bearer "+token)).To(Equal(http.StatusOK))
+		Expect(received.Get("Authorization")).To(Equal("Bearer sk-stored"))
+
+		Expect(get("X-Api-Key", token)).To(Equal(http.StatusOK))
+		Expect(received.Get("X-Api-Key")).To(Equal("sk-stored"))
+
+		Expect(tokens.List()[0].Requests).To(Equal(2))
+	})
+
+	It("forwards other credentials untouched", func() {
+		Expect(get("Authorization", "Bearer sk-agent")).To(Equal(http.StatusOK))
+		Expect(received.Get("Authorization")).To(Equal("Bearer sk-agent"))
+	})
+
+	It("rejects unknown and revoked run tokens before forwarding them", func() {
+		Expect(get("Authorization", "Bearer "+runtoken.Prefix+"unknown")).To(Equal(http.StatusUnauthorized))
+
+		token, _ := tokens.Issue("run-1", runtoken.Limits{})
+		Expect(tokens.RevokeRun("run-1")).To(Equal(1))
+		Expect(get("Authorization", "Bearer "+token)).To(Equal(http.StatusUnauthorized))
+		Expect(received).To(BeNil())
+	})
+
+	It("refuses run tokens over their request budget", func() {
+		token, _ := tokens.Issue("run-1", runtoken.Limits{MaxRequests: 1})
+		Expect(get("Authorization", "Bearer "+token)).To(Equal(http.StatusOK))
+
+		received = nil
+		Expect(get("Authorization", "Bearer "+token)).To(Equal(http.StatusPaymentRequired))
+		Expect(received).To(BeNil())
+	})
+
+	It("records turns under the token's run", func() {
+		token, _ := tokens.Issue("run-1", runtoken.Limits{})
+		Expect(chat("/api/chat", token, "")).To(Equal(http.StatusOK))
+		Expect(chat("/runs/run-1/api/chat", token, "")).To(Equal(http.StatusOK))
+
+		p.Close()
+		p = nil
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).NotTo(BeEmpty())
+		for _, n := range nodes {
+			Expect(n.RunID).To(Equal("run-1"))
+		}
+	})
+
+	It("refuses requests naming a run other than the token's", func() {
+		token, _ := tokens.Issue("run-1", runtoken.Limits{})
+
+		Expect(chat("/runs/run-2/api/chat", token, "")).To(Equal(http.StatusForbidden))
+		Expect(chat("/api/chat", token, "run-2")).To(Equal(http.StatusForbidden))
+		Expect(received).To(BeNil())
+
+		p.Close()
+		p = nil
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(BeEmpty())
+	})
+
+	It("refuses tokens issued before a restart", func() {
+		// Tokens live in memory only, so a restarted daemon starts with a
+		// new, empty service.
+		token, _ := tokens.Issue("run-1", runtoken.Limits{})
+		p.config.RunTokens = runtoken.New()
+
+		Expect(get("Authorization", "Bearer "+token)).To(Equal(http.StatusUnauthorized))
+		Expect(received).To(BeNil())
+	})
+})
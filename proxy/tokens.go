@@ -0,0 +1,204 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/llm/provider"
+	"github.com/papercomputeco/tapes/pkg/llm/provider/anthropic"
+	"github.com/papercomputeco/tapes/pkg/llm/provider/openai"
+)
+
+// Token counts are cached briefly, since agents count the same conversation
+// repeatedly as it grows and budget checks count each request once more.
+const (
+	tokenCountCacheSize = 1024
+	tokenCountCacheTTL  = 5 * time.Minute
+)
+
+// tokenCountCache holds recent input token counts keyed by tokenCountKey.
+type tokenCountCache struct {
+	mu      sync.Mutex
+	entries map[string]tokenCountEntry
+}
+
+type tokenCountEntry struct {
+	tokens  int
+	expires time.Time
+}
+
+func newTokenCountCache() *tokenCountCache {
+	return &tokenCountCache{entries: map[string]tokenCountEntry{}}
+}
+
+func (c *tokenCountCache) get(key string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return 0, false
+	}
+	return entry.tokens, true
+}
+
+// put caches a count, evicting expired entries and then arbitrary ones when
+// the cache is full.
+func (c *tokenCountCache) put(key string, tokens int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= tokenCountCacheSize {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	for k := range c.entries {
+		if len(c.entries) < tokenCountCacheSize {
+			break
+		}
+		delete(c.entries, k)
+	}
+	c.entries[key] = tokenCountEntry{tokens: tokens, expires: now.Add(tokenCountCacheTTL)}
+}
+
+// tokenCountKey identifies a count by its request body and the beta features
+// it was counted with, which can change how tools are tokenized.
+func tokenCountKey(beta string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(beta))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// isTokenCountEndpoint reports whether path is prov's token counting endpoint.
+func isTokenCountEndpoint(prov provider.Provider, path string) bool {
+	counter, ok := prov.(provider.TokenCountProvider)
+	return ok && counter.TokenCountEndpoint(path)
+}
+
+// handleCountTokens forwards a token counting request, answering repeated
+// requests from the cache. Counts are not conversations and are never stored.
+func (p *Proxy) handleCountTokens(ctx context.Context, c *fiber.Ctx, path, upstreamURL string, prov provider.Provider, agentName string) error {
+	body := c.Body()
+	key := tokenCountKey(c.Get("anthropic-beta"), body)
+	if tokens, ok := p.tokenCounts.get(key); ok {
+		return c.JSON(fiber.Map{"input_tokens": tokens})
+	}
+
+	newRequest := func() (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(c.Context(), http.MethodPost, upstreamURL+path, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		p.headerHandler.SetUpstreamRequestHeaders(c, httpReq)
+		return httpReq, nil
+	}
+
+	httpResp, _, err := p.sendUpstream(ctx, c, prov, agentName, path, nil, newRequest)
+	if errors.Is(err, errBuildRequest) {
+		p.logger.Error("failed to create upstream request", zap.Error(err))
+		return c.Status(fiber.StatusInternalServerError).JSON(llm.ErrorResponse{Error: "internal error"})
+	}
+	if err != nil {
+		p.logger.Error("upstream request failed", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(llm.ErrorResponse{Error: "upstream request failed"})
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		p.logger.Error("failed to read upstream response", zap.Error(err))
+		return c.Status(fiber.StatusBadGateway).JSON(llm.ErrorResponse{Error: "failed to read upstream response"})
+	}
+	p.headerHandler.SetClientResponseHeaders(c, httpResp)
+
+	if httpResp.StatusCode == http.StatusOK {
+		if tokens, err := prov.(provider.TokenCountProvider).ParseTokenCount(respBody); err == nil {
+			p.tokenCounts.put(key, tokens)
+		}
+	}
+	return c.Status(httpResp.StatusCode).Send(respBody)
+}
+
+// countTokens counts the input tokens of a chat request for budget checks,
+// and reports whether the count is an estimate. Anthropic requests are
+// counted exactly by the upstream, sharing the cache with agents' own counts;
+// other providers, and Anthropic requests that could not be counted, are
+// estimated locally.
+func (p *Proxy) countTokens(ctx context.Context, c *fiber.Ctx, prov provider.Provider, upstreamURL string, req *llm.ChatRequest) (int, bool) {
+	var counter llm.TokenCounter = llm.EstimateCounter{}
+	switch prov.Name() {
+	case providerAnthropic:
+		tokens, err := p.countAnthropicTokens(ctx, c, upstreamURL, req)
+		if err == nil {
+			return tokens, false
+		}
+		p.logger.Warn("failed to count tokens, estimating", zap.Error(err))
+	case providerOpenAI:
+		counter = openai.NewTokenCounter()
+	}
+
+	tokens, err := counter.CountTokens(ctx, req)
+	if err != nil {
+		return llm.EstimateTokens(req), true
+	}
+	return tokens, true
+}
+
+func (p *Proxy) countAnthropicTokens(ctx context.Context, c *fiber.Ctx, upstreamURL string, req *llm.ChatRequest) (int, error) {
+	body, err := anthropic.CountTokensRequest(req.RawRequest)
+	if err != nil {
+		return 0, err
+	}
+	key := tokenCountKey(c.Get("anthropic-beta"), body)
+	if tokens, ok := p.tokenCounts.get(key); ok {
+		return tokens, nil
+	}
+
+	// The count is sent with the agent's own credentials and API version.
+	headers, err := http.NewRequest(http.MethodPost, upstreamURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	p.headerHandler.SetUpstreamRequestHeaders(c, headers)
+	headers.Header.Del(fiber.HeaderContentLength)
+
+	counter := &anthropic.TokenCounter{BaseURL: upstreamURL, Header: headers.Header, Client: p.httpClient}
+	tokens, err := counter.CountTokens(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	p.tokenCounts.put(key, tokens)
+	return tokens, nil
+}
+
+// checkBudget counts a chat request's input tokens and checks it against the
+// configured budget.
+func (p *Proxy) checkBudget(ctx context.Context, c *fiber.Ctx, prov provider.Provider, agentName, upstreamURL string, req *llm.ChatRequest) error {
+	user := p.requestUser(c)
+	tokens, estimated := p.countTokens(ctx, c, prov, upstreamURL, req)
+	return p.config.Budget.Check(ctx, BudgetRequest{
+		Provider:    prov.Name(),
+		Model:       req.Model,
+		AgentName:   agentName,
+		User:        user,
+		InputTokens: tokens,
+		Estimated:   estimated,
+	})
+}
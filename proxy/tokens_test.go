@@ -0,0 +1,129 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+var _ = Describe("Token Counting", func() {
+	var (
+		p        *Proxy
+		driver   *inmemory.Driver
+		upstream *httptest.Server
+		counts   atomic.Int32
+		messages atomic.Int32
+	)
+
+	newProxy := func(budget Budget) {
+		var err error
+		driver = inmemory.NewDriver()
+		p, err = New(Config{ListenAddr: ":0", UpstreamURL: upstream.URL, ProviderType: "anthropic", Budget: budget}, driver, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	send := func(path, body string) (int, string) {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Api-Key", "sk-test")
+
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(respBody)
+	}
+
+	BeforeEach(func() {
+		counts.Store(0)
+		messages.Store(0)
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/v1/messages/count_tokens":
+				counts.Add(1)
+				body, _ := io.ReadAll(r.Body)
+				Expect(string(body)).NotTo(ContainSubstring("max_tokens"))
+				w.Write([]byte(`{"input_tokens":1500}`))
+			case "/v1/messages":
+				messages.Add(1)
+				w.Write([]byte(`{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-5","content":[{"type":"text","text":"Hi!"}],"stop_reason":"end_turn","usage":{"input_tokens":1500,"output_tokens":3}}`))
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		if p != nil {
+			p.Close()
+		}
+		upstream.Close()
+	})
+
+	It("proxies and caches token counts without storing them", func() {
+		newProxy(nil)
+		body := `{"model":"claude-sonnet-4-5","messages":[{"role":"user","content":"Hello"}]}`
+
+		for range 2 {
+			status, respBody := send("/v1/messages/count_tokens", body)
+			Expect(status).To(Equal(http.StatusOK))
+			Expect(respBody).To(MatchJSON(`{"input_tokens":1500}`))
+		}
+		Expect(counts.Load()).To(Equal(int32(1)))
+
+		p.Close()
+		p = nil
+
+		nodes, err := driver.List(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(BeEmpty())
+		letters, err := driver.ListDeadLetters(GinkgoT().Context())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(letters).To(BeEmpty())
+	})
+
+	It("rejects requests over budget before forwarding them", func() {
+		newProxy(MaxInputTokens(1000))
+
+		status, respBody := send("/v1/messages", `{"model":"claude-sonnet-4-5","max_tokens":1024,"messages":[{"role":"user","content":"Hello"}]}`)
+		Expect(status).To(Equal(http.StatusPaymentRequired))
+		Expect(respBody).To(ContainSubstring("1500 input tokens, limit is 1000"))
+		Expect(counts.Load()).To(Equal(int32(1)))
+		Expect(messages.Load()).To(BeZero())
+	})
+
+	It("forwards requests within budget, reusing the agent's count", func() {
+		newProxy(MaxInputTokens(2000))
+
+		status, _ := send("/v1/messages/count_tokens", `{"messages":[{"role":"user","content":"Hello"}],"model":"claude-sonnet-4-5"}`)
+		Expect(status).To(Equal(http.StatusOK))
+
+		status, respBody := send("/v1/messages", `{"model":"claude-sonnet-4-5","max_tokens":1024,"messages":[{"role":"user","content":"Hello"}]}`)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(respBody).To(ContainSubstring("Hi!"))
+		Expect(counts.Load()).To(Equal(int32(1)))
+		Expect(messages.Load()).To(Equal(int32(1)))
+	})
+})
+
+var _ = Describe("MaxInputTokens", func() {
+	It("allows requests up to the limit", func() {
+		budget := MaxInputTokens(100)
+		Expect(budget.Check(GinkgoT().Context(), BudgetRequest{InputTokens: 100})).To(Succeed())
+		Expect(budget.Check(GinkgoT().Context(), BudgetRequest{InputTokens: 101})).To(MatchError(ErrOverBudget))
+	})
+
+	It("allows estimated counts the estimate's margin over the limit", func() {
+		budget := MaxInputTokens(100)
+		Expect(budget.Check(GinkgoT().Context(), BudgetRequest{InputTokens: 115, Estimated: true})).To(Succeed())
+		Expect(budget.Check(GinkgoT().Context(), BudgetRequest{InputTokens: 116, Estimated: true})).To(
+			MatchError(ContainSubstring("an estimated 116 input tokens, limit is 100")))
+	})
+})
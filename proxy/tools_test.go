@@ -0,0 +1,88 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+	"github.com/papercomputeco/tapes/proxy/header"
+)
+
+var _ = Describe("Session tools", func() {
+	var (
+		p        *Proxy
+		driver   *inmemory.Driver
+		upstream *httptest.Server
+	)
+
+	BeforeEach(func() {
+		upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(makeOllamaResponseBody("test-model", "assistant", "It is sunny."))
+		}))
+		driver = inmemory.NewDriver()
+		var err error
+		p, err = New(Config{
+			ListenAddr:   ":0",
+			UpstreamURL:  upstream.URL,
+			ProviderType: "ollama",
+		}, driver, zap.NewNop())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		if p != nil {
+			p.Close()
+		}
+		upstream.Close()
+	})
+
+	send := func(session string) {
+		reqBody := `{"model":"test-model","stream":false,
+			"messages":[{"role":"user","content":"What is the weather?"}],
+			"tools":[{"type":"function","function":{"name":"get_weather","parameters":{"type":"object"}}}]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/chat", strings.NewReader(reqBody))
+		if session != "" {
+			req.Header.Set(header.SessionHeader, session)
+		}
+		resp, err := p.server.Test(req)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	}
+
+	It("stores the tool definitions of a request linked to its session", func() {
+		send("session-1")
+		p.Close()
+		p = nil
+
+		tools, err := driver.ListSessionTools(GinkgoT().Context(), "session-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tools).To(HaveLen(1))
+		Expect(tools[0].Tool).To(Equal(llm.ToolDefinition{
+			Type:   "function",
+			Name:   "get_weather",
+			Schema: map[string]any{"type": "object"},
+		}))
+
+		node, err := driver.Get(GinkgoT().Context(), tools[0].NodeHash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(node.Bucket.Role).To(Equal("assistant"))
+	})
+
+	It("stores nothing for requests of no known session", func() {
+		send("")
+		p.Close()
+		p = nil
+
+		tools, err := driver.ListSessionTools(GinkgoT().Context(), "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tools).To(BeEmpty())
+	})
+})
@@ -0,0 +1,95 @@
+package proxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// maxErrorMessageBytes bounds an error message kept from a response body that
+// is not a recognized error payload, such as an HTML page from a gateway.
+const maxErrorMessageBytes = 1024
+
+// upstreamErrorPayload covers the error payloads of the supported providers:
+// OpenAI's {"error": {"message", "type", "code"}}, Anthropic's
+// {"type": "error", "error": {"type", "message"}}, and Ollama's
+// {"error": "message"}.
+type upstreamErrorPayload struct {
+	Error json.RawMessage `json:"error"`
+}
+
+type upstreamErrorDetail struct {
+	Type    string          `json:"type"`
+	Code    json.RawMessage `json:"code"`
+	Message string          `json:"message"`
+}
+
+// parseUpstreamError describes an error response from the upstream provider.
+// The Retry-After header may hold seconds or an HTTP date, which is resolved
+// against now.
+func parseUpstreamError(status int, retryAfter string, body []byte, now time.Time) *llm.UpstreamError {
+	upstreamErr := &llm.UpstreamError{
+		Status:            status,
+		RetryAfterSeconds: parseRetryAfter(retryAfter, now),
+	}
+
+	var payload upstreamErrorPayload
+	if err := json.Unmarshal(body, &payload); err == nil && len(payload.Error) > 0 {
+		var detail upstreamErrorDetail
+		var message string
+		switch {
+		case json.Unmarshal(payload.Error, &detail) == nil:
+			upstreamErr.Type = detail.Type
+			upstreamErr.Message = detail.Message
+			if upstreamErr.Type == "" {
+				upstreamErr.Type = errorCode(detail.Code)
+			}
+		case json.Unmarshal(payload.Error, &message) == nil:
+			upstreamErr.Message = message
+		}
+	}
+
+	if upstreamErr.Message == "" {
+		upstreamErr.Message = strings.TrimSpace(string(body))
+		if len(upstreamErr.Message) > maxErrorMessageBytes {
+			upstreamErr.Message = strings.ToValidUTF8(upstreamErr.Message[:maxErrorMessageBytes], "")
+		}
+	}
+	if upstreamErr.Message == "" {
+		upstreamErr.Message = http.StatusText(status)
+	}
+	return upstreamErr
+}
+
+// errorCode returns an OpenAI error code, which is a string or a number.
+func errorCode(code json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(code, &s); err == nil {
+		return s
+	}
+	var n json.Number
+	if err := json.Unmarshal(code, &n); err == nil {
+		return n.String()
+	}
+	return ""
+}
+
+// parseRetryAfter returns the delay of a Retry-After header in whole seconds,
+// or zero when it is missing or malformed.
+func parseRetryAfter(value string, now time.Time) int {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(seconds, 0)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(int(at.Sub(now).Round(time.Second)/time.Second), 0)
+	}
+	return 0
+}
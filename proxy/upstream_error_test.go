@@ -0,0 +1,50 @@
+package proxy
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+var _ = Describe("parseUpstreamError", func() {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	DescribeTable("parses provider error payloads",
+		func(status int, body string, expected llm.UpstreamError) {
+			Expect(*parseUpstreamError(status, "", []byte(body), now)).To(Equal(expected))
+		},
+		Entry("OpenAI", http.StatusTooManyRequests,
+			`{"error": {"message": "Rate limit reached", "type": "requests", "param": null, "code": "rate_limit_exceeded"}}`,
+			llm.UpstreamError{Status: 429, Type: "requests", Message: "Rate limit reached"}),
+		Entry("OpenAI with only a code", http.StatusUnauthorized,
+			`{"error": {"message": "Incorrect API key provided", "code": "invalid_api_key"}}`,
+			llm.UpstreamError{Status: 401, Type: "invalid_api_key", Message: "Incorrect API key provided"}),
+		Entry("Anthropic", 529,
+			`{"type": "error", "error": {"type": "overloaded_error", "message": "Overloaded"}}`,
+			llm.UpstreamError{Status: 529, Type: "overloaded_error", Message: "Overloaded"}),
+		Entry("Ollama", http.StatusNotFound,
+			`{"error": "model 'llama9' not found"}`,
+			llm.UpstreamError{Status: 404, Message: "model 'llama9' not found"}),
+		Entry("plain text", http.StatusBadGateway,
+			"upstream connect error",
+			llm.UpstreamError{Status: 502, Message: "upstream connect error"}),
+		Entry("empty body", http.StatusServiceUnavailable,
+			"",
+			llm.UpstreamError{Status: 503, Message: "Service Unavailable"}),
+	)
+
+	DescribeTable("parses Retry-After",
+		func(value string, expected int) {
+			Expect(parseRetryAfter(value, now)).To(Equal(expected))
+		},
+		Entry("seconds", "30", 30),
+		Entry("HTTP date", now.Add(90*time.Second).Format(http.TimeFormat), 90),
+		Entry("past date", now.Add(-time.Minute).Format(http.TimeFormat), 0),
+		Entry("missing", "", 0),
+		Entry("malformed", "soon", 0),
+	)
+})
@@ -0,0 +1,199 @@
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/redact"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
+)
+
+// maxJournalLineSize bounds a single journaled job. Conversation turns can carry
+// large tool outputs and base64 images, so this is generous.
+const maxJournalLineSize = 64 * 1024 * 1024
+
+// journalAAD binds sealed journal entries to the journal, so that they cannot
+// be passed off as other sealed content.
+var journalAAD = []byte("tapes-journal")
+
+// errJournalEncrypted is returned when replaying a sealed journal without the
+// key it was sealed with.
+var errJournalEncrypted = errors.New("journal is encrypted and no encryption key is configured")
+
+// journalCodec turns jobs into journal lines and back. Jobs are redacted like
+// stored content, and sealed when storage is encrypted, so that the journal
+// holds no more than the database would.
+type journalCodec struct {
+	redactor *redact.Redactor
+	cipher   *encryption.Cipher
+}
+
+// encode returns the journal line for job, without its newline.
+func (jc journalCodec) encode(job Job) ([]byte, error) {
+	if jc.redactor != nil {
+		job = redactJob(jc.redactor, job)
+	}
+	line, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+	if jc.cipher == nil {
+		return line, nil
+	}
+	sealed, err := jc.cipher.Seal(line, journalAAD)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(sealed), nil
+}
+
+// decode parses a journal line. Plain entries, written before encryption was
+// enabled, are read as they are.
+func (jc journalCodec) decode(line []byte) (Job, error) {
+	if !bytes.HasPrefix(line, []byte("{")) {
+		if jc.cipher == nil {
+			return Job{}, errJournalEncrypted
+		}
+		opened, err := jc.cipher.Open(string(line), journalAAD)
+		if err != nil {
+			return Job{}, err
+		}
+		line = opened
+	}
+
+	var job Job
+	err := json.Unmarshal(line, &job)
+	return job, err
+}
+
+// redactJob returns a copy of job with the content of its request, response,
+// error, and raw payloads redacted.
+func redactJob(r *redact.Redactor, job Job) Job {
+	if job.Req != nil {
+		req := *job.Req
+		req.System = r.RedactString(req.System)
+		req.Messages = redactMessages(r, req.Messages)
+		job.Req = &req
+	}
+	if job.Resp != nil {
+		resp := *job.Resp
+		resp.Message.Content = r.RedactBlocks(resp.Message.Content)
+		job.Resp = &resp
+	}
+	if job.Error != nil {
+		upstreamErr := *job.Error
+		upstreamErr.Message = r.RedactString(upstreamErr.Message)
+		job.Error = &upstreamErr
+	}
+	if len(job.RawRequest) > 0 {
+		job.RawRequest = []byte(r.RedactPayload(string(job.RawRequest)))
+	}
+	if len(job.RawResponse) > 0 {
+		job.RawResponse = []byte(r.RedactPayload(string(job.RawResponse)))
+	}
+	return job
+}
+
+// redactMessages returns a copy of messages with their content redacted.
+func redactMessages(r *redact.Redactor, messages []llm.Message) []llm.Message {
+	result := make([]llm.Message, len(messages))
+	for i, msg := range messages {
+		msg.Content = r.RedactBlocks(msg.Content)
+		result[i] = msg
+	}
+	return result
+}
+
+// writeJournal writes jobs to the newline-delimited journal at path.
+// Appending lets repeated unclean shutdowns accumulate rather than overwrite
+// jobs that have not yet been replayed; with truncate set the journal is
+// replaced instead, for when it still holds jobs that have since been stored.
+func writeJournal(path string, jobs []Job, jc journalCodec, truncate bool) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating journal dir: %w", err)
+	}
+
+	flag := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if truncate {
+		flag = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flag, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening journal: %w", err)
+	}
+
+	w := bufio.NewWriter(file)
+	for _, job := range jobs {
+		line, err := jc.encode(job)
+		if err != nil {
+			_ = file.Close()
+			return fmt.Errorf("encoding journal entry: %w", err)
+		}
+		_, _ = w.Write(line)
+		_ = w.WriteByte('\n')
+	}
+
+	if err := w.Flush(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("writing journal: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("syncing journal: %w", err)
+	}
+	return file.Close()
+}
+
+// readJournal loads all jobs from the journal at path, leaving the file in
+// place until they are stored. A missing journal yields no jobs. Malformed
+// entries, such as a line truncated by a crash mid-write, are skipped and
+// counted.
+func readJournal(path string, jc journalCodec) ([]Job, int, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("opening journal: %w", err)
+	}
+	defer file.Close()
+
+	var (
+		jobs    []Job
+		skipped int
+	)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJournalLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		job, err := jc.decode(line)
+		if errors.Is(err, errJournalEncrypted) {
+			return nil, 0, err
+		}
+		if err != nil || !job.complete() {
+			skipped++
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("reading journal: %w", err)
+	}
+
+	return jobs, skipped, nil
+}
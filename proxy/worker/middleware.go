@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+)
+
+// Middleware inspects, rewrites, or drops a job before it is stored.
+//
+// Returning a nil job drops the turn without error, which lets integrators
+// filter captures by model, path, or header. Returning a modified job enriches
+// or rewrites what is stored, for example by setting Job.Project. Returning an
+// error fails the job. Middleware runs on worker goroutines, so it must be
+// safe for concurrent use, and it may see the same job again when journaled
+// jobs are replayed after an unclean shutdown.
+type Middleware func(ctx context.Context, job *Job) (*Job, error)
+
+// Use appends middleware to the chain run before each job is stored.
+// Middleware runs in registration order, after any set in Config.Middleware.
+func (p *Pool) Use(mw ...Middleware) {
+	p.middlewareMu.Lock()
+	defer p.middlewareMu.Unlock()
+	p.middleware = append(p.middleware, mw...)
+}
+
+// applyMiddleware runs job through the middleware chain. It returns nil when
+// a middleware dropped the job.
+func (p *Pool) applyMiddleware(ctx context.Context, job Job) (*Job, error) {
+	p.middlewareMu.RLock()
+	chain := p.middleware
+	p.middlewareMu.RUnlock()
+
+	current := &job
+	for i, mw := range chain {
+		next, err := mw(ctx, current)
+		if err != nil {
+			return nil, fmt.Errorf("middleware %d: %w", i, err)
+		}
+		if next == nil {
+			return nil, nil
+		}
+		current = next
+	}
+	return current, nil
+}
@@ -0,0 +1,101 @@
+package worker
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+var _ = Describe("Middleware", func() {
+	var (
+		driver *inmemory.Driver
+		wp     *Pool
+	)
+
+	newPool := func(mw ...Middleware) {
+		logger, _ := zap.NewDevelopment()
+		driver = inmemory.NewDriver()
+
+		var err error
+		wp, err = NewPool(&Config{
+			Driver:     driver,
+			Logger:     logger,
+			Middleware: mw,
+		})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	storedCount := func() int {
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		return len(nodes)
+	}
+
+	It("drops jobs when middleware returns nil", func() {
+		newPool(func(_ context.Context, job *Job) (*Job, error) {
+			if job.Req.Model == "test-model" {
+				return nil, nil
+			}
+			return job, nil
+		})
+
+		Expect(wp.Submit(testJob("skip me"))).To(Succeed())
+		drain(wp)
+
+		Expect(storedCount()).To(Equal(0))
+		Expect(wp.Stats().Filtered).To(Equal(uint64(1)))
+		Expect(wp.Stats().Processed).To(Equal(uint64(0)))
+	})
+
+	It("stores jobs enriched by middleware", func() {
+		newPool(func(_ context.Context, job *Job) (*Job, error) {
+			job.Project = "from-middleware"
+			return job, nil
+		})
+
+		Expect(wp.Submit(testJob("hello"))).To(Succeed())
+		drain(wp)
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(2))
+		for _, n := range nodes {
+			Expect(n.Project).To(Equal("from-middleware"))
+		}
+	})
+
+	It("fails jobs when middleware returns an error", func() {
+		newPool(func(_ context.Context, _ *Job) (*Job, error) {
+			return nil, errors.New("policy violation")
+		})
+
+		Expect(wp.Submit(testJob("hello"))).To(Succeed())
+		drain(wp)
+
+		Expect(storedCount()).To(Equal(0))
+		Expect(wp.Stats().Failed).To(Equal(uint64(1)))
+	})
+
+	It("runs configured middleware before middleware registered with Use", func() {
+		var order []string
+		newPool(func(_ context.Context, job *Job) (*Job, error) {
+			order = append(order, "config")
+			return job, nil
+		})
+		wp.Use(func(_ context.Context, job *Job) (*Job, error) {
+			order = append(order, "use")
+			return job, nil
+		})
+
+		Expect(wp.Submit(testJob("hello"))).To(Succeed())
+		drain(wp)
+
+		Expect(order).To(Equal([]string{"config", "use"}))
+		Expect(wp.Stats().Processed).To(Equal(uint64(1)))
+	})
+})
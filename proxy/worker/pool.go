@@ -8,16 +8,33 @@ package worker
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"math"
+	"os"
+	"slices"
+	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
+	"github.com/papercomputeco/tapes/pkg/capture"
+	"github.com/papercomputeco/tapes/pkg/crash"
 	"github.com/papercomputeco/tapes/pkg/embeddings"
+	"github.com/papercomputeco/tapes/pkg/events"
 	"github.com/papercomputeco/tapes/pkg/llm"
 	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/redact"
 	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
+	"github.com/papercomputeco/tapes/pkg/storage/media"
+	"github.com/papercomputeco/tapes/pkg/telemetry"
 	"github.com/papercomputeco/tapes/pkg/vector"
 )
 
@@ -26,12 +43,185 @@ var (
 	defaultJobQueueSize uint = 256
 )
 
+// OverflowPolicy determines what Enqueue does when the job queue is at capacity.
+type OverflowPolicy string
+
+const (
+	// OverflowDropNew rejects the incoming job when the queue is full.
+	// This is the default policy.
+	OverflowDropNew OverflowPolicy = "drop-new"
+
+	// OverflowDropOldest evicts the oldest queued job to make room for the
+	// incoming job, favoring the most recent conversation state.
+	OverflowDropOldest OverflowPolicy = "drop-oldest"
+
+	// OverflowBlock waits for queue capacity, up to Config.EnqueueTimeout
+	// when set. This applies backpressure to the caller.
+	OverflowBlock OverflowPolicy = "block"
+)
+
+var (
+	// ErrQueueFull is returned by Submit when the queue is full and the
+	// overflow policy rejects the job.
+	ErrQueueFull = errors.New("job queue full")
+
+	// ErrEnqueueTimeout is returned by Submit when the block policy could not
+	// find queue capacity before Config.EnqueueTimeout elapsed.
+	ErrEnqueueTimeout = errors.New("timed out waiting for job queue capacity")
+
+	// ErrPoolClosed is returned by Submit after Close has been called.
+	ErrPoolClosed = errors.New("worker pool closed")
+)
+
+// ParseOverflowPolicy validates a policy name. An empty name yields the default
+// OverflowDropNew policy.
+func ParseOverflowPolicy(name string) (OverflowPolicy, error) {
+	switch OverflowPolicy(name) {
+	case "":
+		return OverflowDropNew, nil
+	case OverflowDropNew, OverflowDropOldest, OverflowBlock:
+		return OverflowPolicy(name), nil
+	default:
+		return "", fmt.Errorf("unknown overflow policy: %q (supported: %s, %s, %s)",
+			name, OverflowDropNew, OverflowDropOldest, OverflowBlock)
+	}
+}
+
+// Stats is a point-in-time snapshot of the pool's job counters.
+type Stats struct {
+	// Queued is the number of jobs currently waiting in the queue.
+	Queued int `json:"queued"`
+
+	// Capacity is the configured queue capacity.
+	Capacity int `json:"capacity"`
+
+	// Enqueued is the total number of jobs accepted into the queue.
+	Enqueued uint64 `json:"enqueued"`
+
+	// Processed is the total number of jobs that were stored successfully.
+	Processed uint64 `json:"processed"`
+
+	// Duplicates is the total number of jobs whose turn was already stored,
+	// such as an agent retrying a request that got the same response. They
+	// are not counted as processed.
+	Duplicates uint64 `json:"duplicates"`
+
+	// Failed is the total number of jobs that errored or timed out during processing.
+	Failed uint64 `json:"failed"`
+
+	// Crashed is the number of failed jobs whose processing panicked.
+	Crashed uint64 `json:"crashed"`
+
+	// DroppedNew is the number of incoming jobs rejected because the queue was full.
+	DroppedNew uint64 `json:"dropped_new"`
+
+	// DroppedOldest is the number of queued jobs evicted by the drop-oldest policy.
+	DroppedOldest uint64 `json:"dropped_oldest"`
+
+	// Filtered is the total number of jobs dropped by middleware.
+	Filtered uint64 `json:"filtered"`
+
+	// Unrecorded is the total number of jobs passed through without being
+	// recorded, and UnrecordedTokens the input and output tokens they used.
+	Unrecorded       uint64 `json:"unrecorded"`
+	UnrecordedTokens uint64 `json:"unrecorded_tokens"`
+
+	// Redactions is the number of matches redacted per rule, when redaction
+	// is enabled.
+	Redactions map[string]uint64 `json:"redactions,omitempty"`
+
+	// Skipped is the number of jobs dropped per capture rule, when capture
+	// rules are configured.
+	Skipped map[string]uint64 `json:"skipped,omitempty"`
+}
+
+// DrainReport summarizes how Close disposed of outstanding jobs.
+type DrainReport struct {
+	// Flushed is the number of jobs workers finished with between the start
+	// of Close and the pool stopping, whether stored, suppressed as
+	// duplicates, filtered, or failed.
+	Flushed uint64 `json:"flushed"`
+
+	// Abandoned is the number of jobs left unprocessed when the drain
+	// deadline passed.
+	Abandoned int `json:"abandoned"`
+
+	// Journaled is the number of abandoned jobs persisted to the journal for
+	// replay. It is less than Abandoned when no JournalPath is configured or
+	// writing the journal failed.
+	Journaled int `json:"journaled"`
+}
+
 // Job is a unit of work for the worker pool to execute against.
 type Job struct {
-	Provider  string
-	AgentName string
-	Req       *llm.ChatRequest
-	Resp      *llm.ChatResponse
+	Provider  string            `json:"provider"`
+	AgentName string            `json:"agent_name,omitempty"`
+	Req       *llm.ChatRequest  `json:"request"`
+	Resp      *llm.ChatResponse `json:"response"`
+
+	// RunID identifies the agent run the turn was captured in, such as one
+	// launch by tapes start. It is recorded on the turn's new nodes.
+	RunID string `json:"run_id,omitempty"`
+
+	// AgentVersion is the version of the agent the turn came from, when it
+	// was reported. It is recorded on the turn's new nodes.
+	AgentVersion string `json:"agent_version,omitempty"`
+
+	// SessionID identifies the agent session the turn belongs to. It is part
+	// of the hash of the job's nodes, so that concurrent sessions never share
+	// nodes.
+	SessionID string `json:"session_id,omitempty"`
+
+	// Error is set instead of Resp when the upstream returned an error. It
+	// is stored as an error node after the request's messages.
+	Error *llm.UpstreamError `json:"error,omitempty"`
+
+	// PolicyViolations are the guardrail rules the turn broke. They are
+	// stored on the response node, or on the error node of a blocked turn.
+	PolicyViolations []llm.PolicyViolation `json:"policy_violations,omitempty"`
+
+	// Record is set instead of Req and Resp for billable requests other than
+	// chat, such as embeddings. It is stored as a single node.
+	Record *llm.UsageRecord `json:"record,omitempty"`
+
+	// Path is the upstream request path the turn was captured from.
+	Path string `json:"path,omitempty"`
+
+	// Headers are the captured client request headers, with credentials
+	// removed. They are available to middleware and are not stored.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Project overrides Config.Project for this job's nodes when set.
+	Project string `json:"project,omitempty"`
+
+	// User overrides Config.User for this job's nodes when set.
+	User string `json:"user,omitempty"`
+
+	// Timing is when the proxy forwarded the request and received the
+	// response. It is stored on the response node.
+	Timing *llm.Timing `json:"timing,omitempty"`
+
+	// TraceParent is the W3C traceparent of the proxy's span for the turn.
+	// Storage spans continue this trace, and its trace ID is stored on the
+	// turn's new nodes.
+	TraceParent string `json:"trace_parent,omitempty"`
+
+	// RawRequest and RawResponse are the turn's original payloads, set when
+	// raw capture is enabled. They are stored alongside the turn's nodes
+	// when the driver supports it. Streamed marks RawResponse as the
+	// stream's data payloads, one per line.
+	RawRequest  []byte `json:"raw_request,omitempty"`
+	RawResponse []byte `json:"raw_response,omitempty"`
+	Streamed    bool   `json:"streamed,omitempty"`
+
+	// Unrecorded marks a turn forwarded with recording off. Submit counts it
+	// in Stats and drops it, so that nothing of it is stored, published, or
+	// journaled.
+	Unrecorded bool `json:"-"`
+
+	// replayed marks a job replayed from the journal, which is removed once
+	// every replayed job has been processed.
+	replayed bool
 }
 
 // Config is the configuration options for the worker pool.
@@ -52,9 +242,82 @@ type Config struct {
 	// QueueSize is the capacity of the buffered job channel (defaults to 256).
 	QueueSize uint
 
+	// OverflowPolicy determines how Enqueue behaves when the queue is full
+	// (defaults to OverflowDropNew).
+	OverflowPolicy OverflowPolicy
+
+	// EnqueueTimeout bounds how long the OverflowBlock policy waits for queue
+	// capacity. Zero waits indefinitely.
+	EnqueueTimeout time.Duration
+
+	// JobTimeout bounds how long a single job may spend in storage and
+	// embedding. Zero disables the timeout.
+	JobTimeout time.Duration
+
+	// SessionIdleTimeout closes a session once it has gone this long without
+	// a turn, marking its root node ended at its last turn. Zero disables
+	// idle closing; sessions can still be ended with EndSessions.
+	SessionIdleTimeout time.Duration
+
+	// MaxContentBytes caps the size of each stored content block's text, tool
+	// output, and inline image data. Oversized content is truncated and
+	// followed by a truncation marker block. Zero disables the limit.
+	MaxContentBytes int
+
+	// Redactor optionally scrubs secrets and personal data from content
+	// before it is hashed and stored. Nil disables redaction.
+	Redactor *redact.Redactor
+
+	// CaptureRules optionally selects the turns that are recorded. Submit
+	// drops the jobs of the others before they are queued. Nil records all.
+	CaptureRules *capture.Rules
+
+	// BlobStore optionally receives content block payloads larger than
+	// BlobThreshold, which are replaced by references in the stored nodes.
+	// Nil keeps all content inline.
+	BlobStore blob.Store
+
+	// BlobThreshold is the payload size in bytes above which content is
+	// offloaded to BlobStore (defaults to blob.DefaultThreshold).
+	BlobThreshold int
+
+	// MediaStore optionally stores images and attached files, which are
+	// replaced by references in the stored nodes. Nil keeps them inline.
+	MediaStore *media.Store
+
+	// Middleware is run, in order, on each job before it is stored.
+	// More can be registered after construction with Pool.Use.
+	Middleware []Middleware
+
+	// JournalPath is an optional file where jobs abandoned by Close are
+	// persisted. Journaled jobs are replayed by NewPool on the next start.
+	JournalPath string
+
 	// Project is the git repository or project name to tag on stored nodes.
 	Project string
 
+	// User is the identity of the person to attribute stored nodes to.
+	User string
+
+	// SigningKey optionally signs the hash of every stored node, so that the
+	// machine that produced a turn can be proven once sessions are
+	// aggregated elsewhere. Nil stores nodes unsigned.
+	SigningKey ed25519.PrivateKey
+
+	// TurnCost optionally prices a turn from its model and token usage for
+	// the session summaries kept by drivers that implement
+	// storage.SessionIndex. Nil leaves their costs at zero.
+	TurnCost func(model string, usage llm.Usage) float64
+
+	// Events optionally receives a TypeNodeCreated event for each newly
+	// stored node, for live tailing, and a TypeSessionEnded event for each
+	// ended session. Nil disables publishing.
+	Events *events.Broker
+
+	// Crashes optionally records a report for each job whose processing
+	// panicked. The worker recovers and moves on to the next job either way.
+	Crashes *crash.Recorder
+
 	// Logger is the provided zap logger
 	Logger *zap.Logger
 }
@@ -65,6 +328,49 @@ type Pool struct {
 	queue  chan Job
 	wg     sync.WaitGroup
 	logger *zap.Logger
+
+	// ctx is canceled when a drain deadline passes, which stops workers from
+	// pulling new jobs and aborts in-flight storage.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// abandonedMu guards abandoned, the in-flight jobs aborted by cancel.
+	abandonedMu sync.Mutex
+	abandoned   []Job
+
+	// journalMu guards replayPending, the number of replayed jobs not yet
+	// processed. The journal is kept until it drops to zero.
+	journalMu     sync.Mutex
+	replayPending int
+
+	// middlewareMu guards middleware, which Use may extend while workers run.
+	middlewareMu sync.RWMutex
+	middleware   []Middleware
+
+	// closeMu guards closed so that sends never race with closing the queue.
+	closeMu sync.RWMutex
+	closed  bool
+
+	// sessionsMu guards sessions, the open sessions by root hash.
+	sessionsMu sync.Mutex
+	sessions   map[string]openSession
+	sessionsWG sync.WaitGroup
+
+	enqueued      atomic.Uint64
+	processed     atomic.Uint64
+	duplicates    atomic.Uint64
+	failed        atomic.Uint64
+	crashed       atomic.Uint64
+	droppedNew    atomic.Uint64
+	droppedOldest atomic.Uint64
+	filtered      atomic.Uint64
+
+	// settled counts the jobs workers took off the queue and finished with,
+	// including those abandoned mid-job by a drain deadline.
+	settled atomic.Uint64
+
+	unrecorded       atomic.Uint64
+	unrecordedTokens atomic.Uint64
 }
 
 // NewPool creates a new Storer and starts its worker goroutines.
@@ -81,10 +387,26 @@ func NewPool(c *Config) (*Pool, error) {
 		return nil, fmt.Errorf("NumWorkers %d exceeds max int", c.NumWorkers)
 	}
 
+	policy, err := ParseOverflowPolicy(string(c.OverflowPolicy))
+	if err != nil {
+		return nil, err
+	}
+	c.OverflowPolicy = policy
+
+	ctx, cancel := context.WithCancel(context.Background())
 	wp := &Pool{
 		config: c,
 		queue:  make(chan Job, c.QueueSize),
 		logger: c.Logger,
+		ctx:    ctx,
+		cancel: cancel,
+
+		middleware: slices.Clone(c.Middleware),
+		sessions:   make(map[string]openSession),
+	}
+
+	if index, ok := c.Driver.(storage.SessionIndex); ok && c.TurnCost != nil {
+		index.SetSessionCost(c.TurnCost)
 	}
 
 	wp.wg.Add(int(c.NumWorkers))
@@ -92,33 +414,387 @@ func NewPool(c *Config) (*Pool, error) {
 		go wp.worker(i)
 	}
 
+	if c.SessionIdleTimeout > 0 && wp.sessionStore() != nil {
+		wp.sessionsWG.Add(1)
+		go wp.closeIdleSessions()
+	}
+
+	if c.JournalPath != "" {
+		if err := wp.replayJournal(); err != nil {
+			wp.logger.Error("failed to replay job journal",
+				zap.String("path", c.JournalPath),
+				zap.Error(err),
+			)
+		}
+	}
+
 	return wp, nil
 }
 
+// replayJournal enqueues jobs left behind by a previous unclean shutdown.
+// Replayed jobs bypass the overflow policy: they wait for queue capacity so
+// that recovered turns are never dropped. The journal is removed once they
+// have all been processed, so a crash during replay loses none of them.
+func (p *Pool) replayJournal() error {
+	jobs, skipped, err := readJournal(p.config.JournalPath, p.journalCodec())
+	if err != nil {
+		return err
+	}
+	if skipped > 0 {
+		p.logger.Warn("skipped malformed journal entries", zap.Int("skipped", skipped))
+	}
+	if len(jobs) == 0 {
+		return p.removeJournal()
+	}
+
+	p.logger.Info("replaying journaled jobs", zap.Int("count", len(jobs)))
+	p.journalMu.Lock()
+	p.replayPending = len(jobs)
+	p.journalMu.Unlock()
+	for _, job := range jobs {
+		job.replayed = true
+		p.queue <- job
+		p.enqueued.Add(1)
+	}
+	return nil
+}
+
+// settleReplayed counts a replayed job as processed, removing the journal
+// after the last one. Jobs abandoned by Close count too: Close writes them
+// to the journal afresh.
+func (p *Pool) settleReplayed() {
+	p.journalMu.Lock()
+	defer p.journalMu.Unlock()
+
+	p.replayPending--
+	if p.replayPending > 0 {
+		return
+	}
+	if err := p.removeJournal(); err != nil {
+		p.logger.Error("failed to remove replayed job journal",
+			zap.String("path", p.config.JournalPath),
+			zap.Error(err),
+		)
+	}
+}
+
+// removeJournal removes the journal, if there is one.
+func (p *Pool) removeJournal() error {
+	err := os.Remove(p.config.JournalPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing journal: %w", err)
+	}
+	return nil
+}
+
+// journalCodec returns the codec of the pool's journal, which redacts with
+// the pool's redactor and seals with the storage driver's cipher.
+func (p *Pool) journalCodec() journalCodec {
+	return journalCodec{redactor: p.config.Redactor, cipher: storage.CipherOf(p.config.Driver)}
+}
+
 // Enqueue submits a job for processing by the worker pool.
-// Returns true if enqueued, false if the queue is full, resulting in the job being dropped
+// Returns true if enqueued, false if the job was rejected. Use Submit to learn
+// why a job was rejected.
 func (p *Pool) Enqueue(job Job) bool {
-	select {
-	case p.queue <- job:
-		p.logger.Debug("job queued",
+	return p.Submit(job) == nil
+}
+
+// Submit submits a job for processing by the worker pool according to the
+// configured OverflowPolicy. It returns ErrQueueFull, ErrEnqueueTimeout, or
+// ErrPoolClosed when the job was not accepted.
+func (p *Pool) Submit(job Job) error {
+	p.closeMu.RLock()
+	defer p.closeMu.RUnlock()
+
+	if p.closed {
+		p.logger.Error("job not queued, pool closed",
 			zap.String("provider", job.Provider),
-			zap.String("model", job.Req.Model),
+			zap.String("model", jobModel(job)),
 		)
-		return true
-	default:
-		p.logger.Error("job not queued, queue full, job dropped",
+		return ErrPoolClosed
+	}
+	if job.Unrecorded {
+		p.passThrough(job)
+		return nil
+	}
+	if !p.config.CaptureRules.Record(captureTurn(job)) {
+		p.logger.Debug("job skipped by capture rules",
 			zap.String("provider", job.Provider),
-			zap.String("model", job.Req.Model),
+			zap.String("model", jobModel(job)),
+			zap.String("path", job.Path),
 		)
-		return false
+		return nil
 	}
+
+	var err error
+	switch p.config.OverflowPolicy {
+	case OverflowBlock:
+		err = p.enqueueBlocking(job)
+	case OverflowDropOldest:
+		p.enqueueDropOldest(job)
+	case OverflowDropNew:
+		err = p.enqueueDropNew(job)
+	}
+	if err != nil {
+		p.logger.Error("job not queued, job dropped",
+			zap.String("provider", job.Provider),
+			zap.String("model", jobModel(job)),
+			zap.String("policy", string(p.config.OverflowPolicy)),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	p.enqueued.Add(1)
+	p.logger.Debug("job queued",
+		zap.String("provider", job.Provider),
+		zap.String("model", jobModel(job)),
+	)
+	return nil
 }
 
-// Close signals workers to stop and waits for in-flight jobs to drain.
-// Call this during graceful shutdown after the proxy HTTP server has stopped.
-func (p *Pool) Close() {
+// passThrough counts a job whose turn is not recorded, keeping only its
+// aggregate token usage.
+func (p *Pool) passThrough(job Job) {
+	p.unrecorded.Add(1)
+
+	var usage *llm.Usage
+	switch {
+	case job.Resp != nil:
+		usage = job.Resp.Usage
+	case job.Record != nil:
+		usage = job.Record.Usage
+	}
+	var tokens int
+	if usage != nil {
+		tokens = usage.PromptTokens + usage.CompletionTokens
+		p.unrecordedTokens.Add(uint64(max(tokens, 0)))
+	}
+
+	p.logger.Debug("job passed through unrecorded",
+		zap.String("provider", job.Provider),
+		zap.String("model", jobModel(job)),
+		zap.Int("tokens", tokens),
+	)
+}
+
+// captureTurn describes a job for matching against capture rules. Its system
+// prompt joins the request's system prompt and system messages.
+func captureTurn(job Job) capture.Turn {
+	turn := capture.Turn{
+		Path:     job.Path,
+		Provider: job.Provider,
+		Agent:    job.AgentName,
+		Model:    jobModel(job),
+	}
+	if job.Req != nil {
+		turn.SystemPrompt = job.Req.SystemPrompt()
+	}
+	return turn
+}
+
+// Store processes a job synchronously, bypassing the queue, and returns the
+// hash of the stored turn's head node. It returns an empty hash when
+// middleware drops the job. Store is meant for batch tools that need the
+// result, such as reprocessing raw captures; it does not count toward Stats.
+func (p *Pool) Store(ctx context.Context, job Job) (string, error) {
+	turn, err := p.applyMiddleware(ctx, job)
+	if err != nil || turn == nil {
+		return "", err
+	}
+
+	var (
+		head     string
+		newNodes []*merkle.Node
+	)
+	if turn.Record != nil {
+		head, newNodes, _, err = p.storeUsageRecord(ctx, *turn)
+	} else {
+		head, newNodes, _, err = p.storeConversationTurn(ctx, *turn)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if p.config.VectorDriver != nil && p.config.Embedder != nil && len(newNodes) > 0 {
+		p.storeEmbeddings(ctx, newNodes)
+	}
+	return head, nil
+}
+
+func (p *Pool) enqueueDropNew(job Job) error {
+	select {
+	case p.queue <- job:
+		return nil
+	default:
+		p.droppedNew.Add(1)
+		return ErrQueueFull
+	}
+}
+
+func (p *Pool) enqueueDropOldest(job Job) {
+	for {
+		select {
+		case p.queue <- job:
+			return
+		default:
+		}
+
+		// Evict the oldest job to make room. A worker may have drained the
+		// queue in the meantime, in which case the retry simply succeeds.
+		select {
+		case evicted := <-p.queue:
+			p.droppedOldest.Add(1)
+			p.logger.Warn("queue full, dropped oldest job",
+				zap.String("provider", evicted.Provider),
+				zap.String("model", jobModel(evicted)),
+			)
+		default:
+		}
+	}
+}
+
+func (p *Pool) enqueueBlocking(job Job) error {
+	if p.config.EnqueueTimeout <= 0 {
+		p.queue <- job
+		return nil
+	}
+
+	timer := time.NewTimer(p.config.EnqueueTimeout)
+	defer timer.Stop()
+
+	select {
+	case p.queue <- job:
+		return nil
+	case <-timer.C:
+		p.droppedNew.Add(1)
+		return ErrEnqueueTimeout
+	}
+}
+
+// Stats returns a snapshot of the pool's queue depth and job counters.
+func (p *Pool) Stats() Stats {
+	stats := Stats{
+		Queued:        len(p.queue),
+		Capacity:      cap(p.queue),
+		Enqueued:      p.enqueued.Load(),
+		Processed:     p.processed.Load(),
+		Duplicates:    p.duplicates.Load(),
+		Failed:        p.failed.Load(),
+		Crashed:       p.crashed.Load(),
+		DroppedNew:    p.droppedNew.Load(),
+		DroppedOldest: p.droppedOldest.Load(),
+		Filtered:      p.filtered.Load(),
+
+		Unrecorded:       p.unrecorded.Load(),
+		UnrecordedTokens: p.unrecordedTokens.Load(),
+	}
+	if p.config.Redactor != nil {
+		stats.Redactions = p.config.Redactor.Counts()
+	}
+	if p.config.CaptureRules != nil {
+		stats.Skipped = p.config.CaptureRules.Counts()
+	}
+	return stats
+}
+
+// Close stops accepting jobs and waits for queued and in-flight jobs to drain
+// until ctx is done. Jobs still outstanding at the deadline are abandoned and,
+// when Config.JournalPath is set, written to the journal for replay on the next
+// start. Call this during graceful shutdown after the proxy HTTP server has stopped.
+//
+// Calling Close more than once is a no-op returning an empty report.
+func (p *Pool) Close(ctx context.Context) (DrainReport, error) {
+	p.closeMu.Lock()
+	if p.closed {
+		p.closeMu.Unlock()
+		return DrainReport{}, nil
+	}
+	p.closed = true
 	close(p.queue)
-	p.wg.Wait()
+	p.closeMu.Unlock()
+
+	start := p.settled.Load()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		p.logger.Warn("worker pool drain deadline exceeded, abandoning jobs")
+		p.cancel()
+		<-done
+	}
+	p.cancel()
+	p.sessionsWG.Wait()
+
+	// Workers have exited; anything left in the closed queue never started.
+	p.abandonedMu.Lock()
+	abandoned := p.abandoned
+	p.abandoned = nil
+	p.abandonedMu.Unlock()
+	// Jobs abandoned mid-job were settled after start but not flushed.
+	flushed := p.settled.Load() - start - uint64(len(abandoned))
+	for job := range p.queue {
+		abandoned = append(abandoned, job)
+	}
+
+	report := DrainReport{
+		Flushed:   flushed,
+		Abandoned: len(abandoned),
+	}
+	if len(abandoned) == 0 {
+		return report, nil
+	}
+
+	if p.config.JournalPath == "" {
+		p.logger.Error("abandoned jobs lost, no journal configured",
+			zap.Int("abandoned", len(abandoned)),
+		)
+		return report, nil
+	}
+
+	// While replayed jobs are pending, the journal still holds those already
+	// stored, so it is rewritten rather than appended to. The pending ones
+	// are among the abandoned jobs.
+	p.journalMu.Lock()
+	replaying := p.replayPending > 0
+	p.replayPending = 0
+	p.journalMu.Unlock()
+
+	if err := writeJournal(p.config.JournalPath, abandoned, p.journalCodec(), replaying); err != nil {
+		return report, fmt.Errorf("journaling abandoned jobs: %w", err)
+	}
+	report.Journaled = len(abandoned)
+
+	p.logger.Info("journaled abandoned jobs",
+		zap.String("path", p.config.JournalPath),
+		zap.Int("count", len(abandoned)),
+	)
+	return report, nil
+}
+
+// complete reports whether job holds a request with its response or error,
+// or a usage record.
+func (job Job) complete() bool {
+	return job.Record != nil || (job.Req != nil && (job.Resp != nil || job.Error != nil))
+}
+
+// jobModel returns the request model for logging, tolerating a nil request.
+func jobModel(job Job) string {
+	switch {
+	case job.Record != nil:
+		return job.Record.Model
+	case job.Req != nil:
+		return job.Req.Model
+	default:
+		return ""
+	}
 }
 
 // worker is the inner worker thread that continuously pulls jobs off the jobs queue
@@ -126,26 +802,138 @@ func (p *Pool) worker(id uint) {
 	defer p.wg.Done()
 	p.logger.Debug("worker started", zap.Uint("worker_id", id))
 
-	for job := range p.queue {
-		p.processJob(job)
-	}
+	for {
+		// Check for cancellation first so that a passed drain deadline leaves
+		// remaining jobs in the queue to be journaled.
+		select {
+		case <-p.ctx.Done():
+			p.logger.Debug("storage worker canceled", zap.Uint("worker_id", id))
+			return
+		default:
+		}
 
-	p.logger.Debug("storage worker stopped", zap.Uint("worker_id", id))
+		select {
+		case <-p.ctx.Done():
+			p.logger.Debug("storage worker canceled", zap.Uint("worker_id", id))
+			return
+		case job, ok := <-p.queue:
+			if !ok {
+				p.logger.Debug("storage worker stopped", zap.Uint("worker_id", id))
+				return
+			}
+			p.processJob(job)
+			p.settled.Add(1)
+			if job.replayed {
+				p.settleReplayed()
+			}
+		}
+	}
 }
 
 // processJob processes a Job, storing the conversation turn and setting the
-// embedding if provided.
+// embedding if provided. A panic fails the job rather than the worker.
 func (p *Pool) processJob(job Job) {
-	ctx := context.Background()
+	defer func() {
+		if v := recover(); v != nil {
+			p.failed.Add(1)
+			p.crashed.Add(1)
+			p.config.Crashes.Log(p.logger, "worker", v, job.Metadata())
+		}
+	}()
+
+	ctx := p.ctx
+	if p.config.JobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.config.JobTimeout)
+		defer cancel()
+	}
 
-	head, newNodes, err := p.storeConversationTurn(ctx, job)
+	ctx, span := telemetry.Tracer().Start(
+		telemetry.ContextWithTraceParent(ctx, job.TraceParent),
+		"worker.store_turn",
+		trace.WithAttributes(
+			attribute.String("tapes.provider", job.Provider),
+			attribute.String("tapes.agent", job.AgentName),
+			attribute.String("tapes.model", jobModel(job)),
+		),
+	)
+	defer span.End()
+
+	turn, err := p.applyMiddleware(ctx, job)
+	if err != nil && p.ctx.Err() != nil {
+		p.abandon(job)
+		return
+	}
 	if err != nil {
+		p.failed.Add(1)
+		recordSpanError(span, err)
+		p.logger.Error("job rejected by middleware",
+			zap.String("provider", job.Provider),
+			zap.Error(err),
+		)
+		return
+	}
+	if turn == nil {
+		p.filtered.Add(1)
+		p.logger.Debug("job dropped by middleware",
+			zap.String("provider", job.Provider),
+			zap.String("path", job.Path),
+		)
+		return
+	}
+
+	var (
+		head      string
+		newNodes  []*merkle.Node
+		duplicate bool
+	)
+	if turn.Record != nil {
+		head, newNodes, duplicate, err = p.storeUsageRecord(ctx, *turn)
+	} else {
+		head, newNodes, duplicate, err = p.storeConversationTurn(ctx, *turn)
+	}
+	if err != nil && p.ctx.Err() != nil {
+		// The drain deadline passed mid-job: hand the job back to Close for
+		// journaling. Re-storing already written nodes is idempotent.
+		p.abandon(job)
+		return
+	}
+	if err != nil {
+		p.failed.Add(1)
+		recordSpanError(span, err)
 		p.logger.Error("async DAG storage failed",
 			zap.String("provider", job.Provider),
 			zap.Error(err),
 		)
 		return
 	}
+	// A retried request is its own exchange with the provider, so its raw
+	// capture and tools are stored even when its turn is a duplicate.
+	if len(turn.RawRequest) > 0 && len(turn.RawResponse) > 0 && turn.Resp != nil {
+		p.storeRawCapture(ctx, *turn, head)
+	}
+	if turn.SessionID != "" && turn.Req != nil && len(turn.Req.Tools) > 0 {
+		p.storeSessionTools(ctx, *turn, head)
+	}
+	if duplicate {
+		// The turn's nodes were already stored and published: count the
+		// retry without embedding them again.
+		p.duplicates.Add(1)
+		span.SetAttributes(
+			attribute.String("tapes.head", head),
+			attribute.Bool("tapes.duplicate", true),
+		)
+		p.logger.Debug("duplicate turn suppressed",
+			zap.String("head", head),
+			zap.String("provider", job.Provider),
+		)
+		return
+	}
+	p.processed.Add(1)
+	span.SetAttributes(
+		attribute.String("tapes.head", head),
+		attribute.Int("tapes.new_nodes", len(newNodes)),
+	)
 
 	p.logger.Info("conversation stored",
 		zap.String("head", head),
@@ -161,14 +949,181 @@ func (p *Pool) processJob(job Job) {
 	}
 }
 
+// Metadata describes the job for crash reports, leaving out its content.
+func (job Job) Metadata() map[string]string {
+	meta := map[string]string{
+		"provider": job.Provider,
+		"model":    jobModel(job),
+	}
+	set := func(key, value string) {
+		if value != "" {
+			meta[key] = value
+		}
+	}
+	set("agent", job.AgentName)
+	set("agent_version", job.AgentVersion)
+	set("run_id", job.RunID)
+	set("session_id", job.SessionID)
+	set("path", job.Path)
+	if job.Req != nil {
+		meta["messages"] = strconv.Itoa(len(job.Req.Messages))
+	}
+	if job.Resp != nil {
+		meta["response_blocks"] = strconv.Itoa(len(job.Resp.Message.Content))
+	}
+	if job.Error != nil {
+		meta["upstream_status"] = strconv.Itoa(job.Error.Status)
+	}
+	if job.Record != nil {
+		meta["record"] = job.Record.Kind
+	}
+	return meta
+}
+
+// abandon hands an in-flight job back to Close for journaling. The original
+// job is kept so that middleware runs again when it is replayed.
+func (p *Pool) abandon(job Job) {
+	p.abandonedMu.Lock()
+	p.abandoned = append(p.abandoned, job)
+	p.abandonedMu.Unlock()
+}
+
+// captureContent prepares content blocks for storage. Redaction runs first so
+// that secrets never reach the blob store or straddle the truncation limit.
+// Images and files are then moved to the media store, oversized payloads are
+// offloaded, and anything still over the capture limit is truncated.
+func (p *Pool) captureContent(ctx context.Context, blocks []llm.ContentBlock) []llm.ContentBlock {
+	if p.config.Redactor != nil {
+		blocks = p.config.Redactor.RedactBlocks(blocks)
+	}
+
+	if p.config.MediaStore != nil {
+		var err error
+		blocks, err = p.config.MediaStore.Capture(ctx, blocks)
+		if err != nil {
+			// Blocks that could not be stored keep their inline data.
+			p.logger.Warn("failed to store media", zap.Error(err))
+		}
+	}
+
+	blocks, err := blob.Offload(ctx, p.config.BlobStore, blocks, p.config.BlobThreshold)
+	if err != nil {
+		// Keep the content inline rather than losing the turn.
+		p.logger.Warn("failed to offload content to blob store", zap.Error(err))
+	}
+
+	return truncateContent(blocks, p.config.MaxContentBytes)
+}
+
+// attribution returns the project, user, trace ID, run, and agent version to
+// tag a job's nodes with. The job's own project and user take precedence over
+// the pool's.
+func (p *Pool) attribution(ctx context.Context, job Job) merkle.NodeMeta {
+	meta := merkle.NodeMeta{
+		Project: p.config.Project,
+		User:    p.config.User,
+		TraceID: telemetry.TraceID(ctx),
+		RunID:   job.RunID,
+
+		AgentVersion: job.AgentVersion,
+	}
+	if job.Project != "" {
+		meta.Project = job.Project
+	}
+	if job.User != "" {
+		meta.User = job.User
+	}
+	return meta
+}
+
+// responseUsage returns the usage reported with a job's response. Counts a
+// response left out, such as those of interrupted streams, are estimated
+// locally so that analytics and costs do not show them as free.
+func responseUsage(job Job) *llm.Usage {
+	usage := job.Resp.Usage
+	if usage != nil && usage.PromptTokens > 0 && usage.CompletionTokens > 0 {
+		return usage
+	}
+	return llm.EstimateUsage(job.Req, job.Resp)
+}
+
+// storeRawCapture stores a turn's original payloads, keyed by its response
+// node. Payloads are redacted like content when redaction is enabled.
+// Failures are logged and never fail the turn.
+func (p *Pool) storeRawCapture(ctx context.Context, job Job, head string) {
+	store, ok := p.config.Driver.(storage.RawCaptureStore)
+	if !ok {
+		return
+	}
+
+	meta := p.attribution(ctx, job)
+	rc := &storage.RawCapture{
+		NodeHash:  head,
+		Provider:  job.Provider,
+		AgentName: job.AgentName,
+		SessionID: job.SessionID,
+		RunID:     job.RunID,
+		Project:   meta.Project,
+		User:      meta.User,
+		Path:      job.Path,
+		Streamed:  job.Streamed,
+		Request:   job.RawRequest,
+		Response:  job.RawResponse,
+	}
+	if p.config.Redactor != nil {
+		rc.Request = []byte(p.config.Redactor.RedactPayload(string(rc.Request)))
+		rc.Response = []byte(p.config.Redactor.RedactPayload(string(rc.Response)))
+	}
+
+	if err := store.AddRawCapture(ctx, rc); err != nil {
+		p.logger.Warn("failed to store raw capture",
+			zap.String("head", head),
+			zap.Error(err),
+		)
+	}
+}
+
+// storeSessionTools stores the tool definitions offered in a turn, linked to
+// its session. Failures are logged and never fail the turn.
+func (p *Pool) storeSessionTools(ctx context.Context, job Job, head string) {
+	store, ok := p.config.Driver.(storage.ToolStore)
+	if !ok {
+		return
+	}
+
+	tools := make([]*storage.SessionTool, 0, len(job.Req.Tools))
+	for _, tool := range job.Req.Tools {
+		tools = append(tools, &storage.SessionTool{
+			SessionID: job.SessionID,
+			RunID:     job.RunID,
+			AgentName: job.AgentName,
+			NodeHash:  head,
+			Tool:      tool,
+		})
+	}
+	if err := store.AddSessionTools(ctx, tools); err != nil {
+		p.logger.Warn("failed to store session tools",
+			zap.String("head", head),
+			zap.String("session_id", job.SessionID),
+			zap.Error(err),
+		)
+	}
+}
+
 // storeConversationTurn stores a request-response pair in the merkle dag.
-// Returns the head hash and the slice of nodes that were newly Put.
-func (p *Pool) storeConversationTurn(ctx context.Context, job Job) (string, []*merkle.Node, error) {
+// The turn's nodes are stored in one batch. Returns the head hash, the
+// slice of nodes that were newly inserted and are worth embedding, and
+// whether the head was already stored, making the turn a duplicate.
+func (p *Pool) storeConversationTurn(ctx context.Context, job Job) (string, []*merkle.Node, bool, error) {
 	var parent *merkle.Node
-	var newNodes []*merkle.Node
+	var nodes []*merkle.Node
 
-	// Store each message from the request as nodes.
+	meta := p.attribution(ctx, job)
+	promptHash := p.storeSystemPrompt(ctx, job)
+
+	// Build a node for each message from the request.
 	for _, msg := range job.Req.Messages {
+		msg.Content = p.captureContent(ctx, msg.Content)
 		bucket := merkle.Bucket{
 			Type:      "message",
 			Role:      msg.Role,
@@ -176,63 +1131,284 @@ func (p *Pool) storeConversationTurn(ctx context.Context, job Job) (string, []*m
 			Model:     job.Req.Model,
 			Provider:  job.Provider,
 			AgentName: job.AgentName,
+			SessionID: job.SessionID,
 		}
 
-		node := merkle.NewNode(bucket, parent, merkle.NodeMeta{Project: p.config.Project})
+		node := merkle.NewNode(bucket, parent, meta)
+		nodes = append(nodes, node)
+		parent = node
+	}
+
+	var head *merkle.Node
+	if job.Resp == nil {
+		head = p.upstreamErrorNode(ctx, job, parent, meta, promptHash)
+	} else {
+		head = merkle.NewNode(
+			merkle.Bucket{
+				Type:      "message",
+				Role:      job.Resp.Message.Role,
+				Content:   p.captureContent(ctx, job.Resp.Message.Content),
+				Model:     job.Resp.Model,
+				Provider:  job.Provider,
+				AgentName: job.AgentName,
+				SessionID: job.SessionID,
+			},
+			parent,
+			merkle.NodeMeta{
+				StopReason: job.Resp.StopReason,
+				Usage:      responseUsage(job),
+				Timing:     job.Timing,
+				Project:    meta.Project,
+				User:       meta.User,
+				TraceID:    meta.TraceID,
+				RunID:      meta.RunID,
 
-		isNew, err := p.config.Driver.Put(ctx, node)
-		if err != nil {
-			return "", nil, fmt.Errorf("storing message node: %w", err)
-		}
+				AgentVersion:     meta.AgentVersion,
+				SystemPromptHash: promptHash,
+				PolicyViolations: job.PolicyViolations,
+			},
+		)
+	}
+	nodes = append(nodes, head)
+
+	inserted, err := p.insertBatch(ctx, nodes)
+	if err != nil {
+		return "", nil, false, fmt.Errorf("storing turn: %w", err)
+	}
+
+	rootHash := nodes[0].Hash
+	p.touchSession(rootHash, job, time.Now())
 
-		p.logger.Debug("stored message in DAG",
+	var newNodes []*merkle.Node
+	for i, node := range nodes {
+		p.logger.Debug("stored node in DAG",
 			zap.String("hash", node.Hash),
-			zap.String("role", msg.Role),
-			zap.String("content", msg.GetText()),
-			zap.Bool("is_new", isNew),
+			zap.String("type", node.Bucket.Type),
+			zap.String("role", node.Bucket.Role),
+			zap.String("content", node.Bucket.ExtractText()),
+			zap.Bool("is_new", inserted[i]),
 		)
 
-		if isNew {
+		if !inserted[i] {
+			continue
+		}
+		p.publish(ctx, node, rootHash)
+
+		// An upstream error is not worth embedding.
+		if node.Bucket.Type != "error" {
 			newNodes = append(newNodes, node)
 		}
-		parent = node
 	}
 
-	responseBucket := merkle.Bucket{
-		Type:      "message",
-		Role:      job.Resp.Message.Role,
-		Content:   job.Resp.Message.Content,
-		Model:     job.Resp.Model,
-		Provider:  job.Provider,
-		AgentName: job.AgentName,
+	return head.Hash, newNodes, !inserted[len(nodes)-1], nil
+}
+
+// upstreamErrorNode builds the node for the error a turn failed with, stored
+// after the request's messages. The node is an assistant turn that stopped
+// with an "error" stop reason, so sessions ending in it are marked failed.
+func (p *Pool) upstreamErrorNode(ctx context.Context, job Job, parent *merkle.Node, meta merkle.NodeMeta, promptHash string) *merkle.Node {
+	upstreamErr := *job.Error
+	if p.config.Redactor != nil {
+		upstreamErr.Message = p.config.Redactor.RedactString(upstreamErr.Message)
+	}
+
+	model := ""
+	if job.Req != nil {
+		model = job.Req.Model
 	}
 
-	responseNode := merkle.NewNode(
-		responseBucket,
+	text := fmt.Sprintf("%d", upstreamErr.Status)
+	if upstreamErr.Type != "" {
+		text += " " + upstreamErr.Type
+	}
+	if upstreamErr.Message != "" {
+		text += ": " + upstreamErr.Message
+	}
+	// Failed attempts of a retried request would otherwise share one node.
+	if upstreamErr.Attempt > 0 {
+		text += fmt.Sprintf(" (attempt %d)", upstreamErr.Attempt)
+	}
+
+	return merkle.NewNode(
+		merkle.Bucket{
+			Type:      "error",
+			Role:      "assistant",
+			Content:   p.captureContent(ctx, []llm.ContentBlock{{Type: "text", Text: text}}),
+			Model:     model,
+			Provider:  job.Provider,
+			AgentName: job.AgentName,
+			SessionID: job.SessionID,
+		},
 		parent,
 		merkle.NodeMeta{
-			StopReason: job.Resp.StopReason,
-			Usage:      job.Resp.Usage,
-			Project:    p.config.Project,
+			StopReason: "error",
+			Timing:     job.Timing,
+			Error:      &upstreamErr,
+			Project:    meta.Project,
+			User:       meta.User,
+			TraceID:    meta.TraceID,
+			RunID:      meta.RunID,
+
+			AgentVersion:     meta.AgentVersion,
+			SystemPromptHash: promptHash,
+			PolicyViolations: job.PolicyViolations,
 		},
 	)
+}
 
-	isNew, err := p.config.Driver.Put(ctx, responseNode)
+// storeSystemPrompt stores the system prompt of a job's request, redacted
+// like content, and returns its hash for the turn's response node. It
+// returns "" when the request has no system prompt or the driver cannot
+// store it. Failures are logged and never fail the turn.
+func (p *Pool) storeSystemPrompt(ctx context.Context, job Job) string {
+	store, ok := p.config.Driver.(storage.SystemPromptStore)
+	if !ok || job.Req == nil {
+		return ""
+	}
+	prompt := job.Req.SystemPrompt()
+	if prompt == "" {
+		return ""
+	}
+	if p.config.Redactor != nil {
+		prompt = p.config.Redactor.RedactString(prompt)
+	}
+
+	hash, err := store.AddSystemPrompt(ctx, prompt)
 	if err != nil {
-		return "", nil, fmt.Errorf("storing response node: %w", err)
+		p.logger.Warn("failed to store system prompt",
+			zap.String("provider", job.Provider),
+			zap.Error(err),
+		)
+		return ""
+	}
+	return hash
+}
+
+// storeUsageRecord stores a billable request other than chat as a single
+// root node whose type is the record kind. The node carries the usage and
+// timing for cost analytics, and its content summarizes the request without
+// the input text, vectors, or audio. The request time is part of the content
+// so that identical requests are counted separately, and a node already
+// stored is a duplicate job.
+func (p *Pool) storeUsageRecord(ctx context.Context, job Job) (string, []*merkle.Node, bool, error) {
+	meta := p.attribution(ctx, job)
+
+	record := job.Record
+	summary := fmt.Sprintf("%s: %d input(s), %d bytes", record.Kind, record.Inputs, record.InputBytes)
+	if record.Dimensions > 0 {
+		summary += fmt.Sprintf(", %d dimensions", record.Dimensions)
+	}
+	if record.AudioSeconds > 0 {
+		summary += fmt.Sprintf(", %.1fs of audio", record.AudioSeconds)
+	}
+	if job.Timing != nil {
+		summary += ", at " + job.Timing.RequestStartedAt.UTC().Format(time.RFC3339Nano)
 	}
 
-	p.logger.Debug("stored response in DAG",
-		zap.String("hash", responseNode.Hash),
-		zap.String("content_preview", job.Resp.Message.GetText()),
-		zap.Bool("is_new", isNew),
+	node := merkle.NewNode(
+		merkle.Bucket{
+			Type:      record.Kind,
+			Role:      "assistant",
+			Content:   []llm.ContentBlock{{Type: "text", Text: summary}},
+			Model:     record.Model,
+			Provider:  job.Provider,
+			AgentName: job.AgentName,
+			SessionID: job.SessionID,
+		},
+		nil,
+		merkle.NodeMeta{
+			Usage:   record.Usage,
+			Timing:  job.Timing,
+			Project: meta.Project,
+			User:    meta.User,
+			TraceID: meta.TraceID,
+			RunID:   meta.RunID,
+
+			AgentVersion: meta.AgentVersion,
+		},
 	)
 
+	isNew, err := p.put(ctx, node)
+	if err != nil {
+		return "", nil, false, fmt.Errorf("storing %s node: %w", record.Kind, err)
+	}
 	if isNew {
-		newNodes = append(newNodes, responseNode)
+		p.publish(ctx, node, node.Hash)
+	}
+
+	// The summary is not worth embedding, so no nodes are returned as new.
+	return node.Hash, nil, !isNew, nil
+}
+
+// put stores node within a storage span.
+func (p *Pool) put(ctx context.Context, node *merkle.Node) (bool, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.put", trace.WithAttributes(
+		attribute.String("tapes.node.hash", node.Hash),
+		attribute.String("tapes.node.role", node.Bucket.Role),
+	))
+	defer span.End()
+
+	p.sign(node)
+	isNew, err := p.config.Driver.Put(ctx, node)
+	if err != nil {
+		recordSpanError(span, err)
+		return false, err
+	}
+	span.SetAttributes(attribute.Bool("tapes.node.new", isNew))
+	return isNew, nil
+}
+
+// insertBatch stores a turn's nodes within a storage span.
+func (p *Pool) insertBatch(ctx context.Context, nodes []*merkle.Node) ([]bool, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "storage.insert_batch", trace.WithAttributes(
+		attribute.Int("tapes.nodes", len(nodes)),
+	))
+	defer span.End()
+
+	p.sign(nodes...)
+	inserted, err := p.config.Driver.InsertBatch(ctx, nodes)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	newNodes := 0
+	for _, isNew := range inserted {
+		if isNew {
+			newNodes++
+		}
 	}
+	span.SetAttributes(attribute.Int("tapes.nodes.new", newNodes))
+	return inserted, nil
+}
+
+// sign signs nodes with Config.SigningKey, when set.
+func (p *Pool) sign(nodes ...*merkle.Node) {
+	if p.config.SigningKey == nil {
+		return
+	}
+	for _, node := range nodes {
+		node.Sign(p.config.SigningKey)
+	}
+}
+
+// publish announces a newly stored node to live subscribers.
+func (p *Pool) publish(ctx context.Context, node *merkle.Node, rootHash string) {
+	if p.config.Events == nil {
+		return
+	}
+	_, span := telemetry.Tracer().Start(ctx, "events.publish", trace.WithAttributes(
+		attribute.String("tapes.node.hash", node.Hash),
+	))
+	defer span.End()
+
+	p.config.Events.Publish(events.NodeCreated(node, rootHash))
+}
 
-	return responseNode.Hash, newNodes, nil
+// recordSpanError marks span as failed with err.
+func recordSpanError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
 }
 
 // storeEmbeddings generates and stores embeddings for the given nodes.
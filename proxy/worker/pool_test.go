@@ -1,18 +1,30 @@
 package worker
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/zap"
 
+	"github.com/papercomputeco/tapes/pkg/capture"
+	"github.com/papercomputeco/tapes/pkg/crash"
+	"github.com/papercomputeco/tapes/pkg/events"
 	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/redact"
+	"github.com/papercomputeco/tapes/pkg/storage/encryption"
 	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
 )
 
 // newTestPool creates a worker pool backed by an in-memory driver.
-// Callers should "wp.Close()" to drain enqueued jobs before asserting storage state.
+// Callers should "drain(wp)" to drain enqueued jobs before asserting storage state.
 func newTestPool() (*Pool, *inmemory.Driver) {
 	logger, _ := zap.NewDevelopment()
 	driver := inmemory.NewDriver()
@@ -26,6 +38,614 @@ func newTestPool() (*Pool, *inmemory.Driver) {
 	return wp, driver
 }
 
+// drain closes the pool without a deadline, waiting for every job to finish.
+func drain(wp *Pool) DrainReport {
+	report, err := wp.Close(context.Background())
+	Expect(err).NotTo(HaveOccurred())
+	return report
+}
+
+// gatedDriver blocks every write until the gate channel is closed, letting
+// tests fill the job queue deterministically.
+type gatedDriver struct {
+	*inmemory.Driver
+	gate chan struct{}
+}
+
+func (d *gatedDriver) wait(ctx context.Context) error {
+	select {
+	case <-d.gate:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *gatedDriver) Put(ctx context.Context, node *merkle.Node) (bool, error) {
+	if err := d.wait(ctx); err != nil {
+		return false, err
+	}
+	return d.Driver.Put(ctx, node)
+}
+
+func (d *gatedDriver) InsertBatch(ctx context.Context, nodes []*merkle.Node) ([]bool, error) {
+	if err := d.wait(ctx); err != nil {
+		return nil, err
+	}
+	return d.Driver.InsertBatch(ctx, nodes)
+}
+
+// testJob builds a single-turn job whose user prompt is the given text.
+func testJob(text string) Job {
+	return Job{
+		Provider: "test-provider",
+		Req: &llm.ChatRequest{
+			Model: "test-model",
+			Messages: []llm.Message{
+				{Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: text}}},
+			},
+		},
+		Resp: &llm.ChatResponse{
+			Model: "test-model",
+			Message: llm.Message{
+				Role:    "assistant",
+				Content: []llm.ContentBlock{{Type: "text", Text: "ok"}},
+			},
+		},
+	}
+}
+
+// newGatedPool creates a single-worker pool with a queue of size 1 whose
+// worker is parked on the first job until the gate is closed.
+func newGatedPool(policy OverflowPolicy, enqueueTimeout time.Duration) (*Pool, *gatedDriver) {
+	logger, _ := zap.NewDevelopment()
+	driver := &gatedDriver{Driver: inmemory.NewDriver(), gate: make(chan struct{})}
+
+	wp, err := NewPool(&Config{
+		Driver:         driver,
+		Logger:         logger,
+		NumWorkers:     1,
+		QueueSize:      1,
+		OverflowPolicy: policy,
+		EnqueueTimeout: enqueueTimeout,
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	// Park the worker on the first job so the queue itself can fill up.
+	Expect(wp.Submit(testJob("in flight"))).To(Succeed())
+	Eventually(func() int { return wp.Stats().Queued }).Should(Equal(0))
+
+	return wp, driver
+}
+
+var _ = Describe("Overflow policies", func() {
+	It("rejects unknown policies", func() {
+		_, err := ParseOverflowPolicy("spill-to-disk")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("defaults to drop-new", func() {
+		policy, err := ParseOverflowPolicy("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy).To(Equal(OverflowDropNew))
+	})
+
+	It("drop-new rejects the incoming job with ErrQueueFull", func() {
+		wp, driver := newGatedPool(OverflowDropNew, 0)
+
+		Expect(wp.Submit(testJob("queued"))).To(Succeed())
+		Expect(wp.Submit(testJob("rejected"))).To(MatchError(ErrQueueFull))
+		Expect(wp.Stats().DroppedNew).To(Equal(uint64(1)))
+
+		close(driver.gate)
+		drain(wp)
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		texts := []string{}
+		for _, n := range nodes {
+			texts = append(texts, n.Bucket.ExtractText())
+		}
+		Expect(texts).To(ContainElement("queued"))
+		Expect(texts).NotTo(ContainElement("rejected"))
+	})
+
+	It("drop-oldest evicts the queued job in favor of the new one", func() {
+		wp, driver := newGatedPool(OverflowDropOldest, 0)
+
+		Expect(wp.Submit(testJob("oldest"))).To(Succeed())
+		Expect(wp.Submit(testJob("newest"))).To(Succeed())
+		Expect(wp.Stats().DroppedOldest).To(Equal(uint64(1)))
+
+		close(driver.gate)
+		drain(wp)
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		texts := []string{}
+		for _, n := range nodes {
+			texts = append(texts, n.Bucket.ExtractText())
+		}
+		Expect(texts).To(ContainElement("newest"))
+		Expect(texts).NotTo(ContainElement("oldest"))
+	})
+
+	It("block times out with ErrEnqueueTimeout when no capacity frees up", func() {
+		wp, driver := newGatedPool(OverflowBlock, 20*time.Millisecond)
+
+		Expect(wp.Submit(testJob("queued"))).To(Succeed())
+		Expect(wp.Submit(testJob("late"))).To(MatchError(ErrEnqueueTimeout))
+
+		close(driver.gate)
+		drain(wp)
+	})
+
+	It("is a no-op when closed twice", func() {
+		wp, _ := newTestPool()
+		drain(wp)
+		Expect(drain(wp)).To(Equal(DrainReport{}))
+	})
+
+	It("returns ErrPoolClosed after Close", func() {
+		wp, _ := newTestPool()
+		drain(wp)
+		Expect(wp.Submit(testJob("after close"))).To(MatchError(ErrPoolClosed))
+	})
+
+	It("fails jobs that exceed the job timeout", func() {
+		logger, _ := zap.NewDevelopment()
+		driver := &gatedDriver{Driver: inmemory.NewDriver(), gate: make(chan struct{})}
+		wp, err := NewPool(&Config{
+			Driver:     driver,
+			Logger:     logger,
+			JobTimeout: 10 * time.Millisecond,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(wp.Submit(testJob("slow"))).To(Succeed())
+		drain(wp)
+
+		Expect(wp.Stats().Failed).To(Equal(uint64(1)))
+		Expect(driver.Count()).To(Equal(0))
+	})
+})
+
+var _ = Describe("Graceful drain", func() {
+	It("reports every job as flushed when the drain completes", func() {
+		wp, _ := newTestPool()
+		Expect(wp.Submit(testJob("one"))).To(Succeed())
+		Expect(wp.Submit(testJob("two"))).To(Succeed())
+
+		report := drain(wp)
+		Expect(report.Flushed).To(Equal(uint64(2)))
+		Expect(report.Abandoned).To(Equal(0))
+	})
+
+	It("counts duplicate and filtered jobs as flushed", func() {
+		wp, err := NewPool(&Config{
+			Driver:     inmemory.NewDriver(),
+			Logger:     zap.NewNop(),
+			NumWorkers: 1,
+			Middleware: []Middleware{func(_ context.Context, job *Job) (*Job, error) {
+				if job.Req.Messages[0].Content[0].Text == "drop" {
+					return nil, nil
+				}
+				return job, nil
+			}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wp.Submit(testJob("one"))).To(Succeed())
+		Expect(wp.Submit(testJob("one"))).To(Succeed())
+		Expect(wp.Submit(testJob("drop"))).To(Succeed())
+
+		report := drain(wp)
+		Expect(report.Flushed).To(Equal(uint64(3)))
+		Expect(report.Abandoned).To(Equal(0))
+	})
+
+	It("journals abandoned jobs and replays them on the next start", func() {
+		journal := filepath.Join(GinkgoT().TempDir(), "journal.jsonl")
+		logger, _ := zap.NewDevelopment()
+
+		stuck := &gatedDriver{Driver: inmemory.NewDriver(), gate: make(chan struct{})}
+		wp, err := NewPool(&Config{
+			Driver:      stuck,
+			Logger:      logger,
+			NumWorkers:  1,
+			JournalPath: journal,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(wp.Submit(testJob("in flight"))).To(Succeed())
+		Expect(wp.Submit(testJob("queued"))).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		report, err := wp.Close(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Flushed).To(Equal(uint64(0)))
+		Expect(report.Abandoned).To(Equal(2))
+		Expect(report.Journaled).To(Equal(2))
+		Expect(journal).To(BeAnExistingFile())
+
+		driver := inmemory.NewDriver()
+		wp, err = NewPool(&Config{
+			Driver:      driver,
+			Logger:      logger,
+			JournalPath: journal,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		drain(wp)
+		Expect(journal).NotTo(BeAnExistingFile())
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		texts := []string{}
+		for _, n := range nodes {
+			texts = append(texts, n.Bucket.ExtractText())
+		}
+		Expect(texts).To(ContainElements("in flight", "queued"))
+	})
+
+	It("skips malformed journal entries", func() {
+		journal := filepath.Join(GinkgoT().TempDir(), "journal.jsonl")
+		Expect(os.WriteFile(journal, []byte("{\"provider\":\"trunc"), 0o600)).To(Succeed())
+
+		wp, driver := newTestPoolWithJournal(journal)
+		drain(wp)
+		Expect(driver.Count()).To(Equal(0))
+		Expect(journal).NotTo(BeAnExistingFile())
+	})
+
+	It("keeps the journal until replayed jobs are stored", func() {
+		journal := filepath.Join(GinkgoT().TempDir(), "journal.jsonl")
+		Expect(writeJournal(journal, []Job{testJob("first"), testJob("second")}, journalCodec{}, false)).To(Succeed())
+		logger, _ := zap.NewDevelopment()
+
+		stuck := &gatedDriver{Driver: inmemory.NewDriver(), gate: make(chan struct{})}
+		wp, err := NewPool(&Config{
+			Driver:      stuck,
+			Logger:      logger,
+			NumWorkers:  1,
+			JournalPath: journal,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(journal).To(BeAnExistingFile())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		report, err := wp.Close(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Journaled).To(Equal(2))
+
+		// The journal is rewritten with the unstored jobs, not appended to.
+		jobs, _, err := readJournal(journal, journalCodec{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jobs).To(HaveLen(2))
+	})
+
+	It("redacts and seals journaled jobs", func() {
+		journal := filepath.Join(GinkgoT().TempDir(), "journal.jsonl")
+		logger, _ := zap.NewDevelopment()
+		redactor, err := redact.NewRedactor(nil)
+		Expect(err).NotTo(HaveOccurred())
+		cipher, err := encryption.NewCipher(bytes.Repeat([]byte{7}, encryption.KeySize))
+		Expect(err).NotTo(HaveOccurred())
+
+		stuck := &gatedDriver{Driver: inmemory.NewDriver(), gate: make(chan struct{})}
+		wp, err := NewPool(&Config{
+			Driver:      &encryptedDriver{gatedDriver: stuck, cipher: cipher},
+			Logger:      logger,
+			NumWorkers:  1,
+			Redactor:    redactor,
+			JournalPath: journal,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		job := testJob("mail jane@example.com")
+		job.RawRequest = []byte(`{"messages":[{"content":"mail jane@example.com"}]}`)
+		Expect(wp.Submit(job)).To(Succeed())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		report, err := wp.Close(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Journaled).To(Equal(1))
+
+		raw, err := os.ReadFile(journal)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(raw)).NotTo(ContainSubstring("mail"))
+
+		// Without the key the journal is kept for a later replay.
+		_, _, err = readJournal(journal, journalCodec{})
+		Expect(err).To(MatchError(errJournalEncrypted))
+
+		jobs, _, err := readJournal(journal, journalCodec{cipher: cipher})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jobs).To(HaveLen(1))
+		Expect(jobs[0].Req.Messages[0].Content[0].Text).To(Equal("mail [REDACTED:email]"))
+		Expect(string(jobs[0].RawRequest)).NotTo(ContainSubstring("jane@example.com"))
+	})
+})
+
+// encryptedDriver reports a storage cipher, as drivers with encryption at
+// rest do.
+type encryptedDriver struct {
+	*gatedDriver
+	cipher *encryption.Cipher
+}
+
+func (d *encryptedDriver) Cipher() *encryption.Cipher {
+	return d.cipher
+}
+
+// newTestPoolWithJournal creates an in-memory backed pool that replays from
+// and journals to the given path.
+func newTestPoolWithJournal(journal string) (*Pool, *inmemory.Driver) {
+	logger, _ := zap.NewDevelopment()
+	driver := inmemory.NewDriver()
+
+	wp, err := NewPool(&Config{
+		Driver:      driver,
+		Logger:      logger,
+		JournalPath: journal,
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	return wp, driver
+}
+
+var _ = Describe("Event publishing", func() {
+	It("publishes each newly stored node once, tagged with the conversation root", func() {
+		logger, _ := zap.NewDevelopment()
+		broker := events.NewBroker()
+		ch, cancel := broker.Subscribe(events.Filter{})
+		defer cancel()
+
+		wp, err := NewPool(&Config{
+			Driver:     inmemory.NewDriver(),
+			Logger:     logger,
+			NumWorkers: 1,
+			Events:     broker,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		first := testJob("hello")
+		Expect(wp.Submit(first)).To(Succeed())
+
+		// The second turn repeats the first turn's nodes, which are not new.
+		second := testJob("hello")
+		second.Req.Messages = append(second.Req.Messages,
+			first.Resp.Message,
+			llm.Message{Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "again"}}},
+		)
+		Expect(wp.Submit(second)).To(Succeed())
+		drain(wp)
+
+		var received []events.Event
+		for len(ch) > 0 {
+			received = append(received, <-ch)
+		}
+		Expect(received).To(HaveLen(4))
+
+		root := received[0].Hash
+		roles := make([]string, 0, len(received))
+		for _, event := range received {
+			Expect(event.Type).To(Equal(events.TypeNodeCreated))
+			Expect(event.RootHash).To(Equal(root))
+			roles = append(roles, event.Role)
+		}
+		Expect(roles).To(Equal([]string{"user", "assistant", "user", "assistant"}))
+		Expect(received[2].Text).To(Equal("again"))
+	})
+})
+
+var _ = Describe("Node signing", func() {
+	It("signs stored nodes and their events with the signing key", func() {
+		pub, key, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+
+		broker := events.NewBroker()
+		ch, cancel := broker.Subscribe(events.Filter{})
+		defer cancel()
+
+		driver := inmemory.NewDriver()
+		wp, err := NewPool(&Config{Driver: driver, Logger: zap.NewNop(), Events: broker, SigningKey: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(wp.Submit(testJob("hello"))).To(Succeed())
+		drain(wp)
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(2))
+		for _, n := range nodes {
+			Expect(n.VerifySignature()).To(BeTrue())
+			Expect([]byte(n.SignerKey)).To(Equal([]byte(pub)))
+		}
+
+		event := <-ch
+		Expect(event.SignerKey).To(Equal([]byte(pub)))
+		stored, err := driver.Get(context.Background(), event.Hash)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ed25519.Verify(pub, stored.SignedPayload(), event.Signature)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Duplicate turns", func() {
+	It("stores and publishes a retried turn once and counts the retry", func() {
+		broker := events.NewBroker()
+		ch, cancel := broker.Subscribe(events.Filter{})
+		defer cancel()
+
+		driver := inmemory.NewDriver()
+		wp, err := NewPool(&Config{
+			Driver:     driver,
+			Logger:     zap.NewNop(),
+			NumWorkers: 1,
+			Events:     broker,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(wp.Submit(testJob("hello"))).To(Succeed())
+		Expect(wp.Submit(testJob("hello"))).To(Succeed())
+		drain(wp)
+
+		stats := wp.Stats()
+		Expect(stats.Processed).To(Equal(uint64(1)))
+		Expect(stats.Duplicates).To(Equal(uint64(1)))
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(2))
+		Expect(ch).To(HaveLen(2))
+	})
+
+	It("stores the raw capture of a retried turn", func() {
+		driver := inmemory.NewDriver()
+		wp, err := NewPool(&Config{Driver: driver, Logger: zap.NewNop(), NumWorkers: 1})
+		Expect(err).NotTo(HaveOccurred())
+
+		for range 2 {
+			job := testJob("hello")
+			job.RawRequest = []byte(`{"messages":[{"content":"hello"}]}`)
+			job.RawResponse = []byte(`{"content":"hi"}`)
+			Expect(wp.Submit(job)).To(Succeed())
+		}
+		drain(wp)
+
+		Expect(wp.Stats().Duplicates).To(Equal(uint64(1)))
+		captures, err := driver.ListRawCaptures(context.Background(), time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(captures).To(HaveLen(2))
+		Expect(captures[0].NodeHash).To(Equal(captures[1].NodeHash))
+	})
+})
+
+var _ = Describe("Unrecorded jobs", func() {
+	It("counts them without storing or publishing anything", func() {
+		broker := events.NewBroker()
+		ch, cancel := broker.Subscribe(events.Filter{})
+		defer cancel()
+
+		driver := inmemory.NewDriver()
+		wp, err := NewPool(&Config{Driver: driver, Logger: zap.NewNop(), Events: broker})
+		Expect(err).NotTo(HaveOccurred())
+
+		job := testJob("hello")
+		job.Unrecorded = true
+		job.Resp.Usage = &llm.Usage{PromptTokens: 10, CompletionTokens: 5}
+		Expect(wp.Submit(job)).To(Succeed())
+		drain(wp)
+
+		stats := wp.Stats()
+		Expect(stats.Unrecorded).To(Equal(uint64(1)))
+		Expect(stats.UnrecordedTokens).To(Equal(uint64(15)))
+		Expect(stats.Enqueued).To(BeZero())
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(BeEmpty())
+		Expect(ch).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Crash recovery", func() {
+	It("fails a job that panics, records a report, and keeps processing", func() {
+		crashDir := filepath.Join(GinkgoT().TempDir(), crash.DirName)
+		driver := inmemory.NewDriver()
+		wp, err := NewPool(&Config{
+			Driver:     driver,
+			Logger:     zap.NewNop(),
+			NumWorkers: 1,
+			Crashes:    crash.NewRecorder(crashDir),
+			Middleware: []Middleware{func(_ context.Context, job *Job) (*Job, error) {
+				if job.Req.Messages[0].Content[0].Text == "poison" {
+					panic("malformed payload")
+				}
+				return job, nil
+			}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		poison := testJob("poison")
+		poison.AgentName = "claude"
+		Expect(wp.Submit(poison)).To(Succeed())
+		Expect(wp.Submit(testJob("hello"))).To(Succeed())
+		drain(wp)
+
+		stats := wp.Stats()
+		Expect(stats.Processed).To(Equal(uint64(1)))
+		Expect(stats.Failed).To(Equal(uint64(1)))
+		Expect(stats.Crashed).To(Equal(uint64(1)))
+
+		reports, err := crash.List(crashDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reports).To(HaveLen(1))
+		Expect(reports[0].Component).To(Equal("worker"))
+		Expect(reports[0].Panic).To(Equal("malformed payload"))
+		Expect(reports[0].Stack).To(ContainSubstring("pool_test.go"))
+		Expect(reports[0].Metadata).To(Equal(map[string]string{
+			"provider":        "test-provider",
+			"model":           "test-model",
+			"agent":           "claude",
+			"messages":        "1",
+			"response_blocks": "1",
+		}))
+	})
+})
+
+var _ = Describe("Capture rules", func() {
+	It("skips matching jobs before they are queued and counts them per rule", func() {
+		rules, err := capture.NewRules([]capture.RuleSpec{
+			{Name: "embeddings", Path: `/embeddings$`},
+			{Name: "classified", SystemPrompt: `(?i)project: classified`},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		driver := inmemory.NewDriver()
+		wp, err := NewPool(&Config{Driver: driver, Logger: zap.NewNop(), CaptureRules: rules})
+		Expect(err).NotTo(HaveOccurred())
+
+		embedding := testJob("embed me")
+		embedding.Path = "/v1/embeddings"
+		Expect(wp.Submit(embedding)).To(Succeed())
+
+		classified := testJob("secret plans")
+		classified.Req.Messages = append([]llm.Message{llm.NewTextMessage("system", "Project: Classified")}, classified.Req.Messages...)
+		Expect(wp.Submit(classified)).To(Succeed())
+
+		Expect(wp.Submit(testJob("hello"))).To(Succeed())
+		drain(wp)
+
+		stats := wp.Stats()
+		Expect(stats.Enqueued).To(Equal(uint64(1)))
+		Expect(stats.Skipped).To(Equal(map[string]uint64{"embeddings": 1, "classified": 1}))
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(2))
+	})
+})
+
+var _ = Describe("Trace context", func() {
+	It("stores the job's trace ID on every new node", func() {
+		wp, driver := newTestPool()
+
+		job := testJob("hello")
+		job.TraceParent = "00-4bf92f3577b34a736d9e2b3ad0e3c1a2-00f067aa0ba902b7-01"
+		Expect(wp.Submit(job)).To(Succeed())
+		drain(wp)
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(HaveLen(2))
+		for _, node := range nodes {
+			Expect(node.TraceID).To(Equal("4bf92f3577b34a736d9e2b3ad0e3c1a2"))
+		}
+	})
+})
+
 var _ = Describe("Worker Pool", func() {
 	var (
 		wp     *Pool
@@ -57,13 +677,103 @@ var _ = Describe("Worker Pool", func() {
 				},
 			})
 			Expect(ok).To(BeTrue())
-			wp.Close()
+			drain(wp)
+		})
+	})
+
+	Describe("Usage Estimation", func() {
+		It("estimates usage for responses that report none", func() {
+			wp.Enqueue(Job{
+				Provider: "anthropic",
+				Req: &llm.ChatRequest{
+					Model:    "claude-sonnet-4-5",
+					Messages: []llm.Message{{Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "Write a haiku about tape."}}}},
+				},
+				Resp: &llm.ChatResponse{
+					Model:   "claude-sonnet-4-5",
+					Message: llm.Message{Role: "assistant", Content: []llm.ContentBlock{{Type: "text", Text: "Magnetic ribbon / remembers every word said / rewind, play again"}}},
+				},
+			})
+			drain(wp)
+
+			leaves, err := driver.Leaves(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leaves).To(HaveLen(1))
+			usage := leaves[0].Usage
+			Expect(usage).NotTo(BeNil())
+			Expect(usage.Estimated).To(BeTrue())
+			Expect(usage.PromptTokens).To(BeNumerically(">", 0))
+			Expect(usage.CompletionTokens).To(BeNumerically(">", usage.PromptTokens))
+			Expect(usage.TotalTokens).To(Equal(usage.PromptTokens + usage.CompletionTokens))
+		})
+
+		It("keeps the counts a response did report", func() {
+			wp.Enqueue(Job{
+				Provider: "openai",
+				Req: &llm.ChatRequest{
+					Model:    "gpt-4o",
+					Messages: []llm.Message{{Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "hello"}}}},
+				},
+				Resp: &llm.ChatResponse{
+					Model:   "gpt-4o",
+					Usage:   &llm.Usage{PromptTokens: 120},
+					Message: llm.Message{Role: "assistant", Content: []llm.ContentBlock{{Type: "text", Text: "Hi there!"}}},
+				},
+			})
+			drain(wp)
+
+			leaves, err := driver.Leaves(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			usage := leaves[0].Usage
+			Expect(usage.PromptTokens).To(Equal(120))
+			Expect(usage.CompletionTokens).To(BeNumerically(">", 0))
+			Expect(usage.Estimated).To(BeTrue())
+		})
+
+		It("keeps a reported zero for an empty response", func() {
+			wp.Enqueue(Job{
+				Provider: "openai",
+				Req: &llm.ChatRequest{
+					Model:    "gpt-4o",
+					Messages: []llm.Message{{Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "hello"}}}},
+				},
+				Resp: &llm.ChatResponse{
+					Model:   "gpt-4o",
+					Usage:   &llm.Usage{PromptTokens: 8, TotalTokens: 8},
+					Message: llm.Message{Role: "assistant"},
+				},
+			})
+			drain(wp)
+
+			leaves, err := driver.Leaves(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leaves[0].Usage).To(Equal(&llm.Usage{PromptTokens: 8, TotalTokens: 8}))
+		})
+
+		It("stores reported usage as is", func() {
+			wp.Enqueue(Job{
+				Provider: "openai",
+				Req: &llm.ChatRequest{
+					Model:    "gpt-4o",
+					Messages: []llm.Message{{Role: "user", Content: []llm.ContentBlock{{Type: "text", Text: "hello"}}}},
+				},
+				Resp: &llm.ChatResponse{
+					Model:   "gpt-4o",
+					Usage:   &llm.Usage{PromptTokens: 8, CompletionTokens: 3, TotalTokens: 11},
+					Message: llm.Message{Role: "assistant", Content: []llm.ContentBlock{{Type: "text", Text: "Hi there!"}}},
+				},
+			})
+			drain(wp)
+
+			leaves, err := driver.Leaves(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(leaves[0].Usage).To(Equal(&llm.Usage{PromptTokens: 8, CompletionTokens: 3, TotalTokens: 11}))
 		})
 	})
 
 	Describe("Multi-Turn Conversation Storage", func() {
 		// These tests exercise the worker pool's storeConversationTurn logic
-		// by enqueuing jobs and draining via wp.Close() before asserting storage state.
+		// by enqueuing jobs and draining via drain(wp) before asserting storage state.
 
 		Context("after turn 1 (user asks a question)", func() {
 			BeforeEach(func() {
@@ -91,7 +801,7 @@ var _ = Describe("Worker Pool", func() {
 				})
 
 				// Drain the worker pool to ensure storage completes before assertions
-				wp.Close()
+				drain(wp)
 			})
 
 			It("has 3 nodes in ancestry (system -> user -> assistant)", func() {
@@ -169,7 +879,7 @@ var _ = Describe("Worker Pool", func() {
 				})
 
 				// Drain the worker pool to ensure all storage completes
-				wp.Close()
+				drain(wp)
 			})
 
 			It("has 5 nodes in ancestry (full conversation history)", func() {
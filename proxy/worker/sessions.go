@@ -0,0 +1,138 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/utils"
+)
+
+// DefaultSessionIdleTimeout is how long a session may go without turns
+// before it is closed, when the timeout is not configured.
+const DefaultSessionIdleTimeout = 30 * time.Minute
+
+// maxSessionCheckInterval bounds how often idle sessions are looked for, so
+// that short timeouts are honored promptly without polling long ones.
+const maxSessionCheckInterval = time.Minute
+
+// ParseSessionIdleTimeout parses a configured session idle timeout such as
+// "30m" or "2h". Empty selects DefaultSessionIdleTimeout, and "0" disables
+// idle closing.
+func ParseSessionIdleTimeout(s string) (time.Duration, error) {
+	if s == "" {
+		return DefaultSessionIdleTimeout, nil
+	}
+	timeout, err := utils.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid session idle timeout: %w", err)
+	}
+	return timeout, nil
+}
+
+// openSession is a session the pool has stored a turn for and not yet ended.
+type openSession struct {
+	agent    string
+	run      string
+	lastTurn time.Time
+}
+
+// sessionStore returns the driver as a SessionStore, or nil when it cannot
+// mark session ends.
+func (p *Pool) sessionStore() storage.SessionStore {
+	store, _ := p.config.Driver.(storage.SessionStore)
+	return store
+}
+
+// touchSession records a turn of job stored in the session rooted at root.
+func (p *Pool) touchSession(root string, job Job, at time.Time) {
+	if p.sessionStore() == nil {
+		return
+	}
+
+	p.sessionsMu.Lock()
+	defer p.sessionsMu.Unlock()
+	p.sessions[root] = openSession{agent: job.AgentName, run: job.RunID, lastTurn: at}
+}
+
+// closeIdleSessions runs until the pool is closed, ending sessions that have
+// gone SessionIdleTimeout without a turn.
+func (p *Pool) closeIdleSessions() {
+	defer p.sessionsWG.Done()
+
+	ticker := time.NewTicker(min(p.config.SessionIdleTimeout, maxSessionCheckInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case now := <-ticker.C:
+			p.endSessions(p.ctx, storage.SessionEndIdle, func(s openSession) (time.Time, bool) {
+				return s.lastTurn, now.Sub(s.lastTurn) >= p.config.SessionIdleTimeout
+			})
+		}
+	}
+}
+
+// EndSessions ends the open sessions of an agent run, marking them ended now
+// because the agent exited. Sessions of a run are those captured with its
+// run ID or, when run is empty, those of agent that had a turn at or after
+// since. It returns the number of sessions ended.
+func (p *Pool) EndSessions(ctx context.Context, agent, run string, since time.Time) int {
+	now := time.Now()
+	return p.endSessions(ctx, storage.SessionEndExit, func(s openSession) (time.Time, bool) {
+		if run != "" {
+			return now, s.run == run
+		}
+		return now, s.agent == agent && !s.lastTurn.Before(since)
+	})
+}
+
+// endSessions ends the open sessions that match reports as ending, at the
+// time it returns. Sessions that fail to be marked are logged and forgotten.
+func (p *Pool) endSessions(ctx context.Context, reason string, match func(openSession) (time.Time, bool)) int {
+	store := p.sessionStore()
+	if store == nil {
+		return 0
+	}
+
+	type endingSession struct {
+		openSession
+		at time.Time
+	}
+
+	p.sessionsMu.Lock()
+	ending := make(map[string]endingSession)
+	for root, s := range p.sessions {
+		if at, ok := match(s); ok {
+			ending[root] = endingSession{openSession: s, at: at}
+			delete(p.sessions, root)
+		}
+	}
+	p.sessionsMu.Unlock()
+
+	ended := 0
+	for root, s := range ending {
+		if err := store.EndSession(ctx, root, s.at, reason); err != nil {
+			p.logger.Warn("failed to end session",
+				zap.String("root", root),
+				zap.String("reason", reason),
+				zap.Error(err),
+			)
+			continue
+		}
+		ended++
+		p.logger.Debug("ended session",
+			zap.String("root", root),
+			zap.String("reason", reason),
+		)
+		if p.config.Events != nil {
+			p.config.Events.Publish(events.SessionEnded(root, s.agent, s.run, reason, s.at))
+		}
+	}
+	return ended
+}
@@ -0,0 +1,142 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/events"
+	"github.com/papercomputeco/tapes/pkg/merkle"
+	"github.com/papercomputeco/tapes/pkg/storage"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+)
+
+var _ = Describe("Session ends", func() {
+	// root returns the root node of the only conversation in driver.
+	root := func(driver *inmemory.Driver) *merkle.Node {
+		roots, err := driver.Roots(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roots).To(HaveLen(1))
+		return roots[0]
+	}
+
+	It("parses idle timeouts", func() {
+		timeout, err := ParseSessionIdleTimeout("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(timeout).To(Equal(DefaultSessionIdleTimeout))
+
+		timeout, err = ParseSessionIdleTimeout("2h")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(timeout).To(Equal(2 * time.Hour))
+
+		timeout, err = ParseSessionIdleTimeout("0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(timeout).To(BeZero())
+
+		_, err = ParseSessionIdleTimeout("soon")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("closes sessions that go the idle timeout without a turn, at their last turn", func() {
+		driver := inmemory.NewDriver()
+		wp, err := NewPool(&Config{
+			Driver:             driver,
+			Logger:             zap.NewNop(),
+			SessionIdleTimeout: 50 * time.Millisecond,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		defer drain(wp)
+
+		before := time.Now()
+		_, err = wp.Store(context.Background(), testJob("hello"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func() string { return root(driver).EndReason }).Should(Equal(storage.SessionEndIdle))
+		Expect(*root(driver).EndedAt).To(BeTemporally(">=", before))
+		Expect(*root(driver).EndedAt).To(BeTemporally("<", time.Now().Add(-50*time.Millisecond)))
+	})
+
+	It("leaves sessions open when the idle timeout is disabled", func() {
+		wp, driver := newTestPool()
+
+		_, err := wp.Store(context.Background(), testJob("hello"))
+		Expect(err).NotTo(HaveOccurred())
+		drain(wp)
+
+		Expect(root(driver).EndedAt).To(BeNil())
+	})
+
+	It("ends the sessions of an exited agent that had turns since it started", func() {
+		driver := inmemory.NewDriver()
+		wp, err := NewPool(&Config{Driver: driver, Logger: zap.NewNop()})
+		Expect(err).NotTo(HaveOccurred())
+		defer drain(wp)
+
+		job := testJob("hello")
+		job.AgentName = "claude"
+		_, err = wp.Store(context.Background(), job)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(wp.EndSessions(context.Background(), "codex", "", time.Time{})).To(Equal(0))
+		Expect(wp.EndSessions(context.Background(), "claude", "", time.Now().Add(time.Minute))).To(Equal(0))
+		Expect(root(driver).EndedAt).To(BeNil())
+
+		Expect(wp.EndSessions(context.Background(), "claude", "", time.Time{})).To(Equal(1))
+		Expect(root(driver).EndReason).To(Equal(storage.SessionEndExit))
+		Expect(root(driver).EndedAt).NotTo(BeNil())
+
+		// An ended session is forgotten and not ended again.
+		Expect(wp.EndSessions(context.Background(), "claude", "", time.Time{})).To(Equal(0))
+	})
+	It("ends only the sessions of an exited agent's run", func() {
+		driver := inmemory.NewDriver()
+		wp, err := NewPool(&Config{Driver: driver, Logger: zap.NewNop()})
+		Expect(err).NotTo(HaveOccurred())
+		defer drain(wp)
+
+		job := testJob("hello")
+		job.AgentName = "claude"
+		job.RunID = "run-1"
+		_, err = wp.Store(context.Background(), job)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(wp.EndSessions(context.Background(), "claude", "run-2", time.Time{})).To(Equal(0))
+		Expect(root(driver).EndedAt).To(BeNil())
+
+		Expect(wp.EndSessions(context.Background(), "claude", "run-1", time.Time{})).To(Equal(1))
+		Expect(root(driver).EndReason).To(Equal(storage.SessionEndExit))
+		Expect(root(driver).RunID).To(Equal("run-1"))
+	})
+
+	It("publishes an event for each ended session", func() {
+		broker := events.NewBroker()
+		ch, cancel := broker.Subscribe(events.Filter{})
+		defer cancel()
+
+		driver := inmemory.NewDriver()
+		wp, err := NewPool(&Config{Driver: driver, Logger: zap.NewNop(), Events: broker})
+		Expect(err).NotTo(HaveOccurred())
+		defer drain(wp)
+
+		job := testJob("hello")
+		job.AgentName = "claude"
+		job.RunID = "run-1"
+		_, err = wp.Store(context.Background(), job)
+		Expect(err).NotTo(HaveOccurred())
+		for len(ch) > 0 {
+			<-ch
+		}
+
+		Expect(wp.EndSessions(context.Background(), "claude", "run-1", time.Time{})).To(Equal(1))
+		Expect(ch).To(HaveLen(1))
+		event := <-ch
+		Expect(event.Type).To(Equal(events.TypeSessionEnded))
+		Expect(event.RootHash).To(Equal(root(driver).Hash))
+		Expect(event.AgentName).To(Equal("claude"))
+		Expect(event.RunID).To(Equal("run-1"))
+		Expect(event.EndReason).To(Equal(storage.SessionEndExit))
+	})
+})
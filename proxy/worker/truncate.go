@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"unicode/utf8"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+)
+
+// TruncatedBlockType is the content block type of a truncation marker. A marker
+// directly follows the block it describes.
+const TruncatedBlockType = "truncated"
+
+// truncateContent caps the size of each content block's text, thinking, tool
+// output, and inline image data at maxBytes. Each oversized field is cut (images are dropped
+// entirely, since partial base64 data is useless) and followed by a truncation
+// marker block recording the original length and content hash.
+//
+// The input slice is never modified. A maxBytes of zero disables truncation.
+func truncateContent(blocks []llm.ContentBlock, maxBytes int) []llm.ContentBlock {
+	if maxBytes <= 0 || !exceedsLimit(blocks, maxBytes) {
+		return blocks
+	}
+
+	result := make([]llm.ContentBlock, 0, len(blocks)+1)
+	for _, block := range blocks {
+		var markers []llm.ContentBlock
+
+		if len(block.Text) > maxBytes {
+			markers = append(markers, truncationMarker(block.Text))
+			block.Text = truncateUTF8(block.Text, maxBytes)
+		}
+		if len(block.Thinking) > maxBytes {
+			markers = append(markers, truncationMarker(block.Thinking))
+			block.Thinking = truncateUTF8(block.Thinking, maxBytes)
+		}
+		if len(block.ToolOutput) > maxBytes {
+			markers = append(markers, truncationMarker(block.ToolOutput))
+			block.ToolOutput = truncateUTF8(block.ToolOutput, maxBytes)
+		}
+		if len(block.ImageBase64) > maxBytes {
+			markers = append(markers, truncationMarker(block.ImageBase64))
+			block.ImageBase64 = ""
+		}
+
+		result = append(result, block)
+		result = append(result, markers...)
+	}
+	return result
+}
+
+func exceedsLimit(blocks []llm.ContentBlock, maxBytes int) bool {
+	for _, block := range blocks {
+		if len(block.Text) > maxBytes || len(block.Thinking) > maxBytes ||
+			len(block.ToolOutput) > maxBytes || len(block.ImageBase64) > maxBytes {
+			return true
+		}
+	}
+	return false
+}
+
+func truncationMarker(original string) llm.ContentBlock {
+	sum := sha256.Sum256([]byte(original))
+	return llm.ContentBlock{
+		Type:          TruncatedBlockType,
+		OriginalBytes: len(original),
+		ContentHash:   "sha256:" + hex.EncodeToString(sum[:]),
+	}
+}
+
+// truncateUTF8 cuts s to at most maxBytes without splitting a multi-byte rune.
+func truncateUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	cut := maxBytes
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
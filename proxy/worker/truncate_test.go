@@ -0,0 +1,227 @@
+package worker
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"unicode/utf8"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/papercomputeco/tapes/pkg/llm"
+	"github.com/papercomputeco/tapes/pkg/redact"
+	"github.com/papercomputeco/tapes/pkg/storage/blob"
+	"github.com/papercomputeco/tapes/pkg/storage/inmemory"
+	"github.com/papercomputeco/tapes/pkg/storage/media"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+var _ = Describe("truncateContent", func() {
+	It("returns blocks unchanged when under the limit", func() {
+		blocks := []llm.ContentBlock{{Type: "text", Text: "short"}}
+		Expect(truncateContent(blocks, 100)).To(Equal(blocks))
+	})
+
+	It("returns blocks unchanged when the limit is disabled", func() {
+		blocks := []llm.ContentBlock{{Type: "text", Text: strings.Repeat("a", 1000)}}
+		Expect(truncateContent(blocks, 0)).To(Equal(blocks))
+	})
+
+	It("truncates oversized tool output and appends a marker", func() {
+		output := strings.Repeat("x", 50)
+		blocks := []llm.ContentBlock{
+			{Type: "tool_result", ToolResultID: "t1", ToolOutput: output},
+			{Type: "text", Text: "after"},
+		}
+
+		result := truncateContent(blocks, 10)
+		Expect(result).To(HaveLen(3))
+		Expect(result[0].ToolOutput).To(Equal(strings.Repeat("x", 10)))
+		Expect(result[0].ToolResultID).To(Equal("t1"))
+		Expect(result[1]).To(Equal(llm.ContentBlock{
+			Type:          TruncatedBlockType,
+			OriginalBytes: 50,
+			ContentHash:   sha256Hex(output),
+		}))
+		Expect(result[2].Text).To(Equal("after"))
+
+		// The caller's blocks are untouched.
+		Expect(blocks[0].ToolOutput).To(Equal(output))
+	})
+
+	It("drops oversized inline image data", func() {
+		image := strings.Repeat("QUJD", 100)
+		result := truncateContent([]llm.ContentBlock{
+			{Type: "image", ImageBase64: image, MediaType: "image/png"},
+		}, 64)
+
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].ImageBase64).To(BeEmpty())
+		Expect(result[0].MediaType).To(Equal("image/png"))
+		Expect(result[1].OriginalBytes).To(Equal(len(image)))
+	})
+
+	It("truncates oversized thinking", func() {
+		thinking := strings.Repeat("t", 40)
+		result := truncateContent([]llm.ContentBlock{
+			{Type: "thinking", Thinking: thinking, Signature: "sig"},
+		}, 16)
+
+		Expect(result).To(HaveLen(2))
+		Expect(result[0].Thinking).To(Equal(strings.Repeat("t", 16)))
+		Expect(result[0].Signature).To(Equal("sig"))
+		Expect(result[1].OriginalBytes).To(Equal(len(thinking)))
+	})
+
+	It("does not split multi-byte runes", func() {
+		result := truncateContent([]llm.ContentBlock{{Type: "text", Text: strings.Repeat("é", 10)}}, 5)
+		Expect(utf8.ValidString(result[0].Text)).To(BeTrue())
+		Expect(result[0].Text).To(Equal("éé"))
+	})
+})
+
+var _ = Describe("Pool content limits", func() {
+	It("stores truncated content when MaxContentBytes is set", func() {
+		logger, _ := zap.NewDevelopment()
+		driver := inmemory.NewDriver()
+		wp, err := NewPool(&Config{
+			Driver:          driver,
+			Logger:          logger,
+			MaxContentBytes: 8,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(wp.Submit(testJob("a very long prompt"))).To(Succeed())
+		drain(wp)
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		var user *llm.ContentBlock
+		var marker *llm.ContentBlock
+		for _, n := range nodes {
+			if n.Bucket.Role == "user" {
+				user = &n.Bucket.Content[0]
+				marker = &n.Bucket.Content[1]
+			}
+		}
+		Expect(user).NotTo(BeNil())
+		Expect(user.Text).To(Equal("a very l"))
+		Expect(marker.Type).To(Equal(TruncatedBlockType))
+		Expect(marker.OriginalBytes).To(Equal(len("a very long prompt")))
+	})
+
+	It("redacts content before truncating and counts matches", func() {
+		logger, _ := zap.NewDevelopment()
+		driver := inmemory.NewDriver()
+		redactor, err := redact.NewRedactor(nil)
+		Expect(err).NotTo(HaveOccurred())
+		wp, err := NewPool(&Config{
+			Driver:          driver,
+			Logger:          logger,
+			Redactor:        redactor,
+			MaxContentBytes: 64,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(wp.Submit(testJob("email jane@example.com please"))).To(Succeed())
+		drain(wp)
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		var user *llm.ContentBlock
+		for _, n := range nodes {
+			if n.Bucket.Role == "user" {
+				user = &n.Bucket.Content[0]
+			}
+		}
+		Expect(user).NotTo(BeNil())
+		Expect(user.Text).To(Equal("email [REDACTED:email] please"))
+		Expect(wp.Stats().Redactions).To(HaveKeyWithValue("email", uint64(1)))
+	})
+
+	It("offloads oversized content to the blob store before truncating", func() {
+		logger, _ := zap.NewDevelopment()
+		driver := inmemory.NewDriver()
+		store, err := blob.NewLocalStore(GinkgoT().TempDir())
+		Expect(err).NotTo(HaveOccurred())
+		wp, err := NewPool(&Config{
+			Driver:          driver,
+			Logger:          logger,
+			BlobStore:       store,
+			BlobThreshold:   8,
+			MaxContentBytes: 8,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(wp.Submit(testJob("a very long prompt"))).To(Succeed())
+		drain(wp)
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		var user []llm.ContentBlock
+		for _, n := range nodes {
+			if n.Bucket.Role == "user" {
+				user = n.Bucket.Content
+			}
+		}
+		Expect(user).To(HaveLen(1))
+		Expect(user[0].Text).To(BeEmpty())
+		Expect(user[0].BlobURI).NotTo(BeEmpty())
+
+		restored, err := blob.Rehydrate(context.Background(), user, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored[0].Text).To(Equal("a very long prompt"))
+	})
+
+	It("moves images to the media store before truncating", func() {
+		logger, _ := zap.NewDevelopment()
+		driver := inmemory.NewDriver()
+		store, err := media.NewStore(GinkgoT().TempDir(), media.Options{})
+		Expect(err).NotTo(HaveOccurred())
+		wp, err := NewPool(&Config{
+			Driver:          driver,
+			Logger:          logger,
+			MediaStore:      store,
+			MaxContentBytes: 8,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		image := []byte("an image larger than the capture limit")
+		job := testJob("look")
+		job.Req.Messages[0].Content = append(job.Req.Messages[0].Content, llm.ContentBlock{
+			Type:        "image",
+			MediaType:   "image/png",
+			ImageBase64: base64.StdEncoding.EncodeToString(image),
+		})
+		Expect(wp.Submit(job)).To(Succeed())
+		drain(wp)
+
+		nodes, err := driver.List(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		var user []llm.ContentBlock
+		for _, n := range nodes {
+			if n.Bucket.Role == "user" {
+				user = n.Bucket.Content
+			}
+		}
+		Expect(user).To(HaveLen(2))
+		Expect(user[1].ImageBase64).To(BeEmpty())
+		Expect(user[1].MediaURI).NotTo(BeEmpty())
+
+		data, err := media.Read(context.Background(), user[1], nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(Equal(image))
+	})
+})
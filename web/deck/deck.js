@@ -25,6 +25,7 @@ const analyticsDurationEl = document.getElementById("analytics-duration");
 const analyticsCostEl = document.getElementById("analytics-cost");
 const analyticsModelsEl = document.getElementById("analytics-models");
 const analyticsProvidersEl = document.getElementById("analytics-providers");
+const analyticsErrorsEl = document.getElementById("analytics-errors");
 const analyticsSubtitleEl = document.getElementById("analytics-subtitle");
 const analyticsPeriodEl = document.getElementById("analytics-period");
 const analyticsInsightsEl = document.getElementById("analytics-insights");
@@ -86,10 +87,12 @@ const debounce = (fn, ms) => {
   };
 };
 
+const sessionLabel = (session) => session.title || session.label;
+
 const getFilteredSessions = (sessions) => {
   if (!filters.search) return sessions;
   const term = filters.search.toLowerCase();
-  return sessions.filter((s) => s.label.toLowerCase().includes(term));
+  return sessions.filter((s) => sessionLabel(s).toLowerCase().includes(term));
 };
 
 const updateSessionCount = (data) => {
@@ -421,7 +424,7 @@ const buildSessionRow = (session, index, onClick) => {
 
   const label = document.createElement("div");
   label.className = "session-label";
-  label.textContent = session.label;
+  label.textContent = sessionLabel(session);
 
   const project = document.createElement("div");
   project.className = "session-project";
@@ -775,7 +778,7 @@ const renderSessionDetail = (detail) => {
   const headerText = document.createElement("div");
   const headerTitle = document.createElement("div");
   headerTitle.className = "detail__title";
-  headerTitle.textContent = detail.summary.label;
+  headerTitle.textContent = sessionLabel(detail.summary);
   const headerSub = document.createElement("div");
   headerSub.className = "detail__subtitle";
   headerSub.textContent = detail.summary.id;
@@ -816,6 +819,8 @@ const renderAnalyticsSummary = (data) => {
     { label: "avg cost/session", value: formatCost(data.avg_session_cost) },
     { label: "avg duration", value: formatDuration(data.avg_duration_ns) },
     { label: "models tracked", value: data.model_performance ? data.model_performance.length : 0 },
+    { label: "cache hit rate", value: `${((data.cache_hit_rate || 0) * 100).toFixed(0)}%` },
+    { label: "error rate", value: `${((data.error_rate || 0) * 100).toFixed(1)}%` },
   ];
   items.forEach((item) => {
     const card = document.createElement("div");
@@ -1161,6 +1166,50 @@ const renderProviderSplit = (data) => {
   analyticsProvidersEl.appendChild(legend);
 };
 
+const renderUpstreamErrors = (data) => {
+  analyticsErrorsEl.innerHTML = "";
+  const failing = (data.errors || []).filter((metric) => metric.errors > 0);
+  if (failing.length === 0) {
+    analyticsErrorsEl.textContent = "no upstream errors";
+    return;
+  }
+  const table = document.createElement("div");
+  table.className = "model-table";
+  const header = document.createElement("div");
+  header.className = "model-table__row model-table__row--header";
+  header.innerHTML = "<div>model</div><div>provider</div><div>requests</div><div>errors</div><div>error rate</div><div>statuses</div>";
+  table.appendChild(header);
+  failing.forEach((metric) => {
+    const row = document.createElement("div");
+    row.className = "model-table__row";
+    const nameEl = document.createElement("div");
+    nameEl.className = "model-table__name";
+    nameEl.textContent = metric.model || "-";
+    nameEl.style.color = colorForModel(metric.model);
+    const providerEl = document.createElement("div");
+    providerEl.textContent = metric.provider || "-";
+    const requestsEl = document.createElement("div");
+    requestsEl.textContent = metric.requests;
+    const errorsEl = document.createElement("div");
+    errorsEl.textContent = metric.errors;
+    const rateEl = document.createElement("div");
+    rateEl.textContent = formatPercent(metric.error_rate);
+    rateEl.style.color = metric.error_rate >= 0.2 ? "var(--primary)" : metric.error_rate >= 0.05 ? "var(--orange)" : "var(--green)";
+    const statusesEl = document.createElement("div");
+    statusesEl.textContent = Object.entries(metric.statuses || {})
+      .map(([status, count]) => `${status} (${count})`)
+      .join(", ") || "-";
+    row.appendChild(nameEl);
+    row.appendChild(providerEl);
+    row.appendChild(requestsEl);
+    row.appendChild(errorsEl);
+    row.appendChild(rateEl);
+    row.appendChild(statusesEl);
+    table.appendChild(row);
+  });
+  analyticsErrorsEl.appendChild(table);
+};
+
 const selectHeatmapDay = (dateStr) => {
   if (selectedDayDate === dateStr) {
     closeDayDetail();
@@ -1594,6 +1643,7 @@ const loadAnalytics = async () => {
   renderHistogram(analyticsCostEl, data.cost_buckets);
   renderModelComparison(data);
   renderProviderSplit(data);
+  renderUpstreamErrors(data);
   renderAnalyticsPeriodControls();
 
   // Load AI insights via facets